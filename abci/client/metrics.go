@@ -0,0 +1,69 @@
+package abcicli
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "abci_client"
+
+	// connectionLabel is the label of the ABCI connection (e.g. "consensus"),
+	// set with WithConnection.
+	connectionLabel = "connection"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of requests queued to be sent to the application.
+	QueueSize metrics.Gauge
+	// Number of requests sent to the application, which wait for a response.
+	PendingRequests metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue"). The metrics are labeled by the ABCI connection as well, so they
+// must be set with WithConnection before they're used.
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	labels = append(labels, connectionLabel)
+	return &Metrics{
+		QueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_size",
+			Help:      "Number of requests queued to be sent to the application.",
+		}, labels).With(labelsAndValues...),
+		PendingRequests: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pending_requests",
+			Help:      "Number of requests sent to the application, which wait for a response.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		QueueSize:       discard.NewGauge(),
+		PendingRequests: discard.NewGauge(),
+	}
+}
+
+// WithConnection returns the metrics of the given ABCI connection (e.g.
+// "consensus").
+func (m *Metrics) WithConnection(conn string) *Metrics {
+	return &Metrics{
+		QueueSize:       m.QueueSize.With(connectionLabel, conn),
+		PendingRequests: m.PendingRequests.With(connectionLabel, conn),
+	}
+}
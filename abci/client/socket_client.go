@@ -14,16 +14,24 @@ import (
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/libs/service"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
-	"github.com/tendermint/tendermint/libs/timer"
 )
 
 const (
-	reqQueueSize    = 256 // TODO make configurable
-	flushThrottleMS = 20  // Don't wait longer than...
+	reqQueueSize = 256 // TODO make configurable
+
+	defaultMaxPendingRequests = 1024
+	defaultFlushSize          = 64 * 1024 // 64KB
+	defaultFlushInterval      = 20 * time.Millisecond
 )
 
 // This is goroutine-safe, but users should beware that the application in
 // general is not meant to be interfaced with concurrent callers.
+//
+// The requests are pipelined: they're written to the connection without
+// waiting for the responses of the previous ones, up to maxPendingRequests
+// requests. The app sends the responses only after a flush request, so the
+// client sends one once the buffered requests reach flushSize bytes or after
+// flushInterval, whichever comes first.
 type socketClient struct {
 	service.BaseService
 
@@ -31,30 +39,73 @@ type socketClient struct {
 	mustConnect bool
 	conn        net.Conn
 
-	reqQueue   chan *ReqRes
-	flushTimer *timer.ThrottleTimer
+	maxPendingRequests int
+	flushSize          int
+	flushInterval      time.Duration
+
+	reqQueue chan *ReqRes
+	// queueMtx is held for writing, while the queue is drained on stop, so no
+	// request is queued after it; stopped is closed when the client stops
+	queueMtx tmsync.RWMutex
+	stopped  chan struct{}
+	// a slot is taken by each request (except flush) sent to the app and
+	// released once its response is received
+	pendingSlots chan struct{}
 
 	mtx     tmsync.Mutex
 	err     error
 	reqSent *list.List                            // list of requests sent, waiting for response
 	resCb   func(*types.Request, *types.Response) // called on all requests, if set.
+	metrics *Metrics
 }
 
 var _ Client = (*socketClient)(nil)
 
+// SocketClientOption sets an optional parameter on the socket client.
+type SocketClientOption func(*socketClient)
+
+// WithMaxPendingRequests sets the maximum number of requests, which are sent
+// to the app without waiting for their responses. Once reached, the client
+// waits for a response before sending the next request.
+func WithMaxPendingRequests(n int) SocketClientOption {
+	return func(cli *socketClient) { cli.maxPendingRequests = n }
+}
+
+// WithFlushSize sets the size of the buffered requests in bytes, after which
+// the client asks the app to send their responses. 0 disables flushing by
+// size, so the requests are flushed after the flush interval only.
+func WithFlushSize(size int) SocketClientOption {
+	return func(cli *socketClient) { cli.flushSize = size }
+}
+
+// WithFlushInterval sets the maximum time the requests are buffered for,
+// before the client asks the app to send their responses.
+func WithFlushInterval(interval time.Duration) SocketClientOption {
+	return func(cli *socketClient) { cli.flushInterval = interval }
+}
+
 // NewSocketClient creates a new socket client, which connects to a given
 // address. If mustConnect is true, the client will return an error upon start
 // if it fails to connect.
-func NewSocketClient(addr string, mustConnect bool) Client {
+func NewSocketClient(addr string, mustConnect bool, options ...SocketClientOption) Client {
 	cli := &socketClient{
 		reqQueue:    make(chan *ReqRes, reqQueueSize),
-		flushTimer:  timer.NewThrottleTimer("socketClient", flushThrottleMS),
+		stopped:     make(chan struct{}),
 		mustConnect: mustConnect,
 
+		maxPendingRequests: defaultMaxPendingRequests,
+		flushSize:          defaultFlushSize,
+		flushInterval:      defaultFlushInterval,
+
 		addr:    addr,
 		reqSent: list.New(),
 		resCb:   nil,
+		metrics: NopMetrics(),
 	}
+	for _, option := range options {
+		option(cli)
+	}
+	cli.pendingSlots = make(chan struct{}, cli.maxPendingRequests)
 	cli.BaseService = *service.NewBaseService(nil, "socketClient", cli)
 	return cli
 }
@@ -89,12 +140,12 @@ func (cli *socketClient) OnStart() error {
 
 // OnStop implements Service by closing connection and flushing all queues.
 func (cli *socketClient) OnStop() {
+	close(cli.stopped)
 	if cli.conn != nil {
 		cli.conn.Close()
 	}
 
 	cli.flushQueue()
-	cli.flushTimer.Stop()
 }
 
 // Error returns an error if the client was stopped abruptly.
@@ -114,35 +165,99 @@ func (cli *socketClient) SetResponseCallback(resCb Callback) {
 	cli.mtx.Unlock()
 }
 
+// SetMetrics implements Client by reporting the size of the request queue and
+// the number of pending requests.
+func (cli *socketClient) SetMetrics(metrics *Metrics) {
+	cli.mtx.Lock()
+	cli.metrics = metrics
+	cli.mtx.Unlock()
+}
+
+func (cli *socketClient) getMetrics() *Metrics {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.metrics
+}
+
 //----------------------------------------
 
 func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
-	w := bufio.NewWriter(conn)
+	var (
+		w = bufio.NewWriter(conn)
+		// the size of the requests written since the last flush request, whose
+		// responses the app holds back
+		unflushed int
+		// fires flushInterval after the first request since the last flush
+		flushCh <-chan time.Time
+	)
+
+	send := func(reqres *ReqRes) error {
+		cli.willSendReq(reqres)
+		err := types.WriteMessage(reqres.Request, w)
+		if err != nil {
+			return fmt.Errorf("write to buffer: %w", err)
+		}
+
+		// If it's a flush request, flush the current buffer.
+		if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
+			unflushed, flushCh = 0, nil
+			err = w.Flush()
+			if err != nil {
+				return fmt.Errorf("flush buffer: %w", err)
+			}
+			return nil
+		}
+
+		unflushed += reqres.Request.Size()
+		if flushCh == nil {
+			flushCh = time.After(cli.flushInterval)
+		}
+		return nil
+	}
+	flush := func() error {
+		if unflushed == 0 {
+			return nil
+		}
+		return send(NewReqRes(types.ToRequestFlush()))
+	}
+
 	for {
 		select {
 		case reqres := <-cli.reqQueue:
-			// cli.Logger.Debug("Sent request", "requestType", reflect.TypeOf(reqres.Request), "request", reqres.Request)
+			cli.getMetrics().QueueSize.Set(float64(len(cli.reqQueue)))
+
+			if _, ok := reqres.Request.Value.(*types.Request_Flush); !ok {
+				select {
+				case cli.pendingSlots <- struct{}{}:
+				default:
+					// Too many requests wait for their responses, so make sure the
+					// app sends them.
+					if err := flush(); err != nil {
+						cli.stopForError(err)
+						return
+					}
+					select {
+					case cli.pendingSlots <- struct{}{}:
+					case <-cli.Quit():
+						return
+					}
+				}
+			}
 
-			cli.willSendReq(reqres)
-			err := types.WriteMessage(reqres.Request, w)
-			if err != nil {
-				cli.stopForError(fmt.Errorf("write to buffer: %w", err))
+			if err := send(reqres); err != nil {
+				cli.stopForError(err)
 				return
 			}
-
-			// If it's a flush request, flush the current buffer.
-			if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
-				err = w.Flush()
-				if err != nil {
-					cli.stopForError(fmt.Errorf("flush buffer: %w", err))
+			if cli.flushSize > 0 && unflushed >= cli.flushSize {
+				if err := flush(); err != nil {
+					cli.stopForError(err)
 					return
 				}
 			}
-		case <-cli.flushTimer.Ch: // flush queue
-			select {
-			case cli.reqQueue <- NewReqRes(types.ToRequestFlush()):
-			default:
-				// Probably will fill the buffer, or retry later.
+		case <-flushCh:
+			if err := flush(); err != nil {
+				cli.stopForError(err)
+				return
 			}
 		case <-cli.Quit():
 			return
@@ -164,7 +279,8 @@ func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
 
 		switch r := res.Value.(type) {
 		case *types.Response_Exception: // app responded with error
-			// XXX After setting cli.err, release waiters (e.g. reqres.Done())
+			// The pending requests are released with cli.Error(), when the client
+			// stops.
 			cli.stopForError(errors.New(r.Exception.Error))
 			return
 		default:
@@ -181,31 +297,42 @@ func (cli *socketClient) willSendReq(reqres *ReqRes) {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
 	cli.reqSent.PushBack(reqres)
+	cli.metrics.PendingRequests.Set(float64(cli.reqSent.Len()))
 }
 
 func (cli *socketClient) didRecvResponse(res *types.Response) error {
 	cli.mtx.Lock()
-	defer cli.mtx.Unlock()
 
 	// Get the first ReqRes.
 	next := cli.reqSent.Front()
 	if next == nil {
+		cli.mtx.Unlock()
 		return fmt.Errorf("unexpected %v when nothing expected", reflect.TypeOf(res.Value))
 	}
 
 	reqres := next.Value.(*ReqRes)
 	if !resMatchesReq(reqres.Request, res) {
+		cli.mtx.Unlock()
 		return fmt.Errorf("unexpected %v when response to %v expected",
 			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))
 	}
 
 	reqres.Response = res
-	reqres.Done()            // release waiters
 	cli.reqSent.Remove(next) // pop first item from linked list
+	cli.metrics.PendingRequests.Set(float64(cli.reqSent.Len()))
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+
+	// The callbacks are invoked without holding the lock and after the slot of
+	// the request is released, as they may queue new requests.
+	if _, ok := res.Value.(*types.Response_Flush); !ok {
+		<-cli.pendingSlots
+	}
+	reqres.Done() // release waiters
 
 	// Notify client listener if set (global callback).
-	if cli.resCb != nil {
-		cli.resCb(reqres.Request, res)
+	if resCb != nil {
+		resCb(reqres.Request, res)
 	}
 
 	// Notify reqRes listener if set (request specific callback).
@@ -255,6 +382,10 @@ func (cli *socketClient) InitChainAsync(req types.RequestInitChain) *ReqRes {
 	return cli.queueRequest(types.ToRequestInitChain(req))
 }
 
+func (cli *socketClient) InitChainAppStateChunkAsync(req types.RequestInitChainAppStateChunk) *ReqRes {
+	return cli.queueRequest(types.ToRequestInitChainAppStateChunk(req))
+}
+
 func (cli *socketClient) BeginBlockAsync(req types.RequestBeginBlock) *ReqRes {
 	return cli.queueRequest(types.ToRequestBeginBlock(req))
 }
@@ -279,6 +410,18 @@ func (cli *socketClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotC
 	return cli.queueRequest(types.ToRequestApplySnapshotChunk(req))
 }
 
+func (cli *socketClient) ProcessProposalAsync(req types.RequestProcessProposal) *ReqRes {
+	return cli.queueRequest(types.ToRequestProcessProposal(req))
+}
+
+func (cli *socketClient) ExtendVoteAsync(req types.RequestExtendVote) *ReqRes {
+	return cli.queueRequest(types.ToRequestExtendVote(req))
+}
+
+func (cli *socketClient) PrepareProposalAsync(req types.RequestPrepareProposal) *ReqRes {
+	return cli.queueRequest(types.ToRequestPrepareProposal(req))
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync() error {
@@ -362,6 +505,16 @@ func (cli *socketClient) InitChainSync(req types.RequestInitChain) (*types.Respo
 	return reqres.Response.GetInitChain(), cli.Error()
 }
 
+func (cli *socketClient) InitChainAppStateChunkSync(
+	req types.RequestInitChainAppStateChunk) (*types.ResponseInitChainAppStateChunk, error) {
+	reqres := cli.queueRequest(types.ToRequestInitChainAppStateChunk(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+
+	return reqres.Response.GetInitChainAppStateChunk(), cli.Error()
+}
+
 func (cli *socketClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
 	reqres := cli.queueRequest(types.ToRequestBeginBlock(req))
 	if err := cli.FlushSync(); err != nil {
@@ -417,36 +570,79 @@ func (cli *socketClient) ApplySnapshotChunkSync(
 	return reqres.Response.GetApplySnapshotChunk(), cli.Error()
 }
 
+func (cli *socketClient) ProcessProposalSync(
+	req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	reqres := cli.queueRequest(types.ToRequestProcessProposal(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetProcessProposal(), cli.Error()
+}
+
+func (cli *socketClient) ExtendVoteSync(
+	req types.RequestExtendVote) (*types.ResponseExtendVote, error) {
+	reqres := cli.queueRequest(types.ToRequestExtendVote(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetExtendVote(), cli.Error()
+}
+
+func (cli *socketClient) PrepareProposalSync(
+	req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	reqres := cli.queueRequest(types.ToRequestPrepareProposal(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetPrepareProposal(), cli.Error()
+}
+
 //----------------------------------------
 
 func (cli *socketClient) queueRequest(req *types.Request) *ReqRes {
 	reqres := NewReqRes(req)
 
-	// TODO: set cli.err if reqQueue times out
-	cli.reqQueue <- reqres
+	cli.queueMtx.RLock()
+	defer cli.queueMtx.RUnlock()
 
-	// Maybe auto-flush, or unset auto-flush
-	switch req.Value.(type) {
-	case *types.Request_Flush:
-		cli.flushTimer.Unset()
+	// If the client is stopped, the request is never sent (the waiters get
+	// cli.Error()).
+	select {
+	case <-cli.stopped:
+		reqres.Done()
+		return reqres
 	default:
-		cli.flushTimer.Set()
 	}
 
+	// TODO: set cli.err if reqQueue times out
+	select {
+	case cli.reqQueue <- reqres:
+	case <-cli.stopped:
+		reqres.Done()
+		return reqres
+	}
+	cli.getMetrics().QueueSize.Set(float64(len(cli.reqQueue)))
+
 	return reqres
 }
 
 func (cli *socketClient) flushQueue() {
 	cli.mtx.Lock()
-	defer cli.mtx.Unlock()
-
-	// mark all in-flight messages as resolved (they will get cli.Error())
+	// mark all in-flight messages as resolved (they will get cli.Error()); the
+	// list is cleared, so they aren't resolved again if their responses are
+	// still received
 	for req := cli.reqSent.Front(); req != nil; req = req.Next() {
 		reqres := req.Value.(*ReqRes)
 		reqres.Done()
 	}
+	cli.reqSent.Init()
+	metrics := cli.metrics
+	cli.mtx.Unlock()
+	metrics.PendingRequests.Set(0)
 
 	// mark all queued messages as resolved
+	cli.queueMtx.Lock()
+	defer cli.queueMtx.Unlock()
 LOOP:
 	for {
 		select {
@@ -456,6 +652,7 @@ LOOP:
 			break LOOP
 		}
 	}
+	metrics.QueueSize.Set(0)
 }
 
 //----------------------------------------
@@ -480,6 +677,8 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_Query)
 	case *types.Request_InitChain:
 		_, ok = res.Value.(*types.Response_InitChain)
+	case *types.Request_InitChainAppStateChunk:
+		_, ok = res.Value.(*types.Response_InitChainAppStateChunk)
 	case *types.Request_BeginBlock:
 		_, ok = res.Value.(*types.Response_BeginBlock)
 	case *types.Request_EndBlock:
@@ -492,6 +691,12 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_ListSnapshots)
 	case *types.Request_OfferSnapshot:
 		_, ok = res.Value.(*types.Response_OfferSnapshot)
+	case *types.Request_ProcessProposal:
+		_, ok = res.Value.(*types.Response_ProcessProposal)
+	case *types.Request_ExtendVote:
+		_, ok = res.Value.(*types.Response_ExtendVote)
+	case *types.Request_PrepareProposal:
+		_, ok = res.Value.(*types.Response_PrepareProposal)
 	}
 	return ok
 }
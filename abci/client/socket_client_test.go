@@ -2,6 +2,7 @@ package abcicli_test
 
 import (
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -9,8 +10,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/code"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/abci/server"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -92,7 +96,173 @@ func TestHangingSyncCalls(t *testing.T) {
 	}
 }
 
-func setupClientServer(t *testing.T, app types.Application) (
+func TestPendingRequestsWindow(t *testing.T) {
+	const txCount = 100
+	s, c := setupClientServer(t, kvstore.NewApplication(), abcicli.WithMaxPendingRequests(2),
+		abcicli.WithFlushInterval(time.Hour))
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the global callback queues a request for each response, which must not
+	// block the responses of the pending requests
+	var checked int32
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if _, ok := req.Value.(*types.Request_DeliverTx); ok {
+			c.CheckTxAsync(types.RequestCheckTx{Tx: req.GetDeliverTx().Tx})
+		}
+		if _, ok := req.Value.(*types.Request_CheckTx); ok {
+			atomic.AddInt32(&checked, 1)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reqs := make([]*abcicli.ReqRes, 0, txCount)
+		for i := 0; i < txCount; i++ {
+			tx := []byte(fmt.Sprintf("key%d=value", i))
+			reqs = append(reqs, c.DeliverTxAsync(types.RequestDeliverTx{Tx: tx}))
+		}
+		// the responses are sent without the flush interval passing, as the
+		// window is full
+		for _, reqres := range reqs {
+			reqres.Wait()
+			assert.Equal(t, code.CodeTypeOK, reqres.Response.GetDeliverTx().Code)
+		}
+		require.NoError(t, c.FlushSync())
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "The responses didn't arrive")
+	}
+	require.NoError(t, c.Error())
+	require.NoError(t, c.FlushSync())
+	assert.EqualValues(t, txCount, atomic.LoadInt32(&checked))
+}
+
+func TestFlushBySizeAndInterval(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []abcicli.SocketClientOption
+	}{
+		{"size", []abcicli.SocketClientOption{abcicli.WithFlushSize(1), abcicli.WithFlushInterval(time.Hour)}},
+		{"interval", []abcicli.SocketClientOption{abcicli.WithFlushSize(0),
+			abcicli.WithFlushInterval(10 * time.Millisecond)}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s, c := setupClientServer(t, kvstore.NewApplication(), tc.options...)
+			t.Cleanup(func() {
+				if err := s.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+			t.Cleanup(func() {
+				if err := c.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			// the response arrives without flushing explicitly
+			reqres := c.EchoAsync("hello")
+			done := make(chan struct{})
+			go func() {
+				reqres.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				assert.Equal(t, "hello", reqres.Response.GetEcho().Message)
+			case <-time.After(time.Second):
+				require.Fail(t, "No response arrived")
+			}
+		})
+	}
+}
+
+func TestQueueRequestAfterStop(t *testing.T) {
+	s, c := setupClientServer(t, kvstore.NewApplication())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.NoError(t, c.Stop())
+
+	// the requests of a stopped client are released instead of blocking on the
+	// full queue
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			c.EchoAsync("hello").Wait()
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "Requests of a stopped client blocked")
+	}
+}
+
+// BenchmarkSocketClientDeliverTx measures the DeliverTx throughput on the
+// kvstore app over a Unix socket, with the requests of a block flushed only
+// at its end and by size (the default).
+func BenchmarkSocketClientDeliverTx(b *testing.B) {
+	const blockSize = 1000
+	benchmarks := []struct {
+		name    string
+		options []abcicli.SocketClientOption
+	}{
+		{"flush per block", []abcicli.SocketClientOption{abcicli.WithFlushSize(0),
+			abcicli.WithFlushInterval(time.Hour)}},
+		{"flush by size", nil},
+	}
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			addr := fmt.Sprintf("unix://%s/abci.sock", b.TempDir())
+			s, err := server.NewServer(addr, "socket", kvstore.NewApplication())
+			require.NoError(b, err)
+			s.SetLogger(log.NewNopLogger())
+			require.NoError(b, s.Start())
+			b.Cleanup(func() { _ = s.Stop() })
+
+			c := abcicli.NewSocketClient(addr, true, bm.options...)
+			c.SetLogger(log.NewNopLogger())
+			require.NoError(b, c.Start())
+			b.Cleanup(func() { _ = c.Stop() })
+
+			txs := make([][]byte, blockSize)
+			for i := range txs {
+				txs[i] = []byte(fmt.Sprintf("key%d=%s", i, tmrand.Str(64)))
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, tx := range txs {
+					c.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+				}
+				if err := c.FlushSync(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func setupClientServer(t *testing.T, app types.Application, options ...abcicli.SocketClientOption) (
 	service.Service, abcicli.Client) {
 	// some port between 20k and 30k
 	port := 20000 + tmrand.Int32()%10000
@@ -103,7 +273,7 @@ func setupClientServer(t *testing.T, app types.Application) (
 	err = s.Start()
 	require.NoError(t, err)
 
-	c := abcicli.NewSocketClient(addr, true)
+	c := abcicli.NewSocketClient(addr, true, options...)
 	err = c.Start()
 	require.NoError(t, err)
 
@@ -2,16 +2,22 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	tmos "github.com/tendermint/tendermint/libs/os"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
@@ -62,6 +68,8 @@ var RootCmd = &cobra.Command{
 			return nil
 		case "version": // skip running for version command
 			return nil
+		case "health": // the health service is checked by its own client
+			return nil
 		}
 
 		if logger == nil {
@@ -153,6 +161,7 @@ func addCommands() {
 	RootCmd.AddCommand(checkTxCmd)
 	RootCmd.AddCommand(commitCmd)
 	RootCmd.AddCommand(versionCmd)
+	RootCmd.AddCommand(healthCmd)
 	RootCmd.AddCommand(testCmd)
 	addQueryFlags()
 	RootCmd.AddCommand(queryCmd)
@@ -259,6 +268,15 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "check the health of a gRPC ABCI server",
+	Long: "check the health of a gRPC ABCI server, exits with an error unless the app is serving, " +
+		"i.e. it's initialized",
+	Args: cobra.ExactArgs(0),
+	RunE: cmdHealth,
+}
+
 var queryCmd = &cobra.Command{
 	Use:   "query",
 	Short: "query the application state",
@@ -631,6 +649,30 @@ func cmdQuery(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func cmdHealth(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, flagAddress, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
+			return tmnet.Connect(addr)
+		}))
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", flagAddress, err)
+	}
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: server.ABCIServiceName})
+	if err != nil {
+		return err
+	}
+	fmt.Println(res.Status)
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("the app is %v", res.Status)
+	}
+	return nil
+}
+
 func cmdCounter(cmd *cobra.Command, args []string) error {
 	app := counter.NewApplication(flagSerial)
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
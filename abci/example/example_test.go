@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"golang.org/x/net/context"
 
@@ -44,6 +46,70 @@ func TestGRPC(t *testing.T) {
 	testGRPCSync(t, types.NewGRPCApplication(types.NewBaseApplication()))
 }
 
+// The kvstore app is reported as serving once it's initialized, and the
+// services can be listed by the reflection.
+func TestGRPCHealth(t *testing.T) {
+	socketFile := fmt.Sprintf("test-%08x.sock", rand.Int31n(1<<30))
+	defer os.Remove(socketFile)
+	socket := fmt.Sprintf("unix://%v", socketFile)
+
+	server := abciserver.NewGRPCServer(socket, types.NewGRPCApplication(kvstore.NewApplication()))
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	conn, err := grpc.Dial(socket, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := conn.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	ctx := context.Background()
+	client := types.NewABCIApplicationClient(conn)
+	healthClient := healthpb.NewHealthClient(conn)
+
+	checkHealth := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for _, service := range []string{"", abciserver.ABCIServiceName} {
+			res, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			require.Equal(t, status, res.Status, "service %q", service)
+		}
+	}
+
+	// the chain isn't initialized yet
+	checkHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	res, err := client.Info(ctx, &types.RequestInfo{})
+	require.NoError(t, err)
+	require.Zero(t, res.LastBlockHeight)
+	checkHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	_, err = client.InitChain(ctx, &types.RequestInitChain{})
+	require.NoError(t, err)
+	checkHealth(healthpb.HealthCheckResponse_SERVING)
+
+	// the reflection lists the services
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	refl, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range refl.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	require.Contains(t, services, abciserver.ABCIServiceName)
+	require.Contains(t, services, "grpc.health.v1.Health")
+	require.NoError(t, stream.CloseSend())
+}
+
 func testStream(t *testing.T, app types.Application) {
 	numDeliverTxs := 20000
 	socketFile := fmt.Sprintf("test-%08x.sock", rand.Int31n(1<<30))
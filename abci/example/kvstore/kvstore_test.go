@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"sort"
 	"testing"
 
@@ -163,6 +164,89 @@ func TestValUpdates(t *testing.T) {
 	valSetEqualTest(t, kvVals, fullVals)
 }
 
+func TestPersistentKVStorePrepareProposal(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "abci-kvstore-test") // TODO
+	if err != nil {
+		t.Fatal(err)
+	}
+	kvstore := NewPersistentKVStoreApplication(dir)
+
+	info := kvstore.Info(types.RequestInfo{})
+	require.True(t, info.SupportsPrepareProposal)
+	require.True(t, info.SupportsProcessProposal)
+
+	val := RandValidatorSetUpdate(1).ValidatorUpdates[0]
+	validTx := MakeValSetChangeTx(val.ProTxHash, val.PubKey, val.Power)
+	invalidTx := []byte("val:proTxHash!pubkey")
+	txs := [][]byte{[]byte("a=1"), invalidTx, validTx}
+
+	// the invalid validator set change tx is dropped from the proposal
+	res := kvstore.PrepareProposal(types.RequestPrepareProposal{Txs: txs})
+	require.Equal(t, [][]byte{[]byte("a=1"), validTx}, res.Txs)
+
+	// and the proposal with it is rejected
+	proc := kvstore.ProcessProposal(types.RequestProcessProposal{Txs: res.Txs})
+	require.Equal(t, types.ResponseProcessProposal_ACCEPT, proc.Status)
+	proc = kvstore.ProcessProposal(types.RequestProcessProposal{Txs: txs})
+	require.Equal(t, types.ResponseProcessProposal_REJECT, proc.Status)
+	require.NotEmpty(t, proc.Info)
+}
+
+func TestPersistentKVStoreInitChainAppStateChunks(t *testing.T) {
+	appState := []byte(`{"a":"1","b":"2"}`)
+	testCases := []struct {
+		name      string
+		newClient func(types.Application, string) (abcicli.Client, service.Service, error)
+	}{
+		{"socket", makeSocketClientServer},
+		{"grpc", makeGRPCClientServer},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "abci-kvstore-test")
+			require.NoError(t, err)
+			t.Cleanup(func() { os.RemoveAll(dir) })
+
+			client, server, err := tc.newClient(NewPersistentKVStoreApplication(dir), "kvstore-chunks-"+tc.name)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				if err := client.Stop(); err != nil {
+					t.Error(err)
+				}
+				if err := server.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			info, err := client.InfoSync(types.RequestInfo{})
+			require.NoError(t, err)
+			require.True(t, info.SupportsInitChainAppStateChunks)
+
+			// the app state is split in the middle of a value
+			chunks := [][]byte{appState[:6], appState[6:]}
+			for i, chunk := range chunks {
+				_, err := client.InitChainAppStateChunkSync(types.RequestInitChainAppStateChunk{
+					Index: uint32(i),
+					Chunk: chunk,
+				})
+				require.NoError(t, err)
+			}
+			_, err = client.InitChainSync(types.RequestInitChain{
+				ValidatorSet:   RandValidatorSetUpdate(1),
+				AppStateChunks: uint32(len(chunks)),
+			})
+			require.NoError(t, err)
+
+			for key, value := range map[string]string{"a": "1", "b": "2"} {
+				res, err := client.QuerySync(types.RequestQuery{Path: "/store", Data: []byte(key)})
+				require.NoError(t, err)
+				require.Equal(t, value, string(res.Value))
+			}
+		})
+	}
+}
+
 func makeApplyBlock(
 	t *testing.T,
 	kvstore types.Application,
@@ -311,6 +395,11 @@ func runClientTests(t *testing.T, client abcicli.Client) {
 	value = testValue
 	tx = []byte(key + "=" + value)
 	testClient(t, client, tx, key, value)
+
+	// the txs of the proposal are returned by the app
+	res, err := client.PrepareProposalSync(types.RequestPrepareProposal{Txs: [][]byte{tx}})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{tx}, res.Txs)
 }
 
 func testClient(t *testing.T, app abcicli.Client, tx []byte, key, value string) {
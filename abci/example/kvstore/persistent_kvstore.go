@@ -3,6 +3,7 @@ package kvstore
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -37,6 +38,10 @@ type PersistentKVStoreApplication struct {
 
 	valProTxHashToPubKeyMap map[string]pc.PublicKey
 
+	// the chunks of the genesis app state received before InitChain
+	appState       bytes.Buffer
+	appStateChunks uint32
+
 	logger log.Logger
 }
 
@@ -64,6 +69,9 @@ func (app *PersistentKVStoreApplication) Info(req types.RequestInfo) types.Respo
 	res := app.app.Info(req)
 	res.LastBlockHeight = app.app.state.Height
 	res.LastBlockAppHash = app.app.state.AppHash
+	res.SupportsPrepareProposal = true
+	res.SupportsProcessProposal = true
+	res.SupportsInitChainAppStateChunks = true
 	return res
 }
 
@@ -90,6 +98,8 @@ func (app *PersistentKVStoreApplication) DeliverTx(req types.RequestDeliverTx) t
 }
 
 func (app *PersistentKVStoreApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	app.logger.Debug("CheckTx", "type", req.Type, "height", req.Height, "block_time", req.BlockTime,
+		"next_proposer", fmt.Sprintf("%X", req.NextProposerProTxHash))
 	return app.app.CheckTx(req)
 }
 
@@ -155,9 +165,49 @@ func (app *PersistentKVStoreApplication) InitChain(req types.RequestInitChain) t
 	app.updateQuorumHash(types.QuorumHashUpdate{
 		QuorumHash: req.ValidatorSet.QuorumHash,
 	})
+
+	appState := req.AppStateBytes
+	if req.AppStateChunks > 0 {
+		if app.appStateChunks != req.AppStateChunks {
+			panic(fmt.Sprintf("received %d app state chunks, expected %d", app.appStateChunks, req.AppStateChunks))
+		}
+		appState = app.appState.Bytes()
+	}
+	if len(appState) > 0 {
+		app.importAppState(appState)
+	}
+	app.appState = bytes.Buffer{}
+	app.appStateChunks = 0
 	return types.ResponseInitChain{}
 }
 
+// InitChainAppStateChunk collects the chunks of the genesis app state, which
+// is imported in InitChain.
+func (app *PersistentKVStoreApplication) InitChainAppStateChunk(
+	req types.RequestInitChainAppStateChunk) types.ResponseInitChainAppStateChunk {
+	if req.Index != app.appStateChunks {
+		panic(fmt.Sprintf("received app state chunk %d, expected %d", req.Index, app.appStateChunks))
+	}
+	app.appState.Write(req.Chunk)
+	app.appStateChunks++
+	return types.ResponseInitChainAppStateChunk{}
+}
+
+// importAppState stores the key/value pairs of the genesis app state, a JSON
+// object with string values, e.g. {"key": "value"}.
+func (app *PersistentKVStoreApplication) importAppState(appState []byte) {
+	values := map[string]string{}
+	if err := json.Unmarshal(appState, &values); err != nil {
+		panic(fmt.Sprintf("failed to decode the app state: %v", err))
+	}
+	for key, value := range values {
+		if err := app.app.state.db.Set(prefixKey([]byte(key)), []byte(value)); err != nil {
+			panic(err)
+		}
+		app.app.state.Size++
+	}
+}
+
 // Track the block hash and header information
 func (app *PersistentKVStoreApplication) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
 	// reset valset changes
@@ -191,6 +241,45 @@ func (app *PersistentKVStoreApplication) ApplySnapshotChunk(
 	return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ABORT}
 }
 
+// PrepareProposal drops the validator set change txs, which can't be decoded,
+// so they don't make it into the block.
+func (app *PersistentKVStoreApplication) PrepareProposal(
+	req types.RequestPrepareProposal) types.ResponsePrepareProposal {
+	txs := make([][]byte, 0, len(req.Txs))
+	for _, tx := range req.Txs {
+		if isValidatorTx(tx) {
+			if _, err := parseValidatorTx(tx); err != nil {
+				app.logger.Info("Dropping invalid validator set change tx", "err", err)
+				continue
+			}
+		}
+		txs = append(txs, tx)
+	}
+	return types.ResponsePrepareProposal{Txs: txs}
+}
+
+// ProcessProposal rejects the blocks with the validator set change txs, which
+// can't be decoded.
+func (app *PersistentKVStoreApplication) ProcessProposal(
+	req types.RequestProcessProposal) types.ResponseProcessProposal {
+	for _, tx := range req.Txs {
+		if isValidatorTx(tx) {
+			if _, err := parseValidatorTx(tx); err != nil {
+				return types.ResponseProcessProposal{
+					Status: types.ResponseProcessProposal_REJECT,
+					Info:   err.Error(),
+				}
+			}
+		}
+	}
+	return app.app.ProcessProposal(req)
+}
+
+func (app *PersistentKVStoreApplication) ExtendVote(
+	req types.RequestExtendVote) types.ResponseExtendVote {
+	return app.app.ExtendVote(req)
+}
+
 //---------------------------------------------
 // update validators
 
@@ -262,42 +351,46 @@ func isQuorumHashTx(tx []byte) bool {
 // format is "val:proTxHash!pubkey!power"
 // pubkey is a base64-encoded 48-byte bls12381 key
 func (app *PersistentKVStoreApplication) execValidatorTx(tx []byte) types.ResponseDeliverTx {
+	v, err := parseValidatorTx(tx)
+	if err != nil {
+		return types.ResponseDeliverTx{
+			Code: code.CodeTypeEncodingError,
+			Log:  err.Error()}
+	}
+
+	return app.updateValidatorSet(v)
+}
+
+// parseValidatorTx decodes the validator update of the validator set change tx
+func parseValidatorTx(tx []byte) (types.ValidatorUpdate, error) {
 	tx = tx[len(ValidatorSetChangePrefix):]
 
 	//  get the pubkey and power
 	values := strings.Split(string(tx), "!")
 	if len(values) != 3 {
-		return types.ResponseDeliverTx{
-			Code: code.CodeTypeEncodingError,
-			Log:  fmt.Sprintf("Expected 'proTxHash!pubkey!power'. Got %v", values)}
+		return types.ValidatorUpdate{}, fmt.Errorf("expected 'proTxHash!pubkey!power'. Got %v", values)
 	}
 	proTxHashS, pubkeyS, powerS := values[0], values[1], values[2]
 
 	// decode the proTxHash
 	proTxHash, err := base64.StdEncoding.DecodeString(proTxHashS)
 	if err != nil {
-		return types.ResponseDeliverTx{
-			Code: code.CodeTypeEncodingError,
-			Log:  fmt.Sprintf("ProTxHash (%s) is invalid base64", proTxHash)}
+		return types.ValidatorUpdate{}, fmt.Errorf("proTxHash (%s) is invalid base64", proTxHashS)
 	}
 
 	// decode the pubkey
 	pubkey, err := base64.StdEncoding.DecodeString(pubkeyS)
 	if err != nil {
-		return types.ResponseDeliverTx{
-			Code: code.CodeTypeEncodingError,
-			Log:  fmt.Sprintf("Pubkey (%s) is invalid base64", pubkeyS)}
+		return types.ValidatorUpdate{}, fmt.Errorf("pubkey (%s) is invalid base64", pubkeyS)
 	}
 
 	// decode the power
 	power, err := strconv.ParseInt(powerS, 10, 64)
 	if err != nil {
-		return types.ResponseDeliverTx{
-			Code: code.CodeTypeEncodingError,
-			Log:  fmt.Sprintf("Power (%s) is not an int", powerS)}
+		return types.ValidatorUpdate{}, fmt.Errorf("power (%s) is not an int", powerS)
 	}
 
-	return app.updateValidatorSet(types.UpdateValidator(proTxHash, pubkey, power))
+	return types.UpdateValidator(proTxHash, pubkey, power), nil
 }
 
 // format is "tpk:pubkey"
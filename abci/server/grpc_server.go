@@ -1,15 +1,29 @@
 package server
 
 import (
+	"context"
 	"net"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/tendermint/tendermint/abci/types"
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/libs/service"
 )
 
+// ABCIServiceName is the name of the gRPC ABCI service, its status is
+// reported by the health service.
+const ABCIServiceName = "tendermint.abci.ABCIApplication"
+
+// gracefulStopTimeout is how long the in-flight requests are drained on stop,
+// before they're cancelled.
+const gracefulStopTimeout = 10 * time.Second
+
 type GRPCServer struct {
 	service.BaseService
 
@@ -17,11 +31,17 @@ type GRPCServer struct {
 	addr     string
 	listener net.Listener
 	server   *grpc.Server
+	health   *health.Server
+
+	servingOnce sync.Once
 
 	app types.ABCIApplicationServer
 }
 
-// NewGRPCServer returns a new gRPC ABCI server
+// NewGRPCServer returns a new gRPC ABCI server. Besides the ABCI service, it
+// serves the gRPC health service and the server reflection. The ABCI service
+// is reported as SERVING once the app is initialized, i.e. it responded to
+// InitChain or to Info with a non-zero height.
 func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &GRPCServer{
@@ -44,7 +64,12 @@ func (s *GRPCServer) OnStart() error {
 
 	s.listener = ln
 	s.server = grpc.NewServer()
-	types.RegisterABCIApplicationServer(s.server, s.app)
+	s.health = health.NewServer()
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	s.health.SetServingStatus(ABCIServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	types.RegisterABCIApplicationServer(s.server, &healthApp{ABCIApplicationServer: s.app, server: s})
+	healthpb.RegisterHealthServer(s.server, s.health)
+	reflection.Register(s.server)
 
 	s.Logger.Info("Listening", "proto", s.proto, "addr", s.addr)
 	go func() {
@@ -55,7 +80,55 @@ func (s *GRPCServer) OnStart() error {
 	return nil
 }
 
-// OnStop stops the gRPC server.
+// OnStop stops the gRPC server, waiting for the in-flight requests up to
+// gracefulStopTimeout.
 func (s *GRPCServer) OnStop() {
-	s.server.Stop()
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(gracefulStopTimeout):
+		s.Logger.Error("Timed out draining the gRPC requests, cancelling them", "timeout", gracefulStopTimeout)
+		s.server.Stop()
+	}
+}
+
+// setServing reports the app as ready to serve.
+func (s *GRPCServer) setServing() {
+	s.servingOnce.Do(func() {
+		s.Logger.Info("ABCI app is initialized, serving")
+		s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		s.health.SetServingStatus(ABCIServiceName, healthpb.HealthCheckResponse_SERVING)
+	})
+}
+
+// healthApp is the app, which reports to the health service when it's
+// initialized.
+type healthApp struct {
+	types.ABCIApplicationServer
+	server *GRPCServer
+}
+
+func (app *healthApp) Info(ctx context.Context, req *types.RequestInfo) (*types.ResponseInfo, error) {
+	res, err := app.ABCIApplicationServer.Info(ctx, req)
+	if err == nil && res.LastBlockHeight > 0 {
+		app.server.setServing()
+	}
+	return res, err
+}
+
+func (app *healthApp) InitChain(
+	ctx context.Context,
+	req *types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	res, err := app.ABCIApplicationServer.InitChain(ctx, req)
+	if err == nil {
+		app.server.setServing()
+	}
+	return res, err
 }
@@ -4,6 +4,14 @@ import (
 	context "golang.org/x/net/context"
 )
 
+// QueryPathReplayBlock is the path of the queries, which re-execute a committed
+// block without changing the state of the application, sent to the apps which
+// set supports_replay in their Info response. The data of the query is the
+// block, encoded as tendermint.types.Block, and the value of the response are
+// the responses of the application to the block, encoded as
+// tendermint.state.ABCIResponses.
+const QueryPathReplayBlock = "/tendermint/replay_block"
+
 // Application is an interface that enables any finite, deterministic state machine
 // to be driven by a blockchain-based replication engine via the ABCI.
 // All methods take a RequestXxx argument and return a ResponseXxx argument,
@@ -24,11 +32,19 @@ type Application interface {
 	EndBlock(RequestEndBlock) ResponseEndBlock       // Signals the end of a block, returns changes to the validator set
 	Commit() ResponseCommit                          // Commit the state and return the application Merkle root hash
 
+	// Genesis App State (Consensus Connection)
+	InitChainAppStateChunk(RequestInitChainAppStateChunk) ResponseInitChainAppStateChunk // Receive a chunk of the app state
+
 	// State Sync Connection
 	ListSnapshots(RequestListSnapshots) ResponseListSnapshots                // List available snapshots
 	OfferSnapshot(RequestOfferSnapshot) ResponseOfferSnapshot                // Offer a snapshot to the application
 	LoadSnapshotChunk(RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk    // Load a snapshot chunk
 	ApplySnapshotChunk(RequestApplySnapshotChunk) ResponseApplySnapshotChunk // Apply a shapshot chunk
+
+	// Proposal Validation (Consensus Connection)
+	PrepareProposal(RequestPrepareProposal) ResponsePrepareProposal // Choose the txs of the block to propose
+	ProcessProposal(RequestProcessProposal) ResponseProcessProposal // Validate a proposed block before prevoting
+	ExtendVote(RequestExtendVote) ResponseExtendVote                // Extend a precommit with deterministic data
 }
 
 //-------------------------------------------------------
@@ -71,6 +87,10 @@ func (BaseApplication) InitChain(req RequestInitChain) ResponseInitChain {
 	return ResponseInitChain{}
 }
 
+func (BaseApplication) InitChainAppStateChunk(req RequestInitChainAppStateChunk) ResponseInitChainAppStateChunk {
+	return ResponseInitChainAppStateChunk{}
+}
+
 func (BaseApplication) BeginBlock(req RequestBeginBlock) ResponseBeginBlock {
 	return ResponseBeginBlock{}
 }
@@ -95,6 +115,18 @@ func (BaseApplication) ApplySnapshotChunk(req RequestApplySnapshotChunk) Respons
 	return ResponseApplySnapshotChunk{}
 }
 
+func (BaseApplication) ProcessProposal(req RequestProcessProposal) ResponseProcessProposal {
+	return ResponseProcessProposal{Status: ResponseProcessProposal_ACCEPT}
+}
+
+func (BaseApplication) ExtendVote(req RequestExtendVote) ResponseExtendVote {
+	return ResponseExtendVote{}
+}
+
+func (BaseApplication) PrepareProposal(req RequestPrepareProposal) ResponsePrepareProposal {
+	return ResponsePrepareProposal{Txs: req.Txs}
+}
+
 //-------------------------------------------------------
 
 // GRPCApplication is a GRPC wrapper for Application
@@ -149,6 +181,12 @@ func (app *GRPCApplication) InitChain(ctx context.Context, req *RequestInitChain
 	return &res, nil
 }
 
+func (app *GRPCApplication) InitChainAppStateChunk(
+	ctx context.Context, req *RequestInitChainAppStateChunk) (*ResponseInitChainAppStateChunk, error) {
+	res := app.app.InitChainAppStateChunk(*req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) BeginBlock(ctx context.Context, req *RequestBeginBlock) (*ResponseBeginBlock, error) {
 	res := app.app.BeginBlock(*req)
 	return &res, nil
@@ -182,3 +220,21 @@ func (app *GRPCApplication) ApplySnapshotChunk(
 	res := app.app.ApplySnapshotChunk(*req)
 	return &res, nil
 }
+
+func (app *GRPCApplication) ProcessProposal(
+	ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	res := app.app.ProcessProposal(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) ExtendVote(
+	ctx context.Context, req *RequestExtendVote) (*ResponseExtendVote, error) {
+	res := app.app.ExtendVote(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) PrepareProposal(
+	ctx context.Context, req *RequestPrepareProposal) (*ResponsePrepareProposal, error) {
+	res := app.app.PrepareProposal(*req)
+	return &res, nil
+}
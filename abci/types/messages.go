@@ -123,6 +123,12 @@ func ToRequestInitChain(req RequestInitChain) *Request {
 	}
 }
 
+func ToRequestInitChainAppStateChunk(req RequestInitChainAppStateChunk) *Request {
+	return &Request{
+		Value: &Request_InitChainAppStateChunk{&req},
+	}
+}
+
 func ToRequestBeginBlock(req RequestBeginBlock) *Request {
 	return &Request{
 		Value: &Request_BeginBlock{&req},
@@ -159,6 +165,24 @@ func ToRequestApplySnapshotChunk(req RequestApplySnapshotChunk) *Request {
 	}
 }
 
+func ToRequestProcessProposal(req RequestProcessProposal) *Request {
+	return &Request{
+		Value: &Request_ProcessProposal{&req},
+	}
+}
+
+func ToRequestExtendVote(req RequestExtendVote) *Request {
+	return &Request{
+		Value: &Request_ExtendVote{&req},
+	}
+}
+
+func ToRequestPrepareProposal(req RequestPrepareProposal) *Request {
+	return &Request{
+		Value: &Request_PrepareProposal{&req},
+	}
+}
+
 //----------------------------------------
 
 func ToResponseException(errStr string) *Response {
@@ -221,6 +245,12 @@ func ToResponseInitChain(res ResponseInitChain) *Response {
 	}
 }
 
+func ToResponseInitChainAppStateChunk(res ResponseInitChainAppStateChunk) *Response {
+	return &Response{
+		Value: &Response_InitChainAppStateChunk{&res},
+	}
+}
+
 func ToResponseBeginBlock(res ResponseBeginBlock) *Response {
 	return &Response{
 		Value: &Response_BeginBlock{&res},
@@ -256,3 +286,21 @@ func ToResponseApplySnapshotChunk(res ResponseApplySnapshotChunk) *Response {
 		Value: &Response_ApplySnapshotChunk{&res},
 	}
 }
+
+func ToResponseProcessProposal(res ResponseProcessProposal) *Response {
+	return &Response{
+		Value: &Response_ProcessProposal{&res},
+	}
+}
+
+func ToResponseExtendVote(res ResponseExtendVote) *Response {
+	return &Response{
+		Value: &Response_ExtendVote{&res},
+	}
+}
+
+func ToResponsePrepareProposal(res ResponsePrepareProposal) *Response {
+	return &Response{
+		Value: &Response_PrepareProposal{&res},
+	}
+}
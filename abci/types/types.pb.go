@@ -120,7 +120,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{34, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +157,35 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{36, 0}
+}
+
+type ResponseProcessProposal_ProposalStatus int32
+
+const (
+	ResponseProcessProposal_UNKNOWN ResponseProcessProposal_ProposalStatus = 0
+	ResponseProcessProposal_ACCEPT  ResponseProcessProposal_ProposalStatus = 1
+	ResponseProcessProposal_REJECT  ResponseProcessProposal_ProposalStatus = 2
+)
+
+var ResponseProcessProposal_ProposalStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "REJECT",
+}
+
+var ResponseProcessProposal_ProposalStatus_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACCEPT":  1,
+	"REJECT":  2,
+}
+
+func (x ResponseProcessProposal_ProposalStatus) String() string {
+	return proto.EnumName(ResponseProcessProposal_ProposalStatus_name, int32(x))
+}
+
+func (ResponseProcessProposal_ProposalStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{37, 0}
 }
 
 type Request struct {
@@ -177,6 +205,10 @@ type Request struct {
 	//	*Request_OfferSnapshot
 	//	*Request_LoadSnapshotChunk
 	//	*Request_ApplySnapshotChunk
+	//	*Request_ProcessProposal
+	//	*Request_ExtendVote
+	//	*Request_PrepareProposal
+	//	*Request_InitChainAppStateChunk
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -264,22 +296,38 @@ type Request_LoadSnapshotChunk struct {
 type Request_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *RequestApplySnapshotChunk `protobuf:"bytes,15,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
-
-func (*Request_Echo) isRequest_Value()               {}
-func (*Request_Flush) isRequest_Value()              {}
-func (*Request_Info) isRequest_Value()               {}
-func (*Request_SetOption) isRequest_Value()          {}
-func (*Request_InitChain) isRequest_Value()          {}
-func (*Request_Query) isRequest_Value()              {}
-func (*Request_BeginBlock) isRequest_Value()         {}
-func (*Request_CheckTx) isRequest_Value()            {}
-func (*Request_DeliverTx) isRequest_Value()          {}
-func (*Request_EndBlock) isRequest_Value()           {}
-func (*Request_Commit) isRequest_Value()             {}
-func (*Request_ListSnapshots) isRequest_Value()      {}
-func (*Request_OfferSnapshot) isRequest_Value()      {}
-func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
-func (*Request_ApplySnapshotChunk) isRequest_Value() {}
+type Request_ProcessProposal struct {
+	ProcessProposal *RequestProcessProposal `protobuf:"bytes,16,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
+}
+type Request_ExtendVote struct {
+	ExtendVote *RequestExtendVote `protobuf:"bytes,17,opt,name=extend_vote,json=extendVote,proto3,oneof" json:"extend_vote,omitempty"`
+}
+type Request_PrepareProposal struct {
+	PrepareProposal *RequestPrepareProposal `protobuf:"bytes,18,opt,name=prepare_proposal,json=prepareProposal,proto3,oneof" json:"prepare_proposal,omitempty"`
+}
+type Request_InitChainAppStateChunk struct {
+	InitChainAppStateChunk *RequestInitChainAppStateChunk `protobuf:"bytes,19,opt,name=init_chain_app_state_chunk,json=initChainAppStateChunk,proto3,oneof" json:"init_chain_app_state_chunk,omitempty"`
+}
+
+func (*Request_Echo) isRequest_Value()                   {}
+func (*Request_Flush) isRequest_Value()                  {}
+func (*Request_Info) isRequest_Value()                   {}
+func (*Request_SetOption) isRequest_Value()              {}
+func (*Request_InitChain) isRequest_Value()              {}
+func (*Request_Query) isRequest_Value()                  {}
+func (*Request_BeginBlock) isRequest_Value()             {}
+func (*Request_CheckTx) isRequest_Value()                {}
+func (*Request_DeliverTx) isRequest_Value()              {}
+func (*Request_EndBlock) isRequest_Value()               {}
+func (*Request_Commit) isRequest_Value()                 {}
+func (*Request_ListSnapshots) isRequest_Value()          {}
+func (*Request_OfferSnapshot) isRequest_Value()          {}
+func (*Request_LoadSnapshotChunk) isRequest_Value()      {}
+func (*Request_ApplySnapshotChunk) isRequest_Value()     {}
+func (*Request_ProcessProposal) isRequest_Value()        {}
+func (*Request_ExtendVote) isRequest_Value()             {}
+func (*Request_PrepareProposal) isRequest_Value()        {}
+func (*Request_InitChainAppStateChunk) isRequest_Value() {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -393,6 +441,34 @@ func (m *Request) GetApplySnapshotChunk() *RequestApplySnapshotChunk {
 	return nil
 }
 
+func (m *Request) GetProcessProposal() *RequestProcessProposal {
+	if x, ok := m.GetValue().(*Request_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
+func (m *Request) GetExtendVote() *RequestExtendVote {
+	if x, ok := m.GetValue().(*Request_ExtendVote); ok {
+		return x.ExtendVote
+	}
+	return nil
+}
+
+func (m *Request) GetPrepareProposal() *RequestPrepareProposal {
+	if x, ok := m.GetValue().(*Request_PrepareProposal); ok {
+		return x.PrepareProposal
+	}
+	return nil
+}
+
+func (m *Request) GetInitChainAppStateChunk() *RequestInitChainAppStateChunk {
+	if x, ok := m.GetValue().(*Request_InitChainAppStateChunk); ok {
+		return x.InitChainAppStateChunk
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -411,6 +487,10 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_OfferSnapshot)(nil),
 		(*Request_LoadSnapshotChunk)(nil),
 		(*Request_ApplySnapshotChunk)(nil),
+		(*Request_ProcessProposal)(nil),
+		(*Request_ExtendVote)(nil),
+		(*Request_PrepareProposal)(nil),
+		(*Request_InitChainAppStateChunk)(nil),
 	}
 }
 
@@ -612,9 +692,12 @@ type RequestInitChain struct {
 	ChainId         string           `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	ConsensusParams *ConsensusParams `protobuf:"bytes,3,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
 	//repeated ValidatorUpdate validators       = 4 [(gogoproto.nullable) = false];
-	AppStateBytes []byte             `protobuf:"bytes,5,opt,name=app_state_bytes,json=appStateBytes,proto3" json:"app_state_bytes,omitempty"`
-	InitialHeight int64              `protobuf:"varint,6,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
-	ValidatorSet  ValidatorSetUpdate `protobuf:"bytes,100,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set"`
+	AppStateBytes []byte `protobuf:"bytes,5,opt,name=app_state_bytes,json=appStateBytes,proto3" json:"app_state_bytes,omitempty"`
+	InitialHeight int64  `protobuf:"varint,6,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
+	// The number of the chunks of the app state sent with InitChainAppStateChunk
+	// before InitChain, app_state_bytes is empty then
+	AppStateChunks uint32             `protobuf:"varint,7,opt,name=app_state_chunks,json=appStateChunks,proto3" json:"app_state_chunks,omitempty"`
+	ValidatorSet   ValidatorSetUpdate `protobuf:"bytes,100,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set"`
 }
 
 func (m *RequestInitChain) Reset()         { *m = RequestInitChain{} }
@@ -685,6 +768,13 @@ func (m *RequestInitChain) GetInitialHeight() int64 {
 	return 0
 }
 
+func (m *RequestInitChain) GetAppStateChunks() uint32 {
+	if m != nil {
+		return m.AppStateChunks
+	}
+	return 0
+}
+
 func (m *RequestInitChain) GetValidatorSet() ValidatorSetUpdate {
 	if m != nil {
 		return m.ValidatorSet
@@ -831,6 +921,13 @@ func (m *RequestBeginBlock) GetByzantineValidators() []Evidence {
 type RequestCheckTx struct {
 	Tx   []byte      `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 	Type CheckTxType `protobuf:"varint,2,opt,name=type,proto3,enum=tendermint.abci.CheckTxType" json:"type,omitempty"`
+	// The height the tx is expected to be executed at, i.e. the height after the
+	// last block the mempool was updated to; 0 if unknown
+	Height int64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// The time of the last block the mempool was updated to; unset if unknown
+	BlockTime *time.Time `protobuf:"bytes,4,opt,name=block_time,json=blockTime,proto3,stdtime" json:"block_time,omitempty"`
+	// The proTxHash of the proposer of the next block, if known
+	NextProposerProTxHash []byte `protobuf:"bytes,5,opt,name=next_proposer_pro_tx_hash,json=nextProposerProTxHash,proto3" json:"next_proposer_pro_tx_hash,omitempty"`
 }
 
 func (m *RequestCheckTx) Reset()         { *m = RequestCheckTx{} }
@@ -880,6 +977,27 @@ func (m *RequestCheckTx) GetType() CheckTxType {
 	return CheckTxType_New
 }
 
+func (m *RequestCheckTx) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RequestCheckTx) GetBlockTime() *time.Time {
+	if m != nil {
+		return m.BlockTime
+	}
+	return nil
+}
+
+func (m *RequestCheckTx) GetNextProposerProTxHash() []byte {
+	if m != nil {
+		return m.NextProposerProTxHash
+	}
+	return nil
+}
+
 type RequestDeliverTx struct {
 	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
 }
@@ -926,6 +1044,12 @@ func (m *RequestDeliverTx) GetTx() []byte {
 
 type RequestEndBlock struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// take_snapshot asks the application to take a state sync snapshot of its
+	// state at this height, once it's committed
+	TakeSnapshot bool `protobuf:"varint,2,opt,name=take_snapshot,json=takeSnapshot,proto3" json:"take_snapshot,omitempty"`
+	// snapshot_retain_height is the height of the oldest snapshot to keep, the
+	// application should prune the older ones. 0 keeps all the snapshots.
+	SnapshotRetainHeight uint64 `protobuf:"varint,3,opt,name=snapshot_retain_height,json=snapshotRetainHeight,proto3" json:"snapshot_retain_height,omitempty"`
 }
 
 func (m *RequestEndBlock) Reset()         { *m = RequestEndBlock{} }
@@ -968,6 +1092,20 @@ func (m *RequestEndBlock) GetHeight() int64 {
 	return 0
 }
 
+func (m *RequestEndBlock) GetTakeSnapshot() bool {
+	if m != nil {
+		return m.TakeSnapshot
+	}
+	return false
+}
+
+func (m *RequestEndBlock) GetSnapshotRetainHeight() uint64 {
+	if m != nil {
+		return m.SnapshotRetainHeight
+	}
+	return 0
+}
+
 type RequestCommit struct {
 }
 
@@ -1216,6 +1354,270 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 	return ""
 }
 
+// Validators call ProcessProposal once a complete proposal block is received
+type RequestProcessProposal struct {
+	Hash   []byte        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Header types1.Header `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
+	Txs    [][]byte      `protobuf:"bytes,3,rep,name=txs,proto3" json:"txs,omitempty"`
+	Round  int32         `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (m *RequestProcessProposal) Reset()         { *m = RequestProcessProposal{} }
+func (m *RequestProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*RequestProcessProposal) ProtoMessage()    {}
+func (*RequestProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{16}
+}
+func (m *RequestProcessProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestProcessProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestProcessProposal.Merge(m, src)
+}
+func (m *RequestProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestProcessProposal proto.InternalMessageInfo
+
+func (m *RequestProcessProposal) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RequestProcessProposal) GetHeader() types1.Header {
+	if m != nil {
+		return m.Header
+	}
+	return types1.Header{}
+}
+
+func (m *RequestProcessProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestProcessProposal) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+// Validators call ExtendVote before they precommit a block, if vote extensions
+// are enabled at the height
+type RequestExtendVote struct {
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (m *RequestExtendVote) Reset()         { *m = RequestExtendVote{} }
+func (m *RequestExtendVote) String() string { return proto.CompactTextString(m) }
+func (*RequestExtendVote) ProtoMessage()    {}
+func (*RequestExtendVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{17}
+}
+func (m *RequestExtendVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestExtendVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestExtendVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestExtendVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestExtendVote.Merge(m, src)
+}
+func (m *RequestExtendVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestExtendVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestExtendVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestExtendVote proto.InternalMessageInfo
+
+func (m *RequestExtendVote) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RequestExtendVote) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RequestExtendVote) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+// The proposer calls PrepareProposal with the txs reaped from the mempool
+// before it creates the proposal block
+type RequestPrepareProposal struct {
+	Height            int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round             int32    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	ProposerProTxHash []byte   `protobuf:"bytes,3,opt,name=proposer_pro_tx_hash,json=proposerProTxHash,proto3" json:"proposer_pro_tx_hash,omitempty"`
+	Txs               [][]byte `protobuf:"bytes,4,rep,name=txs,proto3" json:"txs,omitempty"`
+	// The maximum total size of the txs of the block, in the protobuf encoding
+	MaxTxBytes int64 `protobuf:"varint,5,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
+}
+
+func (m *RequestPrepareProposal) Reset()         { *m = RequestPrepareProposal{} }
+func (m *RequestPrepareProposal) String() string { return proto.CompactTextString(m) }
+func (*RequestPrepareProposal) ProtoMessage()    {}
+func (*RequestPrepareProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{18}
+}
+func (m *RequestPrepareProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestPrepareProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestPrepareProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestPrepareProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPrepareProposal.Merge(m, src)
+}
+func (m *RequestPrepareProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestPrepareProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPrepareProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPrepareProposal proto.InternalMessageInfo
+
+func (m *RequestPrepareProposal) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RequestPrepareProposal) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RequestPrepareProposal) GetProposerProTxHash() []byte {
+	if m != nil {
+		return m.ProposerProTxHash
+	}
+	return nil
+}
+
+func (m *RequestPrepareProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestPrepareProposal) GetMaxTxBytes() int64 {
+	if m != nil {
+		return m.MaxTxBytes
+	}
+	return 0
+}
+
+// Tenderdash sends the app state of the genesis doc to the application in
+// chunks before InitChain, if the application supports it
+type RequestInitChainAppStateChunk struct {
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (m *RequestInitChainAppStateChunk) Reset()         { *m = RequestInitChainAppStateChunk{} }
+func (m *RequestInitChainAppStateChunk) String() string { return proto.CompactTextString(m) }
+func (*RequestInitChainAppStateChunk) ProtoMessage()    {}
+func (*RequestInitChainAppStateChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{19}
+}
+func (m *RequestInitChainAppStateChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestInitChainAppStateChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestInitChainAppStateChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestInitChainAppStateChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestInitChainAppStateChunk.Merge(m, src)
+}
+func (m *RequestInitChainAppStateChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestInitChainAppStateChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestInitChainAppStateChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestInitChainAppStateChunk proto.InternalMessageInfo
+
+func (m *RequestInitChainAppStateChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RequestInitChainAppStateChunk) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -1234,6 +1636,10 @@ type Response struct {
 	//	*Response_OfferSnapshot
 	//	*Response_LoadSnapshotChunk
 	//	*Response_ApplySnapshotChunk
+	//	*Response_ProcessProposal
+	//	*Response_ExtendVote
+	//	*Response_PrepareProposal
+	//	*Response_InitChainAppStateChunk
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1241,7 +1647,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1324,23 +1730,39 @@ type Response_LoadSnapshotChunk struct {
 type Response_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *ResponseApplySnapshotChunk `protobuf:"bytes,16,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
-
-func (*Response_Exception) isResponse_Value()          {}
-func (*Response_Echo) isResponse_Value()               {}
-func (*Response_Flush) isResponse_Value()              {}
-func (*Response_Info) isResponse_Value()               {}
-func (*Response_SetOption) isResponse_Value()          {}
-func (*Response_InitChain) isResponse_Value()          {}
-func (*Response_Query) isResponse_Value()              {}
-func (*Response_BeginBlock) isResponse_Value()         {}
-func (*Response_CheckTx) isResponse_Value()            {}
-func (*Response_DeliverTx) isResponse_Value()          {}
-func (*Response_EndBlock) isResponse_Value()           {}
-func (*Response_Commit) isResponse_Value()             {}
-func (*Response_ListSnapshots) isResponse_Value()      {}
-func (*Response_OfferSnapshot) isResponse_Value()      {}
-func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
-func (*Response_ApplySnapshotChunk) isResponse_Value() {}
+type Response_ProcessProposal struct {
+	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,17,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
+}
+type Response_ExtendVote struct {
+	ExtendVote *ResponseExtendVote `protobuf:"bytes,18,opt,name=extend_vote,json=extendVote,proto3,oneof" json:"extend_vote,omitempty"`
+}
+type Response_PrepareProposal struct {
+	PrepareProposal *ResponsePrepareProposal `protobuf:"bytes,19,opt,name=prepare_proposal,json=prepareProposal,proto3,oneof" json:"prepare_proposal,omitempty"`
+}
+type Response_InitChainAppStateChunk struct {
+	InitChainAppStateChunk *ResponseInitChainAppStateChunk `protobuf:"bytes,20,opt,name=init_chain_app_state_chunk,json=initChainAppStateChunk,proto3,oneof" json:"init_chain_app_state_chunk,omitempty"`
+}
+
+func (*Response_Exception) isResponse_Value()              {}
+func (*Response_Echo) isResponse_Value()                   {}
+func (*Response_Flush) isResponse_Value()                  {}
+func (*Response_Info) isResponse_Value()                   {}
+func (*Response_SetOption) isResponse_Value()              {}
+func (*Response_InitChain) isResponse_Value()              {}
+func (*Response_Query) isResponse_Value()                  {}
+func (*Response_BeginBlock) isResponse_Value()             {}
+func (*Response_CheckTx) isResponse_Value()                {}
+func (*Response_DeliverTx) isResponse_Value()              {}
+func (*Response_EndBlock) isResponse_Value()               {}
+func (*Response_Commit) isResponse_Value()                 {}
+func (*Response_ListSnapshots) isResponse_Value()          {}
+func (*Response_OfferSnapshot) isResponse_Value()          {}
+func (*Response_LoadSnapshotChunk) isResponse_Value()      {}
+func (*Response_ApplySnapshotChunk) isResponse_Value()     {}
+func (*Response_ProcessProposal) isResponse_Value()        {}
+func (*Response_ExtendVote) isResponse_Value()             {}
+func (*Response_PrepareProposal) isResponse_Value()        {}
+func (*Response_InitChainAppStateChunk) isResponse_Value() {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1461,6 +1883,34 @@ func (m *Response) GetApplySnapshotChunk() *ResponseApplySnapshotChunk {
 	return nil
 }
 
+func (m *Response) GetProcessProposal() *ResponseProcessProposal {
+	if x, ok := m.GetValue().(*Response_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
+func (m *Response) GetExtendVote() *ResponseExtendVote {
+	if x, ok := m.GetValue().(*Response_ExtendVote); ok {
+		return x.ExtendVote
+	}
+	return nil
+}
+
+func (m *Response) GetPrepareProposal() *ResponsePrepareProposal {
+	if x, ok := m.GetValue().(*Response_PrepareProposal); ok {
+		return x.PrepareProposal
+	}
+	return nil
+}
+
+func (m *Response) GetInitChainAppStateChunk() *ResponseInitChainAppStateChunk {
+	if x, ok := m.GetValue().(*Response_InitChainAppStateChunk); ok {
+		return x.InitChainAppStateChunk
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1480,6 +1930,10 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_OfferSnapshot)(nil),
 		(*Response_LoadSnapshotChunk)(nil),
 		(*Response_ApplySnapshotChunk)(nil),
+		(*Response_ProcessProposal)(nil),
+		(*Response_ExtendVote)(nil),
+		(*Response_PrepareProposal)(nil),
+		(*Response_InitChainAppStateChunk)(nil),
 	}
 }
 
@@ -1492,7 +1946,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1536,7 +1990,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1579,7 +2033,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1615,13 +2069,23 @@ type ResponseInfo struct {
 	LastBlockHeight           int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash          []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
 	LastCoreChainLockedHeight uint32 `protobuf:"varint,100,opt,name=last_core_chain_locked_height,json=lastCoreChainLockedHeight,proto3" json:"last_core_chain_locked_height,omitempty"`
+	// The application implements ProcessProposal and wants it to be called
+	SupportsProcessProposal bool `protobuf:"varint,101,opt,name=supports_process_proposal,json=supportsProcessProposal,proto3" json:"supports_process_proposal,omitempty"`
+	// The application implements PrepareProposal and wants it to be called
+	SupportsPrepareProposal bool `protobuf:"varint,102,opt,name=supports_prepare_proposal,json=supportsPrepareProposal,proto3" json:"supports_prepare_proposal,omitempty"`
+	// The application re-executes the committed blocks deterministically on
+	// the query connection, see QueryPathReplayBlock
+	SupportsReplay bool `protobuf:"varint,103,opt,name=supports_replay,json=supportsReplay,proto3" json:"supports_replay,omitempty"`
+	// The application implements InitChainAppStateChunk and wants the app state
+	// of the genesis doc to be sent in chunks
+	SupportsInitChainAppStateChunks bool `protobuf:"varint,104,opt,name=supports_init_chain_app_state_chunks,json=supportsInitChainAppStateChunks,proto3" json:"supports_init_chain_app_state_chunks,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1692,6 +2156,34 @@ func (m *ResponseInfo) GetLastCoreChainLockedHeight() uint32 {
 	return 0
 }
 
+func (m *ResponseInfo) GetSupportsProcessProposal() bool {
+	if m != nil {
+		return m.SupportsProcessProposal
+	}
+	return false
+}
+
+func (m *ResponseInfo) GetSupportsPrepareProposal() bool {
+	if m != nil {
+		return m.SupportsPrepareProposal
+	}
+	return false
+}
+
+func (m *ResponseInfo) GetSupportsReplay() bool {
+	if m != nil {
+		return m.SupportsReplay
+	}
+	return false
+}
+
+func (m *ResponseInfo) GetSupportsInitChainAppStateChunks() bool {
+	if m != nil {
+		return m.SupportsInitChainAppStateChunks
+	}
+	return false
+}
+
 // nondeterministic
 type ResponseSetOption struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -1704,7 +2196,7 @@ func (m *ResponseSetOption) Reset()         { *m = ResponseSetOption{} }
 func (m *ResponseSetOption) String() string { return proto.CompactTextString(m) }
 func (*ResponseSetOption) ProtoMessage()    {}
 func (*ResponseSetOption) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseSetOption) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1766,7 +2258,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1840,7 +2332,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1940,7 +2432,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1985,13 +2477,22 @@ type ResponseCheckTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// the priority of the tx in the priority mempool (mempool version v1),
+	// higher priority txs are reaped first
+	Priority int64 `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	// the sender of the tx, the priority mempool never evicts a tx of the sender
+	// while a tx the sender submitted later is still pending
+	Sender string `protobuf:"bytes,10,opt,name=sender,proto3" json:"sender,omitempty"`
+	// the hash of a pending tx, which is replaced by this tx in the mempool,
+	// e.g. when a wallet resubmits a tx with a higher fee
+	ReplaceTxHash []byte `protobuf:"bytes,11,opt,name=replace_tx_hash,json=replaceTxHash,proto3" json:"replace_tx_hash,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2076,6 +2577,27 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetReplaceTxHash() []byte {
+	if m != nil {
+		return m.ReplaceTxHash
+	}
+	return nil
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2091,7 +2613,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2188,7 +2710,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2255,7 +2777,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2306,7 +2828,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2350,7 +2872,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2394,7 +2916,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2440,7 +2962,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2490,6 +3012,187 @@ func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 	return nil
 }
 
+type ResponseProcessProposal struct {
+	Status ResponseProcessProposal_ProposalStatus `protobuf:"varint,1,opt,name=status,proto3,enum=tendermint.abci.ResponseProcessProposal_ProposalStatus" json:"status,omitempty"`
+	Info   string                                 `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal{} }
+func (m *ResponseProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*ResponseProcessProposal) ProtoMessage()    {}
+func (*ResponseProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{37}
+}
+func (m *ResponseProcessProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseProcessProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseProcessProposal.Merge(m, src)
+}
+func (m *ResponseProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseProcessProposal proto.InternalMessageInfo
+
+func (m *ResponseProcessProposal) GetStatus() ResponseProcessProposal_ProposalStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResponseProcessProposal_UNKNOWN
+}
+
+func (m *ResponseProcessProposal) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
+type ResponseExtendVote struct {
+	// The extension must be deterministic, the extension signature shares of
+	// the validators are recovered into a threshold signature. The block isn't
+	// committed until they agree on it, it's retried in the next rounds.
+	VoteExtension []byte `protobuf:"bytes,1,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`
+}
+
+func (m *ResponseExtendVote) Reset()         { *m = ResponseExtendVote{} }
+func (m *ResponseExtendVote) String() string { return proto.CompactTextString(m) }
+func (*ResponseExtendVote) ProtoMessage()    {}
+func (*ResponseExtendVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{38}
+}
+func (m *ResponseExtendVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseExtendVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseExtendVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseExtendVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseExtendVote.Merge(m, src)
+}
+func (m *ResponseExtendVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseExtendVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseExtendVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseExtendVote proto.InternalMessageInfo
+
+func (m *ResponseExtendVote) GetVoteExtension() []byte {
+	if m != nil {
+		return m.VoteExtension
+	}
+	return nil
+}
+
+type ResponsePrepareProposal struct {
+	// The txs of the proposal block, in order. They may be reordered, removed
+	// or added, but must not exceed max_tx_bytes
+	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *ResponsePrepareProposal) Reset()         { *m = ResponsePrepareProposal{} }
+func (m *ResponsePrepareProposal) String() string { return proto.CompactTextString(m) }
+func (*ResponsePrepareProposal) ProtoMessage()    {}
+func (*ResponsePrepareProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{39}
+}
+func (m *ResponsePrepareProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponsePrepareProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponsePrepareProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponsePrepareProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponsePrepareProposal.Merge(m, src)
+}
+func (m *ResponsePrepareProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponsePrepareProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponsePrepareProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponsePrepareProposal proto.InternalMessageInfo
+
+func (m *ResponsePrepareProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+type ResponseInitChainAppStateChunk struct {
+}
+
+func (m *ResponseInitChainAppStateChunk) Reset()         { *m = ResponseInitChainAppStateChunk{} }
+func (m *ResponseInitChainAppStateChunk) String() string { return proto.CompactTextString(m) }
+func (*ResponseInitChainAppStateChunk) ProtoMessage()    {}
+func (*ResponseInitChainAppStateChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{40}
+}
+func (m *ResponseInitChainAppStateChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseInitChainAppStateChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseInitChainAppStateChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseInitChainAppStateChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseInitChainAppStateChunk.Merge(m, src)
+}
+func (m *ResponseInitChainAppStateChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseInitChainAppStateChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseInitChainAppStateChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseInitChainAppStateChunk proto.InternalMessageInfo
+
 // ConsensusParams contains all consensus-relevant parameters
 // that can be adjusted by the abci app
 type ConsensusParams struct {
@@ -2497,13 +3200,15 @@ type ConsensusParams struct {
 	Evidence  *types1.EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
 	Validator *types1.ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *types1.VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Abci      *types1.ABCIParams      `protobuf:"bytes,5,opt,name=abci,proto3" json:"abci,omitempty"`
+	Synchrony *types1.SynchronyParams `protobuf:"bytes,6,opt,name=synchrony,proto3" json:"synchrony,omitempty"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
 func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
 func (*ConsensusParams) ProtoMessage()    {}
 func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2560,6 +3265,20 @@ func (m *ConsensusParams) GetVersion() *types1.VersionParams {
 	return nil
 }
 
+func (m *ConsensusParams) GetAbci() *types1.ABCIParams {
+	if m != nil {
+		return m.Abci
+	}
+	return nil
+}
+
+func (m *ConsensusParams) GetSynchrony() *types1.SynchronyParams {
+	if m != nil {
+		return m.Synchrony
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Note: must be greater than 0
@@ -2572,7 +3291,7 @@ func (m *BlockParams) Reset()         { *m = BlockParams{} }
 func (m *BlockParams) String() string { return proto.CompactTextString(m) }
 func (*BlockParams) ProtoMessage()    {}
 func (*BlockParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *BlockParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2616,17 +3335,20 @@ func (m *BlockParams) GetMaxGas() int64 {
 }
 
 type LastCommitInfo struct {
-	Round          int32  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
-	QuorumHash     []byte `protobuf:"bytes,3,opt,name=quorumHash,proto3" json:"quorumHash,omitempty"`
-	BlockSignature []byte `protobuf:"bytes,4,opt,name=blockSignature,proto3" json:"blockSignature,omitempty"`
-	StateSignature []byte `protobuf:"bytes,5,opt,name=stateSignature,proto3" json:"stateSignature,omitempty"`
+	Round int32 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// The validators of the last block and whether they signed it, as recorded
+	// by the signers of the last commit of the block. Empty for the first block.
+	Votes          []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
+	QuorumHash     []byte     `protobuf:"bytes,3,opt,name=quorumHash,proto3" json:"quorumHash,omitempty"`
+	BlockSignature []byte     `protobuf:"bytes,4,opt,name=blockSignature,proto3" json:"blockSignature,omitempty"`
+	StateSignature []byte     `protobuf:"bytes,5,opt,name=stateSignature,proto3" json:"stateSignature,omitempty"`
 }
 
 func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2662,6 +3384,13 @@ func (m *LastCommitInfo) GetRound() int32 {
 	return 0
 }
 
+func (m *LastCommitInfo) GetVotes() []VoteInfo {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
 func (m *LastCommitInfo) GetQuorumHash() []byte {
 	if m != nil {
 		return m.QuorumHash
@@ -2695,7 +3424,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{44}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2749,7 +3478,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{45}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2813,7 +3542,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{46}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2882,7 +3611,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{47}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2936,7 +3665,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{48}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2990,13 +3719,16 @@ type ValidatorSetUpdate struct {
 	ValidatorUpdates   []ValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
 	ThresholdPublicKey crypto.PublicKey  `protobuf:"bytes,2,opt,name=threshold_public_key,json=thresholdPublicKey,proto3" json:"threshold_public_key"`
 	QuorumHash         []byte            `protobuf:"bytes,3,opt,name=quorum_hash,json=quorumHash,proto3" json:"quorum_hash,omitempty"`
+	// the LLMQ type of the quorum, 0 keeps the quorum type of the current
+	// validator set
+	QuorumType int32 `protobuf:"varint,4,opt,name=quorum_type,json=quorumType,proto3" json:"quorum_type,omitempty"`
 }
 
 func (m *ValidatorSetUpdate) Reset()         { *m = ValidatorSetUpdate{} }
 func (m *ValidatorSetUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorSetUpdate) ProtoMessage()    {}
 func (*ValidatorSetUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{49}
 }
 func (m *ValidatorSetUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3046,6 +3778,13 @@ func (m *ValidatorSetUpdate) GetQuorumHash() []byte {
 	return nil
 }
 
+func (m *ValidatorSetUpdate) GetQuorumType() int32 {
+	if m != nil {
+		return m.QuorumType
+	}
+	return 0
+}
+
 type ThresholdPublicKeyUpdate struct {
 	ThresholdPublicKey crypto.PublicKey `protobuf:"bytes,1,opt,name=threshold_public_key,json=thresholdPublicKey,proto3" json:"threshold_public_key"`
 }
@@ -3054,7 +3793,7 @@ func (m *ThresholdPublicKeyUpdate) Reset()         { *m = ThresholdPublicKeyUpda
 func (m *ThresholdPublicKeyUpdate) String() string { return proto.CompactTextString(m) }
 func (*ThresholdPublicKeyUpdate) ProtoMessage()    {}
 func (*ThresholdPublicKeyUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{42}
+	return fileDescriptor_252557cfdd89a31a, []int{50}
 }
 func (m *ThresholdPublicKeyUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3098,7 +3837,7 @@ func (m *QuorumHashUpdate) Reset()         { *m = QuorumHashUpdate{} }
 func (m *QuorumHashUpdate) String() string { return proto.CompactTextString(m) }
 func (*QuorumHashUpdate) ProtoMessage()    {}
 func (*QuorumHashUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{43}
+	return fileDescriptor_252557cfdd89a31a, []int{51}
 }
 func (m *QuorumHashUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3144,7 +3883,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{44}
+	return fileDescriptor_252557cfdd89a31a, []int{52}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3205,7 +3944,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{45}
+	return fileDescriptor_252557cfdd89a31a, []int{53}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3282,7 +4021,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{46}
+	return fileDescriptor_252557cfdd89a31a, []int{54}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3358,6 +4097,7 @@ func init() {
 	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
 	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
 	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseProcessProposal_ProposalStatus", ResponseProcessProposal_ProposalStatus_name, ResponseProcessProposal_ProposalStatus_value)
 	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
 	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
 	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
@@ -3374,6 +4114,10 @@ func init() {
 	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
 	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
 	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestProcessProposal)(nil), "tendermint.abci.RequestProcessProposal")
+	proto.RegisterType((*RequestExtendVote)(nil), "tendermint.abci.RequestExtendVote")
+	proto.RegisterType((*RequestPrepareProposal)(nil), "tendermint.abci.RequestPrepareProposal")
+	proto.RegisterType((*RequestInitChainAppStateChunk)(nil), "tendermint.abci.RequestInitChainAppStateChunk")
 	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
 	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
@@ -3391,6 +4135,10 @@ func init() {
 	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
 	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponseProcessProposal)(nil), "tendermint.abci.ResponseProcessProposal")
+	proto.RegisterType((*ResponseExtendVote)(nil), "tendermint.abci.ResponseExtendVote")
+	proto.RegisterType((*ResponsePrepareProposal)(nil), "tendermint.abci.ResponsePrepareProposal")
+	proto.RegisterType((*ResponseInitChainAppStateChunk)(nil), "tendermint.abci.ResponseInitChainAppStateChunk")
 	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
@@ -3410,193 +4158,234 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2975 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcb, 0x73, 0x23, 0xd5,
-	0xd5, 0x57, 0xeb, 0xad, 0x23, 0x4b, 0x96, 0xef, 0x98, 0x19, 0x8d, 0x66, 0xc6, 0x1e, 0x9a, 0x82,
-	0x6f, 0x18, 0xc0, 0xfe, 0xf0, 0x14, 0xaf, 0x84, 0x04, 0x6c, 0xa1, 0x41, 0xc6, 0xc6, 0x36, 0x6d,
-	0xcd, 0x90, 0x84, 0x30, 0x4d, 0x4b, 0x7d, 0x2d, 0x35, 0x23, 0x75, 0x37, 0xdd, 0x57, 0x42, 0x66,
-	0x0b, 0xd9, 0xb0, 0x22, 0x9b, 0x54, 0x36, 0xfc, 0x1d, 0x59, 0xa4, 0x2a, 0x6b, 0x96, 0x54, 0x65,
-	0x93, 0x15, 0xa1, 0x98, 0xca, 0x26, 0xcb, 0x6c, 0x52, 0x95, 0xaa, 0x54, 0x52, 0xf7, 0xd1, 0x2f,
-	0x49, 0x2d, 0xc9, 0xcc, 0x32, 0xbb, 0xbe, 0xa7, 0xcf, 0x39, 0x7d, 0xcf, 0xed, 0xbe, 0xbf, 0xf3,
-	0x3b, 0xa7, 0x2f, 0x5c, 0x23, 0xd8, 0xd4, 0xb1, 0x33, 0x30, 0x4c, 0xb2, 0xad, 0xb5, 0x3b, 0xc6,
-	0x36, 0x39, 0xb7, 0xb1, 0xbb, 0x65, 0x3b, 0x16, 0xb1, 0xd0, 0x6a, 0x70, 0x73, 0x8b, 0xde, 0xac,
-	0xdd, 0x08, 0x69, 0x77, 0x9c, 0x73, 0x9b, 0x58, 0xdb, 0xb6, 0x63, 0x59, 0x67, 0x5c, 0xbf, 0x76,
-	0x3d, 0x74, 0x9b, 0xf9, 0x09, 0x7b, 0x8b, 0xdc, 0x15, 0xc6, 0x0f, 0xf1, 0xb9, 0x77, 0xf7, 0xc6,
-	0x94, 0xad, 0xad, 0x39, 0xda, 0xc0, 0xbb, 0xbd, 0xd9, 0xb5, 0xac, 0x6e, 0x1f, 0x6f, 0xb3, 0x51,
-	0x7b, 0x78, 0xb6, 0x4d, 0x8c, 0x01, 0x76, 0x89, 0x36, 0xb0, 0x85, 0xc2, 0x7a, 0xd7, 0xea, 0x5a,
-	0xec, 0x72, 0x9b, 0x5e, 0x71, 0xa9, 0xfc, 0xdb, 0x3c, 0xe4, 0x14, 0xfc, 0xc9, 0x10, 0xbb, 0x04,
-	0xed, 0x40, 0x1a, 0x77, 0x7a, 0x56, 0x55, 0xba, 0x29, 0xdd, 0x2a, 0xee, 0x5c, 0xdf, 0x9a, 0x08,
-	0x6e, 0x4b, 0xe8, 0x35, 0x3a, 0x3d, 0xab, 0x99, 0x50, 0x98, 0x2e, 0x7a, 0x09, 0x32, 0x67, 0xfd,
-	0xa1, 0xdb, 0xab, 0x26, 0x99, 0xd1, 0x8d, 0x38, 0xa3, 0xbb, 0x54, 0xa9, 0x99, 0x50, 0xb8, 0x36,
-	0x7d, 0x94, 0x61, 0x9e, 0x59, 0xd5, 0xd4, 0xfc, 0x47, 0xed, 0x9b, 0x67, 0xec, 0x51, 0x54, 0x17,
-	0xed, 0x01, 0xb8, 0x98, 0xa8, 0x96, 0x4d, 0x0c, 0xcb, 0xac, 0xa6, 0x99, 0xe5, 0x93, 0x71, 0x96,
-	0xa7, 0x98, 0x1c, 0x33, 0xc5, 0x66, 0x42, 0x29, 0xb8, 0xde, 0x80, 0xfa, 0x30, 0x4c, 0x83, 0xa8,
-	0x9d, 0x9e, 0x66, 0x98, 0xd5, 0xcc, 0x7c, 0x1f, 0xfb, 0xa6, 0x41, 0xea, 0x54, 0x91, 0xfa, 0x30,
-	0xbc, 0x01, 0x0d, 0xf9, 0x93, 0x21, 0x76, 0xce, 0xab, 0xd9, 0xf9, 0x21, 0xbf, 0x47, 0x95, 0x68,
-	0xc8, 0x4c, 0x1b, 0x35, 0xa0, 0xd8, 0xc6, 0x5d, 0xc3, 0x54, 0xdb, 0x7d, 0xab, 0xf3, 0xb0, 0x9a,
-	0x63, 0xc6, 0x72, 0x9c, 0xf1, 0x1e, 0x55, 0xdd, 0xa3, 0x9a, 0xcd, 0x84, 0x02, 0x6d, 0x7f, 0x84,
-	0x5e, 0x87, 0x7c, 0xa7, 0x87, 0x3b, 0x0f, 0x55, 0x32, 0xae, 0xe6, 0x99, 0x8f, 0xcd, 0x38, 0x1f,
-	0x75, 0xaa, 0xd7, 0x1a, 0x37, 0x13, 0x4a, 0xae, 0xc3, 0x2f, 0x69, 0xfc, 0x3a, 0xee, 0x1b, 0x23,
-	0xec, 0x50, 0xfb, 0xc2, 0xfc, 0xf8, 0xdf, 0xe2, 0x9a, 0xcc, 0x43, 0x41, 0xf7, 0x06, 0xe8, 0x0d,
-	0x28, 0x60, 0x53, 0x17, 0x61, 0x00, 0x73, 0x71, 0x33, 0xf6, 0x5b, 0x31, 0x75, 0x2f, 0x88, 0x3c,
-	0x16, 0xd7, 0xe8, 0x55, 0xc8, 0x76, 0xac, 0xc1, 0xc0, 0x20, 0xd5, 0x22, 0xb3, 0xde, 0x88, 0x0d,
-	0x80, 0x69, 0x35, 0x13, 0x8a, 0xd0, 0x47, 0x47, 0x50, 0xee, 0x1b, 0x2e, 0x51, 0x5d, 0x53, 0xb3,
-	0xdd, 0x9e, 0x45, 0xdc, 0xea, 0x0a, 0xf3, 0xf0, 0x74, 0x9c, 0x87, 0x43, 0xc3, 0x25, 0xa7, 0x9e,
-	0x72, 0x33, 0xa1, 0x94, 0xfa, 0x61, 0x01, 0xf5, 0x67, 0x9d, 0x9d, 0x61, 0xc7, 0x77, 0x58, 0x2d,
-	0xcd, 0xf7, 0x77, 0x4c, 0xb5, 0x3d, 0x7b, 0xea, 0xcf, 0x0a, 0x0b, 0xd0, 0x07, 0x70, 0xa9, 0x6f,
-	0x69, 0xba, 0xef, 0x4e, 0xed, 0xf4, 0x86, 0xe6, 0xc3, 0x6a, 0x99, 0x39, 0x7d, 0x36, 0x76, 0x92,
-	0x96, 0xa6, 0x7b, 0x2e, 0xea, 0xd4, 0xa0, 0x99, 0x50, 0xd6, 0xfa, 0x93, 0x42, 0xf4, 0x00, 0xd6,
-	0x35, 0xdb, 0xee, 0x9f, 0x4f, 0x7a, 0x5f, 0x65, 0xde, 0x6f, 0xc7, 0x79, 0xdf, 0xa5, 0x36, 0x93,
-	0xee, 0x91, 0x36, 0x25, 0xdd, 0xcb, 0x41, 0x66, 0xa4, 0xf5, 0x87, 0x58, 0xfe, 0x3f, 0x28, 0x86,
-	0xb6, 0x3a, 0xaa, 0x42, 0x6e, 0x80, 0x5d, 0x57, 0xeb, 0x62, 0x86, 0x0c, 0x05, 0xc5, 0x1b, 0xca,
-	0x65, 0x58, 0x09, 0x6f, 0x6f, 0x79, 0xe0, 0x1b, 0xd2, 0x8d, 0x4b, 0x0d, 0x47, 0xd8, 0x71, 0xe9,
-	0x6e, 0x15, 0x86, 0x62, 0x88, 0x9e, 0x82, 0x12, 0xfb, 0x7c, 0x54, 0xef, 0x3e, 0x45, 0x8f, 0xb4,
-	0xb2, 0xc2, 0x84, 0xf7, 0x85, 0xd2, 0x26, 0x14, 0xed, 0x1d, 0xdb, 0x57, 0x49, 0x31, 0x15, 0xb0,
-	0x77, 0x6c, 0xa1, 0x20, 0xff, 0x04, 0x2a, 0x93, 0xbb, 0x1d, 0x55, 0x20, 0xf5, 0x10, 0x9f, 0x8b,
-	0xe7, 0xd1, 0x4b, 0xb4, 0x2e, 0xc2, 0x62, 0xcf, 0x28, 0x28, 0x22, 0xc6, 0x3f, 0x27, 0x7d, 0x63,
-	0x7f, 0x9b, 0xa3, 0x57, 0x21, 0x4d, 0x51, 0x53, 0x00, 0x60, 0x6d, 0x8b, 0x43, 0xea, 0x96, 0x07,
-	0xa9, 0x5b, 0x2d, 0x0f, 0x52, 0xf7, 0xf2, 0xdf, 0x7c, 0xb7, 0x99, 0xf8, 0xea, 0xaf, 0x9b, 0x92,
-	0xc2, 0x2c, 0xd0, 0x55, 0xba, 0x2b, 0x35, 0xc3, 0x54, 0x0d, 0x5d, 0x3c, 0x27, 0xc7, 0xc6, 0xfb,
-	0x3a, 0x3a, 0x80, 0x4a, 0xc7, 0x32, 0x5d, 0x6c, 0xba, 0x43, 0x57, 0xe5, 0x90, 0x2d, 0x60, 0x6f,
-	0x7a, 0xd7, 0xd4, 0x3d, 0xc5, 0x13, 0xa6, 0xa7, 0xac, 0x76, 0xa2, 0x02, 0xf4, 0x0c, 0xac, 0x6a,
-	0xb6, 0xad, 0xba, 0x44, 0x23, 0x58, 0x6d, 0x9f, 0x13, 0xec, 0x32, 0x10, 0x5b, 0x51, 0x4a, 0x9a,
-	0x6d, 0x9f, 0x52, 0xe9, 0x1e, 0x15, 0xa2, 0xa7, 0xa1, 0x4c, 0x01, 0xcb, 0xd0, 0xfa, 0x6a, 0x0f,
-	0x1b, 0xdd, 0x1e, 0x61, 0x60, 0x95, 0x52, 0x4a, 0x42, 0xda, 0x64, 0x42, 0x74, 0x04, 0xa5, 0x91,
-	0xd6, 0x37, 0x74, 0x8d, 0x58, 0x8e, 0xea, 0x62, 0x52, 0xd5, 0xd9, 0xc4, 0x9e, 0x9a, 0x9a, 0xd8,
-	0x7d, 0x4f, 0xeb, 0x14, 0x93, 0x7b, 0xb6, 0x4e, 0x9f, 0x93, 0xa6, 0x4b, 0xa0, 0xac, 0x8c, 0x42,
-	0x77, 0x64, 0xdd, 0xff, 0x20, 0x18, 0xf8, 0x21, 0x04, 0x69, 0x5d, 0x23, 0x1a, 0x5b, 0xd0, 0x15,
-	0x85, 0x5d, 0x53, 0x99, 0xad, 0x91, 0x9e, 0x58, 0x26, 0x76, 0x8d, 0x2e, 0x43, 0x56, 0x4c, 0x33,
-	0xc5, 0xa6, 0x29, 0x46, 0xf4, 0xdd, 0xd9, 0x8e, 0x35, 0xc2, 0x0c, 0xed, 0xf3, 0x0a, 0x1f, 0xc8,
-	0x5f, 0x24, 0x61, 0x6d, 0x0a, 0x26, 0xa9, 0xdf, 0x9e, 0xe6, 0xf6, 0xbc, 0x67, 0xd1, 0x6b, 0xf4,
-	0x32, 0xf5, 0xab, 0xe9, 0xd8, 0x11, 0xe9, 0xa9, 0x1a, 0x0e, 0x8c, 0xa7, 0xde, 0x26, 0xbb, 0x2f,
-	0xa2, 0x11, 0xda, 0xe8, 0x18, 0x2a, 0x7d, 0xcd, 0x25, 0x2a, 0x87, 0x1d, 0x35, 0x94, 0xaa, 0xa6,
-	0xc1, 0xf6, 0x50, 0xf3, 0x80, 0x8a, 0x7e, 0xf4, 0xc2, 0x51, 0xb9, 0x1f, 0x91, 0x22, 0x05, 0xd6,
-	0xdb, 0xe7, 0x9f, 0x69, 0x26, 0x31, 0x4c, 0xac, 0xfa, 0x4b, 0xe6, 0x56, 0xd3, 0x37, 0x53, 0xb7,
-	0x8a, 0x3b, 0x57, 0xa7, 0x9c, 0x36, 0x46, 0x86, 0x8e, 0xcd, 0x8e, 0xb7, 0xca, 0x97, 0x7c, 0x63,
-	0xff, 0x45, 0xb8, 0xb2, 0x02, 0xe5, 0x28, 0xd0, 0xa3, 0x32, 0x24, 0xc9, 0x58, 0x2c, 0x40, 0x92,
-	0x8c, 0xd1, 0xff, 0x43, 0x9a, 0x06, 0xc9, 0x82, 0x2f, 0xcf, 0xc8, 0xb2, 0xc2, 0xae, 0x75, 0x6e,
-	0x63, 0x85, 0x69, 0xca, 0xb2, 0xbf, 0x2b, 0x7c, 0xf0, 0x9f, 0xf4, 0x2a, 0x3f, 0x0b, 0xab, 0x13,
-	0xe8, 0x1e, 0x7a, 0x7f, 0x52, 0xf8, 0xfd, 0xc9, 0xab, 0x50, 0x8a, 0x40, 0xb9, 0x7c, 0x19, 0xd6,
-	0x67, 0x21, 0xb3, 0xdc, 0xf3, 0xe5, 0x11, 0x84, 0x45, 0x2f, 0x41, 0xde, 0x87, 0x66, 0xbe, 0x2b,
-	0xa7, 0xd7, 0xca, 0x53, 0x56, 0x7c, 0x55, 0xba, 0x1d, 0xe9, 0x36, 0x61, 0xdf, 0x43, 0x92, 0x4d,
-	0x3c, 0xa7, 0xd9, 0x76, 0x53, 0x73, 0x7b, 0xf2, 0x47, 0x50, 0x8d, 0x83, 0xdd, 0x89, 0x30, 0xd2,
-	0xfe, 0x67, 0x78, 0x19, 0xb2, 0x67, 0x96, 0x33, 0xd0, 0x08, 0x73, 0x56, 0x52, 0xc4, 0x88, 0x7e,
-	0x9e, 0x1c, 0x82, 0x53, 0x4c, 0xcc, 0x07, 0xb2, 0x0a, 0x57, 0x63, 0xa1, 0x97, 0x9a, 0x18, 0xa6,
-	0x8e, 0xf9, 0x7a, 0x96, 0x14, 0x3e, 0x08, 0x1c, 0xf1, 0xc9, 0xf2, 0x01, 0x7d, 0xac, 0xcb, 0x62,
-	0x65, 0xfe, 0x0b, 0x8a, 0x18, 0xc9, 0x7f, 0xcb, 0x43, 0x5e, 0xc1, 0xae, 0x4d, 0xb1, 0x01, 0xed,
-	0x41, 0x01, 0x8f, 0x3b, 0x98, 0x93, 0x22, 0x29, 0x96, 0x54, 0x70, 0xed, 0x86, 0xa7, 0x49, 0x33,
-	0xba, 0x6f, 0x86, 0xee, 0x08, 0xe2, 0x17, 0xcf, 0xe1, 0x84, 0x79, 0x98, 0xf9, 0xbd, 0xec, 0x31,
-	0xbf, 0x54, 0x6c, 0x12, 0xe7, 0x56, 0x13, 0xd4, 0xef, 0x8e, 0xa0, 0x7e, 0xe9, 0x05, 0x0f, 0x8b,
-	0x70, 0xbf, 0x7a, 0x84, 0xfb, 0x65, 0x16, 0x84, 0x19, 0x43, 0xfe, 0xea, 0x11, 0xf2, 0x97, 0x5d,
-	0xe0, 0x24, 0x86, 0xfd, 0xbd, 0xec, 0xb1, 0xbf, 0xdc, 0x82, 0xb0, 0x27, 0xe8, 0xdf, 0xdd, 0x28,
-	0xfd, 0xcb, 0xc7, 0x00, 0xad, 0x67, 0x1d, 0xcb, 0xff, 0x7e, 0x16, 0xe2, 0x7f, 0x85, 0x58, 0xf2,
-	0xc5, 0x9d, 0xcc, 0x20, 0x80, 0xf5, 0x08, 0x01, 0x84, 0x05, 0x6b, 0x10, 0xc3, 0x00, 0xdf, 0x0c,
-	0x33, 0xc0, 0x62, 0x2c, 0x89, 0x14, 0x1f, 0xcd, 0x2c, 0x0a, 0xf8, 0x9a, 0x4f, 0x01, 0x57, 0x62,
-	0x39, 0xac, 0x88, 0x61, 0x92, 0x03, 0x1e, 0x4f, 0x71, 0x40, 0xce, 0xd9, 0x9e, 0x89, 0x75, 0xb1,
-	0x80, 0x04, 0x1e, 0x4f, 0x91, 0xc0, 0xf2, 0x02, 0x87, 0x0b, 0x58, 0xe0, 0xaf, 0x67, 0xb3, 0xc0,
-	0x78, 0x9e, 0x26, 0xa6, 0xb9, 0x1c, 0x0d, 0x54, 0x63, 0x68, 0x60, 0x85, 0xb9, 0x7f, 0x2e, 0xd6,
-	0xfd, 0xc5, 0x79, 0xe0, 0xb3, 0x34, 0xcd, 0x4e, 0x00, 0x07, 0x85, 0x2a, 0xec, 0x38, 0x96, 0x23,
-	0x28, 0x16, 0x1f, 0xc8, 0xb7, 0x68, 0xe2, 0x0f, 0x40, 0x62, 0x0e, 0x67, 0x64, 0x29, 0x21, 0x04,
-	0x0c, 0xf2, 0xbf, 0xa4, 0xc0, 0x96, 0xe5, 0xca, 0x30, 0x69, 0x28, 0x08, 0xd2, 0x10, 0xa2, 0x92,
-	0xc9, 0x28, 0x95, 0xdc, 0x84, 0x22, 0x85, 0xfa, 0x09, 0x96, 0xa8, 0xd9, 0x1e, 0x4b, 0x44, 0xb7,
-	0x61, 0x8d, 0xe5, 0x72, 0x4e, 0x38, 0x05, 0xbe, 0xa7, 0x59, 0x9a, 0x5a, 0xa5, 0x37, 0xf8, 0xc7,
-	0xc9, 0x81, 0xfe, 0x05, 0xb8, 0x14, 0xd2, 0xf5, 0x53, 0x08, 0xa7, 0x58, 0x15, 0x5f, 0x7b, 0x97,
-	0xe7, 0x12, 0xf4, 0x26, 0xdc, 0x10, 0x34, 0xc1, 0xc1, 0x1c, 0x55, 0x54, 0x7a, 0x1b, 0xeb, 0xde,
-	0x63, 0x74, 0x06, 0xf2, 0x57, 0x39, 0x19, 0x70, 0x30, 0x43, 0x90, 0x43, 0xa6, 0xc1, 0x1f, 0x28,
-	0xbf, 0x1b, 0x2c, 0x71, 0xc0, 0x61, 0x11, 0xa4, 0x3b, 0x96, 0x8e, 0x45, 0x8a, 0x60, 0xd7, 0x94,
-	0xd7, 0xf6, 0xad, 0xae, 0x48, 0x04, 0xf4, 0x92, 0x6a, 0xf9, 0x38, 0x5a, 0xe0, 0x30, 0x29, 0xff,
-	0x21, 0x19, 0xf8, 0x0b, 0x68, 0xed, 0x2c, 0x06, 0x2a, 0xfd, 0x58, 0x06, 0x1a, 0x4e, 0xad, 0xa9,
-	0x48, 0x6a, 0x45, 0x1f, 0xc0, 0x7a, 0x84, 0x4d, 0xaa, 0x43, 0xc6, 0x14, 0x2f, 0x4e, 0x2a, 0xd1,
-	0x68, 0xea, 0x0e, 0xfa, 0x10, 0xae, 0x99, 0x78, 0x3c, 0xb5, 0xd6, 0xde, 0x33, 0xf0, 0x34, 0x8c,
-	0x70, 0x7e, 0x17, 0x59, 0x77, 0xe5, 0x0a, 0xf5, 0x11, 0x11, 0x71, 0xf7, 0xf2, 0x3f, 0xa5, 0xe0,
-	0xbb, 0xf4, 0xb9, 0xeb, 0x8f, 0x7b, 0x0b, 0x41, 0x8e, 0xcf, 0xb0, 0xaf, 0x4c, 0xe4, 0x78, 0x51,
-	0x99, 0x64, 0xd9, 0x9a, 0x45, 0x2b, 0x93, 0x1c, 0xcf, 0xfa, 0x6c, 0x80, 0x5e, 0x85, 0x02, 0x6b,
-	0x19, 0xa9, 0x96, 0xed, 0x8a, 0x34, 0x71, 0x2d, 0x1c, 0x16, 0xef, 0x0c, 0x6d, 0x9d, 0x50, 0x9d,
-	0x63, 0xdb, 0x55, 0xf2, 0xb6, 0xb8, 0x0a, 0xd1, 0x97, 0x42, 0x84, 0x45, 0x5f, 0x87, 0x02, 0x9d,
-	0xbd, 0x6b, 0x6b, 0x1d, 0xcc, 0x20, 0xbf, 0xa0, 0x04, 0x02, 0xf9, 0x01, 0xa0, 0xe9, 0xa4, 0x83,
-	0x9a, 0x90, 0xc5, 0x23, 0x6c, 0x12, 0xfa, 0xa5, 0x50, 0x8a, 0x7a, 0x79, 0x06, 0x45, 0xc5, 0x26,
-	0xd9, 0xab, 0xd2, 0x17, 0xf6, 0xf7, 0xef, 0x36, 0x2b, 0x5c, 0xfb, 0x79, 0x6b, 0x60, 0x10, 0x3c,
-	0xb0, 0xc9, 0xb9, 0x22, 0xec, 0xe5, 0xcf, 0x93, 0x94, 0x2f, 0x46, 0x12, 0xd2, 0xcc, 0xb5, 0xf5,
-	0xb6, 0x7d, 0x32, 0x54, 0x2b, 0x2c, 0xb7, 0xde, 0x1b, 0x00, 0x5d, 0xcd, 0x55, 0x3f, 0xd5, 0x4c,
-	0x82, 0x75, 0xb1, 0xe8, 0x21, 0x09, 0xaa, 0x41, 0x9e, 0x8e, 0x86, 0x2e, 0xd6, 0x45, 0x19, 0xe4,
-	0x8f, 0x43, 0x71, 0xe6, 0x1e, 0x2f, 0xce, 0xe8, 0x2a, 0xe7, 0x27, 0x57, 0xf9, 0x37, 0xa1, 0x9d,
-	0x19, 0x50, 0xeb, 0xff, 0xbd, 0x75, 0xf8, 0x07, 0xab, 0xbb, 0xa3, 0xcc, 0x00, 0xfd, 0x02, 0xae,
-	0x4c, 0x00, 0x94, 0xd8, 0xd6, 0xae, 0xa0, 0xa4, 0x8b, 0x71, 0xea, 0x89, 0x28, 0x4e, 0xf1, 0x5d,
-	0xed, 0x86, 0xc2, 0x4a, 0x3d, 0x66, 0x58, 0x0b, 0xf0, 0x47, 0x7f, 0x3c, 0xfc, 0x89, 0xc5, 0x4e,
-	0x7c, 0x31, 0xec, 0x94, 0x66, 0x61, 0xa7, 0xbc, 0x4f, 0x2b, 0xc5, 0x30, 0x9d, 0x9a, 0xf9, 0x91,
-	0x3d, 0x05, 0x25, 0x07, 0x13, 0x1a, 0x58, 0xa4, 0x16, 0x5f, 0xe1, 0x42, 0x91, 0xb0, 0x4e, 0xe0,
-	0x89, 0x99, 0xb4, 0x0a, 0xbd, 0x02, 0x85, 0x80, 0x91, 0x49, 0x31, 0x65, 0xad, 0x5f, 0xaa, 0x05,
-	0xba, 0xf2, 0x9f, 0xa4, 0xc0, 0x65, 0xb4, 0xf8, 0x6b, 0x40, 0xd6, 0xc1, 0xee, 0xb0, 0xcf, 0xcb,
-	0xb1, 0xf2, 0xce, 0x0b, 0xcb, 0x11, 0x32, 0x2a, 0x1d, 0xf6, 0x89, 0x22, 0x8c, 0xe5, 0x07, 0x90,
-	0xe5, 0x12, 0x54, 0x84, 0xdc, 0xbd, 0xa3, 0x83, 0xa3, 0xe3, 0xf7, 0x8f, 0x2a, 0x09, 0x04, 0x90,
-	0xdd, 0xad, 0xd7, 0x1b, 0x27, 0xad, 0x8a, 0x84, 0x0a, 0x90, 0xd9, 0xdd, 0x3b, 0x56, 0x5a, 0x95,
-	0x24, 0x15, 0x2b, 0x8d, 0x77, 0x1a, 0xf5, 0x56, 0x25, 0x85, 0xd6, 0xa0, 0xc4, 0xaf, 0xd5, 0xbb,
-	0xc7, 0xca, 0xbb, 0xbb, 0xad, 0x4a, 0x3a, 0x24, 0x3a, 0x6d, 0x1c, 0xbd, 0xd5, 0x50, 0x2a, 0x19,
-	0xf9, 0x45, 0x5a, 0xef, 0xc5, 0x50, 0xb8, 0xa0, 0xb2, 0x93, 0x42, 0x95, 0x9d, 0xfc, 0xfb, 0x24,
-	0xd4, 0xe2, 0x79, 0x19, 0x7a, 0x67, 0x22, 0xf0, 0x9d, 0x0b, 0x90, 0xba, 0x89, 0xe8, 0xd1, 0xd3,
-	0x50, 0x76, 0xf0, 0x19, 0x26, 0x9d, 0x1e, 0xe7, 0x89, 0x74, 0x4b, 0xa5, 0x6e, 0x95, 0x94, 0x92,
-	0x90, 0x32, 0x23, 0x97, 0xab, 0x7d, 0x8c, 0x3b, 0x44, 0xe5, 0x45, 0x26, 0xdf, 0x30, 0x05, 0xaa,
-	0x46, 0xa5, 0xa7, 0x5c, 0x28, 0x7f, 0x74, 0xa1, 0xb5, 0x2c, 0x40, 0x46, 0x69, 0xb4, 0x94, 0x5f,
-	0x56, 0x52, 0x08, 0x41, 0x99, 0x5d, 0xaa, 0xa7, 0x47, 0xbb, 0x27, 0xa7, 0xcd, 0x63, 0xba, 0x96,
-	0x97, 0x60, 0xd5, 0x5b, 0x4b, 0x4f, 0x98, 0x91, 0xff, 0x23, 0xc1, 0xea, 0xc4, 0xe6, 0x46, 0x3b,
-	0x90, 0xe1, 0xb5, 0x46, 0xdc, 0x9f, 0x09, 0x06, 0x23, 0x02, 0x09, 0xb8, 0x2a, 0x7a, 0x1d, 0xf2,
-	0x58, 0x34, 0x51, 0x66, 0x81, 0x08, 0xdf, 0x9c, 0x5e, 0x9b, 0x45, 0x98, 0xfa, 0x16, 0xe8, 0x0d,
-	0x28, 0xf8, 0xfb, 0x48, 0x14, 0xb8, 0x4f, 0x4e, 0x9b, 0xfb, 0x9b, 0x50, 0xd8, 0x07, 0x36, 0xe8,
-	0xb5, 0x80, 0xb0, 0xa6, 0xe3, 0xa0, 0x41, 0x30, 0x54, 0x61, 0xec, 0xe9, 0xcb, 0x75, 0x28, 0x86,
-	0xe2, 0x41, 0xd7, 0xa0, 0x30, 0xd0, 0xc6, 0xa2, 0xd9, 0xc7, 0xdb, 0x2b, 0xf9, 0x81, 0x36, 0xe6,
-	0x7d, 0xbe, 0x2b, 0x90, 0xa3, 0x37, 0xbb, 0x1a, 0x47, 0xca, 0x94, 0x92, 0x1d, 0x68, 0xe3, 0xb7,
-	0x35, 0x57, 0xfe, 0x9d, 0x04, 0xe5, 0x68, 0x67, 0x8a, 0x7e, 0x8a, 0x8e, 0x35, 0x34, 0x75, 0xe6,
-	0x24, 0xa3, 0xf0, 0x01, 0x4d, 0x1a, 0x9f, 0x0c, 0x2d, 0x67, 0x38, 0x68, 0x06, 0x8c, 0x2e, 0x24,
-	0x41, 0xcf, 0x40, 0x99, 0x2d, 0xe8, 0xa9, 0xd1, 0x35, 0x35, 0x32, 0x74, 0x78, 0x2f, 0x6e, 0x45,
-	0x99, 0x90, 0x52, 0x3d, 0xd6, 0x95, 0x0c, 0xf4, 0x38, 0x6b, 0x9e, 0x90, 0xca, 0x9f, 0x41, 0x86,
-	0x41, 0x2e, 0x85, 0x20, 0xd6, 0x9c, 0x12, 0x34, 0x9f, 0x5e, 0xa3, 0x0f, 0x01, 0x34, 0x42, 0x1c,
-	0xa3, 0x3d, 0xe4, 0xd8, 0x9f, 0x9a, 0x59, 0x1a, 0x32, 0xfb, 0x5d, 0x4f, 0x6f, 0xef, 0xba, 0xc0,
-	0xee, 0xf5, 0xc0, 0x34, 0x84, 0xdf, 0x21, 0x87, 0xf2, 0x11, 0x94, 0xa3, 0xb6, 0xe1, 0x76, 0xf1,
-	0xca, 0x8c, 0x76, 0xb1, 0x4f, 0xca, 0x7c, 0x4a, 0x97, 0xe2, 0x8d, 0x48, 0x36, 0x90, 0xbf, 0x94,
-	0x20, 0xdf, 0x1a, 0x8b, 0x0d, 0x11, 0xd3, 0x03, 0x0b, 0x4c, 0x93, 0xe1, 0x8e, 0x0f, 0x6f, 0xaa,
-	0xa5, 0xfc, 0x56, 0xdd, 0x9b, 0xfe, 0x96, 0x4f, 0x2f, 0x5b, 0x93, 0x7b, 0x3d, 0x4b, 0x01, 0x73,
-	0xbb, 0x50, 0xf0, 0xbf, 0x47, 0xd6, 0x38, 0xb5, 0x3e, 0x15, 0x9d, 0xa3, 0x94, 0xc2, 0x07, 0x68,
-	0x03, 0x8a, 0xb6, 0x63, 0xa9, 0x64, 0xcc, 0xe9, 0x3b, 0x7f, 0x91, 0x94, 0x6d, 0xb6, 0xc6, 0xac,
-	0x37, 0xf6, 0x85, 0x04, 0xab, 0xbe, 0x0f, 0x91, 0x98, 0x7e, 0x0a, 0x39, 0x7b, 0xd8, 0x56, 0xbd,
-	0x55, 0x9a, 0xd8, 0x7d, 0x1e, 0x19, 0x1d, 0xb6, 0xfb, 0x46, 0xe7, 0x00, 0x9f, 0x7b, 0x73, 0xb2,
-	0x87, 0xed, 0x03, 0xbe, 0x98, 0x7c, 0x1a, 0xc9, 0x39, 0xd3, 0x48, 0x4d, 0x4e, 0xe3, 0x7b, 0x09,
-	0xd0, 0x74, 0x7e, 0x43, 0xa7, 0xb0, 0x16, 0xa4, 0x48, 0x8f, 0x1f, 0xf0, 0x4c, 0x73, 0x33, 0x3e,
-	0x3f, 0x46, 0x0a, 0x8b, 0xca, 0x28, 0x2a, 0x76, 0x51, 0x0b, 0xd6, 0x49, 0xcf, 0xc1, 0x6e, 0xcf,
-	0xea, 0xeb, 0xaa, 0xcd, 0xc2, 0x60, 0xb1, 0x26, 0x97, 0x8e, 0x15, 0xf9, 0xf6, 0xfe, 0x1d, 0x5a,
-	0x94, 0xf2, 0x2d, 0x14, 0x8e, 0x30, 0xb4, 0xab, 0x64, 0x1b, 0xaa, 0xad, 0x29, 0x33, 0x11, 0x67,
-	0xdc, 0x94, 0xa4, 0xc7, 0x99, 0x92, 0x7c, 0x07, 0x2a, 0xef, 0xf9, 0xcf, 0x17, 0x4f, 0x9a, 0x98,
-	0xa6, 0x34, 0x35, 0xcd, 0x11, 0xe4, 0xef, 0x5b, 0x84, 0x97, 0xe5, 0x3f, 0x0f, 0x43, 0xa2, 0xf7,
-	0x87, 0x24, 0x76, 0xd9, 0xc5, 0x4c, 0x42, 0x88, 0x78, 0x1b, 0xd6, 0x5c, 0xa3, 0x6b, 0x62, 0x5d,
-	0x0d, 0x4a, 0x6c, 0xb6, 0xcc, 0x79, 0x65, 0x95, 0xdf, 0x38, 0xf4, 0xea, 0x6b, 0xf9, 0xdf, 0x12,
-	0xe4, 0x3d, 0x6c, 0x46, 0x2f, 0x86, 0x80, 0xa2, 0x3c, 0xa3, 0x61, 0xe8, 0x29, 0x06, 0x6d, 0xec,
-	0xe8, 0x5c, 0x93, 0x17, 0x9f, 0x6b, 0xdc, 0xff, 0x08, 0xef, 0x07, 0x51, 0xfa, 0xc2, 0x3f, 0x88,
-	0x9e, 0x07, 0x44, 0x2c, 0xa2, 0xf5, 0xd5, 0x91, 0x45, 0x0c, 0xb3, 0xab, 0xf2, 0x6d, 0xc1, 0x39,
-	0x7a, 0x85, 0xdd, 0xb9, 0xcf, 0x6e, 0x9c, 0x50, 0xb9, 0xfc, 0x47, 0x09, 0xf2, 0x3e, 0x0d, 0xba,
-	0x68, 0x57, 0xfa, 0x32, 0x64, 0x45, 0xa6, 0xe7, 0x6d, 0x69, 0x31, 0xf2, 0x7f, 0x90, 0xa4, 0x43,
-	0x3f, 0x48, 0x6a, 0x90, 0x1f, 0x60, 0xa2, 0x31, 0x2e, 0xc8, 0xf1, 0xda, 0x1f, 0xa3, 0x57, 0xa0,
-	0xba, 0xa0, 0xb1, 0xf1, 0x44, 0x67, 0x56, 0x53, 0xe3, 0xf6, 0x6b, 0x50, 0x0c, 0xfd, 0x59, 0xa0,
-	0x18, 0x7b, 0xd4, 0x78, 0xbf, 0x92, 0xa8, 0xe5, 0xbe, 0xfc, 0xfa, 0x66, 0xea, 0x08, 0x7f, 0x8a,
-	0xaa, 0x90, 0x53, 0x1a, 0xf5, 0x66, 0xa3, 0x7e, 0x50, 0x91, 0x6a, 0xc5, 0x2f, 0xbf, 0xbe, 0x99,
-	0x53, 0x30, 0x6b, 0x50, 0xde, 0x6e, 0xc2, 0x4a, 0xf8, 0x75, 0x46, 0x59, 0x06, 0x82, 0xf2, 0x5b,
-	0xf7, 0x4e, 0x0e, 0xf7, 0xeb, 0xbb, 0xad, 0x86, 0x7a, 0xff, 0xb8, 0xd5, 0xa8, 0x48, 0xe8, 0x0a,
-	0x5c, 0x3a, 0xdc, 0x7f, 0xbb, 0xd9, 0x52, 0xeb, 0x87, 0xfb, 0x8d, 0xa3, 0x96, 0xba, 0xdb, 0x6a,
-	0xed, 0xd6, 0x0f, 0x2a, 0xc9, 0x9d, 0xcf, 0x01, 0x56, 0x77, 0xf7, 0xea, 0xfb, 0x94, 0x21, 0x19,
-	0x1d, 0x4d, 0x34, 0x80, 0xd3, 0xac, 0x3b, 0x35, 0xf7, 0x68, 0x43, 0x6d, 0x7e, 0xff, 0x1b, 0xdd,
-	0x85, 0x0c, 0x6b, 0x5c, 0xa1, 0xf9, 0x67, 0x1d, 0x6a, 0x0b, 0x1a, 0xe2, 0x74, 0x32, 0x6c, 0x5f,
-	0xcd, 0x3d, 0xfc, 0x50, 0x9b, 0xdf, 0x1f, 0x47, 0x0a, 0x14, 0x82, 0xbe, 0xd1, 0xe2, 0xc3, 0x10,
-	0xb5, 0x25, 0x7a, 0xe6, 0xd4, 0x67, 0x50, 0xa1, 0x2e, 0x3e, 0x1c, 0x50, 0x5b, 0x22, 0x55, 0xa1,
-	0x43, 0xc8, 0x79, 0xb5, 0xff, 0xa2, 0xe3, 0x0a, 0xb5, 0x85, 0xfd, 0x6c, 0xfa, 0x0a, 0x78, 0x8f,
-	0x66, 0xfe, 0xd9, 0x8b, 0xda, 0x82, 0xe6, 0x3c, 0xda, 0x87, 0xac, 0xa8, 0x87, 0x16, 0x1c, 0x41,
-	0xa8, 0x2d, 0xea, 0x4f, 0xd3, 0x45, 0x0b, 0x1a, 0x6e, 0x8b, 0x4f, 0x94, 0xd4, 0x96, 0xf8, 0xef,
-	0x80, 0xee, 0x01, 0x84, 0x3a, 0x32, 0x4b, 0x1c, 0x15, 0xa9, 0x2d, 0xf3, 0x3f, 0x01, 0x1d, 0x43,
-	0xde, 0xaf, 0xbc, 0x17, 0x1e, 0xdc, 0xa8, 0x2d, 0x6e, 0xec, 0xa3, 0x07, 0x50, 0x8a, 0xd6, 0x82,
-	0xcb, 0x1d, 0xc7, 0xa8, 0x2d, 0xd9, 0xb1, 0xa7, 0xfe, 0xa3, 0x85, 0xe1, 0x72, 0xc7, 0x33, 0x6a,
-	0x4b, 0x36, 0xf0, 0xd1, 0xc7, 0xb0, 0x36, 0x5d, 0xb8, 0x2d, 0x7f, 0x5a, 0xa3, 0x76, 0x81, 0x96,
-	0x3e, 0x1a, 0x00, 0x9a, 0x51, 0xf0, 0x5d, 0xe0, 0xf0, 0x46, 0xed, 0x22, 0x1d, 0xfe, 0xbd, 0xc6,
-	0x37, 0x3f, 0x6c, 0x48, 0xdf, 0xfe, 0xb0, 0x21, 0x7d, 0xff, 0xc3, 0x86, 0xf4, 0xd5, 0xa3, 0x8d,
-	0xc4, 0xb7, 0x8f, 0x36, 0x12, 0x7f, 0x79, 0xb4, 0x91, 0xf8, 0xd5, 0x73, 0x5d, 0x83, 0xf4, 0x86,
-	0xed, 0xad, 0x8e, 0x35, 0xd8, 0x0e, 0x9f, 0x2c, 0x9b, 0x75, 0xda, 0xad, 0x9d, 0x65, 0x19, 0xee,
-	0xce, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xde, 0xe5, 0x9e, 0x0f, 0x0d, 0x27, 0x00, 0x00,
+	// 3620 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5b, 0x4d, 0x70, 0x23, 0xd5,
+	0xf1, 0xd7, 0xa7, 0x25, 0xb5, 0x3e, 0xfd, 0xd6, 0xeb, 0xd5, 0x6a, 0x77, 0x6d, 0xff, 0x67, 0xff,
+	0xc0, 0xb2, 0x80, 0xfd, 0xc7, 0xfb, 0x5f, 0x58, 0x20, 0x09, 0xd8, 0x42, 0x8b, 0xcc, 0x1a, 0xdb,
+	0x8c, 0xb5, 0x4b, 0x12, 0xc2, 0x0e, 0x63, 0xe9, 0x59, 0x1a, 0x56, 0xd2, 0x0c, 0x33, 0x23, 0x23,
+	0x71, 0x4b, 0x15, 0xb9, 0x50, 0x39, 0x70, 0xcc, 0x85, 0x43, 0xce, 0x39, 0xa7, 0x72, 0x48, 0x55,
+	0xce, 0x54, 0xaa, 0x52, 0xc5, 0x31, 0x27, 0x48, 0x41, 0xe5, 0xc2, 0x91, 0x4b, 0x4e, 0x54, 0xa5,
+	0xde, 0xd7, 0x7c, 0x69, 0x46, 0x92, 0xd9, 0xdc, 0x72, 0xd3, 0xeb, 0xe9, 0xee, 0x79, 0xfd, 0xe6,
+	0xbd, 0xee, 0xfe, 0x75, 0x3f, 0xc1, 0x15, 0x1b, 0x0f, 0x3b, 0xd8, 0x1c, 0x68, 0x43, 0x7b, 0x4b,
+	0x3d, 0x69, 0x6b, 0x5b, 0xf6, 0xc4, 0xc0, 0xd6, 0xa6, 0x61, 0xea, 0xb6, 0x8e, 0xca, 0xee, 0xc3,
+	0x4d, 0xf2, 0xb0, 0x76, 0xcd, 0xc3, 0xdd, 0x36, 0x27, 0x86, 0xad, 0x6f, 0x19, 0xa6, 0xae, 0x9f,
+	0x32, 0xfe, 0xda, 0x55, 0xcf, 0x63, 0xaa, 0xc7, 0xab, 0xad, 0x76, 0x75, 0x5a, 0xf8, 0x11, 0x9e,
+	0x88, 0xa7, 0xd7, 0xa6, 0x64, 0x0d, 0xd5, 0x54, 0x07, 0xe2, 0xf1, 0x7a, 0x57, 0xd7, 0xbb, 0x7d,
+	0xbc, 0x45, 0x47, 0x27, 0xa3, 0xd3, 0x2d, 0x5b, 0x1b, 0x60, 0xcb, 0x56, 0x07, 0x06, 0x67, 0x58,
+	0xe9, 0xea, 0x5d, 0x9d, 0xfe, 0xdc, 0x22, 0xbf, 0x18, 0x55, 0xfa, 0x1e, 0x20, 0x23, 0xe3, 0x0f,
+	0x47, 0xd8, 0xb2, 0xd1, 0x36, 0xa4, 0x70, 0xbb, 0xa7, 0x57, 0xe3, 0x1b, 0xf1, 0x1b, 0xf9, 0xed,
+	0xab, 0x9b, 0x01, 0xe3, 0x36, 0x39, 0x5f, 0xa3, 0xdd, 0xd3, 0x9b, 0x31, 0x99, 0xf2, 0xa2, 0xdb,
+	0x90, 0x3e, 0xed, 0x8f, 0xac, 0x5e, 0x35, 0x41, 0x85, 0xae, 0x45, 0x09, 0xdd, 0x25, 0x4c, 0xcd,
+	0x98, 0xcc, 0xb8, 0xc9, 0xab, 0xb4, 0xe1, 0xa9, 0x5e, 0x4d, 0xce, 0x7e, 0xd5, 0xde, 0xf0, 0x94,
+	0xbe, 0x8a, 0xf0, 0xa2, 0x5d, 0x00, 0x0b, 0xdb, 0x8a, 0x6e, 0xd8, 0x9a, 0x3e, 0xac, 0xa6, 0xa8,
+	0xe4, 0xff, 0x44, 0x49, 0x1e, 0x63, 0xfb, 0x90, 0x32, 0x36, 0x63, 0x72, 0xce, 0x12, 0x03, 0xa2,
+	0x43, 0x1b, 0x6a, 0xb6, 0xd2, 0xee, 0xa9, 0xda, 0xb0, 0x9a, 0x9e, 0xad, 0x63, 0x6f, 0xa8, 0xd9,
+	0x75, 0xc2, 0x48, 0x74, 0x68, 0x62, 0x40, 0x4c, 0xfe, 0x70, 0x84, 0xcd, 0x49, 0x75, 0x69, 0xb6,
+	0xc9, 0x6f, 0x13, 0x26, 0x62, 0x32, 0xe5, 0x46, 0x0d, 0xc8, 0x9f, 0xe0, 0xae, 0x36, 0x54, 0x4e,
+	0xfa, 0x7a, 0xfb, 0x51, 0x35, 0x43, 0x85, 0xa5, 0x28, 0xe1, 0x5d, 0xc2, 0xba, 0x4b, 0x38, 0x9b,
+	0x31, 0x19, 0x4e, 0x9c, 0x11, 0xfa, 0x09, 0x64, 0xdb, 0x3d, 0xdc, 0x7e, 0xa4, 0xd8, 0xe3, 0x6a,
+	0x96, 0xea, 0x58, 0x8f, 0xd2, 0x51, 0x27, 0x7c, 0xad, 0x71, 0x33, 0x26, 0x67, 0xda, 0xec, 0x27,
+	0xb1, 0xbf, 0x83, 0xfb, 0xda, 0x19, 0x36, 0x89, 0x7c, 0x6e, 0xb6, 0xfd, 0xaf, 0x33, 0x4e, 0xaa,
+	0x21, 0xd7, 0x11, 0x03, 0xf4, 0x2a, 0xe4, 0xf0, 0xb0, 0xc3, 0xcd, 0x00, 0xaa, 0x62, 0x23, 0x72,
+	0xaf, 0x0c, 0x3b, 0xc2, 0x88, 0x2c, 0xe6, 0xbf, 0xd1, 0x1d, 0x58, 0x6a, 0xeb, 0x83, 0x81, 0x66,
+	0x57, 0xf3, 0x54, 0x7a, 0x2d, 0xd2, 0x00, 0xca, 0xd5, 0x8c, 0xc9, 0x9c, 0x1f, 0x1d, 0x40, 0xa9,
+	0xaf, 0x59, 0xb6, 0x62, 0x0d, 0x55, 0xc3, 0xea, 0xe9, 0xb6, 0x55, 0x2d, 0x50, 0x0d, 0x4f, 0x44,
+	0x69, 0xd8, 0xd7, 0x2c, 0xfb, 0x58, 0x30, 0x37, 0x63, 0x72, 0xb1, 0xef, 0x25, 0x10, 0x7d, 0xfa,
+	0xe9, 0x29, 0x36, 0x1d, 0x85, 0xd5, 0xe2, 0x6c, 0x7d, 0x87, 0x84, 0x5b, 0xc8, 0x13, 0x7d, 0xba,
+	0x97, 0x80, 0xde, 0x85, 0x0b, 0x7d, 0x5d, 0xed, 0x38, 0xea, 0x94, 0x76, 0x6f, 0x34, 0x7c, 0x54,
+	0x2d, 0x51, 0xa5, 0x4f, 0x47, 0x4e, 0x52, 0x57, 0x3b, 0x42, 0x45, 0x9d, 0x08, 0x34, 0x63, 0xf2,
+	0x72, 0x3f, 0x48, 0x44, 0x0f, 0x61, 0x45, 0x35, 0x8c, 0xfe, 0x24, 0xa8, 0xbd, 0x4c, 0xb5, 0xdf,
+	0x8c, 0xd2, 0xbe, 0x43, 0x64, 0x82, 0xea, 0x91, 0x3a, 0x45, 0x45, 0x2d, 0xa8, 0x18, 0xa6, 0xde,
+	0xc6, 0x96, 0xa5, 0x18, 0xa6, 0x6e, 0xe8, 0x96, 0xda, 0xaf, 0x56, 0xa8, 0xee, 0xa7, 0xa2, 0x74,
+	0x1f, 0x31, 0xfe, 0x23, 0xce, 0xde, 0x8c, 0xc9, 0x65, 0xc3, 0x4f, 0x22, 0xdb, 0x1e, 0x8f, 0x89,
+	0xb8, 0x72, 0xa6, 0xdb, 0xb8, 0xba, 0x3c, 0x7b, 0xdb, 0x37, 0x28, 0xeb, 0x03, 0xdd, 0xc6, 0x64,
+	0xdb, 0x63, 0x67, 0xc4, 0x26, 0x87, 0x0d, 0xd5, 0xc4, 0xee, 0xe4, 0xd0, 0xbc, 0xc9, 0x51, 0x7e,
+	0xff, 0xe4, 0x7c, 0x24, 0xd4, 0x87, 0x9a, 0xeb, 0x0e, 0x14, 0xd5, 0x30, 0x14, 0xcb, 0x56, 0x6d,
+	0xcc, 0x17, 0xf6, 0x02, 0xd5, 0xbf, 0x39, 0xd7, 0x3d, 0xec, 0x18, 0xc6, 0x31, 0x11, 0x13, 0x8b,
+	0xbb, 0xaa, 0x85, 0x3e, 0xd9, 0xcd, 0x40, 0xfa, 0x4c, 0xed, 0x8f, 0xb0, 0xf4, 0x14, 0xe4, 0x3d,
+	0xbe, 0x14, 0x55, 0x21, 0x33, 0xc0, 0x96, 0xa5, 0x76, 0x31, 0x75, 0xbd, 0x39, 0x59, 0x0c, 0xa5,
+	0x12, 0x14, 0xbc, 0xfe, 0x53, 0x1a, 0x40, 0xde, 0xe3, 0x19, 0x89, 0xe0, 0x19, 0x36, 0x2d, 0xe2,
+	0x0e, 0xb9, 0x20, 0x1f, 0xa2, 0xeb, 0x50, 0xa4, 0xe7, 0x53, 0x11, 0xcf, 0x89, 0x7b, 0x4e, 0xc9,
+	0x05, 0x4a, 0x7c, 0xc0, 0x99, 0xd6, 0x21, 0x6f, 0x6c, 0x1b, 0x0e, 0x4b, 0x92, 0xb2, 0x80, 0xb1,
+	0x6d, 0x70, 0x06, 0xe9, 0x65, 0xa8, 0x04, 0xdd, 0x29, 0xaa, 0x40, 0xf2, 0x11, 0x9e, 0xf0, 0xf7,
+	0x91, 0x9f, 0x68, 0x85, 0x9b, 0x45, 0xdf, 0x91, 0x93, 0xb9, 0x8d, 0x3f, 0x24, 0xa0, 0x12, 0x5c,
+	0x28, 0x74, 0x07, 0x52, 0x24, 0x2c, 0xf1, 0x08, 0x53, 0xdb, 0x64, 0x31, 0x6b, 0x53, 0xc4, 0xac,
+	0xcd, 0x96, 0x88, 0x59, 0xbb, 0xd9, 0x2f, 0xbe, 0x5a, 0x8f, 0x7d, 0xf6, 0xf5, 0x7a, 0x5c, 0xa6,
+	0x12, 0xe8, 0x32, 0x64, 0xd9, 0x47, 0xd2, 0x3a, 0xfc, 0x3d, 0x19, 0x3a, 0xde, 0xeb, 0xa0, 0x7b,
+	0x50, 0x69, 0xeb, 0x43, 0x0b, 0x0f, 0xad, 0x91, 0xa5, 0xb0, 0x98, 0x58, 0x4d, 0x46, 0xb8, 0xa5,
+	0xba, 0x60, 0x3c, 0xa2, 0x7c, 0x72, 0xb9, 0xed, 0x27, 0xa0, 0x27, 0xa1, 0xec, 0x6e, 0x83, 0x93,
+	0x89, 0x8d, 0x2d, 0x1a, 0x25, 0x0a, 0x72, 0x51, 0xe5, 0xdf, 0x72, 0x97, 0x10, 0xd1, 0x13, 0x50,
+	0x22, 0x5f, 0x59, 0x53, 0xfb, 0x4a, 0x0f, 0x6b, 0xdd, 0x9e, 0x4d, 0xa3, 0x41, 0x52, 0x2e, 0x72,
+	0x6a, 0x93, 0x12, 0xd1, 0x0d, 0xa8, 0x04, 0x76, 0x95, 0x45, 0x3d, 0x7f, 0x51, 0x2e, 0xa9, 0xde,
+	0xbd, 0x41, 0x5c, 0x51, 0xf1, 0x4c, 0xed, 0x6b, 0x1d, 0xd5, 0xd6, 0x4d, 0xc5, 0xc2, 0x76, 0xb5,
+	0x43, 0x4d, 0xb8, 0x3e, 0x65, 0xc2, 0x03, 0xc1, 0x75, 0x8c, 0xed, 0xfb, 0x46, 0x87, 0xcc, 0x28,
+	0x45, 0x16, 0x4b, 0x2e, 0x9c, 0x79, 0x9e, 0x48, 0x1d, 0x28, 0x78, 0xe3, 0x10, 0x42, 0x90, 0xea,
+	0xa8, 0xb6, 0x4a, 0x97, 0xbe, 0x20, 0xd3, 0xdf, 0x84, 0x66, 0xa8, 0x76, 0x8f, 0x2f, 0x28, 0xfd,
+	0x8d, 0x56, 0x61, 0x89, 0x1b, 0x94, 0xa4, 0x06, 0xf1, 0x11, 0xf9, 0xca, 0x86, 0xa9, 0x9f, 0x61,
+	0x1a, 0x78, 0xb3, 0x32, 0x1b, 0x48, 0x9f, 0x24, 0x60, 0x79, 0x2a, 0x62, 0x11, 0xbd, 0x3d, 0xd5,
+	0xea, 0x89, 0x77, 0x91, 0xdf, 0xe8, 0x05, 0xa2, 0x57, 0xed, 0x60, 0x93, 0x67, 0x0a, 0x55, 0xaf,
+	0x61, 0x2c, 0x0b, 0x6a, 0xd2, 0xe7, 0xdc, 0x1a, 0xce, 0x8d, 0x0e, 0xa1, 0xd2, 0x57, 0x2d, 0x5b,
+	0x61, 0x11, 0x40, 0xf1, 0x64, 0x0d, 0xd3, 0x71, 0x6f, 0x5f, 0x15, 0x31, 0x83, 0x1c, 0x0f, 0xae,
+	0xa8, 0xd4, 0xf7, 0x51, 0x91, 0x0c, 0x2b, 0x27, 0x93, 0x8f, 0xd5, 0xa1, 0xad, 0x0d, 0xb1, 0xe2,
+	0x2c, 0x99, 0x55, 0x4d, 0x6d, 0x24, 0x6f, 0xe4, 0xb7, 0x2f, 0x4f, 0x29, 0x6d, 0x9c, 0x69, 0x1d,
+	0x3c, 0x6c, 0x8b, 0x55, 0xbe, 0xe0, 0x08, 0x3b, 0x1f, 0xc2, 0x92, 0xfe, 0x19, 0x87, 0x92, 0x3f,
+	0xe8, 0xa2, 0x12, 0x24, 0xec, 0x31, 0x5f, 0x81, 0x84, 0x3d, 0x46, 0xff, 0x07, 0x29, 0x62, 0x25,
+	0xb5, 0xbe, 0x14, 0x92, 0xf1, 0x70, 0xb9, 0xd6, 0xc4, 0xc0, 0x32, 0xe5, 0x8c, 0xfc, 0x12, 0xaf,
+	0x02, 0xb0, 0xb3, 0x4d, 0x8f, 0x52, 0x6a, 0xee, 0x51, 0x4a, 0xd1, 0x63, 0x94, 0xa3, 0x32, 0x84,
+	0x8a, 0xee, 0xc0, 0xe5, 0x21, 0x1e, 0xdb, 0xdc, 0x91, 0x62, 0x93, 0xfc, 0x50, 0xec, 0xb1, 0x42,
+	0xbf, 0x19, 0xdb, 0xed, 0x17, 0x09, 0xc3, 0x11, 0x7f, 0x7e, 0x64, 0xea, 0xad, 0x71, 0x53, 0xb5,
+	0x7a, 0x92, 0x04, 0x95, 0x60, 0x6e, 0x10, 0x34, 0x54, 0xfa, 0x24, 0x0e, 0xe5, 0x40, 0xf4, 0xf7,
+	0x98, 0x12, 0xf7, 0x99, 0x72, 0x1d, 0x8a, 0xb6, 0xfa, 0x08, 0xbb, 0xe1, 0x37, 0x41, 0x37, 0x57,
+	0x81, 0x10, 0x9d, 0xa0, 0xfa, 0xff, 0xb0, 0xea, 0x44, 0x3c, 0x13, 0xdb, 0xc4, 0x09, 0x78, 0xd6,
+	0x25, 0x25, 0xaf, 0x88, 0xa7, 0x32, 0x7d, 0xc8, 0x4e, 0x9e, 0x54, 0x86, 0xa2, 0x2f, 0x8b, 0x90,
+	0x56, 0x61, 0x25, 0x2c, 0x29, 0x90, 0x7a, 0xb0, 0x12, 0x16, 0xdc, 0xd1, 0x6d, 0xc8, 0x3a, 0xd3,
+	0x62, 0xfe, 0x6a, 0x7a, 0x6f, 0x08, 0x66, 0xd9, 0x61, 0x25, 0x8e, 0x8a, 0x9c, 0x78, 0xba, 0x96,
+	0x09, 0xba, 0x28, 0x19, 0xd5, 0x30, 0xe8, 0xea, 0xbd, 0x0f, 0xd5, 0xa8, 0x88, 0x1f, 0x58, 0xa1,
+	0x94, 0xb3, 0x42, 0xab, 0xb0, 0x74, 0xaa, 0x9b, 0x03, 0x95, 0x2d, 0x4d, 0x51, 0xe6, 0x23, 0x72,
+	0x1c, 0x59, 0x90, 0x4a, 0x52, 0x32, 0x1b, 0x48, 0x0a, 0x5c, 0x8e, 0x8c, 0xfa, 0x44, 0x44, 0x1b,
+	0x76, 0x30, 0xfb, 0x56, 0x45, 0x99, 0x0d, 0x5c, 0x45, 0x6c, 0xb2, 0x6c, 0x40, 0x5e, 0x6b, 0x51,
+	0x5b, 0xa9, 0xfe, 0x9c, 0xcc, 0x47, 0xd2, 0x6f, 0xe3, 0xb0, 0x1a, 0x1e, 0xfb, 0xff, 0xa3, 0x87,
+	0xbe, 0x02, 0x49, 0x7b, 0x4c, 0xbc, 0x78, 0xf2, 0x46, 0x41, 0x26, 0x3f, 0xc9, 0x34, 0x4d, 0x7d,
+	0x34, 0xec, 0xd0, 0xfd, 0x9e, 0x96, 0xd9, 0x40, 0xba, 0x0f, 0xcb, 0x53, 0x89, 0x43, 0xe8, 0x44,
+	0xdc, 0xe5, 0x4d, 0x04, 0xbd, 0x1a, 0x53, 0x9b, 0xf4, 0xaa, 0xfd, 0x83, 0xd7, 0x4a, 0x7f, 0xc6,
+	0x10, 0xb5, 0x93, 0x1d, 0x45, 0x09, 0x8f, 0x22, 0xb4, 0x05, 0x2b, 0xa1, 0x87, 0x2c, 0x49, 0xa7,
+	0xb6, 0x6c, 0x04, 0x0f, 0x98, 0x30, 0x3c, 0xe5, 0x1a, 0xbe, 0x01, 0x85, 0x81, 0x3a, 0x26, 0x92,
+	0x6e, 0x34, 0x4a, 0xca, 0x30, 0x50, 0xc7, 0xad, 0x31, 0x0d, 0x45, 0xd2, 0x3d, 0xb8, 0x36, 0x33,
+	0x23, 0x39, 0xcf, 0x87, 0x97, 0xfe, 0x9a, 0x87, 0xac, 0x8c, 0x2d, 0x83, 0x84, 0x45, 0xb4, 0x0b,
+	0x39, 0x3c, 0x6e, 0x63, 0x06, 0xb8, 0xe2, 0x91, 0x99, 0x1b, 0xe3, 0x6e, 0x08, 0x4e, 0x82, 0x16,
+	0x1c, 0x31, 0x74, 0x8b, 0x83, 0xca, 0x68, 0x7c, 0xc8, 0xc5, 0xbd, 0xa8, 0xf2, 0x05, 0x81, 0x2a,
+	0x93, 0x91, 0x00, 0x81, 0x49, 0x05, 0x60, 0xe5, 0x2d, 0x0e, 0x2b, 0x53, 0x73, 0x5e, 0xe6, 0xc3,
+	0x95, 0x75, 0x1f, 0xae, 0x4c, 0xcf, 0x31, 0x33, 0x02, 0x58, 0xd6, 0x7d, 0xc0, 0x72, 0x69, 0x8e,
+	0x92, 0x08, 0x64, 0xf9, 0x82, 0x40, 0x96, 0x99, 0x39, 0x66, 0x07, 0xa0, 0xe5, 0x5d, 0x3f, 0xb4,
+	0xcc, 0x46, 0x64, 0x0e, 0x42, 0x3a, 0x12, 0x5b, 0xfe, 0xd4, 0x83, 0x2d, 0x73, 0x91, 0xc0, 0x8e,
+	0x29, 0x09, 0x01, 0x97, 0x75, 0x1f, 0xb8, 0x84, 0x39, 0x6b, 0x10, 0x81, 0x2e, 0x5f, 0xf3, 0xa2,
+	0xcb, 0x7c, 0x24, 0x40, 0xe5, 0x9b, 0x26, 0x0c, 0x5e, 0xbe, 0xe4, 0xc0, 0xcb, 0x42, 0x24, 0x3e,
+	0xe6, 0x36, 0x04, 0xf1, 0xe5, 0xe1, 0x14, 0xbe, 0x64, 0x78, 0xf0, 0xc9, 0x48, 0x15, 0x73, 0x00,
+	0xe6, 0xe1, 0x14, 0xc0, 0x2c, 0xcd, 0x51, 0x38, 0x07, 0x61, 0xfe, 0x2a, 0x1c, 0x61, 0x46, 0x63,
+	0x40, 0x3e, 0xcd, 0xc5, 0x20, 0xa6, 0x12, 0x01, 0x31, 0x19, 0x0c, 0x7c, 0x26, 0x52, 0xfd, 0xc2,
+	0x18, 0xf3, 0x7e, 0x08, 0xc6, 0x64, 0x90, 0xf0, 0x46, 0xa4, 0xf2, 0x05, 0x40, 0xe6, 0x5d, 0x3f,
+	0xc8, 0x44, 0x73, 0x0e, 0x40, 0x24, 0xca, 0xbc, 0x1f, 0x82, 0x32, 0x2f, 0xcc, 0x9d, 0xde, 0x5c,
+	0x98, 0x39, 0x98, 0x09, 0x33, 0x57, 0xe8, 0x0b, 0xb6, 0xe6, 0x3b, 0x8b, 0x73, 0xe3, 0xcc, 0xa7,
+	0x61, 0x59, 0x28, 0x71, 0xbc, 0x33, 0xf1, 0xfb, 0xd8, 0x34, 0x75, 0x93, 0x43, 0x38, 0x36, 0x90,
+	0x6e, 0x40, 0xc1, 0x61, 0x9d, 0x8d, 0x49, 0x69, 0x62, 0xe5, 0xf1, 0xbe, 0xd2, 0x77, 0x49, 0x28,
+	0x78, 0x1d, 0xab, 0x0f, 0x6a, 0xe4, 0x38, 0xd4, 0xf0, 0x40, 0xd5, 0x84, 0x1f, 0xaa, 0xae, 0x43,
+	0x9e, 0xac, 0x48, 0x00, 0x85, 0xaa, 0x86, 0x40, 0xa1, 0xe8, 0x26, 0x2c, 0x53, 0x04, 0xc0, 0x92,
+	0x5e, 0x1e, 0x7d, 0x53, 0x34, 0x0c, 0x96, 0xc9, 0x03, 0xe6, 0x01, 0x28, 0x19, 0x3d, 0x07, 0x17,
+	0x3c, 0xbc, 0x4e, 0x22, 0xc6, 0x92, 0xda, 0x8a, 0xc3, 0xbd, 0xc3, 0x32, 0x32, 0xf4, 0x1a, 0x5c,
+	0xe3, 0xe0, 0xc2, 0xc4, 0xfc, 0xeb, 0x90, 0xc7, 0xb8, 0x23, 0x5e, 0xd3, 0xa1, 0x11, 0xf3, 0x32,
+	0x83, 0x10, 0x26, 0xa6, 0x8b, 0xbd, 0x4f, 0x39, 0xf8, 0x0b, 0x5f, 0x86, 0xcb, 0xd6, 0xc8, 0x30,
+	0x74, 0xd3, 0xb6, 0x14, 0xbe, 0x2b, 0xdd, 0xad, 0x83, 0x69, 0x36, 0x7b, 0x49, 0x30, 0x04, 0x33,
+	0x26, 0xbf, 0x6c, 0x60, 0xdb, 0x9d, 0x06, 0x65, 0xfd, 0x5b, 0xea, 0x29, 0x28, 0x3b, 0xb2, 0x26,
+	0x36, 0xfa, 0xea, 0xa4, 0xda, 0xa5, 0x12, 0x25, 0x41, 0x96, 0x29, 0x15, 0xbd, 0x05, 0xff, 0xeb,
+	0x30, 0x46, 0x6f, 0x42, 0xab, 0xda, 0xa3, 0xd2, 0xeb, 0x82, 0x37, 0x7c, 0xd3, 0x59, 0xd2, 0x5b,
+	0xb0, 0x3c, 0x15, 0x09, 0xc9, 0x07, 0x6f, 0xeb, 0x1d, 0xcc, 0xf3, 0x0b, 0xfa, 0x9b, 0x64, 0x32,
+	0x7d, 0xbd, 0xcb, 0xd3, 0x47, 0xf2, 0x93, 0x70, 0x39, 0xc1, 0x39, 0xc7, 0x62, 0xaf, 0xf4, 0xa7,
+	0x84, 0xab, 0xcf, 0x79, 0x65, 0x28, 0xa2, 0x8f, 0xff, 0x58, 0x44, 0xef, 0x4d, 0xc8, 0x93, 0xbe,
+	0x84, 0x1c, 0xbd, 0x0b, 0x2b, 0x3e, 0xcc, 0xad, 0x8c, 0x28, 0x9e, 0x3e, 0x3f, 0xf4, 0x46, 0x67,
+	0x53, 0x4f, 0xd0, 0x7b, 0x70, 0x85, 0xa2, 0xac, 0xc0, 0xde, 0x12, 0xef, 0xc0, 0xd3, 0xb1, 0x89,
+	0x25, 0xc4, 0xbe, 0x7d, 0x26, 0x5f, 0x22, 0x3a, 0x7c, 0x24, 0xa6, 0x5e, 0xfa, 0x57, 0x1c, 0x8a,
+	0xbe, 0x74, 0xe0, 0xc7, 0x7f, 0x05, 0x37, 0x41, 0x64, 0xc9, 0x25, 0x1b, 0x88, 0x4a, 0xcf, 0x12,
+	0x5d, 0x33, 0x7f, 0xa5, 0x27, 0x43, 0x69, 0x6c, 0x80, 0xee, 0x40, 0x8e, 0xf6, 0x38, 0x14, 0xdd,
+	0xb0, 0x78, 0xee, 0x71, 0xc5, 0x6b, 0x16, 0x6b, 0x65, 0x6c, 0x1e, 0x11, 0x9e, 0x43, 0xc3, 0x92,
+	0xb3, 0x06, 0xff, 0xe5, 0x49, 0xa6, 0x73, 0xbe, 0x64, 0xfa, 0x2a, 0xe4, 0xc8, 0xec, 0x2d, 0x43,
+	0x6d, 0x63, 0x9a, 0x47, 0xe4, 0x64, 0x97, 0x20, 0x3d, 0x04, 0x34, 0x9d, 0xc9, 0xa0, 0x26, 0x2c,
+	0xe1, 0x33, 0x3c, 0xb4, 0xc9, 0x4e, 0x21, 0x40, 0x7e, 0x35, 0x04, 0xc8, 0xe3, 0xa1, 0xbd, 0x5b,
+	0x25, 0x1f, 0xec, 0xbb, 0xaf, 0xd6, 0x2b, 0x8c, 0xfb, 0x59, 0x7d, 0xa0, 0xd9, 0x78, 0x60, 0xd8,
+	0x13, 0x99, 0xcb, 0x4b, 0x5f, 0x27, 0xa0, 0x2c, 0x5e, 0x20, 0xd0, 0x7c, 0xd8, 0xda, 0x0a, 0x37,
+	0x97, 0xf0, 0x54, 0x54, 0x16, 0x5b, 0xef, 0x35, 0x80, 0xae, 0x6a, 0x29, 0x1f, 0xa9, 0x43, 0x1b,
+	0x77, 0x44, 0x46, 0xef, 0x52, 0x50, 0x0d, 0xb2, 0x64, 0x34, 0xb2, 0x70, 0x87, 0x97, 0x95, 0x9c,
+	0xb1, 0xc7, 0xce, 0xcc, 0xe3, 0xd9, 0xe9, 0x5f, 0xe5, 0x6c, 0x60, 0x95, 0xc9, 0x1c, 0x0c, 0x53,
+	0xd3, 0x4d, 0xcd, 0x9e, 0xf0, 0xaf, 0xe3, 0x8c, 0x3d, 0xe8, 0x10, 0xbc, 0xe8, 0x90, 0x14, 0xcf,
+	0xa8, 0x2f, 0x6a, 0x63, 0x07, 0xe9, 0xe4, 0x59, 0xf1, 0x8c, 0x93, 0x79, 0x19, 0xe1, 0x37, 0x9e,
+	0x53, 0xef, 0x16, 0x12, 0xfe, 0xeb, 0xd6, 0x58, 0xfa, 0x9e, 0xd6, 0x48, 0xfd, 0xa9, 0x2c, 0xfa,
+	0x39, 0x5c, 0x0a, 0x38, 0x3f, 0xee, 0x32, 0xac, 0x6a, 0x62, 0x41, 0x1f, 0x78, 0xd1, 0xef, 0x03,
+	0x99, 0xc7, 0xb0, 0x3c, 0x66, 0x25, 0x1f, 0xd3, 0xac, 0x39, 0xbe, 0xad, 0xf3, 0x78, 0xbe, 0x2d,
+	0xd2, 0x2f, 0xe3, 0xf3, 0xf9, 0xe5, 0x78, 0x98, 0x5f, 0x96, 0xf6, 0xa0, 0x24, 0xd6, 0x9c, 0xe5,
+	0xff, 0xa1, 0x9b, 0xec, 0x3a, 0x14, 0xa7, 0x6b, 0x4d, 0x49, 0xb9, 0x60, 0x7a, 0x6b, 0x4c, 0x47,
+	0x70, 0x31, 0x14, 0x07, 0xa0, 0x17, 0x21, 0xe7, 0x42, 0x88, 0x78, 0x44, 0x61, 0x51, 0xb0, 0xcb,
+	0x2e, 0xaf, 0xf4, 0x97, 0x38, 0x5c, 0x0c, 0x45, 0x02, 0xa8, 0x01, 0x4b, 0x26, 0xb6, 0x46, 0x7d,
+	0x56, 0x78, 0x28, 0x6d, 0x3f, 0xb7, 0x18, 0x82, 0x20, 0xd4, 0x51, 0xdf, 0x96, 0xb9, 0xb0, 0xf4,
+	0x10, 0x96, 0x18, 0x05, 0xe5, 0x21, 0x73, 0xff, 0xe0, 0xde, 0xc1, 0xe1, 0x3b, 0x07, 0x95, 0x18,
+	0x02, 0x58, 0xda, 0xa9, 0xd7, 0x1b, 0x47, 0xad, 0x4a, 0x1c, 0xe5, 0x20, 0xbd, 0xb3, 0x7b, 0x28,
+	0xb7, 0x2a, 0x09, 0x42, 0x96, 0x1b, 0x6f, 0x36, 0xea, 0xad, 0x4a, 0x12, 0x2d, 0x43, 0x91, 0xfd,
+	0x56, 0xee, 0x1e, 0xca, 0x6f, 0xed, 0xb4, 0x2a, 0x29, 0x0f, 0xe9, 0xb8, 0x71, 0xf0, 0x7a, 0x43,
+	0xae, 0xa4, 0xa5, 0xe7, 0xe1, 0xb2, 0x98, 0xc7, 0x74, 0x91, 0xcb, 0x29, 0x39, 0xc4, 0xbd, 0x25,
+	0x87, 0xdf, 0x25, 0xa0, 0x16, 0x0d, 0x24, 0xd0, 0x9b, 0x01, 0xc3, 0xb7, 0xcf, 0x81, 0x42, 0x02,
+	0xd6, 0x93, 0xaa, 0xbd, 0x89, 0x4f, 0xb1, 0xdd, 0xee, 0x89, 0xb4, 0x27, 0xb1, 0x91, 0xbc, 0x51,
+	0x94, 0x8b, 0x9c, 0xca, 0x6b, 0xf1, 0x94, 0xed, 0x03, 0xdc, 0xb6, 0x15, 0xe6, 0xd8, 0xd8, 0x81,
+	0xc9, 0xc9, 0x45, 0x46, 0x3d, 0x66, 0x44, 0xe9, 0xfd, 0x73, 0xad, 0x65, 0x0e, 0xd2, 0x72, 0xa3,
+	0x25, 0xff, 0xa2, 0x92, 0x44, 0x08, 0x4a, 0xf4, 0xa7, 0x72, 0x7c, 0xb0, 0x73, 0x74, 0xdc, 0x3c,
+	0x24, 0x6b, 0x79, 0x01, 0xca, 0x62, 0x2d, 0x05, 0x31, 0x2d, 0xfd, 0x31, 0x0e, 0x97, 0x22, 0x60,
+	0x10, 0x3a, 0x84, 0x25, 0xcb, 0x56, 0xed, 0x91, 0xc5, 0xd7, 0xe5, 0xc5, 0x45, 0x01, 0xd4, 0xa6,
+	0xf8, 0x71, 0x4c, 0xc5, 0x65, 0xae, 0xc6, 0xf1, 0xa2, 0x09, 0x4f, 0x7e, 0x76, 0x1b, 0x4a, 0x7e,
+	0xee, 0x68, 0x53, 0xdd, 0xbd, 0x92, 0x90, 0x5e, 0x01, 0x34, 0x8d, 0xb5, 0xc8, 0xb2, 0x12, 0x78,
+	0xa6, 0x50, 0xc0, 0xe5, 0x74, 0xad, 0x0a, 0x72, 0x91, 0x50, 0x1b, 0x82, 0x28, 0x3d, 0xe3, 0xb5,
+	0xd9, 0x9f, 0xf5, 0xf2, 0xf2, 0x58, 0xdc, 0x29, 0x8f, 0x49, 0x1b, 0xb0, 0x36, 0x1b, 0x27, 0x11,
+	0x27, 0x5b, 0x0e, 0x38, 0x48, 0xb4, 0x0d, 0x69, 0x56, 0x60, 0x88, 0xba, 0xea, 0x40, 0x5d, 0x31,
+	0x63, 0x96, 0xd3, 0x27, 0xa2, 0xf1, 0x8e, 0x79, 0x2b, 0x20, 0xcc, 0x11, 0x33, 0x07, 0x27, 0x9a,
+	0x05, 0x5c, 0xd4, 0x91, 0x20, 0x4d, 0x73, 0xc7, 0x17, 0x55, 0x93, 0xd3, 0x65, 0x0d, 0x26, 0xee,
+	0x38, 0x32, 0x2e, 0xef, 0xca, 0xa0, 0x97, 0x5c, 0x00, 0x95, 0x8a, 0x72, 0xaf, 0x1c, 0x31, 0x71,
+	0x61, 0xc1, 0x4f, 0x5a, 0x0f, 0xc4, 0xa8, 0x6a, 0x7a, 0xda, 0x58, 0x26, 0xb7, 0xb3, 0x5b, 0xdf,
+	0xe3, 0x42, 0x94, 0x93, 0xcc, 0xd6, 0x9a, 0x0c, 0xdb, 0x3d, 0x53, 0x1f, 0x8a, 0x6b, 0x0e, 0x21,
+	0xb3, 0x3d, 0x16, 0x2c, 0x62, 0xb6, 0x8e, 0x8c, 0x54, 0x87, 0xbc, 0x67, 0x09, 0xd1, 0x15, 0xc8,
+	0x0d, 0x54, 0x51, 0xc1, 0x64, 0x85, 0xd3, 0xec, 0x40, 0x65, 0xf5, 0x4b, 0x74, 0x09, 0x32, 0xe4,
+	0x61, 0x57, 0xb5, 0x44, 0x71, 0x76, 0xa0, 0x8e, 0xdf, 0x50, 0x2d, 0xe9, 0x6f, 0x71, 0x28, 0xf9,
+	0x5b, 0x3a, 0x6e, 0x99, 0x35, 0xee, 0x2d, 0xb3, 0xde, 0x86, 0x34, 0xd9, 0x42, 0xec, 0x34, 0x87,
+	0xb9, 0x5a, 0xb2, 0xfd, 0x3c, 0x2d, 0x21, 0xc6, 0x4d, 0x52, 0x84, 0x0f, 0x47, 0xba, 0x39, 0x1a,
+	0x34, 0x5d, 0x6c, 0xe0, 0xa1, 0xa0, 0x27, 0xa1, 0x44, 0x3f, 0xfd, 0xb1, 0xd6, 0x1d, 0xaa, 0xf6,
+	0xc8, 0x64, 0xcd, 0x96, 0x82, 0x1c, 0xa0, 0x12, 0x3e, 0x0a, 0xa5, 0x5c, 0x3e, 0x86, 0x37, 0x03,
+	0x54, 0xe9, 0x63, 0x48, 0xd3, 0x00, 0x4b, 0x4e, 0x1a, 0xed, 0x05, 0x71, 0x80, 0x4c, 0x7e, 0xa3,
+	0xf7, 0x00, 0x54, 0xdb, 0x36, 0xb5, 0x93, 0x91, 0x6b, 0xc8, 0x7a, 0x78, 0x80, 0xde, 0x11, 0x7c,
+	0xbb, 0x57, 0x79, 0xa4, 0x5e, 0x71, 0x45, 0x3d, 0xd1, 0xda, 0xa3, 0x50, 0x3a, 0x80, 0x92, 0x5f,
+	0xd6, 0xdb, 0xc8, 0x2d, 0x84, 0x34, 0x72, 0x9d, 0xf4, 0xde, 0x01, 0x07, 0x49, 0xd6, 0xf8, 0xa3,
+	0x03, 0xe9, 0xd3, 0x38, 0x64, 0x5b, 0x63, 0xee, 0xfe, 0x66, 0x14, 0xc5, 0x99, 0x68, 0xc2, 0x5b,
+	0x78, 0x66, 0x0d, 0xa3, 0xa4, 0xd3, 0x19, 0x7b, 0xcd, 0x71, 0xf0, 0xa9, 0x45, 0x4b, 0x86, 0xa2,
+	0x5d, 0xc0, 0x83, 0xda, 0x0e, 0xe4, 0x9c, 0x93, 0x43, 0x5e, 0x6a, 0xe8, 0x1f, 0xf1, 0xce, 0x45,
+	0x52, 0x66, 0x03, 0xb4, 0x06, 0x79, 0x6f, 0x01, 0x9e, 0x7d, 0x48, 0x82, 0x5b, 0x78, 0x4a, 0x4a,
+	0xba, 0x56, 0x8e, 0x0e, 0x9e, 0x86, 0xbc, 0x02, 0x19, 0x63, 0x74, 0xa2, 0x88, 0x55, 0x0a, 0x1c,
+	0x1d, 0x01, 0x6b, 0x46, 0x27, 0x7d, 0xad, 0x7d, 0x0f, 0x4f, 0xc4, 0x9c, 0x8c, 0xd1, 0xc9, 0x3d,
+	0xb6, 0x98, 0x6c, 0x1a, 0x89, 0x19, 0xd3, 0x48, 0x06, 0xa7, 0xf1, 0xeb, 0x04, 0xa0, 0xe9, 0x6c,
+	0x06, 0x1d, 0xc3, 0xb2, 0x9b, 0x10, 0x89, 0x6c, 0x90, 0xe5, 0x15, 0x1b, 0xd1, 0xd9, 0x90, 0x0f,
+	0xa2, 0x56, 0xce, 0xfc, 0x64, 0x0b, 0xb5, 0x60, 0xc5, 0xee, 0x99, 0xd8, 0xea, 0xe9, 0xfd, 0x8e,
+	0x62, 0x50, 0x33, 0xa8, 0xad, 0x89, 0x85, 0x6d, 0x45, 0x8e, 0xbc, 0xf3, 0x84, 0x94, 0x73, 0xd8,
+	0x11, 0x52, 0x7a, 0xe1, 0xa7, 0xca, 0x65, 0xa0, 0x67, 0x80, 0xf5, 0x73, 0x38, 0x03, 0xe9, 0x7e,
+	0x4a, 0x06, 0x54, 0x5b, 0x53, 0x7a, 0xf9, 0x42, 0x44, 0xcd, 0x39, 0xfe, 0x38, 0x73, 0x96, 0x6e,
+	0x41, 0xe5, 0x6d, 0x67, 0x82, 0xfc, 0x4d, 0x01, 0x3b, 0xe2, 0x41, 0x3b, 0xa4, 0x33, 0xc8, 0x0a,
+	0xb7, 0x82, 0x7e, 0xe6, 0xf5, 0xee, 0xe2, 0x72, 0x43, 0xe4, 0x77, 0xe1, 0x33, 0x71, 0x45, 0x48,
+	0x89, 0xcb, 0xd2, 0xba, 0x43, 0xdc, 0x51, 0xdc, 0xea, 0x15, 0xef, 0x85, 0x96, 0xd9, 0x83, 0x7d,
+	0x51, 0xba, 0x92, 0x7e, 0x88, 0x43, 0x56, 0x84, 0x19, 0xf4, 0xbc, 0xc7, 0x93, 0x94, 0x42, 0x1a,
+	0x1e, 0x82, 0xd1, 0xd3, 0x56, 0xf6, 0xcd, 0x35, 0x71, 0xfe, 0xb9, 0x46, 0xb5, 0xa5, 0xc5, 0xdd,
+	0x8e, 0xd4, 0xb9, 0xef, 0x76, 0x3c, 0x0b, 0xc8, 0xd6, 0x6d, 0xb5, 0x4f, 0x8a, 0xb7, 0xda, 0xb0,
+	0xab, 0xb0, 0x73, 0xc3, 0x20, 0x5b, 0x85, 0x3e, 0x79, 0x40, 0x1f, 0x1c, 0x11, 0xba, 0xf4, 0xe7,
+	0x38, 0x64, 0x9d, 0xac, 0xf8, 0xbc, 0x6d, 0xd3, 0x55, 0x58, 0xe2, 0x89, 0x1f, 0xeb, 0x9b, 0xf2,
+	0x91, 0xd3, 0x33, 0x4c, 0x79, 0x7a, 0x86, 0x35, 0xc8, 0x0e, 0xb0, 0xad, 0x52, 0x68, 0xc0, 0x1c,
+	0xba, 0x33, 0x46, 0x2f, 0x42, 0x75, 0x4e, 0xcd, 0xf0, 0x62, 0x3b, 0xac, 0x5e, 0x78, 0xf3, 0x25,
+	0xc8, 0x7b, 0x3a, 0xfd, 0xc4, 0x09, 0x1f, 0x34, 0xde, 0xa9, 0xc4, 0x6a, 0x99, 0x4f, 0x3f, 0xdf,
+	0x48, 0x1e, 0xe0, 0x8f, 0x48, 0xa1, 0x54, 0x6e, 0xd4, 0x9b, 0x8d, 0xfa, 0xbd, 0x4a, 0xbc, 0x96,
+	0xff, 0xf4, 0xf3, 0x8d, 0x8c, 0x8c, 0x69, 0x83, 0xe5, 0x66, 0x13, 0x0a, 0xde, 0xcf, 0xe9, 0xcf,
+	0xc4, 0x10, 0x94, 0x5e, 0xbf, 0x7f, 0xb4, 0xbf, 0x57, 0xdf, 0x69, 0x35, 0x94, 0x07, 0x87, 0xad,
+	0x46, 0x25, 0x8e, 0x2e, 0xc1, 0x85, 0xfd, 0xbd, 0x37, 0x9a, 0x2d, 0xa5, 0xbe, 0xbf, 0xd7, 0x38,
+	0x68, 0x29, 0x3b, 0xad, 0xd6, 0x4e, 0xfd, 0x5e, 0x25, 0xb1, 0xfd, 0xfb, 0x22, 0x94, 0x49, 0xcc,
+	0x27, 0x09, 0xb3, 0xd6, 0x56, 0x79, 0x03, 0x2b, 0x45, 0x0b, 0xbf, 0x33, 0xaf, 0x7d, 0xd6, 0x66,
+	0xf7, 0xef, 0xd0, 0x5d, 0x48, 0xd3, 0x9a, 0x30, 0x9a, 0x7d, 0x0f, 0xb4, 0x36, 0xa7, 0xa1, 0x47,
+	0x26, 0x43, 0xcf, 0xd5, 0xcc, 0x8b, 0xa1, 0xb5, 0xd9, 0xfd, 0x3d, 0x24, 0x43, 0xce, 0x2d, 0x51,
+	0xce, 0xbf, 0x28, 0x5a, 0x5b, 0xa0, 0xe7, 0x47, 0x74, 0xba, 0x05, 0x8b, 0xf9, 0x17, 0x27, 0x6b,
+	0x0b, 0xc4, 0x32, 0xb4, 0x0f, 0x19, 0x51, 0x66, 0x9a, 0x77, 0x95, 0xb3, 0x36, 0xb7, 0x1f, 0x47,
+	0x3e, 0x01, 0x2b, 0x07, 0xce, 0xbe, 0x97, 0x5a, 0x9b, 0xd3, 0x5c, 0x44, 0x7b, 0xb0, 0xc4, 0xe1,
+	0xf1, 0x9c, 0xeb, 0x99, 0xb5, 0x79, 0xfd, 0x35, 0xb2, 0x68, 0x6e, 0x6d, 0x77, 0xfe, 0x6d, 0xdb,
+	0xda, 0x02, 0x7d, 0x53, 0x74, 0x1f, 0xc0, 0x53, 0xfc, 0x5b, 0xe0, 0x1a, 0x6d, 0x6d, 0x91, 0x7e,
+	0x28, 0x3a, 0x84, 0xac, 0x53, 0x88, 0x99, 0x7b, 0xa9, 0xb5, 0x36, 0xbf, 0x31, 0x89, 0x1e, 0x42,
+	0xd1, 0x5f, 0x1a, 0x58, 0xec, 0xaa, 0x6a, 0x6d, 0xc1, 0x8e, 0x23, 0xd1, 0xef, 0xaf, 0x13, 0x2c,
+	0x76, 0x75, 0xb5, 0xb6, 0x60, 0x03, 0x12, 0x7d, 0x00, 0xcb, 0xd3, 0x38, 0x7e, 0xf1, 0x9b, 0xac,
+	0xb5, 0x73, 0xb4, 0x24, 0xd1, 0x00, 0x50, 0x08, 0xfe, 0x3f, 0xc7, 0xc5, 0xd6, 0xda, 0x79, 0x3a,
+	0x94, 0xa8, 0x03, 0xe5, 0x20, 0xa6, 0x5e, 0xf4, 0xa2, 0x6b, 0x6d, 0xe1, 0x6e, 0x25, 0xd9, 0xa8,
+	0x1e, 0x08, 0xbc, 0xc0, 0xc5, 0xd7, 0xda, 0x22, 0x7d, 0x4b, 0x36, 0xf9, 0x40, 0x4b, 0x68, 0xc1,
+	0x8b, 0xb0, 0xb5, 0x85, 0x7b, 0x99, 0x68, 0x02, 0xab, 0x11, 0x77, 0x4a, 0xce, 0x79, 0x2b, 0xb6,
+	0x76, 0xde, 0xf6, 0xe6, 0x6e, 0xe3, 0x8b, 0x6f, 0xd6, 0xe2, 0x5f, 0x7e, 0xb3, 0x16, 0xff, 0xc7,
+	0x37, 0x6b, 0xf1, 0xcf, 0xbe, 0x5d, 0x8b, 0x7d, 0xf9, 0xed, 0x5a, 0xec, 0xef, 0xdf, 0xae, 0xc5,
+	0x7e, 0xf9, 0x4c, 0x57, 0xb3, 0x7b, 0xa3, 0x93, 0xcd, 0xb6, 0x3e, 0xd8, 0xf2, 0xfe, 0x27, 0x22,
+	0xec, 0x7f, 0x1a, 0x27, 0x4b, 0x34, 0xff, 0xb8, 0xf5, 0xef, 0x01, 0x00, 0x38, 0xb5, 0xe6, 0x61,
+	0xc7, 0x31, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3626,6 +4415,10 @@ type ABCIApplicationClient interface {
 	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
+	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
+	ExtendVote(ctx context.Context, in *RequestExtendVote, opts ...grpc.CallOption) (*ResponseExtendVote, error)
+	PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error)
+	InitChainAppStateChunk(ctx context.Context, in *RequestInitChainAppStateChunk, opts ...grpc.CallOption) (*ResponseInitChainAppStateChunk, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3771,6 +4564,42 @@ func (c *aBCIApplicationClient) ApplySnapshotChunk(ctx context.Context, in *Requ
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error) {
+	out := new(ResponseProcessProposal)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/ProcessProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ExtendVote(ctx context.Context, in *RequestExtendVote, opts ...grpc.CallOption) (*ResponseExtendVote, error) {
+	out := new(ResponseExtendVote)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/ExtendVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error) {
+	out := new(ResponsePrepareProposal)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/PrepareProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) InitChainAppStateChunk(ctx context.Context, in *RequestInitChainAppStateChunk, opts ...grpc.CallOption) (*ResponseInitChainAppStateChunk, error) {
+	out := new(ResponseInitChainAppStateChunk)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/InitChainAppStateChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3788,6 +4617,10 @@ type ABCIApplicationServer interface {
 	OfferSnapshot(context.Context, *RequestOfferSnapshot) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
+	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
+	ExtendVote(context.Context, *RequestExtendVote) (*ResponseExtendVote, error)
+	PrepareProposal(context.Context, *RequestPrepareProposal) (*ResponsePrepareProposal, error)
+	InitChainAppStateChunk(context.Context, *RequestInitChainAppStateChunk) (*ResponseInitChainAppStateChunk, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3839,6 +4672,18 @@ func (*UnimplementedABCIApplicationServer) LoadSnapshotChunk(ctx context.Context
 func (*UnimplementedABCIApplicationServer) ApplySnapshotChunk(ctx context.Context, req *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplySnapshotChunk not implemented")
 }
+func (*UnimplementedABCIApplicationServer) ProcessProposal(ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessProposal not implemented")
+}
+func (*UnimplementedABCIApplicationServer) ExtendVote(ctx context.Context, req *RequestExtendVote) (*ResponseExtendVote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendVote not implemented")
+}
+func (*UnimplementedABCIApplicationServer) PrepareProposal(ctx context.Context, req *RequestPrepareProposal) (*ResponsePrepareProposal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareProposal not implemented")
+}
+func (*UnimplementedABCIApplicationServer) InitChainAppStateChunk(ctx context.Context, req *RequestInitChainAppStateChunk) (*ResponseInitChainAppStateChunk, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitChainAppStateChunk not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -4114,24 +4959,96 @@ func _ABCIApplication_ApplySnapshotChunk_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "tendermint.abci.ABCIApplication",
-	HandlerType: (*ABCIApplicationServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Echo",
-			Handler:    _ABCIApplication_Echo_Handler,
-		},
-		{
-			MethodName: "Flush",
-			Handler:    _ABCIApplication_Flush_Handler,
-		},
-		{
-			MethodName: "Info",
-			Handler:    _ABCIApplication_Info_Handler,
-		},
-		{
-			MethodName: "SetOption",
+func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestProcessProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/ProcessProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, req.(*RequestProcessProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_ExtendVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestExtendVote)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ExtendVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/ExtendVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ExtendVote(ctx, req.(*RequestExtendVote))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_PrepareProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPrepareProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).PrepareProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/PrepareProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).PrepareProposal(ctx, req.(*RequestPrepareProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_InitChainAppStateChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestInitChainAppStateChunk)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).InitChainAppStateChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/InitChainAppStateChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).InitChainAppStateChunk(ctx, req.(*RequestInitChainAppStateChunk))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.abci.ABCIApplication",
+	HandlerType: (*ABCIApplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler:    _ABCIApplication_Echo_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _ABCIApplication_Flush_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _ABCIApplication_Info_Handler,
+		},
+		{
+			MethodName: "SetOption",
 			Handler:    _ABCIApplication_SetOption_Handler,
 		},
 		{
@@ -4178,6 +5095,22 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplySnapshotChunk",
 			Handler:    _ABCIApplication_ApplySnapshotChunk_Handler,
 		},
+		{
+			MethodName: "ProcessProposal",
+			Handler:    _ABCIApplication_ProcessProposal_Handler,
+		},
+		{
+			MethodName: "ExtendVote",
+			Handler:    _ABCIApplication_ExtendVote_Handler,
+		},
+		{
+			MethodName: "PrepareProposal",
+			Handler:    _ABCIApplication_PrepareProposal_Handler,
+		},
+		{
+			MethodName: "InitChainAppStateChunk",
+			Handler:    _ABCIApplication_InitChainAppStateChunk_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -4530,6 +5463,98 @@ func (m *Request_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ProcessProposal != nil {
+		{
+			size, err := m.ProcessProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_ExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_ExtendVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ExtendVote != nil {
+		{
+			size, err := m.ExtendVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_PrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_PrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PrepareProposal != nil {
+		{
+			size, err := m.PrepareProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_InitChainAppStateChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_InitChainAppStateChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.InitChainAppStateChunk != nil {
+		{
+			size, err := m.InitChainAppStateChunk.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4692,6 +5717,11 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	dAtA[i] = 0x6
 	i--
 	dAtA[i] = 0xa2
+	if m.AppStateChunks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateChunks))
+		i--
+		dAtA[i] = 0x38
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -4723,12 +5753,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n18, err18 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err18 != nil {
-		return 0, err18
+	n22, err22 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err22 != nil {
+		return 0, err22
 	}
-	i -= n18
-	i = encodeVarintTypes(dAtA, i, uint64(n18))
+	i -= n22
+	i = encodeVarintTypes(dAtA, i, uint64(n22))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -4870,6 +5900,28 @@ func (m *RequestCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NextProposerProTxHash) > 0 {
+		i -= len(m.NextProposerProTxHash)
+		copy(dAtA[i:], m.NextProposerProTxHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NextProposerProTxHash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.BlockTime != nil {
+		n25, err25 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.BlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.BlockTime):])
+		if err25 != nil {
+			return 0, err25
+		}
+		i -= n25
+		i = encodeVarintTypes(dAtA, i, uint64(n25))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Type != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
 		i--
@@ -4935,6 +5987,21 @@ func (m *RequestEndBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SnapshotRetainHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SnapshotRetainHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TakeSnapshot {
+		i--
+		if m.TakeSnapshot {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Height != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
 		i--
@@ -5111,7 +6178,7 @@ func (m *RequestApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *RequestProcessProposal) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5121,52 +6188,235 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestProcessProposal) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Value != nil {
-		{
-			size := m.Value.Size()
-			i -= size
-			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestExtendVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestExtendVote) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response_Exception) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestExtendVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.Exception != nil {
-		{
-			size, err := m.Exception.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
-func (m *Response_Echo) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *RequestPrepareProposal) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestPrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestPrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxTxBytes != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxTxBytes))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ProposerProTxHash) > 0 {
+		i -= len(m.ProposerProTxHash)
+		copy(dAtA[i:], m.ProposerProTxHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProposerProTxHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestInitChainAppStateChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestInitChainAppStateChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestInitChainAppStateChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chunk) > 0 {
+		i -= len(m.Chunk)
+		copy(dAtA[i:], m.Chunk)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Chunk)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_Exception) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Exception != nil {
+		{
+			size, err := m.Exception.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_Echo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *Response_Echo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -5481,6 +6731,98 @@ func (m *Response_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, er
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ProcessProposal != nil {
+		{
+			size, err := m.ProcessProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_ExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_ExtendVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ExtendVote != nil {
+		{
+			size, err := m.ExtendVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_PrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_PrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PrepareProposal != nil {
+		{
+			size, err := m.PrepareProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_InitChainAppStateChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_InitChainAppStateChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.InitChainAppStateChunk != nil {
+		{
+			size, err := m.InitChainAppStateChunk.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5584,6 +6926,54 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SupportsInitChainAppStateChunks {
+		i--
+		if m.SupportsInitChainAppStateChunks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.SupportsReplay {
+		i--
+		if m.SupportsReplay {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.SupportsPrepareProposal {
+		i--
+		if m.SupportsPrepareProposal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.SupportsProcessProposal {
+		i--
+		if m.SupportsProcessProposal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x6
+		i--
+		dAtA[i] = 0xa8
+	}
 	if m.LastCoreChainLockedHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.LastCoreChainLockedHeight))
 		i--
@@ -5877,6 +7267,25 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ReplaceTxHash) > 0 {
+		i -= len(m.ReplaceTxHash)
+		copy(dAtA[i:], m.ReplaceTxHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ReplaceTxHash)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x48
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -6254,20 +7663,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA46 := make([]byte, len(m.RefetchChunks)*10)
-		var j45 int
+		dAtA56 := make([]byte, len(m.RefetchChunks)*10)
+		var j55 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA46[j45] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA56[j55] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j45++
+				j55++
 			}
-			dAtA46[j45] = uint8(num)
-			j45++
+			dAtA56[j55] = uint8(num)
+			j55++
 		}
-		i -= j45
-		copy(dAtA[i:], dAtA46[:j45])
-		i = encodeVarintTypes(dAtA, i, uint64(j45))
+		i -= j55
+		copy(dAtA[i:], dAtA56[:j55])
+		i = encodeVarintTypes(dAtA, i, uint64(j55))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6279,7 +7688,7 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
-func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+func (m *ResponseProcessProposal) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6289,26 +7698,170 @@ func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseProcessProposal) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ResponseProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Version != nil {
-		{
-			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
-		i--
+	if len(m.Info) > 0 {
+		i -= len(m.Info)
+		copy(dAtA[i:], m.Info)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Status != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseExtendVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseExtendVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.VoteExtension) > 0 {
+		i -= len(m.VoteExtension)
+		copy(dAtA[i:], m.VoteExtension)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.VoteExtension)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponsePrepareProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponsePrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponsePrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseInitChainAppStateChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseInitChainAppStateChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseInitChainAppStateChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Synchrony != nil {
+		{
+			size, err := m.Synchrony.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Abci != nil {
+		{
+			size, err := m.Abci.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Version != nil {
+		{
+			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
 		dAtA[i] = 0x22
 	}
 	if m.Validator != nil {
@@ -6424,6 +7977,20 @@ func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x1a
 	}
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if m.Round != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
 		i--
@@ -6673,6 +8240,11 @@ func (m *ValidatorSetUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.QuorumType != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.QuorumType))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.QuorumHash) > 0 {
 		i -= len(m.QuorumHash)
 		copy(dAtA[i:], m.QuorumHash)
@@ -6838,12 +8410,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n56, err56 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err56 != nil {
-		return 0, err56
+	n68, err68 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err68 != nil {
+		return 0, err68
 	}
-	i -= n56
-	i = encodeVarintTypes(dAtA, i, uint64(n56))
+	i -= n68
+	i = encodeVarintTypes(dAtA, i, uint64(n68))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -7131,6 +8703,54 @@ func (m *Request_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_ExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtendVote != nil {
+		l = m.ExtendVote.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_PrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PrepareProposal != nil {
+		l = m.PrepareProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_InitChainAppStateChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InitChainAppStateChunk != nil {
+		l = m.InitChainAppStateChunk.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7212,6 +8832,9 @@ func (m *RequestInitChain) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	if m.AppStateChunks != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateChunks))
+	}
 	l = m.ValidatorSet.Size()
 	n += 2 + l + sovTypes(uint64(l))
 	return n
@@ -7276,6 +8899,17 @@ func (m *RequestCheckTx) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovTypes(uint64(m.Type))
 	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.BlockTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.BlockTime)
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NextProposerProTxHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7301,6 +8935,12 @@ func (m *RequestEndBlock) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovTypes(uint64(m.Height))
 	}
+	if m.TakeSnapshot {
+		n += 2
+	}
+	if m.SnapshotRetainHeight != 0 {
+		n += 1 + sovTypes(uint64(m.SnapshotRetainHeight))
+	}
 	return n
 }
 
@@ -7377,74 +9017,161 @@ func (m *RequestApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
-func (m *Response) Size() (n int) {
+func (m *RequestProcessProposal) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
 	}
 	return n
 }
 
-func (m *Response_Exception) Size() (n int) {
+func (m *RequestExtendVote) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Exception != nil {
-		l = m.Exception.Size()
+	l = len(m.Hash)
+	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
 	return n
 }
-func (m *Response_Echo) Size() (n int) {
+
+func (m *RequestPrepareProposal) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Echo != nil {
-		l = m.Echo.Size()
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = len(m.ProposerProTxHash)
+	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxTxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxTxBytes))
+	}
 	return n
 }
-func (m *Response_Flush) Size() (n int) {
+
+func (m *RequestInitChainAppStateChunk) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Flush != nil {
-		l = m.Flush.Size()
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.Chunk)
+	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-func (m *Response_Info) Size() (n int) {
+
+func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Value != nil {
+		n += m.Value.Size()
 	}
 	return n
 }
-func (m *Response_SetOption) Size() (n int) {
+
+func (m *Response_Exception) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.SetOption != nil {
-		l = m.SetOption.Size()
+	if m.Exception != nil {
+		l = m.Exception.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Echo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Echo != nil {
+		l = m.Echo.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Flush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Flush != nil {
+		l = m.Flush.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Info) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_SetOption) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SetOption != nil {
+		l = m.SetOption.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
@@ -7581,6 +9308,54 @@ func (m *Response_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_ExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtendVote != nil {
+		l = m.ExtendVote.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_PrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PrepareProposal != nil {
+		l = m.PrepareProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_InitChainAppStateChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InitChainAppStateChunk != nil {
+		l = m.InitChainAppStateChunk.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7643,6 +9418,18 @@ func (m *ResponseInfo) Size() (n int) {
 	if m.LastCoreChainLockedHeight != 0 {
 		n += 2 + sovTypes(uint64(m.LastCoreChainLockedHeight))
 	}
+	if m.SupportsProcessProposal {
+		n += 3
+	}
+	if m.SupportsPrepareProposal {
+		n += 3
+	}
+	if m.SupportsReplay {
+		n += 3
+	}
+	if m.SupportsInitChainAppStateChunks {
+		n += 3
+	}
 	return n
 }
 
@@ -7783,6 +9570,17 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ReplaceTxHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7934,6 +9732,59 @@ func (m *ResponseApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *ResponseProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovTypes(uint64(m.Status))
+	}
+	l = len(m.Info)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.VoteExtension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponsePrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ResponseInitChainAppStateChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
 func (m *ConsensusParams) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7956,6 +9807,14 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Version.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Abci != nil {
+		l = m.Abci.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Synchrony != nil {
+		l = m.Synchrony.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7983,6 +9842,12 @@ func (m *LastCommitInfo) Size() (n int) {
 	if m.Round != 0 {
 		n += 1 + sovTypes(uint64(m.Round))
 	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	l = len(m.QuorumHash)
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
@@ -8110,6 +9975,9 @@ func (m *ValidatorSetUpdate) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.QuorumType != 0 {
+		n += 1 + sovTypes(uint64(m.QuorumType))
+	}
 	return n
 }
 
@@ -8762,61 +10630,46 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_ApplySnapshotChunk{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestEcho) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &RequestProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestEcho: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestEcho: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Value = &Request_ProcessProposal{v}
+			iNdEx = postIndex
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendVote", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -8826,38 +10679,193 @@ func (m *RequestEcho) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			v := &RequestExtendVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+			m.Value = &Request_ExtendVote{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestPrepareProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_PrepareProposal{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChainAppStateChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestInitChainAppStateChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_InitChainAppStateChunk{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestEcho) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestEcho: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
 
 	if iNdEx > l {
@@ -9332,6 +11340,25 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateChunks", wireType)
+			}
+			m.AppStateChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 100:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSet", wireType)
@@ -9807,6 +11834,95 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockTime == nil {
+				m.BlockTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.BlockTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextProposerProTxHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextProposerProTxHash = append(m.NextProposerProTxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextProposerProTxHash == nil {
+				m.NextProposerProTxHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9960,23 +12076,62 @@ func (m *RequestEndBlock) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakeSnapshot", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TakeSnapshot = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotRetainHeight", wireType)
+			}
+			m.SnapshotRetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotRetainHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
@@ -10443,7 +12598,7 @@ func (m *RequestApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RequestProcessProposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10466,17 +12621,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestProcessProposal: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10486,30 +12641,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseException{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10536,17 +12690,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Echo{v}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10556,32 +12708,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseFlush{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_Flush{v}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			var msglen int
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10591,67 +12740,66 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_Info{v}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestExtendVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseSetOption{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Value = &Response_SetOption{v}
-			iNdEx = postIndex
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestExtendVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestExtendVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10661,32 +12809,31 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInitChain{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-			m.Value = &Response_InitChain{v}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var msglen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10696,32 +12843,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &ResponseQuery{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_Query{v}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			var msglen int
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10731,34 +12862,68 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseBeginBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_BeginBlock{v}
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestPrepareProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestPrepareProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestPrepareProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
 					return ErrIntOverflowTypes
 				}
 				if iNdEx >= l {
@@ -10766,32 +12931,35 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			v := &ResponseCheckTx{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Value = &Response_CheckTx{v}
-			iNdEx = postIndex
-		case 10:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerProTxHash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10801,32 +12969,31 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseDeliverTx{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ProposerProTxHash = append(m.ProposerProTxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerProTxHash == nil {
+				m.ProposerProTxHash = []byte{}
 			}
-			m.Value = &Response_DeliverTx{v}
 			iNdEx = postIndex
-		case 11:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10836,32 +13003,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEndBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_EndBlock{v}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxBytes", wireType)
 			}
-			var msglen int
+			m.MaxTxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10871,32 +13035,85 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MaxTxBytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCommit{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestInitChainAppStateChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			m.Value = &Response_Commit{v}
-			iNdEx = postIndex
-		case 13:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestInitChainAppStateChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestInitChainAppStateChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10906,30 +13123,79 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseListSnapshots{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
 			}
-			m.Value = &Response_ListSnapshots{v}
 			iNdEx = postIndex
-		case 14:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10956,15 +13222,260 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseOfferSnapshot{}
+			v := &ResponseException{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_OfferSnapshot{v}
+			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
-		case 15:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseEcho{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Echo{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseFlush{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Flush{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseInfo{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Info{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseSetOption{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_SetOption{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseInitChain{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_InitChain{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseQuery{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Query{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseBeginBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_BeginBlock{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10981,27 +13492,891 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &ResponseLoadSnapshotChunk{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_LoadSnapshotChunk{v}
-			iNdEx = postIndex
-		case 16:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseCheckTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_CheckTx{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseDeliverTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_DeliverTx{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseEndBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_EndBlock{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseCommit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Commit{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseListSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ListSnapshots{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseOfferSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_OfferSnapshot{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseLoadSnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_LoadSnapshotChunk{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseApplySnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ApplySnapshotChunk{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ProcessProposal{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendVote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseExtendVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ExtendVote{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponsePrepareProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_PrepareProposal{v}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChainAppStateChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseInitChainAppStateChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_InitChainAppStateChunk{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseException) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseException: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseException: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEcho: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseFlush: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseFlush: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+			}
+			m.AppVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
+			}
+			m.LastBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastBlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastBlockAppHash == nil {
+				m.LastBlockAppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 100:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCoreChainLockedHeight", wireType)
+			}
+			m.LastCoreChainLockedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastCoreChainLockedHeight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 101:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportsProcessProposal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SupportsProcessProposal = bool(v != 0)
+		case 102:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportsPrepareProposal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SupportsPrepareProposal = bool(v != 0)
+		case 103:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportsReplay", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SupportsReplay = bool(v != 0)
+		case 104:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportsInitChainAppStateChunks", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11011,27 +14386,12 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &ResponseApplySnapshotChunk{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_ApplySnapshotChunk{v}
-			iNdEx = postIndex
+			m.SupportsInitChainAppStateChunks = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11053,7 +14413,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseException) Unmarshal(dAtA []byte) error {
+func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11076,15 +14436,34 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseException: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseSetOption: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseException: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11112,61 +14491,11 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEcho: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEcho: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11194,7 +14523,7 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11217,57 +14546,7 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseFlush: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseFlush: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
+func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11290,17 +14569,17 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11310,29 +14589,33 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = string(dAtA[iNdEx:postIndex])
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11342,67 +14625,31 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Version = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
-			}
-			m.AppVersion = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.AppVersion |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
-			}
-			m.LastBlockHeight = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LastBlockHeight |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSetUpdate", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11412,31 +14659,30 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.LastBlockAppHash == nil {
-				m.LastBlockAppHash = []byte{}
+			if err := m.ValidatorSetUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 100:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastCoreChainLockedHeight", wireType)
+		case 101:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCoreChainLockUpdate", wireType)
 			}
-			m.LastCoreChainLockedHeight = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11446,11 +14692,28 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastCoreChainLockedHeight |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NextCoreChainLockUpdate == nil {
+				m.NextCoreChainLockUpdate = &types1.CoreChainLock{}
+			}
+			if err := m.NextCoreChainLockUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11472,7 +14735,7 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
+func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11495,10 +14758,10 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseSetOption: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11584,61 +14847,30 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 			}
 			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11648,31 +14880,29 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConsensusParams == nil {
-				m.ConsensusParams = &ConsensusParams{}
-			}
-			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -11699,14 +14929,14 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.AppHash == nil {
-				m.AppHash = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 100:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSetUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOps", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11733,13 +14963,117 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ValidatorSetUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ProofOps == nil {
+				m.ProofOps = &crypto.ProofOps{}
+			}
+			if err := m.ProofOps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 101:
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextCoreChainLockUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11766,10 +15100,8 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NextCoreChainLockUpdate == nil {
-				m.NextCoreChainLockUpdate = &types1.CoreChainLock{}
-			}
-			if err := m.NextCoreChainLockUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11794,7 +15126,7 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
+func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11817,10 +15149,10 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11842,6 +15174,40 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
@@ -11908,9 +15274,9 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
 			}
-			m.Index = 0
+			m.GasWanted = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11920,16 +15286,16 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= int64(b&0x7F) << shift
+				m.GasWanted |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
 			}
-			var byteLen int
+			m.GasUsed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11939,31 +15305,16 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.GasUsed |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11973,31 +15324,31 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProofOps", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12007,33 +15358,29 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ProofOps == nil {
-				m.ProofOps = &crypto.ProofOps{}
-			}
-			if err := m.ProofOps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			m.Height = 0
+			m.Priority = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12043,14 +15390,14 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				m.Priority |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12078,63 +15425,13 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplaceTxHash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12144,24 +15441,24 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ReplaceTxHash = append(m.ReplaceTxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ReplaceTxHash == nil {
+				m.ReplaceTxHash = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -12185,7 +15482,7 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12208,10 +15505,10 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12456,7 +15753,7 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12479,70 +15776,17 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12552,29 +15796,33 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12584,65 +15832,29 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
-			}
-			m.GasWanted = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasWanted |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
-			}
-			m.GasUsed = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasUsed |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 7:
+			iNdEx = postIndex
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCoreChainLockUpdate", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12669,16 +15881,18 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.NextCoreChainLockUpdate == nil {
+				m.NextCoreChainLockUpdate = &types1.CoreChainLock{}
+			}
+			if err := m.NextCoreChainLockUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 101:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSetUpdate", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12688,23 +15902,27 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			if m.ValidatorSetUpdate == nil {
+				m.ValidatorSetUpdate = &ValidatorSetUpdate{}
+			}
+			if err := m.ValidatorSetUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12727,7 +15945,7 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12750,17 +15968,17 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12770,31 +15988,98 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConsensusParamUpdates == nil {
-				m.ConsensusParamUpdates = &ConsensusParams{}
-			}
-			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+			}
+			m.RetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12821,16 +16106,66 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Snapshots = append(m.Snapshots, &Snapshot{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 100:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextCoreChainLockUpdate", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			var msglen int
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12840,33 +16175,66 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Result |= ResponseOfferSnapshot_Result(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NextCoreChainLockUpdate == nil {
-				m.NextCoreChainLockUpdate = &types1.CoreChainLock{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			if err := m.NextCoreChainLockUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 101:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSetUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12876,26 +16244,24 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ValidatorSetUpdate == nil {
-				m.ValidatorSetUpdate = &ValidatorSetUpdate{}
-			}
-			if err := m.ValidatorSetUpdate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -12919,7 +16285,7 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
+func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12942,17 +16308,17 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			var byteLen int
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12962,31 +16328,92 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Result |= ResponseApplySnapshotChunk_Result(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+		case 2:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RefetchChunks = append(m.RefetchChunks, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RefetchChunks) == 0 {
+					m.RefetchChunks = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RefetchChunks = append(m.RefetchChunks, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
 			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
 			}
-			m.RetainHeight = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12996,11 +16423,24 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RetainHeight |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13022,7 +16462,7 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
+func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13045,17 +16485,36 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseProcessProposal: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseProcessProposal_ProposalStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13065,25 +16524,23 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Snapshots = append(m.Snapshots, &Snapshot{})
-			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13106,7 +16563,7 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
+func (m *ResponseExtendVote) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13129,17 +16586,17 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseExtendVote: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseExtendVote: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
 			}
-			m.Result = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13149,11 +16606,26 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Result |= ResponseOfferSnapshot_Result(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
+			if m.VoteExtension == nil {
+				m.VoteExtension = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13175,7 +16647,7 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
+func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13198,15 +16670,15 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponsePrepareProposal: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponsePrepareProposal: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -13233,10 +16705,8 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
-			if m.Chunk == nil {
-				m.Chunk = []byte{}
-			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13259,7 +16729,7 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
+func (m *ResponseInitChainAppStateChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13282,139 +16752,12 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseInitChainAppStateChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseInitChainAppStateChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
-			}
-			m.Result = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Result |= ResponseApplySnapshotChunk_Result(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowTypes
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.RefetchChunks = append(m.RefetchChunks, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowTypes
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthTypes
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthTypes
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.RefetchChunks) == 0 {
-					m.RefetchChunks = make([]uint32, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowTypes
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.RefetchChunks = append(m.RefetchChunks, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13609,6 +16952,78 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Abci", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Abci == nil {
+				m.Abci = &types1.ABCIParams{}
+			}
+			if err := m.Abci.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Synchrony", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Synchrony == nil {
+				m.Synchrony = &types1.SynchronyParams{}
+			}
+			if err := m.Synchrony.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13766,6 +17181,40 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, VoteInfo{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field QuorumHash", wireType)
@@ -14667,6 +18116,25 @@ func (m *ValidatorSetUpdate) Unmarshal(dAtA []byte) error {
 				m.QuorumHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumType", wireType)
+			}
+			m.QuorumType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QuorumType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
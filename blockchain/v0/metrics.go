@@ -0,0 +1,63 @@
+package v0
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "blockchain"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Blocks per second received from a given peer.
+	PeerBlockRate metrics.Gauge
+	// Estimated size of the requested blocks, which are not received yet.
+	InFlightBytes metrics.Gauge
+	// Number of block requests moved away from the slow peers.
+	RebalancedRequests metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		PeerBlockRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_block_rate",
+			Help:      "Blocks per second received from a given peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		InFlightBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "in_flight_bytes",
+			Help:      "Estimated size of the requested blocks, which are not received yet.",
+		}, labels).With(labelsAndValues...),
+		RebalancedRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rebalanced_requests",
+			Help:      "Number of block requests moved away from the slow peers.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		PeerBlockRate:      discard.NewGauge(),
+		InFlightBytes:      discard.NewGauge(),
+		RebalancedRequests: discard.NewCounter(),
+	}
+}
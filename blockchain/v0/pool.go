@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -43,9 +44,19 @@ const (
 
 	// Maximum difference between current and new block's height.
 	maxDiffBetweenCurrentAndReceivedBlockHeight = 100
+
+	// A pending request is moved away from a peer, if another peer is
+	// expected to deliver the block this many times sooner.
+	slowPeerFactor = 2
 )
 
-var peerTimeout = 15 * time.Second // not const so we can override with tests
+var (
+	peerTimeout = 15 * time.Second // not const so we can override with tests
+
+	// how often the peers' throughput is updated and the pending requests are
+	// rebalanced
+	rebalanceInterval = time.Second
+)
 
 /*
 	Peers self report their heights when we join the block pool.
@@ -56,6 +67,12 @@ var peerTimeout = 15 * time.Second // not const so we can override with tests
 	Requests are continuously made for blocks of higher heights until
 	the limit is reached. If most of the requests have no available peers, and we
 	are not at peer limits, we can probably switch to consensus reactor
+
+	The window of the requested heights is spread across all the peers: each
+	request goes to the peer expected to deliver the block the soonest, given
+	its pending requests and measured throughput. The requests pending on the
+	peers, which turn out to be much slower than the others, are moved to the
+	faster ones.
 */
 
 // BlockPool keeps track of the fast sync peers, block requests and block responses.
@@ -74,8 +91,16 @@ type BlockPool struct {
 	// atomic
 	numPending int32 // number of requests pending assignment or block response
 
+	// the estimated size of the pending requests, the average size of the
+	// received blocks per request, is limited by maxInFlightBytes; no limit if 0
+	maxInFlightBytes int64
+	avgBlockSize     float64
+	lastRebalance    time.Time
+
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
+
+	metrics *Metrics
 }
 
 // NewBlockPool returns a new BlockPool with the height equal to start. Block
@@ -90,6 +115,8 @@ func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- p
 
 		requestsCh: requestsCh,
 		errorsCh:   errorsCh,
+
+		metrics: NopMetrics(),
 	}
 	bp.BaseService = *service.NewBaseService(nil, "BlockPool", bp)
 	return bp
@@ -109,22 +136,24 @@ func (pool *BlockPool) makeRequestersRoutine() {
 		if !pool.IsRunning() {
 			break
 		}
+		pool.rebalanceRequests()
 
 		_, numPending, lenRequesters := pool.GetStatus()
 		switch {
-		case numPending >= maxPendingRequests:
+		case numPending >= maxPendingRequests, lenRequesters >= maxTotalRequesters, pool.inFlightBytesExceeded():
 			// sleep for a bit.
 			time.Sleep(requestIntervalMS * time.Millisecond)
 			// check for timed out peers
 			pool.removeTimedoutPeers()
-		case lenRequesters >= maxTotalRequesters:
-			// sleep for a bit.
+		default:
+			// request for more blocks.
+			if pool.makeNextRequester() {
+				continue
+			}
+			// the peers have no more blocks, sleep for a bit.
 			time.Sleep(requestIntervalMS * time.Millisecond)
 			// check for timed out peers
 			pool.removeTimedoutPeers()
-		default:
-			// request for more blocks.
-			pool.makeNextRequester()
 		}
 	}
 }
@@ -153,6 +182,78 @@ func (pool *BlockPool) removeTimedoutPeers() {
 	}
 }
 
+// rebalanceRequests updates the peers' throughput, and moves the pending
+// requests away from the peers, which are expected to deliver the blocks
+// slowPeerFactor times later than another peer, starting from the lowest
+// heights. It's done once per rebalanceInterval.
+func (pool *BlockPool) rebalanceRequests() {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	now := time.Now()
+	if now.Sub(pool.lastRebalance) < rebalanceInterval {
+		return
+	}
+	pool.lastRebalance = now
+	for _, peer := range pool.peers {
+		peer.updateRate(now)
+		pool.metrics.PeerBlockRate.With("peer_id", string(peer.id)).Set(peer.blockRate)
+	}
+
+	heights := make([]int64, 0, len(pool.requesters))
+	for height := range pool.requesters {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	moved := make(map[p2p.ID]int32) // number of the requests moved to a peer
+	for _, height := range heights {
+		requester := pool.requesters[height]
+		slow := pool.peers[requester.getPeerID()]
+		if slow == nil || !slow.rateMeasured || requester.getBlock() != nil {
+			continue
+		}
+		delay := slow.expectedDelay(-1)
+		var fast *bpPeer
+		for _, peer := range pool.peers {
+			if peer == slow || !peer.available(height, moved[peer.id]) {
+				continue
+			}
+			peerDelay := peer.expectedDelay(moved[peer.id])
+			if peerDelay*slowPeerFactor < delay && (fast == nil || peerDelay < fast.expectedDelay(moved[fast.id])) {
+				fast = peer
+			}
+		}
+		if fast == nil || !requester.moveFrom(slow.id) {
+			continue
+		}
+		pool.Logger.Debug("Moving the request away from the slow peer", "height", height, "peer", slow.id,
+			"blocks/s", slow.blockRate, "fasterPeer", fast.id)
+		moved[fast.id]++
+		pool.metrics.RebalancedRequests.Add(1)
+	}
+}
+
+// inFlightBytesExceeded returns true, if the estimated size of the pending
+// requests reached maxInFlightBytes. Until the first block is received, and
+// the size of the blocks is unknown, only one block is requested at once.
+func (pool *BlockPool) inFlightBytesExceeded() bool {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if pool.maxInFlightBytes == 0 {
+		return false
+	}
+	if pool.avgBlockSize == 0 {
+		return atomic.LoadInt32(&pool.numPending) > 0
+	}
+	return pool.inFlightBytes() >= pool.maxInFlightBytes
+}
+
+func (pool *BlockPool) inFlightBytes() int64 {
+	return int64(float64(atomic.LoadInt32(&pool.numPending)) * pool.avgBlockSize)
+}
+
 // GetStatus returns pool's height, numPending requests and the number of
 // requesters.
 func (pool *BlockPool) GetStatus() (height int64, numPending int32, lenRequesters int) {
@@ -202,6 +303,18 @@ func (pool *BlockPool) PeekTwoBlocks() (first *types.Block, second *types.Block)
 	return
 }
 
+// PeekBlock returns the block at the given height, nil if it's not received
+// yet.
+func (pool *BlockPool) PeekBlock(height int64) *types.Block {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if r := pool.requesters[height]; r != nil {
+		return r.getBlock()
+	}
+	return nil
+}
+
 // PopRequest pops the first block at pool.height.
 // It must have been validated by 'second'.Commit from PeekTwoBlocks().
 func (pool *BlockPool) PopRequest() {
@@ -217,6 +330,10 @@ func (pool *BlockPool) PopRequest() {
 		if err := r.Stop(); err != nil {
 			pool.Logger.Error("Error stopping requester", "err", err)
 		}
+		// the block from the other peer isn't expected anymore
+		if peer := pool.peers[r.getMovedFrom()]; peer != nil {
+			peer.decrPending(0)
+		}
 		delete(pool.requesters, pool.height)
 		pool.height++
 	} else {
@@ -266,16 +383,29 @@ func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int
 		return
 	}
 
-	if requester.setBlock(block, peerID) {
-		atomic.AddInt32(&pool.numPending, -1)
-		peer := pool.peers[peerID]
-		if peer != nil {
-			peer.decrPending(blockSize)
-		}
-	} else {
+	expected, stored := requester.setBlock(block, peerID)
+	if !expected {
 		pool.Logger.Info("invalid peer", "peer", peerID, "blockHeight", block.Height)
 		pool.sendError(errors.New("invalid peer"), peerID)
+		return
+	}
+
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.decrPending(blockSize)
+		peer.blocks++
+	}
+	if !stored {
+		pool.Logger.Debug("The block is already received from another peer", "peer", peerID,
+			"blockHeight", block.Height)
+		return
 	}
+	atomic.AddInt32(&pool.numPending, -1)
+	if pool.avgBlockSize == 0 {
+		pool.avgBlockSize = float64(blockSize)
+	} else {
+		pool.avgBlockSize = 0.9*pool.avgBlockSize + 0.1*float64(blockSize)
+	}
+	pool.metrics.InFlightBytes.Set(float64(pool.inFlightBytes()))
 }
 
 // MaxPeerHeight returns the highest reported height.
@@ -319,6 +449,7 @@ func (pool *BlockPool) removePeer(peerID p2p.ID) {
 		if requester.getPeerID() == peerID {
 			requester.redo(peerID)
 		}
+		requester.forgetMovedFrom(peerID)
 	}
 
 	peer, ok := pool.peers[peerID]
@@ -328,6 +459,7 @@ func (pool *BlockPool) removePeer(peerID p2p.ID) {
 		}
 
 		delete(pool.peers, peerID)
+		pool.metrics.PeerBlockRate.With("peer_id", string(peerID)).Set(0)
 
 		// Find a new peer with the biggest height and update maxPeerHeight if the
 		// peer's height was the biggest.
@@ -348,36 +480,70 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = max
 }
 
-// Pick an available peer with the given height available.
+// Pick an available peer with the given height available, which is expected
+// to deliver the block the soonest, skipping the excluded one. The peer isn't
+// picked, if a busy measured peer is expected to deliver the block
+// slowPeerFactor times sooner, once it's available.
 // If no peers are available, returns nil.
-func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
+func (pool *BlockPool) pickIncrAvailablePeer(height int64, exclude p2p.ID) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var (
+		best     *bpPeer
+		minDelay = math.Inf(1)
+	)
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
 			continue
 		}
-		if peer.numPending >= maxPendingRequestsPerPeer {
+		if peer.id == exclude || height < peer.base || height > peer.height {
+			continue
+		}
+		delay := peer.expectedDelay(0)
+		if peer.rateMeasured && delay < minDelay {
+			minDelay = delay
+		}
+		if !peer.available(height, 0) {
 			continue
 		}
-		if height < peer.base || height > peer.height {
+		if best == nil {
+			best = peer
 			continue
 		}
-		peer.incrPending()
-		return peer
+		bestDelay := best.expectedDelay(0)
+		if delay < bestDelay || (delay == bestDelay && peer.numPending < best.numPending) {
+			best = peer
+		}
 	}
-	return nil
+	if best == nil || best.expectedDelay(0) > minDelay*slowPeerFactor {
+		return nil
+	}
+	best.incrPending()
+	return best
 }
 
-func (pool *BlockPool) makeNextRequester() {
+// cancelPending decrements the pending requests of the peer picked for a block,
+// which isn't requested from it.
+func (pool *BlockPool) cancelPending(peerID p2p.ID) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.decrPending(0)
+	}
+}
+
+// makeNextRequester returns false, if the next height is higher than the
+// peers have.
+func (pool *BlockPool) makeNextRequester() bool {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
 	nextHeight := pool.height + pool.requestersLen()
 	if nextHeight > pool.maxPeerHeight {
-		return
+		return false
 	}
 
 	request := newBPRequester(pool, nextHeight)
@@ -389,6 +555,7 @@ func (pool *BlockPool) makeNextRequester() {
 	if err != nil {
 		request.Logger.Error("Error starting request", "err", err)
 	}
+	return true
 }
 
 func (pool *BlockPool) requestersLen() int64 {
@@ -439,6 +606,13 @@ type bpPeer struct {
 	id          p2p.ID
 	recvMonitor *flow.Monitor
 
+	// the blocks per second received from the peer, measured once per
+	// rebalanceInterval
+	blocks       int64
+	rateUpdate   time.Time
+	blockRate    float64
+	rateMeasured bool
+
 	timeout *time.Timer
 
 	logger log.Logger
@@ -451,6 +625,7 @@ func newBPPeer(pool *BlockPool, peerID p2p.ID, base int64, height int64) *bpPeer
 		base:       base,
 		height:     height,
 		numPending: 0,
+		rateUpdate: time.Now(),
 		logger:     log.NewNopLogger(),
 	}
 	return peer
@@ -460,6 +635,46 @@ func (peer *bpPeer) setLogger(l log.Logger) {
 	peer.logger = l
 }
 
+// available returns true, if the peer has the block at the given height, and
+// can take one more request besides the extra ones.
+func (peer *bpPeer) available(height int64, extra int32) bool {
+	return peer.numPending+extra < maxPendingRequestsPerPeer && height >= peer.base && height <= peer.height
+}
+
+// expectedDelay returns the expected time in seconds for the peer to deliver
+// one more block besides its pending requests and the extra ones. It's zero,
+// if the peer's throughput isn't measured yet, so that it gets the requests.
+func (peer *bpPeer) expectedDelay(extra int32) float64 {
+	if !peer.rateMeasured {
+		return 0
+	}
+	if peer.blockRate == 0 {
+		return math.Inf(1)
+	}
+	return float64(peer.numPending+extra+1) / peer.blockRate
+}
+
+// updateRate updates the moving average of the blocks per second received
+// from the peer, if at least rebalanceInterval passed since the last update.
+// The periods, when nothing is requested from the peer, are skipped.
+func (peer *bpPeer) updateRate(now time.Time) {
+	elapsed := now.Sub(peer.rateUpdate)
+	if elapsed < rebalanceInterval {
+		return
+	}
+	if peer.blocks > 0 || peer.numPending > 0 {
+		rate := float64(peer.blocks) / elapsed.Seconds()
+		if peer.rateMeasured {
+			peer.blockRate = 0.5*peer.blockRate + 0.5*rate
+		} else {
+			peer.blockRate = rate
+			peer.rateMeasured = true
+		}
+	}
+	peer.blocks = 0
+	peer.rateUpdate = now
+}
+
 func (peer *bpPeer) resetMonitor() {
 	peer.recvMonitor = flow.New(time.Second, time.Second*40)
 	initialValue := float64(minRecvRate) * math.E
@@ -511,9 +726,10 @@ type bpRequester struct {
 	gotBlockCh chan struct{}
 	redoCh     chan p2p.ID // redo may send multitime, add peerId to identify repeat
 
-	mtx    tmsync.Mutex
-	peerID p2p.ID
-	block  *types.Block
+	mtx       tmsync.Mutex
+	peerID    p2p.ID
+	block     *types.Block
+	movedFrom p2p.ID // the other peer the block is requested from, till it sends it
 }
 
 func newBPRequester(pool *BlockPool, height int64) *bpRequester {
@@ -535,12 +751,22 @@ func (bpr *bpRequester) OnStart() error {
 	return nil
 }
 
-// Returns true if the peer matches and block doesn't already exist.
-func (bpr *bpRequester) setBlock(block *types.Block, peerID p2p.ID) bool {
+// Returns whether the block is expected from the peer: either the peer
+// matches, or the request was moved away from it; and whether it's stored,
+// since it doesn't already exist.
+func (bpr *bpRequester) setBlock(block *types.Block, peerID p2p.ID) (expected, stored bool) {
 	bpr.mtx.Lock()
-	if bpr.block != nil || bpr.peerID != peerID {
+	switch {
+	case bpr.movedFrom == peerID && bpr.block != nil:
+		bpr.movedFrom = ""
 		bpr.mtx.Unlock()
-		return false
+		return true, false
+	case bpr.movedFrom == peerID:
+		// the block from the peer the request was moved to is not needed
+		bpr.movedFrom, bpr.peerID = bpr.peerID, peerID
+	case bpr.block != nil || bpr.peerID != peerID:
+		bpr.mtx.Unlock()
+		return false, false
 	}
 	bpr.block = block
 	bpr.mtx.Unlock()
@@ -549,7 +775,7 @@ func (bpr *bpRequester) setBlock(block *types.Block, peerID p2p.ID) bool {
 	case bpr.gotBlockCh <- struct{}{}:
 	default:
 	}
-	return true
+	return true, true
 }
 
 func (bpr *bpRequester) getBlock() *types.Block {
@@ -564,6 +790,21 @@ func (bpr *bpRequester) getPeerID() p2p.ID {
 	return bpr.peerID
 }
 
+func (bpr *bpRequester) getMovedFrom() p2p.ID {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	return bpr.movedFrom
+}
+
+// forgetMovedFrom stops expecting the block from the removed peer.
+func (bpr *bpRequester) forgetMovedFrom(peerID p2p.ID) {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	if bpr.movedFrom == peerID {
+		bpr.movedFrom = ""
+	}
+}
+
 // This is called from the requestRoutine, upon redo().
 func (bpr *bpRequester) reset() {
 	bpr.mtx.Lock()
@@ -587,6 +828,25 @@ func (bpr *bpRequester) redo(peerID p2p.ID) {
 	}
 }
 
+// Tells bpRequester to request the block from another peer than the given
+// slow one, if it's still waiting for the block from it. The block is taken
+// from whichever peer sends it first.
+func (bpr *bpRequester) moveFrom(peerID p2p.ID) bool {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+
+	if bpr.block != nil || bpr.peerID != peerID || bpr.movedFrom != "" {
+		return false
+	}
+	select {
+	case bpr.redoCh <- "":
+		bpr.movedFrom, bpr.peerID = peerID, ""
+		return true
+	default:
+		return false
+	}
+}
+
 // Responsible for making more requests as necessary
 // Returns only when a block is found (e.g. AddBlock() is called)
 func (bpr *bpRequester) requestRoutine() {
@@ -599,7 +859,7 @@ OUTER_LOOP:
 			if !bpr.IsRunning() || !bpr.pool.IsRunning() {
 				return
 			}
-			peer = bpr.pool.pickIncrAvailablePeer(bpr.height)
+			peer = bpr.pool.pickIncrAvailablePeer(bpr.height, bpr.getMovedFrom())
 			if peer == nil {
 				// log.Info("No peers available", "height", height)
 				time.Sleep(requestIntervalMS * time.Millisecond)
@@ -608,11 +868,19 @@ OUTER_LOOP:
 			break PICK_PEER_LOOP
 		}
 		bpr.mtx.Lock()
-		bpr.peerID = peer.id
+		received := bpr.block != nil
+		if !received {
+			bpr.peerID = peer.id
+		}
 		bpr.mtx.Unlock()
 
-		// Send request and wait.
-		bpr.pool.sendRequest(bpr.height, peer.id)
+		if received {
+			// The peer the request was moved away from has sent the block.
+			bpr.pool.cancelPending(peer.id)
+		} else {
+			// Send request and wait.
+			bpr.pool.sendRequest(bpr.height, peer.id)
+		}
 	WAIT_LOOP:
 		for {
 			select {
@@ -624,7 +892,7 @@ OUTER_LOOP:
 			case <-bpr.Quit():
 				return
 			case peerID := <-bpr.redoCh:
-				if peerID == bpr.peerID {
+				if peerID == bpr.getPeerID() {
 					bpr.reset()
 					continue OUTER_LOOP
 				} else {
@@ -2,6 +2,7 @@ package v0
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 
 func init() {
 	peerTimeout = 2 * time.Second
+	rebalanceInterval = 100 * time.Millisecond
 }
 
 type testPeer struct {
@@ -240,3 +242,136 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+// testBlockSize is the size of the blocks served by the syncPeers, big
+// enough for the slow peers not to be considered timed out.
+const testBlockSize = 10 * 1024
+
+// syncPeers syncs the pool with the peers serving the requests one by one,
+// each block taking the peer's delay, till the given height. It returns the
+// time it took, the number of the blocks served by each peer, and the maximum
+// number of the requests pending on the peers at once.
+func syncPeers(t *testing.T, pool *BlockPool, height int64,
+	delays map[p2p.ID]time.Duration) (time.Duration, map[p2p.ID]int, int) {
+	t.Helper()
+
+	requestsCh := make(chan BlockRequest, maxTotalRequesters)
+	errorsCh := make(chan peerError, 1000)
+	pool.requestsCh, pool.errorsCh = requestsCh, errorsCh
+	pool.SetLogger(log.TestingLogger())
+	require.NoError(t, pool.Start())
+	t.Cleanup(func() {
+		if err := pool.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var (
+		mtx                 sync.Mutex
+		served              = make(map[p2p.ID]int)
+		pending, maxPending int
+	)
+	inputs := make(map[p2p.ID]chan BlockRequest)
+	for peerID, delay := range delays {
+		peerID, delay := peerID, delay
+		inputCh := make(chan BlockRequest, maxTotalRequesters)
+		inputs[peerID] = inputCh
+		go func() {
+			for request := range inputCh {
+				time.Sleep(delay)
+				pool.AddBlock(peerID, &types.Block{Header: types.Header{Height: request.Height}}, testBlockSize)
+				mtx.Lock()
+				served[peerID]++
+				pending--
+				mtx.Unlock()
+			}
+		}()
+		t.Cleanup(func() { close(inputCh) })
+	}
+
+	start := time.Now()
+	for peerID := range delays {
+		pool.SetPeerRange(peerID, 1, height+1)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case request := <-requestsCh:
+				mtx.Lock()
+				if pending++; pending > maxPending {
+					maxPending = pending
+				}
+				mtx.Unlock()
+				inputs[request.PeerID] <- request
+			case err := <-errorsCh:
+				t.Error(err)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		poolHeight, _, _ := pool.GetStatus()
+		if poolHeight > height {
+			break
+		}
+		if time.Since(start) > time.Minute {
+			t.Fatalf("timed out syncing at height %d", poolHeight)
+		}
+		if first, second := pool.PeekTwoBlocks(); first != nil && second != nil {
+			pool.PopRequest()
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	duration := time.Since(start)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	return duration, served, maxPending
+}
+
+func TestBlockPoolParallelPeers(t *testing.T) {
+	const (
+		height = 5000
+		delay  = 500 * time.Microsecond
+	)
+
+	onePeer, served, _ := syncPeers(t, NewBlockPool(1, nil, nil), height, map[p2p.ID]time.Duration{"a": delay})
+	assert.GreaterOrEqual(t, served["a"], height)
+
+	delays := map[p2p.ID]time.Duration{"a": delay, "b": delay, "c": delay, "d": delay}
+	fourPeers, served, _ := syncPeers(t, NewBlockPool(1, nil, nil), height, delays)
+	for peerID := range delays {
+		assert.Greater(t, served[peerID], height/8, "peer %s", peerID)
+	}
+
+	t.Logf("synced %d blocks in %v from 1 peer, in %v from 4 peers", height, onePeer, fourPeers)
+	assert.Less(t, int64(fourPeers), int64(onePeer/2))
+}
+
+func TestBlockPoolRebalance(t *testing.T) {
+	const height = 1000
+
+	fast, _, _ := syncPeers(t, NewBlockPool(1, nil, nil), height,
+		map[p2p.ID]time.Duration{"fast": time.Millisecond})
+
+	// the requests pending on the slow peer are moved to the fast one
+	mixed, served, _ := syncPeers(t, NewBlockPool(1, nil, nil), height,
+		map[p2p.ID]time.Duration{"fast": time.Millisecond, "slow": 500 * time.Millisecond})
+	t.Logf("synced %d blocks in %v from the fast peer, in %v from the fast and slow peers", height, fast, mixed)
+	assert.Less(t, served["slow"], height/10)
+	assert.Less(t, int64(mixed), int64(fast+2*time.Second))
+}
+
+func TestBlockPoolMaxInFlightBytes(t *testing.T) {
+	pool := NewBlockPool(1, nil, nil)
+	pool.maxInFlightBytes = 10 * testBlockSize
+
+	_, _, maxPending := syncPeers(t, pool, 300,
+		map[p2p.ID]time.Duration{"a": time.Millisecond, "b": time.Millisecond})
+	assert.LessOrEqual(t, maxPending, 10)
+}
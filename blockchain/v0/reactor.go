@@ -21,6 +21,9 @@ const (
 
 	trySyncIntervalMS = 10
 
+	// number of the fetched blocks prepared ahead of the verification
+	verifyQueueSize = 64
+
 	// stop syncing when last block's time is
 	// within this much of the system time.
 	// stopSyncingDurationMinutes = 10
@@ -63,9 +66,12 @@ type BlockchainReactor struct {
 	errorsCh   <-chan peerError
 }
 
+// ReactorOption sets an optional parameter on the BlockchainReactor.
+type ReactorOption func(*BlockchainReactor)
+
 // NewBlockchainReactor returns new reactor instance.
 func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore, nodeProTxHash *crypto.ProTxHash,
-	fastSync bool) *BlockchainReactor {
+	fastSync bool, options ...ReactorOption) *BlockchainReactor {
 
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
@@ -94,9 +100,24 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		errorsCh:     errorsCh,
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
+
+	for _, option := range options {
+		option(bcR)
+	}
 	return bcR
 }
 
+// ReactorMetrics sets the metrics
+func ReactorMetrics(metrics *Metrics) ReactorOption {
+	return func(bcR *BlockchainReactor) { bcR.pool.metrics = metrics }
+}
+
+// ReactorMaxInFlightBytes limits the estimated size of the blocks requested
+// at once. No limit, if 0.
+func ReactorMaxInFlightBytes(max int64) ReactorOption {
+	return func(bcR *BlockchainReactor) { bcR.pool.maxInFlightBytes = max }
+}
+
 // SetLogger implements service.Service by setting the logger on reactor and pool.
 func (bcR *BlockchainReactor) SetLogger(l log.Logger) {
 	bcR.BaseService.Logger = l
@@ -258,9 +279,6 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 // NOTE: Don't sleep in the FOR_LOOP or otherwise slow it down!
 func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
 
-	trySyncTicker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
-	defer trySyncTicker.Stop()
-
 	statusUpdateTicker := time.NewTicker(statusUpdateIntervalSeconds * time.Second)
 	defer statusUpdateTicker.Stop()
 
@@ -275,7 +293,28 @@ func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
 	lastHundred := time.Now()
 	lastRate := 0.0
 
-	didProcessCh := make(chan struct{}, 1)
+	// The blocks are fetched from the pool ahead of the verification, which
+	// waits for the next block to verify the first one with its commit. The
+	// commits of the quorum of the latest state are verified while fetching.
+	verifier := newCommitVerifier(chainID, state.Validators)
+	var (
+		first     *fetchedBlock
+		fetchedCh <-chan *fetchedBlock
+		stopFetch chan struct{}
+	)
+	// restartFetch fetches the blocks from the pool height again, once the
+	// fetched ones are redone.
+	restartFetch := func() {
+		if stopFetch != nil {
+			close(stopFetch)
+		}
+		stopFetch = make(chan struct{})
+		first = nil
+		height, _, _ := bcR.pool.GetStatus()
+		fetchedCh = bcR.fetchBlocksRoutine(height, verifier, stopFetch)
+	}
+	restartFetch()
+	defer func() { close(stopFetch) }()
 
 	go func() {
 		for {
@@ -337,74 +376,66 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 
-		case <-trySyncTicker.C: // chan time
-			select {
-			case didProcessCh <- struct{}{}:
-			default:
-			}
-
-		case <-didProcessCh:
-			// NOTE: It is a subtle mistake to process more than a single block
-			// at a time (e.g. 10) here, because we only TrySend 1 request per
-			// loop.  The ratio mismatch can result in starving of blocks, a
-			// sudden burst of requests and responses, and repeat.
-			// Consequently, it is better to split these routines rather than
-			// coupling them as it's written here.  TODO uncouple from request
-			// routine.
-
-			// See if there are any blocks to sync.
-			first, second := bcR.pool.PeekTwoBlocks()
-			// bcR.Logger.Info("TrySync peeked", "first", first, "second", second)
-			if first == nil || second == nil {
+		case second := <-fetchedCh:
+			if first == nil {
 				// We need both to sync the first block.
+				first = second
+				continue FOR_LOOP
+			}
+			// The blocks may have been redone since they were fetched.
+			if block1, block2 := bcR.pool.PeekTwoBlocks(); block1 != first.block || block2 != second.block {
+				restartFetch()
 				continue FOR_LOOP
-			} else {
-				// Try again quickly next loop.
-				didProcessCh <- struct{}{}
 			}
 
-			firstParts := first.MakePartSet(types.BlockPartSizeBytes)
-			firstPartSetHeader := firstParts.Header()
-			firstID := types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
-			firstStateID := types.StateID{LastAppHash: first.Header.AppHash}
-
-			// Finally, verify the first block using the second's commit
-			// NOTE: we can probably make this more efficient, but note that calling
-			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
-			// currently necessary.
-			err := state.Validators.VerifyCommit(
-				chainID, firstID, firstStateID, first.Height, second.LastCommit)
+			firstStateID := types.StateID{LastAppHash: first.block.Header.AppHash}
+
+			// Finally, verify the first block using the second's commit, unless
+			// it's verified with the same quorum ahead.
+			var err error
+			if verified := second.prevVerified; verified != nil && sameQuorum(verified.vals, state.Validators) {
+				err = verified.err
+			} else {
+				err = state.Validators.VerifyCommit(
+					chainID, first.blockID, firstStateID, first.block.Height, second.block.LastCommit)
+			}
+			if err == nil {
+				err = bcR.blockExec.VerifyNotRolledBack(first.block.Height, first.blockID)
+			}
 			if err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
-				peerID := bcR.pool.RedoRequest(first.Height)
+				peerID := bcR.pool.RedoRequest(first.block.Height)
 				peer := bcR.Switch.Peers().Get(peerID)
 				if peer != nil {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
 					bcR.Switch.StopPeerForError(peer, fmt.Errorf("blockchainReactor validation error: %v", err))
 				}
-				peerID2 := bcR.pool.RedoRequest(second.Height)
+				peerID2 := bcR.pool.RedoRequest(second.block.Height)
 				peer2 := bcR.Switch.Peers().Get(peerID2)
 				if peer2 != nil && peer2 != peer {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
 					bcR.Switch.StopPeerForError(peer2, fmt.Errorf("blockchainReactor validation error: %v", err))
 				}
+				restartFetch()
 				continue FOR_LOOP
 			} else {
 				bcR.pool.PopRequest()
 
 				// TODO: batch saves so we dont persist to disk every block
-				bcR.store.SaveBlock(first, firstParts, second.LastCommit)
+				bcR.store.SaveBlock(first.block, first.parts, second.block.LastCommit)
 
 				// TODO: same thing for app - but we would need a way to
 				// get the hash without persisting the state
 				var err error
-				state, _, err = bcR.blockExec.ApplyBlock(state, bcR.nodeProTxHash, firstID, first)
+				state, _, err = bcR.blockExec.ApplyBlock(state, bcR.nodeProTxHash, first.blockID, first.block)
 				if err != nil {
 					// TODO This is bad, are we zombie?
-					panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
+					panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.block.Height,
+						first.block.Hash(), err))
 				}
+				verifier.setValidators(state.Validators)
 				blocksSynced++
 
 				if blocksSynced%100 == 0 {
@@ -413,6 +444,7 @@ FOR_LOOP:
 						"max_peer_height", bcR.pool.MaxPeerHeight(), "blocks/s", lastRate)
 					lastHundred = time.Now()
 				}
+				first = second
 			}
 			continue FOR_LOOP
 
@@ -422,6 +454,92 @@ FOR_LOOP:
 	}
 }
 
+// fetchedBlock is a block fetched from the pool along with its part set.
+type fetchedBlock struct {
+	block   *types.Block
+	parts   *types.PartSet
+	blockID types.BlockID
+	// the verification of the previous block with the commit of this one,
+	// nil if it's not verified ahead
+	prevVerified *verifiedCommit
+}
+
+// fetchBlocksRoutine passes the blocks starting from the given height to the
+// returned channel in order, as soon as the pool receives them. The part sets
+// of the blocks are made there, and the commits of the received blocks are
+// verified in batches of up to verifyWindowSize, while the previous blocks
+// are verified and applied, up to verifyQueueSize blocks ahead.
+func (bcR *BlockchainReactor) fetchBlocksRoutine(height int64, verifier *commitVerifier,
+	stop <-chan struct{}) <-chan *fetchedBlock {
+
+	fetchedCh := make(chan *fetchedBlock, verifyQueueSize)
+	go func() {
+		ticker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
+		defer ticker.Stop()
+
+		// The window holds the last passed block, which is verified with the
+		// commit of the next one, followed by the blocks to pass.
+		var (
+			window []*fetchedBlock
+			passed int
+		)
+		// flush verifies the window and passes its blocks, keeping the last
+		// one for the next window.
+		flush := func() bool {
+			for i, verified := range verifier.verifyCommits(window) {
+				verified := verified
+				window[i+1].prevVerified = &verified
+			}
+			for _, fetched := range window[passed:] {
+				select {
+				case fetchedCh <- fetched:
+				case <-stop:
+					return false
+				case <-bcR.Quit():
+					return false
+				}
+			}
+			window = window[len(window)-1:]
+			passed = 1
+			return true
+		}
+
+		for {
+			block := bcR.pool.PeekBlock(height)
+			if block == nil {
+				if len(window) > passed {
+					if !flush() {
+						return
+					}
+					continue
+				}
+				select {
+				case <-ticker.C:
+					continue
+				case <-stop:
+					return
+				case <-bcR.Quit():
+					return
+				}
+			}
+
+			// NOTE: calling block.Hash() doesn't verify the tx contents, so
+			// MakePartSet() is currently necessary.
+			parts := block.MakePartSet(types.BlockPartSizeBytes)
+			window = append(window, &fetchedBlock{
+				block:   block,
+				parts:   parts,
+				blockID: types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()},
+			})
+			height++
+			if len(window) > verifyWindowSize && !flush() {
+				return
+			}
+		}
+	}()
+	return fetchedCh
+}
+
 // BroadcastStatusRequest broadcasts `BlockStore` base and height.
 func (bcR *BlockchainReactor) BroadcastStatusRequest() error {
 	bm, err := bc.EncodeMsg(&bcproto.StatusRequest{})
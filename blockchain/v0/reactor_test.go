@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/crypto"
 
 	"github.com/stretchr/testify/assert"
@@ -57,7 +58,7 @@ func newBlockchainReactor(logger log.Logger, genDoc *types.GenesisDoc, privVals
 
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err = proxyApp.Start()
 	if err != nil {
 		panic(fmt.Errorf("error start app: %w", err))
@@ -108,6 +109,7 @@ func newBlockchainReactor(logger log.Logger, genDoc *types.GenesisDoc, privVals
 			lastCommit = types.NewCommit(vote.Height, vote.Round,
 				lastBlockMeta.BlockID, lastBlockMeta.StateID, nil, vote.BlockSignature,
 				vote.StateSignature)
+			lastCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
 		}
 
 		thisBlock := makeBlock(blockHeight, nil, state, lastCommit)
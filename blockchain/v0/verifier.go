@@ -0,0 +1,94 @@
+package v0
+
+import (
+	"bytes"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// maximum number of the commits verified in one batch
+	verifyWindowSize = 16
+)
+
+// commitVerifier verifies the commits of the fetched blocks ahead of their
+// application. The validator set of the latest applied state is cached
+// across the heights of its quorum, and the blocks of the quorum are
+// verified in batches with its threshold public key. The blocks of another
+// quorum are left to be verified, once the previous blocks are applied.
+type commitVerifier struct {
+	chainID string
+
+	mtx  tmsync.Mutex
+	vals *types.ValidatorSet
+}
+
+// verifiedCommit is the result of the verification of a block with the
+// commit of the next one.
+type verifiedCommit struct {
+	vals *types.ValidatorSet
+	err  error
+}
+
+func newCommitVerifier(chainID string, vals *types.ValidatorSet) *commitVerifier {
+	return &commitVerifier{chainID: chainID, vals: vals}
+}
+
+// setValidators replaces the cached validator set, if it belongs to another
+// quorum, i.e. once a validator set update block is applied.
+func (v *commitVerifier) setValidators(vals *types.ValidatorSet) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	if !sameQuorum(v.vals, vals) {
+		v.vals = vals
+	}
+}
+
+func (v *commitVerifier) validators() *types.ValidatorSet {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	return v.vals
+}
+
+// verifyCommits verifies each of the blocks but the last with the commit of
+// the next block, in one batch. The blocks are verified up to the first
+// block of another quorum than the cached one. It returns the result of each
+// verified block.
+func (v *commitVerifier) verifyCommits(blocks []*fetchedBlock) []verifiedCommit {
+	vals := v.validators()
+
+	var (
+		blockIDs []types.BlockID
+		stateIDs []types.StateID
+		heights  []int64
+		commits  []*types.Commit
+	)
+	for i := 0; i+1 < len(blocks); i++ {
+		block, commit := blocks[i].block, blocks[i+1].block.LastCommit
+		if !bytes.Equal(block.ValidatorsHash, vals.QuorumHash) || commit == nil {
+			break
+		}
+		blockIDs = append(blockIDs, blocks[i].blockID)
+		stateIDs = append(stateIDs, types.StateID{LastAppHash: block.AppHash})
+		heights = append(heights, block.Height)
+		commits = append(commits, commit)
+	}
+	if len(commits) == 0 {
+		return nil
+	}
+
+	errs := vals.VerifyCommits(v.chainID, blockIDs, stateIDs, heights, commits)
+	verified := make([]verifiedCommit, len(errs))
+	for i, err := range errs {
+		verified[i] = verifiedCommit{vals: vals, err: err}
+	}
+	return verified
+}
+
+// sameQuorum returns true, if the commits are verified the same way with
+// both validator sets.
+func sameQuorum(vals1, vals2 *types.ValidatorSet) bool {
+	return vals1.QuorumType == vals2.QuorumType && bytes.Equal(vals1.QuorumHash, vals2.QuorumHash) &&
+		vals1.ThresholdPublicKey.Equals(vals2.ThresholdPublicKey)
+}
@@ -0,0 +1,143 @@
+package v0
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// makeFetchedBlocks makes a chain of n blocks, each committed by the next
+// one, signed by the single genesis validator.
+func makeFetchedBlocks(t testing.TB, n int) (sm.State, []*fetchedBlock) {
+	validators, privVals, quorumHash, thresholdPublicKey := types.GenerateGenesisValidators(1)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		GenesisTime:        tmtime.Now(),
+		ChainID:            "verifier_test",
+		Validators:         validators,
+		ThresholdPublicKey: thresholdPublicKey,
+		QuorumHash:         quorumHash,
+	})
+	require.NoError(t, err)
+
+	blocks := make([]*fetchedBlock, n)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
+	for i := range blocks {
+		block := makeBlock(int64(i+1), nil, state, lastCommit)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		blocks[i] = &fetchedBlock{block: block, parts: parts, blockID: blockID}
+
+		vote, err := types.MakeVote(block.Height, blockID, types.StateID{LastAppHash: block.AppHash},
+			state.Validators, privVals[0], state.ChainID)
+		require.NoError(t, err)
+		lastCommit = types.NewCommit(vote.Height, vote.Round, vote.BlockID, vote.StateID, nil,
+			vote.BlockSignature, vote.StateSignature)
+		lastCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
+	}
+	return state, blocks
+}
+
+// verifyCommit verifies the block with the commit of the next one, the way
+// it's verified before it's applied.
+func verifyCommit(state sm.State, first, second *fetchedBlock) error {
+	return state.Validators.VerifyCommit(state.ChainID, first.blockID,
+		types.StateID{LastAppHash: first.block.AppHash}, first.block.Height, second.block.LastCommit)
+}
+
+// The blocks verified ahead are accepted and rejected the same way, as they
+// are verified one by one.
+func TestCommitVerifierDifferential(t *testing.T) {
+	state, blocks := makeFetchedBlocks(t, 3*verifyWindowSize+1)
+
+	// invalid signatures
+	blocks[3].block.LastCommit.ThresholdBlockSignature = blocks[4].block.LastCommit.ThresholdBlockSignature
+	blocks[5].block.LastCommit.ThresholdStateSignature = nil
+	// wrong block ID
+	blocks[7].blockID = types.BlockID{}
+	// wrong height
+	blocks[10].block.LastCommit.Height++
+	// wrong state ID
+	blocks[11].block.AppHash = crypto.CRandBytes(32)
+	// a commit of another chain
+	otherState, otherBlocks := makeFetchedBlocks(t, verifyWindowSize)
+	blocks[20].block.LastCommit = otherBlocks[1].block.LastCommit
+	blocks[19].blockID = otherBlocks[0].blockID
+	blocks[19].block.AppHash = otherBlocks[0].block.AppHash
+
+	verifier := newCommitVerifier(state.ChainID, state.Validators)
+	for start := 0; start+1 < len(blocks); start += verifyWindowSize {
+		end := start + verifyWindowSize + 1
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		verified := verifier.verifyCommits(blocks[start:end])
+		require.Len(t, verified, end-start-1)
+		for i, v := range verified {
+			first, second := blocks[start+i], blocks[start+i+1]
+			assert.Same(t, state.Validators, v.vals)
+			assert.Equal(t, verifyCommit(state, first, second), v.err, "height %d", first.block.Height)
+		}
+	}
+
+	// the blocks of another quorum are left to be verified, once the previous
+	// blocks are applied
+	assert.Empty(t, verifier.verifyCommits(otherBlocks))
+	verified := verifier.verifyCommits([]*fetchedBlock{blocks[0], blocks[1], otherBlocks[2], otherBlocks[3]})
+	require.Len(t, verified, 2)
+	assert.NoError(t, verified[0].err)
+	assert.Error(t, verified[1].err)
+	verifier.setValidators(otherState.Validators)
+	verified = verifier.verifyCommits(otherBlocks[2:4])
+	require.Len(t, verified, 1)
+	assert.NoError(t, verified[0].err)
+}
+
+func TestCommitVerifierSetValidators(t *testing.T) {
+	state, _ := makeFetchedBlocks(t, 1)
+	otherState, _ := makeFetchedBlocks(t, 1)
+
+	verifier := newCommitVerifier(state.ChainID, state.Validators)
+	verifier.setValidators(state.Validators.Copy())
+	assert.Same(t, state.Validators, verifier.validators())
+	verifier.setValidators(otherState.Validators)
+	assert.Same(t, otherState.Validators, verifier.validators())
+}
+
+// BenchmarkVerifyCommits compares the verification of the blocks one by one
+// with the validator set of each state, with the verification ahead in
+// batches with the cached validator set.
+func BenchmarkVerifyCommits(b *testing.B) {
+	const n = 4 * verifyWindowSize
+	state, blocks := makeFetchedBlocks(b, n+1)
+
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j++ {
+				if err := verifyCommit(state.Copy(), blocks[j], blocks[j+1]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run(fmt.Sprintf("window of %d", verifyWindowSize), func(b *testing.B) {
+		verifier := newCommitVerifier(state.ChainID, state.Validators)
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j += verifyWindowSize {
+				for _, v := range verifier.verifyCommits(blocks[j : j+verifyWindowSize+1]) {
+					if v.err != nil {
+						b.Fatal(v.err)
+					}
+				}
+				verifier.setValidators(state.Copy().Validators)
+			}
+		}
+	})
+}
@@ -482,6 +482,10 @@ func (bcR *BlockchainReactor) processBlock() error {
 			"first", first.Height, "second", second.Height)
 		return errBlockVerificationFailure
 	}
+	if err := bcR.blockExec.VerifyNotRolledBack(first.Height, firstID); err != nil {
+		bcR.Logger.Error("refusing to sync the block", "err", err)
+		return errBlockVerificationFailure
+	}
 
 	bcR.store.SaveBlock(first, firstParts, second.LastCommit)
 
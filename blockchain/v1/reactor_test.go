@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/crypto"
 
 	"github.com/stretchr/testify/assert"
@@ -93,7 +94,7 @@ func newBlockchainReactor(
 
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err = proxyApp.Start()
 	if err != nil {
 		panic(fmt.Errorf("error start app: %w", err))
@@ -132,6 +133,7 @@ func newBlockchainReactor(
 			// since there is only 1 vote, use it as threshold
 			lastCommit = types.NewCommit(vote.Height, vote.Round, lastBlockMeta.BlockID, lastBlockMeta.StateID,
 				state.Validators.QuorumHash, vote.BlockSignature, vote.StateSignature)
+			lastCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
 		}
 
 		thisBlock := makeBlock(blockHeight, nil, state, lastCommit)
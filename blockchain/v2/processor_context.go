@@ -48,7 +48,10 @@ func (pc *pContext) setState(state state.State) {
 
 func (pc pContext) verifyCommit(chainID string, blockID types.BlockID, stateID types.StateID,
 	height int64, commit *types.Commit) error {
-	return pc.state.Validators.VerifyCommit(chainID, blockID, stateID, height, commit)
+	if err := pc.state.Validators.VerifyCommit(chainID, blockID, stateID, height, commit); err != nil {
+		return err
+	}
+	return pc.applier.VerifyNotRolledBack(height, blockID)
 }
 
 func (pc *pContext) saveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
@@ -55,6 +55,7 @@ type blockVerifier interface {
 
 type blockApplier interface {
 	ApplyBlock(state state.State, nodeProTxHash *crypto.ProTxHash, blockID types.BlockID, block *types.Block) (state.State, int64, error)
+	VerifyNotRolledBack(height int64, blockID types.BlockID) error
 }
 
 // XXX: unify naming in this package around tmState
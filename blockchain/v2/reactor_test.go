@@ -7,6 +7,7 @@ import (
 	"sync"
 	"testing"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/crypto"
 
 	"github.com/stretchr/testify/assert"
@@ -103,6 +104,10 @@ func (mba *mockBlockApplier) ApplyBlock(
 	return state, 0, nil
 }
 
+func (mba *mockBlockApplier) VerifyNotRolledBack(height int64, blockID types.BlockID) error {
+	return nil
+}
+
 type mockSwitchIo struct {
 	mtx                 sync.Mutex
 	switchedToConsensus bool
@@ -172,7 +177,7 @@ func newTestReactor(p testReactorParams) *BlockchainReactor {
 	} else {
 		app := &testApp{}
 		cc := proxy.NewLocalClientCreator(app)
-		proxyApp := proxy.NewAppConns(cc)
+		proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 		err := proxyApp.Start()
 		if err != nil {
 			panic(fmt.Errorf("error start app: %w", err))
@@ -515,7 +520,7 @@ func newReactorStore(
 
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err = proxyApp.Start()
 	if err != nil {
 		panic(fmt.Errorf("error start app: %w", err))
@@ -559,6 +564,7 @@ func newReactorStore(
 			lastCommit = types.NewCommit(vote.Height, vote.Round,
 				lastBlockMeta.BlockID, lastBlockMeta.StateID, nil,
 				vote.BlockSignature, vote.StateSignature)
+			lastCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
 		}
 
 		thisBlock := makeBlock(blockHeight, nil, state, lastCommit)
@@ -0,0 +1,34 @@
+package local_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tendermint/tendermint/client/local"
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func ExampleNewFromConfig() {
+	// the configuration of the stopped node, whose home is ~/.tenderdash
+	config := cfg.DefaultConfig()
+	config.SetRoot("/home/user/.tenderdash")
+
+	r, err := local.NewFromConfig(config)
+	if err != nil {
+		log.Fatal(err) //nolint:gocritic
+	}
+	defer r.Close()
+
+	for height := r.BaseHeight(); height <= r.LatestHeight(); height++ {
+		block, err := r.BlockByHeight(height)
+		if err != nil {
+			log.Fatal(err)
+		}
+		vals, err := r.ValidatorSetByHeight(height)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("block %d %X with %d txs, validated by quorum %X\n",
+			height, block.Hash(), len(block.Txs), vals.QuorumHash)
+	}
+}
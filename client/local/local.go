@@ -0,0 +1,203 @@
+// Package local is the supported API to read the chain data of a node from
+// Go, without going through the RPC.
+//
+// A Reader reads the blocks, commits, validator sets and ABCI results from
+// the block and state stores, either of a node running in the same process
+// (NewFromNode) or of the data directory of a stopped node (NewFromConfig).
+// Unlike the store and state packages, which are internal to Tendermint and
+// change with it, the API of this package is kept stable.
+//
+// Not to be confused with rpc/client/local, which executes the RPC routes on
+// a running node.
+package local
+
+import (
+	"errors"
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmdb "github.com/tendermint/tendermint/libs/db"
+	nm "github.com/tendermint/tendermint/node"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrBlockNotFound is returned by BlockByHash if there's no block with the
+// hash in the block store.
+var ErrBlockNotFound = errors.New("block not found")
+
+// ErrHeightNotAvailable is returned for the heights, which are above the
+// latest height of the stores.
+type ErrHeightNotAvailable struct {
+	Height int64
+	Latest int64
+}
+
+func (e ErrHeightNotAvailable) Error() string {
+	return fmt.Sprintf("height %d is not available yet, the latest height is %d", e.Height, e.Latest)
+}
+
+// Reader reads the chain data from the block and state stores. It never writes
+// to the stores, and is safe for concurrent use.
+//
+// The heights below the base of the block store return sm.ErrPruned, and the
+// ones above the latest height return ErrHeightNotAvailable.
+type Reader struct {
+	blockStore sm.BlockStore
+	stateStore sm.Store
+
+	dbs []dbm.DB // closed by Close, if opened by NewFromConfig
+}
+
+// New returns a Reader of the given stores.
+func New(blockStore sm.BlockStore, stateStore sm.Store) *Reader {
+	return &Reader{
+		blockStore: blockStore,
+		stateStore: stateStore,
+	}
+}
+
+// NewFromNode returns a Reader of the stores of a node running in the same
+// process. The node keeps writing to the stores while they are read.
+func NewFromNode(node *nm.Node) *Reader {
+	return New(node.BlockStore(), node.StateStore())
+}
+
+// NewFromConfig opens the block and state databases of the stopped node
+// read-only and returns a Reader of them, which must be closed. It fails if
+// the databases are locked by a running node.
+func NewFromConfig(config *cfg.Config) (*Reader, error) {
+	r := &Reader{}
+	for _, id := range []string{"blockstore", "state"} {
+		db, err := tmdb.NewReadOnlyDB(id, dbm.BackendType(config.DBBackendFor(id)), config.DBDir())
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("can't open %s database, is the node running? %w", id, err)
+		}
+		r.dbs = append(r.dbs, db)
+	}
+	r.blockStore = store.NewBlockStore(r.dbs[0])
+	r.stateStore = sm.NewStore(r.dbs[1])
+	return r, nil
+}
+
+// Close closes the databases opened by NewFromConfig. The stores of the other
+// Readers are owned by someone else, so it does nothing.
+func (r *Reader) Close() error {
+	var errs []error
+	for _, db := range r.dbs {
+		if err := db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.dbs = nil
+	if len(errs) > 0 {
+		return fmt.Errorf("can't close the databases: %v", errs)
+	}
+	return nil
+}
+
+// BaseHeight returns the lowest height of the block store, the heights below
+// it were pruned. It's 0 if the store is empty.
+func (r *Reader) BaseHeight() int64 {
+	return r.blockStore.Base()
+}
+
+// LatestHeight returns the height of the latest block of the block store. It's
+// 0 if the store is empty.
+func (r *Reader) LatestHeight() int64 {
+	return r.blockStore.Height()
+}
+
+// BlockByHeight returns the block at the height.
+func (r *Reader) BlockByHeight(height int64) (*types.Block, error) {
+	if err := r.checkHeight(height, r.blockStore.Height()); err != nil {
+		return nil, err
+	}
+	block := r.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+	return block, nil
+}
+
+// BlockByHash returns the block with the hash, or ErrBlockNotFound.
+func (r *Reader) BlockByHash(hash tmbytes.HexBytes) (*types.Block, error) {
+	block := r.blockStore.LoadBlockByHash(hash)
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+	return block, nil
+}
+
+// CommitByHeight returns the commit of the block at the height. The commit of
+// the latest block is the one seen by the node, the others are the canonical
+// commits included in the next blocks.
+func (r *Reader) CommitByHeight(height int64) (*types.Commit, error) {
+	latest := r.blockStore.Height()
+	if err := r.checkHeight(height, latest); err != nil {
+		return nil, err
+	}
+	var commit *types.Commit
+	if height == latest {
+		commit = r.blockStore.LoadSeenCommit(height)
+	} else {
+		commit = r.blockStore.LoadBlockCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("no commit at height %d", height)
+	}
+	return commit, nil
+}
+
+// ValidatorSetByHeight returns the validator set, which validates the block at
+// the height. The validator set of the next height, after the latest one, is
+// known too.
+func (r *Reader) ValidatorSetByHeight(height int64) (*types.ValidatorSet, error) {
+	if err := r.checkHeight(height, r.blockStore.Height()+1); err != nil {
+		return nil, err
+	}
+	return r.stateStore.LoadValidators(height)
+}
+
+// ABCIResultsByHeight returns the responses of the application to the block
+// at the height.
+func (r *Reader) ABCIResultsByHeight(height int64) (*tmstate.ABCIResponses, error) {
+	if err := r.checkHeight(height, r.blockStore.Height()); err != nil {
+		return nil, err
+	}
+	return r.stateStore.LoadABCIResponses(height)
+}
+
+// StateIDByHeight returns the state ID signed by the commit of the block at the
+// height, i.e. the app hash of the state the block was executed on.
+func (r *Reader) StateIDByHeight(height int64) (types.StateID, error) {
+	if err := r.checkHeight(height, r.blockStore.Height()); err != nil {
+		return types.StateID{}, err
+	}
+	meta := r.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return types.StateID{}, fmt.Errorf("no block at height %d", height)
+	}
+	return types.StateID{LastAppHash: meta.Header.AppHash}, nil
+}
+
+// checkHeight checks the height is between the base of the block store and
+// the latest one.
+func (r *Reader) checkHeight(height, latest int64) error {
+	if height <= 0 {
+		return fmt.Errorf("height must be greater than 0, but got %d", height)
+	}
+	if height > latest {
+		return ErrHeightNotAvailable{Height: height, Latest: latest}
+	}
+	if base := r.blockStore.Base(); height < base {
+		return sm.ErrPruned{Height: height, Base: base}
+	}
+	return nil
+}
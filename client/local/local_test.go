@@ -0,0 +1,157 @@
+package local_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/client/local"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// startNode starts a node, which persists its databases, and returns the
+// blocks of the first heights it commits.
+func startNode(t *testing.T, config *cfg.Config, heights int) (*node.Node, []dbm.DB, map[int64]*types.Block) {
+	// the databases are closed once the process of the node exits
+	var dbs []dbm.DB
+	dbProvider := func(ctx *node.DBContext) (dbm.DB, error) {
+		db, err := node.DefaultDBProvider(ctx)
+		if err == nil {
+			dbs = append(dbs, db)
+		}
+		return db, err
+	}
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := node.NewNode(config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		node.DefaultGenesisDocProviderFunc(config),
+		dbProvider,
+		node.DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "local_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+
+	blocks := make(map[int64]*types.Block)
+	for len(blocks) < heights {
+		select {
+		case msg := <-blocksSub.Out():
+			block := msg.Data().(types.EventDataNewBlock).Block
+			blocks[block.Height] = block
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+	return n, dbs, blocks
+}
+
+// checkReader checks that the reader returns the blocks committed by the node.
+func checkReader(t *testing.T, r *local.Reader, blocks map[int64]*types.Block) {
+	for height, want := range blocks {
+		block, err := r.BlockByHeight(height)
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash(), block.Hash(), "height %d", height)
+
+		block, err = r.BlockByHash(want.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, height, block.Height)
+
+		commit, err := r.CommitByHeight(height)
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash(), commit.BlockID.Hash, "height %d", height)
+
+		stateID, err := r.StateIDByHeight(height)
+		require.NoError(t, err)
+		assert.Equal(t, commit.StateID, stateID, "height %d", height)
+
+		vals, err := r.ValidatorSetByHeight(height)
+		require.NoError(t, err)
+		assert.Equal(t, want.ValidatorsHash, vals.Hash(), "height %d", height)
+
+		results, err := r.ABCIResultsByHeight(height)
+		require.NoError(t, err)
+		assert.Len(t, results.DeliverTxs, len(want.Txs), "height %d", height)
+	}
+}
+
+func TestReader(t *testing.T) {
+	config := cfg.ResetTestRoot("local_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	// the databases are persisted and locked like the ones of a node
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	n, dbs, blocks := startNode(t, config, 3)
+
+	// in-process with the running node
+	r := local.NewFromNode(n)
+	checkReader(t, r, blocks)
+	assert.NoError(t, r.Close())
+
+	_, err := local.NewFromConfig(config)
+	require.Error(t, err, "the databases are locked by the running node")
+	assert.Contains(t, err.Error(), "is the node running")
+
+	require.NoError(t, n.Stop())
+	for _, db := range dbs {
+		require.NoError(t, db.Close())
+	}
+
+	// against the data directory of the stopped node
+	r, err = local.NewFromConfig(config)
+	require.NoError(t, err)
+	defer r.Close() //nolint:errcheck // ignore for tests
+	checkReader(t, r, blocks)
+
+	latest := r.LatestHeight()
+	assert.EqualValues(t, 1, r.BaseHeight())
+	_, err = r.BlockByHeight(latest + 1)
+	assert.Equal(t, local.ErrHeightNotAvailable{Height: latest + 1, Latest: latest}, err)
+	_, err = r.CommitByHeight(0)
+	assert.Error(t, err)
+	_, err = r.BlockByHash(make([]byte, 32))
+	assert.Equal(t, local.ErrBlockNotFound, err)
+
+	// the validators of the next height are known
+	_, err = r.ValidatorSetByHeight(latest + 1)
+	assert.NoError(t, err)
+	_, err = r.ValidatorSetByHeight(latest + 2)
+	assert.Equal(t, local.ErrHeightNotAvailable{Height: latest + 2, Latest: latest + 1}, err)
+}
+
+func TestReaderPruned(t *testing.T) {
+	blockStore := new(prunedBlockStore)
+	r := local.New(blockStore, nil)
+
+	_, err := r.BlockByHeight(4)
+	assert.Equal(t, sm.ErrPruned{Height: 4, Base: 5}, err)
+	_, err = r.ABCIResultsByHeight(4)
+	assert.Equal(t, sm.ErrPruned{Height: 4, Base: 5}, err)
+	_, err = r.BlockByHeight(11)
+	assert.Equal(t, local.ErrHeightNotAvailable{Height: 11, Latest: 10}, err)
+}
+
+// prunedBlockStore is an empty block store, which was pruned up to the height
+// 5, with the latest height 10.
+type prunedBlockStore struct {
+	sm.BlockStore
+}
+
+func (prunedBlockStore) Base() int64   { return 5 }
+func (prunedBlockStore) Height() int64 { return 10 }
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/evidence"
+	tmdb "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+)
+
+// CompactCmd deletes the keys left behind in the block, state and evidence
+// databases and compacts them, to reclaim the space of the pruned heights.
+var CompactCmd = &cobra.Command{
+	Use:   "experimental-compact",
+	Short: "Delete the orphaned keys of the databases and compact them",
+	Long: `Delete the keys left behind in the block, state and evidence databases
+below the base of the block store (the retain height of the last prune) and
+by interrupted writes, then compact the databases to reclaim the space of the
+deleted keys (goleveldb and pebble only) and report the bytes reclaimed.
+
+The node must be stopped, the command refuses to run while the databases are
+locked by the node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := compactDatabases(config, logger)
+		return err
+	},
+}
+
+// compactedDB is a database compacted by the experimental-compact command.
+type compactedDB struct {
+	id string
+	db dbm.DB
+	// the number of the keys deleted
+	deleted uint64
+}
+
+// compactDatabases prunes and compacts the databases of the node. It returns
+// the number of bytes reclaimed.
+func compactDatabases(config *cfg.Config, logger log.Logger) (int64, error) {
+	// The databases are all opened first, so that nothing is touched if the
+	// node holds the lock of any of them.
+	dbs := []*compactedDB{{id: "blockstore"}, {id: "state"}, {id: "evidence"}}
+	for _, cdb := range dbs {
+		if dbm.BackendType(config.DBBackendFor(cdb.id)) == dbm.MemDBBackend {
+			return 0, fmt.Errorf("the memdb backend of the %s database isn't persisted, nothing to compact", cdb.id)
+		}
+	}
+	defer func() {
+		for _, cdb := range dbs {
+			if cdb.db == nil {
+				continue
+			}
+			if err := cdb.db.Close(); err != nil {
+				logger.Error("Failed to close database", "db", cdb.id, "err", err)
+			}
+		}
+	}()
+	sizesBefore := make(map[string]int64, len(dbs))
+	for _, cdb := range dbs {
+		size, err := dbSize(config, cdb.id)
+		if err != nil {
+			return 0, err
+		}
+		sizesBefore[cdb.id] = size
+
+		cdb.db, err = node.DefaultDBProvider(&node.DBContext{ID: cdb.id, Config: config})
+		if err != nil {
+			// the databases are locked by the running node
+			return 0, fmt.Errorf("can't open %s database, is the node running? %w", cdb.id, err)
+		}
+	}
+	blockStoreDB, stateDB, evidenceDB := dbs[0], dbs[1], dbs[2]
+
+	// The block store is the reference: the other data of the heights below
+	// its base can't be used anymore.
+	blockStore := store.NewBlockStore(blockStoreDB.db)
+	retainHeight := blockStore.Base()
+	logger.Info("Deleting orphaned keys", "retain_height", retainHeight, "height", blockStore.Height())
+
+	var err error
+	if blockStoreDB.deleted, err = blockStore.PruneOrphans(); err != nil {
+		return 0, fmt.Errorf("pruning block store: %w", err)
+	}
+	if stateDB.deleted, err = sm.PruneOrphans(stateDB.db, retainHeight); err != nil {
+		return 0, fmt.Errorf("pruning state: %w", err)
+	}
+	if retainHeight > 0 {
+		if evidenceDB.deleted, err = evidence.PruneOrphans(evidenceDB.db, retainHeight); err != nil {
+			return 0, fmt.Errorf("pruning evidence: %w", err)
+		}
+	}
+
+	for _, cdb := range dbs {
+		if err := compactDB(cdb.db); err != nil {
+			return 0, fmt.Errorf("compacting %s database: %w", cdb.id, err)
+		}
+		// the size is final once the database is closed
+		if err := cdb.db.Close(); err != nil {
+			return 0, fmt.Errorf("closing %s database: %w", cdb.id, err)
+		}
+		cdb.db = nil
+	}
+
+	var reclaimed int64
+	for _, cdb := range dbs {
+		size, err := dbSize(config, cdb.id)
+		if err != nil {
+			return 0, err
+		}
+		logger.Info("Compacted database", "db", cdb.id, "deleted_keys", cdb.deleted,
+			"size_before", sizesBefore[cdb.id], "size_after", size, "reclaimed", sizesBefore[cdb.id]-size)
+		reclaimed += sizesBefore[cdb.id] - size
+	}
+	logger.Info("Compacted databases", "reclaimed", reclaimed)
+	return reclaimed, nil
+}
+
+// compactDB compacts the whole database, if the backend supports it.
+func compactDB(db dbm.DB) error {
+	switch db := db.(type) {
+	case *dbm.GoLevelDB:
+		return db.DB().CompactRange(util.Range{})
+	case *tmdb.PebbleDB:
+		return db.Compact()
+	default:
+		// the other backends compact in the background
+		return nil
+	}
+}
+
+// dbSize returns the size of the files of the database on the disk.
+func dbSize(config *cfg.Config, id string) (int64, error) {
+	var size int64
+	err := filepath.Walk(filepath.Join(config.DBDir(), id+".db"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	return size, err
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	compactTestBlocks       = 1000
+	compactTestRetainHeight = 500
+)
+
+// orphanedBlockKeys are left behind in the block store by an interrupted
+// prune, and an interrupted write of the next block.
+var orphanedBlockKeys = []string{"H:100", "P:100:0", "C:100", "SC:100", "P:1001:0", "SC:1001"}
+
+// makeCompactFixture stores a chain of compactTestBlocks blocks pruned up to
+// compactTestRetainHeight, with orphaned keys left behind, and returns the
+// hashes of the blocks.
+func makeCompactFixture(t *testing.T) (*cfg.Config, map[int64][]byte) {
+	stores := openTestNodeStores(t, "compact_test")
+	defer stores.Close()
+	state, stateStore, blockStore := stores.state, stores.stateStore, stores.blockStore
+
+	hashes := make(map[int64][]byte, compactTestBlocks)
+	for h := int64(1); h <= compactTestBlocks; h++ {
+		block, parts := state.MakeBlock(h, nil, []types.Tx{types.Tx("tx")}, new(types.Commit), nil,
+			state.Validators.GetProposer().ProTxHash)
+		blockStore.SaveBlock(block, parts, new(types.Commit))
+		hashes[h] = block.Hash()
+
+		require.NoError(t, stateStore.SaveABCIResponses(h, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}},
+		}))
+		state.LastBlockHeight = h
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, stateStore.Save(state))
+	}
+
+	_, err := blockStore.PruneBlocks(compactTestRetainHeight)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.PruneStates(1, compactTestRetainHeight))
+
+	for _, key := range orphanedBlockKeys {
+		require.NoError(t, stores.blockStoreDB.Set([]byte(key), []byte("orphan")))
+	}
+	// left behind by an interrupted prune of the state
+	require.NoError(t, stateStore.SaveABCIResponses(100, new(tmstate.ABCIResponses)))
+	return stores.config, hashes
+}
+
+func TestCompactDatabases(t *testing.T) {
+	config, hashes := makeCompactFixture(t)
+
+	reclaimed, err := compactDatabases(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Positive(t, reclaimed)
+
+	// the databases reopen, and serve the heights left
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: config})
+	require.NoError(t, err)
+	defer stateDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	assert.EqualValues(t, compactTestRetainHeight, blockStore.Base())
+	assert.EqualValues(t, compactTestBlocks, blockStore.Height())
+	for _, key := range orphanedBlockKeys {
+		ok, err := blockStoreDB.Has([]byte(key))
+		require.NoError(t, err)
+		assert.False(t, ok, key)
+	}
+
+	stateStore := sm.NewStore(stateDB)
+	for h := int64(1); h <= compactTestBlocks; h++ {
+		if h < compactTestRetainHeight {
+			assert.Nil(t, blockStore.LoadBlock(h), "height %d", h)
+			_, err := stateStore.LoadABCIResponses(h)
+			assert.Equal(t, sm.ErrPruned{Height: h, Base: compactTestRetainHeight}, err)
+			continue
+		}
+		block := blockStore.LoadBlock(h)
+		require.NotNil(t, block, "height %d", h)
+		assert.Equal(t, hashes[h], block.Hash().Bytes(), "height %d", h)
+		assert.Equal(t, block, blockStore.LoadBlockByHash(hashes[h]))
+
+		_, err := stateStore.LoadABCIResponses(h)
+		require.NoError(t, err, "height %d", h)
+		_, err = stateStore.LoadValidators(h)
+		require.NoError(t, err, "height %d", h)
+	}
+}
+
+func TestCompactDatabasesRefusesWhileNodeIsRunning(t *testing.T) {
+	config, _ := makeCompactFixture(t)
+
+	// the node holds the lock of the state database
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: config})
+	require.NoError(t, err)
+	defer stateDB.Close()
+
+	_, err = compactDatabases(config, log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is the node running")
+
+	// nothing is deleted
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	for _, key := range orphanedBlockKeys {
+		ok, err := blockStoreDB.Has([]byte(key))
+		require.NoError(t, err)
+		assert.True(t, ok, key)
+	}
+}
@@ -38,4 +38,7 @@ func init() {
 
 	DebugCmd.AddCommand(killCmd)
 	DebugCmd.AddCommand(dumpCmd)
+	DebugCmd.AddCommand(walRepairCmd)
+	DebugCmd.AddCommand(dumpResultsCmd)
+	DebugCmd.AddCommand(diffResultsCmd)
 }
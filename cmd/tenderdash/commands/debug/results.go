@@ -0,0 +1,201 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/node"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+)
+
+var (
+	resultsHeight int64
+	resultsOutput string
+	resultsDump   string
+	resultsOther  string
+)
+
+var dumpResultsCmd = &cobra.Command{
+	Use:   "dump-results",
+	Short: "Dump the results of a block, to compare them with the ones of another node",
+	Long: `Dump the ABCI responses of the block at --height, as computed by the stopped
+node, in the format of the dump, which a node writes when the app hash of the
+next block doesn't match its own. The dump is written to --output, or to the
+diagnostics directory of the node.
+
+Example:
+$ tenderdash debug dump-results --height 1000 --output results-1000.json`,
+	Args: cobra.NoArgs,
+	RunE: dumpResultsCmdHandler,
+}
+
+var diffResultsCmd = &cobra.Command{
+	Use:   "diff-results",
+	Short: "Compare the results of a block with the ones of another node",
+	Long: `Compare the ABCI responses of the block at --height, as computed by this node,
+with the dump of another node given by --against, tx by tx, and print the first
+divergent DeliverTx result and event.
+
+The results of this node are read from --dump, or from the dump the node wrote
+on an app hash mismatch, or from the state store of the stopped node. The dump
+of the other node is written by the node on an app hash mismatch, or by
+dump-results.
+
+Example:
+$ tenderdash debug diff-results --height 1000 --against results-1000.json`,
+	Args: cobra.NoArgs,
+	RunE: diffResultsCmdHandler,
+}
+
+func init() {
+	dumpResultsCmd.Flags().Int64Var(&resultsHeight, "height", 0, "the height of the block")
+	dumpResultsCmd.Flags().StringVar(&resultsOutput, "output", "",
+		"the file to write the dump to (default is the diagnostics directory of the node)")
+
+	diffResultsCmd.Flags().Int64Var(&resultsHeight, "height", 0, "the height of the block")
+	diffResultsCmd.Flags().StringVar(&resultsOther, "against", "", "the results dump of the other node")
+	diffResultsCmd.Flags().StringVar(&resultsDump, "dump", "",
+		"the results dump of this node (default is the dump of the node or its state store)")
+}
+
+func dumpResultsCmdHandler(_ *cobra.Command, args []string) error {
+	if resultsHeight <= 0 {
+		return errors.New("the height must be given with --height")
+	}
+	conf := nodeConfig()
+	dump, err := loadResultsDump(conf, resultsHeight)
+	if err != nil {
+		return err
+	}
+
+	file := resultsOutput
+	if file == "" {
+		file = sm.ResultsDumpFile(conf.DiagnosticsDir(), resultsHeight)
+	}
+	if err := sm.WriteResultsDump(file, dump); err != nil {
+		return fmt.Errorf("failed to write the dump: %w", err)
+	}
+	logger.Info("dumped the results", "height", resultsHeight, "file", file)
+	return nil
+}
+
+func diffResultsCmdHandler(_ *cobra.Command, args []string) error {
+	if resultsHeight <= 0 {
+		return errors.New("the height must be given with --height")
+	}
+	if resultsOther == "" {
+		return errors.New("the dump of the other node must be given with --against")
+	}
+	other, err := sm.ReadResultsDump(resultsOther)
+	if err != nil {
+		return err
+	}
+	if other.Height != resultsHeight {
+		return fmt.Errorf("the dump of the other node is of the height %d, not %d", other.Height, resultsHeight)
+	}
+
+	conf := nodeConfig()
+	var local sm.ResultsDump
+	switch file := sm.ResultsDumpFile(conf.DiagnosticsDir(), resultsHeight); {
+	case resultsDump != "":
+		local, err = sm.ReadResultsDump(resultsDump)
+	case fileExists(file):
+		local, err = sm.ReadResultsDump(file)
+	default:
+		local, err = loadResultsDump(conf, resultsHeight)
+	}
+	if err != nil {
+		return err
+	}
+	if local.ChainID != other.ChainID {
+		return fmt.Errorf("the dumps are of different chains: %s and %s", local.ChainID, other.ChainID)
+	}
+
+	fmt.Printf("height %d, app hash %v (this node), %v (other node)\n", resultsHeight, local.AppHash, other.AppHash)
+	diff := sm.DiffResults(local.ABCIResponses, other.ABCIResponses)
+	if diff.Equal() {
+		fmt.Println("the results match")
+		return nil
+	}
+	fmt.Println(diff.Reason)
+	if diff.TxIndex < 0 {
+		return nil
+	}
+
+	marshaler := jsonpb.Marshaler{OrigName: true, Indent: "  "}
+	for _, res := range []struct {
+		node string
+		tx   *abci.ResponseDeliverTx
+	}{{"this node", diff.Local}, {"other node", diff.Other}} {
+		fmt.Printf("\nresult of the tx %d (%s):\n", diff.TxIndex, res.node)
+		if res.tx == nil {
+			fmt.Println("none")
+			continue
+		}
+		s, err := marshaler.MarshalToString(res.tx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(s)
+	}
+	if diff.EventIndex >= 0 {
+		fmt.Printf("\nthe first divergent event is %d\n", diff.EventIndex)
+	}
+	return nil
+}
+
+// nodeConfig returns the config of the node in the home directory.
+func nodeConfig() *cfg.Config {
+	conf := cfg.DefaultConfig()
+	if err := viper.Unmarshal(conf); err != nil {
+		logger.Error("failed to read the config, using the default one", "err", err)
+	}
+	return conf.SetRoot(viper.GetString(cli.HomeFlag))
+}
+
+// loadResultsDump loads the results of the block at the height from the state
+// store of the stopped node. The app hash is the one of the next block, if
+// it's stored.
+func loadResultsDump(conf *cfg.Config, height int64) (sm.ResultsDump, error) {
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: conf})
+	if err != nil {
+		return sm.ResultsDump{}, fmt.Errorf("can't open state database, is the node running? %w", err)
+	}
+	defer stateDB.Close()
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: conf})
+	if err != nil {
+		return sm.ResultsDump{}, fmt.Errorf("can't open blockstore database, is the node running? %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateStore := sm.NewStore(stateDB)
+	state, err := stateStore.Load()
+	if err != nil {
+		return sm.ResultsDump{}, err
+	}
+	abciResponses, err := stateStore.LoadABCIResponses(height)
+	if err != nil {
+		return sm.ResultsDump{}, err
+	}
+	dump := sm.ResultsDump{ChainID: state.ChainID, Height: height, ABCIResponses: abciResponses}
+	if meta := store.NewBlockStore(blockStoreDB).LoadBlockMeta(height + 1); meta != nil {
+		dump.AppHash = meta.Header.AppHash
+	} else if height == state.LastBlockHeight {
+		dump.AppHash = state.AppHash
+	}
+	return dump, nil
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
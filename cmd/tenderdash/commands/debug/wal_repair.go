@@ -0,0 +1,64 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/libs/cli"
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+var walRepairCmd = &cobra.Command{
+	Use:   "wal-repair [wal-file]",
+	Short: "Repair the consensus WAL, whose tail is corrupted",
+	Long: `Repair the consensus WAL, whose tail is corrupted, e.g. by a torn write on
+a power loss, by truncating it at the last valid record. The node does the same
+on startup. The WAL is not modified, if the corruption is followed by other
+data. The original WAL is backed up to <wal-file>.CORRUPTED, if it's modified.
+
+The node must be stopped. If the WAL file isn't given, the WAL of the node's
+home directory is repaired.
+
+Example:
+$ tenderdash debug wal-repair /path/to/data/cs.wal/wal`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: walRepairCmdHandler,
+}
+
+func walRepairCmdHandler(cmd *cobra.Command, args []string) error {
+	var walFile string
+	if len(args) > 0 {
+		walFile = args[0]
+	} else {
+		conf := cfg.DefaultConfig()
+		conf = conf.SetRoot(viper.GetString(cli.HomeFlag))
+		walFile = conf.Consensus.WalFile()
+	}
+
+	backupFile := walFile + ".CORRUPTED"
+	if err := tmos.CopyFile(walFile, backupFile); err != nil {
+		return fmt.Errorf("failed to back up the WAL: %w", err)
+	}
+
+	dropped, err := consensus.RepairWALTail(walFile)
+	if dropped == 0 {
+		if err := os.Remove(backupFile); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to repair the WAL: %w", err)
+	}
+
+	if dropped == 0 {
+		logger.Info("the WAL is not corrupted", "file", walFile)
+		return nil
+	}
+	logger.Info("repaired the WAL", "file", walFile, "dropped_bytes", dropped, "backup", backupFile)
+	return nil
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/inspect"
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+// InspectCmd serves a read-only subset of the RPC from the data directory of
+// a stopped node.
+var InspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Serve a read-only RPC over the data of a stopped node",
+	Long: `Open the block and state databases of a stopped node read-only and serve
+the status, block, block_results, commit, validators and consensus_params
+RPC routes from them on rpc.laddr, without running p2p nor consensus, to
+debug a halted node with the standard RPC tooling.
+
+Nothing is written to the databases. The command refuses to run while the
+databases are locked by the node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ins, err := inspect.NewFromConfig(config, logger)
+		if err != nil {
+			return err
+		}
+		if err := ins.Start(); err != nil {
+			return fmt.Errorf("failed to start inspector: %w", err)
+		}
+		logger.Info("Serving the RPC of the stopped node", "laddr", config.RPC.ListenAddress)
+
+		// Stop upon receiving SIGTERM or CTRL-C.
+		tmos.TrapSignal(logger, func() {
+			if err := ins.Stop(); err != nil {
+				logger.Error("unable to stop the inspector", "error", err)
+			}
+		})
+
+		// Run forever.
+		select {}
+	},
+}
+
+func init() {
+	InspectCmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// defaultKeyTransitionBlocks is the default number of heights of the
+// transition window of a key rotation.
+const defaultKeyTransitionBlocks = 100
+
+var (
+	rotateHeight           int64
+	rotateTransitionBlocks int64
+)
+
+// KeyCmd groups the commands managing the keys of the validator.
+var KeyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Manage the keys of the validator",
+}
+
+var rotateOperatorCmd = &cobra.Command{
+	Use:   "rotate-operator",
+	Short: "Generate a new BLS operator key, used once the validator set is updated with it",
+	Long: `Generate a new BLS operator key and write it alongside the current one in
+the private validator key file.
+
+From --height (the height after the last signed one by default), and during
+--transition-blocks heights, the validator signs with the key whose public key
+is the one of the validator in the validator set of the height: the current
+key until the validator set is updated with the new public key, then the new
+one. Once the transition window ends, the new key replaces the current one.
+
+The new public key, printed by the command, must be registered before the end
+of the transition window. The node must be restarted to load the new key.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pubKey, err := rotateOperatorKey(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(),
+			rotateHeight, rotateTransitionBlocks, logger)
+		if err != nil {
+			return err
+		}
+		fmt.Println(pubKey.HexString())
+		return nil
+	},
+}
+
+func init() {
+	rotateOperatorCmd.Flags().Int64Var(&rotateHeight, "height", 0,
+		"the first height of the transition window (default: the height after the last signed one)")
+	rotateOperatorCmd.Flags().Int64Var(&rotateTransitionBlocks, "transition-blocks", defaultKeyTransitionBlocks,
+		"the number of heights, during which either key signs")
+
+	KeyCmd.AddCommand(rotateOperatorCmd)
+}
+
+// rotateOperatorKey writes a new key alongside the current key of the private
+// validator, and returns its public key.
+func rotateOperatorKey(keyFile, stateFile string, height, transitionBlocks int64,
+	logger log.Logger) (crypto.PubKey, error) {
+	if !tmos.FileExists(keyFile) {
+		return nil, fmt.Errorf("private validator key file %s doesn't exist", keyFile)
+	}
+	var pv *privval.FilePV
+	if tmos.FileExists(stateFile) {
+		pv = privval.LoadFilePV(keyFile, stateFile)
+	} else {
+		pv = privval.LoadFilePVEmptyState(keyFile, stateFile)
+	}
+	if height == 0 {
+		height = pv.LastSignState.Height + 1
+	}
+
+	privKey := bls12381.GenPrivKey()
+	if err := pv.RotateKey(privKey, height, transitionBlocks); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Rotated operator key", "keyFile", keyFile, "pubKey", privKey.PubKey().HexString(),
+		"height", height, "endHeight", height+transitionBlocks)
+	return privKey.PubKey(), nil
+}
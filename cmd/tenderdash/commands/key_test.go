@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
+)
+
+func TestRotateOperatorKey(t *testing.T) {
+	config := cfg.ResetTestRoot("key_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	keyFile, stateFile := config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()
+
+	pv := privval.LoadFilePV(keyFile, stateFile)
+	pv.LastSignState.Height = 41
+	pv.LastSignState.Save()
+	quorumHash, err := pv.GetFirstQuorumHash()
+	require.NoError(t, err)
+	oldPubKey, err := pv.GetPubKey(quorumHash)
+	require.NoError(t, err)
+
+	pubKey, err := rotateOperatorKey(keyFile, stateFile, 0, 10, log.TestingLogger())
+	require.NoError(t, err)
+	assert.NotEqual(t, oldPubKey, pubKey)
+
+	// the new key is written alongside the old one, from the next height
+	pv = privval.LoadFilePV(keyFile, stateFile)
+	rotation, ok := pv.Key.Rotations[quorumHash.String()]
+	require.True(t, ok)
+	assert.Equal(t, pubKey, rotation.PubKey)
+	assert.EqualValues(t, 42, rotation.Height)
+	assert.EqualValues(t, 52, rotation.EndHeight())
+	currentPubKey, err := pv.GetPubKey(quorumHash)
+	require.NoError(t, err)
+	assert.Equal(t, oldPubKey, currentPubKey)
+
+	// a rotation is in progress
+	_, err = rotateOperatorKey(keyFile, stateFile, 0, 10, log.TestingLogger())
+	assert.Error(t, err)
+}
@@ -18,9 +18,15 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	lhttp "github.com/tendermint/tendermint/light/provider/http"
+	lp2p "github.com/tendermint/tendermint/light/provider/p2p"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	dbs "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/p2p"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 )
 
@@ -38,6 +44,12 @@ Furthermore to the chainID, a fresh instance of a light client will
 need a primary RPC address and witness RPC addresses. To restart the node, thereafter
 only the chainID is required.
 
+The primary and the witnesses can also be full nodes serving the light blocks
+over p2p, given as p2p://<node ID>@<host>:<port>. The light client then dials
+them with the node key of the home directory. As the calls which aren't
+verified are forwarded to the primary, a p2p primary requires an RPC address to
+forward them to (using --primary-rpc).
+
 When /abci_query is called, the Merkle key path format is:
 
 	/{store name}/{key}
@@ -48,21 +60,26 @@ for applications built w/ Cosmos SDK).
 	RunE: runProxy,
 	Args: cobra.ExactArgs(1),
 	Example: `light cosmoshub-3 -p http://52.57.29.196:26657 -w http://public-seed-node.cosmoshub.certus.one:26657
-	--height 962118 --hash 28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD`,
+	--height 962118 --hash 28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD
+light dash-testnet -p p2p://ad3b09cce2ee5e4e5b03b1e9b8ad4c1a5a0b4d8c@10.0.0.1:26656
+	-w p2p://1c5c8d3f1bd0ab7e1e2c7d8f5bd4ac8b6e1f2a3c@10.0.0.2:26656 --primary-rpc http://10.0.0.1:26657`,
 }
 
 var (
 	listenAddr         string
 	primaryAddr        string
+	primaryRPCAddr     string
 	witnessAddrsJoined string
+	archiveAddrsJoined string
 	chainID            string
 	home               string
 	maxOpenConnections int
 
 	verbose bool
 
-	primaryKey   = []byte("primary")
-	witnessesKey = []byte("witnesses")
+	primaryKey    = []byte("primary")
+	witnessesKey  = []byte("witnesses")
+	primaryRPCKey = []byte("primary-rpc")
 )
 
 func init() {
@@ -70,8 +87,12 @@ func init() {
 		"serve the proxy on the given address")
 	LightCmd.Flags().StringVarP(&primaryAddr, "primary", "p", "",
 		"connect to a Tendermint node at this address")
+	LightCmd.Flags().StringVar(&primaryRPCAddr, "primary-rpc", "",
+		"forward the calls, which aren't verified, to this RPC address, if the primary is a p2p address")
 	LightCmd.Flags().StringVarP(&witnessAddrsJoined, "witnesses", "w", "",
 		"tendermint nodes to cross-check the primary node, comma-separated")
+	LightCmd.Flags().StringVar(&archiveAddrsJoined, "archive-endpoints", "",
+		"archive nodes to fetch the data of the heights pruned by the primary node from, comma-separated")
 	LightCmd.Flags().StringVar(&home, "home-dir", os.ExpandEnv(filepath.Join("$HOME", ".tendermint-light")),
 		"specify the home directory")
 	LightCmd.Flags().IntVar(
@@ -108,7 +129,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 
 	if primaryAddr == "" { // check to see if we can start from an existing state
 		var err error
-		primaryAddr, witnessesAddrs, err = checkForExistingProviders(db)
+		primaryAddr, witnessesAddrs, primaryRPCAddr, err = checkForExistingProviders(db)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve primary or witness from db: %w", err)
 		}
@@ -117,7 +138,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 				" Run the command: tendermint light --help for more information")
 		}
 	} else {
-		err := saveProviders(db, primaryAddr, witnessAddrsJoined)
+		err := saveProviders(db, primaryAddr, witnessAddrsJoined, primaryRPCAddr)
 		if err != nil {
 			logger.Error("Unable to save primary and or witness addresses", "err", err)
 		}
@@ -144,11 +165,30 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		light.DashCoreVerification(),
 	}
 
-	c, err := light.NewHTTPClient(
+	forwardAddr := primaryAddr
+	if lp2p.IsAddress(primaryAddr) {
+		if primaryRPCAddr == "" {
+			return errors.New("the primary is a p2p address, please provide an RPC address to forward" +
+				" the calls to (using --primary-rpc)")
+		}
+		forwardAddr = primaryRPCAddr
+	}
+
+	var archiveAddrs []string
+	if archiveAddrsJoined != "" {
+		archiveAddrs = strings.Split(archiveAddrsJoined, ",")
+	}
+
+	providers, err := makeProviders(append(witnessesAddrs, primaryAddr), archiveAddrs, logger)
+	if err != nil {
+		return err
+	}
+
+	c, err := light.NewClient(
 		context.Background(),
 		chainID,
-		primaryAddr,
-		witnessesAddrs,
+		providers[len(providers)-1],
+		providers[:len(providers)-1],
 		dbs.New(db, chainID),
 		options...,
 	)
@@ -167,7 +207,20 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		cfg.WriteTimeout = config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
-	p, err := lproxy.NewProxy(c, listenAddr, primaryAddr, cfg, logger, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
+	proxyOpts := []lrpc.Option{lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn())}
+	if len(archiveAddrs) > 0 {
+		archives := []rpcclient.Client{}
+		for _, addr := range archiveAddrs {
+			archive, err := rpchttp.NewWithTimeout(addr, "/websocket", uint(cfg.WriteTimeout.Seconds()))
+			if err != nil {
+				return fmt.Errorf("failed to create http client for archive node %s: %w", addr, err)
+			}
+			archives = append(archives, archive)
+		}
+		proxyOpts = append(proxyOpts, lrpc.ArchiveClients(archives...))
+	}
+
+	p, err := lproxy.NewProxy(c, listenAddr, forwardAddr, cfg, logger, proxyOpts...)
 	if err != nil {
 		return err
 	}
@@ -186,20 +239,78 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func checkForExistingProviders(db dbm.DB) (string, []string, error) {
+// makeProviders creates the providers at the addresses. The p2p ones share a
+// p2p node, which is started here. The primary provider at the last address
+// falls back to the archive nodes for the pruned heights, if it's a HTTP one.
+func makeProviders(addrs []string, primaryArchives []string, logger log.Logger) ([]provider.Provider, error) {
+	var p2pAddrs []string
+	for _, addr := range addrs {
+		if lp2p.IsAddress(addr) {
+			p2pAddrs = append(p2pAddrs, addr)
+		}
+	}
+
+	var p2pProviders []provider.Provider
+	if len(p2pAddrs) > 0 {
+		nodeKey, err := p2p.LoadOrGenNodeKey(filepath.Join(home, "node_key.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load or generate the node key: %w", err)
+		}
+		node, err := lp2p.NewNode(chainID, nodeKey, config.P2P, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the p2p node: %w", err)
+		}
+		if err := node.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start the p2p node: %w", err)
+		}
+		p2pProviders, err = node.Providers(p2pAddrs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	providers := make([]provider.Provider, len(addrs))
+	for i, addr := range addrs {
+		if lp2p.IsAddress(addr) {
+			providers[i], p2pProviders = p2pProviders[0], p2pProviders[1:]
+			continue
+		}
+		var (
+			p   provider.Provider
+			err error
+		)
+		if i == len(addrs)-1 && len(primaryArchives) > 0 {
+			// the primary is the last one
+			p, err = lhttp.NewWithArchives(chainID, addr, primaryArchives)
+		} else {
+			p, err = lhttp.New(chainID, addr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		providers[i] = p
+	}
+	return providers, nil
+}
+
+func checkForExistingProviders(db dbm.DB) (string, []string, string, error) {
 	primaryBytes, err := db.Get(primaryKey)
 	if err != nil {
-		return "", []string{""}, err
+		return "", []string{""}, "", err
 	}
 	witnessesBytes, err := db.Get(witnessesKey)
 	if err != nil {
-		return "", []string{""}, err
+		return "", []string{""}, "", err
+	}
+	primaryRPCBytes, err := db.Get(primaryRPCKey)
+	if err != nil {
+		return "", []string{""}, "", err
 	}
 	witnessesAddrs := strings.Split(string(witnessesBytes), ",")
-	return string(primaryBytes), witnessesAddrs, nil
+	return string(primaryBytes), witnessesAddrs, string(primaryRPCBytes), nil
 }
 
-func saveProviders(db dbm.DB, primaryAddr, witnessesAddrs string) error {
+func saveProviders(db dbm.DB, primaryAddr, witnessesAddrs, primaryRPCAddr string) error {
 	err := db.Set(primaryKey, []byte(primaryAddr))
 	if err != nil {
 		return fmt.Errorf("failed to save primary provider: %w", err)
@@ -208,5 +319,9 @@ func saveProviders(db dbm.DB, primaryAddr, witnessesAddrs string) error {
 	if err != nil {
 		return fmt.Errorf("failed to save witness providers: %w", err)
 	}
+	err = db.Set(primaryRPCKey, []byte(primaryRPCAddr))
+	if err != nil {
+		return fmt.Errorf("failed to save primary RPC address: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmdb "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
+)
+
+const (
+	// migrateBatchSize is the number of keys written at once to the new
+	// database.
+	migrateBatchSize = 1000
+	// migrateProgressInterval is the interval of the progress reports.
+	migrateProgressInterval = 10 * time.Second
+)
+
+// migrateStores are the IDs of the databases of the stores, which can be
+// migrated.
+var migrateStores = []string{"blockstore", "state", "evidence", "tx_index", "light"}
+
+var (
+	migrateStore     string
+	migrateToBackend string
+)
+
+// MigrateDBCmd copies a store to a database of another backend.
+var MigrateDBCmd = &cobra.Command{
+	Use:   "migrate-db",
+	Short: "Migrate a store to another database backend",
+	Long: `Copy all the keys of the --store database (blockstore, state, evidence,
+tx_index or light) from its configured backend to a new database of the --to
+backend, then replace it by the new one. The old database is kept next to it,
+with the .bak-<backend> suffix, until it is deleted by hand.
+
+The backend of the store must then be set in storage.backends of the config,
+before starting the node. The node must be stopped, the command refuses to run
+while the database is locked by the node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrateDB(config, migrateStore, migrateToBackend, logger)
+	},
+}
+
+func init() {
+	MigrateDBCmd.Flags().StringVar(&migrateStore, "store", "",
+		"the store to migrate: blockstore, state, evidence, tx_index or light")
+	MigrateDBCmd.Flags().StringVar(&migrateToBackend, "to", "",
+		"the database backend to migrate the store to, e.g. pebble")
+}
+
+// migrateDB copies the database of the store to a database of the backend,
+// and replaces it by the copy.
+func migrateDB(config *cfg.Config, store, toBackend string, logger log.Logger) error {
+	if !tmstrings.StringInSlice(store, migrateStores) {
+		return fmt.Errorf("unknown store %q, expected one of %v", store, migrateStores)
+	}
+	from, to := dbm.BackendType(config.DBBackendFor(store)), dbm.BackendType(toBackend)
+	switch {
+	case to == "":
+		return errors.New("the backend to migrate to is required")
+	case from == to:
+		return fmt.Errorf("the %s store already uses the %s backend", store, to)
+	case from == dbm.MemDBBackend || to == dbm.MemDBBackend:
+		return errors.New("the memdb backend isn't persisted, nothing to migrate")
+	}
+
+	dbDir := config.DBDir()
+	srcPath := dbPath(dbDir, store, from)
+	backupPath := fmt.Sprintf("%s.bak-%s", srcPath, from)
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("can't find the %s database: %w", store, err)
+	}
+	if _, err := os.Stat(backupPath); err == nil {
+		return fmt.Errorf("the backup %s of a previous migration must be deleted first", backupPath)
+	}
+
+	src, err := tmdb.NewDB(store, from, dbDir)
+	if err != nil {
+		// the database is locked by the running node
+		return fmt.Errorf("can't open %s database, is the node running? %w", store, err)
+	}
+	// pebble panics if closed twice
+	defer func() {
+		if src != nil {
+			src.Close()
+		}
+	}()
+
+	// The keys are copied to a temporary database, which replaces the source
+	// once complete, so that an interrupted migration leaves it untouched.
+	tmpName := store + ".migrating"
+	tmpPath := dbPath(dbDir, tmpName, to)
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	dst, err := tmdb.NewDB(tmpName, to, dbDir)
+	if err != nil {
+		return fmt.Errorf("creating %s database: %w", to, err)
+	}
+	defer func() {
+		if dst != nil {
+			dst.Close()
+		}
+	}()
+
+	logger.Info("Migrating database", "db", store, "from", from, "to", to)
+	keys, err := copyDB(src, dst, store, logger)
+	if err != nil {
+		return fmt.Errorf("migrating %s database: %w", store, err)
+	}
+
+	err = src.Close()
+	src = nil
+	if err != nil {
+		return fmt.Errorf("closing %s database: %w", store, err)
+	}
+	err = dst.Close()
+	dst = nil
+	if err != nil {
+		return fmt.Errorf("closing %s database: %w", to, err)
+	}
+	if err := os.Rename(srcPath, backupPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, dbPath(dbDir, store, to)); err != nil {
+		return err
+	}
+
+	logger.Info("Migrated database", "db", store, "keys", keys, "backup", backupPath)
+	logger.Info(fmt.Sprintf("Set storage.backends.%s = %q in the config before starting the node", store, to))
+	return nil
+}
+
+// copyDB streams all the keys of the src database into the dst database, and
+// returns the number of keys copied.
+func copyDB(src, dst dbm.DB, id string, logger log.Logger) (uint64, error) {
+	itr, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+
+	var (
+		keys, size uint64
+		lastReport = time.Now()
+	)
+	batch := dst.NewBatch()
+	defer func() { batch.Close() }()
+	for ; itr.Valid(); itr.Next() {
+		key, value := itr.Key(), itr.Value()
+		if err := batch.Set(key, value); err != nil {
+			return keys, err
+		}
+		keys++
+		size += uint64(len(key) + len(value))
+
+		if keys%migrateBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				return keys, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+		if time.Since(lastReport) >= migrateProgressInterval {
+			logger.Info("Migrating database", "db", id, "keys", keys, "bytes", size)
+			lastReport = time.Now()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return keys, err
+	}
+	// the last batch is synced, which persists the ones before
+	if err := batch.WriteSync(); err != nil {
+		return keys, err
+	}
+	return keys, nil
+}
+
+// dbPath returns the path of the file or the directory of the database.
+func dbPath(dir, name string, backend dbm.BackendType) string {
+	if backend == dbm.BadgerDBBackend {
+		return filepath.Join(dir, name)
+	}
+	return filepath.Join(dir, name+".db")
+}
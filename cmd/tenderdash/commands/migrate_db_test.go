@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmdb "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+)
+
+const migrateTestKeys = 2500
+
+// makeMigrateFixture stores migrateTestKeys keys in a goleveldb block store.
+func makeMigrateFixture(t *testing.T) *cfg.Config {
+	config := cfg.ResetTestRoot("migrate_db_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	db, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer db.Close()
+	for i := 0; i < migrateTestKeys; i++ {
+		require.NoError(t, db.Set(migrateTestKey(i), []byte(fmt.Sprintf("value:%d", i))))
+	}
+	return config
+}
+
+func migrateTestKey(i int) []byte {
+	return []byte(fmt.Sprintf("key:%06d", i))
+}
+
+func TestMigrateDB(t *testing.T) {
+	config := makeMigrateFixture(t)
+
+	require.NoError(t, migrateDB(config, "blockstore", "pebble", log.TestingLogger()))
+
+	// the old database is kept
+	_, err := os.Stat(filepath.Join(config.DBDir(), "blockstore.db.bak-goleveldb"))
+	require.NoError(t, err)
+
+	// the store opens with its new backend, and serves the same keys
+	config.Storage.Backends.BlockStore = string(tmdb.PebbleDBBackend)
+	db, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer db.Close()
+	require.IsType(t, &tmdb.PebbleDB{}, db)
+
+	itr, err := db.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer itr.Close()
+	i := 0
+	for ; itr.Valid(); itr.Next() {
+		assert.Equal(t, migrateTestKey(i), itr.Key())
+		assert.Equal(t, []byte(fmt.Sprintf("value:%d", i)), itr.Value())
+		i++
+	}
+	require.NoError(t, itr.Error())
+	assert.Equal(t, migrateTestKeys, i)
+}
+
+func TestMigrateDBRefuses(t *testing.T) {
+	config := makeMigrateFixture(t)
+
+	err := migrateDB(config, "blockstore", "goleveldb", log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already uses")
+
+	err = migrateDB(config, "unknown", "pebble", log.TestingLogger())
+	require.Error(t, err)
+
+	// the node holds the lock of the database
+	db, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = migrateDB(config, "blockstore", "pebble", log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is the node running")
+
+	// the database is untouched
+	value, err := db.Get(migrateTestKey(0))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value:0"), value)
+	_, err = os.Stat(filepath.Join(config.DBDir(), "blockstore.db.bak-goleveldb"))
+	assert.True(t, os.IsNotExist(err))
+}
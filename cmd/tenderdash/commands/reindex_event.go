@@ -0,0 +1,292 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// reindexCheckpointKey is the key of the progress of the last reindexing in
+// the state database, which is removed when the reindexing completes.
+var reindexCheckpointKey = []byte("reindexCheckpoint")
+
+// reindexProgressInterval is the interval of the progress reports.
+const reindexProgressInterval = 10 * time.Second
+
+var (
+	reindexStartHeight int64
+	reindexEndHeight   int64
+	reindexDryRun      bool
+)
+
+// ReindexEventCmd indexes the events of the stored blocks and their txs by
+// the configured indexers, e.g. after the indexing was enabled or the indexer
+// was changed.
+var ReindexEventCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Reindex the events of the stored blocks and txs",
+	Long: `Reindex the events of the blocks and txs between --start-height and
+--end-height (all the stored blocks by default) by the indexers set in
+tx_index.indexer, reading the blocks from the block store and the ABCI
+results from the state store.
+
+The progress is saved after every block, so an interrupted reindexing of the
+same heights continues where it stopped. The node must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reindexEvents(config, reindexStartHeight, reindexEndHeight, reindexDryRun, logger)
+	},
+}
+
+func init() {
+	ReindexEventCmd.Flags().Int64Var(&reindexStartHeight, "start-height", 0,
+		"the first height to reindex (default: the base of the block store)")
+	ReindexEventCmd.Flags().Int64Var(&reindexEndHeight, "end-height", 0,
+		"the last height to reindex (default: the height of the block store)")
+	ReindexEventCmd.Flags().BoolVar(&reindexDryRun, "dry-run", false,
+		"read the blocks and the ABCI results without indexing them")
+}
+
+// reindexCheckpoint is the progress of the reindexing of the heights.
+type reindexCheckpoint struct {
+	StartHeight int64 `json:"start_height"`
+	EndHeight   int64 `json:"end_height"`
+	// the last reindexed height
+	Height int64 `json:"height"`
+}
+
+// eventReindexer feeds the stored blocks and their ABCI results to the
+// indexers.
+type eventReindexer struct {
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	stateDB    dbm.DB
+
+	txIndexer    txindex.TxIndexer
+	blockIndexer indexer.BlockIndexer
+	eventSinks   []indexer.EventSink
+
+	dryRun bool
+	logger log.Logger
+}
+
+func reindexEvents(config *cfg.Config, startHeight, endHeight int64, dryRun bool, logger log.Logger) error {
+	// the databases are closed on exit
+	var dbs []dbm.DB
+	defer func() {
+		for _, db := range dbs {
+			if err := db.Close(); err != nil {
+				logger.Error("Failed to close database", "err", err)
+			}
+		}
+	}()
+	dbProvider := func(ctx *node.DBContext) (dbm.DB, error) {
+		db, err := node.DefaultDBProvider(ctx)
+		if err != nil {
+			// the databases are locked by the running node
+			return nil, fmt.Errorf("can't open %s database, is the node running? %w", ctx.ID, err)
+		}
+		dbs = append(dbs, db)
+		return db, nil
+	}
+
+	blockStoreDB, err := dbProvider(&node.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return err
+	}
+	stateDB, err := dbProvider(&node.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return err
+	}
+
+	r := &eventReindexer{
+		blockStore: store.NewBlockStore(blockStoreDB),
+		stateStore: sm.NewStore(stateDB),
+		stateDB:    stateDB,
+		dryRun:     dryRun,
+		logger:     logger,
+	}
+
+	if !dryRun {
+		state, err := r.stateStore.Load()
+		if err != nil {
+			return err
+		}
+		if state.IsEmpty() {
+			return errors.New("no state found, nothing to reindex")
+		}
+
+		r.txIndexer, r.blockIndexer, r.eventSinks, err = node.CreateIndexers(config, dbProvider, state.ChainID)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			for _, sink := range r.eventSinks {
+				if err := sink.Stop(); err != nil {
+					logger.Error("Failed to stop event sink", "sink", sink.Type(), "err", err)
+				}
+			}
+		}()
+	}
+
+	return r.reindex(startHeight, endHeight)
+}
+
+// reindex reindexes the blocks between the heights, the stored ones if zero.
+func (r *eventReindexer) reindex(startHeight, endHeight int64) error {
+	base, height := r.blockStore.Base(), r.blockStore.Height()
+	if height == 0 {
+		return errors.New("no blocks found, nothing to reindex")
+	}
+	if startHeight == 0 {
+		startHeight = base
+	}
+	if endHeight == 0 {
+		endHeight = height
+	}
+	switch {
+	case startHeight < base:
+		return fmt.Errorf("start height %d is below the base of the block store %d", startHeight, base)
+	case endHeight > height:
+		return fmt.Errorf("end height %d is above the height of the block store %d", endHeight, height)
+	case startHeight > endHeight:
+		return fmt.Errorf("start height %d is above end height %d", startHeight, endHeight)
+	}
+
+	from := startHeight
+	if !r.dryRun {
+		checkpoint, err := r.loadCheckpoint()
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil && checkpoint.StartHeight == startHeight && checkpoint.EndHeight == endHeight {
+			from = checkpoint.Height + 1
+			r.logger.Info("Resuming reindexing from the checkpoint", "height", from)
+		}
+	}
+
+	r.logger.Info("Reindexing events", "start_height", startHeight, "end_height", endHeight, "dry_run", r.dryRun)
+
+	var (
+		numTxs     int
+		lastReport = time.Now()
+	)
+	for h := from; h <= endHeight; h++ {
+		n, err := r.reindexBlock(h)
+		if err != nil {
+			return err
+		}
+		numTxs += n
+
+		if !r.dryRun {
+			if err := r.saveCheckpoint(reindexCheckpoint{StartHeight: startHeight, EndHeight: endHeight, Height: h}); err != nil {
+				return err
+			}
+		}
+
+		if time.Since(lastReport) >= reindexProgressInterval {
+			r.logger.Info("Reindexing events", "height", h, "end_height", endHeight, "txs", numTxs,
+				"progress", fmt.Sprintf("%.1f%%", float64(h-startHeight+1)*100/float64(endHeight-startHeight+1)))
+			lastReport = time.Now()
+		}
+	}
+
+	if !r.dryRun {
+		if err := r.stateDB.DeleteSync(reindexCheckpointKey); err != nil {
+			return err
+		}
+	}
+	r.logger.Info("Reindexed events", "start_height", startHeight, "end_height", endHeight,
+		"blocks", endHeight-from+1, "txs", numTxs, "dry_run", r.dryRun)
+	return nil
+}
+
+// reindexBlock feeds the block to the indexers, like the indexer service
+// does. It returns the number of the txs of the block.
+func (r *eventReindexer) reindexBlock(height int64) (int, error) {
+	block := r.blockStore.LoadBlock(height)
+	if block == nil {
+		return 0, fmt.Errorf("block %d not found", height)
+	}
+	abciResponses, err := r.stateStore.LoadABCIResponses(height)
+	if err != nil {
+		return 0, err
+	}
+	if len(abciResponses.DeliverTxs) != len(block.Txs) {
+		return 0, fmt.Errorf("block %d has %d txs, but %d ABCI results",
+			height, len(block.Txs), len(abciResponses.DeliverTxs))
+	}
+
+	header := types.EventDataNewBlockHeader{
+		Header: block.Header,
+		NumTxs: int64(len(block.Txs)),
+	}
+	if abciResponses.BeginBlock != nil {
+		header.ResultBeginBlock = *abciResponses.BeginBlock
+	}
+	if abciResponses.EndBlock != nil {
+		header.ResultEndBlock = *abciResponses.EndBlock
+	}
+
+	batch := txindex.NewBatch(header.NumTxs)
+	for i, tx := range block.Txs {
+		if err := batch.Add(&abci.TxResult{
+			Height: height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *(abciResponses.DeliverTxs[i]),
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if r.dryRun {
+		return len(block.Txs), nil
+	}
+
+	if err := r.blockIndexer.Index(header); err != nil {
+		return 0, fmt.Errorf("indexing block %d: %w", height, err)
+	}
+	if err := r.txIndexer.AddBatch(batch); err != nil {
+		return 0, fmt.Errorf("indexing txs of block %d: %w", height, err)
+	}
+	for _, sink := range r.eventSinks {
+		if err := sink.IndexBlock(header, batch.Ops); err != nil {
+			return 0, fmt.Errorf("writing block %d to %s event sink: %w", height, sink.Type(), err)
+		}
+	}
+	return len(block.Txs), nil
+}
+
+func (r *eventReindexer) loadCheckpoint() (*reindexCheckpoint, error) {
+	bz, err := r.stateDB.Get(reindexCheckpointKey)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+	checkpoint := new(reindexCheckpoint)
+	if err := json.Unmarshal(bz, checkpoint); err != nil {
+		return nil, fmt.Errorf("can't decode reindexing checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+func (r *eventReindexer) saveCheckpoint(checkpoint reindexCheckpoint) error {
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return r.stateDB.Set(reindexCheckpointKey, bz)
+}
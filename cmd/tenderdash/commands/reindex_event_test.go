@@ -0,0 +1,311 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/node"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+const reindexTestBlocks = 200
+
+// reindexFixture is the chain of reindexTestBlocks blocks stored in the data
+// directory, and indexed natively by the indexer service.
+type reindexFixture struct {
+	config       *cfg.Config
+	txIndexer    txindex.TxIndexer
+	blockIndexer *blockidxkv.BlockerIndexer
+}
+
+// testNodeStores are the block and state stores of a test node.
+type testNodeStores struct {
+	config       *cfg.Config
+	state        sm.State
+	stateStore   sm.Store
+	blockStore   *store.BlockStore
+	blockStoreDB dbm.DB
+	stateDB      dbm.DB
+}
+
+// openTestNodeStores opens the stores in a new test root named name, and saves
+// the genesis state in them. The databases are persisted and locked like the
+// ones of a node, so they must be closed before a command opens them.
+func openTestNodeStores(t *testing.T, name string) *testNodeStores {
+	config := cfg.ResetTestRoot(name)
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: config})
+	if err != nil {
+		blockStoreDB.Close()
+		require.NoError(t, err)
+	}
+	stores := &testNodeStores{
+		config:       config,
+		stateStore:   sm.NewStore(stateDB),
+		blockStore:   store.NewBlockStore(blockStoreDB),
+		blockStoreDB: blockStoreDB,
+		stateDB:      stateDB,
+	}
+
+	stores.state, err = stores.stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	if err == nil {
+		err = stores.stateStore.Save(stores.state)
+	}
+	if err != nil {
+		stores.Close()
+		require.NoError(t, err)
+	}
+	return stores
+}
+
+func (stores *testNodeStores) Close() {
+	stores.blockStoreDB.Close()
+	stores.stateDB.Close()
+}
+
+func makeReindexFixture(t *testing.T) *reindexFixture {
+	stores := openTestNodeStores(t, "reindex_test")
+	defer stores.Close()
+	config, state, stateStore, blockStore := stores.config, stores.state, stores.stateStore, stores.blockStore
+
+	// the native indexing of the events published while executing the blocks
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	indexDB := dbm.NewMemDB()
+	fixture := &reindexFixture{
+		config:       config,
+		txIndexer:    kv.NewTxIndex(indexDB),
+		blockIndexer: blockidxkv.New(dbm.NewPrefixDB(indexDB, []byte("block_events"))),
+	}
+	indexerService := txindex.NewIndexerService(fixture.txIndexer, fixture.blockIndexer, eventBus)
+	indexerService.SetLogger(log.TestingLogger())
+	require.NoError(t, indexerService.Start())
+	defer indexerService.Stop() //nolint:errcheck // ignore for tests
+
+	for h := int64(1); h <= reindexTestBlocks; h++ {
+		var txs []types.Tx
+		for i := int64(0); i < h%4; i++ {
+			txs = append(txs, types.Tx(fmt.Sprintf("tx-%d-%d", h, i)))
+		}
+		block, parts := state.MakeBlock(h, nil, txs, new(types.Commit), nil, state.Validators.GetProposer().ProTxHash)
+		blockStore.SaveBlock(block, parts, new(types.Commit))
+
+		abciResponses := &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{
+				{Type: "begin_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("parity"), Value: []byte(fmt.Sprint(h % 2)), Index: true},
+				}},
+			}},
+			EndBlock: &abci.ResponseEndBlock{Events: []abci.Event{
+				{Type: "end_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("num_txs"), Value: []byte(fmt.Sprint(len(txs))), Index: true},
+				}},
+			}},
+		}
+		for i := range txs {
+			abciResponses.DeliverTxs = append(abciResponses.DeliverTxs, &abci.ResponseDeliverTx{
+				Code: abci.CodeTypeOK,
+				Events: []abci.Event{
+					{Type: "account", Attributes: []abci.EventAttribute{
+						{Key: []byte("owner"), Value: []byte(fmt.Sprintf("owner-%d", i)), Index: true},
+						{Key: []byte("number"), Value: []byte(fmt.Sprint(h)), Index: true},
+					}},
+				},
+			})
+		}
+		require.NoError(t, stateStore.SaveABCIResponses(h, abciResponses))
+
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header:           block.Header,
+			NumTxs:           int64(len(txs)),
+			ResultBeginBlock: *abciResponses.BeginBlock,
+			ResultEndBlock:   *abciResponses.EndBlock,
+		}))
+		for i, tx := range txs {
+			require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+				Height: h,
+				Index:  uint32(i),
+				Tx:     tx,
+				Result: *abciResponses.DeliverTxs[i],
+			}}))
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		ok, err := fixture.blockIndexer.Has(reindexTestBlocks)
+		return err == nil && ok
+	}, 10*time.Second, 10*time.Millisecond)
+	return fixture
+}
+
+// openReindexedIndexers opens the kv indexers written by the reindexing.
+func openReindexedIndexers(t *testing.T, config *cfg.Config) (txindex.TxIndexer, *blockidxkv.BlockerIndexer) {
+	store, err := node.DefaultDBProvider(&node.DBContext{ID: "tx_index", Config: config})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return kv.NewTxIndex(store), blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
+}
+
+func searchTxs(t *testing.T, txIndexer txindex.TxIndexer, q string) []*abci.TxResult {
+	results, err := txIndexer.Search(context.Background(), query.MustParse(q))
+	require.NoError(t, err)
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Height == results[j].Height {
+			return results[i].Index < results[j].Index
+		}
+		return results[i].Height < results[j].Height
+	})
+	return results
+}
+
+func searchBlocks(t *testing.T, blockIndexer *blockidxkv.BlockerIndexer, q string) []int64 {
+	heights, err := blockIndexer.Search(context.Background(), query.MustParse(q))
+	require.NoError(t, err)
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+func TestReindexEvents(t *testing.T) {
+	fixture := makeReindexFixture(t)
+
+	require.NoError(t, reindexEvents(fixture.config, 0, 0, false, log.TestingLogger()))
+
+	txIndexer, blockIndexer := openReindexedIndexers(t, fixture.config)
+
+	// the tx_search results match the ones of the natively indexed node
+	for _, q := range []string{
+		"tx.height = 5",
+		"tx.height > 100 AND tx.height <= 120",
+		fmt.Sprintf("tx.hash = '%X'", types.Tx("tx-199-2").Hash()),
+		"account.owner = 'owner-2'",
+		"account.number >= 150 AND account.owner = 'owner-0'",
+		"account.owner EXISTS",
+	} {
+		expected := searchTxs(t, fixture.txIndexer, q)
+		require.NotEmpty(t, expected, q)
+		assert.Equal(t, expected, searchTxs(t, txIndexer, q), q)
+	}
+
+	// as well as the block_search ones
+	for _, q := range []string{
+		"block.height > 190",
+		"begin_event.parity = 1",
+		"end_event.num_txs = 3 AND block.height <= 50",
+	} {
+		expected := searchBlocks(t, fixture.blockIndexer, q)
+		require.NotEmpty(t, expected, q)
+		assert.Equal(t, expected, searchBlocks(t, blockIndexer, q), q)
+	}
+}
+
+func TestReindexEventsHeights(t *testing.T) {
+	fixture := makeReindexFixture(t)
+
+	for _, tc := range []struct {
+		start, end int64
+	}{
+		{0, reindexTestBlocks + 1},
+		{120, 110},
+	} {
+		assert.Error(t, reindexEvents(fixture.config, tc.start, tc.end, false, log.TestingLogger()))
+	}
+
+	require.NoError(t, reindexEvents(fixture.config, 101, 150, false, log.TestingLogger()))
+
+	txIndexer, _ := openReindexedIndexers(t, fixture.config)
+	results := searchTxs(t, txIndexer, "account.owner EXISTS")
+	require.NotEmpty(t, results)
+	assert.EqualValues(t, 101, results[0].Height)
+	assert.EqualValues(t, 150, results[len(results)-1].Height)
+}
+
+func TestReindexEventsResumes(t *testing.T) {
+	fixture := makeReindexFixture(t)
+
+	withCheckpoint := func(fn func(r *eventReindexer)) {
+		stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: fixture.config})
+		require.NoError(t, err)
+		defer stateDB.Close()
+		fn(&eventReindexer{stateDB: stateDB})
+	}
+	// the reindexing of all the blocks was interrupted after the height 100
+	interrupt := func() {
+		withCheckpoint(func(r *eventReindexer) {
+			require.NoError(t, r.saveCheckpoint(reindexCheckpoint{StartHeight: 1, EndHeight: reindexTestBlocks, Height: 100}))
+		})
+	}
+	isReindexed := func(height int64) bool {
+		store, err := node.DefaultDBProvider(&node.DBContext{ID: "tx_index", Config: fixture.config})
+		require.NoError(t, err)
+		defer store.Close()
+		return len(searchTxs(t, kv.NewTxIndex(store), fmt.Sprintf("tx.height = %d", height))) > 0
+	}
+
+	interrupt()
+	require.NoError(t, reindexEvents(fixture.config, 1, reindexTestBlocks, false, log.TestingLogger()))
+	assert.False(t, isReindexed(5))
+	assert.False(t, isReindexed(50))
+	assert.False(t, isReindexed(99))
+	assert.True(t, isReindexed(101))
+	assert.True(t, isReindexed(reindexTestBlocks-1))
+
+	// the checkpoint is removed when the reindexing completes
+	withCheckpoint(func(r *eventReindexer) {
+		checkpoint, err := r.loadCheckpoint()
+		require.NoError(t, err)
+		assert.Nil(t, checkpoint)
+	})
+
+	// the checkpoint of other heights is ignored
+	interrupt()
+	require.NoError(t, reindexEvents(fixture.config, 1, 10, false, log.TestingLogger()))
+	assert.True(t, isReindexed(5))
+}
+
+func TestReindexEventsDryRun(t *testing.T) {
+	fixture := makeReindexFixture(t)
+
+	require.NoError(t, reindexEvents(fixture.config, 0, 0, true, log.TestingLogger()))
+
+	txIndexer, blockIndexer := openReindexedIndexers(t, fixture.config)
+	assert.Empty(t, searchTxs(t, txIndexer, "account.owner EXISTS"))
+	ok, err := blockIndexer.Has(1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestReindexEventsRefusesWhileNodeIsRunning(t *testing.T) {
+	fixture := makeReindexFixture(t)
+
+	// the node holds the lock of the block store
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: fixture.config})
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+
+	err = reindexEvents(fixture.config, 0, 0, false, log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is the node running")
+}
@@ -11,7 +11,7 @@ var ReplayCmd = &cobra.Command{
 	Use:   "replay",
 	Short: "Replay messages from WAL",
 	Run: func(cmd *cobra.Command, args []string) {
-		consensus.RunReplayFile(config.BaseConfig, config.Consensus, false)
+		consensus.RunReplayFile(config, false)
 	},
 }
 
@@ -22,7 +22,7 @@ var ReplayConsoleCmd = &cobra.Command{
 	Aliases: []string{"replay_console"},
 	Short:   "Replay messages from WAL in a console",
 	Run: func(cmd *cobra.Command, args []string) {
-		consensus.RunReplayFile(config.BaseConfig, config.Consensus, true)
+		consensus.RunReplayFile(config, true)
 	},
 	PreRun: deprecateSnakeCase,
 }
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/privval"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+)
+
+// RollbackCmd rewinds the blocks and the state of a stopped node to a lower
+// height.
+var RollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll back the blocks and the state of the node to a lower height",
+	Long: `Roll back the block store and the state store (validator sets, consensus
+params and ABCI responses) of a stopped node to the height given by --to-height,
+as if the blocks above it were never committed, and truncate the consensus WAL
+after the height. The application must be rolled back to the height separately,
+or reset, so that it replays the blocks on start.
+
+The rollback is refused below the earliest stored block or state, e.g. across
+the snapshot the node was state synced from, and while the databases are
+locked by the node.
+
+The block discarded at the height above is recorded, and the node refuses to
+sync it from the peers, which may still have it, until the rollback is
+confirmed with --confirm, e.g. to sync the discarded blocks again.
+
+With --reset-signer-state, the last sign state of the file signer is rewound
+to the height, so that the validator signs the heights above it again. This is
+only safe, if the whole network discarded the blocks above the height. The
+state of a remote signer must be rewound separately.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if confirmRollback {
+			return confirmLastRollback(config, logger)
+		}
+		if rollbackHeight <= 0 {
+			return errors.New("the height to roll back to must be given with --to-height")
+		}
+		return rollbackNode(config, rollbackHeight, resetSignerState, logger)
+	},
+}
+
+var (
+	rollbackHeight   int64
+	confirmRollback  bool
+	resetSignerState bool
+)
+
+func init() {
+	RollbackCmd.Flags().Int64Var(&rollbackHeight, "to-height", 0, "the height to roll back to")
+	RollbackCmd.Flags().BoolVar(&confirmRollback, "confirm", false,
+		"confirm the last rollback, the node syncs the discarded block from the peers again")
+	RollbackCmd.Flags().BoolVar(&resetSignerState, "reset-signer-state", false,
+		"(unsafe) rewind the last sign state of the file signer to the height")
+}
+
+// rollbackNode rolls back the stores and the WAL of the node to the height.
+func rollbackNode(config *cfg.Config, height int64, resetSigner bool, logger log.Logger) error {
+	dbs, err := openNodeDBs(config, "blockstore", "state")
+	if err != nil {
+		return err
+	}
+	defer closeNodeDBs(dbs, logger)
+	blockStore := store.NewBlockStore(dbs[0])
+	stateStore := sm.NewStore(dbs[1])
+
+	latest := blockStore.Height()
+	state, err := sm.Rollback(blockStore, stateStore, height)
+	if err != nil {
+		return fmt.Errorf("failed to roll back: %w", err)
+	}
+	if err := consensus.TruncateWAL(config.Consensus.WalFile(), height); err != nil {
+		return fmt.Errorf("failed to truncate the consensus WAL: %w", err)
+	}
+	logger.Info("Rolled back the node", "height", state.LastBlockHeight, "from", latest,
+		"app_hash", state.AppHash)
+
+	if !resetSigner {
+		return nil
+	}
+	if config.PrivValidatorCoreRPCHost != "" || config.PrivValidatorListenAddr != "" {
+		logger.Error("The last sign state of the remote signer must be rewound separately")
+		return nil
+	}
+	if _, err := os.Stat(config.PrivValidatorKeyFile()); err != nil {
+		return fmt.Errorf("can't load the file signer: %w", err)
+	}
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	if pv.RollbackLastSignState(height) {
+		logger.Info("Rewound the last sign state", "height", height, "file", config.PrivValidatorStateFile())
+	}
+	return nil
+}
+
+// confirmLastRollback deletes the marker of the last rollback.
+func confirmLastRollback(config *cfg.Config, logger log.Logger) error {
+	dbs, err := openNodeDBs(config, "state")
+	if err != nil {
+		return err
+	}
+	defer closeNodeDBs(dbs, logger)
+	stateStore := sm.NewStore(dbs[0])
+
+	marker, err := stateStore.LoadRollbackMarker()
+	if err != nil {
+		return err
+	}
+	if marker == nil {
+		logger.Info("No rollback to confirm")
+		return nil
+	}
+	if err := stateStore.ConfirmRollback(); err != nil {
+		return err
+	}
+	logger.Info("Confirmed the rollback", "height", marker.Height)
+	return nil
+}
+
+// openNodeDBs opens the databases of the stopped node. It fails if any of
+// them is locked by the running node.
+func openNodeDBs(config *cfg.Config, ids ...string) ([]dbm.DB, error) {
+	dbs := make([]dbm.DB, 0, len(ids))
+	for _, id := range ids {
+		db, err := node.DefaultDBProvider(&node.DBContext{ID: id, Config: config})
+		if err != nil {
+			closeNodeDBs(dbs, log.NewNopLogger())
+			return nil, fmt.Errorf("can't open %s database, is the node running? %w", id, err)
+		}
+		dbs = append(dbs, db)
+	}
+	return dbs, nil
+}
+
+func closeNodeDBs(dbs []dbm.DB, logger log.Logger) {
+	for _, db := range dbs {
+		if err := db.Close(); err != nil {
+			logger.Error("Failed to close database", "err", err)
+		}
+	}
+}
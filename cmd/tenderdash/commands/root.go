@@ -57,24 +57,47 @@ var RootCmd = &cobra.Command{
 			return err
 		}
 
-		if config.LogFormat == cfg.LogFormatJSON {
-			logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
-		}
-
-		logger, err = tmflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel)
+		l, err := newLogger(config)
 		if err != nil {
 			return err
 		}
 
-		if viper.GetBool(cli.TraceFlag) {
-			logger = log.NewTracingLogger(logger)
-		}
-
-		logger = logger.With("module", "main")
+		// the log levels and format can be reloaded, see NewRunNodeCmd
+		logger = log.NewReloadableLogger(l).With("module", "main")
 		return nil
 	},
 }
 
+// newLogger returns the logger to stdout with the format and the levels of the
+// config.
+func newLogger(config *cfg.Config) (log.Logger, error) {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	if config.LogFormat == cfg.LogFormatJSON {
+		logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+	}
+
+	logger, err := tmflags.ParseLogLevels(config.LogLevel, config.Log.Levels, logger, cfg.DefaultLogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if viper.GetBool(cli.TraceFlag) {
+		logger = log.NewTracingLogger(logger)
+	}
+	return logger, nil
+}
+
+// reloadConfig reads the config file again and returns the config, like
+// ParseConfig does on startup.
+func reloadConfig() (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+	return ParseConfig()
+}
+
 // deprecateSnakeCase is a util function for 0.34.1. Should be removed in 0.35
 func deprecateSnakeCase(cmd *cobra.Command, args []string) {
 	if strings.Contains(cmd.CalledAs(), "_") {
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -37,6 +39,8 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"genesis_hash",
 		[]byte{},
 		"optional SHA-256 hash of the genesis file")
+	cmd.Flags().Duration("drain_timeout", config.DrainTimeout,
+		"how long to wait on stop for the consensus to commit the block in progress")
 	cmd.Flags().Int64("consensus.double_sign_check_height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
@@ -72,6 +76,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPNP port forwarding")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "enable/disable seed mode")
+	cmd.Flags().Bool("p2p.only_masternode_peers", config.P2P.OnlyMasternodePeers,
+		"only keep the outbound connections to the masternodes")
+	cmd.Flags().Bool("p2p.verify_masternode_identity", config.P2P.VerifyMasternodeIdentity,
+		"reject the peers, which claim a masternode proTxHash without a valid proof")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "comma-delimited private peer IDs")
 
 	// consensus flags
@@ -111,6 +119,7 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create node: %w", err)
 			}
+			n.SetConfigReloader(reloadConfig, newLogger)
 
 			if err := n.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
@@ -118,6 +127,18 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 
 			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
 
+			// Reload the config upon receiving SIGHUP.
+			sighup := make(chan os.Signal, 1)
+			signal.Notify(sighup, syscall.SIGHUP)
+			go func() {
+				for range sighup {
+					logger.Info("Reloading the config")
+					if _, _, err := n.ReloadConfig(); err != nil {
+						logger.Error("unable to reload the config", "error", err)
+					}
+				}
+			}()
+
 			// Stop upon receiving SIGTERM or CTRL-C.
 			tmos.TrapSignal(logger, func() {
 				if n.IsRunning() {
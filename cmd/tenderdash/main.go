@@ -20,6 +20,12 @@ func main() {
 		cmd.LightCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
+		cmd.ReindexEventCmd,
+		cmd.CompactCmd,
+		cmd.RollbackCmd,
+		cmd.MigrateDBCmd,
+		cmd.InspectCmd,
+		cmd.KeyCmd,
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ShowValidatorCmd,
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"os"
 	"reflect"
 	"testing"
 	"time"
@@ -62,6 +63,33 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorSocketMode = "0600"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, os.FileMode(0600), cfg.PrivValidatorSocketFileMode())
+	cfg.PrivValidatorSocketMode = "0800"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorSocketMode = "10600"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.DrainTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestLogConfigValidateBasic(t *testing.T) {
+	cfg := TestLogConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Levels = map[string]string{"consensus": "debug", "p2p": "none", "*": "error"}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the levels
+	for _, levels := range []map[string]string{{"consensus": "trace"}, {"": "info"}, {"p2p,consensus": "info"}} {
+		cfg.Levels = levels
+		assert.Error(t, cfg.ValidateBasic(), levels)
+	}
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -73,7 +101,11 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"MaxSubscriptionReplayHeights",
+		"MaxSearchResultsPerPage",
 		"TimeoutBroadcastTxCommit",
+		"MaxConcurrentRequestsPerIP",
+		"ExpensiveRequestsBurst",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
 	}
@@ -83,6 +115,29 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.ExpensiveRequestsPerSecond = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.SubscriptionBufferSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.GenesisChunkSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.SubscriptionSlowConsumerPolicy = "drop_all"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	assert.Equal(t, os.FileMode(0), cfg.UnixSocketFileMode())
+	cfg.UnixSocketMode = "660"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, os.FileMode(0660), cfg.UnixSocketFileMode())
+	cfg.UnixSocketMode = "rw-rw----"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -93,9 +148,13 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxNumInboundPeers",
 		"MaxNumOutboundPeers",
 		"FlushThrottleTimeout",
+		"BanDuration",
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"PersistentPeersReconnectMaxBackoff",
+		"PersistentPeersReconnectJitter",
+		"PersistentPeersPingInterval",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -103,6 +162,28 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg = TestP2PConfig()
+	cfg.PersistentPeersPongTimeout = cfg.PersistentPeersPingInterval
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PersistentPeersPongTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PersistentPeersPingInterval = 0
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigSendQueueCapacities(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.SendQueueCapacities = " 0x30:10, 34:1000 ,"
+	require.NoError(t, cfg.ValidateBasic())
+	capacities, err := cfg.ChannelSendQueueCapacities()
+	require.NoError(t, err)
+	assert.Equal(t, map[byte]int{0x30: 10, 0x22: 1000}, capacities)
+
+	for _, wrong := range []string{"0x30", "0x30:10:1", "0x100:10", "mempool:10", "0x30:ten", "0x30:0"} {
+		cfg.SendQueueCapacities = wrong
+		assert.Error(t, cfg.ValidateBasic(), wrong)
+	}
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -114,6 +195,9 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"TTLDuration",
+		"TTLNumBlocks",
+		"AnnounceTimeout",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -121,11 +205,43 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Version = MempoolV1
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Version = "v2"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.Enable = true
+	cfg.RPCServers = []string{"127.0.0.1:26657", "127.0.0.2:26657"}
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0123456789abcdef"
+	require.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the chunk fetching
+	cfg.ChunkRequestTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ChunkRequestTimeout = time.Second
+	cfg.MaxChunkRetries = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxChunkRetries = 0
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the snapshot policy
+	cfg.SnapshotKeepRecent = 2
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SnapshotInterval = 10
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the light block rate limit
+	cfg.LightBlockRequestsPerSecond = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LightBlockRequestsPerSecond = 0
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -138,6 +254,10 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 
 	cfg.Version = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Version = "v0"
+	cfg.MaxInFlightBytes = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -164,7 +284,34 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerGossipSleepDuration negative":     {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
+		"CompactBlockTimeout":                  {func(c *ConsensusConfig) { c.CompactBlockTimeout = time.Second }, false},
+		"CompactBlockTimeout negative":         {func(c *ConsensusConfig) { c.CompactBlockTimeout = -1 }, true},
+		"PeerNewRoundStepRate negative":        {func(c *ConsensusConfig) { c.PeerNewRoundStepRate = -1 }, true},
+		"PeerHasVoteRate negative":             {func(c *ConsensusConfig) { c.PeerHasVoteRate = -1 }, true},
+		"PeerVoteRate":                         {func(c *ConsensusConfig) { c.PeerVoteRate = 0 }, false},
+		"PeerVoteRate negative":                {func(c *ConsensusConfig) { c.PeerVoteRate = -1 }, true},
+		"PeerMessageRate negative":             {func(c *ConsensusConfig) { c.PeerMessageRate = -1 }, true},
+		"PeerMisbehaviorThreshold negative":    {func(c *ConsensusConfig) { c.PeerMisbehaviorThreshold = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"ProcessProposalHeight negative":       {func(c *ConsensusConfig) { c.ProcessProposalHeight = -1 }, true},
+		"TimeoutMode adaptive":                 {func(c *ConsensusConfig) { c.TimeoutMode = TimeoutModeAdaptive }, false},
+		"TimeoutMode unknown":                  {func(c *ConsensusConfig) { c.TimeoutMode = "dynamic" }, true},
+		"AdaptiveTimeoutHeights zero": {func(c *ConsensusConfig) {
+			c.TimeoutMode = TimeoutModeAdaptive
+			c.AdaptiveTimeoutHeights = 0
+		}, true},
+		"AdaptiveTimeoutPercentile above 1": {func(c *ConsensusConfig) {
+			c.TimeoutMode = TimeoutModeAdaptive
+			c.AdaptiveTimeoutPercentile = 1.5
+		}, true},
+		"AdaptiveTimeoutFactor below 1": {func(c *ConsensusConfig) {
+			c.TimeoutMode = TimeoutModeAdaptive
+			c.AdaptiveTimeoutFactor = 0.5
+		}, true},
+		"AdaptiveTimeoutMax below min": {func(c *ConsensusConfig) {
+			c.TimeoutMode = TimeoutModeAdaptive
+			c.AdaptiveTimeoutMax = c.AdaptiveTimeoutMin - 1
+		}, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -189,4 +336,71 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxOpenConnections = 3
+
+	cfg.OtelEndpoint = "otel-collector:4317"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.True(t, cfg.TracingEnabled())
+
+	// tamper with the otel endpoint
+	cfg.OtelEndpoint = "otel-collector"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.True(t, cfg.IsEnabled("kv"))
+	assert.False(t, cfg.IsEnabled("psql"))
+
+	testCases := []struct {
+		indexer  []string
+		psqlConn string
+		expErr   bool
+	}{
+		{nil, "", false},
+		{[]string{"null"}, "", false},
+		{[]string{"kv", "psql"}, "postgresql://localhost/tendermint", false},
+		{[]string{"psql"}, "postgresql://localhost/tendermint", false},
+		{[]string{"psql"}, "", true},
+		{[]string{"kv", "kv"}, "", true},
+		{[]string{"null", "kv"}, "", true},
+		{[]string{"sql"}, "", true},
+	}
+	for _, tc := range testCases {
+		cfg.Indexer, cfg.PsqlConn = tc.indexer, tc.psqlConn
+		if tc.expErr {
+			assert.Error(t, cfg.ValidateBasic(), tc.indexer)
+		} else {
+			assert.NoError(t, cfg.ValidateBasic(), tc.indexer)
+		}
+	}
+}
+
+func TestStorageConfigValidateBasic(t *testing.T) {
+	cfg := TestStorageConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.RetainABCIResponsesBlocks = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.RetainABCIResponsesBlocks = 100
+	cfg.RetainValidatorSetsBlocks = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.RetainValidatorSetsBlocks = 100
+	cfg.Backends.BlockStore = "pebble"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Backends.Light = "leveldb"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestConfigDBBackendFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DBBackend = "goleveldb"
+	cfg.Storage.Backends.BlockStore = "pebble"
+
+	assert.Equal(t, "pebble", cfg.DBBackendFor("blockstore"))
+	assert.Equal(t, "goleveldb", cfg.DBBackendFor("state"))
+	assert.Equal(t, "goleveldb", cfg.DBBackendFor("tx_index"))
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -64,6 +65,26 @@ func TestEnsureTestRoot(t *testing.T) {
 	ensureFiles(t, rootDir, defaultDataDir, baseConfig.Genesis, baseConfig.PrivValidatorKey, baseConfig.PrivValidatorState)
 }
 
+func TestWriteConfigFileLogLevels(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "config-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	for _, levels := range []map[string]string{{}, {"consensus": "debug", "p2p": "error", "*": "info"}} {
+		config := DefaultConfig()
+		config.Log.Levels = levels
+		configFile := filepath.Join(tmpDir, "config.toml")
+		WriteConfigFile(configFile, config)
+
+		v := viper.New()
+		v.SetConfigFile(configFile)
+		require.NoError(t, v.ReadInConfig())
+		loaded := DefaultConfig()
+		require.NoError(t, v.Unmarshal(loaded))
+		assert.Equal(t, levels, loaded.Log.Levels)
+	}
+}
+
 func checkConfig(configFile string) bool {
 	var valid bool
 
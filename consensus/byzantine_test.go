@@ -191,9 +191,13 @@ func TestByzantinePrevoteEquivocation(t *testing.T) {
 		}
 		proposerProTxHash := lazyProposer.privValidatorProTxHash
 
-		block, blockParts := lazyProposer.blockExec.CreateProposalBlock(
-			lazyProposer.Height, lazyProposer.state, commit, proposerProTxHash,
+		block, blockParts, err := lazyProposer.blockExec.CreateProposalBlock(
+			lazyProposer.Height, lazyProposer.Round, lazyProposer.state, commit, proposerProTxHash,
 		)
+		if err != nil {
+			lazyProposer.Logger.Error("enterPropose: failed to create proposal block", "err", err)
+			return
+		}
 
 		// Flush the WAL. Otherwise, we may not recompute the same proposal to sign,
 		// and the privValidator will refuse to sign anything.
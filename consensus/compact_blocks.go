@@ -0,0 +1,289 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// The proposal block is sent to the peers, which support the compact blocks,
+// as a CompactBlockMessage: the block without the transactions, which are
+// referenced by their hashes. The peer reconstructs the block from its mempool,
+// requesting the missing transactions with a WantTxsMessage, and feeds its
+// parts to the consensus as if they were received as BlockPartMessages. If the
+// peer doesn't acknowledge the reconstruction within the compact block timeout,
+// the block parts are sent as usual.
+
+// maxCompactBlockTxsBytes is the size of the transactions sent in a
+// CompactBlockTxsMessage, leaving room for the indexes and the encoding. The
+// transactions, which don't fit, get to the peer as the block parts.
+const maxCompactBlockTxsBytes = maxMsgSize - 64*1024
+
+// TxProvider looks up the transactions of the compact blocks by their keys,
+// the hashes of the transactions. It's implemented by the mempool.
+type TxProvider interface {
+	TxByKey(key [mempl.TxKeySize]byte) (types.Tx, bool)
+}
+
+// ReactorCompactBlocks enables the compact blocks, which are reconstructed from
+// the transactions provided by txs. The block parts are sent to the peers,
+// which don't reconstruct the compact block within the timeout.
+func ReactorCompactBlocks(txs TxProvider, timeout time.Duration) ReactorOption {
+	return func(conR *Reactor) {
+		conR.txs = txs
+		conR.compactBlockTimeout = timeout
+	}
+}
+
+// compactBlock is the encoded CompactBlockMessage of the proposal block of a
+// round, nil if it doesn't fit into a message.
+type compactBlock struct {
+	height    int64
+	round     int32
+	blockHash tmbytes.HexBytes
+	bz        []byte
+}
+
+// pendingCompactBlock is a compact block, which is missing the transactions
+// requested from the peer.
+type pendingCompactBlock struct {
+	msg     *CompactBlockMessage
+	peerID  p2p.ID
+	txs     types.Txs
+	have    []bool
+	missing int
+}
+
+// compactBlocksEnabled returns true, if both we and the peer support the
+// compact blocks.
+func (conR *Reactor) compactBlocksEnabled(peer p2p.Peer) bool {
+	if conR.txs == nil {
+		return false
+	}
+	nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && nodeInfo.HasChannel(CompactBlockChannel)
+}
+
+// gossipCompactBlock sends the proposal block to the peer as a compact block.
+// It returns true, if the compact block is sent or the peer is still given
+// time to reconstruct it, false if the block parts should be sent.
+func (conR *Reactor) gossipCompactBlock(logger log.Logger, rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) bool {
+
+	if !conR.compactBlocksEnabled(peer) || rs.Height != prs.Height || rs.Round != prs.Round ||
+		rs.ProposalBlock == nil || !rs.ProposalBlockParts.IsComplete() {
+		return false
+	}
+
+	sentAt := ps.compactBlockSent(rs.Height, rs.Round)
+	if sentAt.IsZero() {
+		bz := conR.compactBlockBytes(rs)
+		if bz == nil {
+			// send the parts right away
+			ps.setCompactBlockSent(rs.Height, rs.Round, tmtime.Now().Add(-conR.compactBlockTimeout))
+			return false
+		}
+		logger.Debug("Sending compact block", "height", rs.Height, "round", rs.Round)
+		if peer.Send(CompactBlockChannel, bz) {
+			ps.setCompactBlockSent(rs.Height, rs.Round, tmtime.Now())
+			return true
+		}
+		return false
+	}
+
+	if tmtime.Now().Sub(sentAt) < conR.compactBlockTimeout {
+		time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+		return true
+	}
+	return false
+}
+
+// compactBlockBytes returns the encoded compact block of the proposal block of
+// the round state, nil if it doesn't fit into a message. It's encoded once for
+// all the peers.
+func (conR *Reactor) compactBlockBytes(rs *cstypes.RoundState) []byte {
+	conR.compactMtx.Lock()
+	defer conR.compactMtx.Unlock()
+
+	blockHash := rs.ProposalBlock.Hash()
+	if cb := conR.compactBlock; cb != nil && cb.height == rs.Height && cb.round == rs.Round &&
+		bytes.Equal(cb.blockHash, blockHash) {
+		return cb.bz
+	}
+
+	cb := &compactBlock{height: rs.Height, round: rs.Round, blockHash: blockHash}
+	pb, txHashes, err := rs.ProposalBlock.ToCompactProto()
+	if err != nil {
+		conR.Logger.Error("Failed to make the compact block", "height", rs.Height, "round", rs.Round, "err", err)
+	} else {
+		bz := MustEncode(&CompactBlockMessage{
+			Height:   rs.Height,
+			Round:    rs.Round,
+			Block:    pb,
+			TxHashes: txHashes,
+		})
+		if len(bz) <= maxMsgSize {
+			cb.bz = bz
+		}
+	}
+	conR.compactBlock = cb
+	return cb.bz
+}
+
+// handleCompactBlock reconstructs the block from the mempool, or requests the
+// missing transactions from the peer.
+func (conR *Reactor) handleCompactBlock(msg *CompactBlockMessage, src p2p.Peer, ps *PeerState) {
+	// the peer has the whole block
+	ps.setHasAllProposalBlockParts(msg.Height, msg.Round)
+
+	rs := conR.conS.GetRoundState()
+	if rs.Height != msg.Height || rs.Round != msg.Round || rs.ProposalBlockParts == nil {
+		return
+	}
+	if rs.ProposalBlockParts.IsComplete() {
+		src.TrySend(CompactBlockChannel, MustEncode(&WantTxsMessage{Height: msg.Height, Round: msg.Round}))
+		return
+	}
+
+	block := &pendingCompactBlock{
+		msg:    msg,
+		peerID: src.ID(),
+		txs:    make(types.Txs, len(msg.TxHashes)),
+		have:   make([]bool, len(msg.TxHashes)),
+	}
+	var missing []uint32
+	for i, hash := range msg.TxHashes {
+		var key [mempl.TxKeySize]byte
+		copy(key[:], hash)
+		if tx, ok := conR.txs.TxByKey(key); ok {
+			block.txs[i] = tx
+			block.have[i] = true
+		} else {
+			missing = append(missing, uint32(i))
+		}
+	}
+	if len(missing) == 0 {
+		conR.reconstructCompactBlock(block, src, ps)
+		return
+	}
+
+	conR.Logger.Debug("Requesting the missing txs of the compact block", "height", msg.Height, "round", msg.Round,
+		"missing", len(missing), "txs", len(msg.TxHashes))
+	conR.Metrics.CompactBlockMissingTxs.Add(float64(len(missing)))
+	block.missing = len(missing)
+	conR.compactMtx.Lock()
+	conR.pendingCompactBlock = block
+	conR.compactMtx.Unlock()
+	src.TrySend(CompactBlockChannel, MustEncode(&WantTxsMessage{
+		Height:  msg.Height,
+		Round:   msg.Round,
+		Indexes: missing,
+	}))
+}
+
+// handleWantTxs sends the requested transactions of the compact block to the
+// peer. No requested transactions mean the peer reconstructed the block.
+func (conR *Reactor) handleWantTxs(msg *WantTxsMessage, src p2p.Peer, ps *PeerState) {
+	if len(msg.Indexes) == 0 {
+		ps.setHasAllProposalBlockParts(msg.Height, msg.Round)
+		return
+	}
+
+	rs := conR.conS.GetRoundState()
+	if rs.Height != msg.Height || rs.Round != msg.Round || rs.ProposalBlock == nil ||
+		!ps.allowCompactBlockTxs(msg.Height, msg.Round) {
+		return
+	}
+
+	txs := rs.ProposalBlock.Txs
+	resp := &CompactBlockTxsMessage{Height: msg.Height, Round: msg.Round}
+	size := 0
+	for _, index := range msg.Indexes {
+		if int(index) >= len(txs) {
+			conR.Switch.StopPeerForError(src, fmt.Errorf("wrong tx index %d of the compact block with %d txs",
+				index, len(txs)))
+			return
+		}
+		tx := txs[index]
+		if size+len(tx) > maxCompactBlockTxsBytes {
+			break
+		}
+		size += len(tx)
+		resp.Indexes = append(resp.Indexes, index)
+		resp.Txs = append(resp.Txs, tx)
+	}
+	src.TrySend(CompactBlockChannel, MustEncode(resp))
+}
+
+// handleCompactBlockTxs adds the requested transactions to the pending compact
+// block, and reconstructs it, once it's complete.
+func (conR *Reactor) handleCompactBlockTxs(msg *CompactBlockTxsMessage, src p2p.Peer, ps *PeerState) {
+	conR.compactMtx.Lock()
+	block := conR.pendingCompactBlock
+	if block == nil || block.peerID != src.ID() || block.msg.Height != msg.Height || block.msg.Round != msg.Round {
+		conR.compactMtx.Unlock()
+		return
+	}
+	for i, index := range msg.Indexes {
+		tx := msg.Txs[i]
+		if int(index) >= len(block.txs) || !bytes.Equal(tx.Hash(), block.msg.TxHashes[index]) {
+			conR.compactMtx.Unlock()
+			conR.Switch.StopPeerForError(src, fmt.Errorf("wrong tx #%d of the compact block", index))
+			return
+		}
+		if !block.have[index] {
+			block.txs[index] = tx
+			block.have[index] = true
+			block.missing--
+		}
+	}
+	complete := block.missing == 0
+	if complete {
+		conR.pendingCompactBlock = nil
+	}
+	conR.compactMtx.Unlock()
+
+	if complete {
+		conR.reconstructCompactBlock(block, src, ps)
+	}
+}
+
+// reconstructCompactBlock passes the parts of the reconstructed block to the
+// consensus, if they match the proposal, and acknowledges the reconstruction to
+// the peer.
+func (conR *Reactor) reconstructCompactBlock(block *pendingCompactBlock, src p2p.Peer, ps *PeerState) {
+	msg := block.msg
+	b, err := types.BlockFromCompactProto(msg.Block, block.txs)
+	if err != nil {
+		conR.Logger.Error("Failed to reconstruct the compact block", "peer", src, "height", msg.Height,
+			"round", msg.Round, "err", err)
+		return
+	}
+	parts := b.MakePartSet(types.BlockPartSizeBytes)
+
+	rs := conR.conS.GetRoundState()
+	if rs.Height != msg.Height || rs.Round != msg.Round || !rs.ProposalBlockParts.HasHeader(parts.Header()) {
+		conR.Logger.Debug("The compact block doesn't match the proposal", "peer", src, "height", msg.Height,
+			"round", msg.Round)
+		return
+	}
+
+	for i := 0; i < int(parts.Total()); i++ {
+		ps.SetHasProposalBlockPart(msg.Height, msg.Round, i)
+		conR.conS.peerMsgQueue <- msgInfo{&BlockPartMessage{
+			Height: msg.Height,
+			Round:  msg.Round,
+			Part:   parts.GetPart(i),
+		}, src.ID()}
+	}
+	conR.Metrics.CompactBlocksReconstructed.Add(1)
+	src.TrySend(CompactBlockChannel, MustEncode(&WantTxsMessage{Height: msg.Height, Round: msg.Round}))
+}
@@ -0,0 +1,243 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	p2pmock "github.com/tendermint/tendermint/p2p/mock"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// compactBlocksPeer is a mock peer, which supports the compact blocks
+type compactBlocksPeer struct {
+	*sentMessagesPeer
+}
+
+func newCompactBlocksPeer() compactBlocksPeer {
+	return compactBlocksPeer{&sentMessagesPeer{Peer: p2pmock.NewPeer(nil)}}
+}
+
+func (p compactBlocksPeer) NodeInfo() p2p.NodeInfo {
+	nodeInfo := p.sentMessagesPeer.NodeInfo().(p2p.DefaultNodeInfo)
+	nodeInfo.Channels = append(nodeInfo.Channels, CompactBlockChannel)
+	return nodeInfo
+}
+
+func (p compactBlocksPeer) takeMsgs() []Message {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	msgs := p.msgs
+	p.msgs = nil
+	return msgs
+}
+
+// txMap is a TxProvider of the given transactions
+type txMap map[[mempl.TxKeySize]byte]types.Tx
+
+func newTxMap(txs ...types.Tx) txMap {
+	m := make(txMap)
+	for _, tx := range txs {
+		m[mempl.TxKey(tx)] = tx
+	}
+	return m
+}
+
+func (m txMap) TxByKey(key [mempl.TxKeySize]byte) (types.Tx, bool) {
+	tx, ok := m[key]
+	return tx, ok
+}
+
+// A validator reconstructs the proposal block from the compact block, getting
+// the missing transaction from the peer, and serves the compact block to the
+// next peer
+func TestReactorCompactBlocks(t *testing.T) {
+	cs1, vss := randState(2)
+	height := cs1.Height
+	vs2 := vss[1]
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+
+	deliverTxsRange(cs1, 0, 3)
+	// make the second validator the proposer by incrementing round
+	incrementRound(vss[1:]...)
+	proposal, propBlock := decideProposal(cs1, vs2, height, 1)
+	require.Len(t, propBlock.Txs, 3)
+
+	const timeout = 500 * time.Millisecond
+	conR := NewReactor(cs1, false,
+		ReactorCompactBlocks(newTxMap(propBlock.Txs[0], propBlock.Txs[2]), timeout))
+	conR.SetLogger(log.TestingLogger())
+
+	peer := newCompactBlocksPeer()
+	ps := NewPeerState(peer)
+	ps.PRS.Height = height
+	ps.PRS.Round = 1
+	ps.SetHasProposal(proposal)
+	require.True(t, conR.compactBlocksEnabled(peer))
+	assert.False(t, conR.compactBlocksEnabled(p2pmock.NewPeer(nil)))
+
+	startTestRound(cs1, height, 1)
+	require.NoError(t, cs1.SetProposal(proposal, peer.ID()))
+	require.Eventually(t, func() bool {
+		rs := cs1.GetRoundState()
+		return rs.Round == 1 && rs.Proposal != nil
+	}, time.Second, 10*time.Millisecond)
+
+	pb, txHashes, err := propBlock.ToCompactProto()
+	require.NoError(t, err)
+	conR.handleCompactBlock(&CompactBlockMessage{
+		Height:   height,
+		Round:    1,
+		Block:    pb,
+		TxHashes: txHashes,
+	}, peer, ps)
+
+	// the missing transaction is requested
+	msgs := peer.takeMsgs()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, &WantTxsMessage{Height: height, Round: 1, Indexes: []uint32{1}}, msgs[0])
+
+	conR.handleCompactBlockTxs(&CompactBlockTxsMessage{
+		Height:  height,
+		Round:   1,
+		Indexes: []uint32{1},
+		Txs:     types.Txs{propBlock.Txs[1]},
+	}, peer, ps)
+
+	// the reconstruction is acknowledged, and the block is prevoted
+	msgs = peer.takeMsgs()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, &WantTxsMessage{Height: height, Round: 1}, msgs[0])
+	ensureProposal(proposalCh, height, 1, proposal.BlockID)
+	ensurePrevote(voteCh, height, 1)
+	validatePrevote(t, cs1, 1, vss[0], propBlock.Hash())
+	assert.True(t, ps.PRS.ProposalBlockParts.IsFull())
+
+	// the next peer gets the compact block
+	peer2 := newCompactBlocksPeer()
+	ps2 := NewPeerState(peer2)
+	ps2.PRS.Height = height
+	ps2.PRS.Round = 1
+	logger := log.TestingLogger()
+	rs := cs1.GetRoundState()
+	require.True(t, conR.gossipCompactBlock(logger, rs, ps2.GetRoundState(), ps2, peer2))
+	msgs = peer2.takeMsgs()
+	require.Len(t, msgs, 1)
+	compactBlock, ok := msgs[0].(*CompactBlockMessage)
+	require.True(t, ok, "%T", msgs[0])
+	assert.Equal(t, txHashes, compactBlock.TxHashes)
+	assert.Equal(t, pb.Header, compactBlock.Block.Header)
+
+	// the missing transactions are served once
+	conR.handleWantTxs(&WantTxsMessage{Height: height, Round: 1, Indexes: []uint32{0, 2}}, peer2, ps2)
+	conR.handleWantTxs(&WantTxsMessage{Height: height, Round: 1, Indexes: []uint32{0, 2}}, peer2, ps2)
+	msgs = peer2.takeMsgs()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, &CompactBlockTxsMessage{
+		Height:  height,
+		Round:   1,
+		Indexes: []uint32{0, 2},
+		Txs:     types.Txs{propBlock.Txs[0], propBlock.Txs[2]},
+	}, msgs[0])
+
+	// the peer is waited for, and gets the block parts after the timeout
+	require.True(t, conR.gossipCompactBlock(logger, rs, ps2.GetRoundState(), ps2, peer2))
+	time.Sleep(timeout)
+	assert.False(t, conR.gossipCompactBlock(logger, rs, ps2.GetRoundState(), ps2, peer2))
+	assert.Empty(t, peer2.takeMsgs())
+
+	// the peer, which doesn't support the compact blocks, gets the block parts
+	peer3 := p2pmock.NewPeer(nil)
+	ps3 := NewPeerState(peer3)
+	ps3.PRS.Height = height
+	ps3.PRS.Round = 1
+	assert.False(t, conR.gossipCompactBlock(logger, rs, ps3.GetRoundState(), ps3, peer3))
+
+	// the acknowledgement marks the parts as known to the peer
+	peer4 := newCompactBlocksPeer()
+	ps4 := NewPeerState(peer4)
+	ps4.PRS.Height = height
+	ps4.PRS.Round = 1
+	ps4.SetHasProposal(proposal)
+	require.False(t, ps4.PRS.ProposalBlockParts.IsFull())
+	conR.handleWantTxs(&WantTxsMessage{Height: height, Round: 1}, peer4, ps4)
+	assert.True(t, ps4.PRS.ProposalBlockParts.IsFull())
+}
+
+func TestCompactBlockMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		malleateFn func(*CompactBlockMessage)
+		expErr     string
+	}{
+		{func(msg *CompactBlockMessage) {}, ""},
+		{func(msg *CompactBlockMessage) { msg.TxHashes = nil }, ""},
+		{func(msg *CompactBlockMessage) { msg.Height = -1 }, "negative Height"},
+		{func(msg *CompactBlockMessage) { msg.Round = -1 }, "negative Round"},
+		{func(msg *CompactBlockMessage) { msg.Block = nil }, "nil Block"},
+		{func(msg *CompactBlockMessage) { msg.Block.Header.Height = 2 }, "doesn't match the height"},
+		{func(msg *CompactBlockMessage) { msg.Block.Data.Txs = [][]byte{{1}} }, "contains transactions"},
+		{func(msg *CompactBlockMessage) { msg.TxHashes[0] = []byte{1} }, "wrong TxHash #0"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			msg := &CompactBlockMessage{
+				Height:   1,
+				Round:    0,
+				Block:    &tmproto.Block{Header: tmproto.Header{Height: 1}},
+				TxHashes: [][]byte{types.Tx("tx").Hash()},
+			}
+
+			tc.malleateFn(msg)
+			err := msg.ValidateBasic()
+			if tc.expErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expErr)
+			}
+		})
+	}
+}
+
+func TestCompactBlockTxsMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		malleateFn func(*CompactBlockTxsMessage)
+		expErr     string
+	}{
+		{func(msg *CompactBlockTxsMessage) {}, ""},
+		{func(msg *CompactBlockTxsMessage) { msg.Height = -1 }, "negative Height"},
+		{func(msg *CompactBlockTxsMessage) { msg.Round = -1 }, "negative Round"},
+		{func(msg *CompactBlockTxsMessage) { msg.Txs = nil }, "1 indexes don't match 0 transactions"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			msg := &CompactBlockTxsMessage{
+				Height:  1,
+				Round:   0,
+				Indexes: []uint32{0},
+				Txs:     types.Txs{types.Tx(tmhash.Sum([]byte("tx")))},
+			}
+
+			tc.malleateFn(msg)
+			err := msg.ValidateBasic()
+			if tc.expErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expErr)
+			}
+		})
+	}
+}
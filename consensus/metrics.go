@@ -60,6 +60,19 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+	// Number of the messages of a peer dropped for exceeding the rate.
+	PeerMessagesDropped metrics.Counter
+
+	// Number of the failed recoveries of the threshold signatures of the
+	// precommits.
+	ThresholdRecoveryFailures metrics.Counter
+	// Time spent recovering the threshold signatures of the precommits.
+	ThresholdRecoveryDuration metrics.Histogram
+
+	// Number of the compact blocks reconstructed from the mempool.
+	CompactBlocksReconstructed metrics.Counter
+	// Number of the transactions of the compact blocks missing from the mempool.
+	CompactBlockMissingTxs metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -186,6 +199,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerMessagesDropped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_messages_dropped_total",
+			Help:      "Number of the messages of a peer dropped for exceeding the rate.",
+		}, append(labels, "peer_id", "message_type")).With(labelsAndValues...),
+		ThresholdRecoveryFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "threshold_recovery_failures_total",
+			Help:      "Number of the failed recoveries of the threshold signatures of the precommits.",
+		}, labels).With(labelsAndValues...),
+		ThresholdRecoveryDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "threshold_recovery_duration_seconds",
+			Help:      "Time spent recovering the threshold signatures of the precommits.",
+		}, labels).With(labelsAndValues...),
+		CompactBlocksReconstructed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_blocks_reconstructed_total",
+			Help:      "Number of the compact blocks reconstructed from the mempool.",
+		}, labels).With(labelsAndValues...),
+		CompactBlockMissingTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compact_block_missing_txs_total",
+			Help:      "Number of the transactions of the compact blocks missing from the mempool.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -216,5 +259,13 @@ func NopMetrics() *Metrics {
 		FastSyncing:     discard.NewGauge(),
 		StateSyncing:    discard.NewGauge(),
 		BlockParts:      discard.NewCounter(),
+
+		PeerMessagesDropped: discard.NewCounter(),
+
+		ThresholdRecoveryFailures: discard.NewCounter(),
+		ThresholdRecoveryDuration: discard.NewHistogram(),
+
+		CompactBlocksReconstructed: discard.NewCounter(),
+		CompactBlockMissingTxs:     discard.NewCounter(),
 	}
 }
@@ -133,6 +133,70 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 				},
 			},
 		}
+	case *RoundStateSyncRequestMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_RoundStateSyncRequest{
+				RoundStateSyncRequest: &tmcons.RoundStateSyncRequest{
+					Height: msg.Height,
+					Round:  msg.Round,
+				},
+			},
+		}
+	case *RoundStateSyncResponseMessage:
+		var proposal *tmproto.Proposal
+		if msg.Proposal != nil {
+			proposal = msg.Proposal.ToProto()
+		}
+		votes := make([]*tmproto.Vote, len(msg.Votes))
+		for i, vote := range msg.Votes {
+			votes[i] = vote.ToProto()
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_RoundStateSyncResponse{
+				RoundStateSyncResponse: &tmcons.RoundStateSyncResponse{
+					Height:   msg.Height,
+					Round:    msg.Round,
+					Proposal: proposal,
+					Votes:    votes,
+				},
+			},
+		}
+	case *CompactBlockMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: &tmcons.CompactBlock{
+					Height:   msg.Height,
+					Round:    msg.Round,
+					Block:    msg.Block,
+					TxHashes: msg.TxHashes,
+				},
+			},
+		}
+	case *WantTxsMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_WantTxs{
+				WantTxs: &tmcons.WantTxs{
+					Height:  msg.Height,
+					Round:   msg.Round,
+					Indexes: msg.Indexes,
+				},
+			},
+		}
+	case *CompactBlockTxsMessage:
+		txs := make([][]byte, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			txs[i] = tx
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxs{
+				CompactBlockTxs: &tmcons.CompactBlockTxs{
+					Height:  msg.Height,
+					Round:   msg.Round,
+					Indexes: msg.Indexes,
+					Txs:     txs,
+				},
+			},
+		}
 	case *VoteSetBitsMessage:
 		bi := msg.BlockID.ToProto()
 		bits := msg.Votes.ToProto()
@@ -281,6 +345,58 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *tmcons.Message_RoundStateSyncRequest:
+		pb = &RoundStateSyncRequestMessage{
+			Height: msg.RoundStateSyncRequest.Height,
+			Round:  msg.RoundStateSyncRequest.Round,
+		}
+	case *tmcons.Message_RoundStateSyncResponse:
+		var proposal *types.Proposal
+		if msg.RoundStateSyncResponse.Proposal != nil {
+			p, err := types.ProposalFromProto(msg.RoundStateSyncResponse.Proposal)
+			if err != nil {
+				return nil, fmt.Errorf("roundStateSyncResponse msg to proto error: %w", err)
+			}
+			proposal = p
+		}
+		votes := make([]*types.Vote, len(msg.RoundStateSyncResponse.Votes))
+		for i, pbVote := range msg.RoundStateSyncResponse.Votes {
+			vote, err := types.VoteFromProto(pbVote)
+			if err != nil {
+				return nil, fmt.Errorf("roundStateSyncResponse msg to proto error: %w", err)
+			}
+			votes[i] = vote
+		}
+		pb = &RoundStateSyncResponseMessage{
+			Height:   msg.RoundStateSyncResponse.Height,
+			Round:    msg.RoundStateSyncResponse.Round,
+			Proposal: proposal,
+			Votes:    votes,
+		}
+	case *tmcons.Message_CompactBlock:
+		pb = &CompactBlockMessage{
+			Height:   msg.CompactBlock.Height,
+			Round:    msg.CompactBlock.Round,
+			Block:    msg.CompactBlock.Block,
+			TxHashes: msg.CompactBlock.TxHashes,
+		}
+	case *tmcons.Message_WantTxs:
+		pb = &WantTxsMessage{
+			Height:  msg.WantTxs.Height,
+			Round:   msg.WantTxs.Round,
+			Indexes: msg.WantTxs.Indexes,
+		}
+	case *tmcons.Message_CompactBlockTxs:
+		txs := make(types.Txs, len(msg.CompactBlockTxs.Txs))
+		for i, tx := range msg.CompactBlockTxs.Txs {
+			txs[i] = tx
+		}
+		pb = &CompactBlockTxsMessage{
+			Height:  msg.CompactBlockTxs.Height,
+			Round:   msg.CompactBlockTxs.Round,
+			Indexes: msg.CompactBlockTxs.Indexes,
+			Txs:     txs,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -353,6 +469,14 @@ func WALToProto(msg WALMessage) (*tmcons.WALMessage, error) {
 				},
 			},
 		}
+	case SignedVoteMessage:
+		pb = tmcons.WALMessage{
+			Sum: &tmcons.WALMessage_SignedVote{
+				SignedVote: &tmcons.SignedVote{
+					Vote: msg.Vote.ToProto(),
+				},
+			},
+		}
 	default:
 		return nil, fmt.Errorf("to proto: wal message not recognized: %T", msg)
 	}
@@ -402,6 +526,14 @@ func WALFromProto(msg *tmcons.WALMessage) (WALMessage, error) {
 			Height: msg.EndHeight.Height,
 		}
 		return pb, nil
+	case *tmcons.WALMessage_SignedVote:
+		vote, err := types.VoteFromProto(msg.SignedVote.Vote)
+		if err != nil {
+			return nil, fmt.Errorf("signed vote from proto error: %w", err)
+		}
+		pb = SignedVoteMessage{
+			Vote: vote,
+		}
 	default:
 		return nil, fmt.Errorf("from proto: wal message not recognized: %T", msg)
 	}
@@ -72,6 +72,9 @@ func TestMsgToProto(t *testing.T) {
 	require.NoError(t, err)
 	pbVote := vote.ToProto()
 
+	compactBlock := &tmproto.Block{Header: tmproto.Header{Height: 1}}
+	txHash := types.Tx("tx").Hash()
+
 	testsCases := []struct {
 		testName string
 		msg      Message
@@ -188,6 +191,88 @@ func TestMsgToProto(t *testing.T) {
 				},
 			},
 		}, false},
+		{"successful RoundStateSyncRequest", &RoundStateSyncRequestMessage{
+			Height: 1,
+			Round:  1,
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_RoundStateSyncRequest{
+				RoundStateSyncRequest: &tmcons.RoundStateSyncRequest{
+					Height: 1,
+					Round:  1,
+				},
+			},
+		}, false},
+		{"successful RoundStateSyncResponse", &RoundStateSyncResponseMessage{
+			Height:   1,
+			Round:    1,
+			Proposal: &proposal,
+			Votes:    []*types.Vote{vote},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_RoundStateSyncResponse{
+				RoundStateSyncResponse: &tmcons.RoundStateSyncResponse{
+					Height:   1,
+					Round:    1,
+					Proposal: pbProposal,
+					Votes:    []*tmproto.Vote{pbVote},
+				},
+			},
+		}, false},
+		{"successful RoundStateSyncResponse without proposal", &RoundStateSyncResponseMessage{
+			Height: 1,
+			Round:  1,
+			Votes:  []*types.Vote{vote},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_RoundStateSyncResponse{
+				RoundStateSyncResponse: &tmcons.RoundStateSyncResponse{
+					Height: 1,
+					Round:  1,
+					Votes:  []*tmproto.Vote{pbVote},
+				},
+			},
+		}, false},
+		{"successful CompactBlock", &CompactBlockMessage{
+			Height:   1,
+			Round:    1,
+			Block:    compactBlock,
+			TxHashes: [][]byte{txHash},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: &tmcons.CompactBlock{
+					Height:   1,
+					Round:    1,
+					Block:    compactBlock,
+					TxHashes: [][]byte{txHash},
+				},
+			},
+		}, false},
+		{"successful WantTxs", &WantTxsMessage{
+			Height:  1,
+			Round:   1,
+			Indexes: []uint32{0, 2},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_WantTxs{
+				WantTxs: &tmcons.WantTxs{
+					Height:  1,
+					Round:   1,
+					Indexes: []uint32{0, 2},
+				},
+			},
+		}, false},
+		{"successful CompactBlockTxs", &CompactBlockTxsMessage{
+			Height:  1,
+			Round:   1,
+			Indexes: []uint32{2},
+			Txs:     types.Txs{types.Tx("tx")},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxs{
+				CompactBlockTxs: &tmcons.CompactBlockTxs{
+					Height:  1,
+					Round:   1,
+					Indexes: []uint32{2},
+					Txs:     [][]byte{[]byte("tx")},
+				},
+			},
+		}, false},
 		{"failure", nil, &tmcons.Message{}, true},
 	}
 	for _, tt := range testsCases {
@@ -228,6 +313,23 @@ func TestWALMsgProto(t *testing.T) {
 	pbParts, err := parts.ToProto()
 	require.NoError(t, err)
 
+	vote := &types.Vote{
+		Type:   tmproto.PrecommitType,
+		Height: 1,
+		Round:  1,
+		BlockID: types.BlockID{
+			Hash:          tmrand.Bytes(32),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(32)},
+		},
+		StateID:            types.StateID{LastAppHash: tmrand.Bytes(32)},
+		ValidatorProTxHash: crypto.RandProTxHash(),
+		ValidatorIndex:     1,
+		BlockSignature:     tmrand.Bytes(96),
+		StateSignature:     tmrand.Bytes(96),
+		Extension:          []byte("extension"),
+		ExtensionSignature: tmrand.Bytes(96),
+	}
+
 	testsCases := []struct {
 		testName string
 		msg      WALMessage
@@ -294,6 +396,15 @@ func TestWALMsgProto(t *testing.T) {
 				},
 			},
 		}, false},
+		{"successful SignedVoteMessage", SignedVoteMessage{
+			Vote: vote,
+		}, &tmcons.WALMessage{
+			Sum: &tmcons.WALMessage_SignedVote{
+				SignedVote: &tmcons.SignedVote{
+					Vote: vote.ToProto(),
+				},
+			},
+		}, false},
 		{"failure", nil, &tmcons.WALMessage{}, true},
 	}
 	for _, tt := range testsCases {
@@ -0,0 +1,273 @@
+package consensus
+
+import (
+	"math"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// a message dropped for exceeding the rate adds rateLimitedMsgScore to the
+	// misbehavior score of the peer, a malformed message malformedMsgScore
+	rateLimitedMsgScore = 1
+	malformedMsgScore   = 10
+
+	// the misbehavior score of a peer halves every misbehaviorHalfLife, so
+	// only the peers misbehaving repeatedly reach the threshold
+	misbehaviorHalfLife = time.Minute
+
+	// the scores are kept after the peers disconnect, so a peer doesn't get
+	// rid of its score by reconnecting. Beyond maxScoredPeers, the scores
+	// decayed below a single dropped message are forgotten.
+	maxScoredPeers = 10000
+)
+
+// peerMsgClass is a class of the consensus messages, whose rate is limited
+// together.
+type peerMsgClass int
+
+const (
+	newRoundStepMsgClass peerMsgClass = iota
+	hasVoteMsgClass
+	voteMsgClass
+	otherMsgClass
+
+	numPeerMsgClasses
+)
+
+func classifyMsg(msg Message) peerMsgClass {
+	switch msg.(type) {
+	case *NewRoundStepMessage:
+		return newRoundStepMsgClass
+	case *HasVoteMessage:
+		return hasVoteMsgClass
+	case *VoteMessage:
+		return voteMsgClass
+	default:
+		return otherMsgClass
+	}
+}
+
+func (c peerMsgClass) String() string {
+	switch c {
+	case newRoundStepMsgClass:
+		return "new_round_step"
+	case hasVoteMsgClass:
+		return "has_vote"
+	case voteMsgClass:
+		return "vote"
+	default:
+		return "other"
+	}
+}
+
+// PeerLimits are the limits of the consensus messages of each peer.
+type PeerLimits struct {
+	// Maximum rates of the messages per second, the messages exceeding them
+	// are dropped. A peer can send a second worth of messages at once. The
+	// votes aren't dropped, until the peer sends another second worth of them
+	// over the rate, then the peer is disconnected. Zero means unlimited.
+	NewRoundStepRate float64
+	HasVoteRate      float64
+	VoteRate         float64
+	OtherRate        float64
+
+	// The misbehavior score, at which the peer is disconnected. A dropped
+	// message adds 1 to the score, a malformed one 10, and the score halves
+	// every minute. Zero means the peers aren't disconnected for the dropped
+	// messages.
+	MisbehaviorThreshold float64
+	// Whether the peers reaching the threshold are banned for the ban
+	// duration of the p2p config.
+	BanMisbehaving bool
+}
+
+// ReactorPeerLimits limits the rates of the consensus messages of each peer,
+// and disconnects the peers, which exceed them or send malformed messages
+// repeatedly.
+func ReactorPeerLimits(limits PeerLimits) ReactorOption {
+	return func(conR *Reactor) {
+		conR.peerLimiter = newPeerLimiter(limits)
+	}
+}
+
+// peerLimiter limits the rates of the messages of each peer by their class
+// with token buckets, and keeps the misbehavior scores of the peers. A nil
+// limiter does not limit anything.
+type peerLimiter struct {
+	limits PeerLimits
+
+	mtx     tmsync.Mutex
+	buckets map[p2p.ID]*[numPeerMsgClasses]tokenBucket
+	scores  map[p2p.ID]*misbehaviorScore
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type misbehaviorScore struct {
+	score float64
+	last  time.Time
+}
+
+func newPeerLimiter(limits PeerLimits) *peerLimiter {
+	return &peerLimiter{
+		limits:  limits,
+		buckets: make(map[p2p.ID]*[numPeerMsgClasses]tokenBucket),
+		scores:  make(map[p2p.ID]*misbehaviorScore),
+	}
+}
+
+func (l *peerLimiter) rate(class peerMsgClass) float64 {
+	switch class {
+	case newRoundStepMsgClass:
+		return l.limits.NewRoundStepRate
+	case hasVoteMsgClass:
+		return l.limits.HasVoteRate
+	case voteMsgClass:
+		return l.limits.VoteRate
+	default:
+		return l.limits.OtherRate
+	}
+}
+
+// allow takes a token from the bucket of the class of the messages of the
+// peer. It returns false, if the bucket is empty.
+func (l *peerLimiter) allow(id p2p.ID, class peerMsgClass, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	rate := l.rate(class)
+	if rate <= 0 {
+		return true
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	b := l.bucket(id, class, rate, now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// reserve takes a token from the bucket of the class of the messages of the
+// peer, even if the bucket is empty, as long as the peer doesn't owe more than
+// a second worth of tokens. It returns false, if the peer exceeded that debt.
+// The debt is paid off at the rate.
+func (l *peerLimiter) reserve(id p2p.ID, class peerMsgClass, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	rate := l.rate(class)
+	if rate <= 0 {
+		return true
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	b := l.bucket(id, class, rate, now)
+	if b.tokens-1 < -burstOf(rate) {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// bucket returns the bucket of the class of the messages of the peer, refilled
+// at the rate until now. l.mtx must be held.
+func (l *peerLimiter) bucket(id p2p.ID, class peerMsgClass, rate float64, now time.Time) *tokenBucket {
+	burst := burstOf(rate)
+	buckets, ok := l.buckets[id]
+	if !ok {
+		buckets = new([numPeerMsgClasses]tokenBucket)
+		l.buckets[id] = buckets
+	}
+	b := &buckets[class]
+	if b.last.IsZero() {
+		b.tokens = burst
+	} else {
+		b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	}
+	b.last = now
+	return b
+}
+
+// burstOf returns the size of the buckets of the rate, a second worth of
+// tokens.
+func burstOf(rate float64) float64 {
+	return math.Max(1, math.Ceil(rate))
+}
+
+// misbehaved adds the weight to the misbehavior score of the peer, and
+// returns true, if the score reached the threshold.
+func (l *peerLimiter) misbehaved(id p2p.ID, weight float64, now time.Time) bool {
+	if l == nil {
+		return false
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	s, ok := l.scores[id]
+	if !ok {
+		if len(l.scores) >= maxScoredPeers {
+			l.pruneScores(now)
+		}
+		s = &misbehaviorScore{last: now}
+		if len(l.scores) < maxScoredPeers {
+			l.scores[id] = s
+		}
+	}
+	s.score = s.decayed(now) + weight
+	s.last = now
+	return l.limits.MisbehaviorThreshold > 0 && s.score >= l.limits.MisbehaviorThreshold
+}
+
+// score returns the misbehavior score of the peer.
+func (l *peerLimiter) score(id p2p.ID, now time.Time) float64 {
+	if l == nil {
+		return 0
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if s, ok := l.scores[id]; ok {
+		return s.decayed(now)
+	}
+	return 0
+}
+
+// pruneScores forgets the scores decayed below a single dropped message.
+func (l *peerLimiter) pruneScores(now time.Time) {
+	for id, s := range l.scores {
+		if s.decayed(now) < rateLimitedMsgScore {
+			delete(l.scores, id)
+		}
+	}
+}
+
+// banMisbehaving returns whether the peers reaching the threshold are banned.
+func (l *peerLimiter) banMisbehaving() bool {
+	return l != nil && l.limits.BanMisbehaving
+}
+
+// removePeer forgets the buckets of the peer, its score is kept.
+func (l *peerLimiter) removePeer(id p2p.ID) {
+	if l == nil {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	delete(l.buckets, id)
+}
+
+func (s *misbehaviorScore) decayed(now time.Time) float64 {
+	return s.score * math.Exp2(-now.Sub(s.last).Seconds()/misbehaviorHalfLife.Seconds())
+}
@@ -0,0 +1,118 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestPeerLimiterAllow(t *testing.T) {
+	l := newPeerLimiter(PeerLimits{NewRoundStepRate: 10, VoteRate: 0.5})
+	now := time.Now()
+
+	// a second worth of messages at once
+	for i := 0; i < 10; i++ {
+		require.True(t, l.allow("peer1", newRoundStepMsgClass, now), i)
+	}
+	assert.False(t, l.allow("peer1", newRoundStepMsgClass, now))
+	// the other peers and classes have their own buckets
+	assert.True(t, l.allow("peer2", newRoundStepMsgClass, now))
+	assert.True(t, l.allow("peer1", voteMsgClass, now))
+	assert.False(t, l.allow("peer1", voteMsgClass, now.Add(time.Second)))
+	assert.True(t, l.allow("peer1", voteMsgClass, now.Add(2*time.Second)))
+	// unlimited
+	for i := 0; i < 100; i++ {
+		require.True(t, l.allow("peer1", hasVoteMsgClass, now), i)
+	}
+
+	// the bucket refills at the rate
+	assert.True(t, l.allow("peer1", newRoundStepMsgClass, now.Add(100*time.Millisecond)))
+	assert.False(t, l.allow("peer1", newRoundStepMsgClass, now.Add(100*time.Millisecond)))
+
+	// the peer starts with the full bucket once it's removed
+	l.removePeer("peer1")
+	for i := 0; i < 10; i++ {
+		require.True(t, l.allow("peer1", newRoundStepMsgClass, now), i)
+	}
+
+	var nilLimiter *peerLimiter
+	assert.True(t, nilLimiter.allow("peer1", voteMsgClass, now))
+	assert.False(t, nilLimiter.misbehaved("peer1", malformedMsgScore, now))
+	assert.False(t, nilLimiter.banMisbehaving())
+	nilLimiter.removePeer("peer1")
+}
+
+func TestPeerLimiterReserve(t *testing.T) {
+	l := newPeerLimiter(PeerLimits{VoteRate: 2})
+	now := time.Now()
+
+	// a second worth of messages at once, and another second worth in debt
+	for i := 0; i < 4; i++ {
+		require.True(t, l.reserve("peer1", voteMsgClass, now), i)
+	}
+	assert.False(t, l.reserve("peer1", voteMsgClass, now))
+	// the debt is paid off at the rate
+	assert.True(t, l.reserve("peer1", voteMsgClass, now.Add(500*time.Millisecond)))
+	assert.False(t, l.reserve("peer1", voteMsgClass, now.Add(500*time.Millisecond)))
+	// the other peers have their own buckets
+	assert.True(t, l.reserve("peer2", voteMsgClass, now))
+	// unlimited
+	assert.True(t, l.reserve("peer1", hasVoteMsgClass, now))
+
+	var nilLimiter *peerLimiter
+	assert.True(t, nilLimiter.reserve("peer1", voteMsgClass, now))
+}
+
+func TestPeerLimiterMisbehaved(t *testing.T) {
+	l := newPeerLimiter(PeerLimits{MisbehaviorThreshold: 20})
+	now := time.Now()
+
+	assert.False(t, l.misbehaved("peer1", malformedMsgScore, now))
+	assert.False(t, l.misbehaved("peer2", malformedMsgScore, now))
+	// the score halves every misbehaviorHalfLife
+	now = now.Add(misbehaviorHalfLife)
+	assert.InDelta(t, 5, l.score("peer1", now), 0.001)
+	assert.False(t, l.misbehaved("peer1", malformedMsgScore, now))
+	assert.True(t, l.misbehaved("peer1", 5*rateLimitedMsgScore, now))
+
+	// the score is kept after the peer is removed
+	l.removePeer("peer1")
+	assert.InDelta(t, 20, l.score("peer1", now), 0.001)
+
+	// the peers aren't disconnected without the threshold
+	l = newPeerLimiter(PeerLimits{})
+	for i := 0; i < 100; i++ {
+		require.False(t, l.misbehaved("peer1", malformedMsgScore, now))
+	}
+}
+
+func TestPeerLimiterPruneScores(t *testing.T) {
+	l := newPeerLimiter(PeerLimits{MisbehaviorThreshold: 20})
+	now := time.Now()
+
+	for i := 0; i < maxScoredPeers; i++ {
+		l.misbehaved(p2p.ID(fmt.Sprintf("peer-%d", i)), rateLimitedMsgScore, now)
+	}
+	l.misbehaved("peer1", malformedMsgScore, now)
+	// no room for the new peer, its score is still counted for the message
+	assert.Zero(t, l.score("peer1", now))
+
+	// the decayed scores make room
+	now = now.Add(misbehaviorHalfLife)
+	l.misbehaved("peer1", malformedMsgScore, now)
+	assert.InDelta(t, malformedMsgScore, l.score("peer1", now), 0.001)
+	assert.Len(t, l.scores, 1)
+}
+
+func TestClassifyMsg(t *testing.T) {
+	assert.Equal(t, newRoundStepMsgClass, classifyMsg(&NewRoundStepMessage{}))
+	assert.Equal(t, hasVoteMsgClass, classifyMsg(&HasVoteMessage{}))
+	assert.Equal(t, voteMsgClass, classifyMsg(&VoteMessage{}))
+	assert.Equal(t, otherMsgClass, classifyMsg(&BlockPartMessage{}))
+	assert.Equal(t, "vote", voteMsgClass.String())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -28,11 +29,20 @@ const (
 	DataChannel        = byte(0x21)
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
+	// CompactBlockChannel is only open, if the compact blocks are enabled, so
+	// the peers know, which of them support the compact blocks
+	CompactBlockChannel = byte(0x24)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
+
+	// a peer is sent the round state at most once per interval, so the round
+	// state sync requests can't be used to flood us
+	roundStateSyncInterval = 10 * time.Second
+	// the votes of the round and the prevotes of the POL round
+	maxRoundStateSyncVotes = 3 * types.MaxVotesCount
 )
 
 //-----------------------------------------------------------------------------
@@ -47,6 +57,16 @@ type Reactor struct {
 	waitSync bool
 	eventBus *types.EventBus
 
+	// the compact blocks are enabled, if txs is set
+	txs                 TxProvider
+	compactBlockTimeout time.Duration
+	compactMtx          tmsync.Mutex
+	compactBlock        *compactBlock        // the compact block of our proposal block
+	pendingCompactBlock *pendingCompactBlock // the compact block missing txs
+
+	// limits the messages of the peers, if set
+	peerLimiter *peerLimiter
+
 	Metrics *Metrics
 }
 
@@ -93,8 +113,11 @@ func (conR *Reactor) OnStart() error {
 // state.
 func (conR *Reactor) OnStop() {
 	conR.unsubscribeFromBroadcastEvents()
-	if err := conR.conS.Stop(); err != nil {
-		conR.Logger.Error("Error stopping consensus state", "err", err)
+	// the node drains the consensus state before stopping the reactors
+	if conR.conS.IsRunning() {
+		if err := conR.conS.Stop(); err != nil {
+			conR.Logger.Error("Error stopping consensus state", "err", err)
+		}
 	}
 	if !conR.WaitSync() {
 		conR.conS.Wait()
@@ -134,17 +157,25 @@ conS:
 conR:
 %+v`, err, conR.conS, conR))
 	}
+
+	// Catch up on the current round without waiting for the gossip.
+	for _, peer := range conR.Switch.Peers().List() {
+		if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+			conR.sendRoundStateSyncRequest(peer, ps)
+		}
+	}
 }
 
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
-	return []*p2p.ChannelDescriptor{
+	channels := []*p2p.ChannelDescriptor{
 		{
 			ID:                  StateChannel,
 			Priority:            6,
 			SendQueueCapacity:   100,
 			RecvMessageCapacity: maxMsgSize,
+			MinSendShare:        0.1,
 		},
 		{
 			ID: DataChannel, // maybe split between gossiping current block and catchup stuff
@@ -153,6 +184,7 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   100,
 			RecvBufferCapacity:  50 * 4096,
 			RecvMessageCapacity: maxMsgSize,
+			MinSendShare:        0.2,
 		},
 		{
 			ID:                  VoteChannel,
@@ -160,6 +192,7 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   100,
 			RecvBufferCapacity:  100 * 100,
 			RecvMessageCapacity: maxMsgSize,
+			MinSendShare:        0.2,
 		},
 		{
 			ID:                  VoteSetBitsChannel,
@@ -169,6 +202,16 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvMessageCapacity: maxMsgSize,
 		},
 	}
+	if conR.txs != nil {
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:                  CompactBlockChannel,
+			Priority:            10,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+		})
+	}
+	return channels
 }
 
 // InitPeer implements Reactor by creating a state for the peer.
@@ -198,11 +241,13 @@ func (conR *Reactor) AddPeer(peer p2p.Peer) {
 	// If we're fast_syncing, broadcast a RoundStepMessage later upon SwitchToValidatorConsensus().
 	if !conR.WaitSync() {
 		conR.sendNewRoundStepMessage(peer)
+		conR.sendRoundStateSyncRequest(peer, peerState)
 	}
 }
 
-// RemovePeer is a noop.
+// RemovePeer forgets the message rates of the peer.
 func (conR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	conR.peerLimiter.removePeer(peer.ID())
 	if !conR.IsRunning() {
 		return
 	}
@@ -229,13 +274,17 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		conR.Switch.StopPeerForError(src, err)
+		conR.stopPeerForMalformedMsg(src, err)
+		return
+	}
+
+	if !conR.allowMsg(src, msg) {
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		conR.Switch.StopPeerForError(src, err)
+		conR.stopPeerForMalformedMsg(src, err)
 		return
 	}
 
@@ -256,7 +305,7 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			conR.conS.mtx.Unlock()
 			if err = msg.ValidateHeight(initialHeight); err != nil {
 				conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-				conR.Switch.StopPeerForError(src, err)
+				conR.stopPeerForMalformedMsg(src, err)
 				return
 			}
 			ps.ApplyNewRoundStepMessage(msg)
@@ -266,6 +315,15 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			ps.ApplyHasVoteMessage(msg)
 		case *HasCommitMessage:
 			ps.ApplyHasCommitMessage(msg)
+		case *RoundStateSyncRequestMessage:
+			if conR.WaitSync() {
+				return
+			}
+			if !ps.allowRoundStateSync(tmtime.Now()) {
+				conR.Logger.Debug("Ignoring round state sync request received too soon", "peer", src, "msg", msg)
+				return
+			}
+			go conR.sendRoundState(src, ps, msg)
 		case *VoteSetMaj23Message:
 			cs := conR.conS
 			cs.mtx.Lock()
@@ -314,9 +372,32 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		case *ProposalPOLMessage:
 			ps.ApplyProposalPOLMessage(msg)
 		case *BlockPartMessage:
+			if err := conR.verifyBlockPart(msg); err != nil {
+				conR.Logger.Error("Peer sent us invalid block part", "peer", src, "msg", msg, "err", err)
+				conR.Switch.StopPeerForMisbehavior(src, err)
+				return
+			}
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
 			conR.Metrics.BlockParts.With("peer_id", string(src.ID())).Add(1)
 			conR.conS.peerMsgQueue <- msgInfo{msg, src.ID()}
+		case *RoundStateSyncResponseMessage:
+			conR.applyRoundStateSyncResponse(msg, src, ps)
+		default:
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
+	case CompactBlockChannel:
+		if conR.WaitSync() || conR.txs == nil {
+			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+			return
+		}
+		switch msg := msg.(type) {
+		case *CompactBlockMessage:
+			conR.handleCompactBlock(msg, src, ps)
+		case *WantTxsMessage:
+			conR.handleWantTxs(msg, src, ps)
+		case *CompactBlockTxsMessage:
+			conR.handleCompactBlockTxs(msg, src, ps)
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -384,6 +465,72 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	}
 }
 
+// allowMsg returns false, if the message exceeds the rate of the messages of
+// its class from the peer, and is dropped. The peer is disconnected, once its
+// misbehavior score reaches the threshold.
+//
+// The votes exceeding the rate aren't dropped, because the peer doesn't send a
+// vote again, once it's sent. The peer runs into debt instead, and once it
+// exceeds the debt, it's disconnected, so the votes are gossiped anew, if it
+// reconnects.
+func (conR *Reactor) allowMsg(src p2p.Peer, msg Message) bool {
+	now := tmtime.Now()
+	class := classifyMsg(msg)
+	if class == voteMsgClass {
+		if conR.peerLimiter.reserve(src.ID(), class, now) {
+			return true
+		}
+	} else if conR.peerLimiter.allow(src.ID(), class, now) {
+		return true
+	}
+	conR.Logger.Debug("Dropping message exceeding the rate", "peer", src, "msg", msg)
+	conR.Metrics.PeerMessagesDropped.With("peer_id", string(src.ID()), "message_type", class.String()).Add(1)
+	err := fmt.Errorf("peer exceeded the rate of the %s messages too often", class)
+	if class == voteMsgClass {
+		err = errors.New("peer exceeded the rate of the votes by more than a second worth of them")
+	}
+	if conR.peerLimiter.misbehaved(src.ID(), rateLimitedMsgScore, now) {
+		conR.stopPeerForMisbehavior(src, err)
+	} else if class == voteMsgClass {
+		conR.Switch.StopPeerForError(src, err)
+	}
+	return false
+}
+
+// stopPeerForMalformedMsg disconnects from the peer, which sent a malformed
+// message, and bans it, if its misbehavior score reaches the threshold.
+func (conR *Reactor) stopPeerForMalformedMsg(src p2p.Peer, err error) {
+	if conR.peerLimiter.misbehaved(src.ID(), malformedMsgScore, tmtime.Now()) {
+		conR.stopPeerForMisbehavior(src, err)
+		return
+	}
+	conR.Switch.StopPeerForError(src, err)
+}
+
+// stopPeerForMisbehavior disconnects from the peer, whose misbehavior score
+// reached the threshold, and bans it, if configured.
+func (conR *Reactor) stopPeerForMisbehavior(src p2p.Peer, err error) {
+	if conR.peerLimiter.banMisbehaving() {
+		conR.Switch.StopPeerForMisbehavior(src, err)
+		return
+	}
+	conR.Switch.StopPeerForError(src, err)
+}
+
+// verifyBlockPart checks the block part of the current proposal against its
+// part set header, so that the peer sending a forged part can be punished.
+// The parts of the other heights and rounds aren't checked.
+func (conR *Reactor) verifyBlockPart(msg *BlockPartMessage) error {
+	rs := conR.conS.GetRoundState()
+	if rs.Height != msg.Height || rs.Round != msg.Round {
+		return nil
+	}
+	if err := rs.ProposalBlockParts.VerifyPart(msg.Part); err != nil {
+		return fmt.Errorf("block part %d of proposal %v/%v: %w", msg.Part.Index, msg.Height, msg.Round, err)
+	}
+	return nil
+}
+
 // SetEventBus sets event bus.
 func (conR *Reactor) SetEventBus(b *types.EventBus) {
 	conR.eventBus = b
@@ -397,6 +544,30 @@ func (conR *Reactor) WaitSync() bool {
 	return conR.waitSync
 }
 
+// DropPeerState forgets what the reactor knows about the round state of the
+// given peer, so it's rebuilt from scratch: the peer is sent our round step
+// and asked for its round state again, as after it connected. The peer stays
+// connected.
+func (conR *Reactor) DropPeerState(id p2p.ID) error {
+	peer := conR.Switch.Peers().Get(id)
+	if peer == nil {
+		return fmt.Errorf("peer %v not found", id)
+	}
+	ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
+	if !ok {
+		return fmt.Errorf("peer %v has no state", id)
+	}
+
+	conR.Logger.Info("dropping peer state", "peer", id, "height", ps.GetHeight())
+	ps.reset()
+
+	if !conR.WaitSync() {
+		conR.sendNewRoundStepMessage(peer)
+		conR.sendRoundStateSyncRequest(peer, ps)
+	}
+	return nil
+}
+
 //--------------------------------------
 
 // subscribeToBroadcastEvents subscribes for new round steps and votes
@@ -431,6 +602,13 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events", "err", err)
 	}
+
+	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventThresholdRecoveryFailure,
+		func(data tmevents.EventData) {
+			conR.broadcastMissingPrecommitsRequest(data.(*types.VoteSet))
+		}); err != nil {
+		conR.Logger.Error("Error adding listener for events", "err", err)
+	}
 }
 
 func (conR *Reactor) unsubscribeFromBroadcastEvents() {
@@ -510,6 +688,33 @@ func (conR *Reactor) broadcastHasCommitMessage(commit *types.Commit) {
 	*/
 }
 
+// Broadcasts VoteSetBitsMessage with the precommits we have for the block,
+// whose threshold signatures we failed to recover. The peers forget the
+// precommits they thought we have, so they send us the ones we're missing.
+func (conR *Reactor) broadcastMissingPrecommitsRequest(precommits *types.VoteSet) {
+	msg := makeMissingPrecommitsRequest(precommits)
+	if msg == nil {
+		return
+	}
+	conR.Logger.Info("requesting missing precommits from peers", "height", msg.Height, "round", msg.Round,
+		"block_id", msg.BlockID, "votes", msg.Votes)
+	conR.Switch.Broadcast(VoteSetBitsChannel, MustEncode(msg))
+}
+
+func makeMissingPrecommitsRequest(precommits *types.VoteSet) *VoteSetBitsMessage {
+	recovery := precommits.ThresholdRecovery()
+	if recovery == nil {
+		return nil
+	}
+	return &VoteSetBitsMessage{
+		Height:  precommits.GetHeight(),
+		Round:   precommits.GetRound(),
+		Type:    tmproto.PrecommitType,
+		BlockID: recovery.BlockID,
+		Votes:   precommits.BitArrayByBlockID(recovery.BlockID),
+	}
+}
+
 func makeRoundStepMessage(rs *cstypes.RoundState) (nrsMsg *NewRoundStepMessage) {
 	nrsMsg = &NewRoundStepMessage{
 		Height:                rs.Height,
@@ -527,8 +732,109 @@ func (conR *Reactor) sendNewRoundStepMessage(peer p2p.Peer) {
 	peer.Send(StateChannel, MustEncode(nrsMsg))
 }
 
+// sendRoundStateSyncRequest asks the peer for the proposal and the votes of its
+// current round at our height, so we don't wait for the gossip to catch up.
+// The request is recorded, so that only its response is applied.
+func (conR *Reactor) sendRoundStateSyncRequest(peer p2p.Peer, ps *PeerState) {
+	rs := conR.conS.GetRoundState()
+	req := &RoundStateSyncRequestMessage{Height: rs.Height, Round: rs.Round}
+	ps.setRoundStateSyncRequest(req, tmtime.Now())
+	peer.Send(StateChannel, MustEncode(req))
+}
+
+// sendRoundState sends the peer the proposal, the votes and the block parts of
+// our current round, if we're at the height of the request and not behind its
+// round. The block parts follow the response on the same channel.
+func (conR *Reactor) sendRoundState(peer p2p.Peer, ps *PeerState, req *RoundStateSyncRequestMessage) {
+	rs := conR.conS.GetRoundState()
+	if rs.Height != req.Height || rs.Round < req.Round {
+		return
+	}
+
+	msg := &RoundStateSyncResponseMessage{
+		Height:   rs.Height,
+		Round:    rs.Round,
+		Proposal: rs.Proposal,
+	}
+	// the prevotes first, so the peer moves to our round before the proposal
+	if rs.Proposal != nil && rs.Proposal.POLRound >= 0 {
+		msg.Votes = appendVotes(msg.Votes, rs.Votes.Prevotes(rs.Proposal.POLRound))
+	}
+	msg.Votes = appendVotes(msg.Votes, rs.Votes.Prevotes(rs.Round))
+	msg.Votes = appendVotes(msg.Votes, rs.Votes.Precommits(rs.Round))
+	if msg.Proposal == nil && len(msg.Votes) == 0 {
+		return
+	}
+	if !peer.Send(DataChannel, MustEncode(msg)) {
+		return
+	}
+	if msg.Proposal != nil {
+		ps.SetHasProposal(msg.Proposal)
+	}
+	for _, vote := range msg.Votes {
+		ps.SetHasVote(vote)
+	}
+
+	parts := 0
+	if rs.Proposal != nil && rs.ProposalBlockParts != nil {
+		for i := 0; i < int(rs.ProposalBlockParts.Total()); i++ {
+			part := rs.ProposalBlockParts.GetPart(i)
+			if part == nil {
+				continue
+			}
+			if !peer.Send(DataChannel, MustEncode(&BlockPartMessage{Height: rs.Height, Round: rs.Round, Part: part})) {
+				return
+			}
+			ps.SetHasProposalBlockPart(rs.Height, rs.Round, i)
+			parts++
+		}
+	}
+	conR.Logger.Debug("Sent round state to peer", "peer", peer, "height", rs.Height, "round", rs.Round,
+		"proposal", msg.Proposal != nil, "votes", len(msg.Votes), "block_parts", parts)
+}
+
+// applyRoundStateSyncResponse queues the votes and the proposal of the peer's
+// round for the consensus state. The votes go first, so we move to the round
+// before the proposal is received. The response is dropped, unless it answers
+// the request sent to the peer.
+func (conR *Reactor) applyRoundStateSyncResponse(msg *RoundStateSyncResponseMessage, src p2p.Peer, ps *PeerState) {
+	if !ps.takeRoundStateSyncRequest(msg, tmtime.Now()) {
+		conR.Logger.Debug("Ignoring unsolicited round state sync response", "peer", src, "msg", msg)
+		return
+	}
+
+	cs := conR.conS
+	cs.mtx.RLock()
+	height, valSize := cs.Height, cs.Validators.Size()
+	cs.mtx.RUnlock()
+	if msg.Height != height {
+		return
+	}
+
+	conR.Logger.Info("Received round state from peer", "peer", src, "height", msg.Height, "round", msg.Round,
+		"proposal", msg.Proposal != nil, "votes", len(msg.Votes))
+	ps.EnsureVoteBitArrays(height, valSize)
+	for _, vote := range msg.Votes {
+		ps.SetHasVote(vote)
+		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, src.ID()}
+	}
+	if msg.Proposal != nil {
+		ps.SetHasProposal(msg.Proposal)
+		cs.peerMsgQueue <- msgInfo{&ProposalMessage{msg.Proposal}, src.ID()}
+	}
+}
+
+func appendVotes(votes []*types.Vote, voteSet *types.VoteSet) []*types.Vote {
+	for i := 0; i < voteSet.Size(); i++ {
+		if vote := voteSet.GetByIndex(int32(i)); vote != nil {
+			votes = append(votes, vote)
+		}
+	}
+	return votes
+}
+
 func (conR *Reactor) gossipDataRoutine(peer p2p.Peer, ps *PeerState) {
-	logger := conR.Logger.With("peer", peer)
+	logger := log.WithFields(conR.Logger, log.Fields{log.FieldPeerID: peer.ID()}).With("peer", peer)
 
 OUTER_LOOP:
 	for {
@@ -543,6 +849,12 @@ OUTER_LOOP:
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+				// The peers, which support the compact blocks, reconstruct the
+				// block from their mempool, the parts are sent only if they don't
+				// in time.
+				if conR.gossipCompactBlock(logger, rs, prs, ps, peer) {
+					continue OUTER_LOOP
+				}
 				part := rs.ProposalBlockParts.GetPart(index)
 				msg := &BlockPartMessage{
 					Height: rs.Height, // This tells peer that this part applies to us.
@@ -669,7 +981,7 @@ func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundSt
 }
 
 func (conR *Reactor) gossipVotesRoutine(peer p2p.Peer, ps *PeerState) {
-	logger := conR.Logger.With("peer", peer)
+	logger := log.WithFields(conR.Logger, log.Fields{log.FieldPeerID: peer.ID()}).With("peer", peer)
 
 	// Simple hack to throttle logs upon sleep.
 	var sleeping = 0
@@ -832,7 +1144,7 @@ func (conR *Reactor) gossipVotesForHeight(
 // NOTE: `queryMaj23Routine` has a simple crude design since it only comes
 // into play for liveness when there's a signature DDoS attack happening.
 func (conR *Reactor) queryMaj23Routine(peer p2p.Peer, ps *PeerState) {
-	logger := conR.Logger.With("peer", peer)
+	logger := log.WithFields(conR.Logger, log.Fields{log.FieldPeerID: peer.ID()}).With("peer", peer)
 
 OUTER_LOOP:
 	for {
@@ -1007,6 +1319,18 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	lastRoundStateSync time.Time // when the round state was last sent to the peer
+
+	// the round state sync request sent to the peer, which isn't answered yet
+	roundStateSyncRequest   *RoundStateSyncRequestMessage
+	roundStateSyncRequestAt time.Time
+
+	// the compact block of the round sent to the peer
+	compactBlockHeight    int64
+	compactBlockRound     int32
+	compactBlockSentAt    time.Time
+	compactBlockTxsServed bool
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -1068,6 +1392,125 @@ func (ps *PeerState) GetHeight() int64 {
 	return ps.PRS.Height
 }
 
+// allowRoundStateSync returns whether the round state can be sent to the peer
+// at the given time, and records the time if so.
+func (ps *PeerState) allowRoundStateSync(now time.Time) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if !ps.lastRoundStateSync.IsZero() && now.Sub(ps.lastRoundStateSync) < roundStateSyncInterval {
+		return false
+	}
+	ps.lastRoundStateSync = now
+	return true
+}
+
+// setRoundStateSyncRequest records the round state sync request sent to the
+// peer at the given time, it replaces the previous request.
+func (ps *PeerState) setRoundStateSyncRequest(req *RoundStateSyncRequestMessage, now time.Time) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.roundStateSyncRequest = req
+	ps.roundStateSyncRequestAt = now
+}
+
+// takeRoundStateSyncRequest returns whether the response answers the round
+// state sync request sent to the peer, and forgets the request if so. The
+// response must be at the height of the request and not behind its round, and
+// it must be received within roundStateSyncInterval.
+func (ps *PeerState) takeRoundStateSyncRequest(resp *RoundStateSyncResponseMessage, now time.Time) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	req := ps.roundStateSyncRequest
+	if req == nil {
+		return false
+	}
+	if now.Sub(ps.roundStateSyncRequestAt) > roundStateSyncInterval {
+		ps.roundStateSyncRequest = nil
+		return false
+	}
+	if resp.Height != req.Height || resp.Round < req.Round {
+		return false
+	}
+	ps.roundStateSyncRequest = nil
+	return true
+}
+
+// reset forgets everything known about the peer, as if it just connected.
+func (ps *PeerState) reset() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.PRS = cstypes.PeerRoundState{
+		Round:              -1,
+		ProposalPOLRound:   -1,
+		LastCommitRound:    -1,
+		CatchupCommitRound: -1,
+	}
+	ps.Stats = &peerStateStats{}
+	ps.lastRoundStateSync = time.Time{}
+	ps.roundStateSyncRequest = nil
+	ps.roundStateSyncRequestAt = time.Time{}
+	ps.compactBlockHeight = 0
+	ps.compactBlockRound = 0
+	ps.compactBlockSentAt = time.Time{}
+	ps.compactBlockTxsServed = false
+}
+
+// compactBlockSent returns when the compact block of the round was sent to
+// the peer, the zero time if it wasn't.
+func (ps *PeerState) compactBlockSent(height int64, round int32) time.Time {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactBlockHeight != height || ps.compactBlockRound != round {
+		return time.Time{}
+	}
+	return ps.compactBlockSentAt
+}
+
+// setCompactBlockSent marks the compact block of the round as sent to the peer
+// at the given time.
+func (ps *PeerState) setCompactBlockSent(height int64, round int32, sentAt time.Time) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlockHeight = height
+	ps.compactBlockRound = round
+	ps.compactBlockSentAt = sentAt
+	ps.compactBlockTxsServed = false
+}
+
+// allowCompactBlockTxs returns true once per compact block sent to the peer,
+// so the peer requests its missing transactions only once.
+func (ps *PeerState) allowCompactBlockTxs(height int64, round int32) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactBlockHeight != height || ps.compactBlockRound != round ||
+		ps.compactBlockSentAt.IsZero() || ps.compactBlockTxsServed {
+		return false
+	}
+	ps.compactBlockTxsServed = true
+	return true
+}
+
+// setHasAllProposalBlockParts sets all the parts of the proposal block as known
+// for the peer.
+func (ps *PeerState) setHasAllProposalBlockParts(height int64, round int32) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.PRS.Height != height || ps.PRS.Round != round || ps.PRS.ProposalBlockParts == nil {
+		return
+	}
+	for i := 0; i < ps.PRS.ProposalBlockParts.Size(); i++ {
+		ps.PRS.ProposalBlockParts.SetIndex(i, true)
+	}
+}
+
 // SetHasProposal sets the given proposal as known for the peer.
 func (ps *PeerState) SetHasProposal(proposal *types.Proposal) {
 	ps.mtx.Lock()
@@ -1534,6 +1977,11 @@ func init() {
 	tmjson.RegisterType(&HasCommitMessage{}, "tendermint/HasCommit")
 	tmjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	tmjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	tmjson.RegisterType(&RoundStateSyncRequestMessage{}, "tendermint/RoundStateSyncRequest")
+	tmjson.RegisterType(&RoundStateSyncResponseMessage{}, "tendermint/RoundStateSyncResponse")
+	tmjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	tmjson.RegisterType(&WantTxsMessage{}, "tendermint/WantTxs")
+	tmjson.RegisterType(&CompactBlockTxsMessage{}, "tendermint/CompactBlockTxs")
 }
 
 func decodeMsg(bz []byte) (msg Message, err error) {
@@ -1882,3 +2330,173 @@ func (m *VoteSetBitsMessage) String() string {
 }
 
 //-------------------------------------
+
+// RoundStateSyncRequestMessage is sent by a peer joining consensus to get the
+// proposal and the votes of the current round at its height immediately.
+type RoundStateSyncRequestMessage struct {
+	Height int64
+	Round  int32
+}
+
+// ValidateBasic performs basic validation.
+func (m *RoundStateSyncRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *RoundStateSyncRequestMessage) String() string {
+	return fmt.Sprintf("[RoundStateSyncRequest %v/%02d]", m.Height, m.Round)
+}
+
+//-------------------------------------
+
+// RoundStateSyncResponseMessage carries the proposal and the votes of the
+// current round, and the prevotes of the POL round of the proposal.
+// The proposal block parts follow as BlockPartMessages.
+type RoundStateSyncResponseMessage struct {
+	Height   int64
+	Round    int32
+	Proposal *types.Proposal
+	Votes    []*types.Vote
+}
+
+// ValidateBasic performs basic validation.
+func (m *RoundStateSyncResponseMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if m.Proposal != nil {
+		if err := m.Proposal.ValidateBasic(); err != nil {
+			return fmt.Errorf("wrong Proposal: %v", err)
+		}
+		if m.Proposal.Height != m.Height || m.Proposal.Round != m.Round {
+			return fmt.Errorf("proposal %v/%02d doesn't match the round state", m.Proposal.Height, m.Proposal.Round)
+		}
+	}
+	if len(m.Votes) > maxRoundStateSyncVotes {
+		return fmt.Errorf("too many votes: %d, max: %d", len(m.Votes), maxRoundStateSyncVotes)
+	}
+	for i, vote := range m.Votes {
+		if err := vote.ValidateBasic(); err != nil {
+			return fmt.Errorf("wrong Vote #%d: %v", i, err)
+		}
+		if vote.Height != m.Height || vote.Round > m.Round {
+			return fmt.Errorf("vote #%d %v/%02d doesn't match the round state", i, vote.Height, vote.Round)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *RoundStateSyncResponseMessage) String() string {
+	return fmt.Sprintf("[RoundStateSyncResponse %v/%02d P:%v V:%d]", m.Height, m.Round, m.Proposal, len(m.Votes))
+}
+
+//-------------------------------------
+
+// CompactBlockMessage is the proposal block without the transactions, which
+// are referenced by their hashes, so the peer reconstructs the block from its
+// mempool. The block is kept in the protobuf form, as it isn't a valid block
+// without its transactions.
+type CompactBlockMessage struct {
+	Height   int64
+	Round    int32
+	Block    *tmproto.Block
+	TxHashes [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if m.Block == nil {
+		return errors.New("nil Block")
+	}
+	if m.Block.Header.Height != m.Height {
+		return fmt.Errorf("block height %d doesn't match the height %d", m.Block.Header.Height, m.Height)
+	}
+	if len(m.Block.Data.Txs) > 0 {
+		return errors.New("block contains transactions")
+	}
+	for i, hash := range m.TxHashes {
+		if len(hash) != tmhash.Size {
+			return fmt.Errorf("wrong TxHash #%d: expected size to be %d bytes, got %d bytes", i, tmhash.Size, len(hash))
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock %v/%02d Txs:%d]", m.Height, m.Round, len(m.TxHashes))
+}
+
+//-------------------------------------
+
+// WantTxsMessage requests the transactions of the compact block, which are
+// missing from the mempool, by their indexes in the block. No indexes
+// acknowledge the reconstruction of the block, so the block parts aren't sent.
+type WantTxsMessage struct {
+	Height  int64
+	Round   int32
+	Indexes []uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *WantTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *WantTxsMessage) String() string {
+	return fmt.Sprintf("[WantTxs %v/%02d Txs:%d]", m.Height, m.Round, len(m.Indexes))
+}
+
+//-------------------------------------
+
+// CompactBlockTxsMessage carries the requested transactions of the compact
+// block.
+type CompactBlockTxsMessage struct {
+	Height  int64
+	Round   int32
+	Indexes []uint32
+	Txs     types.Txs
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if len(m.Indexes) != len(m.Txs) {
+		return fmt.Errorf("%d indexes don't match %d transactions", len(m.Indexes), len(m.Txs))
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxs %v/%02d Txs:%d]", m.Height, m.Round, len(m.Txs))
+}
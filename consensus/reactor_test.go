@@ -31,6 +31,7 @@ import (
 	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
@@ -40,6 +41,7 @@ import (
 	statemocks "github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 //----------------------------------------------
@@ -47,7 +49,7 @@ import (
 
 var defaultTestTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 
-func startConsensusNet(t *testing.T, css []*State, n int) (
+func startConsensusNet(t *testing.T, css []*State, n int, options ...ReactorOption) (
 	[]*Reactor,
 	[]types.Subscription,
 	[]*types.EventBus,
@@ -59,7 +61,7 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	for i := 0; i < n; i++ {
 		/*logger, err := tmflags.ParseLogLevel("consensus:info,*:error", logger, "info")
 		if err != nil {	t.Fatal(err)}*/
-		reactors[i] = NewReactor(css[i], true) // so we dont start the consensus states
+		reactors[i] = NewReactor(css[i], true, options...) // so we dont start the consensus states
 		reactors[i].SetLogger(css[i].Logger)
 
 		// eventBus is already started with the cs
@@ -126,6 +128,193 @@ func TestReactorBasic(t *testing.T) {
 	}, css)
 }
 
+// Ensure the proposer of every committed block is the one selected from the
+// validator set in the state store for the round the block was committed in
+func TestReactorProposerFromStateStore(t *testing.T) {
+	const (
+		N      = 4
+		blocks = 100
+	)
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", NewTimeoutTicker, newCounter)
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	// the commit of a block is stored with the next block
+	for i := 0; i <= blocks; i++ {
+		select {
+		case <-blocksSubs[0].Out():
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", i+1)
+		}
+	}
+
+	stateStore, blockStore := css[0].blockExec.Store(), css[0].blockStore
+	proposers := make(map[string]int)
+	for height := int64(1); height <= blocks; height++ {
+		block := blockStore.LoadBlock(height)
+		require.NotNil(t, block, "height %d", height)
+		commit := blockStore.LoadBlockCommit(height)
+		require.NotNil(t, commit, "height %d", height)
+		vals, err := stateStore.LoadValidatorsAtRound(height, commit.Round)
+		require.NoError(t, err)
+		assert.Equal(t, block.ProposerProTxHash, vals.GetProposer().ProTxHash, "height %d", height)
+		proposers[block.ProposerProTxHash.String()]++
+	}
+	// the proposers rotate
+	assert.Len(t, proposers, N)
+}
+
+// sentMessagesPeer is a mock peer, which records the messages sent to it
+type sentMessagesPeer struct {
+	*p2pmock.Peer
+
+	mtx  sync.Mutex
+	msgs []Message
+}
+
+func (p *sentMessagesPeer) Send(chID byte, msgBytes []byte) bool {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.msgs = append(p.msgs, msg)
+	return true
+}
+
+func (p *sentMessagesPeer) TrySend(chID byte, msgBytes []byte) bool {
+	return p.Send(chID, msgBytes)
+}
+
+// A validator, which lags a round behind, catches up on the round from the
+// round state of a peer, and serves its round state to the next peer
+func TestReactorRoundStateSync(t *testing.T) {
+	cs1, vss := randState(4)
+	height := cs1.Height
+	conR := NewReactor(cs1, false)
+	conR.SetLogger(log.TestingLogger())
+
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+
+	startTestRound(cs1, height, 0)
+	ensureNewRound(newRoundCh, height, 0)
+	ensurePrevote(voteCh, height, 0)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	// the other validators are at round 1 already
+	incrementRound(vss[1:]...)
+	proposerProTxHash := cs1.Validators.CopyIncrementProposerPriority(1).GetProposer().ProTxHash
+	var proposer *validatorStub
+	for _, vs := range vss[1:] {
+		proTxHash, err := vs.GetProTxHash()
+		require.NoError(t, err)
+		if bytes2.Equal(proTxHash, proposerProTxHash) {
+			proposer = vs
+		}
+	}
+	require.NotNil(t, proposer)
+	proposal, propBlock := decideProposal(cs1, proposer, height, 1)
+	propBlockParts := propBlock.MakePartSet(types.BlockPartSizeBytes)
+	blockID := proposal.BlockID
+	prevotes := signVotes(tmproto.PrevoteType, blockID.Hash, cs1.state.AppHash, cs1.Validators.QuorumType,
+		cs1.Validators.QuorumHash, blockID.PartSetHeader, vss[1:]...)
+
+	peer := p2pmock.NewPeer(nil)
+	ps := NewPeerState(peer)
+	resp := &RoundStateSyncResponseMessage{
+		Height:   height,
+		Round:    1,
+		Proposal: proposal,
+		Votes:    prevotes,
+	}
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: height, Round: 1, Step: cstypes.RoundStepPropose})
+	// an unsolicited response is dropped
+	conR.applyRoundStateSyncResponse(resp, peer, ps)
+	assert.False(t, ps.GetRoundState().Proposal)
+
+	ps.setRoundStateSyncRequest(&RoundStateSyncRequestMessage{Height: height, Round: 0}, tmtime.Now())
+	conR.applyRoundStateSyncResponse(resp, peer, ps)
+	assert.True(t, ps.GetRoundState().Proposal)
+	// the request is answered only once
+	assert.False(t, ps.takeRoundStateSyncRequest(resp, tmtime.Now()))
+	for i := 0; i < int(propBlockParts.Total()); i++ {
+		cs1.peerMsgQueue <- msgInfo{&BlockPartMessage{height, 1, propBlockParts.GetPart(i)}, peer.ID()}
+	}
+
+	// we skip to round 1 and prevote the proposal without waiting for the
+	// gossip
+	for range prevotes {
+		ensurePrevote(voteCh, height, 1)
+	}
+	ensureNewRound(newRoundCh, height, 1)
+	ensureNewProposal(proposalCh, height, 1)
+	ensurePrevote(voteCh, height, 1)
+	validatePrevote(t, cs1, 1, vss[0], blockID.Hash)
+	ensurePrecommit(voteCh, height, 1)
+
+	// the next peer, which is at round 0, gets the round state once
+	peer2 := &sentMessagesPeer{Peer: p2pmock.NewPeer(nil)}
+	ps2 := NewPeerState(peer2)
+	now := tmtime.Now()
+	require.True(t, ps2.allowRoundStateSync(now))
+	conR.sendRoundState(peer2, ps2, &RoundStateSyncRequestMessage{Height: height, Round: 0})
+	assert.False(t, ps2.allowRoundStateSync(now.Add(time.Second)))
+	assert.True(t, ps2.allowRoundStateSync(now.Add(roundStateSyncInterval)))
+
+	peer2.mtx.Lock()
+	defer peer2.mtx.Unlock()
+	require.Len(t, peer2.msgs, 1+int(propBlockParts.Total()))
+	resp, ok := peer2.msgs[0].(*RoundStateSyncResponseMessage)
+	require.True(t, ok, "%T", peer2.msgs[0])
+	assert.Equal(t, height, resp.Height)
+	assert.EqualValues(t, 1, resp.Round)
+	assert.Equal(t, blockID, resp.Proposal.BlockID)
+	// all the prevotes and our precommit
+	require.Len(t, resp.Votes, len(vss)+1)
+	for _, vote := range resp.Votes[:len(vss)] {
+		assert.Equal(t, tmproto.PrevoteType, vote.Type)
+	}
+	assert.Equal(t, tmproto.PrecommitType, resp.Votes[len(vss)].Type)
+	for i, msg := range peer2.msgs[1:] {
+		part, ok := msg.(*BlockPartMessage)
+		require.True(t, ok, "%T", msg)
+		assert.EqualValues(t, i, part.Part.Index)
+	}
+
+	// a peer at another height gets nothing
+	peer3 := &sentMessagesPeer{Peer: p2pmock.NewPeer(nil)}
+	conR.sendRoundState(peer3, NewPeerState(peer3), &RoundStateSyncRequestMessage{Height: height + 1})
+	assert.Empty(t, peer3.msgs)
+}
+
+func TestPeerStateRoundStateSyncRequest(t *testing.T) {
+	ps := NewPeerState(p2pmock.NewPeer(nil))
+	now := tmtime.Now()
+	req := &RoundStateSyncRequestMessage{Height: 10, Round: 2}
+
+	testCases := []struct {
+		name     string
+		resp     *RoundStateSyncResponseMessage
+		at       time.Time
+		expected bool
+	}{
+		{"other height", &RoundStateSyncResponseMessage{Height: 11, Round: 2}, now, false},
+		{"behind the round", &RoundStateSyncResponseMessage{Height: 10, Round: 1}, now, false},
+		{"expired", &RoundStateSyncResponseMessage{Height: 10, Round: 2}, now.Add(roundStateSyncInterval + time.Second), false},
+		{"same round", &RoundStateSyncResponseMessage{Height: 10, Round: 2}, now, true},
+		{"later round", &RoundStateSyncResponseMessage{Height: 10, Round: 3}, now.Add(time.Second), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ps.setRoundStateSyncRequest(req, now)
+			assert.Equal(t, tc.expected, ps.takeRoundStateSyncRequest(tc.resp, tc.at))
+		})
+	}
+}
+
 // Ensure we can process blocks with evidence
 func TestReactorWithEvidence(t *testing.T) {
 	nValidators := 4
@@ -306,6 +495,82 @@ func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	assert.Equal(t, true, ps.BlockPartsSent() > 0, "number of votes sent should have increased")
 }
 
+// A peer exceeding the rate of the votes is disconnected, and the gossip with
+// the other peers goes on.
+func TestReactorPeerLimits(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N,
+		ReactorPeerLimits(PeerLimits{VoteRate: 1000, MisbehaviorThreshold: 10}))
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	// wait till everyone makes the first new block
+	timeoutWaitGroup(t, N, func(j int) {
+		<-blocksSubs[j].Out()
+	}, css)
+
+	reactor := reactors[0]
+	peer := p2pmock.NewPeer(nil)
+	reactor.InitPeer(peer)
+	// valid votes of another height, which are ignored by the consensus
+	msg := MustEncode(&VoteMessage{Vote: &types.Vote{
+		Type:               tmproto.PrevoteType,
+		Height:             1000000,
+		ValidatorProTxHash: crypto.RandProTxHash(),
+		BlockSignature:     tmrand.Bytes(types.SignatureSize),
+	}})
+	sent := 0
+	for ; sent < 10000 && peer.IsRunning(); sent++ {
+		reactor.Receive(VoteChannel, peer, msg)
+	}
+	assert.False(t, peer.IsRunning(), "the peer wasn't disconnected after %d votes", sent)
+	// the votes aren't dropped, till the peer exceeds the rate by a second worth
+	assert.Greater(t, sent, 2000)
+	assert.GreaterOrEqual(t, reactor.peerLimiter.score(peer.ID(), tmtime.Now()), float64(rateLimitedMsgScore))
+
+	// the honest peers are still connected, and the blocks are made
+	assert.Equal(t, N-1, reactor.Switch.Peers().Size())
+	for _, p := range reactor.Switch.Peers().List() {
+		assert.Zero(t, reactor.peerLimiter.score(p.ID(), tmtime.Now()))
+	}
+	for i := 0; i < 2; i++ {
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+}
+
+// Test the dropped state of a peer is rebuilt from its messages.
+func TestReactorDropPeerState(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	// wait till everyone makes a few blocks
+	for i := 0; i < 3; i++ {
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+
+	peer := reactors[1].Switch.Peers().List()[0]
+	ps := peer.Get(types.PeerStateKey).(*PeerState)
+	votes := ps.VotesSent()
+	require.Greater(t, votes, 0)
+
+	require.NoError(t, reactors[1].DropPeerState(peer.ID()))
+	assert.Less(t, ps.VotesSent(), votes, "the stats of the peer should be reset")
+	assert.Eventually(t, func() bool {
+		return ps.GetHeight() >= css[1].GetRoundState().Height
+	}, 10*time.Second, 10*time.Millisecond, "the round state of the peer should be rebuilt")
+	assert.True(t, reactors[1].Switch.Peers().Has(peer.ID()), "the peer should stay connected")
+
+	assert.Error(t, reactors[1].DropPeerState("unknown"))
+}
+
 func TestReactorValidatorSetChanges(t *testing.T) {
 	nPeers := 7
 	nVals := 4
@@ -465,6 +730,195 @@ func TestReactorWithTimeoutCommit(t *testing.T) {
 	}, css)
 }
 
+// lastCommitVotesApp records the votes of LastCommitInfo it gets by height.
+type lastCommitVotesApp struct {
+	abci.Application
+
+	mtx   tmsync.Mutex
+	votes map[int64][]abci.VoteInfo
+}
+
+func (app *lastCommitVotesApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.mtx.Lock()
+	app.votes[req.Header.Height] = req.LastCommitInfo.Votes
+	app.mtx.Unlock()
+	return app.Application.BeginBlock(req)
+}
+
+func (app *lastCommitVotesApp) lastCommitVotes(height int64) []abci.VoteInfo {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.votes[height]
+}
+
+// Ensure the validators, which don't precommit, are reported to the app as
+// absent by the signers of the last commit, the same on every node, and an
+// event is fired for every block they miss.
+func TestReactorValidatorMissedBlocks(t *testing.T) {
+	const (
+		N       = 4
+		nBlocks = 11
+	)
+	apps := make([]*lastCommitVotesApp, 0, N)
+	css, cleanup := randConsensusNet(N, "consensus_missed_blocks_test", newMockTickerFunc(true),
+		func() abci.Application {
+			app := &lastCommitVotesApp{Application: newCounter(), votes: make(map[int64][]abci.VoteInfo)}
+			apps = append(apps, app)
+			return app
+		})
+	defer cleanup()
+	// the stalled validator is the proposer of some rounds
+	for i := 0; i < N; i++ {
+		ticker := NewTimeoutTicker()
+		ticker.SetLogger(css[i].Logger)
+		css[i].SetTimeoutTicker(ticker)
+	}
+
+	stalled := css[N-1].privValidatorProTxHash
+	missedSubs := make([]types.Subscription, N-1)
+	for i := 0; i < N-1; i++ {
+		sub, err := css[i].eventBus.Subscribe(context.Background(), testSubscriber,
+			types.EventQueryValidatorMissedBlock, nBlocks)
+		require.NoError(t, err)
+		missedSubs[i] = sub
+	}
+
+	// the last validator never starts, so it misses all the blocks
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N-1)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	for i := 0; i < nBlocks; i++ {
+		timeoutWaitGroup(t, N-1, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+
+	for i := 0; i < N-1; i++ {
+		// the first block has no last commit
+		assert.Empty(t, apps[i].lastCommitVotes(1))
+		for height := int64(2); height <= nBlocks; height++ {
+			votes := apps[i].lastCommitVotes(height)
+			require.Len(t, votes, N, "validator %d, height %d", i, height)
+			assert.Equal(t, apps[0].lastCommitVotes(height), votes, "validator %d, height %d", i, height)
+			for _, vote := range votes {
+				absent := bytes2.Equal(vote.Validator.ProTxHash, stalled)
+				assert.Equal(t, !absent, vote.SignedLastBlock, "validator %d, height %d", i, height)
+			}
+		}
+
+		// the quorum needs all the other validators, so only the stalled one misses
+		for height := int64(1); height < nBlocks; height++ {
+			select {
+			case msg := <-missedSubs[i].Out():
+				event := msg.Data().(types.EventDataValidatorMissedBlock)
+				assert.EqualValues(t, height, event.Height, "validator %d", i)
+				assert.EqualValues(t, stalled, event.ProTxHash, "validator %d", i)
+			case <-time.After(time.Second):
+				t.Fatalf("validator %d didn't fire EventValidatorMissedBlock for height %d", i, height)
+			}
+		}
+	}
+}
+
+// divergentApp is a kvstore, whose result of the tx and app hashes from then
+// on differ from the ones of the other nodes.
+type divergentApp struct {
+	*kvstore.Application
+	tx types.Tx
+
+	mtx      tmsync.Mutex
+	diverged bool
+}
+
+func (app *divergentApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	res := app.Application.DeliverTx(req)
+	if bytes2.Equal(req.Tx, app.tx) {
+		res.Events[0].Attributes[0].Value = []byte("divergent")
+		app.mtx.Lock()
+		app.diverged = true
+		app.mtx.Unlock()
+	}
+	return res
+}
+
+func (app *divergentApp) Commit() abci.ResponseCommit {
+	res := app.Application.Commit()
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if app.diverged {
+		res.Data = append([]byte{0xFF}, res.Data[1:]...)
+	}
+	return res
+}
+
+// Ensure a node, whose app diverges, dumps the results of the block on the app
+// hash mismatch, and the diff with the results of another node identifies the
+// divergent tx.
+func TestReactorAppHashMismatchDump(t *testing.T) {
+	const N = 4
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	divergentTx := txs[1]
+
+	diagnosticsDirs := make([]string, 0, N)
+	nApps := 0
+	css, cleanup := randConsensusNet(N, "consensus_app_hash_mismatch_test", newMockTickerFunc(true),
+		func() abci.Application {
+			nApps++
+			if nApps == 1 {
+				return &divergentApp{Application: kvstore.NewApplication(), tx: divergentTx}
+			}
+			return kvstore.NewApplication()
+		},
+		func(c *cfg.Config) {
+			diagnosticsDirs = append(diagnosticsDirs, c.DiagnosticsDir())
+		})
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	for i := 0; i < N; i++ {
+		for _, tx := range txs {
+			require.NoError(t, assertMempool(css[i].txNotifier).CheckTx(tx, nil, mempl.TxInfo{}))
+		}
+	}
+
+	// the others commit the block with the tx and the next one without the
+	// divergent node
+	var (
+		height  int64
+		txIndex = -1
+	)
+	for txIndex < 0 {
+		select {
+		case msg := <-blocksSubs[1].Out():
+			block := msg.Data().(types.EventDataNewBlock).Block
+			height, txIndex = block.Height, block.Txs.Index(divergentTx)
+		case <-time.After(10 * time.Second):
+			t.Fatal("the divergent tx wasn't committed")
+		}
+	}
+	file := sm.ResultsDumpFile(diagnosticsDirs[0], height)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(file)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond, "the results weren't dumped")
+
+	dump, err := sm.ReadResultsDump(file)
+	require.NoError(t, err)
+	assert.Equal(t, height, dump.Height)
+	assert.EqualValues(t, css[0].GetState().AppHash, dump.AppHash)
+	assert.NotEqualValues(t, dump.AppHash, dump.ExpectedAppHash)
+
+	other, err := css[1].blockExec.Store().LoadABCIResponses(height)
+	require.NoError(t, err)
+	diff := sm.DiffResults(dump.ABCIResponses, other)
+	require.False(t, diff.Equal())
+	assert.Equal(t, txIndex, diff.TxIndex)
+	assert.Equal(t, 0, diff.EventIndex)
+	assert.Equal(t, []byte("divergent"), diff.Local.Events[0].Attributes[0].Value)
+	assert.Equal(t, other.DeliverTxs[txIndex], diff.Other)
+}
+
 func waitForAndValidateBlock(
 	t *testing.T,
 	nPeers int,
@@ -909,3 +1363,60 @@ func TestVoteSetBitsMessageValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestRoundStateSyncResponseMessageValidateBasic(t *testing.T) {
+	quorumHash := crypto.RandQuorumHash()
+	pv := types.NewMockPVForQuorum(quorumHash)
+	pk, err := pv.GetPubKey(quorumHash)
+	require.NoError(t, err)
+	val := types.NewValidatorDefaultVotingPower(pk, pv.ProTxHash)
+	vote, err := types.MakeVote(1, types.BlockID{}, types.StateID{},
+		&types.ValidatorSet{Proposer: val, Validators: []*types.Validator{val}, QuorumHash: quorumHash}, pv, "chainID")
+	require.NoError(t, err)
+	blockID := types.BlockID{
+		Hash:          tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+	}
+
+	testCases := []struct {
+		malleateFn func(*RoundStateSyncResponseMessage)
+		expErr     string
+	}{
+		{func(msg *RoundStateSyncResponseMessage) {}, ""},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Proposal = nil }, ""},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Votes = nil }, ""},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Height = -1 }, "negative Height"},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Round = -1 }, "negative Round"},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Proposal.Signature = nil }, "wrong Proposal"},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Proposal.Round = 0 }, "doesn't match the round state"},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Votes[0].Height = 2 }, "vote #0 2/00 doesn't match the round state"},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Votes[0].Round = 2 }, "vote #0 1/02 doesn't match the round state"},
+		{func(msg *RoundStateSyncResponseMessage) { msg.Votes[0].ValidatorIndex = -1 }, "wrong Vote #0"},
+		{func(msg *RoundStateSyncResponseMessage) {
+			msg.Votes = make([]*types.Vote, maxRoundStateSyncVotes+1)
+		}, "too many votes"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			proposal := types.NewProposal(1, 1, 1, 0, blockID)
+			proposal.Signature = tmrand.Bytes(types.SignatureSize)
+			voteCopy := vote.Copy()
+			msg := &RoundStateSyncResponseMessage{
+				Height:   1,
+				Round:    1,
+				Proposal: proposal,
+				Votes:    []*types.Vote{voteCopy},
+			}
+
+			tc.malleateFn(msg)
+			err := msg.ValidateBasic()
+			if tc.expErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expErr)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/dashevo/dashd-go/btcjson"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/log"
@@ -18,6 +20,10 @@ import (
 
 var crc32c = crc32.MakeTable(crc32.Castagnoli)
 
+// initChainAppStateChunkSize is the size of the chunks of the genesis app state
+// sent to the apps, which support InitChainAppStateChunk.
+const initChainAppStateChunkSize = 1 << 20 // 1MB
+
 // Functionality to replay blocks and messages on recovery from a crash.
 // There are two general failure scenarios:
 //
@@ -82,6 +88,11 @@ func (cs *State) readReplayMessage(msg *TimedWALMessage, newStepSub types.Subscr
 	case timeoutInfo:
 		cs.Logger.Info("Replay: Timeout", "height", m.Height, "round", m.Round, "step", m.Step, "dur", m.Duration)
 		cs.handleTimeout(m, cs.RoundState)
+	case SignedVoteMessage:
+		// the vote is re-used, when we sign it again, see catchupReplay
+		v := m.Vote
+		cs.Logger.Info("Replay: Signed vote", "height", v.Height, "round", v.Round, "type", v.Type,
+			"blockID", v.BlockID)
 	default:
 		return fmt.Errorf("replay: Unknown TimedWALMessage type: %v", reflect.TypeOf(msg.Msg))
 	}
@@ -138,7 +149,10 @@ func (cs *State) catchupReplay(csHeight int64) error {
 
 	cs.Logger.Info("Catchup by replaying consensus messages", "height", csHeight)
 
-	var msg *TimedWALMessage
+	var (
+		msg  *TimedWALMessage
+		msgs []*TimedWALMessage
+	)
 	dec := WALDecoder{gr}
 
 LOOP:
@@ -153,7 +167,20 @@ LOOP:
 		case err != nil:
 			return err
 		}
+		msgs = append(msgs, msg)
+	}
+
+	// The votes we signed are recorded before they are sent, but after the
+	// messages, which made us sign them. Collect them first, so we re-use them
+	// instead of signing them again.
+	for _, msg := range msgs {
+		if m, ok := msg.Msg.(SignedVoteMessage); ok {
+			cs.replaySignedVotes = append(cs.replaySignedVotes, m.Vote)
+		}
+	}
+	defer func() { cs.replaySignedVotes = nil }()
 
+	for _, msg := range msgs {
 		// NOTE: since the priv key is set when the msgs are received
 		// it will attempt to eg double sign but we can just ignore it
 		// since the votes will be replayed and we'll get to the next step
@@ -207,6 +234,9 @@ type Handshaker struct {
 
 	nBlocks int // number of blocks applied to the state
 
+	// the app wants the app state of the genesis doc in chunks
+	appStateChunks bool
+
 	appHashSize int
 }
 
@@ -264,6 +294,8 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		"protocol-version", res.AppVersion,
 	)
 
+	h.appStateChunks = res.SupportsInitChainAppStateChunks
+
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
 		h.initialState.Version.Consensus.App = res.AppVersion
@@ -336,7 +368,14 @@ func (h *Handshaker) ReplayBlocks(
 			InitialHeight:   h.genDoc.InitialHeight,
 			ConsensusParams: csParams,
 			ValidatorSet:    nextVals,
-			AppStateBytes:   h.genDoc.AppState,
+		}
+		if h.appStateChunks {
+			req.AppStateChunks, err = h.sendAppStateChunks(proxyApp.Consensus())
+		} else {
+			req.AppStateBytes, err = h.genDoc.LoadAppState()
+		}
+		if err != nil {
+			return nil, err
 		}
 		res, err := proxyApp.Consensus().InitChainSync(req)
 		if err != nil {
@@ -358,10 +397,14 @@ func (h *Handshaker) ReplayBlocks(
 				if err != nil {
 					return nil, err
 				}
-				newValidatorSet := types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, h.genDoc.QuorumType, quorumHash, h.genDoc.NodeProTxHash)
+				quorumType := h.genDoc.QuorumType
+				if res.ValidatorSetUpdate.QuorumType != 0 {
+					quorumType = btcjson.LLMQType(res.ValidatorSetUpdate.QuorumType)
+				}
+				newValidatorSet := types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, quorumType, quorumHash, h.genDoc.NodeProTxHash)
 				h.logger.Debug("Updating validator set", "old", state.Validators, "new", newValidatorSet)
 				state.Validators = newValidatorSet
-				state.NextValidators = types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, h.genDoc.QuorumType, quorumHash, h.genDoc.NodeProTxHash).CopyIncrementProposerPriority(1)
+				state.NextValidators = types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, quorumType, quorumHash, h.genDoc.NodeProTxHash).CopyIncrementProposerPriority(1)
 			} else if len(h.genDoc.Validators) == 0 {
 				// If validator set is not set in genesis and still empty after InitChain, exit.
 				h.logger.Debug("Validator set is nil in genesis and still empty after InitChain")
@@ -460,6 +503,38 @@ func (h *Handshaker) ReplayBlocks(
 		appBlockHeight, storeBlockHeight, stateBlockHeight))
 }
 
+// sendAppStateChunks streams the app state of the genesis doc to the app in
+// chunks of initChainAppStateChunkSize bytes before InitChain, so that the app
+// state isn't held in memory at once. It returns the number of chunks sent.
+func (h *Handshaker) sendAppStateChunks(appConn proxy.AppConnConsensus) (uint32, error) {
+	r, err := h.genDoc.AppStateReader()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	var index uint32
+	for {
+		chunk := make([]byte, initChainAppStateChunkSize)
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			_, err := appConn.InitChainAppStateChunkSync(abci.RequestInitChainAppStateChunk{
+				Index: index,
+				Chunk: chunk[:n],
+			})
+			if err != nil {
+				return 0, fmt.Errorf("error sending the app state chunk %d: %w", index, err)
+			}
+			index++
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return index, nil
+		} else if err != nil {
+			return 0, fmt.Errorf("couldn't read the app state: %w", err)
+		}
+	}
+}
+
 func (h *Handshaker) replayBlocks(
 	state sm.State,
 	proxyApp proxy.AppConns,
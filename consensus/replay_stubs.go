@@ -1,6 +1,8 @@
 package consensus
 
 import (
+	"time"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/clist"
 	mempl "github.com/tendermint/tendermint/mempool"
@@ -23,8 +25,15 @@ func (emptyMempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo)
 }
 func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (emptyMempool) ReapTxsAfter(_ [mempl.TxKeySize]byte, _ int) (types.Txs, error) {
+	return nil, mempl.ErrTxNotFound
+}
+func (emptyMempool) TxByKey(_ [mempl.TxKeySize]byte) (types.Tx, bool) { return nil, false }
+func (emptyMempool) Snapshot(_ int) []mempl.TxSnapshot                { return nil }
 func (emptyMempool) Update(
 	_ int64,
+	_ time.Time,
+	_ []byte,
 	_ types.Txs,
 	_ []*abci.ResponseDeliverTx,
 	_ mempl.PreCheckFunc,
@@ -33,6 +42,7 @@ func (emptyMempool) Update(
 	return nil
 }
 func (emptyMempool) Flush()                        {}
+func (emptyMempool) FlushCache()                   {}
 func (emptyMempool) FlushAppConn() error           { return nil }
 func (emptyMempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (emptyMempool) EnableTxsAvailable()           {}
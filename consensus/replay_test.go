@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
@@ -221,6 +222,95 @@ LOOP:
 	}
 }
 
+type randomExtensionApp struct {
+	*kvstore.Application
+}
+
+func (app randomExtensionApp) ExtendVote(req abci.RequestExtendVote) abci.ResponseExtendVote {
+	return abci.ResponseExtendVote{VoteExtension: tmrand.Bytes(8)}
+}
+
+// TestWALCrashBetweenSignAndBroadcast crashes the validator after it signs its
+// precommit, but before the precommit is broadcast. The application extends
+// the votes randomly, so the precommit signed again would differ. The replay
+// re-uses the precommit recorded in the WAL instead.
+func TestWALCrashBetweenSignAndBroadcast(t *testing.T) {
+	walConfig := ResetConfig(t.Name())
+	defer os.RemoveAll(walConfig.RootDir)
+	state, privVals := randGenesisState(1, false, 10)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = state.InitialHeight
+	blockDB := dbm.NewMemDB()
+
+	cs := newStateWithConfigAndBlockStore(walConfig, state, privVals[0],
+		randomExtensionApp{kvstore.NewApplication()}, blockDB)
+	csWal, err := cs.OpenWAL(cs.config.WalFile())
+	require.NoError(t, err)
+	var signed *types.Vote
+	walPanicked := make(chan error)
+	cs.wal = &crashingWAL{
+		next:         csWal,
+		panicCh:      walPanicked,
+		heightToStop: state.InitialHeight + 1,
+		crashOn: func(m WALMessage) bool {
+			mi, ok := m.(msgInfo)
+			if !ok {
+				return false
+			}
+			msg, ok := mi.Msg.(*VoteMessage)
+			if ok && mi.PeerID == "" && msg.Vote.Type == tmproto.PrecommitType {
+				signed = msg.Vote
+				return true
+			}
+			return false
+		},
+		msgIndex: 1,
+	}
+	require.NoError(t, cs.Start())
+	select {
+	case err := <-walPanicked:
+		t.Logf("WAL panicked: %v", err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("WAL did not panic for 10 seconds")
+	}
+	require.NoError(t, csWal.Stop())
+	require.NotNil(t, signed)
+	require.NotEmpty(t, signed.Extension)
+
+	// restart from the WAL
+	cs = newStateWithConfigAndBlockStore(walConfig, state, privVals[0],
+		randomExtensionApp{kvstore.NewApplication()}, blockDB)
+	voteCh := subscribe(cs.eventBus, types.EventQueryVote)
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	require.NoError(t, cs.Start())
+	defer func() {
+		if err := cs.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var precommit *types.Vote
+	for precommit == nil {
+		select {
+		case msg := <-voteCh:
+			vote := msg.Data().(types.EventDataVote).Vote
+			if vote.Type == tmproto.PrecommitType {
+				precommit = vote
+			}
+		case <-time.After(ensureTimeout):
+			t.Fatal("Timed out waiting for the precommit")
+		}
+	}
+	assert.Equal(t, signed.BlockID, precommit.BlockID)
+	assert.Equal(t, signed.Extension, precommit.Extension)
+	assert.Equal(t, signed.ExtensionSignature, precommit.ExtensionSignature)
+	assert.Equal(t, signed.BlockSignature, precommit.BlockSignature)
+
+	ensureNewBlock(newBlockCh, state.InitialHeight)
+	commit := cs.blockStore.LoadSeenCommit(state.InitialHeight)
+	require.NotNil(t, commit)
+	assert.Equal(t, signed.Extension, commit.VoteExtension)
+}
+
 // crashingWAL is a WAL which crashes or rather simulates a crash during Save
 // (before and after). It remembers a message for which we last panicked
 // (lastPanickedForMsgIndex), so we don't panic for it in subsequent iterations.
@@ -228,6 +318,8 @@ type crashingWAL struct {
 	next         WAL
 	panicCh      chan error
 	heightToStop int64
+	// if set, only the messages it matches crash the WAL
+	crashOn func(WALMessage) bool
 
 	msgIndex                int // current message index
 	lastPanickedForMsgIndex int // last message for which we panicked
@@ -267,6 +359,10 @@ func (w *crashingWAL) Write(m WALMessage) error {
 		return w.next.Write(m)
 	}
 
+	if w.crashOn != nil && !w.crashOn(m) {
+		return w.next.Write(m)
+	}
+
 	if w.msgIndex > w.lastPanickedForMsgIndex {
 		w.lastPanickedForMsgIndex = w.msgIndex
 		_, file, line, _ := runtime.Caller(1)
@@ -972,7 +1068,7 @@ func testHandshakeReplay(t *testing.T, config *cfg.Config, nBlocks int, mode uin
 	if nBlocks > 0 {
 		// run nBlocks against a new client to build up the app state.
 		// use a throwaway tendermint state
-		proxyApp := proxy.NewAppConns(clientCreator2)
+		proxyApp := proxy.NewAppConns(clientCreator2, abcicli.NopMetrics())
 		stateDB1 := dbm.NewMemDB()
 		stateStore := sm.NewStore(stateDB1)
 		err := stateStore.Save(genesisState)
@@ -992,7 +1088,7 @@ func testHandshakeReplay(t *testing.T, config *cfg.Config, nBlocks int, mode uin
 	// now start the app using the handshake - it should sync
 	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
 	handshaker := NewHandshaker(stateStore, state, store, genDoc, config.Consensus.AppHashSize)
-	proxyApp := proxy.NewAppConns(clientCreator2)
+	proxyApp := proxy.NewAppConns(clientCreator2, abcicli.NopMetrics())
 	if err := proxyApp.Start(); err != nil {
 		t.Fatalf("Error starting proxy app connections: %v", err)
 	}
@@ -1103,7 +1199,7 @@ func buildTMStateFromChain(
 	clientCreator := proxy.NewLocalClientCreator(
 		kvstore.NewPersistentKVStoreApplication(
 			filepath.Join(config.DBDir(), fmt.Sprintf("replay_test_%d_%d_t", nBlocks, mode))))
-	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyApp := proxy.NewAppConns(clientCreator, abcicli.NopMetrics())
 	if err := proxyApp.Start(); err != nil {
 		panic(err)
 	}
@@ -1169,7 +1265,7 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 	{
 		app := &badApp{numBlocks: 3, allHashesAreWrong: true}
 		clientCreator := proxy.NewLocalClientCreator(app)
-		proxyApp := proxy.NewAppConns(clientCreator)
+		proxyApp := proxy.NewAppConns(clientCreator, abcicli.NopMetrics())
 		err := proxyApp.Start()
 		require.NoError(t, err)
 		t.Cleanup(func() {
@@ -1193,7 +1289,7 @@ func TestHandshakePanicsIfAppReturnsWrongAppHash(t *testing.T) {
 	{
 		app := &badApp{numBlocks: 3, onlyLastHashIsWrong: true}
 		clientCreator := proxy.NewLocalClientCreator(app)
-		proxyApp := proxy.NewAppConns(clientCreator)
+		proxyApp := proxy.NewAppConns(clientCreator, abcicli.NopMetrics())
 		err := proxyApp.Start()
 		require.NoError(t, err)
 		t.Cleanup(func() {
@@ -1256,6 +1352,7 @@ func makeBlock(state sm.State, lastBlock *types.Block, lastBlockMeta *types.Bloc
 		lastCommit = types.NewCommit(vote.Height, vote.Round,
 			lastBlockMeta.BlockID, lastBlockMeta.StateID, crypto.RandQuorumHash(),
 			vote.BlockSignature, vote.StateSignature)
+		lastCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
 	}
 
 	return state.MakeBlock(height, nil, []types.Tx{}, lastCommit, nil, state.Validators.GetProposer().ProTxHash)
@@ -1481,6 +1578,13 @@ func (bs *mockBlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+func (bs *mockBlockStore) RollbackBlocks(height int64) (uint64, error) {
+	rolledBack := uint64(len(bs.chain)) - uint64(height)
+	bs.chain = bs.chain[:height]
+	bs.commits = bs.commits[:height]
+	return rolledBack, nil
+}
+
 //---------------------------------------
 // Test handshake/init chain
 
@@ -1505,7 +1609,7 @@ func TestHandshakeUpdatesValidators(t *testing.T) {
 	// now start the app using the handshake - it should sync
 	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
 	handshaker := NewHandshaker(stateStore, state, store, genDoc, config.Consensus.AppHashSize)
-	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyApp := proxy.NewAppConns(clientCreator, abcicli.NopMetrics())
 	if err := proxyApp.Start(); err != nil {
 		t.Fatalf("Error starting proxy app connections: %v", err)
 	}
@@ -1538,3 +1642,81 @@ func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitC
 		ValidatorSetUpdate: *ica.vals,
 	}
 }
+
+func TestHandshakeSendsAppStateChunks(t *testing.T) {
+	testCases := []struct {
+		name           string
+		appStateChunks bool
+		expectChunks   int
+	}{
+		{"chunks", true, 3},
+		{"inline", false, 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := ResetConfig("handshake_test_")
+			defer os.RemoveAll(config.RootDir)
+			privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+			pubKey, err := privVal.GetPubKey(crypto.QuorumHash{})
+			require.NoError(t, err)
+			stateDB, state, store := stateAndStore(config, pubKey, 0x0)
+			stateStore := sm.NewStore(stateDB)
+
+			genDoc, err := sm.MakeGenesisDocFromFile(config.GenesisFile())
+			require.NoError(t, err)
+			genDoc.AppState = []byte(fmt.Sprintf(`{"data":%q}`, tmrand.Str(5*initChainAppStateChunkSize/2)))
+
+			app := &appStateChunksApp{appStateChunks: tc.appStateChunks}
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app), abcicli.NopMetrics())
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			handshaker := NewHandshaker(stateStore, state, store, genDoc, config.Consensus.AppHashSize)
+			require.NoError(t, handshaker.Handshake(proxyApp))
+
+			require.NotNil(t, app.initChain)
+			assert.EqualValues(t, tc.expectChunks, app.initChain.AppStateChunks)
+			require.Len(t, app.chunks, tc.expectChunks)
+			appState := app.initChain.AppStateBytes
+			if tc.appStateChunks {
+				assert.Empty(t, appState)
+				appState = nil
+				for i, chunk := range app.chunks {
+					assert.EqualValues(t, i, chunk.Index)
+					assert.LessOrEqual(t, len(chunk.Chunk), initChainAppStateChunkSize)
+					appState = append(appState, chunk.Chunk...)
+				}
+			}
+			assert.Equal(t, []byte(genDoc.AppState), appState)
+		})
+	}
+}
+
+// records the app state chunks and the InitChain request
+type appStateChunksApp struct {
+	abci.BaseApplication
+	appStateChunks bool
+
+	chunks    []abci.RequestInitChainAppStateChunk
+	initChain *abci.RequestInitChain
+}
+
+func (app *appStateChunksApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{SupportsInitChainAppStateChunks: app.appStateChunks}
+}
+
+func (app *appStateChunksApp) InitChainAppStateChunk(
+	req abci.RequestInitChainAppStateChunk) abci.ResponseInitChainAppStateChunk {
+	app.chunks = append(app.chunks, req)
+	return abci.ResponseInitChainAppStateChunk{}
+}
+
+func (app *appStateChunksApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	app.initChain = &req
+	return abci.ResponseInitChain{}
+}
@@ -5,11 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"runtime/debug"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"go.opentelemetry.io/otel/trace"
 
 	cfg "github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
@@ -22,6 +22,7 @@ import (
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tracing"
 	"github.com/tendermint/tendermint/p2p"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
@@ -43,6 +44,9 @@ var (
 
 var msgQueueSize = 1000
 
+// drainPollInterval is how often Drain checks for the safe point
+var drainPollInterval = 10 * time.Millisecond
+
 // msgs from the reactor which may update the state
 type msgInfo struct {
 	Msg    Message `json:"msg"`
@@ -128,6 +132,14 @@ type State struct {
 	replayMode   bool // so we don't log signing errors during replay
 	doWALCatchup bool // determines if we even try to do the catchup
 
+	// the votes we signed at the height being replayed, re-used instead of
+	// signing them again
+	replaySignedVotes []*types.Vote
+
+	// chooses the timeouts of the steps at each height
+	timeoutTracker *timeoutTracker
+	stepTimer      stepTimer
+
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
@@ -145,6 +157,10 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// for tracing the block lifecycle
+	tracer trace.Tracer
+	trace  heightTrace
 }
 
 // StateOption sets an optional parameter on the State.
@@ -189,6 +205,8 @@ func NewStateWithLogger(
 		evpool:           evpool,
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		tracer:           tracing.NopTracer(),
+		timeoutTracker:   newTimeoutTracker(config),
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -287,6 +305,29 @@ func (cs *State) GetValidatorSet() (int64, *types.ValidatorSet) {
 	return cs.state.LastBlockHeight, cs.state.Validators.Copy()
 }
 
+// ForceRoundSkip makes the node give up its current round as if the precommit
+// wait timeout expired: it precommits nil unless it already precommitted and
+// moves to the next round. It's meant for debugging stalled rounds and only
+// affects this node. It returns the height and the round it skipped.
+func (cs *State) ForceRoundSkip() (int64, int32, error) {
+	cs.mtx.RLock()
+	height, round, step := cs.Height, cs.Round, cs.Step
+	cs.mtx.RUnlock()
+
+	if step == cstypes.RoundStepNewHeight || step >= cstypes.RoundStepApplyCommit {
+		return height, round, fmt.Errorf("can't skip round %d at height %d in step %v", round, height, step)
+	}
+
+	cs.Logger.Info("forcing round skip", "height", height, "round", round, "step", step)
+	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{
+		Duration: 0,
+		Height:   height,
+		Round:    round,
+		Step:     cstypes.RoundStepPrecommitWait,
+	})
+	return height, round, nil
+}
+
 // SetPrivValidator sets the private validator account for signing votes. It
 // immediately requests pubkey and caches it.
 func (cs *State) SetPrivValidator(priv types.PrivValidator) {
@@ -376,13 +417,15 @@ func (cs *State) OnStart() error {
 
 			cs.Logger.Debug("backed up WAL file", "src", cs.config.WalFile(), "dst", corruptedFile)
 
-			// 3) try to repair (WAL file will be overwritten!)
-			if err := repairWalFile(corruptedFile, cs.config.WalFile()); err != nil {
+			// 3) try to repair by truncating the corrupted tail (e.g. a torn write),
+			// the corruption followed by other data can't be repaired
+			dropped, err := RepairWALTail(cs.config.WalFile())
+			if err != nil {
 				cs.Logger.Error("the WAL repair failed", "err", err)
 				return err
 			}
 
-			cs.Logger.Info("successful WAL repair")
+			cs.Logger.Info("successful WAL repair", "dropped_bytes", dropped)
 
 			// reload WAL file
 			if err := cs.loadWalFile(); err != nil {
@@ -457,6 +500,36 @@ func (cs *State) Wait() {
 	<-cs.done
 }
 
+// Drain stops the state machine at a safe point, waiting up to the timeout
+// for the block, which got +2/3 precommits, to be committed. The receive
+// routine exits between messages, so the message being handled (e.g. the
+// commit of the block to the application) completes, and the WAL is flushed,
+// fsynced and closed. It returns false, if the timeout expired before the safe
+// point.
+func (cs *State) Drain(timeout time.Duration) bool {
+	if !cs.IsRunning() {
+		return true
+	}
+
+	safe := true
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for safe && cs.GetRoundState().Step == cstypes.RoundStepApplyCommit {
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			safe = false
+		}
+	}
+
+	if err := cs.Stop(); err != nil {
+		cs.Logger.Error("failed trying to stop consensus state", "err", err)
+	}
+	cs.Wait()
+	return safe
+}
+
 // OpenWAL opens a file to log all consensus messages and timeouts for
 // deterministic accountability.
 func (cs *State) OpenWAL(walFile string) (WAL, error) {
@@ -685,6 +758,7 @@ func (cs *State) updateToState(state sm.State, commit *types.Commit, logger log.
 	// RoundState fields
 	cs.updateHeight(height)
 	cs.updateRoundStep(0, cstypes.RoundStepNewHeight)
+	cs.Timeouts = cs.timeoutTracker.timeouts(height)
 
 	if cs.CommitTime.IsZero() {
 		// "Now" makes it easier to sync up dev nodes.
@@ -692,9 +766,9 @@ func (cs *State) updateToState(state sm.State, commit *types.Commit, logger log.
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(tmtime.Now())
+		cs.StartTime = tmtime.Now().Add(cs.Timeouts.Commit)
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = cs.CommitTime.Add(cs.Timeouts.Commit)
 	}
 
 	if cs.Validators == nil || !bytes.Equal(cs.Validators.QuorumHash, validators.QuorumHash) {
@@ -731,6 +805,7 @@ func (cs *State) newStep() {
 	}
 
 	cs.nSteps++
+	cs.traceStep()
 
 	// newStep is called by updateToState in NewState before the eventBus is set!
 	if cs.eventBus != nil {
@@ -1010,7 +1085,7 @@ func (cs *State) handleTxsAvailable() {
 // Enter: +2/3 prevotes any or +2/3 precommits for block or any from (height, round)
 // NOTE: cs.StartTime was already set for height.
 func (cs *State) enterNewRound(height int64, round int32) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	if cs.Height != height || round < cs.Round || (cs.Round == round && cs.Step != cstypes.RoundStepNewHeight) {
 		logger.Debug(
@@ -1026,6 +1101,9 @@ func (cs *State) enterNewRound(height int64, round int32) {
 
 	logger.Debug("entering new round", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	// the precommit step of the last round ended without a commit
+	cs.stopStepTimer(height, cs.Round, cstypes.RoundStepPrecommit)
+
 	// increment validators if necessary
 	validators := cs.Validators
 	if cs.Round < round {
@@ -1051,6 +1129,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 
 	cs.Votes.SetRound(tmmath.SafeAddInt32(round, 1)) // also track next round (round+1) to allow round-skipping
 	cs.TriggeredTimeoutPrecommit = false
+	cs.ProposalRejectReason = ""
 
 	if err := cs.eventBus.PublishEventNewRound(cs.NewRoundEvent()); err != nil {
 		cs.Logger.Error("failed publishing new round", "err", err)
@@ -1092,7 +1171,7 @@ func (cs *State) needProofBlock(height int64) bool {
 // 		after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
 func (cs *State) enterPropose(height int64, round int32) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	if cs.Height != height || round < cs.Round || (cs.Round == round && cstypes.RoundStepPropose <= cs.Step) {
 		logger.Debug(
@@ -1104,6 +1183,8 @@ func (cs *State) enterPropose(height int64, round int32) {
 
 	logger.Debug("entering propose step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.startStepTimer(height, round, cstypes.RoundStepPropose)
+
 	defer func() {
 		// Done enterPropose:
 		cs.updateRoundStep(round, cstypes.RoundStepPropose)
@@ -1118,7 +1199,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.proposeTimeout(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1194,14 +1275,16 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 		cs.Logger.Error("propose step; failed signing proposal; couldn't get proTxHash", "height", height, "round", round, "err", err)
 		return
 	}
+	setPrivValidatorQuorumType(cs.privValidator, validatorsAtProposalHeight)
+	setPrivValidatorPubKey(cs.privValidator, p.Height, validatorsAtProposalHeight, proTxHash)
 	pubKey, err := cs.privValidator.GetPubKey(validatorsAtProposalHeight.QuorumHash)
 	if err != nil {
 		cs.Logger.Error("propose step; failed signing proposal; couldn't get pubKey", "height", height, "round", round, "err", err)
 		return
 	}
 	cs.Logger.Debug("signing proposal","height", proposal.Height, "round", proposal.Round,
-		"proposerProTxHash", proTxHash.ShortString(), "public key", pubKey.Bytes(), "quorum type",
-		validatorsAtProposalHeight.QuorumType, "quorum hash", validatorsAtProposalHeight.QuorumHash)
+		"proposerProTxHash", proTxHash.ShortStringN(crypto.ProTxHashLogSize), "public key", pubKey.Bytes(),
+		"quorum type", validatorsAtProposalHeight.QuorumType, "quorum hash", validatorsAtProposalHeight.QuorumHash)
 
 	if _, err := cs.privValidator.SignProposal(cs.state.ChainID, validatorsAtProposalHeight.QuorumType, validatorsAtProposalHeight.QuorumHash, p); err == nil {
 		proposal.Signature = p.Signature
@@ -1271,7 +1354,15 @@ func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.Pa
 	}
 	proposerProTxHash := cs.privValidatorProTxHash
 
-	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerProTxHash)
+	_, span := tracing.StartSpan(cs.traceContext(), "create_proposal_block",
+		tracing.Height(cs.Height), tracing.Round(cs.Round))
+	block, blockParts, err := cs.blockExec.CreateProposalBlock(cs.Height, cs.Round, cs.state, commit, proposerProTxHash)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		cs.Logger.Error("propose step; failed to create proposal block", "err", err)
+		return nil, nil
+	}
+	return block, blockParts
 }
 
 // Enter: `timeoutPropose` after entering Propose.
@@ -1279,7 +1370,7 @@ func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.Pa
 // Prevote for LockedBlock if we're locked, or ProposalBlock if valid.
 // Otherwise vote nil.
 func (cs *State) enterPrevote(height int64, round int32, allowOldBlocks bool) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	if cs.Height != height || round < cs.Round || (cs.Round == round && cstypes.RoundStepPrevote <= cs.Step) {
 		logger.Debug(
@@ -1297,6 +1388,9 @@ func (cs *State) enterPrevote(height int64, round int32, allowOldBlocks bool) {
 
 	logger.Debug("entering prevote step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.stopStepTimer(height, round, cstypes.RoundStepPropose)
+	cs.startStepTimer(height, round, cstypes.RoundStepPrevote)
+
 	// SignDigest and broadcast vote as necessary
 	cs.doPrevote(height, round, allowOldBlocks)
 
@@ -1305,7 +1399,7 @@ func (cs *State) enterPrevote(height int64, round int32, allowOldBlocks bool) {
 }
 
 func (cs *State) defaultDoPrevote(height int64, round int32, allowOldBlocks bool) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	// If a block is locked, prevote that.
 	if cs.LockedBlock != nil {
@@ -1339,13 +1433,27 @@ func (cs *State) defaultDoPrevote(height int64, round int32, allowOldBlocks bool
 		return
 	}
 
-	// Validate proposal block time
+	// Validate proposal block time against our clock. If it's too far ahead, we
+	// prevote nil, and the round times out, so the next proposer proposes a new
+	// block with its own time.
 	if !allowOldBlocks {
 		err = cs.blockExec.ValidateBlockTime(cs.state, cs.ProposalBlock)
 		if err != nil {
 			// ProposalBlock is invalid, prevote nil.
-			debug.PrintStack()
 			logger.Error("enterPrevote: ProposalBlock time is invalid", "err", err)
+			cs.ProposalRejectReason = err.Error()
+			cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+	}
+
+	// Let the application validate the proposal block, the call is bounded by timeout_propose
+	if cs.config.ProcessProposalHeight > 0 && height >= cs.config.ProcessProposalHeight {
+		err = cs.blockExec.ProcessProposal(cs.ProposalBlock, round, cs.proposeTimeout(round))
+		if err != nil {
+			// ProposalBlock is rejected by the application, prevote nil.
+			logger.Error("prevote step: ProposalBlock is not accepted by the application", "err", err)
+			cs.ProposalRejectReason = err.Error()
 			cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
 			return
 		}
@@ -1360,7 +1468,7 @@ func (cs *State) defaultDoPrevote(height int64, round int32, allowOldBlocks bool
 
 // Enter: any +2/3 prevotes at next round.
 func (cs *State) enterPrevoteWait(height int64, round int32) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	if cs.Height != height || round < cs.Round || (cs.Round == round && cstypes.RoundStepPrevoteWait <= cs.Step) {
 		logger.Debug(
@@ -1386,7 +1494,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.prevoteTimeout(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1396,7 +1504,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 // else, unlock an existing lock and precommit nil if +2/3 of prevotes were nil,
 // else, precommit nil otherwise.
 func (cs *State) enterPrecommit(height int64, round int32) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	if cs.Height != height || round < cs.Round || (cs.Round == round && cstypes.RoundStepPrecommit <= cs.Step) {
 		logger.Debug(
@@ -1409,6 +1517,9 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 	logger.Debug("entering precommit step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.stopStepTimer(height, round, cstypes.RoundStepPrevote)
+	cs.startStepTimer(height, round, cstypes.RoundStepPrecommit)
+
 	defer func() {
 		// Done enterPrecommit:
 		cs.updateRoundStep(round, cstypes.RoundStepPrecommit)
@@ -1481,6 +1592,7 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 		// Validate the block.
 		if err := cs.blockExec.ValidateBlock(cs.state, cs.ProposalBlock); err != nil {
+			err = cs.blockExec.DumpAppHashMismatch(cs.state, err)
 			panic(fmt.Sprintf("precommit step; +2/3 prevoted for an invalid block: %v", err))
 		}
 
@@ -1519,7 +1631,7 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 // Enter: any +2/3 precommits for next round.
 func (cs *State) enterPrecommitWait(height int64, round int32) {
-	logger := cs.Logger.With("height", height, "round", round)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height, log.FieldRound: round})
 
 	if cs.Height != height || round < cs.Round || (cs.Round == round && cs.TriggeredTimeoutPrecommit) {
 		logger.Debug(
@@ -1546,12 +1658,12 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.precommitTimeout(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
 func (cs *State) enterCommit(height int64, commitRound int32) {
-	logger := cs.Logger.With("height", height, "commit_round", commitRound)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height}).With("commit_round", commitRound)
 
 	if cs.Height != height || cstypes.RoundStepApplyCommit <= cs.Step {
 		logger.Debug(
@@ -1563,6 +1675,8 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 
 	logger.Debug("entering commit step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.stopStepTimer(height, commitRound, cstypes.RoundStepPrecommit)
+
 	defer func() {
 		// Done enterCommit:
 		// keep cs.Round the same, commitRound points to the right Precommits set.
@@ -1618,7 +1732,7 @@ func (cs *State) updateProposalBlockAndPartsBeforeCommit(blockID types.BlockID,
 
 // If we have the block AND +2/3 commits for it, finalize.
 func (cs *State) tryFinalizeCommit(height int64) {
-	logger := cs.Logger.With("height", height)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height})
 
 	if cs.Height != height {
 		panic(fmt.Sprintf("tryFinalizeCommit() cs.Height: %v vs height: %v", cs.Height, height))
@@ -1641,12 +1755,21 @@ func (cs *State) tryFinalizeCommit(height int64) {
 		return
 	}
 
+	if cs.missingVoteExtension(height, cs.Votes.Precommits(cs.CommitRound)) {
+		// the commit of the block must carry the vote extension
+		logger.Debug(
+			"failed attempt to finalize commit; waiting for the threshold vote extension signature",
+			"commit_round", cs.CommitRound,
+		)
+		return
+	}
+
 	cs.finalizeCommit(height)
 }
 
 // Increment height and goto cstypes.RoundStepNewHeight
 func (cs *State) finalizeCommit(height int64) {
-	logger := cs.Logger.With("height", height)
+	logger := log.WithFields(cs.Logger, log.Fields{log.FieldHeight: height})
 
 	if cs.Height != height || cs.Step != cstypes.RoundStepApplyCommit {
 		logger.Debug(
@@ -1670,6 +1793,7 @@ func (cs *State) finalizeCommit(height int64) {
 	}
 
 	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
+		err = cs.blockExec.DumpAppHashMismatch(cs.state, err)
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 
@@ -1779,6 +1903,7 @@ func (cs *State) verifyCommit(commit *types.Commit, peerID p2p.ID) (added bool,
 	}
 
 	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
+		err = cs.blockExec.DumpAppHashMismatch(cs.state, err)
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 	return true, nil
@@ -1857,9 +1982,8 @@ func (cs *State) applyCommit(commit *types.Commit, logger log.Logger) {
 		retainHeight int64
 	)
 
-
-
-	stateCopy, retainHeight, err = cs.blockExec.ApplyBlockWithLogger(
+	stateCopy, retainHeight, err = cs.blockExec.ApplyBlockWithContext(
+		cs.traceContext(),
 		stateCopy,
 		&cs.privValidatorProTxHash,
 		types.BlockID{
@@ -1888,6 +2012,7 @@ func (cs *State) applyCommit(commit *types.Commit, logger log.Logger) {
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.endHeightTrace(cs.CommitRound)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy, commit, logger)
@@ -2018,8 +2143,8 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	if !proposer.PubKey.VerifySignatureDigest(proposalBlockSignId, proposal.Signature) {
 		cs.Logger.Debug("error verifying signature", "height", proposal.Height,
-			"round", proposal.Round, "proposer", proposer.ProTxHash.ShortString(), "signature", proposal.Signature, "pubkey",
-			proposer.PubKey.Bytes(), "quorumType", cs.state.Validators.QuorumType,
+			"round", proposal.Round, "proposer", proposer.ProTxHash.ShortStringN(crypto.ProTxHashLogSize),
+			"signature", proposal.Signature, "pubkey", proposer.PubKey.Bytes(), "quorumType", cs.state.Validators.QuorumType,
 			"quorumHash", cs.state.Validators.QuorumHash, "proposalSignId", proposalBlockSignId)
 		return ErrInvalidProposalSignature
 	}
@@ -2067,6 +2192,9 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID, from
 	if err != nil {
 		return added, err
 	}
+	if added {
+		cs.traceBlockPart(round, cs.ProposalBlockParts.IsComplete())
+	}
 	if cs.ProposalBlockParts.ByteSize() > cs.state.ConsensusParams.Block.MaxBytes {
 		return added, fmt.Errorf("total size of proposal block parts exceeds maximum block bytes (%d > %d)",
 			cs.ProposalBlockParts.ByteSize(), cs.state.ConsensusParams.Block.MaxBytes,
@@ -2221,6 +2349,10 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 			return
 		}
 
+		if err := cs.checkVoteExtension(vote); err != nil {
+			return false, err
+		}
+
 		added, err = cs.LastPrecommits.AddVote(vote)
 		if !added {
 			return
@@ -2267,6 +2399,10 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		return false, err
 	}
 
+	if err := cs.checkVoteExtension(vote); err != nil {
+		return false, err
+	}
+
 	cs.Logger.Debug(
 		"adding vote",
 		"vote_height", vote.Height,
@@ -2276,6 +2412,12 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 	)
 
 	height := cs.Height
+	var recoveryAttempts int
+	if vote.Type == tmproto.PrecommitType {
+		if recovery := cs.Votes.Precommits(vote.Round).ThresholdRecovery(); recovery != nil {
+			recoveryAttempts = recovery.Attempts
+		}
+	}
 	added, err = cs.Votes.AddVote(vote, peerID)
 	if !added {
 		// Either duplicate, or error upon cs.Votes.AddByIndex()
@@ -2377,15 +2519,33 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 			"round", vote.Round,
 			"val_index", vote.ValidatorIndex,
 			"data", data)
+		cs.observeThresholdRecovery(precommits, recoveryAttempts)
 
 		blockID, ok := precommits.TwoThirdsMajority()
-		if ok {
+		if ok && len(blockID.Hash) != 0 && cs.missingVoteExtension(height, precommits) {
+			// the block can't be committed without the vote extension, we wait
+			// for more precommits, and retry the block in the next round after
+			// timeout_precommit, as the validators locked on it propose and
+			// precommit it again
+			cs.Logger.Debug("waiting for the precommits to agree on the vote extension",
+				"height", height,
+				"round", vote.Round,
+			)
+			cs.enterNewRound(height, vote.Round)
+			cs.enterPrecommit(height, vote.Round)
+			cs.enterPrecommitWait(height, vote.Round)
+		} else if ok {
 			// Executed as TwoThirdsMajority could be from a higher round
 			cs.enterNewRound(height, vote.Round)
 			cs.enterPrecommit(height, vote.Round)
 
 			if len(blockID.Hash) != 0 {
-				cs.enterCommit(height, vote.Round)
+				if cs.Step == cstypes.RoundStepApplyCommit && cs.CommitRound == vote.Round {
+					// the threshold vote extension signature might be recovered now
+					cs.tryFinalizeCommit(height)
+				} else {
+					cs.enterCommit(height, vote.Round)
+				}
 				if cs.config.SkipTimeoutCommit && precommits.HasAll() {
 					cs.enterNewRound(cs.Height, 0)
 				}
@@ -2404,6 +2564,57 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 	return added, err
 }
 
+// missingVoteExtension returns true if the vote extensions are enabled at the
+// height, and the precommits haven't agreed on the extension yet.
+func (cs *State) missingVoteExtension(height int64, precommits *types.VoteSet) bool {
+	return types.VoteExtensionsEnabled(cs.state.ConsensusParams.ABCI, height) &&
+		!precommits.HasThresholdVoteExtension()
+}
+
+// observeThresholdRecovery reports the attempt to recover the threshold
+// signatures of the precommits, if one was made since the given number of
+// attempts. If it failed again in the round, we ask the peers for the
+// precommits we're missing.
+func (cs *State) observeThresholdRecovery(precommits *types.VoteSet, prevAttempts int) {
+	recovery := precommits.ThresholdRecovery()
+	if recovery == nil || recovery.Attempts == prevAttempts {
+		return
+	}
+	cs.metrics.ThresholdRecoveryDuration.Observe(recovery.AttemptDuration.Seconds())
+	cs.traceThresholdRecovery(precommits, recovery)
+	if recovery.Recovered {
+		return
+	}
+
+	cs.metrics.ThresholdRecoveryFailures.Add(1)
+	cs.LastThresholdRecoveryError = recovery.Error
+	cs.Logger.Error("failed to recover the threshold signatures of the precommits",
+		"height", precommits.GetHeight(),
+		"round", precommits.GetRound(),
+		"block_id", recovery.BlockID,
+		"attempts", recovery.Attempts,
+		"err", recovery.Error)
+	if recovery.Attempts > 1 {
+		cs.evsw.FireEvent(types.EventThresholdRecoveryFailure, precommits)
+	}
+}
+
+// checkVoteExtension checks that the precommits for a block carry a vote
+// extension if, and only if, the vote extensions are enabled at the height.
+func (cs *State) checkVoteExtension(vote *types.Vote) error {
+	if vote.Type != tmproto.PrecommitType || vote.BlockID.Hash == nil {
+		return nil
+	}
+	extensionsEnabled := types.VoteExtensionsEnabled(cs.state.ConsensusParams.ABCI, vote.Height)
+	if extensionsEnabled && !vote.IsExtended() {
+		return fmt.Errorf("precommit at height %d has no vote extension", vote.Height)
+	}
+	if !extensionsEnabled && vote.IsExtended() {
+		return fmt.Errorf("vote extensions are disabled at height %d", vote.Height)
+	}
+	return nil
+}
+
 // CONTRACT: cs.privValidator is not nil.
 func (cs *State) signVote(
 	msgType tmproto.SignedMsgType,
@@ -2426,6 +2637,12 @@ func (cs *State) signVote(
 
 	var lastAppHash = cs.state.AppHash
 
+	// we may have crashed after signing the vote, but before broadcasting it,
+	// so re-use it, as the vote extension may differ if we sign it again
+	if vote := cs.replaySignedVote(msgType, hash, header); vote != nil {
+		return vote, nil
+	}
+
 	vote := &types.Vote{
 		ValidatorProTxHash: proTxHash,
 		ValidatorIndex:     valIdx,
@@ -2440,15 +2657,65 @@ func (cs *State) signVote(
 	if hash == nil {
 		vote.StateID.LastAppHash = nil
 	}
+
+	if msgType == tmproto.PrecommitType && hash != nil &&
+		types.VoteExtensionsEnabled(cs.state.ConsensusParams.ABCI, cs.Height) {
+		extension, err := cs.extendVote(hash)
+		if err != nil {
+			return nil, err
+		}
+		vote.Extension = extension
+	}
 	// fmt.Printf("##state signing vote %v\n", vote)
 
 	v := vote.ToProto()
 	// fmt.Printf("validators for signing vote are %v\n", cs.state.Validators)
+	setPrivValidatorPubKey(cs.privValidator, cs.Height, cs.state.Validators, cs.privValidatorProTxHash)
 	err := cs.privValidator.SignVote(cs.state.ChainID, cs.state.Validators.QuorumType, cs.state.Validators.QuorumHash, v)
 	vote.BlockSignature = v.BlockSignature
 	vote.StateSignature = v.StateSignature
+	vote.ExtensionSignature = v.ExtensionSignature
+	if err != nil {
+		return vote, err
+	}
+
+	if err := cs.wal.WriteSync(SignedVoteMessage{vote}); err != nil {
+		return nil, err
+	}
+
+	return vote, nil
+}
+
+// replaySignedVote returns the vote for the block, which we signed at the
+// current round, and which is recorded in the WAL being replayed.
+func (cs *State) replaySignedVote(
+	msgType tmproto.SignedMsgType,
+	hash []byte,
+	header types.PartSetHeader,
+) *types.Vote {
+	blockID := types.BlockID{Hash: hash, PartSetHeader: header}
+	for _, vote := range cs.replaySignedVotes {
+		if vote.Height == cs.Height && vote.Round == cs.Round && vote.Type == msgType &&
+			vote.BlockID.Equals(blockID) {
+			return vote
+		}
+	}
+	return nil
+}
 
-	return vote, err
+// extendVote requests the extension of the precommit for the block with the
+// hash from the application.
+func (cs *State) extendVote(hash []byte) ([]byte, error) {
+	var block *types.Block
+	switch {
+	case cs.LockedBlock.HashesTo(hash):
+		block = cs.LockedBlock
+	case cs.ProposalBlock.HashesTo(hash):
+		block = cs.ProposalBlock
+	default:
+		return nil, fmt.Errorf("can't extend the precommit for the unknown block %X", hash)
+	}
+	return cs.blockExec.ExtendVote(block, cs.Round)
 }
 
 // sign the vote and publish on internalMsgQueue
@@ -2488,6 +2755,8 @@ func (cs *State) updatePrivValidatorPubKey() error {
 		return nil
 	}
 
+	setPrivValidatorQuorumType(cs.privValidator, cs.Validators)
+	setPrivValidatorPubKey(cs.privValidator, cs.Height, cs.Validators, cs.privValidatorProTxHash)
 	pubKey, err := cs.privValidator.GetPubKey(cs.Validators.QuorumHash)
 	if err != nil {
 		return err
@@ -2496,6 +2765,28 @@ func (cs *State) updatePrivValidatorPubKey() error {
 	return nil
 }
 
+// setPrivValidatorQuorumType tells the private validator the quorum type of the
+// validator set, if it needs it to look up the keys of the quorum.
+func setPrivValidatorQuorumType(privValidator types.PrivValidator, vals *types.ValidatorSet) {
+	if setter, ok := privValidator.(types.QuorumTypeSetter); ok && vals != nil {
+		setter.SetQuorumType(vals.QuorumHash, vals.QuorumType)
+	}
+}
+
+// setPrivValidatorPubKey tells the private validator the public key of the
+// validator in the validator set of the height, if it selects the signing key
+// by it, e.g. during a rotation of its key.
+func setPrivValidatorPubKey(privValidator types.PrivValidator, height int64, vals *types.ValidatorSet,
+	proTxHash crypto.ProTxHash) {
+	setter, ok := privValidator.(types.ValidatorPubKeySetter)
+	if !ok || vals == nil {
+		return
+	}
+	if _, val := vals.GetByProTxHash(proTxHash); val != nil {
+		setter.SetValidatorPubKey(height, vals.QuorumHash, val.PubKey)
+	}
+}
+
 // updatePrivValidatorProTxHash get's the private validator proTxHash and
 // memoizes it. This func returns an error if the private validator is not
 // responding or responds with an error.
@@ -2535,39 +2826,3 @@ func CompareHRS(h1 int64, r1 int32, s1 cstypes.RoundStepType, h2 int64, r2 int32
 	}
 	return 0
 }
-
-// repairWalFile decodes messages from src (until the decoder errors) and
-// writes them to dst.
-func repairWalFile(src, dst string) error {
-	in, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer in.Close()
-
-	out, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-
-	var (
-		dec = NewWALDecoder(in)
-		enc = NewWALEncoder(out)
-	)
-
-	// best-case repair (until first error is encountered)
-	for {
-		msg, err := dec.Decode()
-		if err != nil {
-			break
-		}
-
-		err = enc.Encode(msg)
-		if err != nil {
-			return fmt.Errorf("failed to encode msg: %w", err)
-		}
-	}
-
-	return nil
-}
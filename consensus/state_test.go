@@ -3,22 +3,35 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/tendermint/tendermint/abci/example/counter"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmevents "github.com/tendermint/tendermint/libs/events"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/libs/tracing"
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 /*
@@ -30,10 +43,14 @@ x * TestEnterProposeNoValidator - timeout into prevote round
 x * TestEnterPropose - finish propose without timing out (we have the proposal)
 x * TestBadProposal - 2 vals, bad proposal (bad block state hash), should prevote and precommit nil
 x * TestOversizedBlock - block with too many txs should be rejected
+x * TestProposalTimeDrift - 2 vals, proposal time ahead of our clock by more than the max drift should be rejected
 FullRoundSuite
 x * TestFullRound1 - 1 val, full successful round
 x * TestFullRoundNil - 1 val, full round of nil
 x * TestFullRound2 - 2 vals, both required for full round
+x * TestQuorumSignProgress - 4 vals, one stalls, it's reported as missing
+x * TestVoteExtensions - 1 val, the precommits are extended from the enable height
+x * TestThresholdRecoveryFailure - 7 vals, one signs with a stale key share, the failure is reported and the precommits re-requested
 LockSuite
 x * TestLockNoPOL - 2 vals, 4 rounds. one val locked, precommits nil every round except first.
 x * TestLockPOLRelock - 4 vals, one precommits, other 3 polka at next round, so we unlock and precomit the polka
@@ -178,6 +195,34 @@ func TestStateEnterProposeYesPrivValidator(t *testing.T) {
 	ensureNoNewTimeout(timeoutCh, cs.config.TimeoutPropose.Nanoseconds())
 }
 
+// a validator, which is stuck in a round, precommits nil and moves to the next
+// round when forced to skip it
+func TestStateForceRoundSkip(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+
+	// the round isn't started yet
+	_, _, err := cs1.ForceRoundSkip()
+	assert.Error(t, err)
+
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	// the others don't vote, so we wait for the prevotes
+	ensurePrevote(voteCh, height, round)
+
+	skippedHeight, skippedRound, err := cs1.ForceRoundSkip()
+	require.NoError(t, err)
+	assert.Equal(t, height, skippedHeight)
+	assert.Equal(t, round, skippedRound)
+
+	ensurePrecommit(voteCh, height, round)
+	validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
+	ensureNewRound(newRoundCh, height, round+1)
+}
+
 func TestStateBadProposal(t *testing.T) {
 	cs1, vss := randState(2)
 	height, round := cs1.Height, cs1.Round
@@ -236,6 +281,95 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+// 2 vals, the clock of the proposer at height 2 is ahead of ours. Its proposal
+// is prevoted if the drift is within the synchrony params. Otherwise, we prevote
+// nil and propose a new block in the next round.
+func TestStateProposalTimeDrift(t *testing.T) {
+	const maxDrift = 10 * time.Second
+	testCases := []struct {
+		name  string
+		drift time.Duration
+		valid bool
+	}{
+		{"within max drift", maxDrift / 2, true},
+		{"beyond max drift", 2 * maxDrift, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cs1, vss := randState(2)
+			cs1.state.ConsensusParams.Synchrony.MaxDrift = maxDrift
+			vs2 := vss[1]
+			height, round := cs1.Height, cs1.Round
+
+			partSize := types.BlockPartSizeBytes
+
+			newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+			proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+			voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+
+			// commit the first block, proposed by us
+			startTestRound(cs1, height, round)
+			ensureNewRound(newRoundCh, height, round)
+			ensureNewProposal(proposalCh, height, round)
+			rs := cs1.GetRoundState()
+			ensurePrevote(voteCh, height, round)
+			signAddVotes(cs1, tmproto.PrevoteType, rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header(), vs2)
+			ensurePrevote(voteCh, height, round)
+			ensurePrecommit(voteCh, height, round)
+			signAddVotes(cs1, tmproto.PrecommitType, rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header(), vs2)
+			ensurePrecommit(voteCh, height, round)
+
+			height++
+			incrementHeight(vs2)
+			ensureNewRound(newRoundCh, height, round)
+			proTxHash, err := vs2.GetProTxHash()
+			require.NoError(t, err)
+			require.Equal(t, proTxHash, cs1.GetRoundState().Validators.GetProposer().ProTxHash)
+
+			// vs2 proposes a block with the time of its clock
+			propBlock, _ := cs1.createProposalBlock()
+			propBlock.Time = tmtime.Now().Add(tc.drift)
+			propBlockParts := propBlock.MakePartSet(partSize)
+			blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+			proposal := types.NewProposal(height, 1, round, -1, blockID)
+			p := proposal.ToProto()
+			_, err = vs2.SignProposal(config.ChainID(), cs1.Validators.QuorumType, cs1.Validators.QuorumHash, p)
+			require.NoError(t, err)
+			proposal.Signature = p.Signature
+			require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+
+			ensureProposal(proposalCh, height, round, blockID)
+			ensurePrevote(voteCh, height, round)
+			if tc.valid {
+				validatePrevote(t, cs1, round, vss[0], propBlock.Hash())
+				assert.Empty(t, cs1.GetRoundState().ProposalRejectReason)
+				return
+			}
+			validatePrevote(t, cs1, round, vss[0], nil)
+			assert.Contains(t, cs1.GetRoundState().ProposalRejectReason, "max drift")
+
+			// vs2 prevotes and precommits nil, so the round times out
+			signAddVotes(cs1, tmproto.PrevoteType, nil, types.PartSetHeader{}, vs2)
+			ensurePrevote(voteCh, height, round)
+			ensurePrecommit(voteCh, height, round)
+			validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
+			signAddVotes(cs1, tmproto.PrecommitType, nil, types.PartSetHeader{}, vs2)
+			ensurePrecommit(voteCh, height, round)
+
+			// we propose a new block with our time in the next round, and prevote it
+			round++
+			ensureNewRound(newRoundCh, height, round)
+			ensureNewProposal(proposalCh, height, round)
+			rs = cs1.GetRoundState()
+			assert.NotEqual(t, propBlock.Hash(), rs.ProposalBlock.Hash())
+			assert.False(t, rs.ProposalBlock.Time.After(tmtime.Now()))
+			ensurePrevote(voteCh, height, round)
+			validatePrevote(t, cs1, round, vss[0], rs.ProposalBlock.Hash())
+		})
+	}
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 	cs1, vss := randState(2)
 	cs1.state.ConsensusParams.Block.MaxBytes = 2000
@@ -298,6 +432,23 @@ func TestStateOversizedBlock(t *testing.T) {
 // FullRoundSuite
 
 // propose, prevote, and precommit a block
+func TestStateDrain(t *testing.T) {
+	cs, _ := randState(1)
+	height := cs.Height
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+
+	// a state machine, which isn't running, is drained already
+	assert.True(t, cs.Drain(time.Second))
+
+	require.NoError(t, cs.Start())
+	ensureNewBlock(newBlockCh, height)
+
+	assert.True(t, cs.Drain(ensureTimeout))
+	assert.False(t, cs.IsRunning())
+	// the receive routine exited
+	cs.Wait()
+}
+
 func TestStateFullRound1(t *testing.T) {
 	cs, vss := randState(1)
 	height, round := cs.Height, cs.Round
@@ -354,6 +505,112 @@ func TestStateFullRoundNil(t *testing.T) {
 	validatePrevoteAndPrecommit(t, cs, round, -1, vss[0], nil, nil)
 }
 
+// syncBuffer is a bytes.Buffer, which can be written and read concurrently.
+type syncBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
+
+// the JSON log lines of the round steps include the contextual fields
+func TestStateFullRoundLogFields(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	var buf syncBuffer
+	cs.SetLogger(log.NewTMJSONLoggerNoTS(&buf).With(log.FieldModule, "consensus"))
+
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+
+	startTestRound(cs, height, round)
+
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewRound(newRoundCh, height+1, 0)
+
+	steps := map[string]bool{
+		"entering new round":         false,
+		"entering propose step":      false,
+		"entering prevote step":      false,
+		"entering precommit step":    false,
+		"entering commit step":       false,
+		"finalizing commit of block": false,
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &fields), line)
+		msg, _ := fields["_msg"].(string)
+		if _, ok := steps[msg]; !ok || fields[log.FieldHeight] != float64(height) {
+			continue
+		}
+		steps[msg] = true
+		assert.Equal(t, "consensus", fields[log.FieldModule], line)
+		if msg != "entering commit step" && msg != "finalizing commit of block" {
+			assert.Equal(t, float64(round), fields[log.FieldRound], line)
+		}
+	}
+	for msg, logged := range steps {
+		assert.True(t, logged, msg)
+	}
+}
+
+// the spans of the block lifecycle are linked under the trace of the height
+func TestStateFullRoundTracing(t *testing.T) {
+	cs, _ := randState(1)
+	height, round := cs.Height, cs.Round
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	StateTracer(provider.Tracer(tracing.TracerName))(cs)
+
+	newRoundCh := subscribe(cs.eventBus, types.EventQueryNewRound)
+
+	startTestRound(cs, height, round)
+
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewRound(newRoundCh, height+1, 0)
+
+	var heightSpan *sdktrace.SpanSnapshot
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "height" {
+			heightSpan = span
+			break
+		}
+	}
+	require.NotNil(t, heightSpan)
+	assert.Contains(t, heightSpan.Attributes, tracing.Height(height))
+	assert.Contains(t, heightSpan.Attributes, tracing.Round(round))
+
+	names := make(map[string]bool)
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID() == heightSpan.SpanContext.TraceID() {
+			names[span.Name] = true
+		}
+	}
+	for _, name := range []string{
+		cstypes.RoundStepPropose.String(),
+		cstypes.RoundStepPrevote.String(),
+		cstypes.RoundStepPrecommit.String(),
+		cstypes.RoundStepApplyCommit.String(),
+		"create_proposal_block",
+		"abci.execute_block",
+		"abci.commit",
+		"state.save",
+	} {
+		assert.True(t, names[name], name)
+	}
+}
+
 // run through propose, prevote, precommit commit with two validators
 // where the first validator has to wait for votes from the second
 func TestStateFullRound2(t *testing.T) {
@@ -391,6 +648,286 @@ func TestStateFullRound2(t *testing.T) {
 	ensureNewBlock(newBlockCh, height)
 }
 
+type voteExtensionApp struct {
+	*counter.Application
+}
+
+func (app voteExtensionApp) ExtendVote(req abci.RequestExtendVote) abci.ResponseExtendVote {
+	return abci.ResponseExtendVote{VoteExtension: []byte(fmt.Sprintf("extension-%d", req.Height))}
+}
+
+// 1 val, the vote extensions are enabled from height 3, so the commits of
+// the blocks before are not extended
+func TestStateVoteExtensions(t *testing.T) {
+	const enableHeight = 3
+	state, privVals := randGenesisState(1, false, 10)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = enableHeight
+	cs1 := newState(state, privVals[0], voteExtensionApp{counter.NewApplication(true)})
+	height, round := cs1.Height, cs1.Round
+
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+
+	startTestRound(cs1, height, round)
+	for h := height; h <= enableHeight+1; h++ {
+		ensureNewBlock(newBlockCh, h)
+	}
+
+	for h := height; h <= enableHeight+1; h++ {
+		commit := cs1.blockStore.LoadSeenCommit(h)
+		require.NotNil(t, commit)
+		if h < enableHeight {
+			assert.False(t, commit.IsExtended(), "height %d", h)
+			continue
+		}
+		assert.True(t, commit.IsExtended(), "height %d", h)
+		assert.Equal(t, []byte(fmt.Sprintf("extension-%d", h)), commit.VoteExtension)
+	}
+
+	// the extended commit is part of the next block
+	block := cs1.blockStore.LoadBlock(enableHeight + 1)
+	require.NotNil(t, block)
+	assert.True(t, block.LastCommit.IsExtended())
+	assert.NoError(t, cs1.state.LastValidators.VerifyCommit(cs1.state.ChainID, block.LastCommit.BlockID,
+		block.LastCommit.StateID, enableHeight, block.LastCommit))
+}
+
+// 4 vals, the precommits for the block sign different vote extensions in round
+// 0, so the block isn't committed until they agree on the extension in round 1
+func TestStateVoteExtensionNextRound(t *testing.T) {
+	state, privVals := randGenesisState(4, false, 10)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = 1
+	cs1 := newState(state, privVals[0], voteExtensionApp{counter.NewApplication(true)})
+	vss := make([]*validatorStub, len(privVals))
+	for i := range privVals {
+		vss[i] = newValidatorStub(privVals[i], int32(i))
+	}
+	incrementHeight(vss[1:]...)
+	height, round := cs1.Height, cs1.Round
+
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+
+	addPrecommits := func(blockID types.BlockID, extension func(i int) []byte) {
+		for i, vs := range vss[1:] {
+			proTxHash, err := vs.GetProTxHash()
+			require.NoError(t, err)
+			vote := &types.Vote{
+				ValidatorIndex:     vs.Index,
+				ValidatorProTxHash: proTxHash,
+				Height:             vs.Height,
+				Round:              vs.Round,
+				Type:               tmproto.PrecommitType,
+				BlockID:            blockID,
+				StateID:            types.StateID{LastAppHash: cs1.state.AppHash},
+				Extension:          extension(i),
+			}
+			v := vote.ToProto()
+			require.NoError(t, vs.SignVote(config.ChainID(), cs1.Validators.QuorumType, cs1.Validators.QuorumHash, v))
+			vote.BlockSignature = v.BlockSignature
+			vote.StateSignature = v.StateSignature
+			vote.ExtensionSignature = v.ExtensionSignature
+			addVotes(cs1, vote)
+			ensurePrecommit(voteCh, height, vs.Round)
+		}
+	}
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensurePrevote(voteCh, height, round)
+	rs := cs1.GetRoundState()
+	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+	blockID := types.BlockID{Hash: propBlockHash, PartSetHeader: propPartSetHeader}
+
+	signAddVotes(cs1, tmproto.PrevoteType, propBlockHash, propPartSetHeader, vss[1:]...)
+	for i := 1; i < len(vss); i++ {
+		ensurePrevote(voteCh, height, round)
+	}
+	ensurePrecommit(voteCh, height, round)
+
+	// the precommits for the block don't agree on the extension
+	addPrecommits(blockID, func(i int) []byte { return []byte(fmt.Sprintf("other-extension-%d", i)) })
+	ensureNoNewEventOnChannel(newBlockCh)
+
+	// the block is retried in the next round after timeout_precommit
+	round++
+	incrementRound(vss[1:]...)
+	ensureNewRound(newRoundCh, height, round)
+	ensurePrevote(voteCh, height, round) // prevote the locked block
+	validatePrevote(t, cs1, round, vss[0], propBlockHash)
+
+	signAddVotes(cs1, tmproto.PrevoteType, propBlockHash, propPartSetHeader, vss[1:]...)
+	for i := 1; i < len(vss); i++ {
+		ensurePrevote(voteCh, height, round)
+	}
+	ensurePrecommit(voteCh, height, round)
+
+	addPrecommits(blockID, func(int) []byte { return []byte(fmt.Sprintf("extension-%d", height)) })
+	ensureNewBlock(newBlockCh, height)
+
+	commit := cs1.blockStore.LoadSeenCommit(height)
+	require.NotNil(t, commit)
+	assert.Equal(t, round, commit.Round)
+	assert.True(t, commit.IsExtended())
+	assert.Equal(t, []byte(fmt.Sprintf("extension-%d", height)), commit.VoteExtension)
+	assert.NoError(t, cs1.state.LastValidators.VerifyCommit(cs1.state.ChainID, commit.BlockID, commit.StateID,
+		height, commit))
+}
+
+// 4 vals, the 4th one stalls, so its sign shares are missing
+func TestStateQuorumSignProgress(t *testing.T) {
+	cs1, vss := randState(4)
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height, round := cs1.Height, cs1.Round
+
+	proTxHash4, err := vs4.GetProTxHash()
+	require.NoError(t, err)
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+
+	startTestRound(cs1, height, round)
+	ensurePrevote(voteCh, height, round)
+	rs := cs1.GetRoundState()
+	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+
+	signAddVotes(cs1, tmproto.PrevoteType, propBlockHash, propPartSetHeader, vs2, vs3)
+	ensurePrevote(voteCh, height, round)
+	ensurePrevote(voteCh, height, round)
+	ensurePrecommit(voteCh, height, round)
+
+	// the round is stalled with only 2 precommits
+	signAddVotes(cs1, tmproto.PrecommitType, propBlockHash, propPartSetHeader, vs2)
+	ensurePrecommit(voteCh, height, round)
+	bz, err := cs1.GetRoundStateSimpleJSON()
+	require.NoError(t, err)
+	var rsSimple cstypes.RoundStateSimple
+	require.NoError(t, tmjson.Unmarshal(bz, &rsSimple))
+	var rounds []struct {
+		PrecommitsQuorum types.QuorumSignProgress `json:"precommits_quorum"`
+	}
+	require.NoError(t, tmjson.Unmarshal(rsSimple.Votes, &rounds))
+	require.Greater(t, len(rounds), int(round))
+	progress := rounds[round].PrecommitsQuorum
+	assert.Equal(t, 2, progress.Shares)
+	assert.Len(t, progress.Missing, 2)
+	assert.Contains(t, progress.Missing, proTxHash4)
+	assert.False(t, progress.Recovered)
+
+	// the 3rd precommit completes the quorum without the 4th validator
+	signAddVotes(cs1, tmproto.PrecommitType, propBlockHash, propPartSetHeader, vs3)
+	ensurePrecommit(voteCh, height, round)
+	ensureNewBlock(newBlockCh, height)
+
+	progress = cs1.GetRoundState().LastPrecommits.QuorumSignProgress()
+	assert.Equal(t, 3, progress.Shares)
+	assert.Equal(t, []crypto.ProTxHash{proTxHash4}, progress.Missing)
+	assert.True(t, progress.Recovered)
+}
+
+// one validator signs with a stale key share, so the threshold signatures of
+// the precommits can't be recovered. The failures are counted and, after the
+// second one, we ask the peers for the precommits we're missing.
+func TestStateThresholdRecoveryFailure(t *testing.T) {
+	state, privVals := randGenesisState(7, false, 10)
+	staleKey := bls12381.GenPrivKey()
+	staleProTxHash, err := privVals[6].GetProTxHash()
+	require.NoError(t, err)
+	staleIdx, _ := state.Validators.GetByProTxHash(staleProTxHash)
+	require.EqualValues(t, 6, staleIdx)
+	state.Validators.Validators[staleIdx].PubKey = staleKey.PubKey()
+	privVals[6] = types.NewMockPVWithParams(staleKey, staleProTxHash, state.Validators.QuorumHash,
+		state.Validators.ThresholdPublicKey, false, false)
+
+	cs1 := newState(state, privVals[0], counter.NewApplication(true))
+	cs1.config.TimeoutPrecommit = time.Minute
+	failures := generic.NewCounter("threshold_recovery_failures")
+	cs1.metrics.ThresholdRecoveryFailures = failures
+	vss := make([]*validatorStub, len(privVals))
+	for i := range privVals {
+		vss[i] = newValidatorStub(privVals[i], int32(i))
+	}
+	incrementHeight(vss[1:]...)
+	height, round := cs1.Height, cs1.Round
+
+	failureCh := make(chan *types.VoteSet, 1)
+	err = cs1.evsw.AddListenerForEvent("test", types.EventThresholdRecoveryFailure,
+		func(data tmevents.EventData) {
+			failureCh <- data.(*types.VoteSet)
+		})
+	require.NoError(t, err)
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+
+	startTestRound(cs1, height, round)
+	ensurePrevote(voteCh, height, round)
+	rs := cs1.GetRoundState()
+	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+
+	signAddVotes(cs1, tmproto.PrevoteType, propBlockHash, propPartSetHeader, vss[1:5]...)
+	for i := 1; i < 5; i++ {
+		ensurePrevote(voteCh, height, round)
+	}
+	ensurePrecommit(voteCh, height, round)
+
+	// the precommits of the stale validator and 3 more have 2/3 of the power
+	signAddVotes(cs1, tmproto.PrecommitType, propBlockHash, propPartSetHeader, vss[6], vss[1], vss[2], vss[3])
+	for i := 0; i < 4; i++ {
+		ensurePrecommit(voteCh, height, round)
+	}
+	// the round state is locked until the vote is handled
+	assert.Contains(t, cs1.GetRoundState().LastThresholdRecoveryError, "threshold public key")
+	assert.EqualValues(t, 1, failures.Value())
+	select {
+	case <-failureCh:
+		t.Fatal("the precommits shouldn't be re-requested after the first failure")
+	default:
+	}
+
+	// the recovery fails again with the next precommit
+	signAddVotes(cs1, tmproto.PrecommitType, propBlockHash, propPartSetHeader, vss[4])
+	ensurePrecommit(voteCh, height, round)
+	var precommits *types.VoteSet
+	select {
+	case precommits = <-failureCh:
+	case <-time.After(ensureTimeout):
+		t.Fatal("expected the precommits to be re-requested")
+	}
+	assert.EqualValues(t, 2, failures.Value())
+	ensureNoNewEvent(newBlockCh, 100*time.Millisecond, "unexpected block with unrecovered threshold signatures")
+
+	// a peer, which thinks we have all the precommits, sends us the one of the
+	// 6th validator after the request
+	msg := makeMissingPrecommitsRequest(precommits)
+	require.NotNil(t, msg)
+	assert.Equal(t, tmproto.PrecommitType, msg.Type)
+	assert.Equal(t, propBlockHash, msg.BlockID.Hash)
+	peerVotes := types.NewVoteSet(config.ChainID(), height, round, tmproto.PrecommitType, rs.Validators)
+	for i := 0; i < len(vss); i++ {
+		vote := precommits.GetByIndex(int32(i))
+		if vote == nil {
+			vote = signVote(vss[i], tmproto.PrecommitType, propBlockHash, cs1.state.AppHash,
+				rs.Validators.QuorumType, rs.Validators.QuorumHash, propPartSetHeader)
+		}
+		_, err := peerVotes.AddVote(vote)
+		require.NoError(t, err)
+	}
+	ps := NewPeerState(p2pmock.NewPeer(nil))
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: height, Round: round, Step: cstypes.RoundStepPrecommit})
+	ps.EnsureVoteBitArrays(height, len(vss))
+	for i := 0; i < len(vss); i++ {
+		ps.SetHasVote(peerVotes.GetByIndex(int32(i)))
+	}
+	_, ok := ps.PickVoteToSend(peerVotes)
+	require.False(t, ok)
+
+	ps.ApplyVoteSetBitsMessage(msg, peerVotes.BitArrayByBlockID(msg.BlockID))
+	vote, ok := ps.PickVoteToSend(peerVotes)
+	require.True(t, ok)
+	proTxHash6, err := vss[5].GetProTxHash()
+	require.NoError(t, err)
+	assert.Equal(t, proTxHash6, vote.ValidatorProTxHash)
+}
+
 //------------------------------------------------------------------------------------------
 // LockSuite
 
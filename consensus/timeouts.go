@@ -0,0 +1,154 @@
+package consensus
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+)
+
+// stepDuration is an observed duration of a consensus step at a height.
+type stepDuration struct {
+	height   int64
+	duration time.Duration
+}
+
+// timeoutTracker chooses the timeouts of the consensus steps at each height.
+//
+// In the adaptive timeout mode, it tracks the durations of the propose, prevote
+// and precommit steps observed over the last AdaptiveTimeoutHeights heights,
+// and sets the timeouts to their AdaptiveTimeoutPercentile multiplied by
+// AdaptiveTimeoutFactor, clamped between AdaptiveTimeoutMin and
+// AdaptiveTimeoutMax. The commit timeout follows the precommit step, as both
+// wait for precommits. A step, which wasn't observed yet, keeps its configured
+// timeout.
+//
+// The timeouts only depend on the observed durations, but they are local to the
+// node, so they differ between the nodes.
+//
+// NOTE: Not thread safe. Should only be used by the cs.receiveRoutine.
+type timeoutTracker struct {
+	config    *cfg.ConsensusConfig
+	durations map[cstypes.RoundStepType][]stepDuration
+}
+
+func newTimeoutTracker(config *cfg.ConsensusConfig) *timeoutTracker {
+	return &timeoutTracker{
+		config:    config,
+		durations: make(map[cstypes.RoundStepType][]stepDuration),
+	}
+}
+
+// observe records the duration of the step at the height.
+func (tt *timeoutTracker) observe(step cstypes.RoundStepType, height int64, duration time.Duration) {
+	if tt.config.TimeoutMode != cfg.TimeoutModeAdaptive {
+		return
+	}
+	tt.durations[step] = append(tt.durations[step], stepDuration{height: height, duration: duration})
+}
+
+// timeouts returns the timeouts of the steps at the height, forgetting the
+// durations observed before the last AdaptiveTimeoutHeights heights.
+func (tt *timeoutTracker) timeouts(height int64) cstypes.Timeouts {
+	timeouts := cstypes.Timeouts{
+		Mode:      tt.config.TimeoutMode,
+		Propose:   tt.config.TimeoutPropose,
+		Prevote:   tt.config.TimeoutPrevote,
+		Precommit: tt.config.TimeoutPrecommit,
+		Commit:    tt.config.TimeoutCommit,
+	}
+	if tt.config.TimeoutMode != cfg.TimeoutModeAdaptive {
+		return timeouts
+	}
+
+	for step, durations := range tt.durations {
+		i := 0
+		for i < len(durations) && durations[i].height <= height-tt.config.AdaptiveTimeoutHeights-1 {
+			i++
+		}
+		tt.durations[step] = durations[i:]
+	}
+
+	if timeout, ok := tt.adaptiveTimeout(cstypes.RoundStepPropose); ok {
+		timeouts.Propose = timeout
+	}
+	if timeout, ok := tt.adaptiveTimeout(cstypes.RoundStepPrevote); ok {
+		timeouts.Prevote = timeout
+	}
+	if timeout, ok := tt.adaptiveTimeout(cstypes.RoundStepPrecommit); ok {
+		timeouts.Precommit = timeout
+		timeouts.Commit = timeout
+	}
+	return timeouts
+}
+
+// adaptiveTimeout returns the timeout of the step based on its observed
+// durations, false if it wasn't observed.
+func (tt *timeoutTracker) adaptiveTimeout(step cstypes.RoundStepType) (time.Duration, bool) {
+	durations := tt.durations[step]
+	if len(durations) == 0 {
+		return 0, false
+	}
+	sorted := make([]time.Duration, len(durations))
+	for i, d := range durations {
+		sorted[i] = d.duration
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// nearest-rank percentile
+	rank := int(math.Ceil(tt.config.AdaptiveTimeoutPercentile * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	timeout := time.Duration(float64(sorted[rank-1]) * tt.config.AdaptiveTimeoutFactor)
+
+	switch {
+	case timeout < tt.config.AdaptiveTimeoutMin:
+		timeout = tt.config.AdaptiveTimeoutMin
+	case timeout > tt.config.AdaptiveTimeoutMax:
+		timeout = tt.config.AdaptiveTimeoutMax
+	}
+	return timeout, true
+}
+
+// stepTimer measures the duration of a consensus step.
+type stepTimer struct {
+	height int64
+	round  int32
+	step   cstypes.RoundStepType
+	start  time.Time
+}
+
+// startStepTimer starts measuring the duration of the step of the round.
+func (cs *State) startStepTimer(height int64, round int32, step cstypes.RoundStepType) {
+	cs.stepTimer = stepTimer{height: height, round: round, step: step, start: time.Now()}
+}
+
+// stopStepTimer observes the duration of the step of the round, if it's being
+// measured. The steps replayed from the WAL are not observed.
+func (cs *State) stopStepTimer(height int64, round int32, step cstypes.RoundStepType) {
+	timer := cs.stepTimer
+	cs.stepTimer = stepTimer{}
+	if cs.replayMode || timer.height != height || timer.round != round || timer.step != step {
+		return
+	}
+	cs.timeoutTracker.observe(step, height, time.Since(timer.start))
+}
+
+// proposeTimeout returns how long we wait for a proposal in the round.
+func (cs *State) proposeTimeout(round int32) time.Duration {
+	return cs.Timeouts.Propose + cs.config.TimeoutProposeDelta*time.Duration(round)
+}
+
+// prevoteTimeout returns how long we wait for straggler prevotes in the round.
+func (cs *State) prevoteTimeout(round int32) time.Duration {
+	return cs.Timeouts.Prevote + cs.config.TimeoutPrevoteDelta*time.Duration(round)
+}
+
+// precommitTimeout returns how long we wait for straggler precommits in the
+// round.
+func (cs *State) precommitTimeout(round int32) time.Duration {
+	return cs.Timeouts.Precommit + cs.config.TimeoutPrecommitDelta*time.Duration(round)
+}
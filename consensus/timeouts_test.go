@@ -0,0 +1,117 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestTimeoutTracker(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.TimeoutMode = cfg.TimeoutModeAdaptive
+	config.AdaptiveTimeoutHeights = 10
+	config.AdaptiveTimeoutPercentile = 0.9
+	config.AdaptiveTimeoutFactor = 2
+	config.AdaptiveTimeoutMin = 50 * time.Millisecond
+	config.AdaptiveTimeoutMax = 5 * time.Second
+	tt := newTimeoutTracker(config)
+
+	// the configured timeouts are used until the steps are observed
+	assert.Equal(t, cstypes.Timeouts{
+		Mode:      cfg.TimeoutModeAdaptive,
+		Propose:   config.TimeoutPropose,
+		Prevote:   config.TimeoutPrevote,
+		Precommit: config.TimeoutPrecommit,
+		Commit:    config.TimeoutCommit,
+	}, tt.timeouts(1))
+
+	for h := int64(1); h <= 10; h++ {
+		tt.observe(cstypes.RoundStepPropose, h, time.Duration(h)*100*time.Millisecond)
+		tt.observe(cstypes.RoundStepPrevote, h, time.Millisecond)
+	}
+	timeouts := tt.timeouts(11)
+	// the 90th percentile of 100ms..1s is 900ms
+	assert.Equal(t, 1800*time.Millisecond, timeouts.Propose)
+	// clamped to the min
+	assert.Equal(t, config.AdaptiveTimeoutMin, timeouts.Prevote)
+	assert.Equal(t, config.TimeoutPrecommit, timeouts.Precommit)
+	assert.Equal(t, config.TimeoutCommit, timeouts.Commit)
+
+	// the precommit step sets the commit timeout too, clamped to the max
+	tt.observe(cstypes.RoundStepPrecommit, 11, time.Minute)
+	timeouts = tt.timeouts(12)
+	assert.Equal(t, config.AdaptiveTimeoutMax, timeouts.Precommit)
+	assert.Equal(t, config.AdaptiveTimeoutMax, timeouts.Commit)
+
+	// only the last heights are taken into account: 1s at height 10 and 10ms
+	// at height 20
+	tt.observe(cstypes.RoundStepPropose, 20, 10*time.Millisecond)
+	assert.Equal(t, 2*time.Second, tt.timeouts(20).Propose)
+	assert.Equal(t, 50*time.Millisecond, tt.timeouts(21).Propose)
+}
+
+func TestTimeoutTrackerFixed(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	tt := newTimeoutTracker(config)
+	tt.observe(cstypes.RoundStepPropose, 1, time.Millisecond)
+	assert.Equal(t, config.TimeoutPropose, tt.timeouts(2).Propose)
+	assert.Empty(t, tt.durations)
+}
+
+// TestAdaptiveTimeoutsFasterBlocks runs a network of validators, connected in
+// memory, with a long timeout_commit. The adaptive timeouts shorten it to the
+// time the precommits take on the fast network, so the blocks are faster.
+func TestAdaptiveTimeoutsFasterBlocks(t *testing.T) {
+	const (
+		nValidators   = 4
+		blocks        = 6
+		timeoutCommit = 500 * time.Millisecond
+	)
+	blockTime := func(timeoutMode string) time.Duration {
+		css, cleanup := randConsensusNet(nValidators, "consensus_adaptive_timeouts_test", NewTimeoutTicker,
+			newCounter, func(c *cfg.Config) {
+				c.Consensus.TimeoutCommit = timeoutCommit
+				c.Consensus.SkipTimeoutCommit = false
+				c.Consensus.TimeoutMode = timeoutMode
+				c.Consensus.AdaptiveTimeoutHeights = 3
+				c.Consensus.AdaptiveTimeoutMin = time.Millisecond
+				c.Consensus.AdaptiveTimeoutMax = timeoutCommit
+			})
+		defer cleanup()
+		reactors, blocksSubs, eventBuses := startConsensusNet(t, css, nValidators)
+		defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+		// the first blocks wait for the configured timeouts, and the validators
+		// starting at different times slow them down
+		for i := 0; i < 3; i++ {
+			<-blocksSubs[0].Out()
+		}
+		start := time.Now()
+		for i := 0; i < blocks; i++ {
+			select {
+			case <-blocksSubs[0].Out():
+			case <-time.After(blocks * 2 * timeoutCommit):
+				t.Fatalf("timed out waiting for block %d", i)
+			}
+		}
+		elapsed := time.Since(start)
+
+		if timeoutMode == cfg.TimeoutModeAdaptive {
+			timeouts := css[0].GetRoundState().Timeouts
+			assert.Equal(t, cfg.TimeoutModeAdaptive, timeouts.Mode)
+			assert.Less(t, int64(timeouts.Commit), int64(timeoutCommit))
+		}
+		return elapsed / blocks
+	}
+
+	fixed := blockTime(cfg.TimeoutModeFixed)
+	adaptive := blockTime(cfg.TimeoutModeAdaptive)
+	t.Logf("block time: fixed %v, adaptive %v", fixed, adaptive)
+	assert.GreaterOrEqual(t, int64(fixed), int64(timeoutCommit))
+	assert.Less(t, int64(adaptive), int64(fixed/2))
+}
@@ -0,0 +1,105 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tendermint/tendermint/libs/tracing"
+	"github.com/tendermint/tendermint/types"
+)
+
+// heightTrace holds the spans of the height being decided. The spans of the
+// steps are the children of the span of the height, and the spans of the
+// operations are the children of the span of the current step.
+type heightTrace struct {
+	ctx  context.Context
+	span trace.Span
+
+	stepCtx  context.Context
+	stepSpan trace.Span
+
+	// from the first received block part to the complete proposal block
+	blockPartsSpan trace.Span
+}
+
+// StateTracer sets the tracer of the block lifecycle.
+func StateTracer(tracer trace.Tracer) StateOption {
+	return func(cs *State) {
+		// the current height was traced with the no-op tracer so far
+		cs.tracer = tracer
+		cs.trace = heightTrace{}
+	}
+}
+
+// traceContext returns the context of the span of the current step, or of
+// the current height if there's no step yet, starting the trace of the height
+// if needed.
+func (cs *State) traceContext() context.Context {
+	if cs.trace.span == nil {
+		cs.trace.ctx, cs.trace.span = cs.tracer.Start(context.Background(), "height",
+			trace.WithAttributes(tracing.Height(cs.Height)))
+	}
+	if cs.trace.stepSpan != nil {
+		return cs.trace.stepCtx
+	}
+	return cs.trace.ctx
+}
+
+// traceStep ends the span of the previous step, and starts the one of the
+// current step. The span of a vote step lasts until the votes are collected.
+func (cs *State) traceStep() {
+	if cs.trace.stepSpan != nil {
+		cs.trace.stepSpan.End()
+		cs.trace.stepSpan = nil
+	}
+	cs.trace.stepCtx, cs.trace.stepSpan = cs.tracer.Start(cs.traceContext(), cs.Step.String(),
+		trace.WithAttributes(tracing.Height(cs.Height), tracing.Round(cs.Round)))
+}
+
+// traceBlockPart starts the span of the gossip of the proposal block parts
+// with the first received part, and ends it once the block is complete.
+func (cs *State) traceBlockPart(round int32, complete bool) {
+	if cs.trace.blockPartsSpan == nil {
+		_, cs.trace.blockPartsSpan = cs.tracer.Start(cs.traceContext(), "block_parts",
+			trace.WithAttributes(tracing.Height(cs.Height), tracing.Round(round)))
+	}
+	if complete {
+		cs.trace.blockPartsSpan.End()
+		cs.trace.blockPartsSpan = nil
+	}
+}
+
+// traceThresholdRecovery records the span of the last attempt to recover the
+// threshold signatures of the precommits.
+func (cs *State) traceThresholdRecovery(precommits *types.VoteSet, recovery *types.ThresholdRecovery) {
+	var err error
+	if !recovery.Recovered {
+		err = errors.New(recovery.Error)
+	}
+	tracing.RecordSpan(cs.traceContext(), cs.tracer, "threshold_recovery",
+		recovery.AttemptTime, recovery.AttemptDuration, err,
+		tracing.Height(precommits.GetHeight()),
+		tracing.Round(precommits.GetRound()),
+		attribute.Int("attempts", recovery.Attempts),
+	)
+}
+
+// endHeightTrace ends the trace of the current height, once its block is
+// committed in the round.
+func (cs *State) endHeightTrace(commitRound int32) {
+	if cs.trace.span == nil {
+		return
+	}
+	if cs.trace.blockPartsSpan != nil {
+		cs.trace.blockPartsSpan.End()
+	}
+	if cs.trace.stepSpan != nil {
+		cs.trace.stepSpan.End()
+	}
+	cs.trace.span.SetAttributes(tracing.Round(commitRound))
+	cs.trace.span.End()
+	cs.trace = heightTrace{}
+}
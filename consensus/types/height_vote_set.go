@@ -261,6 +261,7 @@ func (hvs *HeightVoteSet) toAllRoundVotes() []roundVotes {
 			PrevotesBitArray:   hvs.roundVoteSets[round].Prevotes.BitArrayString(),
 			Precommits:         hvs.roundVoteSets[round].Precommits.VoteStrings(),
 			PrecommitsBitArray: hvs.roundVoteSets[round].Precommits.BitArrayString(),
+			PrecommitsQuorum:   hvs.roundVoteSets[round].Precommits.QuorumSignProgress(),
 		}
 	}
 	// TODO: all other peer catchup rounds
@@ -268,9 +269,10 @@ func (hvs *HeightVoteSet) toAllRoundVotes() []roundVotes {
 }
 
 type roundVotes struct {
-	Round              int32    `json:"round"`
-	Prevotes           []string `json:"prevotes"`
-	PrevotesBitArray   string   `json:"prevotes_bit_array"`
-	Precommits         []string `json:"precommits"`
-	PrecommitsBitArray string   `json:"precommits_bit_array"`
+	Round              int32                    `json:"round"`
+	Prevotes           []string                 `json:"prevotes"`
+	PrevotesBitArray   string                   `json:"prevotes_bit_array"`
+	Precommits         []string                 `json:"precommits"`
+	PrecommitsBitArray string                   `json:"precommits_bit_array"`
+	PrecommitsQuorum   types.QuorumSignProgress `json:"precommits_quorum"`
 }
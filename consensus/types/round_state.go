@@ -93,6 +93,28 @@ type RoundState struct {
 	LastCommit                *types.Commit       `json:"last_commit"`
 	LastValidators            *types.ValidatorSet `json:"last_validators"`
 	TriggeredTimeoutPrecommit bool                `json:"triggered_timeout_precommit"`
+
+	// Reason the proposal block of the current round was rejected, for its time or
+	// by the application (ProcessProposal)
+	ProposalRejectReason string `json:"proposal_reject_reason"`
+
+	// Error of the last failed recovery of the threshold signatures of the
+	// precommits
+	LastThresholdRecoveryError string `json:"last_threshold_recovery_error"`
+
+	// Timeouts of the steps at this height
+	Timeouts Timeouts `json:"timeouts"`
+}
+
+// Timeouts are the timeouts of the consensus steps at a height, the deltas of
+// the rounds are added to them. They are the configured timeouts, or adapted to
+// the durations of the steps observed over the last heights.
+type Timeouts struct {
+	Mode      string        `json:"mode"`
+	Propose   time.Duration `json:"propose"`
+	Prevote   time.Duration `json:"prevote"`
+	Precommit time.Duration `json:"precommit"`
+	Commit    time.Duration `json:"commit"`
 }
 
 // RoundStateSimple is a compressed version of the RoundState for use in RPC
@@ -104,6 +126,10 @@ type RoundStateSimple struct {
 	ValidBlockHash    bytes.HexBytes      `json:"valid_block_hash"`
 	Votes             json.RawMessage     `json:"height_vote_set"`
 	Proposer          types.ValidatorInfo `json:"proposer"`
+	Timeouts          Timeouts            `json:"timeouts"`
+
+	ProposalRejectReason       string `json:"proposal_reject_reason,omitempty"`
+	LastThresholdRecoveryError string `json:"last_threshold_recovery_error,omitempty"`
 }
 
 // RoundStateSimple compresses the RoundState to RoundStateSimple
@@ -127,6 +153,9 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 			ProTxHash: proTxHash,
 			Index:     idx,
 		},
+		Timeouts:                   rs.Timeouts,
+		ProposalRejectReason:       rs.ProposalRejectReason,
+		LastThresholdRecoveryError: rs.LastThresholdRecoveryError,
 	}
 }
 
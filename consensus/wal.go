@@ -1,11 +1,14 @@
 package consensus
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
@@ -26,6 +30,11 @@ const (
 
 	// how often the WAL should be sync'd during period sync'ing
 	walDefaultFlushInterval = 2 * time.Second
+
+	// walVersion is the version of the WAL records written by this node.
+	// Version 2 adds SignedVoteMessage. The records, which were written before
+	// the WAL was versioned, have version 0 and are decoded as version 1.
+	walVersion = 2
 )
 
 //--------------------------------------------------------
@@ -43,12 +52,20 @@ type EndHeightMessage struct {
 	Height int64 `json:"height"`
 }
 
+// SignedVoteMessage is the vote signed by this node. It's written to the WAL
+// before the vote is broadcast, so the replay re-uses the exact vote, including
+// the vote extension, instead of signing it again.
+type SignedVoteMessage struct {
+	Vote *types.Vote `json:"vote"`
+}
+
 type WALMessage interface{}
 
 func init() {
 	tmjson.RegisterType(msgInfo{}, "tendermint/wal/MsgInfo")
 	tmjson.RegisterType(timeoutInfo{}, "tendermint/wal/TimeoutInfo")
 	tmjson.RegisterType(EndHeightMessage{}, "tendermint/wal/EndHeightMessage")
+	tmjson.RegisterType(SignedVoteMessage{}, "tendermint/wal/SignedVoteMessage")
 }
 
 //--------------------------------------------------------
@@ -303,8 +320,9 @@ func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
 		return err
 	}
 	pv := tmcons.TimedWALMessage{
-		Time: v.Time,
-		Msg:  pbMsg,
+		Time:    v.Time,
+		Msg:     pbMsg,
+		Version: walVersion,
 	}
 
 	data, err := proto.Marshal(&pv)
@@ -365,7 +383,8 @@ func NewWALDecoder(rd io.Reader) *WALDecoder {
 func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	b := make([]byte, 4)
 
-	_, err := dec.rd.Read(b)
+	// a record, which is cut short (e.g. by a torn write), is corrupted
+	_, err := io.ReadFull(dec.rd, b)
 	if errors.Is(err, io.EOF) {
 		return nil, err
 	}
@@ -375,7 +394,7 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	crc := binary.BigEndian.Uint32(b)
 
 	b = make([]byte, 4)
-	_, err = dec.rd.Read(b)
+	_, err = io.ReadFull(dec.rd, b)
 	if err != nil {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %v", err)}
 	}
@@ -389,7 +408,7 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	}
 
 	data := make([]byte, length)
-	n, err := dec.rd.Read(data)
+	n, err := io.ReadFull(dec.rd, data)
 	if err != nil {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read data: %v (read: %d, wanted: %d)", err, n, length)}
 	}
@@ -406,6 +425,13 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("failed to decode data: %v", err)}
 	}
 
+	// the records of a newer version may contain messages, which can't be
+	// replayed correctly
+	if res.Version > walVersion {
+		return nil, fmt.Errorf("unsupported WAL record version %d, the latest supported version is %d",
+			res.Version, walVersion)
+	}
+
 	walMsg, err := WALFromProto(res.Msg)
 	if err != nil {
 		return nil, DataCorruptionError{fmt.Errorf("failed to convert from proto: %w", err)}
@@ -418,6 +444,155 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	return tMsgWal, err
 }
 
+// RepairWALTail truncates the WAL file at its last valid record, if the file
+// is corrupted strictly at its tail, e.g. by a torn write on a power loss. The
+// tail is corrupted, if the first corrupted record extends to the end of the
+// file or is only followed by zeros. It returns the number of dropped bytes, 0
+// if the file isn't corrupted.
+//
+// If the corrupted record is followed by other data, which may contain valid
+// records, the file isn't modified and a DataCorruptionError is returned.
+func RepairWALTail(walFile string) (int64, error) {
+	data, err := ioutil.ReadFile(walFile)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		rd  = bytes.NewReader(data)
+		dec = NewWALDecoder(rd)
+	)
+	for {
+		offset := rd.Size() - int64(rd.Len())
+		_, err := dec.Decode()
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err == nil {
+			continue
+		}
+		if !IsDataCorruptionError(err) {
+			return 0, err
+		}
+
+		if rest := data[rd.Size()-int64(rd.Len()):]; !isZeros(rest) {
+			return 0, DataCorruptionError{fmt.Errorf(
+				"corrupted record at offset %d is followed by %d bytes: %v", offset, len(rest), err)}
+		}
+		if err := os.Truncate(walFile, offset); err != nil {
+			return 0, err
+		}
+		return rd.Size() - offset, nil
+	}
+}
+
+func isZeros(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TruncateWAL drops the records of the WAL following the #ENDHEIGHT of the
+// given height, e.g. after a rollback of the node to the height, so that
+// consensus replays the WAL from there. If the WAL doesn't contain the
+// #ENDHEIGHT, e.g. since the node fast synced the height or the file holding
+// it was removed, all the records are dropped and the #ENDHEIGHT is written
+// instead. The WAL must not be in use.
+func TruncateWAL(walFile string, height int64) error {
+	if err := tmos.EnsureDir(filepath.Dir(walFile), 0700); err != nil {
+		return err
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return err
+	}
+	info := group.ReadGroupInfo()
+	group.Close()
+
+	// the rotated files, oldest first, followed by the head
+	paths := make([]string, 0, info.MaxIndex-info.MinIndex+1)
+	for index := info.MinIndex; index < info.MaxIndex; index++ {
+		paths = append(paths, fmt.Sprintf("%v.%03d", walFile, index))
+	}
+	paths = append(paths, walFile)
+
+	for i, path := range paths {
+		offset, found, err := findEndHeight(path, height)
+		if err != nil {
+			return err
+		}
+		if found {
+			if err := os.Truncate(path, offset); err != nil {
+				return err
+			}
+			return removeWALFiles(walFile, paths[i+1:])
+		}
+	}
+
+	if err := removeWALFiles(walFile, paths); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(walFile, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if err := NewWALEncoder(f).Encode(&TimedWALMessage{tmtime.Now(), EndHeightMessage{height}}); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// findEndHeight returns the offset following the #ENDHEIGHT of the height in
+// the WAL file. The records following a corrupted record are not searched.
+func findEndHeight(path string, height int64) (int64, bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var (
+		rd  = bytes.NewReader(data)
+		dec = NewWALDecoder(rd)
+	)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF || IsDataCorruptionError(err) {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		if m, ok := msg.Msg.(EndHeightMessage); ok && m.Height == height {
+			return rd.Size() - int64(rd.Len()), true, nil
+		}
+	}
+}
+
+// removeWALFiles removes the given rotated files of the WAL and empties the
+// head, if it's given.
+func removeWALFiles(walFile string, paths []string) error {
+	for _, path := range paths {
+		if path == walFile {
+			if err := os.Truncate(path, 0); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 type nilWAL struct{}
 
 var _ WAL = nilWAL{}
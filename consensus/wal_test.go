@@ -3,6 +3,9 @@ package consensus
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -11,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +22,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/autofile"
 	"github.com/tendermint/tendermint/libs/log"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
 	tmtypes "github.com/tendermint/tendermint/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
@@ -108,6 +113,108 @@ func TestWALEncoderDecoder(t *testing.T) {
 	}
 }
 
+func TestWALDecoderVersion(t *testing.T) {
+	now := tmtime.Now()
+	pbMsg, err := WALToProto(EndHeightMessage{1})
+	require.NoError(t, err)
+	// the records written before the WAL was versioned
+	oldRecord := walRecord(t, &tmcons.TimedWALMessage{Time: now, Msg: pbMsg})
+	newerRecord := walRecord(t, &tmcons.TimedWALMessage{Time: now, Msg: pbMsg, Version: walVersion + 1})
+
+	dec := NewWALDecoder(bytes.NewReader(oldRecord))
+	decoded, err := dec.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, EndHeightMessage{1}, decoded.Msg)
+
+	dec = NewWALDecoder(bytes.NewReader(newerRecord))
+	_, err = dec.Decode()
+	require.Error(t, err)
+	assert.False(t, IsDataCorruptionError(err))
+}
+
+// walRecord encodes the message, like WALEncoder does, but as is.
+func walRecord(t *testing.T, msg *tmcons.TimedWALMessage) []byte {
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	record := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint32(record[0:4], crc32.Checksum(data, crc32c))
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(data)))
+	copy(record[8:], data)
+	return record
+}
+
+func TestRepairWALTail(t *testing.T) {
+	now := tmtime.Now()
+	msgs := []TimedWALMessage{
+		{Time: now, Msg: EndHeightMessage{0}},
+		{Time: now, Msg: timeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: types.RoundStepPropose}},
+		{Time: now, Msg: tmtypes.EventDataRoundState{Height: 1, Round: 1, Step: ""}},
+	}
+	b := new(bytes.Buffer)
+	enc := NewWALEncoder(b)
+	ends := make([]int, len(msgs))
+	for i := range msgs {
+		require.NoError(t, enc.Encode(&msgs[i]))
+		ends[i] = b.Len()
+	}
+	wal := b.Bytes()
+	last := ends[len(ends)-2] // offset of the last record
+
+	corrupt := func(data []byte, offset int) []byte {
+		data = append([]byte{}, data...)
+		data[offset] ^= 0xFF
+		return data
+	}
+
+	testCases := []struct {
+		name      string
+		data      []byte
+		dropped   int64
+		records   int
+		corrupted bool
+	}{
+		{"intact", wal, 0, 3, false},
+		{"torn at record boundary", wal[:last], 0, 2, false},
+		{"torn checksum", wal[:last+3], 3, 2, false},
+		{"torn length", wal[:last+6], 6, 2, false},
+		{"torn data", wal[:last+13], 13, 2, false},
+		{"torn before the last byte", wal[:len(wal)-1], int64(len(wal) - 1 - last), 2, false},
+		{"zeroed tail", append(append([]byte{}, wal...), make([]byte, 100)...), 100, 3, false},
+		{"corrupted last record", corrupt(wal, len(wal)-1), int64(len(wal) - last), 2, false},
+		{"corrupted record followed by records", corrupt(wal, ends[0]+10), 0, 0, true},
+		{"corrupted length followed by data", corrupt(wal, ends[0]+5), 0, 0, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			walFile := filepath.Join(t.TempDir(), "wal")
+			require.NoError(t, ioutil.WriteFile(walFile, tc.data, 0600))
+
+			dropped, err := RepairWALTail(walFile)
+			data, readErr := ioutil.ReadFile(walFile)
+			require.NoError(t, readErr)
+			if tc.corrupted {
+				assert.True(t, IsDataCorruptionError(err), "expected data corruption error, got %v", err)
+				assert.Equal(t, tc.data, data, "the WAL must not be modified")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.dropped, dropped)
+			assert.Len(t, data, len(tc.data)-int(tc.dropped))
+
+			// the WAL decodes to the end after the repair
+			dec := NewWALDecoder(bytes.NewReader(data))
+			for i := 0; i < tc.records; i++ {
+				decoded, err := dec.Decode()
+				require.NoError(t, err)
+				assert.Equal(t, msgs[i].Msg, decoded.Msg)
+			}
+			_, err = dec.Decode()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
 func TestWALWrite(t *testing.T) {
 	walDir, err := ioutil.TempDir("", "wal")
 	require.NoError(t, err)
@@ -284,3 +391,82 @@ func BenchmarkWalDecode100MB(b *testing.B) {
 func BenchmarkWalDecode1GB(b *testing.B) {
 	benchmarkWalDecode(b, 1024*1024*1024)
 }
+
+func TestTruncateWAL(t *testing.T) {
+	// writes the round state and the #ENDHEIGHT of each height, rotating the
+	// files after each height in rotateAfter
+	writeWAL := func(t *testing.T, walFile string, heights int64, rotateAfter ...int64) {
+		group, err := autofile.OpenGroup(walFile)
+		require.NoError(t, err)
+		enc := NewWALEncoder(group)
+		for h := int64(1); h <= heights; h++ {
+			require.NoError(t, enc.Encode(&TimedWALMessage{tmtime.Now(), tmtypes.EventDataRoundState{Height: h}}))
+			require.NoError(t, enc.Encode(&TimedWALMessage{tmtime.Now(), EndHeightMessage{h}}))
+			require.NoError(t, group.FlushAndSync())
+			for _, r := range rotateAfter {
+				if r == h {
+					group.RotateFile()
+				}
+			}
+		}
+		group.Close()
+	}
+	// returns the messages following the #ENDHEIGHT of the height
+	searchWAL := func(t *testing.T, walFile string, height int64) ([]WALMessage, bool) {
+		wal, err := NewWAL(walFile)
+		require.NoError(t, err)
+		defer wal.Group().Close()
+		gr, found, err := wal.SearchForEndHeight(height, &WALSearchOptions{})
+		require.NoError(t, err)
+		if !found {
+			return nil, false
+		}
+		defer gr.Close()
+		var msgs []WALMessage
+		dec := NewWALDecoder(gr)
+		for {
+			msg, err := dec.Decode()
+			if err == io.EOF {
+				return msgs, true
+			}
+			require.NoError(t, err)
+			msgs = append(msgs, msg.Msg)
+		}
+	}
+
+	testCases := map[string]struct {
+		heights     int64
+		rotateAfter []int64
+		height      int64
+		files       int
+	}{
+		"head":             {10, nil, 5, 0},
+		"rotated file":     {10, []int64{3, 6, 8}, 5, 2},
+		"last of rotated":  {10, []int64{3, 6, 8}, 6, 2},
+		"not found":        {10, []int64{3, 6, 8}, 0, 0},
+		"above the latest": {10, []int64{3, 6, 8}, 20, 0},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			walDir, err := ioutil.TempDir("", "wal")
+			require.NoError(t, err)
+			defer os.RemoveAll(walDir)
+			walFile := filepath.Join(walDir, "wal")
+			writeWAL(t, walFile, tc.heights, tc.rotateAfter...)
+
+			require.NoError(t, TruncateWAL(walFile, tc.height))
+
+			msgs, found := searchWAL(t, walFile, tc.height)
+			require.True(t, found)
+			assert.Empty(t, msgs)
+			if tc.height < tc.heights {
+				_, found = searchWAL(t, walFile, tc.height+1)
+				assert.False(t, found)
+			}
+			rotated, err := filepath.Glob(walFile + ".*")
+			require.NoError(t, err)
+			assert.Len(t, rotated, tc.files)
+		})
+	}
+}
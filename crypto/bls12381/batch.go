@@ -0,0 +1,102 @@
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+
+	bls "github.com/dashpay/bls-signatures/go-bindings"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = (*BatchVerifier)(nil)
+
+// BatchVerifier implements crypto.BatchVerifier.
+//
+// The signatures are verified with a single multi-pairing check of their
+// aggregate. Each signature and its public key are multiplied with a random
+// scalar first, so invalid signatures can't cancel each other out in the
+// aggregate. If the batch is invalid, the signatures are verified one by one to
+// find the invalid ones.
+type BatchVerifier struct {
+	pubKeys    []PubKey
+	digests    [][]byte
+	signatures [][]byte
+}
+
+// NewBatchVerifier returns an empty BatchVerifier.
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add appends the signature of the digest into the batch.
+func (b *BatchVerifier) Add(key crypto.PubKey, digest, signature []byte) error {
+	pubKey, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not %s: %T", KeyType, key)
+	}
+	if len(pubKey) != PubKeySize {
+		return errors.New("invalid public key size")
+	}
+	if len(signature) != SignatureSize {
+		return errors.New("invalid signature size")
+	}
+	b.pubKeys = append(b.pubKeys, pubKey)
+	b.digests = append(b.digests, digest)
+	b.signatures = append(b.signatures, signature)
+	return nil
+}
+
+// Verify verifies all the signatures in the batch. An empty batch is invalid.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.signatures))
+	if len(b.signatures) == 0 {
+		return false, valid
+	}
+	if b.verifyAggregate() {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+	// find the invalid signatures
+	for i, pubKey := range b.pubKeys {
+		valid[i] = pubKey.VerifySignatureDigest(b.digests[i], b.signatures[i])
+	}
+	return false, valid
+}
+
+// verifyAggregate checks e(g1, sum(r_i*sig_i)) == prod(e(r_i*pk_i, H(digest_i))).
+func (b *BatchVerifier) verifyAggregate() bool {
+	if len(b.signatures) == 1 {
+		return b.pubKeys[0].VerifySignatureDigest(b.digests[0], b.signatures[0])
+	}
+	publicKeys := make([]*bls.PublicKey, len(b.pubKeys))
+	signatures := make([]*bls.InsecureSignature, len(b.signatures))
+	for i := range b.signatures {
+		publicKey, err := bls.PublicKeyFromBytes(b.pubKeys[i])
+		if err != nil {
+			return false
+		}
+		signature, err := bls.InsecureSignatureFromBytes(b.signatures[i])
+		if err != nil {
+			return false
+		}
+		// the polynomial x + x*id multiplies x with the random scalar 1+id
+		var id bls.Hash
+		copy(id[:], crypto.CRandBytes(len(id)))
+		publicKeys[i], err = bls.PublicKeyShare([]*bls.PublicKey{publicKey, publicKey}, id)
+		if err != nil {
+			return false
+		}
+		signatures[i], err = bls.InsecureSignatureShare([]*bls.InsecureSignature{signature, signature}, id)
+		if err != nil {
+			return false
+		}
+	}
+	aggregate, err := bls.InsecureSignatureAggregate(signatures)
+	if err != nil {
+		return false
+	}
+	return aggregate.Verify(b.digests, publicKeys)
+}
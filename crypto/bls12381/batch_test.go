@@ -0,0 +1,109 @@
+package bls12381_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+type signedDigest struct {
+	pubKey    crypto.PubKey
+	digest    []byte
+	signature []byte
+}
+
+func newSignedDigests(t testing.TB, n int) []signedDigest {
+	sigs := make([]signedDigest, n)
+	for i := range sigs {
+		privKey := bls12381.GenPrivKey()
+		digest := crypto.CRandBytes(crypto.DefaultHashSize)
+		signature, err := privKey.SignDigest(digest)
+		require.NoError(t, err)
+		sigs[i] = signedDigest{pubKey: privKey.PubKey(), digest: digest, signature: signature}
+	}
+	return sigs
+}
+
+func newBatchVerifier(t testing.TB, sigs []signedDigest) *bls12381.BatchVerifier {
+	bv := bls12381.NewBatchVerifier()
+	for _, sig := range sigs {
+		require.NoError(t, bv.Add(sig.pubKey, sig.digest, sig.signature))
+	}
+	return bv
+}
+
+func TestBatchVerifier(t *testing.T) {
+	sigs := newSignedDigests(t, 10)
+
+	ok, valid := newBatchVerifier(t, sigs).Verify()
+	assert.True(t, ok)
+	assert.Equal(t, []bool{true, true, true, true, true, true, true, true, true, true}, valid)
+
+	// one corrupted signature is identified
+	sigs[3].signature, _ = bls12381.GenPrivKey().SignDigest(sigs[3].digest)
+	ok, valid = newBatchVerifier(t, sigs).Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []bool{true, true, true, false, true, true, true, true, true, true}, valid)
+}
+
+func TestBatchVerifierSingle(t *testing.T) {
+	sigs := newSignedDigests(t, 1)
+	ok, valid := newBatchVerifier(t, sigs).Verify()
+	assert.True(t, ok)
+	assert.Equal(t, []bool{true}, valid)
+
+	sigs[0].digest = crypto.CRandBytes(crypto.DefaultHashSize)
+	ok, valid = newBatchVerifier(t, sigs).Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []bool{false}, valid)
+}
+
+func TestBatchVerifierSwappedSignatures(t *testing.T) {
+	// the aggregate of the swapped signatures is valid, the batch isn't
+	sigs := newSignedDigests(t, 2)
+	sigs[0].signature, sigs[1].signature = sigs[1].signature, sigs[0].signature
+	ok, valid := newBatchVerifier(t, sigs).Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []bool{false, false}, valid)
+}
+
+func TestBatchVerifierEmpty(t *testing.T) {
+	ok, valid := bls12381.NewBatchVerifier().Verify()
+	assert.False(t, ok)
+	assert.Empty(t, valid)
+}
+
+func TestBatchVerifierAdd(t *testing.T) {
+	bv := bls12381.NewBatchVerifier()
+	digest := crypto.CRandBytes(crypto.DefaultHashSize)
+	assert.Error(t, bv.Add(ed25519.GenPrivKey().PubKey(), digest, make([]byte, bls12381.SignatureSize)))
+	assert.Error(t, bv.Add(bls12381.GenPrivKey().PubKey(), digest, make([]byte, bls12381.SignatureSize-1)))
+}
+
+func BenchmarkBatchVerification(b *testing.B) {
+	for _, n := range []int{67, 100} {
+		sigs := newSignedDigests(b, n)
+		b.Run(fmt.Sprintf("single/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, sig := range sigs {
+					if !sig.pubKey.VerifySignatureDigest(sig.digest, sig.signature) {
+						b.Fatal("invalid signature")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if ok, _ := newBatchVerifier(b, sigs).Verify(); !ok {
+					b.Fatal("invalid batch")
+				}
+			}
+		})
+	}
+}
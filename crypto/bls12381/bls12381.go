@@ -3,6 +3,8 @@ package bls12381
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/hex"
 	"errors"
@@ -152,13 +154,24 @@ func genPrivKey(rand io.Reader) PrivKey {
 	return PrivKey(privateKey.Serialize())
 }
 
-// GenPrivKeyFromSecret hashes the secret with SHA2, and uses
-// that 32 byte output to create the private key.
-// NOTE: secret should be the output of a KDF like bcrypt,
-// if it's derived from user input.
+// genPrivKeyFromSecretDomain separates the keys derived by GenPrivKeyFromSecret
+// from any other use of the same secret.
+const genPrivKeyFromSecretDomain = "tenderdash/bls12381/GenPrivKeyFromSecret/v1"
+
+// GenPrivKeyFromSecret derives the private key deterministically from the
+// secret with HMAC-SHA256, keyed with a domain separation tag.
+//
+// It's meant for reproducible test fixtures only, e.g. the keys of e2e
+// testnets. The key is as strong as the secret, so it must never be used to
+// generate the key of a real validator, use GenPrivKey instead.
 func GenPrivKeyFromSecret(secret []byte) PrivKey {
-	seed := crypto.Sha256(secret) // Not Ripemd160 because we want 32 bytes.
-	privKey, err := bls.PrivateKeyFromSeed(seed)
+	mac := hmac.New(sha256.New, []byte(genPrivKeyFromSecretDomain))
+	mac.Write(secret)
+	seed := mac.Sum(nil)
+	// clearing the top bits keeps the key below the group order, so the key
+	// doesn't depend on the modular reduction of the library
+	seed[0] &= 0x3f
+	privKey, err := bls.PrivateKeyFromBytes(seed, false)
 	if err != nil {
 		panic(err)
 	}
@@ -411,6 +424,18 @@ func (pubKey PubKey) AggregateSignatures(sigSharesData [][]byte, messages [][]by
 	return aggregatedSignature.Serialize(), error
 }
 
+// ValidateBasic checks that the public key has the right size and is a point
+// of the G1 group.
+func (pubKey PubKey) ValidateBasic() error {
+	if len(pubKey) != PubKeySize {
+		return fmt.Errorf("public key is wrong size: expected %d bytes, got %d", PubKeySize, len(pubKey))
+	}
+	if _, err := bls.PublicKeyFromBytes(pubKey); err != nil {
+		return fmt.Errorf("public key is not a point of the G1 group: %w", err)
+	}
+	return nil
+}
+
 func (pubKey PubKey) VerifySignatureDigest(hash []byte, sig []byte) bool {
 	// make sure we use the same algorithm to sign
 	if len(sig) == 0 {
@@ -212,3 +212,10 @@ func TestAggregationDiffMessages(t *testing.T) {
 	assert.False(t, pubKey.VerifyAggregateSignature(wrongMessages, aggregateSignature))
 	assert.False(t, pubKey.VerifyAggregateSignature(messages, wrongAggregateSignature))
 }
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := bls12381.GenPrivKeyFromSecret([]byte("it's a secret"))
+	assert.Equal(t, "3725e374ff52266af2e1ea274455c1e40d9d39e61f46800117982f636963b759", hex.EncodeToString(privKey.Bytes()))
+	assert.Equal(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("it's a secret")))
+	assert.NotEqual(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("it's another secret")))
+}
@@ -0,0 +1,108 @@
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+
+	bls "github.com/dashpay/bls-signatures/go-bindings"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// KeyShare is the share of a quorum member in the private key of the quorum.
+//
+// The shares are evaluations of a random polynomial of degree threshold-1,
+// whose constant term is the private key of the quorum, at the IDs of the
+// members. Like in Dash Core, the ID of a member is its proTxHash.
+type KeyShare struct {
+	ID      crypto.ProTxHash
+	PrivKey PrivKey
+}
+
+// PubKey returns the public key share of the member.
+func (share KeyShare) PubKey() crypto.PubKey {
+	return share.PrivKey.PubKey()
+}
+
+// SplitKey splits the private key into the key shares of n members with random
+// proTxHashes, any threshold of them are able to recover the signatures of the
+// private key.
+func SplitKey(privKey crypto.PrivKey, n, threshold int) ([]KeyShare, error) {
+	return SplitKeyOnProTxHashes(privKey, CreateProTxHashes(n), threshold)
+}
+
+// SplitKeyOnProTxHashes splits the private key into the key shares of the
+// members with the proTxHashes, any threshold of them are able to recover the
+// signatures of the private key.
+func SplitKeyOnProTxHashes(
+	privKey crypto.PrivKey,
+	proTxHashes []crypto.ProTxHash,
+	threshold int,
+) ([]KeyShare, error) {
+	if _, ok := privKey.(PrivKey); !ok {
+		return nil, fmt.Errorf("private key is not %s: %T", KeyType, privKey)
+	}
+	if threshold <= 0 {
+		return nil, errors.New("threshold must be positive")
+	}
+	if len(proTxHashes) < threshold {
+		return nil, fmt.Errorf("members must not be less than the threshold (got %d members, threshold %d)",
+			len(proTxHashes), threshold)
+	}
+	secrets := make([]*bls.PrivateKey, threshold)
+	secret, err := bls.PrivateKeyFromBytes(privKey.Bytes(), false)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+	secrets[0] = secret
+	for i := 1; i < threshold; i++ {
+		secrets[i], err = bls.PrivateKeyFromSeed(crypto.CRandBytes(SeedSize))
+		if err != nil {
+			return nil, err
+		}
+	}
+	shares := make([]KeyShare, len(proTxHashes))
+	for i, proTxHash := range proTxHashes {
+		if len(proTxHash) != crypto.ProTxHashSize {
+			return nil, fmt.Errorf("proTxHash incorrect size, expected %d bytes (got %d)",
+				crypto.ProTxHashSize, len(proTxHash))
+		}
+		var id bls.Hash
+		copy(id[:], ReverseBytes(proTxHash))
+		skShare, err := bls.PrivateKeyShare(secrets, id)
+		if err != nil {
+			return nil, fmt.Errorf("error creating the key share of %X: %w", proTxHash, err)
+		}
+		shares[i] = KeyShare{ID: proTxHash, PrivKey: PrivKey(skShare.Serialize())}
+	}
+	return shares, nil
+}
+
+// SignShare signs the digest with the key share, like a quorum member signs the
+// sign id of a quorum signing request.
+func SignShare(share KeyShare, digest []byte) ([]byte, error) {
+	return share.PrivKey.SignDigest(digest)
+}
+
+// RecoverThresholdSignature recovers the signature of the quorum from the
+// signature shares of the members with the ids. At least threshold shares are
+// needed, fewer shares recover a signature which doesn't verify with the public
+// key of the quorum.
+func RecoverThresholdSignature(sigShares [][]byte, ids []crypto.ProTxHash) ([]byte, error) {
+	return RecoverThresholdSignatureFromShares(sigShares, proTxHashesToBytes(ids))
+}
+
+// RecoverThresholdPublicKey recovers the public key of the quorum from the
+// public key shares of the members with the ids, at least threshold shares are
+// needed.
+func RecoverThresholdPublicKey(pubShares []crypto.PubKey, ids []crypto.ProTxHash) (crypto.PubKey, error) {
+	return RecoverThresholdPublicKeyFromPublicKeys(pubShares, proTxHashesToBytes(ids))
+}
+
+func proTxHashesToBytes(proTxHashes []crypto.ProTxHash) [][]byte {
+	ids := make([][]byte, len(proTxHashes))
+	for i, proTxHash := range proTxHashes {
+		ids[i] = proTxHash
+	}
+	return ids
+}
@@ -0,0 +1,88 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestThresholdSignatureRecovery(t *testing.T) {
+	const (
+		members   = 10
+		threshold = 7
+	)
+	privKey := bls12381.GenPrivKey()
+	shares, err := bls12381.SplitKey(privKey, members, threshold)
+	require.NoError(t, err)
+	require.Len(t, shares, members)
+
+	digest := crypto.CRandBytes(crypto.DefaultHashSize)
+	sigShares := make([][]byte, members)
+	for i, share := range shares {
+		sigShares[i], err = bls12381.SignShare(share, digest)
+		require.NoError(t, err)
+		assert.True(t, share.PubKey().VerifySignatureDigest(digest, sigShares[i]))
+	}
+
+	testCases := []struct {
+		name  string
+		from  int
+		count int
+		valid bool
+	}{
+		{"threshold shares", 0, threshold, true},
+		{"other threshold shares", members - threshold, threshold, true},
+		{"threshold+1 shares", 0, threshold + 1, true},
+		{"all shares", 0, members, true},
+		{"threshold-1 shares", 0, threshold - 1, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ids := make([]crypto.ProTxHash, tc.count)
+			pubShares := make([]crypto.PubKey, tc.count)
+			for i := range ids {
+				ids[i] = shares[tc.from+i].ID
+				pubShares[i] = shares[tc.from+i].PubKey()
+			}
+			sig, err := bls12381.RecoverThresholdSignature(sigShares[tc.from:tc.from+tc.count], ids)
+			require.NoError(t, err)
+			assert.Equal(t, tc.valid, privKey.PubKey().VerifySignatureDigest(digest, sig))
+
+			pubKey, err := bls12381.RecoverThresholdPublicKey(pubShares, ids)
+			require.NoError(t, err)
+			assert.Equal(t, tc.valid, privKey.PubKey().Equals(pubKey))
+		})
+	}
+}
+
+func TestSplitKeyOnProTxHashes(t *testing.T) {
+	proTxHashes := bls12381.CreateProTxHashes(4)
+	privKey := bls12381.GenPrivKey()
+	shares, err := bls12381.SplitKeyOnProTxHashes(privKey, proTxHashes, 3)
+	require.NoError(t, err)
+	for i, share := range shares {
+		assert.Equal(t, proTxHashes[i], share.ID)
+	}
+
+	// a threshold of 1 shares the private key itself
+	shares, err = bls12381.SplitKeyOnProTxHashes(privKey, proTxHashes, 1)
+	require.NoError(t, err)
+	for _, share := range shares {
+		assert.Equal(t, privKey, share.PrivKey)
+	}
+
+	_, err = bls12381.SplitKeyOnProTxHashes(privKey, proTxHashes, 5)
+	assert.Error(t, err)
+	_, err = bls12381.SplitKeyOnProTxHashes(privKey, proTxHashes, 0)
+	assert.Error(t, err)
+	_, err = bls12381.SplitKeyOnProTxHashes(privKey, []crypto.ProTxHash{{0x01}}, 1)
+	assert.Error(t, err)
+	_, err = bls12381.SplitKeyOnProTxHashes(ed25519.GenPrivKey(), proTxHashes, 3)
+	assert.Error(t, err)
+}
@@ -17,6 +17,9 @@ const (
 	DefaultAppHashSize = LargeAppHashSize
 	ProTxHashSize      = DefaultHashSize
 	QuorumHashSize     = DefaultHashSize
+	// ProTxHashLogSize is the number of bytes of a proTxHash printed in logs,
+	// see bytes.HexBytes.ShortStringN.
+	ProTxHashLogSize = 6
 )
 
 type KeyType int
@@ -101,6 +104,18 @@ type PrivKey interface {
 	TypeValue() KeyType
 }
 
+// BatchVerifier verifies many signatures at once. The key types, which support
+// batch verification, implement it.
+type BatchVerifier interface {
+	// Add appends the signature of the digest, like the ones verified with
+	// PubKey.VerifySignatureDigest, into the batch.
+	Add(key PubKey, digest, signature []byte) error
+	// Verify verifies all the signatures in the batch, and returns whether all
+	// of them are valid and the validity of each signature in the order they
+	// were added.
+	Verify() (bool, []bool)
+}
+
 type Symmetric interface {
 	Keygen() []byte
 	Encrypt(plaintext []byte, secret []byte) (ciphertext []byte)
@@ -24,6 +24,15 @@ const (
 	baseKeyPending   = byte(0x01)
 )
 
+var (
+	// ErrEvidenceAlreadyPending is returned by AddEvidence if the evidence was
+	// already verified and is awaiting to be committed.
+	ErrEvidenceAlreadyPending = errors.New("evidence is already pending")
+	// ErrEvidenceAlreadyCommitted is returned by AddEvidence if the evidence was
+	// already committed.
+	ErrEvidenceAlreadyCommitted = errors.New("evidence was already committed")
+)
+
 // Pool maintains a pool of valid evidence to be broadcasted and committed
 type Pool struct {
 	logger log.Logger
@@ -137,7 +146,7 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 	// We have already verified this piece of evidence - no need to do it again
 	if evpool.isPending(ev) {
 		evpool.logger.Debug("Evidence already pending, ignoring this one", "ev", ev)
-		return nil
+		return ErrEvidenceAlreadyPending
 	}
 
 	// check that the evidence isn't already committed
@@ -145,7 +154,7 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 		// this can happen if the peer that sent us the evidence is behind so we shouldn't
 		// punish the peer.
 		evpool.logger.Debug("Evidence was already committed, ignoring this one", "ev", ev)
-		return nil
+		return ErrEvidenceAlreadyCommitted
 	}
 
 	// 1) Verify against state.
@@ -535,6 +544,43 @@ type duplicateVoteSet struct {
 	VoteB *types.Vote
 }
 
+// PruneOrphans deletes the pending and committed evidence below the retain
+// height (e.g. the base of the block store), which can't be verified anymore
+// without the blocks. It's meant to be run offline, e.g. by the
+// experimental-compact command, and returns the number of keys deleted.
+func PruneOrphans(evidenceDB dbm.DB, retainHeight int64) (uint64, error) {
+	// The keys are collected first, since not every backend allows to write
+	// while iterating.
+	var orphans [][]byte
+	for _, prefixKey := range []byte{baseKeyCommitted, baseKeyPending} {
+		end := append([]byte{prefixKey}, bE(retainHeight)...)
+		iter, err := evidenceDB.Iterator([]byte{prefixKey}, end)
+		if err != nil {
+			return 0, err
+		}
+		for ; iter.Valid(); iter.Next() {
+			orphans = append(orphans, append([]byte(nil), iter.Key()...))
+		}
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	batch := evidenceDB.NewBatch()
+	defer batch.Close()
+	for _, key := range orphans {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return uint64(len(orphans)), nil
+}
+
 func bytesToEv(evBytes []byte) (types.Evidence, error) {
 	var evpb tmproto.Evidence
 	err := evpb.Unmarshal(evBytes)
@@ -93,7 +93,7 @@ func TestEvidencePoolSingleValidator(t *testing.T) {
 	assert.Equal(t, evidenceBytes, size) // check that the size of the single evidence in bytes is correct, bls is 64 more than edwards
 
 	// shouldn't be able to add evidence twice
-	assert.NoError(t, pool.AddEvidence(ev))
+	assert.Equal(t, evidence.ErrEvidenceAlreadyPending, pool.AddEvidence(ev))
 	evs, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
 	assert.Equal(t, 1, len(evs))
 
@@ -152,7 +152,7 @@ func TestEvidencePoolQuorum(t *testing.T) {
 	assert.Equal(t, evidenceBytes, size) // check that the size of the single evidence in bytes is correct, bls is 64 more than edwards
 
 	// shouldn't be able to add evidence twice
-	assert.NoError(t, pool.AddEvidence(ev))
+	assert.Equal(t, evidence.ErrEvidenceAlreadyPending, pool.AddEvidence(ev))
 	evs, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
 	assert.Equal(t, 1, len(evs))
 
@@ -281,6 +281,9 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	if assert.Error(t, err) {
 		assert.Equal(t, "evidence was already committed", err.(*types.ErrInvalidEvidence).Reason.Error())
 	}
+
+	// c) Adding the committed evidence again is reported as a duplicate
+	assert.Equal(t, evidence.ErrEvidenceAlreadyCommitted, pool.AddEvidence(ev))
 }
 
 func TestVerifyPendingEvidencePasses(t *testing.T) {
@@ -360,6 +363,47 @@ func TestRecoverPendingEvidence(t *testing.T) {
 
 }
 
+func TestPruneOrphans(t *testing.T) {
+	height := int64(10)
+	quorumHash := crypto.RandQuorumHash()
+	val := types.NewMockPVForQuorum(quorumHash)
+	evidenceDB := dbm.NewMemDB()
+	stateStore := initializeValidatorState(val, height, btcjson.LLMQType_5_60, quorumHash)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(dbm.NewMemDB(), state, val.ProTxHash)
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	vals := state.Validators
+	committedEv := types.NewMockDuplicateVoteEvidenceWithValidator(4,
+		defaultEvidenceTime.Add(4*time.Minute), val, evidenceChainID, vals.QuorumType, vals.QuorumHash)
+	pendingEv := types.NewMockDuplicateVoteEvidenceWithValidator(8,
+		defaultEvidenceTime.Add(8*time.Minute), val, evidenceChainID, vals.QuorumType, vals.QuorumHash)
+	require.NoError(t, pool.AddEvidence(committedEv))
+	require.NoError(t, pool.AddEvidence(pendingEv))
+	state.LastBlockHeight++
+	pool.Update(state, types.EvidenceList{committedEv})
+
+	// only the committed evidence is below the retain height
+	pruned, err := evidence.PruneOrphans(evidenceDB, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pruned)
+
+	pool, err = evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	evList, _ := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Equal(t, []types.Evidence{pendingEv}, evList)
+
+	pruned, err = evidence.PruneOrphans(evidenceDB, height)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pruned)
+	pruned, err = evidence.PruneOrphans(evidenceDB, height)
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+}
+
 func initializeStateFromValidatorSet(valSet *types.ValidatorSet, height int64) sm.Store {
 	stateDB := dbm.NewMemDB()
 	stateStore := sm.NewStore(stateDB)
@@ -76,11 +76,15 @@ func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 
 	for _, ev := range evis {
 		err := evR.evpool.AddEvidence(ev)
+		if err == ErrEvidenceAlreadyPending || err == ErrEvidenceAlreadyCommitted {
+			// peers may send us evidence we already have, e.g. if they are behind
+			continue
+		}
 		switch err.(type) {
 		case *types.ErrInvalidEvidence:
 			evR.Logger.Error(err.Error())
 			// punish peer
-			evR.Switch.StopPeerForError(src, err)
+			evR.Switch.StopPeerForMisbehavior(src, err)
 			return
 		case nil:
 		default:
@@ -46,6 +46,8 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 	// apply the evidence-specific verification logic
 	switch ev := evidence.(type) {
 	case *types.DuplicateVoteEvidence:
+		// the keys of the validators rotate with the quorum, so the votes are verified with the
+		// validator set at the height of the evidence
 		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
 		if err != nil {
 			return err
@@ -59,18 +61,13 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 
 // VerifyDuplicateVote verifies DuplicateVoteEvidence against the state of full node. This involves the
 // following checks:
-//      - the validator is in the validator set at the height of the evidence
-//      - the height, round, type and validator address of the votes must be the same
+//      - the height, round, type and validator proTxHash of the votes must be the same
 //      - the block ID's must be different
-//      - The signatures must both be valid
+//      - the validator is in the validator set at the height of the evidence
+//      - The signatures must both be valid with the key of the validator in that validator set
+// The validator set must be the one at the height of the evidence, as the validators are identified
+// by their proTxHash, while their keys change with the quorum.
 func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet *types.ValidatorSet) error {
-	_, val := valSet.GetByProTxHash(e.VoteA.ValidatorProTxHash)
-	if val == nil {
-		return fmt.Errorf("proTxHash %X was not a validator at height %d", e.VoteA.ValidatorProTxHash, e.Height())
-	}
-	proTxHash := val.ProTxHash
-	pubKey := val.PubKey
-
 	// H/R/S must be the same
 	if e.VoteA.Height != e.VoteB.Height ||
 		e.VoteA.Round != e.VoteB.Round ||
@@ -96,9 +93,12 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 		)
 	}
 
-	// proTxHash must match address (this should already be true, sanity check)
-	if !bytes.Equal(proTxHash, e.VoteA.ValidatorProTxHash) {
-		return fmt.Errorf("proTxHash (%X) doesn't match pubkey (%v)", e.VoteA.ValidatorProTxHash, proTxHash)
+	_, val := valSet.GetByProTxHash(e.VoteA.ValidatorProTxHash)
+	if val == nil {
+		return fmt.Errorf("proTxHash %X was not a validator at height %d", e.VoteA.ValidatorProTxHash, e.Height())
+	}
+	if val.PubKey == nil {
+		return fmt.Errorf("public key of validator %X at height %d is unknown", e.VoteA.ValidatorProTxHash, e.Height())
 	}
 
 	// validator voting power and total voting power must match
@@ -113,13 +113,18 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 
 	va := e.VoteA.ToProto()
 	vb := e.VoteB.ToProto()
-	// Signatures must be valid
-	blockSignId := types.VoteBlockSignId(chainID, va, valSet.QuorumType, valSet.QuorumHash)
-	if !pubKey.VerifySignatureDigest(blockSignId, e.VoteA.BlockSignature) {
+	// Signatures must be valid with the key of the validator at the height, they are verified in one batch
+	valid := types.VerifySignatureDigests(val.PubKey,
+		[][]byte{
+			types.VoteBlockSignId(chainID, va, valSet.QuorumType, valSet.QuorumHash),
+			types.VoteBlockSignId(chainID, vb, valSet.QuorumType, valSet.QuorumHash),
+		},
+		[][]byte{e.VoteA.BlockSignature, e.VoteB.BlockSignature})
+	if !valid[0] {
 		return fmt.Errorf("verifying VoteA: %s", types.ErrVoteInvalidBlockSignature.Error())
 	}
-	if !pubKey.VerifySignatureDigest(types.VoteBlockSignId(chainID, vb, valSet.QuorumType, valSet.QuorumHash), e.VoteB.BlockSignature) {
-		return fmt.Errorf("verifying VoteB: %s", types.ErrVoteInvalidStateSignature.Error())
+	if !valid[1] {
+		return fmt.Errorf("verifying VoteB: %s", types.ErrVoteInvalidBlockSignature.Error())
 	}
 
 	return nil
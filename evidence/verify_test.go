@@ -11,6 +11,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/evidence/mocks"
@@ -128,6 +129,72 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyDuplicateVoteEvidenceKeyRotation(t *testing.T) {
+	const chainID = "mychain"
+	quorumType := btcjson.LLMQType_5_60
+	quorumHash1, quorumHash2 := crypto.RandQuorumHash(), crypto.RandQuorumHash()
+	val := types.NewMockPVForQuorum(quorumHash1)
+	proTxHash, err := val.GetProTxHash()
+	require.NoError(t, err)
+
+	// the key of the validator rotates with the quorum at height 15, its proTxHash stays the same
+	require.NoError(t, val.UpdatePrivateKey(bls12381.GenPrivKey(), quorumHash2, 15))
+	valSets := make(map[int64]*types.ValidatorSet)
+	for height, quorumHash := range map[int64]crypto.QuorumHash{10: quorumHash1, 20: quorumHash2} {
+		validator := val.ExtractIntoValidator(quorumHash)
+		valSets[height] = types.NewValidatorSet([]*types.Validator{validator}, validator.PubKey, quorumType,
+			quorumHash, true)
+	}
+	require.False(t, valSets[10].Validators[0].PubKey.Equals(valSets[20].Validators[0].PubKey))
+
+	evTime := map[int64]time.Time{10: defaultEvidenceTime, 20: defaultEvidenceTime.Add(1 * time.Minute)}
+	state := sm.State{
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(2 * time.Minute),
+		LastBlockHeight: 21,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	for height, valSet := range valSets {
+		stateStore.On("LoadValidators", height).Return(valSet, nil)
+		blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: types.Header{Time: evTime[height]}})
+	}
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		height     int64
+		quorumHash crypto.QuorumHash
+		valid      bool
+	}{
+		{"signed with the key before the rotation", 10, quorumHash1, true},
+		{"signed with the key after the rotation", 20, quorumHash2, true},
+		{"signed with the rotated key before the rotation", 10, quorumHash2, false},
+		{"signed with the old key after the rotation", 20, quorumHash1, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ev := types.NewMockDuplicateVoteEvidenceWithPrivValInValidatorSet(tc.height, evTime[tc.height], val,
+				valSets[tc.height], chainID, quorumType, tc.quorumHash)
+			require.NotNil(t, ev)
+			err := pool.CheckEvidence(types.EvidenceList{ev})
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			// the application learns the proTxHash of the validator
+			abciEv := ev.ABCI()
+			require.Len(t, abciEv, 1)
+			assert.EqualValues(t, proTxHash, abciEv[0].Validator.ProTxHash)
+		})
+	}
+}
+
 func makeVote(
 	t *testing.T, val types.PrivValidator, chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, valIndex int32, height int64,
 	round int32, step int, blockID types.BlockID, stateID types.StateID) *types.Vote {
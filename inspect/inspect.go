@@ -0,0 +1,208 @@
+// Package inspect serves a read-only subset of the RPC from the block and
+// state stores of a stopped node, so that the standard RPC tooling can be used
+// to debug a halted chain.
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	dbm "github.com/tendermint/tm-db"
+
+	cfg "github.com/tendermint/tendermint/config"
+	tmdb "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/p2p"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/version"
+)
+
+// Inspector serves the rpccore.InspectRoutes over the block and state stores
+// of a node, which isn't running. It doesn't run p2p nor consensus, and never
+// writes to the stores.
+type Inspector struct {
+	service.BaseService
+
+	config     *cfg.Config
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	nodeInfo   p2p.DefaultNodeInfo
+
+	dbs       []dbm.DB // closed on stop, if opened by NewFromConfig
+	listeners []net.Listener
+}
+
+// New returns an Inspector serving the RPC from the given stores on the
+// rpc.laddr of the config.
+func New(
+	config *cfg.Config,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	nodeInfo p2p.DefaultNodeInfo,
+	logger log.Logger,
+) *Inspector {
+	ins := &Inspector{
+		config:     config,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		nodeInfo:   nodeInfo,
+	}
+	ins.BaseService = *service.NewBaseService(logger, "Inspector", ins)
+	return ins
+}
+
+// NewFromConfig opens the block and state databases of the node read-only and
+// returns an Inspector over them. It fails if the databases are locked by the
+// running node.
+func NewFromConfig(config *cfg.Config, logger log.Logger) (*Inspector, error) {
+	var dbs []dbm.DB
+	closeDBs := func() {
+		for _, db := range dbs {
+			_ = db.Close()
+		}
+	}
+	for _, id := range []string{"blockstore", "state"} {
+		db, err := tmdb.NewReadOnlyDB(id, dbm.BackendType(config.DBBackendFor(id)), config.DBDir())
+		if err != nil {
+			closeDBs()
+			return nil, fmt.Errorf("can't open %s database, is the node running? %w", id, err)
+		}
+		dbs = append(dbs, db)
+	}
+	blockStore := store.NewBlockStore(dbs[0])
+	stateStore := sm.NewStore(dbs[1])
+
+	state, err := stateStore.Load()
+	if err != nil {
+		closeDBs()
+		return nil, fmt.Errorf("can't load state: %w", err)
+	}
+	if state.IsEmpty() {
+		closeDBs()
+		return nil, errors.New("no state found, the node has never run")
+	}
+	ins := New(config, blockStore, stateStore, makeNodeInfo(config, state), logger)
+	ins.dbs = dbs
+	return ins, nil
+}
+
+// makeNodeInfo returns the node info reported by the status route. The node
+// key is only read, it isn't generated if it's missing.
+func makeNodeInfo(config *cfg.Config, state sm.State) p2p.DefaultNodeInfo {
+	var nodeID p2p.ID
+	if nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile()); err == nil {
+		nodeID = nodeKey.ID()
+	}
+	return p2p.DefaultNodeInfo{
+		ProtocolVersion: p2p.NewProtocolVersion(
+			version.P2PProtocol,
+			state.Version.Consensus.Block,
+			state.Version.Consensus.App,
+		),
+		DefaultNodeID: nodeID,
+		ListenAddr:    config.P2P.ListenAddress,
+		Network:       state.ChainID,
+		Version:       version.TMCoreSemVer,
+		Moniker:       config.Moniker,
+		Other: p2p.DefaultNodeInfoOther{
+			RPCAddress: config.RPC.ListenAddress,
+		},
+	}
+}
+
+// OnStart implements service.Service by starting the RPC servers.
+func (ins *Inspector) OnStart() error {
+	rpccore.SetEnvironment(&rpccore.Environment{
+		StateStore:       ins.stateStore,
+		BlockStore:       ins.blockStore,
+		ConsensusReactor: stoppedConsensus{},
+		P2PTransport:     stoppedTransport{nodeInfo: ins.nodeInfo},
+
+		Logger: ins.Logger.With("module", "rpc"),
+
+		Config: *ins.config.RPC,
+	})
+
+	config := rpcserver.DefaultConfig()
+	config.MaxBodyBytes = ins.config.RPC.MaxBodyBytes
+	config.MaxHeaderBytes = ins.config.RPC.MaxHeaderBytes
+	config.MaxOpenConnections = ins.config.RPC.MaxOpenConnections
+	config.UnixSocketMode = ins.config.RPC.UnixSocketFileMode()
+
+	rpcLogger := ins.Logger.With("module", "rpc-server")
+	for _, listenAddr := range strings.Split(ins.config.RPC.ListenAddress, ",") {
+		listenAddr = strings.TrimSpace(listenAddr)
+		if listenAddr == "" {
+			continue
+		}
+		mux := http.NewServeMux()
+		rpcserver.RegisterRPCFuncs(mux, rpccore.InspectRoutes, rpcLogger)
+		listener, err := rpcserver.Listen(listenAddr, config)
+		if err != nil {
+			ins.closeListeners()
+			return err
+		}
+		ins.listeners = append(ins.listeners, listener)
+		go func() {
+			if err := rpcserver.Serve(listener, mux, rpcLogger, config); err != nil {
+				ins.Logger.Error("Error serving server", "err", err)
+			}
+		}()
+	}
+	return nil
+}
+
+// OnStop implements service.Service by stopping the RPC servers and closing
+// the databases opened by NewFromConfig.
+func (ins *Inspector) OnStop() {
+	ins.closeListeners()
+	for _, db := range ins.dbs {
+		if err := db.Close(); err != nil {
+			ins.Logger.Error("Error closing database", "err", err)
+		}
+	}
+}
+
+// Listeners returns the addresses of the RPC servers.
+func (ins *Inspector) Listeners() []net.Addr {
+	addrs := make([]net.Addr, len(ins.listeners))
+	for i, l := range ins.listeners {
+		addrs[i] = l.Addr()
+	}
+	return addrs
+}
+
+func (ins *Inspector) closeListeners() {
+	for _, l := range ins.listeners {
+		if err := l.Close(); err != nil {
+			ins.Logger.Error("Error closing listener", "listener", l, "err", err)
+		}
+	}
+	ins.listeners = nil
+}
+
+// stoppedConsensus is the consensus of the stopped node, which isn't syncing.
+type stoppedConsensus struct{}
+
+func (stoppedConsensus) WaitSync() bool { return false }
+
+func (stoppedConsensus) DropPeerState(id p2p.ID) error {
+	return errors.New("consensus is stopped")
+}
+
+// stoppedTransport is the p2p transport of the stopped node, which isn't
+// listening.
+type stoppedTransport struct {
+	nodeInfo p2p.DefaultNodeInfo
+}
+
+func (stoppedTransport) Listeners() []string      { return nil }
+func (stoppedTransport) IsListening() bool        { return false }
+func (t stoppedTransport) NodeInfo() p2p.NodeInfo { return t.nodeInfo }
@@ -0,0 +1,241 @@
+package inspect
+
+import (
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	tmdb "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newTestConfig(t *testing.T) *cfg.Config {
+	config := cfg.ResetTestRoot("inspect_test")
+	t.Cleanup(func() { os.RemoveAll(config.RootDir) })
+	// the databases are persisted and locked like the ones of a node
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+	return config
+}
+
+// startInspector starts the inspector of the stopped node on a random port
+// and returns a client of its RPC.
+func startInspector(t *testing.T, config *cfg.Config) *rpcclient.Client {
+	config.RPC.ListenAddress = "tcp://127.0.0.1:0"
+	ins, err := NewFromConfig(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, ins.Start())
+	t.Cleanup(func() {
+		if err := ins.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	client, err := rpcclient.New("http://" + ins.Listeners()[0].String())
+	require.NoError(t, err)
+	return client
+}
+
+// dataDirDigest returns the digest of the files of the data directory.
+func dataDirDigest(t *testing.T, config *cfg.Config) map[string][32]byte {
+	digest := make(map[string][32]byte)
+	err := filepath.Walk(config.DBDir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		bz, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		digest[path] = sha256.Sum256(bz)
+		return nil
+	})
+	require.NoError(t, err)
+	return digest
+}
+
+func TestInspectStoppedNode(t *testing.T) {
+	config := newTestConfig(t)
+
+	// the databases are closed once the process of the node exits
+	var dbs []dbm.DB
+	dbProvider := func(ctx *node.DBContext) (dbm.DB, error) {
+		db, err := node.DefaultDBProvider(ctx)
+		if err == nil {
+			dbs = append(dbs, db)
+		}
+		return db, err
+	}
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := node.NewNode(config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		node.DefaultGenesisDocProviderFunc(config),
+		dbProvider,
+		node.DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "inspect_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+
+	// stop the node in the middle of the chain
+	hashes := make(map[int64][]byte)
+	for len(hashes) < 3 {
+		select {
+		case msg := <-blocksSub.Out():
+			block := msg.Data().(types.EventDataNewBlock).Block
+			hashes[block.Height] = block.Hash()
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+
+	_, err = NewFromConfig(config, log.TestingLogger())
+	require.Error(t, err, "the databases are locked by the running node")
+	assert.Contains(t, err.Error(), "is the node running")
+
+	require.NoError(t, n.Stop())
+	for _, db := range dbs {
+		require.NoError(t, db.Close())
+	}
+	digest := dataDirDigest(t, config)
+
+	client := startInspector(t, config)
+	ctx := context.Background()
+	for height, hash := range hashes {
+		height := height
+		params := map[string]interface{}{"height": &height}
+
+		block := new(ctypes.ResultBlock)
+		_, err := client.Call(ctx, "block", params, block)
+		require.NoError(t, err)
+		assert.EqualValues(t, hash, block.BlockID.Hash, "height %d", height)
+
+		commit := new(ctypes.ResultCommit)
+		_, err = client.Call(ctx, "commit", params, commit)
+		require.NoError(t, err)
+		assert.EqualValues(t, hash, commit.Commit.BlockID.Hash, "height %d", height)
+
+		_, err = client.Call(ctx, "block_results", params, new(ctypes.ResultBlockResults))
+		require.NoError(t, err)
+		_, err = client.Call(ctx, "validators", params, new(ctypes.ResultValidators))
+		require.NoError(t, err)
+		_, err = client.Call(ctx, "consensus_params", params, new(ctypes.ResultConsensusParams))
+		require.NoError(t, err)
+	}
+
+	status := new(ctypes.ResultStatus)
+	_, err = client.Call(ctx, "status", nil, status)
+	require.NoError(t, err)
+	assert.Equal(t, n.NodeInfo().ID(), status.NodeInfo.ID())
+	assert.GreaterOrEqual(t, status.SyncInfo.LatestBlockHeight, int64(3))
+	assert.False(t, status.SyncInfo.CatchingUp)
+
+	// the node routes, which need a running node, aren't served
+	_, err = client.Call(ctx, "broadcast_tx_sync", map[string]interface{}{"tx": []byte("a=1")},
+		new(ctypes.ResultBroadcastTx))
+	assert.Error(t, err)
+
+	assert.Equal(t, digest, dataDirDigest(t, config), "the data directory was written to")
+}
+
+func TestInspectStores(t *testing.T) {
+	const blocks = 10
+	config := newTestConfig(t)
+
+	blockStoreDB, err := tmdb.NewDB("blockstore", dbm.GoLevelDBBackend, config.DBDir())
+	require.NoError(t, err)
+	stateDB, err := tmdb.NewDB("state", dbm.GoLevelDBBackend, config.DBDir())
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(stateDB)
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	require.NoError(t, stateStore.Save(state))
+	blockStore := store.NewBlockStore(blockStoreDB)
+	hashes := make(map[int64][]byte, blocks)
+	for h := int64(1); h <= blocks; h++ {
+		block, parts := state.MakeBlock(h, nil, []types.Tx{types.Tx("tx")}, new(types.Commit), nil,
+			state.Validators.GetProposer().ProTxHash)
+		blockStore.SaveBlock(block, parts, new(types.Commit))
+		hashes[h] = block.Hash()
+
+		require.NoError(t, stateStore.SaveABCIResponses(h, &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}))
+		state.LastBlockHeight = h
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, stateStore.Save(state))
+	}
+
+	// the node holds the locks of the databases
+	_, err = NewFromConfig(config, log.TestingLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is the node running")
+
+	require.NoError(t, blockStoreDB.Close())
+	require.NoError(t, stateDB.Close())
+	digest := dataDirDigest(t, config)
+
+	client := startInspector(t, config)
+	ctx := context.Background()
+	height := int64(5)
+	params := map[string]interface{}{"height": &height}
+
+	block := new(ctypes.ResultBlock)
+	_, err = client.Call(ctx, "block", params, block)
+	require.NoError(t, err)
+	assert.EqualValues(t, hashes[height], block.BlockID.Hash)
+
+	results := new(ctypes.ResultBlockResults)
+	_, err = client.Call(ctx, "block_results", params, results)
+	require.NoError(t, err)
+	assert.Len(t, results.TxsResults, 1)
+
+	commit := new(ctypes.ResultCommit)
+	_, err = client.Call(ctx, "commit", params, commit)
+	require.NoError(t, err)
+	assert.EqualValues(t, hashes[height], commit.Header.Hash())
+
+	validators := new(ctypes.ResultValidators)
+	_, err = client.Call(ctx, "validators", params, validators)
+	require.NoError(t, err)
+	assert.Equal(t, state.Validators.Size(), len(validators.Validators))
+
+	_, err = client.Call(ctx, "consensus_params", params, new(ctypes.ResultConsensusParams))
+	require.NoError(t, err)
+
+	status := new(ctypes.ResultStatus)
+	_, err = client.Call(ctx, "status", nil, status)
+	require.NoError(t, err)
+	assert.EqualValues(t, blocks, status.SyncInfo.LatestBlockHeight)
+	assert.EqualValues(t, hashes[blocks], status.SyncInfo.LatestBlockHash)
+	assert.Equal(t, state.ChainID, status.NodeInfo.Network)
+
+	assert.Equal(t, digest, dataDirDigest(t, config), "the data directory was written to")
+}
@@ -1,9 +1,14 @@
 package bytes
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // HexBytes enables HEX-encoding for json/encoding.
@@ -22,7 +27,7 @@ func (bz *HexBytes) Unmarshal(data []byte) error {
 
 // MarshalJSON is the point of Bytes.
 func (bz HexBytes) MarshalJSON() ([]byte, error) {
-	s := strings.ToUpper(hex.EncodeToString(bz))
+	s, _ := bz.MarshalText()
 	jbz := make([]byte, len(s)+2)
 	jbz[0] = '"'
 	copy(jbz[1:], s)
@@ -30,14 +35,36 @@ func (bz HexBytes) MarshalJSON() ([]byte, error) {
 	return jbz, nil
 }
 
-// UnmarshalJSON is the point of Bytes.
+// UnmarshalJSON decodes a JSON string of hex digits in any case, optionally
+// prefixed with 0x or 0X. The empty string decodes to an empty slice, and
+// null to a nil slice.
 func (bz *HexBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*bz = nil
+		return nil
+	}
 	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
 		return fmt.Errorf("invalid hex string: %s", data)
 	}
-	bz2, err := hex.DecodeString(string(data[1 : len(data)-1]))
+	return bz.UnmarshalText(data[1 : len(data)-1])
+}
+
+// MarshalText encodes bytes as upper-case hex digits without a prefix, the same
+// as MarshalJSON does. It makes HexBytes readable in YAML and in any other
+// encoding, which supports encoding.TextMarshaler.
+func (bz HexBytes) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(hex.EncodeToString(bz))), nil
+}
+
+// UnmarshalText decodes hex digits in any case, optionally prefixed with 0x or 0X.
+func (bz *HexBytes) UnmarshalText(text []byte) error {
+	s := string(text)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	bz2, err := hex.DecodeString(s)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid hex string %q: %w", text, err)
 	}
 	*bz = bz2
 	return nil
@@ -48,8 +75,54 @@ func (bz HexBytes) Bytes() []byte {
 	return bz
 }
 
+// Equal reports whether bz and other are the same length and contain the same bytes.
+// A nil value is equal to an empty one.
+func (bz HexBytes) Equal(other HexBytes) bool {
+	return bytes.Equal(bz, other)
+}
+
+// ConstantTimeEqual is Equal, which takes time independent of the contents of
+// bz and other, use it to compare signatures and other secrets. It still leaks
+// whether the lengths differ.
+func (bz HexBytes) ConstantTimeEqual(other HexBytes) bool {
+	return subtle.ConstantTimeCompare(bz, other) == 1
+}
+
+// Compare returns an integer comparing bz and other lexicographically, the result
+// is 0 if bz == other, -1 if bz < other, and +1 if bz > other.
+func (bz HexBytes) Compare(other HexBytes) int {
+	return bytes.Compare(bz, other)
+}
+
+// Copy returns a copy of bz, which doesn't share the underlying array with it.
+// A nil value is copied as nil.
+func (bz HexBytes) Copy() HexBytes {
+	if bz == nil {
+		return nil
+	}
+	c := make(HexBytes, len(bz))
+	copy(c, bz)
+	return c
+}
+
+// shortStringLen is the number of bytes printed by ShortString.
+const shortStringLen = 3
+
+// ShortString returns the upper-case hex of the first 3 bytes, see ShortStringN.
 func (bz HexBytes) ShortString() string {
-	return strings.ToUpper(hex.EncodeToString(bz[:3]))
+	return bz.ShortStringN(shortStringLen)
+}
+
+// ShortStringN returns the upper-case hex of the first n bytes followed by an
+// ellipsis, or the whole value if it is not longer than n bytes.
+func (bz HexBytes) ShortStringN(n int) string {
+	if n < 0 {
+		n = 0
+	}
+	if len(bz) <= n {
+		return bz.String()
+	}
+	return strings.ToUpper(hex.EncodeToString(bz[:n])) + "…"
 }
 
 func (bz HexBytes) String() string {
@@ -65,14 +138,106 @@ func (bz HexBytes) ReversedBytes() HexBytes {
 	return s
 }
 
-// Format writes either address of 0th element in a slice in base 16 notation,
-// with leading 0x (%p), or casts HexBytes to bytes and writes as hexadecimal
-// string to s.
+// Format implements fmt.Formatter:
+//
+//	%s, %v  upper-case hex digits, the same as String and the JSON form without quotes
+//	%q      the upper-case hex digits quoted, the same as the JSON form; %#q backquotes them
+//	%x, %X  lower-case or upper-case hex digits; %#x and %#X prefix them with 0x or 0X,
+//	        and % x and % X separate the bytes with spaces
+//	%#v     a Go-syntax literal, bytes.HexBytes{0xde, 0xad}
+//	%p      the address of the 0th element in base 16 notation, with leading 0x
+//
+// The precision truncates the hex digits to that many bytes, marking the
+// truncation with an ellipsis like ShortStringN, e.g. %.4s of a hash gives
+// DEADBEEF…. The width pads the result with spaces, on the right with the -
+// flag. Other verbs write an error like %!d(bytes.HexBytes=DEADBEEF), as fmt
+// does for bad verbs.
+//
+// Note that %x gives lower-case digits, and %d and the other bad verbs give an
+// error, while they all used to give the upper-case hex digits. Use %X or %s
+// for the upper-case form, which is the one of String and JSON.
 func (bz HexBytes) Format(s fmt.State, verb rune) {
+	if verb == 'p' {
+		fmt.Fprintf(s, "%p", []byte(bz))
+		return
+	}
+
+	var out string
 	switch verb {
-	case 'p':
-		s.Write([]byte(fmt.Sprintf("%p", bz)))
+	case 's', 'v':
+		if verb == 'v' && s.Flag('#') {
+			out = bz.goString()
+			break
+		}
+		out = bz.hexString(s, false, false, false)
+	case 'q':
+		out = bz.hexString(s, false, false, false)
+		if s.Flag('#') {
+			out = "`" + out + "`"
+		} else {
+			out = strconv.Quote(out)
+		}
+	case 'x', 'X':
+		out = bz.hexString(s, verb == 'x', s.Flag('#'), s.Flag(' '))
+	default:
+		out = "%!" + string(verb) + "(bytes.HexBytes=" + bz.String() + ")"
+	}
+
+	if width, ok := s.Width(); ok {
+		if pad := width - utf8.RuneCountInString(out); pad > 0 {
+			if s.Flag('-') {
+				out += strings.Repeat(" ", pad)
+			} else {
+				out = strings.Repeat(" ", pad) + out
+			}
+		}
+	}
+	_, _ = io.WriteString(s, out)
+}
+
+// hexString returns the hex digits of bz truncated to the precision of s,
+// optionally prefixed with 0x and separated with spaces per byte.
+func (bz HexBytes) hexString(s fmt.State, lower, prefix, spaced bool) string {
+	value, truncated := bz, false
+	if prec, ok := s.Precision(); ok && prec < len(bz) {
+		value, truncated = bz[:prec], true
+	}
+
+	digits := hex.EncodeToString(value)
+	var out string
+	switch {
+	case len(value) == 0:
+	case spaced:
+		parts := make([]string, len(value))
+		for i := range parts {
+			parts[i] = digits[2*i : 2*i+2]
+			if prefix {
+				parts[i] = "0x" + parts[i]
+			}
+		}
+		out = strings.Join(parts, " ")
+	case prefix:
+		out = "0x" + digits
 	default:
-		s.Write([]byte(fmt.Sprintf("%X", []byte(bz))))
+		out = digits
+	}
+	if !lower {
+		out = strings.ToUpper(out)
+	}
+	if truncated {
+		out += "…"
+	}
+	return out
+}
+
+// goString returns the Go-syntax literal of bz for %#v.
+func (bz HexBytes) goString() string {
+	if bz == nil {
+		return "bytes.HexBytes(nil)"
+	}
+	elems := make([]string, len(bz))
+	for i, b := range bz {
+		elems[i] = fmt.Sprintf("%#x", b)
 	}
+	return "bytes.HexBytes{" + strings.Join(elems, ", ") + "}"
 }
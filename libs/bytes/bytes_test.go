@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 // This is a trivial test for protobuf compatibility.
@@ -71,3 +73,351 @@ func TestHexBytes_String(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestHexBytes_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input   string
+		want    HexBytes
+		wantErr string
+	}{
+		{input: `"DEADBEEF"`, want: HexBytes{0xde, 0xad, 0xbe, 0xef}},
+		{input: `"deadbeef"`, want: HexBytes{0xde, 0xad, 0xbe, 0xef}},
+		{input: `"DeAdBeEf"`, want: HexBytes{0xde, 0xad, 0xbe, 0xef}},
+		{input: `"0xDEADBEEF"`, want: HexBytes{0xde, 0xad, 0xbe, 0xef}},
+		{input: `"0Xdeadbeef"`, want: HexBytes{0xde, 0xad, 0xbe, 0xef}},
+		{input: `"0x"`, want: HexBytes{}},
+		{input: `""`, want: HexBytes{}},
+		{input: `null`, want: nil},
+		{input: `"ABC"`, wantErr: `invalid hex string "ABC": encoding/hex: odd length hex string`},
+		{input: `"0xABC"`, wantErr: `invalid hex string "0xABC": encoding/hex: odd length hex string`},
+		{input: `"0x0x00"`, wantErr: `invalid hex string "0x0x00": encoding/hex: invalid byte: U+0078 'x'`},
+		{input: `"XYZW"`, wantErr: `invalid hex string "XYZW": encoding/hex: invalid byte: U+0058 'X'`},
+		{input: `DEADBEEF`, wantErr: "invalid hex string: DEADBEEF"},
+		{input: `"DEADBEEF`, wantErr: `invalid hex string: "DEADBEEF`},
+		{input: `"`, wantErr: `invalid hex string: "`},
+		{input: `12`, wantErr: "invalid hex string: 12"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			bz := HexBytes{0x01}
+			err := bz.UnmarshalJSON([]byte(tc.input))
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, bz)
+		})
+	}
+}
+
+func TestHexBytes_UnmarshalText(t *testing.T) {
+	testCases := []struct {
+		input   string
+		want    HexBytes
+		wantErr bool
+	}{
+		{input: "0a0B", want: HexBytes{0x0a, 0x0b}},
+		{input: "0x0a0B", want: HexBytes{0x0a, 0x0b}},
+		{input: "", want: HexBytes{}},
+		{input: "0x0", wantErr: true},
+		{input: "x0a", wantErr: true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			var bz HexBytes
+			err := bz.UnmarshalText([]byte(tc.input))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, bz)
+		})
+	}
+}
+
+// Test that marshaling stays upper-case without a prefix, so round-trips are stable.
+func TestHexBytes_RoundTrip(t *testing.T) {
+	for _, input := range []string{`"0xdeadbeef"`, `"DeAdBeEf"`, `"DEADBEEF"`} {
+		var bz HexBytes
+		assert.NoError(t, json.Unmarshal([]byte(input), &bz))
+		out, err := json.Marshal(bz)
+		assert.NoError(t, err)
+		assert.Equal(t, `"DEADBEEF"`, string(out))
+
+		var bz2 HexBytes
+		assert.NoError(t, json.Unmarshal(out, &bz2))
+		assert.Equal(t, bz, bz2)
+	}
+
+	type TestStruct struct {
+		B HexBytes
+	}
+	ts := TestStruct{B: HexBytes{0x01}}
+	assert.NoError(t, json.Unmarshal([]byte(`{"B":null}`), &ts))
+	assert.Nil(t, ts.B)
+}
+
+func TestHexBytes_ShortString(t *testing.T) {
+	hash := make(HexBytes, 32)
+	for i := range hash {
+		hash[i] = byte(i + 0xa0)
+	}
+	testCases := []struct {
+		name  string
+		input HexBytes
+		n     int
+		want  string
+	}{
+		{"nil", nil, 3, ""},
+		{"empty", HexBytes{}, 3, ""},
+		{"1 byte", HexBytes{0xab}, 3, "AB"},
+		{"3 bytes", HexBytes{0xab, 0xcd, 0xef}, 3, "ABCDEF"},
+		{"4 bytes", HexBytes{0xab, 0xcd, 0xef, 0x01}, 3, "ABCDEF…"},
+		{"32 bytes", hash, 3, "A0A1A2…"},
+		{"nil, 6 bytes prefix", nil, 6, ""},
+		{"1 byte, 6 bytes prefix", HexBytes{0xab}, 6, "AB"},
+		{"3 bytes, 6 bytes prefix", HexBytes{0xab, 0xcd, 0xef}, 6, "ABCDEF"},
+		{"32 bytes, 6 bytes prefix", hash, 6, "A0A1A2A3A4A5…"},
+		{"32 bytes, 32 bytes prefix", hash, 32, hash.String()},
+		{"zero prefix", hash, 0, "…"},
+		{"negative prefix", hash, -1, "…"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.input.ShortStringN(tc.n))
+			if tc.n == shortStringLen {
+				assert.Equal(t, tc.want, tc.input.ShortString())
+			}
+		})
+	}
+}
+
+func TestHexBytes_MarshalText(t *testing.T) {
+	testCases := []struct {
+		input HexBytes
+		want  string
+	}{
+		{input: nil, want: ""},
+		{input: HexBytes{}, want: ""},
+		{input: HexBytes{0x0a, 0xbc}, want: "0ABC"},
+		{input: HexBytes{0xde, 0xad, 0xbe, 0xef}, want: "DEADBEEF"},
+	}
+	for _, tc := range testCases {
+		text, err := tc.input.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, string(text))
+
+		jsonBytes, err := tc.input.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.Quote(tc.want), string(jsonBytes))
+	}
+}
+
+// hexKey is a comparable map key encoded through the text interfaces of HexBytes,
+// HexBytes itself can't be a map key as it is a slice.
+type hexKey [4]byte
+
+func (k hexKey) MarshalText() ([]byte, error) {
+	return HexBytes(k[:]).MarshalText()
+}
+
+func (k *hexKey) UnmarshalText(text []byte) error {
+	var bz HexBytes
+	if err := bz.UnmarshalText(text); err != nil {
+		return err
+	}
+	if len(bz) != len(k) {
+		return fmt.Errorf("invalid key length %d", len(bz))
+	}
+	copy(k[:], bz)
+	return nil
+}
+
+func TestHexBytes_MapKeyJSON(t *testing.T) {
+	m := map[hexKey]HexBytes{
+		{0xde, 0xad, 0xbe, 0xef}: {0x01, 0x02},
+		{0x00, 0x00, 0x00, 0x01}: nil,
+	}
+	jsonBytes, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"00000001":"","DEADBEEF":"0102"}`, string(jsonBytes))
+
+	m2 := map[hexKey]HexBytes{}
+	require.NoError(t, json.Unmarshal(jsonBytes, &m2))
+	assert.Equal(t, HexBytes{0x01, 0x02}, m2[hexKey{0xde, 0xad, 0xbe, 0xef}])
+	assert.Equal(t, HexBytes{}, m2[hexKey{0x00, 0x00, 0x00, 0x01}])
+
+	// lower-case and 0x-prefixed keys are accepted as well
+	m3 := map[hexKey]HexBytes{}
+	require.NoError(t, json.Unmarshal([]byte(`{"0xdeadbeef":"0x0102"}`), &m3))
+	assert.Equal(t, HexBytes{0x01, 0x02}, m3[hexKey{0xde, 0xad, 0xbe, 0xef}])
+}
+
+func TestHexBytes_YAML(t *testing.T) {
+	type TestStruct struct {
+		Hash   HexBytes            `yaml:"hash"`
+		Digits HexBytes            `yaml:"digits"`
+		Empty  HexBytes            `yaml:"empty"`
+		Hashes map[string]HexBytes `yaml:"hashes"`
+		Keys   map[hexKey]string   `yaml:"keys"`
+	}
+	ts := TestStruct{
+		Hash:   HexBytes{0xde, 0xad, 0xbe, 0xef},
+		Digits: HexBytes{0x12, 0x34}, // hex digits only, which YAML would resolve to a number
+		Empty:  HexBytes{},
+		Hashes: map[string]HexBytes{"a": {0xab}},
+		Keys:   map[hexKey]string{{0x01, 0x02, 0x03, 0x04}: "v"},
+	}
+	out, err := yaml.Marshal(ts)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "hash: DEADBEEF\n")
+	assert.Contains(t, string(out), `digits: "1234"`)
+	assert.Contains(t, string(out), `"01020304": v`)
+
+	var ts2 TestStruct
+	require.NoError(t, yaml.Unmarshal(out, &ts2))
+	assert.Equal(t, ts, ts2)
+
+	// unquoted numbers and 0x-prefixed values are decoded as hex digits
+	var ts3 TestStruct
+	require.NoError(t, yaml.Unmarshal([]byte("hash: 0xdeadbeef\ndigits: 1234\n"), &ts3))
+	assert.Equal(t, HexBytes{0xde, 0xad, 0xbe, 0xef}, ts3.Hash)
+	assert.Equal(t, HexBytes{0x12, 0x34}, ts3.Digits)
+
+	err = yaml.Unmarshal([]byte("hash: XYZ\n"), &ts3)
+	assert.Error(t, err)
+}
+
+func TestHexBytes_Equal(t *testing.T) {
+	testCases := []struct {
+		name  string
+		a, b  HexBytes
+		equal bool
+		cmp   int
+	}{
+		{"both nil", nil, nil, true, 0},
+		{"nil and empty", nil, HexBytes{}, true, 0},
+		{"same", HexBytes{0x01, 0x02}, HexBytes{0x01, 0x02}, true, 0},
+		{"different", HexBytes{0x01, 0x02}, HexBytes{0x01, 0x03}, false, -1},
+		{"shorter", HexBytes{0x01}, HexBytes{0x01, 0x00}, false, -1},
+		{"longer", HexBytes{0x01, 0x00}, HexBytes{0x01}, false, 1},
+		{"nil and non-empty", nil, HexBytes{0x00}, false, -1},
+		{"greater", HexBytes{0x02}, HexBytes{0x01, 0xff}, false, 1},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.equal, tc.a.Equal(tc.b))
+			assert.Equal(t, tc.equal, tc.b.Equal(tc.a))
+			assert.Equal(t, tc.equal, tc.a.ConstantTimeEqual(tc.b))
+			assert.Equal(t, tc.equal, tc.b.ConstantTimeEqual(tc.a))
+			assert.Equal(t, tc.cmp, tc.a.Compare(tc.b))
+			assert.Equal(t, -tc.cmp, tc.b.Compare(tc.a))
+		})
+	}
+}
+
+func TestHexBytes_Copy(t *testing.T) {
+	assert.Nil(t, HexBytes(nil).Copy())
+	assert.Equal(t, HexBytes{}, HexBytes{}.Copy())
+
+	buf := HexBytes{0x01, 0x02, 0x03, 0x04}
+	part := buf[1:3]
+	c := part.Copy()
+	assert.Equal(t, HexBytes{0x02, 0x03}, c)
+	assert.Equal(t, len(c), cap(c))
+
+	// changes of the shared buffer don't affect the copy and vice versa
+	buf[1] = 0xff
+	c[1] = 0xee
+	assert.Equal(t, HexBytes{0x02, 0xee}, c)
+	assert.Equal(t, HexBytes{0x01, 0xff, 0x03, 0x04}, buf)
+
+	// appending to the copy doesn't overwrite the buffer beyond the slice
+	_ = append(c, 0xaa)
+	_ = append(part.Copy(), 0xaa)
+	assert.Equal(t, HexBytes{0x01, 0xff, 0x03, 0x04}, buf)
+}
+
+func TestHexBytes_Format(t *testing.T) {
+	hash := HexBytes{0xde, 0xad, 0xbe, 0xef}
+	type TestStruct struct {
+		Hash HexBytes `json:"hash"`
+	}
+	testCases := []struct {
+		format string
+		arg    interface{}
+		want   string
+	}{
+		{"%s", hash, "DEADBEEF"},
+		{"%v", hash, "DEADBEEF"},
+		{"%q", hash, `"DEADBEEF"`},
+		{"%#q", hash, "`DEADBEEF`"},
+		{"%x", hash, "deadbeef"},
+		{"%X", hash, "DEADBEEF"},
+		{"%#x", hash, "0xdeadbeef"},
+		{"%#X", hash, "0XDEADBEEF"},
+		{"% x", hash, "de ad be ef"},
+		{"% #X", hash, "0XDE 0XAD 0XBE 0XEF"},
+		{"%#v", hash, "bytes.HexBytes{0xde, 0xad, 0xbe, 0xef}"},
+		{"%#v", HexBytes(nil), "bytes.HexBytes(nil)"},
+		{"%#v", HexBytes{}, "bytes.HexBytes{}"},
+		{"%d", hash, "%!d(bytes.HexBytes=DEADBEEF)"},
+		// precision truncates to that many bytes, width pads
+		{"%.2s", hash, "DEAD…"},
+		{"%.2x", hash, "dead…"},
+		{"%.2q", hash, `"DEAD…"`},
+		{"%.4s", hash, "DEADBEEF"},
+		{"%.0s", hash, "…"},
+		{"%10s", hash, "  DEADBEEF"},
+		{"%-10s|", hash, "DEADBEEF  |"},
+		{"%7.2s", hash, "  DEAD…"},
+		{"%3s", hash, "DEADBEEF"},
+		{"%s", HexBytes(nil), ""},
+		{"%q", HexBytes{}, `""`},
+		{"%#x", HexBytes{}, ""},
+		// the structs print like their JSON form
+		{"%v", TestStruct{hash}, "{DEADBEEF}"},
+		{"%+v", TestStruct{hash}, "{Hash:DEADBEEF}"},
+		{"%#v", TestStruct{hash}, "bytes.TestStruct{Hash:bytes.HexBytes{0xde, 0xad, 0xbe, 0xef}}"},
+		{"%v", []HexBytes{hash, {0x01}}, "[DEADBEEF 01]"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.format, func(t *testing.T) {
+			assert.Equal(t, tc.want, fmt.Sprintf(tc.format, tc.arg))
+		})
+	}
+
+	jsonBytes, err := json.Marshal(hash)
+	require.NoError(t, err)
+	assert.Equal(t, string(jsonBytes), fmt.Sprintf("%q", hash))
+	assert.Equal(t, fmt.Sprintf("%p", []byte(hash)), fmt.Sprintf("%p", hash))
+}
+
+// Test that no combination of the verbs, the flags, the width and the
+// precision panics.
+func TestHexBytes_FormatNoPanic(t *testing.T) {
+	values := []HexBytes{nil, {}, {0x00}, {0xde, 0xad, 0xbe, 0xef}}
+	verbs := "svqxXpdbo%"
+	flags := []string{"", "#", "-", "+", " ", "0", "# -", "#0", "+# "}
+	sizes := []string{"", "0", "1", "3", "20", ".", ".0", ".1", ".3", ".20", "5.2", "*", ".*"}
+	for _, bz := range values {
+		for _, verb := range verbs {
+			for _, flag := range flags {
+				for _, size := range sizes {
+					format := "%" + flag + size + string(verb)
+					assert.NotPanics(t, func() {
+						_ = fmt.Sprintf(format, 4, bz)
+						_ = fmt.Sprintf(format, -4, bz)
+					}, format)
+				}
+			}
+		}
+	}
+}
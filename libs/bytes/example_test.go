@@ -0,0 +1,21 @@
+package bytes_test
+
+import (
+	"fmt"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+func ExampleHexBytes_Format() {
+	hash := tmbytes.HexBytes{0xde, 0xad, 0xbe, 0xef}
+	fmt.Printf("%s %v %q\n", hash, hash, hash)
+	fmt.Printf("%x %X %#x % X\n", hash, hash, hash, hash)
+	fmt.Printf("%#v\n", hash)
+	// truncated to 2 bytes and padded to 8 characters, e.g. for log lines
+	fmt.Printf("[%8.2s] [%-8.2s]\n", hash, hash)
+	// Output:
+	// DEADBEEF DEADBEEF "DEADBEEF"
+	// deadbeef DEADBEEF 0xdeadbeef DE AD BE EF
+	// bytes.HexBytes{0xde, 0xad, 0xbe, 0xef}
+	// [   DEAD…] [DEAD…   ]
+}
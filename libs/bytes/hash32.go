@@ -0,0 +1,125 @@
+package bytes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Hash32Size is the size of a Hash32 in bytes, the size of proTxHashes and quorum hashes.
+const Hash32Size = 32
+
+// ErrHash32Size is returned when a value of the wrong size is converted into a Hash32.
+var ErrHash32Size = errors.New("invalid hash size")
+
+// Hash32 is a 32 bytes hash, like a proTxHash or a quorum hash. Unlike HexBytes it can't be
+// truncated, and it is encoded into JSON and text the same way as HexBytes.
+type Hash32 [Hash32Size]byte
+
+// Hash32FromHexBytes converts bz into a Hash32, bz must be exactly Hash32Size bytes long.
+func Hash32FromHexBytes(bz HexBytes) (Hash32, error) {
+	var h Hash32
+	if err := ValidateHash32(bz); err != nil {
+		return h, err
+	}
+	copy(h[:], bz)
+	return h, nil
+}
+
+// MustHash32FromHexBytes is Hash32FromHexBytes, which panics on a wrong size.
+func MustHash32FromHexBytes(bz HexBytes) Hash32 {
+	h, err := Hash32FromHexBytes(bz)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// ValidateHash32 returns ErrHash32Size if bz can't be converted into a Hash32.
+func ValidateHash32(bz []byte) error {
+	if len(bz) != Hash32Size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrHash32Size, Hash32Size, len(bz))
+	}
+	return nil
+}
+
+// HexBytes returns a copy of the hash as HexBytes.
+func (h Hash32) HexBytes() HexBytes {
+	return h.Bytes()
+}
+
+// Bytes returns a copy of the hash as a byte slice.
+func (h Hash32) Bytes() []byte {
+	bz := make([]byte, Hash32Size)
+	copy(bz, h[:])
+	return bz
+}
+
+// IsZero returns true if all bytes of the hash are zero, which is the case for
+// an unset hash.
+func (h Hash32) IsZero() bool {
+	return h == Hash32{}
+}
+
+// Equal returns true if h and other are the same hash.
+func (h Hash32) Equal(other Hash32) bool {
+	return h == other
+}
+
+// Validate returns an error if the hash is not set.
+func (h Hash32) Validate() error {
+	if h.IsZero() {
+		return errors.New("hash is zero")
+	}
+	return nil
+}
+
+func (h Hash32) String() string {
+	return HexBytes(h[:]).String()
+}
+
+// ShortString returns the upper-case hex of the first bytes of the hash, see HexBytes.ShortString.
+func (h Hash32) ShortString() string {
+	return HexBytes(h[:]).ShortString()
+}
+
+// MarshalJSON encodes the hash as a string of upper-case hex digits, like HexBytes does.
+func (h Hash32) MarshalJSON() ([]byte, error) {
+	return HexBytes(h[:]).MarshalJSON()
+}
+
+// UnmarshalJSON decodes a string of hex digits like HexBytes does, and rejects values
+// of the wrong size. null leaves the hash unchanged.
+func (h *Hash32) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var bz HexBytes
+	if err := json.Unmarshal(data, &bz); err != nil {
+		return err
+	}
+	return h.fromHexBytes(bz)
+}
+
+// MarshalText encodes the hash as upper-case hex digits, like HexBytes does.
+func (h Hash32) MarshalText() ([]byte, error) {
+	return HexBytes(h[:]).MarshalText()
+}
+
+// UnmarshalText decodes hex digits like HexBytes does, and rejects values of the wrong size.
+func (h *Hash32) UnmarshalText(text []byte) error {
+	var bz HexBytes
+	if err := bz.UnmarshalText(text); err != nil {
+		return err
+	}
+	return h.fromHexBytes(bz)
+}
+
+func (h *Hash32) fromHexBytes(bz HexBytes) error {
+	h2, err := Hash32FromHexBytes(bz)
+	if err != nil {
+		return err
+	}
+	*h = h2
+	return nil
+}
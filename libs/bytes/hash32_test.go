@@ -0,0 +1,136 @@
+package bytes
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func testHash32() Hash32 {
+	var h Hash32
+	for i := range h {
+		h[i] = byte(i + 0xa0)
+	}
+	return h
+}
+
+func TestHash32FromHexBytes(t *testing.T) {
+	h := testHash32()
+	h2, err := Hash32FromHexBytes(h.HexBytes())
+	require.NoError(t, err)
+	assert.Equal(t, h, h2)
+
+	for _, size := range []int{0, 1, Hash32Size - 1, Hash32Size + 1} {
+		_, err := Hash32FromHexBytes(make(HexBytes, size))
+		assert.True(t, errors.Is(err, ErrHash32Size), "size %d", size)
+	}
+	_, err = Hash32FromHexBytes(nil)
+	assert.True(t, errors.Is(err, ErrHash32Size))
+
+	assert.Panics(t, func() { MustHash32FromHexBytes(HexBytes{0x01}) })
+	assert.Equal(t, h, MustHash32FromHexBytes(h.HexBytes()))
+}
+
+func TestHash32_Bytes(t *testing.T) {
+	h := testHash32()
+	bz := h.HexBytes()
+	assert.Equal(t, h.String(), bz.String())
+	assert.Equal(t, []byte(bz), h.Bytes())
+
+	// the returned slices don't alias the hash
+	bz[0] = 0x00
+	assert.Equal(t, byte(0xa0), h[0])
+}
+
+func TestHash32_IsZero(t *testing.T) {
+	var h Hash32
+	assert.True(t, h.IsZero())
+	assert.Error(t, h.Validate())
+
+	h[Hash32Size-1] = 0x01
+	assert.False(t, h.IsZero())
+	assert.NoError(t, h.Validate())
+	assert.True(t, h.Equal(MustHash32FromHexBytes(h.HexBytes())))
+	assert.False(t, h.Equal(Hash32{}))
+}
+
+func TestHash32_JSON(t *testing.T) {
+	type TestStruct struct {
+		Hash  Hash32   `json:"hash"`
+		Bytes HexBytes `json:"bytes"`
+	}
+	h := testHash32()
+	ts := TestStruct{Hash: h, Bytes: h.HexBytes()}
+	jsonBytes, err := json.Marshal(ts)
+	require.NoError(t, err)
+	hexStr := strings.ToUpper("a0a1a2a3a4a5a6a7a8a9aaabacadaeafb0b1b2b3b4b5b6b7b8b9babbbcbdbebf")
+	assert.Equal(t, `{"hash":"`+hexStr+`","bytes":"`+hexStr+`"}`, string(jsonBytes))
+
+	var ts2 TestStruct
+	require.NoError(t, json.Unmarshal(jsonBytes, &ts2))
+	assert.Equal(t, ts, ts2)
+
+	// lower-case and 0x-prefixed hashes are accepted like by HexBytes
+	var h2 Hash32
+	require.NoError(t, json.Unmarshal([]byte(`"0x`+strings.ToLower(hexStr)+`"`), &h2))
+	assert.Equal(t, h, h2)
+
+	// null leaves the hash unchanged
+	require.NoError(t, json.Unmarshal([]byte(`null`), &h2))
+	assert.Equal(t, h, h2)
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"empty", `""`},
+		{"truncated", `"` + hexStr[:62] + `"`},
+		{"too long", `"` + hexStr + `00"`},
+		{"invalid hex", `"` + hexStr[:63] + `X"`},
+		{"not a string", `12`},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			h3 := h
+			assert.Error(t, json.Unmarshal([]byte(tc.input), &h3))
+			assert.Equal(t, h, h3)
+		})
+	}
+	var h3 Hash32
+	err = json.Unmarshal([]byte(`"`+hexStr[:62]+`"`), &h3)
+	assert.True(t, errors.Is(err, ErrHash32Size))
+}
+
+func TestHash32_Text(t *testing.T) {
+	type TestStruct struct {
+		Hash   Hash32            `yaml:"hash"`
+		Hashes map[Hash32]string `yaml:"hashes"`
+	}
+	h := testHash32()
+	ts := TestStruct{Hash: h, Hashes: map[Hash32]string{h: "a"}}
+	out, err := yaml.Marshal(ts)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "hash: "+h.String()+"\n")
+
+	var ts2 TestStruct
+	require.NoError(t, yaml.Unmarshal(out, &ts2))
+	assert.Equal(t, ts, ts2)
+
+	// map keys are encoded through the text interfaces
+	jsonBytes, err := json.Marshal(map[Hash32]int{h: 1})
+	require.NoError(t, err)
+	assert.Equal(t, `{"`+h.String()+`":1}`, string(jsonBytes))
+	m := map[Hash32]int{}
+	require.NoError(t, json.Unmarshal(jsonBytes, &m))
+	assert.Equal(t, 1, m[h])
+
+	var ts3 TestStruct
+	err = yaml.Unmarshal([]byte("hash: ABCD\n"), &ts3)
+	assert.Error(t, err)
+}
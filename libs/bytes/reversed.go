@@ -0,0 +1,73 @@
+package bytes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ReversedHexBytes stores bytes in their natural order, like uint256 does in Dash Core,
+// and encodes them into JSON and text as hex in the reversed order, the way Dash Core
+// displays block, transaction and quorum hashes in RPC. It decodes any hex accepted by
+// HexBytes.
+type ReversedHexBytes []byte
+
+// ToCoreHex returns the lower-case hex of bz in the reversed byte order, like Dash Core
+// displays a uint256 holding bz.
+func (bz HexBytes) ToCoreHex() string {
+	return hex.EncodeToString(bz.ReversedBytes())
+}
+
+// FromCoreHex decodes a hash displayed by Dash Core into its natural byte order,
+// the reverse of HexBytes.ToCoreHex.
+func FromCoreHex(s string) (HexBytes, error) {
+	var bz HexBytes
+	if err := bz.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	return bz.ReversedBytes(), nil
+}
+
+// HexBytes returns bz as HexBytes, in the natural byte order.
+func (bz ReversedHexBytes) HexBytes() HexBytes {
+	return HexBytes(bz)
+}
+
+// String returns the hex of bz as displayed by Dash Core.
+func (bz ReversedHexBytes) String() string {
+	return HexBytes(bz).ToCoreHex()
+}
+
+// MarshalJSON encodes bz as a string of hex as displayed by Dash Core.
+func (bz ReversedHexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bz.String())
+}
+
+// UnmarshalJSON decodes a string of hex as displayed by Dash Core,
+// null decodes to a nil slice.
+func (bz *ReversedHexBytes) UnmarshalJSON(data []byte) error {
+	var hbz HexBytes
+	if err := hbz.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if hbz == nil {
+		*bz = nil
+		return nil
+	}
+	*bz = ReversedHexBytes(hbz.ReversedBytes())
+	return nil
+}
+
+// MarshalText encodes bz as hex as displayed by Dash Core.
+func (bz ReversedHexBytes) MarshalText() ([]byte, error) {
+	return []byte(bz.String()), nil
+}
+
+// UnmarshalText decodes hex as displayed by Dash Core.
+func (bz *ReversedHexBytes) UnmarshalText(text []byte) error {
+	hbz, err := FromCoreHex(string(text))
+	if err != nil {
+		return err
+	}
+	*bz = ReversedHexBytes(hbz)
+	return nil
+}
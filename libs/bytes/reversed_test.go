@@ -0,0 +1,102 @@
+package bytes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// the hash of the Dash mainnet genesis block as displayed by Dash Core
+	genesisCoreHex = "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6"
+	// the same hash in its natural byte order, as it is serialized in block headers
+	genesisHex = "b67a40f3cd5804437a108f105533739c37e6229bc1adcab385140b59fd0f0000"
+)
+
+func TestHexBytes_CoreHex(t *testing.T) {
+	genesis, err := hex.DecodeString(genesisHex)
+	require.NoError(t, err)
+
+	assert.Equal(t, genesisCoreHex, HexBytes(genesis).ToCoreHex())
+
+	bz, err := FromCoreHex(genesisCoreHex)
+	require.NoError(t, err)
+	assert.Equal(t, HexBytes(genesis), bz)
+
+	// upper-case and 0x-prefixed hashes are accepted
+	bz, err = FromCoreHex("0x00000FFD590B1485B3CAADC19B22E6379C733355108F107A430458CDF3407AB6")
+	require.NoError(t, err)
+	assert.Equal(t, HexBytes(genesis), bz)
+
+	bz, err = FromCoreHex("")
+	require.NoError(t, err)
+	assert.Empty(t, bz)
+	assert.Equal(t, "", HexBytes(nil).ToCoreHex())
+
+	_, err = FromCoreHex("0ff")
+	assert.Error(t, err)
+}
+
+// coreQuorumSignFixture is a result of quorum sign RPC of Dash Core
+const coreQuorumSignFixture = `{
+  "llmqType": 100,
+  "quorumHash": "53d959f609a654cf4e5e3ba083a6b4fc2fd0bbee0b1b1c7e5b4d7d95c64ce6b5",
+  "id": "0000000000000000000000000000000000000000000000000000000000000001",
+  "msgHash": "8b2e6d9a3d1a4c7f04c3b1a5b5e4a1f9e0d1c2b3a4958677685f4e3d2c1b0a99",
+  "signHash": "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6"
+}`
+
+func TestReversedHexBytes_JSON(t *testing.T) {
+	type quorumSignResult struct {
+		LLMQType   int              `json:"llmqType"`
+		QuorumHash ReversedHexBytes `json:"quorumHash"`
+		ID         ReversedHexBytes `json:"id"`
+		MsgHash    ReversedHexBytes `json:"msgHash"`
+		SignHash   ReversedHexBytes `json:"signHash"`
+	}
+	res := quorumSignResult{}
+	require.NoError(t, json.Unmarshal([]byte(coreQuorumSignFixture), &res))
+
+	// the id is 1 as a uint256, so the least significant byte comes first
+	id := make([]byte, 32)
+	id[0] = 0x01
+	assert.Equal(t, ReversedHexBytes(id), res.ID)
+
+	genesis, err := hex.DecodeString(genesisHex)
+	require.NoError(t, err)
+	assert.Equal(t, ReversedHexBytes(genesis), res.SignHash)
+	assert.Equal(t, HexBytes(genesis), res.SignHash.HexBytes())
+	assert.Equal(t, genesisCoreHex, res.SignHash.String())
+
+	// encoding gives the same JSON as Dash Core
+	out, err := json.MarshalIndent(res, "", "  ")
+	require.NoError(t, err)
+	assert.Equal(t, coreQuorumSignFixture, string(out))
+
+	// nil and null
+	out, err = json.Marshal(ReversedHexBytes(nil))
+	require.NoError(t, err)
+	assert.Equal(t, `""`, string(out))
+	res.ID = ReversedHexBytes{0x01}
+	require.NoError(t, json.Unmarshal([]byte(`{"id":null}`), &res))
+	assert.Nil(t, res.ID)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id":"0ff"}`), &res))
+	assert.Error(t, json.Unmarshal([]byte(`{"id":1}`), &res))
+}
+
+func TestReversedHexBytes_Text(t *testing.T) {
+	m := map[string]ReversedHexBytes{}
+	require.NoError(t, json.Unmarshal([]byte(`{"a":"0102"}`), &m))
+	assert.Equal(t, ReversedHexBytes{0x02, 0x01}, m["a"])
+
+	var bz ReversedHexBytes
+	require.NoError(t, bz.UnmarshalText([]byte(genesisCoreHex)))
+	text, err := bz.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, genesisCoreHex, string(text))
+	assert.Equal(t, genesisHex, hex.EncodeToString(bz))
+}
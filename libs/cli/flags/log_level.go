@@ -3,6 +3,7 @@ package flags
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -89,3 +90,42 @@ func ParseLogLevel(lvl string, logger log.Logger, defaultLogLevelValue string) (
 
 	return log.NewFilter(logger, options...), nil
 }
+
+// ParseLogLevels is the same as ParseLogLevel, but the levels of the modules
+// in the levels map override the ones given in lvl. The map may also include
+// the * key for all other modules.
+//
+// Example:
+//		ParseLogLevels("info", map[string]string{"consensus": "debug"}, log.NewTMLogger(os.Stdout), "info")
+func ParseLogLevels(
+	lvl string,
+	levels map[string]string,
+	logger log.Logger,
+	defaultLogLevelValue string,
+) (log.Logger, error) {
+	if lvl == "" {
+		return nil, errors.New("empty log level")
+	}
+
+	// prefix simple one word levels (e.g. "info") with "*"
+	if !strings.Contains(lvl, ":") {
+		lvl = defaultLogLevelKey + ":" + lvl
+	}
+
+	modules := make([]string, 0, len(levels))
+	for module := range levels {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	// the later pairs override the earlier ones
+	list := []string{lvl}
+	for _, module := range modules {
+		if module == "" || strings.ContainsAny(module, ":,") {
+			return nil, fmt.Errorf("invalid module name %q", module)
+		}
+		list = append(list, module+":"+levels[module])
+	}
+
+	return ParseLogLevel(strings.Join(list, ","), logger, defaultLogLevelValue)
+}
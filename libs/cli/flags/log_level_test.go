@@ -92,3 +92,32 @@ func TestParseLogLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	jsonLogger := log.NewTMJSONLoggerNoTS(&buf)
+
+	logger, err := tmflags.ParseLogLevels("mempool:error,state:error",
+		map[string]string{"mempool": "debug", "wire": "none", "*": "debug"}, jsonLogger, defaultLogLevelValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.With("module", "mempool").Debug("Kingpin")
+	logger.With("module", "wire").Error("Kitty Pryde")
+	logger.With("module", "state").Info("Mind")
+	logger.Debug("Gideon")
+	want := `{"_msg":"Kingpin","level":"debug","module":"mempool"}` + "\n" +
+		`{"_msg":"Gideon","level":"debug"}`
+	if have := strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	// invalid levels and module names
+	incorrectLogLevels := []map[string]string{{"mempool": "some"}, {"": "info"}, {"mempool:state": "info"}}
+	for _, levels := range incorrectLogLevels {
+		if _, err := tmflags.ParseLogLevels("info", levels, jsonLogger, defaultLogLevelValue); err == nil {
+			t.Fatalf("Expected %v to produce error", levels)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package db provides the database backends of the node, which aren't
+// provided by tm-db, on top of the ones of tm-db.
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// PebbleDBBackend represents pebble (uses github.com/cockroachdb/pebble)
+//   - pure go
+//   - stable
+const PebbleDBBackend dbm.BackendType = "pebble"
+
+var (
+	errKeyEmpty    = errors.New("key cannot be empty")
+	errValueNil    = errors.New("value cannot be nil")
+	errBatchClosed = errors.New("batch has been written or closed")
+)
+
+// NewDB creates a new database of the given backend type, with the given name,
+// in the given directory. It supports the backends of tm-db, and pebble.
+func NewDB(name string, backend dbm.BackendType, dir string) (dbm.DB, error) {
+	if backend != PebbleDBBackend {
+		return dbm.NewDB(name, backend, dir)
+	}
+
+	db, err := NewPebbleDB(name, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	return db, nil
+}
+
+// NewReadOnlyDB opens the existing database of the given backend type, with
+// the given name, in the given directory, for reading only: the writes fail
+// and nothing is written to the disk. Opening fails if the database is locked,
+// e.g. by a running node. Only the goleveldb and pebble backends are supported.
+func NewReadOnlyDB(name string, backend dbm.BackendType, dir string) (dbm.DB, error) {
+	var (
+		db  dbm.DB
+		err error
+	)
+	switch backend {
+	case dbm.GoLevelDBBackend:
+		db, err = dbm.NewGoLevelDBWithOpts(name, dir, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	case PebbleDBBackend:
+		db, err = NewPebbleDBWithOpts(name, dir, &pebble.Options{ReadOnly: true, ErrorIfNotExists: true})
+	default:
+		return nil, fmt.Errorf("backend %s can't be opened read-only, only %s and %s can",
+			backend, dbm.GoLevelDBBackend, PebbleDBBackend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database read-only: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// PebbleDB is a database backed by pebble.
+type PebbleDB struct {
+	db *pebble.DB
+}
+
+var _ dbm.DB = (*PebbleDB)(nil)
+
+// NewPebbleDB opens or creates the pebble database with the given name, in
+// the given directory.
+func NewPebbleDB(name string, dir string) (*PebbleDB, error) {
+	return NewPebbleDBWithOpts(name, dir, &pebble.Options{})
+}
+
+// NewPebbleDBWithOpts opens or creates the pebble database with the given
+// name, in the given directory, using the given options.
+func NewPebbleDBWithOpts(name string, dir string, opts *pebble.Options) (*PebbleDB, error) {
+	dbPath := filepath.Join(dir, name+".db")
+	db, err := pebble.Open(dbPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PebbleDB{db: db}, nil
+}
+
+// Get implements DB.
+func (db *PebbleDB) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errKeyEmpty
+	}
+	res, closer, err := db.db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer closer.Close()
+	// the value is only valid until the closer is closed
+	return cp(res), nil
+}
+
+// Has implements DB.
+func (db *PebbleDB) Has(key []byte) (bool, error) {
+	bytes, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return bytes != nil, nil
+}
+
+// Set implements DB.
+func (db *PebbleDB) Set(key []byte, value []byte) error {
+	return db.set(key, value, pebble.NoSync)
+}
+
+// SetSync implements DB.
+func (db *PebbleDB) SetSync(key []byte, value []byte) error {
+	return db.set(key, value, pebble.Sync)
+}
+
+func (db *PebbleDB) set(key []byte, value []byte, opts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if value == nil {
+		return errValueNil
+	}
+	return db.db.Set(key, value, opts)
+}
+
+// Delete implements DB.
+func (db *PebbleDB) Delete(key []byte) error {
+	return db.delete(key, pebble.NoSync)
+}
+
+// DeleteSync implements DB.
+func (db *PebbleDB) DeleteSync(key []byte) error {
+	return db.delete(key, pebble.Sync)
+}
+
+func (db *PebbleDB) delete(key []byte, opts *pebble.WriteOptions) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	return db.db.Delete(key, opts)
+}
+
+// DB returns the underlying pebble database.
+func (db *PebbleDB) DB() *pebble.DB {
+	return db.db
+}
+
+// Close implements DB.
+func (db *PebbleDB) Close() error {
+	return db.db.Close()
+}
+
+// Print implements DB.
+func (db *PebbleDB) Print() error {
+	fmt.Printf("%v\n", db.db.Metrics())
+
+	itr := db.db.NewIter(nil)
+	defer itr.Close()
+	for itr.First(); itr.Valid(); itr.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", itr.Key(), itr.Value())
+	}
+	return itr.Error()
+}
+
+// Stats implements DB.
+func (db *PebbleDB) Stats() map[string]string {
+	return map[string]string{
+		"pebble.metrics": db.db.Metrics().String(),
+	}
+}
+
+// NewBatch implements DB.
+func (db *PebbleDB) NewBatch() dbm.Batch {
+	return newPebbleDBBatch(db)
+}
+
+// Iterator implements DB.
+func (db *PebbleDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return newPebbleDBIterator(itr, start, end, false), nil
+}
+
+// ReverseIterator implements DB.
+func (db *PebbleDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errKeyEmpty
+	}
+	itr := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	return newPebbleDBIterator(itr, start, end, true), nil
+}
+
+// Compact compacts the whole database.
+func (db *PebbleDB) Compact() error {
+	itr := db.db.NewIter(nil)
+	defer itr.Close()
+
+	if !itr.First() {
+		return itr.Error() // empty
+	}
+	first := cp(itr.Key())
+	itr.Last()
+	// the end of the range is exclusive
+	last := append(cp(itr.Key()), 0)
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return db.db.Compact(first, last)
+}
+
+func cp(bz []byte) []byte {
+	ret := make([]byte, len(bz))
+	copy(ret, bz)
+	return ret
+}
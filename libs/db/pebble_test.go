@@ -0,0 +1,195 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newTestPebbleDB(t *testing.T) *PebbleDB {
+	db, err := NewPebbleDB("test", t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return db
+}
+
+func TestNewDB(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := NewDB("pebble", PebbleDBBackend, dir)
+	require.NoError(t, err)
+	assert.IsType(t, &PebbleDB{}, db)
+	require.NoError(t, db.Close())
+
+	db, err = NewDB("goleveldb", dbm.GoLevelDBBackend, dir)
+	require.NoError(t, err)
+	assert.IsType(t, &dbm.GoLevelDB{}, db)
+	require.NoError(t, db.Close())
+
+	_, err = NewDB("unknown", dbm.BackendType("unknown"), dir)
+	require.Error(t, err)
+}
+
+func TestNewReadOnlyDB(t *testing.T) {
+	for _, backend := range []dbm.BackendType{dbm.GoLevelDBBackend, PebbleDBBackend} {
+		backend := backend
+		t.Run(string(backend), func(t *testing.T) {
+			dir := t.TempDir()
+
+			_, err := NewReadOnlyDB("test", backend, dir)
+			require.Error(t, err, "missing database")
+
+			db, err := NewDB("test", backend, dir)
+			require.NoError(t, err)
+			require.NoError(t, db.SetSync([]byte("a"), []byte{0x01}))
+			_, err = NewReadOnlyDB("test", backend, dir)
+			require.Error(t, err, "locked database")
+			require.NoError(t, db.Close())
+
+			db, err = NewReadOnlyDB("test", backend, dir)
+			require.NoError(t, err)
+			defer db.Close()
+			value, err := db.Get([]byte("a"))
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0x01}, value)
+			assert.Error(t, db.SetSync([]byte("b"), []byte{0x02}))
+		})
+	}
+
+	_, err := NewReadOnlyDB("test", dbm.MemDBBackend, t.TempDir())
+	require.Error(t, err)
+}
+
+func TestPebbleDBGetSetDelete(t *testing.T) {
+	db := newTestPebbleDB(t)
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, db.Set([]byte("a"), []byte{0x01}))
+	require.NoError(t, db.SetSync([]byte("b"), []byte{}))
+
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, value)
+	value, err = db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, value)
+	ok, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, db.Delete([]byte("a")))
+	require.NoError(t, db.DeleteSync([]byte("b")))
+	ok, err = db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = db.Has([]byte("b"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, errKeyEmpty, db.Set(nil, []byte{0x01}))
+	assert.Equal(t, errValueNil, db.Set([]byte("a"), nil))
+	assert.Equal(t, errKeyEmpty, db.Delete([]byte{}))
+	_, err = db.Get(nil)
+	assert.Equal(t, errKeyEmpty, err)
+}
+
+func TestPebbleDBIterator(t *testing.T) {
+	db := newTestPebbleDB(t)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, db.Set([]byte(key), []byte(key)))
+	}
+
+	testCases := []struct {
+		start, end []byte
+		reverse    bool
+		expected   []string
+	}{
+		{nil, nil, false, []string{"a", "b", "c", "d"}},
+		{nil, nil, true, []string{"d", "c", "b", "a"}},
+		{[]byte("b"), []byte("d"), false, []string{"b", "c"}},
+		{[]byte("b"), []byte("d"), true, []string{"c", "b"}},
+		{[]byte("bb"), nil, false, []string{"c", "d"}},
+		{nil, []byte("bb"), true, []string{"b", "a"}},
+		{[]byte("e"), nil, false, nil},
+	}
+	for _, tc := range testCases {
+		var (
+			itr dbm.Iterator
+			err error
+		)
+		if tc.reverse {
+			itr, err = db.ReverseIterator(tc.start, tc.end)
+		} else {
+			itr, err = db.Iterator(tc.start, tc.end)
+		}
+		require.NoError(t, err)
+
+		var keys []string
+		for ; itr.Valid(); itr.Next() {
+			assert.Equal(t, itr.Key(), itr.Value())
+			keys = append(keys, string(itr.Key()))
+		}
+		require.NoError(t, itr.Error())
+		require.NoError(t, itr.Close())
+		assert.Equal(t, tc.expected, keys, "[%s, %s) reverse=%v", tc.start, tc.end, tc.reverse)
+		assert.Panics(t, func() { itr.Key() })
+	}
+
+	_, err := db.Iterator([]byte{}, nil)
+	assert.Equal(t, errKeyEmpty, err)
+}
+
+func TestPebbleDBBatch(t *testing.T) {
+	db := newTestPebbleDB(t)
+	require.NoError(t, db.Set([]byte("a"), []byte{0x01}))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("b"), []byte{0x02}))
+	require.NoError(t, batch.Delete([]byte("a")))
+
+	// nothing is written until the batch is
+	ok, err := db.Has([]byte("b"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, batch.WriteSync())
+	ok, err = db.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	value, err := db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02}, value)
+
+	assert.Equal(t, errBatchClosed, batch.Set([]byte("c"), []byte{0x03}))
+	assert.Equal(t, errBatchClosed, batch.Write())
+	require.NoError(t, batch.Close())
+}
+
+func TestPebbleDBCompact(t *testing.T) {
+	db := newTestPebbleDB(t)
+	require.NoError(t, db.Compact())
+
+	for i := byte(1); i <= 100; i++ {
+		require.NoError(t, db.Set([]byte{i}, []byte{i}))
+	}
+	for i := byte(1); i <= 50; i++ {
+		require.NoError(t, db.Delete([]byte{i}))
+	}
+	require.NoError(t, db.Compact())
+
+	value, err := db.Get([]byte{100})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{100}, value)
+	ok, err := db.Has([]byte{1})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
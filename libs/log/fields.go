@@ -0,0 +1,43 @@
+package log
+
+import (
+	"sort"
+)
+
+// The names of the contextual fields, which are the same in all the packages,
+// so that the log lines can be filtered and correlated by them.
+const (
+	FieldModule = moduleKey
+	FieldHeight = "height"
+	FieldRound  = "round"
+	FieldPeerID = "peer_id"
+)
+
+// Fields are the contextual fields of a logger, keyed by their names.
+type Fields map[string]interface{}
+
+// WithFields returns a new contextual logger with the fields prepended, in
+// the order of their names, to the keyvals of every log line. The fields
+// with nil values, which aren't available, are skipped.
+//
+// Example:
+//		logger = log.WithFields(logger, log.Fields{log.FieldHeight: 5, log.FieldRound: 0})
+//		logger.Info("Hello") # produces "I... Hello height=5 round=0"
+func WithFields(logger Logger, fields Fields) Logger {
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		if value != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return logger
+	}
+	sort.Strings(names)
+
+	keyvals := make([]interface{}, 0, 2*len(names))
+	for _, name := range names {
+		keyvals = append(keyvals, name, fields[name])
+	}
+	return logger.With(keyvals...)
+}
@@ -0,0 +1,40 @@
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := log.WithFields(log.NewTMLogger(&buf), log.Fields{
+		log.FieldRound:  1,
+		log.FieldModule: "consensus",
+		log.FieldHeight: int64(5),
+		log.FieldPeerID: nil,
+	})
+	logger.Info("foo", "bar", "baz")
+
+	have := strings.TrimSpace(buf.String())
+	if want := ` module=consensus height=5 round=1 bar=baz`; !strings.HasSuffix(have, want) {
+		t.Errorf("\nwant '...%s'\nhave '%s'", want, have)
+	}
+}
+
+func TestJSONLoggerFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := log.NewTMJSONLoggerNoTS(&buf).With("peerID", "ab")
+	logger.Info("foo", "peerID", "cd")
+	logger.Info("bar")
+
+	want := `{"_msg":"foo","level":"info","peer_id":"cd"}` + "\n" +
+		`{"_msg":"bar","level":"info","peer_id":"ab"}` + "\n"
+	if have := buf.String(); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+}
@@ -0,0 +1,101 @@
+package log
+
+import (
+	"sync"
+)
+
+// ReloadableLogger is a logger, whose underlying logger can be replaced at
+// runtime, e.g. to change the log level or format without restarting the
+// process. The loggers derived from it with With share the underlying logger,
+// so replacing it with Reload on any of them applies to all of them.
+type ReloadableLogger struct {
+	root    *reloadableRoot
+	keyvals []interface{}
+
+	mtx        sync.Mutex
+	generation uint64 // generation of the root logger, which next derives from
+	next       Logger // the root logger with keyvals
+}
+
+// reloadableRoot is the underlying logger shared by the reloadable loggers.
+type reloadableRoot struct {
+	mtx        sync.RWMutex
+	logger     Logger
+	generation uint64 // incremented on every reload
+}
+
+var _ Logger = (*ReloadableLogger)(nil)
+
+// NewReloadableLogger returns a ReloadableLogger, which logs with the given
+// logger until it's replaced with Reload.
+func NewReloadableLogger(logger Logger) *ReloadableLogger {
+	return &ReloadableLogger{
+		root: &reloadableRoot{logger: logger},
+		next: logger,
+	}
+}
+
+// Reload replaces the underlying logger of the logger and of all the loggers
+// derived from it or from the same logger with With.
+func (l *ReloadableLogger) Reload(logger Logger) {
+	l.root.mtx.Lock()
+	defer l.root.mtx.Unlock()
+	l.root.logger = logger
+	l.root.generation++
+}
+
+// Debug implements Logger.
+func (l *ReloadableLogger) Debug(msg string, keyvals ...interface{}) {
+	l.current().Debug(msg, keyvals...)
+}
+
+// P2PDebug implements Logger.
+func (l *ReloadableLogger) P2PDebug(msg string, keyvals ...interface{}) {
+	l.current().P2PDebug(msg, keyvals...)
+}
+
+// Info implements Logger.
+func (l *ReloadableLogger) Info(msg string, keyvals ...interface{}) {
+	l.current().Info(msg, keyvals...)
+}
+
+// Error implements Logger.
+func (l *ReloadableLogger) Error(msg string, keyvals ...interface{}) {
+	l.current().Error(msg, keyvals...)
+}
+
+// With implements Logger. The returned logger is a ReloadableLogger, which
+// shares the underlying logger.
+func (l *ReloadableLogger) With(keyvals ...interface{}) Logger {
+	newKeyvals := make([]interface{}, 0, len(l.keyvals)+len(keyvals))
+	newKeyvals = append(newKeyvals, l.keyvals...)
+	newKeyvals = append(newKeyvals, keyvals...)
+
+	l.root.mtx.RLock()
+	defer l.root.mtx.RUnlock()
+	return &ReloadableLogger{
+		root:       l.root,
+		keyvals:    newKeyvals,
+		generation: l.root.generation,
+		next:       l.root.logger.With(newKeyvals...),
+	}
+}
+
+// current returns the underlying logger with the keyvals of the logger. It's
+// derived again only once the underlying logger was replaced, as the filters
+// decide on the allowed levels when the keyvals are added.
+func (l *ReloadableLogger) current() Logger {
+	l.root.mtx.RLock()
+	defer l.root.mtx.RUnlock()
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.generation != l.root.generation {
+		l.next = l.root.logger
+		if len(l.keyvals) > 0 {
+			l.next = l.root.logger.With(l.keyvals...)
+		}
+		l.generation = l.root.generation
+	}
+	return l.next
+}
@@ -0,0 +1,41 @@
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestReloadableLogger(t *testing.T) {
+	var buf bytes.Buffer
+	newLogger := func(options ...log.Option) log.Logger {
+		return log.NewFilter(log.NewTMJSONLoggerNoTS(&buf), options...)
+	}
+
+	root := log.NewReloadableLogger(newLogger(log.AllowInfo()))
+	logger := root.With("module", "consensus")
+	logger.Debug("hidden")
+	logger.Info("shown", "level was", "info")
+
+	// the derived loggers log with the new logger, keeping their keyvals
+	root.Reload(newLogger(log.AllowDebug()))
+	logger.Debug("shown", "level was", "debug")
+	root.With("module", "mempool").Debug("shown", "level was", "debug")
+
+	// the module filters apply to the derived loggers
+	root.Reload(newLogger(log.AllowError(), log.AllowDebugWith("module", "mempool")))
+	logger.Info("hidden")
+	logger.(*log.ReloadableLogger).With("module", "mempool").Debug("shown", "level was", "mempool:debug")
+
+	want := strings.Join([]string{
+		`{"_msg":"shown","level":"info","level was":"info","module":"consensus"}`,
+		`{"_msg":"shown","level":"debug","level was":"debug","module":"consensus"}`,
+		`{"_msg":"shown","level":"debug","level was":"debug","module":"mempool"}`,
+		`{"_msg":"shown","level":"debug","level was":"mempool:debug","module":"mempool"}`,
+	}, "\n")
+	if have := strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant:\n%s\nhave:\n%s", want, have)
+	}
+}
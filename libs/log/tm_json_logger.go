@@ -6,12 +6,18 @@ import (
 	kitlog "github.com/go-kit/kit/log"
 )
 
+// jsonFieldAliases are the legacy keys, which are logged in the JSON format
+// under the names of the contextual fields (see Fields) instead.
+var jsonFieldAliases = map[string]string{
+	"peerID": FieldPeerID,
+}
+
 // NewTMJSONLogger returns a Logger that encodes keyvals to the Writer as a
 // single JSON object. Each log event produces no more than one call to
 // w.Write. The passed Writer must be safe for concurrent use by multiple
 // goroutines if the returned Logger will be used concurrently.
 func NewTMJSONLogger(w io.Writer) Logger {
-	logger := kitlog.NewJSONLogger(w)
+	logger := kitlog.Logger(&jsonFieldsLogger{kitlog.NewJSONLogger(w)})
 	logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC)
 	return &tmLogger{logger}
 }
@@ -19,6 +25,30 @@ func NewTMJSONLogger(w io.Writer) Logger {
 // NewTMJSONLoggerNoTS is the same as NewTMJSONLogger, but without the
 // timestamp.
 func NewTMJSONLoggerNoTS(w io.Writer) Logger {
-	logger := kitlog.NewJSONLogger(w)
-	return &tmLogger{logger}
+	return &tmLogger{&jsonFieldsLogger{kitlog.NewJSONLogger(w)}}
+}
+
+// jsonFieldsLogger renames the legacy keys of the keyvals to the names of the
+// contextual fields, so that they're the same in all the JSON log lines.
+type jsonFieldsLogger struct {
+	next kitlog.Logger
+}
+
+func (l *jsonFieldsLogger) Log(keyvals ...interface{}) error {
+	copied := false
+	for i := 0; i < len(keyvals)-1; i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			continue
+		}
+		if name, ok := jsonFieldAliases[key]; ok {
+			// the keyvals may be shared with a contextual logger
+			if !copied {
+				keyvals = append([]interface{}(nil), keyvals...)
+				copied = true
+			}
+			keyvals[i] = name
+		}
+	}
+	return l.next.Log(keyvals...)
 }
@@ -1,18 +1,98 @@
 package net
 
 import (
+	"errors"
+	"fmt"
 	"net"
+	"os"
 	"strings"
+	"syscall"
 )
 
 // Connect dials the given address and returns a net.Conn. The protoAddr argument should be prefixed with the protocol,
 // eg. "tcp://127.0.0.1:8080" or "unix:///tmp/test.sock"
 func Connect(protoAddr string) (net.Conn, error) {
 	proto, address := ProtocolAndAddress(protoAddr)
+	if proto == "unix" {
+		return DialUnix(address)
+	}
 	conn, err := net.Dial(proto, address)
 	return conn, err
 }
 
+// Listen listens on the given address, like Connect dials it. The unix
+// sockets are created with ListenUnix and the mode.
+func Listen(protoAddr string, mode os.FileMode) (net.Listener, error) {
+	proto, address := ProtocolAndAddress(protoAddr)
+	if proto == "unix" {
+		return ListenUnix(address, mode)
+	}
+	return net.Listen(proto, address)
+}
+
+// DialUnix dials the unix socket at the path. The errors mention the path of
+// the socket.
+func DialUnix(path string) (net.Conn, error) {
+	conn, err := net.Dial("unix", path)
+	switch {
+	case err == nil:
+		return conn, nil
+	case errors.Is(err, os.ErrPermission):
+		return nil, fmt.Errorf("permission denied to connect to unix socket %s, check its mode and owner: %w",
+			path, err)
+	default:
+		return nil, fmt.Errorf("failed to connect to unix socket %s: %w", path, err)
+	}
+}
+
+// ListenUnix listens on the unix socket at the path. A stale socket file,
+// which is left by a process that didn't clean up, is removed first, but not
+// a socket somebody listens on. The mode of the socket file is set to the
+// given one, unless it's 0.
+func ListenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", path, err)
+	}
+	if mode != 0 {
+		if err := os.Chmod(path, mode); err != nil {
+			ln.Close()
+			return nil, fmt.Errorf("failed to set mode of unix socket %s: %w", path, err)
+		}
+	}
+	return ln, nil
+}
+
+// removeStaleSocket removes the socket file at the path, if nobody listens on
+// it.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to check unix socket %s: %w", path, err)
+	case info.Mode()&os.ModeSocket == 0:
+		return fmt.Errorf("failed to listen on unix socket %s: file exists and is not a socket", path)
+	}
+
+	conn, err := net.Dial("unix", path)
+	switch {
+	case err == nil:
+		conn.Close()
+		return fmt.Errorf("failed to listen on unix socket %s: address already in use", path)
+	case !errors.Is(err, syscall.ECONNREFUSED):
+		return fmt.Errorf("failed to check unix socket %s: %w", path, err)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale unix socket %s: %w", path, err)
+	}
+	return nil
+}
+
 // ProtocolAndAddress splits an address into the protocol and address components.
 // For instance, "tcp://127.0.0.1:8080" will be split into "tcp" and "127.0.0.1:8080".
 // If the address has no protocol prefix, the default is "tcp".
@@ -1,9 +1,15 @@
 package net
 
 import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProtocolAndAddress(t *testing.T) {
@@ -36,3 +42,94 @@ func TestProtocolAndAddress(t *testing.T) {
 		assert.Equal(t, addr, c.addr)
 	}
 }
+
+func TestListenUnixRemovesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stale.sock")
+
+	// a socket file left by a process, which didn't clean up
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	ln, err = ListenUnix(path, 0)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	conn, err := DialUnix(path)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestListenUnixSocketInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "used.sock")
+	ln, err := ListenUnix(path, 0)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	_, err = ListenUnix(path, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+
+	// the socket still works
+	conn, err := DialUnix(path)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestListenUnixNotSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, ioutil.WriteFile(path, []byte("data"), 0600))
+
+	_, err := ListenUnix(path, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+
+	// the file is kept
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
+
+func TestListenUnixMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mode.sock")
+	ln, err := ListenUnix(path, 0640)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	assert.NotZero(t, info.Mode()&os.ModeSocket)
+}
+
+func TestDialUnixPermissionDenied(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores the mode of the socket")
+	}
+
+	path := filepath.Join(t.TempDir(), "denied.sock")
+	ln, err := ListenUnix(path, 0)
+	require.NoError(t, err)
+	defer ln.Close()
+	require.NoError(t, os.Chmod(path, 0))
+
+	_, err = DialUnix(path)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, os.ErrPermission))
+	assert.Contains(t, err.Error(), path)
+	assert.Contains(t, err.Error(), "permission denied")
+
+	_, err = Connect("unix://" + path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+}
+
+func TestDialUnixNoSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+	_, err := DialUnix(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+}
@@ -160,17 +160,44 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, NewSubscription(outCap))
+}
+
+// SubscribeWithPolicy does the same as Subscribe, except the given policy is
+// applied once the client isn't pulling messages fast enough and capacity
+// messages are buffered. With SlowConsumerDropOldest and
+// SlowConsumerDropNewest, the messages are buffered by the subscription, so a
+// stalled client never delays the server, and the dropped messages are
+// replaced by an EventsDropped message. Panics if capacity is less than or
+// equal to zero.
+func (s *Server) SubscribeWithPolicy(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	capacity int,
+	policy SlowConsumerPolicy) (*Subscription, error) {
+	if capacity <= 0 {
+		panic("Negative or zero capacity")
+	}
+	if _, err := ParseSlowConsumerPolicy(string(policy)); err != nil {
+		return nil, err
+	}
+
+	return s.subscribe(ctx, clientID, query, NewSubscriptionWithPolicy(capacity, policy))
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, NewSubscription(0))
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	subscription *Subscription) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -181,7 +208,6 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 		return nil, ErrAlreadySubscribed
 	}
 
-	subscription := NewSubscription(outCapacity)
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -351,6 +377,9 @@ func (state *state) add(clientID string, q Query, subscription *Subscription) {
 	}
 	// create subscription
 	state.subscriptions[qStr][clientID] = subscription
+	if subscription.policy != SlowConsumerTerminate {
+		go subscription.forward()
+	}
 
 	// initialize query if needed
 	if _, ok := state.queries[qStr]; !ok {
@@ -415,10 +444,14 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 		if match {
 			for clientID, subscription := range clientSubscriptions {
-				if cap(subscription.out) == 0 {
+				switch {
+				case subscription.policy != SlowConsumerTerminate:
+					// never blocks, the subscription drops messages instead
+					subscription.push(NewMessage(msg, events))
+				case cap(subscription.out) == 0:
 					// block on unbuffered channel
 					subscription.out <- NewMessage(msg, events)
-				} else {
+				default:
 					// don't block on buffered channels
 					select {
 					case subscription.out <- NewMessage(msg, events):
@@ -149,6 +149,131 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSlowClientDropsMessages(t *testing.T) {
+	const (
+		capacity  = 3
+		published = 10
+	)
+	for _, policy := range []pubsub.SlowConsumerPolicy{pubsub.SlowConsumerDropOldest, pubsub.SlowConsumerDropNewest} {
+		policy := policy
+		t.Run(string(policy), func(t *testing.T) {
+			s := pubsub.NewServer()
+			s.SetLogger(log.TestingLogger())
+			require.NoError(t, s.Start())
+			t.Cleanup(func() {
+				if err := s.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			ctx := context.Background()
+			subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, capacity, policy)
+			require.NoError(t, err)
+			assert.Equal(t, policy, subscription.Policy())
+			for i := 1; i <= published; i++ {
+				require.NoError(t, s.Publish(ctx, i))
+			}
+
+			// the client pulls the messages after all of them are published
+			time.Sleep(100 * time.Millisecond)
+			var (
+				received []int
+				dropped  int
+				last     int // the last message received
+				gap      int // the messages dropped after it
+			)
+			for _, data := range drain(subscription.Out()) {
+				switch data := data.(type) {
+				case int:
+					assert.Equal(t, last+gap+1, data, "expected the dropped messages to be counted")
+					received = append(received, data)
+					last, gap = data, 0
+				case pubsub.EventsDropped:
+					assert.Positive(t, data.Count)
+					dropped += data.Count
+					gap += data.Count
+				default:
+					t.Fatalf("unexpected message %v", data)
+				}
+			}
+			assert.Equal(t, published, last+gap)
+			assert.Equal(t, published, len(received)+dropped)
+			assert.Positive(t, dropped)
+			if policy == pubsub.SlowConsumerDropOldest {
+				assert.Equal(t, []int{8, 9, 10}, received[len(received)-capacity:])
+			} else {
+				assert.Equal(t, 1, received[0])
+			}
+			assert.Nil(t, subscription.Err())
+
+			// no gap once the client keeps up
+			require.NoError(t, s.Publish(ctx, published+1))
+			assertReceive(t, published+1, subscription.Out())
+		})
+	}
+}
+
+func TestStalledClientDoesNotDelayOthers(t *testing.T) {
+	const published = 10000
+
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	stalled, err := s.SubscribeWithPolicy(ctx, "stalled-client", query.Empty{}, 10, pubsub.SlowConsumerDropOldest)
+	require.NoError(t, err)
+	subscription, err := s.Subscribe(ctx, clientID, query.Empty{}, published)
+	require.NoError(t, err)
+
+	var maxLatency time.Duration
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < published; i++ {
+			select {
+			case msg := <-subscription.Out():
+				if latency := time.Since(msg.Data().(time.Time)); latency > maxLatency {
+					maxLatency = latency
+				}
+			case <-subscription.Cancelled():
+				return
+			}
+		}
+	}()
+	for i := 0; i < published; i++ {
+		require.NoError(t, s.Publish(ctx, time.Now()))
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the client to receive all the messages")
+	}
+	require.Nil(t, subscription.Err())
+	assert.Less(t, int64(maxLatency), int64(500*time.Millisecond), "max latency %v", maxLatency)
+	assert.Nil(t, stalled.Err())
+
+	// the stalled client gets the latest messages after a gap
+	messages := drain(stalled.Out())
+	require.NotEmpty(t, messages)
+	var received, dropped int
+	for _, data := range messages {
+		if marker, ok := data.(pubsub.EventsDropped); ok {
+			dropped += marker.Count
+		} else {
+			received++
+		}
+	}
+	assert.Positive(t, dropped)
+	assert.Equal(t, published, received+dropped)
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -500,6 +625,19 @@ func assertReceive(t *testing.T, expected interface{}, ch <-chan pubsub.Message,
 	}
 }
 
+// drain returns the data of the messages received until the channel is idle.
+func drain(ch <-chan pubsub.Message) []interface{} {
+	var data []interface{}
+	for {
+		select {
+		case msg := <-ch:
+			data = append(data, msg.Data())
+		case <-time.After(100 * time.Millisecond):
+			return data
+		}
+	}
+}
+
 func assertCancelled(t *testing.T, subscription *pubsub.Subscription, err error) {
 	_, ok := <-subscription.Cancelled()
 	assert.False(t, ok)
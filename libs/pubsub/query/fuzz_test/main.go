@@ -2,6 +2,7 @@ package fuzz_test
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 )
@@ -26,5 +27,10 @@ func Fuzz(data []byte) int {
 		panic("query changed")
 	}
 
+	if !reflect.DeepEqual(q0.Expression(), q1.Expression()) {
+		fmt.Printf("q0: %q\n", sdata1)
+		panic("expression changed")
+	}
+
 	return 1
 }
@@ -1,14 +1,17 @@
 package query_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 )
 
-// TODO: fuzzy testing?
 func TestParser(t *testing.T) {
 	cases := []struct {
 		query string
@@ -84,6 +87,26 @@ func TestParser(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", true},
+		{"tm.events.type='NewBlock' or tm.events.type='Tx' and tx.height > 5", true},
+		{"tm.events.type='NewBlock' OR", false},
+		{"OR tm.events.type='NewBlock'", false},
+		{"tm.events.type='NewBlock' OR OR tm.events.type='Tx'", false},
+		{"tm.events.type='NewBlock'OR tm.events.type='Tx'", false},
+		{"(tm.events.type='NewBlock')", true},
+		{"( tm.events.type='NewBlock' )", true},
+		{"((tm.events.type='NewBlock'))", true},
+		{"tx.height > 5 AND (tm.events.type='NewBlock' OR slashing EXISTS)", true},
+		{"(tx.height > 5 AND tm.events.type='NewBlock') OR slashing EXISTS", true},
+		{"tx.height > 5 AND(tm.events.type='NewBlock' OR slashing EXISTS)", false},
+		{"(tm.events.type='NewBlock'", false},
+		{"tm.events.type='NewBlock')", false},
+		{"(tm.events.type='NewBlock'))", false},
+		{"()", false},
+		{"(tm.events.type)='NewBlock'", false},
+		{"tm.events.type=('NewBlock')", false},
+		{"tm.events.type='(NewBlock'", true},
 	}
 
 	for _, c := range cases {
@@ -95,3 +118,92 @@ func TestParser(t *testing.T) {
 		}
 	}
 }
+
+var fuzzConditions = []struct {
+	s string
+	c query.Condition
+}{
+	{"a.x = 1", query.Condition{CompositeKey: "a.x", Op: query.OpEqual, Operand: int64(1)}},
+	{"a.x>=2.5", query.Condition{CompositeKey: "a.x", Op: query.OpGreaterEqual, Operand: 2.5}},
+	{"b.y CONTAINS 'OR (x AND'", query.Condition{CompositeKey: "b.y", Op: query.OpContains, Operand: "OR (x AND"}},
+	{"c EXISTS", query.Condition{CompositeKey: "c", Op: query.OpExists}},
+	{"d.t < DATE 2020-01-02", query.Condition{
+		CompositeKey: "d.t", Op: query.OpLess, Operand: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}},
+	{"e.u='x'", query.Condition{CompositeKey: "e.u", Op: query.OpEqual, Operand: "x"}},
+}
+
+// randomQuery returns a random query nested up to the depth along with its
+// syntax tree. The operator of the query differs from the parent's one.
+func randomQuery(depth int, parent query.ExpressionOp) (string, *query.Expression) {
+	spaces := func() string { return strings.Repeat(" ", tmrand.Intn(3)) }
+
+	if depth == 0 || tmrand.Intn(3) == 0 {
+		c := fuzzConditions[tmrand.Intn(len(fuzzConditions))]
+		s := c.s
+		if tmrand.Intn(4) == 0 {
+			s = "(" + spaces() + s + spaces() + ")"
+		}
+		return s, &query.Expression{Op: query.ExprCondition, Condition: c.c}
+	}
+
+	op, keywords := query.ExprAnd, []string{"AND", "and", "AnD"}
+	if parent == query.ExprAnd || (parent != query.ExprOr && tmrand.Bool()) {
+		op, keywords = query.ExprOr, []string{"OR", "or", "oR"}
+	}
+	expr := &query.Expression{Op: op}
+	var b strings.Builder
+	for i := 0; i < 2+tmrand.Intn(3); i++ {
+		if i > 0 {
+			b.WriteString(" " + spaces() + keywords[tmrand.Intn(len(keywords))] + " " + spaces())
+		}
+		s, operand := randomQuery(depth-1, op)
+		b.WriteString(s)
+		expr.Operands = append(expr.Operands, operand)
+	}
+	s := b.String()
+	// a disjunction within a conjunction must be parenthesized
+	if (op == query.ExprOr && parent == query.ExprAnd) || tmrand.Intn(4) == 0 {
+		s = "(" + spaces() + s + spaces() + ")"
+	}
+	return s, expr
+}
+
+func TestParserFuzz(t *testing.T) {
+	events := map[string][]string{
+		"a.x": {"1", "3"},
+		"b.y": {"OR (x AND y)"},
+		"c":   {""},
+		"d.t": {"2020-01-01"},
+		"e.u": {"y"},
+	}
+
+	for i := 0; i < 1000; i++ {
+		s, expr := randomQuery(4, query.ExprCondition)
+		q, err := query.New(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expr, q.Expression(), s)
+		_, err = q.Matches(events)
+		require.NoError(t, err, s)
+
+		// the mutated queries are either rejected or can be matched
+		bz := []byte(s)
+		for j := 0; j < 1+tmrand.Intn(3); j++ {
+			pos := tmrand.Intn(len(bz))
+			switch tmrand.Intn(3) {
+			case 0:
+				bz = append(bz[:pos], bz[pos+1:]...)
+			case 1:
+				bz = append(bz[:pos], append([]byte{"() '=<>ADOR.1"[tmrand.Intn(13)]}, bz[pos:]...)...)
+			default:
+				bz[pos] = "() '=<>ADOR.1"[tmrand.Intn(13)]
+			}
+		}
+		q, err = query.New(string(bz))
+		if err != nil {
+			continue
+		}
+		require.NotNil(t, q.Expression(), string(bz))
+		_, _ = q.Matches(events)
+		_, _ = q.Conditions()
+	}
+}
@@ -1,6 +1,7 @@
 // Package query provides a parser for a custom query format:
 //
 //		abci.invoice.number=22 AND abci.invoice.owner=Ivan
+//		abci.invoice.number=22 AND (abci.invoice.owner=Ivan OR abci.invoice.payer EXISTS)
 //
 // See query.peg for the grammar, which is a https://en.wikipedia.org/wiki/Parsing_expression_grammar.
 // More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
@@ -25,6 +26,7 @@ var (
 type Query struct {
 	str    string
 	parser *QueryParser
+	expr   *Expression
 }
 
 // Condition represents a single condition within a query and consists of composite key
@@ -43,7 +45,13 @@ func New(s string) (*Query, error) {
 	if err := p.Parse(); err != nil {
 		return nil, err
 	}
-	return &Query{str: s, parser: p}, nil
+	q := &Query{str: s, parser: p}
+	expr, err := q.expression()
+	if err != nil {
+		return nil, err
+	}
+	q.expr = expr
+	return q, nil
 }
 
 // MustParse turns the given string into a query or panics; for tests or others
@@ -89,18 +97,70 @@ const (
 	TimeLayout = time.RFC3339
 )
 
-// Conditions returns a list of conditions. It returns an error if there is any
-// error with the provided grammar in the Query.
+// Expression returns the syntax tree of the query.
+func (q *Query) Expression() *Expression {
+	return q.expr
+}
+
+// Conditions returns a list of conditions, which all must be met for the
+// query to match. It returns an error if there is any error with the provided
+// grammar in the Query or if the query has an OR operator, in which case the
+// Expression must be used instead.
 func (q *Query) Conditions() ([]Condition, error) {
+	conditions, ok := q.expr.conjunction()
+	if !ok {
+		return nil, fmt.Errorf("query %q is not a conjunction of conditions", q.str)
+	}
+	return conditions, nil
+}
+
+// expression builds the syntax tree of the parsed query. It returns an error
+// if an operand can't be converted.
+func (q *Query) expression() (*Expression, error) {
 	var (
 		eventAttr string
 		op        Operator
 	)
 
-	conditions := make([]Condition, 0)
+	// the expressions not yet combined, with the positions they begin at
+	type node struct {
+		begin uint32
+		expr  *Expression
+	}
+	stack := make([]node, 0)
+	push := func(begin uint32, operand interface{}) {
+		stack = append(stack, node{begin, &Expression{
+			Op:        ExprCondition,
+			Condition: Condition{eventAttr, op, operand},
+		}})
+	}
+	// combine replaces the expressions beginning within the token with their
+	// conjunction or disjunction
+	combine := func(token token32, exprOp ExpressionOp) {
+		i := len(stack)
+		for i > 0 && stack[i-1].begin >= token.begin {
+			i--
+		}
+		if len(stack)-i < 2 {
+			return
+		}
+		expr := &Expression{Op: exprOp}
+		for _, n := range stack[i:] {
+			if n.expr.Op == exprOp {
+				// flatten the parenthesized expressions of the same operator
+				expr.Operands = append(expr.Operands, n.expr.Operands...)
+			} else {
+				expr.Operands = append(expr.Operands, n.expr)
+			}
+		}
+		stack = append(stack[:i], node{token.begin, expr})
+	}
+
 	buffer, begin, end := q.parser.Buffer, 0, 0
 
-	// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
+	// tokens must be in the following order: tag ("tx.gas") -> operator ("=")
+	// -> operand ("7"), with the conditions preceding the terms and the
+	// expressions combining them
 	for token := range q.parser.Tokens() {
 		switch token.pegRule {
 		case rulePegText:
@@ -129,12 +189,12 @@ func (q *Query) Conditions() ([]Condition, error) {
 
 		case ruleexists:
 			op = OpExists
-			conditions = append(conditions, Condition{eventAttr, op, nil})
+			push(token.begin, nil)
 
 		case rulevalue:
 			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
 			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-			conditions = append(conditions, Condition{eventAttr, op, valueWithoutSingleQuotes})
+			push(token.begin, valueWithoutSingleQuotes)
 
 		case rulenumber:
 			number := buffer[begin:end]
@@ -148,7 +208,7 @@ func (q *Query) Conditions() ([]Condition, error) {
 					return nil, err
 				}
 
-				conditions = append(conditions, Condition{eventAttr, op, value})
+				push(token.begin, value)
 			} else {
 				value, err := strconv.ParseInt(number, 10, 64)
 				if err != nil {
@@ -159,7 +219,7 @@ func (q *Query) Conditions() ([]Condition, error) {
 					return nil, err
 				}
 
-				conditions = append(conditions, Condition{eventAttr, op, value})
+				push(token.begin, value)
 			}
 
 		case ruletime:
@@ -172,7 +232,7 @@ func (q *Query) Conditions() ([]Condition, error) {
 				return nil, err
 			}
 
-			conditions = append(conditions, Condition{eventAttr, op, value})
+			push(token.begin, value)
 
 		case ruledate:
 			value, err := time.Parse("2006-01-02", buffer[begin:end])
@@ -184,11 +244,21 @@ func (q *Query) Conditions() ([]Condition, error) {
 				return nil, err
 			}
 
-			conditions = append(conditions, Condition{eventAttr, op, value})
+			push(token.begin, value)
+
+		case ruleterm:
+			combine(token, ExprAnd)
+
+		case ruleexpr:
+			combine(token, ExprOr)
 		}
 	}
 
-	return conditions, nil
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("got %d expressions at the top level of the query (should never happen if the grammar is correct)",
+			len(stack))
+	}
+	return stack[0].expr, nil
 }
 
 // Matches returns true if the query matches against any event in the given set
@@ -203,160 +273,104 @@ func (q *Query) Matches(events map[string][]string) (bool, error) {
 		return false, nil
 	}
 
-	var (
-		eventAttr string
-		op        Operator
-	)
-
-	buffer, begin, end := q.parser.Buffer, 0, 0
-
-	// tokens must be in the following order:
-
-	// tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
-		switch token.pegRule {
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-
-		case ruletag:
-			eventAttr = buffer[begin:end]
-
-		case rulele:
-			op = OpLessEqual
-
-		case rulege:
-			op = OpGreaterEqual
-
-		case rulel:
-			op = OpLess
-
-		case ruleg:
-			op = OpGreater
+	return q.expr.Matches(events)
+}
 
-		case ruleequal:
-			op = OpEqual
+// ExpressionOp is the kind of a query expression.
+type ExpressionOp uint8
 
-		case rulecontains:
-			op = OpContains
-		case ruleexists:
-			op = OpExists
-			if strings.Contains(eventAttr, ".") {
-				// Searching for a full "type.attribute" event.
-				_, ok := events[eventAttr]
-				if !ok {
-					return false, nil
-				}
-			} else {
-				foundEvent := false
-
-			loop:
-				for compositeKey := range events {
-					if strings.Index(compositeKey, eventAttr) == 0 {
-						foundEvent = true
-						break loop
-					}
-				}
-				if !foundEvent {
-					return false, nil
-				}
-			}
+const (
+	// a single condition
+	ExprCondition ExpressionOp = iota
+	// "AND"; all the operands must match
+	ExprAnd
+	// "OR"; any of the operands must match
+	ExprOr
+)
 
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
+// Expression is a node of the syntax tree of a query: either a single
+// condition or a conjunction or a disjunction of two or more operands. The
+// operands of a conjunction are never conjunctions themselves, nor are the
+// operands of a disjunction disjunctions.
+//
+// e.g. "a=1 AND (b=2 OR c EXISTS)" => And(a=1, Or(b=2, c EXISTS))
+type Expression struct {
+	Op        ExpressionOp
+	Condition Condition     // if Op is ExprCondition
+	Operands  []*Expression // if Op is ExprAnd or ExprOr
+}
 
-			// see if the triplet (event attribute, operator, operand) matches any event
-			// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
-			match, err := match(eventAttr, op, reflect.ValueOf(valueWithoutSingleQuotes), events)
-			if err != nil {
+// Matches returns true if the expression matches against the given set of
+// events, evaluating the operands in order until the result is known. An
+// error is returned if any attempted condition match returns an error.
+func (e *Expression) Matches(events map[string][]string) (bool, error) {
+	switch e.Op {
+	case ExprAnd:
+		for _, operand := range e.Operands {
+			match, err := operand.Matches(events)
+			if err != nil || !match {
 				return false, err
 			}
+		}
+		return true, nil
 
-			if !match {
-				return false, nil
-			}
-
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
-				}
-			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as int64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
-				}
+	case ExprOr:
+		for _, operand := range e.Operands {
+			match, err := operand.Matches(events)
+			if err != nil || match {
+				return match, err
 			}
+		}
+		return false, nil
 
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return false, err
-			}
+	default:
+		return e.Condition.matches(events)
+	}
+}
 
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
+// conjunction returns the conditions of the expression if all of them must be
+// met for it to match.
+func (e *Expression) conjunction() ([]Condition, bool) {
+	switch e.Op {
+	case ExprCondition:
+		return []Condition{e.Condition}, true
+
+	case ExprAnd:
+		conditions := make([]Condition, 0, len(e.Operands))
+		for _, operand := range e.Operands {
+			if operand.Op != ExprCondition {
+				return nil, false
 			}
+			conditions = append(conditions, operand.Condition)
+		}
+		return conditions, true
 
-			if !match {
-				return false, nil
-			}
+	default:
+		return nil, false
+	}
+}
 
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return false, err
-			}
+// matches returns true if the condition matches against the given set of
+// events.
+func (c Condition) matches(events map[string][]string) (bool, error) {
+	if c.Op != OpExists {
+		// see if the triplet (event attribute, operator, operand) matches any event
+		// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
+		return match(c.CompositeKey, c.Op, reflect.ValueOf(c.Operand), events)
+	}
 
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
+	if strings.Contains(c.CompositeKey, ".") {
+		// Searching for a full "type.attribute" event.
+		_, ok := events[c.CompositeKey]
+		return ok, nil
+	}
 
-			if !match {
-				return false, nil
-			}
+	for compositeKey := range events {
+		if strings.Index(compositeKey, c.CompositeKey) == 0 {
+			return true, nil
 		}
 	}
-
-	return true, nil
+	return false, nil
 }
 
 // match returns true if the given triplet (attribute, operator, operand) matches
@@ -16,6 +16,9 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	rulee
+	ruleexpr
+	ruleterm
+	rulegroup
 	rulecondition
 	ruletag
 	rulevalue
@@ -27,6 +30,7 @@ const (
 	rulemonth
 	ruleday
 	ruleand
+	ruleor
 	ruleequal
 	rulecontains
 	ruleexists
@@ -44,6 +48,9 @@ const (
 var rul3s = [...]string{
 	"Unknown",
 	"e",
+	"expr",
+	"term",
+	"group",
 	"condition",
 	"tag",
 	"value",
@@ -55,6 +62,7 @@ var rul3s = [...]string{
 	"month",
 	"day",
 	"and",
+	"or",
 	"equal",
 	"contains",
 	"exists",
@@ -356,7 +364,7 @@ func (t *tokens32) Expand(index int) {
 type QueryParser struct {
 	Buffer string
 	buffer []rune
-	rules  [21]func() bool
+	rules  [25]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -494,7 +502,7 @@ func (p *QueryParser) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 e <- <('"' condition (' '+ and ' '+ condition)* '"' !.)> */
+		/* 0 e <- <('"' expr '"' !.)> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
@@ -504,122 +512,299 @@ func (p *QueryParser) Init() {
 					goto l0
 				}
 				position++
-				if !_rules[rulecondition]() {
+				if !_rules[ruleexpr]() {
 					goto l0
 				}
-			l2:
+				if buffer[position] != rune('"') {
+					goto l0
+				}
+				position++
 				{
-					position3, tokenIndex3, depth3 := position, tokenIndex, depth
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l2
+					}
+					goto l0
+				l2:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+				}
+				depth--
+				add(rulee, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 expr <- <(term (' '+ or ' '+ term)*)> */
+		func() bool {
+			position3, tokenIndex3, depth3 := position, tokenIndex, depth
+			{
+				position4 := position
+				depth++
+				if !_rules[ruleterm]() {
+					goto l3
+				}
+			l5:
+				{
+					position6, tokenIndex6, depth6 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l3
+						goto l6
 					}
 					position++
-				l4:
+				l7:
 					{
-						position5, tokenIndex5, depth5 := position, tokenIndex, depth
+						position8, tokenIndex8, depth8 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l5
+							goto l8
 						}
 						position++
-						goto l4
-					l5:
-						position, tokenIndex, depth = position5, tokenIndex5, depth5
+						goto l7
+					l8:
+						position, tokenIndex, depth = position8, tokenIndex8, depth8
 					}
 					{
-						position6 := position
+						position9 := position
 						depth++
 						{
-							position7, tokenIndex7, depth7 := position, tokenIndex, depth
+							position10, tokenIndex10, depth10 := position, tokenIndex, depth
+							if buffer[position] != rune('o') {
+								goto l11
+							}
+							position++
+							goto l10
+						l11:
+							position, tokenIndex, depth = position10, tokenIndex10, depth10
+							if buffer[position] != rune('O') {
+								goto l6
+							}
+							position++
+						}
+					l10:
+						{
+							position12, tokenIndex12, depth12 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l13
+							}
+							position++
+							goto l12
+						l13:
+							position, tokenIndex, depth = position12, tokenIndex12, depth12
+							if buffer[position] != rune('R') {
+								goto l6
+							}
+							position++
+						}
+					l12:
+						depth--
+						add(ruleor, position9)
+					}
+					if buffer[position] != rune(' ') {
+						goto l6
+					}
+					position++
+				l14:
+					{
+						position15, tokenIndex15, depth15 := position, tokenIndex, depth
+						if buffer[position] != rune(' ') {
+							goto l15
+						}
+						position++
+						goto l14
+					l15:
+						position, tokenIndex, depth = position15, tokenIndex15, depth15
+					}
+					if !_rules[ruleterm]() {
+						goto l6
+					}
+					goto l5
+				l6:
+					position, tokenIndex, depth = position6, tokenIndex6, depth6
+				}
+				depth--
+				add(ruleexpr, position4)
+			}
+			return true
+		l3:
+			position, tokenIndex, depth = position3, tokenIndex3, depth3
+			return false
+		},
+		/* 2 term <- <((group / condition) (' '+ and ' '+ (group / condition))*)> */
+		func() bool {
+			position142, tokenIndex142, depth142 := position, tokenIndex, depth
+			{
+				position143 := position
+				depth++
+				{
+					position144, tokenIndex144, depth144 := position, tokenIndex, depth
+					if !_rules[rulegroup]() {
+						goto l145
+					}
+					goto l144
+				l145:
+					position, tokenIndex, depth = position144, tokenIndex144, depth144
+					if !_rules[rulecondition]() {
+						goto l142
+					}
+				}
+			l144:
+			l146:
+				{
+					position147, tokenIndex147, depth147 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l147
+					}
+					position++
+				l148:
+					{
+						position149, tokenIndex149, depth149 := position, tokenIndex, depth
+						if buffer[position] != rune(' ') {
+							goto l149
+						}
+						position++
+						goto l148
+					l149:
+						position, tokenIndex, depth = position149, tokenIndex149, depth149
+					}
+					{
+						position150 := position
+						depth++
+						{
+							position151, tokenIndex151, depth151 := position, tokenIndex, depth
 							if buffer[position] != rune('a') {
-								goto l8
+								goto l152
 							}
 							position++
-							goto l7
-						l8:
-							position, tokenIndex, depth = position7, tokenIndex7, depth7
+							goto l151
+						l152:
+							position, tokenIndex, depth = position151, tokenIndex151, depth151
 							if buffer[position] != rune('A') {
-								goto l3
+								goto l147
 							}
 							position++
 						}
-					l7:
+					l151:
 						{
-							position9, tokenIndex9, depth9 := position, tokenIndex, depth
+							position153, tokenIndex153, depth153 := position, tokenIndex, depth
 							if buffer[position] != rune('n') {
-								goto l10
+								goto l154
 							}
 							position++
-							goto l9
-						l10:
-							position, tokenIndex, depth = position9, tokenIndex9, depth9
+							goto l153
+						l154:
+							position, tokenIndex, depth = position153, tokenIndex153, depth153
 							if buffer[position] != rune('N') {
-								goto l3
+								goto l147
 							}
 							position++
 						}
-					l9:
+					l153:
 						{
-							position11, tokenIndex11, depth11 := position, tokenIndex, depth
+							position155, tokenIndex155, depth155 := position, tokenIndex, depth
 							if buffer[position] != rune('d') {
-								goto l12
+								goto l156
 							}
 							position++
-							goto l11
-						l12:
-							position, tokenIndex, depth = position11, tokenIndex11, depth11
+							goto l155
+						l156:
+							position, tokenIndex, depth = position155, tokenIndex155, depth155
 							if buffer[position] != rune('D') {
-								goto l3
+								goto l147
 							}
 							position++
 						}
-					l11:
+					l155:
 						depth--
-						add(ruleand, position6)
+						add(ruleand, position150)
 					}
 					if buffer[position] != rune(' ') {
-						goto l3
+						goto l147
 					}
 					position++
-				l13:
+				l157:
 					{
-						position14, tokenIndex14, depth14 := position, tokenIndex, depth
+						position158, tokenIndex158, depth158 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l14
+							goto l158
 						}
 						position++
-						goto l13
-					l14:
-						position, tokenIndex, depth = position14, tokenIndex14, depth14
+						goto l157
+					l158:
+						position, tokenIndex, depth = position158, tokenIndex158, depth158
 					}
-					if !_rules[rulecondition]() {
-						goto l3
+					{
+						position159, tokenIndex159, depth159 := position, tokenIndex, depth
+						if !_rules[rulegroup]() {
+							goto l160
+						}
+						goto l159
+					l160:
+						position, tokenIndex, depth = position159, tokenIndex159, depth159
+						if !_rules[rulecondition]() {
+							goto l147
+						}
 					}
-					goto l2
-				l3:
-					position, tokenIndex, depth = position3, tokenIndex3, depth3
+				l159:
+					goto l146
+				l147:
+					position, tokenIndex, depth = position147, tokenIndex147, depth147
 				}
-				if buffer[position] != rune('"') {
-					goto l0
+				depth--
+				add(ruleterm, position143)
+			}
+			return true
+		l142:
+			position, tokenIndex, depth = position142, tokenIndex142, depth142
+			return false
+		},
+		/* 3 group <- <('(' ' '* expr ' '* ')')> */
+		func() bool {
+			position161, tokenIndex161, depth161 := position, tokenIndex, depth
+			{
+				position162 := position
+				depth++
+				if buffer[position] != rune('(') {
+					goto l161
 				}
 				position++
+			l163:
 				{
-					position15, tokenIndex15, depth15 := position, tokenIndex, depth
-					if !matchDot() {
-						goto l15
+					position164, tokenIndex164, depth164 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l164
 					}
-					goto l0
-				l15:
-					position, tokenIndex, depth = position15, tokenIndex15, depth15
+					position++
+					goto l163
+				l164:
+					position, tokenIndex, depth = position164, tokenIndex164, depth164
 				}
+				if !_rules[ruleexpr]() {
+					goto l161
+				}
+			l165:
+				{
+					position166, tokenIndex166, depth166 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l166
+					}
+					position++
+					goto l165
+				l166:
+					position, tokenIndex, depth = position166, tokenIndex166, depth166
+				}
+				if buffer[position] != rune(')') {
+					goto l161
+				}
+				position++
 				depth--
-				add(rulee, position1)
+				add(rulegroup, position162)
 			}
 			return true
-		l0:
-			position, tokenIndex, depth = position0, tokenIndex0, depth0
+		l161:
+			position, tokenIndex, depth = position161, tokenIndex161, depth161
 			return false
 		},
-		/* 1 condition <- <(tag ' '* ((le ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / (ge ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / ((&('E' | 'e') exists) | (&('=') (equal ' '* ((&('\'') value) | (&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('>') (g ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('<') (l ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('C' | 'c') (contains ' '* value)))))> */
+		/* 4 condition <- <(tag ' '* ((le ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / (ge ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number))) / ((&('E' | 'e') exists) | (&('=') (equal ' '* ((&('\'') value) | (&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('>') (g ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('<') (l ' '* ((&('D' | 'd') date) | (&('T' | 't') time) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') number)))) | (&('C' | 'c') (contains ' '* value)))))> */
 		func() bool {
 			position16, tokenIndex16, depth16 := position, tokenIndex, depth
 			{
@@ -1314,9 +1499,9 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position16, tokenIndex16, depth16
 			return false
 		},
-		/* 2 tag <- <<(!((&('<') '<') | (&('>') '>') | (&('=') '=') | (&('\'') '\'') | (&('"') '"') | (&(')') ')') | (&('(') '(') | (&('\\') '\\') | (&('\r') '\r') | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' ')) .)+>> */
+		/* 5 tag <- <<(!((&('<') '<') | (&('>') '>') | (&('=') '=') | (&('\'') '\'') | (&('"') '"') | (&(')') ')') | (&('(') '(') | (&('\\') '\\') | (&('\r') '\r') | (&('\n') '\n') | (&('\t') '\t') | (&(' ') ' ')) .)+>> */
 		nil,
-		/* 3 value <- <<('\'' (!('"' / '\'') .)* '\'')>> */
+		/* 6 value <- <<('\'' (!('"' / '\'') .)* '\'')>> */
 		func() bool {
 			position85, tokenIndex85, depth85 := position, tokenIndex, depth
 			{
@@ -1375,7 +1560,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position85, tokenIndex85, depth85
 			return false
 		},
-		/* 4 number <- <<('0' / ([1-9] digit* ('.' digit*)?))>> */
+		/* 7 number <- <<('0' / ([1-9] digit* ('.' digit*)?))>> */
 		func() bool {
 			position93, tokenIndex93, depth93 := position, tokenIndex, depth
 			{
@@ -1441,7 +1626,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position93, tokenIndex93, depth93
 			return false
 		},
-		/* 5 digit <- <[0-9]> */
+		/* 8 digit <- <[0-9]> */
 		func() bool {
 			position104, tokenIndex104, depth104 := position, tokenIndex, depth
 			{
@@ -1459,7 +1644,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position104, tokenIndex104, depth104
 			return false
 		},
-		/* 6 time <- <(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ' ' <(year '-' month '-' day 'T' digit digit ':' digit digit ':' digit digit ((('-' / '+') digit digit ':' digit digit) / 'Z'))>)> */
+		/* 9 time <- <(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ' ' <(year '-' month '-' day 'T' digit digit ':' digit digit ':' digit digit ((('-' / '+') digit digit ':' digit digit) / 'Z'))>)> */
 		func() bool {
 			position106, tokenIndex106, depth106 := position, tokenIndex, depth
 			{
@@ -1632,7 +1817,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position106, tokenIndex106, depth106
 			return false
 		},
-		/* 7 date <- <(('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') ' ' <(year '-' month '-' day)>)> */
+		/* 10 date <- <(('d' / 'D') ('a' / 'A') ('t' / 'T') ('e' / 'E') ' ' <(year '-' month '-' day)>)> */
 		func() bool {
 			position121, tokenIndex121, depth121 := position, tokenIndex, depth
 			{
@@ -1733,7 +1918,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position121, tokenIndex121, depth121
 			return false
 		},
-		/* 8 year <- <(('1' / '2') digit digit digit)> */
+		/* 11 year <- <(('1' / '2') digit digit digit)> */
 		func() bool {
 			position132, tokenIndex132, depth132 := position, tokenIndex, depth
 			{
@@ -1771,7 +1956,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position132, tokenIndex132, depth132
 			return false
 		},
-		/* 9 month <- <(('0' / '1') digit)> */
+		/* 12 month <- <(('0' / '1') digit)> */
 		func() bool {
 			position136, tokenIndex136, depth136 := position, tokenIndex, depth
 			{
@@ -1803,7 +1988,7 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position136, tokenIndex136, depth136
 			return false
 		},
-		/* 10 day <- <(((&('3') '3') | (&('2') '2') | (&('1') '1') | (&('0') '0')) digit)> */
+		/* 13 day <- <(((&('3') '3') | (&('2') '2') | (&('1') '1') | (&('0') '0')) digit)> */
 		func() bool {
 			position140, tokenIndex140, depth140 := position, tokenIndex, depth
 			{
@@ -1849,21 +2034,23 @@ func (p *QueryParser) Init() {
 			position, tokenIndex, depth = position140, tokenIndex140, depth140
 			return false
 		},
-		/* 11 and <- <(('a' / 'A') ('n' / 'N') ('d' / 'D'))> */
+		/* 14 and <- <(('a' / 'A') ('n' / 'N') ('d' / 'D'))> */
+		nil,
+		/* 15 or <- <(('o' / 'O') ('r' / 'R'))> */
 		nil,
-		/* 12 equal <- <'='> */
+		/* 16 equal <- <'='> */
 		nil,
-		/* 13 contains <- <(('c' / 'C') ('o' / 'O') ('n' / 'N') ('t' / 'T') ('a' / 'A') ('i' / 'I') ('n' / 'N') ('s' / 'S'))> */
+		/* 17 contains <- <(('c' / 'C') ('o' / 'O') ('n' / 'N') ('t' / 'T') ('a' / 'A') ('i' / 'I') ('n' / 'N') ('s' / 'S'))> */
 		nil,
-		/* 14 exists <- <(('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))> */
+		/* 18 exists <- <(('e' / 'E') ('x' / 'X') ('i' / 'I') ('s' / 'S') ('t' / 'T') ('s' / 'S'))> */
 		nil,
-		/* 15 le <- <('<' '=')> */
+		/* 19 le <- <('<' '=')> */
 		nil,
-		/* 16 ge <- <('>' '=')> */
+		/* 20 ge <- <('>' '=')> */
 		nil,
-		/* 17 l <- <'<'> */
+		/* 21 l <- <'<'> */
 		nil,
-		/* 18 g <- <'>'> */
+		/* 22 g <- <'>'> */
 		nil,
 		nil,
 	}
@@ -150,6 +150,40 @@ func TestMatches(t *testing.T) {
 			false,
 			false,
 		},
+		{"tx.gas > 9 OR tx.gas < 7", map[string][]string{"tx.gas": {"8"}}, false, false, false},
+		{"tx.gas > 9 OR tx.gas < 9", map[string][]string{"tx.gas": {"8"}}, false, true, false},
+		{"slash.reason EXISTS OR slash.power > 1000", map[string][]string{"slash.power": {"1001"}}, false, true, false},
+		{
+			"tm.events.type='Tx' AND (transfer.sender='alice' OR transfer.recipient='alice')",
+			map[string][]string{"tm.events.type": {"Tx"}, "transfer.sender": {"bob"}, "transfer.recipient": {"alice"}},
+			false,
+			true,
+			false,
+		},
+		{
+			"tm.events.type='Tx' AND (transfer.sender='alice' OR transfer.recipient='alice')",
+			map[string][]string{"tm.events.type": {"NewBlock"}, "transfer.recipient": {"alice"}},
+			false,
+			false,
+			false,
+		},
+		{
+			"tm.events.type='Tx' AND transfer.sender='alice' OR transfer.recipient='alice'",
+			map[string][]string{"tm.events.type": {"NewBlock"}, "transfer.recipient": {"alice"}},
+			false,
+			true,
+			false,
+		},
+		{
+			"( (tx.gas < 7) OR (tx.gas > 9 AND tx.fee EXISTS) )",
+			map[string][]string{"tx.gas": {"10"}, "tx.fee": {"1"}},
+			false,
+			true,
+			false,
+		},
+		// the operands are evaluated in order until the result is known
+		{"tx.gas > 7 OR tx.gas > DATE 2017-01-01", map[string][]string{"tx.gas": {"8"}}, false, true, false},
+		{"tx.gas < 7 OR tx.gas > DATE 2017-01-01", map[string][]string{"tx.gas": {"8"}}, false, false, true},
 	}
 
 	for _, tc := range testCases {
@@ -209,6 +243,14 @@ func TestConditions(t *testing.T) {
 				{CompositeKey: "slashing", Op: query.OpExists},
 			},
 		},
+		{
+			s: "(tx.gas > 7 AND tx.gas < 9) AND (slashing EXISTS)",
+			conditions: []query.Condition{
+				{CompositeKey: "tx.gas", Op: query.OpGreater, Operand: int64(7)},
+				{CompositeKey: "tx.gas", Op: query.OpLess, Operand: int64(9)},
+				{CompositeKey: "slashing", Op: query.OpExists},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -220,3 +262,50 @@ func TestConditions(t *testing.T) {
 		assert.Equal(t, tc.conditions, c)
 	}
 }
+
+func TestConditionsOfDisjunction(t *testing.T) {
+	q := query.MustParse("tx.gas > 7 AND (tx.gas < 9 OR slashing EXISTS)")
+	_, err := q.Conditions()
+	assert.Error(t, err)
+}
+
+func TestExpression(t *testing.T) {
+	cond := func(key string, op query.Operator, operand interface{}) *query.Expression {
+		return &query.Expression{
+			Op:        query.ExprCondition,
+			Condition: query.Condition{CompositeKey: key, Op: op, Operand: operand},
+		}
+	}
+	and := func(operands ...*query.Expression) *query.Expression {
+		return &query.Expression{Op: query.ExprAnd, Operands: operands}
+	}
+	or := func(operands ...*query.Expression) *query.Expression {
+		return &query.Expression{Op: query.ExprOr, Operands: operands}
+	}
+	a, b, c, d := cond("a.x", query.OpEqual, int64(1)), cond("b.x", query.OpExists, nil),
+		cond("c.x", query.OpContains, "c"), cond("d.x", query.OpLess, 2.5)
+
+	testCases := []struct {
+		s    string
+		expr *query.Expression
+	}{
+		{"a.x=1", a},
+		{"(a.x=1)", a},
+		{"a.x=1 AND b.x EXISTS", and(a, b)},
+		{"a.x=1 OR b.x EXISTS", or(a, b)},
+		// AND takes precedence over OR
+		{"a.x=1 OR b.x EXISTS AND c.x CONTAINS 'c' OR d.x < 2.5", or(a, and(b, c), d)},
+		{"a.x=1 AND b.x EXISTS OR c.x CONTAINS 'c' AND d.x < 2.5", or(and(a, b), and(c, d))},
+		{"a.x=1 AND (b.x EXISTS OR c.x CONTAINS 'c') AND d.x < 2.5", and(a, or(b, c), d)},
+		// the parenthesized expressions of the same operator are flattened
+		{"(a.x=1 AND b.x EXISTS) AND (c.x CONTAINS 'c' AND d.x < 2.5)", and(a, b, c, d)},
+		{"((a.x=1 OR (b.x EXISTS)) OR c.x CONTAINS 'c')", or(a, b, c)},
+		{"( a.x=1 AND ( b.x EXISTS OR c.x CONTAINS 'c' ) )", and(a, or(b, c))},
+	}
+
+	for _, tc := range testCases {
+		q, err := query.New(tc.s)
+		require.NoError(t, err, tc.s)
+		assert.Equal(t, tc.expr, q.Expression(), tc.s)
+	}
+}
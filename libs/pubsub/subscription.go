@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"errors"
+	"fmt"
 
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 )
@@ -15,6 +16,37 @@ var (
 	ErrOutOfCapacity = errors.New("client is not pulling messages fast enough")
 )
 
+// SlowConsumerPolicy defines what happens to the messages published to a
+// subscription, whose client isn't pulling them fast enough.
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerTerminate cancels the subscription with ErrOutOfCapacity.
+	SlowConsumerTerminate SlowConsumerPolicy = "terminate"
+	// SlowConsumerDropOldest drops the oldest buffered message to make room
+	// for the new one.
+	SlowConsumerDropOldest SlowConsumerPolicy = "drop_oldest"
+	// SlowConsumerDropNewest drops the new message.
+	SlowConsumerDropNewest SlowConsumerPolicy = "drop_newest"
+)
+
+// ParseSlowConsumerPolicy returns the policy with the given name.
+func ParseSlowConsumerPolicy(name string) (SlowConsumerPolicy, error) {
+	switch policy := SlowConsumerPolicy(name); policy {
+	case SlowConsumerTerminate, SlowConsumerDropOldest, SlowConsumerDropNewest:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown slow consumer policy %q", name)
+	}
+}
+
+// EventsDropped is the data of the message delivered in place of the messages
+// dropped by the SlowConsumerDropOldest and SlowConsumerDropNewest policies,
+// so that the client knows there is a gap.
+type EventsDropped struct {
+	Count int `json:"count"`
+}
+
 // A Subscription represents a client subscription for a particular query and
 // consists of three things:
 // 1) channel onto which messages and events are published
@@ -26,6 +58,15 @@ type Subscription struct {
 	cancelled chan struct{}
 	mtx       tmsync.RWMutex
 	err       error
+
+	// The subscriptions dropping messages buffer them in buf, which is
+	// forwarded to out by their own goroutine, so the server never blocks.
+	policy   SlowConsumerPolicy
+	capacity int
+	bufMtx   tmsync.Mutex
+	buf      []Message
+	buffered int // messages in buf, excluding the EventsDropped markers
+	ready    chan struct{}
 }
 
 // NewSubscription returns a new subscription with the given outCapacity.
@@ -33,6 +74,24 @@ func NewSubscription(outCapacity int) *Subscription {
 	return &Subscription{
 		out:       make(chan Message, outCapacity),
 		cancelled: make(chan struct{}),
+		policy:    SlowConsumerTerminate,
+	}
+}
+
+// NewSubscriptionWithPolicy returns a new subscription buffering up to
+// capacity messages, whose client is handled by the given policy once the
+// buffer is full.
+func NewSubscriptionWithPolicy(capacity int, policy SlowConsumerPolicy) *Subscription {
+	if policy == SlowConsumerTerminate {
+		return NewSubscription(capacity)
+	}
+	return &Subscription{
+		out:       make(chan Message),
+		cancelled: make(chan struct{}),
+		policy:    policy,
+		capacity:  capacity,
+		buf:       make([]Message, 0, capacity),
+		ready:     make(chan struct{}, 1),
 	}
 }
 
@@ -62,6 +121,12 @@ func (s *Subscription) Err() error {
 	return s.err
 }
 
+// Policy returns the policy applied once the client isn't pulling messages
+// fast enough.
+func (s *Subscription) Policy() SlowConsumerPolicy {
+	return s.policy
+}
+
 func (s *Subscription) cancel(err error) {
 	s.mtx.Lock()
 	s.err = err
@@ -69,6 +134,110 @@ func (s *Subscription) cancel(err error) {
 	close(s.cancelled)
 }
 
+// push buffers the message, dropping one if the buffer is full, and wakes up
+// forward. The dropped messages are counted by an EventsDropped marker at the
+// position of the gap: before the buffered messages for
+// SlowConsumerDropOldest, after them for SlowConsumerDropNewest.
+func (s *Subscription) push(msg Message) {
+	s.bufMtx.Lock()
+	switch {
+	case s.buffered < s.capacity:
+		s.buf = append(s.buf, msg)
+		s.buffered++
+	case s.policy == SlowConsumerDropNewest:
+		if last := len(s.buf) - 1; last >= 0 && isEventsDropped(s.buf[last]) {
+			s.buf[last] = eventsDroppedMessage(s.buf[last], 1)
+		} else {
+			s.buf = append(s.buf, eventsDroppedMessage(Message{}, 1))
+		}
+	default: // SlowConsumerDropOldest
+		if len(s.buf) > 0 && isEventsDropped(s.buf[0]) {
+			// the marker is followed by the oldest message
+			s.buf = append(s.buf[:1], s.buf[2:]...)
+			s.buf[0] = eventsDroppedMessage(s.buf[0], 1)
+		} else {
+			s.buf[0] = eventsDroppedMessage(Message{}, 1)
+		}
+		s.buf = append(s.buf, msg)
+	}
+	s.bufMtx.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes the first buffered message.
+func (s *Subscription) pop() (Message, bool) {
+	s.bufMtx.Lock()
+	defer s.bufMtx.Unlock()
+	if len(s.buf) == 0 {
+		return Message{}, false
+	}
+	msg := s.buf[0]
+	s.buf[0] = Message{}
+	s.buf = s.buf[1:]
+	if !isEventsDropped(msg) {
+		s.buffered--
+	}
+	return msg, true
+}
+
+// forward delivers the buffered messages to out until the subscription is
+// cancelled.
+func (s *Subscription) forward() {
+	for {
+		msg, ok := s.pop()
+		if !ok {
+			select {
+			case <-s.ready:
+				continue
+			case <-s.cancelled:
+				return
+			}
+		}
+
+		// an EventsDropped marker waiting for the client is put back, once
+		// more messages are pushed, so it's merged with the next marker
+		var ready <-chan struct{}
+		if isEventsDropped(msg) {
+			ready = s.ready
+		}
+		select {
+		case s.out <- msg:
+		case <-ready:
+			s.unpop(msg)
+		case <-s.cancelled:
+			return
+		}
+	}
+}
+
+// unpop puts the EventsDropped marker back in front of the buffered messages.
+func (s *Subscription) unpop(marker Message) {
+	s.bufMtx.Lock()
+	defer s.bufMtx.Unlock()
+	if len(s.buf) > 0 && isEventsDropped(s.buf[0]) {
+		s.buf[0] = eventsDroppedMessage(s.buf[0], marker.data.(EventsDropped).Count)
+		return
+	}
+	s.buf = append([]Message{marker}, s.buf...)
+}
+
+func isEventsDropped(msg Message) bool {
+	_, ok := msg.data.(EventsDropped)
+	return ok
+}
+
+// eventsDroppedMessage returns the marker counting n more dropped messages
+// than the given one.
+func eventsDroppedMessage(marker Message, n int) Message {
+	dropped, _ := marker.data.(EventsDropped)
+	dropped.Count += n
+	return NewMessage(dropped, nil)
+}
+
 // Message glues data and events together.
 type Message struct {
 	data   interface{}
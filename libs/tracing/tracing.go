@@ -0,0 +1,130 @@
+// Package tracing traces the lifecycle of the blocks with OpenTelemetry.
+//
+// The spans of a height are linked under a per-height trace, and carry the
+// height and round attributes. Unless an OTLP endpoint is configured, the
+// tracers are no-op ones, which record nothing.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracers of Tendermint.
+const TracerName = "github.com/tendermint/tendermint"
+
+// ServiceName is the name of the service of the exported spans.
+const ServiceName = "tenderdash"
+
+// The keys of the attributes of the spans.
+const (
+	HeightKey = attribute.Key("height")
+	RoundKey  = attribute.Key("round")
+)
+
+// Height returns the height attribute of a span.
+func Height(height int64) attribute.KeyValue {
+	return HeightKey.Int64(height)
+}
+
+// Round returns the round attribute of a span.
+func Round(round int32) attribute.KeyValue {
+	return RoundKey.Int64(int64(round))
+}
+
+// Provider creates the tracers and exports their spans.
+type Provider struct {
+	trace.TracerProvider
+
+	shutdown func(ctx context.Context) error
+}
+
+// NopProvider returns a Provider of no-op tracers, which record nothing.
+func NopProvider() *Provider {
+	return &Provider{
+		TracerProvider: trace.NewNoopTracerProvider(),
+		shutdown:       func(context.Context) error { return nil },
+	}
+}
+
+// NewProvider returns a Provider exporting the spans in batches to the OTLP
+// gRPC endpoint (host:port), e.g. an OpenTelemetry collector. The spans are
+// tagged with the instance, e.g. the moniker of the node.
+func NewProvider(ctx context.Context, endpoint, instance string) (*Provider, error) {
+	if endpoint == "" {
+		return nil, errors.New("empty endpoint")
+	}
+	exporter, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(
+		otlpgrpc.WithEndpoint(endpoint),
+		otlpgrpc.WithInsecure(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.ServiceNameKey.String(ServiceName),
+			semconv.ServiceInstanceIDKey.String(instance),
+		)),
+	)
+	return &Provider{
+		TracerProvider: provider,
+		shutdown:       provider.Shutdown,
+	}, nil
+}
+
+// Tracer returns the tracer of Tendermint.
+func (p *Provider) Tracer() trace.Tracer {
+	return p.TracerProvider.Tracer(TracerName)
+}
+
+// Shutdown exports the remaining spans and stops the exports.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.shutdown(ctx)
+}
+
+// NopTracer returns a no-op tracer, which records nothing.
+func NopTracer() trace.Tracer {
+	return trace.NewNoopTracerProvider().Tracer(TracerName)
+}
+
+// StartSpan starts a child span of the span of the context with its tracer,
+// so it records nothing if the context has no span.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordSpan records a span, which started at the given time and took the
+// duration, e.g. of an operation measured by someone else.
+func RecordSpan(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name string,
+	start time.Time,
+	duration time.Duration,
+	err error,
+	attrs ...attribute.KeyValue,
+) {
+	_, span := tracer.Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	EndSpan(span, err, trace.WithTimestamp(start.Add(duration)))
+}
+
+// EndSpan ends the span, marking it as failed if there's an error.
+func EndSpan(span trace.Span, err error, options ...trace.SpanOption) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(options...)
+}
@@ -0,0 +1,54 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/tendermint/tendermint/libs/tracing"
+)
+
+func TestStartSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)).Tracer(tracing.TracerName)
+
+	// without a span in the context nothing is recorded
+	_, span := tracing.StartSpan(context.Background(), "orphan")
+	span.End()
+	assert.Empty(t, exporter.GetSpans())
+
+	ctx, parent := tracer.Start(context.Background(), "height")
+	_, child := tracing.StartSpan(ctx, "commit", tracing.Height(5), tracing.Round(1))
+	child.End()
+	tracing.RecordSpan(ctx, tracer, "threshold_recovery", time.Now(), time.Second, errors.New("failed"))
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "commit", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext.TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.ElementsMatch(t, spans[0].Attributes, []attribute.KeyValue{tracing.Height(5), tracing.Round(1)})
+
+	assert.Equal(t, "threshold_recovery", spans[1].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent.SpanID())
+	assert.Equal(t, time.Second, spans[1].EndTime.Sub(spans[1].StartTime))
+	assert.Equal(t, codes.Error, spans[1].StatusCode)
+}
+
+func TestNopProvider(t *testing.T) {
+	provider := tracing.NopProvider()
+	ctx, span := provider.Tracer().Start(context.Background(), "height")
+	assert.False(t, span.SpanContext().IsValid())
+	_, child := tracing.StartSpan(ctx, "commit")
+	assert.False(t, child.SpanContext().IsValid())
+	assert.NoError(t, provider.Shutdown(context.Background()))
+}
@@ -1,7 +1,6 @@
 package light
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -416,7 +415,7 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 	l, err := c.TrustedLightBlock(newHeader.Height)
 	if err == nil {
 		// Make sure it's the same header.
-		if !bytes.Equal(l.Hash(), newHeader.Hash()) {
+		if !l.Hash().Equal(newHeader.Hash()) {
 			return fmt.Errorf("existing trusted header %X does not match newHeader %X", l.Hash(), newHeader.Hash())
 		}
 		c.logger.Info("Header has already been verified",
@@ -430,7 +429,7 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 		return fmt.Errorf("failed to retrieve light block from primary to verify against: %w", err)
 	}
 
-	if !bytes.Equal(l.Hash(), newHeader.Hash()) {
+	if !l.Hash().Equal(newHeader.Hash()) {
 		return fmt.Errorf("light block header %X does not match newHeader %X", l.Hash(), newHeader.Hash())
 	}
 
@@ -438,7 +437,8 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 }
 
 func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.LightBlock, now time.Time) error {
-	c.logger.Info("VerifyHeader", "height", newLightBlock.Height, "hash", newLightBlock.Hash())
+	logger := log.WithFields(c.logger, log.Fields{log.FieldHeight: newLightBlock.Height})
+	logger.Info("VerifyHeader", "hash", newLightBlock.Hash())
 
 	var (
 		verifyFunc func(ctx context.Context, new *types.LightBlock, now time.Time) error
@@ -455,7 +455,7 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 	err = verifyFunc(ctx, newLightBlock, now)
 
 	if err != nil {
-		c.logger.Error("Can't verify", "err", err)
+		logger.Error("Can't verify", "err", err)
 		return err
 	}
 
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/dashevo/dashd-go/btcjson"
 	"math/rand"
@@ -14,6 +15,7 @@ import (
 	"github.com/tendermint/tendermint/light/provider"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -30,18 +32,16 @@ var (
 type http struct {
 	chainID string
 	client  rpcclient.RemoteClient
+	// archive nodes to fall back to when client has pruned the requested height
+	archives []rpcclient.RemoteClient
 }
 
 // New creates a HTTP provider, which is using the rpchttp.HTTP client under
 // the hood. If no scheme is provided in the remote URL, http will be used by
-// default. The 5s timeout is used for all requests.
+// default. The remote can be a unix socket, e.g. unix:///var/run/tm.sock. The
+// 5s timeout is used for all requests.
 func New(chainID, remote string) (provider.Provider, error) {
-	// Ensure URL scheme is set (default HTTP) when not provided.
-	if !strings.Contains(remote, "://") {
-		remote = "http://" + remote
-	}
-
-	httpClient, err := rpchttp.NewWithTimeout(remote, "/websocket", timeout)
+	httpClient, err := newClient(remote)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +49,15 @@ func New(chainID, remote string) (provider.Provider, error) {
 	return NewWithClient(chainID, httpClient), nil
 }
 
+func newClient(remote string) (*rpchttp.HTTP, error) {
+	// Ensure URL scheme is set (default HTTP) when not provided.
+	if !strings.Contains(remote, "://") {
+		remote = "http://" + remote
+	}
+
+	return rpchttp.NewWithTimeout(remote, "/websocket", timeout)
+}
+
 // NewWithClient allows you to provide a custom client.
 func NewWithClient(chainID string, client rpcclient.RemoteClient) provider.Provider {
 	return &http{
@@ -57,6 +66,35 @@ func NewWithClient(chainID string, client rpcclient.RemoteClient) provider.Provi
 	}
 }
 
+// NewWithArchives creates a HTTP provider like New, which fetches the light
+// blocks of the heights pruned by the remote from the archive nodes. The
+// archive nodes are tried in the given order.
+func NewWithArchives(chainID, remote string, archives []string) (provider.Provider, error) {
+	httpClient, err := newClient(remote)
+	if err != nil {
+		return nil, err
+	}
+	archiveClients := make([]rpcclient.RemoteClient, len(archives))
+	for i, archive := range archives {
+		if archiveClients[i], err = newClient(archive); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewWithClientAndArchives(chainID, httpClient, archiveClients), nil
+}
+
+// NewWithClientAndArchives allows you to provide custom clients of the remote
+// and the archive nodes, see NewWithArchives.
+func NewWithClientAndArchives(chainID string, client rpcclient.RemoteClient,
+	archives []rpcclient.RemoteClient) provider.Provider {
+	return &http{
+		client:   client,
+		chainID:  chainID,
+		archives: archives,
+	}
+}
+
 // ChainID returns a chainID this provider was configured with.
 func (p *http) ChainID() string {
 	return p.chainID
@@ -67,14 +105,32 @@ func (p *http) String() string {
 }
 
 // LightBlock fetches a LightBlock at the given height and checks the
-// chainID matches.
+// chainID matches. If the remote has pruned the height, the LightBlock is
+// fetched from the archive nodes.
 func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
 	h, err := validateHeight(height)
 	if err != nil {
 		return nil, provider.ErrBadLightBlock{Reason: err}
 	}
 
-	sh, err := p.signedHeader(ctx, h)
+	lb, err := p.lightBlock(ctx, p.client, h, height)
+	if isHeightPruned(err) {
+		for _, archive := range p.archives {
+			lb, err = p.lightBlock(ctx, archive, h, height)
+			if err == nil {
+				break
+			}
+		}
+	}
+	if isHeightPruned(err) {
+		return nil, provider.ErrLightBlockNotFound
+	}
+	return lb, err
+}
+
+func (p *http) lightBlock(ctx context.Context, client rpcclient.RemoteClient, h *int64,
+	height int64) (*types.LightBlock, error) {
+	sh, err := p.signedHeader(ctx, client, h)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +141,7 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 		}
 	}
 
-	vs, err := p.validatorSet(ctx, &sh.Height)
+	vs, err := p.validatorSet(ctx, client, &sh.Height)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +165,10 @@ func (p *http) ReportEvidence(ctx context.Context, ev types.Evidence) error {
 	return err
 }
 
-func (p *http) validatorSet(ctx context.Context, height *int64) (*types.ValidatorSet, error) {
+// validatorSet fetches the validator set of the height from the client. The
+// ctypes.ErrHeightPruned of the client is returned as is.
+func (p *http) validatorSet(ctx context.Context, client rpcclient.RemoteClient,
+	height *int64) (*types.ValidatorSet, error) {
 	// Since the malicious node could report a massive number of pages, making us
 	// spend a considerable time iterating, we restrict the number of pages here.
 	// => 10000 validators max
@@ -128,13 +187,15 @@ func (p *http) validatorSet(ctx context.Context, height *int64) (*types.Validato
 	for len(vals) != total && page <= maxPages {
 		for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
 			requestThresholdPublicKey := attempt == 1
-			res, err := p.client.Validators(ctx, height, &page, &perPage, &requestThresholdPublicKey)
+			res, err := client.Validators(ctx, height, &page, &perPage, &requestThresholdPublicKey)
 			if err != nil {
 				// TODO: standardize errors on the RPC side
 				if regexpTooHigh.MatchString(err.Error()) {
 					return nil, provider.ErrHeightTooHigh
 				}
-
+				if isHeightPruned(err) {
+					return nil, err
+				}
 				if regexpMissingHeight.MatchString(err.Error()) {
 					return nil, provider.ErrLightBlockNotFound
 				}
@@ -179,15 +240,20 @@ func (p *http) validatorSet(ctx context.Context, height *int64) (*types.Validato
 	return valSet, nil
 }
 
-func (p *http) signedHeader(ctx context.Context, height *int64) (*types.SignedHeader, error) {
+// signedHeader fetches the signed header of the height from the client. The
+// ctypes.ErrHeightPruned of the client is returned as is.
+func (p *http) signedHeader(ctx context.Context, client rpcclient.RemoteClient,
+	height *int64) (*types.SignedHeader, error) {
 	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
-		commit, err := p.client.Commit(ctx, height)
+		commit, err := client.Commit(ctx, height)
 		if err != nil {
 			// TODO: standardize errors on the RPC side
 			if regexpTooHigh.MatchString(err.Error()) {
 				return nil, provider.ErrHeightTooHigh
 			}
-
+			if isHeightPruned(err) {
+				return nil, err
+			}
 			if regexpMissingHeight.MatchString(err.Error()) {
 				return nil, provider.ErrLightBlockNotFound
 			}
@@ -200,6 +266,11 @@ func (p *http) signedHeader(ctx context.Context, height *int64) (*types.SignedHe
 	return nil, provider.ErrNoResponse
 }
 
+func isHeightPruned(err error) bool {
+	var errPruned ctypes.ErrHeightPruned
+	return errors.As(err, &errPruned)
+}
+
 func validateHeight(height int64) (*int64, error) {
 	if height < 0 {
 		return nil, fmt.Errorf("expected height >= 0, got height %d", height)
@@ -7,11 +7,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/light/provider"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	rpcmock "github.com/tendermint/tendermint/rpc/client/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 )
@@ -28,6 +32,10 @@ func TestNewProvider(t *testing.T) {
 	c, err = lighthttp.New("chain-test", "153.200.0.1")
 	require.NoError(t, err)
 	require.Equal(t, fmt.Sprintf("%s", c), "http{http://153.200.0.1}")
+
+	c, err = lighthttp.New("chain-test", "unix:///var/run/tm.sock")
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%s", c), "http{unix:///var/run/tm.sock}")
 }
 
 func TestMain(m *testing.M) {
@@ -87,3 +95,52 @@ func TestProvider(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, provider.ErrLightBlockNotFound, err)
 }
+
+// remoteClient is a mock client of a remote node
+type remoteClient struct {
+	*rpcmock.Client
+	remote string
+}
+
+func (c remoteClient) Remote() string {
+	return c.remote
+}
+
+func TestProviderArchives(t *testing.T) {
+	cfg := rpctest.GetConfig()
+	c, err := rpchttp.New(cfg.RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, rpcclient.WaitForHeight(c, 10, nil))
+	status, err := c.Status(context.Background())
+	require.NoError(t, err)
+	chainID := status.NodeInfo.Network
+	height := status.SyncInfo.LatestBlockHeight - 1
+
+	// the primary and an archive have pruned the height
+	newPrunedClient := func(remote string) remoteClient {
+		c := remoteClient{Client: &rpcmock.Client{}, remote: remote}
+		c.On("Commit", mock.Anything, mock.Anything).Return(nil,
+			ctypes.ErrHeightPruned{Height: height, EarliestHeight: height + 1})
+		return c
+	}
+	primary := newPrunedClient("primary")
+	prunedArchive := newPrunedClient("archive")
+
+	// the archives are asked in turn, the light block is fetched from the
+	// first one, which has the height
+	p := lighthttp.NewWithClientAndArchives(chainID, primary, []rpcclient.RemoteClient{prunedArchive, c})
+	lb, err := p.LightBlock(context.Background(), height)
+	require.NoError(t, err)
+	assert.Equal(t, height, lb.Height)
+	prunedArchive.AssertCalled(t, "Commit", mock.Anything, mock.Anything)
+
+	// the light block isn't found, if the archives have pruned the height too
+	p = lighthttp.NewWithClientAndArchives(chainID, primary, []rpcclient.RemoteClient{prunedArchive})
+	_, err = p.LightBlock(context.Background(), height)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+
+	// no archives
+	p = lighthttp.NewWithClient(chainID, primary)
+	_, err = p.LightBlock(context.Background(), height)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+}
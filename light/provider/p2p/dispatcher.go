@@ -0,0 +1,175 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmp2p "github.com/tendermint/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	"github.com/tendermint/tendermint/statesync"
+)
+
+// lightBlockMsgSize is the maximum size of a light block response, it matches
+// the one of the statesync reactor serving the light blocks.
+const lightBlockMsgSize = int(1e7)
+
+var (
+	errPeerNotConnected = errors.New("peer is not connected")
+	errPeerDisconnected = errors.New("peer disconnected")
+)
+
+// request identifies the requests of a light block at a height of a peer,
+// 0 is the latest one.
+type request struct {
+	peer   tmp2p.ID
+	height uint64
+}
+
+// Dispatcher is the reactor of a light client, which requests the light blocks
+// from the full nodes over the statesync.LightBlockChannel and dispatches the
+// responses to the waiting providers.
+type Dispatcher struct {
+	tmp2p.BaseReactor
+
+	mtx     tmsync.Mutex
+	pending map[request][]chan *ssproto.LightBlockResponse
+}
+
+// NewDispatcher creates a new light block dispatcher.
+func NewDispatcher() *Dispatcher {
+	d := &Dispatcher{
+		pending: make(map[request][]chan *ssproto.LightBlockResponse),
+	}
+	d.BaseReactor = *tmp2p.NewBaseReactor("LightBlockDispatcher", d)
+	return d
+}
+
+// GetChannels implements p2p.Reactor.
+func (d *Dispatcher) GetChannels() []*tmp2p.ChannelDescriptor {
+	return []*tmp2p.ChannelDescriptor{
+		{
+			ID:                  statesync.LightBlockChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: lightBlockMsgSize,
+		},
+	}
+}
+
+// RemovePeer implements p2p.Reactor. The pending requests of the peer fail.
+func (d *Dispatcher) RemovePeer(peer tmp2p.Peer, reason interface{}) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for req, waiting := range d.pending {
+		if req.peer != peer.ID() {
+			continue
+		}
+		for _, ch := range waiting {
+			close(ch)
+		}
+		delete(d.pending, req)
+	}
+}
+
+// Receive implements p2p.Reactor.
+func (d *Dispatcher) Receive(chID byte, src tmp2p.Peer, msgBytes []byte) {
+	if !d.IsRunning() {
+		return
+	}
+
+	msg := &ssproto.Message{}
+	if err := proto.Unmarshal(msgBytes, msg); err != nil {
+		d.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		d.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	switch msg := msg.Sum.(type) {
+	case *ssproto.Message_LightBlockResponse:
+		res := msg.LightBlockResponse
+		d.mtx.Lock()
+		req := request{peer: src.ID(), height: res.Height}
+		waiting := d.pending[req]
+		delete(d.pending, req)
+		d.mtx.Unlock()
+
+		if len(waiting) == 0 {
+			d.Logger.Debug("Received unexpected light block", "height", res.Height, "peer", src.ID())
+			return
+		}
+		for _, ch := range waiting {
+			ch <- res
+		}
+
+	case *ssproto.Message_LightBlockRequest:
+		d.Logger.Debug("Ignoring light block request, the light client doesn't serve light blocks",
+			"peer", src.ID())
+
+	default:
+		d.Logger.Error("Received unknown message", "msg", fmt.Sprintf("%T", msg), "peer", src.ID())
+	}
+}
+
+// LightBlock requests the light block at the height from the peer, 0 requests
+// the latest one, and waits for the response.
+func (d *Dispatcher) LightBlock(
+	ctx context.Context,
+	peerID tmp2p.ID,
+	height uint64,
+) (*ssproto.LightBlockResponse, error) {
+	peer := d.Switch.Peers().Get(peerID)
+	if peer == nil {
+		return nil, errPeerNotConnected
+	}
+
+	req := request{peer: peerID, height: height}
+	ch := make(chan *ssproto.LightBlockResponse, 1)
+	d.mtx.Lock()
+	d.pending[req] = append(d.pending[req], ch)
+	d.mtx.Unlock()
+
+	bz, err := (&ssproto.Message{Sum: &ssproto.Message_LightBlockRequest{
+		LightBlockRequest: &ssproto.LightBlockRequest{Height: height},
+	}}).Marshal()
+	if err != nil {
+		d.cancel(req, ch)
+		return nil, err
+	}
+	if !peer.Send(statesync.LightBlockChannel, bz) {
+		d.cancel(req, ch)
+		return nil, fmt.Errorf("failed to send the request to peer %s", peerID)
+	}
+
+	select {
+	case res, ok := <-ch:
+		if !ok {
+			return nil, errPeerDisconnected
+		}
+		return res, nil
+	case <-ctx.Done():
+		d.cancel(req, ch)
+		return nil, ctx.Err()
+	}
+}
+
+// cancel stops waiting for the response to the request.
+func (d *Dispatcher) cancel(req request, ch chan *ssproto.LightBlockResponse) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	waiting := d.pending[req]
+	for i, c := range waiting {
+		if c == ch {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(waiting) == 0 {
+		delete(d.pending, req)
+	} else {
+		d.pending[req] = waiting
+	}
+}
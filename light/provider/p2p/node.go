@@ -0,0 +1,109 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/light/provider"
+	tmp2p "github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/statesync"
+	"github.com/tendermint/tendermint/version"
+)
+
+// AddressPrefix is the prefix of the addresses of the full nodes, which serve
+// the light blocks over p2p: p2p://<node ID>@<host>:<port>.
+const AddressPrefix = "p2p://"
+
+// IsAddress returns true if the address is the one of a full node, which
+// serves the light blocks over p2p.
+func IsAddress(addr string) bool {
+	return strings.HasPrefix(addr, AddressPrefix)
+}
+
+// Node is the p2p node of a light client. It dials the full nodes, and
+// requests the light blocks from them over the statesync.LightBlockChannel.
+// It doesn't accept incoming connections.
+type Node struct {
+	service.BaseService
+
+	chainID    string
+	sw         *tmp2p.Switch
+	dispatcher *Dispatcher
+}
+
+// NewNode creates the p2p node of a light client of the chain.
+func NewNode(chainID string, nodeKey *tmp2p.NodeKey, config *cfg.P2PConfig, logger log.Logger) (*Node, error) {
+	listenAddr := config.ExternalAddress
+	if listenAddr == "" {
+		listenAddr = config.ListenAddress
+	}
+	nodeInfo := tmp2p.DefaultNodeInfo{
+		ProtocolVersion: tmp2p.NewProtocolVersion(version.P2PProtocol, version.BlockProtocol, 0),
+		DefaultNodeID:   nodeKey.ID(),
+		ListenAddr:      listenAddr,
+		Network:         chainID,
+		Version:         version.TMCoreSemVer,
+		Channels:        []byte{statesync.LightBlockChannel},
+		Moniker:         "light",
+	}
+	if err := nodeInfo.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid node info: %w", err)
+	}
+
+	dispatcher := NewDispatcher()
+	dispatcher.SetLogger(logger.With("module", "light-p2p"))
+
+	transport := tmp2p.NewMultiplexTransport(nodeInfo, *nodeKey, tmp2p.MConnConfig(config))
+	sw := tmp2p.NewSwitch(config, transport)
+	sw.SetLogger(logger.With("module", "p2p"))
+	sw.SetNodeKey(nodeKey)
+	sw.SetNodeInfo(nodeInfo)
+	sw.AddReactor("LIGHTBLOCKS", dispatcher)
+
+	n := &Node{
+		chainID:    chainID,
+		sw:         sw,
+		dispatcher: dispatcher,
+	}
+	n.BaseService = *service.NewBaseService(logger, "LightP2PNode", n)
+	return n, nil
+}
+
+// OnStart implements service.Service.
+func (n *Node) OnStart() error {
+	return n.sw.Start()
+}
+
+// OnStop implements service.Service.
+func (n *Node) OnStop() {
+	if err := n.sw.Stop(); err != nil {
+		n.Logger.Error("Error stopping the switch", "err", err)
+	}
+}
+
+// Providers dials the full nodes at the addresses (see AddressPrefix), and
+// returns the providers of the light blocks served by them. The node
+// reconnects to the full nodes, if they disconnect. It must be started.
+func (n *Node) Providers(addrs []string) ([]provider.Provider, error) {
+	peers := make([]string, len(addrs))
+	providers := make([]provider.Provider, len(addrs))
+	for i, addr := range addrs {
+		peers[i] = strings.TrimPrefix(addr, AddressPrefix)
+		netAddr, err := tmp2p.NewNetAddressString(peers[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid p2p address %s: %w", addr, err)
+		}
+		providers[i] = New(n.chainID, netAddr.ID, n.dispatcher)
+	}
+
+	if err := n.sw.AddPersistentPeers(peers); err != nil {
+		return nil, err
+	}
+	if err := n.sw.DialPeersAsync(peers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
@@ -0,0 +1,128 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/tendermint/tendermint/light/provider"
+	tmp2p "github.com/tendermint/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	// the requests are retried with a backoff, while the peer is connecting
+	// or doesn't respond
+	maxRetryAttempts = 5
+	requestTimeout   = 10 * time.Second
+
+	errEvidenceNotSupported = errors.New("reporting evidence over p2p is not supported")
+)
+
+// p2p provider requests the light blocks from a full node over the p2p
+// connection of the Dispatcher.
+type p2p struct {
+	chainID    string
+	peerID     tmp2p.ID
+	dispatcher *Dispatcher
+}
+
+// New creates a p2p provider, which requests the light blocks from the peer
+// through the dispatcher. The peer is dialed by the switch of the dispatcher,
+// see Node.
+func New(chainID string, peerID tmp2p.ID, dispatcher *Dispatcher) provider.Provider {
+	return &p2p{
+		chainID:    chainID,
+		peerID:     peerID,
+		dispatcher: dispatcher,
+	}
+}
+
+// ChainID returns a chainID this provider was configured with.
+func (p *p2p) ChainID() string {
+	return p.chainID
+}
+
+func (p *p2p) String() string {
+	return fmt.Sprintf("p2p{%s}", p.peerID)
+}
+
+// LightBlock requests a LightBlock at the given height from the peer and
+// checks the chainID matches.
+func (p *p2p) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("expected height >= 0, got height %d", height)}
+	}
+
+	res, err := p.request(ctx, uint64(height))
+	if err != nil {
+		return nil, err
+	}
+	if res.LightBlock == nil {
+		return nil, p.notFound(ctx, height)
+	}
+
+	lb, err := types.LightBlockFromProto(res.LightBlock)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if height != 0 && lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// ReportEvidence is not supported, the peers serve the light blocks only.
+func (p *p2p) ReportEvidence(context.Context, types.Evidence) error {
+	return errEvidenceNotSupported
+}
+
+// request requests the light block at the height, retrying with exponential
+// backoff.
+func (p *p2p) request(ctx context.Context, height uint64) (*ssproto.LightBlockResponse, error) {
+	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		res, err := p.dispatcher.LightBlock(reqCtx, p.peerID, height)
+		cancel()
+		if err == nil {
+			return res, nil
+		}
+		if attempt == maxRetryAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoffTimeout(uint16(attempt))):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, provider.ErrNoResponse
+}
+
+// notFound tells a light block above the latest one of the peer from a
+// missing one, e.g. pruned.
+func (p *p2p) notFound(ctx context.Context, height int64) error {
+	if height == 0 {
+		return provider.ErrLightBlockNotFound
+	}
+	res, err := p.request(ctx, 0)
+	if err == nil && res.LightBlock != nil && res.LightBlock.GetSignedHeader().GetHeader().GetHeight() < height {
+		return provider.ErrHeightTooHigh
+	}
+	return provider.ErrLightBlockNotFound
+}
+
+// exponential backoff (with jitter)
+// 0.5s -> 2s -> 4.5s -> 8s with 1s variation
+func backoffTimeout(attempt uint16) time.Duration {
+	// nolint:gosec // G404: Use of weak random number generator
+	return time.Duration(500*attempt*attempt)*time.Millisecond + time.Duration(rand.Intn(1000))*time.Millisecond
+}
@@ -0,0 +1,168 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/light/provider"
+	tmp2p "github.com/tendermint/tendermint/p2p"
+	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	"github.com/tendermint/tendermint/statesync"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+	"github.com/tendermint/tendermint/version"
+)
+
+const chainID = "test-chain"
+
+// server serves the light blocks like the statesync reactor of a full node,
+// 0 serves the latest one.
+type server struct {
+	tmp2p.BaseReactor
+
+	blocks map[uint64]*tmproto.LightBlock
+	latest uint64
+}
+
+func newServer(blocks map[uint64]*tmproto.LightBlock, latest uint64) *server {
+	s := &server{blocks: blocks, latest: latest}
+	s.BaseReactor = *tmp2p.NewBaseReactor("LightBlockServer", s)
+	return s
+}
+
+func (s *server) GetChannels() []*tmp2p.ChannelDescriptor {
+	return NewDispatcher().GetChannels()
+}
+
+func (s *server) Receive(chID byte, src tmp2p.Peer, msgBytes []byte) {
+	msg := &ssproto.Message{}
+	if err := proto.Unmarshal(msgBytes, msg); err != nil {
+		panic(err)
+	}
+	req := msg.GetLightBlockRequest()
+	if req == nil {
+		return
+	}
+	height := req.Height
+	if height == 0 {
+		height = s.latest
+	}
+	bz, err := (&ssproto.Message{Sum: &ssproto.Message_LightBlockResponse{
+		LightBlockResponse: &ssproto.LightBlockResponse{LightBlock: s.blocks[height], Height: req.Height},
+	}}).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	src.Send(statesync.LightBlockChannel, bz)
+}
+
+// makeLightBlock makes a light block, which passes the basic validation. The
+// signatures aren't verified by the provider.
+func makeLightBlock(t *testing.T, height int64, vals *types.ValidatorSet) *tmproto.LightBlock {
+	header := &types.Header{
+		Version:            tmversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            chainID,
+		Height:             height,
+		Time:               tmtime.Now(),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		ProposerProTxHash:  crypto.RandProTxHash(),
+	}
+	commit := &types.Commit{
+		Height: height,
+		BlockID: types.BlockID{
+			Hash:          header.Hash(),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(32)},
+		},
+		QuorumHash:              vals.QuorumHash,
+		ThresholdBlockSignature: tmrand.Bytes(types.SignatureSize),
+		ThresholdStateSignature: tmrand.Bytes(types.SignatureSize),
+	}
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+	require.NoError(t, lb.ValidateBasic(chainID))
+	pb, err := lb.ToProto()
+	require.NoError(t, err)
+	return pb
+}
+
+func TestProvider(t *testing.T) {
+	defer func(attempts int) { maxRetryAttempts = attempts }(maxRetryAttempts)
+	maxRetryAttempts = 1
+
+	vals, _ := types.GenerateValidatorSet(4)
+	lb2 := makeLightBlock(t, 2, vals)
+	// the block at height 1 is pruned, and the one at height 3 is malformed
+	srv := newServer(map[uint64]*tmproto.LightBlock{2: lb2, 3: lb2}, 3)
+	dispatcher := NewDispatcher()
+
+	proTxHashes := []*crypto.ProTxHash{new(crypto.ProTxHash), new(crypto.ProTxHash)}
+	for _, proTxHash := range proTxHashes {
+		*proTxHash = crypto.RandProTxHash()
+	}
+	switches := tmp2p.MakeConnectedSwitches(cfg.TestP2PConfig(), proTxHashes,
+		func(i int, sw *tmp2p.Switch) *tmp2p.Switch {
+			if i == 0 {
+				sw.AddReactor("LIGHTBLOCKS", srv)
+			} else {
+				sw.AddReactor("LIGHTBLOCKS", dispatcher)
+			}
+			return sw
+		}, tmp2p.Connect2Switches)
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			_ = sw.Stop()
+		}
+	})
+	peerID := switches[0].NodeInfo().ID()
+
+	p := New(chainID, peerID, dispatcher)
+	assert.Equal(t, "p2p{"+string(peerID)+"}", p.(interface{ String() string }).String())
+	ctx := context.Background()
+
+	lb, err := p.LightBlock(ctx, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, lb.Height)
+
+	lb, err = p.LightBlock(ctx, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, lb.Height)
+
+	_, err = p.LightBlock(ctx, 1)
+	assert.Equal(t, provider.ErrLightBlockNotFound, err)
+
+	_, err = p.LightBlock(ctx, 5)
+	assert.Equal(t, provider.ErrHeightTooHigh, err)
+
+	_, err = p.LightBlock(ctx, 3)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	_, err = p.LightBlock(ctx, -1)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	_, err = New("other-chain", peerID, dispatcher).LightBlock(ctx, 2)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+
+	// the requests to a peer, which isn't connected, fail
+	_, err = New(chainID, "unknown", dispatcher).LightBlock(ctx, 2)
+	assert.Equal(t, provider.ErrNoResponse, err)
+
+	assert.Equal(t, errEvidenceNotSupported, p.ReportEvidence(ctx, nil))
+}
+
+func TestIsAddress(t *testing.T) {
+	assert.True(t, IsAddress("p2p://ad3b09cce2ee5e4e5b03b1e9b8ad4c1a5a0b4d8c@127.0.0.1:26656"))
+	assert.False(t, IsAddress("http://127.0.0.1:26657"))
+	assert.False(t, IsAddress("tcp://127.0.0.1:26657"))
+}
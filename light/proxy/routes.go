@@ -18,23 +18,28 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
 
 		// info API
-		"health":        rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
-		"status":        rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
-		"net_info":      rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
-		"blockchain":    rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight"),
-		"genesis":       rpcserver.NewRPCFunc(makeGenesisFunc(c), ""),
-		"block":         rpcserver.NewRPCFunc(makeBlockFunc(c), "height"),
-		"block_by_hash": rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash"),
-		"block_results": rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height"),
-		"commit":        rpcserver.NewRPCFunc(makeCommitFunc(c), "height"),
-		"tx":            rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove"),
-		"tx_search":     rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
-		"validators":    rpcserver.NewRPCFunc(makeValidatorsFunc(c),
+		"health":          rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
+		"status":          rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
+		"net_info":        rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
+		"blockchain":      rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight"),
+		"genesis":         rpcserver.NewRPCFunc(makeGenesisFunc(c), ""),
+		"genesis_chunked": rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "chunk"),
+		"block":           rpcserver.NewRPCFunc(makeBlockFunc(c), "height"),
+		"block_by_hash":   rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash"),
+		"block_results":   rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height"),
+		"commit":          rpcserver.NewRPCFunc(makeCommitFunc(c), "height"),
+		"tx":              rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove"),
+		"tx_search":       rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
+		"validators": rpcserver.NewRPCFunc(makeValidatorsFunc(c),
 			"height,page,per_page,request_threshold_public_key"),
 
+		"validator_quorum": rpcserver.NewRPCFunc(makeValidatorQuorumFunc(c), "height"),
+		"proposer":         rpcserver.NewRPCFunc(makeProposerFunc(c), "height,round"),
+
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
 		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height"),
+		"block_params":         rpcserver.NewRPCFunc(makeBlockParamsFunc(c), "height"),
 		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
 
@@ -93,6 +98,14 @@ func makeGenesisFunc(c *lrpc.Client) rpcGenesisFunc {
 	}
 }
 
+type rpcGenesisChunkedFunc func(ctx *rpctypes.Context, chunk uint) (*ctypes.ResultGenesisChunk, error)
+
+func makeGenesisChunkedFunc(c *lrpc.Client) rpcGenesisChunkedFunc {
+	return func(ctx *rpctypes.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+		return c.GenesisChunked(ctx.Context(), chunk)
+	}
+}
+
 type rpcBlockFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error)
 
 func makeBlockFunc(c *lrpc.Client) rpcBlockFunc {
@@ -183,6 +196,22 @@ func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
 	}
 }
 
+type rpcValidatorQuorumFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultValidatorQuorum, error)
+
+func makeValidatorQuorumFunc(c *lrpc.Client) rpcValidatorQuorumFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultValidatorQuorum, error) {
+		return c.ValidatorQuorum(ctx.Context(), height)
+	}
+}
+
+type rpcProposerFunc func(ctx *rpctypes.Context, height *int64, round *int) (*ctypes.ResultProposer, error)
+
+func makeProposerFunc(c *lrpc.Client) rpcProposerFunc {
+	return func(ctx *rpctypes.Context, height *int64, round *int) (*ctypes.ResultProposer, error) {
+		return c.Proposer(ctx.Context(), height, round)
+	}
+}
+
 type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error)
 
 func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
@@ -207,6 +236,14 @@ func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
 	}
 }
 
+type rpcBlockParamsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockParams, error)
+
+func makeBlockParamsFunc(c *lrpc.Client) rpcBlockParamsFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockParams, error) {
+		return c.BlockParams(ctx.Context(), height)
+	}
+}
+
 type rpcUnconfirmedTxsFunc func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 
 func makeUnconfirmedTxsFunc(c *lrpc.Client) rpcUnconfirmedTxsFunc {
@@ -1,7 +1,6 @@
 package rpc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -17,6 +16,7 @@ import (
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	service "github.com/tendermint/tendermint/libs/service"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -25,6 +25,32 @@ import (
 
 var errNegOrZeroHeight = errors.New("negative or zero height")
 
+// ErrCannotVerify is returned when the data of a height can't be verified,
+// because the light client can't verify the header of the height, e.g. the
+// height is outside of the trusted range.
+type ErrCannotVerify struct {
+	Height int64 // 0 for the latest height
+	Reason error
+}
+
+func (e ErrCannotVerify) Error() string {
+	if e.Height == 0 {
+		return fmt.Sprintf("cannot verify the latest height: %v", e.Reason)
+	}
+	return fmt.Sprintf("cannot verify height %d: %v", e.Height, e.Reason)
+}
+
+// Unwrap returns the underlying reason.
+func (e ErrCannotVerify) Unwrap() error {
+	return e.Reason
+}
+
+// ErrCannotVerifyParams is returned for the consensus params, which aren't
+// hashed into the header (the evidence, validator, version, ABCI and synchrony
+// params), so the light client can't verify them.
+var ErrCannotVerifyParams = errors.New("cannot verify the consensus params other than the block params, " +
+	"which aren't hashed into the header")
+
 // KeyPathFunc builds a merkle path out of the given path and key.
 type KeyPathFunc func(path string, key []byte) (merkle.KeyPath, error)
 
@@ -47,6 +73,8 @@ type Client struct {
 
 	next rpcclient.Client
 	lc   LightClient
+	// archive nodes to fall back to when next has pruned the requested height
+	archives []rpcclient.Client
 
 	// proof runtime used to verify values returned by ABCIQuery
 	prt       *merkle.ProofRuntime
@@ -67,6 +95,17 @@ func KeyPathFn(fn KeyPathFunc) Option {
 	}
 }
 
+// ArchiveClients option can be used to set clients of archive nodes, which are
+// asked for a block, block results or validators when next has pruned the
+// requested height. The clients are tried in the given order. The light blocks,
+// which the results are verified against (and which Commit returns), are
+// fetched by the light client, see light/provider/http.NewWithArchives.
+func ArchiveClients(clients ...rpcclient.Client) Option {
+	return func(c *Client) {
+		c.archives = clients
+	}
+}
+
 // DefaultMerkleKeyPathFn creates a function used to generate merkle key paths
 // from a path string and a key. This is the default used by the cosmos SDK.
 // This merkle key paths are required when verifying /abci_query calls
@@ -228,7 +267,24 @@ func (c *Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusSta
 	return c.next.ConsensusState(ctx)
 }
 
+// ConsensusParams returns ErrCannotVerifyParams, because only the block params
+// are hashed into the header, so the other params can't be verified. Use
+// BlockParams to get the verified block params.
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+	return nil, ErrCannotVerifyParams
+}
+
+// BlockParams fetches the consensus params of the height from the primary,
+// verifies them against the trusted header of the height, and returns the
+// block params, which are the only params hashed into the header.
+func (c *Client) BlockParams(ctx context.Context, height *int64) (*ctypes.ResultBlockParams, error) {
+	// Don't proxy the requests for the heights, which can't be verified.
+	if height != nil {
+		if _, err := c.updateLightClientIfNeededTo(ctx, height); err != nil {
+			return nil, err
+		}
+	}
+
 	res, err := c.next.ConsensusParams(ctx, height)
 	if err != nil {
 		return nil, err
@@ -241,6 +297,9 @@ func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Re
 	if res.BlockHeight <= 0 {
 		return nil, errNegOrZeroHeight
 	}
+	if height != nil && res.BlockHeight != *height {
+		return nil, fmt.Errorf("params height %d does not match the requested height %d", res.BlockHeight, *height)
+	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.BlockHeight)
@@ -249,12 +308,18 @@ func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.Re
 	}
 
 	// Verify hash.
-	if cH, tH := types.HashConsensusParams(res.ConsensusParams), l.ConsensusHash; !bytes.Equal(cH, tH) {
+	if cH, tH := types.HashConsensusParams(res.ConsensusParams), l.ConsensusHash; !tH.Equal(cH) {
 		return nil, fmt.Errorf("params hash %X does not match trusted hash %X",
 			cH, tH)
 	}
 
-	return res, nil
+	return &ctypes.ResultBlockParams{
+		BlockHeight: res.BlockHeight,
+		BlockParams: tmproto.BlockParams{
+			MaxBytes: res.ConsensusParams.Block.MaxBytes,
+			MaxGas:   res.ConsensusParams.Block.MaxGas,
+		},
+	}, nil
 }
 
 func (c *Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
@@ -293,7 +358,7 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 		if err != nil {
 			return nil, fmt.Errorf("trusted header %d: %w", meta.Header.Height, err)
 		}
-		if bmH, tH := meta.Header.Hash(), h.Hash(); !bytes.Equal(bmH, tH) {
+		if bmH, tH := meta.Header.Hash(), h.Hash(); !bmH.Equal(tH) {
 			return nil, fmt.Errorf("block meta header %X does not match with trusted header %X",
 				bmH, tH)
 		}
@@ -306,9 +371,23 @@ func (c *Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return c.next.Genesis(ctx)
 }
 
+// GenesisChunked calls rpcclient#GenesisChunked. The chunks aren't verified,
+// like the genesis doc of Genesis.
+func (c *Client) GenesisChunked(ctx context.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+	return c.next.GenesisChunked(ctx, chunk)
+}
+
 // Block calls rpcclient#Block and then verifies the result.
 func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	res, err := c.next.Block(ctx, height)
+	if err != nil && c.isHeightPruned(err) {
+		for _, archive := range c.archives {
+			res, err = archive.Block(ctx, height)
+			if err == nil {
+				break
+			}
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -320,7 +399,7 @@ func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock,
 	if err := res.Block.ValidateBasic(); err != nil {
 		return nil, err
 	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
+	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bmH.Equal(bH) {
 		return nil, fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
@@ -332,7 +411,7 @@ func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock,
 	}
 
 	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
+	if bH, tH := res.Block.Hash(), l.Hash(); !bH.Equal(tH) {
 		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
 			bH, tH)
 	}
@@ -354,7 +433,7 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 	if err := res.Block.ValidateBasic(); err != nil {
 		return nil, err
 	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
+	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bmH.Equal(bH) {
 		return nil, fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
@@ -366,7 +445,7 @@ func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBl
 	}
 
 	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
+	if bH, tH := res.Block.Hash(), l.Hash(); !bH.Equal(tH) {
 		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
 			bH, tH)
 	}
@@ -391,6 +470,14 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 	}
 
 	res, err := c.next.BlockResults(ctx, &h)
+	if err != nil && c.isHeightPruned(err) {
+		for _, archive := range c.archives {
+			res, err = archive.BlockResults(ctx, &h)
+			if err == nil {
+				break
+			}
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -430,7 +517,7 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.Resul
 	rH := merkle.HashFromByteSlices([][]byte{bbeBytes, results.Hash(), ebeBytes})
 
 	// Verify block results.
-	if !bytes.Equal(rH, trustedBlock.LastResultsHash) {
+	if !trustedBlock.LastResultsHash.Equal(rH) {
 		return nil, fmt.Errorf("last results %X does not match with trusted last results %X",
 			rH, trustedBlock.LastResultsHash)
 	}
@@ -494,7 +581,8 @@ func (c *Client) BlockSearch(
 	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
 }
 
-// Validators fetches and verifies validators.
+// Validators fetches the validator set of the height from the primary, and
+// verifies it against the trusted header of the height.
 func (c *Client) Validators(ctx context.Context, height *int64, pagePtr, perPagePtr *int,
 	requestThresholdPublicKey *bool) (*ctypes.ResultValidators,
 	error) {
@@ -505,7 +593,15 @@ func (c *Client) Validators(ctx context.Context, height *int64, pagePtr, perPage
 		return nil, err
 	}
 
-	totalCount := len(l.ValidatorSet.Validators)
+	vals, err := c.fetchValidatorSet(ctx, l.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyValidatorSet(vals, l); err != nil {
+		return nil, fmt.Errorf("validator set of height %d: %w", l.Height, err)
+	}
+
+	totalCount := len(vals.Validators)
 	perPage := validatePerPage(perPagePtr)
 	page, err := validatePage(pagePtr, perPage, totalCount)
 	if err != nil {
@@ -513,20 +609,151 @@ func (c *Client) Validators(ctx context.Context, height *int64, pagePtr, perPage
 	}
 
 	skipCount := validateSkipCount(page, perPage)
-	v := l.ValidatorSet.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
+	v := vals.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
 	var thresholdPublicKey crypto.PubKey = nil
-	if *requestThresholdPublicKey {
-		thresholdPublicKey = l.ValidatorSet.ThresholdPublicKey
+	if requestThresholdPublicKey != nil && *requestThresholdPublicKey {
+		thresholdPublicKey = vals.ThresholdPublicKey
 	}
 
 	return &ctypes.ResultValidators{
 		BlockHeight:        l.Height,
 		Validators:         v,
 		ThresholdPublicKey: &thresholdPublicKey,
+		QuorumType:         vals.QuorumType,
+		QuorumHash:         &vals.QuorumHash,
 		Count:              len(v),
 		Total:              totalCount}, nil
 }
 
+// fetchValidatorSet fetches all the pages of the validator set of the height
+// from the primary, or from the archive nodes, if the primary has pruned it.
+func (c *Client) fetchValidatorSet(ctx context.Context, height int64) (*types.ValidatorSet, error) {
+	vals, err := c.fetchValidatorSetFrom(ctx, c.next, height)
+	if err != nil && c.isHeightPruned(err) {
+		for _, archive := range c.archives {
+			vals, err = c.fetchValidatorSetFrom(ctx, archive, height)
+			if err == nil {
+				break
+			}
+		}
+	}
+	return vals, err
+}
+
+func (c *Client) fetchValidatorSetFrom(ctx context.Context, client rpcclient.Client,
+	height int64) (*types.ValidatorSet, error) {
+	var (
+		vals               []*types.Validator
+		res                *ctypes.ResultValidators
+		perPage            = maxPerPage
+		requestThresholdPK = true
+	)
+	for page := 1; ; page++ {
+		var err error
+		res, err = client.Validators(ctx, &height, &page, &perPage, &requestThresholdPK)
+		if err != nil {
+			return nil, err
+		}
+		if res.BlockHeight != height {
+			return nil, fmt.Errorf("validators height %d does not match the requested height %d",
+				res.BlockHeight, height)
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+	}
+	if len(vals) != res.Total {
+		return nil, fmt.Errorf("got %d validators, out of %d", len(vals), res.Total)
+	}
+
+	valSet := &types.ValidatorSet{
+		Validators: vals,
+		QuorumType: res.QuorumType,
+	}
+	if res.ThresholdPublicKey != nil {
+		valSet.ThresholdPublicKey = *res.ThresholdPublicKey
+	}
+	if res.QuorumHash != nil {
+		valSet.QuorumHash = *res.QuorumHash
+	}
+	return valSet, nil
+}
+
+// verifyValidatorSet verifies the validator set against the trusted light
+// block of its height. The hash of a validator set is the hash of its quorum,
+// which doesn't cover the members, so they are compared with the ones of the
+// validator set verified by the light client too.
+func verifyValidatorSet(vals *types.ValidatorSet, trusted *types.LightBlock) error {
+	if vH, tH := tmbytes.HexBytes(vals.Hash()), trusted.ValidatorsHash; !tH.Equal(vH) {
+		return fmt.Errorf("validators hash %X does not match trusted hash %X", vH, tH)
+	}
+
+	trustedVals := trusted.ValidatorSet
+	if vals.QuorumType != trustedVals.QuorumType {
+		return fmt.Errorf("quorum type %d does not match trusted quorum type %d",
+			vals.QuorumType, trustedVals.QuorumType)
+	}
+	if !equalPubKeys(vals.ThresholdPublicKey, trustedVals.ThresholdPublicKey) {
+		return fmt.Errorf("threshold public key %v does not match trusted threshold public key %v",
+			vals.ThresholdPublicKey, trustedVals.ThresholdPublicKey)
+	}
+	if len(vals.Validators) != len(trustedVals.Validators) {
+		return fmt.Errorf("%d validators do not match the %d trusted validators",
+			len(vals.Validators), len(trustedVals.Validators))
+	}
+	for _, val := range vals.Validators {
+		_, trustedVal := trustedVals.GetByProTxHash(val.ProTxHash)
+		if trustedVal == nil {
+			return fmt.Errorf("validator %X is not a trusted validator", val.ProTxHash)
+		}
+		if val.VotingPower != trustedVal.VotingPower || !equalPubKeys(val.PubKey, trustedVal.PubKey) {
+			return fmt.Errorf("validator %v does not match trusted validator %v", val, trustedVal)
+		}
+	}
+	return nil
+}
+
+// equalPubKeys returns true if both keys are nil or equal.
+func equalPubKeys(a, b crypto.PubKey) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equals(b)
+}
+
+// ValidatorQuorum returns the quorum of the verified validator set.
+func (c *Client) ValidatorQuorum(ctx context.Context, height *int64) (*ctypes.ResultValidatorQuorum, error) {
+	// Update the light client if we're behind and retrieve the light block at the requested height
+	// or at the latest height if no height is provided.
+	l, err := c.updateLightClientIfNeededTo(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]ctypes.QuorumMember, len(l.ValidatorSet.Validators))
+	for i, val := range l.ValidatorSet.Validators {
+		members[i] = ctypes.QuorumMember{
+			ProTxHash: val.ProTxHash,
+			PubKey:    val.PubKey,
+		}
+	}
+
+	return &ctypes.ResultValidatorQuorum{
+		BlockHeight:        l.Height,
+		QuorumType:         l.ValidatorSet.QuorumType,
+		QuorumHash:         l.ValidatorSet.QuorumHash,
+		ThresholdPublicKey: l.ValidatorSet.ThresholdPublicKey,
+		Members:            members,
+	}, nil
+}
+
+// Proposer returns the proposer selected by the full node. It is not verified,
+// because the proposer priorities are not covered by the validator set hash.
+func (c *Client) Proposer(ctx context.Context, height *int64, round *int) (*ctypes.ResultProposer, error) {
+	return c.next.Proposer(ctx, height, round)
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
@@ -555,11 +782,26 @@ func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height *int64)
 		l, err = c.lc.VerifyLightBlockAtHeight(ctx, *height, time.Now())
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to update light client to %d: %w", height, err)
+		var h int64
+		if height != nil {
+			h = *height
+		}
+		return nil, ErrCannotVerify{Height: h, Reason: err}
 	}
 	return l, nil
 }
 
+// isHeightPruned returns true if err is returned by a node that has pruned the requested height
+func (c *Client) isHeightPruned(err error) bool {
+	var errPruned ctypes.ErrHeightPruned
+	if errors.As(err, &errPruned) {
+		c.Logger.Debug("height is pruned, falling back to archive nodes",
+			"height", errPruned.Height, "earliest", errPruned.EarliestHeight, "archives", len(c.archives))
+		return true
+	}
+	return false
+}
+
 func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
 	c.prt.RegisterOpDecoder(typ, dec)
 }
@@ -1,5 +1,152 @@
 package rpc
 
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	lcmock "github.com/tendermint/tendermint/light/rpc/mocks"
+	rpcmock "github.com/tendermint/tendermint/rpc/client/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// mockValidators makes next serve the validators in pages, as a full node.
+func mockValidators(next *rpcmock.Client, height int64, vals *types.ValidatorSet, validators []*types.Validator) {
+	next.On("Validators", mock.Anything, &height, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *int64, page, perPage *int, _ *bool) *ctypes.ResultValidators {
+			skip := (*page - 1) * *perPage
+			end := skip + *perPage
+			if end > len(validators) {
+				end = len(validators)
+			}
+			return &ctypes.ResultValidators{
+				BlockHeight:        height,
+				Validators:         validators[skip:end],
+				ThresholdPublicKey: &vals.ThresholdPublicKey,
+				QuorumType:         vals.QuorumType,
+				QuorumHash:         &vals.QuorumHash,
+				Count:              end - skip,
+				Total:              len(validators),
+			}
+		},
+		nil,
+	)
+}
+
+func mockTrustedLightBlock(lc *lcmock.LightClient, height int64, vals *types.ValidatorSet) {
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, height, mock.AnythingOfType("time.Time")).Return(
+		&types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{Height: height, ValidatorsHash: vals.Hash()},
+			},
+			ValidatorSet: vals,
+		},
+		nil,
+	)
+}
+
+func TestValidators(t *testing.T) {
+	const height = int64(10)
+	vals, _ := types.GenerateValidatorSet(5)
+
+	next := &rpcmock.Client{}
+	mockValidators(next, height, vals, vals.Validators)
+	lc := &lcmock.LightClient{}
+	mockTrustedLightBlock(lc, height, vals)
+
+	c := NewClient(next, lc)
+	h, page, perPage, requestThresholdPublicKey := height, 2, 2, true
+	res, err := c.Validators(context.Background(), &h, &page, &perPage, &requestThresholdPublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.BlockHeight)
+	assert.Equal(t, vals.Validators[2:4], res.Validators)
+	assert.Equal(t, 5, res.Total)
+	assert.Equal(t, vals.ThresholdPublicKey, *res.ThresholdPublicKey)
+	assert.Equal(t, vals.QuorumHash, *res.QuorumHash)
+}
+
+func TestValidatorsWithExtraMember(t *testing.T) {
+	const height = int64(10)
+	vals, _ := types.GenerateValidatorSet(5)
+	extra, _ := types.RandValidator()
+
+	// The hash of the validator set is the one of its quorum, which the
+	// extra member doesn't change.
+	next := &rpcmock.Client{}
+	mockValidators(next, height, vals, append(vals.Copy().Validators, extra))
+	lc := &lcmock.LightClient{}
+	mockTrustedLightBlock(lc, height, vals)
+
+	c := NewClient(next, lc)
+	h := height
+	_, err := c.Validators(context.Background(), &h, nil, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "do not match the 5 trusted validators")
+}
+
+func TestValidatorsCannotVerify(t *testing.T) {
+	const height = int64(1000)
+
+	next := &rpcmock.Client{}
+	lc := &lcmock.LightClient{}
+	reason := errors.New("height is outside of the trusted range")
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, height, mock.AnythingOfType("time.Time")).Return(nil, reason)
+
+	c := NewClient(next, lc)
+	h := height
+	_, err := c.Validators(context.Background(), &h, nil, nil, nil)
+	assert.Equal(t, ErrCannotVerify{Height: height, Reason: reason}, err)
+	_, err = c.BlockParams(context.Background(), &h)
+	assert.Equal(t, ErrCannotVerify{Height: height, Reason: reason}, err)
+
+	// nothing is proxied
+	next.AssertNotCalled(t, "Validators", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	next.AssertNotCalled(t, "ConsensusParams", mock.Anything, mock.Anything)
+}
+
+func TestBlockParams(t *testing.T) {
+	const height = int64(10)
+	params := types.DefaultConsensusParams()
+
+	next := &rpcmock.Client{}
+	next.On("ConsensusParams", mock.Anything, mock.Anything).Return(
+		&ctypes.ResultConsensusParams{BlockHeight: height, ConsensusParams: *params}, nil)
+	lc := &lcmock.LightClient{}
+	lc.On("VerifyLightBlockAtHeight", mock.Anything, height, mock.AnythingOfType("time.Time")).Return(
+		&types.LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: &types.Header{Height: height, ConsensusHash: types.HashConsensusParams(*params)},
+			},
+		},
+		nil,
+	)
+
+	c := NewClient(next, lc)
+	h := height
+	res, err := c.BlockParams(context.Background(), &h)
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultBlockParams{BlockHeight: height, BlockParams: params.Block}, res)
+
+	// the params, which aren't hashed, can't be verified, so they are refused
+	_, err = c.ConsensusParams(context.Background(), &h)
+	assert.Equal(t, ErrCannotVerifyParams, err)
+	next.AssertNumberOfCalls(t, "ConsensusParams", 1)
+
+	// the params of the primary don't match the trusted header
+	next.ExpectedCalls = nil
+	params.Block.MaxBytes++
+	next.On("ConsensusParams", mock.Anything, mock.Anything).Return(
+		&ctypes.ResultConsensusParams{BlockHeight: height, ConsensusParams: *params}, nil)
+	_, err = c.BlockParams(context.Background(), &h)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match trusted hash")
+}
+
 //
 // // TestABCIQuery tests ABCIQuery requests and verifies proofs. HAPPY PATH 😀
 // func TestABCIQuery(t *testing.T) {
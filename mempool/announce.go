@@ -0,0 +1,230 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+const (
+	// knownTxsCapacity is the number of tx keys a generation of the known txs
+	// of a peer holds. The previous generation is kept, so the peer is known to
+	// have at least the last knownTxsCapacity txs it announced or got.
+	knownTxsCapacity = 10000
+
+	// knownTxsBitsPerKey and knownTxsHashes give a false positive rate of
+	// about 0.1%: the tx isn't sent to the peer, which seems to have it, but
+	// the peer still gets it from its other peers.
+	knownTxsBitsPerKey = 16
+	knownTxsHashes     = 7
+
+	// maxAnnouncedTxs is the maximum number of the announcements to a peer,
+	// which wait for the answer, and of the ones of the peer, which wait for
+	// ours. Beyond it, the txs are sent without announcing them, and the
+	// announcements of the peer are left to time out.
+	maxAnnouncedTxs = 10000
+
+	// maxRequestedTxs is the maximum number of the txs requested from the
+	// peers at once, the announcements beyond it are left to time out.
+	maxRequestedTxs = 10000
+)
+
+// knownTxs is a rotating bloom filter of the keys of the txs a peer has. Once
+// the current filter holds knownTxsCapacity keys, it becomes the previous one,
+// and a new one is started, so the memory is bounded and the oldest keys are
+// forgotten.
+type knownTxs struct {
+	mtx   tmsync.Mutex
+	cur   []uint64
+	prev  []uint64
+	count int
+}
+
+func newKnownTxs() *knownTxs {
+	return &knownTxs{
+		cur:  make([]uint64, knownTxsCapacity*knownTxsBitsPerKey/64),
+		prev: make([]uint64, knownTxsCapacity*knownTxsBitsPerKey/64),
+	}
+}
+
+// Add remembers that the peer has the tx.
+func (k *knownTxs) Add(key [TxKeySize]byte) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	if bloomHas(k.cur, key) {
+		return
+	}
+	if k.count == knownTxsCapacity {
+		k.prev = k.cur
+		k.cur = make([]uint64, len(k.prev))
+		k.count = 0
+	}
+	h1, h2 := bloomHashes(key)
+	for i := uint64(0); i < knownTxsHashes; i++ {
+		bit := (h1 + i*h2) % uint64(len(k.cur)*64)
+		k.cur[bit/64] |= 1 << (bit % 64)
+	}
+	k.count++
+}
+
+// Has returns true if the peer has the tx, or, rarely, if it doesn't.
+func (k *knownTxs) Has(key [TxKeySize]byte) bool {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	return bloomHas(k.cur, key) || bloomHas(k.prev, key)
+}
+
+func bloomHas(bits []uint64, key [TxKeySize]byte) bool {
+	h1, h2 := bloomHashes(key)
+	for i := uint64(0); i < knownTxsHashes; i++ {
+		bit := (h1 + i*h2) % uint64(len(bits)*64)
+		if bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes returns the hashes the positions of the key in a bloom filter
+// are derived from. The key is a hash already, so its bytes are used as is.
+func bloomHashes(key [TxKeySize]byte) (uint64, uint64) {
+	return binary.LittleEndian.Uint64(key[:8]), binary.LittleEndian.Uint64(key[8:16]) | 1
+}
+
+//-----------------------------------------------------------------------------
+
+// peerTxs is the state of the announcements exchanged with a peer.
+type peerTxs struct {
+	known *knownTxs
+
+	mtx tmsync.Mutex
+	// announcements to the peer, which wait for the answer, by the time the
+	// txs are sent to the peer
+	pending map[[TxKeySize]byte]time.Time
+	// announcements of the peer, which are answered once the txs requested
+	// from the other peers are in the mempool, by the time they're dropped
+	deferred map[[TxKeySize]byte]time.Time
+}
+
+func newPeerTxs() *peerTxs {
+	return &peerTxs{
+		known:    newKnownTxs(),
+		pending:  make(map[[TxKeySize]byte]time.Time),
+		deferred: make(map[[TxKeySize]byte]time.Time),
+	}
+}
+
+// Announce records the announcement of the tx to the peer, which is answered
+// until the deadline. It returns false, if too many announcements wait for
+// the answer.
+func (ps *peerTxs) Announce(key [TxKeySize]byte, deadline time.Time) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if len(ps.pending) >= maxAnnouncedTxs {
+		return false
+	}
+	ps.pending[key] = deadline
+	return true
+}
+
+// Answered removes the announcement of the tx to the peer, and returns true
+// if it waited for the answer.
+func (ps *peerTxs) Answered(key [TxKeySize]byte) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	_, ok := ps.pending[key]
+	delete(ps.pending, key)
+	return ok
+}
+
+// Defer records the announcement of the tx by the peer, which is answered
+// once the tx is in the mempool, or dropped after the deadline.
+func (ps *peerTxs) Defer(key [TxKeySize]byte, deadline time.Time) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if len(ps.deferred) < maxAnnouncedTxs {
+		ps.deferred[key] = deadline
+	}
+}
+
+// TakeDeferred removes the announcement of the tx by the peer, and returns
+// true if it waits for the answer.
+func (ps *peerTxs) TakeDeferred(key [TxKeySize]byte) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	_, ok := ps.deferred[key]
+	delete(ps.deferred, key)
+	return ok
+}
+
+// Expired removes and returns the announcements to the peer, which weren't
+// answered before now, and drops the expired announcements of the peer.
+func (ps *peerTxs) Expired(now time.Time) [][TxKeySize]byte {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	var keys [][TxKeySize]byte
+	for key, deadline := range ps.pending {
+		if !deadline.After(now) {
+			keys = append(keys, key)
+			delete(ps.pending, key)
+		}
+	}
+	for key, deadline := range ps.deferred {
+		if !deadline.After(now) {
+			delete(ps.deferred, key)
+		}
+	}
+	return keys
+}
+
+//-----------------------------------------------------------------------------
+
+// txRequests are the txs requested from the peers. A tx is requested from the
+// first peer, which announces it, and from the next one after the deadline.
+type txRequests struct {
+	mtx       tmsync.Mutex
+	deadlines map[[TxKeySize]byte]time.Time
+}
+
+func newTxRequests() *txRequests {
+	return &txRequests{deadlines: make(map[[TxKeySize]byte]time.Time)}
+}
+
+// Start returns true if the tx should be requested, it is then requested
+// until the deadline.
+func (r *txRequests) Start(key [TxKeySize]byte, now, deadline time.Time) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if d, ok := r.deadlines[key]; ok && d.After(now) {
+		return false
+	}
+	if len(r.deadlines) >= maxRequestedTxs {
+		for k, d := range r.deadlines {
+			if !d.After(now) {
+				delete(r.deadlines, k)
+			}
+		}
+		if len(r.deadlines) >= maxRequestedTxs {
+			return false
+		}
+	}
+	r.deadlines[key] = deadline
+	return true
+}
+
+// Done removes the request of the tx, which was received.
+func (r *txRequests) Done(key [TxKeySize]byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.deadlines, key)
+}
@@ -0,0 +1,99 @@
+package mempool
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTxKey(i int) [TxKeySize]byte {
+	var bz [8]byte
+	binary.BigEndian.PutUint64(bz[:], uint64(i))
+	return sha256.Sum256(bz[:])
+}
+
+func TestKnownTxs(t *testing.T) {
+	known := newKnownTxs()
+	for i := 0; i < knownTxsCapacity; i++ {
+		known.Add(testTxKey(i))
+	}
+	for i := 0; i < knownTxsCapacity; i++ {
+		require.True(t, known.Has(testTxKey(i)), i)
+	}
+
+	// the false positive rate is about 0.1%
+	falsePositives := 0
+	for i := knownTxsCapacity; i < 3*knownTxsCapacity; i++ {
+		if known.Has(testTxKey(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 2*knownTxsCapacity/200)
+
+	// the keys of the previous generation are remembered, the older ones are
+	// forgotten
+	for i := knownTxsCapacity; i < 3*knownTxsCapacity; i++ {
+		known.Add(testTxKey(i))
+	}
+	for i := 2 * knownTxsCapacity; i < 3*knownTxsCapacity; i++ {
+		require.True(t, known.Has(testTxKey(i)), i)
+	}
+	forgotten := 0
+	for i := 0; i < knownTxsCapacity; i++ {
+		if !known.Has(testTxKey(i)) {
+			forgotten++
+		}
+	}
+	assert.Greater(t, forgotten, knownTxsCapacity*99/100)
+}
+
+func TestPeerTxs(t *testing.T) {
+	ps := newPeerTxs()
+	now := time.Now()
+
+	require.True(t, ps.Announce(testTxKey(1), now.Add(time.Second)))
+	require.True(t, ps.Announce(testTxKey(2), now.Add(2*time.Second)))
+	assert.Empty(t, ps.Expired(now))
+
+	assert.True(t, ps.Answered(testTxKey(1)))
+	assert.False(t, ps.Answered(testTxKey(1)))
+	require.True(t, ps.Announce(testTxKey(1), now.Add(time.Second)))
+
+	ps.Defer(testTxKey(3), now.Add(time.Second))
+	ps.Defer(testTxKey(4), now.Add(2*time.Second))
+	assert.Equal(t, [][TxKeySize]byte{testTxKey(1)}, ps.Expired(now.Add(time.Second)))
+	assert.False(t, ps.TakeDeferred(testTxKey(3)))
+	assert.True(t, ps.TakeDeferred(testTxKey(4)))
+	assert.False(t, ps.TakeDeferred(testTxKey(4)))
+
+	for i := 1; i < maxAnnouncedTxs; i++ {
+		require.True(t, ps.Announce(testTxKey(i+10), now))
+	}
+	assert.False(t, ps.Announce(testTxKey(0), now))
+	assert.Len(t, ps.Expired(now.Add(2*time.Second)), maxAnnouncedTxs)
+	assert.True(t, ps.Announce(testTxKey(0), now))
+}
+
+func TestTxRequests(t *testing.T) {
+	r := newTxRequests()
+	now := time.Now()
+
+	assert.True(t, r.Start(testTxKey(1), now, now.Add(time.Second)))
+	assert.False(t, r.Start(testTxKey(1), now, now.Add(time.Second)))
+	// requested again from another peer after the deadline
+	assert.True(t, r.Start(testTxKey(1), now.Add(time.Second), now.Add(2*time.Second)))
+
+	r.Done(testTxKey(1))
+	assert.True(t, r.Start(testTxKey(1), now, now.Add(time.Second)))
+
+	for i := 1; i < maxRequestedTxs; i++ {
+		require.True(t, r.Start(testTxKey(i+1), now, now.Add(time.Second)))
+	}
+	assert.False(t, r.Start(testTxKey(0), now, now.Add(time.Second)))
+	// the expired requests make room for the new ones
+	assert.True(t, r.Start(testTxKey(0), now.Add(time.Second), now.Add(2*time.Second)))
+}
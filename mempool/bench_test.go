@@ -2,10 +2,16 @@ package mempool
 
 import (
 	"encoding/binary"
+	"fmt"
+	mrand "math/rand"
 	"testing"
+	"time"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
 )
 
 func BenchmarkReap(b *testing.B) {
@@ -28,6 +34,67 @@ func BenchmarkReap(b *testing.B) {
 	}
 }
 
+func BenchmarkReapMaxBytesMaxGas(b *testing.B) {
+	const size = 50000
+	for _, version := range []string{cfg.MempoolV0, cfg.MempoolV1} {
+		b.Run(version, func(b *testing.B) {
+			config := cfg.ResetTestRoot("mempool_test")
+			config.Mempool.Version = version
+			config.Mempool.Size = size
+			mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+			defer cleanup()
+
+			for i := 0; i < size; i++ {
+				tx := priorityTx(fmt.Sprintf("sender%d", i%100), mrand.Int63n(1000), fmt.Sprintf("%d", i))
+				if err := mempool.CheckTx(tx, nil, TxInfo{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				mempool.ReapMaxBytesMaxGas(100000000, 10000000)
+			}
+		})
+	}
+}
+
+// BenchmarkUpdateRecheck measures the latency from the commit of a block to
+// the start of the next round, when the mempool is updated with the committed
+// txs and the remaining txs are rechecked.
+func BenchmarkUpdateRecheck(b *testing.B) {
+	const (
+		size        = 1000
+		recheckTime = 100 * time.Microsecond
+	)
+	cc := proxy.NewLocalClientCreator(recheckApp{recheckTime: recheckTime})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	for i := 0; i < size; i++ {
+		if err := mempool.CheckTx(types.Tx(fmt.Sprintf("good%d", i)), nil, TxInfo{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		mempool.Lock()
+		if err := mempool.Update(int64(i+1), time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil); err != nil {
+			b.Fatal(err)
+		}
+		mempool.Unlock()
+		latency := time.Since(start)
+
+		// the commit doesn't wait for the recheck of the txs
+		if latency >= size*recheckTime/2 {
+			b.Fatalf("the commit took %v, the recheck takes %v", latency, size*recheckTime)
+		}
+		b.StopTimer()
+		mempool.waitForRecheck()
+		b.StartTimer()
+	}
+}
+
 func BenchmarkCheckTx(b *testing.B) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"container/list"
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
@@ -33,10 +36,23 @@ var newline = []byte("\n")
 // CheckTx abci message before the transaction is added to the pool. The
 // mempool uses a concurrent list structure for storing transactions that can
 // be efficiently accessed by multiple concurrent readers.
+//
+// With the mempool version v1, the mempool is prioritized: the txs are reaped
+// in the order of the priority returned by CheckTx, and the txs with the same
+// priority in the order they were added. If the mempool is full, the txs with
+// a lower priority than the new tx are evicted to make room for it. The txs
+// are gossiped in the order they were added with both versions.
 type CListMempool struct {
 	// Atomic integers
-	height   int64 // the last block Update()'d to
-	txsBytes int64 // total size of mempool, in bytes
+	height   int64  // the last block Update()'d to
+	txsBytes int64  // total size of mempool, in bytes
+	lastSeq  uint64 // sequence number of the last added tx
+	maxTxs   int64  // max number of txs, see SetSize
+
+	// the time of the last block Update()'d to and the proposer of the next
+	// block, which are sent to the app with CheckTx
+	blockTime             time.Time
+	nextProposerProTxHash []byte
 
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
@@ -44,12 +60,20 @@ type CListMempool struct {
 
 	config *cfg.MempoolConfig
 
+	// true if the txs are reaped by their priority (mempool version v1)
+	prioritized bool
+	// serializes adding the txs, which may evict or replace the same txs
+	addMtx tmsync.Mutex
+
 	// Exclusive mutex for Update method to prevent concurrent execution of
 	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
 	updateMtx tmsync.RWMutex
 	preCheck  PreCheckFunc
 	postCheck PostCheckFunc
 
+	// called for each tx removed from the mempool
+	onTxRemoved func(tx types.Tx)
+
 	wal          *auto.AutoFile // a log of mempool txs
 	txs          *clist.CList   // concurrent linked-list of good txs
 	proxyAppConn proxy.AppConnMempool
@@ -60,10 +84,26 @@ type CListMempool struct {
 	recheckCursor *clist.CElement // next expected response
 	recheckEnd    *clist.CElement // re-checking stops here
 
+	// The txs are rechecked asynchronously after Update, so the commit doesn't
+	// wait for the recheck. sendMtx serializes sending CheckTx requests, so
+	// the requests of a recheck are not interleaved with the requests of new
+	// txs. recheckCond is broadcast when a tx was rechecked or the recheck
+	// finished, it protects recheckEpoch, rechecking and the checkedEpoch of
+	// the txs.
+	sendMtx      tmsync.Mutex
+	recheckMtx   tmsync.Mutex
+	recheckCond  *sync.Cond
+	recheckEpoch uint64 // incremented for every recheck
+	rechecking   bool   // true while a recheck is in progress
+
 	// Map for quick access to txs to record sender in CheckTx.
 	// txsMap: txKey -> CElement
 	txsMap sync.Map
 
+	// Sequence numbers of the recently removed txs, to continue ReapTxsAfter
+	// after them.
+	removedSeqs *txSeqCache
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache txCache
@@ -87,14 +127,18 @@ func NewCListMempool(
 ) *CListMempool {
 	mempool := &CListMempool{
 		config:        config,
+		prioritized:   config.Version == cfg.MempoolV1,
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
+		removedSeqs:   newTxSeqCache(config.Size),
 		height:        height,
+		maxTxs:        int64(config.Size),
 		recheckCursor: nil,
 		recheckEnd:    nil,
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
 	}
+	mempool.recheckCond = sync.NewCond(&mempool.recheckMtx)
 	if config.CacheSize > 0 {
 		mempool.cache = newMapTxCache(config.CacheSize)
 	} else {
@@ -117,6 +161,29 @@ func (mem *CListMempool) SetLogger(l log.Logger) {
 	mem.logger = l
 }
 
+// SetSize changes the max number of txs in the mempool. If the mempool holds
+// more txs, they are not removed, but no new txs are added until enough of
+// them are removed.
+func (mem *CListMempool) SetSize(size int) {
+	atomic.StoreInt64(&mem.maxTxs, int64(size))
+}
+
+// SetCacheSize changes the number of txs the cache remembers, forgetting the
+// oldest ones if it shrinks. The cache can't be enabled or disabled this way,
+// so an error is returned if size is 0 and the cache is enabled, or the other
+// way round.
+func (mem *CListMempool) SetCacheSize(size int) error {
+	cache, ok := mem.cache.(*mapTxCache)
+	if !ok || size <= 0 {
+		if ok || size > 0 {
+			return errors.New("the cache can't be enabled or disabled while the mempool is running")
+		}
+		return nil
+	}
+	cache.Resize(size)
+	return nil
+}
+
 // WithPreCheck sets a filter for the mempool to reject a tx if f(tx) returns
 // false. This is ran before CheckTx. Only applies to the first created block.
 // After that, Update overwrites the existing value.
@@ -131,6 +198,23 @@ func WithPostCheck(f PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithTxRemovedCallback sets a callback, which is called for each tx removed
+// from the mempool, e.g. because it was committed, became invalid or its TTL
+// expired.
+func WithTxRemovedCallback(cb func(tx types.Tx)) CListMempoolOption {
+	return func(mem *CListMempool) { mem.onTxRemoved = cb }
+}
+
+// WithLastBlock sets the time of the last block and the proTxHash of the
+// proposer of the next block, which are sent to the app with CheckTx until
+// the first Update.
+func WithLastBlock(blockTime time.Time, nextProposerProTxHash []byte) CListMempoolOption {
+	return func(mem *CListMempool) {
+		mem.blockTime = blockTime
+		mem.nextProposerProTxHash = nextProposerProTxHash
+	}
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
@@ -193,6 +277,10 @@ func (mem *CListMempool) Flush() {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
+	// the recheck in progress expects the txs to stay in the mempool; a new one
+	// can't start while the lock is held
+	mem.waitForRecheck()
+
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
 	mem.cache.Reset()
 
@@ -205,6 +293,19 @@ func (mem *CListMempool) Flush() {
 		mem.txsMap.Delete(key)
 		return true
 	})
+	mem.removedSeqs.Reset()
+}
+
+// FlushCache resets the cache of the seen txs, the txs in the mempool stay.
+// They're kept in the cache, so they aren't added to the mempool twice.
+func (mem *CListMempool) FlushCache() {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	mem.cache.Reset()
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		_ = mem.cache.Push(e.Value.(*mempoolTx).tx)
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -238,8 +339,12 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 
 	txSize := len(tx)
 
-	if err := mem.isFull(txSize); err != nil {
-		return err
+	// the prioritized mempool may evict txs with a lower priority to make room
+	// for the tx, its priority is known after CheckTx
+	if !mem.prioritized {
+		if err := mem.isFull(txSize); err != nil {
+			return err
+		}
 	}
 
 	if txSize > mem.config.MaxTxBytes {
@@ -285,7 +390,10 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 		return ErrTxInCache
 	}
 
-	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
+	// the requests of a recheck can't be sent in the meantime
+	mem.sendMtx.Lock()
+	defer mem.sendMtx.Unlock()
+	reqRes := mem.proxyAppConn.CheckTxAsync(mem.checkTxRequest(tx, abci.CheckTxType_New))
 	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, txInfo.SenderP2PID, cb))
 
 	return nil
@@ -348,6 +456,12 @@ func (mem *CListMempool) reqResCb(
 // Called from:
 //  - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
+	// the tx was checked after the last commit
+	mem.recheckMtx.Lock()
+	memTx.checkedEpoch = mem.recheckEpoch
+	mem.recheckMtx.Unlock()
+
+	memTx.seq = atomic.AddUint64(&mem.lastSeq, 1)
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(TxKey(memTx.tx), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
@@ -355,17 +469,21 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 }
 
 // Called from:
-//  - Update (lock held) if tx was committed
+//  - Update (lock held) if tx was committed or its TTL expired
 // 	- resCbRecheck (lock not held) if tx was invalidated
 func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(TxKey(tx))
+	mem.removedSeqs.Push(TxKey(tx), elem.Value.(*mempoolTx).seq)
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
+	if mem.onTxRemoved != nil {
+		mem.onTxRemoved(tx)
+	}
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
@@ -379,14 +497,25 @@ func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bo
 }
 
 func (mem *CListMempool) isFull(txSize int) error {
+	return mem.isFullReplacing(txSize, nil)
+}
+
+// isFullReplacing is isFull for a tx, which replaces the given pending tx, if
+// it's not nil.
+func (mem *CListMempool) isFullReplacing(txSize int, replaced *clist.CElement) error {
 	var (
 		memSize  = mem.Size()
 		txsBytes = mem.TxsBytes()
 	)
+	if replaced != nil {
+		memSize--
+		txsBytes -= int64(len(replaced.Value.(*mempoolTx).tx))
+	}
 
-	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+	maxTxs := int(atomic.LoadInt64(&mem.maxTxs))
+	if memSize >= maxTxs || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
 		return ErrMempoolIsFull{
-			memSize, mem.config.Size,
+			memSize, maxTxs,
 			txsBytes, mem.config.MaxTxsBytes,
 		}
 	}
@@ -394,6 +523,94 @@ func (mem *CListMempool) isFull(txSize int) error {
 	return nil
 }
 
+// evictTxs makes room for a tx with the given size and priority in the
+// prioritized mempool, by evicting the txs with a lower priority, the lowest
+// priority and the latest added ones first. A tx is never evicted while a tx of
+// the same sender, which was added later, is still in the mempool, as the
+// later tx depends on it, e.g. by its nonce. If there isn't enough room even
+// after evicting all these txs, nothing is evicted and false is returned. The
+// tx, which the new tx replaces, if not nil, is not evicted, but its room is
+// taken into account.
+func (mem *CListMempool) evictTxs(txSize int, priority int64, replaced *clist.CElement) bool {
+	var (
+		memSize  = mem.Size()
+		txsBytes = mem.TxsBytes()
+		evicted  = make(map[*clist.CElement]bool)
+		victims  []*clist.CElement
+	)
+	if replaced != nil {
+		evicted[replaced] = true
+		memSize--
+		txsBytes -= int64(len(replaced.Value.(*mempoolTx).tx))
+	}
+	maxTxs := int(atomic.LoadInt64(&mem.maxTxs))
+	for memSize >= maxTxs || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+		victim := mem.lowestPriorityTx(priority, evicted)
+		if victim == nil {
+			return false
+		}
+		evicted[victim] = true
+		victims = append(victims, victim)
+		memSize--
+		txsBytes -= int64(len(victim.Value.(*mempoolTx).tx))
+	}
+	for _, e := range victims {
+		memTx := e.Value.(*mempoolTx)
+		mem.logger.Debug("evicting tx with a lower priority", "tx", txID(memTx.tx), "priority", memTx.priority)
+		// remove from cache, so the tx can be submitted again
+		mem.removeTx(memTx.tx, e, true)
+	}
+	return true
+}
+
+// lowestPriorityTx returns the latest added tx with the lowest priority, which
+// is lower than the given one, skipping the evicted txs and the txs of the
+// senders, which have a later tx in the mempool.
+func (mem *CListMempool) lowestPriorityTx(priority int64, evicted map[*clist.CElement]bool) *clist.CElement {
+	var (
+		lowest  *clist.CElement
+		senders = make(map[string]bool)
+	)
+	for e := mem.txs.Back(); e != nil; e = e.Prev() {
+		if evicted[e] {
+			continue
+		}
+		memTx := e.Value.(*mempoolTx)
+		if memTx.sender != "" {
+			if senders[memTx.sender] {
+				continue
+			}
+			senders[memTx.sender] = true
+		}
+		if memTx.priority < priority && (lowest == nil || memTx.priority < lowest.Value.(*mempoolTx).priority) {
+			lowest = e
+		}
+	}
+	return lowest
+}
+
+// pendingTx returns the pending tx with the given hash, or nil.
+func (mem *CListMempool) pendingTx(hash []byte) *clist.CElement {
+	var key [TxKeySize]byte
+	if len(hash) != len(key) {
+		return nil
+	}
+	copy(key[:], hash)
+	if e, ok := mem.txsMap.Load(key); ok {
+		return e.(*clist.CElement)
+	}
+	return nil
+}
+
+// seenTx returns true if the tx with the given key is in the mempool or in the
+// cache of the seen txs.
+func (mem *CListMempool) seenTx(key [TxKeySize]byte) bool {
+	if _, ok := mem.txsMap.Load(key); ok {
+		return true
+	}
+	return mem.cache.Has(key)
+}
+
 // callback, which is called after the app checked the tx for the first time.
 //
 // The case where the app checks the tx for the second and subsequent times is
@@ -411,18 +628,43 @@ func (mem *CListMempool) resCbFirstTime(
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
+			if mem.prioritized || len(r.CheckTx.ReplaceTxHash) > 0 {
+				mem.addMtx.Lock()
+				defer mem.addMtx.Unlock()
+			}
+
+			// The app may replace a pending tx with this tx, e.g. a tx of the same
+			// sender with a higher fee.
+			var replaced *clist.CElement
+			if len(r.CheckTx.ReplaceTxHash) > 0 {
+				replaced = mem.pendingTx(r.CheckTx.ReplaceTxHash)
+			}
+
 			// Check mempool isn't full again to reduce the chance of exceeding the
 			// limits.
-			if err := mem.isFull(len(tx)); err != nil {
+			if err := mem.isFullReplacing(len(tx), replaced); err != nil &&
+				!(mem.prioritized && mem.evictTxs(len(tx), r.CheckTx.Priority, replaced)) {
 				// remove from cache (mempool might have a space later)
 				mem.cache.Remove(tx)
 				mem.logger.Error(err.Error())
 				return
 			}
 
+			if replaced != nil {
+				replacedTx := replaced.Value.(*mempoolTx).tx
+				mem.logger.Debug("replacing transaction", "tx", txID(replacedTx), "by", txID(tx))
+				// keep the replaced tx in the cache, so it's not added again, e.g.
+				// when a peer gossips it back
+				mem.removeTx(replacedTx, replaced, false)
+				_ = mem.cache.Push(replacedTx)
+			}
+
 			memTx := &mempoolTx{
 				height:    mem.height,
+				timestamp: time.Now().UTC(),
 				gasWanted: r.CheckTx.GasWanted,
+				priority:  r.CheckTx.Priority,
+				sender:    r.CheckTx.Sender,
 				tx:        tx,
 			}
 			memTx.senders.Store(peerID, true)
@@ -468,8 +710,9 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 		if mem.postCheck != nil {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
+		mem.recheckMtx.Lock()
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Good, nothing to do.
+			memTx.checkedEpoch = mem.recheckEpoch
 		} else {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Debug("tx is no longer valid", "tx", txID(tx), "res", r, "err", postCheckErr)
@@ -484,12 +727,15 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 		if mem.recheckCursor == nil {
 			// Done!
 			mem.logger.Debug("done rechecking txs")
+			mem.rechecking = false
 
 			// incase the recheck removed all txs
 			if mem.Size() > 0 {
 				mem.notifyTxsAvailable()
 			}
 		}
+		mem.recheckCond.Broadcast()
+		mem.recheckMtx.Unlock()
 	default:
 		// ignore other messages
 	}
@@ -519,20 +765,26 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
-	var totalGas int64
+	var totalGas, dataSize int64
 
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
+	reaped := make([]*clist.CElement, 0, mem.txs.Len())
+	for _, e := range mem.reapOrder() {
+		// don't reap the txs, which the recheck after the last commit
+		// invalidates
+		if !mem.config.ReapBeforeRecheck && !mem.waitForRecheckOf(e) {
+			continue
+		}
 		memTx := e.Value.(*mempoolTx)
 
-		dataSize := types.ComputeProtoSizeForTxs(append(txs, memTx.tx))
+		// the size of the repeated txs field is the sum of the sizes of the txs
+		dataSize += types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
 
 		// Check total size requirement
 		if maxBytes > -1 && dataSize > maxBytes {
-			return txs
+			break
 		}
 		// Check total gas requirement.
 		// If maxGas is negative, skip this check.
@@ -540,10 +792,21 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		// must be non-negative, it follows that this won't overflow.
 		newTotalGas := totalGas + memTx.gasWanted
 		if maxGas > -1 && newTotalGas > maxGas {
-			return txs
+			break
 		}
 		totalGas = newTotalGas
-		txs = append(txs, memTx.tx)
+		reaped = append(reaped, e)
+	}
+
+	// A tx may be replaced while the txs are reaped. The replaced tx is removed
+	// before the tx, which replaces it, is added, so if both were reaped, the
+	// replaced one is removed by now and the block never contains both of them.
+	txs := make([]types.Tx, 0, len(reaped))
+	for _, e := range reaped {
+		if e.Removed() {
+			continue
+		}
+		txs = append(txs, e.Value.(*mempoolTx).tx)
 	}
 	return txs
 }
@@ -558,23 +821,139 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-		txs = append(txs, memTx.tx)
+	for _, e := range mem.reapOrder() {
+		if len(txs) > max {
+			break
+		}
+		txs = append(txs, e.Value.(*mempoolTx).tx)
 	}
 	return txs
 }
 
+// Snapshot implements Mempool. It holds the mempool lock, so no tx is added
+// or removed while the metadata of the txs is copied.
+func (mem *CListMempool) Snapshot(limit int) []TxSnapshot {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+	mem.recheckMtx.Lock()
+	defer mem.recheckMtx.Unlock()
+
+	elems := mem.reapOrder()
+	if limit >= 0 && limit < len(elems) {
+		elems = elems[:limit]
+	}
+	snapshot := make([]TxSnapshot, len(elems))
+	for i, e := range elems {
+		memTx := e.Value.(*mempoolTx)
+		snapshot[i] = TxSnapshot{
+			Key:            TxKey(memTx.tx),
+			Size:           len(memTx.tx),
+			Height:         memTx.Height(),
+			Time:           memTx.timestamp,
+			GasWanted:      memTx.gasWanted,
+			Priority:       memTx.priority,
+			Sender:         memTx.sender,
+			RecheckPending: mem.rechecking && memTx.checkedEpoch < mem.recheckEpoch,
+			Tx:             memTx.tx,
+		}
+	}
+	return snapshot
+}
+
+// reapOrder returns the txs in the order they are reaped: by their priority
+// in the prioritized mempool, and in the order they were added otherwise.
+func (mem *CListMempool) reapOrder() []*clist.CElement {
+	elems := make([]*clist.CElement, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		elems = append(elems, e)
+	}
+	if mem.prioritized {
+		sort.Slice(elems, func(i, j int) bool {
+			txI, txJ := elems[i].Value.(*mempoolTx), elems[j].Value.(*mempoolTx)
+			if txI.priority != txJ.priority {
+				return txI.priority > txJ.priority
+			}
+			return txI.seq < txJ.seq
+		})
+	}
+	return elems
+}
+
+// waitForRecheckOf blocks until the tx is rechecked after the last commit, if
+// the recheck is in progress. It returns false if the recheck removed the tx
+// from the mempool.
+func (mem *CListMempool) waitForRecheckOf(e *clist.CElement) bool {
+	memTx := e.Value.(*mempoolTx)
+	mem.recheckMtx.Lock()
+	defer mem.recheckMtx.Unlock()
+	for mem.rechecking && memTx.checkedEpoch < mem.recheckEpoch && !e.Removed() {
+		mem.recheckCond.Wait()
+	}
+	return !e.Removed()
+}
+
+// waitForRecheck blocks until the recheck in progress, if any, is finished.
+func (mem *CListMempool) waitForRecheck() {
+	mem.recheckMtx.Lock()
+	defer mem.recheckMtx.Unlock()
+	for mem.rechecking {
+		mem.recheckCond.Wait()
+	}
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ReapTxsAfter(after [TxKeySize]byte, max int) (types.Txs, error) {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	var seq uint64
+	if e, ok := mem.txsMap.Load(after); ok {
+		seq = e.(*clist.CElement).Value.(*mempoolTx).seq
+	} else if seq, ok = mem.removedSeqs.Get(after); !ok {
+		return nil, ErrTxNotFound
+	}
+
+	if max < 0 {
+		max = mem.txs.Len()
+	}
+
+	txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max))
+	for e := mem.txs.Front(); e != nil && len(txs) < max; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.seq > seq {
+			txs = append(txs, memTx.tx)
+		}
+	}
+	return txs, nil
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) TxByKey(key [TxKeySize]byte) (types.Tx, bool) {
+	e, ok := mem.txsMap.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).tx, true
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
+	blockTime time.Time,
+	nextProposerProTxHash []byte,
 	txs types.Txs,
 	deliverTxResponses []*abci.ResponseDeliverTx,
 	preCheck PreCheckFunc,
 	postCheck PostCheckFunc,
 ) error {
+	// The txs of the previous height must be rechecked, before they are
+	// updated again.
+	mem.waitForRecheck()
+
 	// Set height
 	mem.height = height
+	mem.blockTime = blockTime
+	mem.nextProposerProTxHash = nextProposerProTxHash
 	mem.notifiedTxsAvailable = false
 
 	if preCheck != nil {
@@ -608,15 +987,25 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	// Evict the txs, which exist in the mempool for too long. The remaining txs
+	// keep their height and timestamp when they are rechecked.
+	if mem.config.TTLNumBlocks > 0 || mem.config.TTLDuration > 0 {
+		mem.purgeExpiredTxs(height)
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
 		if mem.config.Recheck {
 			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
-			mem.recheckTxs()
-			// At this point, mem.txs are being rechecked.
+			mem.recheckMtx.Lock()
+			mem.recheckEpoch++
+			mem.rechecking = true
+			mem.recheckMtx.Unlock()
+			go mem.recheckTxs()
+			// At this point, mem.txs are being rechecked in the background.
 			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
-			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
+			// ReapMaxBytesMaxGas waits for the txs it reaps to be rechecked.
 		} else {
 			mem.notifyTxsAvailable()
 		}
@@ -628,9 +1017,63 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// purgeExpiredTxs removes the txs, which exist in the mempool for more than
+// TTLNumBlocks blocks or for more than TTLDuration, in the order they were
+// added. They are removed from the cache as well, so they can be submitted
+// again.
+//
+// Lock() must be held by the caller during execution.
+func (mem *CListMempool) purgeExpiredTxs(height int64) {
+	now := time.Now().UTC()
+	for e := mem.txs.Front(); e != nil; {
+		next := e.Next()
+		memTx := e.Value.(*mempoolTx)
+		if (mem.config.TTLNumBlocks > 0 && height-memTx.Height() > mem.config.TTLNumBlocks) ||
+			(mem.config.TTLDuration > 0 && now.Sub(memTx.timestamp) > mem.config.TTLDuration) {
+			mem.logger.Debug("evicting expired tx", "tx", txID(memTx.tx), "height", memTx.Height(),
+				"timestamp", memTx.timestamp)
+			mem.removeTx(memTx.tx, e, true)
+			mem.metrics.EvictedTTLTxs.Add(1)
+		}
+		e = next
+	}
+}
+
+// checkTxRequest returns the CheckTx request for the tx, which carries the
+// height the tx is expected to be executed at, the time of the last block and
+// the proposer of the next block.
+func (mem *CListMempool) checkTxRequest(tx types.Tx, checkType abci.CheckTxType) abci.RequestCheckTx {
+	req := abci.RequestCheckTx{
+		Tx:                    tx,
+		Type:                  checkType,
+		Height:                mem.height + 1,
+		NextProposerProTxHash: mem.nextProposerProTxHash,
+	}
+	if !mem.blockTime.IsZero() {
+		blockTime := mem.blockTime
+		req.BlockTime = &blockTime
+	}
+	return req
+}
+
+// recheckTxs rechecks all txs in the mempool, the responses are handled by
+// resCbRecheck.
 func (mem *CListMempool) recheckTxs() {
-	if mem.Size() == 0 {
-		panic("recheckTxs is called, but the mempool is empty")
+	mem.sendMtx.Lock()
+	defer mem.sendMtx.Unlock()
+
+	// the responses to the txs, which were checked before, must be handled
+	// before the recheck starts
+	err := mem.proxyAppConn.FlushSync()
+	if err != nil {
+		mem.logger.Error("failed to flush the app connection before the recheck", "err", err)
+	}
+	if err != nil || mem.Size() == 0 {
+		mem.recheckMtx.Lock()
+		mem.rechecking = false
+		mem.recheckCond.Broadcast()
+		mem.recheckMtx.Unlock()
+		return
 	}
 
 	mem.recheckCursor = mem.txs.Front()
@@ -640,10 +1083,7 @@ func (mem *CListMempool) recheckTxs() {
 	// NOTE: globalCb may be called concurrently.
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
-		mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
-			Tx:   memTx.tx,
-			Type: abci.CheckTxType_Recheck,
-		})
+		mem.proxyAppConn.CheckTxAsync(mem.checkTxRequest(memTx.tx, abci.CheckTxType_Recheck))
 	}
 
 	mem.proxyAppConn.FlushAsync()
@@ -653,9 +1093,16 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64     // height that this tx had been validated in
+	timestamp time.Time // time when this tx had been validated
+	gasWanted int64     // amount of gas this tx states it will require
+	priority  int64     // priority of this tx in the prioritized mempool
+	sender    string    // sender of this tx, if the app identifies it
+
+	// recheck epoch of the mempool, when this tx was last checked
+	checkedEpoch uint64
+	tx        types.Tx  //
+	seq       uint64    // position of this tx in the order of the mempool
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -673,6 +1120,7 @@ type txCache interface {
 	Reset()
 	Push(tx types.Tx) bool
 	Remove(tx types.Tx)
+	Has(key [TxKeySize]byte) bool
 }
 
 // mapTxCache maintains a LRU cache of transactions. This only stores the hash
@@ -729,6 +1177,20 @@ func (cache *mapTxCache) Push(tx types.Tx) bool {
 	return true
 }
 
+// Resize changes the number of txs the cache remembers, forgetting the oldest
+// ones if there are more.
+func (cache *mapTxCache) Resize(size int) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	cache.size = size
+	for cache.list.Len() > size {
+		popped := cache.list.Front()
+		delete(cache.cacheMap, popped.Value.([TxKeySize]byte))
+		cache.list.Remove(popped)
+	}
+}
+
 // Remove removes the given tx from the cache.
 func (cache *mapTxCache) Remove(tx types.Tx) {
 	cache.mtx.Lock()
@@ -742,13 +1204,82 @@ func (cache *mapTxCache) Remove(tx types.Tx) {
 	cache.mtx.Unlock()
 }
 
+// Has returns true if the tx with the given key is in the cache.
+func (cache *mapTxCache) Has(key [TxKeySize]byte) bool {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	_, ok := cache.cacheMap[key]
+	return ok
+}
+
 type nopTxCache struct{}
 
 var _ txCache = (*nopTxCache)(nil)
 
-func (nopTxCache) Reset()             {}
-func (nopTxCache) Push(types.Tx) bool { return true }
-func (nopTxCache) Remove(types.Tx)    {}
+func (nopTxCache) Reset()                   {}
+func (nopTxCache) Push(types.Tx) bool       { return true }
+func (nopTxCache) Remove(types.Tx)          {}
+func (nopTxCache) Has([TxKeySize]byte) bool { return false }
+
+//--------------------------------------------------------------------------------
+
+// txSeqCache remembers the sequence numbers of the last size txs removed from
+// the mempool.
+type txSeqCache struct {
+	mtx  tmsync.Mutex
+	size int
+	seqs map[[TxKeySize]byte]uint64
+	list *list.List
+}
+
+func newTxSeqCache(size int) *txSeqCache {
+	return &txSeqCache{
+		size: size,
+		seqs: make(map[[TxKeySize]byte]uint64, size),
+		list: list.New(),
+	}
+}
+
+// Reset resets the cache to an empty state.
+func (cache *txSeqCache) Reset() {
+	cache.mtx.Lock()
+	cache.seqs = make(map[[TxKeySize]byte]uint64, cache.size)
+	cache.list.Init()
+	cache.mtx.Unlock()
+}
+
+// Push remembers the sequence number of the removed tx with the given key,
+// forgetting the oldest one if the cache is full.
+func (cache *txSeqCache) Push(key [TxKeySize]byte, seq uint64) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if cache.size <= 0 {
+		return
+	}
+	if _, exists := cache.seqs[key]; exists {
+		// the tx was added again after it was removed
+		cache.seqs[key] = seq
+		return
+	}
+	if cache.list.Len() >= cache.size {
+		popped := cache.list.Front()
+		delete(cache.seqs, popped.Value.([TxKeySize]byte))
+		cache.list.Remove(popped)
+	}
+	cache.list.PushBack(key)
+	cache.seqs[key] = seq
+}
+
+// Get returns the sequence number of the removed tx with the given key.
+func (cache *txSeqCache) Get(key [TxKeySize]byte) (uint64, bool) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	seq, ok := cache.seqs[key]
+	return seq, ok
+}
 
 //--------------------------------------------------------------------------------
 
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
@@ -9,6 +10,8 @@ import (
 	mrand "math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -170,7 +173,7 @@ func TestMempoolFilters(t *testing.T) {
 		{10, PreCheckMaxBytes(22), PostCheckMaxGas(0), 0},
 	}
 	for tcIndex, tt := range tests {
-		err := mempool.Update(1, emptyTxArr, abciResponses(len(emptyTxArr), abci.CodeTypeOK), tt.preFilter, tt.postFilter)
+		err := mempool.Update(1, time.Time{}, nil, emptyTxArr, abciResponses(len(emptyTxArr), abci.CodeTypeOK), tt.preFilter, tt.postFilter)
 		require.NoError(t, err)
 		checkTxs(t, mempool, tt.numTxsToCreate, UnknownPeerID)
 		require.Equal(t, tt.expectedNumTxs, mempool.Size(), "mempool had the incorrect size, on test case %d", tcIndex)
@@ -186,7 +189,7 @@ func TestMempoolUpdate(t *testing.T) {
 
 	// 1. Adds valid txs to the cache
 	{
-		err := mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+		err := mempool.Update(1, time.Time{}, nil, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
 		require.NoError(t, err)
 		err = mempool.CheckTx([]byte{0x01}, nil, TxInfo{})
 		if assert.Error(t, err) {
@@ -198,7 +201,7 @@ func TestMempoolUpdate(t *testing.T) {
 	{
 		err := mempool.CheckTx([]byte{0x02}, nil, TxInfo{})
 		require.NoError(t, err)
-		err = mempool.Update(1, []types.Tx{[]byte{0x02}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+		err = mempool.Update(1, time.Time{}, nil, []types.Tx{[]byte{0x02}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
 		require.NoError(t, err)
 		assert.Zero(t, mempool.Size())
 	}
@@ -207,7 +210,7 @@ func TestMempoolUpdate(t *testing.T) {
 	{
 		err := mempool.CheckTx([]byte{0x03}, nil, TxInfo{})
 		require.NoError(t, err)
-		err = mempool.Update(1, []types.Tx{[]byte{0x03}}, abciResponses(1, 1), nil, nil)
+		err = mempool.Update(1, time.Time{}, nil, []types.Tx{[]byte{0x03}}, abciResponses(1, 1), nil, nil)
 		require.NoError(t, err)
 		assert.Zero(t, mempool.Size())
 
@@ -216,6 +219,86 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolFlushCache(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// the committed tx is only in the cache, the pending one is in the mempool
+	err := mempool.Update(1, time.Time{}, nil, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, mempool.CheckTx([]byte{0x02}, nil, TxInfo{}))
+
+	mempool.FlushCache()
+	assert.Equal(t, 1, mempool.Size())
+
+	// the committed tx can be submitted again, the pending one can't be added
+	// twice
+	assert.NoError(t, mempool.CheckTx([]byte{0x01}, nil, TxInfo{}))
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx([]byte{0x02}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+}
+
+func TestReapTxsAfter(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 20000
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	txs := checkTxs(t, mempool, 10000, UnknownPeerID)
+
+	_, err := mempool.ReapTxsAfter(TxKey([]byte("unknown")), 100)
+	assert.Equal(t, ErrTxNotFound, err)
+
+	// more txs are added during the walk
+	added := make(chan types.Txs)
+	go func() {
+		added <- checkTxs(t, mempool, 1000, UnknownPeerID)
+	}()
+
+	walked := types.Txs{txs[0]}
+	for page := 0; ; page++ {
+		cursor := walked[len(walked)-1]
+		if page == 10 {
+			// the cursor tx and a tx, which was not walked yet, are committed
+			mempool.Lock()
+			err := mempool.Update(1, time.Time{}, nil, types.Txs{cursor, txs[5000]}, abciResponses(2, abci.CodeTypeOK), nil, nil)
+			mempool.Unlock()
+			require.NoError(t, err)
+
+			_, ok := mempool.TxByKey(TxKey(txs[5000]))
+			assert.False(t, ok)
+		}
+
+		reaped, err := mempool.ReapTxsAfter(TxKey(cursor), 100)
+		require.NoError(t, err)
+		if len(reaped) == 0 {
+			break
+		}
+		assert.LessOrEqual(t, len(reaped), 100)
+		walked = append(walked, reaped...)
+	}
+
+	// wait for the added txs and walk them too
+	addedTxs := <-added
+	reaped, err := mempool.ReapTxsAfter(TxKey(walked[len(walked)-1]), -1)
+	require.NoError(t, err)
+	walked = append(walked, reaped...)
+
+	// the txs are walked in the order of the mempool, exactly once
+	expected := append(append(types.Txs{}, txs[:5000]...), txs[5001:]...)
+	require.Equal(t, len(expected)+len(addedTxs), len(walked))
+	assert.Equal(t, expected, walked[:len(expected)])
+	assert.ElementsMatch(t, addedTxs, walked[len(expected):])
+
+	tx, ok := mempool.TxByKey(TxKey(txs[1]))
+	assert.True(t, ok)
+	assert.Equal(t, txs[1], tx)
+}
+
 func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	app := counter.NewApplication(true)
 	cc := proxy.NewLocalClientCreator(app)
@@ -238,7 +321,7 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 		// simulate new block
 		_ = app.DeliverTx(abci.RequestDeliverTx{Tx: a})
 		_ = app.DeliverTx(abci.RequestDeliverTx{Tx: b})
-		err = mempool.Update(1, []types.Tx{a, b},
+		err = mempool.Update(1, time.Time{}, nil, []types.Tx{a, b},
 			[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: 2}}, nil, nil)
 		require.NoError(t, err)
 
@@ -273,6 +356,598 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	}
 }
 
+func TestMempool_ExpiredTxs_NumBlocks(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.TTLNumBlocks = 3
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	var removed types.Txs
+	WithTxRemovedCallback(func(tx types.Tx) { removed = append(removed, tx) })(mempool)
+
+	// txs added at heights 0 and 1
+	txs0 := checkTxs(t, mempool, 10, UnknownPeerID)
+	require.NoError(t, mempool.Update(1, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	txs1 := checkTxs(t, mempool, 5, UnknownPeerID)
+
+	// the txs are rechecked at every height, which doesn't reset their TTL
+	for height := int64(2); height <= 3; height++ {
+		require.NoError(t, mempool.Update(height, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+		assert.Equal(t, len(txs0)+len(txs1), mempool.Size())
+		assert.Empty(t, removed)
+	}
+
+	// the txs added at height 0 expire first, in the order they were added
+	require.NoError(t, mempool.Update(4, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, txs0, removed)
+	assert.Equal(t, len(txs1), mempool.Size())
+
+	// an evicted tx can be submitted again, its TTL starts again
+	require.NoError(t, mempool.CheckTx(txs0[0], nil, TxInfo{}))
+
+	require.NoError(t, mempool.Update(5, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, append(txs0, txs1...), removed)
+	assert.Equal(t, types.Txs{txs0[0]}, mempool.ReapMaxTxs(-1))
+}
+
+func TestMempool_ExpiredTxs_Duration(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.TTLDuration = 500 * time.Millisecond
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	var removed types.Txs
+	WithTxRemovedCallback(func(tx types.Tx) { removed = append(removed, tx) })(mempool)
+
+	txs0 := checkTxs(t, mempool, 10, UnknownPeerID)
+	time.Sleep(300 * time.Millisecond)
+	txs1 := checkTxs(t, mempool, 5, UnknownPeerID)
+	require.NoError(t, mempool.Update(1, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	assert.Empty(t, removed)
+
+	// the txs added first expire first
+	time.Sleep(300 * time.Millisecond)
+	require.NoError(t, mempool.Update(2, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, txs0, removed)
+	assert.Equal(t, len(txs1), mempool.Size())
+
+	// an evicted tx can be submitted again
+	require.NoError(t, mempool.CheckTx(txs0[0], nil, TxInfo{}))
+
+	time.Sleep(300 * time.Millisecond)
+	require.NoError(t, mempool.Update(3, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, append(txs0, txs1...), removed)
+	assert.Equal(t, types.Txs{txs0[0]}, mempool.ReapMaxTxs(-1))
+}
+
+// priorityApp accepts the txs formatted as "<sender>=<priority>=<data>" and
+// returns their sender and priority in CheckTx. The sender may be empty.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	parts := bytes.SplitN(req.Tx, []byte("="), 3)
+	if len(parts) != 3 {
+		return abci.ResponseCheckTx{Code: 1}
+	}
+	priority, err := strconv.ParseInt(string(parts[1]), 10, 64)
+	if err != nil {
+		return abci.ResponseCheckTx{Code: 1}
+	}
+	return abci.ResponseCheckTx{
+		Code:      abci.CodeTypeOK,
+		GasWanted: 1,
+		Priority:  priority,
+		Sender:    string(parts[0]),
+	}
+}
+
+func priorityTx(sender string, priority int64, data string) types.Tx {
+	return types.Tx(fmt.Sprintf("%s=%d=%s", sender, priority, data))
+}
+
+func newPrioritizedMempool(t *testing.T, size int, maxTxsBytes int64) (*CListMempool, cleanupFunc) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Version = cfg.MempoolV1
+	config.Mempool.Size = size
+	config.Mempool.MaxTxsBytes = maxTxsBytes
+	return newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+}
+
+func TestPrioritizedMempool_Reap(t *testing.T) {
+	txs := types.Txs{
+		priorityTx("", 1, "a"),
+		priorityTx("", 5, "b"),
+		priorityTx("", 3, "c"),
+		priorityTx("", 5, "d"),
+		priorityTx("", 1, "e"),
+	}
+	byPriority := types.Txs{txs[1], txs[3], txs[2], txs[0], txs[4]}
+
+	mempool, cleanup := newPrioritizedMempool(t, 100, 1<<20)
+	defer cleanup()
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// the txs are reaped by priority, then in the order they were added
+	assert.Equal(t, byPriority, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, byPriority, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, byPriority[:2], mempool.ReapMaxBytesMaxGas(-1, 2))
+	maxBytes := types.ComputeProtoSizeForTxs(byPriority[:3])
+	assert.Equal(t, byPriority[:3], mempool.ReapMaxBytesMaxGas(maxBytes, -1))
+
+	// the txs are gossiped and walked in the order they were added
+	gossiped := types.Txs{}
+	for e := mempool.TxsFront(); e != nil; e = e.Next() {
+		gossiped = append(gossiped, e.Value.(*mempoolTx).tx)
+	}
+	assert.Equal(t, txs, gossiped)
+	reaped, err := mempool.ReapTxsAfter(TxKey(txs[0]), -1)
+	require.NoError(t, err)
+	assert.Equal(t, txs[1:], reaped)
+
+	// the FIFO mempool ignores the priority
+	config := cfg.ResetTestRoot("mempool_test")
+	fifo, cleanupFIFO := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+	defer cleanupFIFO()
+	for _, tx := range txs {
+		require.NoError(t, fifo.CheckTx(tx, nil, TxInfo{}))
+	}
+	assert.Equal(t, txs, fifo.ReapMaxBytesMaxGas(-1, -1))
+}
+
+func TestPrioritizedMempool_Eviction(t *testing.T) {
+	mempool, cleanup := newPrioritizedMempool(t, 4, 1<<20)
+	defer cleanup()
+
+	var removed types.Txs
+	WithTxRemovedCallback(func(tx types.Tx) { removed = append(removed, tx) })(mempool)
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "a"),
+		priorityTx("", 2, "b"),
+		priorityTx("alice", 10, "c"),
+		priorityTx("", 2, "d"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// a tx with the lowest priority is not evicted to make room for the tx
+	lowTx := priorityTx("", 1, "e")
+	require.NoError(t, mempool.CheckTx(lowTx, nil, TxInfo{}))
+	assert.Empty(t, removed)
+	_, ok := mempool.TxByKey(TxKey(lowTx))
+	assert.False(t, ok)
+
+	// the lowest priority tx of alice is not evicted, while her later tx is
+	// pending, the latest added tx of the lowest priority is evicted instead
+	require.NoError(t, mempool.CheckTx(priorityTx("", 3, "f"), nil, TxInfo{}))
+	assert.Equal(t, types.Txs{txs[3]}, removed)
+	assert.Equal(t, 4, mempool.Size())
+
+	// the evicted tx was removed from the cache and can be submitted again
+	require.NoError(t, mempool.CheckTx(txs[3], nil, TxInfo{}))
+	assert.Equal(t, types.Txs{txs[3]}, removed)
+	_, ok = mempool.TxByKey(TxKey(txs[3]))
+	assert.False(t, ok)
+
+	// once the later tx of alice is committed, her first tx can be evicted
+	require.NoError(t, mempool.Update(1, time.Time{}, nil, types.Txs{txs[2]}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.NoError(t, mempool.CheckTx(priorityTx("", 2, "g"), nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(priorityTx("", 3, "h"), nil, TxInfo{}))
+	assert.Equal(t, types.Txs{txs[3], txs[2], txs[0]}, removed)
+	assert.Equal(t, types.Txs{priorityTx("", 3, "f"), priorityTx("", 3, "h"), txs[1], priorityTx("", 2, "g")},
+		mempool.ReapMaxTxs(-1))
+}
+
+func TestPrioritizedMempool_EvictionMaxTxsBytes(t *testing.T) {
+	small := priorityTx("", 1, "a")
+	mempool, cleanup := newPrioritizedMempool(t, 100, int64(3*len(small)))
+	defer cleanup()
+
+	txs := types.Txs{priorityTx("", 1, "a"), priorityTx("", 2, "b"), priorityTx("", 1, "c")}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// the large tx needs the room of two txs, the ones with the lowest priority
+	// are evicted, starting with the latest added
+	large := priorityTx("", 3, "large")
+	require.NoError(t, mempool.CheckTx(large, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{large, txs[1]}, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, int64(len(large)+len(txs[1])), mempool.TxsBytes())
+
+	// nothing is evicted, if there isn't enough room for the tx even after
+	// evicting all txs with a lower priority
+	huge := priorityTx("", 3, "huge-huge-huge")
+	require.NoError(t, mempool.CheckTx(huge, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{large, txs[1]}, mempool.ReapMaxTxs(-1))
+}
+
+func TestPrioritizedMempool_Concurrency(t *testing.T) {
+	const (
+		size    = 200
+		senders = 10
+		txCount = 1000
+	)
+	mempool, cleanup := newPrioritizedMempool(t, size, 1<<20)
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	for s := 0; s < senders; s++ {
+		wg.Add(1)
+		go func(s int) {
+			defer wg.Done()
+			for i := 0; i < txCount; i++ {
+				tx := priorityTx(fmt.Sprintf("sender%d", s), mrand.Int63n(100), fmt.Sprintf("%d", i))
+				err := mempool.CheckTx(tx, nil, TxInfo{SenderID: uint16(s)})
+				if err != nil {
+					assert.IsType(t, ErrMempoolIsFull{}, err)
+				}
+			}
+		}(s)
+	}
+
+	// reap and commit blocks while the txs are added
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for height := int64(1); height <= 20; height++ {
+			txs := mempool.ReapMaxBytesMaxGas(-1, 50)
+			mempool.Lock()
+			err := mempool.Update(height, time.Time{}, nil, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil)
+			mempool.Unlock()
+			assert.NoError(t, err)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	wg.Wait()
+	<-done
+
+	assert.LessOrEqual(t, mempool.Size(), size)
+	var txsBytes int64
+	for _, tx := range mempool.ReapMaxTxs(-1) {
+		txsBytes += int64(len(tx))
+	}
+	assert.Equal(t, txsBytes, mempool.TxsBytes())
+
+	// the txs are reaped in the order of their priority
+	reaped := mempool.reapOrder()
+	for i := 1; i < len(reaped); i++ {
+		assert.GreaterOrEqual(t, reaped[i-1].Value.(*mempoolTx).priority, reaped[i].Value.(*mempoolTx).priority)
+	}
+}
+
+// recheckApp takes recheckTime to recheck a tx and invalidates the txs
+// starting with "bad" in the recheck.
+type recheckApp struct {
+	abci.BaseApplication
+	recheckTime time.Duration
+}
+
+func (app recheckApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if req.Type == abci.CheckTxType_Recheck {
+		time.Sleep(app.recheckTime)
+		if bytes.HasPrefix(req.Tx, []byte("bad")) {
+			return abci.ResponseCheckTx{Code: 1}
+		}
+	}
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+}
+
+func TestMempool_AsyncRecheck(t *testing.T) {
+	const (
+		txCount     = 20
+		recheckTime = 10 * time.Millisecond
+	)
+	txs := make(types.Txs, 0, txCount)
+	for i := 0; i < txCount; i++ {
+		prefix := "good"
+		if i%2 == 1 {
+			prefix = "bad"
+		}
+		txs = append(txs, types.Tx(fmt.Sprintf("%s%d", prefix, i)))
+	}
+
+	testCases := []struct {
+		name              string
+		reapBeforeRecheck bool
+	}{
+		{"reap rechecked txs", false},
+		{"reap before recheck", true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := cfg.ResetTestRoot("mempool_test")
+			config.Mempool.ReapBeforeRecheck = tc.reapBeforeRecheck
+			cc := proxy.NewLocalClientCreator(recheckApp{recheckTime: recheckTime})
+			mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+			defer cleanup()
+
+			for _, tx := range txs {
+				require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+			}
+
+			// the commit doesn't wait for the recheck
+			start := time.Now()
+			mempool.Lock()
+			require.NoError(t, mempool.Update(1, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+			mempool.Unlock()
+			assert.Less(t, int64(time.Since(start)), int64(txCount*recheckTime/2))
+
+			reaped := mempool.ReapMaxBytesMaxGas(-1, -1)
+			if tc.reapBeforeRecheck {
+				assert.Len(t, reaped, txCount)
+			} else {
+				// the txs invalidated by the recheck are not reaped
+				assert.Len(t, reaped, txCount/2)
+				for _, tx := range reaped {
+					assert.True(t, bytes.HasPrefix(tx, []byte("good")))
+				}
+			}
+
+			// new txs can be added during the recheck and the next commit waits
+			// for the recheck
+			require.NoError(t, mempool.CheckTx(types.Tx("good-new"), nil, TxInfo{}))
+			mempool.Lock()
+			require.NoError(t, mempool.Update(2, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+			mempool.Unlock()
+			mempool.waitForRecheck()
+			assert.Equal(t, txCount/2+1, mempool.Size())
+		})
+	}
+}
+
+// checkTxContextApp records the CheckTx requests.
+type checkTxContextApp struct {
+	abci.BaseApplication
+
+	mtx  sync.Mutex
+	reqs []abci.RequestCheckTx
+}
+
+func (app *checkTxContextApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.reqs = append(app.reqs, req)
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+}
+
+func (app *checkTxContextApp) lastRequest() abci.RequestCheckTx {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.reqs[len(app.reqs)-1]
+}
+
+func TestMempool_CheckTxContext(t *testing.T) {
+	app := &checkTxContextApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(config.RootDir)
+	appConnMem, err := cc.NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() { _ = appConnMem.Stop() })
+
+	// before the first update, the last block of the node is used
+	startTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	startProposer := tmrand.Bytes(32)
+	mempool := NewCListMempool(config.Mempool, appConnMem, 5, WithLastBlock(startTime, startProposer))
+	mempool.SetLogger(log.TestingLogger())
+
+	require.NoError(t, mempool.CheckTx(types.Tx("tx1"), nil, TxInfo{}))
+	require.NoError(t, mempool.FlushAppConn())
+	req := app.lastRequest()
+	assert.Equal(t, abci.CheckTxType_New, req.Type)
+	assert.EqualValues(t, 6, req.Height)
+	require.NotNil(t, req.BlockTime)
+	assert.Equal(t, startTime, *req.BlockTime)
+	assert.Equal(t, startProposer, req.NextProposerProTxHash)
+
+	// the txs are rechecked with the last updated block
+	blockTime := startTime.Add(time.Second)
+	proposer := tmrand.Bytes(32)
+	mempool.Lock()
+	require.NoError(t, mempool.Update(6, blockTime, proposer, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mempool.Unlock()
+	mempool.waitForRecheck()
+	req = app.lastRequest()
+	assert.Equal(t, abci.CheckTxType_Recheck, req.Type)
+	assert.EqualValues(t, 7, req.Height)
+	require.NotNil(t, req.BlockTime)
+	assert.Equal(t, blockTime, *req.BlockTime)
+	assert.Equal(t, proposer, req.NextProposerProTxHash)
+
+	// the block time is not sent if unknown
+	mempool.Lock()
+	require.NoError(t, mempool.Update(7, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mempool.Unlock()
+	mempool.waitForRecheck()
+	require.NoError(t, mempool.CheckTx(types.Tx("tx2"), nil, TxInfo{}))
+	require.NoError(t, mempool.FlushAppConn())
+	req = app.lastRequest()
+	assert.Equal(t, abci.CheckTxType_New, req.Type)
+	assert.EqualValues(t, 8, req.Height)
+	assert.Nil(t, req.BlockTime)
+	assert.Nil(t, req.NextProposerProTxHash)
+}
+
+// replaceApp replaces the pending tx of a sender with its next tx. The txs are
+// in the form "sender=data".
+type replaceApp struct {
+	abci.BaseApplication
+
+	mtx     sync.Mutex
+	pending map[string]types.Tx
+}
+
+func newReplaceApp() *replaceApp {
+	return &replaceApp{pending: make(map[string]types.Tx)}
+}
+
+func (app *replaceApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if req.Type == abci.CheckTxType_Recheck {
+		return abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+	}
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	sender := string(bytes.SplitN(req.Tx, []byte("="), 2)[0])
+	res := abci.ResponseCheckTx{Code: abci.CodeTypeOK}
+	if pending, ok := app.pending[sender]; ok {
+		key := TxKey(pending)
+		res.ReplaceTxHash = key[:]
+	}
+	app.pending[sender] = req.Tx
+	return res
+}
+
+func TestMempool_ReplaceTx(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	// the prioritized mempool checks whether it's full only after the app
+	// checked the tx, so a tx may replace a tx in the full mempool
+	config.Mempool.Version = cfg.MempoolV1
+	config.Mempool.Size = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(newReplaceApp()), config)
+	defer cleanup()
+
+	var removed types.Txs
+	mempool.onTxRemoved = func(tx types.Tx) { removed = append(removed, tx) }
+
+	for _, tx := range []string{"a=1", "b=1", "a=2"} {
+		require.NoError(t, mempool.CheckTx(types.Tx(tx), nil, TxInfo{}))
+	}
+
+	// the replaced tx is removed, even though the mempool is full
+	assert.Equal(t, types.Txs{types.Tx("b=1"), types.Tx("a=2")}, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, types.Txs{types.Tx("a=1")}, removed)
+	assert.EqualValues(t, len("b=1")+len("a=2"), mempool.TxsBytes())
+
+	// the replaced tx stays in the cache, so it's not added again, e.g. when a
+	// peer gossips it back
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx(types.Tx("a=1"), nil, TxInfo{SenderID: 1}))
+
+	// an unknown hash doesn't replace anything
+	mempool.Flush()
+	require.NoError(t, mempool.CheckTx(types.Tx("c=1"), nil, TxInfo{}))
+	assert.Equal(t, 1, mempool.Size())
+}
+
+func TestMempool_ReplaceTxWhileReaping(t *testing.T) {
+	const (
+		senders      = 10
+		txsPerSender = 100
+	)
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(newReplaceApp()), config)
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func(sender int) {
+			defer wg.Done()
+			for j := 0; j < txsPerSender; j++ {
+				tx := types.Tx(fmt.Sprintf("%d=%d", sender, j))
+				assert.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+			}
+		}(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// a block never contains a tx and the tx, which replaced it
+	for reaping := true; reaping; {
+		select {
+		case <-done:
+			reaping = false
+		default:
+		}
+		reaped := make(map[string]types.Tx)
+		for _, tx := range mempool.ReapMaxBytesMaxGas(-1, -1) {
+			sender := string(bytes.SplitN(tx, []byte("="), 2)[0])
+			if other, ok := reaped[sender]; ok {
+				t.Fatalf("reaped both %s and %s", other, tx)
+			}
+			reaped[sender] = tx
+		}
+	}
+
+	// only the last tx of each sender is left
+	assert.Equal(t, senders, mempool.Size())
+}
+
+func TestMempool_Snapshot(t *testing.T) {
+	const txCount = 1000
+
+	mempool, cleanup := newPrioritizedMempool(t, txCount, 1<<20)
+	defer cleanup()
+	txs := make(types.Txs, txCount)
+	for i := range txs {
+		txs[i] = priorityTx(fmt.Sprintf("s%d", i%10), int64(i%3), strconv.Itoa(i))
+		require.NoError(t, mempool.CheckTx(txs[i], nil, TxInfo{}))
+	}
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mempool.Unlock()
+	mempool.waitForRecheck()
+
+	start := time.Now()
+	snapshot := mempool.Snapshot(-1)
+	assert.Less(t, int64(time.Since(start)), int64(100*time.Millisecond))
+
+	// the txs are in the order they are reaped
+	require.Len(t, snapshot, txCount)
+	for i, tx := range mempool.ReapMaxBytesMaxGas(-1, -1) {
+		memTx := snapshot[i]
+		assert.Equal(t, TxKey(tx), memTx.Key)
+		assert.Equal(t, tx, memTx.Tx)
+		assert.Equal(t, len(tx), memTx.Size)
+		assert.EqualValues(t, 0, memTx.Height)
+		assert.False(t, memTx.Time.IsZero())
+		assert.EqualValues(t, 1, memTx.GasWanted)
+		assert.Equal(t, string(bytes.SplitN(tx, []byte("="), 2)[0]), memTx.Sender)
+		assert.False(t, memTx.RecheckPending)
+	}
+
+	assert.Equal(t, snapshot[:10], mempool.Snapshot(10))
+	assert.Empty(t, mempool.Snapshot(0))
+}
+
+func TestMempool_SnapshotRecheckPending(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	cc := proxy.NewLocalClientCreator(recheckApp{recheckTime: 10 * time.Millisecond})
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	txs := types.Txs{types.Tx("good1"), types.Tx("good2"), types.Tx("good3")}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, time.Time{}, nil, nil, abciResponses(0, abci.CodeTypeOK), nil, nil))
+	mempool.Unlock()
+
+	// the last tx is rechecked last
+	assert.True(t, mempool.Snapshot(-1)[2].RecheckPending)
+
+	mempool.waitForRecheck()
+	for _, memTx := range mempool.Snapshot(-1) {
+		assert.False(t, memTx.RecheckPending)
+	}
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -294,7 +969,7 @@ func TestTxsAvailable(t *testing.T) {
 	// it should fire once now for the new height
 	// since there are still txs left
 	committedTxs, txs := txs[:50], txs[50:]
-	if err := mempool.Update(1, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil); err != nil {
+	if err := mempool.Update(1, time.Time{}, nil, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil); err != nil {
 		t.Error(err)
 	}
 	ensureFire(t, mempool.TxsAvailable(), timeoutMS)
@@ -306,7 +981,7 @@ func TestTxsAvailable(t *testing.T) {
 
 	// now call update with all the txs. it should not fire as there are no txs left
 	committedTxs = append(txs, moreTxs...) //nolint: gocritic
-	if err := mempool.Update(2, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil); err != nil {
+	if err := mempool.Update(2, time.Time{}, nil, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil); err != nil {
 		t.Error(err)
 	}
 	ensureNoFire(t, mempool.TxsAvailable(), timeoutMS)
@@ -365,7 +1040,7 @@ func TestSerialReap(t *testing.T) {
 			binary.BigEndian.PutUint64(txBytes, uint64(i))
 			txs = append(txs, txBytes)
 		}
-		if err := mempool.Update(0, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil); err != nil {
+		if err := mempool.Update(0, time.Time{}, nil, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil); err != nil {
 			t.Error(err)
 		}
 	}
@@ -536,7 +1211,7 @@ func TestMempoolTxsBytes(t *testing.T) {
 	assert.EqualValues(t, 1, mempool.TxsBytes())
 
 	// 3. zero again after tx is removed by Update
-	err = mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	err = mempool.Update(1, time.Time{}, nil, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
@@ -586,8 +1261,9 @@ func TestMempoolTxsBytes(t *testing.T) {
 	require.NotEmpty(t, res2.Data)
 
 	// Pretend like we committed nothing so txBytes gets rechecked and removed.
-	err = mempool.Update(1, []types.Tx{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	err = mempool.Update(1, time.Time{}, nil, []types.Tx{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
 	require.NoError(t, err)
+	mempool.waitForRecheck()
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
 	// 7. Test RemoveTxByKey function
@@ -601,6 +1277,39 @@ func TestMempoolTxsBytes(t *testing.T) {
 
 }
 
+func TestMempool_SetSize(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 1
+	config.Mempool.CacheSize = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx([]byte{0x01}, nil, TxInfo{}))
+	err := mempool.CheckTx([]byte{0x02}, nil, TxInfo{})
+	assert.IsType(t, ErrMempoolIsFull{}, err)
+
+	mempool.SetSize(2)
+	require.NoError(t, mempool.CheckTx([]byte{0x02}, nil, TxInfo{}))
+	assert.Equal(t, 2, mempool.Size())
+
+	// the txs, which are already in the mempool, are kept
+	mempool.SetSize(1)
+	assert.Equal(t, 2, mempool.Size())
+	err = mempool.CheckTx([]byte{0x03}, nil, TxInfo{})
+	assert.IsType(t, ErrMempoolIsFull{}, err)
+
+	// shrinking the cache forgets the oldest txs
+	require.NoError(t, mempool.SetCacheSize(1))
+	cache := mempool.cache.(*mapTxCache)
+	assert.Equal(t, 1, cache.list.Len())
+	assert.False(t, cache.Push([]byte{0x02}))
+	assert.True(t, cache.Push([]byte{0x01}))
+
+	assert.Error(t, mempool.SetCacheSize(0))
+}
+
 // This will non-deterministically catch some concurrency failures like
 // https://github.com/tendermint/tendermint/issues/3509
 // TODO: all of the tests should probably also run using the remote proxy app
@@ -8,6 +8,10 @@ import (
 var (
 	// ErrTxInCache is returned to the client if we saw tx earlier
 	ErrTxInCache = errors.New("tx already exists in cache")
+
+	// ErrTxNotFound is returned if the tx to continue reaping after is neither
+	// in the mempool nor was recently removed from it
+	ErrTxNotFound = errors.New("tx not found in mempool")
 )
 
 // ErrTxTooLarge means the tx is too big to be sent in a message to other peers
@@ -2,6 +2,7 @@ package mempool
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/p2p"
@@ -29,6 +30,24 @@ type Mempool interface {
 	// transactions (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// ReapTxsAfter reaps up to max transactions, which follow the transaction
+	// with the given key in the mempool. The key can belong to a transaction,
+	// which was recently removed from the mempool, so the mempool can be walked
+	// page by page while transactions are added and removed. ErrTxNotFound is
+	// returned if the key is unknown.
+	// If max is negative, there is no cap on the number of returned
+	// transactions.
+	ReapTxsAfter(after [TxKeySize]byte, max int) (types.Txs, error)
+
+	// TxByKey returns the transaction with the given key, if it is in the
+	// mempool.
+	TxByKey(key [TxKeySize]byte) (types.Tx, bool)
+
+	// Snapshot returns the metadata of up to limit transactions in the order
+	// they are reaped, taken atomically. If limit is negative, there is no cap
+	// on the number of returned transactions.
+	Snapshot(limit int) []TxSnapshot
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely update.
 	Lock()
 
@@ -36,10 +55,14 @@ type Mempool interface {
 	Unlock()
 
 	// Update informs the mempool that the given txs were committed and can be discarded.
+	// The block time and the proTxHash of the next proposer are sent to the
+	// app with the following CheckTx requests.
 	// NOTE: this should be called *after* block is committed by consensus.
 	// NOTE: Lock/Unlock must be managed by caller
 	Update(
 		blockHeight int64,
+		blockTime time.Time,
+		nextProposerProTxHash []byte,
 		blockTxs types.Txs,
 		deliverTxResponses []*abci.ResponseDeliverTx,
 		newPreFn PreCheckFunc,
@@ -54,6 +77,11 @@ type Mempool interface {
 	// Flush removes all transactions from the mempool and cache
 	Flush()
 
+	// FlushCache removes the transactions, which aren't in the mempool, from
+	// the cache of the seen transactions, so they can be submitted again. The
+	// transactions in the mempool stay.
+	FlushCache()
+
 	// TxsAvailable returns a channel which fires once for every height,
 	// and only when transactions are available in the mempool.
 	// NOTE: the returned channel may be nil if EnableTxsAvailable was not called.
@@ -100,6 +128,21 @@ type TxInfo struct {
 	SenderP2PID p2p.ID
 }
 
+// TxSnapshot is the metadata of a transaction in the mempool.
+type TxSnapshot struct {
+	Key       [TxKeySize]byte
+	Size      int
+	Height    int64     // height at which the transaction was added
+	Time      time.Time // time at which the transaction was added
+	GasWanted int64
+	Priority  int64
+	Sender    string // empty if the app doesn't identify the sender
+	// true if the transaction wasn't rechecked yet after the last commit
+	RecheckPending bool
+	// the transaction itself, it's not copied
+	Tx types.Tx
+}
+
 //--------------------------------------------------------------------------------
 
 // PreCheckMaxBytes checks that the size of the transaction is smaller or equal to the expected maxBytes.
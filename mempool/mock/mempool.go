@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"time"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/clist"
 	mempl "github.com/tendermint/tendermint/mempool"
@@ -20,8 +22,15 @@ func (Mempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo) error
 }
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (Mempool) ReapTxsAfter(_ [mempl.TxKeySize]byte, _ int) (types.Txs, error) {
+	return nil, mempl.ErrTxNotFound
+}
+func (Mempool) TxByKey(_ [mempl.TxKeySize]byte) (types.Tx, bool) { return nil, false }
+func (Mempool) Snapshot(_ int) []mempl.TxSnapshot                { return nil }
 func (Mempool) Update(
 	_ int64,
+	_ time.Time,
+	_ []byte,
 	_ types.Txs,
 	_ []*abci.ResponseDeliverTx,
 	_ mempl.PreCheckFunc,
@@ -30,6 +39,7 @@ func (Mempool) Update(
 	return nil
 }
 func (Mempool) Flush()                        {}
+func (Mempool) FlushCache()                   {}
 func (Mempool) FlushAppConn() error           { return nil }
 func (Mempool) TxsAvailable() <-chan struct{} { return make(chan struct{}) }
 func (Mempool) EnableTxsAvailable()           {}
@@ -30,11 +30,20 @@ const (
 // Reactor handles mempool tx broadcasting amongst peers.
 // It maintains a map from peer ID to counter, to prevent gossiping txs to the
 // peers you received it from.
+//
+// The txs are sent to the peers as is, or announced by their hashes with
+// AnnounceTxs: the peers request the txs they don't have, answer the others,
+// and get the txs after the AnnounceTimeout, if they don't answer. The txs
+// aren't sent to the peers, which announced them.
 type Reactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
 	mempool *CListMempool
 	ids     *mempoolIDs
+
+	mtx      tmsync.RWMutex
+	peerTxs  map[p2p.ID]*peerTxs
+	requests *txRequests
 }
 
 type mempoolIDs struct {
@@ -103,9 +112,11 @@ func newMempoolIDs() *mempoolIDs {
 // NewReactor returns a new Reactor with the given config and mempool.
 func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 	memR := &Reactor{
-		config:  config,
-		mempool: mempool,
-		ids:     newMempoolIDs(),
+		config:   config,
+		mempool:  mempool,
+		ids:      newMempoolIDs(),
+		peerTxs:  make(map[p2p.ID]*peerTxs),
+		requests: newTxRequests(),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
 	return memR
@@ -114,9 +125,20 @@ func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 // InitPeer implements Reactor by creating a state for the peer.
 func (memR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
 	memR.ids.ReserveForPeer(peer)
+	memR.mtx.Lock()
+	memR.peerTxs[peer.ID()] = newPeerTxs()
+	memR.mtx.Unlock()
 	return peer
 }
 
+// getPeerTxs returns the state of the announcements exchanged with the peer,
+// or nil if the peer wasn't initialized.
+func (memR *Reactor) getPeerTxs(peer p2p.Peer) *peerTxs {
+	memR.mtx.RLock()
+	defer memR.mtx.RUnlock()
+	return memR.peerTxs[peer.ID()]
+}
+
 // SetLogger sets the Logger on the reactor and the underlying mempool.
 func (memR *Reactor) SetLogger(l log.Logger) {
 	memR.Logger = l
@@ -155,12 +177,18 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 func (memR *Reactor) AddPeer(peer p2p.Peer) {
 	if memR.config.Broadcast {
 		go memR.broadcastTxRoutine(peer)
+		if memR.config.AnnounceTimeout > 0 {
+			go memR.announceTimeoutRoutine(peer)
+		}
 	}
 }
 
 // RemovePeer implements Reactor.
 func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	memR.ids.Reclaim(peer)
+	memR.mtx.Lock()
+	delete(memR.peerTxs, peer.ID())
+	memR.mtx.Unlock()
 	// broadcast routine checks if peer is gone and returns
 }
 
@@ -175,19 +203,90 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	}
 	memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
 
-	txInfo := TxInfo{SenderID: memR.ids.GetForPeer(src)}
-	if src != nil {
-		txInfo.SenderP2PID = src.ID()
+	ps := memR.getPeerTxs(src)
+	switch msg := msg.(type) {
+	case *TxsMessage:
+		txInfo := TxInfo{SenderID: memR.ids.GetForPeer(src)}
+		if src != nil {
+			txInfo.SenderP2PID = src.ID()
+		}
+		for _, tx := range msg.Txs {
+			if ps != nil {
+				key := TxKey(tx)
+				ps.known.Add(key)
+				ps.Answered(key)
+				memR.requests.Done(key)
+			}
+			err = memR.mempool.CheckTx(tx, nil, txInfo)
+			if err == ErrTxInCache {
+				memR.Logger.Debug("Tx already exists in cache", "tx", txID(tx))
+			} else if err != nil {
+				memR.Logger.Info("Could not check tx", "tx", txID(tx), "err", err)
+			}
+		}
+		// broadcasting happens from go routines per peer
+
+	case *HaveTxsMessage:
+		if ps != nil {
+			memR.receiveHaveTxs(src, ps, msg.Keys)
+		}
+
+	case *WantTxsMessage:
+		if ps != nil {
+			memR.receiveWantTxs(src, ps, msg.Keys)
+		}
+	}
+}
+
+// receiveHaveTxs answers the announcement of the txs by the peer: the txs,
+// which aren't in the mempool or the cache, are requested, unless they're
+// requested from another peer, then the answer is deferred until they're in
+// the mempool. The txs the peer has aren't sent to it. The announcements,
+// which answer ours, aren't answered.
+func (memR *Reactor) receiveHaveTxs(src p2p.Peer, ps *peerTxs, keys [][TxKeySize]byte) {
+	var (
+		have = make([][TxKeySize]byte, 0, len(keys))
+		want = make([][TxKeySize]byte, 0, len(keys))
+		now  = time.Now()
+	)
+	for _, key := range keys {
+		ps.known.Add(key)
+		if ps.Answered(key) {
+			continue
+		}
+		switch {
+		case memR.mempool.seenTx(key):
+			have = append(have, key)
+		case memR.requests.Start(key, now, now.Add(memR.config.AnnounceTimeout)):
+			want = append(want, key)
+		default:
+			ps.Defer(key, now.Add(memR.config.AnnounceTimeout))
+		}
 	}
-	for _, tx := range msg.Txs {
-		err = memR.mempool.CheckTx(tx, nil, txInfo)
-		if err == ErrTxInCache {
-			memR.Logger.Debug("Tx already exists in cache", "tx", txID(tx))
-		} else if err != nil {
-			memR.Logger.Info("Could not check tx", "tx", txID(tx), "err", err)
+	// the peer sends the txs after the timeout, if the answers aren't sent
+	if len(have) > 0 {
+		src.Send(MempoolChannel, mustEncode(&HaveTxsMessage{Keys: have}))
+	}
+	if len(want) > 0 {
+		src.Send(MempoolChannel, mustEncode(&WantTxsMessage{Keys: want}))
+	}
+}
+
+// receiveWantTxs sends the txs the peer requested. The txs, which can't be
+// sent, are sent after the timeout of their announcement.
+func (memR *Reactor) receiveWantTxs(src p2p.Peer, ps *peerTxs, keys [][TxKeySize]byte) {
+	for _, key := range keys {
+		e := memR.mempool.pendingTx(key[:])
+		if e == nil {
+			// the tx was removed from the mempool
+			ps.Answered(key)
+			continue
+		}
+		if src.Send(MempoolChannel, mustEncode(&TxsMessage{Txs: []types.Tx{e.Value.(*mempoolTx).tx}})) {
+			ps.Answered(key)
+			ps.known.Add(key)
 		}
 	}
-	// broadcasting happens from go routines per peer
 }
 
 // PeerState describes the state of a peer.
@@ -198,6 +297,7 @@ type PeerState interface {
 // Send new mempool txs to peer.
 func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	peerID := memR.ids.GetForPeer(peer)
+	ps := memR.getPeerTxs(peer)
 	var next *clist.CElement
 
 	for {
@@ -244,16 +344,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// https://github.com/tendermint/tendermint/issues/5796
 
 		if _, ok := memTx.senders.Load(peerID); !ok {
-			msg := protomem.Message{
-				Sum: &protomem.Message_Txs{
-					Txs: &protomem.Txs{Txs: [][]byte{memTx.tx}},
-				},
-			}
-			bz, err := msg.Marshal()
-			if err != nil {
-				panic(err)
-			}
-			success := peer.Send(MempoolChannel, bz)
+			success := memR.sendTx(peer, ps, memTx.tx)
 			if !success {
 				time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
 				continue
@@ -272,36 +363,159 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// sendTx sends the tx to the peer, unless the peer has it, or announces it
+// with AnnounceTxs, and returns false if it should be retried. The deferred
+// announcement of the tx by the peer is answered.
+func (memR *Reactor) sendTx(peer p2p.Peer, ps *peerTxs, tx types.Tx) bool {
+	if ps == nil {
+		return peer.Send(MempoolChannel, mustEncode(&TxsMessage{Txs: []types.Tx{tx}}))
+	}
+
+	key := TxKey(tx)
+	if ps.known.Has(key) {
+		if ps.TakeDeferred(key) {
+			peer.Send(MempoolChannel, mustEncode(&HaveTxsMessage{Keys: [][TxKeySize]byte{key}}))
+		}
+		return true
+	}
+	if memR.config.AnnounceTxs && memR.config.AnnounceTimeout > 0 &&
+		ps.Announce(key, time.Now().Add(memR.config.AnnounceTimeout)) {
+		if !peer.Send(MempoolChannel, mustEncode(&HaveTxsMessage{Keys: [][TxKeySize]byte{key}})) {
+			ps.Answered(key)
+			return false
+		}
+		return true
+	}
+	if !peer.Send(MempoolChannel, mustEncode(&TxsMessage{Txs: []types.Tx{tx}})) {
+		return false
+	}
+	ps.known.Add(key)
+	return true
+}
+
+// announceTimeoutRoutine sends the txs announced to the peer, which the peer
+// didn't answer within the AnnounceTimeout.
+func (memR *Reactor) announceTimeoutRoutine(peer p2p.Peer) {
+	ps := memR.getPeerTxs(peer)
+	if ps == nil {
+		return
+	}
+	ticker := time.NewTicker(memR.config.AnnounceTimeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, key := range ps.Expired(now) {
+				if ps.known.Has(key) {
+					continue
+				}
+				e := memR.mempool.pendingTx(key[:])
+				if e == nil {
+					continue
+				}
+				tx := e.Value.(*mempoolTx).tx
+				if peer.Send(MempoolChannel, mustEncode(&TxsMessage{Txs: []types.Tx{tx}})) {
+					ps.known.Add(key)
+				} else {
+					ps.Announce(key, now.Add(memR.config.AnnounceTimeout))
+				}
+			}
+		case <-peer.Quit():
+			return
+		case <-memR.Quit():
+			return
+		}
+	}
+}
+
 //-----------------------------------------------------------------------------
 // Messages
 
-func (memR *Reactor) decodeMsg(bz []byte) (TxsMessage, error) {
+// Message is a message sent or received by the Reactor.
+type Message interface {
+	ValidateBasic() error
+}
+
+func (memR *Reactor) decodeMsg(bz []byte) (Message, error) {
 	msg := protomem.Message{}
 	err := msg.Unmarshal(bz)
 	if err != nil {
-		return TxsMessage{}, err
+		return nil, err
 	}
 
-	var message TxsMessage
-
-	if i, ok := msg.Sum.(*protomem.Message_Txs); ok {
+	var message Message
+	switch i := msg.Sum.(type) {
+	case *protomem.Message_Txs:
 		txs := i.Txs.GetTxs()
-
-		if len(txs) == 0 {
-			return message, errors.New("empty TxsMessage")
-		}
-
 		decoded := make([]types.Tx, len(txs))
 		for j, tx := range txs {
 			decoded[j] = types.Tx(tx)
 		}
+		message = &TxsMessage{Txs: decoded}
+
+	case *protomem.Message_HaveTxs:
+		keys, err := keysFromHashes(i.HaveTxs.GetHashes())
+		if err != nil {
+			return nil, err
+		}
+		message = &HaveTxsMessage{Keys: keys}
+
+	case *protomem.Message_WantTxs:
+		keys, err := keysFromHashes(i.WantTxs.GetHashes())
+		if err != nil {
+			return nil, err
+		}
+		message = &WantTxsMessage{Keys: keys}
+
+	default:
+		return nil, fmt.Errorf("msg type: %T is not supported", msg)
+	}
+	return message, message.ValidateBasic()
+}
+
+func keysFromHashes(hashes [][]byte) ([][TxKeySize]byte, error) {
+	keys := make([][TxKeySize]byte, len(hashes))
+	for i, hash := range hashes {
+		if len(hash) != TxKeySize {
+			return nil, fmt.Errorf("wrong tx hash #%d: expected size to be %d bytes, got %d bytes",
+				i, TxKeySize, len(hash))
+		}
+		copy(keys[i][:], hash)
+	}
+	return keys, nil
+}
+
+func hashesFromKeys(keys [][TxKeySize]byte) [][]byte {
+	hashes := make([][]byte, len(keys))
+	for i := range keys {
+		hashes[i] = keys[i][:]
+	}
+	return hashes
+}
 
-		message = TxsMessage{
-			Txs: decoded,
+// mustEncode encodes the message to send it to a peer.
+func mustEncode(msg Message) []byte {
+	var pb protomem.Message
+	switch msg := msg.(type) {
+	case *TxsMessage:
+		txs := make([][]byte, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			txs[i] = tx
 		}
-		return message, nil
+		pb.Sum = &protomem.Message_Txs{Txs: &protomem.Txs{Txs: txs}}
+	case *HaveTxsMessage:
+		pb.Sum = &protomem.Message_HaveTxs{HaveTxs: &protomem.HaveTxs{Hashes: hashesFromKeys(msg.Keys)}}
+	case *WantTxsMessage:
+		pb.Sum = &protomem.Message_WantTxs{WantTxs: &protomem.WantTxs{Hashes: hashesFromKeys(msg.Keys)}}
+	default:
+		panic(fmt.Sprintf("unknown message type %T", msg))
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		panic(err)
 	}
-	return message, fmt.Errorf("msg type: %T is not supported", msg)
+	return bz
 }
 
 //-------------------------------------
@@ -311,7 +525,52 @@ type TxsMessage struct {
 	Txs []types.Tx
 }
 
+// ValidateBasic implements Message.
+func (m *TxsMessage) ValidateBasic() error {
+	if len(m.Txs) == 0 {
+		return errors.New("empty TxsMessage")
+	}
+	return nil
+}
+
 // String returns a string representation of the TxsMessage.
 func (m *TxsMessage) String() string {
 	return fmt.Sprintf("[TxsMessage %v]", m.Txs)
 }
+
+// HaveTxsMessage announces the txs the peer has by their keys, or answers the
+// announcement of the txs.
+type HaveTxsMessage struct {
+	Keys [][TxKeySize]byte
+}
+
+// ValidateBasic implements Message.
+func (m *HaveTxsMessage) ValidateBasic() error {
+	if len(m.Keys) == 0 {
+		return errors.New("empty HaveTxsMessage")
+	}
+	return nil
+}
+
+// String returns a string representation of the HaveTxsMessage.
+func (m *HaveTxsMessage) String() string {
+	return fmt.Sprintf("[HaveTxsMessage %d txs]", len(m.Keys))
+}
+
+// WantTxsMessage requests the announced txs by their keys.
+type WantTxsMessage struct {
+	Keys [][TxKeySize]byte
+}
+
+// ValidateBasic implements Message.
+func (m *WantTxsMessage) ValidateBasic() error {
+	if len(m.Keys) == 0 {
+		return errors.New("empty WantTxsMessage")
+	}
+	return nil
+}
+
+// String returns a string representation of the WantTxsMessage.
+func (m *WantTxsMessage) String() string {
+	return fmt.Sprintf("[WantTxsMessage %d txs]", len(m.Keys))
+}
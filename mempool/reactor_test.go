@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"github.com/tendermint/tendermint/crypto"
@@ -66,6 +67,145 @@ func TestReactorBroadcastTxsMessage(t *testing.T) {
 	waitForTxsOnReactors(t, txs, reactors)
 }
 
+// Send a bunch of txs to the first reactor's mempool, which announces them, and
+// wait for them all to be requested by the others.
+func TestReactorBroadcastTxsMessageAnnounced(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.AnnounceTxs = true
+	// the txs are received in order only if they're requested, not sent after
+	// the timeout
+	config.Mempool.AnnounceTimeout = timeout
+	const N = 2
+	reactors := makeAndConnectReactors(config, N)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+
+	txs := checkTxs(t, reactors[0].mempool, numTxs, UnknownPeerID)
+	waitForTxsOnReactors(t, txs, reactors)
+}
+
+// recordingPeer is a mock peer, which records the messages sent to it.
+type recordingPeer struct {
+	*mock.Peer
+
+	mtx  sync.Mutex
+	msgs []Message
+}
+
+func newRecordingPeer() *recordingPeer {
+	return &recordingPeer{Peer: mock.NewPeer(nil)}
+}
+
+func (p *recordingPeer) Send(chID byte, msgBytes []byte) bool {
+	msg, err := (&Reactor{}).decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.msgs = append(p.msgs, msg)
+	return true
+}
+
+func (p *recordingPeer) TrySend(chID byte, msgBytes []byte) bool {
+	return p.Send(chID, msgBytes)
+}
+
+// Take returns and forgets the messages sent to the peer.
+func (p *recordingPeer) Take() []Message {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	msgs := p.msgs
+	p.msgs = nil
+	return msgs
+}
+
+func newAnnouncingReactor(t *testing.T, announceTimeout time.Duration) *Reactor {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.AnnounceTxs = true
+	config.Mempool.AnnounceTimeout = announceTimeout
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(kvstore.NewApplication()), config)
+	t.Cleanup(cleanup)
+
+	memR := NewReactor(config.Mempool, mempool)
+	memR.SetLogger(log.TestingLogger())
+	return memR
+}
+
+func TestReactorAnnouncements(t *testing.T) {
+	memR := newAnnouncingReactor(t, time.Minute)
+	peers := make([]*recordingPeer, 4)
+	for i := range peers {
+		peers[i] = newRecordingPeer()
+		memR.InitPeer(peers[i])
+	}
+
+	tx := types.Tx("announced=tx")
+	keys := [][TxKeySize]byte{TxKey(tx)}
+	have := mustEncode(&HaveTxsMessage{Keys: keys})
+
+	// the tx is requested from the first peer, which announces it, the answer
+	// to the next one is deferred
+	memR.Receive(MempoolChannel, peers[0], have)
+	assert.Equal(t, []Message{&WantTxsMessage{Keys: keys}}, peers[0].Take())
+	memR.Receive(MempoolChannel, peers[1], have)
+	assert.Empty(t, peers[1].Take())
+
+	// once the tx is in the mempool, the deferred announcement is answered,
+	// and the tx isn't sent to the peer, which has it
+	memR.Receive(MempoolChannel, peers[0], mustEncode(&TxsMessage{Txs: []types.Tx{tx}}))
+	require.Equal(t, 1, memR.mempool.Size())
+	require.True(t, memR.sendTx(peers[1], memR.getPeerTxs(peers[1]), tx))
+	assert.Equal(t, []Message{&HaveTxsMessage{Keys: keys}}, peers[1].Take())
+	require.True(t, memR.sendTx(peers[1], memR.getPeerTxs(peers[1]), tx))
+	assert.Empty(t, peers[1].Take())
+
+	// the tx is announced to the peer, which doesn't have it, and sent once
+	// it's requested
+	require.True(t, memR.sendTx(peers[2], memR.getPeerTxs(peers[2]), tx))
+	assert.Equal(t, []Message{&HaveTxsMessage{Keys: keys}}, peers[2].Take())
+	memR.Receive(MempoolChannel, peers[2], mustEncode(&WantTxsMessage{Keys: keys}))
+	assert.Equal(t, []Message{&TxsMessage{Txs: []types.Tx{tx}}}, peers[2].Take())
+
+	// the announcement of a tx in the mempool is answered, and the tx isn't
+	// sent to the peer
+	memR.Receive(MempoolChannel, peers[3], have)
+	assert.Equal(t, []Message{&HaveTxsMessage{Keys: keys}}, peers[3].Take())
+	require.True(t, memR.sendTx(peers[3], memR.getPeerTxs(peers[3]), tx))
+	assert.Empty(t, peers[3].Take())
+}
+
+func TestReactorAnnounceTimeout(t *testing.T) {
+	memR := newAnnouncingReactor(t, 10*time.Millisecond)
+	peer := newRecordingPeer()
+	memR.InitPeer(peer)
+	defer peer.Stop() //nolint:errcheck // ignore error
+
+	tx := types.Tx("announced=tx")
+	require.NoError(t, memR.mempool.CheckTx(tx, nil, TxInfo{SenderID: UnknownPeerID}))
+	require.True(t, memR.sendTx(peer, memR.getPeerTxs(peer), tx))
+	assert.Equal(t, []Message{&HaveTxsMessage{Keys: [][TxKeySize]byte{TxKey(tx)}}}, peer.Take())
+
+	// the peer doesn't answer, so the tx is sent after the timeout
+	go memR.announceTimeoutRoutine(peer)
+	var msgs []Message
+	require.Eventually(t, func() bool {
+		msgs = append(msgs, peer.Take()...)
+		return len(msgs) > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []Message{&TxsMessage{Txs: []types.Tx{tx}}}, msgs)
+}
+
 // regression test for https://github.com/tendermint/tendermint/issues/5408
 func TestReactorConcurrency(t *testing.T) {
 	config := cfg.TestConfig()
@@ -103,7 +243,7 @@ func TestReactorConcurrency(t *testing.T) {
 			for i := range txs {
 				deliverTxResponses[i] = &abci.ResponseDeliverTx{Code: 0}
 			}
-			err := reactors[0].mempool.Update(1, txs, deliverTxResponses, nil, nil)
+			err := reactors[0].mempool.Update(1, time.Time{}, nil, txs, deliverTxResponses, nil, nil)
 			assert.NoError(t, err)
 		}()
 
@@ -115,7 +255,7 @@ func TestReactorConcurrency(t *testing.T) {
 
 			reactors[1].mempool.Lock()
 			defer reactors[1].mempool.Unlock()
-			err := reactors[1].mempool.Update(1, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil)
+			err := reactors[1].mempool.Update(1, time.Time{}, nil, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil)
 			assert.NoError(t, err)
 		}()
 
@@ -388,3 +528,52 @@ func TestMempoolVectors(t *testing.T) {
 		require.Equal(t, tc.expBytes, hex.EncodeToString(bz), tc.testName)
 	}
 }
+
+func TestMempoolAnnouncementVectors(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x01}, TxKeySize)
+	testCases := []struct {
+		testName string
+		msg      memproto.Message
+		expBytes string
+	}{
+		{"have txs", memproto.Message{Sum: &memproto.Message_HaveTxs{HaveTxs: &memproto.HaveTxs{Hashes: [][]byte{hash}}}},
+			"12220a200101010101010101010101010101010101010101010101010101010101010101"},
+		{"want txs", memproto.Message{Sum: &memproto.Message_WantTxs{WantTxs: &memproto.WantTxs{Hashes: [][]byte{hash}}}},
+			"1a220a200101010101010101010101010101010101010101010101010101010101010101"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		bz, err := tc.msg.Marshal()
+		require.NoError(t, err, tc.testName)
+
+		require.Equal(t, tc.expBytes, hex.EncodeToString(bz), tc.testName)
+	}
+}
+
+func TestReactorDecodeMsg(t *testing.T) {
+	memR := &Reactor{}
+	key := TxKey(types.Tx("tx"))
+
+	msg, err := memR.decodeMsg(mustEncode(&HaveTxsMessage{Keys: [][TxKeySize]byte{key}}))
+	require.NoError(t, err)
+	assert.Equal(t, &HaveTxsMessage{Keys: [][TxKeySize]byte{key}}, msg)
+
+	msg, err = memR.decodeMsg(mustEncode(&WantTxsMessage{Keys: [][TxKeySize]byte{key}}))
+	require.NoError(t, err)
+	assert.Equal(t, &WantTxsMessage{Keys: [][TxKeySize]byte{key}}, msg)
+
+	for name, pb := range map[string]memproto.Message{
+		"no message": {},
+		"no txs":     {Sum: &memproto.Message_Txs{Txs: &memproto.Txs{}}},
+		"no hashes":  {Sum: &memproto.Message_HaveTxs{HaveTxs: &memproto.HaveTxs{}}},
+		"short hash": {Sum: &memproto.Message_WantTxs{WantTxs: &memproto.WantTxs{Hashes: [][]byte{key[1:]}}}},
+		"empty hash": {Sum: &memproto.Message_HaveTxs{HaveTxs: &memproto.HaveTxs{Hashes: [][]byte{{}}}}},
+	} {
+		bz, err := pb.Marshal()
+		require.NoError(t, err, name)
+		_, err = memR.decodeMsg(bz)
+		assert.Error(t, err, name)
+	}
+}
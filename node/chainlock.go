@@ -0,0 +1,106 @@
+package node
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// chainLockPollInterval is how often Dash Core is asked for its best chain lock.
+const chainLockPollInterval = time.Second
+
+// chainLockWatcher polls the best chain lock of Dash Core in the background,
+// records the highest chain locked core height and publishes an
+// EventChainLockUpdate each time it advances. It's observational, consensus
+// doesn't depend on it.
+type chainLockWatcher struct {
+	service.BaseService
+
+	provider types.ChainLockProvider
+	eventBus *types.EventBus
+	interval time.Duration
+
+	mtx    tmsync.RWMutex
+	height uint32    // the highest chain locked core height, 0 if none was seen
+	time   time.Time // when the chain lock of height was seen
+}
+
+func newChainLockWatcher(
+	provider types.ChainLockProvider,
+	eventBus *types.EventBus,
+	interval time.Duration,
+) *chainLockWatcher {
+	w := &chainLockWatcher{
+		provider: provider,
+		eventBus: eventBus,
+		interval: interval,
+	}
+	w.BaseService = *service.NewBaseService(nil, "ChainLockWatcher", w)
+	return w
+}
+
+// OnStart implements service.Service by starting the polling routine.
+func (w *chainLockWatcher) OnStart() error {
+	go w.pollRoutine()
+	return nil
+}
+
+// ChainLockedHeight returns the highest chain locked core height seen so far,
+// and when it was seen. The height is 0 if no chain lock was seen yet.
+func (w *chainLockWatcher) ChainLockedHeight() (uint32, time.Time) {
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+	return w.height, w.time
+}
+
+func (w *chainLockWatcher) pollRoutine() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.poll()
+		select {
+		case <-ticker.C:
+		case <-w.Quit():
+			return
+		}
+	}
+}
+
+// poll requests the best chain lock and publishes it, if it's above the
+// recorded one. Dash Core may report a lower chain lock after a reorg or a
+// restart, the recorded height never goes down.
+func (w *chainLockWatcher) poll() {
+	chainLock, err := w.provider.GetBestChainLock()
+	if errors.Is(err, privval.ErrNoChainLock) {
+		return
+	}
+	if err != nil {
+		w.Logger.Error("Failed to get the best chain lock", "err", err)
+		return
+	}
+
+	w.mtx.Lock()
+	if chainLock.CoreBlockHeight <= w.height {
+		w.mtx.Unlock()
+		return
+	}
+	w.height = chainLock.CoreBlockHeight
+	w.time = tmtime.Now()
+	data := types.EventDataChainLockUpdate{
+		CoreBlockHeight: w.height,
+		CoreBlockHash:   chainLock.CoreBlockHash,
+		Time:            w.time,
+	}
+	w.mtx.Unlock()
+
+	w.Logger.Debug("Chain lock advanced", "core_height", data.CoreBlockHeight,
+		"core_block_hash", data.CoreBlockHash)
+	if err := w.eventBus.PublishEventChainLockUpdate(data); err != nil {
+		w.Logger.Error("Failed to publish the chain lock update", "err", err)
+	}
+}
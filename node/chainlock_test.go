@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+// chainLockProvider returns the chain lock of the height set last, or the
+// error set last.
+type chainLockProvider struct {
+	mtx    tmsync.Mutex
+	height uint32
+	err    error
+}
+
+func (p *chainLockProvider) set(height uint32, err error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.height, p.err = height, err
+}
+
+func (p *chainLockProvider) GetBestChainLock() (*types.CoreChainLock, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	chainLock := types.NewMockChainLock(p.height)
+	return &chainLock, nil
+}
+
+func TestChainLockWatcher(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+	sub, err := eventBus.Subscribe(context.Background(), "chainlock_test", types.EventQueryChainLockUpdate)
+	require.NoError(t, err)
+
+	provider := &chainLockProvider{err: privval.ErrNoChainLock}
+	w := newChainLockWatcher(provider, eventBus, 10*time.Millisecond)
+	w.SetLogger(log.TestingLogger())
+	require.NoError(t, w.Start())
+	t.Cleanup(func() { _ = w.Stop() })
+
+	nextUpdate := func() types.EventDataChainLockUpdate {
+		select {
+		case msg := <-sub.Out():
+			return msg.Data().(types.EventDataChainLockUpdate)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a chain lock update")
+		}
+		return types.EventDataChainLockUpdate{}
+	}
+
+	// no chain lock is known yet
+	time.Sleep(50 * time.Millisecond)
+	height, _ := w.ChainLockedHeight()
+	assert.EqualValues(t, 0, height)
+
+	provider.set(1000, nil)
+	update := nextUpdate()
+	assert.EqualValues(t, 1000, update.CoreBlockHeight)
+	assert.EqualValues(t, types.NewMockChainLock(1000).CoreBlockHash, update.CoreBlockHash)
+	height, seen := w.ChainLockedHeight()
+	assert.EqualValues(t, 1000, height)
+	assert.Equal(t, update.Time, seen)
+
+	// the failures and the lower chain locks don't move the height back
+	provider.set(0, errors.New("dash core is down"))
+	time.Sleep(50 * time.Millisecond)
+	provider.set(990, nil)
+	time.Sleep(50 * time.Millisecond)
+	height, _ = w.ChainLockedHeight()
+	assert.EqualValues(t, 1000, height)
+
+	provider.set(1002, nil)
+	update = nextUpdate()
+	assert.EqualValues(t, 1002, update.CoreBlockHeight)
+	height, _ = w.ChainLockedHeight()
+	assert.EqualValues(t, 1002, height)
+
+	select {
+	case msg := <-sub.Out():
+		t.Fatalf("unexpected chain lock update %v", msg.Data())
+	default:
+	}
+}
@@ -0,0 +1,117 @@
+package node
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// the app state of the genesis doc is stored apart from the genesis doc, in
+// chunks of genesisAppStateChunkSize bytes, so that it's never held in memory
+// at once
+const genesisAppStateChunkSize = 1 << 20 // 1MB
+
+var (
+	genesisAppStateSizeKey = []byte("genesisAppStateSize")
+)
+
+func genesisAppStateChunkKey(index int64) []byte {
+	return []byte(fmt.Sprintf("genesisAppState:%v", index))
+}
+
+// saveGenesisAppState stores the app state of the genesis doc in chunks.
+func saveGenesisAppState(db dbm.DB, genDoc *types.GenesisDoc) error {
+	r, err := genDoc.AppStateReader()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var size int64
+	chunk := make([]byte, genesisAppStateChunkSize)
+	for index := int64(0); ; index++ {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			if err := db.Set(genesisAppStateChunkKey(index), chunk[:n]); err != nil {
+				return err
+			}
+			size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(size))
+	return db.Set(genesisAppStateSizeKey, b)
+}
+
+// loadGenesisAppStateSize returns the size of the app state stored with
+// saveGenesisAppState.
+func loadGenesisAppStateSize(db dbm.DB) (int64, error) {
+	b, err := db.Get(genesisAppStateSizeKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid genesis app state size %X", b)
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+// dbGenesisAppState is the app state of the genesis doc stored in the DB, which
+// is read chunk by chunk.
+type dbGenesisAppState struct {
+	db   dbm.DB
+	size int64
+}
+
+var _ types.GenesisAppStateSource = dbGenesisAppState{}
+
+func (s dbGenesisAppState) Size() int64 {
+	return s.size
+}
+
+func (s dbGenesisAppState) Open() (io.ReadCloser, error) {
+	return ioutil.NopCloser(&dbGenesisAppStateReader{db: s.db, remaining: s.size}), nil
+}
+
+type dbGenesisAppStateReader struct {
+	db        dbm.DB
+	next      int64
+	chunk     []byte
+	remaining int64
+}
+
+func (r *dbGenesisAppStateReader) Read(p []byte) (int, error) {
+	if len(r.chunk) == 0 {
+		if r.remaining == 0 {
+			return 0, io.EOF
+		}
+		chunk, err := r.db.Get(genesisAppStateChunkKey(r.next))
+		if err != nil {
+			return 0, err
+		}
+		if len(chunk) == 0 || int64(len(chunk)) > r.remaining {
+			return 0, errors.New("genesis app state is corrupted")
+		}
+		r.chunk = chunk
+		r.remaining -= int64(len(chunk))
+		r.next++
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
@@ -9,6 +9,8 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -16,7 +18,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	dbm "github.com/tendermint/tm-db"
+	"go.opentelemetry.io/otel/trace"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
 	bcv1 "github.com/tendermint/tendermint/blockchain/v1"
@@ -25,10 +29,13 @@ import (
 	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/evidence"
+	tmdb "github.com/tendermint/tendermint/libs/db"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tracing"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
@@ -41,6 +48,7 @@ import (
 	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
+	"github.com/tendermint/tendermint/state/indexer/sink/psql"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/state/txindex/null"
@@ -62,11 +70,23 @@ type DBContext struct {
 // DBProvider takes a DBContext and returns an instantiated DB.
 type DBProvider func(*DBContext) (dbm.DB, error)
 
-// DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the ctx.Config.
+// DefaultDBProvider returns a database using the DBBackend (or the backend
+// of the store in the storage config) and DBDir specified in the ctx.Config.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
-	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	dbType := dbm.BackendType(ctx.Config.DBBackendFor(ctx.ID))
+	return tmdb.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+}
+
+// trackDBs records the databases opened by the dbProvider in dbs, which the
+// node closes once it's stopped.
+func trackDBs(dbProvider DBProvider, dbs *[]dbm.DB) DBProvider {
+	return func(ctx *DBContext) (dbm.DB, error) {
+		db, err := dbProvider(ctx)
+		if err == nil {
+			*dbs = append(*dbs, db)
+		}
+		return db, err
+	}
 }
 
 // GenesisDocProvider returns a GenesisDoc.
@@ -75,10 +95,11 @@ func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 type GenesisDocProvider func() (*types.GenesisDoc, error)
 
 // DefaultGenesisDocProviderFunc returns a GenesisDocProvider that loads
-// the GenesisDoc from the config.GenesisFile() on the filesystem.
+// the GenesisDoc from the config.GenesisFile() on the filesystem. The app state
+// is read from the file only when it's needed.
 func DefaultGenesisDocProviderFunc(config *cfg.Config) GenesisDocProvider {
 	return func() (*types.GenesisDoc, error) {
-		return types.GenesisDocFromFile(config.GenesisFile())
+		return types.GenesisDocFromFileStreaming(config.GenesisFile())
 	}
 }
 
@@ -105,20 +126,27 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state, privval, blockchain
+// and ABCI client Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics,
+	*privval.Metrics, *bcv0.Metrics, *abcicli.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics,
+		*privval.Metrics, *bcv0.Metrics, *abcicli.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				privval.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				bcv0.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				abcicli.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), privval.NopMetrics(),
+			bcv0.NopMetrics(), abcicli.NopMetrics()
 	}
 }
 
@@ -217,10 +245,23 @@ type Node struct {
 	evidencePool      *evidence.Pool          // tracking evidence
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
+	rpcLimitListeners []*rpcserver.LimitListener
+	rpcWriteTimeout   time.Duration
+	rpcEnv            *rpccore.Environment
+	genesisChunks     *rpccore.GenesisChunks // the genesis doc served by /genesis_chunked, once the RPC is configured
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	pruner            *sm.Pruner        // prunes the state store, if the storage retention is configured
+	chainLockWatcher  *chainLockWatcher // watches the chain locks of Dash Core, if the node signs with it
 	prometheusSrv     *http.Server
+	tracerProvider    *tracing.Provider // exports the spans of the block lifecycle
+	dbs               []dbm.DB          // the databases opened by the node, closed once it's stopped
+
+	// reloads the config, see ReloadConfig
+	reloadMtx      tmsync.Mutex
+	configLoader   func() (*cfg.Config, error)
+	loggerProvider func(*cfg.Config) (log.Logger, error)
 }
 
 func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
@@ -239,8 +280,9 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, metrics *abcicli.Metrics,
+	logger log.Logger) (proxy.AppConns, error) {
+	proxyApp := proxy.NewAppConns(clientCreator, metrics)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -257,20 +299,23 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
-func createAndStartIndexerService(
+// CreateIndexers returns the tx and block indexers, which serve the search
+// RPCs, and the write-only event sinks, enabled by tx_index.indexer.
+func CreateIndexers(
 	config *cfg.Config,
 	dbProvider DBProvider,
-	eventBus *types.EventBus,
-	logger log.Logger,
-) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
+	chainID string,
+) (txindex.TxIndexer, indexer.BlockIndexer, []indexer.EventSink, error) {
 
 	var (
 		txIndexer    txindex.TxIndexer
 		blockIndexer indexer.BlockIndexer
+		eventSinks   []indexer.EventSink
 	)
 
-	switch config.TxIndex.Indexer {
-	case "kv":
+	// The search RPCs are served by the kv indexer, the other ones are
+	// write-only sinks.
+	if config.TxIndex.IsEnabled(string(indexer.KV)) {
 		store, err := dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
 			return nil, nil, nil, err
@@ -278,12 +323,36 @@ func createAndStartIndexerService(
 
 		txIndexer = kv.NewTxIndex(store)
 		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
-	default:
+	} else {
 		txIndexer = &null.TxIndex{}
 		blockIndexer = &blockidxnull.BlockerIndexer{}
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
+	if config.TxIndex.IsEnabled(string(indexer.PSQL)) {
+		sink, err := psql.NewEventSink(config.TxIndex.PsqlConn, chainID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("creating psql indexer: %w", err)
+		}
+		eventSinks = append(eventSinks, sink)
+	}
+
+	return txIndexer, blockIndexer, eventSinks, nil
+}
+
+func createAndStartIndexerService(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	eventBus *types.EventBus,
+	chainID string,
+	logger log.Logger,
+) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
+
+	txIndexer, blockIndexer, eventSinks, err := CreateIndexers(config, dbProvider, chainID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, eventSinks...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	if err := indexerService.Start(); err != nil {
@@ -332,6 +401,7 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 		config.Mempool,
 		proxyApp.Mempool(),
 		state.LastBlockHeight,
+		mempl.WithLastBlock(state.LastBlockTime, state.NextProposerProTxHash()),
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
@@ -369,11 +439,13 @@ func createBlockchainReactor(config *cfg.Config,
 	blockStore *store.BlockStore,
 	nodeProTxHash *crypto.ProTxHash,
 	fastSync bool,
+	bcMetrics *bcv0.Metrics,
 	logger log.Logger) (bcReactor p2p.Reactor, err error) {
 
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, nodeProTxHash, fastSync)
+		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, nodeProTxHash, fastSync,
+			bcv0.ReactorMetrics(bcMetrics), bcv0.ReactorMaxInFlightBytes(config.FastSync.MaxInFlightBytes))
 	case "v1":
 		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, nodeProTxHash, fastSync)
 	case "v2":
@@ -394,6 +466,7 @@ func createConsensusReactor(config *cfg.Config,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
+	tracer trace.Tracer,
 	waitSync bool,
 	eventBus *types.EventBus,
 	consensusLogger log.Logger) (*cs.Reactor, *cs.State) {
@@ -407,11 +480,27 @@ func createConsensusReactor(config *cfg.Config,
 		evidencePool,
 		consensusLogger,
 		cs.StateMetrics(csMetrics),
+		cs.StateTracer(tracer),
 	)
 	if privValidator != nil {
 		consensusState.SetPrivValidator(privValidator)
 	}
-	consensusReactor := cs.NewReactor(consensusState, waitSync, cs.ReactorMetrics(csMetrics))
+	reactorOptions := []cs.ReactorOption{
+		cs.ReactorMetrics(csMetrics),
+		cs.ReactorPeerLimits(cs.PeerLimits{
+			NewRoundStepRate:     config.Consensus.PeerNewRoundStepRate,
+			HasVoteRate:          config.Consensus.PeerHasVoteRate,
+			VoteRate:             config.Consensus.PeerVoteRate,
+			OtherRate:            config.Consensus.PeerMessageRate,
+			MisbehaviorThreshold: config.Consensus.PeerMisbehaviorThreshold,
+			BanMisbehaving:       config.Consensus.PeerMisbehaviorBan,
+		}),
+	}
+	if config.Consensus.CompactBlocks {
+		reactorOptions = append(reactorOptions,
+			cs.ReactorCompactBlocks(mempool, config.Consensus.CompactBlockTimeout))
+	}
+	consensusReactor := cs.NewReactor(consensusState, waitSync, reactorOptions...)
 	consensusReactor.SetLogger(consensusLogger)
 	// services which will be publishing and/or subscribing for messages (events)
 	// consensusReactor will set it on consensusState and blockExecutor
@@ -419,11 +508,23 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// createTracerProvider returns the provider of the tracers of the block
+// lifecycle, exporting the spans if configured, or a no-op one.
+func createTracerProvider(config *cfg.Config, logger log.Logger) (*tracing.Provider, error) {
+	if !config.Instrumentation.TracingEnabled() {
+		return tracing.NopProvider(), nil
+	}
+	logger.Info("Exporting the spans of the block lifecycle", "endpoint", config.Instrumentation.OtelEndpoint)
+	return tracing.NewProvider(context.Background(), config.Instrumentation.OtelEndpoint, config.Moniker)
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	identityVerifier p2p.MasternodeIdentityVerifier,
+	banList *p2p.BanList,
 ) (
 	*p2p.MultiplexTransport,
 	[]p2p.PeerFilterFunc,
@@ -481,6 +582,14 @@ func createTransport(
 
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 
+	// Verify the proof of the masternode ownership of the peers.
+	if identityVerifier != nil {
+		p2p.MultiplexTransportMasternodeIdentityVerifier(identityVerifier)(transport)
+	}
+
+	// Refuse the banned peers.
+	p2p.MultiplexTransportBanList(banList)(transport)
+
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
@@ -499,6 +608,7 @@ func createSwitch(config *cfg.Config,
 	evidenceReactor *evidence.Reactor,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
+	banList *p2p.BanList,
 	p2pLogger log.Logger) *p2p.Switch {
 
 	sw := p2p.NewSwitch(
@@ -506,6 +616,7 @@ func createSwitch(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchBanList(banList),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -549,7 +660,7 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 }
 
 func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
-	sw *p2p.Switch, logger log.Logger) *pex.Reactor {
+	sw *p2p.Switch, masternodes pex.MasternodeList, logger log.Logger) *pex.Reactor {
 
 	// TODO persistent peers ? so we can have their DNS addrs saved
 	pexReactor := pex.NewReactor(addrBook,
@@ -563,6 +674,8 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 			// https://github.com/tendermint/tendermint/issues/3523
 			SeedDisconnectWaitPeriod:     28 * time.Hour,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			OnlyMasternodePeers:          config.P2P.OnlyMasternodePeers,
+			Masternodes:                  masternodes,
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
@@ -588,8 +701,9 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 		}
 	}
 
+	discoveryTime := config.DiscoveryTime // the config may be reloaded while syncing
 	go func() {
-		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
+		state, commit, err := ssR.Sync(stateProvider, discoveryTime)
 		if err != nil {
 			ssR.Logger.Error("State sync failed", "err", err)
 			return
@@ -632,6 +746,9 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option) (*Node, error) {
 
+	var dbs []dbm.DB
+	dbProvider = trackDBs(dbProvider, &dbs)
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
@@ -644,8 +761,15 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, pvMetrics, bcMetrics, abciMetrics := metricsProvider(genDoc.ChainID)
+
+	tracerProvider, err := createTracerProvider(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create tracer provider: %w", err)
+	}
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, abciMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -659,23 +783,49 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
+	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, genDoc.ChainID, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	if filePV, ok := privValidator.(*privval.FilePV); ok {
+		filePV.SetMetrics(pvMetrics)
+	}
+
 	var weAreOnlyValidator bool
 	var proTxHash crypto.ProTxHash
 	if config.PrivValidatorCoreRPCHost != "" {
 		logger.Info("Initializing Dash Core Signing", "quorum hash", state.Validators.QuorumHash.String())
 		username := config.BaseConfig.PrivValidatorCoreRPCUsername
 		password := config.BaseConfig.PrivValidatorCoreRPCPassword
-		llmqType := config.Consensus.QuorumType
+		// the quorum type of the validator set takes precedence over the config,
+		// the sign requests carry the quorum type of the validator set anyway
+		llmqType := state.Validators.QuorumType
+		if llmqType == 0 {
+			llmqType = config.Consensus.QuorumType
+		}
 		if llmqType == 0 {
 			llmqType = btcjson.LLMQType_100_67
 		}
+		var fallbackPV *privval.FilePV
+		if config.PrivValidatorCoreFallback {
+			var ok bool
+			if fallbackPV, ok = privValidator.(*privval.FilePV); !ok {
+				return nil, errors.New("priv_validator_core_fallback requires the private validator key file")
+			}
+			logger.Info("Signing with the private validator key file when Dash Core is unavailable")
+		}
 		// If a local port is provided for Dash Core rpc into the service to sign.
-		privValidator, err = createAndStartPrivValidatorRPCClient(config.PrivValidatorCoreRPCHost, config.Consensus.QuorumType, username, password, logger)
+		privValidator, err = createAndStartPrivValidatorRPCClient(
+			config.PrivValidatorCoreRPCHost,
+			llmqType,
+			username,
+			password,
+			config.Consensus.TimeoutPropose,
+			pvMetrics,
+			fallbackPV,
+			logger,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("error with private validator socket client: %w", err)
 		}
@@ -688,7 +838,8 @@ func NewNode(config *cfg.Config,
 		// If an address is provided, listen on the socket for a connection from an
 		// external signing process.
 		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, genDoc.QuorumHash, logger)
+		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr,
+			config.PrivValidatorSocketFileMode(), genDoc.ChainID, genDoc.QuorumHash, pvMetrics, logger)
 		if err != nil {
 			return nil, fmt.Errorf("error with private validator socket client: %w", err)
 		}
@@ -735,8 +886,6 @@ func NewNode(config *cfg.Config,
 
 	logNodeStartupInfo(state, proTxHash, logger, consensusLogger)
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
-
 	// Make Mempool Reactor
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
 
@@ -751,6 +900,37 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	snapshotPolicy := sm.SnapshotPolicy{
+		Interval:   config.StateSync.SnapshotInterval,
+		KeepRecent: config.StateSync.SnapshotKeepRecent,
+	}
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithAppHashSize(config.Consensus.AppHashSize),
+		sm.BlockExecutorWithSnapshotPolicy(snapshotPolicy),
+		sm.BlockExecutorWithDiagnosticsDir(config.DiagnosticsDir()),
+		sm.BlockExecutorWithProposalConn(proxyApp.Proposal()),
+	}
+
+	// Watch the chain locks of Dash Core, if the private validator is backed by it.
+	var chainLocks *chainLockWatcher
+	if provider, ok := privValidator.(types.ChainLockProvider); ok {
+		chainLocks = newChainLockWatcher(provider, eventBus, chainLockPollInterval)
+		chainLocks.SetLogger(logger.With("module", "chainlock"))
+	}
+
+	// Prune the state store in the background, if the storage retention is configured.
+	var pruner *sm.Pruner
+	if config.Storage.RetainABCIResponsesBlocks > 0 || config.Storage.RetainValidatorSetsBlocks > 0 {
+		pruner = sm.NewPruner(stateStore,
+			config.Storage.RetainABCIResponsesBlocks,
+			config.Storage.RetainValidatorSetsBlocks,
+			sm.PrunerWithSnapshotPolicy(snapshotPolicy),
+		)
+		pruner.SetLogger(logger.With("module", "pruner"))
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithPruner(pruner))
+	}
+
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -760,12 +940,12 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evidencePool,
 		nextCoreChainLock,
-		sm.BlockExecutorWithMetrics(smMetrics),
-		sm.BlockExecutorWithAppHashSize(config.Consensus.AppHashSize),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, &proTxHash, fastSync && !stateSync, logger)
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, &proTxHash, fastSync && !stateSync,
+		bcMetrics, logger)
 	if err != nil {
 		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
 	}
@@ -779,30 +959,43 @@ func NewNode(config *cfg.Config,
 	}
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger,
+		privValidator, csMetrics, tracerProvider.Tracer(), stateSync || fastSync, eventBus, consensusLogger,
 	)
 
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
 	// https://github.com/tendermint/tendermint/issues/4644
-	stateSyncReactor := statesync.NewReactor(proxyApp.Snapshot(), proxyApp.Query(),
-		config.StateSync.TempDir)
+	stateSyncReactor := statesync.NewReactor(
+		*config.StateSync, proxyApp.Snapshot(), proxyApp.Query(), stateStore, blockStore,
+	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
 	if err != nil {
 		return nil, err
 	}
+	// Prove the ownership of the masternode to the peers.
+	if err := proveMasternodeIdentity(&nodeInfo, privValidator, state); err != nil {
+		logger.Error("Failed to prove the masternode identity, connecting as a full node", "err", err)
+	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	var identityVerifier p2p.MasternodeIdentityVerifier
+	if config.P2P.VerifyMasternodeIdentity {
+		identityVerifier = newMasternodeIdentityVerifier(genDoc.ChainID, stateStore, privValidator)
+	}
+	banList, err := p2p.LoadBanList(config.P2P.BanListFile())
+	if err != nil {
+		return nil, fmt.Errorf("could not load ban list: %w", err)
+	}
+	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp, identityVerifier, banList)
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, banList, p2pLogger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -834,7 +1027,12 @@ func NewNode(config *cfg.Config,
 	// Note we currently use the addrBook regardless at least for AddOurAddress
 	var pexReactor *pex.Reactor
 	if config.P2P.PexReactor {
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		// the masternode list is provided by Dash Core
+		masternodes, _ := privValidator.(pex.MasternodeList)
+		if config.P2P.OnlyMasternodePeers && masternodes == nil {
+			return nil, errors.New("only_masternode_peers requires priv_validator_core_rpc_host")
+		}
+		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, masternodes, logger)
 	}
 
 	if config.RPC.PprofListenAddress != "" {
@@ -871,7 +1069,11 @@ func NewNode(config *cfg.Config,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
 		blockIndexer:     blockIndexer,
+		pruner:           pruner,
+		chainLockWatcher: chainLocks,
+		tracerProvider:   tracerProvider,
 		eventBus:         eventBus,
+		dbs:              dbs,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -928,6 +1130,18 @@ func (n *Node) OnStart() error {
 		}
 	}
 
+	if n.pruner != nil {
+		if err := n.pruner.Start(); err != nil {
+			return err
+		}
+	}
+
+	if n.chainLockWatcher != nil {
+		if err := n.chainLockWatcher.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -960,45 +1174,95 @@ func (n *Node) OnStart() error {
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
 
-	n.Logger.Info("Stopping Node")
+	n.Logger.Info("Stopping Node", "drain_timeout", n.config.DrainTimeout)
 
-	// first stop the non-reactor services
-	if err := n.eventBus.Stop(); err != nil {
-		n.Logger.Error("Error closing eventBus", "err", err)
-	}
-	if err := n.indexerService.Stop(); err != nil {
-		n.Logger.Error("Error closing indexerService", "err", err)
-	}
+	// first stop accepting new p2p and rpc connections
+	n.stopStage("listeners", func() {
+		if err := n.transport.Close(); err != nil {
+			n.Logger.Error("Error closing transport", "err", err)
+		}
+		n.isListening = false
 
-	// now stop the reactors
-	if err := n.sw.Stop(); err != nil {
-		n.Logger.Error("Error closing switch", "err", err)
-	}
+		for _, l := range n.rpcListeners {
+			n.Logger.Info("Closing rpc listener", "listener", l)
+			if err := l.Close(); err != nil {
+				n.Logger.Error("Error closing listener", "listener", l, "err", err)
+			}
+		}
+	})
 
-	// stop mempool WAL
-	if n.config.Mempool.WalEnabled() {
-		n.mempool.CloseWAL()
-	}
+	// let the consensus commit the block in progress, the peers are still
+	// connected to gossip its parts; the WAL is flushed, fsynced and closed
+	n.stopStage("consensus", func() {
+		// fast sync can't switch to the consensus once it's drained
+		if n.bcReactor.IsRunning() {
+			if err := n.bcReactor.Stop(); err != nil {
+				n.Logger.Error("Error closing fast sync reactor", "err", err)
+			}
+		}
+		if !n.consensusState.Drain(n.config.DrainTimeout) {
+			n.Logger.Error("Consensus didn't commit the block in progress within the drain timeout",
+				"drain_timeout", n.config.DrainTimeout)
+		}
+	})
 
-	if err := n.transport.Close(); err != nil {
-		n.Logger.Error("Error closing transport", "err", err)
-	}
+	// now stop the peers and the reactors, in the reverse order of createSwitch
+	n.stopStage("reactors", func() {
+		if err := n.sw.Stop(); err != nil {
+			n.Logger.Error("Error closing switch", "err", err)
+		}
 
-	n.isListening = false
+		// stop mempool WAL
+		if n.config.Mempool.WalEnabled() {
+			n.mempool.CloseWAL()
+		}
+	})
 
-	// finally stop the listeners / external services
-	for _, l := range n.rpcListeners {
-		n.Logger.Info("Closing rpc listener", "listener", l)
-		if err := l.Close(); err != nil {
-			n.Logger.Error("Error closing listener", "listener", l, "err", err)
+	// then the non-reactor services
+	n.stopStage("services", func() {
+		if err := n.indexerService.Stop(); err != nil {
+			n.Logger.Error("Error closing indexerService", "err", err)
 		}
-	}
+		if n.pruner != nil {
+			if err := n.pruner.Stop(); err != nil {
+				n.Logger.Error("Error closing pruner", "err", err)
+			}
+		}
+		if n.chainLockWatcher != nil {
+			if err := n.chainLockWatcher.Stop(); err != nil {
+				n.Logger.Error("Error closing chain lock watcher", "err", err)
+			}
+		}
+		if err := n.eventBus.Stop(); err != nil {
+			n.Logger.Error("Error closing eventBus", "err", err)
+		}
+		if pvsc, ok := n.privValidator.(service.Service); ok {
+			if err := pvsc.Stop(); err != nil {
+				n.Logger.Error("Error closing private validator", "err", err)
+			}
+		}
+	})
 
-	if pvsc, ok := n.privValidator.(service.Service); ok {
-		if err := pvsc.Stop(); err != nil {
-			n.Logger.Error("Error closing private validator", "err", err)
+	// nothing calls the application anymore
+	n.stopStage("abci", func() {
+		if err := n.proxyApp.Stop(); err != nil {
+			n.Logger.Error("Error closing ABCI connections", "err", err)
 		}
-	}
+	})
+
+	// finally close the stores
+	n.stopStage("stores", func() {
+		if n.genesisChunks != nil {
+			if err := n.genesisChunks.Close(); err != nil {
+				n.Logger.Error("Error closing the genesis chunks", "err", err)
+			}
+		}
+		for _, db := range n.dbs {
+			if err := db.Close(); err != nil {
+				n.Logger.Error("Error closing database", "err", err)
+			}
+		}
+	})
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -1006,6 +1270,19 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+	defer cancel()
+	if err := n.tracerProvider.Shutdown(ctx); err != nil {
+		n.Logger.Error("Error exporting the remaining spans", "err", err)
+	}
+}
+
+// stopStage runs a stage of stopping the node, logging how long it took.
+func (n *Node) stopStage(stage string, stop func()) {
+	start := time.Now()
+	stop()
+	n.Logger.Info("Stopped node stage", "stage", stage, "took", time.Since(start))
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
@@ -1014,7 +1291,14 @@ func (n *Node) ConfigureRPC() error {
 	if err != nil {
 		return fmt.Errorf("can't get proTxHash for rpc: %w", err)
 	}
-	rpccore.SetEnvironment(&rpccore.Environment{
+	if n.genesisChunks == nil {
+		n.genesisChunks, err = rpccore.NewGenesisChunks(n.genesisDoc,
+			filepath.Join(n.config.DBDir(), genesisChunksFile), n.config.RPC.GenesisChunkSize)
+		if err != nil {
+			return fmt.Errorf("can't prepare the genesis chunks for rpc: %w", err)
+		}
+	}
+	n.rpcEnv = &rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
 
@@ -1027,16 +1311,22 @@ func (n *Node) ConfigureRPC() error {
 
 		ProTxHash:        proTxHash,
 		GenDoc:           n.genesisDoc,
+		GenesisChunks:    n.genesisChunks,
 		TxIndexer:        n.txIndexer,
 		BlockIndexer:     n.blockIndexer,
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		ConfigReloader:   n,
 
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
-	})
+	}
+	if n.chainLockWatcher != nil {
+		n.rpcEnv.ChainLockWatcher = n.chainLockWatcher
+	}
+	rpccore.SetEnvironment(n.rpcEnv)
 	return nil
 }
 
@@ -1056,12 +1346,18 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.MaxConcurrentRequestsPerIP = n.config.RPC.MaxConcurrentRequestsPerIP
+	config.RateLimitedRequestsPerSecond = n.config.RPC.ExpensiveRequestsPerSecond
+	config.RateLimitedRequestsBurst = n.config.RPC.ExpensiveRequestsBurst
+	config.MaxSubscriptionsPerConnection = n.config.RPC.MaxSubscriptionsPerClient
+	config.UnixSocketMode = n.config.RPC.UnixSocketFileMode()
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
 	if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
+	n.rpcWriteTimeout = config.WriteTimeout
 
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
@@ -1088,6 +1384,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if err != nil {
 			return nil, err
 		}
+		n.rpcLimitListeners = append(n.rpcLimitListeners, listener.(*rpcserver.LimitListener))
 
 		var rootHandler http.Handler = mux
 		if n.config.RPC.IsCorsEnabled() {
@@ -1135,6 +1432,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 		// NOTE: GRPCMaxOpenConnections is used, not MaxOpenConnections
 		config.MaxOpenConnections = n.config.RPC.GRPCMaxOpenConnections
+		config.UnixSocketMode = n.config.RPC.UnixSocketFileMode()
 		// If necessary adjust global WriteTimeout to ensure it's greater than
 		// TimeoutBroadcastTxCommit.
 		// See https://github.com/tendermint/tendermint/issues/3435
@@ -1189,6 +1487,11 @@ func (n *Node) BlockStore() *store.BlockStore {
 	return n.blockStore
 }
 
+// StateStore returns the Node's StateStore.
+func (n *Node) StateStore() sm.Store {
+	return n.stateStore
+}
+
 // ConsensusState returns the Node's ConsensusState.
 func (n *Node) ConsensusState() *cs.State {
 	return n.consensusState
@@ -1300,7 +1603,7 @@ func makeNodeInfo(
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
 			evidence.EvidenceChannel,
-			statesync.SnapshotChannel, statesync.ChunkChannel,
+			statesync.SnapshotChannel, statesync.ChunkChannel, statesync.LightBlockChannel,
 		},
 		Moniker: config.Moniker,
 		Other: p2p.DefaultNodeInfoOther{
@@ -1313,6 +1616,10 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	if config.Consensus.CompactBlocks {
+		nodeInfo.Channels = append(nodeInfo.Channels, cs.CompactBlockChannel)
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
@@ -1325,12 +1632,112 @@ func makeNodeInfo(
 	return nodeInfo, err
 }
 
+// proveMasternodeIdentity sets the proTxHash of the node and the signature of
+// the node ID by its key in the current quorum, so the peers can verify the
+// ownership of the masternode. The node info is left as is, if the node isn't
+// a member of the current validator set.
+func proveMasternodeIdentity(nodeInfo *p2p.DefaultNodeInfo, privValidator types.PrivValidator, state sm.State) error {
+	signer, ok := privValidator.(types.NodeIDSigner)
+	if !ok || state.Validators == nil {
+		return nil
+	}
+	proTxHash, err := privValidator.GetProTxHash()
+	if err != nil {
+		return fmt.Errorf("can't get proTxHash: %w", err)
+	}
+	vals := state.Validators
+	if !vals.HasProTxHash(proTxHash) {
+		return nil
+	}
+	signature, err := signer.SignNodeID(state.ChainID, string(nodeInfo.ID()), vals.QuorumType, vals.QuorumHash)
+	if err != nil {
+		return fmt.Errorf("can't sign the node ID: %w", err)
+	}
+	nodeInfo.ProTxHash = &proTxHash
+	nodeInfo.QuorumHash = vals.QuorumHash
+	nodeInfo.NodeIDSignature = signature
+	return nil
+}
+
+// quorumMemberKeys provides the public key shares of the quorum members, e.g.
+// the Dash Core signer client.
+type quorumMemberKeys interface {
+	GetMemberPubKey(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash,
+		proTxHash crypto.ProTxHash) (crypto.PubKey, error)
+}
+
+// masternodeIdentityVerifier verifies the proof of the masternode ownership of
+// the peers with the public keys of the validator sets of the latest state.
+// The keys of the other quorums are looked up in Dash Core, if available.
+type masternodeIdentityVerifier struct {
+	chainID    string
+	stateStore sm.Store
+	memberKeys quorumMemberKeys
+}
+
+var _ p2p.MasternodeIdentityVerifier = (*masternodeIdentityVerifier)(nil)
+
+func newMasternodeIdentityVerifier(
+	chainID string,
+	stateStore sm.Store,
+	privValidator types.PrivValidator,
+) *masternodeIdentityVerifier {
+	memberKeys, _ := privValidator.(quorumMemberKeys)
+	return &masternodeIdentityVerifier{
+		chainID:    chainID,
+		stateStore: stateStore,
+		memberKeys: memberKeys,
+	}
+}
+
+// VerifyMasternodeIdentity implements p2p.MasternodeIdentityVerifier.
+func (v *masternodeIdentityVerifier) VerifyMasternodeIdentity(nodeInfo p2p.DefaultNodeInfo) error {
+	proTxHash := *nodeInfo.ProTxHash
+	quorumHash := crypto.QuorumHash(nodeInfo.QuorumHash)
+	nodeID := string(nodeInfo.ID())
+
+	state, err := v.stateStore.Load()
+	if err != nil {
+		return err
+	}
+	for _, vals := range []*types.ValidatorSet{state.Validators, state.NextValidators, state.LastValidators} {
+		if vals == nil || !bytes.Equal(vals.QuorumHash, quorumHash) {
+			continue
+		}
+		_, val := vals.GetByProTxHash(proTxHash)
+		if val == nil {
+			return fmt.Errorf("masternode %v isn't a member of quorum %v", proTxHash, quorumHash)
+		}
+		return types.VerifyNodeIDSignature(v.chainID, nodeID, proTxHash, vals.QuorumType, quorumHash,
+			val.PubKey, nodeInfo.NodeIDSignature)
+	}
+
+	if v.memberKeys == nil || state.Validators == nil {
+		return fmt.Errorf("unknown quorum %v", quorumHash)
+	}
+	// the quorums of the same chain are of the same type
+	quorumType := state.Validators.QuorumType
+	pubKey, err := v.memberKeys.GetMemberPubKey(quorumType, quorumHash, proTxHash)
+	if err != nil {
+		return err
+	}
+	return types.VerifyNodeIDSignature(v.chainID, nodeID, proTxHash, quorumType, quorumHash,
+		pubKey, nodeInfo.NodeIDSignature)
+}
+
 //------------------------------------------------------------------------------
 
 var (
 	genesisDocKey = []byte("genesisDoc")
 )
 
+// the file in the data directory the genesis doc JSON is written to for
+// /genesis_chunked
+const genesisChunksFile = "genesis_chunks.json"
+
+// how long the node waits for the remaining spans to be exported on stop
+const tracerShutdownTimeout = 5 * time.Second
+
 // LoadStateFromDBOrGenesisDocProvider attempts to load the state from the
 // database, or creates one using the given genesisDocProvider. On success this also
 // returns the genesis doc loaded through the given provider.
@@ -1368,17 +1775,35 @@ func loadGenesisDoc(db dbm.DB) (*types.GenesisDoc, error) {
 	if len(b) == 0 {
 		return nil, errors.New("genesis doc not found")
 	}
-	var genDoc *types.GenesisDoc
-	err = tmjson.Unmarshal(b, &genDoc)
+	// the app state isn't decoded, it can be large
+	genDoc, err := types.GenesisDocFromJSONStreaming(b)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to load genesis doc due to unmarshaling error: %v (bytes: %X)", err, b))
 	}
-	return genDoc, nil
+	if len(genDoc.AppState) > 0 {
+		// the older versions store the app state in the genesis doc
+		if err := saveGenesisDoc(db, genDoc); err != nil {
+			panic(fmt.Sprintf("Failed to migrate genesis doc: %v", err))
+		}
+	}
+	size, err := loadGenesisAppStateSize(db)
+	if err != nil {
+		panic(err)
+	}
+	if size == 0 {
+		return genDoc.WithAppStateSource(nil), nil
+	}
+	return genDoc.WithAppStateSource(dbGenesisAppState{db: db, size: size}), nil
 }
 
-// panics if failed to marshal the given genesis document
+// saveGenesisDoc stores the genesis doc without its app state, the app state is
+// stored in chunks, see saveGenesisAppState. The genesis doc is stored last, so
+// that it's found only once its app state is stored.
 func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) error {
-	b, err := tmjson.Marshal(genDoc)
+	if err := saveGenesisAppState(db, genDoc); err != nil {
+		return fmt.Errorf("failed to save genesis app state: %w", err)
+	}
+	b, err := tmjson.Marshal(genDoc.WithAppStateSource(nil))
 	if err != nil {
 		return fmt.Errorf("failed to save genesis doc due to marshaling error: %w", err)
 	}
@@ -1390,12 +1815,14 @@ func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) error {
 }
 
 func createAndStartPrivValidatorSocketClient(
-	listenAddr,
+	listenAddr string,
+	socketMode os.FileMode,
 	chainID string,
 	initialQuorumHash crypto.QuorumHash,
+	metrics *privval.Metrics,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
-	pve, err := privval.NewSignerListener(listenAddr, logger)
+	pve, err := privval.NewSignerListenerWithSocketMode(listenAddr, socketMode, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
@@ -1404,6 +1831,7 @@ func createAndStartPrivValidatorSocketClient(
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
+	pvsc.SetMetrics(metrics)
 
 	// try to get a pubkey from private validate first time
 	_, err = pvsc.GetPubKey(initialQuorumHash)
@@ -1425,16 +1853,28 @@ func createAndStartPrivValidatorRPCClient(
 	defaultQuorumType btcjson.LLMQType,
 	username string,
 	password string,
+	retryTimeout time.Duration,
+	metrics *privval.Metrics,
+	fallbackPV *privval.FilePV,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
 
-	pvsc, err := privval.NewDashCoreSignerClient(host, username, password, defaultQuorumType)
+	// the sign requests are retried within the proposal timeout, if Dash Core is restarted
+	pvsc, err := privval.NewDashCoreSignerClient(host, username, password, defaultQuorumType,
+		privval.DashCoreSignerClientRetryTimeout(retryTimeout),
+		privval.DashCoreSignerClientMetrics(metrics),
+		privval.DashCoreSignerClientLogger(logger.With("module", "privval")),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
+	var pv types.PrivValidator = pvsc
+	if fallbackPV != nil {
+		pv = privval.NewDashCoreFallbackSignerClient(pvsc, fallbackPV, logger.With("module", "privval"))
+	}
 
 	// try to get a proTxHash from private validate first time to make sure connection works
-	_, err = pvsc.GetProTxHash()
+	_, err = pv.GetProTxHash()
 	if err != nil {
 		return nil, fmt.Errorf("can't get proTxHash when starting private validator rpc client: %w", err)
 	}
@@ -1445,7 +1885,7 @@ func createAndStartPrivValidatorRPCClient(
 	//)
 	//pvscWithRetries := privval.NewRetrySignerClient(pvsc, retries, timeout)
 
-	return pvsc, nil
+	return pv, nil
 }
 
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
@@ -1,14 +1,18 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/crypto"
 
 	"github.com/stretchr/testify/assert"
@@ -18,8 +22,11 @@ import (
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	cfg "github.com/tendermint/tendermint/config"
+	cs "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/evidence"
+	tmflags "github.com/tendermint/tendermint/libs/cli/flags"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	mempl "github.com/tendermint/tendermint/mempool"
@@ -76,6 +83,46 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+// The node is stopped at random heights and restarted, each stop leaves the
+// WAL ending cleanly, so the consensus doesn't need to repair it on start.
+func TestNodeStopRestartCleanWAL(t *testing.T) {
+	config := cfg.ResetTestRoot("node_restart_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = string(dbm.GoLevelDBBackend)
+
+	var height int64
+	for i := 0; i < 3; i++ {
+		n, err := DefaultNewNode(config, log.TestingLogger())
+		require.NoError(t, err)
+		require.NoError(t, n.Start())
+		require.GreaterOrEqual(t, n.BlockStore().Height(), height)
+
+		height = n.BlockStore().Height() + 1 + tmrand.Int63n(3)
+		require.Eventually(t, func() bool {
+			return n.BlockStore().Height() >= height
+		}, 30*time.Second, 10*time.Millisecond)
+		require.NoError(t, n.Stop())
+
+		requireCleanWAL(t, config.Consensus.WalFile())
+	}
+}
+
+func requireCleanWAL(t *testing.T, walFile string) {
+	t.Helper()
+	f, err := os.Open(walFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	dec := cs.NewWALDecoder(f)
+	for {
+		_, err := dec.Decode()
+		if err == io.EOF {
+			return
+		}
+		require.NoError(t, err, "the WAL needs a repair")
+	}
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -133,6 +180,83 @@ func TestNodeSetAppVersion(t *testing.T) {
 	assert.Equal(t, n.nodeInfo.(p2p.DefaultNodeInfo).ProtocolVersion.App, appVersion)
 }
 
+// syncBuffer is a bytes.Buffer, which can be written and read concurrently.
+type syncBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
+
+func TestNodeReloadConfig(t *testing.T) {
+	config := cfg.ResetTestRoot("node_reload_config_test")
+	defer os.RemoveAll(config.RootDir)
+	config.LogLevel = "info"
+
+	var buf syncBuffer
+	newLogger := func(config *cfg.Config) (log.Logger, error) {
+		return tmflags.ParseLogLevels(config.LogLevel, config.Log.Levels, log.NewTMLogger(&buf), cfg.DefaultLogLevel)
+	}
+	logger, err := newLogger(config)
+	require.NoError(t, err)
+
+	n, err := DefaultNewNode(config, log.NewReloadableLogger(logger))
+	require.NoError(t, err)
+
+	_, _, err = n.ReloadConfig()
+	assert.Error(t, err, "reloading without a loader")
+
+	newConfig := *config
+	newMempool := *config.Mempool
+	newConfig.Mempool = &newMempool
+	newConfig.LogLevel = "debug"
+	newConfig.Moniker = "reloaded"
+	newConfig.Mempool.Size = 1
+	n.SetConfigReloader(func() (*cfg.Config, error) { return &newConfig, nil }, newLogger)
+
+	n.Logger.Debug("debug message before reload")
+	applied, requiresRestart, err := n.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log_level", "mempool.size"}, applied)
+	assert.Equal(t, []string{"moniker"}, requiresRestart)
+	n.Logger.Debug("debug message after reload")
+
+	assert.NotContains(t, buf.String(), "debug message before reload")
+	assert.Contains(t, buf.String(), "debug message after reload")
+	assert.Contains(t, buf.String(), "Changed config requires restart")
+
+	// the mempool uses the new size
+	require.NoError(t, n.Mempool().CheckTx([]byte("a=1"), nil, mempl.TxInfo{}))
+	err = n.Mempool().CheckTx([]byte("b=2"), nil, mempl.TxInfo{})
+	assert.IsType(t, mempl.ErrMempoolIsFull{}, err)
+
+	// the keys, which require a restart, are reported again
+	applied, requiresRestart, err = n.ReloadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Equal(t, []string{"moniker"}, requiresRestart)
+
+	// the levels of the modules override the log level
+	newLog := *config.Log
+	newLog.Levels = map[string]string{"*": "error"}
+	newConfig.Log = &newLog
+	applied, _, err = n.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log.levels"}, applied)
+	n.Logger.Info("info message after levels reload")
+	assert.NotContains(t, buf.String(), "info message after levels reload")
+}
+
 func TestNodeSetPrivValTCP(t *testing.T) {
 	addr := "tcp://" + testFreeAddr(t)
 
@@ -235,7 +359,7 @@ func TestCreateProposalBlock(t *testing.T) {
 	config := cfg.ResetTestRoot("node_create_proposal")
 	defer os.RemoveAll(config.RootDir)
 	cc := proxy.NewLocalClientCreator(kvstore.NewApplication())
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err := proxyApp.Start()
 	require.Nil(t, err)
 	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
@@ -306,7 +430,8 @@ func TestCreateProposalBlock(t *testing.T) {
 	)
 
 	commit := types.NewCommit(height-1, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
-	block, _ := blockExec.CreateProposalBlock(height, state, commit, proposerProTxHash)
+	block, _, err := blockExec.CreateProposalBlock(height, 0, state, commit, proposerProTxHash)
+	require.NoError(t, err)
 
 	// check that the part set does not exceed the maximum block size
 	partSet := block.MakePartSet(partSize)
@@ -328,7 +453,7 @@ func TestMaxProposalBlockSize(t *testing.T) {
 	config := cfg.ResetTestRoot("node_create_proposal")
 	defer os.RemoveAll(config.RootDir)
 	cc := proxy.NewLocalClientCreator(kvstore.NewApplication())
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err := proxyApp.Start()
 	require.Nil(t, err)
 	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
@@ -373,7 +498,8 @@ func TestMaxProposalBlockSize(t *testing.T) {
 	)
 
 	commit := types.NewCommit(height-1, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
-	block, _ := blockExec.CreateProposalBlock(height, state, commit, proposerProTxHash)
+	block, _, err := blockExec.CreateProposalBlock(height, 0, state, commit, proposerProTxHash)
+	require.NoError(t, err)
 
 	pb, err := block.ToProto()
 	require.NoError(t, err)
@@ -429,6 +555,57 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	assert.Contains(t, channels, cr.Channels[0].ID)
 }
 
+func TestSaveLoadGenesisDoc(t *testing.T) {
+	vals, _, quorumHash, thresholdPublicKey := types.GenerateGenesisValidators(1)
+	genDoc := &types.GenesisDoc{
+		ChainID:            "test-chain",
+		Validators:         vals,
+		ThresholdPublicKey: thresholdPublicKey,
+		QuorumHash:         quorumHash,
+		// a few chunks
+		AppState: []byte(fmt.Sprintf(`{"data":%q}`, tmrand.Str(5*genesisAppStateChunkSize/2))),
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+
+	testCases := []struct {
+		name string
+		save func(db dbm.DB) error
+	}{
+		{"current version", func(db dbm.DB) error {
+			return saveGenesisDoc(db, genDoc)
+		}},
+		{"older version", func(db dbm.DB) error {
+			// the older versions store the app state in the genesis doc
+			b, err := tmjson.Marshal(genDoc)
+			if err != nil {
+				return err
+			}
+			return db.Set(genesisDocKey, b)
+		}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			db := dbm.NewMemDB()
+			require.NoError(t, tc.save(db))
+
+			loaded, err := loadGenesisDoc(db)
+			require.NoError(t, err)
+			// the app state is read from the db when it's needed
+			assert.Nil(t, loaded.AppState)
+			appState, err := loaded.LoadAppState()
+			require.NoError(t, err)
+			assert.Equal(t, []byte(genDoc.AppState), []byte(appState))
+			assert.Equal(t, genDoc.WithAppStateSource(nil), loaded.WithAppStateSource(nil))
+
+			// the genesis doc is stored without the app state
+			b, err := db.Get(genesisDocKey)
+			require.NoError(t, err)
+			assert.NotContains(t, string(b), "app_state")
+		})
+	}
+}
+
 func state(nVals int, height int64) (sm.State, dbm.DB, []types.PrivValidator) {
 	vals, privVals, quorumHash, thresholdPublicKey := types.GenerateGenesisValidators(nVals)
 	for i := 0; i < nVals; i++ {
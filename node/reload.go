@@ -0,0 +1,192 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+)
+
+// reloadableConfigKeys are the config keys, which can be changed while the
+// node is running, with the functions applying the new values to the running
+// components and to the config of the node.
+var reloadableConfigKeys = map[string]func(n *Node, config *cfg.Config) error{
+	"log_level":                       (*Node).reloadLogger,
+	"log_format":                      (*Node).reloadLogger,
+	"log.levels":                      (*Node).reloadLogger,
+	"rpc.max_open_connections":        (*Node).reloadRPCMaxOpenConnections,
+	"rpc.timeout_broadcast_tx_commit": (*Node).reloadRPCTimeoutBroadcastTxCommit,
+	"mempool.size":                    (*Node).reloadMempoolSize,
+	"mempool.cache_size":              (*Node).reloadMempoolCacheSize,
+	"statesync.discovery_time":        (*Node).reloadStateSyncDiscoveryTime,
+}
+
+// SetConfigReloader enables ReloadConfig. load returns the current config,
+// usually read from the config file again, and newLogger returns the logger
+// for the given config, which replaces the logger of the node, if it's a
+// *log.ReloadableLogger.
+func (n *Node) SetConfigReloader(
+	load func() (*cfg.Config, error),
+	newLogger func(*cfg.Config) (log.Logger, error),
+) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	n.configLoader = load
+	n.loggerProvider = newLogger
+}
+
+// ReloadConfig loads the config again and applies the changes of the keys,
+// which can be changed while the node is running (see reloadableConfigKeys).
+// It returns the applied keys and the changed keys, which require a restart.
+// The latter are not applied, so they are reported on every reload until the
+// node is restarted.
+func (n *Node) ReloadConfig() (applied, requiresRestart []string, err error) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+
+	if n.configLoader == nil {
+		return nil, nil, errors.New("reloading the config is not enabled")
+	}
+	config, err := n.configLoader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the config: %w", err)
+	}
+
+	for _, key := range changedConfigKeys(n.config, config) {
+		apply, ok := reloadableConfigKeys[key]
+		if !ok {
+			n.Logger.Info("Changed config requires restart", "key", key)
+			requiresRestart = append(requiresRestart, key)
+			continue
+		}
+		if err := apply(n, config); err != nil {
+			n.Logger.Error("Can't apply changed config, it requires restart", "key", key, "err", err)
+			requiresRestart = append(requiresRestart, key)
+			continue
+		}
+		n.Logger.Info("Applied changed config", "key", key)
+		applied = append(applied, key)
+	}
+	return applied, requiresRestart, nil
+}
+
+func (n *Node) reloadLogger(config *cfg.Config) error {
+	logger, ok := n.Logger.(*log.ReloadableLogger)
+	if !ok || n.loggerProvider == nil {
+		return errors.New("the logger can't be reloaded")
+	}
+	newLogger, err := n.loggerProvider(config)
+	if err != nil {
+		return err
+	}
+	logger.Reload(newLogger)
+	n.config.LogLevel = config.LogLevel
+	n.config.LogFormat = config.LogFormat
+	n.config.Log.Levels = config.Log.Levels
+	return nil
+}
+
+func (n *Node) reloadRPCMaxOpenConnections(config *cfg.Config) error {
+	for _, l := range n.rpcLimitListeners {
+		l.SetLimit(config.RPC.MaxOpenConnections)
+	}
+	n.config.RPC.MaxOpenConnections = config.RPC.MaxOpenConnections
+	return nil
+}
+
+func (n *Node) reloadRPCTimeoutBroadcastTxCommit(config *cfg.Config) error {
+	timeout := config.RPC.TimeoutBroadcastTxCommit
+	if n.rpcEnv != nil {
+		// the write timeout of the RPC servers was adjusted to the old timeout
+		if n.rpcWriteTimeout > 0 && timeout >= n.rpcWriteTimeout {
+			return fmt.Errorf("timeout %v must be less than the write timeout of the RPC servers %v",
+				timeout, n.rpcWriteTimeout)
+		}
+		n.rpcEnv.SetTimeoutBroadcastTxCommit(timeout)
+	}
+	n.config.RPC.TimeoutBroadcastTxCommit = timeout
+	return nil
+}
+
+func (n *Node) reloadMempoolSize(config *cfg.Config) error {
+	mempool, ok := n.mempool.(*mempl.CListMempool)
+	if !ok {
+		return errors.New("the mempool can't be resized")
+	}
+	mempool.SetSize(config.Mempool.Size)
+	n.config.Mempool.Size = config.Mempool.Size
+	return nil
+}
+
+func (n *Node) reloadMempoolCacheSize(config *cfg.Config) error {
+	mempool, ok := n.mempool.(*mempl.CListMempool)
+	if !ok {
+		return errors.New("the mempool cache can't be resized")
+	}
+	if err := mempool.SetCacheSize(config.Mempool.CacheSize); err != nil {
+		return err
+	}
+	n.config.Mempool.CacheSize = config.Mempool.CacheSize
+	return nil
+}
+
+func (n *Node) reloadStateSyncDiscoveryTime(config *cfg.Config) error {
+	if n.stateSyncReactor != nil {
+		n.stateSyncReactor.SetDiscoveryTime(config.StateSync.DiscoveryTime)
+	}
+	n.config.StateSync.DiscoveryTime = config.StateSync.DiscoveryTime
+	return nil
+}
+
+// changedConfigKeys returns the sorted keys of the config file, whose values
+// differ between the configs.
+func changedConfigKeys(oldConfig, newConfig *cfg.Config) []string {
+	var keys []string
+	appendChangedConfigKeys(&keys, "", reflect.ValueOf(oldConfig).Elem(), reflect.ValueOf(newConfig).Elem())
+	sort.Strings(keys)
+	return keys
+}
+
+// appendChangedConfigKeys compares the fields of the config structs by their
+// mapstructure tags, descending into the sections and the squashed structs.
+func appendChangedConfigKeys(keys *[]string, prefix string, oldValue, newValue reflect.Value) {
+	configPkg := reflect.TypeOf(cfg.Config{}).PkgPath()
+	for i := 0; i < oldValue.NumField(); i++ {
+		field := oldValue.Type().Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		tag := field.Tag.Get("mapstructure")
+		name := strings.Split(tag, ",")[0]
+		oldField, newField := oldValue.Field(i), newValue.Field(i)
+		if field.Type.Kind() == reflect.Ptr && field.Type.Elem().PkgPath() == configPkg &&
+			field.Type.Elem().Kind() == reflect.Struct {
+			if oldField.IsNil() || newField.IsNil() {
+				if oldField.IsNil() != newField.IsNil() {
+					*keys = append(*keys, prefix+name)
+				}
+				continue
+			}
+			oldField, newField = oldField.Elem(), newField.Elem()
+		}
+		if oldField.Kind() == reflect.Struct && oldField.Type().PkgPath() == configPkg {
+			if strings.Contains(tag, "squash") {
+				appendChangedConfigKeys(keys, prefix, oldField, newField)
+			} else {
+				appendChangedConfigKeys(keys, prefix+name+".", oldField, newField)
+			}
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			*keys = append(*keys, prefix+name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package p2p
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/libs/tempfile"
+)
+
+// BannedPeer is a peer, which is banned until the given time.
+type BannedPeer struct {
+	ID       ID        `json:"id"`
+	Reason   string    `json:"reason"`
+	BannedAt time.Time `json:"banned_at"`
+	Until    time.Time `json:"until"`
+}
+
+// banListJSON is the file format of the ban list.
+type banListJSON struct {
+	Bans []BannedPeer `json:"bans"`
+}
+
+// BanList is the list of the banned peers. The banned peers are refused on
+// accept and on dial, until their bans expire. The list is persisted to the
+// file, if any, on every change.
+type BanList struct {
+	filePath string
+	now      func() time.Time
+
+	mtx  tmsync.Mutex
+	bans map[ID]BannedPeer
+}
+
+// NewBanList returns an empty ban list, which isn't persisted.
+func NewBanList() *BanList {
+	return &BanList{
+		now:  time.Now,
+		bans: make(map[ID]BannedPeer),
+	}
+}
+
+// LoadBanList loads the ban list from the file, the list is empty if the file
+// doesn't exist. The changes of the list are saved to the file.
+func LoadBanList(filePath string) (*BanList, error) {
+	bl := NewBanList()
+	bl.filePath = filePath
+
+	bz, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return bl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't read ban list %s: %w", filePath, err)
+	}
+	var blJSON banListJSON
+	if err := json.Unmarshal(bz, &blJSON); err != nil {
+		return nil, fmt.Errorf("can't decode ban list %s: %w", filePath, err)
+	}
+	now := bl.now()
+	for _, ban := range blJSON.Bans {
+		if ban.Until.After(now) {
+			bl.bans[ban.ID] = ban
+		}
+	}
+	return bl, nil
+}
+
+// Ban bans the peer for the duration. The ban of a banned peer is replaced,
+// unless it expires later.
+func (bl *BanList) Ban(id ID, reason string, duration time.Duration) (BannedPeer, error) {
+	if err := validateID(id); err != nil {
+		return BannedPeer{}, err
+	}
+	if duration <= 0 {
+		return BannedPeer{}, errors.New("ban duration must be positive")
+	}
+
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	now := bl.now()
+	ban := BannedPeer{ID: id, Reason: reason, BannedAt: now, Until: now.Add(duration)}
+	if prev, ok := bl.bans[id]; ok && prev.Until.After(ban.Until) {
+		ban.Until = prev.Until
+	}
+	bl.bans[id] = ban
+	return ban, bl.save()
+}
+
+// Unban lifts the ban of the peer. It returns false, if the peer isn't banned.
+func (bl *BanList) Unban(id ID) (bool, error) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	ban, ok := bl.bans[id]
+	if !ok {
+		return false, nil
+	}
+	delete(bl.bans, id)
+	if !ban.Until.After(bl.now()) {
+		// the ban has expired already
+		return false, bl.save()
+	}
+	return true, bl.save()
+}
+
+// IsBanned returns the ban of the peer, if the peer is banned.
+func (bl *BanList) IsBanned(id ID) (BannedPeer, bool) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	ban, ok := bl.bans[id]
+	if !ok || !ban.Until.After(bl.now()) {
+		return BannedPeer{}, false
+	}
+	return ban, true
+}
+
+// List returns the banned peers, sorted by the expiration of their bans.
+func (bl *BanList) List() []BannedPeer {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	now := bl.now()
+	bans := make([]BannedPeer, 0, len(bl.bans))
+	for _, ban := range bl.bans {
+		if ban.Until.After(now) {
+			bans = append(bans, ban)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		if bans[i].Until.Equal(bans[j].Until) {
+			return bans[i].ID < bans[j].ID
+		}
+		return bans[i].Until.Before(bans[j].Until)
+	})
+	return bans
+}
+
+// save drops the expired bans, and writes the list to the file, if any.
+// CONTRACT: bl.mtx is locked.
+func (bl *BanList) save() error {
+	now := bl.now()
+	blJSON := banListJSON{Bans: make([]BannedPeer, 0, len(bl.bans))}
+	for id, ban := range bl.bans {
+		if !ban.Until.After(now) {
+			delete(bl.bans, id)
+			continue
+		}
+		blJSON.Bans = append(blJSON.Bans, ban)
+	}
+	if bl.filePath == "" {
+		return nil
+	}
+	sort.Slice(blJSON.Bans, func(i, j int) bool { return blJSON.Bans[i].ID < blJSON.Bans[j].ID })
+
+	bz, err := json.MarshalIndent(blJSON, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(bl.filePath, bz, 0644); err != nil {
+		return fmt.Errorf("can't save ban list %s: %w", bl.filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func randBanListID() ID {
+	return PubKeyToID(ed25519.GenPrivKey().PubKey())
+}
+
+func TestBanListBan(t *testing.T) {
+	var (
+		bl  = NewBanList()
+		now = time.Now()
+		id  = randBanListID()
+	)
+	bl.now = func() time.Time { return now }
+
+	_, err := bl.Ban("wrong", "spam", time.Hour)
+	assert.Error(t, err)
+	_, err = bl.Ban(id, "spam", 0)
+	assert.Error(t, err)
+	_, ok := bl.IsBanned(id)
+	assert.False(t, ok)
+
+	ban, err := bl.Ban(id, "spam", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, BannedPeer{ID: id, Reason: "spam", BannedAt: now, Until: now.Add(time.Hour)}, ban)
+
+	banned, ok := bl.IsBanned(id)
+	assert.True(t, ok)
+	assert.Equal(t, ban, banned)
+
+	// a shorter ban doesn't shorten the existing one
+	ban, err = bl.Ban(id, "flood", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "flood", ban.Reason)
+	assert.Equal(t, now.Add(time.Hour), ban.Until)
+
+	// a longer one extends it
+	ban, err = bl.Ban(id, "flood", 2*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Hour), ban.Until)
+
+	unbanned, err := bl.Unban(id)
+	require.NoError(t, err)
+	assert.True(t, unbanned)
+	_, ok = bl.IsBanned(id)
+	assert.False(t, ok)
+
+	unbanned, err = bl.Unban(id)
+	require.NoError(t, err)
+	assert.False(t, unbanned)
+}
+
+func TestBanListExpiry(t *testing.T) {
+	var (
+		bl  = NewBanList()
+		now = time.Now()
+		id1 = randBanListID()
+		id2 = randBanListID()
+	)
+	bl.now = func() time.Time { return now }
+
+	_, err := bl.Ban(id1, "spam", time.Hour)
+	require.NoError(t, err)
+	_, err = bl.Ban(id2, "flood", time.Minute)
+	require.NoError(t, err)
+
+	bans := bl.List()
+	require.Len(t, bans, 2)
+	assert.Equal(t, id2, bans[0].ID)
+	assert.Equal(t, id1, bans[1].ID)
+
+	now = now.Add(time.Minute)
+	_, ok := bl.IsBanned(id2)
+	assert.False(t, ok)
+	_, ok = bl.IsBanned(id1)
+	assert.True(t, ok)
+	bans = bl.List()
+	require.Len(t, bans, 1)
+	assert.Equal(t, id1, bans[0].ID)
+
+	// the expired ban can't be lifted
+	unbanned, err := bl.Unban(id2)
+	require.NoError(t, err)
+	assert.False(t, unbanned)
+
+	now = now.Add(time.Hour)
+	_, ok = bl.IsBanned(id1)
+	assert.False(t, ok)
+	assert.Empty(t, bl.List())
+}
+
+func TestBanListPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ban_list")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "banlist.json")
+
+	// the list is empty, if the file doesn't exist
+	bl, err := LoadBanList(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, bl.List())
+
+	var (
+		id1 = randBanListID()
+		id2 = randBanListID()
+		id3 = randBanListID()
+	)
+	ban1, err := bl.Ban(id1, "spam", time.Hour)
+	require.NoError(t, err)
+	_, err = bl.Ban(id2, "flood", 2*time.Hour)
+	require.NoError(t, err)
+	_, err = bl.Ban(id3, "short", 200*time.Millisecond)
+	require.NoError(t, err)
+	_, err = bl.Unban(id2)
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+
+	// the expired and the lifted bans aren't loaded
+	bl, err = LoadBanList(filePath)
+	require.NoError(t, err)
+	bans := bl.List()
+	require.Len(t, bans, 1)
+	assert.Equal(t, ban1.ID, bans[0].ID)
+	assert.Equal(t, ban1.Reason, bans[0].Reason)
+	assert.True(t, ban1.Until.Equal(bans[0].Until))
+	_, ok := bl.IsBanned(id1)
+	assert.True(t, ok)
+
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("{"), 0644))
+	_, err = LoadBanList(filePath)
+	assert.Error(t, err)
+}
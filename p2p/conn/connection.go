@@ -110,9 +110,19 @@ type MConnection struct {
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
 	pongTimeoutCh chan bool // true - timeout, false - peer sent pong
+	pingSent      time.Time // time of the latest ping
+	rtt           int64     // atomic, round-trip time of the latest ping
 
 	chStatsTimer *time.Ticker // update channel stats periodically
 
+	// The channels are scheduled by the weighted fair queueing, in the virtual
+	// time, which advances by the bytes sent on the channel divided by its
+	// priority. vclock is the virtual start time of the latest packet sent.
+	vclock float64
+	// start of the current flush interval, in which the channels are
+	// guaranteed their minimum share of the send budget
+	sendIntervalStart time.Time
+
 	created time.Time // time of creation
 
 	_maxPacketMsgSize int
@@ -134,6 +144,10 @@ type MConnConfig struct {
 
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+
+	// Capacities of the send queues by channel ID, overriding the ones of the
+	// channel descriptors
+	SendQueueCapacities map[byte]int `mapstructure:"send_queue_capacities"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -192,11 +206,20 @@ func NewMConnectionWithConfig(
 	// Create channels
 	var channelsIdx = map[byte]*Channel{}
 	var channels = []*Channel{}
+	var minSendShares float64
 
 	for _, desc := range chDescs {
-		channel := newChannel(mconn, *desc)
+		chDesc := *desc
+		if capacity, ok := config.SendQueueCapacities[chDesc.ID]; ok {
+			chDesc.SendQueueCapacity = capacity
+		}
+		channel := newChannel(mconn, chDesc)
 		channelsIdx[channel.desc.ID] = channel
 		channels = append(channels, channel)
+		minSendShares += channel.desc.MinSendShare
+	}
+	if minSendShares > 1 {
+		panic(fmt.Sprintf("Channels' minimum send shares add up to %v > 1", minSendShares))
 	}
 	mconn.channels = channels
 	mconn.channelsIdx = channelsIdx
@@ -450,6 +473,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(_n)
+			c.pingSent = time.Now()
 			c.Logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -464,6 +488,7 @@ FOR_LOOP:
 				err = errors.New("pong timeout")
 			} else {
 				c.stopPongTimer()
+				atomic.StoreInt64(&c.rtt, int64(time.Since(c.pingSent)))
 			}
 		case <-c.pong:
 			c.Logger.Debug("Send Pong")
@@ -478,6 +503,7 @@ FOR_LOOP:
 			break FOR_LOOP
 		case <-c.send:
 			// Send some PacketMsgs
+
 			eof := c.sendSomePacketMsgs()
 			if !eof {
 				// Keep sendRoutine awake.
@@ -509,7 +535,13 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 	// Block until .sendMonitor says we can write.
 	// Once we're ready we send more than we asked for,
 	// but amortized it should even out.
-	c.sendMonitor.Limit(c._maxPacketMsgSize, atomic.LoadInt64(&c.config.SendRate), true)
+	// The packets written so far are flushed before blocking, so that they
+	// don't wait for the next sample of the rate limiter.
+	sendRate := atomic.LoadInt64(&c.config.SendRate)
+	if c.sendMonitor.Limit(c._maxPacketMsgSize, sendRate, false) < c._maxPacketMsgSize {
+		c.flush()
+	}
+	c.sendMonitor.Limit(c._maxPacketMsgSize, sendRate, true)
 
 	// Now send some PacketMsgs.
 	for i := 0; i < numBatchPacketMsgs; i++ {
@@ -523,40 +555,91 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
-	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *Channel
-	for _, channel := range c.channels {
-		// If nothing to send, skip this channel
-		if !channel.isSendPending() {
-			continue
-		}
-		// Get ratio, and keep track of lowest ratio.
-		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
-		if ratio < leastRatio {
-			leastRatio = ratio
-			leastChannel = channel
-		}
+	// The channels, which haven't got their minimum share of the send budget
+	// in this flush interval, are chosen first. Otherwise, the chosen channel
+	// is the one with the least virtual start time.
+	c.updateSendInterval()
+	channel := c.reservedChannel()
+	if channel == nil {
+		channel = c.fairChannel()
 	}
 
 	// Nothing to send?
-	if leastChannel == nil {
+	if channel == nil {
 		return true
 	}
-	// c.Logger.Info("Found a msgPacket to send")
 
 	// Make & send a PacketMsg from this channel
-	_n, err := leastChannel.writePacketMsgTo(c.bufConnWriter)
+	start := math.Max(channel.vtime, c.vclock)
+	_n, err := channel.writePacketMsgTo(c.bufConnWriter)
 	if err != nil {
 		c.Logger.Error("Failed to write PacketMsg", "err", err)
 		c.stopForError(err)
 		return true
 	}
+	c.vclock = start
+	channel.vtime = start + float64(_n)/float64(channel.desc.Priority)
+	channel.intervalSent += int64(_n)
 	c.sendMonitor.Update(_n)
 	c.flushTimer.Set()
 	return false
 }
 
+// updateSendInterval starts the next flush interval, once the current one is
+// over.
+func (c *MConnection) updateSendInterval() {
+	if now := time.Now(); now.Sub(c.sendIntervalStart) >= c.config.FlushThrottle {
+		c.sendIntervalStart = now
+		for _, channel := range c.channels {
+			channel.intervalSent = 0
+		}
+	}
+}
+
+// reservedChannel returns the pending channel, which is the furthest from its
+// minimum share of the send budget of the flush interval, nil if all the
+// pending channels got their share.
+func (c *MConnection) reservedChannel() *Channel {
+	budget := float64(atomic.LoadInt64(&c.config.SendRate)) * c.config.FlushThrottle.Seconds()
+	if budget == 0 {
+		return nil
+	}
+
+	var leastRatio float64 = 1
+	var leastChannel *Channel
+	for _, channel := range c.channels {
+		if channel.desc.MinSendShare == 0 || !channel.isSendPending() {
+			continue
+		}
+		ratio := float64(channel.intervalSent) / (channel.desc.MinSendShare * budget)
+		if ratio < leastRatio {
+			leastRatio = ratio
+			leastChannel = channel
+		}
+	}
+	return leastChannel
+}
+
+// fairChannel returns the pending channel with the least virtual start time
+// of its next packet, nil if nothing is pending.
+func (c *MConnection) fairChannel() *Channel {
+	var leastStart float64 = math.MaxFloat64
+	var leastChannel *Channel
+	for _, channel := range c.channels {
+		// If nothing to send, skip this channel
+		if !channel.isSendPending() {
+			continue
+		}
+		// The idle channel doesn't save up the virtual time.
+		start := math.Max(channel.vtime, c.vclock)
+		if start < leastStart {
+			leastStart = start
+			leastChannel = channel
+		}
+	}
+	return leastChannel
+}
+
 // recvRoutine reads PacketMsgs and reconstructs the message using the channels' "recving" buffer.
 // After a whole message has been assembled, it's pushed to onReceive().
 // Blocks depending on how the connection is throttled.
@@ -688,6 +771,7 @@ func (c *MConnection) maxPacketMsgSize() int {
 
 type ConnectionStatus struct {
 	Duration    time.Duration
+	RTT         time.Duration // round-trip time of the latest ping, if any
 	SendMonitor flow.Status
 	RecvMonitor flow.Status
 	Channels    []ChannelStatus
@@ -697,6 +781,8 @@ type ChannelStatus struct {
 	ID                byte
 	SendQueueCapacity int
 	SendQueueSize     int
+	SendQueueBytes    int64
+	Dropped           int64
 	Priority          int
 	RecentlySent      int64
 }
@@ -704,6 +790,7 @@ type ChannelStatus struct {
 func (c *MConnection) Status() ConnectionStatus {
 	var status ConnectionStatus
 	status.Duration = time.Since(c.created)
+	status.RTT = time.Duration(atomic.LoadInt64(&c.rtt))
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
 	status.Channels = make([]ChannelStatus, len(c.channels))
@@ -712,6 +799,8 @@ func (c *MConnection) Status() ConnectionStatus {
 			ID:                channel.desc.ID,
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
+			SendQueueBytes:    atomic.LoadInt64(&channel.sendQueueBytes),
+			Dropped:           atomic.LoadInt64(&channel.dropped),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
 		}
@@ -727,6 +816,10 @@ type ChannelDescriptor struct {
 	SendQueueCapacity   int
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
+
+	// Minimum share of the send budget of each flush interval, i.e. SendRate *
+	// FlushThrottle, which is reserved for the channel, in [0, 1]
+	MinSendShare float64
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -754,6 +847,12 @@ type Channel struct {
 	sending       []byte
 	recentlySent  int64 // exponential moving average
 
+	sendQueueBytes int64 // atomic
+	dropped        int64 // atomic, number of messages not queued
+
+	vtime        float64 // virtual finish time of the latest packet sent
+	intervalSent int64   // bytes sent in the current flush interval
+
 	maxPacketMsgPayloadSize int
 
 	Logger log.Logger
@@ -764,6 +863,9 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	if desc.Priority <= 0 {
 		panic("Channel default priority must be a positive integer")
 	}
+	if desc.MinSendShare < 0 || desc.MinSendShare > 1 {
+		panic("Channel minimum send share must be in [0, 1]")
+	}
 	return &Channel{
 		conn:                    conn,
 		desc:                    desc,
@@ -784,8 +886,10 @@ func (ch *Channel) sendBytes(bytes []byte) bool {
 	select {
 	case ch.sendQueue <- bytes:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
+		atomic.AddInt64(&ch.sendQueueBytes, int64(len(bytes)))
 		return true
 	case <-time.After(defaultSendTimeout):
+		atomic.AddInt64(&ch.dropped, 1)
 		return false
 	}
 }
@@ -797,8 +901,10 @@ func (ch *Channel) trySendBytes(bytes []byte) bool {
 	select {
 	case ch.sendQueue <- bytes:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
+		atomic.AddInt64(&ch.sendQueueBytes, int64(len(bytes)))
 		return true
 	default:
+		atomic.AddInt64(&ch.dropped, 1)
 		return false
 	}
 }
@@ -833,6 +939,7 @@ func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
 	packet := tmp2p.PacketMsg{ChannelID: int32(ch.desc.ID)}
 	maxSize := ch.maxPacketMsgPayloadSize
 	packet.Data = ch.sending[:tmmath.MinInt(maxSize, len(ch.sending))]
+	atomic.AddInt64(&ch.sendQueueBytes, -int64(len(packet.Data)))
 	if len(ch.sending) <= maxSize {
 		packet.EOF = true
 		ch.sending = nil
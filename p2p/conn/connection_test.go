@@ -13,6 +13,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/protoio"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
 	"github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -362,6 +363,10 @@ func TestMConnectionPingPongs(t *testing.T) {
 	case <-time.After(2 * pongTimerExpired):
 		assert.True(t, mconn.IsRunning())
 	}
+	// the round-trip time of the pings is measured
+	rtt := mconn.Status().RTT
+	assert.Positive(t, int64(rtt))
+	assert.Less(t, int64(rtt), int64(mconn.config.PongTimeout))
 }
 
 func TestMConnectionStopsAndReturnsError(t *testing.T) {
@@ -564,6 +569,149 @@ func TestMConnectionTrySend(t *testing.T) {
 	assert.Equal(t, "TrySend", <-resultCh)
 }
 
+func TestMConnectionSendQueueStatus(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	cfg := DefaultMConnConfig()
+	cfg.SendQueueCapacities = map[byte]int{0x01: 3}
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, SendQueueCapacity: 1}}
+	mconn := NewMConnectionWithConfig(client, chDescs, func(byte, []byte) {}, func(interface{}) {}, cfg)
+	mconn.SetLogger(log.TestingLogger())
+	require.NoError(t, mconn.Start())
+	defer mconn.Stop() // nolint:errcheck // ignore for tests
+
+	// nothing is read from the server, so the queue fills up
+	var queued, dropped int
+	for i := 0; i < 10; i++ {
+		if mconn.TrySend(0x01, make([]byte, 2*defaultMaxPacketMsgPayloadSize)) {
+			queued++
+		} else {
+			dropped++
+		}
+	}
+	assert.GreaterOrEqual(t, queued, 3)
+	assert.Positive(t, dropped)
+
+	status := mconn.Status().Channels[0]
+	assert.Equal(t, 3, status.SendQueueCapacity)
+	assert.EqualValues(t, dropped, status.Dropped)
+	assert.Positive(t, status.SendQueueBytes)
+	assert.LessOrEqual(t, status.SendQueueBytes, int64(queued*2*defaultMaxPacketMsgPayloadSize))
+}
+
+// newChannelsTestMConnections makes a pair of connections with the channels,
+// sending from the client to the server, with the received bytes counted by
+// channel.
+func newChannelsTestMConnections(
+	t *testing.T,
+	chDescs []*ChannelDescriptor,
+	onReceive func(chID byte, msgBytes []byte),
+) (client, server *MConnection) {
+	serverConn, clientConn := NetPipe()
+	t.Cleanup(func() {
+		serverConn.Close()
+		clientConn.Close()
+	})
+
+	cfg := DefaultMConnConfig()
+	cfg.SendRate = 200000
+	cfg.FlushThrottle = 10 * time.Millisecond
+	onError := func(r interface{}) { t.Error(r) }
+	client = NewMConnectionWithConfig(clientConn, chDescs, func(byte, []byte) {}, onError, cfg)
+	client.SetLogger(log.TestingLogger())
+	server = NewMConnectionWithConfig(serverConn, chDescs, onReceive, onError, cfg)
+	server.SetLogger(log.TestingLogger())
+	require.NoError(t, client.Start())
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		client.Stop() // nolint:errcheck // ignore for tests
+		server.Stop() // nolint:errcheck // ignore for tests
+	})
+	return client, server
+}
+
+// flood keeps the channel's send queue full, until the test is done.
+func flood(t *testing.T, mconn *MConnection, chID byte, size int) {
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			mconn.Send(chID, make([]byte, size))
+		}
+	}()
+}
+
+// The channel gets its minimum share of the send budget, though its priority
+// is lower.
+func TestMConnectionMinSendShare(t *testing.T) {
+	var (
+		mtx      tmsync.Mutex
+		received = make(map[byte]int)
+	)
+	client, _ := newChannelsTestMConnections(t, []*ChannelDescriptor{
+		{ID: 0x01, Priority: 10, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, MinSendShare: 0.5},
+	}, func(chID byte, msgBytes []byte) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		received[chID] += len(msgBytes)
+	})
+
+	flood(t, client, 0x01, 1000)
+	flood(t, client, 0x02, 1000)
+	time.Sleep(time.Second)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	total := received[0x01] + received[0x02]
+	require.Positive(t, total)
+	assert.GreaterOrEqual(t, float64(received[0x02])/float64(total), 0.4, "received %v", received)
+}
+
+// The votes are delivered in time, though the mempool channel is flooded.
+func TestMConnectionVoteLatencyUnderMempoolFlood(t *testing.T) {
+	const (
+		mempoolChannel = byte(0x30)
+		voteChannel    = byte(0x22)
+		maxLatency     = 200 * time.Millisecond
+	)
+	votes := make(chan time.Time, 100)
+	client, _ := newChannelsTestMConnections(t, []*ChannelDescriptor{
+		{ID: mempoolChannel, Priority: 5, SendQueueCapacity: 1000},
+		{ID: voteChannel, Priority: 7, SendQueueCapacity: 100, MinSendShare: 0.2},
+	}, func(chID byte, msgBytes []byte) {
+		if chID == voteChannel {
+			votes <- time.Now()
+		}
+	})
+
+	flood(t, client, mempoolChannel, 10*defaultMaxPacketMsgPayloadSize)
+	time.Sleep(100 * time.Millisecond)
+
+	var latencies []time.Duration
+	for i := 0; i < 20; i++ {
+		sent := time.Now()
+		require.True(t, client.Send(voteChannel, make([]byte, 200)))
+		select {
+		case received := <-votes:
+			latencies = append(latencies, received.Sub(sent))
+		case <-time.After(time.Second):
+			t.Fatal("vote not received")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i, latency := range latencies {
+		assert.Less(t, int64(latency), int64(maxLatency), "vote #%d latency %v", i, latency)
+	}
+}
+
 // nolint:lll //ignore line length for tests
 func TestConnVectors(t *testing.T) {
 
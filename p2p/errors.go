@@ -19,7 +19,9 @@ type ErrRejected struct {
 	conn              net.Conn
 	err               error
 	id                ID
+	nodeInfo          NodeInfo
 	isAuthFailure     bool
+	isBanned          bool
 	isDuplicate       bool
 	isFiltered        bool
 	isIncompatible    bool
@@ -37,6 +39,10 @@ func (e ErrRejected) Error() string {
 		return fmt.Sprintf("auth failure: %s", e.err)
 	}
 
+	if e.isBanned {
+		return fmt.Sprintf("banned ID<%v>: %s", e.id, e.err)
+	}
+
 	if e.isDuplicate {
 		if e.conn != nil {
 			return fmt.Sprintf(
@@ -78,9 +84,22 @@ func (e ErrRejected) Error() string {
 	return fmt.Sprintf("%s", e.err)
 }
 
+// ID returns the ID of the rejected Peer, if known.
+func (e ErrRejected) ID() ID {
+	return e.id
+}
+
+// NodeInfo returns the NodeInfo of the incompatible Peer, nil otherwise.
+func (e ErrRejected) NodeInfo() NodeInfo {
+	return e.nodeInfo
+}
+
 // IsAuthFailure when Peer authentication was unsuccessful.
 func (e ErrRejected) IsAuthFailure() bool { return e.isAuthFailure }
 
+// IsBanned when Peer ID is in the ban list.
+func (e ErrRejected) IsBanned() bool { return e.isBanned }
+
 // IsDuplicate when Peer ID or IP are present already.
 func (e ErrRejected) IsDuplicate() bool { return e.isDuplicate }
 
@@ -23,8 +23,17 @@ type Metrics struct {
 	PeerSendBytesTotal metrics.Counter
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge
+	// Bytes queued to be sent to a given peer, by channel.
+	PeerQueuedSendBytes metrics.Gauge
+	// Number of messages to a given peer, which were dropped, because the
+	// send queue of the channel was full.
+	PeerDroppedSendMsgs metrics.Counter
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge
+	// Number of attempts to reconnect to a given persistent peer.
+	PeerReconnectAttempts metrics.Counter
+	// Round-trip time of the latest ping of a given peer.
+	PeerRTT metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -60,12 +69,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_pending_send_bytes",
 			Help:      "Number of pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerQueuedSendBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_queued_send_bytes",
+			Help:      "Number of bytes queued to be sent to a given peer, by channel.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		PeerDroppedSendMsgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_dropped_send_msgs",
+			Help:      "Number of messages to a given peer, dropped because the send queue was full.",
+		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "num_txs",
 			Help:      "Number of transactions submitted by each peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerReconnectAttempts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_reconnect_attempts_total",
+			Help:      "Number of attempts to reconnect to a given persistent peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerRTT: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_rtt_seconds",
+			Help:      "Round-trip time of the latest ping of a given peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
 	}
 }
 
@@ -76,6 +109,10 @@ func NopMetrics() *Metrics {
 		PeerReceiveBytesTotal: discard.NewCounter(),
 		PeerSendBytesTotal:    discard.NewCounter(),
 		PeerPendingSendBytes:  discard.NewGauge(),
+		PeerQueuedSendBytes:   discard.NewGauge(),
+		PeerDroppedSendMsgs:   discard.NewCounter(),
 		NumTxs:                discard.NewGauge(),
+		PeerReconnectAttempts: discard.NewCounter(),
+		PeerRTT:               discard.NewGauge(),
 	}
 }
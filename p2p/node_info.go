@@ -7,6 +7,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"reflect"
 
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
@@ -89,6 +90,12 @@ type DefaultNodeInfo struct {
 	// Node Type
 	ProTxHash *crypto.ProTxHash
 
+	// Proof of the masternode ownership: the signature of the node ID by the
+	// key of the masternode in the quorum. Empty, if the node isn't a
+	// masternode.
+	QuorumHash      tmbytes.HexBytes `json:"quorum_hash,omitempty"`
+	NodeIDSignature tmbytes.HexBytes `json:"node_id_signature,omitempty"`
+
 	// Check compatibility.
 	// Channels are HexBytes so easier to read as JSON
 	Network  string           `json:"network"`  // network/chain ID
@@ -160,6 +167,20 @@ func (info DefaultNodeInfo) Validate() error {
 		channels[ch] = struct{}{}
 	}
 
+	// Validate the proof of the masternode ownership.
+	if len(info.NodeIDSignature) > 0 || len(info.QuorumHash) > 0 {
+		if info.ProTxHash == nil {
+			return errors.New("info.NodeIDSignature is set without info.ProTxHash")
+		}
+		if len(info.QuorumHash) != crypto.QuorumHashSize {
+			return fmt.Errorf("info.QuorumHash must be %d bytes long, got %d", crypto.QuorumHashSize, len(info.QuorumHash))
+		}
+		if len(info.NodeIDSignature) != bls12381.SignatureSize {
+			return fmt.Errorf("info.NodeIDSignature must be %d bytes long, got %d",
+				bls12381.SignatureSize, len(info.NodeIDSignature))
+		}
+	}
+
 	// Validate Moniker.
 	if !tmstrings.IsASCIIText(info.Moniker) || tmstrings.ASCIITrim(info.Moniker) == "" {
 		return fmt.Errorf("info.Moniker must be valid non-empty ASCII text without tabs, but got %v", info.Moniker)
@@ -254,6 +275,8 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 	if info.ProTxHash != nil {
 		dni.ProTxHash = *info.ProTxHash
 	}
+	dni.QuorumHash = info.QuorumHash
+	dni.NodeIDSignature = info.NodeIDSignature
 	dni.Other = tmp2p.DefaultNodeInfoOther{
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
@@ -272,12 +295,14 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 			Block: pb.ProtocolVersion.Block,
 			App:   pb.ProtocolVersion.App,
 		},
-		DefaultNodeID: ID(pb.DefaultNodeID),
-		ListenAddr:    pb.ListenAddr,
-		Network:       pb.Network,
-		Version:       pb.Version,
-		Channels:      pb.Channels,
-		Moniker:       pb.Moniker,
+		DefaultNodeID:   ID(pb.DefaultNodeID),
+		ListenAddr:      pb.ListenAddr,
+		Network:         pb.Network,
+		Version:         pb.Version,
+		Channels:        pb.Channels,
+		Moniker:         pb.Moniker,
+		QuorumHash:      pb.QuorumHash,
+		NodeIDSignature: pb.NodeIDSignature,
 		Other: DefaultNodeInfoOther{
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
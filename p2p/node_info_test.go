@@ -3,6 +3,8 @@ package p2p
 import (
 	"testing"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +27,7 @@ func TestNodeInfoValidate(t *testing.T) {
 	nonASCII := "¢§µ"
 	emptyTab := "\t"
 	emptySpace := "  "
+	proTxHash := crypto.RandProTxHash()
 
 	testCases := []struct {
 		testName         string
@@ -64,6 +67,23 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Proof without ProTxHash", func(ni *DefaultNodeInfo) {
+			ni.QuorumHash, ni.NodeIDSignature = crypto.RandQuorumHash(), make([]byte, bls12381.SignatureSize)
+		}, true},
+		{"Short QuorumHash", func(ni *DefaultNodeInfo) {
+			ni.ProTxHash = &proTxHash
+			ni.QuorumHash, ni.NodeIDSignature = []byte{1}, make([]byte, bls12381.SignatureSize)
+		}, true},
+		{"Short NodeIDSignature", func(ni *DefaultNodeInfo) {
+			ni.ProTxHash = &proTxHash
+			ni.QuorumHash, ni.NodeIDSignature = crypto.RandQuorumHash(), []byte{1}
+		}, true},
+		{"Good proof", func(ni *DefaultNodeInfo) {
+			ni.ProTxHash = &proTxHash
+			ni.QuorumHash, ni.NodeIDSignature = crypto.RandQuorumHash(), make([]byte, bls12381.SignatureSize)
+		}, false},
+		{"ProTxHash without proof", func(ni *DefaultNodeInfo) { ni.ProTxHash = &proTxHash }, false},
 	}
 
 	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
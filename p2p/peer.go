@@ -5,6 +5,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/cmap"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
@@ -170,7 +171,7 @@ func (p *peer) String() string {
 	}
 
 	if proTxHash != nil {
-		return fmt.Sprintf("Peer{%sproTxHash:%v}", mConnString, proTxHash.ShortString())
+		return fmt.Sprintf("Peer{%sproTxHash:%v}", mConnString, proTxHash.ShortStringN(crypto.ProTxHashLogSize))
 	} else {
 		return fmt.Sprintf("Peer{%speerId:%v}", mConnString, p.ID())
 	}
@@ -271,6 +272,8 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	} else {
+		p.metrics.PeerDroppedSendMsgs.With("peer_id", string(p.ID()), "chID", fmt.Sprintf("%#x", chID)).Add(1)
 	}
 	return res
 }
@@ -290,6 +293,8 @@ func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
 			"chID", fmt.Sprintf("%#x", chID),
 		}
 		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	} else {
+		p.metrics.PeerDroppedSendMsgs.With("peer_id", string(p.ID()), "chID", fmt.Sprintf("%#x", chID)).Add(1)
 	}
 	return res
 }
@@ -367,9 +372,15 @@ func (p *peer) metricsReporter() {
 			var sendQueueSize float64
 			for _, chStatus := range status.Channels {
 				sendQueueSize += float64(chStatus.SendQueueSize)
+				p.metrics.PeerQueuedSendBytes.
+					With("peer_id", string(p.ID()), "chID", fmt.Sprintf("%#x", chStatus.ID)).
+					Set(float64(chStatus.SendQueueBytes))
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			if status.RTT > 0 {
+				p.metrics.PeerRTT.With("peer_id", string(p.ID())).Set(status.RTT.Seconds())
+			}
 		case <-p.Quit():
 			return
 		}
@@ -43,8 +43,14 @@ type AddrBook interface {
 
 	AddPrivateIDs([]string)
 
+	// Set our chain ID. The addresses of other chains are not gossiped or
+	// picked to dial.
+	SetChainID(chainID string)
+
 	// Add and remove an address
 	AddAddress(addr *p2p.NetAddress, src *p2p.NetAddress) error
+	// Add an address gossiped by the peer on the given chain
+	AddAddressWithChainID(addr *p2p.NetAddress, src *p2p.NetAddress, chainID string) error
 	RemoveAddress(*p2p.NetAddress)
 
 	// Check if the address is in the book
@@ -66,6 +72,11 @@ type AddrBook interface {
 	// Add bad peers back to addrBook
 	ReinstateBadPeers()
 
+	// Tag the address with the chain ID and the proTxHash of its node info
+	MarkNodeInfo(addr *p2p.NetAddress, chainID string, proTxHash crypto.ProTxHash)
+	// The proTxHash of the node info of the address, false if not seen yet
+	ProTxHash(*p2p.NetAddress) (crypto.ProTxHash, bool)
+
 	IsGood(*p2p.NetAddress) bool
 	IsBanned(*p2p.NetAddress) bool
 
@@ -90,6 +101,7 @@ type addrBook struct {
 	// accessed concurrently
 	mtx        tmsync.Mutex
 	rand       *tmrand.Rand
+	chainID    string
 	ourAddrs   map[string]struct{}
 	privateIDs map[p2p.ID]struct{}
 	addrLookup map[p2p.ID]*knownAddress // new & old
@@ -205,6 +217,16 @@ func (a *addrBook) AddPrivateIDs(ids []string) {
 	}
 }
 
+// SetChainID implements AddrBook. The addresses tagged with other chain IDs
+// are neither gossiped nor picked to dial, and the addresses without the
+// chain ID are only picked to dial.
+func (a *addrBook) SetChainID(chainID string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.chainID = chainID
+}
+
 // AddAddress implements AddrBook
 // Add address to a "new" bucket. If it's already in one, only add it probabilistically.
 // Returns error if the addr is non-routable. Does not add self.
@@ -213,7 +235,18 @@ func (a *addrBook) AddAddress(addr *p2p.NetAddress, src *p2p.NetAddress) error {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
-	return a.addAddress(addr, src)
+	return a.addAddress(addr, src, "")
+}
+
+// AddAddressWithChainID implements AddrBook. It adds the address like
+// AddAddress, tagged with the chain ID of the peer, which gossiped it.
+// Returns error if the chain ID is not ours, or the address is known to be on
+// another chain.
+func (a *addrBook) AddAddressWithChainID(addr *p2p.NetAddress, src *p2p.NetAddress, chainID string) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return a.addAddress(addr, src, chainID)
 }
 
 // RemoveAddress implements AddrBook - removes the address from the book.
@@ -224,6 +257,33 @@ func (a *addrBook) RemoveAddress(addr *p2p.NetAddress) {
 	a.removeAddress(addr)
 }
 
+// MarkNodeInfo implements AddrBook - it tags the address with the chain ID and
+// the proTxHash of the node info seen at the handshake.
+func (a *addrBook) MarkNodeInfo(addr *p2p.NetAddress, chainID string, proTxHash crypto.ProTxHash) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil {
+		return
+	}
+	ka.markNodeInfo(chainID, proTxHash)
+}
+
+// ProTxHash implements AddrBook - it returns the proTxHash of the node info
+// of the address, nil if the node isn't a masternode. It returns false, if the
+// node info isn't seen yet.
+func (a *addrBook) ProTxHash(addr *p2p.NetAddress) (crypto.ProTxHash, bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil || !ka.NodeInfoSeen {
+		return nil, false
+	}
+	return ka.ProTxHash, true
+}
+
 // IsGood returns true if peer was ever marked as good and haven't
 // done anything wrong since then.
 func (a *addrBook) IsGood(addr *p2p.NetAddress) bool {
@@ -305,14 +365,22 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 			bucket = a.bucketsNew[a.rand.Intn(len(a.bucketsNew))]
 		}
 	}
-	// pick a random index and loop over the map to return that index
+	// pick a random index and loop over the map to return that index, or
+	// the next address of our chain
 	randIndex := a.rand.Intn(len(bucket))
+	var first *knownAddress
 	for _, ka := range bucket {
-		if randIndex == 0 {
+		if randIndex <= 0 && a.isDialable(ka) {
 			return ka.Addr
 		}
+		if first == nil && a.isDialable(ka) {
+			first = ka
+		}
 		randIndex--
 	}
+	if first != nil {
+		return first.Addr
+	}
 	return nil
 }
 
@@ -399,19 +467,20 @@ func (a *addrBook) GetSelection() []*p2p.NetAddress {
 		return nil
 	}
 
-	numAddresses := tmmath.MaxInt(
-		tmmath.MinInt(minGetSelection, bookSize),
-		bookSize*getSelectionPercent/100)
-	numAddresses = tmmath.MinInt(maxGetSelection, numAddresses)
-
 	// XXX: instead of making a list of all addresses, shuffling, and slicing a random chunk,
 	// could we just select a random numAddresses of indexes?
-	allAddr := make([]*p2p.NetAddress, bookSize)
-	i := 0
+	allAddr := make([]*p2p.NetAddress, 0, bookSize)
 	for _, ka := range a.addrLookup {
-		allAddr[i] = ka.Addr
-		i++
+		if a.isGossipable(ka) {
+			allAddr = append(allAddr, ka.Addr)
+		}
 	}
+	bookSize = len(allAddr)
+
+	numAddresses := tmmath.MaxInt(
+		tmmath.MinInt(minGetSelection, bookSize),
+		bookSize*getSelectionPercent/100)
+	numAddresses = tmmath.MinInt(maxGetSelection, numAddresses)
 
 	// Fisher-Yates shuffle the array. We only need to do the first
 	// `numAddresses' since we are throwing the rest.
@@ -483,6 +552,17 @@ func (a *addrBook) size() int {
 	return a.nNew + a.nOld
 }
 
+// isGossipable returns true, if the address is known to be on our chain.
+func (a *addrBook) isGossipable(ka *knownAddress) bool {
+	return a.chainID == "" || ka.ChainID == a.chainID
+}
+
+// isDialable returns true, if the address is not known to be on another
+// chain.
+func (a *addrBook) isDialable(ka *knownAddress) bool {
+	return a.chainID == "" || ka.ChainID == "" || ka.ChainID == a.chainID
+}
+
 //----------------------------------------------------------
 
 // Save persists the address book to disk.
@@ -637,7 +717,7 @@ func (a *addrBook) pickOldest(bucketType byte, bucketIdx int) *knownAddress {
 
 // adds the address to a "new" bucket. if its already in one,
 // it only adds it probabilistically
-func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
+func (a *addrBook) addAddress(addr, src *p2p.NetAddress, chainID string) error {
 	if addr == nil || src == nil {
 		return ErrAddrBookNilAddr{addr, src}
 	}
@@ -667,8 +747,20 @@ func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
 		return ErrAddrBookNonRoutable{addr}
 	}
 
+	if a.chainID != "" && chainID != "" && chainID != a.chainID {
+		return ErrAddrBookOtherChain{addr, chainID}
+	}
+
 	ka := a.addrLookup[addr.ID]
 	if ka != nil {
+		// The chain ID of the node info takes precedence over the gossiped one.
+		if !a.isDialable(ka) {
+			return ErrAddrBookOtherChain{addr, ka.ChainID}
+		}
+		if ka.ChainID == "" {
+			ka.ChainID = chainID
+		}
+
 		// If its already old and the address ID's are the same, ignore it.
 		// Thereby avoiding issues with a node on the network attempting to change
 		// the IP of a known node ID. (Which could yield an eclipse attack on the node)
@@ -685,7 +777,7 @@ func (a *addrBook) addAddress(addr, src *p2p.NetAddress) error {
 			return nil
 		}
 	} else {
-		ka = newKnownAddress(addr, src)
+		ka = newKnownAddress(addr, src, chainID)
 	}
 
 	bucket, err := a.calcNewBucket(addr, src)
@@ -712,9 +804,12 @@ func (a *addrBook) randomPickAddresses(bucketType byte, num int) []*p2p.NetAddre
 	addresses := make([]*knownAddress, 0, total)
 	for _, bucket := range buckets {
 		for _, ka := range bucket {
-			addresses = append(addresses, ka)
+			if a.isGossipable(ka) {
+				addresses = append(addresses, ka)
+			}
 		}
 	}
+	total = len(addresses)
 	selection := make([]*p2p.NetAddress, 0, num)
 	chosenSet := make(map[string]bool, num)
 	rand.Shuffle(total, func(i, j int) {
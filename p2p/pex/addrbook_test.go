@@ -2,6 +2,7 @@ package pex
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -644,6 +646,119 @@ func TestAddrBookAddDoesNotOverwriteOldIP(t *testing.T) {
 	}
 }
 
+// The addresses of other chains are neither picked to dial nor gossiped.
+func TestAddrBookMixedChains(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	book.SetChainID("ours")
+
+	ours := make(map[p2p.ID]bool)
+	for _, addrSrc := range randNetAddressPairs(t, 10) {
+		require.NoError(t, book.AddAddressWithChainID(addrSrc.addr, addrSrc.src, "ours"))
+		ours[addrSrc.addr.ID] = true
+	}
+	// the addresses gossiped by the peers of other chains are refused
+	for _, addrSrc := range randNetAddressPairs(t, 10) {
+		err := book.AddAddressWithChainID(addrSrc.addr, addrSrc.src, "theirs")
+		assert.Equal(t, ErrAddrBookOtherChain{addrSrc.addr, "theirs"}, err)
+	}
+	// the node info of the address takes precedence over the gossiped chain ID
+	for _, addrSrc := range randNetAddressPairs(t, 30) {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+		book.MarkNodeInfo(addrSrc.addr, "theirs", nil)
+		if i := len(ours); i%2 == 0 {
+			book.MarkGood(addrSrc.addr.ID)
+		}
+		err := book.AddAddressWithChainID(addrSrc.addr, addrSrc.src, "ours")
+		assert.Equal(t, ErrAddrBookOtherChain{addrSrc.addr, "theirs"}, err)
+	}
+	// the addresses without the chain ID are dialed, but not gossiped
+	untagged := randIPv4Address(t)
+	require.NoError(t, book.AddAddress(untagged, untagged))
+	assert.Equal(t, 41, book.Size())
+
+	picked := make(map[p2p.ID]bool)
+	for i := 0; i < 1000; i++ {
+		if addr := book.PickAddress(50); addr != nil {
+			require.True(t, ours[addr.ID] || addr.ID == untagged.ID, "picked %v of another chain", addr)
+			picked[addr.ID] = true
+		}
+	}
+	assert.True(t, picked[untagged.ID])
+
+	for _, addr := range book.GetSelection() {
+		assert.True(t, ours[addr.ID], "gossiped %v", addr)
+	}
+	selection := book.GetSelectionWithBias(50)
+	assert.Len(t, selection, len(ours))
+	for _, addr := range selection {
+		assert.True(t, ours[addr.ID], "gossiped %v", addr)
+	}
+}
+
+// The address book without the version is upgraded on loading.
+func TestAddrBookUpgrade(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	addrSrc := randNetAddressPairs(t, 1)[0]
+	bz, err := json.Marshal(map[string]interface{}{
+		"key": "0123456789abcdef01234567",
+		"addrs": []map[string]interface{}{{
+			"addr":        addrSrc.addr,
+			"src":         addrSrc.src,
+			"buckets":     []int{0},
+			"bucket_type": bucketTypeNew,
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(fname, bz, 0644))
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	book.SetChainID("ours")
+	require.NoError(t, book.Start())
+	assert.Equal(t, 1, book.Size())
+	_, seen := book.ProTxHash(addrSrc.addr)
+	assert.False(t, seen)
+	assert.Equal(t, addrSrc.addr, book.PickAddress(100))
+	assert.Empty(t, book.GetSelection())
+
+	proTxHash := crypto.RandProTxHash()
+	book.MarkNodeInfo(addrSrc.addr, "ours", proTxHash)
+	require.NoError(t, book.Stop())
+	book.(*addrBook).Wait()
+
+	aJSON := &addrBookJSON{}
+	bz, err = ioutil.ReadFile(fname)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, aJSON))
+	assert.Equal(t, addrBookVersion, aJSON.Version)
+
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	book.SetChainID("ours")
+	require.NoError(t, book.Start())
+	loaded, seen := book.ProTxHash(addrSrc.addr)
+	assert.True(t, seen)
+	assert.Equal(t, proTxHash, loaded)
+	assert.Equal(t, []*p2p.NetAddress{addrSrc.addr}, book.GetSelection())
+	require.NoError(t, book.Stop())
+	book.(*addrBook).Wait()
+
+	// the future versions aren't loaded
+	aJSON.Version = addrBookVersion + 1
+	bz, err = json.Marshal(aJSON)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(fname, bz, 0644))
+	book = NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	assert.Panics(t, func() { book.(*addrBook).loadFromFile(fname) })
+}
+
 func TestAddrBookGroupKey(t *testing.T) {
 	// non-strict routability
 	testCases := []struct {
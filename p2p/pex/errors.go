@@ -26,6 +26,15 @@ func (err errAddrBookOldAddressNewBucket) Error() string {
 		err.Addr, err.BucketID)
 }
 
+type ErrAddrBookOtherChain struct {
+	Addr    *p2p.NetAddress
+	ChainID string
+}
+
+func (err ErrAddrBookOtherChain) Error() string {
+	return fmt.Sprintf("Cannot add address %v of another chain %v", err.Addr, err.ChainID)
+}
+
 type ErrAddrBookSelf struct {
 	Addr *p2p.NetAddress
 }
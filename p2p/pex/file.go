@@ -10,9 +10,17 @@ import (
 
 /* Loading & Saving */
 
+// addrBookVersion is the version of the address book file. The address book
+// of an older version is upgraded on loading.
+//
+// 0: the address book without the version
+// 1: the chain IDs and the proTxHashes of the addresses
+const addrBookVersion = 1
+
 type addrBookJSON struct {
-	Key   string          `json:"key"`
-	Addrs []*knownAddress `json:"addrs"`
+	Version int             `json:"version"`
+	Key     string          `json:"key"`
+	Addrs   []*knownAddress `json:"addrs"`
 }
 
 func (a *addrBook) saveToFile(filePath string) {
@@ -26,8 +34,9 @@ func (a *addrBook) saveToFile(filePath string) {
 		addrs = append(addrs, ka)
 	}
 	aJSON := &addrBookJSON{
-		Key:   a.key,
-		Addrs: addrs,
+		Version: addrBookVersion,
+		Key:     a.key,
+		Addrs:   addrs,
 	}
 
 	jsonBytes, err := json.MarshalIndent(aJSON, "", "\t")
@@ -62,6 +71,9 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 	if err != nil {
 		panic(fmt.Sprintf("Error reading file %s: %v", filePath, err))
 	}
+	if err := a.upgradeAddrBookJSON(aJSON); err != nil {
+		panic(fmt.Sprintf("Error upgrading file %s: %v", filePath, err))
+	}
 
 	// Restore all the fields...
 	// Restore the key
@@ -81,3 +93,26 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 	}
 	return true
 }
+
+// upgradeAddrBookJSON upgrades the loaded address book of an older version to
+// the current one, step by step.
+func (a *addrBook) upgradeAddrBookJSON(aJSON *addrBookJSON) error {
+	if aJSON.Version > addrBookVersion {
+		return fmt.Errorf("unsupported version %d, expected up to %d", aJSON.Version, addrBookVersion)
+	}
+	for aJSON.Version < addrBookVersion {
+		a.Logger.Info("Upgrading AddrBook", "from", aJSON.Version, "to", aJSON.Version+1)
+		switch aJSON.Version {
+		case 0:
+			// The chain IDs of the addresses are unknown. They are dialed, but
+			// not gossiped, until their node infos are seen at the handshakes.
+			for _, ka := range aJSON.Addrs {
+				ka.ChainID = ""
+				ka.ProTxHash = nil
+				ka.NodeInfoSeen = false
+			}
+		}
+		aJSON.Version++
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package pex
 import (
 	"time"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/p2p"
 )
 
@@ -17,12 +18,19 @@ type knownAddress struct {
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
 	LastBanTime time.Time       `json:"last_ban_time"`
+
+	// The chain ID of the node info of the address, or of the peer, which
+	// gossiped it, until the node info is seen at the handshake.
+	ChainID      string           `json:"chain_id,omitempty"`
+	ProTxHash    crypto.ProTxHash `json:"pro_tx_hash,omitempty"`
+	NodeInfoSeen bool             `json:"node_info_seen,omitempty"`
 }
 
-func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
+func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress, chainID string) *knownAddress {
 	return &knownAddress{
 		Addr:        addr,
 		Src:         src,
+		ChainID:     chainID,
 		Attempts:    0,
 		LastAttempt: time.Now(),
 		BucketType:  bucketTypeNew,
@@ -42,6 +50,12 @@ func (ka *knownAddress) isNew() bool {
 	return ka.BucketType == bucketTypeNew
 }
 
+func (ka *knownAddress) markNodeInfo(chainID string, proTxHash crypto.ProTxHash) {
+	ka.ChainID = chainID
+	ka.ProTxHash = proTxHash
+	ka.NodeInfoSeen = true
+}
+
 func (ka *knownAddress) markAttempt() {
 	now := time.Now()
 	ka.LastAttempt = now
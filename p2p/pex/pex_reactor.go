@@ -8,6 +8,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/cmap"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -122,6 +123,18 @@ type ReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// Only keep the outbound connections to the peers, which advertise the
+	// proTxHash of a masternode in the Masternodes list. The persistent peers
+	// are kept anyway.
+	OnlyMasternodePeers bool
+	Masternodes         MasternodeList
+}
+
+// MasternodeList is the current masternode list.
+type MasternodeList interface {
+	// HasMasternode returns true, if the proTxHash belongs to a masternode.
+	HasMasternode(proTxHash crypto.ProTxHash) (bool, error)
 }
 
 type _attemptsToDial struct {
@@ -145,10 +158,15 @@ func NewReactor(b AddrBook, config *ReactorConfig) *Reactor {
 
 // OnStart implements BaseService
 func (r *Reactor) OnStart() error {
+	if r.config.OnlyMasternodePeers && r.config.Masternodes == nil {
+		return errors.New("only masternode peers require the masternode list")
+	}
+
 	err := r.book.Start()
 	if err != nil && err != service.ErrAlreadyStarted {
 		return err
 	}
+	r.book.SetChainID(nodeChainID(r.Switch.NodeInfo()))
 
 	numOnline, seedAddrs, err := r.checkSeeds()
 	if err != nil {
@@ -189,11 +207,21 @@ func (r *Reactor) GetChannels() []*conn.ChannelDescriptor {
 }
 
 // AddPeer implements Reactor by adding peer to the address book (if inbound)
-// or by requesting more addresses (if outbound).
+// or by requesting more addresses (if outbound). The address is tagged with
+// the chain ID and the proTxHash of the peer's node info.
 func (r *Reactor) AddPeer(p Peer) {
 	if p.IsOutbound() {
 		// For outbound peers, the address is already in the books -
 		// either via DialPeersAsync or r.Receive.
+		proTxHash := nodeProTxHash(p.NodeInfo())
+		r.book.MarkNodeInfo(p.SocketAddr(), nodeChainID(p.NodeInfo()), proTxHash)
+		if r.config.OnlyMasternodePeers && !p.IsPersistent() && !r.isMasternode(proTxHash) {
+			r.Logger.Info("Disconnecting from the peer, which is not a masternode", "peer", p)
+			// In a go-routine so the other reactors add the peer first.
+			go r.Switch.StopPeerGracefully(p)
+			return
+		}
+
 		// Ask it for more peers if we need.
 		if r.book.NeedMoreAddrs() {
 			r.RequestAddrs(p)
@@ -213,6 +241,7 @@ func (r *Reactor) AddPeer(p Peer) {
 		// we don't trust inbound as much - let ensurePeersRoutine handle it.
 		err = r.book.AddAddress(addr, src)
 		r.logErrAddrBook(err)
+		r.book.MarkNodeInfo(addr, nodeChainID(p.NodeInfo()), nodeProTxHash(p.NodeInfo()))
 	}
 }
 
@@ -374,9 +403,11 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 		}
 	}
 
+	// the addresses are tagged with the chain ID of the peer
+	srcChainID := nodeChainID(src.NodeInfo())
 	for _, netAddr := range addrs {
 		// NOTE: we check netAddr validity and routability in book#AddAddress.
-		err = r.book.AddAddress(netAddr, srcAddr)
+		err = r.book.AddAddressWithChainID(netAddr, srcAddr, srcChainID)
 		if err != nil {
 			r.logErrAddrBook(err)
 			// XXX: should we be strict about incoming data and disconnect from a
@@ -488,6 +519,11 @@ func (r *Reactor) ensurePeers() {
 		if r.Switch.IsDialingOrExistingAddress(try) {
 			continue
 		}
+		if r.config.OnlyMasternodePeers {
+			if proTxHash, seen := r.book.ProTxHash(try); seen && !r.isMasternode(proTxHash) {
+				continue
+			}
+		}
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialling again, or have dialed too many times already
@@ -587,6 +623,20 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 	return nil
 }
 
+// isMasternode returns true, if the proTxHash belongs to a masternode in the
+// current masternode list.
+func (r *Reactor) isMasternode(proTxHash crypto.ProTxHash) bool {
+	if len(proTxHash) == 0 {
+		return false
+	}
+	ok, err := r.config.Masternodes.HasMasternode(proTxHash)
+	if err != nil {
+		r.Logger.Error("Failed to look up the masternode", "proTxHash", proTxHash, "err", err)
+		return false
+	}
+	return ok
+}
+
 // maxBackoffDurationForPeer caps the backoff duration for persistent peers.
 func (r *Reactor) maxBackoffDurationForPeer(addr *p2p.NetAddress, planned time.Duration) time.Duration {
 	if r.config.PersistentPeersMaxDialPeriod > 0 &&
@@ -760,14 +810,37 @@ func (r *Reactor) attemptDisconnects() {
 
 func markAddrInBookBasedOnErr(addr *p2p.NetAddress, book AddrBook, err error) {
 	// TODO: detect more "bad peer" scenarios
-	switch err.(type) {
+	switch err := err.(type) {
 	case p2p.ErrSwitchAuthenticationFailure:
 		book.MarkBad(addr, defaultBanTime)
+	case p2p.ErrRejected:
+		// the incompatible node may be on another chain
+		if nodeInfo := err.NodeInfo(); nodeInfo != nil {
+			book.MarkNodeInfo(addr, nodeChainID(nodeInfo), nodeProTxHash(nodeInfo))
+		}
+		book.MarkAttempt(addr)
 	default:
 		book.MarkAttempt(addr)
 	}
 }
 
+// nodeChainID returns the chain ID of the node info, "" if unknown.
+func nodeChainID(nodeInfo p2p.NodeInfo) string {
+	if nodeInfo, ok := nodeInfo.(p2p.DefaultNodeInfo); ok {
+		return nodeInfo.Network
+	}
+	return ""
+}
+
+// nodeProTxHash returns the proTxHash of the node info, nil if the node isn't
+// a masternode.
+func nodeProTxHash(nodeInfo p2p.NodeInfo) crypto.ProTxHash {
+	if proTxHash := nodeInfo.GetProTxHash(); proTxHash != nil {
+		return *proTxHash
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // Messages
 
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,7 +15,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/mock"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
@@ -531,6 +535,139 @@ func TestPEXReactorDialPeer(t *testing.T) {
 	}
 }
 
+// The addresses of other chains are not dialed.
+func TestPEXReactorDoesNotDialOtherChains(t *testing.T) {
+	r, book := createReactor(&ReactorConfig{})
+	defer teardownReactor(book)
+	book.(*addrBook).routabilityStrict = false
+
+	sw := createSwitchAndAddReactors(r)
+	sw.SetAddrBook(book)
+	book.SetChainID(nodeChainID(sw.NodeInfo()))
+
+	var (
+		src   = newNodeInfoPeer(nodeChainID(sw.NodeInfo()), nil)
+		other = newNodeInfoPeer("other", nil)
+		ours  []*p2p.NetAddress
+		their []*p2p.NetAddress
+	)
+	for i := 0; i < 5; i++ {
+		ours = append(ours, newClosedNetAddress(t))
+		their = append(their, newClosedNetAddress(t))
+	}
+	r.RequestAddrs(src)
+	require.NoError(t, r.ReceiveAddrs(ours, src))
+	// the addresses gossiped by the peer of another chain are refused
+	r.RequestAddrs(other)
+	require.NoError(t, r.ReceiveAddrs(their, other))
+	assert.Equal(t, len(ours), book.Size())
+	// the addresses known before are tagged, once their node info is seen
+	for _, addr := range their {
+		require.NoError(t, book.AddAddress(addr, src.SocketAddr()))
+		book.MarkNodeInfo(addr, "other", nil)
+	}
+	assert.Equal(t, len(ours)+len(their), book.Size())
+
+	attempted := func() bool {
+		for _, addr := range ours {
+			if r.AttemptsToDial(addr) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	require.Eventually(t, func() bool {
+		r.ensurePeers()
+		return attempted()
+	}, 5*time.Second, 100*time.Millisecond)
+	for _, addr := range their {
+		assert.Equal(t, 0, r.AttemptsToDial(addr), "dialed %v of another chain", addr)
+	}
+	for _, addr := range book.GetSelection() {
+		assert.NotContains(t, their, addr)
+	}
+}
+
+func TestPEXReactorOnlyMasternodePeers(t *testing.T) {
+	masternode := newNodeInfoPeer("", crypto.RandProTxHash())
+	masternodes := masternodeMap{masternode.proTxHash.String(): true}
+	r, book := createReactor(&ReactorConfig{OnlyMasternodePeers: true, Masternodes: masternodes})
+	defer teardownReactor(book)
+	book.(*addrBook).routabilityStrict = false
+
+	sw := createSwitchAndAddReactors(r)
+	sw.SetAddrBook(book)
+	book.SetChainID(nodeChainID(sw.NodeInfo()))
+
+	masternode.network = nodeChainID(sw.NodeInfo())
+	masternode.Outbound = true
+	fullNode := newNodeInfoPeer(nodeChainID(sw.NodeInfo()), crypto.RandProTxHash())
+	fullNode.Outbound = true
+	for _, peer := range []*nodeInfoPeer{masternode, fullNode} {
+		require.NoError(t, book.AddAddress(peer.SocketAddr(), peer.SocketAddr()))
+		r.AddPeer(peer)
+	}
+
+	// the outbound peer, which isn't a masternode, is disconnected
+	require.Eventually(t, func() bool { return !fullNode.IsRunning() }, time.Second, 10*time.Millisecond)
+	assert.True(t, masternode.IsRunning())
+	proTxHash, seen := book.ProTxHash(fullNode.SocketAddr())
+	assert.True(t, seen)
+	assert.Equal(t, fullNode.proTxHash, proTxHash)
+
+	// and isn't dialed again
+	book.RemoveAddress(masternode.SocketAddr())
+	for i := 0; i < 10; i++ {
+		r.ensurePeers()
+	}
+	assert.Equal(t, 0, r.AttemptsToDial(fullNode.SocketAddr()))
+
+	// the masternode list is required
+	r2, book2 := createReactor(&ReactorConfig{OnlyMasternodePeers: true})
+	defer teardownReactor(book2)
+	createSwitchAndAddReactors(r2)
+	assert.Error(t, r2.Start())
+}
+
+// nodeInfoPeer is a mock peer with the chain ID and the proTxHash in its node
+// info
+type nodeInfoPeer struct {
+	*mock.Peer
+	network   string
+	proTxHash crypto.ProTxHash
+}
+
+func newNodeInfoPeer(network string, proTxHash crypto.ProTxHash) *nodeInfoPeer {
+	return &nodeInfoPeer{Peer: mock.NewPeer(net.IPv4(127, 0, 0, 1)), network: network, proTxHash: proTxHash}
+}
+
+func (p *nodeInfoPeer) NodeInfo() p2p.NodeInfo {
+	nodeInfo := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	nodeInfo.Network = p.network
+	if p.proTxHash != nil {
+		nodeInfo.ProTxHash = &p.proTxHash
+	}
+	return nodeInfo
+}
+
+// masternodeMap is a MasternodeList of the given proTxHashes
+type masternodeMap map[string]bool
+
+func (m masternodeMap) HasMasternode(proTxHash crypto.ProTxHash) (bool, error) {
+	return m[proTxHash.String()], nil
+}
+
+// newClosedNetAddress returns the local address with a random ID and a port,
+// which isn't listened on.
+func newClosedNetAddress(t *testing.T) *p2p.NetAddress {
+	port, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	nodeKey := p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), fmt.Sprintf("127.0.0.1:%d", port)))
+	require.NoError(t, err)
+	return addr
+}
+
 func assertPeersWithTimeout(
 	t *testing.T,
 	switches []*p2p.Switch,
@@ -2,7 +2,6 @@ package p2p
 
 import (
 	"fmt"
-	"math"
 	"sync"
 	"time"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/tendermint/tendermint/libs/cmap"
 	"github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
 )
 
@@ -18,15 +18,11 @@ const (
 	// before dialing peers or reconnecting to help prevent DoS
 	dialRandomizerIntervalMilliseconds = 3000
 
-	// repeatedly try to reconnect for a few minutes
-	// ie. 5 * 20 = 100s
-	reconnectAttempts = 20
-	reconnectInterval = 5 * time.Second
-
-	// then move into exponential backoff mode for ~1day
-	// ie. 3**10 = 16hrs
-	reconnectBackOffAttempts    = 10
-	reconnectBackOffBaseSeconds = 3
+	// the pause after the first failed attempt to reconnect to a persistent
+	// peer, which is doubled after each next one
+	reconnectBackoffBase = time.Second
+	// the interval to check for the reconnections due, if none is scheduled
+	reconnectCheckInterval = time.Minute
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -37,6 +33,10 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.SendRate = cfg.SendRate
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
+	// the capacities are validated with the config
+	if capacities, err := cfg.ChannelSendQueueCapacities(); err == nil {
+		mConfig.SendQueueCapacities = capacities
+	}
 	return mConfig
 }
 
@@ -70,17 +70,23 @@ type Switch struct {
 
 	config       *config.P2PConfig
 	reactors     map[string]Reactor
+	reactorNames []string // in the order the reactors were added
 	chDescs      []*conn.ChannelDescriptor
 	reactorsByCh map[byte]Reactor
 	peers        *PeerSet
 	dialing      *cmap.CMap
-	reconnecting *cmap.CMap
 	nodeInfo     NodeInfo // our node info
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
+	banList              *BanList
+
+	// the reconnections to the persistent peers
+	reconnectMtx tmsync.Mutex
+	reconnects   map[ID]*reconnectState
+	reconnectCh  chan struct{} // wakes up the reconnectRoutine
 
 	transport Transport
 
@@ -114,12 +120,14 @@ func NewSwitch(
 		reactorsByCh:         make(map[byte]Reactor),
 		peers:                NewPeerSet(),
 		dialing:              cmap.NewCMap(),
-		reconnecting:         cmap.NewCMap(),
+		reconnects:           make(map[ID]*reconnectState),
+		reconnectCh:          make(chan struct{}, 1),
 		metrics:              NopMetrics(),
 		transport:            transport,
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
+		banList:              NewBanList(),
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -144,6 +152,12 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchBanList sets the list of the banned peers, e.g. the one loaded from
+// the file.
+func SwitchBanList(banList *BanList) SwitchOption {
+	return func(sw *Switch) { sw.banList = banList }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -164,6 +178,9 @@ func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 		sw.chDescs = append(sw.chDescs, chDesc)
 		sw.reactorsByCh[chID] = reactor
 	}
+	if _, ok := sw.reactors[name]; !ok {
+		sw.reactorNames = append(sw.reactorNames, name)
+	}
 	sw.reactors[name] = reactor
 	reactor.SetSwitch(sw)
 	return reactor
@@ -183,6 +200,12 @@ func (sw *Switch) RemoveReactor(name string, reactor Reactor) {
 		delete(sw.reactorsByCh, chDesc.ID)
 	}
 	delete(sw.reactors, name)
+	for i, n := range sw.reactorNames {
+		if n == name {
+			sw.reactorNames = append(sw.reactorNames[:i], sw.reactorNames[i+1:]...)
+			break
+		}
+	}
 	reactor.SetSwitch(nil)
 }
 
@@ -219,10 +242,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 //---------------------------------------------------------------------
 // Service start/stop
 
-// OnStart implements BaseService. It starts all the reactors and peers.
+// OnStart implements BaseService. It starts all the reactors, in the order
+// they were added, and peers.
 func (sw *Switch) OnStart() error {
 	// Start reactors
-	for _, reactor := range sw.reactors {
+	for _, name := range sw.reactorNames {
+		reactor := sw.reactors[name]
 		err := reactor.Start()
 		if err != nil {
 			return fmt.Errorf("failed to start %v: %w", reactor, err)
@@ -231,11 +256,15 @@ func (sw *Switch) OnStart() error {
 
 	// Start accepting Peers.
 	go sw.acceptRoutine()
+	go sw.reconnectRoutine()
 
 	return nil
 }
 
-// OnStop implements BaseService. It stops all peers and reactors.
+// OnStop implements BaseService. It stops all peers and reactors. The
+// reactors are stopped in the reverse order they were added, so a reactor
+// added after the ones it depends on is stopped before them. The reactors
+// stopped already, e.g. by the node, are skipped.
 func (sw *Switch) OnStop() {
 	// Stop peers
 	for _, p := range sw.peers.List() {
@@ -244,7 +273,11 @@ func (sw *Switch) OnStop() {
 
 	// Stop reactors
 	sw.Logger.Debug("Switch: Stopping reactors")
-	for _, reactor := range sw.reactors {
+	for i := len(sw.reactorNames) - 1; i >= 0; i-- {
+		reactor := sw.reactors[sw.reactorNames[i]]
+		if !reactor.IsRunning() {
+			continue
+		}
 		if err := reactor.Stop(); err != nil {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
@@ -346,6 +379,64 @@ func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	}
 }
 
+// StopPeerForMisbehavior disconnects from a misbehaving peer, and bans it for
+// the configured ban duration.
+func (sw *Switch) StopPeerForMisbehavior(peer Peer, reason interface{}) {
+	sw.banForMisbehavior(peer.ID(), reason)
+	sw.StopPeerForError(peer, reason)
+}
+
+// banForMisbehavior bans the peer for the configured ban duration, if any.
+func (sw *Switch) banForMisbehavior(id ID, reason interface{}) {
+	if sw.config.BanDuration <= 0 {
+		return
+	}
+	ban, err := sw.banList.Ban(id, fmt.Sprintf("%v", reason), sw.config.BanDuration)
+	if err != nil {
+		sw.Logger.Error("Failed to ban peer", "peer", id, "err", err)
+	}
+	if !ban.Until.IsZero() {
+		sw.Logger.Info("Banned misbehaving peer", "peer", id, "reason", ban.Reason, "until", ban.Until)
+	}
+}
+
+// BanPeer bans the peer for the duration, and disconnects from it, if
+// connected. The peer is refused on accept and on dial, until the ban expires.
+func (sw *Switch) BanPeer(id ID, reason string, duration time.Duration) error {
+	ban, err := sw.banList.Ban(id, reason, duration)
+	if ban.Until.IsZero() {
+		return err
+	}
+	if err != nil {
+		// the peer is banned anyway, until the restart
+		sw.Logger.Error("Failed to save the ban list", "err", err)
+	}
+	sw.Logger.Info("Banned peer", "peer", id, "reason", reason, "until", ban.Until)
+
+	if peer := sw.peers.Get(id); peer != nil {
+		sw.StopPeerForError(peer, fmt.Errorf("banned: %s", reason))
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the peer.
+func (sw *Switch) UnbanPeer(id ID) error {
+	unbanned, err := sw.banList.Unban(id)
+	if err != nil {
+		return err
+	}
+	if !unbanned {
+		return fmt.Errorf("peer %v isn't banned", id)
+	}
+	sw.Logger.Info("Unbanned peer", "peer", id)
+	return nil
+}
+
+// BannedPeers returns the banned peers.
+func (sw *Switch) BannedPeers() []BannedPeer {
+	return sw.banList.List()
+}
+
 // StopPeerGracefully disconnects from a peer gracefully.
 // TODO: handle graceful disconnects.
 func (sw *Switch) StopPeerGracefully(peer Peer) {
@@ -372,61 +463,153 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	}
 }
 
-// reconnectToPeer tries to reconnect to the addr, first repeatedly
-// with a fixed interval, then with exponential backoff.
-// If no success after all that, it stops trying, and leaves it
-// to the PEX/Addrbook to find the peer with the addr again
+// reconnectState is the state of the reconnection to a persistent peer.
+type reconnectState struct {
+	addr     *NetAddress
+	attempts int       // failed attempts since the latest successful handshake
+	active   bool      // true, while reconnecting
+	dialing  bool      // true, while an attempt is in progress
+	nextDial time.Time // time of the next attempt
+}
+
+// reconnectToPeer schedules the reconnection to the addr. The peer is
+// redialed by the reconnectRoutine with exponential backoff, capped at
+// persistent_peers_reconnect_max_backoff, until the handshake with it
+// succeeds. The backoff is kept across the reconnections, and it's reset
+// after a successful handshake.
 // NOTE: this will keep trying even if the handshake or auth fails.
-// TODO: be more explicit with error types so we only retry on certain failures
-//  - ie. if we're getting ErrDuplicatePeer we can stop
-//  	because the addrbook got us the peer back already
 func (sw *Switch) reconnectToPeer(addr *NetAddress) {
-	if sw.reconnecting.Has(string(addr.ID)) {
+	sw.reconnectMtx.Lock()
+	state, ok := sw.reconnects[addr.ID]
+	if !ok {
+		state = &reconnectState{}
+		sw.reconnects[addr.ID] = state
+	}
+	if state.active {
+		sw.reconnectMtx.Unlock()
 		return
 	}
-	sw.reconnecting.Set(string(addr.ID), addr)
-	defer sw.reconnecting.Delete(string(addr.ID))
+	state.addr = addr
+	state.active = true
+	state.nextDial = time.Now().Add(sw.reconnectBackoff(state.attempts))
+	attempts := state.attempts
+	sw.reconnectMtx.Unlock()
+
+	sw.Logger.Info("Reconnecting to peer", "addr", addr, "failedAttempts", attempts)
+	sw.wakeReconnectRoutine()
+}
 
-	start := time.Now()
-	sw.Logger.Info("Reconnecting to peer", "addr", addr)
-	for i := 0; i < reconnectAttempts; i++ {
-		if !sw.IsRunning() {
-			return
-		}
+// reconnectBackoff returns the pause before the attempt to reconnect to a
+// persistent peer after the given number of failed ones: none before the
+// first attempt, then exponentially growing from reconnectBackoffBase up to
+// persistent_peers_reconnect_max_backoff, with a random jitter.
+func (sw *Switch) reconnectBackoff(attempts int) time.Duration {
+	if attempts == 0 {
+		return 0
+	}
+	backoff := sw.config.PersistentPeersReconnectMaxBackoff
+	if attempts <= 30 && (backoff == 0 || reconnectBackoffBase<<(attempts-1) < backoff) {
+		backoff = reconnectBackoffBase << (attempts - 1)
+	}
+	if jitter := sw.config.PersistentPeersReconnectJitter; jitter > 0 {
+		backoff += time.Duration(sw.rng.Int63n(int64(jitter)))
+	}
+	return backoff
+}
 
-		err := sw.DialPeerWithAddress(addr)
-		if err == nil {
-			return // success
-		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
-			return
-		}
+// resetReconnect resets the backoff of the peer after a successful handshake,
+// and stops reconnecting to it.
+func (sw *Switch) resetReconnect(id ID) {
+	sw.reconnectMtx.Lock()
+	defer sw.reconnectMtx.Unlock()
+	if state, ok := sw.reconnects[id]; ok {
+		state.attempts = 0
+		state.active = false
+	}
+}
 
-		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
-		// sleep a set amount
-		sw.randomSleep(reconnectInterval)
-		continue
+func (sw *Switch) wakeReconnectRoutine() {
+	select {
+	case sw.reconnectCh <- struct{}{}:
+	default:
 	}
+}
 
-	sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
-		"addr", addr, "elapsed", time.Since(start))
-	for i := 0; i < reconnectBackOffAttempts; i++ {
-		if !sw.IsRunning() {
+// reconnectRoutine dials the persistent peers, which are due to be
+// reconnected.
+func (sw *Switch) reconnectRoutine() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-sw.reconnectCh:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-sw.Quit():
 			return
 		}
+		timer.Reset(sw.dialDueReconnects())
+	}
+}
 
-		// sleep an exponentially increasing amount
-		sleepIntervalSeconds := math.Pow(reconnectBackOffBaseSeconds, float64(i))
-		sw.randomSleep(time.Duration(sleepIntervalSeconds) * time.Second)
+// dialDueReconnects starts the attempts to reconnect, which are due, and
+// returns the time until the next one is.
+func (sw *Switch) dialDueReconnects() time.Duration {
+	sw.reconnectMtx.Lock()
+	defer sw.reconnectMtx.Unlock()
 
-		err := sw.DialPeerWithAddress(addr)
-		if err == nil {
-			return // success
-		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
-			return
+	now := time.Now()
+	wait := reconnectCheckInterval
+	for id, state := range sw.reconnects {
+		if !state.active || state.dialing {
+			continue
 		}
-		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
+		if until := state.nextDial.Sub(now); until > 0 {
+			if until < wait {
+				wait = until
+			}
+			continue
+		}
+		state.dialing = true
+		sw.metrics.PeerReconnectAttempts.With("peer_id", string(id)).Add(1)
+		go sw.dialReconnect(state.addr)
 	}
-	sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "elapsed", time.Since(start))
+	return wait
+}
+
+// dialReconnect makes an attempt to reconnect to the addr, and schedules the
+// next one, if it fails.
+func (sw *Switch) dialReconnect(addr *NetAddress) {
+	err := sw.DialPeerWithAddress(addr)
+	if !sw.IsRunning() {
+		return
+	}
+
+	sw.reconnectMtx.Lock()
+	state := sw.reconnects[addr.ID]
+	state.dialing = false
+	if !state.active {
+		// the peer is connected already
+		sw.reconnectMtx.Unlock()
+		return
+	}
+	switch err.(type) {
+	case nil, ErrCurrentlyDialingOrExistingAddress:
+		state.active = false
+	default:
+		state.attempts++
+		state.nextDial = time.Now().Add(sw.reconnectBackoff(state.attempts))
+	}
+	attempts, nextDial := state.attempts, state.nextDial
+	sw.reconnectMtx.Unlock()
+
+	if err != nil {
+		sw.Logger.Info("Error reconnecting to peer. Trying again", "addr", addr, "err", err,
+			"failedAttempts", attempts, "backoff", time.Until(nextDial))
+	}
+	sw.wakeReconnectRoutine()
 }
 
 // SetAddrBook allows to set address book on Switch.
@@ -537,6 +720,9 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	if sw.IsDialingOrExistingAddress(addr) {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
+	if err := sw.checkBanned(addr.ID); err != nil {
+		return err
+	}
 
 	sw.dialing.Set(string(addr.ID), addr)
 	defer sw.dialing.Delete(string(addr.ID))
@@ -618,11 +804,13 @@ func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
 func (sw *Switch) acceptRoutine() {
 	for {
 		p, err := sw.transport.Accept(peerConfig{
-			chDescs:      sw.chDescs,
-			onPeerError:  sw.StopPeerForError,
-			reactorsByCh: sw.reactorsByCh,
-			metrics:      sw.metrics,
-			isPersistent: sw.IsPeerPersistent,
+			chDescs:                sw.chDescs,
+			onPeerError:            sw.StopPeerForError,
+			reactorsByCh:           sw.reactorsByCh,
+			metrics:                sw.metrics,
+			isPersistent:           sw.IsPeerPersistent,
+			persistentPingInterval: sw.config.PersistentPeersPingInterval,
+			persistentPongTimeout:  sw.config.PersistentPeersPongTimeout,
 		})
 		if err != nil {
 			switch err := err.(type) {
@@ -635,6 +823,12 @@ func (sw *Switch) acceptRoutine() {
 					sw.addrBook.AddOurAddress(&addr)
 				}
 
+				// The peer, which failed the authentication or claimed a
+				// masternode it doesn't own, is banned.
+				if err.ID() != "" && (err.IsAuthFailure() || err.IsNodeInfoInvalid()) {
+					sw.banForMisbehavior(err.ID(), err)
+				}
+
 				sw.Logger.Info(
 					"Inbound Peer rejected",
 					"err", err,
@@ -721,11 +915,13 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	}
 
 	p, err := sw.transport.Dial(*addr, peerConfig{
-		chDescs:      sw.chDescs,
-		onPeerError:  sw.StopPeerForError,
-		isPersistent: sw.IsPeerPersistent,
-		reactorsByCh: sw.reactorsByCh,
-		metrics:      sw.metrics,
+		chDescs:                sw.chDescs,
+		onPeerError:            sw.StopPeerForError,
+		isPersistent:           sw.IsPeerPersistent,
+		reactorsByCh:           sw.reactorsByCh,
+		metrics:                sw.metrics,
+		persistentPingInterval: sw.config.PersistentPeersPingInterval,
+		persistentPongTimeout:  sw.config.PersistentPeersPongTimeout,
 	})
 	if err != nil {
 		if e, ok := err.(ErrRejected); ok {
@@ -765,6 +961,10 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if err := sw.checkBanned(p.ID()); err != nil {
+		return err
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -787,6 +987,18 @@ func (sw *Switch) filterPeer(p Peer) error {
 	return nil
 }
 
+// checkBanned returns ErrRejected, if the peer is banned.
+func (sw *Switch) checkBanned(id ID) error {
+	if ban, ok := sw.banList.IsBanned(id); ok {
+		return ErrRejected{
+			id:       id,
+			err:      fmt.Errorf("%s (until %v)", ban.Reason, ban.Until),
+			isBanned: true,
+		}
+	}
+	return nil
+}
+
 // addPeer starts up the Peer and adds it to the Switch. Error is returned if
 // the peer is filtered out or failed to start or can't be added.
 func (sw *Switch) addPeer(p Peer) error {
@@ -832,6 +1044,10 @@ func (sw *Switch) addPeer(p Peer) error {
 		reactor.AddPeer(p)
 	}
 
+	// The backoff of the persistent peer is reset after a successful
+	// handshake.
+	sw.resetReconnect(p.ID())
+
 	sw.Logger.Info("Added peer", "peer", p)
 
 	return nil
@@ -450,6 +450,58 @@ func TestSwitchStopPeerForError(t *testing.T) {
 	assert.EqualValues(t, 0, peersMetricValue())
 }
 
+func TestSwitchBanPeer(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := sw2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.Equal(t, 1, sw1.Peers().Size())
+	id := sw2.NodeInfo().ID()
+
+	assert.Error(t, sw1.BanPeer(id, "spam", 0))
+	assert.Equal(t, 1, sw1.Peers().Size())
+
+	// the banned peer is disconnected
+	require.NoError(t, sw1.BanPeer(id, "spam", time.Hour))
+	assert.Equal(t, 0, sw1.Peers().Size())
+	bans := sw1.BannedPeers()
+	require.Len(t, bans, 1)
+	assert.Equal(t, id, bans[0].ID)
+	assert.Equal(t, "spam", bans[0].Reason)
+
+	// and isn't dialed
+	addr := sw2.NetAddress()
+	err := sw1.DialPeerWithAddress(addr)
+	if rejected, ok := err.(ErrRejected); assert.True(t, ok, "expected ErrRejected, got %v", err) {
+		assert.True(t, rejected.IsBanned())
+	}
+
+	require.NoError(t, sw1.UnbanPeer(id))
+	assert.Empty(t, sw1.BannedPeers())
+	assert.Error(t, sw1.UnbanPeer(id))
+}
+
+func TestSwitchStopPeerForMisbehavior(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := sw2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.Equal(t, 1, sw1.Peers().Size())
+
+	p := sw1.Peers().List()[0]
+	sw1.StopPeerForMisbehavior(p, fmt.Errorf("invalid evidence"))
+	assert.Equal(t, 0, sw1.Peers().Size())
+
+	ban, ok := sw1.banList.IsBanned(p.ID())
+	require.True(t, ok)
+	assert.Equal(t, "invalid evidence", ban.Reason)
+	assert.Equal(t, sw1.config.BanDuration, ban.Until.Sub(ban.BannedAt))
+}
+
 func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", nil, initSwitchFunc)
 	err := sw.Start()
@@ -529,6 +581,72 @@ func TestSwitchReconnectsToInboundPersistentPeer(t *testing.T) {
 	assert.Equal(t, 1, sw.Peers().Size())
 }
 
+func TestSwitchReconnectBackoff(t *testing.T) {
+	conf := *cfg
+	conf.PersistentPeersReconnectMaxBackoff = 10 * time.Second
+	conf.PersistentPeersReconnectJitter = 0
+	sw := MakeSwitch(&conf, 1, "testing", "123.123.123", nil, initSwitchFunc)
+
+	for attempts, backoff := range []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second,
+		8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		assert.Equal(t, backoff, sw.reconnectBackoff(attempts), "attempts %d", attempts)
+	}
+	assert.Equal(t, 10*time.Second, sw.reconnectBackoff(100))
+
+	conf.PersistentPeersReconnectJitter = time.Second
+	for i := 0; i < 100; i++ {
+		backoff := sw.reconnectBackoff(3)
+		assert.GreaterOrEqual(t, int64(backoff), int64(4*time.Second))
+		assert.Less(t, int64(backoff), int64(5*time.Second))
+	}
+}
+
+// The backoff grows while the persistent peer is down, and it's reset after
+// the successful handshake.
+func TestSwitchReconnectBackoffResetsAfterHandshake(t *testing.T) {
+	conf := *cfg
+	conf.PersistentPeersReconnectJitter = 0
+	sw := MakeSwitch(&conf, 1, "testing", "123.123.123", nil, initSwitchFunc)
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the peer is down
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	rp.Stop()
+	addr := rp.Addr()
+	require.NoError(t, sw.AddPersistentPeers([]string{addr.String()}))
+
+	state := func() reconnectState {
+		sw.reconnectMtx.Lock()
+		defer sw.reconnectMtx.Unlock()
+		return *sw.reconnects[addr.ID]
+	}
+	sw.reconnectToPeer(addr)
+	require.Eventually(t, func() bool { return state().attempts == 2 }, 5*time.Second, 10*time.Millisecond)
+	st := state()
+	assert.True(t, st.active)
+	assert.InDelta(t, float64(2*time.Second), float64(time.Until(st.nextDial)), float64(500*time.Millisecond))
+
+	// the peer is back
+	rp = &remotePeer{PrivKey: rp.PrivKey, Config: cfg, listenAddr: addr.DialString()}
+	rp.Start()
+	defer rp.Stop()
+	require.Eventually(t, func() bool { return sw.Peers().Has(addr.ID) }, 5*time.Second, 10*time.Millisecond)
+	st = state()
+	assert.False(t, st.active)
+	assert.Zero(t, st.attempts)
+
+	// the next reconnection starts right away
+	sw.StopPeerForError(sw.Peers().Get(addr.ID), errors.New("some err"))
+	require.Eventually(t, func() bool { return sw.Peers().Has(addr.ID) }, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, state().attempts)
+}
+
 func TestSwitchDialPeersAsync(t *testing.T) {
 	if testing.Short() {
 		return
@@ -793,6 +911,39 @@ func TestSwitchInitPeerIsNotCalledBeforeRemovePeer(t *testing.T) {
 	assert.False(t, reactor.InitCalledBeforeRemoveFinished())
 }
 
+type stopOrderReactor struct {
+	*BaseReactor
+
+	name    string
+	stopped *[]string
+}
+
+func (r *stopOrderReactor) OnStop() {
+	*r.stopped = append(*r.stopped, r.name)
+}
+
+func TestSwitchStopsReactorsInReverseOrder(t *testing.T) {
+	var stopped []string
+	reactors := make([]*stopOrderReactor, 3)
+	for i, name := range []string{"mempool", "consensus", "pex"} {
+		reactors[i] = &stopOrderReactor{name: name, stopped: &stopped}
+		reactors[i].BaseReactor = NewBaseReactor(name, reactors[i])
+	}
+
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", nil, func(i int, sw *Switch) *Switch {
+		for _, r := range reactors {
+			sw.AddReactor(r.name, r)
+		}
+		return sw
+	})
+	require.NoError(t, sw.Start())
+
+	// a reactor stopped already isn't stopped again
+	require.NoError(t, reactors[1].Stop())
+	require.NoError(t, sw.Stop())
+	assert.Equal(t, []string{"consensus", "pex", "mempool"}, stopped)
+}
+
 func BenchmarkSwitchBroadcast(b *testing.B) {
 	s1, s2 := MakeSwitchPair(b, func(i int, sw *Switch) *Switch {
 		// Make bar reactors of bar channels each
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 	"time"
 
 	"golang.org/x/net/netutil"
@@ -50,6 +51,10 @@ type peerConfig struct {
 	isPersistent func(*NetAddress) bool
 	reactorsByCh map[byte]Reactor
 	metrics      *Metrics
+	// persistentPingInterval and persistentPongTimeout override the ones of
+	// the connection config for the persistent peers, if not zero.
+	persistentPingInterval time.Duration
+	persistentPongTimeout  time.Duration
 }
 
 // Transport emits and connects to Peers. The implementation of Peer is left to
@@ -130,6 +135,31 @@ func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.maxIncomingConnections = n }
 }
 
+// MultiplexTransportMasternodeIdentityVerifier sets the verifier of the proof
+// of the masternode ownership in the NodeInfo of the peers. The peers, which
+// claim a proTxHash without a valid proof, are rejected. Default: nil (the
+// proTxHash isn't verified)
+func MultiplexTransportMasternodeIdentityVerifier(
+	verifier MasternodeIdentityVerifier,
+) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.identityVerifier = verifier }
+}
+
+// MultiplexTransportBanList sets the list of the banned peers, which are
+// refused right after the authentication of their connections. Default: nil
+// (no peer is banned)
+func MultiplexTransportBanList(banList *BanList) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.banList = banList }
+}
+
+// MasternodeIdentityVerifier verifies that a peer, which claims the proTxHash
+// of a masternode, controls the key of the masternode.
+type MasternodeIdentityVerifier interface {
+	// VerifyMasternodeIdentity returns an error, if the NodeInfo doesn't carry
+	// a valid signature of the node ID by the key of the masternode.
+	VerifyMasternodeIdentity(nodeInfo DefaultNodeInfo) error
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
 // multiplexed peers.
 type MultiplexTransport struct {
@@ -150,6 +180,8 @@ type MultiplexTransport struct {
 	nodeInfo         NodeInfo
 	nodeKey          NodeKey
 	resolver         IPResolver
+	identityVerifier MasternodeIdentityVerifier
+	banList          *BanList
 
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
@@ -435,6 +467,18 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	// Refuse the banned peers before the handshake.
+	if mt.banList != nil {
+		if ban, ok := mt.banList.IsBanned(connID); ok {
+			return nil, nil, ErrRejected{
+				conn:     c,
+				id:       connID,
+				err:      fmt.Errorf("%s (until %v)", ban.Reason, ban.Until),
+				isBanned: true,
+			}
+		}
+	}
+
 	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
@@ -481,13 +525,44 @@ func (mt *MultiplexTransport) upgrade(
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
+			nodeInfo:       nodeInfo,
 			isIncompatible: true,
 		}
 	}
 
+	if err := mt.verifyMasternodeIdentity(nodeInfo); err != nil {
+		return nil, nil, ErrRejected{
+			conn:              c,
+			err:               err,
+			id:                nodeInfo.ID(),
+			nodeInfo:          nodeInfo,
+			isNodeInfoInvalid: true,
+		}
+	}
+
 	return secretConn, nodeInfo, nil
 }
 
+// verifyMasternodeIdentity verifies the proof of the masternode ownership, if
+// the peer claims a proTxHash and the verifier is set. The peers without a
+// proTxHash are not masternodes, they don't need a proof.
+func (mt *MultiplexTransport) verifyMasternodeIdentity(nodeInfo NodeInfo) error {
+	if mt.identityVerifier == nil || nodeInfo.GetProTxHash() == nil {
+		return nil
+	}
+	ni, ok := nodeInfo.(DefaultNodeInfo)
+	if !ok {
+		return fmt.Errorf("wrong NodeInfo type. Expected DefaultNodeInfo, got %v", reflect.TypeOf(nodeInfo))
+	}
+	if len(ni.NodeIDSignature) == 0 {
+		return fmt.Errorf("no proof of the ownership of masternode %v", *ni.ProTxHash)
+	}
+	if err := mt.identityVerifier.VerifyMasternodeIdentity(ni); err != nil {
+		return fmt.Errorf("failed to verify the ownership of masternode %v: %w", *ni.ProTxHash, err)
+	}
+	return nil
+}
+
 func (mt *MultiplexTransport) wrapPeer(
 	c net.Conn,
 	ni NodeInfo,
@@ -514,9 +589,16 @@ func (mt *MultiplexTransport) wrapPeer(
 		socketAddr,
 	)
 
+	// the persistent peers are checked for liveness more often
+	mConfig := mt.mConfig
+	if persistent && cfg.persistentPingInterval > 0 {
+		mConfig.PingInterval = cfg.persistentPingInterval
+		mConfig.PongTimeout = cfg.persistentPongTimeout
+	}
+
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		mConfig,
 		ni,
 		cfg.reactorsByCh,
 		cfg.chDescs,
@@ -10,10 +10,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/protoio"
 	"github.com/tendermint/tendermint/p2p/conn"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
 )
 
 var defaultNodeName = "host_peer"
@@ -435,6 +442,39 @@ func TestTransportMultiplexRejectMissmatchID(t *testing.T) {
 	}
 }
 
+func TestTransportMultiplexRejectBanned(t *testing.T) {
+	mt := testSetupMultiplexTransport(t)
+	defer mt.Close()
+
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	banList := NewBanList()
+	_, err := banList.Ban(id, "spam", time.Hour)
+	require.NoError(t, err)
+	MultiplexTransportBanList(banList)(mt)
+
+	errc := make(chan error)
+
+	go func() {
+		dialer := newMultiplexTransport(testNodeInfo(id, "dialer", nil), NodeKey{PrivKey: pv})
+		addr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
+
+		_, err := dialer.Dial(*addr, peerConfig{})
+		errc <- err
+	}()
+
+	_, err = mt.Accept(peerConfig{})
+	if rejected, ok := err.(ErrRejected); ok {
+		assert.True(t, rejected.IsBanned())
+		assert.Equal(t, id, rejected.ID())
+		assert.Contains(t, err.Error(), "spam")
+	} else {
+		t.Errorf("expected ErrRejected, got %v", err)
+	}
+	// the handshake isn't done with the banned peer
+	assert.Error(t, <-errc)
+}
+
 func TestTransportMultiplexDialRejectWrongID(t *testing.T) {
 	mt := testSetupMultiplexTransport(t)
 
@@ -539,6 +579,93 @@ func TestTransportMultiplexRejectSelf(t *testing.T) {
 	}
 }
 
+// testIdentityVerifier verifies the node ID signatures with the public keys of
+// the masternodes of one quorum.
+type testIdentityVerifier map[string]crypto.PubKey
+
+func (v testIdentityVerifier) VerifyMasternodeIdentity(nodeInfo DefaultNodeInfo) error {
+	return types.VerifyNodeIDSignature(nodeInfo.Network, string(nodeInfo.ID()), *nodeInfo.ProTxHash,
+		btcjson.LLMQType_5_60, nodeInfo.QuorumHash, v[nodeInfo.ProTxHash.String()], nodeInfo.NodeIDSignature)
+}
+
+func TestTransportMultiplexVerifyMasternodeIdentity(t *testing.T) {
+	var (
+		quorumHash = crypto.RandQuorumHash()
+		proTxHash  = crypto.RandProTxHash()
+		privKey    = bls12381.GenPrivKey()
+		verifier   = testIdentityVerifier{proTxHash.String(): privKey.PubKey()}
+	)
+	signNodeID := func(privKey crypto.PrivKey, nodeID ID) []byte {
+		signID := types.NodeIDSignId("testing", string(nodeID), proTxHash, btcjson.LLMQType_5_60, quorumHash)
+		signature, err := privKey.SignDigest(signID)
+		require.NoError(t, err)
+		return signature
+	}
+
+	testCases := []struct {
+		name       string
+		malleateFn func(nodeInfo *DefaultNodeInfo)
+		expErr     string
+	}{
+		{"valid proof", func(nodeInfo *DefaultNodeInfo) {}, ""},
+		{"full node", func(nodeInfo *DefaultNodeInfo) {
+			nodeInfo.ProTxHash, nodeInfo.QuorumHash, nodeInfo.NodeIDSignature = nil, nil, nil
+		}, ""},
+		{"no proof", func(nodeInfo *DefaultNodeInfo) {
+			nodeInfo.QuorumHash, nodeInfo.NodeIDSignature = nil, nil
+		}, "no proof of the ownership"},
+		{"forged signature", func(nodeInfo *DefaultNodeInfo) {
+			nodeInfo.NodeIDSignature = signNodeID(bls12381.GenPrivKey(), nodeInfo.ID())
+		}, "invalid node ID signature"},
+		{"signature of another node ID", func(nodeInfo *DefaultNodeInfo) {
+			nodeInfo.NodeIDSignature = signNodeID(privKey, PubKeyToID(ed25519.GenPrivKey().PubKey()))
+		}, "invalid node ID signature"},
+		{"another quorum", func(nodeInfo *DefaultNodeInfo) {
+			nodeInfo.QuorumHash = crypto.RandQuorumHash()
+		}, "invalid node ID signature"},
+		{"unknown masternode", func(nodeInfo *DefaultNodeInfo) {
+			otherProTxHash := crypto.RandProTxHash()
+			nodeInfo.ProTxHash = &otherProTxHash
+		}, "no public key of masternode"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mt := testSetupMultiplexTransport(t)
+			MultiplexTransportMasternodeIdentityVerifier(verifier)(mt)
+			defer mt.Close()
+
+			pv := ed25519.GenPrivKey()
+			id := PubKeyToID(pv.PubKey())
+			nodeInfo := testNodeInfo(id, "dialer", &proTxHash).(DefaultNodeInfo)
+			nodeInfo.QuorumHash = quorumHash
+			nodeInfo.NodeIDSignature = signNodeID(privKey, id)
+			tc.malleateFn(&nodeInfo)
+			dialer := newMultiplexTransport(nodeInfo, NodeKey{PrivKey: pv})
+
+			go func() {
+				addr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
+				if p, err := dialer.Dial(*addr, peerConfig{}); err == nil {
+					_ = p.CloseConn()
+				}
+			}()
+
+			p, err := mt.Accept(peerConfig{})
+			if tc.expErr == "" {
+				require.NoError(t, err)
+				_ = p.CloseConn()
+				return
+			}
+			require.Error(t, err)
+			rejected, ok := err.(ErrRejected)
+			require.True(t, ok, "expected ErrRejected, got %v", err)
+			assert.True(t, rejected.IsNodeInfoInvalid())
+			assert.Contains(t, err.Error(), tc.expErr)
+		})
+	}
+}
+
 func TestTransportConnDuplicateIPFilter(t *testing.T) {
 	filter := ConnDuplicateIPFilter()
 
@@ -0,0 +1,194 @@
+package privval
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	rpc "github.com/dashevo/dashd-go/rpcclient"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+const (
+	coreRetryMinBackoff = 50 * time.Millisecond
+	coreRetryMaxBackoff = time.Second
+)
+
+// ErrCoreUnavailable is returned when Dash Core doesn't respond within the
+// retry timeout.
+var ErrCoreUnavailable = errors.New("dash core is unavailable")
+
+// ErrNoChainLock is returned when Dash Core doesn't know any chain lock yet.
+var ErrNoChainLock = errors.New("dash core doesn't know any chain lock")
+
+// coreErrorKind classifies the errors of the requests to Dash Core.
+type coreErrorKind int
+
+const (
+	// Dash Core responded with an error, the connection is healthy.
+	coreErrorResponse coreErrorKind = iota
+	// Dash Core is temporarily unavailable, the request can be retried.
+	coreErrorRetryable
+	// Dash Core rejects the requests, retrying doesn't help.
+	coreErrorFatal
+)
+
+// classifyCoreError classifies an error returned by the RPC client.
+//
+// The RPC client reports the failures of the HTTP transport as net errors,
+// and the responses, which are not JSON-RPC ones, as the HTTP status code with
+// the raw response, e.g. when the work queue of Dash Core is full.
+func classifyCoreError(err error) coreErrorKind {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, rpc.ErrClientShutdown),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &netErr):
+		return coreErrorRetryable
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "Work queue depth exceeded"),
+		strings.HasPrefix(msg, "status code: 503"):
+		return coreErrorRetryable
+	case strings.HasPrefix(msg, "status code: 401"),
+		strings.HasPrefix(msg, "status code: 403"):
+		return coreErrorFatal
+	}
+	return coreErrorResponse
+}
+
+// call calls fn with the RPC client. The requests failed because Dash Core is
+// unavailable are retried with a jittered backoff until the retry timeout
+// expires, the RPC client is re-created after each such failure.
+func (sc *DashCoreSignerClient) call(fn func(client *rpc.Client) error) error {
+	deadline := time.Now().Add(sc.retryTimeout)
+	backoff := coreRetryMinBackoff
+	for {
+		client := sc.client()
+		err := fn(client)
+		if err == nil {
+			sc.markHealthy()
+			return nil
+		}
+		switch classifyCoreError(err) {
+		case coreErrorResponse:
+			sc.markHealthy()
+			return err
+		case coreErrorFatal:
+			sc.markFailed()
+			return err
+		}
+		sc.markFailed()
+		sc.reconnect(client, err)
+
+		// sleep between backoff/2 and backoff
+		delay := backoff/2 + time.Duration(tmrand.Int63n(int64(backoff/2)+1))
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("%w: %v", ErrCoreUnavailable, err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-sc.quit:
+			return err
+		}
+		backoff *= 2
+		if backoff > coreRetryMaxBackoff {
+			backoff = coreRetryMaxBackoff
+		}
+	}
+}
+
+func (sc *DashCoreSignerClient) client() *rpc.Client {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.endpoint
+}
+
+// reconnect re-creates the RPC client, unless it was already re-created
+// after failed is used.
+func (sc *DashCoreSignerClient) reconnect(failed *rpc.Client, err error) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	if sc.endpoint != failed {
+		return
+	}
+	select {
+	case <-sc.quit:
+		return
+	default:
+	}
+	client, newErr := rpc.New(sc.connCfg, nil)
+	if newErr != nil {
+		sc.logger.Error("Failed to re-create the Dash Core RPC client", "err", newErr)
+		return
+	}
+	sc.logger.Info("Re-created the Dash Core RPC client", "host", sc.host, "err", err)
+	sc.endpoint = client
+	failed.Shutdown()
+}
+
+func (sc *DashCoreSignerClient) markHealthy() {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	sc.failures = 0
+	sc.metrics.CoreConsecutiveFailures.Set(0)
+}
+
+func (sc *DashCoreSignerClient) markFailed() {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	sc.failures++
+	sc.metrics.CoreConsecutiveFailures.Set(float64(sc.failures))
+}
+
+// ConsecutiveFailures returns the number of consecutive failed requests to
+// Dash Core.
+func (sc *DashCoreSignerClient) ConsecutiveFailures() int {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.failures
+}
+
+// healthCheckRoutine probes the connection to Dash Core, so it's re-created
+// before the next sign request, if Dash Core was restarted.
+func (sc *DashCoreSignerClient) healthCheckRoutine() {
+	ticker := time.NewTicker(sc.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sc.probe()
+		case <-sc.quit:
+			return
+		}
+	}
+}
+
+// probe sends a single getnetworkinfo request without retrying it.
+func (sc *DashCoreSignerClient) probe() {
+	client := sc.client()
+	_, err := client.GetNetworkInfo()
+	if err == nil {
+		sc.markHealthy()
+		return
+	}
+	switch classifyCoreError(err) {
+	case coreErrorResponse:
+		sc.markHealthy()
+	case coreErrorFatal:
+		sc.markFailed()
+		sc.logger.Error("Dash Core rejects the requests", "err", err)
+	case coreErrorRetryable:
+		sc.markFailed()
+		sc.reconnect(client, err)
+	}
+}
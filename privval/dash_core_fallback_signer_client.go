@@ -0,0 +1,248 @@
+package privval
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dashevo/dashd-go/btcjson"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DashCoreFallbackSignerClient implements PrivValidator.
+// It signs with Dash Core and falls back to the keys of the FilePV, if Dash Core
+// is unavailable. It's meant for devnets, which run without a full Dash Core,
+// the keys of the FilePV must be the key shares of the quorums.
+//
+// The signatures of both paths are persisted in the last sign state of the
+// FilePV, so the fallback never signs conflicting data for a height, round and
+// step, which were signed with Dash Core, and vice versa.
+type DashCoreFallbackSignerClient struct {
+	core   *DashCoreSignerClient
+	file   *FilePV
+	logger log.Logger
+
+	mtx sync.Mutex // serializes the sign requests
+}
+
+var (
+	_ types.PrivValidator     = (*DashCoreFallbackSignerClient)(nil)
+	_ types.QuorumTypeSetter  = (*DashCoreFallbackSignerClient)(nil)
+	_ types.NodeIDSigner      = (*DashCoreFallbackSignerClient)(nil)
+	_ types.QuorumSigner      = (*DashCoreFallbackSignerClient)(nil)
+	_ types.ChainLockProvider = (*DashCoreFallbackSignerClient)(nil)
+)
+
+// NewDashCoreFallbackSignerClient returns a DashCoreFallbackSignerClient,
+// which prefers core and falls back to file.
+func NewDashCoreFallbackSignerClient(
+	core *DashCoreSignerClient,
+	file *FilePV,
+	logger log.Logger,
+) *DashCoreFallbackSignerClient {
+	return &DashCoreFallbackSignerClient{
+		core:   core,
+		file:   file,
+		logger: logger,
+	}
+}
+
+// Close closes the connection to Dash Core.
+func (sc *DashCoreFallbackSignerClient) Close() error {
+	return sc.core.Close()
+}
+
+// SetQuorumType sets the quorum type of the quorum, which is used to look up
+// its keys in Dash Core.
+func (sc *DashCoreFallbackSignerClient) SetQuorumType(quorumHash crypto.QuorumHash, quorumType btcjson.LLMQType) {
+	sc.core.SetQuorumType(quorumHash, quorumType)
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+func (sc *DashCoreFallbackSignerClient) ExtractIntoValidator(quorumHash crypto.QuorumHash) *types.Validator {
+	pubKey, _ := sc.GetPubKey(quorumHash)
+	proTxHash, _ := sc.GetProTxHash()
+	if len(proTxHash) != crypto.DefaultHashSize {
+		panic("proTxHash wrong length")
+	}
+	return &types.Validator{
+		PubKey:      pubKey,
+		VotingPower: types.DefaultDashVotingPower,
+		ProTxHash:   proTxHash,
+	}
+}
+
+func (sc *DashCoreFallbackSignerClient) GetPubKey(quorumHash crypto.QuorumHash) (crypto.PubKey, error) {
+	pubKey, err := sc.core.GetPubKey(quorumHash)
+	if errors.Is(err, ErrCoreUnavailable) {
+		return sc.file.GetPubKey(quorumHash)
+	}
+	return pubKey, err
+}
+
+func (sc *DashCoreFallbackSignerClient) GetFirstQuorumHash() (crypto.QuorumHash, error) {
+	return sc.file.GetFirstQuorumHash()
+}
+
+func (sc *DashCoreFallbackSignerClient) GetThresholdPublicKey(quorumHash crypto.QuorumHash) (crypto.PubKey, error) {
+	pubKey, err := sc.core.GetThresholdPublicKey(quorumHash)
+	if errors.Is(err, ErrCoreUnavailable) {
+		return sc.file.GetThresholdPublicKey(quorumHash)
+	}
+	return pubKey, err
+}
+
+func (sc *DashCoreFallbackSignerClient) GetHeight(quorumHash crypto.QuorumHash) (int64, error) {
+	return sc.file.GetHeight(quorumHash)
+}
+
+func (sc *DashCoreFallbackSignerClient) GetProTxHash() (crypto.ProTxHash, error) {
+	proTxHash, err := sc.core.GetProTxHash()
+	if errors.Is(err, ErrCoreUnavailable) {
+		return sc.file.GetProTxHash()
+	}
+	return proTxHash, err
+}
+
+// GetMemberPubKey returns the public key share of the masternode in the
+// quorum from Dash Core.
+func (sc *DashCoreFallbackSignerClient) GetMemberPubKey(
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	proTxHash crypto.ProTxHash,
+) (crypto.PubKey, error) {
+	return sc.core.GetMemberPubKey(quorumType, quorumHash, proTxHash)
+}
+
+// SignVote signs the vote with Dash Core, or with the FilePV if Dash Core is
+// unavailable.
+func (sc *DashCoreFallbackSignerClient) SignVote(
+	chainID string,
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	vote *tmproto.Vote,
+) error {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	sameHRS, err := sc.file.LastSignState.CheckHRS(height, round, step)
+	if err != nil {
+		return err
+	}
+	if sameHRS {
+		// reuse the last signature, unless the data conflicts
+		return sc.file.SignVote(chainID, quorumType, quorumHash, vote)
+	}
+
+	err = sc.core.SignVote(chainID, quorumType, quorumHash, vote)
+	if err == nil {
+		var extensionSignBytes []byte
+		if types.VoteExtensionRequested(vote) {
+			extensionSignBytes = types.VoteExtensionSignBytes(chainID, vote)
+		}
+		sc.file.saveSigned(height, round, step,
+			types.VoteBlockSignBytes(chainID, vote), vote.BlockSignature,
+			types.VoteStateSignBytes(chainID, vote), vote.StateSignature,
+			extensionSignBytes, vote.ExtensionSignature)
+		return nil
+	}
+	if !errors.Is(err, ErrCoreUnavailable) {
+		return err
+	}
+	sc.logger.Error("Dash Core is unavailable, signing the vote with the file private validator",
+		"height", height, "round", round, "type", vote.Type, "quorum_hash", quorumHash, "err", err)
+	return sc.file.SignVote(chainID, quorumType, quorumHash, vote)
+}
+
+// SignProposal signs the proposal with Dash Core, or with the FilePV if Dash
+// Core is unavailable.
+func (sc *DashCoreFallbackSignerClient) SignProposal(
+	chainID string,
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	proposal *tmproto.Proposal,
+) ([]byte, error) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	height, round, step := proposal.Height, proposal.Round, stepPropose
+	sameHRS, err := sc.file.LastSignState.CheckHRS(height, round, step)
+	if err != nil {
+		return nil, err
+	}
+	if sameHRS {
+		// reuse the last signature, unless the data conflicts
+		return sc.file.SignProposal(chainID, quorumType, quorumHash, proposal)
+	}
+
+	signID, err := sc.core.SignProposal(chainID, quorumType, quorumHash, proposal)
+	if err == nil {
+		sc.file.saveSigned(height, round, step,
+			types.ProposalBlockSignBytes(chainID, proposal), proposal.Signature, nil, nil, nil, nil)
+		return signID, nil
+	}
+	if !errors.Is(err, ErrCoreUnavailable) {
+		return nil, err
+	}
+	sc.logger.Error("Dash Core is unavailable, signing the proposal with the file private validator",
+		"height", height, "round", round, "quorum_hash", quorumHash, "err", err)
+	return sc.file.SignProposal(chainID, quorumType, quorumHash, proposal)
+}
+
+// GetBestChainLock requests the best chain lock known by Dash Core, there is
+// nothing to fall back to.
+func (sc *DashCoreFallbackSignerClient) GetBestChainLock() (*types.CoreChainLock, error) {
+	return sc.core.GetBestChainLock()
+}
+
+// SignNodeID signs the node ID with Dash Core, or with the FilePV if Dash Core
+// is unavailable.
+func (sc *DashCoreFallbackSignerClient) SignNodeID(
+	chainID string,
+	nodeID string,
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+) ([]byte, error) {
+	signature, err := sc.core.SignNodeID(chainID, nodeID, quorumType, quorumHash)
+	if !errors.Is(err, ErrCoreUnavailable) {
+		return signature, err
+	}
+	sc.logger.Error("Dash Core is unavailable, signing the node ID with the file private validator",
+		"quorum_hash", quorumHash, "err", err)
+	return sc.file.SignNodeID(chainID, nodeID, quorumType, quorumHash)
+}
+
+// QuorumSign signs the message hash with Dash Core, or with the FilePV if Dash
+// Core is unavailable.
+func (sc *DashCoreFallbackSignerClient) QuorumSign(
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	requestID []byte,
+	messageHash []byte,
+) ([]byte, error) {
+	signature, err := sc.core.QuorumSign(quorumType, quorumHash, requestID, messageHash)
+	if !errors.Is(err, ErrCoreUnavailable) {
+		return signature, err
+	}
+	sc.logger.Error("Dash Core is unavailable, signing with the file private validator",
+		"quorum_hash", quorumHash, "err", err)
+	return sc.file.QuorumSign(quorumType, quorumHash, requestID, messageHash)
+}
+
+// UpdatePrivateKey updates the keys of the FilePV, Dash Core manages its keys
+// itself.
+func (sc *DashCoreFallbackSignerClient) UpdatePrivateKey(
+	privateKey crypto.PrivKey,
+	quorumHash crypto.QuorumHash,
+	height int64,
+) error {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	return sc.file.UpdatePrivateKey(privateKey, quorumHash, height)
+}
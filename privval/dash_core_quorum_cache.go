@@ -0,0 +1,121 @@
+package privval
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	defaultQuorumCacheSize = 32
+	defaultQuorumCacheTTL  = time.Hour
+)
+
+// quorumKey identifies a quorum in the quorumCache.
+type quorumKey struct {
+	quorumType btcjson.LLMQType
+	quorumHash string
+}
+
+func newQuorumKey(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash) quorumKey {
+	return quorumKey{quorumType: quorumType, quorumHash: quorumHash.String()}
+}
+
+type quorumCacheEntry struct {
+	key       quorumKey
+	info      *btcjson.QuorumInfoResult
+	fetchedAt time.Time
+}
+
+// quorumCache is a LRU cache of the quorum info results of Dash Core, the
+// public keys and the members of a quorum don't change during its lifetime.
+// Entries expire after the ttl, a ttl of 0 disables the expiry.
+type quorumCache struct {
+	mtx  sync.Mutex
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+	// map of the quorum keys to the elements of the list
+	entries map[quorumKey]*list.Element
+	// the most recently used entries are at the front
+	list *list.List
+}
+
+func newQuorumCache(size int, ttl time.Duration) *quorumCache {
+	return &quorumCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[quorumKey]*list.Element, size),
+		list:    list.New(),
+	}
+}
+
+// Get returns the cached quorum info, nil if the quorum is missing or its
+// entry expired.
+func (c *quorumCache) Get(key quorumKey) *btcjson.QuorumInfoResult {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := e.Value.(*quorumCacheEntry)
+	if c.ttl > 0 && c.now().Sub(entry.fetchedAt) >= c.ttl {
+		c.remove(e)
+		return nil
+	}
+	c.list.MoveToFront(e)
+	return entry.info
+}
+
+// Put caches the quorum info, the least recently used entry is evicted if the
+// cache is full.
+func (c *quorumCache) Put(key quorumKey, info *btcjson.QuorumInfoResult) {
+	if c.size <= 0 {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*quorumCacheEntry)
+		entry.info = info
+		entry.fetchedAt = c.now()
+		c.list.MoveToFront(e)
+		return
+	}
+	if c.list.Len() >= c.size {
+		c.remove(c.list.Back())
+	}
+	c.entries[key] = c.list.PushFront(&quorumCacheEntry{key: key, info: info, fetchedAt: c.now()})
+}
+
+// Remove drops the quorum from the cache.
+func (c *quorumCache) Remove(key quorumKey) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Reset drops all quorums from the cache.
+func (c *quorumCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.entries = make(map[quorumKey]*list.Element, c.size)
+	c.list.Init()
+}
+
+func (c *quorumCache) remove(e *list.Element) {
+	c.list.Remove(e)
+	delete(c.entries, e.Value.(*quorumCacheEntry).key)
+}
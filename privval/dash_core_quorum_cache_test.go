@@ -0,0 +1,57 @@
+package privval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+func TestQuorumCacheEviction(t *testing.T) {
+	cache := newQuorumCache(2, 0)
+	keys := make([]quorumKey, 3)
+	for i := range keys {
+		keys[i] = newQuorumKey(btcjson.LLMQType_5_60, crypto.RandQuorumHash())
+	}
+
+	cache.Put(keys[0], &btcjson.QuorumInfoResult{QuorumHash: keys[0].quorumHash})
+	cache.Put(keys[1], &btcjson.QuorumInfoResult{QuorumHash: keys[1].quorumHash})
+	// keys[0] is used, so keys[1] is the least recently used one
+	assert.NotNil(t, cache.Get(keys[0]))
+	cache.Put(keys[2], &btcjson.QuorumInfoResult{QuorumHash: keys[2].quorumHash})
+
+	assert.NotNil(t, cache.Get(keys[0]))
+	assert.Nil(t, cache.Get(keys[1]))
+	assert.NotNil(t, cache.Get(keys[2]))
+
+	// the same quorum of another type is a different entry
+	assert.Nil(t, cache.Get(quorumKey{quorumType: btcjson.LLMQType_50_60, quorumHash: keys[0].quorumHash}))
+
+	cache.Remove(keys[0])
+	assert.Nil(t, cache.Get(keys[0]))
+	cache.Reset()
+	assert.Nil(t, cache.Get(keys[2]))
+}
+
+func TestQuorumCacheTTL(t *testing.T) {
+	now := time.Now()
+	cache := newQuorumCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+	key := newQuorumKey(btcjson.LLMQType_5_60, crypto.RandQuorumHash())
+
+	cache.Put(key, &btcjson.QuorumInfoResult{QuorumHash: key.quorumHash})
+	now = now.Add(59 * time.Second)
+	assert.NotNil(t, cache.Get(key))
+	now = now.Add(time.Second)
+	assert.Nil(t, cache.Get(key))
+}
+
+func TestQuorumCacheDisabled(t *testing.T) {
+	cache := newQuorumCache(0, 0)
+	key := newQuorumKey(btcjson.LLMQType_5_60, crypto.RandQuorumHash())
+	cache.Put(key, &btcjson.QuorumInfoResult{QuorumHash: key.quorumHash})
+	assert.Nil(t, cache.Get(key))
+}
@@ -1,38 +1,127 @@
 package privval
 
 import (
-	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dashevo/dashd-go/btcjson"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 
 	rpc "github.com/dashevo/dashd-go/rpcclient"
 	"github.com/tendermint/tendermint/crypto"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	types "github.com/tendermint/tendermint/types"
 )
 
+const (
+	defaultCoreRetryTimeout        = 3 * time.Second
+	defaultCoreHealthCheckInterval = 10 * time.Second
+)
+
+// DashCoreSignerClientOption sets an optional parameter on the DashCoreSignerClient.
+type DashCoreSignerClientOption func(*DashCoreSignerClient)
+
+// DashCoreSignerClientRetryTimeout sets how long the requests failed because
+// Dash Core is unavailable are retried. Use the proposal timeout, so the
+// signing survives a restart of Dash Core.
+//
+// Default: 3s
+func DashCoreSignerClientRetryTimeout(timeout time.Duration) DashCoreSignerClientOption {
+	return func(sc *DashCoreSignerClient) { sc.retryTimeout = timeout }
+}
+
+// DashCoreSignerClientHealthCheckInterval sets how often the connection to
+// Dash Core is probed. 0 disables the probing.
+//
+// Default: 10s
+func DashCoreSignerClientHealthCheckInterval(interval time.Duration) DashCoreSignerClientOption {
+	return func(sc *DashCoreSignerClient) { sc.healthCheckInterval = interval }
+}
+
+// DashCoreSignerClientQuorumCacheSize sets how many quorums are cached, the
+// quorum info is requested from Dash Core only once per quorum. 0 disables the
+// caching.
+//
+// Default: 32
+func DashCoreSignerClientQuorumCacheSize(size int) DashCoreSignerClientOption {
+	return func(sc *DashCoreSignerClient) { sc.quorumCacheSize = size }
+}
+
+// DashCoreSignerClientQuorumCacheTTL sets how long a quorum is cached. 0
+// disables the expiry.
+//
+// Default: 1h
+func DashCoreSignerClientQuorumCacheTTL(ttl time.Duration) DashCoreSignerClientOption {
+	return func(sc *DashCoreSignerClient) { sc.quorumCacheTTL = ttl }
+}
+
+// DashCoreSignerClientMetrics sets the metrics.
+func DashCoreSignerClientMetrics(metrics *Metrics) DashCoreSignerClientOption {
+	return func(sc *DashCoreSignerClient) { sc.metrics = metrics }
+}
+
+// DashCoreSignerClientLogger sets the logger.
+func DashCoreSignerClientLogger(logger log.Logger) DashCoreSignerClientOption {
+	return func(sc *DashCoreSignerClient) { sc.logger = logger }
+}
+
 // DashCoreSignerClient implements PrivValidator.
 // Handles remote validator connections that provide signing services
 type DashCoreSignerClient struct {
 	endpoint          *rpc.Client
+	connCfg           *rpc.ConnConfig
 	host              string
 	cachedProTxHash   crypto.ProTxHash
 	rpcUsername       string
 	rpcPassword       string
 	defaultQuorumType btcjson.LLMQType
+
+	retryTimeout        time.Duration
+	healthCheckInterval time.Duration
+	quorumCacheSize     int
+	quorumCacheTTL      time.Duration
+	metrics             *Metrics
+	logger              log.Logger
+
+	mtx      sync.Mutex // guards endpoint, failures and quorumTypes
+	failures int        // consecutive failed requests to Dash Core
+	quorums  *quorumCache
+	// the quorum types of the quorums, the default quorum type is used for the
+	// quorums missing here
+	quorumTypes map[string]btcjson.LLMQType
+	quit        chan struct{}
+	stopOnce    sync.Once
 }
 
-var _ types.PrivValidator = (*DashCoreSignerClient)(nil)
+var (
+	_ types.PrivValidator     = (*DashCoreSignerClient)(nil)
+	_ types.QuorumTypeSetter  = (*DashCoreSignerClient)(nil)
+	_ types.NodeIDSigner      = (*DashCoreSignerClient)(nil)
+	_ types.QuorumSigner      = (*DashCoreSignerClient)(nil)
+	_ types.ChainLockProvider = (*DashCoreSignerClient)(nil)
+)
 
 // NewDashCoreSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
-func NewDashCoreSignerClient(host string, rpcUsername string, rpcPassword string, defaultQuorumType btcjson.LLMQType) (*DashCoreSignerClient, error) {
+//
+// The quorums are signed with the quorum type of the sign requests, the
+// default quorum type is used to look up the keys of the quorums, which
+// weren't signed with or set with SetQuorumType yet.
+func NewDashCoreSignerClient(
+	host string,
+	rpcUsername string,
+	rpcPassword string,
+	defaultQuorumType btcjson.LLMQType,
+	options ...DashCoreSignerClientOption,
+) (*DashCoreSignerClient, error) {
 	// Connect to local dash core RPC server using HTTP POST mode.
 	connCfg := &rpc.ConnConfig{
 		Host:         host,
@@ -48,12 +137,41 @@ func NewDashCoreSignerClient(host string, rpcUsername string, rpcPassword string
 		return nil, err
 	}
 
-	return &DashCoreSignerClient{endpoint: client, host: host, rpcUsername: rpcUsername, rpcPassword: rpcPassword, defaultQuorumType: defaultQuorumType}, nil
+	sc := &DashCoreSignerClient{
+		endpoint:            client,
+		connCfg:             connCfg,
+		host:                host,
+		rpcUsername:         rpcUsername,
+		rpcPassword:         rpcPassword,
+		defaultQuorumType:   defaultQuorumType,
+		retryTimeout:        defaultCoreRetryTimeout,
+		healthCheckInterval: defaultCoreHealthCheckInterval,
+		quorumCacheSize:     defaultQuorumCacheSize,
+		quorumCacheTTL:      defaultQuorumCacheTTL,
+		metrics:             NopMetrics(),
+		logger:              log.NewNopLogger(),
+		quorumTypes:         make(map[string]btcjson.LLMQType),
+		quit:                make(chan struct{}),
+	}
+	for _, option := range options {
+		option(sc)
+	}
+	sc.quorums = newQuorumCache(sc.quorumCacheSize, sc.quorumCacheTTL)
+	if sc.healthCheckInterval > 0 {
+		go sc.healthCheckRoutine()
+	}
+
+	return sc, nil
 }
 
 // Close closes the underlying connection
 func (sc *DashCoreSignerClient) Close() error {
-	sc.endpoint.Shutdown()
+	sc.stopOnce.Do(func() {
+		close(sc.quit)
+		sc.mtx.Lock()
+		sc.endpoint.Shutdown()
+		sc.mtx.Unlock()
+	})
 	return nil
 }
 
@@ -62,12 +180,18 @@ func (sc *DashCoreSignerClient) Close() error {
 
 // Ping sends a ping request to the remote signer
 func (sc *DashCoreSignerClient) Ping() error {
-	err := sc.endpoint.Ping()
+	err := sc.call(func(client *rpc.Client) error {
+		return client.Ping()
+	})
 	if err != nil {
 		return err
 	}
 
-	pb, err := sc.endpoint.GetPeerInfo()
+	var pb []btcjson.GetPeerInfoResult
+	err = sc.call(func(client *rpc.Client) (err error) {
+		pb, err = client.GetPeerInfo()
+		return err
+	})
 	if pb == nil {
 		return err
 	}
@@ -91,19 +215,33 @@ func (sc *DashCoreSignerClient) ExtractIntoValidator(quorumHash crypto.QuorumHas
 // GetPubKey retrieves a public key from a remote signer
 // returns an error if client is not able to provide the key
 func (sc *DashCoreSignerClient) GetPubKey(quorumHash crypto.QuorumHash) (crypto.PubKey, error) {
-	if len(quorumHash.Bytes()) != crypto.DefaultHashSize {
-		return nil, fmt.Errorf("quorum hash must be 32 bytes long if requesting public key from dash core")
-	}
+	return sc.getPubKey(sc.quorumType(quorumHash), quorumHash)
+}
+
+func (sc *DashCoreSignerClient) getPubKey(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash) (crypto.PubKey, error) {
+	proTxHash, err := sc.GetProTxHash()
 
-	response, err := sc.endpoint.QuorumInfo(sc.defaultQuorumType, quorumHash.String(), false)
 	if err != nil {
-		return nil, fmt.Errorf("getPubKey Quorum Info Error for (%d) %s : %w", sc.defaultQuorumType, quorumHash.String(), err)
+		return nil, fmt.Errorf("getPubKey proTxHash error: %w", err)
 	}
 
-	proTxHash, err := sc.GetProTxHash()
+	return sc.GetMemberPubKey(quorumType, quorumHash, proTxHash)
+}
+
+// GetMemberPubKey returns the public key share of the masternode in the
+// quorum, nil if the masternode isn't a member of the quorum.
+func (sc *DashCoreSignerClient) GetMemberPubKey(
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	proTxHash crypto.ProTxHash,
+) (crypto.PubKey, error) {
+	if len(quorumHash.Bytes()) != crypto.DefaultHashSize {
+		return nil, fmt.Errorf("quorum hash must be 32 bytes long if requesting public key from dash core")
+	}
 
+	response, err := sc.quorumInfo(quorumType, quorumHash)
 	if err != nil {
-		return nil, fmt.Errorf("getPubKey proTxHash error: %w", err)
+		return nil, fmt.Errorf("getPubKey Quorum Info Error for (%d) %s : %w", quorumType, quorumHash.String(), err)
 	}
 
 	var decodedPublicKeyShare []byte
@@ -118,7 +256,7 @@ func (sc *DashCoreSignerClient) GetPubKey(quorumHash crypto.QuorumHash) (crypto.
 		if len(decodedMemberProTxHash) != crypto.DefaultHashSize {
 			return nil, fmt.Errorf("decoding proTxHash %d is incorrect size when getting public key : %v", len(decodedMemberProTxHash), err)
 		}
-		if bytes.Equal(proTxHash, decodedMemberProTxHash) {
+		if proTxHash.Equal(decodedMemberProTxHash) {
 			decodedPublicKeyShare, err = hex.DecodeString(quorumMember.PubKeyShare)
 			found = true
 			if err != nil {
@@ -154,9 +292,10 @@ func (sc *DashCoreSignerClient) GetThresholdPublicKey(quorumHash crypto.QuorumHa
 		return nil, fmt.Errorf("quorum hash must be 32 bytes long if requesting public key from dash core")
 	}
 
-	response, err := sc.endpoint.QuorumInfo(sc.defaultQuorumType, quorumHash.String(), false)
+	quorumType := sc.quorumType(quorumHash)
+	response, err := sc.quorumInfo(quorumType, quorumHash)
 	if err != nil {
-		return nil, fmt.Errorf("getThresholdPublicKey Quorum Info Error for (%d) %s : %w", sc.defaultQuorumType, quorumHash.String(), err)
+		return nil, fmt.Errorf("getThresholdPublicKey Quorum Info Error for (%d) %s : %w", quorumType, quorumHash.String(), err)
 	}
 	decodedThresholdPublicKey, err := hex.DecodeString(response.QuorumPublicKey)
 	if len(decodedThresholdPublicKey) != bls12381.PubKeySize {
@@ -173,45 +312,88 @@ func (sc *DashCoreSignerClient) GetProTxHash() (crypto.ProTxHash, error) {
 		return sc.cachedProTxHash, nil
 	}
 
-	masternodeStatus, err := sc.endpoint.MasternodeStatus()
+	var masternodeStatus *btcjson.MasternodeStatusResult
+	err := sc.call(func(client *rpc.Client) (err error) {
+		masternodeStatus, err = client.MasternodeStatus()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("send: %w", err)
 	}
 
-	decodedProTxHash, err := hex.DecodeString(masternodeStatus.ProTxHash)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding proTxHash : %v", err)
-	}
-	if len(decodedProTxHash) != crypto.DefaultHashSize {
+	var proTxHash tmbytes.Hash32
+	err = proTxHash.UnmarshalText([]byte(masternodeStatus.ProTxHash))
+	if errors.Is(err, tmbytes.ErrHash32Size) {
 		// We are proof of service banned. Get the proTxHash from our IP Address
-		networkInfo, err := sc.endpoint.GetNetworkInfo()
-		if err == nil && len(networkInfo.LocalAddresses) > 0 {
+		var networkInfo *btcjson.GetNetworkInfoResult
+		netErr := sc.call(func(client *rpc.Client) (err error) {
+			networkInfo, err = client.GetNetworkInfo()
+			return err
+		})
+		if netErr == nil && len(networkInfo.LocalAddresses) > 0 {
 			localAddress := networkInfo.LocalAddresses[0].Address
 			localPort := networkInfo.LocalAddresses[0].Port
 			localHost := fmt.Sprintf("%s:%d", localAddress, localPort)
-			results, err := sc.endpoint.MasternodeListJSON(localHost)
-			if err == nil {
+			var results map[string]btcjson.MasternodelistResultJSON
+			listErr := sc.call(func(client *rpc.Client) (err error) {
+				results, err = client.MasternodeListJSON(localHost)
+				return err
+			})
+			if listErr == nil {
 				for _, v := range results {
-					decodedProTxHash, err = hex.DecodeString(v.ProTxHash)
+					err = proTxHash.UnmarshalText([]byte(v.ProTxHash))
 				}
 			}
 		}
-		if len(decodedProTxHash) != crypto.DefaultHashSize {
+		if errors.Is(err, tmbytes.ErrHash32Size) {
 			debug.PrintStack()
-			return nil, fmt.Errorf("decoding proTxHash %d is incorrect size when signing proposal : %v", len(decodedProTxHash), err)
 		}
 	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding proTxHash : %w", err)
+	}
+
+	sc.cachedProTxHash = proTxHash.HexBytes()
 
-	sc.cachedProTxHash = decodedProTxHash
+	return sc.cachedProTxHash, nil
+}
 
-	return decodedProTxHash, nil
+// HasMasternode returns true, if the proTxHash belongs to a masternode in the
+// masternode list of Dash Core.
+func (sc *DashCoreSignerClient) HasMasternode(proTxHash crypto.ProTxHash) (bool, error) {
+	filter := strings.ToLower(proTxHash.String())
+	var results map[string]btcjson.MasternodelistResultJSON
+	err := sc.call(func(client *rpc.Client) (err error) {
+		results, err = client.MasternodeListJSON(filter)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("masternode list: %w", err)
+	}
+	for _, mn := range results {
+		if strings.EqualFold(mn.ProTxHash, filter) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // SignVote requests a remote signer to sign a vote
 func (sc *DashCoreSignerClient) SignVote(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, protoVote *tmproto.Vote) error {
-	if len(quorumHash) != crypto.DefaultHashSize {
+	start := time.Now()
+	err := sc.signVote(chainID, quorumType, quorumHash, protoVote)
+	sc.metrics.observeSign(signMsgType(protoVote.Type), quorumType, protoVote.Height, start, err)
+	return err
+}
+
+func (sc *DashCoreSignerClient) signVote(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, protoVote *tmproto.Vote) error {
+	if tmbytes.ValidateHash32(quorumHash) != nil {
 		return fmt.Errorf("quorum hash is not the right length %s", quorumHash.String())
 	}
+	if quorumType == 0 {
+		return fmt.Errorf("error signing vote with invalid quorum type")
+	}
+	sc.SetQuorumType(quorumHash, quorumType)
 	blockSignBytes := types.VoteBlockSignBytes(chainID, protoVote)
 	stateSignBytes := types.VoteStateSignBytes(chainID, protoVote)
 
@@ -223,23 +405,25 @@ func (sc *DashCoreSignerClient) SignVote(chainID string, quorumType btcjson.LLMQ
 
 	stateMessageHashString := strings.ToUpper(hex.EncodeToString(stateMessageHash))
 
-	blockRequestId := types.VoteBlockRequestIdProto(protoVote)
+	blockRequestId, err := tmbytes.Hash32FromHexBytes(types.VoteBlockRequestIdProto(protoVote))
+	if err != nil {
+		return fmt.Errorf("invalid block request id: %w", err)
+	}
 
-	blockRequestIdString := strings.ToUpper(hex.EncodeToString(blockRequestId))
+	blockRequestIdString := blockRequestId.String()
 
-	stateRequestId := types.VoteStateRequestIdProto(protoVote)
+	stateRequestId, err := tmbytes.Hash32FromHexBytes(types.VoteStateRequestIdProto(protoVote))
+	if err != nil {
+		return fmt.Errorf("invalid state request id: %w", err)
+	}
 
-	stateRequestIdString := strings.ToUpper(hex.EncodeToString(stateRequestId))
+	stateRequestIdString := stateRequestId.String()
 
 	// proTxHash, err := sc.GetProTxHash()
 
-	blockResponse, err := sc.endpoint.QuorumSign(quorumType, blockRequestIdString, blockMessageHashString, quorumHash.String(), false)
-
-	if blockResponse == nil {
-		return ErrUnexpectedResponse
-	}
+	blockResponse, err := sc.quorumSign(quorumType, blockRequestIdString, blockMessageHashString, quorumHash)
 	if err != nil {
-		return &RemoteSignerError{Code: 500, Description: err.Error()}
+		return err
 	}
 
 	//fmt.Printf("blockResponse %v", blockResponse)
@@ -252,30 +436,14 @@ func (sc *DashCoreSignerClient) SignVote(chainID string, quorumType btcjson.LLMQ
 		return fmt.Errorf("decoding signature %d is incorrect size when signing proposal : %v", len(blockDecodedSignature), err)
 	}
 
-	/// fmt.Printf("Signed Vote proTxHash %s blockSignBytes %s block signature %s \n", proTxHash, hex.EncodeToString(blockSignBytes),
-	//	hex.EncodeToString(blockDecodedSignature))
-
-	// signId := crypto.SignId(sc.defaultQuorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(blockRequestId), bls12381.ReverseBytes(blockMessageHash))
-
-	// fmt.Printf("core returned block requestId %s our block request Id %s\n", blockResponse.ID, blockRequestIdString)
-	//
-	// fmt.Printf("core block signId %s our block sign Id %s\n", blockResponse.SignHash, hex.EncodeToString(signId))
-	//
-	//pubKey, err := sc.GetPubKey(quorumHash)
-	//verified := pubKey.VerifySignatureDigest(signId, blockDecodedSignature)
-	//if verified {
-	//	fmt.Printf("Verified core signing with public key %v\n", pubKey)
-	//} else {
-	//	fmt.Printf("Unable to verify signature %v\n", pubKey)
-	//}
-
-	stateResponse, err := sc.endpoint.QuorumSign(sc.defaultQuorumType, stateRequestIdString, stateMessageHashString, quorumHash.String(), false)
-
-	if stateResponse == nil {
-		return ErrUnexpectedResponse
+	err = sc.verifySignature(quorumType, quorumHash, blockRequestId[:], blockMessageHash, blockDecodedSignature)
+	if err != nil {
+		return fmt.Errorf("error verifying block signature when signing vote : %w", err)
 	}
+
+	stateResponse, err := sc.quorumSign(quorumType, stateRequestIdString, stateMessageHashString, quorumHash)
 	if err != nil {
-		return &RemoteSignerError{Code: 500, Description: err.Error()}
+		return err
 	}
 
 	stateDecodedSignature, err := hex.DecodeString(stateResponse.Signature)
@@ -286,52 +454,79 @@ func (sc *DashCoreSignerClient) SignVote(chainID string, quorumType btcjson.LLMQ
 		return fmt.Errorf("decoding signature %d is incorrect size when signing proposal : %v", len(stateDecodedSignature), err)
 	}
 
+	err = sc.verifySignature(quorumType, quorumHash, stateRequestId[:], stateMessageHash, stateDecodedSignature)
+	if err != nil {
+		return fmt.Errorf("error verifying state signature when signing vote : %w", err)
+	}
 
-	// fmt.Printf("Signed Vote proTxHash %s stateSignBytes %s block signature %s \n", proTxHash, hex.EncodeToString(stateSignBytes),
-	// 	hex.EncodeToString(stateDecodedSignature))
+	protoVote.BlockSignature = blockDecodedSignature
+	protoVote.StateSignature = stateDecodedSignature
 
-	// stateSignId := crypto.SignId(sc.defaultQuorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(stateRequestId), bls12381.ReverseBytes(stateMessageHash))
+	if types.VoteExtensionRequested(protoVote) {
+		extensionMessageHash := crypto.Sha256(types.VoteExtensionSignBytes(chainID, protoVote))
 
-	// fmt.Printf("core returned state requestId %s our state request Id %s\n", stateResponse.ID, stateRequestIdString)
-	//
-	// fmt.Printf("core state signId %s our state sign Id %s\n", stateResponse.SignHash, hex.EncodeToString(stateSignId))
-	//
-	//stateVerified := pubKey.VerifySignatureDigest(stateSignId, stateDecodedSignature)
-	//if stateVerified {
-	//	fmt.Printf("Verified state core signing with public key %v\n", pubKey)
-	//} else {
-	//	fmt.Printf("Unable to verify state signature %v\n", pubKey)
-	//}
+		extensionRequestId, err := tmbytes.Hash32FromHexBytes(types.VoteExtensionRequestIdProto(protoVote))
+		if err != nil {
+			return fmt.Errorf("invalid extension request id: %w", err)
+		}
 
-	protoVote.BlockSignature = blockDecodedSignature
-	protoVote.StateSignature = stateDecodedSignature
+		extensionResponse, err := sc.quorumSign(quorumType, extensionRequestId.String(),
+			strings.ToUpper(hex.EncodeToString(extensionMessageHash)), quorumHash)
+		if err != nil {
+			return err
+		}
+
+		extensionDecodedSignature, err := hex.DecodeString(extensionResponse.Signature)
+		if err != nil {
+			return fmt.Errorf("error decoding signature when signing vote extension : %v", err)
+		}
+		if len(extensionDecodedSignature) != bls12381.SignatureSize {
+			return fmt.Errorf("decoding signature %d is incorrect size when signing vote extension",
+				len(extensionDecodedSignature))
+		}
+
+		err = sc.verifySignature(quorumType, quorumHash, extensionRequestId[:], extensionMessageHash,
+			extensionDecodedSignature)
+		if err != nil {
+			return fmt.Errorf("error verifying extension signature when signing vote : %w", err)
+		}
+
+		protoVote.ExtensionSignature = extensionDecodedSignature
+	}
 
 	return nil
 }
 
 // SignProposal requests a remote signer to sign a proposal
 func (sc *DashCoreSignerClient) SignProposal(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, proposalProto *tmproto.Proposal) ([]byte, error) {
+	start := time.Now()
+	signID, err := sc.signProposal(chainID, quorumType, quorumHash, proposalProto)
+	sc.metrics.observeSign(signMsgTypeProposal, quorumType, proposalProto.Height, start, err)
+	return signID, err
+}
+
+func (sc *DashCoreSignerClient) signProposal(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, proposalProto *tmproto.Proposal) ([]byte, error) {
 	messageBytes:= types.ProposalBlockSignBytes(chainID, proposalProto)
 
 	messageHash := crypto.Sha256(messageBytes)
 
 	messageHashString := strings.ToUpper(hex.EncodeToString(messageHash))
 
-	requestIdHash := types.ProposalRequestIdProto(proposalProto)
+	requestIdHash, err := tmbytes.Hash32FromHexBytes(types.ProposalRequestIdProto(proposalProto))
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal request id: %w", err)
+	}
 
-	requestIdHashString := strings.ToUpper(hex.EncodeToString(requestIdHash))
+	requestIdHashString := requestIdHash.String()
 
 	if quorumType == 0 {
 		return nil, fmt.Errorf("error signing proposal with invalid quorum type")
 	}
+	sc.SetQuorumType(quorumHash, quorumType)
 
-	response, err := sc.endpoint.QuorumSign(quorumType, requestIdHashString, messageHashString, quorumHash.String(), false)
-
-	if response == nil {
-		return nil, ErrUnexpectedResponse
-	}
+	response, err := sc.quorumSign(quorumType, requestIdHashString, messageHashString, quorumHash)
 	if err != nil {
-		return nil, &RemoteSignerError{Code: 500, Description: err.Error()}
+		return nil, err
 	}
 
 	decodedSignature, err := hex.DecodeString(response.Signature)
@@ -342,34 +537,247 @@ func (sc *DashCoreSignerClient) SignProposal(chainID string, quorumType btcjson.
 		return nil, fmt.Errorf("decoding signature %d is incorrect size when signing proposal : %v", len(decodedSignature), err)
 	}
 
-	//fmt.Printf("proposal message that is being signed %v\n", messageBytes)
-	//
-	//fmt.Printf("proposal response %v\n", response)
-	//
-	//fmt.Printf("Proposal signBytes %s signature %s \n", hex.EncodeToString(messageBytes),
-	//	hex.EncodeToString(decodedSignature))
-	//
-	//signId := crypto.SignId(sc.defaultQuorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(requestIdHash), bls12381.ReverseBytes(messageHash))
-	//
-	//fmt.Printf("core returned requestId %s our request Id %s\n", response.ID, requestIdHashString)
-	////
-	//fmt.Printf("core signId %s our sign Id %s\n", response.SignHash, hex.EncodeToString(signId))
-	////
-	//pubKey, err := sc.GetPubKey(quorumHash)
-	//verified := pubKey.VerifySignatureDigest(signId, decodedSignature)
-	//if verified {
-	//	fmt.Printf("Verified core signing with public key %v\n", pubKey)
-	//} else {
-	//	fmt.Printf("Unable to verify signature %v\n", pubKey)
-	//}
-
+	err = sc.verifySignature(quorumType, quorumHash, requestIdHash[:], messageHash, decodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying signature when signing proposal : %w", err)
+	}
 
 	proposalProto.Signature = decodedSignature
 
 	return nil, nil
 }
 
+// SignNodeID requests Dash Core to sign the node ID with the quorum, to prove
+// the ownership of the masternode to the peers. Implements types.NodeIDSigner.
+func (sc *DashCoreSignerClient) SignNodeID(chainID string, nodeID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash) ([]byte, error) {
+	if quorumType == 0 {
+		return nil, fmt.Errorf("error signing node ID with invalid quorum type")
+	}
+	proTxHash, err := sc.GetProTxHash()
+	if err != nil {
+		return nil, err
+	}
+
+	messageHash := crypto.Sha256(types.NodeIDSignBytes(chainID, nodeID))
+	requestID := types.NodeIDRequestId(proTxHash, nodeID)
+
+	return sc.QuorumSign(quorumType, quorumHash, requestID, messageHash)
+}
+
+// QuorumSign requests Dash Core to sign the message hash under the request ID
+// with the quorum, and verifies the returned signature share with the public
+// key share of the node. Implements types.QuorumSigner.
+func (sc *DashCoreSignerClient) QuorumSign(
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	requestID []byte,
+	messageHash []byte,
+) ([]byte, error) {
+	if quorumType == 0 {
+		return nil, fmt.Errorf("error signing with invalid quorum type")
+	}
+	if tmbytes.ValidateHash32(quorumHash) != nil {
+		return nil, fmt.Errorf("quorum hash is not the right length %s", quorumHash.String())
+	}
+	requestIDHash, err := tmbytes.Hash32FromHexBytes(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request id: %w", err)
+	}
+	if err := tmbytes.ValidateHash32(messageHash); err != nil {
+		return nil, fmt.Errorf("invalid message hash: %w", err)
+	}
+	sc.SetQuorumType(quorumHash, quorumType)
+
+	response, err := sc.quorumSign(quorumType, requestIDHash.String(),
+		strings.ToUpper(hex.EncodeToString(messageHash)), quorumHash)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedSignature, err := hex.DecodeString(response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature : %v", err)
+	}
+	if len(decodedSignature) != bls12381.SignatureSize {
+		return nil, fmt.Errorf("decoding signature %d is incorrect size", len(decodedSignature))
+	}
+
+	err = sc.verifySignature(quorumType, quorumHash, requestIDHash[:], messageHash, decodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying signature : %w", err)
+	}
+	return decodedSignature, nil
+}
+
 func (sc *DashCoreSignerClient) UpdatePrivateKey(privateKey crypto.PrivKey, quorumHash crypto.QuorumHash,  height int64) error {
 	// the private key is dealt with on the abci client
 	return nil
 }
+
+// quorumSign requests Dash Core to sign the message hash with the quorum
+func (sc *DashCoreSignerClient) quorumSign(
+	quorumType btcjson.LLMQType,
+	requestID string,
+	messageHash string,
+	quorumHash crypto.QuorumHash,
+) (*btcjson.QuorumSignResultWithBool, error) {
+	var response *btcjson.QuorumSignResultWithBool
+	err := sc.call(func(client *rpc.Client) (err error) {
+		response, err = client.QuorumSign(quorumType, requestID, messageHash, quorumHash.String(), false)
+		return err
+	})
+	if err != nil {
+		var rpcErr *btcjson.RPCError
+		if errors.As(err, &rpcErr) {
+			// the quorum might be retired, don't keep it in the cache
+			sc.quorums.Remove(newQuorumKey(quorumType, quorumHash))
+		}
+		if errors.Is(err, ErrCoreUnavailable) {
+			return nil, err
+		}
+		return nil, &RemoteSignerError{Code: 500, Description: err.Error()}
+	}
+	if response == nil {
+		return nil, ErrUnexpectedResponse
+	}
+	if !strings.EqualFold(response.QuorumHash, quorumHash.String()) {
+		// Dash Core signed with another quorum, the cached one is outdated
+		sc.quorums.Remove(newQuorumKey(quorumType, quorumHash))
+	}
+	return response, nil
+}
+
+// quorumInfo returns the quorum info of the quorum, the info is requested from
+// Dash Core only if the quorum isn't cached.
+func (sc *DashCoreSignerClient) quorumInfo(
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+) (*btcjson.QuorumInfoResult, error) {
+	key := newQuorumKey(quorumType, quorumHash)
+	if info := sc.quorums.Get(key); info != nil {
+		return info, nil
+	}
+	var response *btcjson.QuorumInfoResult
+	err := sc.call(func(client *rpc.Client) (err error) {
+		response, err = client.QuorumInfo(quorumType, quorumHash.String(), false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, ErrUnexpectedResponse
+	}
+	sc.quorums.Put(key, response)
+	return response, nil
+}
+
+// SetQuorumType sets the quorum type of the quorum, which is used to look up
+// its keys in Dash Core.
+func (sc *DashCoreSignerClient) SetQuorumType(quorumHash crypto.QuorumHash, quorumType btcjson.LLMQType) {
+	if quorumType == 0 || len(quorumHash) != crypto.QuorumHashSize {
+		return
+	}
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	sc.quorumTypes[quorumHash.String()] = quorumType
+}
+
+// quorumType returns the quorum type of the quorum, the default quorum type if
+// it's unknown.
+func (sc *DashCoreSignerClient) quorumType(quorumHash crypto.QuorumHash) btcjson.LLMQType {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	if quorumType, ok := sc.quorumTypes[quorumHash.String()]; ok {
+		return quorumType
+	}
+	return sc.defaultQuorumType
+}
+
+// Refresh drops the cached quorums, so their info is requested from Dash Core
+// again.
+func (sc *DashCoreSignerClient) Refresh() {
+	sc.quorums.Reset()
+}
+
+// verifySignature verifies the signature share returned by Dash Core with the
+// public key share of the node. The cached quorums are refreshed once if the
+// verification fails, in case the cached public key share is outdated.
+func (sc *DashCoreSignerClient) verifySignature(
+	quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash,
+	requestID []byte,
+	messageHash []byte,
+	signature []byte,
+) error {
+	// Dash Core displays the hashes in the reversed byte order
+	signID := crypto.SignId(
+		quorumType,
+		bls12381.ReverseBytes(quorumHash),
+		bls12381.ReverseBytes(requestID),
+		bls12381.ReverseBytes(messageHash),
+	)
+	for refreshed := false; ; refreshed = true {
+		pubKey, err := sc.getPubKey(quorumType, quorumHash)
+		if err != nil {
+			return err
+		}
+		if pubKey == nil {
+			// we are not a member of the quorum, there is nothing to verify with
+			return nil
+		}
+		if pubKey.VerifySignatureDigest(signID, signature) {
+			return nil
+		}
+		if refreshed {
+			return fmt.Errorf("invalid signature share for quorum %s", quorumHash.String())
+		}
+		sc.logger.Info("Refreshing the cached quorums, failed to verify a signature share",
+			"quorum_hash", quorumHash)
+		sc.Refresh()
+	}
+}
+
+// bestChainLockResult is the result of the getbestchainlock request, which
+// isn't supported by the RPC client.
+type bestChainLockResult struct {
+	BlockHash string `json:"blockhash"`
+	Height    uint32 `json:"height"`
+	Signature string `json:"signature"`
+}
+
+// GetBestChainLock requests the best chain lock known by Dash Core. It returns
+// ErrNoChainLock, if Dash Core doesn't know any chain lock yet. The block hash
+// is in the byte order of the response. Implements types.ChainLockProvider.
+func (sc *DashCoreSignerClient) GetBestChainLock() (*types.CoreChainLock, error) {
+	var raw []byte
+	err := sc.call(func(client *rpc.Client) (err error) {
+		raw, err = client.RawRequest("getbestchainlock", nil)
+		return err
+	})
+	var rpcErr *btcjson.RPCError
+	if errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "Unable to find any ChainLock") {
+		return nil, ErrNoChainLock
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result bestChainLockResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("error decoding best chain lock: %w", err)
+	}
+	blockHash, err := hex.DecodeString(result.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding chain lock block hash: %w", err)
+	}
+	signature, err := hex.DecodeString(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding chain lock signature: %w", err)
+	}
+	return &types.CoreChainLock{
+		CoreBlockHeight: result.Height,
+		CoreBlockHash:   blockHash,
+		Signature:       signature,
+	}, nil
+}
@@ -1,7 +1,6 @@
 package privval
 
 import (
-	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -55,10 +54,32 @@ type FilePVKey struct {
 	// quorumHash -> heightString
 	FirstHeightOfQuorums map[string]string `json:"first_height_of_quorums"`
 	ProTxHash            crypto.ProTxHash `json:"pro_tx_hash"`
+	// quorumHash -> the rotation of the key of the quorum in progress
+	Rotations map[string]KeyRotation `json:"rotations,omitempty"`
 
 	filePath string
 }
 
+// KeyRotation is a new key of a quorum, written alongside the old one. During
+// the transition window of the rotation, the key whose public key is the one
+// of the validator in the validator set of the height signs, so that the node
+// keeps signing until the validator set is updated with the new key. The new
+// key replaces the old one once the window ends.
+type KeyRotation struct {
+	PrivKey crypto.PrivKey `json:"priv_key"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
+	// the first height of the transition window
+	Height int64 `json:"height"`
+	// the number of heights of the transition window
+	TransitionBlocks int64 `json:"transition_blocks"`
+}
+
+// EndHeight returns the first height after the transition window, from
+// which the new key always signs.
+func (r KeyRotation) EndHeight() int64 {
+	return r.Height + r.TransitionBlocks
+}
+
 // Save persists the FilePVKey to its filePath.
 func (pvKey FilePVKey) Save() {
 	outFile := pvKey.filePath
@@ -110,6 +131,9 @@ type FilePVLastSignState struct {
 	BlockSignBytes tmbytes.HexBytes `json:"block_sign_bytes,omitempty"`
 	StateSignature []byte           `json:"state_signature,omitempty"`
 	StateSignBytes tmbytes.HexBytes `json:"state_sign_bytes,omitempty"`
+	// ExtensionSignature and ExtensionSignBytes are set for the precommits with a vote extension
+	ExtensionSignature []byte           `json:"extension_signature,omitempty"`
+	ExtensionSignBytes tmbytes.HexBytes `json:"extension_sign_bytes,omitempty"`
 
 	filePath string
 }
@@ -187,8 +211,26 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	metrics *Metrics
+
+	// quorumHash -> the public key of the validator in the last validator set
+	// of the quorum, to select the key signing during a rotation
+	validatorPubKeys map[string]validatorPubKey
+}
+
+// validatorPubKey is the public key of the validator in the validator set of
+// a height.
+type validatorPubKey struct {
+	height int64
+	pubKey crypto.PubKey
 }
 
+var (
+	_ types.ValidatorPubKeySetter = (*FilePV)(nil)
+	_ types.QuorumSigner          = (*FilePV)(nil)
+)
+
 // FilePVOption ...
 type FilePVOption func(filePV *FilePV) error
 
@@ -381,10 +423,14 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return pv
 }
 
-// GetPubKey returns the public key of the validator.
+// GetPubKey returns the public key of the validator. During a rotation of the
+// key of the quorum, it is the new key once the validator set uses it.
 // Implements PrivValidator.
 func (pv *FilePV) GetPubKey(quorumHash crypto.QuorumHash) (crypto.PubKey, error) {
 	if keys, ok := pv.Key.PrivateKeys[quorumHash.String()]; ok {
+		if rotation, ok := pv.Key.Rotations[quorumHash.String()]; ok && pv.usesRotatedKey(quorumHash, rotation) {
+			return rotation.PubKey, nil
+		}
 		return keys.PubKey, nil
 	}
 	return nil, fmt.Errorf("no public key for quorum hash %v", quorumHash)
@@ -459,7 +505,10 @@ func (pv *FilePV) GetProTxHash() (crypto.ProTxHash, error) {
 // SignVote signs a canonical representation of the vote, along with the
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, vote *tmproto.Vote) error {
-	if err := pv.signVote(chainID, quorumType, quorumHash, vote); err != nil {
+	start := time.Now()
+	err := pv.signVote(chainID, quorumType, quorumHash, vote)
+	pv.metrics.observeSign(signMsgType(vote.Type), quorumType, vote.Height, start, err)
+	if err != nil {
 		return fmt.Errorf("error signing vote: %v", err)
 	}
 	return nil
@@ -468,13 +517,127 @@ func (pv *FilePV) SignVote(chainID string, quorumType btcjson.LLMQType, quorumHa
 // SignProposal signs a canonical representation of the proposal, along with
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, proposal *tmproto.Proposal) ([]byte, error) {
+	start := time.Now()
 	signId, err := pv.signProposal(chainID, quorumType, quorumHash, proposal)
+	pv.metrics.observeSign(signMsgTypeProposal, quorumType, proposal.Height, start, err)
 	if err != nil {
 		return signId, fmt.Errorf("error signing proposal: %v", err)
 	}
 	return signId, nil
 }
 
+// SignNodeID signs the node ID with the key of the quorum, to prove the
+// ownership of the masternode to the peers. Implements types.NodeIDSigner.
+func (pv *FilePV) SignNodeID(chainID string, nodeID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash) ([]byte, error) {
+	privKey, ok := pv.privKeyForHeight(quorumHash, pv.LastSignState.Height)
+	if !ok {
+		return nil, fmt.Errorf("file private validator could not sign node ID for quorum hash %v", quorumHash)
+	}
+	signId := types.NodeIDSignId(chainID, nodeID, pv.Key.ProTxHash, quorumType, quorumHash)
+	return privKey.SignDigest(signId)
+}
+
+// QuorumSign signs the message hash under the request ID with the key of the
+// quorum. Implements types.QuorumSigner.
+func (pv *FilePV) QuorumSign(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, requestID []byte,
+	messageHash []byte) ([]byte, error) {
+	privKey, ok := pv.privKeyForHeight(quorumHash, pv.LastSignState.Height)
+	if !ok {
+		return nil, fmt.Errorf("file private validator could not sign for quorum hash %v", quorumHash)
+	}
+	signId := crypto.SignId(quorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(requestID),
+		bls12381.ReverseBytes(messageHash))
+	return privKey.SignDigest(signId)
+}
+
+// SetValidatorPubKey sets the public key of the validator in the validator set
+// of the height, which selects the key signing during a rotation.
+// Implements types.ValidatorPubKeySetter.
+func (pv *FilePV) SetValidatorPubKey(height int64, quorumHash crypto.QuorumHash, pubKey crypto.PubKey) {
+	if pv.validatorPubKeys == nil {
+		pv.validatorPubKeys = make(map[string]validatorPubKey)
+	}
+	pv.validatorPubKeys[quorumHash.String()] = validatorPubKey{height: height, pubKey: pubKey}
+}
+
+// RotateKey writes the new key alongside the key of each quorum, which both
+// sign during the transition window of the given number of heights, from the
+// given height. It fails if a rotation is already in progress.
+func (pv *FilePV) RotateKey(privKey crypto.PrivKey, height, transitionBlocks int64) error {
+	if len(pv.Key.PrivateKeys) == 0 {
+		return errors.New("there is no key to rotate")
+	}
+	if height <= 0 {
+		return fmt.Errorf("rotation height must be positive, got %d", height)
+	}
+	if transitionBlocks <= 0 {
+		return fmt.Errorf("transition blocks must be positive, got %d", transitionBlocks)
+	}
+	for quorumHash, rotation := range pv.Key.Rotations {
+		return fmt.Errorf("the rotation of the key of quorum %s until height %d is in progress",
+			quorumHash, rotation.EndHeight())
+	}
+
+	pv.Key.Rotations = make(map[string]KeyRotation, len(pv.Key.PrivateKeys))
+	for quorumHash := range pv.Key.PrivateKeys {
+		pv.Key.Rotations[quorumHash] = KeyRotation{
+			PrivKey:          privKey,
+			PubKey:           privKey.PubKey(),
+			Height:           height,
+			TransitionBlocks: transitionBlocks,
+		}
+	}
+	pv.Key.Save()
+	return nil
+}
+
+// privKeyForHeight returns the key of the quorum signing at the height. During
+// the transition window of a rotation, it is the new key if the validator set
+// of the height uses it. After the window, the new key replaces the old one.
+func (pv *FilePV) privKeyForHeight(quorumHash crypto.QuorumHash, height int64) (crypto.PrivKey, bool) {
+	quorumKeys, ok := pv.Key.PrivateKeys[quorumHash.String()]
+	if !ok {
+		return nil, false
+	}
+	rotation, ok := pv.Key.Rotations[quorumHash.String()]
+	switch {
+	case !ok || height < rotation.Height:
+		return quorumKeys.PrivKey, true
+	case height >= rotation.EndHeight():
+		pv.completeRotation(quorumHash, rotation)
+		return rotation.PrivKey, true
+	case pv.usesRotatedKey(quorumHash, rotation):
+		return rotation.PrivKey, true
+	default:
+		return quorumKeys.PrivKey, true
+	}
+}
+
+// usesRotatedKey returns true if the last validator set of the quorum uses the
+// new key of the rotation.
+func (pv *FilePV) usesRotatedKey(quorumHash crypto.QuorumHash, rotation KeyRotation) bool {
+	val, ok := pv.validatorPubKeys[quorumHash.String()]
+	return ok && val.height >= rotation.Height && val.pubKey != nil && val.pubKey.Equals(rotation.PubKey)
+}
+
+// completeRotation replaces the key of the quorum by the new key of the
+// rotation, and persists it.
+func (pv *FilePV) completeRotation(quorumHash crypto.QuorumHash, rotation KeyRotation) {
+	quorumKeys := pv.Key.PrivateKeys[quorumHash.String()]
+	quorumKeys.PrivKey = rotation.PrivKey
+	quorumKeys.PubKey = rotation.PubKey
+	pv.Key.PrivateKeys[quorumHash.String()] = quorumKeys
+	delete(pv.Key.Rotations, quorumHash.String())
+	if pv.Key.filePath != "" {
+		pv.Key.Save()
+	}
+}
+
+// SetMetrics sets the metrics of the sign requests.
+func (pv *FilePV) SetMetrics(metrics *Metrics) {
+	pv.metrics = metrics
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
@@ -493,9 +656,27 @@ func (pv *FilePV) Reset() {
 	pv.LastSignState.StateSignature = stateSig
 	pv.LastSignState.BlockSignBytes = nil
 	pv.LastSignState.StateSignBytes = nil
+	pv.LastSignState.ExtensionSignature = nil
+	pv.LastSignState.ExtensionSignBytes = nil
 	pv.Save()
 }
 
+// RollbackLastSignState rewinds the last sign state to the given height, if
+// it's above it, so that the heights above it can be signed again after the
+// node was rolled back to the height. It returns whether the state changed.
+// NOTE: Unsafe, unless the blocks signed above the height were discarded!
+func (pv *FilePV) RollbackLastSignState(height int64) bool {
+	if pv.LastSignState.Height <= height {
+		return false
+	}
+	pv.LastSignState = FilePVLastSignState{
+		Height:   height,
+		filePath: pv.LastSignState.filePath,
+	}
+	pv.LastSignState.Save()
+	return true
+}
+
 // String returns a string representation of the FilePV.
 func (pv *FilePV) String() string {
 	return fmt.Sprintf(
@@ -508,6 +689,12 @@ func (pv *FilePV) String() string {
 }
 
 func (pv *FilePV) UpdatePrivateKey(privateKey crypto.PrivKey, quorumHash crypto.QuorumHash, height int64) error {
+	if pv.Key.UpdateHeights == nil {
+		pv.Key.UpdateHeights = make(map[string]crypto.QuorumHash)
+	}
+	if pv.Key.FirstHeightOfQuorums == nil {
+		pv.Key.FirstHeightOfQuorums = make(map[string]string)
+	}
 	pv.Key.PrivateKeys[quorumHash.String()] = crypto.QuorumKeys{
 		PrivKey: privateKey,
 		PubKey: privateKey.PubKey(),
@@ -549,6 +736,12 @@ func (pv *FilePV) signVote(chainID string, quorumType btcjson.LLMQType, quorumHa
 
 	stateSignBytes := types.VoteStateSignBytes(chainID, vote)
 
+	// the extension is set by consensus, if vote extensions are enabled
+	var extensionSignBytes []byte
+	if types.VoteExtensionRequested(vote) {
+		extensionSignBytes = types.VoteExtensionSignBytes(chainID, vote)
+	}
+
 	// We might crash before writing to the wal,
 	// causing us to try to re-sign for the same HRS.
 	// If signbytes are the same, use the last signature.
@@ -556,19 +749,19 @@ func (pv *FilePV) signVote(chainID string, quorumType btcjson.LLMQType, quorumHa
 	// Otherwise, return error
 	if sameHRS {
 
-		if bytes.Equal(blockSignBytes, lss.BlockSignBytes) && bytes.Equal(stateSignBytes, lss.StateSignBytes) {
+		if lss.BlockSignBytes.ConstantTimeEqual(blockSignBytes) && lss.StateSignBytes.ConstantTimeEqual(stateSignBytes) &&
+			lss.ExtensionSignBytes.ConstantTimeEqual(extensionSignBytes) {
 			vote.BlockSignature = lss.BlockSignature
 			vote.StateSignature = lss.StateSignature
+			vote.ExtensionSignature = lss.ExtensionSignature
 		} else {
 			err = fmt.Errorf("conflicting data")
 		}
 		return err
 	}
 
-	var privKey crypto.PrivKey
-	if quorumKeys, ok := pv.Key.PrivateKeys[quorumHash.String()]; ok {
-		privKey = quorumKeys.PrivKey
-	} else {
+	privKey, ok := pv.privKeyForHeight(quorumHash, height)
+	if !ok {
 		return fmt.Errorf("file private validator could not sign vote for quorum hash %v", quorumHash)
 	}
 
@@ -585,6 +778,14 @@ func (pv *FilePV) signVote(chainID string, quorumType btcjson.LLMQType, quorumHa
 		}
 	}
 
+	var sigExtension []byte
+	if extensionSignBytes != nil {
+		sigExtension, err = privKey.SignDigest(types.VoteExtensionSignId(chainID, vote, quorumType, quorumHash))
+		if err != nil {
+			return err
+		}
+	}
+
 	//  if vote.BlockID.Hash == nil {
 	//	  fmt.Printf("***********we are signing NIL (%d/%d) %X signed (file) for vote %v blockSignBytes %X\n",
 	//	    vote.Height, vote.Round, sigBlock, vote, blockSignBytes)
@@ -593,10 +794,12 @@ func (pv *FilePV) signVote(chainID string, quorumType btcjson.LLMQType, quorumHa
 	//	   sigBlock, vote)
 	//  }
 
-	pv.saveSigned(height, round, step, blockSignBytes, sigBlock, stateSignBytes, sigState)
+	pv.saveSigned(height, round, step, blockSignBytes, sigBlock, stateSignBytes, sigState,
+		extensionSignBytes, sigExtension)
 
 	vote.BlockSignature = sigBlock
 	vote.StateSignature = sigState
+	vote.ExtensionSignature = sigExtension
 
 	return nil
 }
@@ -624,21 +827,19 @@ func (pv *FilePV) signProposal(chainID string, quorumType btcjson.LLMQType, quor
 	// If they only differ by timestamp, use last timestamp and signature
 	// Otherwise, return error
 	if sameHRS {
-		if bytes.Equal(blockSignBytes, lss.BlockSignBytes) {
-			proposal.Signature = lss.BlockSignBytes
+		if lss.BlockSignBytes.ConstantTimeEqual(blockSignBytes) {
+			proposal.Signature = lss.BlockSignature
 		} else if timestamp, ok := checkProposalsOnlyDifferByTimestamp(lss.BlockSignBytes, blockSignBytes); ok {
 			proposal.Timestamp = timestamp
-			proposal.Signature = lss.BlockSignBytes
+			proposal.Signature = lss.BlockSignature
 		} else {
 			err = fmt.Errorf("conflicting data")
 		}
 		return blockSignId, err
 	}
 
-	var privKey crypto.PrivKey
-	if quorumKeys, ok := pv.Key.PrivateKeys[quorumHash.String()]; ok {
-		privKey = quorumKeys.PrivKey
-	} else {
+	privKey, ok := pv.privKeyForHeight(quorumHash, height)
+	if !ok {
 		return blockSignId, fmt.Errorf("file private validator could not sign vote for quorum hash %v", quorumHash)
 	}
 
@@ -650,14 +851,15 @@ func (pv *FilePV) signProposal(chainID string, quorumType btcjson.LLMQType, quor
 	// fmt.Printf("file proposer %X \nsigning proposal at height %d \nwith key %X \nproposalSignId %X\n signature %X\n", pv.Key.ProTxHash,
 	//  proposal.Height, pv.Key.PrivKey.PubKey().Bytes(), blockSignId, blockSig)
 
-	pv.saveSigned(height, round, step, blockSignBytes, blockSig, nil, nil)
+	pv.saveSigned(height, round, step, blockSignBytes, blockSig, nil, nil, nil, nil)
 	proposal.Signature = blockSig
 	return blockSignId, nil
 }
 
 // Persist height/round/step and signature
 func (pv *FilePV) saveSigned(height int64, round int32, step int8,
-	blockSignBytes []byte, blockSig []byte, stateSignBytes []byte, stateSig []byte) {
+	blockSignBytes []byte, blockSig []byte, stateSignBytes []byte, stateSig []byte,
+	extensionSignBytes []byte, extensionSig []byte) {
 
 	pv.LastSignState.Height = height
 	pv.LastSignState.Round = round
@@ -666,6 +868,8 @@ func (pv *FilePV) saveSigned(height int64, round int32, step int8,
 	pv.LastSignState.BlockSignBytes = blockSignBytes
 	pv.LastSignState.StateSignature = stateSig
 	pv.LastSignState.StateSignBytes = stateSignBytes
+	pv.LastSignState.ExtensionSignature = extensionSig
+	pv.LastSignState.ExtensionSignBytes = extensionSignBytes
 	pv.LastSignState.Save()
 }
 
@@ -255,6 +255,112 @@ func TestSignVote(t *testing.T) {
 	assert.Equal(stateSignature, vote.StateSignature)
 }
 
+func TestSignVoteExtension(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.NoError(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.NoError(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	quorumHash, err := privVal.GetFirstQuorumHash()
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey(quorumHash)
+	require.NoError(t, err)
+
+	randbytes := tmrand.Bytes(tmhash.Size)
+	block := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	state := types.StateID{LastAppHash: tmrand.Bytes(tmhash.Size)}
+
+	vote := newVote(privVal.Key.ProTxHash, 0, 10, 1, tmproto.PrecommitType, block, state)
+	vote.Extension = []byte("extension")
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", 0, quorumHash, v))
+	assert.True(t, pubKey.VerifySignatureDigest(types.VoteExtensionSignId("mychainid", v, 0, quorumHash),
+		v.ExtensionSignature))
+
+	// the same extension reuses the last signature
+	extensionSignature := v.ExtensionSignature
+	v.ExtensionSignature = nil
+	require.NoError(t, privVal.SignVote("mychainid", 0, quorumHash, v))
+	assert.Equal(t, extensionSignature, v.ExtensionSignature)
+
+	// another extension conflicts
+	v.Extension = []byte("other extension")
+	assert.Error(t, privVal.SignVote("mychainid", 0, quorumHash, v))
+
+	// a nil extension isn't signed
+	vote = newVote(privVal.Key.ProTxHash, 0, 11, 1, tmproto.PrecommitType, block, state)
+	v = vote.ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", 0, quorumHash, v))
+	assert.Nil(t, v.ExtensionSignature)
+}
+
+func TestSignVoteKeyRotation(t *testing.T) {
+	testCases := []struct {
+		name string
+		// the height, from which the validator set uses the new key, 0 if it
+		// isn't updated during the transition window
+		updateHeight int64
+	}{
+		{"validator set updated two heights after the rotation", 12},
+		{"validator set not updated", 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+			require.NoError(t, err)
+			tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+			require.NoError(t, err)
+
+			privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+			quorumHash, err := privVal.GetFirstQuorumHash()
+			require.NoError(t, err)
+			oldPubKey, err := privVal.GetPubKey(quorumHash)
+			require.NoError(t, err)
+
+			// the transition window is [10, 15)
+			newPrivKey := bls12381.GenPrivKey()
+			require.NoError(t, privVal.RotateKey(newPrivKey, 10, 5))
+			assert.Error(t, privVal.RotateKey(bls12381.GenPrivKey(), 10, 5), "rotation in progress")
+
+			randbytes := tmrand.Bytes(tmhash.Size)
+			block := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+			state := types.StateID{LastAppHash: tmrand.Bytes(tmhash.Size)}
+
+			for height := int64(8); height <= 16; height++ {
+				valPubKey := oldPubKey
+				if tc.updateHeight > 0 && height >= tc.updateHeight {
+					valPubKey = newPrivKey.PubKey()
+				}
+				privVal.SetValidatorPubKey(height, quorumHash, valPubKey)
+
+				// the new key always signs after the transition window
+				expected := valPubKey
+				if height >= 15 {
+					expected = newPrivKey.PubKey()
+				}
+				v := newVote(privVal.Key.ProTxHash, 0, height, 0, tmproto.PrevoteType, block, state).ToProto()
+				require.NoError(t, privVal.SignVote("mychainid", 0, quorumHash, v))
+				assert.True(t, expected.VerifySignatureDigest(types.VoteBlockSignId("mychainid", v, 0, quorumHash),
+					v.BlockSignature), "height %d", height)
+
+				pubKey, err := privVal.GetPubKey(quorumHash)
+				require.NoError(t, err)
+				assert.Equal(t, expected, pubKey, "height %d", height)
+			}
+
+			// the new key replaced the old one
+			assert.Empty(t, privVal.Key.Rotations)
+			loaded := LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+			pubKey, err := loaded.GetPubKey(quorumHash)
+			require.NoError(t, err)
+			assert.Equal(t, newPrivKey.PubKey(), pubKey)
+			assert.Empty(t, loaded.Key.Rotations)
+		})
+	}
+}
+
 func TestSignProposal(t *testing.T) {
 	assert := assert.New(t)
 
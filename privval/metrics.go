@@ -0,0 +1,150 @@
+package privval
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of consecutive failed requests to Dash Core.
+	CoreConsecutiveFailures metrics.Gauge
+	// Time to sign a vote in seconds.
+	SignVoteSeconds metrics.Histogram
+	// Time to sign a proposal in seconds.
+	SignProposalSeconds metrics.Histogram
+	// Number of failed sign requests.
+	SignFailures metrics.Counter
+	// Height of the last successful signature.
+	LastSignedHeight metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		CoreConsecutiveFailures: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "core_consecutive_failures",
+			Help:      "Number of consecutive failed requests to Dash Core.",
+		}, labels).With(labelsAndValues...),
+		SignVoteSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_vote_seconds",
+			Help:      "Time to sign a vote in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 14),
+		}, append(labels, "type", "quorum_type")).With(labelsAndValues...),
+		SignProposalSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_proposal_seconds",
+			Help:      "Time to sign a proposal in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 14),
+		}, append(labels, "type", "quorum_type")).With(labelsAndValues...),
+		SignFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_failures",
+			Help:      "Number of failed sign requests.",
+		}, append(labels, "type", "class")).With(labelsAndValues...),
+		LastSignedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "last_signed_height",
+			Help:      "Height of the last successful signature.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		CoreConsecutiveFailures: discard.NewGauge(),
+		SignVoteSeconds:         discard.NewHistogram(),
+		SignProposalSeconds:     discard.NewHistogram(),
+		SignFailures:            discard.NewCounter(),
+		LastSignedHeight:        discard.NewGauge(),
+	}
+}
+
+// observeSign records the duration and the outcome of a sign request, nil
+// metrics are ignored.
+func (m *Metrics) observeSign(
+	msgType string,
+	quorumType btcjson.LLMQType,
+	height int64,
+	start time.Time,
+	err error,
+) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.SignFailures.With("type", msgType, "class", signErrorClass(err)).Add(1)
+		return
+	}
+	histogram := m.SignVoteSeconds
+	if msgType == signMsgTypeProposal {
+		histogram = m.SignProposalSeconds
+	}
+	histogram.With("type", msgType, "quorum_type", strconv.Itoa(int(quorumType))).
+		Observe(time.Since(start).Seconds())
+	m.LastSignedHeight.Set(float64(height))
+}
+
+const signMsgTypeProposal = "proposal"
+
+// signMsgType returns the type label of a vote.
+func signMsgType(voteType tmproto.SignedMsgType) string {
+	switch voteType {
+	case tmproto.PrevoteType:
+		return "prevote"
+	case tmproto.PrecommitType:
+		return "precommit"
+	default:
+		return "unknown"
+	}
+}
+
+// signErrorClass returns the class label of a sign error.
+func signErrorClass(err error) string {
+	var (
+		timeoutErr EndpointTimeoutError
+		remoteErr  *RemoteSignerError
+	)
+	switch {
+	case errors.Is(err, ErrCoreUnavailable):
+		return "core_unavailable"
+	case errors.Is(err, ErrNoConnection),
+		errors.Is(err, ErrReadTimeout),
+		errors.Is(err, ErrWriteTimeout),
+		errors.As(err, &timeoutErr):
+		return "connection"
+	case errors.As(err, &remoteErr):
+		return "remote"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,77 @@
+package privval
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestFilePVMetrics(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.NoError(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.NoError(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.SetMetrics(PrometheusMetrics("test_file_pv", "chain_id", "mychainid"))
+	quorumHash, err := privVal.GetFirstQuorumHash()
+	require.NoError(t, err)
+
+	block := types.BlockID{Hash: tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: tmrand.Bytes(tmhash.Size)}}
+	state := types.StateID{LastAppHash: tmrand.Bytes(tmhash.Size)}
+
+	vote := newVote(privVal.Key.ProTxHash, 0, 10, 1, tmproto.PrevoteType, block, state).ToProto()
+	require.NoError(t, privVal.SignVote("mychainid", 100, quorumHash, vote))
+	proposal := newProposal(11, 1, 0, block).ToProto()
+	_, err = privVal.SignProposal("mychainid", 100, quorumHash, proposal)
+	require.NoError(t, err)
+	// height regression
+	vote = newVote(privVal.Key.ProTxHash, 0, 9, 1, tmproto.PrecommitType, block, state).ToProto()
+	require.Error(t, privVal.SignVote("mychainid", 100, quorumHash, vote))
+
+	for name, count := range map[string]int{
+		"test_file_pv_privval_sign_vote_seconds":     1,
+		"test_file_pv_privval_sign_proposal_seconds": 1,
+	} {
+		n, err := testutil.GatherAndCount(prometheus.DefaultGatherer, name)
+		require.NoError(t, err)
+		assert.Equal(t, count, n, name)
+	}
+	expected := `
+# HELP test_file_pv_privval_last_signed_height Height of the last successful signature.
+# TYPE test_file_pv_privval_last_signed_height gauge
+test_file_pv_privval_last_signed_height{chain_id="mychainid"} 11
+# HELP test_file_pv_privval_sign_failures Number of failed sign requests.
+# TYPE test_file_pv_privval_sign_failures counter
+test_file_pv_privval_sign_failures{chain_id="mychainid",class="other",type="precommit"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected),
+		"test_file_pv_privval_last_signed_height", "test_file_pv_privval_sign_failures"))
+}
+
+func TestSignErrorClass(t *testing.T) {
+	testCases := []struct {
+		err   error
+		class string
+	}{
+		{err: ErrCoreUnavailable, class: "core_unavailable"},
+		{err: ErrReadTimeout, class: "connection"},
+		{err: ErrConnectionTimeout, class: "connection"},
+		{err: &RemoteSignerError{Code: 500, Description: "quorum not found"}, class: "remote"},
+		{err: ErrUnexpectedResponse, class: "other"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.class, signErrorClass(tc.err), tc.err.Error())
+	}
+}
@@ -24,6 +24,10 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_ProTxHashRequest{ProTxHashRequest: pb}
 	case *privvalproto.ProTxHashResponse:
 		msg.Sum = &privvalproto.Message_ProTxHashResponse{ProTxHashResponse: pb}
+	case *privvalproto.ThresholdPubKeyRequest:
+		msg.Sum = &privvalproto.Message_ThresholdPubKeyRequest{ThresholdPubKeyRequest: pb}
+	case *privvalproto.ThresholdPubKeyResponse:
+		msg.Sum = &privvalproto.Message_ThresholdPubKeyResponse{ThresholdPubKeyResponse: pb}
 	case *privvalproto.SignVoteRequest:
 		msg.Sum = &privvalproto.Message_SignVoteRequest{SignVoteRequest: pb}
 	case *privvalproto.SignedVoteResponse:
@@ -32,6 +36,10 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_SignedProposalResponse{SignedProposalResponse: pb}
 	case *privvalproto.SignProposalRequest:
 		msg.Sum = &privvalproto.Message_SignProposalRequest{SignProposalRequest: pb}
+	case *privvalproto.QuorumSignRequest:
+		msg.Sum = &privvalproto.Message_QuorumSignRequest{QuorumSignRequest: pb}
+	case *privvalproto.QuorumSignResponse:
+		msg.Sum = &privvalproto.Message_QuorumSignResponse{QuorumSignResponse: pb}
 	case *privvalproto.PingRequest:
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
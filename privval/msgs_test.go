@@ -62,8 +62,11 @@ func exampleProposal() *types.Proposal {
 
 // nolint:lll // ignore line length for tests
 func TestPrivvalVectors(t *testing.T) {
-	pk := bls12381.GenPrivKeyFromSecret([]byte("it's a secret")).PubKey()
-	ppk, err := cryptoenc.PubKeyToProto(pk)
+	// a constant key keeps the vectors independent of the key derivation
+	pkBytes, err := hex.DecodeString(
+		"11c7f5ac5a6d01fd9dde3840f7ebbb6a20deed6fba72a347dd66da2f8c9c977c6604b2cd2e0148206c2add9a8f5ddd74")
+	require.NoError(t, err)
+	ppk, err := cryptoenc.PubKeyToProto(bls12381.PubKey(pkBytes))
 	require.NoError(t, err)
 
 	// Generate a simple vote
@@ -18,9 +18,14 @@ import (
 type SignerClient struct {
 	endpoint *SignerListenerEndpoint
 	chainID  string
+	metrics  *Metrics
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator = (*SignerClient)(nil)
+	_ types.NodeIDSigner  = (*SignerClient)(nil)
+	_ types.QuorumSigner  = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -31,7 +36,12 @@ func NewSignerClient(endpoint *SignerListenerEndpoint, chainID string) (*SignerC
 		}
 	}
 
-	return &SignerClient{endpoint: endpoint, chainID: chainID}, nil
+	return &SignerClient{endpoint: endpoint, chainID: chainID, metrics: NopMetrics()}, nil
+}
+
+// SetMetrics sets the metrics of the sign requests.
+func (sc *SignerClient) SetMetrics(metrics *Metrics) {
+	sc.metrics = metrics
 }
 
 // Close closes the underlying connection
@@ -140,7 +150,7 @@ func (sc *SignerClient) GetThresholdPublicKey(quorumHash crypto.QuorumHash) (cry
 		return nil, fmt.Errorf("send: %w", err)
 	}
 
-	resp := response.GetPubKeyResponse()
+	resp := response.GetThresholdPubKeyResponse()
 	if resp == nil {
 		return nil, ErrUnexpectedResponse
 	}
@@ -161,6 +171,13 @@ func (sc *SignerClient) GetHeight(quorumHash crypto.QuorumHash) (int64, error) {
 
 // SignVote requests a remote signer to sign a vote
 func (sc *SignerClient) SignVote(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, vote *tmproto.Vote) error {
+	start := time.Now()
+	err := sc.signVote(chainID, quorumType, quorumHash, vote)
+	sc.metrics.observeSign(signMsgType(vote.Type), quorumType, vote.Height, start, err)
+	return err
+}
+
+func (sc *SignerClient) signVote(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, vote *tmproto.Vote) error {
 	// fmt.Printf("--> sending request to sign vote (%d/%d) %v - %v", vote.Height, vote.Round, vote.BlockID, vote)
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID,
 		QuorumType: int32(quorumType), QuorumHash: quorumHash}))
@@ -183,6 +200,13 @@ func (sc *SignerClient) SignVote(chainID string, quorumType btcjson.LLMQType, qu
 
 // SignProposal requests a remote signer to sign a proposal
 func (sc *SignerClient) SignProposal(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, proposal *tmproto.Proposal) ([]byte, error) {
+	start := time.Now()
+	signID, err := sc.signProposal(chainID, quorumType, quorumHash, proposal)
+	sc.metrics.observeSign(signMsgTypeProposal, quorumType, proposal.Height, start, err)
+	return signID, err
+}
+
+func (sc *SignerClient) signProposal(chainID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, proposal *tmproto.Proposal) ([]byte, error) {
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(
 		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID,
 			QuorumType: int32(quorumType), QuorumHash: quorumHash},
@@ -207,6 +231,42 @@ func (sc *SignerClient) SignProposal(chainID string, quorumType btcjson.LLMQType
 	return blockSignId, nil
 }
 
+// SignNodeID requests the remote signer to sign the node ID with the quorum, to
+// prove the ownership of the masternode to the peers.
+func (sc *SignerClient) SignNodeID(chainID string, nodeID string, quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash) ([]byte, error) {
+	proTxHash, err := sc.GetProTxHash()
+	if err != nil {
+		return nil, err
+	}
+	messageHash := crypto.Sha256(types.NodeIDSignBytes(chainID, nodeID))
+	requestID := types.NodeIDRequestId(proTxHash, nodeID)
+
+	return sc.QuorumSign(quorumType, quorumHash, requestID, messageHash)
+}
+
+// QuorumSign requests the remote signer to sign the message hash under the
+// request ID with the quorum. A remote signer hosting a DashCoreSignerClient
+// signs with Dash Core.
+func (sc *SignerClient) QuorumSign(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, requestID []byte,
+	messageHash []byte) ([]byte, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.QuorumSignRequest{ChainId: sc.chainID,
+		QuorumType: int32(quorumType), QuorumHash: quorumHash, RequestId: requestID, MessageHash: messageHash}))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.GetQuorumSignResponse()
+	if resp == nil {
+		return nil, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return nil, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return resp.Signature, nil
+}
+
 func (sc *SignerClient) UpdatePrivateKey(privateKey crypto.PrivKey, quorumHash crypto.QuorumHash, height int64) error {
 	// the private key is dealt with on the abci client
 	return nil
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	cryptoproto "github.com/tendermint/tendermint/proto/tendermint/crypto"
@@ -211,6 +212,45 @@ func TestSignerVote(t *testing.T) {
 	}
 }
 
+func TestSignerQuorumSign(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		pubKey, err := tc.mockPV.GetPubKey(tc.quorumHash)
+		require.NoError(t, err)
+		proTxHash, err := tc.mockPV.GetProTxHash()
+		require.NoError(t, err)
+
+		requestID := crypto.CRandBytes(crypto.DefaultHashSize)
+		messageHash := crypto.CRandBytes(crypto.DefaultHashSize)
+		signature, err := tc.signerClient.QuorumSign(tc.quorumType, tc.quorumHash, requestID, messageHash)
+		require.NoError(t, err)
+		signID := crypto.SignId(tc.quorumType, bls12381.ReverseBytes(tc.quorumHash), bls12381.ReverseBytes(requestID),
+			bls12381.ReverseBytes(messageHash))
+		assert.True(t, pubKey.VerifySignatureDigest(signID, signature))
+
+		signature, err = tc.signerClient.SignNodeID(tc.chainID, "node-id", tc.quorumType, tc.quorumHash)
+		require.NoError(t, err)
+		assert.NoError(t, types.VerifyNodeIDSignature(tc.chainID, "node-id", proTxHash, tc.quorumType, tc.quorumHash,
+			pubKey, signature))
+
+		// the quorum of the remote signer is unknown
+		_, err = tc.signerClient.QuorumSign(tc.quorumType, crypto.RandQuorumHash(), requestID, messageHash)
+		require.Error(t, err)
+		assert.IsType(t, &RemoteSignerError{}, err)
+	}
+}
+
 func TestSignerVoteResetDeadline(t *testing.T) {
 	for _, tc := range getSignerTestCases(t) {
 		hash := tmrand.Bytes(tmhash.Size)
@@ -132,6 +132,32 @@ func DefaultValidationRequestHandler(
 		} else {
 			res = mustWrapMsg(&privvalproto.SignedProposalResponse{Proposal: *proposal, Error: nil})
 		}
+	case *privvalproto.Message_QuorumSignRequest:
+		if r.QuorumSignRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.QuorumSignResponse{
+				Signature: nil, Error: &privvalproto.RemoteSignerError{
+					Code: 0, Description: "unable to sign"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.QuorumSignRequest.GetChainId(), chainID)
+		}
+
+		signer, ok := privVal.(types.QuorumSigner)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.QuorumSignResponse{
+				Signature: nil, Error: &privvalproto.RemoteSignerError{
+					Code: 0, Description: "quorum signing is not supported by the private validator"}})
+			return res, nil
+		}
+
+		var signature []byte
+		signature, err = signer.QuorumSign(btcjson.LLMQType(r.QuorumSignRequest.QuorumType),
+			r.QuorumSignRequest.QuorumHash, r.QuorumSignRequest.RequestId, r.QuorumSignRequest.MessageHash)
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.QuorumSignResponse{
+				Signature: nil, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.QuorumSignResponse{Signature: signature, Error: nil})
+		}
+
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
@@ -34,10 +34,10 @@ func DialTCPFn(addr string, timeoutReadWrite time.Duration, privKey crypto.PrivK
 	}
 }
 
-// DialUnixFn dials the given unix socket.
+// DialUnixFn dials the given unix socket. The errors mention the path of the
+// socket.
 func DialUnixFn(addr string) SocketDialer {
 	return func() (net.Conn, error) {
-		unixAddr := &net.UnixAddr{Name: addr, Net: "unix"}
-		return net.DialUnix("unix", nil, unixAddr)
+		return tmnet.DialUnix(addr)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
@@ -27,13 +28,24 @@ func IsConnTimeout(err error) bool {
 
 // NewSignerListener creates a new SignerListenerEndpoint using the corresponding listen address
 func NewSignerListener(listenAddr string, logger log.Logger) (*SignerListenerEndpoint, error) {
+	return NewSignerListenerWithSocketMode(listenAddr, 0, logger)
+}
+
+// NewSignerListenerWithSocketMode creates a new SignerListenerEndpoint like
+// NewSignerListener, setting the file mode of the unix socket, unless it's 0.
+// A stale file of the unix socket is removed first.
+func NewSignerListenerWithSocketMode(
+	listenAddr string,
+	socketMode os.FileMode,
+	logger log.Logger,
+) (*SignerListenerEndpoint, error) {
 	var listener net.Listener
 
-	protocol, address := tmnet.ProtocolAndAddress(listenAddr)
-	ln, err := net.Listen(protocol, address)
+	ln, err := tmnet.Listen(listenAddr, socketMode)
 	if err != nil {
 		return nil, err
 	}
+	protocol, _ := tmnet.ProtocolAndAddress(listenAddr)
 	switch protocol {
 	case "unix":
 		listener = NewUnixListener(ln)
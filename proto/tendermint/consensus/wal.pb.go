@@ -197,12 +197,59 @@ func (m *EndHeight) GetHeight() int64 {
 	return 0
 }
 
+// SignedVote is the vote signed by this node. It's written to the WAL before
+// the vote is broadcast, so the replay re-uses it instead of signing again.
+type SignedVote struct {
+	Vote *types.Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+}
+
+func (m *SignedVote) Reset()         { *m = SignedVote{} }
+func (m *SignedVote) String() string { return proto.CompactTextString(m) }
+func (*SignedVote) ProtoMessage()    {}
+func (*SignedVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed0b60c2d348ab09, []int{3}
+}
+func (m *SignedVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignedVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignedVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignedVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedVote.Merge(m, src)
+}
+func (m *SignedVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignedVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedVote proto.InternalMessageInfo
+
+func (m *SignedVote) GetVote() *types.Vote {
+	if m != nil {
+		return m.Vote
+	}
+	return nil
+}
+
 type WALMessage struct {
 	// Types that are valid to be assigned to Sum:
 	//	*WALMessage_EventDataRoundState
 	//	*WALMessage_MsgInfo
 	//	*WALMessage_TimeoutInfo
 	//	*WALMessage_EndHeight
+	//	*WALMessage_SignedVote
 	Sum isWALMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -210,7 +257,7 @@ func (m *WALMessage) Reset()         { *m = WALMessage{} }
 func (m *WALMessage) String() string { return proto.CompactTextString(m) }
 func (*WALMessage) ProtoMessage()    {}
 func (*WALMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ed0b60c2d348ab09, []int{3}
+	return fileDescriptor_ed0b60c2d348ab09, []int{4}
 }
 func (m *WALMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -257,11 +304,15 @@ type WALMessage_TimeoutInfo struct {
 type WALMessage_EndHeight struct {
 	EndHeight *EndHeight `protobuf:"bytes,4,opt,name=end_height,json=endHeight,proto3,oneof" json:"end_height,omitempty"`
 }
+type WALMessage_SignedVote struct {
+	SignedVote *SignedVote `protobuf:"bytes,5,opt,name=signed_vote,json=signedVote,proto3,oneof" json:"signed_vote,omitempty"`
+}
 
 func (*WALMessage_EventDataRoundState) isWALMessage_Sum() {}
 func (*WALMessage_MsgInfo) isWALMessage_Sum()             {}
 func (*WALMessage_TimeoutInfo) isWALMessage_Sum()         {}
 func (*WALMessage_EndHeight) isWALMessage_Sum()           {}
+func (*WALMessage_SignedVote) isWALMessage_Sum()          {}
 
 func (m *WALMessage) GetSum() isWALMessage_Sum {
 	if m != nil {
@@ -298,6 +349,13 @@ func (m *WALMessage) GetEndHeight() *EndHeight {
 	return nil
 }
 
+func (m *WALMessage) GetSignedVote() *SignedVote {
+	if x, ok := m.GetSum().(*WALMessage_SignedVote); ok {
+		return x.SignedVote
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*WALMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -305,20 +363,23 @@ func (*WALMessage) XXX_OneofWrappers() []interface{} {
 		(*WALMessage_MsgInfo)(nil),
 		(*WALMessage_TimeoutInfo)(nil),
 		(*WALMessage_EndHeight)(nil),
+		(*WALMessage_SignedVote)(nil),
 	}
 }
 
 // TimedWALMessage wraps WALMessage and adds Time for debugging purposes.
+// The records written before the WAL was versioned have version 0.
 type TimedWALMessage struct {
-	Time time.Time   `protobuf:"bytes,1,opt,name=time,proto3,stdtime" json:"time"`
-	Msg  *WALMessage `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Time    time.Time   `protobuf:"bytes,1,opt,name=time,proto3,stdtime" json:"time"`
+	Msg     *WALMessage `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Version uint32      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (m *TimedWALMessage) Reset()         { *m = TimedWALMessage{} }
 func (m *TimedWALMessage) String() string { return proto.CompactTextString(m) }
 func (*TimedWALMessage) ProtoMessage()    {}
 func (*TimedWALMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ed0b60c2d348ab09, []int{4}
+	return fileDescriptor_ed0b60c2d348ab09, []int{5}
 }
 func (m *TimedWALMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -361,10 +422,18 @@ func (m *TimedWALMessage) GetMsg() *WALMessage {
 	return nil
 }
 
+func (m *TimedWALMessage) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*MsgInfo)(nil), "tendermint.consensus.MsgInfo")
 	proto.RegisterType((*TimeoutInfo)(nil), "tendermint.consensus.TimeoutInfo")
 	proto.RegisterType((*EndHeight)(nil), "tendermint.consensus.EndHeight")
+	proto.RegisterType((*SignedVote)(nil), "tendermint.consensus.SignedVote")
 	proto.RegisterType((*WALMessage)(nil), "tendermint.consensus.WALMessage")
 	proto.RegisterType((*TimedWALMessage)(nil), "tendermint.consensus.TimedWALMessage")
 }
@@ -372,41 +441,45 @@ func init() {
 func init() { proto.RegisterFile("tendermint/consensus/wal.proto", fileDescriptor_ed0b60c2d348ab09) }
 
 var fileDescriptor_ed0b60c2d348ab09 = []byte{
-	// 539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xdd, 0x8a, 0xd3, 0x40,
-	0x14, 0xce, 0x6c, 0xff, 0x4f, 0x15, 0x21, 0x96, 0xa5, 0x16, 0x36, 0x8d, 0x5d, 0x84, 0x5e, 0x25,
-	0xb0, 0x22, 0x88, 0x5e, 0xa8, 0xa5, 0x2b, 0x2d, 0xb8, 0x20, 0xe3, 0x8a, 0x20, 0x42, 0x48, 0x37,
-	0xa7, 0x69, 0x60, 0x33, 0x53, 0x32, 0x13, 0xc5, 0x2b, 0x5f, 0xa1, 0x97, 0xbe, 0x89, 0xaf, 0xb0,
-	0x97, 0x7b, 0xe9, 0xd5, 0x2a, 0xed, 0x8b, 0x48, 0x66, 0xd2, 0x36, 0xb8, 0xd9, 0xbb, 0x39, 0x73,
-	0xbe, 0x73, 0xbe, 0x73, 0xbe, 0x6f, 0x06, 0x2c, 0x89, 0x2c, 0xc0, 0x24, 0x8e, 0x98, 0x74, 0x2f,
-	0x38, 0x13, 0xc8, 0x44, 0x2a, 0xdc, 0x6f, 0xfe, 0xa5, 0xb3, 0x4c, 0xb8, 0xe4, 0x66, 0x67, 0x9f,
-	0x77, 0x76, 0xf9, 0x5e, 0x27, 0xe4, 0x21, 0x57, 0x00, 0x37, 0x3b, 0x69, 0x6c, 0xcf, 0x2e, 0xed,
-	0x25, 0xbf, 0x2f, 0x51, 0xe4, 0x88, 0xa3, 0x02, 0x42, 0xdd, 0xbb, 0xf8, 0x15, 0x99, 0xdc, 0xa6,
-	0xad, 0x90, 0xf3, 0xf0, 0x12, 0x5d, 0x15, 0xcd, 0xd2, 0xb9, 0x1b, 0xa4, 0x89, 0x2f, 0x23, 0xce,
-	0xf2, 0x7c, 0xff, 0xff, 0xbc, 0x8c, 0x62, 0x14, 0xd2, 0x8f, 0x97, 0x1a, 0x30, 0x40, 0x68, 0x9c,
-	0x89, 0x70, 0xca, 0xe6, 0xdc, 0x7c, 0x06, 0x95, 0x58, 0x84, 0x5d, 0x62, 0x93, 0x61, 0xfb, 0xe4,
-	0xc8, 0x29, 0x5b, 0xc3, 0x39, 0x43, 0x21, 0xfc, 0x10, 0x47, 0xd5, 0xab, 0x9b, 0xbe, 0x41, 0x33,
-	0xbc, 0x79, 0x0c, 0x8d, 0x25, 0x62, 0xe2, 0x45, 0x41, 0xf7, 0xc0, 0x26, 0xc3, 0xd6, 0x08, 0xd6,
-	0x37, 0xfd, 0xfa, 0x7b, 0xc4, 0x64, 0x3a, 0xa6, 0xf5, 0x2c, 0x35, 0x0d, 0x06, 0x2b, 0x02, 0xed,
-	0xf3, 0x28, 0x46, 0x9e, 0x4a, 0xc5, 0xf5, 0x0a, 0x9a, 0xdb, 0x49, 0x73, 0xc2, 0x47, 0x8e, 0x1e,
-	0xd5, 0xd9, 0x8e, 0xea, 0x8c, 0x73, 0xc0, 0xa8, 0x99, 0x91, 0xfd, 0xfc, 0xd3, 0x27, 0x74, 0x57,
-	0x64, 0x1e, 0x42, 0x7d, 0x81, 0x51, 0xb8, 0x90, 0x8a, 0xb4, 0x42, 0xf3, 0xc8, 0xec, 0x40, 0x2d,
-	0xe1, 0x29, 0x0b, 0xba, 0x15, 0x9b, 0x0c, 0x6b, 0x54, 0x07, 0xa6, 0x09, 0x55, 0x21, 0x71, 0xd9,
-	0xad, 0xda, 0x64, 0x78, 0x9f, 0xaa, 0xf3, 0xe0, 0x18, 0x5a, 0xa7, 0x2c, 0x98, 0xe8, 0xb2, 0x7d,
-	0x3b, 0x52, 0x6c, 0x37, 0xf8, 0x75, 0x00, 0xf0, 0xe9, 0xcd, 0xbb, 0x7c, 0x6d, 0xf3, 0x0b, 0x1c,
-	0x2a, 0xf9, 0xbd, 0xc0, 0x97, 0xbe, 0xa7, 0x7a, 0x7b, 0x42, 0xfa, 0x12, 0xf3, 0x25, 0x9e, 0x14,
-	0x55, 0xd3, 0x36, 0x9e, 0x66, 0xf8, 0xb1, 0x2f, 0x7d, 0x9a, 0xa1, 0x3f, 0x64, 0xe0, 0x89, 0x41,
-	0x1f, 0xe2, 0xed, 0x6b, 0xf3, 0x05, 0x34, 0x63, 0x11, 0x7a, 0x11, 0x9b, 0x73, 0xb5, 0xd5, 0xdd,
-	0x2e, 0x68, 0xc7, 0x26, 0x06, 0x6d, 0xc4, 0xb9, 0x79, 0x6f, 0xe1, 0x9e, 0xd4, 0xfa, 0xea, 0xfa,
-	0x8a, 0xaa, 0x7f, 0x5c, 0x5e, 0x5f, 0x70, 0x62, 0x62, 0xd0, 0xb6, 0x2c, 0x18, 0xf3, 0x1a, 0x00,
-	0x59, 0xe0, 0xe5, 0x62, 0x54, 0x55, 0x97, 0x7e, 0x79, 0x97, 0x9d, 0x7a, 0x13, 0x83, 0xb6, 0x70,
-	0x1b, 0x8c, 0x6a, 0x50, 0x11, 0x69, 0x3c, 0xf8, 0x01, 0x0f, 0x32, 0x9a, 0xa0, 0xa0, 0xde, 0x73,
-	0xa8, 0x66, 0x54, 0xb9, 0x56, 0xbd, 0x5b, 0x86, 0x9f, 0x6f, 0xdf, 0xa6, 0x76, 0x7c, 0x95, 0x39,
-	0xae, 0x2a, 0xcc, 0x13, 0xfd, 0x34, 0xb5, 0x28, 0x76, 0xf9, 0x38, 0x7b, 0x22, 0xf5, 0x2e, 0x47,
-	0x1f, 0xaf, 0xd6, 0x16, 0xb9, 0x5e, 0x5b, 0xe4, 0xef, 0xda, 0x22, 0xab, 0x8d, 0x65, 0x5c, 0x6f,
-	0x2c, 0xe3, 0xf7, 0xc6, 0x32, 0x3e, 0xbf, 0x0c, 0x23, 0xb9, 0x48, 0x67, 0xce, 0x05, 0x8f, 0xdd,
-	0xe2, 0xf7, 0xda, 0x1f, 0xf5, 0x47, 0x2d, 0xfb, 0x9c, 0xb3, 0xba, 0xca, 0x3d, 0xfd, 0x17, 0x00,
-	0x00, 0xff, 0xff, 0x0b, 0xad, 0x1c, 0x1b, 0x07, 0x04, 0x00, 0x00,
+	// 601 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x5f, 0x6b, 0xd4, 0x4e,
+	0x14, 0xcd, 0xec, 0xdf, 0xf6, 0xee, 0xef, 0x87, 0x30, 0x96, 0xb2, 0x16, 0x9b, 0x5d, 0xb7, 0x08,
+	0x8b, 0x0f, 0x09, 0x54, 0x84, 0xa2, 0x0f, 0xea, 0xda, 0xca, 0x16, 0x2c, 0xc8, 0xb4, 0x2a, 0x88,
+	0x10, 0xd2, 0xe6, 0x36, 0x0d, 0x34, 0x33, 0x4b, 0x66, 0x52, 0xf1, 0x5b, 0xf4, 0x51, 0xbf, 0x51,
+	0x1f, 0xfb, 0xe8, 0x53, 0x95, 0xed, 0xd7, 0xf0, 0x41, 0x66, 0x26, 0xbb, 0x1b, 0xdc, 0xd0, 0xb7,
+	0xb9, 0xb9, 0xe7, 0x9e, 0x7b, 0x72, 0xcf, 0x9d, 0x01, 0x57, 0x21, 0x8f, 0x30, 0x4b, 0x13, 0xae,
+	0xfc, 0x13, 0xc1, 0x25, 0x72, 0x99, 0x4b, 0xff, 0x6b, 0x78, 0xee, 0x4d, 0x32, 0xa1, 0x04, 0x5d,
+	0x5b, 0xe4, 0xbd, 0x79, 0x7e, 0x63, 0x2d, 0x16, 0xb1, 0x30, 0x00, 0x5f, 0x9f, 0x2c, 0x76, 0xa3,
+	0x5f, 0xc9, 0xa5, 0xbe, 0x4d, 0x50, 0x16, 0x88, 0xcd, 0x12, 0xc2, 0x7c, 0xf7, 0xf1, 0x02, 0xb9,
+	0x9a, 0xa5, 0x1f, 0x2e, 0xa5, 0xcb, 0xc5, 0x6e, 0x2c, 0x44, 0x7c, 0x8e, 0xbe, 0x89, 0x8e, 0xf3,
+	0x53, 0x3f, 0xca, 0xb3, 0x50, 0x25, 0x82, 0x17, 0xf9, 0xde, 0xbf, 0x79, 0x95, 0xa4, 0x28, 0x55,
+	0x98, 0x4e, 0x2c, 0x60, 0x80, 0xd0, 0x3e, 0x90, 0xf1, 0x3e, 0x3f, 0x15, 0xf4, 0x19, 0xd4, 0x53,
+	0x19, 0x77, 0x49, 0x9f, 0x0c, 0x3b, 0xdb, 0x9b, 0x5e, 0xd5, 0x4f, 0x7a, 0x07, 0x28, 0x65, 0x18,
+	0xe3, 0xa8, 0x71, 0x75, 0xd3, 0x73, 0x98, 0xc6, 0xd3, 0x2d, 0x68, 0x4f, 0x10, 0xb3, 0x20, 0x89,
+	0xba, 0xb5, 0x3e, 0x19, 0xae, 0x8e, 0x60, 0x7a, 0xd3, 0x6b, 0xbd, 0x47, 0xcc, 0xf6, 0x77, 0x59,
+	0x4b, 0xa7, 0xf6, 0xa3, 0xc1, 0x25, 0x81, 0xce, 0x51, 0x92, 0xa2, 0xc8, 0x95, 0xe9, 0xf5, 0x12,
+	0x56, 0x66, 0x4a, 0x8b, 0x86, 0x0f, 0x3c, 0x2b, 0xd5, 0x9b, 0x49, 0xf5, 0x76, 0x0b, 0xc0, 0x68,
+	0x45, 0x37, 0xfb, 0xfe, 0xab, 0x47, 0xd8, 0xbc, 0x88, 0xae, 0x43, 0xeb, 0x0c, 0x93, 0xf8, 0x4c,
+	0x99, 0xa6, 0x75, 0x56, 0x44, 0x74, 0x0d, 0x9a, 0x99, 0xc8, 0x79, 0xd4, 0xad, 0xf7, 0xc9, 0xb0,
+	0xc9, 0x6c, 0x40, 0x29, 0x34, 0xa4, 0xc2, 0x49, 0xb7, 0xd1, 0x27, 0xc3, 0xff, 0x99, 0x39, 0x0f,
+	0xb6, 0x60, 0x75, 0x8f, 0x47, 0x63, 0x5b, 0xb6, 0xa0, 0x23, 0x65, 0xba, 0xc1, 0x0e, 0xc0, 0x61,
+	0x12, 0x73, 0x8c, 0x3e, 0x0a, 0x85, 0xf4, 0x09, 0x34, 0x2e, 0x84, 0xc2, 0x42, 0xf1, 0x7a, 0x79,
+	0x44, 0xd6, 0x14, 0x8d, 0x62, 0x06, 0x33, 0xf8, 0x53, 0x03, 0xf8, 0xf4, 0xfa, 0x5d, 0x31, 0x30,
+	0xfa, 0x05, 0xd6, 0x8d, 0xad, 0x41, 0x14, 0xaa, 0x30, 0x30, 0xaa, 0x02, 0xa9, 0xc2, 0x39, 0xd9,
+	0xe3, 0x65, 0xb2, 0x3d, 0x8d, 0xdf, 0x0d, 0x55, 0xc8, 0x34, 0xfa, 0x50, 0x83, 0xc7, 0x0e, 0xbb,
+	0x8f, 0xcb, 0x9f, 0xe9, 0x73, 0x58, 0x49, 0x65, 0x1c, 0x24, 0xfc, 0x54, 0x74, 0x6b, 0x77, 0xfa,
+	0x67, 0xbd, 0x1e, 0x3b, 0xac, 0x9d, 0x16, 0xb6, 0xbf, 0x85, 0xff, 0x94, 0x75, 0xc6, 0xd6, 0xd7,
+	0x4d, 0xfd, 0xa3, 0xea, 0xfa, 0x92, 0x87, 0x63, 0x87, 0x75, 0xd4, 0x22, 0xa4, 0xaf, 0x00, 0x90,
+	0x47, 0x41, 0x31, 0xc6, 0x86, 0x61, 0xe9, 0x55, 0xb3, 0xcc, 0xe7, 0x3e, 0x76, 0xd8, 0x2a, 0xce,
+	0x4d, 0x78, 0x03, 0x1d, 0x69, 0x86, 0x1d, 0x98, 0x29, 0x37, 0x0d, 0x45, 0xbf, 0x9a, 0x62, 0xe1,
+	0xca, 0xd8, 0x61, 0x20, 0xe7, 0xd1, 0xa8, 0x09, 0x75, 0x99, 0xa7, 0x83, 0x1f, 0x04, 0xee, 0x69,
+	0xb1, 0x51, 0xc9, 0x83, 0x1d, 0x68, 0x68, 0xc1, 0xc5, 0xc4, 0x37, 0x96, 0x16, 0xee, 0x68, 0x76,
+	0x37, 0xec, 0xc6, 0x5d, 0xea, 0x8d, 0x33, 0x15, 0x74, 0xdb, 0x5e, 0x8d, 0xda, 0x5d, 0x8a, 0x16,
+	0x8d, 0xec, 0xbd, 0xe8, 0x42, 0xfb, 0x02, 0x33, 0xa9, 0x37, 0xbc, 0x6e, 0xd6, 0x6e, 0x16, 0x8e,
+	0x3e, 0x5c, 0x4d, 0x5d, 0x72, 0x3d, 0x75, 0xc9, 0xef, 0xa9, 0x4b, 0x2e, 0x6f, 0x5d, 0xe7, 0xfa,
+	0xd6, 0x75, 0x7e, 0xde, 0xba, 0xce, 0xe7, 0x17, 0x71, 0xa2, 0xce, 0xf2, 0x63, 0xef, 0x44, 0xa4,
+	0x7e, 0xf9, 0xde, 0x2f, 0x8e, 0xf6, 0x81, 0xa9, 0x7a, 0x54, 0x8e, 0x5b, 0x26, 0xf7, 0xf4, 0xef,
+	0x00, 0xb2, 0x5f, 0xa7, 0x1c, 0xbf, 0x04, 0x00, 0x00,
 }
 
 func (m *MsgInfo) Marshal() (dAtA []byte, err error) {
@@ -523,6 +596,41 @@ func (m *EndHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SignedVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignedVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignedVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Vote != nil {
+		{
+			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *WALMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -639,6 +747,27 @@ func (m *WALMessage_EndHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *WALMessage_SignedVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WALMessage_SignedVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignedVote != nil {
+		{
+			size, err := m.SignedVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *TimedWALMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -659,6 +788,11 @@ func (m *TimedWALMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Version != 0 {
+		i = encodeVarintWal(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Msg != nil {
 		{
 			size, err := m.Msg.MarshalToSizedBuffer(dAtA[:i])
@@ -671,12 +805,12 @@ func (m *TimedWALMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err8 != nil {
-		return 0, err8
+	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err10 != nil {
+		return 0, err10
 	}
-	i -= n8
-	i = encodeVarintWal(dAtA, i, uint64(n8))
+	i -= n10
+	i = encodeVarintWal(dAtA, i, uint64(n10))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -740,6 +874,19 @@ func (m *EndHeight) Size() (n int) {
 	return n
 }
 
+func (m *SignedVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vote != nil {
+		l = m.Vote.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	return n
+}
+
 func (m *WALMessage) Size() (n int) {
 	if m == nil {
 		return 0
@@ -800,6 +947,18 @@ func (m *WALMessage_EndHeight) Size() (n int) {
 	}
 	return n
 }
+func (m *WALMessage_SignedVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedVote != nil {
+		l = m.SignedVote.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	return n
+}
 func (m *TimedWALMessage) Size() (n int) {
 	if m == nil {
 		return 0
@@ -812,6 +971,9 @@ func (m *TimedWALMessage) Size() (n int) {
 		l = m.Msg.Size()
 		n += 1 + l + sovWal(uint64(l))
 	}
+	if m.Version != 0 {
+		n += 1 + sovWal(uint64(m.Version))
+	}
 	return n
 }
 
@@ -1145,6 +1307,92 @@ func (m *EndHeight) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SignedVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &types.Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *WALMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1314,6 +1562,41 @@ func (m *WALMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &WALMessage_EndHeight{v}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedVote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignedVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &WALMessage_SignedVote{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWal(dAtA[iNdEx:])
@@ -1433,6 +1716,25 @@ func (m *TimedWALMessage) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWal(dAtA[iNdEx:])
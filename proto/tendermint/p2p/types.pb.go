@@ -153,6 +153,8 @@ type DefaultNodeInfo struct {
 	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
 	ProTxHash       []byte               `protobuf:"bytes,9,opt,name=pro_tx_hash,json=proTxHash,proto3" json:"pro_tx_hash,omitempty"`
+	QuorumHash      []byte               `protobuf:"bytes,10,opt,name=quorum_hash,json=quorumHash,proto3" json:"quorum_hash,omitempty"`
+	NodeIDSignature []byte               `protobuf:"bytes,11,opt,name=node_id_signature,json=nodeIdSignature,proto3" json:"node_id_signature,omitempty"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -251,6 +253,20 @@ func (m *DefaultNodeInfo) GetProTxHash() []byte {
 	return nil
 }
 
+func (m *DefaultNodeInfo) GetQuorumHash() []byte {
+	if m != nil {
+		return m.QuorumHash
+	}
+	return nil
+}
+
+func (m *DefaultNodeInfo) GetNodeIDSignature() []byte {
+	if m != nil {
+		return m.NodeIDSignature
+	}
+	return nil
+}
+
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -313,39 +329,42 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 504 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x3d, 0x8f, 0xda, 0x40,
-	0x10, 0xc5, 0x60, 0xbe, 0x86, 0x70, 0x5c, 0x56, 0x28, 0xf2, 0x51, 0xd8, 0x08, 0xa5, 0xa0, 0x02,
-	0x89, 0x28, 0x45, 0xba, 0x84, 0x50, 0x84, 0xe6, 0xce, 0x5a, 0x9d, 0x52, 0xa4, 0xb1, 0x8c, 0x77,
-	0x0f, 0xaf, 0x80, 0xdd, 0xd5, 0xee, 0x5e, 0x42, 0x7e, 0x43, 0x9a, 0xfc, 0xac, 0x2b, 0xaf, 0x4c,
-	0x85, 0x22, 0xf3, 0x47, 0x22, 0xaf, 0x7d, 0x09, 0x87, 0xd2, 0xcd, 0x9b, 0xd9, 0x79, 0x6f, 0xf6,
-	0x69, 0x06, 0x06, 0x86, 0x72, 0x42, 0xd5, 0x8e, 0x71, 0x33, 0x95, 0x33, 0x39, 0x35, 0xdf, 0x25,
-	0xd5, 0x13, 0xa9, 0x84, 0x11, 0xe8, 0xe2, 0x5f, 0x6d, 0x22, 0x67, 0x72, 0xd0, 0x5f, 0x8b, 0xb5,
-	0xb0, 0xa5, 0x69, 0x1e, 0x15, 0xaf, 0x46, 0x21, 0xc0, 0x35, 0x35, 0x1f, 0x08, 0x51, 0x54, 0x6b,
-	0xf4, 0x0a, 0xaa, 0x8c, 0x78, 0xce, 0xd0, 0x19, 0xb7, 0xe7, 0x8d, 0xec, 0x10, 0x54, 0x97, 0x0b,
-	0x5c, 0x65, 0xc4, 0xe6, 0xa5, 0x57, 0x3d, 0xc9, 0x87, 0xb8, 0xca, 0x24, 0x42, 0xe0, 0x4a, 0xa1,
-	0x8c, 0x57, 0x1b, 0x3a, 0xe3, 0x2e, 0xb6, 0xf1, 0xe8, 0x16, 0x7a, 0x61, 0x4e, 0x9d, 0x88, 0xed,
-	0x67, 0xaa, 0x34, 0x13, 0x1c, 0x5d, 0x41, 0x4d, 0xce, 0xa4, 0xe5, 0x75, 0xe7, 0xcd, 0xec, 0x10,
-	0xd4, 0xc2, 0x59, 0x88, 0xf3, 0x1c, 0xea, 0x43, 0x7d, 0xb5, 0x15, 0xc9, 0xc6, 0x92, 0xbb, 0xb8,
-	0x00, 0xe8, 0x12, 0x6a, 0xb1, 0x94, 0x96, 0xd6, 0xc5, 0x79, 0x38, 0xfa, 0x51, 0x83, 0xde, 0x82,
-	0xde, 0xc5, 0xf7, 0x5b, 0x73, 0x2d, 0x08, 0x5d, 0xf2, 0x3b, 0x81, 0x42, 0xb8, 0x94, 0xa5, 0x52,
-	0xf4, 0xb5, 0x90, 0xb2, 0x1a, 0x9d, 0x59, 0x30, 0x79, 0xfe, 0xf9, 0xc9, 0xd9, 0x44, 0x73, 0xf7,
-	0xe1, 0x10, 0x54, 0x70, 0x4f, 0x9e, 0x0d, 0xfa, 0x0e, 0x7a, 0xa4, 0x10, 0x89, 0xb8, 0x20, 0x34,
-	0x62, 0xa4, 0xfc, 0xf4, 0xcb, 0xec, 0x10, 0x74, 0x4f, 0xf5, 0x17, 0xb8, 0x4b, 0x4e, 0x20, 0x41,
-	0x01, 0x74, 0xb6, 0x4c, 0x1b, 0xca, 0xa3, 0x98, 0x10, 0x65, 0x47, 0x6f, 0x63, 0x28, 0x52, 0xb9,
-	0xbd, 0xc8, 0x83, 0x26, 0xa7, 0xe6, 0x9b, 0x50, 0x1b, 0xcf, 0xb5, 0xc5, 0x27, 0x98, 0x57, 0x9e,
-	0xc6, 0xaf, 0x17, 0x95, 0x12, 0xa2, 0x01, 0xb4, 0x92, 0x34, 0xe6, 0x9c, 0x6e, 0xb5, 0xd7, 0x18,
-	0x3a, 0xe3, 0x17, 0xf8, 0x2f, 0xce, 0xbb, 0x76, 0x82, 0xb3, 0x0d, 0x55, 0x5e, 0xb3, 0xe8, 0x2a,
-	0x21, 0x7a, 0x0f, 0x75, 0x61, 0x52, 0xaa, 0xbc, 0x96, 0x35, 0xe3, 0xf5, 0xb9, 0x19, 0x67, 0x3e,
-	0xde, 0xe4, 0x6f, 0x4b, 0x47, 0x8a, 0x46, 0xe4, 0x43, 0x47, 0x2a, 0x11, 0x99, 0x7d, 0x94, 0xc6,
-	0x3a, 0xf5, 0xda, 0x56, 0xba, 0x2d, 0x95, 0xb8, 0xdd, 0x7f, 0x8a, 0x75, 0x3a, 0x5a, 0x41, 0xff,
-	0x7f, 0x24, 0xe8, 0x0a, 0x5a, 0x66, 0x1f, 0x31, 0x4e, 0xe8, 0xbe, 0xd8, 0x22, 0xdc, 0x34, 0xfb,
-	0x65, 0x0e, 0xd1, 0x14, 0x3a, 0x4a, 0x26, 0xd6, 0x1c, 0xaa, 0x75, 0x69, 0xeb, 0x45, 0x76, 0x08,
-	0x00, 0x87, 0x1f, 0xcb, 0xfd, 0xc3, 0xa0, 0x64, 0x52, 0xc6, 0xf3, 0x9b, 0x87, 0xcc, 0x77, 0x1e,
-	0x33, 0xdf, 0xf9, 0x9d, 0xf9, 0xce, 0xcf, 0xa3, 0x5f, 0x79, 0x3c, 0xfa, 0x95, 0x5f, 0x47, 0xbf,
-	0xf2, 0xe5, 0xed, 0x9a, 0x99, 0xf4, 0x7e, 0x35, 0x49, 0xc4, 0x6e, 0x7a, 0x72, 0x00, 0xa7, 0xb7,
-	0x60, 0xd7, 0xfc, 0xf9, 0x71, 0xac, 0x1a, 0x36, 0xfb, 0xe6, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x3c, 0xbe, 0x56, 0x42, 0x35, 0x03, 0x00, 0x00,
+	// 547 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x31, 0x6f, 0xda, 0x40,
+	0x14, 0xc6, 0xe0, 0x84, 0xf0, 0x68, 0xe2, 0xe4, 0x1a, 0x55, 0x0e, 0x83, 0x8d, 0x50, 0x07, 0x26,
+	0x90, 0xa8, 0x3a, 0x74, 0x6a, 0x4b, 0x19, 0xca, 0x92, 0x58, 0xd7, 0xa8, 0x43, 0x17, 0xcb, 0xf8,
+	0x2e, 0xd8, 0x02, 0xee, 0xae, 0xe7, 0xa3, 0xa5, 0xff, 0xa2, 0x3f, 0x2b, 0x63, 0xc6, 0x4e, 0x56,
+	0x65, 0xe6, 0xfe, 0x87, 0xca, 0x77, 0x26, 0x25, 0xa8, 0xdb, 0xfb, 0xbe, 0xef, 0xdd, 0xfb, 0xde,
+	0x7d, 0xf6, 0x41, 0x47, 0x51, 0x46, 0xa8, 0x5c, 0xa5, 0x4c, 0x0d, 0xc5, 0x48, 0x0c, 0xd5, 0x0f,
+	0x41, 0xb3, 0x81, 0x90, 0x5c, 0x71, 0x74, 0xf6, 0x4f, 0x1b, 0x88, 0x91, 0xe8, 0x5c, 0xce, 0xf9,
+	0x9c, 0x6b, 0x69, 0x58, 0x56, 0xa6, 0xab, 0x17, 0x00, 0x5c, 0x53, 0xf5, 0x9e, 0x10, 0x49, 0xb3,
+	0x0c, 0xbd, 0x80, 0x7a, 0x4a, 0x5c, 0xab, 0x6b, 0xf5, 0x5b, 0xe3, 0xe3, 0x22, 0xf7, 0xeb, 0xd3,
+	0x09, 0xae, 0xa7, 0x44, 0xf3, 0xc2, 0xad, 0xef, 0xf1, 0x01, 0xae, 0xa7, 0x02, 0x21, 0xb0, 0x05,
+	0x97, 0xca, 0x6d, 0x74, 0xad, 0xfe, 0x29, 0xd6, 0x75, 0xef, 0x16, 0x9c, 0xa0, 0x1c, 0x1d, 0xf3,
+	0xe5, 0x67, 0x2a, 0xb3, 0x94, 0x33, 0x74, 0x05, 0x0d, 0x31, 0x12, 0x7a, 0xae, 0x3d, 0x6e, 0x16,
+	0xb9, 0xdf, 0x08, 0x46, 0x01, 0x2e, 0x39, 0x74, 0x09, 0x47, 0xb3, 0x25, 0x8f, 0x17, 0x7a, 0xb8,
+	0x8d, 0x0d, 0x40, 0xe7, 0xd0, 0x88, 0x84, 0xd0, 0x63, 0x6d, 0x5c, 0x96, 0xbd, 0x3f, 0x0d, 0x70,
+	0x26, 0xf4, 0x2e, 0x5a, 0x2f, 0xd5, 0x35, 0x27, 0x74, 0xca, 0xee, 0x38, 0x0a, 0xe0, 0x5c, 0x54,
+	0x4e, 0xe1, 0x37, 0x63, 0xa5, 0x3d, 0xda, 0x23, 0x7f, 0xf0, 0xf4, 0xf2, 0x83, 0x83, 0x8d, 0xc6,
+	0xf6, 0x7d, 0xee, 0xd7, 0xb0, 0x23, 0x0e, 0x16, 0x7d, 0x03, 0x0e, 0x31, 0x26, 0x21, 0xe3, 0x84,
+	0x86, 0x29, 0xa9, 0x2e, 0x7d, 0x51, 0xe4, 0xfe, 0xe9, 0xbe, 0xff, 0x04, 0x9f, 0x92, 0x3d, 0x48,
+	0x90, 0x0f, 0xed, 0x65, 0x9a, 0x29, 0xca, 0xc2, 0x88, 0x10, 0xa9, 0x57, 0x6f, 0x61, 0x30, 0x54,
+	0x19, 0x2f, 0x72, 0xa1, 0xc9, 0xa8, 0xfa, 0xce, 0xe5, 0xc2, 0xb5, 0xb5, 0xb8, 0x83, 0xa5, 0xb2,
+	0x5b, 0xff, 0xc8, 0x28, 0x15, 0x44, 0x1d, 0x38, 0x89, 0x93, 0x88, 0x31, 0xba, 0xcc, 0xdc, 0xe3,
+	0xae, 0xd5, 0x7f, 0x86, 0x1f, 0x71, 0x79, 0x6a, 0xc5, 0x59, 0xba, 0xa0, 0xd2, 0x6d, 0x9a, 0x53,
+	0x15, 0x44, 0xef, 0xe0, 0x88, 0xab, 0x84, 0x4a, 0xf7, 0x44, 0x87, 0xf1, 0xf2, 0x30, 0x8c, 0x83,
+	0x1c, 0x6f, 0xca, 0xde, 0x2a, 0x11, 0x73, 0x10, 0x79, 0xd0, 0x16, 0x92, 0x87, 0x6a, 0x13, 0x26,
+	0x51, 0x96, 0xb8, 0x2d, 0x6d, 0xdd, 0x12, 0x92, 0xdf, 0x6e, 0x3e, 0x46, 0x59, 0x52, 0x5e, 0xf6,
+	0xeb, 0x9a, 0xcb, 0xf5, 0xca, 0xe8, 0xa0, 0x75, 0x30, 0x94, 0x6e, 0x78, 0x0b, 0x17, 0x55, 0x80,
+	0x61, 0x96, 0xce, 0x59, 0xa4, 0xd6, 0x92, 0xba, 0xed, 0xb2, 0x6d, 0xfc, 0xbc, 0xc8, 0x7d, 0xc7,
+	0x64, 0xf8, 0x69, 0x27, 0x61, 0x87, 0xe9, 0x14, 0x1f, 0x89, 0xde, 0x0c, 0x2e, 0xff, 0xb7, 0x26,
+	0xba, 0x82, 0x13, 0xb5, 0x09, 0x53, 0x46, 0xe8, 0xc6, 0xfc, 0xa7, 0xb8, 0xa9, 0x36, 0xd3, 0x12,
+	0xa2, 0x21, 0xb4, 0xa5, 0x88, 0x75, 0xfc, 0x34, 0xcb, 0xaa, 0x0f, 0x77, 0x56, 0xe4, 0x3e, 0xe0,
+	0xe0, 0x43, 0xf5, 0x87, 0x63, 0x90, 0x22, 0xae, 0xea, 0xf1, 0xcd, 0x7d, 0xe1, 0x59, 0x0f, 0x85,
+	0x67, 0xfd, 0x2e, 0x3c, 0xeb, 0xe7, 0xd6, 0xab, 0x3d, 0x6c, 0xbd, 0xda, 0xaf, 0xad, 0x57, 0xfb,
+	0xf2, 0x7a, 0x9e, 0xaa, 0x64, 0x3d, 0x1b, 0xc4, 0x7c, 0x35, 0xdc, 0x7b, 0x62, 0x7b, 0xa5, 0x79,
+	0x48, 0x4f, 0x9f, 0xdf, 0xec, 0x58, 0xb3, 0xaf, 0xfe, 0x0e, 0x00, 0x57, 0x98, 0x7b, 0x58, 0x97,
+	0x03, 0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -448,6 +467,20 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NodeIDSignature) > 0 {
+		i -= len(m.NodeIDSignature)
+		copy(dAtA[i:], m.NodeIDSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NodeIDSignature)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.QuorumHash) > 0 {
+		i -= len(m.QuorumHash)
+		copy(dAtA[i:], m.QuorumHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.QuorumHash)))
+		i--
+		dAtA[i] = 0x52
+	}
 	if len(m.ProTxHash) > 0 {
 		i -= len(m.ProTxHash)
 		copy(dAtA[i:], m.ProTxHash)
@@ -644,6 +677,14 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.QuorumHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NodeIDSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1233,6 +1274,74 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				m.ProTxHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QuorumHash = append(m.QuorumHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.QuorumHash == nil {
+				m.QuorumHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeIDSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeIDSignature = append(m.NodeIDSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.NodeIDSignature == nil {
+				m.NodeIDSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
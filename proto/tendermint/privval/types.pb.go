@@ -668,6 +668,137 @@ func (m *SignedProposalResponse) GetError() *RemoteSignerError {
 	return nil
 }
 
+// QuorumSignRequest is a request to sign the message hash with the key of the
+// remote signer in the quorum, under the request ID
+type QuorumSignRequest struct {
+	ChainId     string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	QuorumType  int32  `protobuf:"varint,2,opt,name=quorum_type,json=quorumType,proto3" json:"quorum_type,omitempty"`
+	QuorumHash  []byte `protobuf:"bytes,3,opt,name=quorum_hash,json=quorumHash,proto3" json:"quorum_hash,omitempty"`
+	RequestId   []byte `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	MessageHash []byte `protobuf:"bytes,5,opt,name=message_hash,json=messageHash,proto3" json:"message_hash,omitempty"`
+}
+
+func (m *QuorumSignRequest) Reset()         { *m = QuorumSignRequest{} }
+func (m *QuorumSignRequest) String() string { return proto.CompactTextString(m) }
+func (*QuorumSignRequest) ProtoMessage()    {}
+func (*QuorumSignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{11}
+}
+func (m *QuorumSignRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuorumSignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuorumSignRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuorumSignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuorumSignRequest.Merge(m, src)
+}
+func (m *QuorumSignRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuorumSignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuorumSignRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuorumSignRequest proto.InternalMessageInfo
+
+func (m *QuorumSignRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *QuorumSignRequest) GetQuorumType() int32 {
+	if m != nil {
+		return m.QuorumType
+	}
+	return 0
+}
+
+func (m *QuorumSignRequest) GetQuorumHash() []byte {
+	if m != nil {
+		return m.QuorumHash
+	}
+	return nil
+}
+
+func (m *QuorumSignRequest) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
+func (m *QuorumSignRequest) GetMessageHash() []byte {
+	if m != nil {
+		return m.MessageHash
+	}
+	return nil
+}
+
+// QuorumSignResponse is a response containing the signature or an error
+type QuorumSignResponse struct {
+	Signature []byte             `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Error     *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *QuorumSignResponse) Reset()         { *m = QuorumSignResponse{} }
+func (m *QuorumSignResponse) String() string { return proto.CompactTextString(m) }
+func (*QuorumSignResponse) ProtoMessage()    {}
+func (*QuorumSignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{12}
+}
+func (m *QuorumSignResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuorumSignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuorumSignResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuorumSignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuorumSignResponse.Merge(m, src)
+}
+func (m *QuorumSignResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuorumSignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuorumSignResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuorumSignResponse proto.InternalMessageInfo
+
+func (m *QuorumSignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *QuorumSignResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 // PingRequest is a request to confirm that the connection is alive.
 type PingRequest struct {
 }
@@ -676,7 +807,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{11}
+	return fileDescriptor_cb4e437a5328cf9c, []int{13}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -713,7 +844,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{12}
+	return fileDescriptor_cb4e437a5328cf9c, []int{14}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -756,6 +887,8 @@ type Message struct {
 	//	*Message_ProTxHashResponse
 	//	*Message_ThresholdPubKeyRequest
 	//	*Message_ThresholdPubKeyResponse
+	//	*Message_QuorumSignRequest
+	//	*Message_QuorumSignResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -763,7 +896,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{13}
+	return fileDescriptor_cb4e437a5328cf9c, []int{15}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -834,6 +967,12 @@ type Message_ThresholdPubKeyRequest struct {
 type Message_ThresholdPubKeyResponse struct {
 	ThresholdPubKeyResponse *ThresholdPubKeyResponse `protobuf:"bytes,12,opt,name=threshold_pub_key_response,json=thresholdPubKeyResponse,proto3,oneof" json:"threshold_pub_key_response,omitempty"`
 }
+type Message_QuorumSignRequest struct {
+	QuorumSignRequest *QuorumSignRequest `protobuf:"bytes,13,opt,name=quorum_sign_request,json=quorumSignRequest,proto3,oneof" json:"quorum_sign_request,omitempty"`
+}
+type Message_QuorumSignResponse struct {
+	QuorumSignResponse *QuorumSignResponse `protobuf:"bytes,14,opt,name=quorum_sign_response,json=quorumSignResponse,proto3,oneof" json:"quorum_sign_response,omitempty"`
+}
 
 func (*Message_PubKeyRequest) isMessage_Sum()           {}
 func (*Message_PubKeyResponse) isMessage_Sum()          {}
@@ -847,6 +986,8 @@ func (*Message_ProTxHashRequest) isMessage_Sum()        {}
 func (*Message_ProTxHashResponse) isMessage_Sum()       {}
 func (*Message_ThresholdPubKeyRequest) isMessage_Sum()  {}
 func (*Message_ThresholdPubKeyResponse) isMessage_Sum() {}
+func (*Message_QuorumSignRequest) isMessage_Sum()       {}
+func (*Message_QuorumSignResponse) isMessage_Sum()      {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -939,6 +1080,20 @@ func (m *Message) GetThresholdPubKeyResponse() *ThresholdPubKeyResponse {
 	return nil
 }
 
+func (m *Message) GetQuorumSignRequest() *QuorumSignRequest {
+	if x, ok := m.GetSum().(*Message_QuorumSignRequest); ok {
+		return x.QuorumSignRequest
+	}
+	return nil
+}
+
+func (m *Message) GetQuorumSignResponse() *QuorumSignResponse {
+	if x, ok := m.GetSum().(*Message_QuorumSignResponse); ok {
+		return x.QuorumSignResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -954,6 +1109,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_ProTxHashResponse)(nil),
 		(*Message_ThresholdPubKeyRequest)(nil),
 		(*Message_ThresholdPubKeyResponse)(nil),
+		(*Message_QuorumSignRequest)(nil),
+		(*Message_QuorumSignResponse)(nil),
 	}
 }
 
@@ -970,6 +1127,8 @@ func init() {
 	proto.RegisterType((*SignedVoteResponse)(nil), "tendermint.privval.SignedVoteResponse")
 	proto.RegisterType((*SignProposalRequest)(nil), "tendermint.privval.SignProposalRequest")
 	proto.RegisterType((*SignedProposalResponse)(nil), "tendermint.privval.SignedProposalResponse")
+	proto.RegisterType((*QuorumSignRequest)(nil), "tendermint.privval.QuorumSignRequest")
+	proto.RegisterType((*QuorumSignResponse)(nil), "tendermint.privval.QuorumSignResponse")
 	proto.RegisterType((*PingRequest)(nil), "tendermint.privval.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "tendermint.privval.PingResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.privval.Message")
@@ -978,67 +1137,73 @@ func init() {
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 949 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0x5d, 0x6f, 0xe3, 0x44,
-	0x17, 0xb6, 0xdb, 0xa4, 0x1f, 0x27, 0x69, 0x9b, 0x4e, 0xfb, 0xb6, 0x69, 0xb4, 0xaf, 0x5b, 0xc2,
-	0xd7, 0xaa, 0x88, 0x04, 0x2d, 0x12, 0x12, 0x5a, 0x6e, 0x68, 0x6b, 0xe1, 0x28, 0x5a, 0x27, 0x4c,
-	0x5c, 0x76, 0xb5, 0x12, 0xb2, 0xf2, 0x31, 0x38, 0x66, 0x1b, 0xcf, 0xac, 0xc7, 0xa9, 0x36, 0xd7,
-	0xdc, 0x71, 0x85, 0xb4, 0x77, 0xfc, 0x02, 0xc4, 0x2f, 0xe0, 0x27, 0xec, 0xe5, 0x5e, 0x72, 0x85,
-	0x50, 0xfb, 0x47, 0x90, 0xc7, 0x13, 0xc7, 0x89, 0x1d, 0x58, 0x54, 0x10, 0x77, 0x9e, 0x73, 0xce,
-	0x3c, 0xe7, 0xf1, 0x79, 0xce, 0x99, 0x19, 0xd0, 0x02, 0xe2, 0x0d, 0x88, 0x3f, 0x72, 0xbd, 0xa0,
-	0xce, 0x7c, 0xf7, 0xfa, 0xba, 0x7b, 0x55, 0x0f, 0x26, 0x8c, 0xf0, 0x1a, 0xf3, 0x69, 0x40, 0x11,
-	0x9a, 0xf9, 0x6b, 0xd2, 0x5f, 0xb9, 0x97, 0xd8, 0xd3, 0xf7, 0x27, 0x2c, 0xa0, 0xf5, 0x67, 0x64,
-	0x22, 0x77, 0xcc, 0x79, 0x05, 0x52, 0x12, 0xaf, 0xb2, 0xef, 0x50, 0x87, 0x8a, 0xcf, 0x7a, 0xf8,
-	0x15, 0x59, 0xab, 0x0d, 0xd8, 0xc5, 0x64, 0x44, 0x03, 0xd2, 0x71, 0x1d, 0x8f, 0xf8, 0xba, 0xef,
-	0x53, 0x1f, 0x21, 0xc8, 0xf5, 0xe9, 0x80, 0x94, 0xd5, 0x13, 0xf5, 0x7e, 0x1e, 0x8b, 0x6f, 0x74,
-	0x02, 0x85, 0x01, 0xe1, 0x7d, 0xdf, 0x65, 0x81, 0x4b, 0xbd, 0xf2, 0xca, 0x89, 0x7a, 0x7f, 0x13,
-	0x27, 0x4d, 0xd5, 0x26, 0x6c, 0xb5, 0xc7, 0xbd, 0x26, 0x99, 0x60, 0xf2, 0x7c, 0x4c, 0x78, 0x80,
-	0x8e, 0x60, 0xa3, 0x3f, 0xec, 0xba, 0x9e, 0xed, 0x0e, 0x04, 0xd4, 0x26, 0x5e, 0x17, 0xeb, 0xc6,
-	0x00, 0x1d, 0x43, 0xe1, 0xf9, 0x98, 0xfa, 0xe3, 0x91, 0x3d, 0xec, 0xf2, 0xa1, 0x40, 0x2b, 0x62,
-	0x88, 0x4c, 0x46, 0x97, 0x0f, 0xab, 0x16, 0x1c, 0x58, 0x43, 0x9f, 0xf0, 0x21, 0xbd, 0x1a, 0xfc,
-	0x73, 0xa8, 0x1f, 0x42, 0xa9, 0xed, 0x53, 0xeb, 0x45, 0xb8, 0xf8, 0x6b, 0xbc, 0xea, 0xf7, 0x2a,
-	0x6c, 0x4f, 0x93, 0x73, 0x46, 0x3d, 0x4e, 0xd0, 0x43, 0x58, 0x67, 0xe3, 0x9e, 0xfd, 0x8c, 0x4c,
-	0x44, 0x70, 0xe1, 0xc1, 0xbd, 0x5a, 0x42, 0xa7, 0x48, 0x93, 0x5a, 0x7b, 0xdc, 0xbb, 0x72, 0xfb,
-	0x4d, 0x32, 0x39, 0xcb, 0xbd, 0xfa, 0xed, 0x58, 0xc1, 0x6b, 0x4c, 0x80, 0xa0, 0x87, 0x90, 0x27,
-	0x61, 0x81, 0x05, 0xb3, 0xc2, 0x83, 0x77, 0x6b, 0x69, 0x89, 0x6b, 0x29, 0x35, 0x70, 0xb4, 0xa7,
-	0xfa, 0x52, 0x85, 0xc3, 0x54, 0x49, 0xfe, 0x73, 0x56, 0x0c, 0x76, 0x13, 0x15, 0x95, 0x74, 0x34,
-	0x28, 0x30, 0x9f, 0xda, 0xc1, 0x8b, 0x48, 0x07, 0x55, 0xe8, 0xb0, 0xc9, 0xa6, 0x71, 0x77, 0xcb,
-	0xf8, 0xa3, 0x0a, 0x3b, 0xa1, 0xf9, 0x2b, 0x1a, 0x90, 0xa9, 0x86, 0xa7, 0x90, 0xbb, 0xa6, 0x01,
-	0x91, 0x3f, 0x7f, 0x90, 0xc4, 0x8b, 0x46, 0x40, 0x04, 0x8b, 0x98, 0x39, 0xbd, 0x57, 0x96, 0xf5,
-	0x4f, 0xb8, 0xab, 0xbc, 0x2a, 0xda, 0x5f, 0xf6, 0x8f, 0x35, 0x61, 0x64, 0xb1, 0xc1, 0x72, 0xa9,
-	0x06, 0xfb, 0x4e, 0x05, 0x24, 0x38, 0x0f, 0x22, 0x7a, 0xb2, 0x20, 0x1f, 0xbd, 0x09, 0x3f, 0x29,
-	0x4b, 0xc4, 0xf2, 0x4e, 0x25, 0xfa, 0x59, 0x85, 0xbd, 0xd0, 0xdc, 0xf6, 0x29, 0xa3, 0xbc, 0x7b,
-	0x35, 0x2d, 0xd3, 0x27, 0xb0, 0xc1, 0xa4, 0x49, 0x52, 0xa9, 0xa4, 0xa9, 0xc4, 0x9b, 0xe2, 0xd8,
-	0x7f, 0xb7, 0x64, 0x2f, 0x55, 0x38, 0x88, 0x4a, 0x36, 0xa3, 0x2b, 0xcb, 0xf6, 0xd9, 0xdf, 0xe1,
-	0x2b, 0xcb, 0x37, 0x63, 0x7d, 0xa7, 0x12, 0x6e, 0x41, 0xa1, 0xed, 0x7a, 0x8e, 0xac, 0x5c, 0x75,
-	0x1b, 0x8a, 0xd1, 0x32, 0x62, 0x56, 0xfd, 0x65, 0x03, 0xd6, 0x1f, 0x11, 0xce, 0xbb, 0x0e, 0x41,
-	0x4d, 0xd8, 0x91, 0xc3, 0x67, 0xfb, 0x51, 0xb8, 0x24, 0xfb, 0x56, 0x56, 0xc6, 0xb9, 0xc3, 0xcc,
-	0x50, 0xf0, 0x16, 0x9b, 0x3b, 0xdd, 0x4c, 0x28, 0xcd, 0xc0, 0xa2, 0x64, 0x92, 0x7f, 0xf5, 0xcf,
-	0xd0, 0xa2, 0x48, 0x43, 0xc1, 0xdb, 0x6c, 0xfe, 0x64, 0xf8, 0x12, 0x76, 0xb9, 0xeb, 0x78, 0x76,
-	0xd8, 0x54, 0x31, 0xbd, 0x55, 0x01, 0xf8, 0x76, 0x16, 0xe0, 0xc2, 0x64, 0x19, 0x0a, 0xde, 0xe1,
-	0x0b, 0xc3, 0xf6, 0x14, 0xf6, 0xb9, 0xd0, 0x6b, 0x0a, 0x2a, 0x69, 0xe6, 0x04, 0xea, 0x7b, 0xcb,
-	0x50, 0xe7, 0x47, 0xc2, 0x50, 0x30, 0xe2, 0xe9, 0x41, 0xf9, 0x1a, 0xfe, 0x27, 0xe8, 0x4e, 0x45,
-	0x8c, 0x29, 0xe7, 0x05, 0xf8, 0xfb, 0xcb, 0xc0, 0x17, 0x3a, 0xdd, 0x50, 0xf0, 0x1e, 0xcf, 0x18,
-	0x80, 0x6f, 0xa0, 0x2c, 0xa9, 0x27, 0x12, 0x48, 0xfa, 0x6b, 0x22, 0xc3, 0xe9, 0x72, 0xfa, 0x8b,
-	0xed, 0x69, 0x28, 0xf8, 0x80, 0x67, 0x37, 0xee, 0x05, 0x14, 0x99, 0xeb, 0x39, 0x31, 0xfb, 0x75,
-	0x81, 0x7d, 0x9c, 0xa9, 0xe0, 0xac, 0xcb, 0x0c, 0x05, 0x17, 0xd8, 0x6c, 0x89, 0xbe, 0x80, 0x2d,
-	0x89, 0x22, 0x29, 0x6e, 0x08, 0x98, 0x93, 0xe5, 0x30, 0x31, 0xb1, 0x22, 0x4b, 0xac, 0xd1, 0x25,
-	0xec, 0x25, 0xce, 0xe3, 0x98, 0xd5, 0xa6, 0x80, 0x7b, 0x27, 0x13, 0x6e, 0xe1, 0x96, 0x34, 0x14,
-	0x5c, 0x62, 0x8b, 0x37, 0xe7, 0x13, 0xd8, 0x9f, 0x87, 0x95, 0x34, 0x61, 0xf9, 0xbc, 0xa5, 0xee,
-	0x0a, 0x43, 0xc1, 0xbb, 0x2c, 0x75, 0x81, 0x38, 0x70, 0x14, 0x4c, 0xaf, 0x3a, 0x7b, 0x71, 0xb8,
-	0x0a, 0xcb, 0x85, 0xca, 0x7e, 0x32, 0x84, 0x42, 0x05, 0xd9, 0x8f, 0x89, 0x6f, 0xa1, 0x92, 0x95,
-	0x48, 0xfe, 0x48, 0x51, 0x64, 0xfa, 0xe0, 0x8d, 0x32, 0xc5, 0xbf, 0x73, 0x18, 0x64, 0xbb, 0xce,
-	0xf2, 0xb0, 0xca, 0xc7, 0xa3, 0xd3, 0x9f, 0x54, 0x58, 0x13, 0x47, 0x0d, 0x47, 0x08, 0xb6, 0x75,
-	0x8c, 0x5b, 0xb8, 0x63, 0x5f, 0x9a, 0x4d, 0xb3, 0xf5, 0xd8, 0x2c, 0x29, 0x48, 0x83, 0x4a, 0x6c,
-	0xd3, 0x9f, 0xb4, 0xf5, 0x73, 0x4b, 0xbf, 0xb0, 0xb1, 0xde, 0x69, 0xb7, 0xcc, 0x8e, 0x5e, 0x52,
-	0x51, 0x19, 0xf6, 0xa5, 0xdf, 0x6c, 0xd9, 0xe7, 0x2d, 0xd3, 0xd4, 0xcf, 0xad, 0x46, 0xcb, 0x2c,
-	0xad, 0xa0, 0xff, 0xc3, 0x91, 0xf4, 0xcc, 0xcc, 0xb6, 0xd5, 0x78, 0xa4, 0xb7, 0x2e, 0xad, 0xd2,
-	0x2a, 0x3a, 0x84, 0x3d, 0xe9, 0xc6, 0xfa, 0xe7, 0x17, 0xb1, 0x23, 0x97, 0x40, 0x7c, 0x8c, 0x1b,
-	0x96, 0x1e, 0x7b, 0xf2, 0x67, 0x9d, 0x57, 0x37, 0x9a, 0xfa, 0xfa, 0x46, 0x53, 0x7f, 0xbf, 0xd1,
-	0xd4, 0x1f, 0x6e, 0x35, 0xe5, 0xf5, 0xad, 0xa6, 0xfc, 0x7a, 0xab, 0x29, 0x4f, 0x3f, 0x75, 0xdc,
-	0x60, 0x38, 0xee, 0xd5, 0xfa, 0x74, 0x54, 0x4f, 0xbe, 0x3a, 0x93, 0x4f, 0xda, 0xf0, 0xa5, 0x99,
-	0x7e, 0xe3, 0xf6, 0xd6, 0x84, 0xe7, 0xe3, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x8a, 0x7c, 0xe0,
-	0x47, 0x00, 0x0b, 0x00, 0x00,
+	// 1050 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xcf, 0x6f, 0x1a, 0x47,
+	0x14, 0xde, 0x35, 0x60, 0x9b, 0xc7, 0x0f, 0xc3, 0xe0, 0xda, 0x18, 0x39, 0xd8, 0xa1, 0xbf, 0x22,
+	0x57, 0x85, 0x2a, 0x95, 0x2a, 0x55, 0xe9, 0xa5, 0xb6, 0x57, 0x5d, 0x84, 0x02, 0x64, 0xc0, 0x75,
+	0x14, 0xa9, 0x5a, 0xf1, 0x63, 0x0a, 0xdb, 0x18, 0x66, 0xbc, 0xb3, 0x58, 0xe1, 0xdc, 0x5b, 0x4f,
+	0x95, 0x72, 0xeb, 0x5f, 0x50, 0xf5, 0xd8, 0xbf, 0x22, 0xc7, 0x1c, 0x7b, 0xaa, 0x2a, 0xfb, 0xdc,
+	0xff, 0xa1, 0xda, 0xd9, 0x61, 0x59, 0x76, 0xa1, 0x89, 0xe5, 0x56, 0xb9, 0xed, 0x7e, 0x6f, 0xe6,
+	0x7b, 0xdf, 0x7c, 0x6f, 0xde, 0x83, 0x85, 0xa2, 0x4d, 0xc6, 0x7d, 0x62, 0x8d, 0xcc, 0xb1, 0x5d,
+	0x61, 0x96, 0x79, 0x75, 0xd5, 0xb9, 0xa8, 0xd8, 0x53, 0x46, 0x78, 0x99, 0x59, 0xd4, 0xa6, 0x08,
+	0xcd, 0xe3, 0x65, 0x19, 0x2f, 0xec, 0xfb, 0xf6, 0xf4, 0xac, 0x29, 0xb3, 0x69, 0xe5, 0x39, 0x99,
+	0xca, 0x1d, 0x0b, 0x51, 0xc1, 0xe4, 0xe7, 0x2b, 0x6c, 0x0f, 0xe8, 0x80, 0x8a, 0xc7, 0x8a, 0xf3,
+	0xe4, 0xa2, 0xa5, 0x2a, 0x64, 0x31, 0x19, 0x51, 0x9b, 0xb4, 0xcc, 0xc1, 0x98, 0x58, 0x9a, 0x65,
+	0x51, 0x0b, 0x21, 0x88, 0xf6, 0x68, 0x9f, 0xe4, 0xd5, 0x43, 0xf5, 0x41, 0x0c, 0x8b, 0x67, 0x74,
+	0x08, 0x89, 0x3e, 0xe1, 0x3d, 0xcb, 0x64, 0xb6, 0x49, 0xc7, 0xf9, 0xb5, 0x43, 0xf5, 0x41, 0x1c,
+	0xfb, 0xa1, 0x52, 0x0d, 0x52, 0xcd, 0x49, 0xb7, 0x46, 0xa6, 0x98, 0x5c, 0x4e, 0x08, 0xb7, 0xd1,
+	0x1e, 0x6c, 0xf6, 0x86, 0x1d, 0x73, 0x6c, 0x98, 0x7d, 0x41, 0x15, 0xc7, 0x1b, 0xe2, 0xbd, 0xda,
+	0x47, 0x07, 0x90, 0xb8, 0x9c, 0x50, 0x6b, 0x32, 0x32, 0x86, 0x1d, 0x3e, 0x14, 0x6c, 0x49, 0x0c,
+	0x2e, 0xa4, 0x77, 0xf8, 0xb0, 0xd4, 0x86, 0x9d, 0xf6, 0xd0, 0x22, 0x7c, 0x48, 0x2f, 0xfa, 0xff,
+	0x1d, 0xeb, 0xa7, 0x90, 0x69, 0x5a, 0xb4, 0xfd, 0xc2, 0x79, 0x79, 0x33, 0x5f, 0xe9, 0x27, 0x15,
+	0xd2, 0xb3, 0xe4, 0x9c, 0xd1, 0x31, 0x27, 0xe8, 0x11, 0x6c, 0xb0, 0x49, 0xd7, 0x78, 0x4e, 0xa6,
+	0x62, 0x71, 0xe2, 0xe1, 0x7e, 0xd9, 0x57, 0x27, 0xb7, 0x26, 0xe5, 0xe6, 0xa4, 0x7b, 0x61, 0xf6,
+	0x6a, 0x64, 0x7a, 0x1c, 0x7d, 0xf5, 0xe7, 0x81, 0x82, 0xd7, 0x99, 0x20, 0x41, 0x8f, 0x20, 0x46,
+	0x1c, 0x83, 0x85, 0xb2, 0xc4, 0xc3, 0x0f, 0xcb, 0xe1, 0x12, 0x97, 0x43, 0xd5, 0xc0, 0xee, 0x9e,
+	0xd2, 0x4b, 0x15, 0x76, 0x43, 0x96, 0xbc, 0x73, 0x55, 0x0c, 0xb2, 0x3e, 0x47, 0xa5, 0x9c, 0x22,
+	0x24, 0x98, 0x45, 0x0d, 0xfb, 0x85, 0x5b, 0x07, 0x55, 0xd4, 0x21, 0xce, 0x66, 0xeb, 0xee, 0x96,
+	0xf1, 0x17, 0x15, 0xb6, 0x1c, 0xf8, 0x5b, 0x6a, 0x93, 0x59, 0x0d, 0x8f, 0x20, 0x7a, 0x45, 0x6d,
+	0x22, 0x0f, 0xbf, 0xe3, 0xe7, 0x73, 0x5b, 0x40, 0x2c, 0x16, 0x6b, 0x16, 0xea, 0xbd, 0xb6, 0xea,
+	0xfe, 0x38, 0xbb, 0xf2, 0x11, 0x71, 0xfd, 0xe5, 0xfd, 0x69, 0x4f, 0x19, 0x09, 0x5e, 0xb0, 0x68,
+	0xe8, 0x82, 0xfd, 0xa8, 0x02, 0x12, 0x9a, 0xfb, 0xae, 0x3c, 0x69, 0xc8, 0x67, 0x6f, 0xa3, 0x4f,
+	0x96, 0xc5, 0x55, 0x79, 0x27, 0x8b, 0x7e, 0x53, 0x21, 0xe7, 0xc0, 0x4d, 0x8b, 0x32, 0xca, 0x3b,
+	0x17, 0x33, 0x9b, 0xbe, 0x80, 0x4d, 0x26, 0x21, 0x29, 0xa5, 0x10, 0x96, 0xe2, 0x6d, 0xf2, 0xd6,
+	0xfe, 0xbf, 0x96, 0xbd, 0x54, 0x61, 0xc7, 0xb5, 0x6c, 0x2e, 0x57, 0xda, 0xf6, 0xd5, 0x6d, 0xf4,
+	0x4a, 0xfb, 0xe6, 0xaa, 0xef, 0x64, 0xe1, 0xef, 0x2a, 0x64, 0x9f, 0x08, 0x91, 0x4e, 0xf0, 0x56,
+	0xb3, 0x47, 0x18, 0xb1, 0xf6, 0x26, 0x23, 0x22, 0x41, 0x23, 0xd0, 0x3d, 0x00, 0xcb, 0xcd, 0xe3,
+	0xd0, 0xbb, 0x46, 0xc5, 0x25, 0x52, 0xed, 0xa3, 0xfb, 0x90, 0x1c, 0x11, 0xce, 0x3b, 0x03, 0xe2,
+	0x12, 0xc4, 0xc4, 0x82, 0x84, 0xc4, 0x84, 0x95, 0x14, 0x90, 0x5f, 0xb3, 0x74, 0x71, 0x1f, 0xe2,
+	0xdc, 0x1c, 0x8c, 0x3b, 0xf6, 0xc4, 0x22, 0xb3, 0x5e, 0xf4, 0x80, 0xbb, 0xb9, 0x94, 0x82, 0x44,
+	0xd3, 0x1c, 0x0f, 0xa4, 0x3d, 0xa5, 0x34, 0x24, 0xdd, 0x57, 0x37, 0x73, 0xe9, 0xef, 0x38, 0x6c,
+	0x3c, 0x76, 0xf5, 0xa1, 0x1a, 0x6c, 0xc9, 0x11, 0x65, 0xc8, 0x33, 0xc9, 0x92, 0xde, 0x5f, 0x96,
+	0x71, 0x61, 0xe4, 0xeb, 0x0a, 0x4e, 0x31, 0x3f, 0x80, 0xea, 0x90, 0x99, 0x93, 0xb9, 0xc9, 0xa4,
+	0xfe, 0xd2, 0xbf, 0xb1, 0xb9, 0x2b, 0x75, 0x05, 0xa7, 0xd9, 0x02, 0x82, 0x9e, 0x40, 0xd6, 0x71,
+	0xc4, 0x70, 0x5a, 0xcf, 0x93, 0x17, 0x11, 0x84, 0xef, 0x2f, 0x23, 0x0c, 0xcc, 0x1f, 0x5d, 0xc1,
+	0x5b, 0x7c, 0x11, 0x42, 0xcf, 0x60, 0x9b, 0x8b, 0x5b, 0x3d, 0x23, 0x95, 0x32, 0xa3, 0x82, 0xf5,
+	0xa3, 0x55, 0xac, 0x8b, 0x83, 0x43, 0x57, 0x30, 0xe2, 0x21, 0x14, 0x7d, 0x07, 0xef, 0x09, 0xb9,
+	0xb3, 0xab, 0xee, 0x49, 0x8e, 0x09, 0xf2, 0x8f, 0x57, 0x91, 0x07, 0xe6, 0x81, 0xae, 0xe0, 0x1c,
+	0x0f, 0xc3, 0xe8, 0x7b, 0xc8, 0x4b, 0xe9, 0xbe, 0x04, 0x52, 0xfe, 0xba, 0xc8, 0x70, 0xb4, 0x5a,
+	0x7e, 0xb0, 0x89, 0x75, 0x05, 0xef, 0xf0, 0xe5, 0xed, 0x7d, 0x0a, 0x49, 0x66, 0x8e, 0x07, 0x9e,
+	0xfa, 0x0d, 0xc1, 0x7d, 0xb0, 0xb4, 0x82, 0xf3, 0x5b, 0xa6, 0x2b, 0x38, 0xc1, 0xe6, 0xaf, 0xe8,
+	0x1b, 0x48, 0x49, 0x16, 0x29, 0x71, 0x53, 0xd0, 0x1c, 0xae, 0xa6, 0xf1, 0x84, 0x25, 0x99, 0xef,
+	0x1d, 0x9d, 0x41, 0xce, 0xf7, 0xab, 0xe5, 0xa9, 0x8a, 0x0b, 0xba, 0x0f, 0x96, 0xd2, 0x05, 0xfe,
+	0x4b, 0xe8, 0x0a, 0xce, 0xb0, 0x00, 0x86, 0x9e, 0xc2, 0xf6, 0x22, 0xad, 0x94, 0x09, 0xab, 0xfb,
+	0x2d, 0xf4, 0x8b, 0xaa, 0x2b, 0x38, 0xcb, 0x82, 0x20, 0x1a, 0xc0, 0x9e, 0x3d, 0xfb, 0x43, 0x60,
+	0x04, 0x9b, 0x2b, 0xb1, 0xba, 0x50, 0xcb, 0xff, 0x58, 0x39, 0x85, 0xb2, 0x97, 0x46, 0xd0, 0x0f,
+	0x50, 0x58, 0x96, 0x48, 0x1e, 0x24, 0x29, 0x32, 0x7d, 0xf2, 0x56, 0x99, 0xbc, 0xe3, 0xec, 0xda,
+	0xcb, 0x43, 0xe8, 0x1c, 0x72, 0x72, 0x4c, 0x8a, 0x2b, 0x3e, 0x3b, 0x4e, 0x6a, 0xb5, 0x5b, 0xa1,
+	0x31, 0xed, 0xb8, 0x75, 0x19, 0x04, 0x9d, 0x86, 0x5c, 0x24, 0x96, 0xf2, 0xd3, 0xab, 0x1b, 0x32,
+	0x3c, 0x4c, 0x9d, 0x86, 0xbc, 0x0c, 0xa1, 0xc7, 0x31, 0x88, 0xf0, 0xc9, 0xe8, 0xe8, 0x57, 0x15,
+	0xd6, 0xc5, 0x7c, 0xe4, 0x08, 0x41, 0x5a, 0xc3, 0xb8, 0x81, 0x5b, 0xc6, 0x59, 0xbd, 0x56, 0x6f,
+	0x9c, 0xd7, 0x33, 0x0a, 0x2a, 0x42, 0xc1, 0xc3, 0xb4, 0xa7, 0x4d, 0xed, 0xa4, 0xad, 0x9d, 0x1a,
+	0x58, 0x6b, 0x35, 0x1b, 0xf5, 0x96, 0x96, 0x51, 0x51, 0x1e, 0xb6, 0x65, 0xbc, 0xde, 0x30, 0x4e,
+	0x1a, 0xf5, 0xba, 0x76, 0xd2, 0xae, 0x36, 0xea, 0x99, 0x35, 0x74, 0x0f, 0xf6, 0x64, 0x64, 0x0e,
+	0x1b, 0xed, 0xea, 0x63, 0xad, 0x71, 0xd6, 0xce, 0x44, 0xd0, 0x2e, 0xe4, 0x64, 0x18, 0x6b, 0x5f,
+	0x9f, 0x7a, 0x81, 0xa8, 0x8f, 0xf1, 0x1c, 0x57, 0xdb, 0x9a, 0x17, 0x89, 0x1d, 0xb7, 0x5e, 0x5d,
+	0x17, 0xd5, 0xd7, 0xd7, 0x45, 0xf5, 0xaf, 0xeb, 0xa2, 0xfa, 0xf3, 0x4d, 0x51, 0x79, 0x7d, 0x53,
+	0x54, 0xfe, 0xb8, 0x29, 0x2a, 0xcf, 0xbe, 0x1c, 0x98, 0xf6, 0x70, 0xd2, 0x2d, 0xf7, 0xe8, 0xa8,
+	0xe2, 0xff, 0xa0, 0x98, 0x3f, 0xba, 0x1f, 0x11, 0xe1, 0xcf, 0x97, 0xee, 0xba, 0x88, 0x7c, 0xfe,
+	0xcf, 0x00, 0xfc, 0xd0, 0x0a, 0x74, 0xdb, 0x0c, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -1510,6 +1675,104 @@ func (m *SignedProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *QuorumSignRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuorumSignRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuorumSignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MessageHash) > 0 {
+		i -= len(m.MessageHash)
+		copy(dAtA[i:], m.MessageHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MessageHash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.RequestId) > 0 {
+		i -= len(m.RequestId)
+		copy(dAtA[i:], m.RequestId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.RequestId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.QuorumHash) > 0 {
+		i -= len(m.QuorumHash)
+		copy(dAtA[i:], m.QuorumHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.QuorumHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.QuorumType != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.QuorumType))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuorumSignResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuorumSignResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuorumSignResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *PingRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1840,6 +2103,48 @@ func (m *Message_ThresholdPubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_QuorumSignRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_QuorumSignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.QuorumSignRequest != nil {
+		{
+			size, err := m.QuorumSignRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_QuorumSignResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_QuorumSignResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.QuorumSignResponse != nil {
+		{
+			size, err := m.QuorumSignResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -2039,6 +2344,51 @@ func (m *SignedProposalResponse) Size() (n int) {
 	return n
 }
 
+func (m *QuorumSignRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.QuorumType != 0 {
+		n += 1 + sovTypes(uint64(m.QuorumType))
+	}
+	l = len(m.QuorumHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.RequestId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.MessageHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *QuorumSignResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *PingRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2213,15 +2563,39 @@ func (m *Message_ThresholdPubKeyResponse) Size() (n int) {
 	}
 	return n
 }
-
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *RemoteSignerError) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
+func (m *Message_QuorumSignRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QuorumSignRequest != nil {
+		l = m.QuorumSignRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_QuorumSignResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QuorumSignResponse != nil {
+		l = m.QuorumSignResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RemoteSignerError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
 		preIndex := iNdEx
@@ -3573,6 +3947,329 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QuorumSignRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuorumSignRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuorumSignRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumType", wireType)
+			}
+			m.QuorumType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QuorumType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QuorumHash = append(m.QuorumHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.QuorumHash == nil {
+				m.QuorumHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestId = append(m.RequestId[:0], dAtA[iNdEx:postIndex]...)
+			if m.RequestId == nil {
+				m.RequestId = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageHash = append(m.MessageHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.MessageHash == nil {
+				m.MessageHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuorumSignResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuorumSignResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuorumSignResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PingRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4122,6 +4819,76 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_ThresholdPubKeyResponse{v}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumSignRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &QuorumSignRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_QuorumSignRequest{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumSignResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &QuorumSignResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_QuorumSignResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
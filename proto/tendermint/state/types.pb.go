@@ -199,6 +199,60 @@ func (m *ConsensusParamsInfo) GetLastHeightChanged() int64 {
 	return 0
 }
 
+// RollbackMarker records a rollback of the node, which the operator hasn't
+// confirmed yet. The block discarded at height + 1 isn't synced from peers.
+type RollbackMarker struct {
+	Height  int64          `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockID types1.BlockID `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id"`
+}
+
+func (m *RollbackMarker) Reset()         { *m = RollbackMarker{} }
+func (m *RollbackMarker) String() string { return proto.CompactTextString(m) }
+func (*RollbackMarker) ProtoMessage()    {}
+func (*RollbackMarker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{3}
+}
+func (m *RollbackMarker) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackMarker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackMarker.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RollbackMarker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackMarker.Merge(m, src)
+}
+func (m *RollbackMarker) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackMarker) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackMarker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackMarker proto.InternalMessageInfo
+
+func (m *RollbackMarker) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RollbackMarker) GetBlockID() types1.BlockID {
+	if m != nil {
+		return m.BlockID
+	}
+	return types1.BlockID{}
+}
+
 type Version struct {
 	Consensus version.Consensus `protobuf:"bytes,1,opt,name=consensus,proto3" json:"consensus"`
 	Software  string            `protobuf:"bytes,2,opt,name=software,proto3" json:"software,omitempty"`
@@ -208,7 +262,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{3}
+	return fileDescriptor_ccfacf933f22bf93, []int{4}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -287,7 +341,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{4}
+	return fileDescriptor_ccfacf933f22bf93, []int{5}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -439,6 +493,7 @@ func init() {
 	proto.RegisterType((*ABCIResponses)(nil), "tendermint.state.ABCIResponses")
 	proto.RegisterType((*ValidatorsInfo)(nil), "tendermint.state.ValidatorsInfo")
 	proto.RegisterType((*ConsensusParamsInfo)(nil), "tendermint.state.ConsensusParamsInfo")
+	proto.RegisterType((*RollbackMarker)(nil), "tendermint.state.RollbackMarker")
 	proto.RegisterType((*Version)(nil), "tendermint.state.Version")
 	proto.RegisterType((*State)(nil), "tendermint.state.State")
 }
@@ -446,60 +501,63 @@ func init() {
 func init() { proto.RegisterFile("tendermint/state/types.proto", fileDescriptor_ccfacf933f22bf93) }
 
 var fileDescriptor_ccfacf933f22bf93 = []byte{
-	// 843 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x96, 0xcf, 0x6f, 0xe2, 0x46,
-	0x14, 0xc7, 0x71, 0xd9, 0x5d, 0x60, 0x1c, 0x60, 0x77, 0xb2, 0x07, 0x2f, 0xdb, 0x35, 0x2c, 0xdb,
-	0x56, 0xa8, 0x07, 0x23, 0x6d, 0x0f, 0x55, 0x2f, 0x91, 0x62, 0x53, 0x35, 0xa8, 0x51, 0xd5, 0x3a,
-	0x51, 0x0e, 0xbd, 0x58, 0x83, 0x3d, 0xd8, 0x56, 0xc1, 0xb6, 0x3c, 0x03, 0x4d, 0xff, 0x80, 0xde,
-	0x73, 0xed, 0x7f, 0x94, 0x63, 0x8e, 0x55, 0x0e, 0x69, 0x45, 0xfe, 0x91, 0x6a, 0x7e, 0xd8, 0x1e,
-	0x20, 0x91, 0x52, 0xed, 0x6d, 0x3c, 0xef, 0xfb, 0x3e, 0xf3, 0xf5, 0xf3, 0xbc, 0x07, 0xe0, 0x73,
-	0x8a, 0x93, 0x00, 0xe7, 0xcb, 0x38, 0xa1, 0x63, 0x42, 0x11, 0xc5, 0x63, 0xfa, 0x47, 0x86, 0x89,
-	0x95, 0xe5, 0x29, 0x4d, 0xe1, 0xcb, 0x2a, 0x6a, 0xf1, 0x68, 0xef, 0x75, 0x98, 0x86, 0x29, 0x0f,
-	0x8e, 0xd9, 0x4a, 0xe8, 0x7a, 0x6f, 0x15, 0x0a, 0x9a, 0xf9, 0xb1, 0x0a, 0xe9, 0xa9, 0x47, 0xf0,
-	0xfd, 0xad, 0xe8, 0x60, 0x2f, 0xba, 0x46, 0x8b, 0x38, 0x40, 0x34, 0xcd, 0xa5, 0xe2, 0xdd, 0x9e,
-	0x22, 0x43, 0x39, 0x5a, 0x16, 0x00, 0x53, 0x09, 0xaf, 0x71, 0x4e, 0xe2, 0x34, 0xd9, 0x3a, 0xa0,
-	0x1f, 0xa6, 0x69, 0xb8, 0xc0, 0x63, 0xfe, 0x34, 0x5b, 0xcd, 0xc7, 0x34, 0x5e, 0x62, 0x42, 0xd1,
-	0x32, 0x13, 0x82, 0xe1, 0xad, 0x06, 0xda, 0xc7, 0xb6, 0x33, 0x75, 0x31, 0xc9, 0xd2, 0x84, 0x60,
-	0x02, 0x1d, 0xa0, 0x07, 0x78, 0x11, 0xaf, 0x71, 0xee, 0xd1, 0x4b, 0x62, 0x68, 0x83, 0xfa, 0x48,
-	0xff, 0x38, 0xb4, 0x94, 0x62, 0xb0, 0x97, 0xb4, 0x8a, 0x84, 0x89, 0xd0, 0x9e, 0x5f, 0xba, 0x20,
-	0x28, 0x96, 0x04, 0x1e, 0x81, 0x16, 0x4e, 0x02, 0x6f, 0xb6, 0x48, 0xfd, 0xdf, 0x8c, 0xcf, 0x06,
-	0xda, 0x48, 0xff, 0xf8, 0xfe, 0x51, 0xc4, 0xf7, 0x49, 0x60, 0x33, 0xa1, 0xdb, 0xc4, 0x72, 0x05,
-	0x27, 0x40, 0x9f, 0xe1, 0x30, 0x4e, 0x24, 0xa1, 0xce, 0x09, 0x1f, 0x1e, 0x25, 0xd8, 0x4c, 0x2b,
-	0x18, 0x60, 0x56, 0xae, 0x87, 0x7f, 0x6a, 0xa0, 0x73, 0x51, 0x14, 0x94, 0x4c, 0x93, 0x79, 0x0a,
-	0x1d, 0xd0, 0x2e, 0x4b, 0xec, 0x11, 0x4c, 0x0d, 0x8d, 0xa3, 0x4d, 0x15, 0x2d, 0x0a, 0x58, 0x26,
-	0x9e, 0x61, 0xea, 0x1e, 0xac, 0x95, 0x27, 0x68, 0x81, 0xc3, 0x05, 0x22, 0xd4, 0x8b, 0x70, 0x1c,
-	0x46, 0xd4, 0xf3, 0x23, 0x94, 0x84, 0x38, 0xe0, 0xef, 0x59, 0x77, 0x5f, 0xb1, 0xd0, 0x09, 0x8f,
-	0x38, 0x22, 0x30, 0xfc, 0x4b, 0x03, 0x87, 0x0e, 0xf3, 0x99, 0x90, 0x15, 0xf9, 0x99, 0x7f, 0x3f,
-	0x6e, 0xc6, 0x05, 0x2f, 0xfd, 0x62, 0xdb, 0x13, 0xdf, 0x55, 0xfa, 0x79, 0xbf, 0xef, 0x67, 0x07,
-	0x60, 0x3f, 0xbb, 0xbe, 0xeb, 0xd7, 0xdc, 0xae, 0xbf, 0xbd, 0xfd, 0xbf, 0xbd, 0x45, 0xa0, 0x71,
-	0x21, 0x2e, 0x0e, 0x3c, 0x06, 0xad, 0x92, 0x26, 0x7d, 0xbc, 0x53, 0x7d, 0xc8, 0x0b, 0x56, 0x39,
-	0x91, 0x1e, 0xaa, 0x2c, 0xd8, 0x03, 0x4d, 0x92, 0xce, 0xe9, 0xef, 0x28, 0xc7, 0xfc, 0xc8, 0x96,
-	0x5b, 0x3e, 0x0f, 0x6f, 0x9b, 0xe0, 0xf9, 0x19, 0xeb, 0x23, 0xf8, 0x1d, 0x68, 0x48, 0x96, 0x3c,
-	0xe6, 0x8d, 0xb5, 0xdb, 0x6b, 0x96, 0x34, 0x25, 0x8f, 0x28, 0xf4, 0xf0, 0x2b, 0xd0, 0xf4, 0x23,
-	0x14, 0x27, 0x5e, 0x2c, 0xde, 0xa9, 0x65, 0xeb, 0x9b, 0xbb, 0x7e, 0xc3, 0x61, 0x7b, 0xd3, 0x89,
-	0xdb, 0xe0, 0xc1, 0x69, 0x00, 0xbf, 0x04, 0x9d, 0x38, 0x89, 0x69, 0x8c, 0x16, 0xb2, 0x12, 0x46,
-	0x87, 0x57, 0xa0, 0x2d, 0x77, 0x45, 0x11, 0xe0, 0xd7, 0x80, 0x97, 0x44, 0x5c, 0xb3, 0x42, 0x59,
-	0xe7, 0xca, 0x2e, 0x0b, 0xf0, 0x7b, 0x24, 0xb5, 0x2e, 0x68, 0x2b, 0xda, 0x38, 0x30, 0x9e, 0xed,
-	0x7b, 0x17, 0x9f, 0x8a, 0x67, 0x4d, 0x27, 0xf6, 0x21, 0xf3, 0xbe, 0xb9, 0xeb, 0xeb, 0xa7, 0x05,
-	0x6a, 0x3a, 0x71, 0xf5, 0x92, 0x3b, 0x0d, 0x4a, 0x26, 0x7f, 0x67, 0xc6, 0x9c, 0x3f, 0xc6, 0xe4,
-	0x95, 0xdb, 0x65, 0xca, 0x4d, 0xc1, 0x14, 0x0f, 0x01, 0x3c, 0x05, 0x5d, 0xc5, 0x27, 0x6b, 0x78,
-	0xe3, 0x39, 0xa7, 0xf6, 0x2c, 0x31, 0x0d, 0xac, 0x62, 0x1a, 0x58, 0xe7, 0xc5, 0x34, 0xb0, 0x9b,
-	0x0c, 0x7b, 0xf5, 0x4f, 0x5f, 0x73, 0xdb, 0xa5, 0x3f, 0x16, 0x85, 0x3f, 0x82, 0x0f, 0x9c, 0xe6,
-	0xa7, 0x39, 0xf6, 0x44, 0xe9, 0x59, 0x0c, 0x07, 0xdb, 0x35, 0x0b, 0x06, 0xda, 0xa8, 0xed, 0x9a,
-	0x4c, 0xea, 0xa4, 0x39, 0xe6, 0xdf, 0xe3, 0x94, 0xeb, 0xd4, 0x12, 0x5e, 0x80, 0xd7, 0x09, 0xbe,
-	0xdc, 0x83, 0x19, 0x98, 0xfb, 0xeb, 0x3f, 0x74, 0xe9, 0x15, 0x16, 0xbf, 0x0b, 0x9a, 0xfb, 0x8a,
-	0x21, 0xb6, 0x02, 0xf0, 0x07, 0xd0, 0xe5, 0xdc, 0xb2, 0x4b, 0x89, 0xf1, 0xe2, 0x49, 0x7d, 0xdd,
-	0x61, 0x69, 0xd5, 0x88, 0x80, 0x47, 0x00, 0x28, 0x8c, 0xc6, 0x93, 0x18, 0x4a, 0x06, 0x33, 0xc2,
-	0xab, 0xa5, 0x40, 0x9a, 0x4f, 0x33, 0xc2, 0xd2, 0x14, 0x23, 0x0e, 0x30, 0xd5, 0x36, 0xae, 0x78,
-	0x65, 0x47, 0xb7, 0xf8, 0x2d, 0x7d, 0x5b, 0x75, 0x74, 0x95, 0x2d, 0x7b, 0xfb, 0xc1, 0xf9, 0x02,
-	0x3e, 0x71, 0xbe, 0xfc, 0x04, 0xbe, 0xd8, 0x9a, 0x2f, 0x3b, 0xfc, 0xd2, 0x9e, 0xce, 0xed, 0x0d,
-	0x94, 0x81, 0xb3, 0x0d, 0x2a, 0x3c, 0x16, 0x1d, 0x98, 0x63, 0xb2, 0x5a, 0x50, 0xe2, 0x45, 0x88,
-	0x44, 0xc6, 0xc1, 0x40, 0x1b, 0x1d, 0x88, 0x0e, 0x74, 0xc5, 0xfe, 0x09, 0x22, 0x11, 0x7c, 0x03,
-	0x9a, 0x28, 0xcb, 0x84, 0xa4, 0xcd, 0x25, 0x0d, 0x94, 0x65, 0x2c, 0x64, 0xff, 0x72, 0xbd, 0x31,
-	0xb5, 0x9b, 0x8d, 0xa9, 0xfd, 0xbb, 0x31, 0xb5, 0xab, 0x7b, 0xb3, 0x76, 0x73, 0x6f, 0xd6, 0xfe,
-	0xbe, 0x37, 0x6b, 0xbf, 0x7e, 0x1b, 0xc6, 0x34, 0x5a, 0xcd, 0x2c, 0x3f, 0x5d, 0x8e, 0xd5, 0x1f,
-	0xd3, 0x6a, 0x29, 0x7e, 0xd1, 0x77, 0xff, 0x0b, 0xcc, 0x5e, 0xf0, 0xfd, 0x6f, 0xfe, 0x0b, 0x00,
-	0x00, 0xff, 0xff, 0xe3, 0xad, 0x37, 0x54, 0x26, 0x08, 0x00, 0x00,
+	// 882 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x96, 0xcf, 0x6f, 0xdb, 0x36,
+	0x14, 0xc7, 0xa3, 0xb9, 0x8d, 0xed, 0xe7, 0xd8, 0x6e, 0x99, 0x62, 0x50, 0xdd, 0x55, 0xf6, 0xdc,
+	0x6d, 0x08, 0x76, 0x90, 0x81, 0xee, 0x30, 0xec, 0x32, 0xa0, 0x76, 0x8a, 0xd5, 0x58, 0x36, 0x6c,
+	0x6a, 0x91, 0xc3, 0x2e, 0x02, 0x2d, 0x31, 0x96, 0x10, 0x59, 0x14, 0x44, 0x26, 0xcb, 0xfe, 0x80,
+	0xdd, 0x7b, 0xdd, 0x7f, 0xd4, 0x63, 0x8f, 0x43, 0x0f, 0xdd, 0x90, 0xfc, 0x23, 0x03, 0x1f, 0x29,
+	0x99, 0x8e, 0x1b, 0x2c, 0xc3, 0x6e, 0xe4, 0xfb, 0xf1, 0xd1, 0xf7, 0x3d, 0x92, 0xcf, 0x86, 0x4f,
+	0x24, 0xcb, 0x63, 0x56, 0xae, 0xd2, 0x5c, 0x4e, 0x84, 0xa4, 0x92, 0x4d, 0xe4, 0x6f, 0x05, 0x13,
+	0x7e, 0x51, 0x72, 0xc9, 0xc9, 0xbd, 0xb5, 0xd7, 0x47, 0xef, 0xe0, 0xc1, 0x92, 0x2f, 0x39, 0x3a,
+	0x27, 0x6a, 0xa5, 0xe3, 0x06, 0x8f, 0x2c, 0x0a, 0x5d, 0x44, 0xa9, 0x0d, 0x19, 0xd8, 0x9f, 0x40,
+	0xfb, 0x86, 0x77, 0xb4, 0xe5, 0x3d, 0xa7, 0x59, 0x1a, 0x53, 0xc9, 0x4b, 0x13, 0xf1, 0x78, 0x2b,
+	0xa2, 0xa0, 0x25, 0x5d, 0x55, 0x00, 0xcf, 0x72, 0x9f, 0xb3, 0x52, 0xa4, 0x3c, 0xdf, 0xf8, 0xc0,
+	0x70, 0xc9, 0xf9, 0x32, 0x63, 0x13, 0xdc, 0x2d, 0xce, 0x4e, 0x26, 0x32, 0x5d, 0x31, 0x21, 0xe9,
+	0xaa, 0xd0, 0x01, 0xe3, 0x77, 0x0e, 0x74, 0x9f, 0x4d, 0x67, 0xf3, 0x80, 0x89, 0x82, 0xe7, 0x82,
+	0x09, 0x32, 0x83, 0x4e, 0xcc, 0xb2, 0xf4, 0x9c, 0x95, 0xa1, 0xbc, 0x10, 0xae, 0x33, 0x6a, 0x1c,
+	0x74, 0x9e, 0x8e, 0x7d, 0xab, 0x19, 0xaa, 0x48, 0xbf, 0x4a, 0x38, 0xd4, 0xb1, 0xaf, 0x2e, 0x02,
+	0x88, 0xab, 0xa5, 0x20, 0xdf, 0x42, 0x9b, 0xe5, 0x71, 0xb8, 0xc8, 0x78, 0x74, 0xea, 0x7e, 0x34,
+	0x72, 0x0e, 0x3a, 0x4f, 0x3f, 0xbd, 0x11, 0xf1, 0x3c, 0x8f, 0xa7, 0x2a, 0x30, 0x68, 0x31, 0xb3,
+	0x22, 0x87, 0xd0, 0x59, 0xb0, 0x65, 0x9a, 0x1b, 0x42, 0x03, 0x09, 0x4f, 0x6e, 0x24, 0x4c, 0x55,
+	0xac, 0x66, 0xc0, 0xa2, 0x5e, 0x8f, 0x7f, 0x77, 0xa0, 0x77, 0x5c, 0x35, 0x54, 0xcc, 0xf3, 0x13,
+	0x4e, 0x66, 0xd0, 0xad, 0x5b, 0x1c, 0x0a, 0x26, 0x5d, 0x07, 0xd1, 0x9e, 0x8d, 0xd6, 0x0d, 0xac,
+	0x13, 0x5f, 0x32, 0x19, 0xec, 0x9d, 0x5b, 0x3b, 0xe2, 0xc3, 0x7e, 0x46, 0x85, 0x0c, 0x13, 0x96,
+	0x2e, 0x13, 0x19, 0x46, 0x09, 0xcd, 0x97, 0x2c, 0xc6, 0x3a, 0x1b, 0xc1, 0x7d, 0xe5, 0x7a, 0x81,
+	0x9e, 0x99, 0x76, 0x8c, 0xff, 0x70, 0x60, 0x7f, 0xa6, 0x74, 0xe6, 0xe2, 0x4c, 0xfc, 0x84, 0xe7,
+	0x87, 0x62, 0x02, 0xb8, 0x17, 0x55, 0xe6, 0x50, 0x9f, 0xab, 0xeb, 0x6c, 0x37, 0x4b, 0xeb, 0xb9,
+	0x06, 0x98, 0xde, 0x79, 0xf3, 0x7e, 0xb8, 0x13, 0xf4, 0xa3, 0x4d, 0xf3, 0x7f, 0xd6, 0xc6, 0xa1,
+	0x17, 0xf0, 0x2c, 0x5b, 0xd0, 0xe8, 0xf4, 0x07, 0x5a, 0x9e, 0xb2, 0x92, 0x7c, 0x0c, 0xbb, 0x3a,
+	0x19, 0xb5, 0x34, 0x02, 0xb3, 0x23, 0xcf, 0xa1, 0x85, 0xa7, 0x11, 0xa6, 0xb1, 0x39, 0xd2, 0x87,
+	0xdb, 0x2a, 0xb1, 0xf1, 0xf3, 0xc3, 0x69, 0x5f, 0xa9, 0xbb, 0x7c, 0x3f, 0x6c, 0x1a, 0x43, 0xd0,
+	0xc4, 0xdc, 0x79, 0x3c, 0x4e, 0xa0, 0x79, 0xac, 0x6f, 0x2a, 0x79, 0x06, 0xed, 0x5a, 0xbe, 0x29,
+	0xfc, 0xb1, 0x8d, 0x34, 0x37, 0x7a, 0x5d, 0xba, 0x29, 0x7a, 0x9d, 0x45, 0x06, 0xd0, 0x12, 0xfc,
+	0x44, 0xfe, 0x4a, 0x4b, 0x86, 0xa2, 0xda, 0x41, 0xbd, 0x1f, 0xbf, 0x6b, 0xc1, 0xdd, 0x97, 0xea,
+	0xe1, 0x92, 0x6f, 0xa0, 0x69, 0x58, 0xae, 0xb3, 0xad, 0x1c, 0x1f, 0xb7, 0x6f, 0x44, 0x99, 0x4f,
+	0x54, 0xf1, 0xe4, 0x0b, 0x68, 0x45, 0x09, 0x4d, 0xf3, 0xaa, 0xea, 0xf6, 0xb4, 0xa3, 0xca, 0x9a,
+	0x29, 0x9b, 0x2a, 0x0b, 0x9d, 0xf3, 0x98, 0x7c, 0x0e, 0xbd, 0x34, 0x4f, 0x65, 0x4a, 0x33, 0xd3,
+	0x7a, 0xb7, 0x87, 0xdd, 0xeb, 0x1a, 0xab, 0xee, 0x3a, 0xf9, 0x12, 0xf0, 0x0c, 0xf4, 0xbd, 0xae,
+	0x22, 0x1b, 0x18, 0xd9, 0x57, 0x0e, 0x6c, 0x97, 0x89, 0x0d, 0xa0, 0x6b, 0xc5, 0xa6, 0xb1, 0x7b,
+	0xe7, 0xdf, 0xba, 0xbe, 0x6f, 0xba, 0xde, 0x39, 0xaa, 0x50, 0xf3, 0xc3, 0xa0, 0x53, 0x73, 0xe7,
+	0x71, 0xcd, 0xc4, 0x9a, 0x15, 0xf3, 0xe4, 0x26, 0x26, 0x76, 0xee, 0x3a, 0xd3, 0x18, 0x35, 0x53,
+	0x6f, 0x62, 0x72, 0x04, 0x7d, 0x4b, 0xa7, 0x9a, 0x30, 0xee, 0x5d, 0xa4, 0x0e, 0x7c, 0x3d, 0x7e,
+	0xfc, 0x6a, 0xfc, 0xf8, 0xaf, 0xaa, 0xf1, 0x33, 0x6d, 0x29, 0xec, 0xeb, 0xbf, 0x86, 0x4e, 0xd0,
+	0xad, 0xf5, 0x29, 0x2f, 0xf9, 0x1e, 0x9e, 0x20, 0x2d, 0xe2, 0x25, 0x0b, 0x75, 0xeb, 0x95, 0x8f,
+	0xc5, 0x9b, 0x3d, 0x8b, 0x47, 0xce, 0x41, 0x37, 0xf0, 0x54, 0xe8, 0x8c, 0x97, 0x0c, 0xcf, 0xe3,
+	0x08, 0xe3, 0xec, 0x16, 0x1e, 0xc3, 0x83, 0x9c, 0x5d, 0x6c, 0xc1, 0x5c, 0x86, 0xfa, 0x86, 0x1f,
+	0x7a, 0x65, 0x16, 0x0b, 0xef, 0x82, 0x13, 0xdc, 0x57, 0x88, 0x0d, 0x07, 0xf9, 0x0e, 0xfa, 0xc8,
+	0xad, 0xc7, 0x82, 0x70, 0x77, 0x6f, 0x35, 0x48, 0x7a, 0x2a, 0xad, 0xb6, 0xa8, 0x41, 0x09, 0x16,
+	0xa3, 0x79, 0x2b, 0x86, 0x95, 0xa1, 0x84, 0x60, 0xb7, 0x2c, 0x48, 0xeb, 0x76, 0x42, 0x54, 0x9a,
+	0x25, 0x64, 0x06, 0x9e, 0x3d, 0x37, 0xd6, 0xbc, 0x7a, 0x84, 0xb4, 0xf1, 0x96, 0x3e, 0x5a, 0x8f,
+	0x90, 0x75, 0xb6, 0x19, 0x26, 0x1f, 0x1c, 0x68, 0xf0, 0x3f, 0x07, 0xda, 0x8f, 0xf0, 0xd9, 0xc6,
+	0x40, 0xbb, 0xc6, 0xaf, 0xe5, 0x75, 0x50, 0xde, 0xc8, 0x9a, 0x70, 0x9b, 0xa0, 0x4a, 0x63, 0xf5,
+	0x02, 0x4b, 0x26, 0xce, 0x32, 0x29, 0xc2, 0x84, 0x8a, 0xc4, 0xdd, 0x1b, 0x39, 0x07, 0x7b, 0xfa,
+	0x05, 0x06, 0xda, 0xfe, 0x82, 0x8a, 0x84, 0x3c, 0x84, 0x16, 0x2d, 0x0a, 0x1d, 0xd2, 0xc5, 0x90,
+	0x26, 0x2d, 0x0a, 0xe5, 0x9a, 0xfe, 0xfc, 0xe6, 0xd2, 0x73, 0xde, 0x5e, 0x7a, 0xce, 0xdf, 0x97,
+	0x9e, 0xf3, 0xfa, 0xca, 0xdb, 0x79, 0x7b, 0xe5, 0xed, 0xfc, 0x79, 0xe5, 0xed, 0xfc, 0xf2, 0xf5,
+	0x32, 0x95, 0xc9, 0xd9, 0xc2, 0x8f, 0xf8, 0x6a, 0x62, 0xff, 0x7a, 0xaf, 0x97, 0xfa, 0x2f, 0xc4,
+	0xf5, 0x3f, 0x1f, 0x8b, 0x5d, 0xb4, 0x7f, 0xf5, 0xcf, 0x00, 0x30, 0x49, 0x3c, 0x8e, 0x97, 0x08,
+	0x00, 0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -641,6 +699,44 @@ func (m *ConsensusParamsInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RollbackMarker) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackMarker) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackMarker) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.BlockID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Version) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -809,12 +905,12 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x32
 	}
-	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
-	if err12 != nil {
-		return 0, err12
+	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
+	if err13 != nil {
+		return 0, err13
 	}
-	i -= n12
-	i = encodeVarintTypes(dAtA, i, uint64(n12))
+	i -= n13
+	i = encodeVarintTypes(dAtA, i, uint64(n13))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -916,6 +1012,20 @@ func (m *ConsensusParamsInfo) Size() (n int) {
 	return n
 }
 
+func (m *RollbackMarker) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = m.BlockID.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func (m *Version) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1362,6 +1472,108 @@ func (m *ConsensusParamsInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RollbackMarker) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollbackMarker: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollbackMarker: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Version) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
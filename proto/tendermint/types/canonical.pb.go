@@ -352,6 +352,74 @@ func (m *CanonicalVote) GetChainID() string {
 	return ""
 }
 
+type CanonicalVoteExtension struct {
+	Extension []byte `protobuf:"bytes,1,opt,name=extension,proto3" json:"extension,omitempty"`
+	Height    int64  `protobuf:"fixed64,2,opt,name=height,proto3" json:"height,omitempty"`
+	Round     int64  `protobuf:"fixed64,3,opt,name=round,proto3" json:"round,omitempty"`
+	ChainID   string `protobuf:"bytes,4,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *CanonicalVoteExtension) Reset()         { *m = CanonicalVoteExtension{} }
+func (m *CanonicalVoteExtension) String() string { return proto.CompactTextString(m) }
+func (*CanonicalVoteExtension) ProtoMessage()    {}
+func (*CanonicalVoteExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8d1a1a84ff7267ed, []int{5}
+}
+func (m *CanonicalVoteExtension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalVoteExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalVoteExtension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalVoteExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalVoteExtension.Merge(m, src)
+}
+func (m *CanonicalVoteExtension) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalVoteExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalVoteExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalVoteExtension proto.InternalMessageInfo
+
+func (m *CanonicalVoteExtension) GetExtension() []byte {
+	if m != nil {
+		return m.Extension
+	}
+	return nil
+}
+
+func (m *CanonicalVoteExtension) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CanonicalVoteExtension) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CanonicalVoteExtension) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
 type CanonicalStateVote struct {
 	Height  int64             `protobuf:"fixed64,1,opt,name=height,proto3" json:"height,omitempty"`
 	StateID *CanonicalStateID `protobuf:"bytes,2,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
@@ -361,7 +429,7 @@ func (m *CanonicalStateVote) Reset()         { *m = CanonicalStateVote{} }
 func (m *CanonicalStateVote) String() string { return proto.CompactTextString(m) }
 func (*CanonicalStateVote) ProtoMessage()    {}
 func (*CanonicalStateVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8d1a1a84ff7267ed, []int{5}
+	return fileDescriptor_8d1a1a84ff7267ed, []int{6}
 }
 func (m *CanonicalStateVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -410,48 +478,51 @@ func init() {
 	proto.RegisterType((*CanonicalPartSetHeader)(nil), "tendermint.types.CanonicalPartSetHeader")
 	proto.RegisterType((*CanonicalProposal)(nil), "tendermint.types.CanonicalProposal")
 	proto.RegisterType((*CanonicalVote)(nil), "tendermint.types.CanonicalVote")
+	proto.RegisterType((*CanonicalVoteExtension)(nil), "tendermint.types.CanonicalVoteExtension")
 	proto.RegisterType((*CanonicalStateVote)(nil), "tendermint.types.CanonicalStateVote")
 }
 
 func init() { proto.RegisterFile("tendermint/types/canonical.proto", fileDescriptor_8d1a1a84ff7267ed) }
 
 var fileDescriptor_8d1a1a84ff7267ed = []byte{
-	// 556 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0xcd, 0x6a, 0xdb, 0x40,
-	0x10, 0xf6, 0x3a, 0x8e, 0x2d, 0xaf, 0xe3, 0xd6, 0x5d, 0x42, 0x30, 0xa6, 0x48, 0x46, 0x87, 0xe2,
-	0x5e, 0x24, 0x48, 0xa0, 0x3d, 0x57, 0xc9, 0x21, 0x0e, 0x2d, 0x4d, 0xd7, 0x21, 0x87, 0x5e, 0xc4,
-	0xda, 0xda, 0x4a, 0xa2, 0xb2, 0x76, 0x91, 0xd6, 0xd0, 0x5c, 0xfa, 0x0c, 0x79, 0x96, 0x3e, 0x45,
-	0x8e, 0x39, 0xb6, 0x17, 0xb7, 0xc8, 0x2f, 0x52, 0x76, 0x25, 0x5b, 0x72, 0x52, 0x7c, 0x09, 0xf4,
-	0x22, 0xe6, 0x4f, 0xdf, 0x7c, 0xf3, 0xcd, 0xb0, 0x70, 0x28, 0x68, 0xec, 0xd1, 0x64, 0x1e, 0xc6,
-	0xc2, 0x16, 0x37, 0x9c, 0xa6, 0xf6, 0x8c, 0xc4, 0x2c, 0x0e, 0x67, 0x24, 0xb2, 0x78, 0xc2, 0x04,
-	0x43, 0xbd, 0xb2, 0xc2, 0x52, 0x15, 0x83, 0x43, 0x9f, 0xf9, 0x4c, 0x25, 0x6d, 0x69, 0xe5, 0x75,
-	0x83, 0x97, 0x8f, 0x90, 0xd4, 0xb7, 0xc8, 0x1a, 0x3e, 0x63, 0x7e, 0x44, 0x6d, 0xe5, 0x4d, 0x17,
-	0x5f, 0x6c, 0x11, 0xce, 0x69, 0x2a, 0xc8, 0x9c, 0xe7, 0x05, 0xe6, 0x77, 0xd8, 0x3b, 0x5d, 0x77,
-	0x76, 0x22, 0x36, 0xfb, 0x3a, 0x3e, 0x43, 0x08, 0x36, 0x02, 0x92, 0x06, 0x7d, 0x30, 0x04, 0xa3,
-	0x03, 0xac, 0x6c, 0x74, 0x0d, 0x9f, 0x73, 0x92, 0x08, 0x37, 0xa5, 0xc2, 0x0d, 0x28, 0xf1, 0x68,
-	0xd2, 0xaf, 0x0f, 0xc1, 0xa8, 0x73, 0x3c, 0xb2, 0x1e, 0x12, 0xb5, 0x36, 0x80, 0x97, 0x24, 0x11,
-	0x13, 0x2a, 0xce, 0x55, 0xbd, 0xd3, 0xb8, 0x5b, 0x1a, 0x35, 0xdc, 0xe5, 0xd5, 0xa0, 0xf9, 0xa6,
-	0xd2, 0x7f, 0x22, 0x88, 0xa0, 0xe3, 0x33, 0x64, 0xc2, 0x6e, 0x44, 0x52, 0xe1, 0x12, 0xce, 0xdd,
-	0x0a, 0x91, 0x8e, 0x0c, 0xbe, 0xe3, 0xfc, 0x9c, 0xa4, 0x81, 0xe9, 0xc0, 0xa3, 0x7f, 0xb7, 0x41,
-	0x87, 0x70, 0x5f, 0x30, 0x41, 0x22, 0xf5, 0x57, 0x17, 0xe7, 0xce, 0x66, 0xa6, 0x7a, 0x39, 0x93,
-	0xf9, 0xab, 0x0e, 0x5f, 0x94, 0x20, 0x09, 0xe3, 0x2c, 0x25, 0x11, 0x3a, 0x81, 0x0d, 0x39, 0x86,
-	0xfa, 0xfd, 0xd9, 0xb1, 0xf1, 0x78, 0xbc, 0x49, 0xe8, 0xc7, 0xd4, 0xfb, 0x90, 0xfa, 0x57, 0x37,
-	0x9c, 0x62, 0x55, 0x8c, 0x8e, 0x60, 0x33, 0xa0, 0xa1, 0x1f, 0x08, 0xd5, 0xa0, 0x87, 0x0b, 0x4f,
-	0x92, 0x49, 0xd8, 0x22, 0xf6, 0xfa, 0x7b, 0x2a, 0x9c, 0x3b, 0xe8, 0x35, 0x6c, 0x73, 0x16, 0xb9,
-	0x79, 0xa6, 0x31, 0x04, 0xa3, 0x3d, 0xe7, 0x20, 0x5b, 0x1a, 0xda, 0xe5, 0xc7, 0xf7, 0x58, 0xc6,
-	0xb0, 0xc6, 0x59, 0xa4, 0x2c, 0x74, 0x01, 0xb5, 0xa9, 0x5c, 0x8b, 0x1b, 0x7a, 0xfd, 0x7d, 0x25,
-	0xb8, 0xb9, 0x43, 0xf0, 0x62, 0x83, 0x4e, 0x27, 0x5b, 0x1a, 0xad, 0xc2, 0xc1, 0x2d, 0x05, 0x30,
-	0xf6, 0x90, 0x03, 0xdb, 0x9b, 0xf5, 0xf7, 0x9b, 0x0a, 0x6c, 0x60, 0xe5, 0x07, 0x62, 0xad, 0x0f,
-	0xc4, 0xba, 0x5a, 0x57, 0x38, 0x9a, 0xdc, 0xd7, 0xed, 0x6f, 0x03, 0xe0, 0xf2, 0x37, 0xf4, 0x0a,
-	0x6a, 0xb3, 0x80, 0x84, 0xb1, 0xe4, 0xd3, 0x1a, 0x82, 0x51, 0x3b, 0xef, 0x75, 0x2a, 0x63, 0xb2,
-	0x97, 0x4a, 0x8e, 0x3d, 0xf3, 0x47, 0x1d, 0x76, 0x37, 0xb4, 0xae, 0x99, 0xa0, 0xff, 0x43, 0xd7,
-	0xaa, 0x58, 0x8d, 0x27, 0x8a, 0x75, 0x01, 0xb5, 0x54, 0xde, 0xe3, 0x7a, 0xd0, 0xdd, 0x58, 0xc5,
-	0xe9, 0xe6, 0x58, 0x85, 0x83, 0x5b, 0x0a, 0x60, 0xec, 0x6d, 0x89, 0xd6, 0xdc, 0x21, 0xda, 0x37,
-	0x88, 0xb6, 0x11, 0x95, 0x70, 0xa5, 0x06, 0x60, 0x4b, 0x83, 0x2a, 0xc3, 0xfa, 0xd3, 0x18, 0x3a,
-	0x9f, 0xee, 0x32, 0x1d, 0xdc, 0x67, 0x3a, 0xf8, 0x93, 0xe9, 0xe0, 0x76, 0xa5, 0xd7, 0xee, 0x57,
-	0x7a, 0xed, 0xe7, 0x4a, 0xaf, 0x7d, 0x7e, 0xeb, 0x87, 0x22, 0x58, 0x4c, 0xad, 0x19, 0x9b, 0xdb,
-	0xd5, 0xa7, 0xa6, 0x34, 0xf3, 0x27, 0xe9, 0xe1, 0x33, 0x34, 0x6d, 0xaa, 0xf8, 0xc9, 0xdf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x35, 0xd4, 0x12, 0x81, 0xeb, 0x04, 0x00, 0x00,
+	// 585 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0x4f, 0x6b, 0xdb, 0x30,
+	0x14, 0x8f, 0x52, 0x37, 0x71, 0x94, 0x66, 0xcb, 0x44, 0x09, 0x21, 0x14, 0x3b, 0xf8, 0x30, 0xb2,
+	0x8b, 0x0d, 0x2d, 0x6c, 0xe7, 0xb9, 0x1d, 0x34, 0x65, 0x63, 0x9d, 0x53, 0x7a, 0xd8, 0xc5, 0x28,
+	0xb1, 0x66, 0x9b, 0x39, 0x96, 0xb0, 0x15, 0x68, 0x2f, 0xfb, 0x04, 0x3b, 0xf4, 0xb3, 0xec, 0x53,
+	0xf4, 0xd8, 0xe3, 0x76, 0xc9, 0x46, 0xf2, 0x45, 0x86, 0x64, 0x27, 0x76, 0xda, 0x11, 0x18, 0x85,
+	0x5e, 0x8c, 0xde, 0x1f, 0xfd, 0x7e, 0xef, 0xfd, 0xde, 0xb3, 0x60, 0x9f, 0x93, 0xd8, 0x23, 0xc9,
+	0x34, 0x8c, 0xb9, 0xc5, 0xaf, 0x19, 0x49, 0xad, 0x09, 0x8e, 0x69, 0x1c, 0x4e, 0x70, 0x64, 0xb2,
+	0x84, 0x72, 0x8a, 0xda, 0x45, 0x86, 0x29, 0x33, 0x7a, 0xfb, 0x3e, 0xf5, 0xa9, 0x0c, 0x5a, 0xe2,
+	0x94, 0xe5, 0xf5, 0x0e, 0x1e, 0x20, 0xc9, 0x6f, 0x1e, 0xd5, 0x7d, 0x4a, 0xfd, 0x88, 0x58, 0xd2,
+	0x1a, 0xcf, 0xbe, 0x58, 0x3c, 0x9c, 0x92, 0x94, 0xe3, 0x29, 0xcb, 0x12, 0x8c, 0x6f, 0xb0, 0x7d,
+	0xbc, 0x62, 0xb6, 0x23, 0x3a, 0xf9, 0x3a, 0x3c, 0x41, 0x08, 0x2a, 0x01, 0x4e, 0x83, 0x2e, 0xe8,
+	0x83, 0xc1, 0x9e, 0x23, 0xcf, 0xe8, 0x12, 0x3e, 0x67, 0x38, 0xe1, 0x6e, 0x4a, 0xb8, 0x1b, 0x10,
+	0xec, 0x91, 0xa4, 0x5b, 0xed, 0x83, 0x41, 0xf3, 0x70, 0x60, 0xde, 0x2f, 0xd4, 0x5c, 0x03, 0x9e,
+	0xe3, 0x84, 0x8f, 0x08, 0x3f, 0x95, 0xf9, 0xb6, 0x72, 0x3b, 0xd7, 0x2b, 0x4e, 0x8b, 0x95, 0x9d,
+	0xc6, 0xeb, 0x12, 0xff, 0x88, 0x63, 0x4e, 0x86, 0x27, 0xc8, 0x80, 0xad, 0x08, 0xa7, 0xdc, 0xc5,
+	0x8c, 0xb9, 0xa5, 0x42, 0x9a, 0xc2, 0xf9, 0x96, 0xb1, 0x53, 0x9c, 0x06, 0x86, 0x0d, 0x3b, 0xff,
+	0xa6, 0x41, 0xfb, 0x70, 0x97, 0x53, 0x8e, 0x23, 0x79, 0xab, 0xe5, 0x64, 0xc6, 0xba, 0xa7, 0x6a,
+	0xd1, 0x93, 0xf1, 0xab, 0x0a, 0x5f, 0x14, 0x20, 0x09, 0x65, 0x34, 0xc5, 0x11, 0x3a, 0x82, 0x8a,
+	0x68, 0x43, 0x5e, 0x7f, 0x76, 0xa8, 0x3f, 0x6c, 0x6f, 0x14, 0xfa, 0x31, 0xf1, 0x3e, 0xa4, 0xfe,
+	0xc5, 0x35, 0x23, 0x8e, 0x4c, 0x46, 0x1d, 0x58, 0x0b, 0x48, 0xe8, 0x07, 0x5c, 0x12, 0xb4, 0x9d,
+	0xdc, 0x12, 0xc5, 0x24, 0x74, 0x16, 0x7b, 0xdd, 0x1d, 0xe9, 0xce, 0x0c, 0xf4, 0x0a, 0x36, 0x18,
+	0x8d, 0xdc, 0x2c, 0xa2, 0xf4, 0xc1, 0x60, 0xc7, 0xde, 0x5b, 0xcc, 0x75, 0xf5, 0xfc, 0xe3, 0x7b,
+	0x47, 0xf8, 0x1c, 0x95, 0xd1, 0x48, 0x9e, 0xd0, 0x19, 0x54, 0xc7, 0x62, 0x2c, 0x6e, 0xe8, 0x75,
+	0x77, 0xa5, 0xe0, 0xc6, 0x16, 0xc1, 0xf3, 0x09, 0xda, 0xcd, 0xc5, 0x5c, 0xaf, 0xe7, 0x86, 0x53,
+	0x97, 0x00, 0x43, 0x0f, 0xd9, 0xb0, 0xb1, 0x1e, 0x7f, 0xb7, 0x26, 0xc1, 0x7a, 0x66, 0xb6, 0x20,
+	0xe6, 0x6a, 0x41, 0xcc, 0x8b, 0x55, 0x86, 0xad, 0x8a, 0x79, 0xdd, 0xfc, 0xd6, 0x81, 0x53, 0x5c,
+	0x43, 0x2f, 0xa1, 0x3a, 0x09, 0x70, 0x18, 0x8b, 0x7a, 0xea, 0x7d, 0x30, 0x68, 0x64, 0x5c, 0xc7,
+	0xc2, 0x27, 0xb8, 0x64, 0x70, 0xe8, 0x19, 0x3f, 0xaa, 0xb0, 0xb5, 0x2e, 0xeb, 0x92, 0x72, 0xf2,
+	0x14, 0xba, 0x96, 0xc5, 0x52, 0x1e, 0x29, 0xd6, 0x19, 0x54, 0x53, 0xb1, 0x8f, 0xab, 0x46, 0xb7,
+	0x63, 0xe5, 0xab, 0x9b, 0x61, 0xe5, 0x86, 0x53, 0x97, 0x00, 0x43, 0x6f, 0x43, 0xb4, 0xda, 0x16,
+	0xd1, 0xbe, 0x03, 0xd8, 0xd9, 0x10, 0xed, 0xdd, 0x15, 0x27, 0x71, 0x1a, 0xd2, 0x18, 0x1d, 0xc0,
+	0x06, 0x59, 0x19, 0xf9, 0xff, 0x50, 0x38, 0xfe, 0x53, 0xa6, 0x72, 0x39, 0xca, 0x96, 0x72, 0xae,
+	0x20, 0xda, 0x6c, 0x50, 0xce, 0xb1, 0xe0, 0x02, 0x1b, 0x5c, 0x65, 0xc1, 0xaa, 0x8f, 0x13, 0xcc,
+	0xfe, 0x74, 0xbb, 0xd0, 0xc0, 0xdd, 0x42, 0x03, 0x7f, 0x16, 0x1a, 0xb8, 0x59, 0x6a, 0x95, 0xbb,
+	0xa5, 0x56, 0xf9, 0xb9, 0xd4, 0x2a, 0x9f, 0xdf, 0xf8, 0x21, 0x0f, 0x66, 0x63, 0x73, 0x42, 0xa7,
+	0x56, 0xf9, 0xe5, 0x2b, 0x8e, 0xd9, 0x0b, 0x79, 0xff, 0x55, 0x1c, 0xd7, 0xa4, 0xff, 0xe8, 0xef,
+	0x00, 0x54, 0x24, 0x4d, 0x16, 0x7a, 0x05, 0x00, 0x00,
 }
 
 func (m *CanonicalBlockID) Marshal() (dAtA []byte, err error) {
@@ -702,6 +773,55 @@ func (m *CanonicalVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CanonicalVoteExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalVoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalVoteExtension) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Round != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Round))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.Height != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Height))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.Extension) > 0 {
+		i -= len(m.Extension)
+		copy(dAtA[i:], m.Extension)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.Extension)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CanonicalStateVote) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -859,6 +979,29 @@ func (m *CanonicalVote) Size() (n int) {
 	return n
 }
 
+func (m *CanonicalVoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Extension)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 9
+	}
+	if m.Round != 0 {
+		n += 9
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	return n
+}
+
 func (m *CanonicalStateVote) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1587,6 +1730,142 @@ func (m *CanonicalVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CanonicalVoteExtension) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCanonical
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalVoteExtension: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalVoteExtension: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extension = append(m.Extension[:0], dAtA[iNdEx:postIndex]...)
+			if m.Extension == nil {
+				m.Extension = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Height = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Round = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCanonical(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *CanonicalStateVote) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -34,6 +34,8 @@ type ConsensusParams struct {
 	Evidence  EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence"`
 	Validator ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator"`
 	Version   VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version"`
+	ABCI      ABCIParams      `protobuf:"bytes,5,opt,name=abci,proto3" json:"abci"`
+	Synchrony SynchronyParams `protobuf:"bytes,6,opt,name=synchrony,proto3" json:"synchrony"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
@@ -97,6 +99,20 @@ func (m *ConsensusParams) GetVersion() VersionParams {
 	return VersionParams{}
 }
 
+func (m *ConsensusParams) GetABCI() ABCIParams {
+	if m != nil {
+		return m.ABCI
+	}
+	return ABCIParams{}
+}
+
+func (m *ConsensusParams) GetSynchrony() SynchronyParams {
+	if m != nil {
+		return m.Synchrony
+	}
+	return SynchronyParams{}
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Max block size, in bytes.
@@ -330,6 +346,111 @@ func (m *VersionParams) GetAppVersion() uint64 {
 	return 0
 }
 
+// ABCIParams configure the ABCI features, which change the consensus protocol.
+type ABCIParams struct {
+	// The first height, at which the precommits carry vote extensions with
+	// threshold-recovered extension signatures. 0 disables the vote extensions.
+	// It can't be changed once the height is reached.
+	VoteExtensionsEnableHeight int64 `protobuf:"varint,1,opt,name=vote_extensions_enable_height,json=voteExtensionsEnableHeight,proto3" json:"vote_extensions_enable_height,omitempty"`
+}
+
+func (m *ABCIParams) Reset()         { *m = ABCIParams{} }
+func (m *ABCIParams) String() string { return proto.CompactTextString(m) }
+func (*ABCIParams) ProtoMessage()    {}
+func (*ABCIParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{5}
+}
+func (m *ABCIParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ABCIParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ABCIParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ABCIParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ABCIParams.Merge(m, src)
+}
+func (m *ABCIParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *ABCIParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ABCIParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ABCIParams proto.InternalMessageInfo
+
+func (m *ABCIParams) GetVoteExtensionsEnableHeight() int64 {
+	if m != nil {
+		return m.VoteExtensionsEnableHeight
+	}
+	return 0
+}
+
+// SynchronyParams bound the block time, which the proposer sets from its local
+// clock. Validators reject the proposals, whose time is before the last block
+// time plus min_step, or after their local time plus max_drift.
+type SynchronyParams struct {
+	// Minimum time increment between consecutive blocks.
+	MinStep time.Duration `protobuf:"bytes,1,opt,name=min_step,json=minStep,proto3,stdduration" json:"min_step"`
+	// Maximum time the proposer's clock can be ahead of the validator's clock.
+	MaxDrift time.Duration `protobuf:"bytes,2,opt,name=max_drift,json=maxDrift,proto3,stdduration" json:"max_drift"`
+}
+
+func (m *SynchronyParams) Reset()         { *m = SynchronyParams{} }
+func (m *SynchronyParams) String() string { return proto.CompactTextString(m) }
+func (*SynchronyParams) ProtoMessage()    {}
+func (*SynchronyParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{6}
+}
+func (m *SynchronyParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SynchronyParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SynchronyParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SynchronyParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SynchronyParams.Merge(m, src)
+}
+func (m *SynchronyParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *SynchronyParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_SynchronyParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SynchronyParams proto.InternalMessageInfo
+
+func (m *SynchronyParams) GetMinStep() time.Duration {
+	if m != nil {
+		return m.MinStep
+	}
+	return 0
+}
+
+func (m *SynchronyParams) GetMaxDrift() time.Duration {
+	if m != nil {
+		return m.MaxDrift
+	}
+	return 0
+}
+
 // HashedParams is a subset of ConsensusParams.
 //
 // It is hashed into the Header.ConsensusHash.
@@ -342,7 +463,7 @@ func (m *HashedParams) Reset()         { *m = HashedParams{} }
 func (m *HashedParams) String() string { return proto.CompactTextString(m) }
 func (*HashedParams) ProtoMessage()    {}
 func (*HashedParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e12598271a686f57, []int{5}
+	return fileDescriptor_e12598271a686f57, []int{7}
 }
 func (m *HashedParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -391,47 +512,58 @@ func init() {
 	proto.RegisterType((*EvidenceParams)(nil), "tendermint.types.EvidenceParams")
 	proto.RegisterType((*ValidatorParams)(nil), "tendermint.types.ValidatorParams")
 	proto.RegisterType((*VersionParams)(nil), "tendermint.types.VersionParams")
+	proto.RegisterType((*ABCIParams)(nil), "tendermint.types.ABCIParams")
+	proto.RegisterType((*SynchronyParams)(nil), "tendermint.types.SynchronyParams")
 	proto.RegisterType((*HashedParams)(nil), "tendermint.types.HashedParams")
 }
 
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 537 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x31, 0x6f, 0xd3, 0x40,
-	0x18, 0xcd, 0xd5, 0xa5, 0x4d, 0xbe, 0x34, 0x4d, 0x75, 0x42, 0x22, 0x14, 0xd5, 0x0e, 0x1e, 0x50,
-	0x25, 0x24, 0x5b, 0x82, 0x01, 0xd1, 0xa5, 0xc2, 0x50, 0x15, 0x84, 0x82, 0x90, 0x05, 0x0c, 0x5d,
-	0xac, 0x73, 0x72, 0xb8, 0x56, 0x73, 0x3e, 0xcb, 0x77, 0x8e, 0x92, 0x7f, 0xc1, 0xd8, 0xb1, 0x23,
-	0xfc, 0x03, 0x7e, 0x42, 0xc7, 0x8e, 0x4c, 0x80, 0x92, 0x85, 0x9f, 0x81, 0x7c, 0xce, 0xe1, 0x38,
-	0x65, 0xf3, 0x7d, 0xdf, 0x7b, 0xef, 0xfc, 0xde, 0xd3, 0xc1, 0x81, 0xa4, 0xc9, 0x88, 0x66, 0x2c,
-	0x4e, 0xa4, 0x2b, 0x67, 0x29, 0x15, 0x6e, 0x4a, 0x32, 0xc2, 0x84, 0x93, 0x66, 0x5c, 0x72, 0xbc,
-	0x57, 0xad, 0x1d, 0xb5, 0xde, 0xbf, 0x1b, 0xf1, 0x88, 0xab, 0xa5, 0x5b, 0x7c, 0x95, 0xb8, 0x7d,
-	0x33, 0xe2, 0x3c, 0x1a, 0x53, 0x57, 0x9d, 0xc2, 0xfc, 0xb3, 0x3b, 0xca, 0x33, 0x22, 0x63, 0x9e,
-	0x94, 0x7b, 0xfb, 0x72, 0x03, 0xba, 0x2f, 0x79, 0x22, 0x68, 0x22, 0x72, 0xf1, 0x5e, 0xdd, 0x80,
-	0x9f, 0xc3, 0x9d, 0x70, 0xcc, 0x87, 0x17, 0x3d, 0xd4, 0x47, 0x87, 0xed, 0x27, 0x07, 0xce, 0xfa,
-	0x5d, 0x8e, 0x57, 0xac, 0x4b, 0xb4, 0xb7, 0x79, 0xfd, 0xd3, 0x6a, 0xf8, 0x25, 0x03, 0x7b, 0xd0,
-	0xa4, 0x93, 0x78, 0x44, 0x93, 0x21, 0xed, 0x6d, 0x28, 0x76, 0xff, 0x36, 0xfb, 0x64, 0x89, 0xa8,
-	0x09, 0xfc, 0xe3, 0xe1, 0x13, 0x68, 0x4d, 0xc8, 0x38, 0x1e, 0x11, 0xc9, 0xb3, 0x9e, 0xa1, 0x44,
-	0x1e, 0xde, 0x16, 0xf9, 0xa4, 0x21, 0x35, 0x95, 0x8a, 0x89, 0x8f, 0x61, 0x7b, 0x42, 0x33, 0x11,
-	0xf3, 0xa4, 0xb7, 0xa9, 0x44, 0xac, 0xff, 0x88, 0x94, 0x80, 0x9a, 0x84, 0x66, 0xd9, 0x14, 0xda,
-	0x2b, 0x3e, 0xf1, 0x03, 0x68, 0x31, 0x32, 0x0d, 0xc2, 0x99, 0xa4, 0x42, 0x25, 0x63, 0xf8, 0x4d,
-	0x46, 0xa6, 0x5e, 0x71, 0xc6, 0xf7, 0x60, 0xbb, 0x58, 0x46, 0x44, 0x28, 0xdb, 0x86, 0xbf, 0xc5,
-	0xc8, 0xf4, 0x94, 0x08, 0xdc, 0x87, 0x1d, 0x19, 0x33, 0x1a, 0xc4, 0x5c, 0x92, 0x80, 0x09, 0xe5,
-	0xc7, 0xf0, 0xa1, 0x98, 0xbd, 0xe1, 0x92, 0x0c, 0x84, 0xfd, 0x0d, 0xc1, 0x6e, 0x3d, 0x11, 0xfc,
-	0x18, 0x70, 0xa1, 0x46, 0x22, 0x1a, 0x24, 0x39, 0x0b, 0x54, 0xb4, 0xfa, 0xce, 0x2e, 0x23, 0xd3,
-	0x17, 0x11, 0x7d, 0x97, 0x33, 0xf5, 0x73, 0x02, 0x0f, 0x60, 0x4f, 0x83, 0x75, 0xb7, 0xcb, 0xe8,
-	0xef, 0x3b, 0x65, 0xf9, 0x8e, 0x2e, 0xdf, 0x79, 0xb5, 0x04, 0x78, 0xcd, 0xc2, 0xea, 0xe5, 0x2f,
-	0x0b, 0xf9, 0xbb, 0xa5, 0x9e, 0xde, 0xd4, 0x6d, 0x1a, 0x75, 0x9b, 0xf6, 0x31, 0x74, 0xd7, 0x72,
-	0xc7, 0x36, 0x74, 0xd2, 0x3c, 0x0c, 0x2e, 0xe8, 0x2c, 0x50, 0x99, 0xf6, 0x50, 0xdf, 0x38, 0x6c,
-	0xf9, 0xed, 0x34, 0x0f, 0xdf, 0xd2, 0xd9, 0x87, 0x62, 0x74, 0xd4, 0xfc, 0x7e, 0x65, 0xa1, 0x3f,
-	0x57, 0x16, 0xb2, 0x8f, 0xa0, 0x53, 0xcb, 0x1c, 0x5b, 0xd0, 0x26, 0x69, 0x1a, 0xe8, 0xa6, 0x0a,
-	0x8f, 0x9b, 0x3e, 0x90, 0x34, 0x5d, 0xc2, 0x56, 0xb8, 0x67, 0xb0, 0xf3, 0x9a, 0x88, 0x73, 0x3a,
-	0x5a, 0x52, 0x1f, 0x41, 0x57, 0x25, 0x13, 0xac, 0xd7, 0xd2, 0x51, 0xe3, 0x81, 0xee, 0xc6, 0x86,
-	0x4e, 0x85, 0xab, 0x1a, 0x6a, 0x6b, 0xd4, 0x29, 0x11, 0xde, 0xc7, 0xaf, 0x73, 0x13, 0x5d, 0xcf,
-	0x4d, 0x74, 0x33, 0x37, 0xd1, 0xef, 0xb9, 0x89, 0xbe, 0x2c, 0xcc, 0xc6, 0xcd, 0xc2, 0x6c, 0xfc,
-	0x58, 0x98, 0x8d, 0xb3, 0x67, 0x51, 0x2c, 0xcf, 0xf3, 0xd0, 0x19, 0x72, 0xe6, 0xae, 0x3e, 0xcb,
-	0xea, 0xb3, 0x7c, 0x77, 0xeb, 0x4f, 0x36, 0xdc, 0x52, 0xf3, 0xa7, 0x7f, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0xfe, 0xe0, 0x3d, 0x9c, 0xcd, 0x03, 0x00, 0x00,
+	// 677 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xc1, 0x6e, 0xd3, 0x4a,
+	0x14, 0x8d, 0x5f, 0xd2, 0x36, 0xbd, 0x69, 0x9a, 0x6a, 0xf4, 0xa4, 0xe7, 0xd7, 0xf7, 0xea, 0x04,
+	0x2f, 0x50, 0x25, 0x24, 0x47, 0x82, 0x05, 0xa2, 0x8b, 0x96, 0xba, 0x8d, 0xda, 0x0a, 0x15, 0x21,
+	0x17, 0x90, 0xe8, 0xc6, 0x1a, 0x27, 0x53, 0xc7, 0x6a, 0x3c, 0x63, 0x79, 0xc6, 0x51, 0xf2, 0x17,
+	0x48, 0x6c, 0xd8, 0xd1, 0x25, 0xfc, 0x01, 0x9f, 0xd0, 0x65, 0x97, 0xac, 0x0a, 0x4a, 0x37, 0x7c,
+	0x06, 0x9a, 0xb1, 0xdd, 0xc4, 0x29, 0x48, 0xb0, 0xb3, 0xef, 0x3d, 0xe7, 0xcc, 0xdc, 0x73, 0x8f,
+	0x06, 0x36, 0x04, 0xa1, 0x3d, 0x12, 0x87, 0x01, 0x15, 0x6d, 0x31, 0x8e, 0x08, 0x6f, 0x47, 0x38,
+	0xc6, 0x21, 0xb7, 0xa2, 0x98, 0x09, 0x86, 0xd6, 0xa6, 0x6d, 0x4b, 0xb5, 0xd7, 0xff, 0xf6, 0x99,
+	0xcf, 0x54, 0xb3, 0x2d, 0xbf, 0x52, 0xdc, 0xba, 0xe1, 0x33, 0xe6, 0x0f, 0x48, 0x5b, 0xfd, 0x79,
+	0xc9, 0x59, 0xbb, 0x97, 0xc4, 0x58, 0x04, 0x8c, 0xa6, 0x7d, 0xf3, 0x43, 0x19, 0x1a, 0x7b, 0x8c,
+	0x72, 0x42, 0x79, 0xc2, 0x5f, 0xa8, 0x13, 0xd0, 0x13, 0x58, 0xf0, 0x06, 0xac, 0x7b, 0xae, 0x6b,
+	0x2d, 0x6d, 0xb3, 0xf6, 0x70, 0xc3, 0x9a, 0x3f, 0xcb, 0xb2, 0x65, 0x3b, 0x45, 0xdb, 0x95, 0xcb,
+	0xeb, 0x66, 0xc9, 0x49, 0x19, 0xc8, 0x86, 0x2a, 0x19, 0x06, 0x3d, 0x42, 0xbb, 0x44, 0xff, 0x4b,
+	0xb1, 0x5b, 0x77, 0xd9, 0x9d, 0x0c, 0x51, 0x10, 0xb8, 0xe5, 0xa1, 0x0e, 0x2c, 0x0f, 0xf1, 0x20,
+	0xe8, 0x61, 0xc1, 0x62, 0xbd, 0xac, 0x44, 0xee, 0xdd, 0x15, 0x79, 0x9d, 0x43, 0x0a, 0x2a, 0x53,
+	0x26, 0xda, 0x81, 0xa5, 0x21, 0x89, 0x79, 0xc0, 0xa8, 0x5e, 0x51, 0x22, 0xcd, 0x9f, 0x88, 0xa4,
+	0x80, 0x82, 0x44, 0xce, 0x42, 0xdb, 0x50, 0xc1, 0x5e, 0x37, 0xd0, 0x17, 0x14, 0xfb, 0xff, 0xbb,
+	0xec, 0x5d, 0x7b, 0xef, 0x28, 0xa3, 0xae, 0x48, 0xea, 0xe4, 0xba, 0x59, 0x91, 0x35, 0x47, 0xf1,
+	0xe4, 0x1c, 0x7c, 0x4c, 0xbb, 0xfd, 0x98, 0xd1, 0xb1, 0xbe, 0xf8, 0xab, 0x39, 0x4e, 0x72, 0x48,
+	0x71, 0x8e, 0x5b, 0xa6, 0x49, 0xa0, 0x36, 0x63, 0x37, 0xfa, 0x0f, 0x96, 0x43, 0x3c, 0x72, 0xbd,
+	0xb1, 0x20, 0x5c, 0x2d, 0xa8, 0xec, 0x54, 0x43, 0x3c, 0xb2, 0xe5, 0x3f, 0xfa, 0x07, 0x96, 0x64,
+	0xd3, 0xc7, 0x5c, 0xb9, 0x5f, 0x76, 0x16, 0x43, 0x3c, 0x3a, 0xc0, 0x1c, 0xb5, 0x60, 0x45, 0x04,
+	0x21, 0x71, 0x03, 0x26, 0xb0, 0x1b, 0x72, 0x65, 0x6b, 0xd9, 0x01, 0x59, 0x3b, 0x62, 0x02, 0x1f,
+	0x73, 0xf3, 0x93, 0x06, 0xab, 0xc5, 0xc5, 0xa0, 0x07, 0x80, 0xa4, 0x1a, 0xf6, 0x89, 0x4b, 0x93,
+	0xd0, 0x55, 0x1b, 0xce, 0xcf, 0x6c, 0x84, 0x78, 0xb4, 0xeb, 0x93, 0xe7, 0x49, 0xa8, 0x2e, 0xc7,
+	0xd1, 0x31, 0xac, 0xe5, 0xe0, 0x3c, 0x62, 0x59, 0x02, 0xfe, 0xb5, 0xd2, 0x0c, 0x5a, 0x79, 0x06,
+	0xad, 0xfd, 0x0c, 0x60, 0x57, 0xe5, 0xb0, 0xef, 0xbf, 0x36, 0x35, 0x67, 0x35, 0xd5, 0xcb, 0x3b,
+	0xc5, 0x31, 0xcb, 0xc5, 0x31, 0xcd, 0x1d, 0x68, 0xcc, 0xad, 0x1f, 0x99, 0x50, 0x8f, 0x12, 0xcf,
+	0x3d, 0x27, 0x63, 0x57, 0xf9, 0xaa, 0x6b, 0xad, 0xf2, 0xe6, 0xb2, 0x53, 0x8b, 0x12, 0xef, 0x19,
+	0x19, 0xbf, 0x94, 0xa5, 0xad, 0xea, 0xe7, 0x8b, 0xa6, 0xf6, 0xfd, 0xa2, 0xa9, 0x99, 0x5b, 0x50,
+	0x2f, 0xac, 0x1e, 0x35, 0xa1, 0x86, 0xa3, 0xc8, 0xcd, 0x03, 0x23, 0x67, 0xac, 0x38, 0x80, 0xa3,
+	0x28, 0x83, 0xcd, 0x70, 0xdf, 0x00, 0x4c, 0x17, 0x8f, 0x76, 0x61, 0x63, 0xc8, 0x04, 0x71, 0xc9,
+	0x48, 0x10, 0x2a, 0x91, 0xdc, 0x25, 0x14, 0x7b, 0x03, 0xe2, 0xf6, 0x49, 0xe0, 0xf7, 0x45, 0x66,
+	0xd7, 0xba, 0x04, 0x75, 0x6e, 0x31, 0x1d, 0x05, 0x39, 0x54, 0x88, 0x19, 0xe9, 0x77, 0x1a, 0x34,
+	0xe6, 0xf2, 0x80, 0xb6, 0xa1, 0x1a, 0x06, 0xd4, 0xe5, 0x82, 0x44, 0xba, 0xf6, 0xfb, 0x7e, 0x2e,
+	0x85, 0x01, 0x3d, 0x11, 0x24, 0x42, 0x4f, 0x53, 0x23, 0x7b, 0x71, 0x70, 0x26, 0xfe, 0x64, 0x21,
+	0xd2, 0xed, 0x7d, 0x49, 0x32, 0x4f, 0x61, 0xe5, 0x10, 0xf3, 0x3e, 0xe9, 0x65, 0x37, 0xba, 0x0f,
+	0x0d, 0x15, 0x05, 0x77, 0x3e, 0x87, 0x75, 0x55, 0x3e, 0xce, 0xc3, 0x68, 0x42, 0x7d, 0x8a, 0x9b,
+	0x46, 0xb2, 0x96, 0xa3, 0x0e, 0x30, 0xb7, 0x5f, 0x7d, 0x9c, 0x18, 0xda, 0xe5, 0xc4, 0xd0, 0xae,
+	0x26, 0x86, 0xf6, 0x6d, 0x62, 0x68, 0x6f, 0x6f, 0x8c, 0xd2, 0xd5, 0x8d, 0x51, 0xfa, 0x72, 0x63,
+	0x94, 0x4e, 0x1f, 0xfb, 0x81, 0xe8, 0x27, 0x9e, 0xd5, 0x65, 0x61, 0x7b, 0xf6, 0x39, 0x9c, 0x7e,
+	0xa6, 0xef, 0xdd, 0xfc, 0x53, 0xe9, 0x2d, 0xaa, 0xfa, 0xa3, 0x1f, 0x03, 0x00, 0x94, 0x96, 0x71,
+	0xef, 0x45, 0x05, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -465,6 +597,12 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	if !this.Version.Equal(&that1.Version) {
 		return false
 	}
+	if !this.ABCI.Equal(&that1.ABCI) {
+		return false
+	}
+	if !this.Synchrony.Equal(&that1.Synchrony) {
+		return false
+	}
 	return true
 }
 func (this *BlockParams) Equal(that interface{}) bool {
@@ -580,6 +718,57 @@ func (this *VersionParams) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ABCIParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ABCIParams)
+	if !ok {
+		that2, ok := that.(ABCIParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.VoteExtensionsEnableHeight != that1.VoteExtensionsEnableHeight {
+		return false
+	}
+	return true
+}
+func (this *SynchronyParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SynchronyParams)
+	if !ok {
+		that2, ok := that.(SynchronyParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MinStep != that1.MinStep {
+		return false
+	}
+	if this.MaxDrift != that1.MaxDrift {
+		return false
+	}
+	return true
+}
 func (this *HashedParams) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -627,6 +816,26 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.Synchrony.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	{
+		size, err := m.ABCI.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
 	{
 		size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -733,12 +942,12 @@ func (m *EvidenceParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x18
 	}
-	n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAgeDuration):])
-	if err5 != nil {
-		return 0, err5
+	n7, err7 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAgeDuration):])
+	if err7 != nil {
+		return 0, err7
 	}
-	i -= n5
-	i = encodeVarintParams(dAtA, i, uint64(n5))
+	i -= n7
+	i = encodeVarintParams(dAtA, i, uint64(n7))
 	i--
 	dAtA[i] = 0x12
 	if m.MaxAgeNumBlocks != 0 {
@@ -809,6 +1018,73 @@ func (m *VersionParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ABCIParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ABCIParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ABCIParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.VoteExtensionsEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.VoteExtensionsEnableHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SynchronyParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SynchronyParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SynchronyParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n8, err8 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxDrift, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxDrift):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintParams(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0x12
+	n9, err9 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MinStep, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MinStep):])
+	if err9 != nil {
+		return 0, err9
+	}
+	i -= n9
+	i = encodeVarintParams(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *HashedParams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -873,6 +1149,17 @@ func NewPopulatedVersionParams(r randyParams, easy bool) *VersionParams {
 	return this
 }
 
+func NewPopulatedABCIParams(r randyParams, easy bool) *ABCIParams {
+	this := &ABCIParams{}
+	this.VoteExtensionsEnableHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.VoteExtensionsEnableHeight *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
 type randyParams interface {
 	Float32() float32
 	Float64() float64
@@ -959,6 +1246,10 @@ func (m *ConsensusParams) Size() (n int) {
 	n += 1 + l + sovParams(uint64(l))
 	l = m.Version.Size()
 	n += 1 + l + sovParams(uint64(l))
+	l = m.ABCI.Size()
+	n += 1 + l + sovParams(uint64(l))
+	l = m.Synchrony.Size()
+	n += 1 + l + sovParams(uint64(l))
 	return n
 }
 
@@ -1024,6 +1315,31 @@ func (m *VersionParams) Size() (n int) {
 	return n
 }
 
+func (m *ABCIParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VoteExtensionsEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.VoteExtensionsEnableHeight))
+	}
+	return n
+}
+
+func (m *SynchronyParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MinStep)
+	n += 1 + l + sovParams(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxDrift)
+	n += 1 + l + sovParams(uint64(l))
+	return n
+}
+
 func (m *HashedParams) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1206,6 +1522,72 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ABCI", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ABCI.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Synchrony", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Synchrony.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1606,6 +1988,191 @@ func (m *VersionParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ABCIParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ABCIParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ABCIParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtensionsEnableHeight", wireType)
+			}
+			m.VoteExtensionsEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VoteExtensionsEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SynchronyParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SynchronyParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SynchronyParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinStep", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MinStep, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDrift", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxDrift, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *HashedParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
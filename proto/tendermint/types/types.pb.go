@@ -11,6 +11,7 @@ import (
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	bits "github.com/tendermint/tendermint/proto/tendermint/libs/bits"
 	version "github.com/tendermint/tendermint/proto/tendermint/version"
 	io "io"
 	math "math"
@@ -593,6 +594,9 @@ type Vote struct {
 	ValidatorIndex     int32         `protobuf:"varint,7,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty"`
 	BlockSignature     []byte        `protobuf:"bytes,8,opt,name=block_signature,json=blockSignature,proto3" json:"block_signature,omitempty"`
 	StateSignature     []byte        `protobuf:"bytes,10,opt,name=state_signature,json=stateSignature,proto3" json:"state_signature,omitempty"`
+	// set on the precommits for a block, if vote extensions are enabled
+	Extension          []byte `protobuf:"bytes,11,opt,name=extension,proto3" json:"extension,omitempty"`
+	ExtensionSignature []byte `protobuf:"bytes,12,opt,name=extension_signature,json=extensionSignature,proto3" json:"extension_signature,omitempty"`
 }
 
 func (m *Vote) Reset()         { *m = Vote{} }
@@ -691,6 +695,20 @@ func (m *Vote) GetStateSignature() []byte {
 	return nil
 }
 
+func (m *Vote) GetExtension() []byte {
+	if m != nil {
+		return m.Extension
+	}
+	return nil
+}
+
+func (m *Vote) GetExtensionSignature() []byte {
+	if m != nil {
+		return m.ExtensionSignature
+	}
+	return nil
+}
+
 // Commit contains the evidence that a block was committed by a set of validators.
 type Commit struct {
 	Height                  int64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -700,6 +718,11 @@ type Commit struct {
 	QuorumHash              []byte  `protobuf:"bytes,6,opt,name=quorum_hash,json=quorumHash,proto3" json:"quorum_hash,omitempty"`
 	ThresholdBlockSignature []byte  `protobuf:"bytes,7,opt,name=threshold_block_signature,json=thresholdBlockSignature,proto3" json:"threshold_block_signature,omitempty"`
 	ThresholdStateSignature []byte  `protobuf:"bytes,8,opt,name=threshold_state_signature,json=thresholdStateSignature,proto3" json:"threshold_state_signature,omitempty"`
+	// set if vote extensions are enabled at the height
+	VoteExtension                   []byte `protobuf:"bytes,9,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`
+	ThresholdVoteExtensionSignature []byte `protobuf:"bytes,10,opt,name=threshold_vote_extension_signature,json=thresholdVoteExtensionSignature,proto3" json:"threshold_vote_extension_signature,omitempty"`
+	// the validators whose precommits for the block were collected
+	Signers *bits.BitArray `protobuf:"bytes,11,opt,name=signers,proto3" json:"signers,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
@@ -784,6 +807,27 @@ func (m *Commit) GetThresholdStateSignature() []byte {
 	return nil
 }
 
+func (m *Commit) GetVoteExtension() []byte {
+	if m != nil {
+		return m.VoteExtension
+	}
+	return nil
+}
+
+func (m *Commit) GetThresholdVoteExtensionSignature() []byte {
+	if m != nil {
+		return m.ThresholdVoteExtensionSignature
+	}
+	return nil
+}
+
+func (m *Commit) GetSigners() *bits.BitArray {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
 type Proposal struct {
 	Type                  SignedMsgType `protobuf:"varint,1,opt,name=type,proto3,enum=tendermint.types.SignedMsgType" json:"type,omitempty"`
 	Height                int64         `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
@@ -1155,100 +1199,106 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1482 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x4f, 0x6f, 0x1a, 0xd7,
-	0x16, 0xf7, 0x00, 0x36, 0x70, 0x00, 0x83, 0xe7, 0x39, 0x09, 0x26, 0x09, 0x46, 0x3c, 0xbd, 0xc4,
-	0xcf, 0x7a, 0xc1, 0x79, 0x49, 0xd5, 0xb4, 0x91, 0xba, 0x30, 0xd8, 0x49, 0x50, 0xfc, 0x87, 0x0e,
-	0x24, 0x55, 0xbb, 0x19, 0x8d, 0x99, 0x1b, 0x98, 0x06, 0xe6, 0x4e, 0x67, 0x2e, 0xae, 0x9d, 0x6d,
-	0x17, 0xad, 0xbc, 0xca, 0xaa, 0x3b, 0x4b, 0x95, 0xda, 0x48, 0xfd, 0x08, 0xfd, 0x08, 0x59, 0x66,
-	0xd7, 0x6e, 0x9a, 0x56, 0xce, 0xa6, 0x8b, 0x7e, 0x88, 0xea, 0x9e, 0x7b, 0x99, 0x3f, 0x60, 0xb7,
-	0x91, 0x95, 0x0d, 0x9a, 0x7b, 0xce, 0xef, 0xdc, 0x7b, 0xce, 0xef, 0xfc, 0xb9, 0x17, 0xb8, 0xc2,
-	0x88, 0x6d, 0x12, 0x77, 0x68, 0xd9, 0x6c, 0x8d, 0x1d, 0x3a, 0xc4, 0x13, 0xbf, 0x35, 0xc7, 0xa5,
-	0x8c, 0xaa, 0x85, 0x40, 0x5b, 0x43, 0x79, 0x69, 0xb1, 0x47, 0x7b, 0x14, 0x95, 0x6b, 0xfc, 0x4b,
-	0xe0, 0x4a, 0xcb, 0x3d, 0x4a, 0x7b, 0x03, 0xb2, 0x86, 0xab, 0xbd, 0xd1, 0x93, 0x35, 0x66, 0x0d,
-	0x89, 0xc7, 0x8c, 0xa1, 0x23, 0x01, 0x57, 0x43, 0xc7, 0x74, 0xdd, 0x43, 0x87, 0x51, 0x8e, 0xa5,
-	0x4f, 0xa4, 0xba, 0x1c, 0x52, 0xef, 0x13, 0xd7, 0xb3, 0xa8, 0x1d, 0xf6, 0xa3, 0x54, 0x99, 0xf2,
-	0x72, 0xdf, 0x18, 0x58, 0xa6, 0xc1, 0xa8, 0x2b, 0x10, 0xd5, 0x0f, 0x21, 0xd7, 0x32, 0x5c, 0xd6,
-	0x26, 0xec, 0x01, 0x31, 0x4c, 0xe2, 0xaa, 0x8b, 0x30, 0xcb, 0x28, 0x33, 0x06, 0x45, 0xa5, 0xa2,
-	0xac, 0xe4, 0x34, 0xb1, 0x50, 0x55, 0x48, 0xf4, 0x0d, 0xaf, 0x5f, 0x8c, 0x55, 0x94, 0x95, 0xac,
-	0x86, 0xdf, 0xd5, 0x3e, 0x24, 0xb8, 0x29, 0xb7, 0xb0, 0x6c, 0x93, 0x1c, 0x8c, 0x2d, 0x70, 0xc1,
-	0xa5, 0x7b, 0x87, 0x8c, 0x78, 0xd2, 0x44, 0x2c, 0xd4, 0xf7, 0x60, 0x16, 0xfd, 0x2f, 0xc6, 0x2b,
-	0xca, 0x4a, 0xe6, 0x56, 0xb1, 0x16, 0x22, 0x4a, 0xc4, 0x57, 0x6b, 0x71, 0x7d, 0x3d, 0xf1, 0xf2,
-	0xf5, 0xf2, 0x8c, 0x26, 0xc0, 0xd5, 0x01, 0x24, 0xeb, 0x03, 0xda, 0x7d, 0xda, 0xdc, 0xf0, 0x1d,
-	0x51, 0x02, 0x47, 0xd4, 0x6d, 0xc8, 0x3b, 0x86, 0xcb, 0x74, 0x8f, 0x30, 0xbd, 0x8f, 0x51, 0xe0,
-	0xa1, 0x99, 0x5b, 0xcb, 0xb5, 0xc9, 0x3c, 0xd4, 0x22, 0xc1, 0xca, 0x53, 0x72, 0x4e, 0x58, 0x58,
-	0xbd, 0x01, 0xc9, 0x36, 0x33, 0x18, 0x69, 0x6e, 0xa8, 0x55, 0xc8, 0x0d, 0x0c, 0x8f, 0xe9, 0x86,
-	0xe3, 0xe8, 0xa1, 0x63, 0x33, 0x5c, 0xb8, 0xee, 0x38, 0x0f, 0x38, 0x0d, 0x2f, 0x66, 0x61, 0x4e,
-	0x72, 0xf7, 0x11, 0x24, 0x65, 0x16, 0x10, 0x98, 0xb9, 0x75, 0x35, 0xec, 0x80, 0x54, 0xd5, 0x1a,
-	0xd4, 0xf6, 0x88, 0xed, 0x8d, 0x3c, 0x79, 0xfc, 0xd8, 0x46, 0xbd, 0x06, 0xa9, 0x6e, 0xdf, 0xb0,
-	0x6c, 0xdd, 0x32, 0x31, 0x80, 0x74, 0x3d, 0x73, 0xf2, 0x7a, 0x39, 0xd9, 0xe0, 0xb2, 0xe6, 0x86,
-	0x96, 0x44, 0x65, 0xd3, 0x54, 0x2f, 0xc2, 0x5c, 0x9f, 0x58, 0xbd, 0x3e, 0x43, 0x16, 0xe3, 0x9a,
-	0x5c, 0xa9, 0x77, 0xa0, 0xd8, 0xa5, 0x2e, 0xd1, 0xc5, 0x26, 0x9c, 0x30, 0x62, 0xea, 0x12, 0x69,
-	0x62, 0x6e, 0x2e, 0x70, 0x3d, 0xee, 0xb7, 0x85, 0xda, 0x07, 0xc2, 0xf0, 0x03, 0x48, 0xf0, 0xc2,
-	0x2b, 0x26, 0xd0, 0xe9, 0x52, 0x4d, 0x54, 0x65, 0x6d, 0x5c, 0x95, 0xb5, 0xce, 0xb8, 0x2a, 0xeb,
-	0x29, 0xee, 0xf1, 0xf3, 0xdf, 0x96, 0x15, 0x0d, 0x2d, 0xd4, 0x86, 0x24, 0x68, 0x8f, 0x9f, 0xc6,
-	0xfd, 0x9e, 0xc5, 0x2d, 0x96, 0xa6, 0x89, 0x97, 0x09, 0x94, 0x31, 0x23, 0x83, 0x42, 0x64, 0xaa,
-	0x2b, 0x50, 0xc0, 0x4d, 0xba, 0x74, 0x38, 0xb4, 0x98, 0x20, 0x7a, 0x0e, 0x89, 0x9e, 0xe7, 0xf2,
-	0x06, 0x8a, 0x39, 0xd7, 0xea, 0x65, 0x48, 0x9b, 0x06, 0x33, 0x04, 0x24, 0x89, 0x90, 0x14, 0x17,
-	0xa0, 0xf2, 0x3a, 0xe4, 0xfd, 0xea, 0xf6, 0x04, 0x24, 0x25, 0x76, 0x09, 0xc4, 0x08, 0xbc, 0x09,
-	0x8b, 0x36, 0x39, 0x60, 0xfa, 0x24, 0x3a, 0x8d, 0x68, 0x95, 0xeb, 0x1e, 0x47, 0x2d, 0xfe, 0x03,
-	0xf3, 0xdd, 0x71, 0xd6, 0x04, 0x16, 0x10, 0x9b, 0xf3, 0xa5, 0x08, 0x5b, 0x82, 0x94, 0x5f, 0x29,
-	0x19, 0x04, 0x24, 0x0d, 0x51, 0x25, 0xea, 0x2a, 0x2c, 0x60, 0x8c, 0x2e, 0xf1, 0x46, 0x03, 0x26,
-	0x37, 0xc9, 0x22, 0x26, 0xcf, 0x15, 0x9a, 0x90, 0x23, 0xf6, 0xdf, 0x90, 0x23, 0xfb, 0x96, 0x49,
-	0xec, 0x2e, 0x11, 0xb8, 0x1c, 0xe2, 0xb2, 0x63, 0x21, 0x82, 0xd6, 0x60, 0xd1, 0x71, 0xa9, 0x43,
-	0x3d, 0xe2, 0xea, 0x8e, 0x4b, 0x75, 0x76, 0x20, 0xb0, 0x04, 0xb1, 0x0b, 0x63, 0x5d, 0xcb, 0xa5,
-	0x9d, 0x03, 0xac, 0xd3, 0xaf, 0x15, 0xc8, 0x35, 0xc2, 0xe9, 0xe7, 0x3e, 0x61, 0xbd, 0x88, 0xe4,
-	0xc9, 0x42, 0x11, 0x4d, 0x9c, 0xe7, 0x0a, 0xcc, 0x8f, 0x2c, 0x91, 0x6b, 0x90, 0x0f, 0x63, 0x83,
-	0x59, 0x90, 0x0b, 0x90, 0xdc, 0xad, 0x2b, 0x90, 0xf6, 0xac, 0x9e, 0x6d, 0xb0, 0x91, 0x4b, 0xb0,
-	0x3c, 0xb3, 0x5a, 0x20, 0xb8, 0x9b, 0xf8, 0xe3, 0xbb, 0x65, 0xa5, 0x5a, 0x84, 0xc4, 0x86, 0xc1,
-	0x0c, 0xb5, 0x00, 0x71, 0x76, 0xe0, 0x15, 0x95, 0x4a, 0x7c, 0x25, 0xab, 0xf1, 0xcf, 0xea, 0x8b,
-	0x38, 0x24, 0x1e, 0x53, 0x46, 0xd4, 0xdb, 0x90, 0xe0, 0x65, 0x83, 0xde, 0xcc, 0x9f, 0xd6, 0xc7,
-	0x6d, 0xab, 0x67, 0x13, 0x73, 0xdb, 0xeb, 0x75, 0x0e, 0x1d, 0xa2, 0x21, 0x38, 0xd4, 0x17, 0xb1,
-	0x48, 0x5f, 0x2c, 0xc2, 0xac, 0x4b, 0x47, 0xb6, 0x89, 0xfe, 0xcc, 0x6a, 0x62, 0xa1, 0x6e, 0x42,
-	0xca, 0xaf, 0xda, 0xc4, 0x3f, 0x55, 0x6d, 0x9e, 0x57, 0x2d, 0x6f, 0x46, 0x29, 0xd0, 0x92, 0x7b,
-	0xb2, 0x78, 0x37, 0x21, 0xe5, 0xf1, 0x69, 0xc1, 0xb7, 0x49, 0x9f, 0xb5, 0x8d, 0x9c, 0x27, 0xc1,
-	0x36, 0x52, 0xa0, 0x25, 0xd1, 0xb6, 0x69, 0xaa, 0xff, 0x87, 0x0b, 0x7e, 0x39, 0x46, 0xf2, 0x29,
-	0x1a, 0x41, 0xf5, 0x95, 0x7e, 0x42, 0x23, 0xf5, 0xae, 0x8b, 0x09, 0x9c, 0xc4, 0x00, 0x83, 0x7a,
-	0x6f, 0xe2, 0x28, 0xbe, 0x0e, 0x79, 0x11, 0x69, 0x90, 0x19, 0xd9, 0x18, 0x28, 0x6e, 0x8f, 0xa5,
-	0x1c, 0x28, 0x62, 0x09, 0x80, 0xa2, 0xce, 0xe7, 0x51, 0xec, 0x03, 0xab, 0xbf, 0xc6, 0x60, 0x4e,
-	0xb4, 0x65, 0x88, 0x74, 0xe5, 0x74, 0xd2, 0x63, 0x67, 0x91, 0x1e, 0x7f, 0x37, 0xa4, 0x27, 0xce,
-	0x4f, 0xfa, 0x32, 0x64, 0xbe, 0x18, 0x51, 0x77, 0x34, 0x0c, 0x53, 0x0d, 0x42, 0x84, 0x14, 0xdf,
-	0x85, 0x25, 0xd6, 0x77, 0x89, 0xd7, 0xa7, 0x03, 0x53, 0x9f, 0xe4, 0x50, 0xcc, 0x9f, 0x4b, 0x3e,
-	0xa0, 0x1e, 0x25, 0x33, 0x62, 0x3b, 0x49, 0x6b, 0x6a, 0xc2, 0xb6, 0x1d, 0xe5, 0xf7, 0xcf, 0x18,
-	0xa4, 0x5a, 0xd8, 0xc1, 0xc6, 0xe0, 0xdd, 0xf6, 0xc2, 0xb9, 0xef, 0x88, 0xd3, 0x9b, 0xe8, 0x32,
-	0xa4, 0x1d, 0x3a, 0xd0, 0x85, 0x26, 0x81, 0x9a, 0x94, 0x43, 0x07, 0xda, 0x54, 0xb2, 0x67, 0xcf,
-	0x9f, 0xec, 0x3a, 0xa4, 0xfd, 0x67, 0x11, 0xe6, 0xe8, 0x6d, 0xaf, 0xa8, 0xc0, 0x2c, 0x3a, 0x96,
-	0x92, 0x13, 0x63, 0xa9, 0xea, 0x42, 0x56, 0x70, 0x28, 0xef, 0xf1, 0x9b, 0x9c, 0x3c, 0x7c, 0x47,
-	0x28, 0xd3, 0xcf, 0x14, 0xe1, 0xb6, 0x40, 0x6a, 0x12, 0xc7, 0x2d, 0xc4, 0xed, 0x25, 0x5f, 0x1e,
-	0xa7, 0x58, 0x88, 0x7e, 0xd1, 0x24, 0xae, 0xfa, 0xad, 0x02, 0xb0, 0xc5, 0x99, 0xc5, 0x78, 0xf9,
-	0x45, 0xea, 0xa1, 0x0b, 0x7a, 0xe4, 0xe4, 0xf2, 0x59, 0xd9, 0x96, 0xe7, 0x67, 0xbd, 0xb0, 0xdf,
-	0x0d, 0xc8, 0x05, 0x13, 0xc1, 0x23, 0x63, 0x67, 0x4e, 0xd9, 0xc4, 0xbf, 0xdf, 0xda, 0x84, 0x69,
-	0xd9, 0xfd, 0xd0, 0xaa, 0xfa, 0x53, 0x0c, 0xd2, 0xe8, 0xd3, 0x36, 0x61, 0x46, 0x24, 0x87, 0xca,
-	0xbb, 0x69, 0x58, 0x72, 0xfe, 0x86, 0xbd, 0x0a, 0x30, 0xee, 0xc2, 0x67, 0x44, 0x56, 0x76, 0x5a,
-	0x0e, 0xb1, 0x67, 0x44, 0x7d, 0xdf, 0xcf, 0x5b, 0xfc, 0xef, 0xf3, 0x26, 0x5f, 0x21, 0xe3, 0xec,
-	0x5d, 0x82, 0xa4, 0x3d, 0x1a, 0xea, 0xfc, 0x32, 0x4a, 0x88, 0x6e, 0xb1, 0x47, 0xc3, 0xce, 0x81,
-	0xa7, 0xde, 0x80, 0x7f, 0xf5, 0x0d, 0x4f, 0x9f, 0xe8, 0x18, 0x6c, 0x94, 0x94, 0x56, 0xe8, 0x1b,
-	0x5e, 0xe4, 0x42, 0xad, 0x7e, 0x0e, 0xc9, 0xce, 0x01, 0xbe, 0x5f, 0x79, 0x63, 0xb8, 0x94, 0xb2,
-	0xf0, 0xab, 0x31, 0xc5, 0x05, 0x38, 0x56, 0x54, 0x48, 0xf0, 0x57, 0xcb, 0xf8, 0x35, 0xcd, 0xbf,
-	0xd5, 0xda, 0x5b, 0xbe, 0x8c, 0xe5, 0x9b, 0x78, 0xf5, 0x67, 0x05, 0x32, 0x92, 0xe6, 0x7b, 0x03,
-	0xa3, 0xc7, 0x2f, 0x90, 0xfa, 0xd6, 0x6e, 0xe3, 0xa1, 0xde, 0xdc, 0xd0, 0xef, 0x6d, 0xad, 0xdf,
-	0xd7, 0x1f, 0xed, 0x3c, 0xdc, 0xd9, 0xfd, 0x64, 0xa7, 0x30, 0x53, 0xba, 0x78, 0x74, 0x5c, 0x51,
-	0x43, 0xd8, 0x47, 0xf6, 0x53, 0x9b, 0x7e, 0x69, 0xf3, 0x27, 0x44, 0xd4, 0x64, 0xbd, 0xde, 0xde,
-	0xdc, 0xe9, 0x14, 0x94, 0xd2, 0x85, 0xa3, 0xe3, 0xca, 0x42, 0xc8, 0x62, 0x7d, 0xcf, 0x23, 0x36,
-	0x9b, 0x36, 0x68, 0xec, 0x6e, 0x6f, 0x37, 0x3b, 0x85, 0xd8, 0x94, 0x81, 0xbc, 0x1c, 0xfe, 0x0b,
-	0x0b, 0x51, 0x83, 0x9d, 0xe6, 0x56, 0x21, 0x5e, 0x52, 0x8f, 0x8e, 0x2b, 0xf3, 0x21, 0xf4, 0x8e,
-	0x35, 0x28, 0xa5, 0xbe, 0xf9, 0xbe, 0x3c, 0xf3, 0xe3, 0x0f, 0x65, 0x65, 0xf5, 0xab, 0x18, 0xe4,
-	0x22, 0x23, 0x4d, 0xfd, 0x1f, 0x5c, 0x6a, 0x37, 0xef, 0xef, 0x6c, 0x6e, 0xe8, 0xdb, 0xed, 0xfb,
-	0x7a, 0xe7, 0xd3, 0xd6, 0x66, 0x28, 0xba, 0xfc, 0xd1, 0x71, 0x25, 0x23, 0x43, 0x3a, 0x0b, 0xdd,
-	0xd2, 0x36, 0x1f, 0xef, 0x76, 0x36, 0x0b, 0x8a, 0x40, 0xb7, 0x5c, 0xb2, 0x4f, 0x19, 0x41, 0xf4,
-	0x4d, 0x58, 0x3a, 0x05, 0xed, 0x07, 0xb6, 0x70, 0x74, 0x5c, 0xc9, 0xb5, 0x5c, 0x22, 0xba, 0x16,
-	0x2d, 0x56, 0xe1, 0xe2, 0xa4, 0x85, 0x84, 0xc7, 0x4b, 0xf3, 0x47, 0xc7, 0x15, 0x68, 0x04, 0xd8,
-	0x1a, 0x14, 0xa7, 0x77, 0xdf, 0x6d, 0xed, 0xb6, 0xd7, 0xb7, 0x0a, 0x95, 0x52, 0xe1, 0xe8, 0xb8,
-	0x92, 0x1d, 0xcf, 0x79, 0x8e, 0x0f, 0x58, 0xa8, 0x7f, 0xfc, 0xf2, 0xa4, 0xac, 0xbc, 0x3a, 0x29,
-	0x2b, 0xbf, 0x9f, 0x94, 0x95, 0xe7, 0x6f, 0xca, 0x33, 0xaf, 0xde, 0x94, 0x67, 0x7e, 0x79, 0x53,
-	0x9e, 0xf9, 0xec, 0x4e, 0xcf, 0x62, 0xfd, 0xd1, 0x5e, 0xad, 0x4b, 0x87, 0x6b, 0xe1, 0xff, 0x77,
-	0xc1, 0xa7, 0xf8, 0x9f, 0x39, 0xf9, 0xdf, 0x6f, 0x6f, 0x0e, 0xe5, 0xb7, 0xff, 0x0a, 0x00, 0x00,
-	0xff, 0xff, 0x0b, 0x25, 0x82, 0x31, 0xbc, 0x0e, 0x00, 0x00,
+	// 1577 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x4d, 0x6f, 0x1a, 0x57,
+	0x17, 0xf6, 0x00, 0x36, 0x70, 0x00, 0x1b, 0x4f, 0x9c, 0x04, 0x93, 0x04, 0x10, 0xaf, 0x92, 0xd7,
+	0xb5, 0x9a, 0x21, 0x75, 0xaa, 0x26, 0x8d, 0xd4, 0x85, 0xc1, 0x24, 0x41, 0xf1, 0x07, 0x1d, 0x88,
+	0xab, 0x76, 0x33, 0x1a, 0x98, 0x1b, 0x98, 0x06, 0xe6, 0x4e, 0x67, 0x2e, 0x2e, 0xce, 0xb6, 0x8b,
+	0x56, 0xee, 0x26, 0xab, 0xee, 0x2c, 0x55, 0x6a, 0x2b, 0xf5, 0x27, 0xf4, 0x27, 0x64, 0x99, 0x5d,
+	0xbb, 0x4a, 0x2b, 0x67, 0xd3, 0x45, 0x97, 0xfd, 0x01, 0xd5, 0xfd, 0x60, 0x3e, 0xc0, 0xee, 0x87,
+	0x95, 0x0d, 0xe2, 0x9e, 0xf3, 0x9c, 0x7b, 0xcf, 0x3d, 0xcf, 0x73, 0xcf, 0xbd, 0x03, 0x57, 0x09,
+	0xb2, 0x0c, 0xe4, 0x0c, 0x4d, 0x8b, 0x54, 0xc8, 0xa1, 0x8d, 0x5c, 0xfe, 0xab, 0xd8, 0x0e, 0x26,
+	0x58, 0xce, 0xfa, 0x5e, 0x85, 0xd9, 0xf3, 0x2b, 0x3d, 0xdc, 0xc3, 0xcc, 0x59, 0xa1, 0xff, 0x38,
+	0x2e, 0x5f, 0xec, 0x61, 0xdc, 0x1b, 0xa0, 0x0a, 0x1b, 0x75, 0x46, 0x4f, 0x2a, 0xc4, 0x1c, 0x22,
+	0x97, 0xe8, 0x43, 0x5b, 0x00, 0xae, 0x05, 0x96, 0xe9, 0x3a, 0x87, 0x36, 0xc1, 0x14, 0x8b, 0x9f,
+	0x08, 0x77, 0x21, 0xe0, 0x3e, 0x40, 0x8e, 0x6b, 0x62, 0x2b, 0x98, 0x47, 0xbe, 0x34, 0x93, 0xe5,
+	0x81, 0x3e, 0x30, 0x0d, 0x9d, 0x60, 0xe7, 0x14, 0xc4, 0xc0, 0xec, 0xb8, 0x95, 0x8e, 0x49, 0x42,
+	0x7b, 0x29, 0xbf, 0x0f, 0x99, 0xa6, 0xee, 0x90, 0x16, 0x22, 0x0f, 0x91, 0x6e, 0x20, 0x47, 0x5e,
+	0x81, 0x79, 0x82, 0x89, 0x3e, 0xc8, 0x49, 0x25, 0x69, 0x2d, 0xa3, 0xf2, 0x81, 0x2c, 0x43, 0xac,
+	0xaf, 0xbb, 0xfd, 0x5c, 0xa4, 0x24, 0xad, 0xa5, 0x55, 0xf6, 0xbf, 0xdc, 0x87, 0x18, 0x0d, 0xa5,
+	0x11, 0xa6, 0x65, 0xa0, 0xf1, 0x24, 0x82, 0x0d, 0xa8, 0xb5, 0x73, 0x48, 0x90, 0x2b, 0x42, 0xf8,
+	0x40, 0x7e, 0x17, 0xe6, 0xd9, 0x0e, 0x73, 0xd1, 0x92, 0xb4, 0x96, 0xda, 0xc8, 0x29, 0x81, 0x52,
+	0xf2, 0x0a, 0x28, 0x4d, 0xea, 0xaf, 0xc6, 0x5e, 0xbc, 0x2a, 0xce, 0xa9, 0x1c, 0x5c, 0x1e, 0x40,
+	0xbc, 0x3a, 0xc0, 0xdd, 0xa7, 0x8d, 0x2d, 0x2f, 0x11, 0xc9, 0x4f, 0x44, 0xde, 0x81, 0x25, 0x5b,
+	0x77, 0x88, 0xe6, 0x22, 0xa2, 0xf5, 0xd9, 0x2e, 0xd8, 0xa2, 0xa9, 0x8d, 0xa2, 0x32, 0xcd, 0x94,
+	0x12, 0xda, 0xac, 0x58, 0x25, 0x63, 0x07, 0x8d, 0xe5, 0x9b, 0x10, 0x6f, 0x11, 0x9d, 0xa0, 0xc6,
+	0x96, 0x5c, 0x86, 0xcc, 0x40, 0x77, 0x89, 0xa6, 0xdb, 0xb6, 0x16, 0x58, 0x36, 0x45, 0x8d, 0x9b,
+	0xb6, 0xfd, 0x90, 0x96, 0xe1, 0x87, 0x79, 0x58, 0x10, 0xb5, 0xfb, 0x00, 0xe2, 0x82, 0x27, 0x06,
+	0x4c, 0x6d, 0x5c, 0x0b, 0x26, 0x20, 0x5c, 0x4a, 0x0d, 0x5b, 0x2e, 0xb2, 0xdc, 0x91, 0x2b, 0x96,
+	0x9f, 0xc4, 0xc8, 0x37, 0x20, 0xd1, 0xed, 0xeb, 0xa6, 0xa5, 0x99, 0x06, 0xdb, 0x40, 0xb2, 0x9a,
+	0x3a, 0x79, 0x55, 0x8c, 0xd7, 0xa8, 0xad, 0xb1, 0xa5, 0xc6, 0x99, 0xb3, 0x61, 0xc8, 0x97, 0x60,
+	0xa1, 0x8f, 0xcc, 0x5e, 0x9f, 0xb0, 0x2a, 0x46, 0x55, 0x31, 0x92, 0xef, 0x40, 0xae, 0x8b, 0x1d,
+	0xa4, 0xf1, 0x49, 0x68, 0xc1, 0x90, 0xa1, 0x09, 0xa4, 0xc1, 0xb8, 0xb9, 0x48, 0xfd, 0x6c, 0xbe,
+	0x6d, 0xe6, 0x7d, 0xc8, 0x03, 0xef, 0x42, 0x8c, 0x4a, 0x33, 0x17, 0x63, 0x49, 0xe7, 0x15, 0xae,
+	0x5b, 0x65, 0xa2, 0x5b, 0xa5, 0x3d, 0xd1, 0x6d, 0x35, 0x41, 0x33, 0x7e, 0xfe, 0x6b, 0x51, 0x52,
+	0x59, 0x84, 0x5c, 0x13, 0x05, 0xea, 0xd0, 0xd5, 0x68, 0xde, 0xf3, 0x6c, 0x8a, 0xd5, 0xd9, 0xc2,
+	0x0b, 0x02, 0xc5, 0x9e, 0x59, 0x05, 0xb9, 0xc9, 0x90, 0xd7, 0x20, 0xcb, 0x26, 0xe9, 0xe2, 0xe1,
+	0xd0, 0x24, 0xbc, 0xd0, 0x0b, 0xac, 0xd0, 0x8b, 0xd4, 0x5e, 0x63, 0x66, 0x5a, 0x6b, 0xf9, 0x0a,
+	0x24, 0x0d, 0x9d, 0xe8, 0x1c, 0x12, 0x67, 0x90, 0x04, 0x35, 0x30, 0xe7, 0xff, 0x61, 0xc9, 0xd3,
+	0xbf, 0xcb, 0x21, 0x09, 0x3e, 0x8b, 0x6f, 0x66, 0xc0, 0x5b, 0xb0, 0x62, 0xa1, 0x31, 0xd1, 0xa6,
+	0xd1, 0x49, 0x86, 0x96, 0xa9, 0x6f, 0x3f, 0x1c, 0x71, 0x1d, 0x16, 0xbb, 0x13, 0xd6, 0x38, 0x16,
+	0x18, 0x36, 0xe3, 0x59, 0x19, 0x6c, 0x15, 0x12, 0x9e, 0x52, 0x52, 0x0c, 0x10, 0xd7, 0xb9, 0x4a,
+	0xe4, 0x75, 0x58, 0x66, 0x7b, 0x74, 0x90, 0x3b, 0x1a, 0x10, 0x31, 0x49, 0x9a, 0x61, 0x96, 0xa8,
+	0x43, 0xe5, 0x76, 0x86, 0xfd, 0x1f, 0x64, 0xd0, 0x81, 0x69, 0x20, 0xab, 0x8b, 0x38, 0x2e, 0xc3,
+	0x70, 0xe9, 0x89, 0x91, 0x81, 0x2a, 0xb0, 0x62, 0x3b, 0xd8, 0xc6, 0x2e, 0x72, 0x34, 0xdb, 0xc1,
+	0x1a, 0x19, 0x73, 0x2c, 0x62, 0xd8, 0xe5, 0x89, 0xaf, 0xe9, 0xe0, 0xf6, 0x98, 0xe9, 0xf4, 0x4b,
+	0x09, 0x32, 0xb5, 0x20, 0xfd, 0x34, 0x27, 0xa6, 0x17, 0x4e, 0x9e, 0x10, 0x0a, 0x3f, 0xc4, 0x4b,
+	0xd4, 0xc1, 0xf8, 0x11, 0x12, 0xb9, 0x01, 0x4b, 0x41, 0xac, 0xdf, 0x0b, 0x32, 0x3e, 0x92, 0xa6,
+	0x75, 0x15, 0x92, 0xae, 0xd9, 0xb3, 0x74, 0x32, 0x72, 0x10, 0x93, 0x67, 0x5a, 0xf5, 0x0d, 0xf7,
+	0x62, 0xbf, 0x7f, 0x5b, 0x94, 0xca, 0x39, 0x88, 0x6d, 0xe9, 0x44, 0x97, 0xb3, 0x10, 0x25, 0x63,
+	0x37, 0x27, 0x95, 0xa2, 0x6b, 0x69, 0x95, 0xfe, 0x2d, 0xff, 0x19, 0x85, 0xd8, 0x3e, 0x26, 0x48,
+	0xbe, 0x0d, 0x31, 0x2a, 0x1b, 0x96, 0xcd, 0xe2, 0x69, 0xe7, 0xb8, 0x65, 0xf6, 0x2c, 0x64, 0xec,
+	0xb8, 0xbd, 0xf6, 0xa1, 0x8d, 0x54, 0x06, 0x0e, 0x9c, 0x8b, 0x48, 0xe8, 0x5c, 0xac, 0xc0, 0xbc,
+	0x83, 0x47, 0x96, 0xc1, 0xf2, 0x99, 0x57, 0xf9, 0x40, 0xae, 0x43, 0xc2, 0x53, 0x6d, 0xec, 0x9f,
+	0x54, 0xbb, 0x44, 0x55, 0x4b, 0x0f, 0xa3, 0x30, 0xa8, 0xf1, 0x8e, 0x10, 0x6f, 0x1d, 0x12, 0x2e,
+	0xed, 0x16, 0x74, 0x9a, 0xe4, 0x59, 0xd3, 0x88, 0x7e, 0xe2, 0x4f, 0x23, 0x0c, 0x6a, 0x9c, 0xc5,
+	0x36, 0x0c, 0xf9, 0x1d, 0xb8, 0xe8, 0xc9, 0x31, 0xc4, 0x27, 0x3f, 0x08, 0xb2, 0xe7, 0xf4, 0x08,
+	0x0d, 0xe9, 0x5d, 0xe3, 0x1d, 0x38, 0xce, 0x36, 0xe8, 0xeb, 0xbd, 0x41, 0xad, 0x14, 0xc8, 0x77,
+	0xea, 0x33, 0x23, 0x0e, 0x06, 0x33, 0xb7, 0x26, 0x56, 0x0a, 0xe4, 0x7b, 0xf1, 0x81, 0x5c, 0xe7,
+	0x8b, 0xcc, 0xec, 0x03, 0xaf, 0x42, 0x12, 0x8d, 0x09, 0xb2, 0x58, 0xab, 0xe3, 0x4a, 0xf7, 0x0d,
+	0x72, 0x05, 0x2e, 0x78, 0x83, 0xc0, 0x54, 0x5c, 0xed, 0xb2, 0xe7, 0xf2, 0xa6, 0x2b, 0x7f, 0x1d,
+	0x83, 0x05, 0x7e, 0xca, 0x03, 0x1c, 0x4a, 0xa7, 0x73, 0x18, 0x39, 0x8b, 0xc3, 0xe8, 0x9b, 0xe1,
+	0x30, 0x76, 0x7e, 0x0e, 0x8b, 0x90, 0xfa, 0x6c, 0x84, 0x9d, 0xd1, 0x30, 0xc8, 0x1c, 0x70, 0x13,
+	0x63, 0xec, 0x1e, 0xac, 0x92, 0xbe, 0x83, 0xdc, 0x3e, 0x1e, 0x18, 0xda, 0x34, 0x25, 0xbc, 0x9d,
+	0x5d, 0xf6, 0x00, 0xd5, 0x30, 0x37, 0xa1, 0xd8, 0x69, 0x96, 0x12, 0x53, 0xb1, 0xad, 0x30, 0x5d,
+	0xd7, 0x61, 0xf1, 0x00, 0x13, 0xa4, 0xf9, 0x9c, 0xf1, 0x56, 0x97, 0xa1, 0xd6, 0xba, 0xc7, 0xdb,
+	0x23, 0x28, 0xfb, 0x4b, 0x84, 0x03, 0x66, 0x14, 0x51, 0xf4, 0x90, 0xfb, 0xc1, 0x39, 0xfc, 0x35,
+	0xef, 0x42, 0x9c, 0xc6, 0x20, 0xc7, 0x65, 0x02, 0x49, 0x6d, 0x14, 0x82, 0x25, 0xa5, 0x8f, 0x11,
+	0x85, 0x3e, 0x46, 0x94, 0xaa, 0x49, 0x36, 0x1d, 0x47, 0x3f, 0x54, 0x27, 0xf0, 0xf2, 0x1f, 0x11,
+	0x48, 0x34, 0x59, 0xfb, 0xd2, 0x07, 0x6f, 0xb6, 0x11, 0x9c, 0xfb, 0x82, 0x3c, 0xbd, 0x83, 0x5c,
+	0x81, 0xa4, 0x8d, 0x07, 0x1a, 0xf7, 0xc4, 0x98, 0x27, 0x61, 0xe3, 0x81, 0x3a, 0x23, 0xcd, 0xf9,
+	0xf3, 0x4b, 0xb3, 0x0a, 0x49, 0xef, 0xd5, 0x98, 0x5b, 0xf8, 0x0f, 0xf7, 0xb3, 0x1f, 0x16, 0xee,
+	0xc9, 0xf1, 0xa9, 0x9e, 0x5c, 0x76, 0x20, 0xcd, 0x6b, 0x28, 0x1e, 0x31, 0xb7, 0x68, 0xf1, 0xe8,
+	0xbf, 0x9c, 0x34, 0xfb, 0x46, 0xe3, 0x69, 0x73, 0xa4, 0xba, 0xd0, 0xf7, 0x22, 0xf8, 0xd5, 0x9d,
+	0x8b, 0x9c, 0x15, 0xc1, 0x4f, 0xb7, 0x2a, 0x70, 0xe5, 0x6f, 0x24, 0x80, 0x6d, 0x5a, 0x59, 0xb6,
+	0x5f, 0xfa, 0x8a, 0x60, 0xe4, 0x1b, 0x5a, 0x68, 0xe5, 0xc2, 0x59, 0x6c, 0x8b, 0xf5, 0xd3, 0x6e,
+	0x30, 0xef, 0x1a, 0x64, 0xfc, 0x76, 0xe8, 0xa2, 0x49, 0x32, 0xa7, 0x4c, 0xe2, 0x5d, 0xee, 0x2d,
+	0x44, 0xd4, 0xf4, 0x41, 0x60, 0x54, 0xfe, 0x29, 0x02, 0x49, 0x96, 0xd3, 0x0e, 0x22, 0x7a, 0x88,
+	0x43, 0xe9, 0xcd, 0xb4, 0x17, 0x74, 0xfe, 0xf6, 0x72, 0x0d, 0x60, 0xd2, 0x33, 0x9e, 0x21, 0xa1,
+	0xec, 0xa4, 0xe8, 0xe0, 0xcf, 0x90, 0xfc, 0x9e, 0xc7, 0x5b, 0xf4, 0xef, 0x79, 0x13, 0x4f, 0xb0,
+	0x09, 0x7b, 0x97, 0x21, 0x6e, 0x8d, 0x86, 0x1a, 0xbd, 0x89, 0x63, 0xfc, 0xb4, 0x58, 0xa3, 0x61,
+	0x7b, 0xec, 0xca, 0x37, 0xe1, 0x42, 0x5f, 0x77, 0xb5, 0xa9, 0x13, 0xc3, 0x0e, 0x4a, 0x42, 0xcd,
+	0xf6, 0x75, 0x37, 0xf4, 0x9a, 0x28, 0x7f, 0x0a, 0xf1, 0xf6, 0x98, 0x3d, 0xde, 0xe9, 0xc1, 0x70,
+	0x30, 0x26, 0xc1, 0x27, 0x73, 0x82, 0x1a, 0x58, 0x13, 0x94, 0x21, 0x46, 0x9f, 0x6c, 0x93, 0x4f,
+	0x09, 0xfa, 0x5f, 0x56, 0xfe, 0xe5, 0x67, 0x81, 0xf8, 0x20, 0x58, 0xff, 0x59, 0x82, 0x94, 0x28,
+	0xf3, 0xfd, 0x81, 0xde, 0xa3, 0xb7, 0x67, 0x75, 0x7b, 0xaf, 0xf6, 0x48, 0x6b, 0x6c, 0x69, 0xf7,
+	0xb7, 0x37, 0x1f, 0x68, 0x8f, 0x77, 0x1f, 0xed, 0xee, 0x7d, 0xb4, 0x9b, 0x9d, 0xcb, 0x5f, 0x3a,
+	0x3a, 0x2e, 0xc9, 0x01, 0xec, 0x63, 0xeb, 0xa9, 0x85, 0x3f, 0xa7, 0x97, 0xd4, 0x4a, 0x38, 0x64,
+	0xb3, 0xda, 0xaa, 0xef, 0xb6, 0xb3, 0x52, 0xfe, 0xe2, 0xd1, 0x71, 0x69, 0x39, 0x10, 0xb1, 0xd9,
+	0x71, 0x91, 0x45, 0x66, 0x03, 0x6a, 0x7b, 0x3b, 0x3b, 0x8d, 0x76, 0x36, 0x32, 0x13, 0x20, 0xae,
+	0xb2, 0xb7, 0x60, 0x39, 0x1c, 0xb0, 0xdb, 0xd8, 0xce, 0x46, 0xf3, 0xf2, 0xd1, 0x71, 0x69, 0x31,
+	0x80, 0xde, 0x35, 0x07, 0xf9, 0xc4, 0x57, 0xdf, 0x15, 0xe6, 0x7e, 0xfc, 0xbe, 0x20, 0xad, 0x7f,
+	0x11, 0x81, 0x4c, 0xa8, 0xa5, 0xc9, 0x6f, 0xc3, 0xe5, 0x56, 0xe3, 0xc1, 0x6e, 0x7d, 0x4b, 0xdb,
+	0x69, 0x3d, 0xd0, 0xda, 0x1f, 0x37, 0xeb, 0x81, 0xdd, 0x2d, 0x1d, 0x1d, 0x97, 0x52, 0x62, 0x4b,
+	0x67, 0xa1, 0x9b, 0x6a, 0x7d, 0x7f, 0xaf, 0x5d, 0xcf, 0x4a, 0x1c, 0xdd, 0x74, 0x10, 0xed, 0xed,
+	0x0c, 0x7d, 0x0b, 0x56, 0x4f, 0x41, 0x7b, 0x1b, 0x5b, 0x3e, 0x3a, 0x2e, 0x65, 0x9a, 0x0e, 0xe2,
+	0xa7, 0x96, 0x45, 0xac, 0xc3, 0xa5, 0xe9, 0x08, 0x01, 0x8f, 0xe6, 0x17, 0x8f, 0x8e, 0x4b, 0x50,
+	0xf3, 0xb1, 0x0a, 0xe4, 0x66, 0x67, 0xdf, 0x6b, 0xee, 0xb5, 0x36, 0xb7, 0xb3, 0xa5, 0x7c, 0xf6,
+	0xe8, 0xb8, 0x94, 0x9e, 0xf4, 0x79, 0x8a, 0xf7, 0xab, 0x50, 0xfd, 0xf0, 0xc5, 0x49, 0x41, 0x7a,
+	0x79, 0x52, 0x90, 0x7e, 0x3b, 0x29, 0x48, 0xcf, 0x5f, 0x17, 0xe6, 0x5e, 0xbe, 0x2e, 0xcc, 0xfd,
+	0xf2, 0xba, 0x30, 0xf7, 0xc9, 0x9d, 0x9e, 0x49, 0xfa, 0xa3, 0x8e, 0xd2, 0xc5, 0xc3, 0x4a, 0xf0,
+	0xf3, 0xd7, 0xff, 0xcb, 0x3f, 0xc3, 0xa7, 0x3f, 0x8d, 0x3b, 0x0b, 0xcc, 0x7e, 0xfb, 0xaf, 0x01,
+	0x00, 0x2c, 0xaa, 0xf8, 0x09, 0xdb, 0x0f, 0x00, 0x00,
 }
 
 func (this *CoreChainLock) Equal(that interface{}) bool {
@@ -1660,6 +1710,20 @@ func (m *Vote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ExtensionSignature) > 0 {
+		i -= len(m.ExtensionSignature)
+		copy(dAtA[i:], m.ExtensionSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ExtensionSignature)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Extension) > 0 {
+		i -= len(m.Extension)
+		copy(dAtA[i:], m.Extension)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Extension)))
+		i--
+		dAtA[i] = 0x5a
+	}
 	if len(m.StateSignature) > 0 {
 		i -= len(m.StateSignature)
 		copy(dAtA[i:], m.StateSignature)
@@ -1744,6 +1808,32 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Signers != nil {
+		{
+			size, err := m.Signers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.ThresholdVoteExtensionSignature) > 0 {
+		i -= len(m.ThresholdVoteExtensionSignature)
+		copy(dAtA[i:], m.ThresholdVoteExtensionSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ThresholdVoteExtensionSignature)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.VoteExtension) > 0 {
+		i -= len(m.VoteExtension)
+		copy(dAtA[i:], m.VoteExtension)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.VoteExtension)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.ThresholdStateSignature) > 0 {
 		i -= len(m.ThresholdStateSignature)
 		copy(dAtA[i:], m.ThresholdStateSignature)
@@ -2296,6 +2386,14 @@ func (m *Vote) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Extension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ExtensionSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -2327,6 +2425,18 @@ func (m *Commit) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.VoteExtension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ThresholdVoteExtensionSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Signers != nil {
+		l = m.Signers.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3904,6 +4014,74 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 				m.StateSignature = []byte{}
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extension = append(m.Extension[:0], dAtA[iNdEx:postIndex]...)
+			if m.Extension == nil {
+				m.Extension = []byte{}
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtensionSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExtensionSignature = append(m.ExtensionSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.ExtensionSignature == nil {
+				m.ExtensionSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -4160,6 +4338,110 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				m.ThresholdStateSignature = []byte{}
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
+			if m.VoteExtension == nil {
+				m.VoteExtension = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThresholdVoteExtensionSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThresholdVoteExtensionSignature = append(m.ThresholdVoteExtensionSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThresholdVoteExtensionSignature == nil {
+				m.ThresholdVoteExtensionSignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signers == nil {
+				m.Signers = &bits.BitArray{}
+			}
+			if err := m.Signers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
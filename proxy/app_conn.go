@@ -5,7 +5,7 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 )
 
-//go:generate mockery --case underscore --name AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot
+//go:generate mockery --case underscore --name AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot|AppConnProposal
 
 //----------------------------------------------------------------------------------------
 // Enforce which abci msgs can be sent on a connection at the type level
@@ -15,11 +15,16 @@ type AppConnConsensus interface {
 	Error() error
 
 	InitChainSync(types.RequestInitChain) (*types.ResponseInitChain, error)
+	InitChainAppStateChunkSync(types.RequestInitChainAppStateChunk) (*types.ResponseInitChainAppStateChunk, error)
 
 	BeginBlockSync(types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	DeliverTxAsync(types.RequestDeliverTx) *abcicli.ReqRes
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
 	CommitSync() (*types.ResponseCommit, error)
+
+	ProcessProposalSync(types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+	ExtendVoteSync(types.RequestExtendVote) (*types.ResponseExtendVote, error)
+	PrepareProposalSync(types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error)
 }
 
 type AppConnMempool interface {
@@ -43,6 +48,15 @@ type AppConnQuery interface {
 	//	SetOptionSync(key string, value string) (res types.Result)
 }
 
+// AppConnProposal is the connection the proposals are validated on, apart from
+// the consensus connection, so that a slow ProcessProposal, which the node
+// stops waiting for, doesn't hold up the execution of the blocks.
+type AppConnProposal interface {
+	Error() error
+
+	ProcessProposalSync(types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+}
+
 type AppConnSnapshot interface {
 	Error() error
 
@@ -77,6 +91,11 @@ func (app *appConnConsensus) InitChainSync(req types.RequestInitChain) (*types.R
 	return app.appConn.InitChainSync(req)
 }
 
+func (app *appConnConsensus) InitChainAppStateChunkSync(
+	req types.RequestInitChainAppStateChunk) (*types.ResponseInitChainAppStateChunk, error) {
+	return app.appConn.InitChainAppStateChunkSync(req)
+}
+
 func (app *appConnConsensus) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
 	return app.appConn.BeginBlockSync(req)
 }
@@ -93,6 +112,21 @@ func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
 	return app.appConn.CommitSync()
 }
 
+func (app *appConnConsensus) ProcessProposalSync(
+	req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	return app.appConn.ProcessProposalSync(req)
+}
+
+func (app *appConnConsensus) ExtendVoteSync(
+	req types.RequestExtendVote) (*types.ResponseExtendVote, error) {
+	return app.appConn.ExtendVoteSync(req)
+}
+
+func (app *appConnConsensus) PrepareProposalSync(
+	req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	return app.appConn.PrepareProposalSync(req)
+}
+
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
@@ -193,3 +227,25 @@ func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
 	return app.appConn.ApplySnapshotChunkSync(req)
 }
+
+//------------------------------------------------
+// Implements AppConnProposal (subset of abcicli.Client)
+
+type appConnProposal struct {
+	appConn abcicli.Client
+}
+
+func NewAppConnProposal(appConn abcicli.Client) AppConnProposal {
+	return &appConnProposal{
+		appConn: appConn,
+	}
+}
+
+func (app *appConnProposal) Error() error {
+	return app.appConn.Error()
+}
+
+func (app *appConnProposal) ProcessProposalSync(
+	req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	return app.appConn.ProcessProposalSync(req)
+}
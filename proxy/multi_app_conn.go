@@ -14,6 +14,7 @@ const (
 	connMempool   = "mempool"
 	connQuery     = "query"
 	connSnapshot  = "snapshot"
+	connProposal  = "proposal"
 )
 
 // AppConns is the Tendermint's interface to the application that consists of
@@ -29,11 +30,13 @@ type AppConns interface {
 	Query() AppConnQuery
 	// Snapshot connection
 	Snapshot() AppConnSnapshot
+	// Proposal connection
+	Proposal() AppConnProposal
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator ClientCreator, metrics *abcicli.Metrics) AppConns {
+	return NewMultiAppConn(clientCreator, metrics)
 }
 
 // multiAppConn implements AppConns.
@@ -48,19 +51,24 @@ type multiAppConn struct {
 	mempoolConn   AppConnMempool
 	queryConn     AppConnQuery
 	snapshotConn  AppConnSnapshot
+	proposalConn  AppConnProposal
 
 	consensusConnClient abcicli.Client
 	mempoolConnClient   abcicli.Client
 	queryConnClient     abcicli.Client
 	snapshotConnClient  abcicli.Client
+	proposalConnClient  abcicli.Client
 
 	clientCreator ClientCreator
+	metrics       *abcicli.Metrics
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator) AppConns {
+// The metrics of the ABCI clients are labeled by the connection.
+func NewMultiAppConn(clientCreator ClientCreator, metrics *abcicli.Metrics) AppConns {
 	multiAppConn := &multiAppConn{
 		clientCreator: clientCreator,
+		metrics:       metrics,
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
 	return multiAppConn
@@ -82,6 +90,10 @@ func (app *multiAppConn) Snapshot() AppConnSnapshot {
 	return app.snapshotConn
 }
 
+func (app *multiAppConn) Proposal() AppConnProposal {
+	return app.proposalConn
+}
+
 func (app *multiAppConn) OnStart() error {
 	c, err := app.abciClientFor(connQuery)
 	if err != nil {
@@ -106,6 +118,14 @@ func (app *multiAppConn) OnStart() error {
 	app.mempoolConnClient = c
 	app.mempoolConn = NewAppConnMempool(c)
 
+	c, err = app.abciClientFor(connProposal)
+	if err != nil {
+		app.stopAllClients()
+		return err
+	}
+	app.proposalConnClient = c
+	app.proposalConn = NewAppConnProposal(c)
+
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
 		app.stopAllClients()
@@ -152,6 +172,10 @@ func (app *multiAppConn) killTMOnClientError() {
 		if err := app.snapshotConnClient.Error(); err != nil {
 			killFn(connSnapshot, err, app.Logger)
 		}
+	case <-app.proposalConnClient.Quit():
+		if err := app.proposalConnClient.Error(); err != nil {
+			killFn(connProposal, err, app.Logger)
+		}
 	}
 }
 
@@ -176,6 +200,11 @@ func (app *multiAppConn) stopAllClients() {
 			app.Logger.Error("error while stopping snapshot client", "error", err)
 		}
 	}
+	if app.proposalConnClient != nil {
+		if err := app.proposalConnClient.Stop(); err != nil {
+			app.Logger.Error("error while stopping proposal client", "error", err)
+		}
+	}
 }
 
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
@@ -184,6 +213,7 @@ func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 		return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", conn, err)
 	}
 	c.SetLogger(app.Logger.With("module", "abci-client", "connection", conn))
+	c.SetMetrics(app.metrics.WithConnection(conn))
 	if err := c.Start(); err != nil {
 		return nil, fmt.Errorf("error starting ABCI client (%s connection): %w", conn, err)
 	}
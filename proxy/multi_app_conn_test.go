@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	abcimocks "github.com/tendermint/tendermint/abci/client/mocks"
 	"github.com/tendermint/tendermint/proxy/mocks"
 )
@@ -21,14 +22,15 @@ func TestAppConns_Start_Stop(t *testing.T) {
 	clientCreatorMock := &mocks.ClientCreator{}
 
 	clientMock := &abcimocks.Client{}
-	clientMock.On("SetLogger", mock.Anything).Return().Times(4)
-	clientMock.On("Start").Return(nil).Times(4)
-	clientMock.On("Stop").Return(nil).Times(4)
-	clientMock.On("Quit").Return(quitCh).Times(4)
+	clientMock.On("SetLogger", mock.Anything).Return().Times(5)
+	clientMock.On("SetMetrics", mock.Anything).Return().Times(5)
+	clientMock.On("Start").Return(nil).Times(5)
+	clientMock.On("Stop").Return(nil).Times(5)
+	clientMock.On("Quit").Return(quitCh).Times(5)
 
-	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil).Times(4)
+	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil).Times(5)
 
-	appConns := NewAppConns(clientCreatorMock)
+	appConns := NewAppConns(clientCreatorMock, abcicli.NopMetrics())
 
 	err := appConns.Start()
 	require.NoError(t, err)
@@ -60,6 +62,7 @@ func TestAppConns_Failure(t *testing.T) {
 
 	clientMock := &abcimocks.Client{}
 	clientMock.On("SetLogger", mock.Anything).Return()
+	clientMock.On("SetMetrics", mock.Anything).Return()
 	clientMock.On("Start").Return(nil)
 	clientMock.On("Stop").Return(nil)
 
@@ -68,7 +71,7 @@ func TestAppConns_Failure(t *testing.T) {
 
 	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil)
 
-	appConns := NewAppConns(clientCreatorMock)
+	appConns := NewAppConns(clientCreatorMock, abcicli.NopMetrics())
 
 	err := appConns.Start()
 	require.NoError(t, err)
@@ -149,6 +149,23 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 		err = client.WaitForHeight(c, status.SyncInfo.LatestBlockHeight+2, nil)
 		require.NoError(t, err)
 
+		// the evidence is committed in one of the following blocks
+		committed := false
+		for h := status.SyncInfo.LatestBlockHeight; h <= status.SyncInfo.LatestBlockHeight+2 && !committed; h++ {
+			block, err := c.Block(context.Background(), &h)
+			require.NoError(t, err)
+			for _, ev := range block.Block.Evidence.Evidence {
+				if bytes.Equal(ev.Hash(), correct.Hash()) {
+					committed = true
+				}
+			}
+		}
+		require.True(t, committed, "evidence %s was not committed", correct)
+
+		// the committed evidence can't be broadcast again
+		_, err = c.BroadcastEvidence(context.Background(), correct)
+		require.Error(t, err, "BroadcastEvidence(%s) succeeded, but the evidence was committed", correct)
+
 		proTxHash := pv.Key.ProTxHash
 		pubKey, err := pv.GetFirstPubKey()
 		require.NoError(t, err, "private validator must have a public key")
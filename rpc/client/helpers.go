@@ -1,11 +1,17 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"time"
 
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -81,3 +87,70 @@ func WaitForOneEvent(c EventsClient, evtTyp string, timeout time.Duration) (type
 		return nil, errors.New("timed out waiting for event")
 	}
 }
+
+// GenesisReader returns a reader of the genesis doc JSON, which fetches the
+// chunks of /genesis_chunked one at a time, as they're read. The reader fails
+// if the chunks are of different genesis docs, e.g. if the node was restarted
+// with another genesis doc, or if the genesis doc JSON doesn't match its hash.
+func GenesisReader(ctx context.Context, c HistoryClient) io.Reader {
+	return &genesisReader{ctx: ctx, c: c, hasher: sha256.New()}
+}
+
+type genesisReader struct {
+	ctx context.Context
+	c   HistoryClient
+
+	next   uint // the number of the next chunk
+	total  int
+	hash   tmbytes.HexBytes
+	hasher hash.Hash
+
+	chunk []byte // the unread part of the current chunk
+	err   error
+}
+
+func (r *genesisReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.fetch()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// fetch fetches the next chunk, it returns io.EOF after the last one.
+func (r *genesisReader) fetch() error {
+	if r.next > 0 && int(r.next) == r.total {
+		if !bytes.Equal(r.hasher.Sum(nil), r.hash) {
+			return fmt.Errorf("the genesis doc doesn't match its hash %v", r.hash)
+		}
+		return io.EOF
+	}
+
+	res, err := r.c.GenesisChunked(r.ctx, r.next)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch the genesis chunk %d: %w", r.next, err)
+	}
+	if r.next == 0 {
+		r.hash = res.Hash
+		r.total = res.TotalChunks
+	}
+	switch {
+	case !bytes.Equal(res.Hash, r.hash) || res.TotalChunks != r.total:
+		return fmt.Errorf("the genesis chunk %d is of the genesis doc %v with %d chunks, expected %v with %d chunks",
+			r.next, res.Hash, res.TotalChunks, r.hash, r.total)
+	case res.ChunkNumber != int(r.next) || res.ChunkNumber >= res.TotalChunks:
+		return fmt.Errorf("got the genesis chunk %d of %d, expected %d", res.ChunkNumber, res.TotalChunks, r.next)
+	}
+	chunk, err := base64.StdEncoding.DecodeString(res.Data)
+	if err != nil {
+		return fmt.Errorf("couldn't decode the genesis chunk %d: %w", r.next, err)
+	}
+	r.hasher.Write(chunk)
+	r.chunk = chunk
+	r.next++
+	return nil
+}
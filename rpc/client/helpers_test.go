@@ -1,7 +1,11 @@
 package client_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"io/ioutil"
 	"strings"
 	"testing"
 
@@ -75,3 +79,62 @@ func TestWaitForHeight(t *testing.T) {
 	require.True(ok)
 	assert.Equal(int64(15), postr.SyncInfo.LatestBlockHeight)
 }
+
+// chunkedGenesisClient serves the chunks of /genesis_chunked from memory.
+type chunkedGenesisClient struct {
+	client.HistoryClient
+	chunks []*ctypes.ResultGenesisChunk
+}
+
+func newChunkedGenesisClient(genDocJSON []byte, chunkSize int) *chunkedGenesisClient {
+	c := &chunkedGenesisClient{}
+	hash := sha256.Sum256(genDocJSON)
+	total := (len(genDocJSON) + chunkSize - 1) / chunkSize
+	for i := 0; i < total; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(genDocJSON) {
+			end = len(genDocJSON)
+		}
+		c.chunks = append(c.chunks, &ctypes.ResultGenesisChunk{
+			ChunkNumber: i,
+			TotalChunks: total,
+			Hash:        hash[:],
+			Data:        base64.StdEncoding.EncodeToString(genDocJSON[i*chunkSize : end]),
+		})
+	}
+	return c
+}
+
+func (c *chunkedGenesisClient) GenesisChunked(ctx context.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+	if int(chunk) >= len(c.chunks) {
+		return nil, errors.New("no such chunk")
+	}
+	return c.chunks[chunk], nil
+}
+
+func TestGenesisReader(t *testing.T) {
+	genDocJSON := []byte(`{"chain_id":"test-chain","app_state":{"accounts":[1,2,3]}}`)
+
+	c := newChunkedGenesisClient(genDocJSON, 10)
+	bz, err := ioutil.ReadAll(client.GenesisReader(context.Background(), c))
+	require.NoError(t, err)
+	assert.Equal(t, genDocJSON, bz)
+
+	// the chunk of another genesis doc
+	other := newChunkedGenesisClient([]byte(`{"chain_id":"other-chain","app_state":{}}`), 10)
+	c.chunks[2] = other.chunks[2]
+	_, err = ioutil.ReadAll(client.GenesisReader(context.Background(), c))
+	assert.Error(t, err)
+
+	// the chunks don't match the hash
+	c = newChunkedGenesisClient(genDocJSON, 10)
+	c.chunks[2].Data = base64.StdEncoding.EncodeToString([]byte("0123456789"))
+	_, err = ioutil.ReadAll(client.GenesisReader(context.Background(), c))
+	assert.Error(t, err)
+
+	// the chunk can't be fetched
+	c = newChunkedGenesisClient(genDocJSON, 10)
+	c.chunks = c.chunks[:3]
+	_, err = ioutil.ReadAll(client.GenesisReader(context.Background(), c))
+	assert.Error(t, err)
+}
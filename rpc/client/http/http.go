@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -33,6 +35,12 @@ slow, Tendermint might cancel the subscription. The client will attempt to
 resubscribe (you don't need to do anything). It will keep trying every second
 indefinitely until successful.
 
+The websocket connection is kept alive with pings, and a connection which
+misses pongs is considered dead. The client then reconnects with exponential
+backoff and re-issues all the active subscriptions, after which
+ErrSubscriptionRecovered is sent on SubscriptionErrors, since the events
+published while the client was disconnected may have been missed.
+
 Request batching is available for JSON RPC requests over HTTP, which conforms to
 the JSON RPC specification (https://www.jsonrpc.org/specification#batch). See
 the example for more details.
@@ -129,6 +137,20 @@ func NewWithTimeout(remote, wsEndpoint string, timeout uint) (*HTTP, error) {
 // NewWithClient allows for setting a custom http client (See New).
 // An error is returned on invalid remote. The function panics when remote is nil.
 func NewWithClient(remote, wsEndpoint string, client *http.Client) (*HTTP, error) {
+	return NewWithClientAndWSOptions(remote, wsEndpoint, client)
+}
+
+// NewWithClientAndWSOptions allows for setting a custom http client (See New)
+// and configuring the websocket client used for subscriptions, for example
+// its keepalive with jsonrpcclient.PingPeriod and jsonrpcclient.ReadWait.
+// The options are applied on top of the defaults, which ping the server every
+// 10s and reconnect when no pong is received within 30s.
+// An error is returned on invalid remote. The function panics when remote is nil.
+func NewWithClientAndWSOptions(
+	remote, wsEndpoint string,
+	client *http.Client,
+	wsOptions ...func(*jsonrpcclient.WSClient),
+) (*HTTP, error) {
 	if client == nil {
 		panic("nil http.Client provided")
 	}
@@ -138,7 +160,7 @@ func NewWithClient(remote, wsEndpoint string, client *http.Client) (*HTTP, error
 		return nil, err
 	}
 
-	wsEvents, err := newWSEvents(remote, wsEndpoint)
+	wsEvents, err := newWSEvents(remote, wsEndpoint, wsOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -394,6 +416,15 @@ func (c *baseRPCClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, err
 	return result, nil
 }
 
+func (c *baseRPCClient) GenesisChunked(ctx context.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+	result := new(ctypes.ResultGenesisChunk)
+	_, err := c.caller.Call(ctx, "genesis_chunked", map[string]interface{}{"chunk": chunk}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	result := new(ctypes.ResultBlock)
 	params := make(map[string]interface{})
@@ -402,7 +433,7 @@ func (c *baseRPCClient) Block(ctx context.Context, height *int64) (*ctypes.Resul
 	}
 	_, err := c.caller.Call(ctx, "block", params, result)
 	if err != nil {
-		return nil, err
+		return nil, heightPrunedErr(err)
 	}
 	return result, nil
 }
@@ -430,7 +461,7 @@ func (c *baseRPCClient) BlockResults(
 	}
 	_, err := c.caller.Call(ctx, "block_results", params, result)
 	if err != nil {
-		return nil, err
+		return nil, heightPrunedErr(err)
 	}
 	return result, nil
 }
@@ -443,7 +474,7 @@ func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.Resu
 	}
 	_, err := c.caller.Call(ctx, "commit", params, result)
 	if err != nil {
-		return nil, err
+		return nil, heightPrunedErr(err)
 	}
 	return result, nil
 }
@@ -543,11 +574,71 @@ func (c *baseRPCClient) Validators(
 	}
 	_, err := c.caller.Call(ctx, "validators", params, result)
 	if err != nil {
-		return nil, err
+		return nil, heightPrunedErr(err)
+	}
+	return result, nil
+}
+
+// ThresholdCommit returns the threshold signatures of the commit of the block at a height,
+// the latest block if height is nil.
+func (c *baseRPCClient) ThresholdCommit(ctx context.Context, height *int64) (*ctypes.ResultThresholdCommit, error) {
+	result := new(ctypes.ResultThresholdCommit)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "threshold_commit", params, result)
+	if err != nil {
+		return nil, heightPrunedErr(err)
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ValidatorQuorum(ctx context.Context, height *int64) (*ctypes.ResultValidatorQuorum, error) {
+	result := new(ctypes.ResultValidatorQuorum)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "validator_quorum", params, result)
+	if err != nil {
+		return nil, heightPrunedErr(err)
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) Proposer(ctx context.Context, height *int64, round *int) (*ctypes.ResultProposer, error) {
+	result := new(ctypes.ResultProposer)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	if round != nil {
+		params["round"] = round
+	}
+	_, err := c.caller.Call(ctx, "proposer", params, result)
+	if err != nil {
+		return nil, heightPrunedErr(err)
 	}
 	return result, nil
 }
 
+// heightPrunedErr converts an RPC error about a pruned height into ctypes.ErrHeightPruned,
+// so callers can learn the earliest available height and fail over to an archive node.
+// The errors about unavailable block results are converted into
+// ctypes.ErrResultsUnavailable the same way. The errors are restored from the
+// code and the data of the RPC error. Any other error is returned as is.
+func heightPrunedErr(err error) error {
+	var rpcErr *rpctypes.RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	if restored, ok := ctypes.ErrFromRPCErrorData(rpcErr.Code, rpcErr.StructuredData); ok {
+		return restored
+	}
+	return err
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -563,8 +654,19 @@ func (c *baseRPCClient) BroadcastEvidence(
 //-----------------------------------------------------------------------------
 // WSEvents
 
+const (
+	defaultWSPingPeriod = 10 * time.Second
+	defaultWSReadWait   = 30 * time.Second
+)
+
 var errNotRunning = errors.New("client is not running. Use .Start() method to start")
 
+// ErrSubscriptionRecovered is sent on SubscriptionErrors after the client has
+// reconnected and re-issued all the active subscriptions. Events published
+// while the client was disconnected may have been missed, unless the
+// subscription was made with WithReplay.
+var ErrSubscriptionRecovered = errors.New("subscriptions recovered after reconnect, events may have been missed")
+
 // WSEvents is a wrapper around WSClient, which implements EventsClient.
 type WSEvents struct {
 	service.BaseService
@@ -573,22 +675,118 @@ type WSEvents struct {
 	ws       *jsonrpcclient.WSClient
 
 	mtx           tmsync.RWMutex
-	subscriptions map[string]chan ctypes.ResultEvent // query -> chan
+	subscriptions map[string]*wsSubscription // query -> subscription
+
+	errs chan error
+}
+
+// wsSubscription keeps the output channel of a subscription and, if the
+// subscription is made with replay, the position to resume from after reconnect.
+type wsSubscription struct {
+	out    chan ctypes.ResultEvent
+	replay bool
+	// height of the last delivered event, events of this height are replayed
+	// again after reconnect, so the delivered ones are kept in seen to skip them
+	height int64
+	seen   map[string]struct{}
+	// the slow consumer policy of the server side subscription, if not default
+	slowConsumerPolicy tmpubsub.SlowConsumerPolicy
 }
 
-func newWSEvents(remote, endpoint string) (*WSEvents, error) {
+// SubscribeOption configures a subscription made with SubscribeWithOptions.
+type SubscribeOption func(*wsSubscription)
+
+// WithReplay makes the server replay the events of committed blocks starting
+// from sinceHeight before the live events. After reconnect, the events are
+// replayed from the height of the last delivered event, so the subscriber
+// doesn't miss events which happened while the client was disconnected.
+// Only block, evidence and tx events are replayed, see /subscribe.
+func WithReplay(sinceHeight int64) SubscribeOption {
+	return func(sub *wsSubscription) {
+		sub.replay = true
+		sub.height = sinceHeight
+	}
+}
+
+// WithOutCapacity sets the capacity of the channel events are published onto.
+// Replay sends many events at once, so a larger capacity should be used with it.
+func WithOutCapacity(capacity int) SubscribeOption {
+	return func(sub *wsSubscription) {
+		sub.out = make(chan ctypes.ResultEvent, capacity)
+	}
+}
+
+// WithSlowConsumerPolicy sets what the server does with the events, once the
+// client isn't reading them fast enough: with SlowConsumerDropOldest or
+// SlowConsumerDropNewest, the events are dropped instead of terminating the
+// subscription, and a types.EventDataEventsDropped event with their count is
+// delivered in place of them.
+func WithSlowConsumerPolicy(policy tmpubsub.SlowConsumerPolicy) SubscribeOption {
+	return func(sub *wsSubscription) {
+		sub.slowConsumerPolicy = policy
+	}
+}
+
+// deliver returns false if the event has already been delivered.
+func (sub *wsSubscription) deliver(event ctypes.ResultEvent) bool {
+	if !sub.replay {
+		return true
+	}
+	height, key := eventKey(event.Data)
+	if height == 0 {
+		return true
+	}
+	switch {
+	case height < sub.height:
+		return false
+	case height > sub.height:
+		sub.height = height
+		sub.seen = make(map[string]struct{})
+	}
+	if _, ok := sub.seen[key]; ok {
+		return false
+	}
+	sub.seen[key] = struct{}{}
+	return true
+}
+
+// eventKey returns the height and a key unique within the height of a block event
+func eventKey(data types.TMEventData) (int64, string) {
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		return data.Block.Height, types.EventNewBlock
+	case types.EventDataNewBlockHeader:
+		return data.Header.Height, types.EventNewBlockHeader
+	case types.EventDataNewEvidence:
+		return data.Height, fmt.Sprintf("%s/%X", types.EventNewEvidence, data.Evidence.Hash())
+	case types.EventDataTx:
+		return data.Height, fmt.Sprintf("%s/%d", types.EventTx, data.Index)
+	}
+	return 0, ""
+}
+
+func newWSEvents(remote, endpoint string, options ...func(*jsonrpcclient.WSClient)) (*WSEvents, error) {
 	w := &WSEvents{
 		endpoint:      endpoint,
 		remote:        remote,
-		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		subscriptions: make(map[string]*wsSubscription),
+		errs:          make(chan error, 1),
 	}
 	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
 
-	var err error
-	w.ws, err = jsonrpcclient.NewWS(w.remote, w.endpoint, jsonrpcclient.OnReconnect(func() {
+	wsOptions := []func(*jsonrpcclient.WSClient){
+		jsonrpcclient.PingPeriod(defaultWSPingPeriod),
+		jsonrpcclient.ReadWait(defaultWSReadWait),
+	}
+	wsOptions = append(wsOptions, options...)
+	wsOptions = append(wsOptions, jsonrpcclient.OnReconnect(func() {
 		// resubscribe immediately
 		w.redoSubscriptionsAfter(0 * time.Second)
+		w.notify(ErrSubscriptionRecovered)
 	}))
+
+	var err error
+	w.ws, err = jsonrpcclient.NewWS(w.remote, w.endpoint, wsOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -615,6 +813,29 @@ func (w *WSEvents) OnStop() {
 	}
 }
 
+// SubscriptionErrors returns a channel, which receives ErrSubscriptionRecovered
+// after the subscriptions were re-issued on reconnect. Only the latest error is
+// kept until it is read.
+func (w *WSEvents) SubscriptionErrors() <-chan error {
+	return w.errs
+}
+
+// notify sends err on SubscriptionErrors without blocking, an error that
+// has not been read yet is replaced.
+func (w *WSEvents) notify(err error) {
+	for {
+		select {
+		case w.errs <- err:
+			return
+		default:
+		}
+		select {
+		case <-w.errs:
+		default:
+		}
+	}
+}
+
 // Subscribe implements EventsClient by using WSClient to subscribe given
 // subscriber to query. By default, returns a channel with cap=1. Error is
 // returned if it fails to subscribe.
@@ -625,27 +846,64 @@ func (w *WSEvents) OnStop() {
 func (w *WSEvents) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+
+	return w.SubscribeWithOptions(ctx, subscriber, query, WithOutCapacity(outCap))
+}
+
+// SubscribeWithOptions subscribes given subscriber to query like Subscribe does,
+// the subscription can be configured with options, see WithReplay,
+// WithOutCapacity and WithSlowConsumerPolicy. By default, returns a channel with cap=1.
+//
+// It returns an error if WSEvents is not running.
+func (w *WSEvents) SubscribeWithOptions(ctx context.Context, subscriber, query string,
+	opts ...SubscribeOption) (out <-chan ctypes.ResultEvent, err error) {
+
 	if !w.IsRunning() {
 		return nil, errNotRunning
 	}
 
-	if err := w.ws.Subscribe(ctx, query); err != nil {
-		return nil, err
+	sub := &wsSubscription{seen: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(sub)
 	}
-
-	outCap := 1
-	if len(outCapacity) > 0 {
-		outCap = outCapacity[0]
+	if sub.out == nil {
+		sub.out = make(chan ctypes.ResultEvent, 1)
 	}
 
-	outc := make(chan ctypes.ResultEvent, outCap)
+	// The subscription is registered before the request is sent, because
+	// replayed events can be received right after the request.
 	w.mtx.Lock()
 	// subscriber param is ignored because Tendermint will override it with
 	// remote IP anyway.
-	w.subscriptions[query] = outc
+	w.subscriptions[query] = sub
 	w.mtx.Unlock()
 
-	return outc, nil
+	if err := w.subscribe(ctx, query, sub); err != nil {
+		w.mtx.Lock()
+		delete(w.subscriptions, query)
+		w.mtx.Unlock()
+		return nil, err
+	}
+
+	return sub.out, nil
+}
+
+func (w *WSEvents) subscribe(ctx context.Context, query string, sub *wsSubscription) error {
+	if !sub.replay && sub.slowConsumerPolicy == "" {
+		return w.ws.Subscribe(ctx, query)
+	}
+	params := map[string]interface{}{"query": query}
+	if sub.replay {
+		params["since_height"] = sub.height
+	}
+	if sub.slowConsumerPolicy != "" {
+		params["slow_consumer_policy"] = sub.slowConsumerPolicy
+	}
+	return w.ws.Call(ctx, "subscribe", params)
 }
 
 // Unsubscribe implements EventsClient by using WSClient to unsubscribe given
@@ -685,7 +943,7 @@ func (w *WSEvents) UnsubscribeAll(ctx context.Context, subscriber string) error
 	}
 
 	w.mtx.Lock()
-	w.subscriptions = make(map[string]chan ctypes.ResultEvent)
+	w.subscriptions = make(map[string]*wsSubscription)
 	w.mtx.Unlock()
 
 	return nil
@@ -698,8 +956,8 @@ func (w *WSEvents) redoSubscriptionsAfter(d time.Duration) {
 
 	w.mtx.RLock()
 	defer w.mtx.RUnlock()
-	for q := range w.subscriptions {
-		err := w.ws.Subscribe(context.Background(), q)
+	for q, sub := range w.subscriptions {
+		err := w.subscribe(context.Background(), q, sub)
 		if err != nil {
 			w.Logger.Error("Failed to resubscribe", "err", err)
 		}
@@ -739,8 +997,10 @@ func (w *WSEvents) eventListener() {
 				continue
 			}
 
-			w.mtx.RLock()
-			if out, ok := w.subscriptions[result.Query]; ok {
+			// the lock is exclusive since a replay position of the subscription is updated
+			w.mtx.Lock()
+			if sub, ok := w.subscriptions[result.Query]; ok && sub.deliver(*result) {
+				out := sub.out
 				if cap(out) == 0 {
 					out <- *result
 				} else {
@@ -751,7 +1011,7 @@ func (w *WSEvents) eventListener() {
 					}
 				}
 			}
-			w.mtx.RUnlock()
+			w.mtx.Unlock()
 		case <-w.Quit():
 			return
 		}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// eventsHandler streams an event every 10ms to the subscribers, the event data
+// tells the number of the connection it was sent on
+type eventsHandler struct {
+	mtx   tmsync.Mutex
+	conns []*websocket.Conn
+}
+
+func (h *eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+
+	h.mtx.Lock()
+	h.conns = append(h.conns, conn)
+	connNum := len(h.conns)
+	h.mtx.Unlock()
+
+	var writeMtx tmsync.Mutex
+	write := func(res rpctypes.RPCResponse) error {
+		writeMtx.Lock()
+		defer writeMtx.Unlock()
+		return conn.WriteJSON(res)
+	}
+
+	for {
+		_, in, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req rpctypes.RPCRequest
+		if err := json.Unmarshal(in, &req); err != nil {
+			panic(err)
+		}
+		if err := write(rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultSubscribe{})); err != nil {
+			return
+		}
+		if req.Method != "subscribe" {
+			continue
+		}
+
+		var params struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			panic(err)
+		}
+		go func() {
+			for i := 0; ; i++ {
+				event := &ctypes.ResultEvent{
+					Query: params.Query,
+					Data:  types.EventDataString(fmt.Sprintf("conn %d event %d", connNum, i)),
+				}
+				bz, err := tmjson.Marshal(event)
+				if err != nil {
+					panic(err)
+				}
+				if err := write(rpctypes.NewRPCSuccessResponse(req.ID, json.RawMessage(bz))); err != nil {
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}()
+	}
+}
+
+// killConn closes the last websocket connection without a close message,
+// like a proxy dropping it
+func (h *eventsHandler) killConn() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.conns[len(h.conns)-1].UnderlyingConn().Close()
+}
+
+func TestWSEventsResubscribeAfterConnectionKilled(t *testing.T) {
+	h := &eventsHandler{}
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	c, err := New(s.URL, "/websocket")
+	require.NoError(t, err)
+	c.SetLogger(log.TestingLogger())
+	require.NoError(t, c.Start())
+	defer c.Stop() // nolint:errcheck // ignore for tests
+
+	query := "tm.event='Test'"
+	out, err := c.Subscribe(context.Background(), "test", query, 100)
+	require.NoError(t, err)
+
+	receive := func() string {
+		select {
+		case event := <-out:
+			assert.Equal(t, query, event.Query)
+			return string(event.Data.(types.EventDataString))
+		case <-time.After(5 * time.Second):
+			t.Fatal("did not receive an event within 5s")
+		}
+		return ""
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, fmt.Sprintf("conn 1 event %d", i), receive())
+	}
+
+	h.killConn()
+
+	select {
+	case err := <-c.SubscriptionErrors():
+		assert.Equal(t, ErrSubscriptionRecovered, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("subscription was not recovered within 10s")
+	}
+
+	// the events of the killed connection left in the channel are skipped
+	for {
+		data := receive()
+		if data == "conn 2 event 0" {
+			break
+		}
+		require.Regexp(t, "^conn 1 event", data)
+	}
+	assert.Equal(t, "conn 2 event 1", receive())
+}
@@ -70,6 +70,8 @@ type SignClient interface {
 	Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int,
 		requestThresholdPublicKey *bool) (*ctypes.ResultValidators, error)
+	ValidatorQuorum(ctx context.Context, height *int64) (*ctypes.ResultValidatorQuorum, error)
+	Proposer(ctx context.Context, height *int64, round *int) (*ctypes.ResultProposer, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
@@ -95,6 +97,7 @@ type SignClient interface {
 // HistoryClient provides access to data from genesis to now in large chunks.
 type HistoryClient interface {
 	Genesis(context.Context) (*ctypes.ResultGenesis, error)
+	GenesisChunked(ctx context.Context, chunk uint) (*ctypes.ResultGenesisChunk, error)
 	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
 }
 
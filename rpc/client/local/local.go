@@ -89,7 +89,7 @@ func (c *Local) ABCIQueryWithOptions(
 }
 
 func (c *Local) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return core.BroadcastTxCommit(c.ctx, tx)
+	return core.BroadcastTxCommit(c.ctx, tx, "", false)
 }
 
 func (c *Local) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
@@ -101,7 +101,7 @@ func (c *Local) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resul
 }
 
 func (c *Local) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	return core.UnconfirmedTxs(c.ctx, limit)
+	return core.UnconfirmedTxs(c.ctx, limit, nil, nil)
 }
 
 func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -154,6 +154,10 @@ func (c *Local) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return core.Genesis(c.ctx)
 }
 
+func (c *Local) GenesisChunked(ctx context.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+	return core.GenesisChunked(c.ctx, chunk)
+}
+
 func (c *Local) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	return core.Block(c.ctx, height)
 }
@@ -175,6 +179,14 @@ func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *in
 	return core.Validators(c.ctx, height, page, perPage, requestThresholdPublicKey)
 }
 
+func (c *Local) ValidatorQuorum(ctx context.Context, height *int64) (*ctypes.ResultValidatorQuorum, error) {
+	return core.ValidatorQuorum(c.ctx, height)
+}
+
+func (c *Local) Proposer(ctx context.Context, height *int64, round *int) (*ctypes.ResultProposer, error) {
+	return core.Proposer(c.ctx, height, round)
+}
+
 func (c *Local) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	return core.Tx(c.ctx, hash, prove)
 }
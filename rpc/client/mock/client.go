@@ -101,7 +101,7 @@ func (c Client) ABCIQueryWithOptions(
 }
 
 func (c Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
-	return core.BroadcastTxCommit(&rpctypes.Context{}, tx)
+	return core.BroadcastTxCommit(&rpctypes.Context{}, tx, "", false)
 }
 
 func (c Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
@@ -158,6 +158,10 @@ func (c Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return core.Genesis(&rpctypes.Context{})
 }
 
+func (c Client) GenesisChunked(ctx context.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+	return core.GenesisChunked(&rpctypes.Context{}, chunk)
+}
+
 func (c Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	return core.Block(&rpctypes.Context{}, height)
 }
@@ -175,6 +179,14 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 	return core.Validators(&rpctypes.Context{}, height, page, perPage, requestThresholdPublicKey)
 }
 
+func (c Client) ValidatorQuorum(ctx context.Context, height *int64) (*ctypes.ResultValidatorQuorum, error) {
+	return core.ValidatorQuorum(&rpctypes.Context{}, height)
+}
+
+func (c Client) Proposer(ctx context.Context, height *int64, round *int) (*ctypes.ResultProposer, error) {
+	return core.Proposer(&rpctypes.Context{}, height, round)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
@@ -436,6 +436,29 @@ func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error)
 	return r0, r1
 }
 
+// GenesisChunked provides a mock function with given fields: ctx, chunk
+func (_m *Client) GenesisChunked(ctx context.Context, chunk uint) (*coretypes.ResultGenesisChunk, error) {
+	ret := _m.Called(ctx, chunk)
+
+	var r0 *coretypes.ResultGenesisChunk
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *coretypes.ResultGenesisChunk); ok {
+		r0 = rf(ctx, chunk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultGenesisChunk)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, chunk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Health provides a mock function with given fields: _a0
 func (_m *Client) Health(_a0 context.Context) (*coretypes.ResultHealth, error) {
 	ret := _m.Called(_a0)
@@ -552,6 +575,29 @@ func (_m *Client) OnStop() {
 	_m.Called()
 }
 
+// Proposer provides a mock function with given fields: ctx, height, round
+func (_m *Client) Proposer(ctx context.Context, height *int64, round *int) (*coretypes.ResultProposer, error) {
+	ret := _m.Called(ctx, height, round)
+
+	var r0 *coretypes.ResultProposer
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, *int) *coretypes.ResultProposer); ok {
+		r0 = rf(ctx, height, round)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultProposer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, *int) error); ok {
+		r1 = rf(ctx, height, round)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Quit provides a mock function with given fields:
 func (_m *Client) Quit() <-chan struct{} {
 	ret := _m.Called()
@@ -779,6 +825,29 @@ func (_m *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return r0
 }
 
+// ValidatorQuorum provides a mock function with given fields: ctx, height
+func (_m *Client) ValidatorQuorum(ctx context.Context, height *int64) (*coretypes.ResultValidatorQuorum, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultValidatorQuorum
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultValidatorQuorum); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorQuorum)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Validators provides a mock function with given fields: ctx, height, page, perPage
 func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perPage *int, requestThresholdPublicKey *bool) (*coretypes.ResultValidators, error) {
 	ret := _m.Called(ctx, height, page, perPage, requestThresholdPublicKey)
@@ -3,6 +3,7 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"net/http"
 	"strings"
@@ -102,6 +103,12 @@ func TestStatus(t *testing.T) {
 		status, err := c.Status(context.Background())
 		require.Nil(t, err, "%d: %+v", i, err)
 		assert.Equal(t, moniker, status.NodeInfo.Moniker)
+
+		// the test node is the only validator
+		assert.True(t, status.ValidatorInfo.IsValidator)
+		assert.NotNil(t, status.ValidatorInfo.PubKey)
+		assert.NotEmpty(t, status.ValidatorInfo.ProTxHash)
+		assert.NotEmpty(t, status.ValidatorInfo.QuorumHash)
 	}
 }
 
@@ -186,6 +193,73 @@ func TestGenesisAndValidators(t *testing.T) {
 	}
 }
 
+func TestGenesisChunked(t *testing.T) {
+	for i, c := range GetClients() {
+		gen, err := c.Genesis(context.Background())
+		require.Nil(t, err, "%d: %+v", i, err)
+
+		bz, err := ioutil.ReadAll(client.GenesisReader(context.Background(), c))
+		require.Nil(t, err, "%d: %+v", i, err)
+		genDoc, err := types.GenesisDocFromJSON(bz)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, gen.Genesis.ChainID, genDoc.ChainID)
+		assert.Equal(t, gen.Genesis.GenesisTime, genDoc.GenesisTime)
+		assert.Equal(t, gen.Genesis.Validators, genDoc.Validators)
+		assert.Equal(t, gen.Genesis.QuorumHash, genDoc.QuorumHash)
+	}
+}
+
+func TestValidatorQuorum(t *testing.T) {
+	for i, c := range GetClients() {
+		gen, err := c.Genesis(context.Background())
+		require.Nil(t, err, "%d: %+v", i, err)
+		gval := gen.Genesis.Validators[0]
+
+		h := int64(1)
+		quorum, err := c.ValidatorQuorum(context.Background(), &h)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, h, quorum.BlockHeight)
+		assert.Equal(t, gen.Genesis.QuorumType, quorum.QuorumType)
+		assert.Equal(t, gen.Genesis.QuorumHash, quorum.QuorumHash)
+		assert.Equal(t, gen.Genesis.ThresholdPublicKey, quorum.ThresholdPublicKey)
+		require.Len(t, quorum.Members, 1)
+		assert.Equal(t, gval.ProTxHash, quorum.Members[0].ProTxHash)
+		assert.Equal(t, gval.PubKey, quorum.Members[0].PubKey)
+
+		// the height in the future is not available
+		h = int64(1 << 40)
+		_, err = c.ValidatorQuorum(context.Background(), &h)
+		assert.Error(t, err, "%d", i)
+	}
+}
+
+func TestProposer(t *testing.T) {
+	for i, c := range GetClients() {
+		gen, err := c.Genesis(context.Background())
+		require.Nil(t, err, "%d: %+v", i, err)
+		gval := gen.Genesis.Validators[0]
+
+		h := int64(1)
+		proposer, err := c.Proposer(context.Background(), &h, nil)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, h, proposer.BlockHeight)
+		assert.EqualValues(t, 0, proposer.Round)
+		assert.Equal(t, gval.ProTxHash, proposer.ProTxHash)
+		require.Len(t, proposer.Validators, 1)
+		assert.Equal(t, gval.ProTxHash, proposer.Validators[0].ProTxHash)
+
+		// the proposer of the committed block is the selected one
+		block, err := c.Block(context.Background(), &h)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, block.Block.ProposerProTxHash, proposer.ProTxHash)
+
+		// the height in the future is not available
+		h = int64(1 << 40)
+		_, err = c.Proposer(context.Background(), &h, nil)
+		assert.Error(t, err, "%d", i)
+	}
+}
+
 func TestABCIQuery(t *testing.T) {
 	for i, c := range GetClients() {
 		// write something
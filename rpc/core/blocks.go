@@ -1,14 +1,19 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sort"
 
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/proxy"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
 	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
 	"github.com/tendermint/tendermint/types"
 )
@@ -131,12 +136,54 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 	return ctypes.NewResultCommit(&header, commit, true), nil
 }
 
+// ThresholdCommit gets the threshold block and state signatures of the commit of
+// the block at the given height, so they can be verified with the threshold
+// public key of the quorum (see ValidatorQuorum) without decoding the commit.
+// If no height is provided, it will fetch the commit of the latest block, which
+// is not canonical yet.
+// More: https://docs.tendermint.com/master/rpc/#/Info/threshold_commit
+func ThresholdCommit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultThresholdCommit, error) {
+	latestHeight := env.BlockStore.Height()
+	height, err := getHeight(latestHeight, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the next block has not been committed yet,
+	// use a non-canonical commit
+	canonical := height != latestHeight
+	var commit *types.Commit
+	if canonical {
+		commit = env.BlockStore.LoadBlockCommit(height)
+	} else {
+		commit = env.BlockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("commit for height %d not found", height)
+	}
+
+	return &ctypes.ResultThresholdCommit{
+		Height:                  commit.Height,
+		Round:                   commit.Round,
+		BlockID:                 commit.BlockID,
+		StateID:                 commit.StateID,
+		QuorumHash:              commit.QuorumHash,
+		ThresholdBlockSignature: commit.ThresholdBlockSignature,
+		ThresholdStateSignature: commit.ThresholdStateSignature,
+		CanonicalCommit:         canonical,
+	}, nil
+}
+
 // BlockResults gets ABCIResults at a given height.
 // If no height is provided, it will fetch results for the latest block.
 //
 // Results are for the height of the block containing the txs.
 // Thus response.results.deliver_tx[5] is the results of executing
 // getBlock(h).Txs[5]
+//
+// The results, which are missing, e.g. below the state sync height, are
+// recomputed if enabled (see missingBlockResults), otherwise
+// ctypes.ErrResultsUnavailable is returned.
 // More: https://docs.tendermint.com/master/rpc/#/Info/block_results
 func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockResults, error) {
 	height, err := getHeight(env.BlockStore.Height(), heightPtr)
@@ -146,7 +193,10 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
-		return nil, err
+		results, err = missingBlockResults(height, err)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &ctypes.ResultBlockResults{
@@ -159,6 +209,86 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 	}, nil
 }
 
+// missingBlockResults returns the results of the block at the height, which
+// aren't in the state store. If rpc.recompute_missing_results is enabled and
+// the app supports the replay of the blocks, the block is re-executed by the
+// app and the results are cached. The results are checked against the results
+// hash of the next block, if it's known.
+func missingBlockResults(height int64, loadErr error) (*tmstate.ABCIResponses, error) {
+	var earliestHeight int64
+	switch err := loadErr.(type) {
+	case sm.ErrPruned:
+		earliestHeight = err.Base
+	case sm.ErrNoABCIResponsesForHeight:
+	default:
+		return nil, loadErr
+	}
+	if results, ok := env.recomputedResults.get(height); ok {
+		return results, nil
+	}
+
+	unavailable := ctypes.ErrResultsUnavailable{Height: height, EarliestHeight: earliestHeight}
+	if !env.Config.RecomputeMissingResults || env.ProxyAppQuery == nil {
+		return nil, unavailable
+	}
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, unavailable
+	}
+	info, err := env.ProxyAppQuery.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("can't request app info: %w", err)
+	}
+	if !info.SupportsReplay {
+		return nil, unavailable
+	}
+
+	results, err := sm.ReplayBlock(env.ProxyAppQuery, block)
+	if err != nil {
+		return nil, fmt.Errorf("can't recompute the results of height %d: %w", height, err)
+	}
+	if next := env.BlockStore.LoadBlockMeta(height + 1); next != nil {
+		if hash := sm.ABCIResponsesResultsHash(results); !bytes.Equal(hash, next.Header.LastResultsHash) {
+			return nil, fmt.Errorf("recomputed results of height %d have the hash %X, but the next block commits to %X",
+				height, hash, next.Header.LastResultsHash)
+		}
+	}
+	env.recomputedResults.add(height, results)
+	return results, nil
+}
+
+// resultsCache caches the recomputed block results, evicting the oldest ones
+// once it's full.
+type resultsCache struct {
+	mtx     tmsync.Mutex
+	results map[int64]*tmstate.ABCIResponses
+	heights []int64 // in the order they were added
+}
+
+func (c *resultsCache) get(height int64) (*tmstate.ABCIResponses, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	results, ok := c.results[height]
+	return results, ok
+}
+
+func (c *resultsCache) add(height int64, results *tmstate.ABCIResponses) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.results == nil {
+		c.results = make(map[int64]*tmstate.ABCIResponses)
+	}
+	if _, ok := c.results[height]; ok {
+		return
+	}
+	if len(c.heights) == recomputedResultsCacheSize {
+		delete(c.results, c.heights[0])
+		c.heights = c.heights[1:]
+	}
+	c.results[height] = results
+	c.heights = append(c.heights, height)
+}
+
 // BlockSearch searches for a paginated set of blocks matching BeginBlock and
 // EndBlock event search criteria.
 func BlockSearch(
@@ -197,7 +327,7 @@ func BlockSearch(
 
 	// paginate results
 	totalCount := len(results)
-	perPage := validatePerPage(perPagePtr)
+	perPage := validateSearchPerPage(perPagePtr)
 
 	page, err := validatePage(pagePtr, perPage, totalCount)
 	if err != nil {
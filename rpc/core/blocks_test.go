@@ -1,19 +1,30 @@
 package core
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	sm "github.com/tendermint/tendermint/state"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -115,12 +126,304 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestHeightPruned(t *testing.T) {
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	env.BlockStore = mockBlockStore{height: 100, base: 50}
+	env.Config.ArchiveEndpoints = []string{"http://archive-1:26657", "http://archive-2:26657"}
+
+	height := int64(10)
+	_, errBlock := Block(&rpctypes.Context{}, &height)
+	_, errBlockResults := BlockResults(&rpctypes.Context{}, &height)
+	_, errCommit := Commit(&rpctypes.Context{}, &height)
+	for _, err := range []error{errBlock, errBlockResults, errCommit} {
+		var errPruned ctypes.ErrHeightPruned
+		require.True(t, errors.As(err, &errPruned), err)
+		assert.Equal(t, height, errPruned.Height)
+		assert.EqualValues(t, 50, errPruned.EarliestHeight)
+		assert.Equal(t, env.Config.ArchiveEndpoints, errPruned.ArchiveEndpoints)
+
+		assert.Equal(t, errPruned, rpcErrorRoundTrip(t, err))
+	}
+}
+
+// rpcErrorRoundTrip returns the error, which a client restores from the
+// JSON-RPC response of the server returning err.
+func rpcErrorRoundTrip(t *testing.T, err error) error {
+	bz, jsonErr := json.Marshal(rpctypes.RPCInternalError(rpctypes.JSONRPCIntID(1), err))
+	require.NoError(t, jsonErr)
+	var resp rpctypes.RPCResponse
+	require.NoError(t, json.Unmarshal(bz, &resp))
+	require.NotNil(t, resp.Error)
+	restored, ok := ctypes.ErrFromRPCErrorData(resp.Error.Code, resp.Error.StructuredData)
+	require.True(t, ok, resp.Error)
+	return restored
+}
+
+func TestBlockResultsMissing(t *testing.T) {
+	const height = 50
+	block := types.MakeBlock(height, 0, nil, []types.Tx{types.Tx("a=1"), types.Tx("b=2")}, nil, nil)
+	results := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Data: []byte{0x01}}, {Code: 1, Log: "not ok"}},
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}
+	resultsBz, err := results.Marshal()
+	require.NoError(t, err)
+
+	// the node was state synced at the height 80
+	stateStore := new(smmocks.Store)
+	stateStore.On("LoadABCIResponses", int64(height)).Return(nil, sm.ErrPruned{Height: height, Base: 80})
+	blockStore := resultsBlockStore{
+		mockBlockStore: mockBlockStore{height: 100},
+		block:          block,
+		nextMeta:       &types.BlockMeta{Header: types.Header{LastResultsHash: sm.ABCIResponsesResultsHash(results)}},
+	}
+	newEnv := func(recompute, supportsReplay bool) *proxymocks.AppConnQuery {
+		app := new(proxymocks.AppConnQuery)
+		app.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{SupportsReplay: supportsReplay}, nil)
+		app.On("QuerySync", mock.MatchedBy(func(req abci.RequestQuery) bool {
+			return req.Path == abci.QueryPathReplayBlock && req.Height == height
+		})).Return(&abci.ResponseQuery{Value: resultsBz}, nil)
+		env = &Environment{StateStore: stateStore, BlockStore: blockStore, ProxyAppQuery: app}
+		env.Config.RecomputeMissingResults = recompute
+		return app
+	}
+	h := int64(height)
+
+	// the results aren't recomputed, unless it's enabled and the app supports it
+	for _, tc := range []struct{ recompute, supportsReplay bool }{{false, true}, {true, false}} {
+		app := newEnv(tc.recompute, tc.supportsReplay)
+		_, err := BlockResults(&rpctypes.Context{}, &h)
+		var errUnavailable ctypes.ErrResultsUnavailable
+		require.True(t, errors.As(err, &errUnavailable), err)
+		assert.Equal(t, ctypes.ErrResultsUnavailable{Height: height, EarliestHeight: 80}, errUnavailable)
+		app.AssertNotCalled(t, "QuerySync", mock.Anything)
+
+		assert.Equal(t, errUnavailable, rpcErrorRoundTrip(t, err))
+	}
+
+	// the results are recomputed once, then cached
+	app := newEnv(true, true)
+	for i := 0; i < 2; i++ {
+		res, err := BlockResults(&rpctypes.Context{}, &h)
+		require.NoError(t, err)
+		assert.Equal(t, results.DeliverTxs, res.TxsResults)
+	}
+	app.AssertNumberOfCalls(t, "QuerySync", 1)
+
+	// the recomputed results must match the results hash of the next block
+	newEnv(true, true)
+	blockStore.nextMeta = &types.BlockMeta{Header: types.Header{LastResultsHash: tmhash.Sum([]byte("other"))}}
+	env.BlockStore = blockStore
+	_, err = BlockResults(&rpctypes.Context{}, &h)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the next block commits to")
+}
+
+func TestThresholdCommit(t *testing.T) {
+	const chainID = "test"
+	vals, privVals := types.GenerateValidatorSet(4)
+	state := sm.State{
+		ChainID:         chainID,
+		InitialHeight:   1,
+		LastBlockHeight: 99,
+		LastValidators:  vals,
+		Validators:      vals,
+		NextValidators:  vals,
+	}
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, env.StateStore.Bootstrap(state))
+	env.ConsensusReactor = &consensus.Reactor{}
+
+	makeCommit := func(height int64) *types.Commit {
+		blockID := types.BlockID{
+			Hash:          tmrand.Bytes(tmhash.Size),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+		}
+		stateID := types.StateID{LastAppHash: tmrand.Bytes(tmhash.Size)}
+		voteSet := types.NewVoteSet(chainID, height, 1, tmproto.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, stateID, height, 1, voteSet, privVals)
+		require.NoError(t, err)
+		return commit
+	}
+	store := commitBlockStore{
+		mockBlockStore: mockBlockStore{height: 100, base: 50},
+		commits:        map[int64]*types.Commit{99: makeCommit(99)},
+		seenCommit:     makeCommit(100),
+	}
+	env.BlockStore = store
+
+	testCases := []struct {
+		height    *int64
+		commit    *types.Commit
+		canonical bool
+	}{
+		{nil, store.seenCommit, false},
+		{int64Ptr(100), store.seenCommit, false},
+		{int64Ptr(99), store.commits[99], true},
+	}
+	for _, tc := range testCases {
+		res, err := ThresholdCommit(&rpctypes.Context{}, tc.height)
+		require.NoError(t, err)
+		assert.Equal(t, tc.commit.Height, res.Height)
+		assert.Equal(t, tc.commit.Round, res.Round)
+		assert.Equal(t, tc.commit.BlockID, res.BlockID)
+		assert.Equal(t, tc.commit.StateID, res.StateID)
+		assert.Equal(t, tc.canonical, res.CanonicalCommit)
+
+		// the signatures are verified with the quorum returned by /validator_quorum
+		quorum, err := ValidatorQuorum(&rpctypes.Context{}, &res.Height)
+		require.NoError(t, err)
+		assert.Equal(t, quorum.QuorumHash, res.QuorumHash)
+		commit := types.Commit{Height: res.Height, Round: res.Round, BlockID: res.BlockID, StateID: res.StateID}
+		blockSignID := commit.CanonicalVoteVerifySignId(chainID, quorum.QuorumType, quorum.QuorumHash)
+		assert.True(t, quorum.ThresholdPublicKey.VerifySignatureDigest(blockSignID, res.ThresholdBlockSignature))
+		stateSignID := commit.CanonicalVoteStateSignId(chainID, quorum.QuorumType, quorum.QuorumHash)
+		assert.True(t, quorum.ThresholdPublicKey.VerifySignatureDigest(stateSignID, res.ThresholdStateSignature))
+		assert.False(t, quorum.ThresholdPublicKey.VerifySignatureDigest(stateSignID, res.ThresholdBlockSignature))
+	}
+
+	// a missing commit
+	_, err := ThresholdCommit(&rpctypes.Context{}, int64Ptr(98))
+	assert.EqualError(t, err, "commit for height 98 not found")
+
+	// a pruned height
+	_, err = ThresholdCommit(&rpctypes.Context{}, int64Ptr(10))
+	var errPruned ctypes.ErrHeightPruned
+	require.True(t, errors.As(err, &errPruned), err)
+	assert.EqualValues(t, 50, errPruned.EarliestHeight)
+
+	_, err = ThresholdCommit(&rpctypes.Context{}, int64Ptr(101))
+	assert.Error(t, err)
+}
+
+func TestBlockSearch(t *testing.T) {
+	const numBlocks = 500
+	env = &Environment{}
+	env.BlockStore = searchBlockStore{mockBlockStore{height: numBlocks}}
+	blockIndexer := blockidxkv.New(dbm.NewMemDB())
+	env.BlockIndexer = blockIndexer
+
+	// every other block changes the quorum
+	for h := int64(1); h <= numBlocks; h++ {
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+				{Type: "quorum", Attributes: []abci.EventAttribute{
+					{Key: []byte("changed"), Value: []byte(fmt.Sprintf("%t", h%2 == 0)), Index: true},
+				}},
+			}},
+		}))
+	}
+
+	query := "quorum.changed = 'true'"
+	for _, orderBy := range []string{"asc", "desc"} {
+		perPage := 45
+		var heights []int64
+		for page := 1; ; page++ {
+			page := page
+			res, err := BlockSearch(&rpctypes.Context{}, query, &page, &perPage, orderBy)
+			require.NoError(t, err)
+			assert.Equal(t, numBlocks/2, res.TotalCount)
+			for _, block := range res.Blocks {
+				heights = append(heights, block.Block.Height)
+			}
+			if len(res.Blocks) < perPage {
+				break
+			}
+		}
+
+		require.Len(t, heights, numBlocks/2)
+		for i, h := range heights {
+			if orderBy == "desc" {
+				assert.EqualValues(t, numBlocks-2*i, h)
+			} else {
+				assert.EqualValues(t, 2*(i+1), h)
+			}
+		}
+	}
+
+	// per_page is limited by the configured maximum
+	env.Config.MaxSearchResultsPerPage = 20
+	perPage := 50
+	res, err := BlockSearch(&rpctypes.Context{}, query, nil, &perPage, "")
+	require.NoError(t, err)
+	assert.Len(t, res.Blocks, 20)
+	assert.Equal(t, numBlocks/2, res.TotalCount)
+	assert.EqualValues(t, numBlocks, res.Blocks[0].Block.Height)
+
+	page := 14
+	_, err = BlockSearch(&rpctypes.Context{}, query, &page, &perPage, "")
+	assert.Error(t, err)
+}
+
+// searchBlockStore is a mockBlockStore with blocks
+type searchBlockStore struct {
+	mockBlockStore
+}
+
+func (store searchBlockStore) LoadBlock(height int64) *types.Block {
+	return &types.Block{Header: types.Header{Height: height}}
+}
+
+func (store searchBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return &types.BlockMeta{Header: types.Header{Height: height}}
+}
+
+// commitBlockStore is a mockBlockStore with commits
+type commitBlockStore struct {
+	mockBlockStore
+	commits    map[int64]*types.Commit
+	seenCommit *types.Commit
+}
+
+func (store commitBlockStore) LoadBlockCommit(height int64) *types.Commit {
+	return store.commits[height]
+}
+
+func (store commitBlockStore) LoadSeenCommit(height int64) *types.Commit {
+	if height == store.height {
+		return store.seenCommit
+	}
+	return nil
+}
+
+// resultsBlockStore has the block, whose results are missing, and the meta of
+// the next block.
+type resultsBlockStore struct {
+	mockBlockStore
+	block    *types.Block
+	nextMeta *types.BlockMeta
+}
+
+func (store resultsBlockStore) LoadBlock(height int64) *types.Block {
+	if height == store.block.Height {
+		return store.block
+	}
+	return nil
+}
+
+func (store resultsBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	if height == store.block.Height+1 {
+		return store.nextMeta
+	}
+	return nil
+}
+
 type mockBlockStore struct {
 	height                int64
+	base                  int64
 	coreChainLockedHeight uint32
 }
 
-func (mockBlockStore) Base() int64                                       { return 1 }
+func (store mockBlockStore) Base() int64 {
+	if store.base > 0 {
+		return store.base
+	}
+	return 1
+}
 func (store mockBlockStore) Height() int64                               { return store.height }
 func (store mockBlockStore) CoreChainLockedHeight() uint32               { return store.coreChainLockedHeight }
 func (store mockBlockStore) Size() int64                                 { return store.height }
@@ -132,5 +435,6 @@ func (mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { retur
 func (mockBlockStore) LoadBlockCommit(height int64) *types.Commit        { return nil }
 func (mockBlockStore) LoadSeenCommit(height int64) *types.Commit         { return nil }
 func (mockBlockStore) PruneBlocks(height int64) (uint64, error)          { return 0, nil }
+func (mockBlockStore) RollbackBlocks(height int64) (uint64, error)       { return 0, nil }
 func (mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/dashevo/dashd-go/btcjson"
 	cm "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
@@ -61,6 +63,93 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 		Total:              totalCount}, nil
 }
 
+// ValidatorQuorum gets the LLMQ quorum backing consensus at the given block height:
+// its hash, type and threshold public key, and the proTxHashes and public keys of
+// its members. The quorum is loaded from the state store of the node, not from
+// Dash Core.
+//
+// If no height is provided, it will fetch the quorum of the latest validator set.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_quorum
+func ValidatorQuorum(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultValidatorQuorum, error) {
+	height, err := getHeight(latestUncommittedHeight(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]ctypes.QuorumMember, len(validators.Validators))
+	for i, val := range validators.Validators {
+		members[i] = ctypes.QuorumMember{
+			ProTxHash: val.ProTxHash,
+			PubKey:    val.PubKey,
+		}
+	}
+
+	return &ctypes.ResultValidatorQuorum{
+		BlockHeight:        height,
+		QuorumType:         validators.QuorumType,
+		QuorumHash:         validators.QuorumHash,
+		ThresholdPublicKey: validators.ThresholdPublicKey,
+		Members:            members,
+	}, nil
+}
+
+// Proposer gets the proposer selected for a round of the given block height,
+// and the proposer priorities of the validators in the round. The proposer is
+// selected from the validator set in the state store of the node, the same
+// way consensus selects it.
+//
+// If no height is provided, it will use the height of the next block. The
+// validator set of the height after it is known in advance too. If no round
+// is provided, it will use round 0.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/proposer
+func Proposer(ctx *rpctypes.Context, heightPtr *int64, roundPtr *int) (*ctypes.ResultProposer, error) {
+	height := latestUncommittedHeight()
+	if heightPtr != nil {
+		var err error
+		// the next validator set of the latest block is stored
+		height, err = getHeight(height+1, heightPtr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	round := 0
+	if roundPtr != nil {
+		round = *roundPtr
+	}
+	if round < 0 || round > maxProposerRound {
+		return nil, fmt.Errorf("round must be between 0 and %d, but got %d", maxProposerRound, round)
+	}
+
+	validators, err := env.StateStore.LoadValidatorsAtRound(height, int32(round))
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := make([]ctypes.ProposerPriority, len(validators.Validators))
+	for i, val := range validators.Validators {
+		priorities[i] = ctypes.ProposerPriority{
+			ProTxHash:        val.ProTxHash,
+			VotingPower:      val.VotingPower,
+			ProposerPriority: val.ProposerPriority,
+		}
+	}
+
+	return &ctypes.ResultProposer{
+		BlockHeight: height,
+		Round:       int32(round),
+		ProTxHash:   validators.GetProposer().ProTxHash,
+		Validators:  priorities,
+	}, nil
+}
+
 // DumpConsensusState dumps consensus state.
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/dump_consensus_state
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/consensus"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestValidatorQuorum(t *testing.T) {
+	vals, _ := types.GenerateValidatorSet(4)
+	state := sm.State{
+		ChainID:         "test",
+		InitialHeight:   1,
+		LastBlockHeight: 99,
+		LastValidators:  vals,
+		Validators:      vals,
+		NextValidators:  vals,
+	}
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, env.StateStore.Bootstrap(state))
+	env.BlockStore = mockBlockStore{height: 100, base: 50}
+	env.ConsensusReactor = &consensus.Reactor{}
+
+	// the latest validator set is the next validator set of the last block
+	for _, heightPtr := range []*int64{nil, int64Ptr(100), int64Ptr(101)} {
+		res, err := ValidatorQuorum(&rpctypes.Context{}, heightPtr)
+		require.NoError(t, err)
+		if heightPtr == nil {
+			assert.EqualValues(t, 101, res.BlockHeight)
+		} else {
+			assert.Equal(t, *heightPtr, res.BlockHeight)
+		}
+		assert.Equal(t, vals.QuorumType, res.QuorumType)
+		assert.Equal(t, vals.QuorumHash, res.QuorumHash)
+		assert.Equal(t, vals.ThresholdPublicKey, res.ThresholdPublicKey)
+		require.Len(t, res.Members, len(vals.Validators))
+		for i, val := range vals.Validators {
+			assert.Equal(t, val.ProTxHash, res.Members[i].ProTxHash)
+			assert.Equal(t, val.PubKey, res.Members[i].PubKey)
+		}
+	}
+
+	_, err := ValidatorQuorum(&rpctypes.Context{}, int64Ptr(102))
+	assert.Error(t, err)
+
+	_, err = ValidatorQuorum(&rpctypes.Context{}, int64Ptr(10))
+	var errPruned ctypes.ErrHeightPruned
+	require.True(t, errors.As(err, &errPruned), err)
+	assert.EqualValues(t, 10, errPruned.Height)
+	assert.EqualValues(t, 50, errPruned.EarliestHeight)
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestProposer(t *testing.T) {
+	vals, _ := types.GenerateValidatorSet(4)
+	state := sm.State{
+		ChainID:         "test",
+		InitialHeight:   1,
+		LastBlockHeight: 99,
+		LastValidators:  vals,
+		Validators:      vals,
+		NextValidators:  vals.CopyIncrementProposerPriority(1),
+	}
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, env.StateStore.Bootstrap(state))
+	env.BlockStore = mockBlockStore{height: 99, base: 50}
+	env.ConsensusReactor = &consensus.Reactor{}
+
+	testCases := []struct {
+		heightPtr      *int64
+		roundPtr       *int
+		expectedHeight int64
+		expectedVals   *types.ValidatorSet
+	}{
+		// the next block
+		{nil, nil, 100, state.Validators},
+		{int64Ptr(100), intPtr(0), 100, state.Validators},
+		{int64Ptr(100), intPtr(3), 100, state.Validators.CopyIncrementProposerPriority(3)},
+		// the block after the next one
+		{int64Ptr(101), nil, 101, state.NextValidators},
+		{int64Ptr(101), intPtr(1), 101, state.NextValidators.CopyIncrementProposerPriority(1)},
+	}
+	for i, tc := range testCases {
+		res, err := Proposer(&rpctypes.Context{}, tc.heightPtr, tc.roundPtr)
+		require.NoError(t, err, "#%d", i)
+		assert.Equal(t, tc.expectedHeight, res.BlockHeight, "#%d", i)
+		if tc.roundPtr != nil {
+			assert.EqualValues(t, *tc.roundPtr, res.Round, "#%d", i)
+		}
+		assert.Equal(t, tc.expectedVals.GetProposer().ProTxHash, res.ProTxHash, "#%d", i)
+		require.Len(t, res.Validators, len(tc.expectedVals.Validators), "#%d", i)
+		for j, val := range tc.expectedVals.Validators {
+			assert.Equal(t, val.ProTxHash, res.Validators[j].ProTxHash, "#%d", i)
+			assert.Equal(t, val.VotingPower, res.Validators[j].VotingPower, "#%d", i)
+			assert.Equal(t, val.ProposerPriority, res.Validators[j].ProposerPriority, "#%d", i)
+		}
+	}
+
+	// the validator set isn't known yet
+	_, err := Proposer(&rpctypes.Context{}, int64Ptr(102), nil)
+	assert.Error(t, err)
+
+	for _, round := range []int{-1, maxProposerRound + 1} {
+		_, err = Proposer(&rpctypes.Context{}, nil, intPtr(round))
+		assert.Error(t, err)
+	}
+
+	_, err = Proposer(&rpctypes.Context{}, int64Ptr(10), nil)
+	var errPruned ctypes.ErrHeightPruned
+	require.True(t, errors.As(err, &errPruned), err)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -1,6 +1,11 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -10,3 +15,90 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeFlushMempoolCache removes the transactions, which aren't in the
+// mempool, from the cache of the seen transactions, so they can be submitted
+// again. The mempool stays as is.
+func UnsafeFlushMempoolCache(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempoolCache, error) {
+	env.Logger.Info("FlushMempoolCache", "size", env.Mempool.Size())
+	env.Mempool.FlushCache()
+	return &ctypes.ResultUnsafeFlushMempoolCache{}, nil
+}
+
+// UnsafeForceRoundSkip makes the node give up its current consensus round and
+// move to the next one, as if the round timed out. Only this node skips the
+// round; the others move on when they time out or see +2/3 of the next round.
+func UnsafeForceRoundSkip(ctx *rpctypes.Context) (*ctypes.ResultForceRoundSkip, error) {
+	height, round, err := env.ConsensusState.ForceRoundSkip()
+	env.Logger.Info("ForceRoundSkip", "height", height, "round", round, "err", err)
+	if err != nil {
+		return &ctypes.ResultForceRoundSkip{}, err
+	}
+	return &ctypes.ResultForceRoundSkip{Height: height, Round: round}, nil
+}
+
+// UnsafeDropPeerState forgets the consensus round state of the peer, which is
+// rebuilt from the messages of the peer. The peer stays connected.
+func UnsafeDropPeerState(ctx *rpctypes.Context, peerID string) (*ctypes.ResultDropPeerState, error) {
+	env.Logger.Info("DropPeerState", "peer", peerID)
+	if err := env.ConsensusReactor.DropPeerState(p2p.ID(peerID)); err != nil {
+		return &ctypes.ResultDropPeerState{}, err
+	}
+	return &ctypes.ResultDropPeerState{Log: fmt.Sprintf("State of peer %s is dropped", peerID)}, nil
+}
+
+// UnsafeMempoolSnapshot returns the metadata of up to limit txs in the
+// mempool (all of them if limit isn't positive), in the order they are reaped.
+// The txs themselves are only returned if includeTx is true.
+func UnsafeMempoolSnapshot(
+	ctx *rpctypes.Context,
+	limitPtr *int,
+	includeTx bool,
+) (*ctypes.ResultMempoolSnapshot, error) {
+	limit := -1
+	if limitPtr != nil && *limitPtr > 0 {
+		limit = *limitPtr
+	}
+
+	snapshot := env.Mempool.Snapshot(limit)
+	now := time.Now()
+	txs := make([]ctypes.MempoolTxStats, len(snapshot))
+	for i := range snapshot {
+		memTx := &snapshot[i]
+		txs[i] = ctypes.MempoolTxStats{
+			Hash:           memTx.Key[:],
+			Size:           memTx.Size,
+			Height:         memTx.Height,
+			Time:           memTx.Time,
+			Age:            now.Sub(memTx.Time),
+			GasWanted:      memTx.GasWanted,
+			Priority:       memTx.Priority,
+			Sender:         memTx.Sender,
+			RecheckPending: memTx.RecheckPending,
+		}
+		if includeTx {
+			txs[i].Tx = memTx.Tx
+		}
+	}
+
+	return &ctypes.ResultMempoolSnapshot{
+		Count:      len(txs),
+		Total:      env.Mempool.Size(),
+		TotalBytes: env.Mempool.TxsBytes(),
+		Txs:        txs,
+	}, nil
+}
+
+// UnsafeReloadConfig reloads the config file, like SIGHUP does, applying the
+// changed keys, which can be changed while the node is running, and listing
+// the changed keys, which require a restart.
+func UnsafeReloadConfig(ctx *rpctypes.Context) (*ctypes.ResultReloadConfig, error) {
+	if env.ConfigReloader == nil {
+		return nil, errors.New("reloading the config is not supported")
+	}
+	applied, requiresRestart, err := env.ConfigReloader.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultReloadConfig{Applied: applied, RequiresRestart: requiresRestart}, nil
+}
@@ -5,12 +5,14 @@ import (
 	"time"
 
 	cfg "github.com/tendermint/tendermint/config"
-	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/log"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
@@ -23,6 +25,13 @@ const (
 	maxPerPage                       = 100
 	defaultRequestThresholdPublicKey = true
 
+	// the proposer priorities are incremented once for every round, so the
+	// rounds of the proposer endpoint are limited
+	maxProposerRound = 1000
+
+	// the number of the recomputed block results, which are cached
+	recomputedResultsCacheSize = 100
+
 	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
 	// must be less than the server's write timeout (see rpcserver.DefaultConfig)
 	SubscribeTimeout = 5 * time.Second
@@ -48,6 +57,7 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	ForceRoundSkip() (height int64, round int32, err error)
 }
 
 type transport interface {
@@ -62,6 +72,22 @@ type peers interface {
 	AddPrivatePeerIDs([]string) error
 	DialPeersAsync([]string) error
 	Peers() p2p.IPeerSet
+	BanPeer(id p2p.ID, reason string, duration time.Duration) error
+	UnbanPeer(id p2p.ID) error
+	BannedPeers() []p2p.BannedPeer
+}
+
+type consensusReactor interface {
+	WaitSync() bool
+	DropPeerState(id p2p.ID) error
+}
+
+type chainLockWatcher interface {
+	ChainLockedHeight() (uint32, time.Time)
+}
+
+type configReloader interface {
+	ReloadConfig() (applied, requiresRestart []string, err error)
 }
 
 //----------------------------------------------
@@ -83,15 +109,38 @@ type Environment struct {
 	// objects
 	ProTxHash        crypto.ProTxHash
 	GenDoc           *types.GenesisDoc // cache the genesis structure
+	GenesisChunks    *GenesisChunks    // the genesis doc JSON served by /genesis_chunked
 	TxIndexer        txindex.TxIndexer
 	BlockIndexer     indexer.BlockIndexer
-	ConsensusReactor *consensus.Reactor
+	ConsensusReactor consensusReactor
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
+	ConfigReloader   configReloader
+	// nil if the node doesn't sign with Dash Core
+	ChainLockWatcher chainLockWatcher
 
 	Logger log.Logger
 
 	Config cfg.RPCConfig
+
+	// protects Config.TimeoutBroadcastTxCommit, which can be reloaded
+	mtx tmsync.RWMutex
+
+	// the block results recomputed by /block_results
+	recomputedResults resultsCache
+}
+
+// SetTimeoutBroadcastTxCommit changes the max timeout of broadcast_tx_commit.
+func (e *Environment) SetTimeoutBroadcastTxCommit(timeout time.Duration) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.Config.TimeoutBroadcastTxCommit = timeout
+}
+
+func (e *Environment) timeoutBroadcastTxCommit() time.Duration {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	return e.Config.TimeoutBroadcastTxCommit
 }
 
 //----------------------------------------------
@@ -118,15 +167,27 @@ func validatePage(pagePtr *int, perPage, totalCount int) (int, error) {
 }
 
 func validatePerPage(perPagePtr *int) int {
-	if perPagePtr == nil { // no per_page parameter
-		return defaultPerPage
+	return validatePerPageWithMax(perPagePtr, maxPerPage)
+}
+
+// validateSearchPerPage validates per_page of the search endpoints, whose
+// maximum is configured by max_search_results_per_page.
+func validateSearchPerPage(perPagePtr *int) int {
+	max := env.Config.MaxSearchResultsPerPage
+	if max <= 0 {
+		max = maxPerPage
+	}
+	return validatePerPageWithMax(perPagePtr, max)
+}
+
+func validatePerPageWithMax(perPagePtr *int, max int) int {
+	if perPagePtr == nil || *perPagePtr < 1 { // no per_page parameter
+		return tmmath.MinInt(defaultPerPage, max)
 	}
 
 	perPage := *perPagePtr
-	if perPage < 1 {
-		return defaultPerPage
-	} else if perPage > maxPerPage {
-		return maxPerPage
+	if perPage > max {
+		return max
 	}
 	return perPage
 }
@@ -148,6 +209,26 @@ func validateSkipCount(page, perPage int) int {
 	return skipCount
 }
 
+// validateCommitTimeout parses the broadcast_tx_commit timeout, which can't
+// exceed the configured one. Empty timeout means the configured one.
+func validateCommitTimeout(timeout string) (time.Duration, error) {
+	max := env.timeoutBroadcastTxCommit()
+	if timeout == "" {
+		return max, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout must be greater than 0, but got %v", d)
+	}
+	if d > max {
+		return 0, fmt.Errorf("timeout %v must be less than or equal to timeout_broadcast_tx_commit %v", d, max)
+	}
+	return d, nil
+}
+
 // latestHeight can be either latest committed or uncommitted (+1) height.
 func getHeight(latestHeight int64, heightPtr *int64) (int64, error) {
 	if heightPtr != nil {
@@ -161,8 +242,11 @@ func getHeight(latestHeight int64, heightPtr *int64) (int64, error) {
 		}
 		base := env.BlockStore.Base()
 		if height < base {
-			return 0, fmt.Errorf("height %d is not available, lowest height is %d",
-				height, base)
+			return 0, ctypes.ErrHeightPruned{
+				Height:           height,
+				EarliestHeight:   base,
+				ArchiveEndpoints: env.Config.ArchiveEndpoints,
+			}
 		}
 		return height, nil
 	}
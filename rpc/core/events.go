@@ -2,23 +2,31 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
-)
-
-const (
-	// Buffer on the Tendermint (server) side to allow some slowness in clients.
-	subBufferSize = 100
+	"github.com/tendermint/tendermint/types"
 )
 
 // Subscribe for events via WebSocket.
+// If sinceHeight is positive, the matching events of committed blocks starting
+// from sinceHeight are replayed before the live events. Only NewBlock,
+// NewBlockHeader, NewEvidence and Tx events can be replayed.
+// slowConsumerPolicy overrides rpc.subscription_slow_consumer_policy of the
+// config for the subscription.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func Subscribe(
+	ctx *rpctypes.Context,
+	query string,
+	sinceHeight int64,
+	slowConsumerPolicy string,
+) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -26,8 +34,23 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
 		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
 	}
+	if sinceHeight < 0 {
+		return nil, fmt.Errorf("since_height must be non-negative, but got %d", sinceHeight)
+	}
+	if sinceHeight > 0 && env.Config.MaxSubscriptionReplayHeights == 0 {
+		return nil, errors.New("subscription replay is disabled")
+	}
 
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
+	if slowConsumerPolicy == "" {
+		slowConsumerPolicy = env.Config.SubscriptionSlowConsumerPolicy
+	}
+	policy, err := tmpubsub.ParseSlowConsumerPolicy(slowConsumerPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Logger.Info("Subscribe to query", "remote", addr, "query", query, "since_height", sinceHeight,
+		"slow_consumer_policy", policy)
 
 	q, err := tmquery.New(query)
 	if err != nil {
@@ -37,27 +60,53 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	sub, err := env.EventBus.SubscribeWithPolicy(subCtx, addr, q, env.Config.SubscriptionBufferSize, policy)
 	if err != nil {
 		return nil, err
 	}
 
+	// The live subscription is made before reading the latest height, so every
+	// block above replayTo is delivered by the subscription and the replay
+	// covers the rest without gaps.
+	var replayTo int64
+	if sinceHeight > 0 {
+		replayTo, err = replayRange(sinceHeight)
+		if err != nil {
+			if err := env.EventBus.Unsubscribe(context.Background(), addr, q); err != nil {
+				env.Logger.Error("Failed to unsubscribe", "remote", addr, "query", query, "err", err)
+			}
+			return nil, err
+		}
+	}
+
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
+	writeEvent := func(resultEvent *ctypes.ResultEvent) {
+		resp := rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+			env.Logger.Info("Can't write response (slow client)",
+				"to", addr, "subscriptionID", subscriptionID, "err", err)
+		}
+	}
 	go func() {
+		if sinceHeight > 0 {
+			replayTo = replayEvents(q, query, sinceHeight, replayTo, writeEvent)
+		}
 		for {
 			select {
 			case msg := <-sub.Out():
-				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
-				)
-				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
-					env.Logger.Info("Can't write response (slow client)",
-						"to", addr, "subscriptionID", subscriptionID, "err", err)
+				if dropped, ok := msg.Data().(tmpubsub.EventsDropped); ok {
+					writeEvent(&ctypes.ResultEvent{Query: query,
+						Data: types.EventDataEventsDropped{Count: dropped.Count}})
+					continue
+				}
+				// skip the events of heights which were replayed or not requested
+				if h := eventHeight(msg.Data()); h > 0 && (h < sinceHeight || h <= replayTo) {
+					continue
 				}
+				writeEvent(&ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()})
 			case <-sub.Cancelled():
 				if sub.Err() != tmpubsub.ErrUnsubscribed {
 					var reason string
@@ -83,6 +132,127 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+// replayRange validates sinceHeight against the replay window and returns
+// the latest height to replay.
+func replayRange(sinceHeight int64) (int64, error) {
+	latest := env.BlockStore.Height()
+	if sinceHeight > latest {
+		// nothing to replay, all the requested heights are delivered live
+		return latest, nil
+	}
+	if base := env.BlockStore.Base(); sinceHeight < base {
+		return 0, ctypes.ErrHeightPruned{
+			Height:           sinceHeight,
+			EarliestHeight:   base,
+			ArchiveEndpoints: env.Config.ArchiveEndpoints,
+		}
+	}
+	if window := env.Config.MaxSubscriptionReplayHeights; latest-sinceHeight+1 > window {
+		return 0, fmt.Errorf("since_height %d is out of the replay window, the earliest height to replay is %d",
+			sinceHeight, latest-window+1)
+	}
+	return latest, nil
+}
+
+// replayEvents writes the events of heights from..to matching the query.
+// The events of a height are rebuilt from the block store and the saved ABCI
+// responses in the order they are published by the block executor.
+// It returns the last replayed height, which is lower than to if the ABCI
+// responses of the latest block have not been saved yet. In this case the
+// events of the block are published to the live subscription later.
+func replayEvents(q *tmquery.Query, query string, from, to int64,
+	writeEvent func(*ctypes.ResultEvent)) int64 {
+	for height := from; height <= to; height++ {
+		block := env.BlockStore.LoadBlock(height)
+		if block == nil {
+			return height - 1
+		}
+		abciResponses, err := env.StateStore.LoadABCIResponses(height)
+		if err != nil {
+			return height - 1
+		}
+		resultEvents := append(abciResponses.BeginBlock.Events, abciResponses.EndBlock.Events...)
+
+		var events []ctypes.ResultEvent
+		events = append(events, newReplayEvent(types.EventDataNewBlock{
+			Block:            block,
+			ResultBeginBlock: *abciResponses.BeginBlock,
+			ResultEndBlock:   *abciResponses.EndBlock,
+		}, types.EventNewBlock, resultEvents))
+		events = append(events, newReplayEvent(types.EventDataNewBlockHeader{
+			Header:           block.Header,
+			NumTxs:           int64(len(block.Txs)),
+			ResultBeginBlock: *abciResponses.BeginBlock,
+			ResultEndBlock:   *abciResponses.EndBlock,
+		}, types.EventNewBlockHeader, resultEvents))
+		for _, ev := range block.Evidence.Evidence {
+			events = append(events, newReplayEvent(types.EventDataNewEvidence{
+				Evidence: ev,
+				Height:   height,
+			}, types.EventNewEvidence, nil))
+		}
+		for i, tx := range block.Txs {
+			event := newReplayEvent(types.EventDataTx{TxResult: abci.TxResult{
+				Height: height,
+				Index:  uint32(i),
+				Tx:     tx,
+				Result: *abciResponses.DeliverTxs[i],
+			}}, types.EventTx, abciResponses.DeliverTxs[i].Events)
+			event.Events[types.TxHashKey] = append(event.Events[types.TxHashKey], fmt.Sprintf("%X", tx.Hash()))
+			event.Events[types.TxHeightKey] = append(event.Events[types.TxHeightKey], fmt.Sprintf("%d", height))
+			events = append(events, event)
+		}
+
+		for i := range events {
+			match, err := q.Matches(events[i].Events)
+			if err != nil {
+				env.Logger.Error("Failed to match replayed event", "height", height, "query", query, "err", err)
+				continue
+			}
+			if match {
+				events[i].Query = query
+				writeEvent(&events[i])
+			}
+		}
+	}
+	return to
+}
+
+// newReplayEvent builds a result event with the same composite keys the event
+// bus adds on publishing.
+func newReplayEvent(data types.TMEventData, eventType string, abciEvents []abci.Event) ctypes.ResultEvent {
+	events := make(map[string][]string)
+	for _, event := range abciEvents {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+			events[compositeTag] = append(events[compositeTag], string(attr.Value))
+		}
+	}
+	events[types.EventTypeKey] = append(events[types.EventTypeKey], eventType)
+	return ctypes.ResultEvent{Data: data, Events: events}
+}
+
+// eventHeight returns the height of a block event or 0 for other events.
+func eventHeight(data types.TMEventData) int64 {
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		return data.Block.Height
+	case types.EventDataNewBlockHeader:
+		return data.Header.Height
+	case types.EventDataNewEvidence:
+		return data.Height
+	case types.EventDataTx:
+		return data.Height
+	}
+	return 0
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
 func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
@@ -110,3 +280,44 @@ func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
 	}
 	return &ctypes.ResultUnsubscribe{}, nil
 }
+
+// SubscribeTxResults subscribes subscriber to the results of the txs matching
+// query. Up to bufferSize results are buffered, the subscription is cancelled
+// with tmpubsub.ErrOutOfCapacity if the subscriber can't keep up. It is used by
+// the gRPC API, which streams the results itself.
+// The returned function unsubscribes.
+func SubscribeTxResults(
+	ctx context.Context,
+	subscriber, query string,
+	bufferSize int,
+) (types.Subscription, func(), error) {
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return nil, nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	}
+
+	txQuery := types.EventQueryTx.String()
+	if query != "" {
+		txQuery = fmt.Sprintf("%s AND %s", txQuery, query)
+	}
+	q, err := tmquery.New(txQuery)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	env.Logger.Info("Subscribe to tx results", "subscriber", subscriber, "query", query)
+
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
+	defer cancel()
+
+	sub, err := env.EventBus.Subscribe(subCtx, subscriber, q, bufferSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	unsubscribe := func() {
+		err := env.EventBus.Unsubscribe(context.Background(), subscriber, q)
+		if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
+			env.Logger.Error("Failed to unsubscribe", "subscriber", subscriber, "query", query, "err", err)
+		}
+	}
+	return sub, unsubscribe, nil
+}
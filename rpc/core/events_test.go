@@ -0,0 +1,326 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSubscribeReplay(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		sinceHeight int64
+		// heights of blocks committed while the events are replayed
+		commitDuringReplay []int64
+		wantHeights        []int64
+		wantErr            bool
+	}{
+		{
+			name:        "no replay",
+			query:       "tm.event = 'NewBlock'",
+			wantHeights: []int64{6, 7},
+		},
+		{
+			name:        "replay new blocks",
+			query:       "tm.event = 'NewBlock'",
+			sinceHeight: 3,
+			wantHeights: []int64{3, 4, 5, 6, 7},
+		},
+		{
+			name:        "replay txs",
+			query:       "tm.event = 'Tx' AND app.key = 'k'",
+			sinceHeight: 4,
+			wantHeights: []int64{4, 5, 6, 7},
+		},
+		{
+			name:               "blocks committed during replay",
+			query:              "tm.event = 'NewBlock'",
+			sinceHeight:        1,
+			commitDuringReplay: []int64{6, 7},
+			wantHeights:        []int64{1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			name:        "since height above the latest height",
+			query:       "tm.event = 'NewBlock'",
+			sinceHeight: 7,
+			wantHeights: []int64{7},
+		},
+		{
+			name:        "since height out of the replay window",
+			query:       "tm.event = 'NewBlock'",
+			sinceHeight: 1,
+			wantErr:     true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			store := newReplayBlockStore()
+			env = &Environment{
+				StateStore: sm.NewStore(dbm.NewMemDB()),
+				BlockStore: store,
+				EventBus:   types.NewEventBus(),
+				Logger:     log.TestingLogger(),
+				Config:     *cfg.TestRPCConfig(),
+			}
+			if tc.wantErr {
+				env.Config.MaxSubscriptionReplayHeights = 2
+			}
+			require.NoError(t, env.EventBus.Start())
+			defer env.EventBus.Stop() //nolint:errcheck // ignore for tests
+
+			// the last block is saved, but its ABCI responses are not yet,
+			// so its events should be delivered live
+			for h := int64(1); h <= 5; h++ {
+				commitBlock(t, store, h, true, false)
+			}
+			commitBlock(t, store, 6, false, false)
+
+			conn := newReplayWSConn(func() {
+				for _, h := range tc.commitDuringReplay {
+					commitBlock(t, store, h, true, true)
+				}
+			})
+			ctx := &rpctypes.Context{JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCIntID(1)}, WSConn: conn}
+			_, err := Subscribe(ctx, tc.query, tc.sinceHeight, "")
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, 0, env.EventBus.NumClients())
+				return
+			}
+			require.NoError(t, err)
+
+			for h := int64(6); h <= 7; h++ {
+				commitBlock(t, store, h, true, true)
+			}
+			assert.Eventually(t, func() bool {
+				return len(conn.heights()) >= len(tc.wantHeights)
+			}, time.Second, 10*time.Millisecond)
+			// wait a bit more to catch duplicates
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, tc.wantHeights, conn.heights())
+		})
+	}
+}
+
+func TestSubscribeSlowConsumerPolicy(t *testing.T) {
+	env = &Environment{
+		StateStore: sm.NewStore(dbm.NewMemDB()),
+		BlockStore: newReplayBlockStore(),
+		EventBus:   types.NewEventBus(),
+		Logger:     log.TestingLogger(),
+		Config:     *cfg.TestRPCConfig(),
+	}
+	env.Config.SubscriptionBufferSize = 2
+	require.NoError(t, env.EventBus.Start())
+	defer env.EventBus.Stop() //nolint:errcheck // ignore for tests
+
+	conn := newStalledWSConn()
+	ctx := &rpctypes.Context{JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCIntID(1)}, WSConn: conn}
+	_, err := Subscribe(ctx, "tm.event = 'NewBlock'", 0, "drop_all")
+	require.Error(t, err)
+	_, err = Subscribe(ctx, "tm.event = 'NewBlock'", 0, "drop_newest")
+	require.NoError(t, err)
+
+	// the events are published without waiting for the stalled client
+	const published = 10
+	for h := int64(1); h <= published; h++ {
+		require.NoError(t, env.EventBus.PublishEventNewBlock(types.EventDataNewBlock{
+			Block: types.MakeBlock(h, 0, nil, nil, nil, nil),
+		}))
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(conn.release)
+
+	var (
+		lastHeight int64
+		dropped    int
+	)
+	assert.Eventually(t, func() bool {
+		lastHeight, dropped = 0, 0
+		for _, data := range conn.events() {
+			switch data := data.(type) {
+			case types.EventDataEventsDropped:
+				dropped += data.Count
+			case types.EventDataNewBlock:
+				assert.Equal(t, lastHeight+int64(dropped)+1, data.Block.Height)
+				lastHeight += int64(dropped) + 1
+				dropped = 0
+			}
+		}
+		return lastHeight+int64(dropped) == published
+	}, time.Second, 10*time.Millisecond)
+	assert.Positive(t, dropped)
+}
+
+// commitBlock saves a block with a single tx into the store, saves its ABCI
+// responses and publishes its events, if it is not done yet
+func commitBlock(t *testing.T, store *replayBlockStore, height int64, saveResponses, publish bool) {
+	block := types.MakeBlock(height, 0, nil, []types.Tx{types.Tx("k=v")}, nil, nil)
+	abciResponses := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Events: []abci.Event{{
+			Type:       "app",
+			Attributes: []abci.EventAttribute{{Key: []byte("key"), Value: []byte("k")}},
+		}}}},
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}
+	store.save(block)
+	if saveResponses {
+		if _, err := env.StateStore.LoadABCIResponses(height); err != nil {
+			require.NoError(t, env.StateStore.SaveABCIResponses(height, abciResponses))
+		}
+	}
+	if publish && store.publish(height) {
+		require.NoError(t, env.EventBus.PublishEventNewBlock(types.EventDataNewBlock{
+			Block:            block,
+			ResultBeginBlock: *abciResponses.BeginBlock,
+			ResultEndBlock:   *abciResponses.EndBlock,
+		}))
+		require.NoError(t, env.EventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: height,
+			Tx:     block.Txs[0],
+			Result: *abciResponses.DeliverTxs[0],
+		}}))
+	}
+}
+
+type replayBlockStore struct {
+	mockBlockStore
+
+	mtx       sync.Mutex
+	blocks    map[int64]*types.Block
+	published map[int64]bool
+}
+
+func newReplayBlockStore() *replayBlockStore {
+	return &replayBlockStore{
+		blocks:    make(map[int64]*types.Block),
+		published: make(map[int64]bool),
+	}
+}
+
+func (store *replayBlockStore) save(block *types.Block) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	store.blocks[block.Height] = block
+}
+
+func (store *replayBlockStore) publish(height int64) bool {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if store.published[height] {
+		return false
+	}
+	store.published[height] = true
+	return true
+}
+
+func (store *replayBlockStore) Height() int64 {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	return int64(len(store.blocks))
+}
+
+func (store *replayBlockStore) LoadBlock(height int64) *types.Block {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	return store.blocks[height]
+}
+
+// replayWSConn collects the heights of the written events, onFirstWrite is
+// called once before the first event is written
+type replayWSConn struct {
+	mtx          sync.Mutex
+	onFirstWrite func()
+	written      []int64
+}
+
+func newReplayWSConn(onFirstWrite func()) *replayWSConn {
+	return &replayWSConn{onFirstWrite: onFirstWrite}
+}
+
+func (conn *replayWSConn) GetRemoteAddr() string { return "127.0.0.1:12345" }
+
+func (conn *replayWSConn) WriteRPCResponse(_ context.Context, resp rpctypes.RPCResponse) error {
+	conn.mtx.Lock()
+	onFirstWrite := conn.onFirstWrite
+	conn.onFirstWrite = nil
+	conn.mtx.Unlock()
+	if onFirstWrite != nil {
+		onFirstWrite()
+	}
+
+	result := ctypes.ResultEvent{}
+	if err := tmjson.Unmarshal(resp.Result, &result); err != nil {
+		return err
+	}
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+	conn.written = append(conn.written, eventHeight(result.Data))
+	return nil
+}
+
+func (conn *replayWSConn) TryWriteRPCResponse(resp rpctypes.RPCResponse) bool { return true }
+
+func (conn *replayWSConn) Context() context.Context { return context.Background() }
+
+func (conn *replayWSConn) heights() []int64 {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+	return append([]int64(nil), conn.written...)
+}
+
+// stalledWSConn collects the data of the written events, the writes are
+// blocked until release is closed
+type stalledWSConn struct {
+	release chan struct{}
+
+	mtx     sync.Mutex
+	written []types.TMEventData
+}
+
+func newStalledWSConn() *stalledWSConn {
+	return &stalledWSConn{release: make(chan struct{})}
+}
+
+func (conn *stalledWSConn) GetRemoteAddr() string { return "127.0.0.1:12345" }
+
+func (conn *stalledWSConn) WriteRPCResponse(_ context.Context, resp rpctypes.RPCResponse) error {
+	<-conn.release
+	result := ctypes.ResultEvent{}
+	if err := tmjson.Unmarshal(resp.Result, &result); err != nil {
+		return err
+	}
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+	conn.written = append(conn.written, result.Data)
+	return nil
+}
+
+func (conn *stalledWSConn) TryWriteRPCResponse(resp rpctypes.RPCResponse) bool { return true }
+
+func (conn *stalledWSConn) Context() context.Context { return context.Background() }
+
+func (conn *stalledWSConn) events() []types.TMEventData {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+	return append([]types.TMEventData(nil), conn.written...)
+}
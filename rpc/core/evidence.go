@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tendermint/tendermint/evidence"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 )
 
 // BroadcastEvidence broadcasts evidence of the misbehavior.
+// The evidence is verified by the evidence pool and gossiped to the peers if
+// it's valid. Evidence which is already pending or committed is rejected.
 // More: https://docs.tendermint.com/master/rpc/#/Info/broadcast_evidence
 func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	if ev == nil {
@@ -21,7 +24,15 @@ func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.Result
 	}
 
 	if err := env.EvidencePool.AddEvidence(ev); err != nil {
-		return nil, fmt.Errorf("failed to add evidence: %w", err)
+		var invalidErr *types.ErrInvalidEvidence
+		switch {
+		case errors.Is(err, evidence.ErrEvidenceAlreadyPending), errors.Is(err, evidence.ErrEvidenceAlreadyCommitted):
+			return nil, fmt.Errorf("duplicate evidence: %w", err)
+		case errors.As(err, &invalidErr):
+			return nil, fmt.Errorf("evidence verification failed: %w", invalidErr.Reason)
+		default:
+			return nil, fmt.Errorf("failed to add evidence: %w", err)
+		}
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
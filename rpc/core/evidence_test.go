@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/evidence"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBroadcastEvidence(t *testing.T) {
+	quorumHash := crypto.RandQuorumHash()
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, time.Now(), types.NewMockPVForQuorum(quorumHash),
+		"test-chain", btcjson.LLMQType_5_60, quorumHash)
+
+	testCases := []struct {
+		name   string
+		addErr error
+		errMsg string
+	}{
+		{"valid evidence", nil, ""},
+		{"pending evidence", evidence.ErrEvidenceAlreadyPending, "duplicate evidence: evidence is already pending"},
+		{"committed evidence", evidence.ErrEvidenceAlreadyCommitted,
+			"duplicate evidence: evidence was already committed"},
+		{"invalid evidence", types.NewErrInvalidEvidence(ev, errors.New("evidence is too old")),
+			"evidence verification failed: evidence is too old"},
+		{"other error", errors.New("db closed"), "failed to add evidence: db closed"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			evpool := &mocks.EvidencePool{}
+			evpool.On("AddEvidence", ev).Return(tc.addErr)
+			env = &Environment{EvidencePool: evpool}
+
+			res, err := BroadcastEvidence(&rpctypes.Context{}, ev)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ev.Hash(), res.Hash)
+		})
+	}
+
+	_, err := BroadcastEvidence(&rpctypes.Context{}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// genesisSource is the genesis doc JSON the chunks are served from.
+type genesisSource interface {
+	// Slice returns size bytes of the genesis doc JSON at the offset. The
+	// returned slice must not be modified.
+	Slice(offset, size int64) ([]byte, error)
+	Close() error
+}
+
+// GenesisChunks serves the genesis doc JSON in chunks for /genesis_chunked,
+// so that the genesis doc with a huge app state isn't marshaled or held in
+// memory for every request. The JSON is written to a file once, and is
+// memory-mapped where the platform allows it.
+type GenesisChunks struct {
+	// protects the source from being closed while a chunk is encoded
+	mtx    tmsync.RWMutex
+	source genesisSource
+
+	size      int64
+	chunkSize int64
+	hash      tmbytes.HexBytes
+}
+
+// NewGenesisChunks writes the JSON of the genesis doc to the file at path,
+// overwriting it, and serves it in chunks of chunkSize bytes. The app state
+// of the genesis doc is streamed to the file, see GenesisDoc.AppStateReader.
+// The GenesisChunks must be closed.
+func NewGenesisChunks(genDoc *types.GenesisDoc, path string, chunkSize int) (*GenesisChunks, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+	size, hash, err := writeGenesisJSON(genDoc, path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't write the genesis doc to %s: %w", path, err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	source, err := openGenesisSource(file, size)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisChunks{
+		source:    source,
+		size:      size,
+		chunkSize: int64(chunkSize),
+		hash:      hash,
+	}, nil
+}
+
+// writeGenesisJSON writes the JSON of the genesis doc to the file at path, and
+// returns its size and SHA256 hash.
+func writeGenesisJSON(genDoc *types.GenesisDoc, path string) (int64, tmbytes.HexBytes, error) {
+	// the app state is the last field of the genesis doc, so it's appended
+	// to the JSON of the rest of the fields
+	doc := *genDoc
+	doc.AppState = nil
+	fields, err := tmjson.Marshal(&doc)
+	if err != nil {
+		return 0, nil, err
+	}
+	appState, err := genDoc.AppStateReader()
+	if err != nil {
+		return 0, nil, err
+	}
+	defer appState.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	buf := bufio.NewWriter(io.MultiWriter(file, hasher))
+	w := &countingWriter{w: buf}
+	r := bufio.NewReader(appState)
+	if _, err := r.Peek(1); err == io.EOF {
+		_, err = w.Write(fields)
+		if err != nil {
+			return 0, nil, err
+		}
+	} else {
+		_, err = w.Write(fields[:len(fields)-1])
+		if err == nil {
+			_, err = io.WriteString(w, `,"app_state":`)
+		}
+		if err == nil {
+			_, err = io.Copy(w, r)
+		}
+		if err == nil {
+			_, err = io.WriteString(w, "}")
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return 0, nil, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, nil, err
+	}
+	return w.n, hasher.Sum(nil), nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// Total returns the number of the chunks.
+func (gc *GenesisChunks) Total() int {
+	return int((gc.size + gc.chunkSize - 1) / gc.chunkSize)
+}
+
+// Hash returns the SHA256 hash of the genesis doc JSON, which identifies the
+// chunks of the same genesis doc JSON.
+func (gc *GenesisChunks) Hash() tmbytes.HexBytes {
+	return gc.hash
+}
+
+// Chunk returns the chunk with the given number, starting from 0, encoded in
+// base64.
+func (gc *GenesisChunks) Chunk(n int) (string, error) {
+	if n < 0 || n >= gc.Total() {
+		return "", fmt.Errorf("there are %d chunks, %d is invalid", gc.Total(), n)
+	}
+	offset := int64(n) * gc.chunkSize
+	size := gc.chunkSize
+	if offset+size > gc.size {
+		size = gc.size - offset
+	}
+
+	gc.mtx.RLock()
+	defer gc.mtx.RUnlock()
+	if gc.source == nil {
+		return "", errors.New("the genesis chunks are closed")
+	}
+	chunk, err := gc.source.Slice(offset, size)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(chunk), nil
+}
+
+// Close releases the genesis doc JSON.
+func (gc *GenesisChunks) Close() error {
+	gc.mtx.Lock()
+	defer gc.mtx.Unlock()
+	if gc.source == nil {
+		return nil
+	}
+	err := gc.source.Close()
+	gc.source = nil
+	return err
+}
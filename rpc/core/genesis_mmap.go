@@ -0,0 +1,33 @@
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mmapGenesisSource is the genesis doc JSON file mapped into memory, so that
+// the chunks are read without copying them, and the pages of the file are
+// reclaimed by the OS under memory pressure.
+type mmapGenesisSource []byte
+
+// openGenesisSource maps the file of the given size into memory, and closes
+// it.
+func openGenesisSource(file *os.File, size int64) (genesisSource, error) {
+	defer file.Close()
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't map %s into memory: %w", file.Name(), err)
+	}
+	return mmapGenesisSource(data), nil
+}
+
+func (s mmapGenesisSource) Slice(offset, size int64) ([]byte, error) {
+	return s[offset : offset+size], nil
+}
+
+func (s mmapGenesisSource) Close() error {
+	return syscall.Munmap(s)
+}
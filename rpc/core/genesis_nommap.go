@@ -0,0 +1,31 @@
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package core
+
+import (
+	"os"
+)
+
+// fileGenesisSource reads the chunks from the genesis doc JSON file on the
+// platforms, which don't support mapping the file into memory.
+type fileGenesisSource struct {
+	file *os.File
+}
+
+// openGenesisSource reads the chunks from the file, which is closed with the
+// source.
+func openGenesisSource(file *os.File, size int64) (genesisSource, error) {
+	return fileGenesisSource{file: file}, nil
+}
+
+func (s fileGenesisSource) Slice(offset, size int64) ([]byte, error) {
+	chunk := make([]byte, size)
+	if _, err := s.file.ReadAt(chunk, offset); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+func (s fileGenesisSource) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestGenesisChunked(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the 64MB genesis in short mode")
+	}
+	const (
+		appStateSize = 64 << 20
+		chunkSize    = 1 << 20
+	)
+
+	dir, err := ioutil.TempDir("", "genesis")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	genDocFile := filepath.Join(dir, "genesis.json")
+	writeLargeGenesisDoc(t, genDocFile, appStateSize)
+	genDoc, err := types.GenesisDocFromFileStreaming(genDocFile)
+	require.NoError(t, err)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	chunks, err := NewGenesisChunks(genDoc, filepath.Join(dir, "genesis_chunks.json"), chunkSize)
+	require.NoError(t, err)
+	defer chunks.Close()
+
+	// the genesis doc JSON is streamed to the file
+	runtime.ReadMemStats(&after)
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(chunkSize))
+	require.Greater(t, chunks.Total(), appStateSize/chunkSize)
+
+	env = &Environment{GenesisChunks: chunks}
+
+	hasher := sha256.New()
+	var genDocJSON []byte
+	for i := 0; i < chunks.Total(); i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		res, err := GenesisChunked(&rpctypes.Context{}, uint(i))
+		require.NoError(t, err)
+
+		// a chunk is only encoded in base64, and the genesis doc isn't
+		// marshaled or read into memory
+		runtime.ReadMemStats(&after)
+		require.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(5*chunkSize))
+
+		assert.Equal(t, i, res.ChunkNumber)
+		assert.Equal(t, chunks.Total(), res.TotalChunks)
+		assert.Equal(t, chunks.Hash(), res.Hash)
+		chunk, err := base64.StdEncoding.DecodeString(res.Data)
+		require.NoError(t, err)
+		if i < chunks.Total()-1 {
+			require.Len(t, chunk, chunkSize)
+		}
+		hasher.Write(chunk)
+		genDocJSON = append(genDocJSON, chunk...)
+	}
+	assert.EqualValues(t, chunks.Hash(), hasher.Sum(nil))
+
+	_, err = GenesisChunked(&rpctypes.Context{}, uint(chunks.Total()))
+	assert.Error(t, err)
+
+	// the chunks are the genesis doc JSON
+	assembled, err := types.GenesisDocFromJSONStreaming(genDocJSON)
+	require.NoError(t, err)
+	appState, err := genDoc.LoadAppState()
+	require.NoError(t, err)
+	assert.Equal(t, genDoc.ChainID, assembled.ChainID)
+	assert.Equal(t, genDoc.GenesisTime, assembled.GenesisTime)
+	assert.Equal(t, []byte(appState), []byte(assembled.AppState))
+}
+
+func TestGenesisChunksWithoutAppState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	genDoc := &types.GenesisDoc{ChainID: "test-chain", GenesisTime: time.Now().UTC()}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	chunks, err := NewGenesisChunks(genDoc, filepath.Join(dir, "genesis_chunks.json"), 10)
+	require.NoError(t, err)
+
+	var genDocJSON []byte
+	for i := 0; i < chunks.Total(); i++ {
+		data, err := chunks.Chunk(i)
+		require.NoError(t, err)
+		chunk, err := base64.StdEncoding.DecodeString(data)
+		require.NoError(t, err)
+		genDocJSON = append(genDocJSON, chunk...)
+	}
+	expected, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+	assert.Equal(t, expected, genDocJSON)
+	assert.EqualValues(t, sha256.Sum256(expected), chunks.Hash())
+
+	_, err = chunks.Chunk(-1)
+	assert.Error(t, err)
+	require.NoError(t, chunks.Close())
+	_, err = chunks.Chunk(0)
+	assert.Error(t, err)
+}
+
+// writeLargeGenesisDoc writes a genesis doc with an app state of at least
+// appStateSize bytes.
+func writeLargeGenesisDoc(t *testing.T, genDocFile string, appStateSize int) {
+	genDoc := &types.GenesisDoc{ChainID: "test-chain", GenesisTime: time.Now().UTC()}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	genDocBytes, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+
+	file, err := os.Create(genDocFile)
+	require.NoError(t, err)
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	_, err = w.Write(append(genDocBytes[:len(genDocBytes)-1], []byte(`,"app_state":{"accounts":[`)...))
+	require.NoError(t, err)
+	for i, size := 0, 0; size < appStateSize; i++ {
+		if i > 0 {
+			require.NoError(t, w.WriteByte(','))
+		}
+		n, err := fmt.Fprintf(w, `{"id":%d,"owner":"%x","balance":"%d"}`, i, sha256.Sum256([]byte{byte(i)}), i*1000)
+		require.NoError(t, err)
+		size += n
+	}
+	_, err = w.Write([]byte("]}}\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+}
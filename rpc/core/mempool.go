@@ -7,6 +7,7 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	mempl "github.com/tendermint/tendermint/mempool"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -51,8 +52,21 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 }
 
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
+// It waits for the tx to be committed up to timeout (e.g. "5s"), which can't
+// exceed the configured timeout_broadcast_tx_commit, the default. If prove is
+// set, the response includes the proof of the tx inclusion in the block.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit
-func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+func BroadcastTxCommit(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	timeout string,
+	prove bool,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	commitTimeout, err := validateCommitTimeout(timeout)
+	if err != nil {
+		return nil, err
+	}
+
 	subscriber := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -61,6 +75,10 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
 	}
 
+	// The timeout covers both CheckTx and the commit.
+	timer := time.NewTimer(commitTimeout)
+	defer timer.Stop()
+
 	// Subscribe to tx being committed in block.
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
@@ -86,8 +104,15 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
 		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
 	}
-	checkTxResMsg := <-checkTxResCh
-	checkTxRes := checkTxResMsg.GetCheckTx()
+	var checkTxRes *abci.ResponseCheckTx
+	select {
+	case checkTxResMsg := <-checkTxResCh:
+		checkTxRes = checkTxResMsg.GetCheckTx()
+	case <-timer.C:
+		err = errors.New("timed out waiting for CheckTx")
+		env.Logger.Error("Error on broadcastTxCommit", "err", err)
+		return &ctypes.ResultBroadcastTxCommit{Hash: tx.Hash()}, err
+	}
 	if checkTxRes.Code != abci.CodeTypeOK {
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
@@ -100,12 +125,21 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	select {
 	case msg := <-deliverTxSub.Out(): // The tx was included in a block.
 		deliverTxRes := msg.Data().(types.EventDataTx)
-		return &ctypes.ResultBroadcastTxCommit{
+		res := &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
 			DeliverTx: deliverTxRes.Result,
 			Hash:      tx.Hash(),
 			Height:    deliverTxRes.Height,
-		}, nil
+		}
+		if prove {
+			// the block is saved before its txs are delivered
+			block := env.BlockStore.LoadBlock(deliverTxRes.Height)
+			if block == nil {
+				return res, fmt.Errorf("block %d of the tx not found", deliverTxRes.Height)
+			}
+			res.Proof = block.Data.Txs.Proof(int(deliverTxRes.Index)) // XXX: overflow on 32-bit machines
+		}
+		return res, nil
 	case <-deliverTxSub.Cancelled():
 		var reason string
 		if deliverTxSub.Err() == nil {
@@ -120,7 +154,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 			DeliverTx: abci.ResponseDeliverTx{},
 			Hash:      tx.Hash(),
 		}, err
-	case <-time.After(env.Config.TimeoutBroadcastTxCommit):
+	case <-timer.C:
 		err = errors.New("timed out waiting for tx to be included in a block")
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
 		return &ctypes.ResultBroadcastTxCommit{
@@ -133,12 +167,56 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
 // including their number.
+// If hashes are given, only the transactions with these hashes, which are
+// still in the mempool, are returned.
+// If after is given, the transactions following the one with this hash are
+// returned. A large mempool can be walked page by page by passing the hash of
+// the last transaction of the previous page, even if it has been removed from
+// the mempool since.
 // More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs
-func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
+func UnconfirmedTxs(
+	ctx *rpctypes.Context,
+	limitPtr *int,
+	hashes []tmbytes.HexBytes,
+	after []byte,
+) (*ctypes.ResultUnconfirmedTxs, error) {
 	// reuse per_page validator
 	limit := validatePerPage(limitPtr)
 
-	txs := env.Mempool.ReapMaxTxs(limit)
+	var txs types.Txs
+	switch {
+	case len(hashes) > 0 && len(after) > 0:
+		return nil, errors.New("hashes and after can't be used together")
+
+	case len(hashes) > 0:
+		if len(hashes) > maxPerPage {
+			return nil, fmt.Errorf("too many hashes (%d), max: %d", len(hashes), maxPerPage)
+		}
+		txs = make(types.Txs, 0, len(hashes))
+		for _, hash := range hashes {
+			key, err := txKey(hash)
+			if err != nil {
+				return nil, err
+			}
+			if tx, ok := env.Mempool.TxByKey(key); ok {
+				txs = append(txs, tx)
+			}
+		}
+
+	case len(after) > 0:
+		key, err := txKey(after)
+		if err != nil {
+			return nil, err
+		}
+		txs, err = env.Mempool.ReapTxsAfter(key, limit)
+		if err != nil {
+			return nil, fmt.Errorf("tx %X: %w", after, err)
+		}
+
+	default:
+		txs = env.Mempool.ReapMaxTxs(limit)
+	}
+
 	return &ctypes.ResultUnconfirmedTxs{
 		Count:      len(txs),
 		Total:      env.Mempool.Size(),
@@ -146,6 +224,14 @@ func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfi
 		Txs:        txs}, nil
 }
 
+func txKey(hash []byte) (key [mempl.TxKeySize]byte, err error) {
+	if len(hash) != mempl.TxKeySize {
+		return key, fmt.Errorf("invalid tx hash %X: expected %d bytes, got %d", hash, mempl.TxKeySize, len(hash))
+	}
+	copy(key[:], hash)
+	return key, nil
+}
+
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.tendermint.com/master/rpc/#/Info/num_unconfirmed_txs
 func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
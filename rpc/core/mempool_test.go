@@ -0,0 +1,191 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/proxy"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestUnconfirmedTxs(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewApplication()).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	defer appConn.Stop() // nolint:errcheck // ignore for tests
+
+	mempool := mempl.NewCListMempool(cfg.TestMempoolConfig(), appConn, 0)
+	env = &Environment{Mempool: mempool}
+
+	txs := make(types.Txs, 250)
+	for i := range txs {
+		txs[i] = types.Tx([]byte{byte(i / 256), byte(i % 256)})
+		require.NoError(t, mempool.CheckTx(txs[i], nil, mempl.TxInfo{}))
+	}
+
+	// look up by hashes, skipping the ones not in the mempool
+	res, err := UnconfirmedTxs(&rpctypes.Context{}, nil,
+		[]tmbytes.HexBytes{txs[3].Hash(), types.Tx("missing").Hash(), txs[7].Hash()}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Tx{txs[3], txs[7]}, res.Txs)
+	assert.Equal(t, 2, res.Count)
+	assert.Equal(t, len(txs), res.Total)
+
+	// walk page by page
+	limit := 100
+	walked := types.Txs{txs[0]}
+	for {
+		res, err := UnconfirmedTxs(&rpctypes.Context{}, &limit, nil, walked[len(walked)-1].Hash())
+		require.NoError(t, err)
+		if res.Count == 0 {
+			break
+		}
+		walked = append(walked, res.Txs...)
+	}
+	assert.Equal(t, txs, walked)
+
+	_, err = UnconfirmedTxs(&rpctypes.Context{}, nil, nil, types.Tx("missing").Hash())
+	assert.Error(t, err)
+	_, err = UnconfirmedTxs(&rpctypes.Context{}, nil, nil, []byte{0x01})
+	assert.Error(t, err)
+	_, err = UnconfirmedTxs(&rpctypes.Context{}, nil, []tmbytes.HexBytes{txs[0].Hash()}, txs[0].Hash())
+	assert.Error(t, err)
+}
+
+func TestUnsafeMempoolSnapshot(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewApplication()).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	defer appConn.Stop() // nolint:errcheck // ignore for tests
+
+	mempool := mempl.NewCListMempool(cfg.TestMempoolConfig(), appConn, 0)
+	env = &Environment{Mempool: mempool}
+
+	txs := make(types.Txs, 1000)
+	for i := range txs {
+		txs[i] = types.Tx([]byte{byte(i / 256), byte(i % 256)})
+		require.NoError(t, mempool.CheckTx(txs[i], nil, mempl.TxInfo{}))
+	}
+
+	start := time.Now()
+	res, err := UnsafeMempoolSnapshot(&rpctypes.Context{}, nil, false)
+	require.NoError(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(100*time.Millisecond))
+	assert.Equal(t, len(txs), res.Count)
+	assert.Equal(t, len(txs), res.Total)
+	assert.EqualValues(t, 2*len(txs), res.TotalBytes)
+	for i, memTx := range res.Txs {
+		assert.EqualValues(t, txs[i].Hash(), memTx.Hash)
+		assert.Equal(t, 2, memTx.Size)
+		assert.Positive(t, int64(memTx.Age))
+		assert.Nil(t, memTx.Tx)
+	}
+
+	limit := 10
+	res, err = UnsafeMempoolSnapshot(&rpctypes.Context{}, &limit, true)
+	require.NoError(t, err)
+	assert.Equal(t, limit, res.Count)
+	assert.Equal(t, len(txs), res.Total)
+	for i, memTx := range res.Txs {
+		assert.Equal(t, txs[i], memTx.Tx)
+	}
+}
+
+func TestUnsafeFlushMempoolCache(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewApplication()).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	defer appConn.Stop() // nolint:errcheck // ignore for tests
+
+	mempool := mempl.NewCListMempool(cfg.TestMempoolConfig(), appConn, 0)
+	env = &Environment{Mempool: mempool, Logger: log.TestingLogger()}
+
+	tx := types.Tx("a=1")
+	require.NoError(t, mempool.CheckTx(tx, nil, mempl.TxInfo{}))
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, time.Time{}, nil, types.Txs{tx},
+		[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}}, nil, nil))
+	mempool.Unlock()
+	assert.Equal(t, mempl.ErrTxInCache, mempool.CheckTx(tx, nil, mempl.TxInfo{}))
+
+	_, err = UnsafeFlushMempoolCache(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.NoError(t, mempool.CheckTx(tx, nil, mempl.TxInfo{}))
+	assert.Equal(t, 1, mempool.Size())
+}
+
+// txsBlockStore is a mockBlockStore with a single block
+type txsBlockStore struct {
+	mockBlockStore
+	block *types.Block
+}
+
+func (store txsBlockStore) LoadBlock(height int64) *types.Block {
+	if height != store.block.Height {
+		return nil
+	}
+	return store.block
+}
+
+func TestBroadcastTxCommit(t *testing.T) {
+	appConn, err := proxy.NewLocalClientCreator(kvstore.NewApplication()).NewABCIClient()
+	require.NoError(t, err)
+	require.NoError(t, appConn.Start())
+	defer appConn.Stop() // nolint:errcheck // ignore for tests
+
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	block := &types.Block{Header: types.Header{Height: 7, DataHash: txs.Hash()}, Data: types.Data{Txs: txs}}
+	env = &Environment{
+		Mempool:    mempl.NewCListMempool(cfg.TestMempoolConfig(), appConn, 0),
+		BlockStore: txsBlockStore{block: block},
+		EventBus:   types.NewEventBus(),
+		Logger:     log.TestingLogger(),
+		Config:     *cfg.TestRPCConfig(),
+	}
+	env.Config.TimeoutBroadcastTxCommit = 5 * time.Second
+	require.NoError(t, env.EventBus.Start())
+	defer env.EventBus.Stop() //nolint:errcheck // ignore for tests
+
+	for _, timeout := range []string{"invalid", "-1s", "0s", "6s"} {
+		_, err := BroadcastTxCommit(&rpctypes.Context{}, txs[0], timeout, false)
+		assert.Error(t, err, timeout)
+	}
+
+	// the tx passes CheckTx, but it is never committed
+	res, err := BroadcastTxCommit(&rpctypes.Context{}, txs[0], "10ms", true)
+	require.Error(t, err)
+	assert.EqualValues(t, abci.CodeTypeOK, res.CheckTx.Code)
+	assert.Zero(t, res.Height)
+	assert.Equal(t, 0, env.EventBus.NumClientSubscriptions(""))
+
+	// commit the tx, once the subscription is in place
+	go func() {
+		for env.EventBus.NumClientSubscriptions("") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		err := env.EventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: block.Height,
+			Index:  1,
+			Tx:     txs[1],
+		}})
+		if err != nil {
+			panic(err)
+		}
+	}()
+	res, err = BroadcastTxCommit(&rpctypes.Context{}, txs[1], "", true)
+	require.NoError(t, err)
+	assert.Equal(t, block.Height, res.Height)
+	assert.EqualValues(t, txs[1], res.Proof.Data)
+	assert.NoError(t, res.Proof.Validate(block.DataHash))
+	assert.Equal(t, 0, env.EventBus.NumClientSubscriptions(""))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/p2p"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -91,10 +92,69 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, uncondit
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeBanPeer bans the peer for the duration (e.g. "24h"), disconnecting it
+// if connected.
+func UnsafeBanPeer(ctx *rpctypes.Context, id, reason, duration string) (*ctypes.ResultBanPeer, error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return &ctypes.ResultBanPeer{}, fmt.Errorf("wrong duration %q: %w", duration, err)
+	}
+	if reason == "" {
+		reason = "banned by RPC"
+	}
+
+	env.Logger.Info("BanPeer", "peer", id, "reason", reason, "duration", d)
+	if err := env.P2PPeers.BanPeer(p2p.ID(id), reason, d); err != nil {
+		return &ctypes.ResultBanPeer{}, err
+	}
+	return &ctypes.ResultBanPeer{Log: fmt.Sprintf("Peer %s is banned for %v", id, d)}, nil
+}
+
+// UnsafeUnbanPeer lifts the ban of the peer.
+func UnsafeUnbanPeer(ctx *rpctypes.Context, id string) (*ctypes.ResultUnbanPeer, error) {
+	env.Logger.Info("UnbanPeer", "peer", id)
+	if err := env.P2PPeers.UnbanPeer(p2p.ID(id)); err != nil {
+		return &ctypes.ResultUnbanPeer{}, err
+	}
+	return &ctypes.ResultUnbanPeer{Log: fmt.Sprintf("Peer %s is unbanned", id)}, nil
+}
+
+// BannedPeers returns the banned peers with the reasons and the expiration of
+// their bans.
+func BannedPeers(ctx *rpctypes.Context) (*ctypes.ResultBannedPeers, error) {
+	peers := env.P2PPeers.BannedPeers()
+	return &ctypes.ResultBannedPeers{NPeers: len(peers), Peers: peers}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
-	return &ctypes.ResultGenesis{Genesis: env.GenDoc}, nil
+	genDoc, err := env.GenDoc.WithAppState()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultGenesis{Genesis: genDoc}, nil
+}
+
+// GenesisChunked returns the chunk of the genesis doc JSON with the given
+// number, starting from 0, for the genesis docs too large for /genesis. The
+// hash of the genesis doc JSON is returned with every chunk, so that the
+// chunks of different genesis docs aren't mixed up.
+// More: https://docs.tendermint.com/master/rpc/#/Info/genesis_chunked
+func GenesisChunked(ctx *rpctypes.Context, chunk uint) (*ctypes.ResultGenesisChunk, error) {
+	if env.GenesisChunks == nil {
+		return nil, errors.New("the genesis chunks aren't available")
+	}
+	data, err := env.GenesisChunks.Chunk(int(chunk))
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultGenesisChunk{
+		ChunkNumber: int(chunk),
+		TotalChunks: env.GenesisChunks.Total(),
+		Hash:        env.GenesisChunks.Hash(),
+		Data:        data,
+	}, nil
 }
 
 func getIDs(peers []string) ([]string, error) {
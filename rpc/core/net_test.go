@@ -85,3 +85,55 @@ func TestUnsafeDialPeers(t *testing.T) {
 		}
 	}
 }
+
+func TestUnsafeBanPeer(t *testing.T) {
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1, "testing", "123.123.123", nil,
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw })
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	env.Logger = log.TestingLogger()
+	env.P2PPeers = sw
+
+	id := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4"
+	testCases := []struct {
+		id, duration string
+		isErr        bool
+	}{
+		{id, "", true},
+		{id, "1 day", true},
+		{id, "-1h", true},
+		{"127.0.0.1:41198", "1h", true},
+		{id, "1h", false},
+	}
+
+	for _, tc := range testCases {
+		res, err := UnsafeBanPeer(&rpctypes.Context{}, tc.id, "spam", tc.duration)
+		if tc.isErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, res)
+		}
+	}
+
+	res, err := BannedPeers(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.NPeers)
+	assert.EqualValues(t, id, res.Peers[0].ID)
+	assert.Equal(t, "spam", res.Peers[0].Reason)
+
+	_, err = UnsafeUnbanPeer(&rpctypes.Context{}, id)
+	require.NoError(t, err)
+	_, err = UnsafeUnbanPeer(&rpctypes.Context{}, id)
+	assert.Error(t, err)
+
+	res, err = BannedPeers(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Zero(t, res.NPeers)
+}
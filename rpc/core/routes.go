@@ -9,33 +9,39 @@ import (
 // Routes is a map of available routes.
 var Routes = map[string]*rpc.RPCFunc{
 	// subscribe/unsubscribe are reserved for websocket events.
-	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
-	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
-	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+	"subscribe": rpc.NewWSRPCFunc(Subscribe, "query,since_height,slow_consumer_policy",
+		rpc.OptionalArgs(2), rpc.Subscribes("query")),
+	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query", rpc.Unsubscribes("query")),
+	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, "", rpc.UnsubscribesAll()),
 
 	// info API
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
+	"banned_peers":         rpc.NewRPCFunc(BannedPeers, ""),
+	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.RateLimited()),
 	"genesis":              rpc.NewRPCFunc(Genesis, ""),
-	"block":                rpc.NewRPCFunc(Block, "height"),
-	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash"),
-	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
+	"genesis_chunked":      rpc.NewRPCFunc(GenesisChunked, "chunk"),
+	"block":                rpc.NewRPCFunc(Block, "height", rpc.RateLimited()),
+	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash", rpc.RateLimited()),
+	"block_results":        rpc.NewRPCFunc(BlockResults, "height", rpc.RateLimited()),
 	"commit":               rpc.NewRPCFunc(Commit, "height"),
+	"threshold_commit":     rpc.NewRPCFunc(ThresholdCommit, "height"),
 	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
-	"block_search":         rpc.NewRPCFunc(BlockSearch, "query,page,per_page,order_by"),
+	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by", rpc.RateLimited()),
+	"block_search":         rpc.NewRPCFunc(BlockSearch, "query,page,per_page,order_by", rpc.RateLimited()),
 	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page,request_threshold_public_key"),
+	"validator_quorum":     rpc.NewRPCFunc(ValidatorQuorum, "height"),
+	"proposer":             rpc.NewRPCFunc(Proposer, "height,round"),
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
-	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
+	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit,hashes,after", rpc.OptionalArgs(2)),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
 	// tx broadcast API
-	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
+	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx,timeout,prove", rpc.OptionalArgs(2)),
 	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
 	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
 
@@ -47,10 +53,28 @@ var Routes = map[string]*rpc.RPCFunc{
 	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
 }
 
+// InspectRoutes is the subset of the routes, which is served from the stores
+// of a stopped node by the inspect command.
+var InspectRoutes = map[string]*rpc.RPCFunc{
+	"status":           Routes["status"],
+	"block":            Routes["block"],
+	"block_results":    Routes["block_results"],
+	"commit":           Routes["commit"],
+	"validators":       Routes["validators"],
+	"consensus_params": Routes["consensus_params"],
+}
+
 // AddUnsafeRoutes adds unsafe routes.
 func AddUnsafeRoutes() {
 	// control API
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
+	Routes["unsafe_ban_peer"] = rpc.NewRPCFunc(UnsafeBanPeer, "id,reason,duration")
+	Routes["unsafe_unban_peer"] = rpc.NewRPCFunc(UnsafeUnbanPeer, "id")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["unsafe_flush_mempool_cache"] = rpc.NewRPCFunc(UnsafeFlushMempoolCache, "")
+	Routes["unsafe_force_round_skip"] = rpc.NewRPCFunc(UnsafeForceRoundSkip, "")
+	Routes["unsafe_drop_peer_state"] = rpc.NewRPCFunc(UnsafeDropPeerState, "peer_id")
+	Routes["mempool_snapshot"] = rpc.NewRPCFunc(UnsafeMempoolSnapshot, "limit,include_tx")
+	Routes["unsafe_reload_config"] = rpc.NewRPCFunc(UnsafeReloadConfig, "")
 }
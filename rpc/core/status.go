@@ -46,9 +46,21 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 
 	// Return the very last voting power, not the voting power of this validator
 	// during the last block.
-	var votingPower int64
-	if val := validatorAtHeight(latestUncommittedHeight()); val != nil {
-		votingPower = val.VotingPower
+	validatorInfo := ctypes.ValidatorInfo{
+		ProTxHash: env.ProTxHash,
+	}
+	if vals, val := validatorAtHeight(latestUncommittedHeight()); vals != nil {
+		validatorInfo.QuorumHash = vals.QuorumHash
+		if val != nil {
+			validatorInfo.IsValidator = true
+			validatorInfo.VotingPower = val.VotingPower
+			validatorInfo.PubKey = val.PubKey
+		}
+	}
+
+	var chainLockedHeight uint32
+	if env.ChainLockWatcher != nil {
+		chainLockedHeight, _ = env.ChainLockWatcher.ChainLockedHeight()
 	}
 
 	result := &ctypes.ResultStatus{
@@ -63,22 +75,25 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			EarliestBlockHeight: earliestBlockHeight,
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
+			ChainLockedHeight:   chainLockedHeight,
 		},
-		ValidatorInfo: ctypes.ValidatorInfo{
-			ProTxHash:   env.ProTxHash,
-			VotingPower: votingPower,
-		},
+		ValidatorInfo: validatorInfo,
 	}
 
 	return result, nil
 }
 
-func validatorAtHeight(h int64) *types.Validator {
+// validatorAtHeight returns the validator set at a height and the validator of the node in it,
+// both are nil if the node has no proTxHash
+func validatorAtHeight(h int64) (*types.ValidatorSet, *types.Validator) {
+	privValProTxHash := env.ProTxHash
+	if len(privValProTxHash) == 0 {
+		return nil, nil
+	}
 	vals, err := env.StateStore.LoadValidators(h)
 	if err != nil {
-		return nil
+		return nil, nil
 	}
-	privValProTxHash := env.ProTxHash
 	_, val := vals.GetByProTxHash(privValProTxHash)
-	return val
+	return vals, val
 }
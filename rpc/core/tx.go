@@ -3,9 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
-	"sort"
 
-	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -72,46 +70,34 @@ func TxSearch(
 		return nil, err
 	}
 
-	results, err := env.TxIndexer.Search(ctx.Context(), q)
-	if err != nil {
-		return nil, err
-	}
-
-	// sort results (must be done before pagination)
+	var orderDesc bool
 	switch orderBy {
 	case "desc":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index > results[j].Index
-			}
-			return results[i].Height > results[j].Height
-		})
+		orderDesc = true
 	case "asc", "":
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Height == results[j].Height {
-				return results[i].Index < results[j].Index
-			}
-			return results[i].Height < results[j].Height
-		})
 	default:
 		return nil, errors.New("expected order_by to be either `asc` or `desc` or empty")
 	}
 
-	// paginate results
-	totalCount := len(results)
-	perPage := validatePerPage(perPagePtr)
+	// the indexer sorts the results by height and index and only loads the
+	// requested page of them
+	perPage := validateSearchPerPage(perPagePtr)
+	skipCount := 0
+	if pagePtr != nil {
+		skipCount = validateSkipCount(*pagePtr, perPage)
+	}
 
-	page, err := validatePage(pagePtr, perPage, totalCount)
+	results, totalCount, err := env.TxIndexer.SearchPage(ctx.Context(), q, orderDesc, skipCount, perPage)
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+	if _, err := validatePage(pagePtr, perPage, totalCount); err != nil {
+		return nil, err
+	}
 
-	apiResults := make([]*ctypes.ResultTx, 0, pageSize)
-	for i := skipCount; i < skipCount+pageSize; i++ {
-		r := results[i]
+	apiResults := make([]*ctypes.ResultTx, 0, len(results))
+	for _, r := range results {
 
 		var proof types.TxProof
 		if prove {
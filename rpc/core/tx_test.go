@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxSearch(t *testing.T) {
+	const numBlocks = 500
+	env = &Environment{}
+	txIndexer := kv.NewTxIndex(dbm.NewMemDB())
+	env.TxIndexer = txIndexer
+
+	// one tx changing the quorum in each block
+	for h := int64(1); h <= numBlocks; h++ {
+		require.NoError(t, txIndexer.Index(&abci.TxResult{
+			Height: h,
+			Index:  1,
+			Tx:     types.Tx(fmt.Sprintf("quorum change %d", h)),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{
+				{Type: "quorum", Attributes: []abci.EventAttribute{
+					{Key: []byte("changed"), Value: []byte("true"), Index: true},
+				}},
+			}},
+		}))
+	}
+
+	query := "quorum.changed = 'true'"
+	for _, orderBy := range []string{"asc", "desc"} {
+		perPage := 45
+		var heights []int64
+		for page := 1; ; page++ {
+			page := page
+			res, err := TxSearch(&rpctypes.Context{}, query, false, &page, &perPage, orderBy)
+			require.NoError(t, err)
+			assert.Equal(t, numBlocks, res.TotalCount)
+			for _, tx := range res.Txs {
+				assert.Equal(t, types.Tx(fmt.Sprintf("quorum change %d", tx.Height)), tx.Tx)
+				heights = append(heights, tx.Height)
+			}
+			if len(res.Txs) < perPage {
+				break
+			}
+		}
+
+		require.Len(t, heights, numBlocks)
+		for i, h := range heights {
+			if orderBy == "desc" {
+				assert.EqualValues(t, numBlocks-i, h)
+			} else {
+				assert.EqualValues(t, i+1, h)
+			}
+		}
+	}
+
+	// per_page is limited by the configured maximum
+	env.Config.MaxSearchResultsPerPage = 20
+	perPage := 50
+	res, err := TxSearch(&rpctypes.Context{}, query, false, nil, &perPage, "")
+	require.NoError(t, err)
+	assert.Len(t, res.Txs, 20)
+	assert.Equal(t, numBlocks, res.TotalCount)
+	assert.EqualValues(t, 1, res.Txs[0].Height)
+
+	page := 26
+	_, err = TxSearch(&rpctypes.Context{}, query, false, &page, &perPage, "")
+	assert.Error(t, err)
+
+	_, err = TxSearch(&rpctypes.Context{}, query, false, nil, nil, "height")
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package coretypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The JSON-RPC error codes of the errors, which are returned with their fields
+// as the data of the error.
+const (
+	CodeHeightPruned       = -32001
+	CodeResultsUnavailable = -32002
+)
+
+// ErrHeightPruned is returned when the requested height is below the earliest height
+// available on the node, because the node pruned it.
+// ArchiveEndpoints is an optional list of nodes where the height can be found.
+type ErrHeightPruned struct {
+	Height           int64    `json:"height"`
+	EarliestHeight   int64    `json:"earliest_height"`
+	ArchiveEndpoints []string `json:"archive_endpoints,omitempty"`
+}
+
+func (e ErrHeightPruned) Error() string {
+	msg := fmt.Sprintf("height %d is pruned, earliest available height is %d", e.Height, e.EarliestHeight)
+	if len(e.ArchiveEndpoints) > 0 {
+		msg += "; archive endpoints: " + strings.Join(e.ArchiveEndpoints, ",")
+	}
+	return msg
+}
+
+// RPCErrorCode implements rpctypes.ErrorWithData.
+func (e ErrHeightPruned) RPCErrorCode() int {
+	return CodeHeightPruned
+}
+
+// RPCErrorData implements rpctypes.ErrorWithData.
+func (e ErrHeightPruned) RPCErrorData() interface{} {
+	return e
+}
+
+// ErrResultsUnavailable is returned when the block of the requested height is
+// available, but its results aren't, e.g. because the node was state synced
+// above it, and they can't be recomputed. EarliestHeight is the earliest height
+// with results, or 0 if it's unknown.
+type ErrResultsUnavailable struct {
+	Height         int64 `json:"height"`
+	EarliestHeight int64 `json:"earliest_height"`
+}
+
+func (e ErrResultsUnavailable) Error() string {
+	return fmt.Sprintf("results of height %d are unavailable, earliest height with results is %d",
+		e.Height, e.EarliestHeight)
+}
+
+// RPCErrorCode implements rpctypes.ErrorWithData.
+func (e ErrResultsUnavailable) RPCErrorCode() int {
+	return CodeResultsUnavailable
+}
+
+// RPCErrorData implements rpctypes.ErrorWithData.
+func (e ErrResultsUnavailable) RPCErrorData() interface{} {
+	return e
+}
+
+// ErrFromRPCErrorData restores the error from the code and the data of an
+// error received over RPC, e.g. ErrHeightPruned. False is returned, if the
+// code isn't of such an error, or the data is malformed.
+func ErrFromRPCErrorData(code int, data json.RawMessage) (error, bool) {
+	switch code {
+	case CodeHeightPruned:
+		var e ErrHeightPruned
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, false
+		}
+		return e, true
+	case CodeResultsUnavailable:
+		var e ErrResultsUnavailable
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, false
+		}
+		return e, true
+	default:
+		return nil, false
+	}
+}
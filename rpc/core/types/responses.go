@@ -24,6 +24,16 @@ type ResultGenesis struct {
 	Genesis *types.GenesisDoc `json:"genesis"`
 }
 
+// ResultGenesisChunk is a chunk of the genesis doc JSON, the chunks are
+// concatenated in the order of their numbers. The chunks with different
+// hashes are of different genesis docs.
+type ResultGenesisChunk struct {
+	ChunkNumber int            `json:"chunk"`
+	TotalChunks int            `json:"total"`
+	Hash        bytes.HexBytes `json:"hash"`
+	Data        string         `json:"data"`
+}
+
 // Single block (with meta)
 type ResultBlock struct {
 	BlockID types.BlockID `json:"block_id"`
@@ -36,6 +46,19 @@ type ResultCommit struct {
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// ResultThresholdCommit is the threshold signatures of the commit of a block,
+// they are verified with the threshold public key of the quorum of the block
+type ResultThresholdCommit struct {
+	Height                  int64          `json:"height"`
+	Round                   int32          `json:"round"`
+	BlockID                 types.BlockID  `json:"block_id"`
+	StateID                 types.StateID  `json:"state_id"`
+	QuorumHash              bytes.HexBytes `json:"quorum_hash"`
+	ThresholdBlockSignature bytes.HexBytes `json:"threshold_block_signature"`
+	ThresholdStateSignature bytes.HexBytes `json:"threshold_state_signature"`
+	CanonicalCommit         bool           `json:"canonical"`
+}
+
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
@@ -73,12 +96,23 @@ type SyncInfo struct {
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
 	CatchingUp bool `json:"catching_up"`
+
+	// ChainLockedHeight is the highest core height chain locked by Dash Core,
+	// as seen by the node. It's 0 if the node doesn't sign with Dash Core.
+	ChainLockedHeight uint32 `json:"chain_locked_height"`
 }
 
 // Info about the node's validator
 type ValidatorInfo struct {
 	ProTxHash   crypto.ProTxHash `json:"pro_tx_hash"`
 	VotingPower int64            `json:"voting_power"`
+	// IsValidator is true if the node is a member of the latest validator set
+	IsValidator bool `json:"is_validator"`
+	// PubKey is the BLS public key share of the node in the latest validator set
+	PubKey crypto.PubKey `json:"pub_key"`
+	// QuorumHash is the hash of the quorum of the latest validator set, it is empty
+	// if the node has no proTxHash
+	QuorumHash crypto.QuorumHash `json:"quorum_hash"`
 }
 
 // Node Status
@@ -114,6 +148,22 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Log from banning a peer
+type ResultBanPeer struct {
+	Log string `json:"log"`
+}
+
+// Log from unbanning a peer
+type ResultUnbanPeer struct {
+	Log string `json:"log"`
+}
+
+// Banned peers
+type ResultBannedPeers struct {
+	NPeers int              `json:"n_peers"`
+	Peers  []p2p.BannedPeer `json:"peers"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -135,12 +185,49 @@ type ResultValidators struct {
 	Total int `json:"total"`
 }
 
+// ResultValidatorQuorum is the LLMQ quorum of the validator set at a height
+type ResultValidatorQuorum struct {
+	BlockHeight        int64             `json:"block_height"`
+	QuorumType         btcjson.LLMQType  `json:"quorum_type"`
+	QuorumHash         crypto.QuorumHash `json:"quorum_hash"`
+	ThresholdPublicKey crypto.PubKey     `json:"threshold_public_key"`
+	Members            []QuorumMember    `json:"members"`
+}
+
+// QuorumMember is a member of a validator quorum
+type QuorumMember struct {
+	ProTxHash crypto.ProTxHash `json:"pro_tx_hash"`
+	PubKey    crypto.PubKey    `json:"pub_key"`
+}
+
+// ResultProposer is the proposer selected for a round of a height
+type ResultProposer struct {
+	BlockHeight int64              `json:"block_height"`
+	Round       int32              `json:"round"`
+	ProTxHash   crypto.ProTxHash   `json:"pro_tx_hash"`
+	Validators  []ProposerPriority `json:"validators"`
+}
+
+// ProposerPriority is the proposer priority of a validator in a round
+type ProposerPriority struct {
+	ProTxHash        crypto.ProTxHash `json:"pro_tx_hash"`
+	VotingPower      int64            `json:"voting_power"`
+	ProposerPriority int64            `json:"proposer_priority"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
 	BlockHeight     int64                   `json:"block_height"`
 	ConsensusParams tmproto.ConsensusParams `json:"consensus_params"`
 }
 
+// ResultBlockParams is the block params for given height, the only consensus
+// params verified by the light client.
+type ResultBlockParams struct {
+	BlockHeight int64               `json:"block_height"`
+	BlockParams tmproto.BlockParams `json:"block_params"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
@@ -175,6 +262,7 @@ type ResultBroadcastTxCommit struct {
 	DeliverTx abci.ResponseDeliverTx `json:"deliver_tx"`
 	Hash      bytes.HexBytes         `json:"hash"`
 	Height    int64                  `json:"height"`
+	Proof     types.TxProof          `json:"proof,omitempty"`
 }
 
 // ResultCheckTx wraps abci.ResponseCheckTx.
@@ -212,6 +300,28 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// List of mempool txs metadata
+type ResultMempoolSnapshot struct {
+	Count      int              `json:"n_txs"`
+	Total      int              `json:"total"`
+	TotalBytes int64            `json:"total_bytes"`
+	Txs        []MempoolTxStats `json:"txs"`
+}
+
+// Metadata of a mempool tx
+type MempoolTxStats struct {
+	Hash           bytes.HexBytes `json:"hash"`
+	Size           int            `json:"size"`
+	Height         int64          `json:"height"`
+	Time           time.Time      `json:"time"`
+	Age            time.Duration  `json:"age"`
+	GasWanted      int64          `json:"gas_wanted"`
+	Priority       int64          `json:"priority"`
+	Sender         string         `json:"sender"`
+	RecheckPending bool           `json:"recheck_pending"`
+	Tx             types.Tx       `json:"tx,omitempty"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -227,13 +337,33 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of reloading the config file. Applied are the changed keys, which
+// were applied to the running node, RequiresRestart are the changed keys,
+// which take effect only after a restart.
+type ResultReloadConfig struct {
+	Applied         []string `json:"applied"`
+	RequiresRestart []string `json:"requires_restart"`
+}
+
+// The height and the round skipped by the node
+type ResultForceRoundSkip struct {
+	Height int64 `json:"height"`
+	Round  int32 `json:"round"`
+}
+
+// Log from dropping the state of a peer
+type ResultDropPeerState struct {
+	Log string `json:"log"`
+}
+
 // empty results
 type (
-	ResultUnsafeFlushMempool struct{}
-	ResultUnsafeProfile      struct{}
-	ResultSubscribe          struct{}
-	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
+	ResultUnsafeFlushMempool      struct{}
+	ResultUnsafeFlushMempoolCache struct{}
+	ResultUnsafeProfile           struct{}
+	ResultSubscribe               struct{}
+	ResultUnsubscribe             struct{}
+	ResultHealth                  struct{}
 )
 
 // Event data from a subscription
@@ -2,13 +2,26 @@ package coregrpc
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	core "github.com/tendermint/tendermint/rpc/core"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
+// Number of tx results buffered for each SubscribeTxResults stream. The
+// streams of the clients, which don't keep up, are closed.
+const txResultsBufferSize = 100
+
 type broadcastAPI struct {
+	// used to generate unique subscriber IDs for the streams
+	lastSubscriberID uint64
 }
 
 func (bapi *broadcastAPI) Ping(ctx context.Context, req *RequestPing) (*ResponsePing, error) {
@@ -19,7 +32,7 @@ func (bapi *broadcastAPI) Ping(ctx context.Context, req *RequestPing) (*Response
 func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
 	// NOTE: there's no way to get client's remote address
 	// see https://stackoverflow.com/questions/33684570/session-and-remote-ip-address-in-grpc-go
-	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx, "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -37,3 +50,58 @@ func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcast
 		},
 	}, nil
 }
+
+func (bapi *broadcastAPI) BroadcastTxCommit(
+	ctx context.Context,
+	req *RequestBroadcastTxCommit,
+) (*ResponseBroadcastTxCommit, error) {
+	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResponseBroadcastTxCommit{
+		CheckTx:   &res.CheckTx,
+		DeliverTx: &res.DeliverTx,
+		Hash:      res.Hash,
+		Height:    res.Height,
+	}, nil
+}
+
+func (bapi *broadcastAPI) SubscribeTxResults(
+	req *RequestSubscribeTxResults,
+	stream BroadcastAPI_SubscribeTxResultsServer,
+) error {
+	subscriber := fmt.Sprintf("grpc-%d", atomic.AddUint64(&bapi.lastSubscriberID, 1))
+	sub, unsubscribe, err := core.SubscribeTxResults(stream.Context(), subscriber, req.Query, txResultsBufferSize)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			data := msg.Data().(types.EventDataTx)
+			res := &ResponseTxResult{
+				Hash:     types.Tx(data.Tx).Hash(),
+				TxResult: &data.TxResult,
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		case <-sub.Cancelled():
+			switch sub.Err() {
+			case tmpubsub.ErrOutOfCapacity:
+				return status.Errorf(codes.ResourceExhausted,
+					"client is not reading the tx results fast enough (buffer size: %d)", txResultsBufferSize)
+			case nil:
+				return status.Error(codes.Unavailable, "Tendermint exited")
+			default:
+				return status.Error(codes.Unavailable, sub.Err().Error())
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
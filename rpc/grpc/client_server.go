@@ -36,3 +36,47 @@ func StartGRPCClient(protoAddr string) BroadcastAPIClient {
 func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
 	return tmnet.Connect(addr)
 }
+
+// Client is a BroadcastAPIClient, which can also stream the tx results to a
+// channel.
+type Client struct {
+	BroadcastAPIClient
+	conn *grpc.ClientConn
+}
+
+// NewClient dials the gRPC server using protoAddr and returns a new Client.
+func NewClient(protoAddr string) (*Client, error) {
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{BroadcastAPIClient: NewBroadcastAPIClient(conn), conn: conn}, nil
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// StreamTxResults subscribes to the results of the txs matching query and
+// sends them to out, until ctx is done or the server closes the stream, e.g.
+// because the client did not read the results fast enough. It returns the
+// error the stream was closed with.
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func (c *Client) StreamTxResults(ctx context.Context, query string, out chan<- *ResponseTxResult) error {
+	stream, err := c.SubscribeTxResults(ctx, &RequestSubscribeTxResults{Query: query})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		select {
+		case out <- res:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -2,14 +2,18 @@ package coregrpc_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	core_grpc "github.com/tendermint/tendermint/rpc/grpc"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestMain(m *testing.M) {
@@ -33,3 +37,35 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestBroadcastTxCommit(t *testing.T) {
+	client, err := core_grpc.NewClient(rpctest.GetConfig().RPC.GRPCListenAddress)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx := types.Tx("grpc=commit")
+	results := make(chan *core_grpc.ResponseTxResult, 1)
+	go func() {
+		_ = client.StreamTxResults(ctx, fmt.Sprintf("%s='%X'", types.TxHashKey, tx.Hash()), results)
+	}()
+
+	res, err := client.BroadcastTxCommit(ctx, &core_grpc.RequestBroadcastTxCommit{Tx: tx})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, res.CheckTx.Code)
+	require.EqualValues(t, 0, res.DeliverTx.Code)
+	assert.EqualValues(t, tx.Hash(), res.Hash)
+	assert.Greater(t, res.Height, int64(0))
+
+	select {
+	case txRes := <-results:
+		assert.EqualValues(t, tx.Hash(), txRes.Hash)
+		assert.Equal(t, res.Height, txRes.TxResult.Height)
+		assert.EqualValues(t, tx, txRes.TxResult.Tx)
+		assert.EqualValues(t, 0, txRes.TxResult.Result.Code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not receive the tx result within 5s")
+	}
+}
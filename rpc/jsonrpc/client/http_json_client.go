@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strings"
 
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -378,6 +379,10 @@ func makeHTTPDialer(remoteAddr string) (func(string, string) (net.Conn, error),
 	}
 
 	dialFn := func(proto, addr string) (net.Conn, error) {
+		if protocol == protoUNIX {
+			// the errors mention the path of the socket
+			return tmnet.DialUnix(u.GetDialAddress())
+		}
 		return net.Dial(protocol, u.GetDialAddress())
 	}
 
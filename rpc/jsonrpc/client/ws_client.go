@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -128,6 +129,8 @@ func MaxReconnectAttempts(max int) func(*WSClient) {
 }
 
 // ReadWait sets the amount of time to wait before a websocket read times out.
+// Pongs extend the deadline, so with PingPeriod set, a connection is considered
+// dead and the client reconnects when no pong is received within readWait.
 // It should only be used in the constructor and is not Goroutine-safe.
 func ReadWait(readWait time.Duration) func(*WSClient) {
 	return func(c *WSClient) {
@@ -460,6 +463,13 @@ func (c *WSClient) readRoutine() {
 		c.PingPongLatencyTimer.UpdateSince(t)
 
 		c.Logger.Debug("got pong")
+
+		// the connection is alive, even if the server has nothing to send
+		if c.readWait > 0 {
+			if err := c.conn.SetReadDeadline(time.Now().Add(c.readWait)); err != nil {
+				c.Logger.Error("failed to set read deadline", "err", err)
+			}
+		}
 		return nil
 	})
 
@@ -472,7 +482,9 @@ func (c *WSClient) readRoutine() {
 		}
 		_, data, err := c.conn.ReadMessage()
 		if err != nil {
-			if !websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) {
+			// A read timeout means that no pongs were received within readWait,
+			// so the connection is dead and the client must reconnect.
+			if !websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) && !isTimeout(err) {
 				return
 			}
 
@@ -520,6 +532,11 @@ func (c *WSClient) readRoutine() {
 	}
 }
 
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // Predefined methods
 
 // Subscribe to a query. Note the server must have a "subscribe" route
@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -177,6 +179,81 @@ func TestWSClientReconnectFailure(t *testing.T) {
 	}
 }
 
+// silentHandler accepts websocket connections, but never answers pings on the
+// first one, like on a connection silently dropped by a proxy.
+type silentHandler struct {
+	conns int32
+}
+
+func (h *silentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	connNum := atomic.AddInt32(&h.conns, 1)
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+	if connNum == 1 {
+		conn.SetPingHandler(func(string) error { return nil })
+	}
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func TestWSClientReconnectsAfterMissedPongs(t *testing.T) {
+	h := &silentHandler{}
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	reconnected := make(chan struct{}, 1)
+	c, err := NewWS("//"+s.Listener.Addr().String(), "/websocket",
+		PingPeriod(50*time.Millisecond), ReadWait(200*time.Millisecond),
+		OnReconnect(func() {
+			select {
+			case reconnected <- struct{}{}:
+			default:
+			}
+		}))
+	require.NoError(t, err)
+	c.SetLogger(log.TestingLogger())
+	require.NoError(t, c.Start())
+	defer c.Stop() // nolint:errcheck // ignore for tests
+
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not reconnect after missed pongs")
+	}
+	assert.EqualValues(t, 2, atomic.LoadInt32(&h.conns))
+
+	// the new connection is kept alive
+	time.Sleep(500 * time.Millisecond)
+	assert.True(t, c.IsActive())
+	assert.EqualValues(t, 2, atomic.LoadInt32(&h.conns))
+}
+
+func TestWSClientKeepsConnectionWithPongs(t *testing.T) {
+	// the default ping handler of the server answers pongs, which keep the
+	// connection alive while there are no messages to read
+	s := httptest.NewServer(&myHandler{})
+	defer s.Close()
+
+	var reconnects int32
+	c, err := NewWS("//"+s.Listener.Addr().String(), "/websocket",
+		PingPeriod(50*time.Millisecond), ReadWait(200*time.Millisecond),
+		OnReconnect(func() { atomic.AddInt32(&reconnects, 1) }))
+	require.NoError(t, err)
+	c.SetLogger(log.TestingLogger())
+	require.NoError(t, c.Start())
+	defer c.Stop() // nolint:errcheck // ignore for tests
+
+	time.Sleep(time.Second)
+	assert.True(t, c.IsActive())
+	assert.EqualValues(t, 0, atomic.LoadInt32(&reconnects))
+}
+
 func TestNotBlockingOnStop(t *testing.T) {
 	timeout := 2 * time.Second
 	s := httptest.NewServer(&myHandler{})
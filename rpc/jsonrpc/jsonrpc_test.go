@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -304,6 +305,16 @@ func TestServersAndClientsBasic(t *testing.T) {
 	}
 }
 
+func TestUnixSocketClientErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+	cl, err := client.New("unix://" + path)
+	require.NoError(t, err)
+
+	_, err = echoViaHTTP(cl, "hello")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+}
+
 func TestHexStringArg(t *testing.T) {
 	cl, err := client.NewURI(tcpAddr)
 	require.Nil(t, err)
@@ -41,6 +41,8 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		var (
 			requests  []types.RPCRequest
 			responses []types.RPCResponse
+			limiter   = limiterFromRequest(r)
+			httpCode  = http.StatusOK
 		)
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
@@ -93,7 +95,12 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				args = append(args, fnArgs...)
 			}
 
-			returns := rpcFunc.f.Call(args)
+			returns, err := limiter.call(r.RemoteAddr, rpcFunc, args)
+			if err != nil {
+				responses = append(responses, types.RPCLimitExceededError(request.ID, err))
+				httpCode = http.StatusTooManyRequests
+				continue
+			}
 			result, err := unreflectResult(returns)
 			if err != nil {
 				responses = append(responses, types.RPCInternalError(request.ID, err))
@@ -103,7 +110,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		}
 
 		if len(responses) > 0 {
-			if wErr := WriteRPCResponseHTTP(w, responses...); wErr != nil {
+			if wErr := writeRPCResponseHTTP(w, httpCode, responses...); wErr != nil {
 				logger.Error("failed to write responses", "res", responses, "err", wErr)
 			}
 		}
@@ -154,16 +161,20 @@ func arrayParamsToArgs(
 	argsOffset int,
 ) ([]reflect.Value, error) {
 
-	if len(rpcFunc.argNames) != len(params) {
+	if len(params) > len(rpcFunc.argNames) || len(params) < len(rpcFunc.argNames)-rpcFunc.optional {
 		return nil, fmt.Errorf("expected %v parameters (%v), got %v (%v)",
 			len(rpcFunc.argNames), rpcFunc.argNames, len(params), params)
 	}
 
-	values := make([]reflect.Value, len(params))
-	for i, p := range params {
+	values := make([]reflect.Value, len(rpcFunc.argNames))
+	for i := range rpcFunc.argNames {
 		argType := rpcFunc.args[i+argsOffset]
+		if i >= len(params) { // use default for omitted optional argument
+			values[i] = reflect.Zero(argType)
+			continue
+		}
 		val := reflect.New(argType)
-		err := tmjson.Unmarshal(p, val.Interface())
+		err := tmjson.Unmarshal(params[i], val.Interface())
 		if err != nil {
 			return nil, err
 		}
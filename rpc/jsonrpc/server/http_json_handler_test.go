@@ -19,6 +19,8 @@ import (
 func testMux() *http.ServeMux {
 	funcMap := map[string]*RPCFunc{
 		"c": NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
+		"o": NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i",
+			OptionalArgs(1)),
 	}
 	mux := http.NewServeMux()
 	buf := new(bytes.Buffer)
@@ -48,6 +50,8 @@ func TestRPCParams(t *testing.T) {
 		{`{"method": "c", "id": "0", "params": ["a"]}`, "got 1", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": ["a", "b"]}`, "invalid character", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": [1, 1]}`, "of type string", types.JSONRPCStringID("0")},
+		{`{"method": "o", "id": "0", "params": []}`, "got 0", types.JSONRPCStringID("0")},
+		{`{"method": "o", "id": "0", "params": ["a", "10", "b"]}`, "got 3", types.JSONRPCStringID("0")},
 
 		// no ID - notification
 		// {`{"jsonrpc": "2.0", "method": "c", "params": ["a", "10"]}`, false, nil},
@@ -56,6 +60,8 @@ func TestRPCParams(t *testing.T) {
 		{`{"jsonrpc": "2.0", "method": "c", "id": "0", "params": null}`, "", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": {}}`, "", types.JSONRPCStringID("0")},
 		{`{"method": "c", "id": "0", "params": ["a", "10"]}`, "", types.JSONRPCStringID("0")},
+		{`{"method": "o", "id": "0", "params": ["a"]}`, "", types.JSONRPCStringID("0")},
+		{`{"method": "o", "id": "0", "params": ["a", "10"]}`, "", types.JSONRPCStringID("0")},
 	}
 
 	for i, tt := range tests {
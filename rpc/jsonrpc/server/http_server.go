@@ -13,15 +13,14 @@ import (
 	"strings"
 	"time"
 
-	"golang.org/x/net/netutil"
-
 	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
 // Config is a RPC server configuration.
 type Config struct {
-	// see netutil.LimitListener
+	// see LimitListener
 	MaxOpenConnections int
 	// mirrors http.Server#ReadTimeout
 	ReadTimeout time.Duration
@@ -32,6 +31,22 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// MaxConcurrentRequestsPerIP limits the number of requests processed at
+	// the same time for a single remote IP. 0 means unlimited.
+	MaxConcurrentRequestsPerIP int
+	// RateLimitedRequestsPerSecond limits the rate, at which a single remote
+	// IP can call the functions created with the RateLimited option. 0 means
+	// unlimited.
+	RateLimitedRequestsPerSecond float64
+	// RateLimitedRequestsBurst is the number of calls to the rate limited
+	// functions a single remote IP can make at once.
+	RateLimitedRequestsBurst int
+	// MaxSubscriptionsPerConnection limits the number of queries a single
+	// websocket connection can be subscribed to. 0 means unlimited.
+	MaxSubscriptionsPerConnection int
+	// UnixSocketMode is the file mode of the unix socket the server listens
+	// on. 0 means the mode given by the umask.
+	UnixSocketMode os.FileMode
 }
 
 // DefaultConfig returns a default configuration.
@@ -46,14 +61,16 @@ func DefaultConfig() *Config {
 }
 
 // Serve creates a http.Server and calls Serve with the given listener. It
-// wraps handler with RecoverAndLogHandler and a handler, which limits the max
-// body size to config.MaxBodyBytes.
+// wraps handler with RecoverAndLogHandler, a handler, which limits the max
+// body size to config.MaxBodyBytes, and a handler, which enforces the per
+// client limits of config.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func Serve(listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
+	handler = maxBytesHandler{h: limitsHandler(handler, config), n: config.MaxBodyBytes}
 	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:        RecoverAndLogHandler(handler, logger),
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
@@ -64,8 +81,9 @@ func Serve(listener net.Listener, handler http.Handler, logger log.Logger, confi
 }
 
 // Serve creates a http.Server and calls ServeTLS with the given listener,
-// certFile and keyFile. It wraps handler with RecoverAndLogHandler and a
-// handler, which limits the max body size to config.MaxBodyBytes.
+// certFile and keyFile. It wraps handler with RecoverAndLogHandler, a handler,
+// which limits the max body size to config.MaxBodyBytes, and a handler, which
+// enforces the per client limits of config.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func ServeTLS(
@@ -77,8 +95,9 @@ func ServeTLS(
 ) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
+	handler = maxBytesHandler{h: limitsHandler(handler, config), n: config.MaxBodyBytes}
 	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:        RecoverAndLogHandler(handler, logger),
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
@@ -115,6 +134,10 @@ func WriteRPCResponseHTTPError(
 
 // WriteRPCResponseHTTP marshals res as JSON (with indent) and writes it to w.
 func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error {
+	return writeRPCResponseHTTP(w, http.StatusOK, res...)
+}
+
+func writeRPCResponseHTTP(w http.ResponseWriter, httpCode int, res ...types.RPCResponse) error {
 	var v interface{}
 	if len(res) == 1 {
 		v = res[0]
@@ -127,7 +150,7 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error
 		return fmt.Errorf("json marshal: %w", err)
 	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(httpCode)
 	_, err = w.Write(jsonBytes)
 	return err
 }
@@ -236,8 +259,11 @@ func (h maxBytesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.h.ServeHTTP(w, r)
 }
 
-// Listen starts a new net.Listener on the given address.
+// Listen starts a new net.Listener on the given address. The listener is a
+// *LimitListener, so the limit of open connections can be changed later.
 // It returns an error if the address is invalid or the call to Listen() fails.
+// A unix socket gets the mode of config.UnixSocketMode, and its stale file is
+// removed first (see tmnet.ListenUnix).
 func Listen(addr string, config *Config) (listener net.Listener, err error) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
@@ -247,13 +273,18 @@ func Listen(addr string, config *Config) (listener net.Listener, err error) {
 		)
 	}
 	proto, addr := parts[0], parts[1]
+	if proto == "unix" {
+		// the errors mention the path of the socket
+		listener, err = tmnet.ListenUnix(addr, config.UnixSocketMode)
+		if err != nil {
+			return nil, err
+		}
+		return NewLimitListener(listener, config.MaxOpenConnections), nil
+	}
 	listener, err = net.Listen(proto, addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %v: %v", addr, err)
 	}
-	if config.MaxOpenConnections > 0 {
-		listener = netutil.LimitListener(listener, config.MaxOpenConnections)
-	}
 
-	return listener, nil
+	return NewLimitListener(listener, config.MaxOpenConnections), nil
 }
@@ -8,6 +8,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -107,6 +109,32 @@ func TestServeTLS(t *testing.T) {
 	assert.Equal(t, []byte("some body"), body)
 }
 
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rpc.sock")
+
+	// a stale socket file of a previous run
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	config := DefaultConfig()
+	config.UnixSocketMode = 0660
+	ln, err := Listen("unix://"+path, config)
+	require.NoError(t, err)
+	defer ln.Close()
+	assert.IsType(t, &LimitListener{}, ln)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+
+	// the socket is in use now
+	_, err = Listen("unix://"+path, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+}
+
 func TestWriteRPCResponseHTTP(t *testing.T) {
 	id := types.JSONRPCIntID(-1)
 
@@ -51,7 +51,14 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 		args = append(args, fnArgs...)
 
-		returns := rpcFunc.f.Call(args)
+		returns, err := limiterFromRequest(r).call(r.RemoteAddr, rpcFunc, args)
+		if err != nil {
+			res := types.RPCLimitExceededError(dummyID, err)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusTooManyRequests, res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
+			}
+			return
+		}
 
 		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net"
+	"sync"
+)
+
+// LimitListener is a net.Listener, which accepts at most limit simultaneous
+// connections from the wrapped listener. Unlike netutil.LimitListener, the
+// limit can be changed while the listener is in use. 0 means unlimited.
+type LimitListener struct {
+	net.Listener
+
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	open   int
+	closed bool
+}
+
+// NewLimitListener returns a LimitListener, which accepts at most limit
+// simultaneous connections from l. 0 means unlimited.
+func NewLimitListener(l net.Listener, limit int) *LimitListener {
+	ll := &LimitListener{Listener: l, limit: limit}
+	ll.cond = sync.NewCond(&ll.mtx)
+	return ll
+}
+
+// SetLimit changes the maximum number of simultaneous connections. Lowering
+// the limit doesn't close the connections, which are already open; new ones
+// are accepted once enough of them are closed. 0 means unlimited.
+func (l *LimitListener) SetLimit(limit int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.limit = limit
+	l.cond.Broadcast()
+}
+
+// Accept waits until the number of open connections is below the limit and
+// then accepts the next connection from the wrapped listener.
+func (l *LimitListener) Accept() (net.Conn, error) {
+	l.acquire()
+	c, err := l.Listener.Accept()
+	if err != nil {
+		l.release()
+		return nil, err
+	}
+	return &limitListenerConn{Conn: c, release: l.release}, nil
+}
+
+// Close closes the wrapped listener and unblocks the pending Accept calls.
+func (l *LimitListener) Close() error {
+	err := l.Listener.Close()
+	l.mtx.Lock()
+	l.closed = true
+	l.cond.Broadcast()
+	l.mtx.Unlock()
+	return err
+}
+
+// acquire waits for a free slot. Once the listener is closed, it returns
+// immediately, so that Accept returns the error of the wrapped listener.
+func (l *LimitListener) acquire() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	for !l.closed && l.limit > 0 && l.open >= l.limit {
+		l.cond.Wait()
+	}
+	l.open++
+}
+
+func (l *LimitListener) release() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.open--
+	l.cond.Signal()
+}
+
+// limitListenerConn releases its slot of the LimitListener, when it's closed
+// for the first time.
+type limitListenerConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitListenerConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
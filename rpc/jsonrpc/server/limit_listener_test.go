@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitListenerSetLimit(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	l := NewLimitListener(ln, 1)
+	defer l.Close()
+
+	accepted := make(chan net.Conn, 3)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		c, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer c.Close()
+	}
+
+	first := <-accepted
+	select {
+	case <-accepted:
+		t.Fatal("accepted a connection over the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// raising the limit accepts one more connection
+	l.SetLimit(2)
+	<-accepted
+	select {
+	case <-accepted:
+		t.Fatal("accepted a connection over the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// closing a connection twice frees a single slot
+	require.NoError(t, first.Close())
+	assert.Error(t, first.Close())
+	<-accepted
+
+	// removing the limit doesn't block anymore
+	l.SetLimit(0)
+	c, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer c.Close()
+	select {
+	case <-accepted:
+	case <-time.After(time.Second):
+		t.Fatal("connection not accepted without a limit")
+	}
+}
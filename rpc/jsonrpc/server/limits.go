@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"reflect"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// maxRateLimitedIPs is the number of remote IPs, whose token buckets are kept
+// before the full ones are dropped.
+const maxRateLimitedIPs = 10000
+
+var (
+	errTooManyConcurrentRequests = errors.New("too many concurrent requests")
+	errRateLimited               = errors.New("rate limit exceeded")
+	errTooManySubscriptions      = errors.New("too many subscriptions")
+)
+
+// limiter enforces the per remote IP limits of Config. A nil limiter does not
+// limit anything.
+type limiter struct {
+	maxConcurrentRequests int
+	maxSubscriptions      int
+	rate                  float64
+	burst                 int
+
+	mtx      tmsync.Mutex
+	inFlight map[string]int
+	buckets  map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter returns a limiter for the given config or nil, if the config does
+// not set any limits.
+func newLimiter(config *Config) *limiter {
+	if config.MaxConcurrentRequestsPerIP <= 0 &&
+		config.MaxSubscriptionsPerConnection <= 0 &&
+		config.RateLimitedRequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := config.RateLimitedRequestsBurst
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		maxConcurrentRequests: config.MaxConcurrentRequestsPerIP,
+		maxSubscriptions:      config.MaxSubscriptionsPerConnection,
+		rate:                  config.RateLimitedRequestsPerSecond,
+		burst:                 burst,
+		inFlight:              make(map[string]int),
+		buckets:               make(map[string]*tokenBucket),
+	}
+}
+
+// call calls rpcFunc with args for the client at remoteAddr. It returns an
+// error without calling rpcFunc, if the client exceeds the limits.
+func (l *limiter) call(remoteAddr string, rpcFunc *RPCFunc, args []reflect.Value) ([]reflect.Value, error) {
+	if l == nil {
+		return rpcFunc.f.Call(args), nil
+	}
+
+	ip := remoteIP(remoteAddr)
+	if err := l.acquire(ip, rpcFunc); err != nil {
+		return nil, err
+	}
+	defer l.release(ip)
+
+	return rpcFunc.f.Call(args), nil
+}
+
+func (l *limiter) acquire(ip string, rpcFunc *RPCFunc) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.maxConcurrentRequests > 0 && l.inFlight[ip] >= l.maxConcurrentRequests {
+		return errTooManyConcurrentRequests
+	}
+	if rpcFunc.rateLimited && l.rate > 0 && !l.take(ip, time.Now()) {
+		return errRateLimited
+	}
+	l.inFlight[ip]++
+	return nil
+}
+
+func (l *limiter) release(ip string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.inFlight[ip]--
+	if l.inFlight[ip] <= 0 {
+		delete(l.inFlight, ip)
+	}
+}
+
+// take takes a token from the bucket of ip. It returns false, if the bucket is
+// empty. Must be called with the mutex held.
+func (l *limiter) take(ip string, now time.Time) bool {
+	b, ok := l.buckets[ip]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitedIPs {
+			l.dropFullBuckets(now)
+		}
+		b = &tokenBucket{tokens: float64(l.burst), last: now}
+		l.buckets[ip] = b
+	}
+
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *limiter) refill(b *tokenBucket, now time.Time) float64 {
+	return math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+}
+
+// dropFullBuckets forgets the IPs, which did not call a rate limited function
+// for long enough to refill their buckets.
+func (l *limiter) dropFullBuckets(now time.Time) {
+	for ip, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.burst) {
+			delete(l.buckets, ip)
+		}
+	}
+}
+
+// remoteIP strips the port from remoteAddr. The clients connected to a unix
+// socket share the same (empty) IP.
+func remoteIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+type limiterKey struct{}
+
+// limitsHandler passes the limiter of config to the handlers in the request
+// context.
+func limitsHandler(h http.Handler, config *Config) http.Handler {
+	l := newLimiter(config)
+	if l == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), limiterKey{}, l)))
+	})
+}
+
+func limiterFromRequest(r *http.Request) *limiter {
+	l, _ := r.Context().Value(limiterKey{}).(*limiter)
+	return l
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const limitExceededCode = -32005
+
+func newLimitsServer(config *Config, funcMap map[string]*RPCFunc) *httptest.Server {
+	wm := NewWebsocketManager(funcMap)
+	wm.SetLogger(log.TestingLogger())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+
+	return httptest.NewServer(limitsHandler(mux, config))
+}
+
+func readRPCResponse(t *testing.T, res *http.Response) types.RPCResponse {
+	defer res.Body.Close()
+	blob, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	var resp types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &resp), string(blob))
+	return resp
+}
+
+func TestRateLimit(t *testing.T) {
+	config := DefaultConfig()
+	config.RateLimitedRequestsPerSecond = 0.001
+	config.RateLimitedRequestsBurst = 2
+	s := newLimitsServer(config, map[string]*RPCFunc{
+		"expensive": NewRPCFunc(func(ctx *types.Context) (string, error) { return "", nil }, "", RateLimited()),
+		"cheap":     NewRPCFunc(func(ctx *types.Context) (string, error) { return "", nil }, ""),
+	})
+	defer s.Close()
+
+	for i := 0; i < 2; i++ {
+		res, err := http.Get(s.URL + "/expensive")
+		require.NoError(t, err)
+		resp := readRPCResponse(t, res)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Nil(t, resp.Error)
+	}
+
+	// URI
+	res, err := http.Get(s.URL + "/expensive")
+	require.NoError(t, err)
+	resp := readRPCResponse(t, res)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, limitExceededCode, resp.Error.Code)
+	assert.Equal(t, errRateLimited.Error(), resp.Error.Data)
+
+	// JSON-RPC
+	res, err = http.Post(s.URL, "application/json",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"expensive","params":{}}`))
+	require.NoError(t, err)
+	resp = readRPCResponse(t, res)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, limitExceededCode, resp.Error.Code)
+
+	// the functions without the option are not limited
+	res, err = http.Get(s.URL + "/cheap")
+	require.NoError(t, err)
+	resp = readRPCResponse(t, res)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Nil(t, resp.Error)
+}
+
+func TestMaxConcurrentRequestsPerIP(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxConcurrentRequestsPerIP = 1
+	started, unblock := make(chan struct{}), make(chan struct{})
+	s := newLimitsServer(config, map[string]*RPCFunc{
+		"block": NewRPCFunc(func(ctx *types.Context) (string, error) {
+			close(started)
+			<-unblock
+			return "", nil
+		}, ""),
+		"echo": NewRPCFunc(func(ctx *types.Context) (string, error) { return "", nil }, ""),
+	})
+	defer s.Close()
+
+	blockedRes := make(chan *http.Response, 1)
+	go func() {
+		res, err := http.Get(s.URL + "/block")
+		if err != nil {
+			panic(err)
+		}
+		blockedRes <- res
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the blocking request was not started within 5s")
+	}
+
+	// URI
+	res, err := http.Get(s.URL + "/echo")
+	require.NoError(t, err)
+	resp := readRPCResponse(t, res)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, limitExceededCode, resp.Error.Code)
+	assert.Equal(t, errTooManyConcurrentRequests.Error(), resp.Error.Data)
+
+	// JSON-RPC
+	res, err = http.Post(s.URL, "application/json",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"echo","params":{}}`))
+	require.NoError(t, err)
+	resp = readRPCResponse(t, res)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, limitExceededCode, resp.Error.Code)
+
+	close(unblock)
+	resp = readRPCResponse(t, <-blockedRes)
+	assert.Nil(t, resp.Error)
+
+	res, err = http.Get(s.URL + "/echo")
+	require.NoError(t, err)
+	resp = readRPCResponse(t, res)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Nil(t, resp.Error)
+}
+
+func TestMaxSubscriptionsPerConnection(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxSubscriptionsPerConnection = 1
+	ok := func(ctx *types.Context, query string) (string, error) { return "", nil }
+	s := newLimitsServer(config, map[string]*RPCFunc{
+		"subscribe":       NewWSRPCFunc(ok, "query", Subscribes("query")),
+		"unsubscribe":     NewWSRPCFunc(ok, "query", Unsubscribes("query")),
+		"unsubscribe_all": NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "", nil }, "", UnsubscribesAll()),
+	})
+	defer s.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	call := func(method, query string) *types.RPCError {
+		req, err := types.MapToRequest(types.JSONRPCStringID(method), method, map[string]interface{}{"query": query})
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		return resp.Error
+	}
+
+	require.Nil(t, call("subscribe", "a"))
+	// subscribing to the same query again does not count
+	require.Nil(t, call("subscribe", "a"))
+
+	rpcErr := call("subscribe", "b")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, limitExceededCode, rpcErr.Code)
+	assert.Equal(t, errTooManySubscriptions.Error(), rpcErr.Data)
+
+	require.Nil(t, call("unsubscribe", "a"))
+	require.Nil(t, call("subscribe", "b"))
+
+	require.Nil(t, call("unsubscribe_all", ""))
+	require.Nil(t, call("subscribe", "c"))
+}
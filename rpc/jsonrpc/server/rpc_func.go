@@ -31,32 +31,109 @@ type RPCFunc struct {
 	args     []reflect.Type // type of each function arg
 	returns  []reflect.Type // type of each return arg
 	argNames []string       // name of each argument
+	optional int            // number of trailing arguments, which can be omitted
 	ws       bool           // websocket only
+
+	rateLimited  bool           // calls are limited by Config.RateLimitedRequestsPerSecond
+	subscription subscriptionOp // how the function changes the subscriptions of the connection
+	queryArg     int            // index of the query argument of (un)subscribe functions
+}
+
+type subscriptionOp int
+
+const (
+	subscriptionNone subscriptionOp = iota
+	subscriptionAdd
+	subscriptionRemove
+	subscriptionRemoveAll
+)
+
+// RPCFuncOption configures an RPCFunc.
+type RPCFuncOption func(*RPCFunc)
+
+// OptionalArgs allows to omit the last n arguments, when a function is called
+// with positional params. The omitted arguments get zero values, like missing
+// named params do. It is used to add new arguments to existing functions
+// without breaking the clients.
+func OptionalArgs(n int) RPCFuncOption {
+	return func(rf *RPCFunc) {
+		rf.optional = n
+	}
+}
+
+// RateLimited marks an expensive function, whose calls are limited by
+// Config.RateLimitedRequestsPerSecond.
+func RateLimited() RPCFuncOption {
+	return func(rf *RPCFunc) {
+		rf.rateLimited = true
+	}
+}
+
+// Subscribes marks a websocket function, which subscribes the connection to
+// the query passed in the argument queryArg. The number of queries a
+// connection is subscribed to is limited by Config.MaxSubscriptionsPerConnection.
+func Subscribes(queryArg string) RPCFuncOption {
+	return func(rf *RPCFunc) {
+		rf.subscription = subscriptionAdd
+		rf.queryArg = rf.argIndex(queryArg)
+	}
+}
+
+// Unsubscribes marks a websocket function, which unsubscribes the connection
+// from the query passed in the argument queryArg.
+func Unsubscribes(queryArg string) RPCFuncOption {
+	return func(rf *RPCFunc) {
+		rf.subscription = subscriptionRemove
+		rf.queryArg = rf.argIndex(queryArg)
+	}
+}
+
+// UnsubscribesAll marks a websocket function, which unsubscribes the
+// connection from all the queries.
+func UnsubscribesAll() RPCFuncOption {
+	return func(rf *RPCFunc) {
+		rf.subscription = subscriptionRemoveAll
+	}
+}
+
+// argIndex returns the index of the argument name. It panics if the function
+// has no such argument.
+func (rf *RPCFunc) argIndex(name string) int {
+	for i, argName := range rf.argNames {
+		if argName == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("no argument %q in %v", name, rf.argNames))
 }
 
 // NewRPCFunc wraps a function for introspection.
 // f is the function, args are comma separated argument names
-func NewRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, false)
+func NewRPCFunc(f interface{}, args string, options ...RPCFuncOption) *RPCFunc {
+	return newRPCFunc(f, args, false, options...)
 }
 
 // NewWSRPCFunc wraps a function for introspection and use in the websockets.
-func NewWSRPCFunc(f interface{}, args string) *RPCFunc {
-	return newRPCFunc(f, args, true)
+func NewWSRPCFunc(f interface{}, args string, options ...RPCFuncOption) *RPCFunc {
+	return newRPCFunc(f, args, true, options...)
 }
 
-func newRPCFunc(f interface{}, args string, ws bool) *RPCFunc {
+func newRPCFunc(f interface{}, args string, ws bool, options ...RPCFuncOption) *RPCFunc {
 	var argNames []string
 	if args != "" {
 		argNames = strings.Split(args, ",")
 	}
-	return &RPCFunc{
+	rf := &RPCFunc{
 		f:        reflect.ValueOf(f),
 		args:     funcArgTypes(f),
 		returns:  funcReturnTypes(f),
 		argNames: argNames,
 		ws:       ws,
 	}
+	for _, opt := range options {
+		opt(rf)
+	}
+	return rf
 }
 
 // return a function's argument types
@@ -86,6 +86,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.limiter = limiterFromRequest(r)
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -136,6 +137,12 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// limits of the server, nil if unlimited
+	limiter *limiter
+
+	// queries the connection is subscribed to, only accessed by readRoutine
+	subscriptions map[string]struct{}
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -160,6 +167,7 @@ func newWSConnection(
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
 		readRoutineQuit:   make(chan struct{}),
+		subscriptions:     make(map[string]struct{}),
 	}
 	for _, option := range options {
 		option(wsc)
@@ -378,7 +386,20 @@ func (wsc *wsConnection) readRoutine() {
 				args = append(args, fnArgs...)
 			}
 
-			returns := rpcFunc.f.Call(args)
+			if err := wsc.checkSubscriptions(rpcFunc, args); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCLimitExceededError(request.ID, err)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
+			returns, err := wsc.limiter.call(wsc.remoteAddr, rpcFunc, args)
+			if err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCLimitExceededError(request.ID, err)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
 
 			// TODO: Need to encode args/returns to string if we want to log them
 			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
@@ -391,6 +412,8 @@ func (wsc *wsConnection) readRoutine() {
 				continue
 			}
 
+			wsc.trackSubscriptions(rpcFunc, args)
+
 			if err := wsc.WriteRPCResponse(writeCtx, types.NewRPCSuccessResponse(request.ID, result)); err != nil {
 				wsc.Logger.Error("Error writing RPC response", "err", err)
 			}
@@ -398,6 +421,43 @@ func (wsc *wsConnection) readRoutine() {
 	}
 }
 
+// checkSubscriptions returns an error, if calling rpcFunc would subscribe the
+// connection to more queries than allowed.
+func (wsc *wsConnection) checkSubscriptions(rpcFunc *RPCFunc, args []reflect.Value) error {
+	if rpcFunc.subscription != subscriptionAdd || wsc.limiter == nil || wsc.limiter.maxSubscriptions <= 0 {
+		return nil
+	}
+	if _, ok := wsc.subscriptions[queryArg(rpcFunc, args)]; ok {
+		return nil
+	}
+	if len(wsc.subscriptions) >= wsc.limiter.maxSubscriptions {
+		return errTooManySubscriptions
+	}
+	return nil
+}
+
+// trackSubscriptions updates the subscriptions of the connection after a
+// successful call of rpcFunc.
+func (wsc *wsConnection) trackSubscriptions(rpcFunc *RPCFunc, args []reflect.Value) {
+	switch rpcFunc.subscription {
+	case subscriptionAdd:
+		wsc.subscriptions[queryArg(rpcFunc, args)] = struct{}{}
+	case subscriptionRemove:
+		delete(wsc.subscriptions, queryArg(rpcFunc, args))
+	case subscriptionRemoveAll:
+		wsc.subscriptions = make(map[string]struct{})
+	}
+}
+
+func queryArg(rpcFunc *RPCFunc, args []reflect.Value) string {
+	// skip types.Context
+	i := 1 + rpcFunc.queryArg
+	if i >= len(args) || args[i].Kind() != reflect.String {
+		return ""
+	}
+	return args[i].String()
+}
+
 // receives on a write channel and writes out on the socket
 func (wsc *wsConnection) writeRoutine() {
 	pingTicker := time.NewTicker(wsc.pingPeriod)
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -137,10 +138,50 @@ func ArrayToRequest(id jsonrpcid, method string, params []interface{}) (RPCReque
 //----------------------------------------
 // RESPONSE
 
+// RPCError is the error of a JSON-RPC response. Its data is a string, except
+// for the errors implementing ErrorWithData, whose data is a JSON value kept in
+// StructuredData.
 type RPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Data    string `json:"data,omitempty"`
+	// the data of the error, if it isn't a string
+	StructuredData json.RawMessage `json:"-"`
+}
+
+// rpcErrorJSON is the JSON encoding of RPCError, with either kind of data.
+type rpcErrorJSON struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (err RPCError) MarshalJSON() ([]byte, error) {
+	e := rpcErrorJSON{Code: err.Code, Message: err.Message, Data: err.StructuredData}
+	if len(e.Data) == 0 && err.Data != "" {
+		data, jsonErr := json.Marshal(err.Data)
+		if jsonErr != nil {
+			return nil, jsonErr
+		}
+		e.Data = data
+	}
+	return json.Marshal(e)
+}
+
+func (err *RPCError) UnmarshalJSON(data []byte) error {
+	var e rpcErrorJSON
+	if jsonErr := json.Unmarshal(data, &e); jsonErr != nil {
+		return jsonErr
+	}
+	*err = RPCError{Code: e.Code, Message: e.Message}
+	switch {
+	case len(e.Data) == 0 || string(e.Data) == "null":
+	case e.Data[0] == '"':
+		return json.Unmarshal(e.Data, &err.Data)
+	default:
+		err.StructuredData = e.Data
+	}
+	return nil
 }
 
 func (err RPCError) Error() string {
@@ -148,9 +189,23 @@ func (err RPCError) Error() string {
 	if err.Data != "" {
 		return fmt.Sprintf(baseFormat+": %s", err.Code, err.Message, err.Data)
 	}
+	if len(err.StructuredData) > 0 {
+		return fmt.Sprintf(baseFormat+": %s", err.Code, err.Message, err.StructuredData)
+	}
 	return fmt.Sprintf(baseFormat, err.Code, err.Message)
 }
 
+// ErrorWithData is implemented by the errors, which are returned to the
+// clients with their own code, and their fields as the data of the error, so
+// that the clients can restore them.
+type ErrorWithData interface {
+	error
+	// RPCErrorCode returns the JSON-RPC error code of the error.
+	RPCErrorCode() int
+	// RPCErrorData returns the data of the error, which is encoded as JSON.
+	RPCErrorData() interface{}
+}
+
 type RPCResponse struct {
 	JSONRPC string          `json:"jsonrpc"`
 	ID      jsonrpcid       `json:"id,omitempty"`
@@ -236,7 +291,20 @@ func RPCInvalidParamsError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32602, "Invalid params", err.Error())
 }
 
+// RPCInternalError returns the error of the request as an internal error,
+// unless the error implements ErrorWithData, in which case its own code, its
+// message and its data are returned.
 func RPCInternalError(id jsonrpcid, err error) RPCResponse {
+	var errWithData ErrorWithData
+	if errors.As(err, &errWithData) {
+		if data, jsonErr := json.Marshal(errWithData.RPCErrorData()); jsonErr == nil {
+			return RPCResponse{
+				JSONRPC: "2.0",
+				ID:      id,
+				Error:   &RPCError{Code: errWithData.RPCErrorCode(), Message: err.Error(), StructuredData: data},
+			}
+		}
+	}
 	return NewRPCErrorResponse(id, -32603, "Internal error", err.Error())
 }
 
@@ -244,6 +312,12 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
 
+// RPCLimitExceededError is returned when a client exceeds one of the limits of
+// the server, e.g. the rate limit.
+func RPCLimitExceededError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32005, "Limit exceeded", err.Error())
+}
+
 //----------------------------------------
 
 // WSRPCConnection represents a websocket connection.
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type SampleResult struct {
@@ -81,3 +82,34 @@ func TestRPCError(t *testing.T) {
 			Message: "Badness",
 		}))
 }
+
+type sampleErrorWithData struct {
+	Height int64 `json:"height"`
+}
+
+func (e sampleErrorWithData) Error() string             { return fmt.Sprintf("height %d is bad", e.Height) }
+func (e sampleErrorWithData) RPCErrorCode() int         { return -32001 }
+func (e sampleErrorWithData) RPCErrorData() interface{} { return e }
+
+func TestRPCErrorWithData(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", sampleErrorWithData{Height: 5})
+	a := RPCInternalError(JSONRPCIntID(1), err)
+	b, jsonErr := json.Marshal(a)
+	require.NoError(t, jsonErr)
+	assert.Equal(t,
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"wrapped: height 5 is bad","data":{"height":5}}}`,
+		string(b))
+
+	response := &RPCResponse{}
+	require.NoError(t, json.Unmarshal(b, response))
+	assert.Equal(t, a, *response)
+
+	// the errors without data are still returned as internal errors with string data
+	a = RPCInternalError(JSONRPCIntID(1), errors.New("hello world"))
+	b, jsonErr = json.Marshal(a)
+	require.NoError(t, jsonErr)
+	response = &RPCResponse{}
+	require.NoError(t, json.Unmarshal(b, response))
+	assert.Equal(t, a, *response)
+	assert.Equal(t, "hello world", response.Error.Data)
+}
@@ -0,0 +1,208 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/tempfile"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
+
+// ResultsDump is the dump of the ABCI responses of a block, the results the
+// app hash of the next block is computed from. The node writes it when the
+// app hash of the next block doesn't match its own, so the results can be
+// compared with the ones of another node (see DiffResults).
+type ResultsDump struct {
+	ChainID string
+	Height  int64
+	// the app hash the node computed from the results
+	AppHash tmbytes.HexBytes
+	// the app hash of the next block, if it doesn't match
+	ExpectedAppHash tmbytes.HexBytes
+	ABCIResponses   *tmstate.ABCIResponses
+}
+
+// resultsDumpJSON is the JSON of a ResultsDump. The ABCI responses are
+// proto-JSON, so the dumps of different versions can be compared.
+type resultsDumpJSON struct {
+	ChainID         string           `json:"chain_id"`
+	Height          int64            `json:"height,string"`
+	AppHash         tmbytes.HexBytes `json:"app_hash"`
+	ExpectedAppHash tmbytes.HexBytes `json:"expected_app_hash,omitempty"`
+	ABCIResponses   json.RawMessage  `json:"abci_responses"`
+}
+
+func (dump ResultsDump) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	marshaler := jsonpb.Marshaler{OrigName: true, Indent: "  "}
+	if err := marshaler.Marshal(&buf, dump.ABCIResponses); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(resultsDumpJSON{
+		ChainID:         dump.ChainID,
+		Height:          dump.Height,
+		AppHash:         dump.AppHash,
+		ExpectedAppHash: dump.ExpectedAppHash,
+		ABCIResponses:   buf.Bytes(),
+	}, "", "  ")
+}
+
+func (dump *ResultsDump) UnmarshalJSON(data []byte) error {
+	var dumpJSON resultsDumpJSON
+	if err := json.Unmarshal(data, &dumpJSON); err != nil {
+		return err
+	}
+	abciResponses := new(tmstate.ABCIResponses)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(bytes.NewReader(dumpJSON.ABCIResponses), abciResponses); err != nil {
+		return fmt.Errorf("wrong abci_responses: %w", err)
+	}
+	*dump = ResultsDump{
+		ChainID:         dumpJSON.ChainID,
+		Height:          dumpJSON.Height,
+		AppHash:         dumpJSON.AppHash,
+		ExpectedAppHash: dumpJSON.ExpectedAppHash,
+		ABCIResponses:   abciResponses,
+	}
+	return nil
+}
+
+// ResultsDumpFile returns the path of the dump of the results of the block at
+// the height in the directory.
+func ResultsDumpFile(dir string, height int64) string {
+	return filepath.Join(dir, fmt.Sprintf("results-%d.json", height))
+}
+
+// WriteResultsDump writes the dump to the file, creating its directory if
+// needed.
+func WriteResultsDump(file string, dump ResultsDump) error {
+	data, err := json.Marshal(dump)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(file, data, 0600)
+}
+
+// ReadResultsDump reads the dump from the file.
+func ReadResultsDump(file string) (ResultsDump, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return ResultsDump{}, err
+	}
+	var dump ResultsDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return ResultsDump{}, fmt.Errorf("failed to read the results dump %s: %w", file, err)
+	}
+	return dump, nil
+}
+
+// ResultsDiff is the first difference between the results of a block.
+type ResultsDiff struct {
+	// the index of the first DeliverTx result, which differs, -1 if they
+	// match
+	TxIndex int
+	// the results of the tx, nil if there's no such tx
+	Local, Other *abci.ResponseDeliverTx
+	// the index of the first event of the tx, which differs, -1 if they
+	// match
+	EventIndex int
+	// what differs, empty if the results match
+	Reason string
+}
+
+// Equal returns whether the results match.
+func (diff ResultsDiff) Equal() bool {
+	return diff.Reason == ""
+}
+
+// DiffResults compares the results of a block computed by two nodes tx by tx,
+// and returns the first difference. The txs are compared before BeginBlock
+// and EndBlock, as the txs are where the app state usually diverges.
+func DiffResults(local, other *tmstate.ABCIResponses) ResultsDiff {
+	diff := ResultsDiff{TxIndex: -1, EventIndex: -1}
+
+	for i := 0; i < len(local.DeliverTxs) || i < len(other.DeliverTxs); i++ {
+		if i >= len(local.DeliverTxs) || i >= len(other.DeliverTxs) {
+			diff.TxIndex = i
+			if i < len(local.DeliverTxs) {
+				diff.Local = local.DeliverTxs[i]
+			} else {
+				diff.Other = other.DeliverTxs[i]
+			}
+			diff.Reason = fmt.Sprintf("the number of txs differs: %d != %d",
+				len(local.DeliverTxs), len(other.DeliverTxs))
+			return diff
+		}
+
+		l, o := local.DeliverTxs[i], other.DeliverTxs[i]
+		if proto.Equal(l, o) {
+			continue
+		}
+		diff.TxIndex = i
+		diff.Local, diff.Other = l, o
+		diff.EventIndex = diffEvents(l.Events, o.Events)
+		diff.Reason = "the tx results differ in " + strings.Join(diffDeliverTx(l, o), ", ")
+		return diff
+	}
+
+	if !proto.Equal(local.BeginBlock, other.BeginBlock) {
+		diff.Reason = "the BeginBlock results differ"
+	} else if !proto.Equal(local.EndBlock, other.EndBlock) {
+		diff.Reason = "the EndBlock results differ"
+	}
+	return diff
+}
+
+// diffDeliverTx returns the names of the fields, which differ.
+func diffDeliverTx(l, o *abci.ResponseDeliverTx) []string {
+	var fields []string
+	if l.Code != o.Code {
+		fields = append(fields, "code")
+	}
+	if !bytes.Equal(l.Data, o.Data) {
+		fields = append(fields, "data")
+	}
+	if l.Log != o.Log {
+		fields = append(fields, "log")
+	}
+	if l.Info != o.Info {
+		fields = append(fields, "info")
+	}
+	if l.GasWanted != o.GasWanted {
+		fields = append(fields, "gas_wanted")
+	}
+	if l.GasUsed != o.GasUsed {
+		fields = append(fields, "gas_used")
+	}
+	if diffEvents(l.Events, o.Events) >= 0 {
+		fields = append(fields, "events")
+	}
+	if l.Codespace != o.Codespace {
+		fields = append(fields, "codespace")
+	}
+	return fields
+}
+
+// diffEvents returns the index of the first event, which differs, -1 if they
+// match.
+func diffEvents(l, o []abci.Event) int {
+	for i := 0; i < len(l) || i < len(o); i++ {
+		if i >= len(l) || i >= len(o) || !proto.Equal(&l[i], &o[i]) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,98 @@
+package state_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+func testResults() *tmstate.ABCIResponses {
+	deliverTx := func(key string) *abci.ResponseDeliverTx {
+		return &abci.ResponseDeliverTx{
+			Data:      []byte(key),
+			GasWanted: 1,
+			GasUsed:   1,
+			Events: []abci.Event{
+				{Type: "app", Attributes: []abci.EventAttribute{{Key: []byte("key"), Value: []byte(key), Index: true}}},
+				{Type: "fee", Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte("1")}}},
+			},
+		}
+	}
+	return &tmstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		DeliverTxs: []*abci.ResponseDeliverTx{deliverTx("a"), deliverTx("b"), deliverTx("c")},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}
+}
+
+func TestResultsDump(t *testing.T) {
+	dump := sm.ResultsDump{
+		ChainID:         "test",
+		Height:          100,
+		AppHash:         []byte{1, 2, 3},
+		ExpectedAppHash: []byte{4, 5, 6},
+		ABCIResponses:   testResults(),
+	}
+	file := sm.ResultsDumpFile(filepath.Join(t.TempDir(), "diagnostics"), dump.Height)
+	require.NoError(t, sm.WriteResultsDump(file, dump))
+
+	read, err := sm.ReadResultsDump(file)
+	require.NoError(t, err)
+	assert.Equal(t, dump.ChainID, read.ChainID)
+	assert.Equal(t, dump.Height, read.Height)
+	assert.Equal(t, dump.AppHash, read.AppHash)
+	assert.Equal(t, dump.ExpectedAppHash, read.ExpectedAppHash)
+	assert.True(t, proto.Equal(dump.ABCIResponses, read.ABCIResponses))
+}
+
+func TestDiffResults(t *testing.T) {
+	testCases := []struct {
+		name       string
+		malleate   func(results *tmstate.ABCIResponses)
+		txIndex    int
+		eventIndex int
+		reason     string
+	}{
+		{"equal", func(*tmstate.ABCIResponses) {}, -1, -1, ""},
+		{"code", func(results *tmstate.ABCIResponses) {
+			results.DeliverTxs[1].Code = 1
+		}, 1, -1, "the tx results differ in code"},
+		{"event", func(results *tmstate.ABCIResponses) {
+			results.DeliverTxs[2].Events[1].Attributes[0].Value = []byte("2")
+			results.DeliverTxs[2].GasUsed = 2
+		}, 2, 1, "the tx results differ in gas_used, events"},
+		{"missing event", func(results *tmstate.ABCIResponses) {
+			results.DeliverTxs[0].Events = results.DeliverTxs[0].Events[:1]
+		}, 0, 1, "the tx results differ in events"},
+		{"missing tx", func(results *tmstate.ABCIResponses) {
+			results.DeliverTxs = results.DeliverTxs[:2]
+		}, 2, -1, "the number of txs differs: 3 != 2"},
+		{"end block", func(results *tmstate.ABCIResponses) {
+			results.EndBlock.Events = []abci.Event{{Type: "end"}}
+		}, -1, -1, "the EndBlock results differ"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			local, other := testResults(), testResults()
+			tc.malleate(other)
+
+			diff := sm.DiffResults(local, other)
+			assert.Equal(t, tc.reason == "", diff.Equal())
+			assert.Equal(t, tc.txIndex, diff.TxIndex)
+			assert.Equal(t, tc.eventIndex, diff.EventIndex)
+			assert.Equal(t, tc.reason, diff.Reason)
+			if tc.txIndex >= 0 {
+				assert.Equal(t, local.DeliverTxs[tc.txIndex], diff.Local)
+			}
+		})
+	}
+}
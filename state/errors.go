@@ -1,6 +1,11 @@
 package state
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+)
 
 type (
 	ErrInvalidBlock error
@@ -48,6 +53,41 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	ErrPruned struct {
+		Height int64
+		Base   int64
+	}
+
+	ErrBlockRolledBack struct {
+		Height int64
+		Hash   []byte
+	}
+
+	ErrProposalRejected struct {
+		Height int64
+		Round  int32
+		Reason string
+	}
+
+	ErrProcessProposalTimeout struct {
+		Height  int64
+		Round   int32
+		Timeout time.Duration
+	}
+
+	ErrInvalidValidatorSetUpdate struct {
+		Height   int64
+		Proposer crypto.ProTxHash
+		Err      error
+	}
+
+	ErrAppHashMismatch struct {
+		Height   int64
+		Local    []byte
+		Block    []byte
+		DumpFile string
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -99,3 +139,40 @@ func (e ErrNoConsensusParamsForHeight) Error() string {
 func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
+
+func (e ErrPruned) Error() string {
+	return fmt.Sprintf("height #%d is pruned, the lowest height available is #%d", e.Height, e.Base)
+}
+
+func (e ErrBlockRolledBack) Error() string {
+	return fmt.Sprintf("block %X at height %d was discarded by a rollback, which isn't confirmed", e.Hash, e.Height)
+}
+
+func (e ErrProposalRejected) Error() string {
+	return fmt.Sprintf("application rejected the proposal for height %d round %d: %s", e.Height, e.Round, e.Reason)
+}
+
+func (e ErrProcessProposalTimeout) Error() string {
+	return fmt.Sprintf(
+		"application did not process the proposal for height %d round %d within %v",
+		e.Height,
+		e.Round,
+		e.Timeout,
+	)
+}
+
+func (e ErrInvalidValidatorSetUpdate) Error() string {
+	return fmt.Sprintf("invalid validator set update of the block %d proposed by %X: %v", e.Height, e.Proposer, e.Err)
+}
+
+func (e ErrAppHashMismatch) Error() string {
+	msg := fmt.Sprintf("wrong Block.Header.AppHash of the block %d.  Expected %X, got %X", e.Height, e.Local, e.Block)
+	if e.DumpFile != "" {
+		msg += fmt.Sprintf(", the results of the block %d are dumped to %s", e.Height-1, e.DumpFile)
+	}
+	return msg
+}
+
+func (e ErrInvalidValidatorSetUpdate) Unwrap() error {
+	return e.Err
+}
@@ -2,21 +2,27 @@ package state
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/tendermint/tendermint/crypto/bls12381"
+	"sync"
 	"time"
 
+	"github.com/dashevo/dashd-go/btcjson"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/fail"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/tracing"
 	mempl "github.com/tendermint/tendermint/mempool"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 //-----------------------------------------------------------------------------
@@ -32,6 +38,8 @@ type BlockExecutor struct {
 	// execute the app against this
 	proxyApp proxy.AppConnConsensus
 	queryApp proxy.AppConnQuery
+	// validate the proposals against this, the consensus connection by default
+	proposalApp proxy.AppConnProposal
 
 	// events
 	eventBus types.BlockEventPublisher
@@ -48,6 +56,25 @@ type BlockExecutor struct {
 	metrics *Metrics
 
 	appHashSize int
+
+	// when the app is asked to take and prune its snapshots
+	snapshots SnapshotPolicy
+
+	// prunes the state store after the blocks are committed, if set
+	pruner *Pruner
+
+	// where the results are dumped on an app hash mismatch, if set
+	diagnosticsDir string
+
+	// the features the app advertised in Info, requested until it succeeds
+	appInfoMtx                sync.Mutex
+	appInfoReceived           bool
+	appProcessProposalSupport bool
+	appPrepareProposalSupport bool
+
+	// the marker of the unconfirmed rollback of the node, loaded once
+	rollbackOnce   sync.Once
+	rollbackMarker *tmstate.RollbackMarker
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -65,6 +92,64 @@ func BlockExecutorWithAppHashSize(size int) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithSnapshotPolicy is used to schedule the snapshots of the app
+func BlockExecutorWithSnapshotPolicy(policy SnapshotPolicy) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.snapshots = policy
+	}
+}
+
+// BlockExecutorWithPruner is used to prune the state store after the blocks
+// are committed
+func BlockExecutorWithPruner(pruner *Pruner) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.pruner = pruner
+	}
+}
+
+// BlockExecutorWithDiagnosticsDir is used to dump the results of the last
+// block to the directory, when the app hash of a block doesn't match
+func BlockExecutorWithDiagnosticsDir(dir string) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.diagnosticsDir = dir
+	}
+}
+
+// BlockExecutorWithProposalConn is used to validate the proposals on their own
+// connection, so that a ProcessProposal request, which timed out, doesn't hold
+// up the execution of the blocks on the consensus connection
+func BlockExecutorWithProposalConn(conn proxy.AppConnProposal) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.proposalApp = conn
+	}
+}
+
+// SnapshotPolicy schedules the state sync snapshots of the app: a snapshot is
+// taken every Interval heights, and the KeepRecent most recent ones are kept.
+// The zero policy leaves the snapshots to the app.
+type SnapshotPolicy struct {
+	Interval   uint64
+	KeepRecent uint64
+}
+
+// Matches returns true if a snapshot is scheduled at the height.
+func (p SnapshotPolicy) Matches(height uint64) bool {
+	return p.Interval > 0 && height > 0 && height%p.Interval == 0
+}
+
+// RetainHeight returns the height of the oldest snapshot to keep, once the
+// snapshot at the height is taken, or 0 to keep all of them.
+func (p SnapshotPolicy) RetainHeight(height uint64) uint64 {
+	if p.KeepRecent == 0 || !p.Matches(height) {
+		return 0
+	}
+	keep := (p.KeepRecent - 1) * p.Interval
+	if keep >= height {
+		return 0
+	}
+	return height - keep
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -81,6 +166,7 @@ func NewBlockExecutor(
 		store:             stateStore,
 		proxyApp:          proxyApp,
 		queryApp:          queryApp,
+		proposalApp:       proxyApp,
 		eventBus:          types.NopEventBus{},
 		mempool:           mempool,
 		evpool:            evpool,
@@ -101,6 +187,27 @@ func (blockExec *BlockExecutor) Store() Store {
 	return blockExec.store
 }
 
+// VerifyNotRolledBack returns ErrBlockRolledBack, if the block with the given
+// ID at the height was discarded by a rollback of the node, which the operator
+// hasn't confirmed yet (see Rollback). The blockchain reactors don't sync such
+// a block from the peers, which still have it.
+func (blockExec *BlockExecutor) VerifyNotRolledBack(height int64, blockID types.BlockID) error {
+	blockExec.rollbackOnce.Do(func() {
+		marker, err := blockExec.store.LoadRollbackMarker()
+		if err != nil {
+			blockExec.logger.Error("failed to load the rollback marker", "err", err)
+			return
+		}
+		blockExec.rollbackMarker = marker
+	})
+
+	marker := blockExec.rollbackMarker
+	if marker == nil || height != marker.Height+1 || !bytes.Equal(blockID.Hash, marker.BlockID.Hash) {
+		return nil
+	}
+	return ErrBlockRolledBack{Height: height, Hash: blockID.Hash}
+}
+
 // SetEventBus - sets the event bus for publishing block related events.
 // If not called, it defaults to types.NopEventBus.
 func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher) {
@@ -111,29 +218,82 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allocated for maximum sized evidence.
 // The rest is given to txs, up to the max gas.
+//
+// If the application advertised PrepareProposal support in Info, it may
+// reorder, remove or add the txs of the block, within the same size limit.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
+	round int32,
 	state State, commit *types.Commit,
 	proposerProTxHash []byte,
-) (*types.Block, *types.PartSet) {
+) (*types.Block, *types.PartSet, error) {
 
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
 
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
+	// the last commit carries the vote extension
+	if types.VoteExtensionsEnabled(state.ConsensusParams.ABCI, height-1) {
+		maxBytes -= types.MaxCommitVoteExtensionBytes
+	}
+
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, crypto.BLS12381, evSize, state.Validators.Size())
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
 
+	if blockExec.appSupportsPrepareProposal() {
+		var err error
+		txs, err = blockExec.prepareProposal(height, round, proposerProTxHash, txs, maxDataBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	nextCoreChainLock := blockExec.NextCoreChainLock
 
 	if nextCoreChainLock != nil && nextCoreChainLock.CoreBlockHeight <= state.LastCoreChainLockedBlockHeight {
 		nextCoreChainLock = nil
 	}
 
-	return state.MakeBlock(height, nextCoreChainLock, txs, commit, evidence, proposerProTxHash)
+	block, blockParts := state.MakeBlock(height, nextCoreChainLock, txs, commit, evidence, proposerProTxHash)
+	return block, blockParts, nil
+}
+
+// prepareProposal asks the application for the txs of the block to propose,
+// given the txs reaped from the mempool.
+func (blockExec *BlockExecutor) prepareProposal(
+	height int64,
+	round int32,
+	proposerProTxHash []byte,
+	txs types.Txs,
+	maxDataBytes int64,
+) (types.Txs, error) {
+	reqTxs := make([][]byte, len(txs))
+	for i, tx := range txs {
+		reqTxs[i] = tx
+	}
+	res, err := blockExec.proxyApp.PrepareProposalSync(abci.RequestPrepareProposal{
+		Height:            height,
+		Round:             round,
+		ProposerProTxHash: proposerProTxHash,
+		Txs:               reqTxs,
+		MaxTxBytes:        maxDataBytes,
+	})
+	if err != nil {
+		return nil, ErrProxyAppConn(err)
+	}
+
+	preparedTxs := make(types.Txs, len(res.Txs))
+	for i, tx := range res.Txs {
+		preparedTxs[i] = tx
+	}
+	if size := types.ComputeProtoSizeForTxs(preparedTxs); size > maxDataBytes {
+		return nil, fmt.Errorf("the txs prepared by the application are too big (max: %d, got: %d)",
+			maxDataBytes, size)
+	}
+	return preparedTxs, nil
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -160,16 +320,119 @@ func (blockExec *BlockExecutor) ValidateBlockChainLock(state State, block *types
 	return err
 }
 
-// ValidateBlockTime validates the given block time against the given state.
-// If the block is invalid, it returns an error.
-// Validation does not mutate state, but does require historical information from the stateDB,
-// ie. to verify evidence from a validator at an old height.
+// ValidateBlockTime validates the given block time against the local clock,
+// within the synchrony params of the given state.
+// If the block time is invalid, it returns an error.
 func (blockExec *BlockExecutor) ValidateBlockTime(state State, block *types.Block) error {
-	err := validateBlockTime(state, block)
+	return validateBlockTime(state, block, tmtime.Now())
+}
+
+// ProcessProposal asks the application to validate a complete proposal block
+// before the node prevotes for it. The call is skipped if the application
+// didn't advertise ProcessProposal support in Info. If the application doesn't
+// respond within the timeout, the proposal is treated as rejected so a slow
+// application can't stall the round. The request is sent on the proposal
+// connection (see BlockExecutorWithProposalConn), where it may keep running
+// after the timeout, without holding up the execution of the blocks.
+// Returns ErrProposalRejected or ErrProcessProposalTimeout when the proposal
+// must not be prevoted.
+func (blockExec *BlockExecutor) ProcessProposal(block *types.Block, round int32, timeout time.Duration) error {
+	if !blockExec.appSupportsProcessProposal() {
+		return nil
+	}
+	pbh := block.Header.ToProto()
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+	req := abci.RequestProcessProposal{
+		Hash:   block.Hash(),
+		Header: *pbh,
+		Txs:    txs,
+		Round:  round,
+	}
+
+	type result struct {
+		res *abci.ResponseProcessProposal
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		res, err := blockExec.proposalApp.ProcessProposalSync(req)
+		resCh <- result{res, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-resCh:
+		if r.err != nil {
+			return ErrProxyAppConn(r.err)
+		}
+		if r.res.Status != abci.ResponseProcessProposal_ACCEPT {
+			reason := r.res.Info
+			if reason == "" {
+				reason = r.res.Status.String()
+			}
+			return ErrProposalRejected{Height: block.Height, Round: round, Reason: reason}
+		}
+		return nil
+	case <-timer.C:
+		return ErrProcessProposalTimeout{Height: block.Height, Round: round, Timeout: timeout}
+	}
+}
+
+// ExtendVote asks the application for the extension of the precommit for the
+// block. The extension must be deterministic, only the extension signed by 2/3+
+// of the voting power makes it into the commit.
+func (blockExec *BlockExecutor) ExtendVote(block *types.Block, round int32) ([]byte, error) {
+	res, err := blockExec.proxyApp.ExtendVoteSync(abci.RequestExtendVote{
+		Hash:   block.Hash(),
+		Height: block.Height,
+		Round:  round,
+	})
 	if err != nil {
-		return err
+		return nil, ErrProxyAppConn(err)
 	}
-	return err
+	if len(res.VoteExtension) > types.MaxVoteExtensionSize {
+		return nil, fmt.Errorf("vote extension is too big (max: %d, got: %d)",
+			types.MaxVoteExtensionSize, len(res.VoteExtension))
+	}
+	// a non-nil extension requests the private validator to sign it
+	if res.VoteExtension == nil {
+		return []byte{}, nil
+	}
+	return res.VoteExtension, nil
+}
+
+func (blockExec *BlockExecutor) appSupportsProcessProposal() bool {
+	blockExec.requestAppInfo()
+	return blockExec.appProcessProposalSupport
+}
+
+func (blockExec *BlockExecutor) appSupportsPrepareProposal() bool {
+	blockExec.requestAppInfo()
+	return blockExec.appPrepareProposalSupport
+}
+
+// requestAppInfo requests the features the app supports, so the apps, which
+// don't implement them, keep working. A failed request is retried on the next
+// call, until then the features are treated as unsupported.
+func (blockExec *BlockExecutor) requestAppInfo() {
+	blockExec.appInfoMtx.Lock()
+	defer blockExec.appInfoMtx.Unlock()
+	if blockExec.appInfoReceived || blockExec.queryApp == nil {
+		return
+	}
+	res, err := blockExec.queryApp.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		blockExec.logger.Error("failed to request app info, ProcessProposal and PrepareProposal are disabled "+
+			"until it succeeds", "err", err)
+		return
+	}
+	blockExec.appInfoReceived = true
+	blockExec.appProcessProposalSupport = res.SupportsProcessProposal
+	blockExec.appPrepareProposalSupport = res.SupportsPrepareProposal
 }
 
 // ApplyBlock validates the block against the state, executes it against the app,
@@ -188,15 +451,37 @@ func (blockExec *BlockExecutor) ApplyBlock(
 func (blockExec *BlockExecutor) ApplyBlockWithLogger(
 	state State, nodeProTxHash *crypto.ProTxHash, blockID types.BlockID, block *types.Block, logger log.Logger,
 ) (State, int64, error) {
+	return blockExec.ApplyBlockWithContext(context.Background(), state, nodeProTxHash, blockID, block, logger)
+}
+
+// ApplyBlockWithContext is the same as ApplyBlockWithLogger, but traces the
+// execution and the commit of the block and the saving of the state as the
+// children of the span of the context, if any.
+//
+// The signers of the last commit of the block are sent to the app as the votes
+// of LastCommitInfo, and an EventValidatorMissedBlock is fired for every other
+// validator of the last block.
+func (blockExec *BlockExecutor) ApplyBlockWithContext(
+	ctx context.Context,
+	state State,
+	nodeProTxHash *crypto.ProTxHash,
+	blockID types.BlockID,
+	block *types.Block,
+	logger log.Logger,
+) (State, int64, error) {
 
 	if err := validateBlock(state, block); err != nil {
-		return state, 0, ErrInvalidBlock(err)
+		return state, 0, ErrInvalidBlock(blockExec.DumpAppHashMismatch(state, err))
 	}
 
+	votes := lastCommitVotes(state.LastValidators, block.LastCommit)
+
 	startTime := time.Now().UnixNano()
+	_, span := tracing.StartSpan(ctx, "abci.execute_block", tracing.Height(block.Height))
 	abciResponses, err := execBlockOnProxyApp(
-		logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		logger, blockExec.proxyApp, block, votes, state.InitialHeight, blockExec.snapshots,
 	)
+	tracing.EndSpan(span, err)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
@@ -216,9 +501,16 @@ func (blockExec *BlockExecutor) ApplyBlockWithLogger(
 	abciValidatorSetUpdates := abciResponses.EndBlock.ValidatorSetUpdate
 	err = validateValidatorSetUpdate(abciValidatorSetUpdates, state.ConsensusParams.Validator)
 	if err != nil {
-		return state, 0, fmt.Errorf("error in validator updates: %v", err)
+		return state, 0, ErrInvalidBlock(ErrInvalidValidatorSetUpdate{
+			Height:   block.Height,
+			Proposer: block.ProposerProTxHash,
+			Err:      err,
+		})
 	}
 
+	// drop the invalid updates of the app instead of halting the chain
+	stateResponses, rejectedUpdates := dropInvalidUpdates(logger, state, block.Height, abciResponses)
+
 	nextCoreChainLock, err := types.CoreChainLockFromProto(abciResponses.EndBlock.NextCoreChainLockUpdate)
 	if err != nil {
 		return state, 0, fmt.Errorf("error in chain lock from proto: %v", err)
@@ -236,13 +528,17 @@ func (blockExec *BlockExecutor) ApplyBlockWithLogger(
 	blockExec.store.Load()
 
 	// Update the state with the block and responses.
-	state, err = updateState(state, nodeProTxHash, blockID, &block.Header, abciResponses, validatorUpdates, thresholdPublicKeyUpdate, quorumHash)
-	if err != nil {
+	state, err = updateState(state, nodeProTxHash, blockID, &block.Header, stateResponses, validatorUpdates, thresholdPublicKeyUpdate, quorumHash)
+	if errors.As(err, &ErrInvalidValidatorSetUpdate{}) {
+		return state, 0, ErrInvalidBlock(err)
+	} else if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
 
 	// Lock mempool, commit app state, update mempoool.
+	_, span = tracing.StartSpan(ctx, "abci.commit", tracing.Height(block.Height))
 	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return state, 0, fmt.Errorf("commit failed for application: %v", err)
 	}
@@ -257,15 +553,22 @@ func (blockExec *BlockExecutor) ApplyBlockWithLogger(
 
 	// Update the app hash and save the state.
 	state.AppHash = appHash
-	if err := blockExec.store.Save(state); err != nil {
+	_, span = tracing.StartSpan(ctx, "state.save", tracing.Height(block.Height))
+	err = blockExec.store.Save(state)
+	tracing.EndSpan(span, err)
+	if err != nil {
 		return state, 0, err
 	}
 
+	if blockExec.pruner != nil {
+		blockExec.pruner.Prune(state)
+	}
+
 	fail.Fail() // XXX
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(logger, blockExec.eventBus, block, abciResponses, validatorUpdates)
+	fireEvents(logger, blockExec.eventBus, block, abciResponses, votes, validatorUpdates, rejectedUpdates)
 
 	return state, retainHeight, nil
 }
@@ -319,6 +622,8 @@ func (blockExec *BlockExecutor) Commit(
 	// Update mempool.
 	err = blockExec.mempool.Update(
 		block.Height,
+		block.Time,
+		state.NextProposerProTxHash(),
 		block.Txs,
 		deliverTxResponses,
 		TxPreCheck(state),
@@ -331,14 +636,32 @@ func (blockExec *BlockExecutor) Commit(
 //---------------------------------------------------------
 // Helper functions for executing blocks and updating state
 
+// lastCommitVotes returns whether the validators of the last block signed it,
+// in the order of the last validator set, as recorded by the signers of the
+// last commit. It returns nil for the first block, which has no last commit.
+func lastCommitVotes(lastValidators *types.ValidatorSet, lastCommit *types.Commit) []abci.VoteInfo {
+	if lastValidators == nil || lastCommit == nil || lastCommit.Signers == nil {
+		return nil
+	}
+	votes := make([]abci.VoteInfo, 0, lastValidators.Size())
+	for idx, val := range lastValidators.Validators {
+		votes = append(votes, abci.VoteInfo{
+			Validator:       types.TM2PB.Validator(val),
+			SignedLastBlock: lastCommit.Signers.GetIndex(idx),
+		})
+	}
+	return votes
+}
+
 // Executes block's transactions on proxyAppConn.
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
 	logger log.Logger,
 	proxyAppConn proxy.AppConnConsensus,
 	block *types.Block,
-	store Store,
+	lastCommitVotes []abci.VoteInfo,
 	initialHeight int64,
+	snapshots SnapshotPolicy,
 ) (*tmstate.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
@@ -372,6 +695,7 @@ func execBlockOnProxyApp(
 		QuorumHash:     block.LastCommit.QuorumHash,
 		BlockSignature: block.LastCommit.ThresholdBlockSignature,
 		StateSignature: block.LastCommit.ThresholdStateSignature,
+		Votes:          lastCommitVotes,
 	}
 
 	byzVals := make([]abci.Evidence, 0)
@@ -406,7 +730,11 @@ func execBlockOnProxyApp(
 	}
 
 	// End block.
-	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(abci.RequestEndBlock{Height: block.Height})
+	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(abci.RequestEndBlock{
+		Height:               block.Height,
+		TakeSnapshot:         snapshots.Matches(uint64(block.Height)),
+		SnapshotRetainHeight: snapshots.RetainHeight(uint64(block.Height)),
+	})
 	if err != nil {
 		logger.Error("error in proxyAppConn.EndBlock", "err", err)
 		return nil, err
@@ -472,6 +800,95 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	return nil
 }
 
+// validateConsensusParamUpdates validates the consensus param updates returned
+// by the application in EndBlock of the block at the height. Besides the checks
+// of types.ValidateConsensusParams, the updated block size must fit the header,
+// the last commit, the core chain lock and the max evidence, so that the
+// proposer is able to create blocks.
+// NOTE: the consensus params don't know the unbonding period of the application,
+// so the application must keep the evidence max age consistent with it.
+func validateConsensusParamUpdates(params tmproto.ConsensusParams, updates *abci.ConsensusParams, height int64) error {
+	// if there was no update return no error
+	if updates == nil {
+		return nil
+	}
+	updated := types.UpdateConsensusParams(params, updates)
+	if err := types.ValidateConsensusParams(updated); err != nil {
+		return err
+	}
+	if err := types.ValidateConsensusParamsUpdate(params, updated, height); err != nil {
+		return err
+	}
+	overhead := types.MaxOverheadForBlock + types.MaxHeaderBytes + types.MaxCoreChainLockSize +
+		types.MaxCommitOverheadBytes + types.MaxCommitSignersBytes(types.MaxVotesCount)
+	if updated.Block.MaxBytes < overhead+updated.Evidence.MaxBytes {
+		return fmt.Errorf("block.MaxBytes is too small to fit the header, the last commit and the evidence. %d < %d",
+			updated.Block.MaxBytes, overhead+updated.Evidence.MaxBytes)
+	}
+	return nil
+}
+
+// validateQuorumTypeUpdate validates the quorum type of the validator set
+// update. The quorum type can only change with a new quorum, i.e. when the
+// validators are updated with a different quorum hash than the one of valSet.
+func validateQuorumTypeUpdate(valSet *types.ValidatorSet, abciValidatorSetUpdate *abci.ValidatorSetUpdate) error {
+	quorumType := btcjson.LLMQType(abciValidatorSetUpdate.GetQuorumType())
+	if quorumType == 0 || quorumType == valSet.QuorumType {
+		return nil
+	}
+	if len(abciValidatorSetUpdate.ValidatorUpdates) == 0 ||
+		bytes.Equal(abciValidatorSetUpdate.QuorumHash, valSet.QuorumHash) {
+		return fmt.Errorf("quorum type can't be changed from %d to %d without a new quorum",
+			valSet.QuorumType, quorumType)
+	}
+	return nil
+}
+
+// dropInvalidUpdates drops the consensus param updates and the quorum type
+// change of abciResponses, which are invalid for the block at the height. The
+// invalid updates are rejected with an event instead of halting the chain.
+// It returns the responses to update the state with, and the rejected updates.
+func dropInvalidUpdates(
+	logger log.Logger,
+	state State,
+	height int64,
+	abciResponses *tmstate.ABCIResponses,
+) (*tmstate.ABCIResponses, []types.EventDataRejectedUpdates) {
+	var rejected []types.EventDataRejectedUpdates
+
+	paramUpdates := abciResponses.EndBlock.ConsensusParamUpdates
+	if err := validateConsensusParamUpdates(state.ConsensusParams, paramUpdates, height); err != nil {
+		logger.Error("dropping invalid consensus param updates", "height", height, "err", err)
+		rejected = append(rejected, types.EventDataRejectedUpdates{
+			Height:                height,
+			ConsensusParamUpdates: paramUpdates,
+			Reason:                err.Error(),
+		})
+
+		// NOTE: must not mutate abciResponses, they're saved and published as is
+		endBlock := *abciResponses.EndBlock
+		endBlock.ConsensusParamUpdates = nil
+		abciResponses = &tmstate.ABCIResponses{
+			DeliverTxs: abciResponses.DeliverTxs,
+			BeginBlock: abciResponses.BeginBlock,
+			EndBlock:   &endBlock,
+		}
+	}
+
+	// updateState ignores the quorum type without a new quorum
+	validatorSetUpdate := abciResponses.EndBlock.ValidatorSetUpdate
+	if err := validateQuorumTypeUpdate(state.NextValidators, validatorSetUpdate); err != nil {
+		logger.Error("dropping invalid quorum type update", "height", height, "err", err)
+		rejected = append(rejected, types.EventDataRejectedUpdates{
+			Height:     height,
+			QuorumType: validatorSetUpdate.QuorumType,
+			Reason:     err.Error(),
+		})
+	}
+
+	return abciResponses, rejected
+}
+
 // updateState returns a new State updated according to the header and responses.
 func updateState(
 	state State,
@@ -491,16 +908,31 @@ func updateState(
 	// Update the validator set with the latest abciResponses.
 	lastHeightValsChanged := state.LastHeightValidatorsChanged
 	if len(validatorUpdates) > 0 {
+		invalidUpdate := func(err error) error {
+			return ErrInvalidValidatorSetUpdate{Height: header.Height, Proposer: header.ProposerProTxHash, Err: err}
+		}
 		if bytes.Equal(nValSet.QuorumHash, quorumHash) {
 			err := nValSet.UpdateWithChangeSet(validatorUpdates, newThresholdPublicKey, quorumHash)
 			if err != nil {
-				return state, fmt.Errorf("error changing validator set: %v", err)
+				return state, invalidUpdate(fmt.Errorf("error changing validator set: %w", err))
 			}
 			// Change results from this height but only applies to the next next height.
 			lastHeightValsChanged = header.Height + 1 + 1
 		} else {
+			// a new quorum comes with its own threshold public key
+			if err := types.ValidateThresholdPublicKey(newThresholdPublicKey); err != nil {
+				return state, invalidUpdate(fmt.Errorf("invalid threshold public key of the new quorum: %w", err))
+			}
+			// the new quorum keeps the quorum type, unless the app changes it
+			quorumType := nValSet.QuorumType
+			if update := abciResponses.EndBlock.ValidatorSetUpdate; update.GetQuorumType() != 0 {
+				quorumType = btcjson.LLMQType(update.QuorumType)
+			}
 			nValSet = types.NewValidatorSetWithLocalNodeProTxHash(validatorUpdates, newThresholdPublicKey,
-				state.Validators.QuorumType, quorumHash, nodeProTxHash)
+				quorumType, quorumHash, nodeProTxHash)
+			if err := nValSet.ThresholdPublicKeyValid(); err != nil {
+				return state, invalidUpdate(fmt.Errorf("threshold public key doesn't match the new quorum: %w", err))
+			}
 			// Change results from this height but only applies to the next next height.
 			lastHeightValsChanged = header.Height + 1 + 1
 		}
@@ -519,6 +951,10 @@ func updateState(
 		if err != nil {
 			return state, fmt.Errorf("error updating consensus params: %v", err)
 		}
+		err = types.ValidateConsensusParamsUpdate(state.ConsensusParams, nextParams, header.Height)
+		if err != nil {
+			return state, fmt.Errorf("error updating consensus params: %v", err)
+		}
 
 		state.Version.Consensus.App = nextParams.Version.AppVersion
 
@@ -552,13 +988,17 @@ func updateState(
 
 // Fire NewBlock, NewBlockHeader.
 // Fire TxEvent for every tx.
+// Fire RejectedUpdates for every rejected update of the app.
+// Fire ValidatorMissedBlock for every validator, which didn't sign the last block.
 // NOTE: if Tendermint crashes before commit, some or all of these events may be published again.
 func fireEvents(
 	logger log.Logger,
 	eventBus types.BlockEventPublisher,
 	block *types.Block,
 	abciResponses *tmstate.ABCIResponses,
+	lastCommitVotes []abci.VoteInfo,
 	validatorUpdates []*types.Validator,
+	rejectedUpdates []types.EventDataRejectedUpdates,
 ) {
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:            block,
@@ -605,13 +1045,65 @@ func fireEvents(
 			logger.Error("failed publishing event", "err", err)
 		}
 	}
+
+	for _, rejected := range rejectedUpdates {
+		if err := eventBus.PublishEventRejectedUpdates(rejected); err != nil {
+			logger.Error("failed publishing rejected updates", "err", err)
+		}
+	}
+
+	for _, vote := range lastCommitVotes {
+		if vote.SignedLastBlock {
+			continue
+		}
+		if err := eventBus.PublishEventValidatorMissedBlock(types.EventDataValidatorMissedBlock{
+			Height:    block.Height - 1,
+			ProTxHash: vote.Validator.ProTxHash,
+		}); err != nil {
+			logger.Error("failed publishing validator missed block", "err", err)
+		}
+	}
 }
 
 //----------------------------------------------------------------------------------------------------
 // Execute block without state. TODO: eliminate
 
+// DumpAppHashMismatch dumps the results of the last block, which the app hash
+// of the node was computed from, to the diagnostics directory, if it's set and
+// the validation error of a block is an app hash mismatch. It's meant to be
+// called when the block is committed anyway, as the node can't go on then. It
+// returns the error with the dump file, if it's written.
+func (blockExec *BlockExecutor) DumpAppHashMismatch(state State, err error) error {
+	var mismatch ErrAppHashMismatch
+	if !errors.As(err, &mismatch) || blockExec.diagnosticsDir == "" || state.LastBlockHeight == 0 {
+		return err
+	}
+	abciResponses, loadErr := blockExec.store.LoadABCIResponses(state.LastBlockHeight)
+	if loadErr != nil {
+		blockExec.logger.Error("failed to load the results to dump", "height", state.LastBlockHeight, "err", loadErr)
+		return err
+	}
+	file := ResultsDumpFile(blockExec.diagnosticsDir, state.LastBlockHeight)
+	dumpErr := WriteResultsDump(file, ResultsDump{
+		ChainID:         state.ChainID,
+		Height:          state.LastBlockHeight,
+		AppHash:         state.AppHash,
+		ExpectedAppHash: mismatch.Block,
+		ABCIResponses:   abciResponses,
+	})
+	if dumpErr != nil {
+		blockExec.logger.Error("failed to dump the results", "height", state.LastBlockHeight, "err", dumpErr)
+		return err
+	}
+	blockExec.logger.Error("app hash mismatch, dumped the results of the last block",
+		"height", state.LastBlockHeight, "file", file)
+	mismatch.DumpFile = file
+	return mismatch
+}
+
 // ExecCommitBlock executes and commits a block on the proxyApp without validating or mutating the state.
-// It returns the application root hash (result of abci.Commit).
+// It returns the application root hash (result of abci.Commit). No snapshots are scheduled for the
+// replayed blocks.
 func ExecCommitBlock(
 	appConnConsensus proxy.AppConnConsensus,
 	block *types.Block,
@@ -619,7 +1111,16 @@ func ExecCommitBlock(
 	store Store,
 	initialHeight int64,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	var lastValidators *types.ValidatorSet
+	if block.Height > initialHeight {
+		var err error
+		if lastValidators, err = store.LoadValidators(block.Height - 1); err != nil {
+			return nil, err
+		}
+	}
+	votes := lastCommitVotes(lastValidators, block.LastCommit)
+
+	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, votes, initialHeight, SnapshotPolicy{})
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -635,3 +1136,41 @@ func ExecCommitBlock(
 	// ResponseCommit has no error or log, just data
 	return res.Data, nil
 }
+
+// ReplayBlock re-executes the committed block on the query connection of the
+// app, which supports it (see abci.QueryPathReplayBlock), without changing the
+// state of the app. It returns the responses of the app to the block.
+func ReplayBlock(appConnQuery proxy.AppConnQuery, block *types.Block) (*tmstate.ABCIResponses, error) {
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	res, err := appConnQuery.QuerySync(abci.RequestQuery{
+		Path:   abci.QueryPathReplayBlock,
+		Data:   bz,
+		Height: block.Height,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.IsErr() {
+		return nil, fmt.Errorf("app failed to replay the block %d: code %d, %s", block.Height, res.Code, res.Log)
+	}
+
+	abciResponses := new(tmstate.ABCIResponses)
+	if err := abciResponses.Unmarshal(res.Value); err != nil {
+		return nil, fmt.Errorf("can't decode the responses of the app to the block %d: %w", block.Height, err)
+	}
+	if len(abciResponses.DeliverTxs) != len(block.Txs) {
+		return nil, fmt.Errorf("app replayed %d txs of the block %d, which has %d",
+			len(abciResponses.DeliverTxs), block.Height, len(block.Txs))
+	}
+	if abciResponses.BeginBlock == nil || abciResponses.EndBlock == nil {
+		return nil, fmt.Errorf("app didn't replay the beginning or the end of the block %d", block.Height)
+	}
+	return abciResponses, nil
+}
@@ -3,9 +3,14 @@ package state_test
 import (
 	"bytes"
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/dashevo/dashd-go/btcjson"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 
@@ -20,6 +25,7 @@ import (
 	mmock "github.com/tendermint/tendermint/mempool/mock"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/proxy"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/types"
@@ -34,7 +40,7 @@ var (
 func TestApplyBlock(t *testing.T) {
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err := proxyApp.Start()
 	require.Nil(t, err)
 	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
@@ -62,7 +68,7 @@ func TestApplyBlock(t *testing.T) {
 func TestBeginBlockByzantineValidators(t *testing.T) {
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err := proxyApp.Start()
 	require.Nil(t, err)
 	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
@@ -113,6 +119,54 @@ func TestBeginBlockByzantineValidators(t *testing.T) {
 	assert.Equal(t, abciEv, app.ByzantineValidators)
 }
 
+// TestEndBlockSnapshotPolicy ensures the app is asked to take and prune the snapshots.
+func TestEndBlockSnapshotPolicy(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	nodeProTxHash := &state.Validators.Validators[0].ProTxHash
+	stateStore := sm.NewStore(stateDB)
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), proxyApp.Query(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, nil,
+		sm.BlockExecutorWithSnapshotPolicy(sm.SnapshotPolicy{Interval: 1, KeepRecent: 1}))
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, _, err = blockExec.ApplyBlock(state, nodeProTxHash, blockID, block)
+	require.Nil(t, err)
+	assert.Equal(t, abci.RequestEndBlock{Height: 1, TakeSnapshot: true, SnapshotRetainHeight: 1}, app.EndBlockRequest)
+}
+
+func TestSnapshotPolicy(t *testing.T) {
+	testCases := []struct {
+		policy       sm.SnapshotPolicy
+		height       uint64
+		matches      bool
+		retainHeight uint64
+	}{
+		{sm.SnapshotPolicy{}, 10, false, 0},
+		{sm.SnapshotPolicy{Interval: 10}, 10, true, 0},
+		{sm.SnapshotPolicy{Interval: 10}, 15, false, 0},
+		{sm.SnapshotPolicy{Interval: 10, KeepRecent: 3}, 0, false, 0},
+		{sm.SnapshotPolicy{Interval: 10, KeepRecent: 3}, 15, false, 0},
+		{sm.SnapshotPolicy{Interval: 10, KeepRecent: 3}, 20, true, 0},
+		{sm.SnapshotPolicy{Interval: 10, KeepRecent: 3}, 30, true, 10},
+		{sm.SnapshotPolicy{Interval: 10, KeepRecent: 3}, 100, true, 80},
+		{sm.SnapshotPolicy{Interval: 10, KeepRecent: 1}, 100, true, 100},
+	}
+	for i, tc := range testCases {
+		assert.Equal(t, tc.matches, tc.policy.Matches(tc.height), "#%d", i)
+		assert.Equal(t, tc.retainHeight, tc.policy.RetainHeight(tc.height), "#%d", i)
+	}
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	pubkey1 := bls12381.GenPrivKey().PubKey()
 	pubkey2 := bls12381.GenPrivKey().PubKey()
@@ -264,11 +318,79 @@ func TestUpdateValidators(t *testing.T) {
 	}
 }
 
+// TestBeginBlockLastCommitVotes ensures we send the signers of the last commit
+// of the block, and fire an event for every validator which didn't sign.
+func TestBeginBlockLastCommitVotes(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(4, 1)
+	nodeProTxHash := &state.Validators.Validators[0].ProTxHash
+	stateStore := sm.NewStore(stateDB)
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), proxyApp.Query(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, nil)
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+	blockExec.SetEventBus(eventBus)
+
+	stalled := state.Validators.Validators[3].ProTxHash
+	missedSub, err := eventBus.Subscribe(context.Background(), "TestBeginBlockLastCommitVotes",
+		types.EventQueryValidatorMissedBlockFor(stalled), 20)
+	require.NoError(t, err)
+	allMissedSub, err := eventBus.Subscribe(context.Background(), "TestBeginBlockLastCommitVotes",
+		types.EventQueryValidatorMissedBlock, 20)
+	require.NoError(t, err)
+
+	// the first block has no last commit, the validator stalls for the next 10
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
+	for height := int64(1); height <= 11; height++ {
+		block, _ := state.MakeBlock(height, nil, makeTxs(height), lastCommit, nil,
+			state.Validators.GetProposer().ProTxHash)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+		state, _, err = blockExec.ApplyBlockWithContext(context.Background(), state, nodeProTxHash, blockID,
+			block, log.TestingLogger())
+		require.NoError(t, err, "height %d", height)
+
+		if height == 1 {
+			assert.Empty(t, app.LastCommitInfo.Votes)
+		} else if assert.Len(t, app.LastCommitInfo.Votes, 4, "height %d", height) {
+			for i, vote := range app.LastCommitInfo.Votes {
+				val := state.LastValidators.Validators[i]
+				assert.EqualValues(t, val.ProTxHash, vote.Validator.ProTxHash)
+				assert.Equal(t, !bytes.Equal(val.ProTxHash, stalled), vote.SignedLastBlock,
+					"height %d, validator %d", height, i)
+			}
+			select {
+			case msg := <-missedSub.Out():
+				event := msg.Data().(types.EventDataValidatorMissedBlock)
+				assert.EqualValues(t, height-1, event.Height)
+				assert.EqualValues(t, stalled, event.ProTxHash)
+			case <-time.After(time.Second):
+				t.Fatalf("Did not receive EventValidatorMissedBlock for height %d within 1 sec.", height-1)
+			}
+		}
+
+		lastCommit, err = makeValidCommit(height, blockID, types.StateID{LastAppHash: state.AppHash},
+			state.LastValidators, privVals)
+		require.NoError(t, err)
+		stalledIdx, _ := state.LastValidators.GetByProTxHash(stalled)
+		lastCommit.Signers.SetIndex(int(stalledIdx), false)
+	}
+
+	// only the stalled validator missed the blocks
+	assert.Equal(t, 10, len(allMissedSub.Out()))
+}
+
 // TestEndBlockValidatorUpdates ensures we update validator set and send an event.
 func TestEndBlockValidatorUpdates(t *testing.T) {
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err := proxyApp.Start()
 	require.Nil(t, err)
 	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
@@ -307,6 +429,20 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 	vals := state.Validators
 	proTxHashes := vals.GetProTxHashes()
 	addProTxHash := crypto.RandProTxHash()
+
+	// subscribers filtering on a proTxHash only get the updates of their validator
+	addedSub, err := eventBus.Subscribe(
+		context.Background(),
+		"TestEndBlockValidatorUpdates",
+		types.EventQueryValidatorSetUpdatesFor(addProTxHash),
+	)
+	require.NoError(t, err)
+	otherSub, err := eventBus.Subscribe(
+		context.Background(),
+		"TestEndBlockValidatorUpdates",
+		types.EventQueryValidatorSetUpdatesFor(crypto.RandProTxHash()),
+	)
+	require.NoError(t, err)
 	proTxHashes = append(proTxHashes, addProTxHash)
 	newVals, _ := types.GenerateValidatorSetUsingProTxHashes(proTxHashes)
 	var pos int
@@ -342,6 +478,19 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("Did not receive EventValidatorSetUpdates within 1 sec.")
 	}
+
+	select {
+	case msg := <-addedSub.Out():
+		_, ok := msg.Data().(types.EventDataValidatorSetUpdates)
+		require.True(t, ok, "Expected event of type EventDataValidatorSetUpdates, got %T", msg.Data())
+	case <-time.After(1 * time.Second):
+		t.Fatal("Did not receive EventValidatorSetUpdates for the added validator within 1 sec.")
+	}
+	select {
+	case msg := <-otherSub.Out():
+		t.Fatalf("Received EventValidatorSetUpdates for an unknown validator: %v", msg.Data())
+	default:
+	}
 }
 
 // TestEndBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
@@ -349,7 +498,7 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	proxyApp := proxy.NewAppConns(cc)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
 	err := proxyApp.Start()
 	require.Nil(t, err)
 	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
@@ -390,6 +539,508 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotEmpty(t, state.NextValidators.Validators)
 }
 
+// TestEndBlockValidatorUpdatesInvalidThresholdPublicKey checks that a new
+// quorum with a threshold public key, which doesn't match its members, makes
+// the block invalid.
+func TestEndBlockValidatorUpdatesInvalidThresholdPublicKey(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(4, 1)
+	nodeProTxHash := &state.Validators.Validators[0].ProTxHash
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		proxyApp.Query(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		nil,
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	// rotate to a new quorum of the same members, with the threshold public key of someone else
+	update := types.ValidatorUpdatesRegenerateOnProTxHashes(state.Validators.GetProTxHashes())
+	update.ThresholdPublicKey, err = cryptoenc.PubKeyToProto(bls12381.GenPrivKey().PubKey())
+	require.NoError(t, err)
+	app.ValidatorSetUpdate = &update
+
+	_, _, err = blockExec.ApplyBlock(state, nodeProTxHash, blockID, block)
+	require.Error(t, err)
+	var invalidUpdate sm.ErrInvalidValidatorSetUpdate
+	require.True(t, errors.As(err, &invalidUpdate), err)
+	assert.EqualValues(t, block.Height, invalidUpdate.Height)
+	assert.Equal(t, block.ProposerProTxHash, invalidUpdate.Proposer)
+	assert.Contains(t, err.Error(), "threshold public key doesn't match the new quorum")
+}
+
+func TestValidateConsensusParamUpdates(t *testing.T) {
+	const height = 10
+	params := *types.DefaultConsensusParams()
+	blockParams := func(maxBytes, maxGas int64) *abci.BlockParams {
+		return &abci.BlockParams{MaxBytes: maxBytes, MaxGas: maxGas}
+	}
+	evidenceParams := func(update func(*tmproto.EvidenceParams)) *tmproto.EvidenceParams {
+		evidence := params.Evidence
+		update(&evidence)
+		return &evidence
+	}
+	synchronyParams := func(update func(*tmproto.SynchronyParams)) *tmproto.SynchronyParams {
+		synchrony := params.Synchrony
+		update(&synchrony)
+		return &synchrony
+	}
+	// the smallest block fitting the header, the last commit, the core chain
+	// lock and the max evidence
+	minBlockBytes := types.MaxOverheadForBlock + types.MaxHeaderBytes + types.MaxCoreChainLockSize +
+		types.MaxCommitOverheadBytes + types.MaxCommitSignersBytes(types.MaxVotesCount) + params.Evidence.MaxBytes
+
+	testCases := []struct {
+		name      string
+		updates   *abci.ConsensusParams
+		shouldErr bool
+	}{
+		{"no updates", nil, false},
+		{"empty updates", &abci.ConsensusParams{}, false},
+
+		{"block.MaxBytes update", &abci.ConsensusParams{Block: blockParams(1024*1024*2, -1)}, false},
+		{"zero block.MaxBytes", &abci.ConsensusParams{Block: blockParams(0, -1)}, true},
+		{"negative block.MaxBytes", &abci.ConsensusParams{Block: blockParams(-1, -1)}, true},
+		{"block.MaxBytes at the hard cap", &abci.ConsensusParams{Block: blockParams(types.MaxBlockSizeBytes, -1)}, false},
+		{"block.MaxBytes above the hard cap",
+			&abci.ConsensusParams{Block: blockParams(types.MaxBlockSizeBytes+1, -1)}, true},
+		{"smallest block.MaxBytes", &abci.ConsensusParams{Block: blockParams(minBlockBytes, -1)}, false},
+		{"block.MaxBytes too small for the evidence",
+			&abci.ConsensusParams{Block: blockParams(minBlockBytes-1, -1)}, true},
+		{"block.MaxGas update", &abci.ConsensusParams{Block: blockParams(params.Block.MaxBytes, 1000)}, false},
+		{"zero block.MaxGas", &abci.ConsensusParams{Block: blockParams(params.Block.MaxBytes, 0)}, false},
+		{"block.MaxGas below -1", &abci.ConsensusParams{Block: blockParams(params.Block.MaxBytes, -2)}, true},
+
+		{"evidence.MaxAgeNumBlocks update", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxAgeNumBlocks = 1 })}, false},
+		{"zero evidence.MaxAgeNumBlocks", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxAgeNumBlocks = 0 })}, true},
+		{"evidence.MaxAgeDuration update", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxAgeDuration = time.Hour })}, false},
+		{"zero evidence.MaxAgeDuration", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxAgeDuration = 0 })}, true},
+		{"zero evidence.MaxBytes", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxBytes = 0 })}, false},
+		{"negative evidence.MaxBytes", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxBytes = -1 })}, true},
+		{"evidence.MaxBytes too big for the block", &abci.ConsensusParams{Evidence: evidenceParams(
+			func(p *tmproto.EvidenceParams) { p.MaxBytes = params.Block.MaxBytes })}, true},
+
+		{"validator.PubKeyTypes update", &abci.ConsensusParams{Validator: &tmproto.ValidatorParams{
+			PubKeyTypes: []string{types.ABCIPubKeyTypeBLS12381}}}, false},
+		{"empty validator.PubKeyTypes", &abci.ConsensusParams{Validator: &tmproto.ValidatorParams{}}, true},
+		{"unknown validator.PubKeyTypes", &abci.ConsensusParams{Validator: &tmproto.ValidatorParams{
+			PubKeyTypes: []string{"unknown"}}}, true},
+
+		{"version.AppVersion update", &abci.ConsensusParams{Version: &tmproto.VersionParams{AppVersion: 2}}, false},
+
+		{"abci.VoteExtensionsEnableHeight update", &abci.ConsensusParams{Abci: &tmproto.ABCIParams{
+			VoteExtensionsEnableHeight: height + 1}}, false},
+		{"abci.VoteExtensionsEnableHeight in the past", &abci.ConsensusParams{Abci: &tmproto.ABCIParams{
+			VoteExtensionsEnableHeight: height}}, true},
+		{"negative abci.VoteExtensionsEnableHeight", &abci.ConsensusParams{Abci: &tmproto.ABCIParams{
+			VoteExtensionsEnableHeight: -1}}, true},
+
+		{"synchrony.MinStep update", &abci.ConsensusParams{Synchrony: synchronyParams(
+			func(p *tmproto.SynchronyParams) { p.MinStep = time.Second })}, false},
+		{"zero synchrony.MinStep", &abci.ConsensusParams{Synchrony: synchronyParams(
+			func(p *tmproto.SynchronyParams) { p.MinStep = 0 })}, true},
+		{"synchrony.MaxDrift update", &abci.ConsensusParams{Synchrony: synchronyParams(
+			func(p *tmproto.SynchronyParams) { p.MaxDrift = time.Minute })}, false},
+		{"zero synchrony.MaxDrift", &abci.ConsensusParams{Synchrony: synchronyParams(
+			func(p *tmproto.SynchronyParams) { p.MaxDrift = 0 })}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := sm.ValidateConsensusParamUpdates(params, tc.updates, height)
+			if tc.shouldErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateQuorumTypeUpdate(t *testing.T) {
+	state, _, _ := makeState(1, 1)
+	valSet := state.NextValidators
+	quorumType := valSet.QuorumType
+	newQuorumType := btcjson.LLMQType_100_67
+	require.NotEqual(t, quorumType, newQuorumType)
+	validatorUpdates := valSet.ABCIEquivalentValidatorUpdates().ValidatorUpdates
+
+	testCases := []struct {
+		name      string
+		update    *abci.ValidatorSetUpdate
+		shouldErr bool
+	}{
+		{"no update", nil, false},
+		{"same quorum type", &abci.ValidatorSetUpdate{QuorumType: int32(quorumType)}, false},
+		{"quorum type change without validator updates",
+			&abci.ValidatorSetUpdate{QuorumType: int32(newQuorumType)}, true},
+		{"quorum type change of the same quorum", &abci.ValidatorSetUpdate{
+			ValidatorUpdates: validatorUpdates,
+			QuorumHash:       valSet.QuorumHash,
+			QuorumType:       int32(newQuorumType),
+		}, true},
+		{"quorum type change of a new quorum", &abci.ValidatorSetUpdate{
+			ValidatorUpdates: validatorUpdates,
+			QuorumHash:       crypto.RandQuorumHash(),
+			QuorumType:       int32(newQuorumType),
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := sm.ValidateQuorumTypeUpdate(valSet, tc.update)
+			if tc.shouldErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestEndBlockInvalidConsensusParamUpdates checks that invalid consensus param
+// updates are dropped with an event instead of halting the chain.
+func TestEndBlockInvalidConsensusParamUpdates(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	nodeProTxHash := &state.Validators.Validators[0].ProTxHash
+	stateStore := sm.NewStore(stateDB)
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		proxyApp.Query(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		nil,
+	)
+
+	eventBus := types.NewEventBus()
+	err = eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop() //nolint:errcheck // ignore for tests
+
+	blockExec.SetEventBus(eventBus)
+
+	rejectedSub, err := eventBus.Subscribe(
+		context.Background(),
+		"TestEndBlockInvalidConsensusParamUpdates",
+		types.EventQueryRejectedUpdates,
+	)
+	require.NoError(t, err)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	// the app tries to set max_bytes=0
+	app.ConsensusParamUpdates = &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 0, MaxGas: -1}}
+	params := state.ConsensusParams
+	lastHeightParamsChanged := state.LastHeightConsensusParamsChanged
+
+	state, _, err = blockExec.ApplyBlock(state, nodeProTxHash, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, params, state.ConsensusParams)
+	assert.Equal(t, lastHeightParamsChanged, state.LastHeightConsensusParamsChanged)
+
+	// the dropped updates are still saved as returned by the app
+	abciResponses, err := stateStore.LoadABCIResponses(block.Height)
+	require.NoError(t, err)
+	assert.Equal(t, app.ConsensusParamUpdates, abciResponses.EndBlock.ConsensusParamUpdates)
+
+	select {
+	case msg := <-rejectedSub.Out():
+		event, ok := msg.Data().(types.EventDataRejectedUpdates)
+		require.True(t, ok, "Expected event of type EventDataRejectedUpdates, got %T", msg.Data())
+		assert.Equal(t, block.Height, event.Height)
+		assert.Equal(t, app.ConsensusParamUpdates, event.ConsensusParamUpdates)
+		assert.NotEmpty(t, event.Reason)
+	case <-rejectedSub.Cancelled():
+		t.Fatalf("rejectedSub was cancelled (reason: %v)", rejectedSub.Err())
+	case <-time.After(1 * time.Second):
+		t.Fatal("Did not receive EventRejectedUpdates within 1 sec.")
+	}
+}
+
+type processProposalApp struct {
+	abci.BaseApplication
+
+	supported bool
+	delay     time.Duration
+	res       abci.ResponseProcessProposal
+	calls     int32
+}
+
+func (app *processProposalApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{SupportsProcessProposal: app.supported}
+}
+
+func (app *processProposalApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	atomic.AddInt32(&app.calls, 1)
+	time.Sleep(app.delay)
+	return app.res
+}
+
+func TestProcessProposal(t *testing.T) {
+	testCases := []struct {
+		name      string
+		app       *processProposalApp
+		wantCalls int32
+		wantErr   interface{}
+	}{
+		{
+			name:      "not supported by the app",
+			app:       &processProposalApp{res: abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}},
+			wantCalls: 0,
+		},
+		{
+			name: "accepted",
+			app: &processProposalApp{
+				supported: true,
+				res:       abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "rejected",
+			app: &processProposalApp{
+				supported: true,
+				res:       abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT, Info: "bad tx"},
+			},
+			wantCalls: 1,
+			wantErr:   sm.ErrProposalRejected{},
+		},
+		{
+			name:      "unknown status",
+			app:       &processProposalApp{supported: true},
+			wantCalls: 1,
+			wantErr:   sm.ErrProposalRejected{},
+		},
+		{
+			name: "timeout",
+			app: &processProposalApp{
+				supported: true,
+				delay:     200 * time.Millisecond,
+				res:       abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT},
+			},
+			wantCalls: 1,
+			wantErr:   sm.ErrProcessProposalTimeout{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cc := proxy.NewLocalClientCreator(tc.app)
+			proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+			require.NoError(t, proxyApp.Start())
+			defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+			state, stateDB, _ := makeState(1, 1)
+			blockExec := sm.NewBlockExecutor(sm.NewStore(stateDB), log.TestingLogger(), proxyApp.Consensus(),
+				proxyApp.Query(), mmock.Mempool{}, sm.EmptyEvidencePool{}, nil,
+				sm.BlockExecutorWithProposalConn(proxyApp.Proposal()))
+
+			block := makeBlock(state, 1)
+			err := blockExec.ProcessProposal(block, 0, 50*time.Millisecond)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.IsType(t, tc.wantErr, err)
+			}
+			time.Sleep(tc.app.delay)
+			assert.Equal(t, tc.wantCalls, atomic.LoadInt32(&tc.app.calls))
+		})
+	}
+}
+
+// the ProcessProposal request, which timed out, doesn't hold up the consensus
+// connection
+func TestProcessProposalTimeoutConsensusConn(t *testing.T) {
+	app := &processProposalApp{supported: true}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	release := make(chan struct{})
+	proposalApp := &proxymocks.AppConnProposal{}
+	proposalApp.On("ProcessProposalSync", mock.Anything).Run(func(mock.Arguments) { <-release }).Return(
+		&abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil)
+	defer close(release)
+
+	state, stateDB, _ := makeState(1, 1)
+	blockExec := sm.NewBlockExecutor(sm.NewStore(stateDB), log.TestingLogger(), proxyApp.Consensus(),
+		proxyApp.Query(), mmock.Mempool{}, sm.EmptyEvidencePool{}, nil,
+		sm.BlockExecutorWithProposalConn(proposalApp))
+	block := makeBlock(state, 1)
+
+	err := blockExec.ProcessProposal(block, 0, 50*time.Millisecond)
+	require.IsType(t, sm.ErrProcessProposalTimeout{}, err)
+
+	// the request is still pending, but the consensus connection is free
+	_, err = blockExec.ExtendVote(block, 0)
+	require.NoError(t, err)
+	assert.Zero(t, atomic.LoadInt32(&app.calls))
+}
+
+// the failed Info request is retried, instead of disabling ProcessProposal
+func TestProcessProposalAppInfoRetry(t *testing.T) {
+	app := &processProposalApp{
+		supported: true,
+		res:       abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT},
+	}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	queryApp := &proxymocks.AppConnQuery{}
+	queryApp.On("InfoSync", proxy.RequestInfo).Return(nil, errors.New("connection refused")).Once()
+	queryApp.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{SupportsProcessProposal: true}, nil).Once()
+
+	state, stateDB, _ := makeState(1, 1)
+	blockExec := sm.NewBlockExecutor(sm.NewStore(stateDB), log.TestingLogger(), proxyApp.Consensus(),
+		queryApp, mmock.Mempool{}, sm.EmptyEvidencePool{}, nil)
+	block := makeBlock(state, 1)
+
+	// the features are unknown, so ProcessProposal is skipped
+	require.NoError(t, blockExec.ProcessProposal(block, 0, time.Second))
+	assert.Zero(t, atomic.LoadInt32(&app.calls))
+
+	// the Info request succeeds, and is not repeated afterwards
+	for i := 1; i <= 2; i++ {
+		require.IsType(t, sm.ErrProposalRejected{}, blockExec.ProcessProposal(block, 0, time.Second))
+		assert.EqualValues(t, i, atomic.LoadInt32(&app.calls))
+	}
+	queryApp.AssertExpectations(t)
+}
+
+type prepareProposalApp struct {
+	abci.BaseApplication
+
+	supported bool
+	txs       [][]byte
+	req       *abci.RequestPrepareProposal
+}
+
+func (app *prepareProposalApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{SupportsPrepareProposal: app.supported}
+}
+
+func (app *prepareProposalApp) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	app.req = &req
+	return abci.ResponsePrepareProposal{Txs: app.txs}
+}
+
+// reapMempool returns the txs when reaped.
+type reapMempool struct {
+	mmock.Mempool
+	txs types.Txs
+}
+
+func (mem reapMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	return mem.txs
+}
+
+func TestCreateProposalBlockPrepareProposal(t *testing.T) {
+	mempoolTxs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+
+	testCases := []struct {
+		name    string
+		app     *prepareProposalApp
+		wantTxs types.Txs
+		wantErr bool
+	}{
+		{
+			name:    "not supported by the app",
+			app:     &prepareProposalApp{txs: [][]byte{[]byte("x")}},
+			wantTxs: mempoolTxs,
+		},
+		{
+			name: "reordered and substituted",
+			app: &prepareProposalApp{
+				supported: true,
+				txs:       [][]byte{[]byte("c"), []byte("x"), []byte("a")},
+			},
+			wantTxs: types.Txs{types.Tx("c"), types.Tx("x"), types.Tx("a")},
+		},
+		{
+			name:    "all removed",
+			app:     &prepareProposalApp{supported: true},
+			wantTxs: types.Txs{},
+		},
+		{
+			name: "too big",
+			app: &prepareProposalApp{
+				supported: true,
+				txs:       [][]byte{make([]byte, types.MaxBlockSizeBytes)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cc := proxy.NewLocalClientCreator(tc.app)
+			proxyApp := proxy.NewAppConns(cc, abcicli.NopMetrics())
+			require.NoError(t, proxyApp.Start())
+			defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+			state, stateDB, _ := makeState(1, 1)
+			blockExec := sm.NewBlockExecutor(sm.NewStore(stateDB), log.TestingLogger(), proxyApp.Consensus(),
+				proxyApp.Query(), reapMempool{txs: mempoolTxs}, sm.EmptyEvidencePool{}, nil)
+
+			proposerProTxHash := state.Validators.GetProposer().ProTxHash
+			commit := types.NewCommit(0, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
+			block, blockParts, err := blockExec.CreateProposalBlock(1, 2, state, commit, proposerProTxHash)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, blockParts)
+			assert.Equal(t, tc.wantTxs, block.Txs)
+
+			if !tc.app.supported {
+				assert.Nil(t, tc.app.req)
+				return
+			}
+			require.NotNil(t, tc.app.req)
+			assert.EqualValues(t, 1, tc.app.req.Height)
+			assert.EqualValues(t, 2, tc.app.req.Round)
+			assert.EqualValues(t, proposerProTxHash, tc.app.req.ProposerProTxHash)
+			assert.Len(t, tc.app.req.Txs, len(mempoolTxs))
+			assert.Positive(t, tc.app.req.MaxTxBytes)
+		})
+	}
+}
+
 func makeBlockID(hash []byte, partSetSize uint32, partSetHash []byte) types.BlockID {
 	var (
 		h   = make([]byte, tmhash.Size)
@@ -44,9 +44,28 @@ func ValidateValidatorUpdates(abciUpdates []abci.ValidatorUpdate, params tmproto
 	return validateValidatorUpdates(abciUpdates, params)
 }
 
+// ValidateConsensusParamUpdates is an alias for validateConsensusParamUpdates
+// exported from execution.go, exclusively and explicitly for testing.
+func ValidateConsensusParamUpdates(params tmproto.ConsensusParams, updates *abci.ConsensusParams, height int64) error {
+	return validateConsensusParamUpdates(params, updates, height)
+}
+
+// ValidateQuorumTypeUpdate is an alias for validateQuorumTypeUpdate exported
+// from execution.go, exclusively and explicitly for testing.
+func ValidateQuorumTypeUpdate(valSet *types.ValidatorSet, abciValidatorSetUpdate *abci.ValidatorSetUpdate) error {
+	return validateQuorumTypeUpdate(valSet, abciValidatorSetUpdate)
+}
+
 // SaveValidatorsInfo is an alias for the private saveValidatorsInfo method in
 // store.go, exported exclusively and explicitly for testing.
 func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *types.ValidatorSet) error {
-	stateStore := dbStore{db}
+	stateStore := dbStore{db: db}
 	return stateStore.saveValidatorsInfo(height, lastHeightChanged, valSet)
 }
+
+// SaveConsensusParamsInfo is an alias for the private saveConsensusParamsInfo
+// method in store.go, exported exclusively and explicitly for testing.
+func SaveConsensusParamsInfo(db dbm.DB, nextHeight, changeHeight int64, params tmproto.ConsensusParams) error {
+	stateStore := dbStore{db: db}
+	return stateStore.saveConsensusParamsInfo(nextHeight, changeHeight, params)
+}
@@ -4,6 +4,7 @@ package state_test
 import (
 	"fmt"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 	dbm "github.com/tendermint/tm-db"
 
@@ -26,7 +27,7 @@ type paramsChangeTestCase struct {
 func newTestApp() proxy.AppConns {
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
-	return proxy.NewAppConns(cc)
+	return proxy.NewAppConns(cc, abcicli.NopMetrics())
 }
 
 func makeAndCommitGoodBlock(
@@ -91,7 +92,43 @@ func makeValidCommit(
 	thresholdBlockSig, _ := bls12381.RecoverThresholdSignatureFromShares(blockSigs, blsIDs)
 	thresholdStateSig, _ := bls12381.RecoverThresholdSignatureFromShares(stateSigs, blsIDs)
 
-	return types.NewCommit(height, 0, blockID, stateID, vals.QuorumHash, thresholdBlockSig, thresholdStateSig), nil
+	commit := types.NewCommit(height, 0, blockID, stateID, vals.QuorumHash, thresholdBlockSig, thresholdStateSig)
+	commit.Signers = types.MakeAllCommitSigners(vals.Size())
+	return commit, nil
+}
+
+// makeValidExtendedCommit returns a valid commit, which carries the vote
+// extension signed by all validators.
+func makeValidExtendedCommit(
+	height int64,
+	blockID types.BlockID,
+	stateID types.StateID,
+	vals *types.ValidatorSet,
+	privVals map[string]types.PrivValidator,
+	extension []byte,
+) (*types.Commit, error) {
+	commit, err := makeValidCommit(height, blockID, stateID, vals, privVals)
+	if err != nil {
+		return nil, err
+	}
+	var extensionSigs [][]byte
+	var blsIDs [][]byte
+	for i := 0; i < vals.Size(); i++ {
+		_, val := vals.GetByIndex(int32(i))
+		vote := commit.GetCanonicalVote()
+		vote.ValidatorProTxHash = val.ProTxHash
+		vote.ValidatorIndex = int32(i)
+		vote.Extension = extension
+		v := vote.ToProto()
+		if err := privVals[val.ProTxHash.String()].SignVote(chainID, vals.QuorumType, vals.QuorumHash, v); err != nil {
+			return nil, err
+		}
+		extensionSigs = append(extensionSigs, v.ExtensionSignature)
+		blsIDs = append(blsIDs, val.ProTxHash)
+	}
+	commit.VoteExtension = extension
+	commit.ThresholdVoteExtensionSignature, err = bls12381.RecoverThresholdSignatureFromShares(extensionSigs, blsIDs)
+	return commit, err
 }
 
 // make some bogus txs
@@ -227,8 +264,11 @@ func randomGenesisDoc() *types.GenesisDoc {
 type testApp struct {
 	abci.BaseApplication
 
-	ByzantineValidators []abci.Evidence
-	ValidatorSetUpdate  *abci.ValidatorSetUpdate
+	ByzantineValidators   []abci.Evidence
+	LastCommitInfo        abci.LastCommitInfo
+	ValidatorSetUpdate    *abci.ValidatorSetUpdate
+	ConsensusParamUpdates *abci.ConsensusParams
+	EndBlockRequest       abci.RequestEndBlock
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -239,15 +279,21 @@ func (app *testApp) Info(req abci.RequestInfo) (resInfo abci.ResponseInfo) {
 
 func (app *testApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 	app.ByzantineValidators = req.ByzantineValidators
+	app.LastCommitInfo = req.LastCommitInfo
 	return abci.ResponseBeginBlock{}
 }
 
 func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
-	return abci.ResponseEndBlock{
-		ValidatorSetUpdate: app.ValidatorSetUpdate,
-		ConsensusParamUpdates: &abci.ConsensusParams{
+	app.EndBlockRequest = req
+	consensusParamUpdates := app.ConsensusParamUpdates
+	if consensusParamUpdates == nil {
+		consensusParamUpdates = &abci.ConsensusParams{
 			Version: &tmproto.VersionParams{
-				AppVersion: 1}}}
+				AppVersion: 1}}
+	}
+	return abci.ResponseEndBlock{
+		ValidatorSetUpdate:    app.ValidatorSetUpdate,
+		ConsensusParamUpdates: consensusParamUpdates}
 }
 
 func (app *testApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
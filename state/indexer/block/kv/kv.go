@@ -91,11 +91,45 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions, err := q.Conditions()
+	// The conditions of the conjunctions are searched together, and the matches
+	// of the disjunctions united.
+	filteredHeights, err := indexer.SearchExpression(q.Expression(),
+		func(conditions []query.Condition) (map[string][]byte, error) {
+			return idx.searchConditions(ctx, conditions)
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query conditions: %w", err)
+		return nil, err
 	}
 
+	// fetch matching heights
+	results = make([]int64, 0, len(filteredHeights))
+	for _, hBz := range filteredHeights {
+		h := int64FromBytes(hBz)
+
+		ok, err := idx.Has(h)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			results = append(results, h)
+		}
+
+		select {
+		case <-ctx.Done():
+			break
+
+		default:
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// searchConditions returns the block heights matching all the given
+// conditions, keyed by their encodings.
+func (idx *BlockerIndexer) searchConditions(ctx context.Context, conditions []query.Condition) (map[string][]byte, error) {
 	// If there is an exact height query, return the result immediately
 	// (if it exists).
 	height, ok := lookForHeight(conditions)
@@ -106,10 +140,10 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		}
 
 		if ok {
-			return []int64{height}, nil
+			return map[string][]byte{string(int64ToBytes(height)): int64ToBytes(height)}, nil
 		}
 
-		return results, nil
+		return make(map[string][]byte), nil
 	}
 
 	var heightsInitialized bool
@@ -184,30 +218,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		}
 	}
 
-	// fetch matching heights
-	results = make([]int64, 0, len(filteredHeights))
-	for _, hBz := range filteredHeights {
-		h := int64FromBytes(hBz)
-
-		ok, err := idx.Has(h)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			results = append(results, h)
-		}
-
-		select {
-		case <-ctx.Done():
-			break
-
-		default:
-		}
-	}
-
-	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
-
-	return results, nil
+	return filteredHeights, nil
 }
 
 // matchRange returns all matching block heights that match a given QueryRange
@@ -128,6 +128,18 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustParse("begin_event.proposer CONTAINS 'FCAA001'"),
 			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
 		},
+		"end_event.foo <= 4 OR end_event.foo >= 10": {
+			q:       query.MustParse("end_event.foo <= 4 OR end_event.foo >= 10"),
+			results: []int64{1, 2, 4, 10},
+		},
+		"block.height = 3 OR block.height = 5 OR block.height = 100": {
+			q:       query.MustParse("block.height = 3 OR block.height = 5 OR block.height = 100"),
+			results: []int64{3, 5},
+		},
+		"block.height > 2 AND (end_event.foo <= 4 OR block.height = 7)": {
+			q:       query.MustParse("block.height > 2 AND (end_event.foo <= 4 OR block.height = 7)"),
+			results: []int64{4, 7},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+// ConditionsSearch returns the matches of all the given query conditions,
+// keyed by the ids of the matched items (e.g. tx hashes).
+type ConditionsSearch func(conditions []query.Condition) (map[string][]byte, error)
+
+// SearchExpression returns the matches of the query expression, keyed by the
+// ids of the matched items. The conditions of each conjunction are searched
+// together, so that they narrow each other down, while the matches of the
+// disjunctions are united and intersected with the matches of the
+// conjunctions they are the operands of.
+//
+// e.g. "a=1 AND b=2 AND (c=3 OR d=4)" => search(a=1, b=2) ∩ (search(c=3) ∪ search(d=4))
+func SearchExpression(expr *query.Expression, search ConditionsSearch) (map[string][]byte, error) {
+	switch expr.Op {
+	case query.ExprCondition:
+		return search([]query.Condition{expr.Condition})
+
+	case query.ExprOr:
+		matches := make(map[string][]byte)
+		for _, operand := range expr.Operands {
+			operandMatches, err := SearchExpression(operand, search)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range operandMatches {
+				matches[k] = v
+			}
+		}
+
+		return matches, nil
+
+	default:
+		var (
+			conditions   []query.Condition
+			disjunctions []*query.Expression
+		)
+		for _, operand := range expr.Operands {
+			if operand.Op == query.ExprCondition {
+				conditions = append(conditions, operand.Condition)
+			} else {
+				disjunctions = append(disjunctions, operand)
+			}
+		}
+
+		var matches map[string][]byte
+		if len(conditions) > 0 {
+			var err error
+			matches, err = search(conditions)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, disjunction := range disjunctions {
+			// Ignore any remaining operands if the previous ones resulted in no
+			// matches.
+			if matches != nil && len(matches) == 0 {
+				break
+			}
+
+			operandMatches, err := SearchExpression(disjunction, search)
+			if err != nil {
+				return nil, err
+			}
+
+			if matches == nil {
+				matches = operandMatches
+				continue
+			}
+
+			for k := range matches {
+				if _, ok := operandMatches[k]; !ok {
+					delete(matches, k)
+				}
+			}
+		}
+
+		return matches, nil
+	}
+}
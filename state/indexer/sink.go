@@ -0,0 +1,32 @@
+package indexer
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// EventSinkType is the type of an event sink, as set in the tx_index.indexer
+// config option.
+type EventSinkType string
+
+const (
+	NULL EventSinkType = "null"
+	KV   EventSinkType = "kv"
+	PSQL EventSinkType = "psql"
+)
+
+// EventSink defines an interface contract for writing the events of the
+// blocks and their transactions to an external store. The sinks are
+// write-only, the search RPCs are served by the kv indexers.
+type EventSink interface {
+	// IndexBlock stores the BeginBlock and EndBlock events of the block along
+	// with the results and the events of its transactions. Indexing the same
+	// block more than once has no effect.
+	IndexBlock(header types.EventDataNewBlockHeader, txResults []*abci.TxResult) error
+
+	// Type returns the type of the sink.
+	Type() EventSinkType
+
+	// Stop releases the resources of the sink.
+	Stop() error
+}
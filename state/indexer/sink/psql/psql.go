@@ -0,0 +1,270 @@
+// Package psql implements an event sink, which writes the events of the
+// blocks and their transactions to a PostgreSQL database.
+package psql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	// register the postgres driver
+	_ "github.com/lib/pq"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	driverName = "postgres"
+
+	// maxAttributesPerInsert limits the number of the attributes inserted by
+	// one statement, keeping the number of its parameters far below the limit
+	// of PostgreSQL (65535).
+	maxAttributesPerInsert = 1000
+)
+
+var _ indexer.EventSink = (*EventSink)(nil)
+
+// EventSink is an indexer.EventSink backed by PostgreSQL. Every block is
+// written with its events, tx results and their events in a single
+// transaction, and a block, which is already in the database, is skipped, so
+// each block is written exactly once.
+type EventSink struct {
+	store   *sql.DB
+	chainID string
+}
+
+// NewEventSink connects to the database by the connection string, and
+// migrates its schema to the latest version. The blocks are written for the
+// chain with the given ID.
+func NewEventSink(connStr string, chainID string) (*EventSink, error) {
+	db, err := sql.Open(driverName, connStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate the schema: %w", err)
+	}
+
+	return &EventSink{store: db, chainID: chainID}, nil
+}
+
+// migrate applies the migrations, which aren't applied yet.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`
+CREATE TABLE IF NOT EXISTS schema_migrations (
+  version    INTEGER PRIMARY KEY,
+  applied_at TIMESTAMPTZ NOT NULL
+);`); err != nil {
+		return err
+	}
+
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("the schema version %d is newer than the latest known one %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		err := runInTransaction(db, func(tx *sql.Tx) error {
+			if _, err := tx.Exec(migrations[i]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2);`,
+				i+1, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// IndexBlock implements indexer.EventSink.
+func (es *EventSink) IndexBlock(h types.EventDataNewBlockHeader, txResults []*abci.TxResult) error {
+	now := time.Now().UTC()
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		var blockID int64
+		err := dbtx.QueryRow(`
+INSERT INTO blocks (height, chain_id, time, created_at)
+  VALUES ($1, $2, $3, $4)
+  ON CONFLICT (height, chain_id) DO NOTHING
+  RETURNING rowid;`, h.Header.Height, es.chainID, h.Header.Time.UTC(), now).Scan(&blockID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// the block and its txs are written already
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("indexing block header: %w", err)
+		}
+
+		attrs := newAttributeBatch(dbtx)
+
+		// the height of the block is indexed as a pseudo-event, like in the kv
+		// indexer
+		blockEvents := append([]abci.Event{
+			makeMetaEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
+		}, h.ResultBeginBlock.Events...)
+		blockEvents = append(blockEvents, h.ResultEndBlock.Events...)
+		if err := insertEvents(dbtx, attrs, blockID, nil, blockEvents); err != nil {
+			return fmt.Errorf("indexing block events: %w", err)
+		}
+
+		for _, txr := range txResults {
+			if txr == nil {
+				continue
+			}
+			bz, err := proto.Marshal(txr)
+			if err != nil {
+				return fmt.Errorf("encoding tx result %d: %w", txr.Index, err)
+			}
+			txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+			var txID int64
+			err = dbtx.QueryRow(`
+INSERT INTO tx_results (block_id, index, created_at, tx_hash, tx_result)
+  VALUES ($1, $2, $3, $4, $5)
+  ON CONFLICT (block_id, index) DO NOTHING
+  RETURNING rowid;`, blockID, txr.Index, now, txHash, bz).Scan(&txID)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("indexing tx result %d: %w", txr.Index, err)
+			}
+
+			txEvents := append([]abci.Event{
+				makeMetaEvent(types.TxHashKey, txHash),
+				makeMetaEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
+			}, txr.Result.Events...)
+			if err := insertEvents(dbtx, attrs, blockID, &txID, txEvents); err != nil {
+				return fmt.Errorf("indexing events of tx result %d: %w", txr.Index, err)
+			}
+		}
+
+		if err := attrs.flush(); err != nil {
+			return fmt.Errorf("indexing attributes: %w", err)
+		}
+		return nil
+	})
+}
+
+// Type implements indexer.EventSink.
+func (es *EventSink) Type() indexer.EventSinkType {
+	return indexer.PSQL
+}
+
+// Stop implements indexer.EventSink by closing the connections to the
+// database.
+func (es *EventSink) Stop() error {
+	return es.store.Close()
+}
+
+// makeMetaEvent returns the event with the single indexed attribute, e.g.
+// tx.hash, by its composite key.
+func makeMetaEvent(compositeKey, value string) abci.Event {
+	i := strings.Index(compositeKey, ".")
+	return abci.Event{
+		Type: compositeKey[:i],
+		Attributes: []abci.EventAttribute{
+			{Key: []byte(compositeKey[i+1:]), Value: []byte(value), Index: true},
+		},
+	}
+}
+
+// insertEvents inserts the events of the block, or of the tx if txID isn't
+// nil, and adds their indexed attributes to the batch. The events without a
+// type are skipped, like in the kv indexer.
+func insertEvents(dbtx *sql.Tx, attrs *attributeBatch, blockID int64, txID *int64, events []abci.Event) error {
+	for _, event := range events {
+		if event.Type == "" {
+			continue
+		}
+
+		var eventID int64
+		err := dbtx.QueryRow(`
+INSERT INTO events (block_id, tx_id, type)
+  VALUES ($1, $2, $3)
+  RETURNING rowid;`, blockID, txID, event.Type).Scan(&eventID)
+		if err != nil {
+			return err
+		}
+
+		for _, attr := range event.Attributes {
+			if !attr.GetIndex() || len(attr.Key) == 0 {
+				continue
+			}
+			if err := attrs.add(eventID, event.Type, string(attr.Key), string(attr.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// attributeBatch collects the attributes of the events, and inserts them by
+// one statement per maxAttributesPerInsert attributes.
+type attributeBatch struct {
+	dbtx   *sql.Tx
+	n      int
+	params []interface{}
+}
+
+func newAttributeBatch(dbtx *sql.Tx) *attributeBatch {
+	return &attributeBatch{dbtx: dbtx}
+}
+
+func (b *attributeBatch) add(eventID int64, eventType, key, value string) error {
+	b.params = append(b.params, eventID, key, eventType+"."+key, value)
+	b.n++
+	if b.n >= maxAttributesPerInsert {
+		return b.flush()
+	}
+	return nil
+}
+
+func (b *attributeBatch) flush() error {
+	if b.n == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO attributes (event_id, key, composite_key, value) VALUES ")
+	for i := 0; i < b.n; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "($%d, $%d, $%d, $%d)", 4*i+1, 4*i+2, 4*i+3, 4*i+4)
+	}
+	sb.WriteString(";")
+
+	if _, err := b.dbtx.Exec(sb.String(), b.params...); err != nil {
+		return err
+	}
+	b.n = 0
+	b.params = b.params[:0]
+	return nil
+}
+
+// runInTransaction runs the query function in a transaction, which is
+// committed if the function succeeds, and rolled back otherwise.
+func runInTransaction(db *sql.DB, query func(*sql.Tx) error) error {
+	dbtx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := query(dbtx); err != nil {
+		_ = dbtx.Rollback()
+		return err
+	}
+	return dbtx.Commit()
+}
@@ -0,0 +1,271 @@
+package psql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	user     = "postgres"
+	password = "secret"
+	port     = "5432"
+	dbName   = "postgres"
+	chainID  = "test-chain"
+)
+
+// connStr is the connection string of the database started in docker, empty
+// if docker isn't available.
+var connStr string
+
+func TestMain(m *testing.M) {
+	os.Exit(runWithPostgres(m))
+}
+
+// runWithPostgres runs the tests with PostgreSQL started in docker. The tests
+// are skipped if docker isn't available.
+func runWithPostgres(m *testing.M) int {
+	pool, err := dockertest.NewPool(os.Getenv("DOCKER_URL"))
+	if err == nil {
+		err = pool.Client.Ping()
+	}
+	if err != nil {
+		log.Printf("docker isn't available, skipping the psql tests: %v", err)
+		return m.Run()
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "13",
+		Env: []string{
+			"POSTGRES_USER=" + user,
+			"POSTGRES_PASSWORD=" + password,
+			"POSTGRES_DB=" + dbName,
+		},
+		ExposedPorts: []string{port},
+	}, func(config *docker.HostConfig) {
+		// the container is removed, when stopped
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		log.Fatalf("failed to start postgres: %v", err)
+	}
+	defer func() {
+		if err := pool.Purge(resource); err != nil {
+			log.Printf("failed to remove postgres: %v", err)
+		}
+	}()
+	// the container is killed in any case
+	if err := resource.Expire(60 * 5); err != nil {
+		log.Fatalf("failed to set the expiration of postgres: %v", err)
+	}
+
+	conn := fmt.Sprintf("postgresql://%s:%s@localhost:%s/%s?sslmode=disable",
+		user, password, resource.GetPort(port+"/tcp"), dbName)
+	err = pool.Retry(func() error {
+		db, err := sql.Open(driverName, conn)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.Ping()
+	})
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	connStr = conn
+	return m.Run()
+}
+
+// setupEventSink returns the event sink, which writes to the empty database.
+func setupEventSink(t *testing.T) *EventSink {
+	if connStr == "" {
+		t.Skip("docker isn't available")
+	}
+
+	db, err := sql.Open(driverName, connStr)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`
+DROP VIEW IF EXISTS tx_events, block_events, event_attributes;
+DROP TABLE IF EXISTS attributes, events, tx_results, blocks, schema_migrations;`)
+	require.NoError(t, err)
+
+	es, err := NewEventSink(connStr, chainID)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := es.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return es
+}
+
+func newTestBlockHeader(height int64) types.EventDataNewBlockHeader {
+	return types.EventDataNewBlockHeader{
+		Header: types.Header{Height: height, Time: time.Now()},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{
+				{Type: "begin_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("proposer"), Value: []byte("FCAA001"), Index: true},
+				}},
+			},
+		},
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{
+				{Type: "end_event", Attributes: []abci.EventAttribute{
+					{Key: []byte("foo"), Value: []byte("100"), Index: true},
+					{Key: []byte("not_indexed"), Value: []byte("bar"), Index: false},
+				}},
+			},
+		},
+	}
+}
+
+func newTestTxResult(height int64, index uint32, tx string) *abci.TxResult {
+	return &abci.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     types.Tx(tx),
+		Result: abci.ResponseDeliverTx{
+			Code: abci.CodeTypeOK,
+			Events: []abci.Event{
+				{Type: "account", Attributes: []abci.EventAttribute{
+					{Key: []byte("owner"), Value: []byte("Ivan"), Index: true},
+					{Key: []byte("number"), Value: []byte(fmt.Sprint(index)), Index: true},
+				}},
+				{Type: "", Attributes: []abci.EventAttribute{
+					{Key: []byte("not_allowed"), Value: []byte("Vlad"), Index: true},
+				}},
+			},
+		},
+	}
+}
+
+func countRows(t *testing.T, es *EventSink, table string) int {
+	var n int
+	require.NoError(t, es.store.QueryRow(`SELECT COUNT(*) FROM `+table+`;`).Scan(&n))
+	return n
+}
+
+func TestIndexBlock(t *testing.T) {
+	es := setupEventSink(t)
+
+	txResults := []*abci.TxResult{newTestTxResult(1, 0, "foo"), newTestTxResult(1, 1, "bar")}
+	require.NoError(t, es.IndexBlock(newTestBlockHeader(1), txResults))
+
+	assert.Equal(t, 1, countRows(t, es, "blocks"))
+	assert.Equal(t, 2, countRows(t, es, "tx_results"))
+
+	// the block events with the block height
+	rows, err := es.store.Query(`
+SELECT composite_key, value FROM block_events WHERE height = 1 AND chain_id = $1 ORDER BY composite_key;`,
+		chainID)
+	require.NoError(t, err)
+	var blockAttrs []string
+	for rows.Next() {
+		var key, value string
+		require.NoError(t, rows.Scan(&key, &value))
+		blockAttrs = append(blockAttrs, key+"="+value)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"begin_event.proposer=FCAA001", "block.height=1", "end_event.foo=100"}, blockAttrs)
+
+	// the tx is found by its events
+	var (
+		index int
+		bz    []byte
+	)
+	err = es.store.QueryRow(`
+SELECT tx_results.index, tx_results.tx_result FROM tx_results
+  JOIN events ON (events.tx_id = tx_results.rowid)
+  JOIN attributes ON (attributes.event_id = events.rowid)
+  WHERE attributes.composite_key = 'account.number' AND attributes.value = '1';`).Scan(&index, &bz)
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+	txr := new(abci.TxResult)
+	require.NoError(t, proto.Unmarshal(bz, txr))
+	assert.Equal(t, txResults[1], txr)
+
+	var height int64
+	err = es.store.QueryRow(`
+SELECT height FROM tx_events WHERE composite_key = 'tx.hash' AND value = $1;`,
+		fmt.Sprintf("%X", types.Tx("foo").Hash())).Scan(&height)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, height)
+
+	// the events without a type and the attributes, which aren't indexed, are skipped
+	var n int
+	err = es.store.QueryRow(`
+SELECT COUNT(*) FROM attributes WHERE key IN ('not_allowed', 'not_indexed');`).Scan(&n)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestIndexBlockExactlyOnce(t *testing.T) {
+	es := setupEventSink(t)
+
+	header := newTestBlockHeader(1)
+	txResults := []*abci.TxResult{newTestTxResult(1, 0, "foo")}
+	require.NoError(t, es.IndexBlock(header, txResults))
+	events, attrs := countRows(t, es, "events"), countRows(t, es, "attributes")
+
+	// the block is written again, e.g. after a crash before the kv indexer
+	// saved it
+	require.NoError(t, es.IndexBlock(header, txResults))
+	require.NoError(t, es.IndexBlock(header, append(txResults, newTestTxResult(1, 1, "bar"))))
+
+	assert.Equal(t, 1, countRows(t, es, "blocks"))
+	assert.Equal(t, 1, countRows(t, es, "tx_results"))
+	assert.Equal(t, events, countRows(t, es, "events"))
+	assert.Equal(t, attrs, countRows(t, es, "attributes"))
+
+	// the block of another chain is written
+	other := &EventSink{store: es.store, chainID: "other-chain"}
+	require.NoError(t, other.IndexBlock(header, txResults))
+	assert.Equal(t, 2, countRows(t, es, "blocks"))
+}
+
+func TestIndexBlockManyAttributes(t *testing.T) {
+	es := setupEventSink(t)
+
+	var txResults []*abci.TxResult
+	for i := 0; i < maxAttributesPerInsert; i++ {
+		txResults = append(txResults, newTestTxResult(1, uint32(i), fmt.Sprint("tx", i)))
+	}
+	require.NoError(t, es.IndexBlock(newTestBlockHeader(1), txResults))
+
+	// 3 per block, 4 per tx: owner, number and the meta tx.hash and tx.height
+	assert.Equal(t, 3+4*maxAttributesPerInsert, countRows(t, es, "attributes"))
+}
+
+func TestMigrate(t *testing.T) {
+	es := setupEventSink(t)
+
+	// the migrations are applied once
+	require.NoError(t, migrate(es.store))
+	var version int
+	require.NoError(t, es.store.QueryRow(`SELECT MAX(version) FROM schema_migrations;`).Scan(&version))
+	assert.Equal(t, len(migrations), version)
+	assert.Equal(t, len(migrations), countRows(t, es, "schema_migrations"))
+
+	// the schema of the newer software isn't touched
+	_, err := es.store.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2);`,
+		len(migrations)+1, time.Now())
+	require.NoError(t, err)
+	assert.Error(t, migrate(es.store))
+}
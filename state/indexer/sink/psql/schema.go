@@ -0,0 +1,70 @@
+package psql
+
+// migrations are the changes of the schema, applied in order. The version of
+// the schema is the number of the applied migrations, which is stored in the
+// schema_migrations table. The applied migrations must never be changed, the
+// changes of the schema are appended as new migrations.
+var migrations = []string{
+	// 1: blocks, tx results, their events and the attributes of the events
+	`
+CREATE TABLE blocks (
+  rowid      BIGSERIAL PRIMARY KEY,
+  height     BIGINT NOT NULL,
+  chain_id   VARCHAR NOT NULL,
+  time       TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+
+  UNIQUE (height, chain_id)
+);
+
+CREATE TABLE tx_results (
+  rowid      BIGSERIAL PRIMARY KEY,
+  block_id   BIGINT NOT NULL REFERENCES blocks(rowid),
+  index      INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  tx_hash    VARCHAR NOT NULL,
+  -- the abci.TxResult encoded in protobuf
+  tx_result  BYTEA NOT NULL,
+
+  UNIQUE (block_id, index)
+);
+
+CREATE INDEX idx_tx_results_tx_hash ON tx_results(tx_hash);
+
+CREATE TABLE events (
+  rowid    BIGSERIAL PRIMARY KEY,
+  block_id BIGINT NOT NULL REFERENCES blocks(rowid),
+  -- NULL for the BeginBlock and EndBlock events
+  tx_id    BIGINT NULL REFERENCES tx_results(rowid),
+  type     VARCHAR NOT NULL
+);
+
+CREATE INDEX idx_events_block_id ON events(block_id);
+CREATE INDEX idx_events_tx_id ON events(tx_id);
+
+CREATE TABLE attributes (
+  event_id      BIGINT NOT NULL REFERENCES events(rowid),
+  key           VARCHAR NOT NULL,
+  -- the event type and the key joined with a dot, e.g. transfer.sender
+  composite_key VARCHAR NOT NULL,
+  value         VARCHAR NULL
+);
+
+CREATE INDEX idx_attributes_event_id ON attributes(event_id);
+CREATE INDEX idx_attributes_composite_key ON attributes(composite_key, value);
+
+CREATE VIEW event_attributes AS
+  SELECT block_id, tx_id, type, key, composite_key, value
+  FROM events LEFT JOIN attributes ON (events.rowid = attributes.event_id);
+
+CREATE VIEW block_events AS
+  SELECT blocks.rowid as block_id, height, chain_id, type, key, composite_key, value
+  FROM blocks JOIN event_attributes ON (blocks.rowid = event_attributes.block_id)
+  WHERE event_attributes.tx_id IS NULL;
+
+CREATE VIEW tx_events AS
+  SELECT height, index, chain_id, type, key, composite_key, value, tx_results.created_at
+  FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id)
+  JOIN event_attributes ON (tx_results.rowid = event_attributes.tx_id);
+`,
+}
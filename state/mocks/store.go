@@ -32,6 +32,20 @@ func (_m *Store) Bootstrap(_a0 state.State) error {
 	return r0
 }
 
+// ConfirmRollback provides a mock function with given fields:
+func (_m *Store) ConfirmRollback() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Load provides a mock function with given fields:
 func (_m *Store) Load() (state.State, error) {
 	ret := _m.Called()
@@ -139,6 +153,29 @@ func (_m *Store) LoadFromDBOrGenesisFile(_a0 string) (state.State, error) {
 	return r0, r1
 }
 
+// LoadRollbackMarker provides a mock function with given fields:
+func (_m *Store) LoadRollbackMarker() (*tendermintstate.RollbackMarker, error) {
+	ret := _m.Called()
+
+	var r0 *tendermintstate.RollbackMarker
+	if rf, ok := ret.Get(0).(func() *tendermintstate.RollbackMarker); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tendermintstate.RollbackMarker)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*tenderminttypes.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -162,6 +199,50 @@ func (_m *Store) LoadValidators(_a0 int64) (*tenderminttypes.ValidatorSet, error
 	return r0, r1
 }
 
+// LoadValidatorsAtRound provides a mock function with given fields: _a0, _a1
+func (_m *Store) LoadValidatorsAtRound(_a0 int64, _a1 int32) (*tenderminttypes.ValidatorSet, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *tenderminttypes.ValidatorSet
+	if rf, ok := ret.Get(0).(func(int64, int32) *tenderminttypes.ValidatorSet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tenderminttypes.ValidatorSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int32) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneABCIResponses provides a mock function with given fields: retainHeight, limit
+func (_m *Store) PruneABCIResponses(retainHeight int64, limit int64) (int64, error) {
+	ret := _m.Called(retainHeight, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(retainHeight, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(retainHeight, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PruneStates provides a mock function with given fields: _a0, _a1
 func (_m *Store) PruneStates(_a0 int64, _a1 int64) error {
 	ret := _m.Called(_a0, _a1)
@@ -176,6 +257,48 @@ func (_m *Store) PruneStates(_a0 int64, _a1 int64) error {
 	return r0
 }
 
+// PruneValidatorSets provides a mock function with given fields: retainHeight, limit
+func (_m *Store) PruneValidatorSets(retainHeight int64, limit int64) (int64, error) {
+	ret := _m.Called(retainHeight, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(retainHeight, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(retainHeight, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rollback provides a mock function with given fields: meta, nextMeta
+func (_m *Store) Rollback(meta *tenderminttypes.BlockMeta, nextMeta *tenderminttypes.BlockMeta) (state.State, error) {
+	ret := _m.Called(meta, nextMeta)
+
+	var r0 state.State
+	if rf, ok := ret.Get(0).(func(*tenderminttypes.BlockMeta, *tenderminttypes.BlockMeta) state.State); ok {
+		r0 = rf(meta, nextMeta)
+	} else {
+		r0 = ret.Get(0).(state.State)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*tenderminttypes.BlockMeta, *tenderminttypes.BlockMeta) error); ok {
+		r1 = rf(meta, nextMeta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: _a0
 func (_m *Store) Save(_a0 state.State) error {
 	ret := _m.Called(_a0)
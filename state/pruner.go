@@ -0,0 +1,165 @@
+package state
+
+import (
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/libs/service"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// defaultPruneBatchSize is the maximum number of heights pruned at once.
+const defaultPruneBatchSize = 1000
+
+// Pruner prunes the ABCI responses and the validator sets of the past heights
+// from the state store in the background, once the node doesn't need to
+// retain them anymore. The BlockExecutor schedules it after each block is
+// committed. It prunes at most a batch of heights at a time, so that a large
+// prune (e.g. the first one of a long running node) doesn't stall the database
+// compaction.
+type Pruner struct {
+	service.BaseService
+
+	store Store
+
+	// the number of the most recent heights to retain, 0 to retain all
+	retainABCIResponses int64
+	retainValidatorSets int64
+
+	// the light clients verify the last snapshot, e.g. to state sync
+	snapshots SnapshotPolicy
+	batchSize int64
+
+	mtx   tmsync.Mutex
+	state State // the last committed state
+
+	pruneCh chan struct{}
+}
+
+type PrunerOption func(*Pruner)
+
+// PrunerWithSnapshotPolicy is used to retain the validator sets of the last
+// snapshot scheduled by the policy.
+func PrunerWithSnapshotPolicy(policy SnapshotPolicy) PrunerOption {
+	return func(p *Pruner) {
+		p.snapshots = policy
+	}
+}
+
+// PrunerWithBatchSize is used to specify the maximum number of heights pruned
+// at once.
+func PrunerWithBatchSize(size int64) PrunerOption {
+	return func(p *Pruner) {
+		p.batchSize = size
+	}
+}
+
+// NewPruner returns a new Pruner, which retains the ABCI responses and the
+// validator sets of the given numbers of the most recent heights (0 retains
+// all of them).
+func NewPruner(store Store, retainABCIResponses, retainValidatorSets int64, options ...PrunerOption) *Pruner {
+	p := &Pruner{
+		store:               store,
+		retainABCIResponses: retainABCIResponses,
+		retainValidatorSets: retainValidatorSets,
+		batchSize:           defaultPruneBatchSize,
+		pruneCh:             make(chan struct{}, 1),
+	}
+	p.BaseService = *service.NewBaseService(nil, "Pruner", p)
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// OnStart implements service.Service by starting the pruning routine.
+func (p *Pruner) OnStart() error {
+	go p.pruneRoutine()
+	return nil
+}
+
+// Prune schedules the pruning of the heights, which aren't retained anymore
+// once the state is committed. It doesn't block.
+func (p *Pruner) Prune(state State) {
+	p.mtx.Lock()
+	p.state = state
+	p.mtx.Unlock()
+
+	p.schedule()
+}
+
+func (p *Pruner) schedule() {
+	select {
+	case p.pruneCh <- struct{}{}:
+	default: // already scheduled
+	}
+}
+
+func (p *Pruner) pruneRoutine() {
+	for {
+		select {
+		case <-p.Quit():
+			return
+		case <-p.pruneCh:
+		}
+
+		p.mtx.Lock()
+		state := p.state
+		p.mtx.Unlock()
+
+		// Continue with the next batch, unless the pruning caught up.
+		if p.pruneBatch(state) {
+			p.schedule()
+		}
+	}
+}
+
+// pruneBatch prunes a batch of heights and returns true if there are more
+// heights to prune.
+func (p *Pruner) pruneBatch(state State) bool {
+	abciRetainHeight, valsRetainHeight := p.retainHeights(state)
+	more := false
+
+	if abciRetainHeight > 0 {
+		pruned, err := p.store.PruneABCIResponses(abciRetainHeight, p.batchSize)
+		if err != nil {
+			p.Logger.Error("Failed to prune ABCI responses", "retainHeight", abciRetainHeight, "err", err)
+		} else if pruned > 0 {
+			p.Logger.Debug("Pruned ABCI responses", "pruned", pruned, "retainHeight", abciRetainHeight)
+			more = more || pruned == p.batchSize
+		}
+	}
+
+	if valsRetainHeight > 0 {
+		pruned, err := p.store.PruneValidatorSets(valsRetainHeight, p.batchSize)
+		if err != nil {
+			p.Logger.Error("Failed to prune validator sets", "retainHeight", valsRetainHeight, "err", err)
+		} else if pruned > 0 {
+			p.Logger.Debug("Pruned validator sets", "pruned", pruned, "retainHeight", valsRetainHeight)
+			more = more || pruned == p.batchSize
+		}
+	}
+
+	return more
+}
+
+// retainHeights returns the lowest heights to retain the ABCI responses and
+// the validator sets of, once the state is committed, or 0 to retain all of
+// them. The validator sets of the heights whose evidence can still be
+// committed (within Evidence.MaxAgeNumBlocks), and the ones of the last
+// snapshot are always retained.
+func (p *Pruner) retainHeights(state State) (abciResponses int64, validatorSets int64) {
+	height := state.LastBlockHeight
+	if p.retainABCIResponses > 0 {
+		abciResponses = height - p.retainABCIResponses + 1
+	}
+	if p.retainValidatorSets > 0 {
+		validatorSets = height - p.retainValidatorSets + 1
+		validatorSets = tmmath.MinInt64(validatorSets, height-state.ConsensusParams.Evidence.MaxAgeNumBlocks)
+		if p.snapshots.Interval > 0 {
+			interval := int64(p.snapshots.Interval)
+			validatorSets = tmmath.MinInt64(validatorSets, height-height%interval)
+		}
+	}
+	return tmmath.MaxInt64(abciResponses, 0), tmmath.MaxInt64(validatorSets, 0)
+}
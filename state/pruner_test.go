@@ -0,0 +1,57 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/libs/log"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+func TestPruner(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	saveTestHeights(t, stateStore, 30)
+
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, 29, state.LastBlockHeight)
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 2
+
+	pruner := sm.NewPruner(stateStore, 5, 5,
+		sm.PrunerWithSnapshotPolicy(sm.SnapshotPolicy{Interval: 10}),
+		sm.PrunerWithBatchSize(4),
+	)
+	pruner.SetLogger(log.TestingLogger())
+	require.NoError(t, pruner.Start())
+	t.Cleanup(func() {
+		if err := pruner.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	pruner.Prune(state)
+
+	// The ABCI responses of the 5 most recent heights are retained, and the
+	// validator sets since the last snapshot at 20, which is older than the
+	// evidence.
+	require.Eventually(t, func() bool {
+		_, err := stateStore.LoadABCIResponses(24)
+		return err == sm.ErrPruned{Height: 24, Base: 25}
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, err := stateStore.LoadValidators(19)
+		return err == sm.ErrPruned{Height: 19, Base: 20}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for h := int64(25); h <= 29; h++ {
+		_, err := stateStore.LoadABCIResponses(h)
+		require.NoError(t, err, "abci height %v", h)
+	}
+	for h := int64(20); h <= 29; h++ {
+		_, err := stateStore.LoadValidators(h)
+		require.NoError(t, err, "validators height %v", h)
+	}
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rollback rewinds the state and the block store to the given height, as if
+// the blocks above it were never committed, and records the block discarded
+// at the next height as rolled back, so that it isn't synced again from the
+// peers until the operator confirms the rollback (see Store.ConfirmRollback).
+// The application and the consensus WAL aren't rolled back.
+//
+// It refuses to roll back below the earliest stored block or state, e.g.
+// across the snapshot the node was state synced from. If it's interrupted, it
+// can be run again with the same height. It returns the rolled back state.
+func Rollback(bs BlockStore, ss Store, height int64) (State, error) {
+	state, err := ss.Load()
+	if err != nil {
+		return State{}, err
+	}
+	if state.IsEmpty() {
+		return State{}, errors.New("no state found")
+	}
+
+	if height == state.LastBlockHeight {
+		// the state was rolled back already, but the blocks may not be
+		marker, err := ss.LoadRollbackMarker()
+		if err != nil {
+			return State{}, err
+		}
+		if marker != nil && marker.Height == height {
+			if bs.Height() > height {
+				if _, err := bs.RollbackBlocks(height); err != nil {
+					return State{}, err
+				}
+			}
+			return state, nil
+		}
+	}
+	if height >= state.LastBlockHeight {
+		return State{}, fmt.Errorf("can't roll back to height %d, the latest height is %d",
+			height, state.LastBlockHeight)
+	}
+	if height < state.InitialHeight {
+		return State{}, fmt.Errorf("can't roll back to height %d below the initial height %d",
+			height, state.InitialHeight)
+	}
+	if base := bs.Base(); height < base {
+		return State{}, fmt.Errorf("can't roll back to height %d below the earliest stored block %d",
+			height, base)
+	}
+
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil {
+		return State{}, fmt.Errorf("block at height %d not found", height)
+	}
+	nextMeta := bs.LoadBlockMeta(height + 1)
+	if nextMeta == nil {
+		return State{}, fmt.Errorf("block at height %d not found", height+1)
+	}
+	rolledBack, err := ss.Rollback(meta, nextMeta)
+	if err != nil {
+		return State{}, fmt.Errorf("can't roll back the state to height %d: %w", height, err)
+	}
+	if _, err := bs.RollbackBlocks(height); err != nil {
+		return State{}, err
+	}
+	return rolledBack, nil
+}
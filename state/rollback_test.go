@@ -0,0 +1,235 @@
+package state_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// rollbackTestChain is a chain with validator set and consensus params
+// changes, whose blocks are committed without executing them.
+type rollbackTestChain struct {
+	blocks    map[int64]*types.Block
+	responses map[int64]*tmstate.ABCIResponses
+	states    map[int64]sm.State
+}
+
+func makeRollbackTestChain(
+	t *testing.T,
+	state sm.State,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	height int64,
+) *rollbackTestChain {
+	chain := &rollbackTestChain{
+		blocks:    make(map[int64]*types.Block),
+		responses: make(map[int64]*tmstate.ABCIResponses),
+		states:    make(map[int64]sm.State),
+	}
+	for h := state.LastBlockHeight + 1; h <= height; h++ {
+		block := makeBlock(state, h)
+		responses := &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK, Data: []byte{byte(h)}}},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}
+		switch h {
+		case 4, 11, 12:
+			update := types.ValidatorUpdatesRegenerateOnProTxHashes(state.Validators.GetProTxHashes())
+			responses.EndBlock.ValidatorSetUpdate = &update
+		case 8, 15:
+			params := state.ConsensusParams
+			params.Block.MaxBytes -= h
+			responses.EndBlock.ConsensusParamUpdates = types.TM2PB.ConsensusParams(&params)
+		}
+		state = commitRollbackTestBlock(t, state, stateStore, blockStore, block, responses)
+		chain.blocks[h] = block
+		chain.responses[h] = responses
+		chain.states[h] = state
+	}
+	return chain
+}
+
+func commitRollbackTestBlock(
+	t *testing.T,
+	state sm.State,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	block *types.Block,
+	responses *tmstate.ABCIResponses,
+) sm.State {
+	parts := block.MakePartSet(testPartSize)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	blockStore.SaveBlock(block, parts, &types.Commit{Height: block.Height, BlockID: blockID})
+	require.NoError(t, stateStore.SaveABCIResponses(block.Height, responses))
+
+	validatorUpdates, thresholdPublicKey, quorumHash, err :=
+		types.PB2TM.ValidatorUpdatesFromValidatorSet(responses.EndBlock.ValidatorSetUpdate)
+	require.NoError(t, err)
+	proTxHash, _ := state.Validators.GetByIndex(0)
+	state, err = sm.UpdateState(state, &proTxHash, blockID, &block.Header, responses, validatorUpdates,
+		thresholdPublicKey, quorumHash)
+	require.NoError(t, err)
+	state.AppHash = tmhash.Sum([]byte(fmt.Sprintf("app hash %d", block.Height)))
+	require.NoError(t, stateStore.Save(state))
+	return state
+}
+
+func TestRollback(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	chain := makeRollbackTestChain(t, state, stateStore, blockStore, 20)
+
+	// the state is rewound to the one saved after the block at the height
+	rolledBack, err := sm.Rollback(blockStore, stateStore, 10)
+	require.NoError(t, err)
+	assert.Equal(t, chain.states[10].Bytes(), rolledBack.Bytes())
+	loaded, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, chain.states[10].Bytes(), loaded.Bytes())
+
+	assert.EqualValues(t, 10, blockStore.Height())
+	assert.Nil(t, blockStore.LoadBlockMeta(11))
+	assert.NotNil(t, blockStore.LoadBlockMeta(10))
+
+	// the data of the heights above is deleted
+	_, err = stateStore.LoadValidators(12)
+	assert.NoError(t, err)
+	_, err = stateStore.LoadValidators(13)
+	assert.Error(t, err)
+	params, err := stateStore.LoadConsensusParams(11)
+	assert.NoError(t, err)
+	assert.Equal(t, chain.states[10].ConsensusParams, params)
+	_, err = stateStore.LoadConsensusParams(12)
+	assert.Error(t, err)
+	_, err = stateStore.LoadABCIResponses(10)
+	assert.NoError(t, err)
+	_, err = stateStore.LoadABCIResponses(11)
+	assert.Error(t, err)
+
+	marker, err := stateStore.LoadRollbackMarker()
+	require.NoError(t, err)
+	require.NotNil(t, marker)
+	assert.EqualValues(t, 10, marker.Height)
+	assert.EqualValues(t, chain.blocks[11].Hash(), marker.BlockID.Hash)
+
+	// an interrupted rollback can be finished
+	rolledBack, err = sm.Rollback(blockStore, stateStore, 10)
+	require.NoError(t, err)
+	assert.Equal(t, chain.states[10].Bytes(), rolledBack.Bytes())
+
+	// committing the same blocks again results in the same states
+	state = rolledBack
+	for h := int64(11); h <= 20; h++ {
+		state = commitRollbackTestBlock(t, state, stateStore, blockStore, chain.blocks[h], chain.responses[h])
+		require.Equal(t, chain.states[h].Bytes(), state.Bytes(), "height %d", h)
+	}
+	for h := int64(1); h <= 22; h++ {
+		vals, err := stateStore.LoadValidators(h)
+		require.NoError(t, err, "height %d", h)
+		if h <= 20 {
+			assert.Equal(t, chain.states[h].LastValidators.Hash(), vals.Hash(), "height %d", h)
+		}
+	}
+	// the params changed at the heights 8 and 15, before and after the rollback
+	for h := int64(1); h <= 20; h++ {
+		params, err := stateStore.LoadConsensusParams(h + 1)
+		require.NoError(t, err, "height %d", h+1)
+		assert.Equal(t, chain.states[h].ConsensusParams, params, "height %d", h+1)
+	}
+
+	require.NoError(t, stateStore.ConfirmRollback())
+	marker, err = stateStore.LoadRollbackMarker()
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+}
+
+func TestRollbackRefused(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	chain := makeRollbackTestChain(t, state, stateStore, blockStore, 20)
+
+	for _, height := range []int64{0, 20, 21} {
+		_, err := sm.Rollback(blockStore, stateStore, height)
+		assert.Error(t, err, "height %d", height)
+	}
+
+	// below the earliest stored block
+	_, err := blockStore.PruneBlocks(5)
+	require.NoError(t, err)
+	_, err = sm.Rollback(blockStore, stateStore, 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "earliest stored block")
+
+	// below the earliest stored state
+	_, err = stateStore.PruneValidatorSets(8, 100)
+	require.NoError(t, err)
+	_, err = sm.Rollback(blockStore, stateStore, 6)
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &sm.ErrPruned{})
+
+	// nothing was changed
+	loaded, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, chain.states[20].Bytes(), loaded.Bytes())
+	assert.EqualValues(t, 20, blockStore.Height())
+	marker, err := stateStore.LoadRollbackMarker()
+	require.NoError(t, err)
+	assert.Nil(t, marker)
+
+	// across the snapshot the node was state synced from
+	syncedStateStore := sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, syncedStateStore.Bootstrap(chain.states[10]))
+	syncedBlockStore := store.NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, syncedBlockStore.SaveSeenCommit(10, &types.Commit{Height: 10}))
+	state = chain.states[10]
+	for h := int64(11); h <= 15; h++ {
+		state = commitRollbackTestBlock(t, state, syncedStateStore, syncedBlockStore, chain.blocks[h],
+			chain.responses[h])
+	}
+	_, err = sm.Rollback(syncedBlockStore, syncedStateStore, 10)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "earliest stored block")
+}
+
+func TestBlockExecutorVerifyNotRolledBack(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	chain := makeRollbackTestChain(t, state, stateStore, blockStore, 12)
+
+	blockID := func(h int64) types.BlockID {
+		return blockStore.LoadBlockMeta(h).BlockID
+	}
+	id11, id12 := blockID(11), blockID(12)
+	_, err := sm.Rollback(blockStore, stateStore, 10)
+	require.NoError(t, err)
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), nil, nil, nil, nil, nil)
+	err = blockExec.VerifyNotRolledBack(11, id11)
+	assert.Equal(t, sm.ErrBlockRolledBack{Height: 11, Hash: chain.blocks[11].Hash()}, err)
+	// another block at the height and the blocks above are synced
+	assert.NoError(t, blockExec.VerifyNotRolledBack(11, id12))
+	assert.NoError(t, blockExec.VerifyNotRolledBack(12, id12))
+
+	// the confirmed rollback isn't enforced
+	require.NoError(t, stateStore.ConfirmRollback())
+	blockExec = sm.NewBlockExecutor(stateStore, log.TestingLogger(), nil, nil, nil, nil, nil)
+	assert.NoError(t, blockExec.VerifyNotRolledBack(11, id11))
+}
@@ -242,7 +242,8 @@ func StateFromProto(pb *tmstate.State) (*State, error) { //nolint:golint
 	}
 
 	state.LastHeightValidatorsChanged = pb.LastHeightValidatorsChanged
-	state.ConsensusParams = pb.ConsensusParams
+	// the states saved by the older versions miss the newer params
+	state.ConsensusParams = types.CompleteConsensusParams(pb.ConsensusParams)
 	state.LastHeightConsensusParamsChanged = pb.LastHeightConsensusParamsChanged
 	state.LastResultsHash = pb.LastResultsHash
 	state.AppHash = pb.AppHash
@@ -275,17 +276,15 @@ func (state State) MakeBlock(
 	// Build base block with block data.
 	block := types.MakeBlock(height, coreChainLockHeight, coreChainLock, txs, commit, evidence)
 
-	// Set time.
+	// Set time from the local clock, at least the min step after the last block.
 	var timestamp time.Time
 	if height == state.InitialHeight {
 		timestamp = state.LastBlockTime // genesis time
 	} else {
-		currentTime := tmtime.Now()
-		if currentTime.Before(state.LastBlockTime) {
-			// this is weird, propose last block time
-			timestamp = state.LastBlockTime
-		} else {
-			timestamp = currentTime
+		timestamp = tmtime.Now()
+		if minTime := state.LastBlockTime.Add(state.ConsensusParams.Synchrony.MinStep); timestamp.Before(minTime) {
+			// the local clock is behind the last block time, this is weird
+			timestamp = minTime
 		}
 	}
 
@@ -311,6 +310,19 @@ func (state State) ValidatorsAtHeight(height int64) *types.ValidatorSet {
 	}
 }
 
+// NextProposerProTxHash returns the proTxHash of the proposer of the first
+// round of the next block, or nil if the validator set is empty.
+func (state State) NextProposerProTxHash() []byte {
+	if state.Validators == nil {
+		return nil
+	}
+	proposer := state.Validators.GetProposer()
+	if proposer == nil {
+		return nil
+	}
+	return proposer.ProTxHash
+}
+
 //------------------------------------------------------------------------
 // Genesis
 
@@ -839,11 +839,11 @@ func TestFourAddFourMinusOneGenesisValidators(t *testing.T) {
 
 	// add 10 validators with the same voting power as the one added directly after genesis:
 	for i := 0; i < 10; i++ {
-		addedProTxHash := crypto.RandProTxHash()
-		proTxHashes := append(proTxHashes, addedProTxHash)
-		proTxHashes, privateKeys3, thresholdPublicKey3 := bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThreshold(proTxHashes)
-		abciValidatorUpdates := make([]abci.ValidatorUpdate, len(proTxHashes))
-		for j, proTxHash := range proTxHashes {
+		// the keys of all the members are regenerated to match the new threshold public key
+		proTxHashes = append(proTxHashes, crypto.RandProTxHash())
+		orderedProTxHashes, privateKeys3, thresholdPublicKey3 := bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThreshold(proTxHashes)
+		abciValidatorUpdates := make([]abci.ValidatorUpdate, len(orderedProTxHashes))
+		for j, proTxHash := range orderedProTxHashes {
 			abciValidatorUpdates[j] = abci.UpdateValidator(proTxHash, privateKeys3[j].PubKey().Bytes(), types.DefaultDashVotingPower)
 		}
 		abciThresholdPublicKey3, err := cryptoenc.PubKeyToProto(thresholdPublicKey3)
@@ -871,16 +871,18 @@ func TestFourAddFourMinusOneGenesisValidators(t *testing.T) {
 	require.Equal(t, 18, len(state.NextValidators.Validators))
 
 	// remove one genesis validator:
-	proTxHashes, privateKeys4, thresholdPublicKey4 := bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThreshold(proTxHashes[1:])
+	removedProTxHash := proTxHashes[1]
+	proTxHashes = append(proTxHashes[:1:1], proTxHashes[2:]...)
+	orderedProTxHashes, privateKeys4, thresholdPublicKey4 := bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThreshold(proTxHashes)
 	var abciValidatorUpdates []abci.ValidatorUpdate
-	updatedPubKey, err := cryptoenc.PubKeyToProto(originalValidatorSet.Validators[0].PubKey)
+	updatedPubKey, err := cryptoenc.PubKeyToProto(originalValidatorSet.Validators[1].PubKey)
 	require.NoError(t, err)
-	updatePreviousVal := abci.ValidatorUpdate{ProTxHash: proTxHashes[0], Power: 0, PubKey: updatedPubKey}
+	updatePreviousVal := abci.ValidatorUpdate{ProTxHash: removedProTxHash, Power: 0, PubKey: updatedPubKey}
 	abciValidatorUpdates = append(abciValidatorUpdates, updatePreviousVal)
-	for i := 1; i < len(proTxHashes); i++ {
-		updatedPubKey, err := cryptoenc.PubKeyToProto(privateKeys4[i-1].PubKey())
+	for i, proTxHash := range orderedProTxHashes {
+		updatedPubKey, err := cryptoenc.PubKeyToProto(privateKeys4[i].PubKey())
 		require.NoError(t, err)
-		updatePreviousVal := abci.ValidatorUpdate{ProTxHash: proTxHashes[i], Power: types.DefaultDashVotingPower, PubKey: updatedPubKey}
+		updatePreviousVal := abci.ValidatorUpdate{ProTxHash: proTxHash, Power: types.DefaultDashVotingPower, PubKey: updatedPubKey}
 		abciValidatorUpdates = append(abciValidatorUpdates, updatePreviousVal)
 	}
 
@@ -1049,6 +1051,40 @@ func TestManyValidatorChangesSaveLoad(t *testing.T) {
 	}
 }
 
+func TestValidatorSetQuorumTypeChangesAtRotation(t *testing.T) {
+	tearDown, _, state := setupTestCase(t)
+	defer tearDown(t)
+	quorumType := state.Validators.QuorumType
+	newQuorumType := btcjson.LLMQType_100_67
+	require.NotEqual(t, quorumType, newQuorumType)
+	firstNodeProTxHash, _ := state.Validators.GetByIndex(0)
+
+	// the app rotates the quorum and changes the quorum type
+	header, _, blockID, responses := makeHeaderPartsResponsesValKeysRegenerate(state, true)
+	responses.EndBlock.ValidatorSetUpdate.QuorumType = int32(newQuorumType)
+	validatorUpdates, thresholdPublicKey, quorumHash, err :=
+		types.PB2TM.ValidatorUpdatesFromValidatorSet(responses.EndBlock.ValidatorSetUpdate)
+	require.NoError(t, err)
+	state, err = sm.UpdateState(state, &firstNodeProTxHash, blockID, &header, responses, validatorUpdates,
+		thresholdPublicKey, quorumHash)
+	require.NoError(t, err)
+	assert.Equal(t, quorumType, state.Validators.QuorumType)
+	assert.Equal(t, newQuorumType, state.NextValidators.QuorumType)
+	assert.Equal(t, quorumHash, state.NextValidators.QuorumHash)
+
+	// the next quorum keeps the quorum type, if the app doesn't change it
+	header, _, blockID, responses = makeHeaderPartsResponsesValKeysRegenerate(state, true)
+	validatorUpdates, thresholdPublicKey, quorumHash, err =
+		types.PB2TM.ValidatorUpdatesFromValidatorSet(responses.EndBlock.ValidatorSetUpdate)
+	require.NoError(t, err)
+	state, err = sm.UpdateState(state, &firstNodeProTxHash, blockID, &header, responses, validatorUpdates,
+		thresholdPublicKey, quorumHash)
+	require.NoError(t, err)
+	assert.Equal(t, newQuorumType, state.Validators.QuorumType)
+	assert.Equal(t, newQuorumType, state.NextValidators.QuorumType)
+	assert.Equal(t, quorumHash, state.NextValidators.QuorumHash)
+}
+
 func TestStateMakeBlock(t *testing.T) {
 	tearDown, _, state := setupTestCase(t)
 	defer tearDown(t)
@@ -3,13 +3,16 @@ package state
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -23,6 +26,16 @@ const (
 	valSetCheckpointInterval = 100000
 )
 
+var (
+	// the lowest heights of the ABCI responses and the validator sets, which
+	// aren't pruned
+	abciResponsesBaseKey = []byte("abciResponsesBaseKey")
+	validatorsBaseKey    = []byte("validatorsBaseKey")
+
+	// the marker of the last rollback, until it's confirmed
+	rollbackMarkerKey = []byte("rollbackMarkerKey")
+)
+
 //------------------------------------------------------------------------
 
 func calcValidatorsKey(height int64) []byte {
@@ -56,6 +69,9 @@ type Store interface {
 	Load() (State, error)
 	// LoadValidators loads the validator set at a given height
 	LoadValidators(int64) (*types.ValidatorSet, error)
+	// LoadValidatorsAtRound loads the validator set at a given height, with the
+	// proposer priorities of a given round of the height
+	LoadValidatorsAtRound(int64, int32) (*types.ValidatorSet, error)
 	// LoadABCIResponses loads the abciResponse for a given height
 	LoadABCIResponses(int64) (*tmstate.ABCIResponses, error)
 	// LoadConsensusParams loads the consensus params for a given height
@@ -68,18 +84,40 @@ type Store interface {
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start prning and which height stop at
 	PruneStates(int64, int64) error
+	// PruneABCIResponses deletes the ABCI responses of up to the given number
+	// of heights below the retain height, starting at the lowest one left, and
+	// returns the number of heights pruned
+	PruneABCIResponses(retainHeight int64, limit int64) (int64, error)
+	// PruneValidatorSets deletes the validator sets of up to the given number
+	// of heights below the retain height, starting at the lowest one left, and
+	// returns the number of heights pruned
+	PruneValidatorSets(retainHeight int64, limit int64) (int64, error)
+	// Rollback overwrites the state with the state after the block of the
+	// first given meta, rebuilt from the stored validator sets and consensus
+	// params, deletes the ABCI responses, validator sets and consensus params
+	// stored above it and records the block of the second meta as rolled back
+	// (see LoadRollbackMarker). It returns the rolled back state.
+	Rollback(meta, nextMeta *types.BlockMeta) (State, error)
+	// LoadRollbackMarker loads the marker of the last rollback, or nil if
+	// there's none or it was confirmed
+	LoadRollbackMarker() (*tmstate.RollbackMarker, error)
+	// ConfirmRollback deletes the marker of the last rollback
+	ConfirmRollback() error
 }
 
 // dbStore wraps a db (github.com/tendermint/tm-db)
 type dbStore struct {
 	db dbm.DB
+
+	// serializes the pruning, which moves the bases
+	pruneMtx *tmsync.Mutex
 }
 
 var _ Store = (*dbStore)(nil)
 
 // NewStore creates the dbStore of the state pkg.
 func NewStore(db dbm.DB) Store {
-	return dbStore{db}
+	return dbStore{db: db, pruneMtx: &tmsync.Mutex{}}
 }
 
 // LoadFromDBOrGenesisFile loads the most recent state from the database,
@@ -210,6 +248,18 @@ func (store dbStore) Bootstrap(state State) error {
 		return err
 	}
 
+	// Nothing is stored below the height, so there's nothing to prune there.
+	if err := store.saveBase(store.db, abciResponsesBaseKey, height); err != nil {
+		return err
+	}
+	valsBase := height
+	if height > 1 && !state.LastValidators.IsNilOrEmpty() {
+		valsBase = height - 1
+	}
+	if err := store.saveBase(store.db, validatorsBaseKey, valsBase); err != nil {
+		return err
+	}
+
 	return store.db.SetSync(stateKey, state.Bytes())
 }
 
@@ -228,6 +278,9 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 	if from >= to {
 		return fmt.Errorf("from height %v must be lower than to height %v", from, to)
 	}
+	store.pruneMtx.Lock()
+	defer store.pruneMtx.Unlock()
+
 	valInfo, err := loadValidatorsInfo(store.db, to)
 	if err != nil {
 		return fmt.Errorf("validators at height %v not found: %w", to, err)
@@ -258,29 +311,8 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 		// params, otherwise they will panic if they're retrieved directly (instead of
 		// indirectly via a LastHeightChanged pointer).
 		if keepVals[h] {
-			v, err := loadValidatorsInfo(store.db, h)
-			if err != nil || v.ValidatorSet == nil {
-				vip, err := store.LoadValidators(h)
-				if err != nil {
-					return err
-				}
-
-				pvi, err := vip.ToProto()
-				if err != nil {
-					return err
-				}
-
-				v.ValidatorSet = pvi
-				v.LastHeightChanged = h
-
-				bz, err := v.Marshal()
-				if err != nil {
-					return err
-				}
-				err = batch.Set(calcValidatorsKey(h), bz)
-				if err != nil {
-					return err
-				}
+			if err := store.keepValidatorsInfo(batch, h); err != nil {
+				return err
 			}
 		} else {
 			err = batch.Delete(calcValidatorsKey(h))
@@ -290,28 +322,9 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 		}
 
 		if keepParams[h] {
-			p, err := store.loadConsensusParamsInfo(h)
-			if err != nil {
+			if err := store.keepConsensusParamsInfo(batch, h); err != nil {
 				return err
 			}
-
-			if p.ConsensusParams.Equal(&tmproto.ConsensusParams{}) {
-				p.ConsensusParams, err = store.LoadConsensusParams(h)
-				if err != nil {
-					return err
-				}
-
-				p.LastHeightChanged = h
-				bz, err := p.Marshal()
-				if err != nil {
-					return err
-				}
-
-				err = batch.Set(calcConsensusParamsKey(h), bz)
-				if err != nil {
-					return err
-				}
-			}
 		} else {
 			err = batch.Delete(calcConsensusParamsKey(h))
 			if err != nil {
@@ -337,6 +350,19 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 		}
 	}
 
+	// The heights below are pruned now, even if they were left by the pruning.
+	for _, key := range [][]byte{abciResponsesBaseKey, validatorsBaseKey} {
+		base, err := store.loadBase(key)
+		if err != nil {
+			return err
+		}
+		if base < to {
+			if err := store.saveBase(batch, key, to); err != nil {
+				return err
+			}
+		}
+	}
+
 	err = batch.WriteSync()
 	if err != nil {
 		return err
@@ -345,6 +371,502 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 	return nil
 }
 
+// PruneABCIResponses deletes the ABCI responses of the heights below the
+// retain height, at most limit of them at once, so that a large prune doesn't
+// stall the database compaction. It starts at the lowest height left by the
+// previous prunes, and returns the number of heights pruned. Once pruned,
+// LoadABCIResponses returns ErrPruned for the heights.
+func (store dbStore) PruneABCIResponses(retainHeight int64, limit int64) (int64, error) {
+	store.pruneMtx.Lock()
+	defer store.pruneMtx.Unlock()
+
+	base, end, err := store.pruneRange(abciResponsesBaseKey, retainHeight, limit)
+	if err != nil || end <= base {
+		return 0, err
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	for h := base; h < end; h++ {
+		if err := batch.Delete(calcABCIResponsesKey(h)); err != nil {
+			return 0, err
+		}
+	}
+	if err := store.saveBase(batch, abciResponsesBaseKey, end); err != nil {
+		return 0, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+
+	return end - base, nil
+}
+
+// PruneValidatorSets deletes the validator sets of the heights below the
+// retain height, at most limit of them at once, so that a large prune doesn't
+// stall the database compaction. It starts at the lowest height left by the
+// previous prunes, and returns the number of heights pruned. Like PruneStates,
+// it keeps the validator sets that the heights left point to (the
+// LastHeightChanged and the last checkpoint), but it deletes the ones kept by
+// the previous prune, once they're no longer needed. Once pruned,
+// LoadValidators returns ErrPruned for the heights.
+func (store dbStore) PruneValidatorSets(retainHeight int64, limit int64) (int64, error) {
+	store.pruneMtx.Lock()
+	defer store.pruneMtx.Unlock()
+
+	base, end, err := store.pruneRange(validatorsBaseKey, retainHeight, limit)
+	if err != nil || end <= base {
+		return 0, err
+	}
+
+	// The validator sets the base points to were kept by the previous prune.
+	keptVals := make(map[int64]bool)
+	if valInfo, err := loadValidatorsInfo(store.db, base); err == nil && valInfo.ValidatorSet == nil {
+		keptVals[valInfo.LastHeightChanged] = true
+		keptVals[lastStoredHeightFor(base, valInfo.LastHeightChanged)] = true
+	}
+	valInfo, err := loadValidatorsInfo(store.db, end)
+	if err != nil {
+		return 0, fmt.Errorf("validators at height %v not found: %w", end, err)
+	}
+	keepVals := make(map[int64]bool)
+	if valInfo.ValidatorSet == nil {
+		keepVals[valInfo.LastHeightChanged] = true
+		keepVals[lastStoredHeightFor(end, valInfo.LastHeightChanged)] = true
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	for h := range keptVals {
+		if h < base && !keepVals[h] {
+			if err := batch.Delete(calcValidatorsKey(h)); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for h := base; h < end; h++ {
+		if keepVals[h] {
+			if err := store.keepValidatorsInfo(batch, h); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err := batch.Delete(calcValidatorsKey(h)); err != nil {
+			return 0, err
+		}
+	}
+	if err := store.saveBase(batch, validatorsBaseKey, end); err != nil {
+		return 0, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+
+	return end - base, nil
+}
+
+// pruneRange returns the range of the heights to prune next: from the base
+// up to the retain height, excluding, and no more than limit heights. The
+// range is empty if there's nothing to prune.
+func (store dbStore) pruneRange(baseKey []byte, retainHeight int64, limit int64) (int64, int64, error) {
+	if limit <= 0 {
+		return 0, 0, fmt.Errorf("limit %v must be greater than 0", limit)
+	}
+	base, err := store.loadBase(baseKey)
+	if err != nil {
+		return 0, 0, err
+	}
+	if base == 0 {
+		// Nothing was pruned yet, so everything is stored since the initial height.
+		state, err := store.Load()
+		if err != nil {
+			return 0, 0, err
+		}
+		if state.IsEmpty() {
+			return 0, 0, nil
+		}
+		base = state.InitialHeight
+	}
+	end := tmmath.MinInt64(retainHeight, base+limit)
+	if end <= base {
+		return base, base, nil
+	}
+	return base, end, nil
+}
+
+// keepValidatorsInfo makes sure the validator set kept at the height isn't
+// just a LastHeightChanged pointer, otherwise it will panic if it's retrieved
+// directly (instead of indirectly via the pointer).
+func (store dbStore) keepValidatorsInfo(batch dbm.Batch, height int64) error {
+	v, err := loadValidatorsInfo(store.db, height)
+	if err == nil && v.ValidatorSet != nil {
+		return nil
+	}
+	vip, err := store.loadValidators(height)
+	if err != nil {
+		return err
+	}
+
+	pvi, err := vip.ToProto()
+	if err != nil {
+		return err
+	}
+
+	v = &tmstate.ValidatorsInfo{
+		ValidatorSet:      pvi,
+		LastHeightChanged: height,
+	}
+	bz, err := v.Marshal()
+	if err != nil {
+		return err
+	}
+	return batch.Set(calcValidatorsKey(height), bz)
+}
+
+// keepConsensusParamsInfo makes sure the consensus params kept at the height
+// aren't just a LastHeightChanged pointer, like keepValidatorsInfo.
+func (store dbStore) keepConsensusParamsInfo(batch dbm.Batch, height int64) error {
+	p, err := store.loadConsensusParamsInfo(height)
+	if err != nil {
+		return err
+	}
+	if !p.ConsensusParams.Equal(&tmproto.ConsensusParams{}) {
+		return nil
+	}
+
+	p.ConsensusParams, err = store.LoadConsensusParams(height)
+	if err != nil {
+		return err
+	}
+	p.LastHeightChanged = height
+
+	bz, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+	return batch.Set(calcConsensusParamsKey(height), bz)
+}
+
+// Rollback implements Store. The rolled back state, the marker and the
+// deletions are written in one batch.
+func (store dbStore) Rollback(meta, nextMeta *types.BlockMeta) (State, error) {
+	height := meta.Header.Height
+	if nextMeta.Header.Height != height+1 {
+		return State{}, fmt.Errorf("expected the block meta of height %d, got height %d",
+			height+1, nextMeta.Header.Height)
+	}
+	state, err := store.Load()
+	if err != nil {
+		return State{}, err
+	}
+	if height >= state.LastBlockHeight {
+		return State{}, fmt.Errorf("can't roll back to height %d, the state is at height %d",
+			height, state.LastBlockHeight)
+	}
+
+	// The state after the block at height stores the validators of the next
+	// two heights and the consensus params of the next height, see save.
+	lastValidators, err := store.LoadValidators(height)
+	if err != nil {
+		return State{}, err
+	}
+	validators, err := store.LoadValidators(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	nextValidators, err := store.LoadValidators(height + 2)
+	if err != nil {
+		return State{}, err
+	}
+	nextValidatorsInfo, err := loadValidatorsInfo(store.db, height+2)
+	if err != nil {
+		return State{}, err
+	}
+	params, err := store.LoadConsensusParams(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+	paramsInfo, err := store.loadConsensusParamsInfo(height + 1)
+	if err != nil {
+		return State{}, err
+	}
+
+	// The header of the next block carries the results of the block.
+	rolledBack := State{
+		Version: tmstate.Version{
+			Consensus: nextMeta.Header.Version,
+			Software:  state.Version.Software,
+		},
+		ChainID:       state.ChainID,
+		InitialHeight: state.InitialHeight,
+
+		LastBlockHeight: height,
+		LastBlockID:     meta.BlockID,
+		LastBlockTime:   meta.Header.Time,
+
+		LastStateID: types.StateID{LastAppHash: meta.Header.AppHash},
+
+		LastCoreChainLockedBlockHeight: meta.Header.CoreChainLockedHeight,
+
+		NextValidators:              nextValidators,
+		Validators:                  validators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: nextValidatorsInfo.LastHeightChanged,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: paramsInfo.LastHeightChanged,
+
+		LastResultsHash: nextMeta.Header.LastResultsHash,
+		AppHash:         nextMeta.Header.AppHash,
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	// The ABCI responses of the height above the state are left behind, if the
+	// node stopped before saving the state.
+	for h := height + 1; h <= state.LastBlockHeight+1; h++ {
+		if err := batch.Delete(calcABCIResponsesKey(h)); err != nil {
+			return State{}, err
+		}
+	}
+	for h := height + 2; h <= state.LastBlockHeight+1; h++ {
+		if err := batch.Delete(calcConsensusParamsKey(h)); err != nil {
+			return State{}, err
+		}
+	}
+	for h := height + 3; h <= state.LastBlockHeight+2; h++ {
+		if err := batch.Delete(calcValidatorsKey(h)); err != nil {
+			return State{}, err
+		}
+	}
+
+	marker := &tmstate.RollbackMarker{Height: height, BlockID: nextMeta.BlockID.ToProto()}
+	bz, err := marker.Marshal()
+	if err != nil {
+		return State{}, err
+	}
+	if err := batch.Set(rollbackMarkerKey, bz); err != nil {
+		return State{}, err
+	}
+	if err := batch.Set(stateKey, rolledBack.Bytes()); err != nil {
+		return State{}, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return State{}, err
+	}
+	return rolledBack, nil
+}
+
+// LoadRollbackMarker implements Store.
+func (store dbStore) LoadRollbackMarker() (*tmstate.RollbackMarker, error) {
+	buf, err := store.db.Get(rollbackMarkerKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return nil, nil
+	}
+
+	marker := new(tmstate.RollbackMarker)
+	if err := marker.Unmarshal(buf); err != nil {
+		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+		tmos.Exit(fmt.Sprintf(`LoadRollbackMarker: Data has been corrupted or its spec has changed:
+                %v\n`, err))
+	}
+	return marker, nil
+}
+
+// ConfirmRollback implements Store.
+func (store dbStore) ConfirmRollback() error {
+	return store.db.DeleteSync(rollbackMarkerKey)
+}
+
+// PruneOrphans deletes the state data below the retain height (e.g. the base
+// of the block store), and the data left below the bases by the previous
+// prunes (see PruneStates), except the validator sets and the consensus params
+// the heights left point to. It iterates over the keys of all the heights, so
+// it's meant to be run offline, e.g. by the experimental-compact command. It
+// returns the number of keys deleted.
+func PruneOrphans(db dbm.DB, retainHeight int64) (uint64, error) {
+	store := dbStore{db: db, pruneMtx: &tmsync.Mutex{}}
+	return store.pruneOrphans(retainHeight)
+}
+
+func (store dbStore) pruneOrphans(retainHeight int64) (uint64, error) {
+	store.pruneMtx.Lock()
+	defer store.pruneMtx.Unlock()
+
+	state, err := store.Load()
+	if err != nil || state.IsEmpty() {
+		return 0, err
+	}
+	// the ABCI responses of the last height are needed to recover from crashes
+	retainHeight = tmmath.MinInt64(retainHeight, state.LastBlockHeight)
+
+	abciBase, err := store.loadBase(abciResponsesBaseKey)
+	if err != nil {
+		return 0, err
+	}
+	abciBase = tmmath.MaxInt64(abciBase, retainHeight)
+	valsBase, err := store.loadBase(validatorsBaseKey)
+	if err != nil {
+		return 0, err
+	}
+	valsBase = tmmath.MaxInt64(valsBase, retainHeight)
+
+	keepVals := make(map[int64]bool)
+	if valsBase > 0 {
+		valInfo, err := loadValidatorsInfo(store.db, valsBase)
+		if err != nil {
+			return 0, fmt.Errorf("validators at height %v not found: %w", valsBase, err)
+		}
+		if valInfo.ValidatorSet == nil {
+			keepVals[valInfo.LastHeightChanged] = true
+			keepVals[lastStoredHeightFor(valsBase, valInfo.LastHeightChanged)] = true
+		}
+	}
+	keepParams := make(map[int64]bool)
+	if retainHeight > 0 {
+		paramsInfo, err := store.loadConsensusParamsInfo(retainHeight)
+		if err != nil {
+			return 0, fmt.Errorf("consensus params at height %v not found: %w", retainHeight, err)
+		}
+		if paramsInfo.ConsensusParams.Equal(&tmproto.ConsensusParams{}) {
+			keepParams[paramsInfo.LastHeightChanged] = true
+		}
+	}
+
+	// The orphans are collected first, since not every backend allows to
+	// write while iterating.
+	var orphans [][]byte
+	for _, prefix := range []struct {
+		key    string
+		orphan func(height int64) bool
+	}{
+		{"abciResponsesKey:", func(h int64) bool { return h < abciBase }},
+		{"validatorsKey:", func(h int64) bool { return h < valsBase && !keepVals[h] }},
+		{"consensusParamsKey:", func(h int64) bool { return h < retainHeight && !keepParams[h] }},
+	} {
+		iter, err := dbm.IteratePrefix(store.db, []byte(prefix.key))
+		if err != nil {
+			return 0, err
+		}
+		for ; iter.Valid(); iter.Next() {
+			h, err := strconv.ParseInt(string(iter.Key()[len(prefix.key):]), 10, 64)
+			if err == nil && prefix.orphan(h) {
+				orphans = append(orphans, append([]byte(nil), iter.Key()...))
+			}
+		}
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// The kept heights are rewritten before anything is deleted.
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for h := range keepVals {
+		if h < valsBase {
+			if err := store.keepValidatorsInfo(batch, h); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for h := range keepParams {
+		if h < retainHeight {
+			if err := store.keepConsensusParamsInfo(batch, h); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	batch.Close()
+
+	batch = store.db.NewBatch()
+	defer batch.Close()
+	for i, key := range orphans {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+		// avoid batches growing too large by flushing to database regularly
+		if (i+1)%1000 == 0 {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Close()
+			batch = store.db.NewBatch()
+			defer batch.Close()
+		}
+	}
+	if abciBase > 0 {
+		if err := store.saveBase(batch, abciResponsesBaseKey, abciBase); err != nil {
+			return 0, err
+		}
+	}
+	if valsBase > 0 {
+		if err := store.saveBase(batch, validatorsBaseKey, valsBase); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+
+	return uint64(len(orphans)), nil
+}
+
+// loadBase loads the lowest height left by the pruning of the data under the
+// key, or 0 if it wasn't pruned yet.
+func (store dbStore) loadBase(key []byte) (int64, error) {
+	buf, err := store.db.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	base := new(gogotypes.Int64Value)
+	if err := base.Unmarshal(buf); err != nil {
+		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+		tmos.Exit(fmt.Sprintf(`loadBase: Data has been corrupted or its spec has changed:
+                %v\n`, err))
+	}
+	return base.Value, nil
+}
+
+// setter is implemented by both dbm.DB and dbm.Batch.
+type setter interface {
+	Set(key, value []byte) error
+}
+
+func (store dbStore) saveBase(setter setter, key []byte, base int64) error {
+	bz, err := (&gogotypes.Int64Value{Value: base}).Marshal()
+	if err != nil {
+		return err
+	}
+	return setter.Set(key, bz)
+}
+
+// prunedErr returns ErrPruned if the height not found under the base key was
+// pruned.
+func (store dbStore) prunedErr(baseKey []byte, height int64) error {
+	base, err := store.loadBase(baseKey)
+	if err != nil {
+		return err
+	}
+	if height < base {
+		return ErrPruned{Height: height, Base: base}
+	}
+	return nil
+}
+
 //------------------------------------------------------------------------
 
 // ABCIResponsesResultsHash returns the root hash of a Merkle tree of
@@ -356,7 +878,8 @@ func ABCIResponsesResultsHash(ar *tmstate.ABCIResponses) []byte {
 }
 
 // LoadABCIResponses loads the ABCIResponses for the given height from the
-// database. If not found, ErrNoABCIResponsesForHeight is returned.
+// database. If not found, ErrNoABCIResponsesForHeight is returned, or ErrPruned
+// if the height was pruned.
 //
 // This is useful for recovering from crashes where we called app.Commit and
 // before we called s.Save(). It can also be used to produce Merkle proofs of
@@ -367,7 +890,9 @@ func (store dbStore) LoadABCIResponses(height int64) (*tmstate.ABCIResponses, er
 		return nil, err
 	}
 	if len(buf) == 0 {
-
+		if err := store.prunedErr(abciResponsesBaseKey, height); err != nil {
+			return nil, err
+		}
 		return nil, ErrNoABCIResponsesForHeight{height}
 	}
 
@@ -415,8 +940,19 @@ func (store dbStore) SaveABCIResponses(height int64, abciResponses *tmstate.ABCI
 //-----------------------------------------------------------------------------
 
 // LoadValidators loads the ValidatorSet for a given height.
-// Returns ErrNoValSetForHeight if the validator set can't be found for this height.
+// Returns ErrNoValSetForHeight if the validator set can't be found for this height,
+// or ErrPruned if the height was pruned.
 func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
+	vals, err := store.loadValidators(height)
+	if _, ok := err.(ErrNoValSetForHeight); ok {
+		if err := store.prunedErr(validatorsBaseKey, height); err != nil {
+			return nil, err
+		}
+	}
+	return vals, err
+}
+
+func (store dbStore) loadValidators(height int64) (*types.ValidatorSet, error) {
 	valInfo, err := loadValidatorsInfo(store.db, height)
 	if err != nil {
 		return nil, ErrNoValSetForHeight{height}
@@ -457,6 +993,24 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// LoadValidatorsAtRound loads the validator set at the given height and
+// increments the proposer priorities for the given round, the way consensus
+// does when it enters the round. The proposer of the round is the proposer of
+// the returned validator set.
+func (store dbStore) LoadValidatorsAtRound(height int64, round int32) (*types.ValidatorSet, error) {
+	if round < 0 {
+		return nil, fmt.Errorf("round must be non-negative, but got %d", round)
+	}
+	vals, err := store.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	if round > 0 {
+		vals.IncrementProposerPriority(round)
+	}
+	return vals, nil
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
@@ -524,7 +1078,9 @@ func (store dbStore) saveValidatorsInfo(height, lastHeightChanged int64, valSet
 
 // ConsensusParamsInfo represents the latest consensus params, or the last height it changed
 
-// LoadConsensusParams loads the ConsensusParams for a given height.
+// LoadConsensusParams loads the ConsensusParams for a given height. The params
+// are stored only at the heights they changed at, the other heights point to
+// the last change, so any height takes two reads at most.
 func (store dbStore) LoadConsensusParams(height int64) (tmproto.ConsensusParams, error) {
 	empty := tmproto.ConsensusParams{}
 
@@ -547,7 +1103,8 @@ func (store dbStore) LoadConsensusParams(height int64) (tmproto.ConsensusParams,
 		paramsInfo = paramsInfo2
 	}
 
-	return paramsInfo.ConsensusParams, nil
+	// the params saved by the older versions miss the newer params
+	return types.CompleteConsensusParams(paramsInfo.ConsensusParams), nil
 }
 
 func (store dbStore) loadConsensusParamsInfo(height int64) (*tmstate.ConsensusParamsInfo, error) {
@@ -45,6 +45,32 @@ func TestStoreLoadValidators(t *testing.T) {
 	assert.NotZero(t, loadedVals.Size())
 }
 
+func TestStoreLoadValidatorsAtRound(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB)
+	vals, _ := types.GenerateValidatorSet(4)
+	for height := int64(1); height <= 5; height++ {
+		require.NoError(t, sm.SaveValidatorsInfo(stateDB, height, 1, vals))
+	}
+
+	// the priorities at height 5 are reconstructed from the ones at height 1
+	for round := int32(0); round < 8; round++ {
+		expected := vals.CopyIncrementProposerPriority(4)
+		if round > 0 {
+			expected.IncrementProposerPriority(round)
+		}
+		loadedVals, err := stateStore.LoadValidatorsAtRound(5, round)
+		require.NoError(t, err)
+		assert.Equal(t, expected.GetProposer().ProTxHash, loadedVals.GetProposer().ProTxHash, "round %d", round)
+		for i, val := range expected.Validators {
+			assert.Equal(t, val.ProposerPriority, loadedVals.Validators[i].ProposerPriority, "round %d", round)
+		}
+	}
+
+	_, err := stateStore.LoadValidatorsAtRound(5, -1)
+	assert.Error(t, err)
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -82,6 +108,29 @@ func BenchmarkLoadValidators(b *testing.B) {
 	}
 }
 
+// The params of any height are loaded with two reads at most: the params
+// stored at the height point to the height they last changed at.
+func BenchmarkLoadConsensusParams(b *testing.B) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB)
+	params := *types.DefaultConsensusParams()
+	require.NoError(b, sm.SaveConsensusParamsInfo(stateDB, 1, 1, params))
+
+	for i := 10; i < 10000000000; i *= 10 { // 10, 100, 1000, ...
+		i := i
+		require.NoError(b, sm.SaveConsensusParamsInfo(stateDB, int64(i), 1, params))
+
+		b.Run(fmt.Sprintf("height=%d", i), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, err := stateStore.LoadConsensusParams(int64(i))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestPruneStates(t *testing.T) {
 	testcases := map[string]struct {
 		makeHeights  int64
@@ -107,56 +156,7 @@ func TestPruneStates(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			db := dbm.NewMemDB()
 			stateStore := sm.NewStore(db)
-			pk := bls12381.GenPrivKey().PubKey()
-			proTxHash := crypto.RandProTxHash()
-			// Generate a bunch of state data. Validators change for heights ending with 3, and
-			// parameters when ending with 5.
-			validator := &types.Validator{VotingPower: types.DefaultDashVotingPower, PubKey: pk, ProTxHash: proTxHash}
-			validatorSet := &types.ValidatorSet{
-				Validators:         []*types.Validator{validator},
-				Proposer:           validator,
-				ThresholdPublicKey: validator.PubKey,
-				QuorumHash:         crypto.RandQuorumHash(),
-			}
-			valsChanged := int64(0)
-			paramsChanged := int64(0)
-
-			for h := int64(1); h <= tc.makeHeights; h++ {
-				if valsChanged == 0 || h%10 == 2 {
-					valsChanged = h + 1 // Have to add 1, since NextValidators is what's stored
-				}
-				if paramsChanged == 0 || h%10 == 5 {
-					paramsChanged = h
-				}
-
-				state := sm.State{
-					InitialHeight:   1,
-					LastBlockHeight: h - 1,
-					Validators:      validatorSet,
-					NextValidators:  validatorSet,
-					ConsensusParams: tmproto.ConsensusParams{
-						Block: tmproto.BlockParams{MaxBytes: 10e6},
-					},
-					LastHeightValidatorsChanged:      valsChanged,
-					LastHeightConsensusParamsChanged: paramsChanged,
-				}
-
-				if state.LastBlockHeight >= 1 {
-					state.LastValidators = state.Validators
-				}
-
-				err := stateStore.Save(state)
-				require.NoError(t, err)
-
-				err = stateStore.SaveABCIResponses(h, &tmstate.ABCIResponses{
-					DeliverTxs: []*abci.ResponseDeliverTx{
-						{Data: []byte{1}},
-						{Data: []byte{2}},
-						{Data: []byte{3}},
-					},
-				})
-				require.NoError(t, err)
-			}
+			saveTestHeights(t, stateStore, tc.makeHeights)
 
 			// Test assertions
 			err := stateStore.PruneStates(tc.pruneFrom, tc.pruneTo)
@@ -177,7 +177,7 @@ func TestPruneStates(t *testing.T) {
 					require.NotNil(t, vals)
 				} else {
 					require.Error(t, err, "validators height %v", h)
-					require.Equal(t, sm.ErrNoValSetForHeight{Height: h}, err)
+					require.Equal(t, sm.ErrPruned{Height: h, Base: tc.pruneTo}, err)
 				}
 
 				params, err := stateStore.LoadConsensusParams(h)
@@ -194,13 +194,108 @@ func TestPruneStates(t *testing.T) {
 					require.NotNil(t, abci)
 				} else {
 					require.Error(t, err, "abci height %v", h)
-					require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: h}, err)
+					require.Equal(t, sm.ErrPruned{Height: h, Base: tc.pruneTo}, err)
 				}
 			}
 		})
 	}
 }
 
+func TestPruneABCIResponsesAndValidatorSets(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+
+	// nothing to prune in an empty store
+	pruned, err := stateStore.PruneABCIResponses(10, 10)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	saveTestHeights(t, stateStore, 30)
+
+	_, err = stateStore.PruneABCIResponses(25, 0)
+	require.Error(t, err)
+
+	// The heights are pruned in batches, up to the retain height.
+	for _, expectPruned := range []int64{10, 10, 4, 0} {
+		pruned, err := stateStore.PruneABCIResponses(25, 10)
+		require.NoError(t, err)
+		require.Equal(t, expectPruned, pruned)
+
+		pruned, err = stateStore.PruneValidatorSets(25, 10)
+		require.NoError(t, err)
+		require.Equal(t, expectPruned, pruned)
+
+		// the heights left can still be loaded between the batches
+		for h := int64(25); h <= 30; h++ {
+			_, err := stateStore.LoadABCIResponses(h)
+			require.NoError(t, err, "abci height %v", h)
+			_, err = stateStore.LoadValidators(h)
+			require.NoError(t, err, "validators height %v", h)
+		}
+	}
+
+	// Only the validator set, which the heights left point to, is kept.
+	for h := int64(1); h < 25; h++ {
+		_, err := stateStore.LoadABCIResponses(h)
+		require.Equal(t, sm.ErrPruned{Height: h, Base: 25}, err, "abci height %v", h)
+
+		_, err = stateStore.LoadValidators(h)
+		if h == 23 {
+			require.NoError(t, err)
+		} else {
+			require.Equal(t, sm.ErrPruned{Height: h, Base: 25}, err, "validators height %v", h)
+		}
+	}
+
+	// the heights above are not found, rather than pruned
+	_, err = stateStore.LoadABCIResponses(31)
+	require.Equal(t, sm.ErrNoABCIResponsesForHeight{Height: 31}, err)
+	_, err = stateStore.LoadValidators(32)
+	require.Equal(t, sm.ErrNoValSetForHeight{Height: 32}, err)
+}
+
+func TestPruneOrphans(t *testing.T) {
+	db := dbm.NewMemDB()
+	stateStore := sm.NewStore(db)
+	saveTestHeights(t, stateStore, 30)
+
+	// left behind by an interrupted prune
+	require.NoError(t, stateStore.PruneStates(1, 10))
+	require.NoError(t, stateStore.SaveABCIResponses(5, new(tmstate.ABCIResponses)))
+
+	pruned, err := sm.PruneOrphans(db, 20)
+	require.NoError(t, err)
+	assert.Positive(t, pruned)
+
+	// The heights left point to the validator set of 13, and the params of 15.
+	for h := int64(1); h <= 30; h++ {
+		_, err := stateStore.LoadABCIResponses(h)
+		if h < 20 {
+			require.Equal(t, sm.ErrPruned{Height: h, Base: 20}, err, "abci height %v", h)
+		} else {
+			require.NoError(t, err, "abci height %v", h)
+		}
+
+		_, err = stateStore.LoadValidators(h)
+		if h < 20 && h != 13 {
+			require.Equal(t, sm.ErrPruned{Height: h, Base: 20}, err, "validators height %v", h)
+		} else {
+			require.NoError(t, err, "validators height %v", h)
+		}
+
+		_, err = stateStore.LoadConsensusParams(h)
+		if h < 20 && h != 15 {
+			require.Error(t, err, "params height %v", h)
+		} else {
+			require.NoError(t, err, "params height %v", h)
+		}
+	}
+
+	// nothing is left to prune
+	pruned, err = sm.PruneOrphans(db, 20)
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+}
+
 func TestABCIResponsesResultsHash(t *testing.T) {
 	responses := &tmstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
@@ -223,6 +318,60 @@ func TestABCIResponsesResultsHash(t *testing.T) {
 	assert.NoError(t, proof.Verify(root, bz))
 }
 
+// saveTestHeights saves the state data of the heights up to makeHeights.
+// Validators change for heights ending with 3, and parameters when ending with 5.
+func saveTestHeights(t *testing.T, stateStore sm.Store, makeHeights int64) {
+	t.Helper()
+	pk := bls12381.GenPrivKey().PubKey()
+	proTxHash := crypto.RandProTxHash()
+	validator := &types.Validator{VotingPower: types.DefaultDashVotingPower, PubKey: pk, ProTxHash: proTxHash}
+	validatorSet := &types.ValidatorSet{
+		Validators:         []*types.Validator{validator},
+		Proposer:           validator,
+		ThresholdPublicKey: validator.PubKey,
+		QuorumHash:         crypto.RandQuorumHash(),
+	}
+	valsChanged := int64(0)
+	paramsChanged := int64(0)
+
+	for h := int64(1); h <= makeHeights; h++ {
+		if valsChanged == 0 || h%10 == 2 {
+			valsChanged = h + 1 // Have to add 1, since NextValidators is what's stored
+		}
+		if paramsChanged == 0 || h%10 == 5 {
+			paramsChanged = h
+		}
+
+		state := sm.State{
+			InitialHeight:   1,
+			LastBlockHeight: h - 1,
+			Validators:      validatorSet,
+			NextValidators:  validatorSet,
+			ConsensusParams: tmproto.ConsensusParams{
+				Block: tmproto.BlockParams{MaxBytes: 10e6},
+			},
+			LastHeightValidatorsChanged:      valsChanged,
+			LastHeightConsensusParamsChanged: paramsChanged,
+		}
+
+		if state.LastBlockHeight >= 1 {
+			state.LastValidators = state.Validators
+		}
+
+		err := stateStore.Save(state)
+		require.NoError(t, err)
+
+		err = stateStore.SaveABCIResponses(h, &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{
+				{Data: []byte{1}},
+				{Data: []byte{2}},
+				{Data: []byte{3}},
+			},
+		})
+		require.NoError(t, err)
+	}
+}
+
 func sliceToMap(s []int64) map[int64]bool {
 	m := make(map[int64]bool, len(s))
 	for _, i := range s {
@@ -9,8 +9,13 @@ import (
 // TxPreCheck returns a function to filter transactions before processing.
 // The function limits the size of a transaction to the block's maximum data size.
 func TxPreCheck(state State) mempl.PreCheckFunc {
+	maxBytes := state.ConsensusParams.Block.MaxBytes
+	// the last commit of the next block carries the vote extension
+	if types.VoteExtensionsEnabled(state.ConsensusParams.ABCI, state.LastBlockHeight) {
+		maxBytes -= types.MaxCommitVoteExtensionBytes
+	}
 	maxDataBytes := types.MaxDataBytesNoEvidence(
-		state.ConsensusParams.Block.MaxBytes,
+		maxBytes,
 		crypto.BLS12381,
 		state.Validators.Size(),
 	)
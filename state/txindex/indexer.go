@@ -24,6 +24,12 @@ type TxIndexer interface {
 
 	// Search allows you to query for transactions.
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
+
+	// SearchPage allows you to query for a page of transactions ordered by height
+	// and index, in descending order if orderDesc is true. It returns at most
+	// limit transactions after skipping the first skip ones, and the total number
+	// of matching transactions.
+	SearchPage(ctx context.Context, q *query.Query, orderDesc bool, skip, limit int) ([]*abci.TxResult, int, error)
 }
 
 // Batch groups together multiple Index operations to be performed at the same time.
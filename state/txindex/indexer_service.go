@@ -15,12 +15,14 @@ const (
 )
 
 // IndexerService connects event bus, transaction and block indexers together in
-// order to index transactions and blocks coming from the event bus.
+// order to index transactions and blocks coming from the event bus. The blocks
+// and transactions are also written to the event sinks, if any.
 type IndexerService struct {
 	service.BaseService
 
 	txIdxr    TxIndexer
 	blockIdxr indexer.BlockIndexer
+	sinks     []indexer.EventSink
 	eventBus  *types.EventBus
 }
 
@@ -29,9 +31,10 @@ func NewIndexerService(
 	txIdxr TxIndexer,
 	blockIdxr indexer.BlockIndexer,
 	eventBus *types.EventBus,
+	sinks ...indexer.EventSink,
 ) *IndexerService {
 
-	is := &IndexerService{txIdxr: txIdxr, blockIdxr: blockIdxr, eventBus: eventBus}
+	is := &IndexerService{txIdxr: txIdxr, blockIdxr: blockIdxr, sinks: sinks, eventBus: eventBus}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
 	return is
 }
@@ -87,14 +90,27 @@ func (is *IndexerService) OnStart() error {
 			} else {
 				is.Logger.Debug("indexed block txs", "height", height, "num_txs", eventDataHeader.NumTxs)
 			}
+
+			for _, sink := range is.sinks {
+				if err := sink.IndexBlock(eventDataHeader, batch.Ops); err != nil {
+					is.Logger.Error("failed to write block to event sink", "sink", sink.Type(), "height", height, "err", err)
+				}
+			}
 		}
 	}()
 	return nil
 }
 
-// OnStop implements service.Service by unsubscribing from all transactions.
+// OnStop implements service.Service by unsubscribing from all transactions,
+// and stopping the event sinks.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
+
+	for _, sink := range is.sinks {
+		if err := sink.Stop(); err != nil {
+			is.Logger.Error("failed to stop event sink", "sink", sink.Type(), "err", err)
+		}
+	}
 }
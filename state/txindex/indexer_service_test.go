@@ -1,6 +1,7 @@
 package txindex_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
@@ -79,3 +82,88 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
 }
+
+// testEventSink records the indexed blocks.
+type testEventSink struct {
+	mtx     tmsync.Mutex
+	err     error
+	heights []int64
+	txs     [][]*abci.TxResult
+	stopped bool
+}
+
+var _ indexer.EventSink = (*testEventSink)(nil)
+
+func (s *testEventSink) IndexBlock(h types.EventDataNewBlockHeader, txResults []*abci.TxResult) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.heights = append(s.heights, h.Header.Height)
+	s.txs = append(s.txs, txResults)
+	return nil
+}
+
+func (s *testEventSink) Type() indexer.EventSinkType { return "test" }
+
+func (s *testEventSink) Stop() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.stopped = true
+	return nil
+}
+
+func TestIndexerServiceWritesToEventSinks(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+	failingSink := &testEventSink{err: errors.New("database is down")}
+	sink := &testEventSink{}
+
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, failingSink, sink)
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+
+	err = eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		NumTxs: int64(1),
+	})
+	require.NoError(t, err)
+	txResult := &abci.TxResult{
+		Height: 1,
+		Index:  uint32(0),
+		Tx:     types.Tx("foo"),
+		Result: abci.ResponseDeliverTx{Code: 0},
+	}
+	err = eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult})
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	// the failure of one sink doesn't affect the other ones and the kv indexer
+	res, err := txIndexer.Get(types.Tx("foo").Hash())
+	require.NoError(t, err)
+	require.Equal(t, txResult, res)
+
+	sink.mtx.Lock()
+	require.Equal(t, []int64{1}, sink.heights)
+	require.Equal(t, [][]*abci.TxResult{{txResult}}, sink.txs)
+	sink.mtx.Unlock()
+
+	// the sinks are stopped with the service
+	require.NoError(t, service.Stop())
+	require.True(t, sink.stopped)
+	require.True(t, failingSink.stopped)
+}
@@ -3,12 +3,17 @@ package kv
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/google/orderedcode"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -20,8 +25,19 @@ import (
 
 const (
 	tagKeySeparator = "/"
+
+	// numericKeyPrefix prefixes the keys of the integer values of the events,
+	// which are ordered by the value, so the range conditions are answered by
+	// iterating over the keys in the range.
+	numericKeyPrefix = "\x00numeric/"
 )
 
+// numericIndexKey marks the store, where the numeric keys were written for all
+// the indexed txs, i.e. which was empty when they were introduced. The range
+// conditions are answered by scanning all the values of the composite key
+// otherwise.
+var numericIndexKey = []byte("\x00numeric_index")
+
 var _ txindex.TxIndexer = (*TxIndex)(nil)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
@@ -68,6 +84,10 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 	storeBatch := txi.store.NewBatch()
 	defer storeBatch.Close()
 
+	if err := txi.markNumericIndex(storeBatch); err != nil {
+		return err
+	}
+
 	for _, result := range b.Ops {
 		hash := types.Tx(result.Tx).Hash()
 
@@ -82,6 +102,10 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 		if err != nil {
 			return err
 		}
+		err = storeBatch.Set(keyForNumericEvent(types.TxHeightKey, result.Height, result), hash)
+		if err != nil {
+			return err
+		}
 
 		rawBytes, err := proto.Marshal(result)
 		if err != nil {
@@ -105,6 +129,10 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	b := txi.store.NewBatch()
 	defer b.Close()
 
+	if err := txi.markNumericIndex(b); err != nil {
+		return err
+	}
+
 	hash := types.Tx(result.Tx).Hash()
 
 	// index tx by events
@@ -118,6 +146,10 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	if err != nil {
 		return err
 	}
+	err = b.Set(keyForNumericEvent(types.TxHeightKey, result.Height, result), hash)
+	if err != nil {
+		return err
+	}
 
 	rawBytes, err := proto.Marshal(result)
 	if err != nil {
@@ -151,6 +183,14 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				if err != nil {
 					return err
 				}
+
+				// the integer values are indexed by the numeric key as well
+				if v, err := strconv.ParseInt(string(attr.Value), 10, 64); err == nil {
+					err := store.Set(keyForNumericEvent(compositeTag, v, result), hash)
+					if err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -158,14 +198,40 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 	return nil
 }
 
+// markNumericIndex marks the store, if nothing was indexed yet, so the range
+// conditions are answered by the numeric keys.
+func (txi *TxIndex) markNumericIndex(b dbm.Batch) error {
+	ok, err := txi.store.Has(numericIndexKey)
+	if err != nil || ok {
+		return err
+	}
+
+	// the txs indexed without the numeric keys
+	it, err := dbm.IteratePrefix(txi.store, startKey(types.TxHeightKey))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	if it.Valid() {
+		return nil
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	return b.Set(numericIndexKey, []byte{1})
+}
+
 // Search performs a search using the given query.
 //
 // It breaks the query into conditions (like "tx.height > 5"). For each
 // condition, it queries the DB index. One special use cases here: (1) if
-// "tx.hash" is found, it returns tx result for it (2) for range queries it is
-// better for the client to provide both lower and upper bounds, so we are not
-// performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// "tx.hash" is found, it returns tx result for it (2) the integer range
+// queries iterate over the numeric keys in the range, unless the txs were
+// indexed before the numeric keys were introduced, so it is better for the
+// client to provide both lower and upper bounds, so we are not performing a
+// full scan. Results from querying indexes are then intersected and returned
+// to the caller, in no particular order.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
@@ -177,14 +243,114 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	default:
 	}
 
-	var hashesInitialized bool
-	filteredHashes := make(map[string][]byte)
+	refs, err := txi.searchRefs(ctx, q)
+	if err != nil {
+		return nil, err
+	}
 
-	// get a list of conditions (like "tx.height > 5")
-	conditions, err := q.Conditions()
+	return txi.load(ctx, refs)
+}
+
+// Count returns the number of transactions matching the given query. Unlike
+// Search, it only reads the index and doesn't load the transactions.
+func (txi *TxIndex) Count(ctx context.Context, q *query.Query) (int, error) {
+	refs, err := txi.searchRefs(ctx, q)
 	if err != nil {
-		return nil, fmt.Errorf("error during parsing conditions from query: %w", err)
+		return 0, err
 	}
+	return len(refs), nil
+}
+
+// SearchPage performs a search using the given query, like Search, and orders
+// the matching transactions by height and index, in descending order if
+// orderDesc is true. It returns at most limit transactions after skipping the
+// first skip ones, along with the total number of matching transactions.
+// Only the returned transactions are loaded from the storage.
+func (txi *TxIndex) SearchPage(
+	ctx context.Context,
+	q *query.Query,
+	orderDesc bool,
+	skip, limit int,
+) ([]*abci.TxResult, int, error) {
+	select {
+	case <-ctx.Done():
+		return make([]*abci.TxResult, 0), 0, nil
+
+	default:
+	}
+
+	refs, err := txi.searchRefs(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if orderDesc {
+			i, j = j, i
+		}
+		if refs[i].height == refs[j].height {
+			return refs[i].index < refs[j].index
+		}
+		return refs[i].height < refs[j].height
+	})
+
+	total := len(refs)
+	if skip < 0 {
+		skip = 0
+	}
+	if skip > total {
+		skip = total
+	}
+	if limit >= 0 && skip+limit < total {
+		refs = refs[skip : skip+limit]
+	} else {
+		refs = refs[skip:]
+	}
+
+	results, err := txi.load(ctx, refs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// txRef references an indexed transaction by its hash, height and index.
+type txRef struct {
+	hash   []byte
+	height int64
+	index  uint32
+}
+
+// searchRefs returns references to all transactions matching the given query,
+// in no particular order, without loading the transactions.
+func (txi *TxIndex) searchRefs(ctx context.Context, q *query.Query) ([]txRef, error) {
+	// the conditions (like "tx.height > 5") of the conjunctions are searched
+	// together, and the matches of the disjunctions united
+	filteredHashes, err := indexer.SearchExpression(q.Expression(),
+		func(conditions []query.Condition) (map[string][]byte, error) {
+			return txi.searchConditions(ctx, conditions)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]txRef, 0, len(filteredHashes))
+	for h, key := range filteredHashes {
+		height, index, err := parseKeyHeightIndex(key)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, txRef{hash: []byte(h), height: height, index: index})
+	}
+
+	return refs, nil
+}
+
+// searchConditions returns all transactions matching all the given
+// conditions by hash, mapped to their index keys.
+func (txi *TxIndex) searchConditions(ctx context.Context, conditions []query.Condition) (map[string][]byte, error) {
+	var hashesInitialized bool
+	filteredHashes := make(map[string][]byte)
 
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
@@ -194,11 +360,12 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		res, err := txi.Get(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
 		case res == nil:
-			return []*abci.TxResult{}, nil
+			return filteredHashes, nil
 		default:
-			return []*abci.TxResult{res}, nil
+			filteredHashes[string(hash)] = keyForHeight(res)
+			return filteredHashes, nil
 		}
 	}
 
@@ -212,9 +379,24 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	if len(ranges) > 0 {
 		skipIndexes = append(skipIndexes, rangeIndexes...)
 
+		numericIndex, err := txi.store.Has(numericIndexKey)
+		if err != nil {
+			return nil, err
+		}
+
 		for _, qr := range ranges {
+			// the integer ranges are iterated over by the numeric keys, if all
+			// the txs are indexed by them
+			if numericIndex && isNumericRange(qr) {
+				filteredHashes, err = txi.matchNumericRange(ctx, qr, filteredHashes, !hashesInitialized)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, !hashesInitialized)
+			}
+
 			if !hashesInitialized {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, true)
 				hashesInitialized = true
 
 				// Ignore any remaining conditions if the first condition resulted
@@ -222,8 +404,6 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 				if len(filteredHashes) == 0 {
 					break
 				}
-			} else {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, false)
 			}
 		}
 	}
@@ -251,18 +431,26 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
+	return filteredHashes, nil
+}
+
+// load loads the referenced transactions from the storage.
+//
+// load will exit early and return any result fetched so far,
+// when a message is received on the context chan.
+func (txi *TxIndex) load(ctx context.Context, refs []txRef) ([]*abci.TxResult, error) {
+	results := make([]*abci.TxResult, 0, len(refs))
+	for _, ref := range refs {
+		res, err := txi.Get(ref.hash)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", ref.hash, err)
 		}
 		results = append(results, res)
 
 		// Potentially exit early.
 		select {
 		case <-ctx.Done():
-			break
+			return results, nil
 		default:
 		}
 	}
@@ -291,8 +479,8 @@ func lookForHeight(conditions []query.Condition) (height int64) {
 }
 
 // match returns all matching txs by hash that meet a given condition and start
-// key. An already filtered result (filteredHashes) is provided such that any
-// non-intersecting matches are removed.
+// key, mapped to their index keys. An already filtered result (filteredHashes)
+// is provided such that any non-intersecting matches are removed.
 //
 // NOTE: filteredHashes may be empty if no previous condition has matched.
 func (txi *TxIndex) match(
@@ -319,7 +507,7 @@ func (txi *TxIndex) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
-			tmpHashes[string(it.Value())] = it.Value()
+			tmpHashes[string(it.Value())] = it.Key()
 
 			// Potentially exit early.
 			select {
@@ -342,7 +530,7 @@ func (txi *TxIndex) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
-			tmpHashes[string(it.Value())] = it.Value()
+			tmpHashes[string(it.Value())] = it.Key()
 
 			// Potentially exit early.
 			select {
@@ -371,7 +559,7 @@ func (txi *TxIndex) match(
 			}
 
 			if strings.Contains(extractValueFromKey(it.Key()), c.Operand.(string)) {
-				tmpHashes[string(it.Value())] = it.Value()
+				tmpHashes[string(it.Value())] = it.Key()
 			}
 
 			// Potentially exit early.
@@ -418,8 +606,8 @@ func (txi *TxIndex) match(
 }
 
 // matchRange returns all matching txs by hash that meet a given queryRange and
-// start key. An already filtered result (filteredHashes) is provided such that
-// any non-intersecting matches are removed.
+// start key, mapped to their index keys. An already filtered result
+// (filteredHashes) is provided such that any non-intersecting matches are removed.
 //
 // NOTE: filteredHashes may be empty if no previous condition has matched.
 func (txi *TxIndex) matchRange(
@@ -436,8 +624,14 @@ func (txi *TxIndex) matchRange(
 	}
 
 	tmpHashes := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
+	// the empty range matches nothing
+	var (
+		lowerBound, upperBound int64
+		numeric                bool
+	)
+	if isNumericRange(qr) {
+		lowerBound, upperBound, numeric = numericBounds(qr)
+	}
 
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
@@ -451,23 +645,14 @@ LOOP:
 			continue
 		}
 
-		if _, ok := qr.AnyBound().(int64); ok {
+		if numeric {
 			v, err := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
 			if err != nil {
 				continue LOOP
 			}
 
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHashes[string(it.Value())] = it.Value()
+			if v >= lowerBound && v <= upperBound {
+				tmpHashes[string(it.Value())] = it.Key()
 			}
 
 			// XXX: passing time in a ABCI Events is not yet implemented
@@ -518,6 +703,118 @@ LOOP:
 	return filteredHashes
 }
 
+// matchNumericRange returns all matching txs by hash that meet a given
+// integer queryRange, mapped to their index keys, by iterating over the
+// numeric keys in the range. An already filtered result (filteredHashes) is
+// provided such that any non-intersecting matches are removed.
+//
+// NOTE: filteredHashes may be empty if no previous condition has matched.
+func (txi *TxIndex) matchNumericRange(
+	ctx context.Context,
+	qr indexer.QueryRange,
+	filteredHashes map[string][]byte,
+	firstRun bool,
+) (map[string][]byte, error) {
+	// A previous match was attempted but resulted in no matches, so we return
+	// no matches (assuming AND operand).
+	if !firstRun && len(filteredHashes) == 0 {
+		return filteredHashes, nil
+	}
+
+	tmpHashes := make(map[string][]byte)
+
+	lower, upper, ok := numericBounds(qr)
+	if ok {
+		prefix := numericKeyPrefixFor(qr.Key)
+		start := append(append([]byte{}, prefix...), encodeNumericValue(lower)...)
+		var end []byte
+		if upper == math.MaxInt64 {
+			end = prefixEnd(prefix)
+		} else {
+			end = append(append([]byte{}, prefix...), encodeNumericValue(upper+1)...)
+		}
+
+		it, err := txi.store.Iterator(start, end)
+		if err != nil {
+			return nil, err
+		}
+		defer it.Close()
+
+		for ; it.Valid(); it.Next() {
+			v, height, index, err := parseNumericKey(it.Key(), len(prefix))
+			if err != nil {
+				return nil, err
+			}
+			tmpHashes[string(it.Value())] = keyForEvent(qr.Key, []byte(strconv.FormatInt(v, 10)),
+				&abci.TxResult{Height: height, Index: index})
+
+			// Potentially exit early.
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tmpHashes) == 0 || firstRun {
+		// Either:
+		//
+		// 1. Regardless if a previous match was attempted, which may have had
+		// results, but no match was found for the current condition, then we
+		// return no matches (assuming AND operand).
+		//
+		// 2. A previous match was not attempted, so we return all results.
+		return tmpHashes, nil
+	}
+
+	// Remove/reduce matches in filteredHashes that were not found in this
+	// match (tmpHashes).
+	for k := range filteredHashes {
+		if tmpHashes[k] == nil {
+			delete(filteredHashes, k)
+		}
+	}
+
+	return filteredHashes, nil
+}
+
+// isNumericRange returns true if the bounds of the range are integers.
+func isNumericRange(qr indexer.QueryRange) bool {
+	for _, b := range []interface{}{qr.LowerBound, qr.UpperBound} {
+		if _, ok := b.(int64); b != nil && !ok {
+			return false
+		}
+	}
+	return qr.AnyBound() != nil
+}
+
+// numericBounds returns the inclusive bounds of the integer range. ok is false
+// if the range is empty.
+func numericBounds(qr indexer.QueryRange) (lower, upper int64, ok bool) {
+	lower, upper = math.MinInt64, math.MaxInt64
+	if qr.LowerBound != nil {
+		lower = qr.LowerBound.(int64)
+		if !qr.IncludeLowerBound {
+			if lower == math.MaxInt64 {
+				return 0, 0, false
+			}
+			lower++
+		}
+	}
+	if qr.UpperBound != nil {
+		upper = qr.UpperBound.(int64)
+		if !qr.IncludeUpperBound {
+			if upper == math.MinInt64 {
+				return 0, 0, false
+			}
+			upper--
+		}
+	}
+	return lower, upper, lower <= upper
+}
+
 // Keys
 
 func isTagKey(key []byte) bool {
@@ -529,6 +826,24 @@ func extractValueFromKey(key []byte) string {
 	return parts[1]
 }
 
+// parseKeyHeightIndex returns the height and the index of the tx referenced by
+// an index key, which are the last two parts of the key.
+func parseKeyHeightIndex(key []byte) (int64, uint32, error) {
+	parts := strings.Split(string(key), tagKeySeparator)
+	if len(parts) < 4 {
+		return 0, 0, fmt.Errorf("invalid index key %q", key)
+	}
+	height, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid height in index key %q: %w", key, err)
+	}
+	index, err := strconv.ParseUint(parts[len(parts)-1], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid index in index key %q: %w", key, err)
+	}
+	return height, uint32(index), nil
+}
+
 func keyForEvent(key string, value []byte, result *abci.TxResult) []byte {
 	return []byte(fmt.Sprintf("%s/%s/%d/%d",
 		key,
@@ -538,6 +853,68 @@ func keyForEvent(key string, value []byte, result *abci.TxResult) []byte {
 	))
 }
 
+// keyForNumericEvent returns the numeric key of the integer value of the
+// composite key:
+//
+//	numericKeyPrefix | orderedcode(compositeKey) | value | height | index
+//
+// The value is encoded by encodeNumericValue, the height and the index in
+// big-endian.
+func keyForNumericEvent(compositeKey string, value int64, result *abci.TxResult) []byte {
+	key := numericKeyPrefixFor(compositeKey)
+	key = append(key, encodeNumericValue(value)...)
+	key = append(key, encodeNumericValue(result.Height)...)
+	var index [4]byte
+	binary.BigEndian.PutUint32(index[:], result.Index)
+	return append(key, index[:]...)
+}
+
+// numericKeyPrefixFor returns the prefix of the numeric keys of the composite
+// key. The composite key is encoded by orderedcode, so the prefix of one
+// composite key is never the prefix of another one.
+func numericKeyPrefixFor(compositeKey string) []byte {
+	key, err := orderedcode.Append([]byte(numericKeyPrefix), compositeKey)
+	if err != nil {
+		panic(err) // never happens for a string
+	}
+	return key
+}
+
+// encodeNumericValue encodes the integer in big-endian with the sign bit
+// flipped, so the encoded values are ordered like the integers, including
+// the negative ones.
+func encodeNumericValue(v int64) []byte {
+	var bz [8]byte
+	binary.BigEndian.PutUint64(bz[:], uint64(v)^(1<<63))
+	return bz[:]
+}
+
+func decodeNumericValue(bz []byte) int64 {
+	return int64(binary.BigEndian.Uint64(bz) ^ (1 << 63))
+}
+
+// parseNumericKey returns the value, the height and the index of the numeric
+// key with the prefix of the given length.
+func parseNumericKey(key []byte, prefixLen int) (value, height int64, index uint32, err error) {
+	if len(key) != prefixLen+8+8+4 {
+		return 0, 0, 0, errors.New("invalid numeric key length")
+	}
+	key = key[prefixLen:]
+	return decodeNumericValue(key[:8]), decodeNumericValue(key[8:16]), binary.BigEndian.Uint32(key[16:]), nil
+}
+
+// prefixEnd returns the key following all the keys with the prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 func keyForHeight(result *abci.TxResult) []byte {
 	return []byte(fmt.Sprintf("%s/%d/%d/%d",
 		types.TxHeightKey,
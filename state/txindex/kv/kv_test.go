@@ -1,10 +1,13 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
@@ -313,6 +316,321 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchPage(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	// 500 blocks with 2 txs each, one of them changing the quorum
+	const numBlocks = 500
+	for h := int64(1); h <= numBlocks; h++ {
+		for i := uint32(0); i < 2; i++ {
+			txResult := txResultWithEvents([]abci.Event{
+				{Type: "quorum", Attributes: []abci.EventAttribute{
+					{Key: []byte("changed"), Value: []byte(fmt.Sprintf("%t", i == 1)), Index: true},
+				}},
+			})
+			txResult.Tx = types.Tx(fmt.Sprintf("tx %d/%d", h, i))
+			txResult.Height = h
+			txResult.Index = i
+			require.NoError(t, indexer.Index(txResult))
+		}
+	}
+
+	ctx := context.Background()
+	q := query.MustParse("quorum.changed = 'true'")
+
+	count, err := indexer.Count(ctx, q)
+	require.NoError(t, err)
+	assert.Equal(t, numBlocks, count)
+
+	count, err = indexer.Count(ctx, query.MustParse("quorum.changed EXISTS"))
+	require.NoError(t, err)
+	assert.Equal(t, 2*numBlocks, count)
+
+	for _, orderDesc := range []bool{false, true} {
+		const perPage = 30
+		var heights []int64
+		for skip := 0; skip < numBlocks; skip += perPage {
+			results, total, err := indexer.SearchPage(ctx, q, orderDesc, skip, perPage)
+			require.NoError(t, err)
+			assert.Equal(t, numBlocks, total)
+			if skip+perPage <= numBlocks {
+				require.Len(t, results, perPage)
+			} else {
+				require.Len(t, results, numBlocks-skip)
+			}
+			for _, res := range results {
+				assert.EqualValues(t, 1, res.Index)
+				heights = append(heights, res.Height)
+			}
+		}
+
+		require.Len(t, heights, numBlocks)
+		for i, h := range heights {
+			if orderDesc {
+				assert.EqualValues(t, numBlocks-i, h)
+			} else {
+				assert.EqualValues(t, i+1, h)
+			}
+		}
+	}
+
+	// txs of the same height are ordered by index
+	results, total, err := indexer.SearchPage(ctx, query.MustParse("tx.height = 5"), true, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, results, 2)
+	assert.EqualValues(t, 1, results[0].Index)
+	assert.EqualValues(t, 0, results[1].Index)
+
+	// skipping all the results gives an empty page
+	results, total, err = indexer.SearchPage(ctx, q, false, numBlocks, 10)
+	require.NoError(t, err)
+	assert.Equal(t, numBlocks, total)
+	assert.Empty(t, results)
+}
+
+func TestTxSearchNumericRange(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	// the numeric and the string values of the same key
+	amounts := []string{
+		"-9223372036854775808", "-1000", "-1", "0", "1", "007", "1000", "1001",
+		"5000", "5001", "abc", "1.5", "9223372036854775807",
+	}
+	batch := txindex.NewBatch(int64(len(amounts)))
+	for i, amount := range amounts {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: []byte("amount"), Value: []byte(amount), Index: true},
+				{Key: []byte("parity"), Value: []byte(fmt.Sprint(i % 2)), Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx-%d", i))
+		txResult.Height = int64(i + 1)
+		txResult.Index = uint32(i)
+		require.NoError(t, batch.Add(txResult))
+	}
+	require.NoError(t, indexer.AddBatch(batch))
+
+	// the same txs indexed before the numeric keys were introduced
+	legacyIndexer := NewTxIndex(db.NewMemDB())
+	require.NoError(t, legacyIndexer.AddBatch(batch))
+	require.NoError(t, legacyIndexer.store.Delete(numericIndexKey))
+
+	testCases := []struct {
+		q       string
+		amounts []string
+	}{
+		{"transfer.amount > 1000 AND transfer.amount <= 5000", []string{"1001", "5000"}},
+		{"transfer.amount >= 1000 AND transfer.amount < 5000", []string{"1000", "1001"}},
+		{"transfer.amount >= 0 AND transfer.amount <= 1", []string{"0", "1"}},
+		{"transfer.amount > 0 AND transfer.amount < 1", nil},
+		{"transfer.amount < 0", []string{"-9223372036854775808", "-1000", "-1"}},
+		{"transfer.amount <= 0", []string{"-9223372036854775808", "-1000", "-1", "0"}},
+		{"transfer.amount > 5000", []string{"5001", "9223372036854775807"}},
+		{"transfer.amount >= 7 AND transfer.amount <= 7", []string{"007"}},
+		{"transfer.amount > 5000 AND transfer.amount < 1000", nil},
+		{"transfer.amount > 9223372036854775807", nil},
+		{"transfer.amount >= 9223372036854775807", []string{"9223372036854775807"}},
+		// intersected with the other conditions
+		{"transfer.amount > 0 AND transfer.parity = 0", []string{"1", "1000", "5000", "9223372036854775807"}},
+		{"transfer.parity = 1 AND transfer.amount <= 1000", []string{"-1000", "0", "007"}},
+		{"transfer.amount > 1000 AND transfer.amount < 1001", nil},
+		{"tx.height > 10 AND transfer.amount >= 1", []string{"9223372036854775807"}},
+		{"tx.height >= 2 AND tx.height < 4", []string{"-1000", "-1"}},
+		// the string values are found by the exact match only
+		{"transfer.amount = 'abc'", []string{"abc"}},
+		{"transfer.amount = 1000", []string{"1000"}},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			q := query.MustParse(tc.q)
+
+			results, err := indexer.Search(ctx, q)
+			require.NoError(t, err)
+			var amounts []string
+			for _, txr := range results {
+				amounts = append(amounts, string(txr.Result.Events[0].Attributes[0].Value))
+			}
+			assert.ElementsMatch(t, tc.amounts, amounts)
+
+			// the results match the ones of the full scan
+			legacyResults, err := legacyIndexer.Search(ctx, q)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, legacyResults, results)
+		})
+	}
+}
+
+// txQueryConditions generate random conditions on the attributes of the txs
+// indexed by TestTxSearchMatchesQuery, one key each.
+var txQueryConditions = []func() string{
+	func() string {
+		ops := []string{"=", "<", "<=", ">", ">="}
+		return fmt.Sprintf("account.number %s %d", ops[tmrand.Intn(len(ops))], tmrand.Intn(10))
+	},
+	func() string {
+		if tmrand.Bool() {
+			return "account.owner CONTAINS 'o'"
+		}
+		return "account.owner = 'alice'"
+	},
+	func() string {
+		if tmrand.Bool() {
+			return "transfer.owner EXISTS"
+		}
+		return "transfer.owner = 'bob'"
+	},
+	func() string {
+		ops := []string{"=", "<", ">="}
+		return fmt.Sprintf("transfer.amount %s %d", ops[tmrand.Intn(len(ops))], tmrand.Intn(1000))
+	},
+	func() string {
+		ops := []string{"=", "<=", ">"}
+		return fmt.Sprintf("tx.height %s %d", ops[tmrand.Intn(len(ops))], 1+tmrand.Intn(10))
+	},
+}
+
+// randomTxQuery returns a random query nested up to the depth. The sibling
+// conditions have distinct keys, since the ranges of the same key are merged
+// by the indexer.
+func randomTxQuery(depth int, or bool) string {
+	keys := tmrand.Perm(len(txQueryConditions))
+	operands := make([]string, 2+tmrand.Intn(2))
+	for i := range operands {
+		if depth > 0 && tmrand.Bool() {
+			operands[i] = "(" + randomTxQuery(depth-1, !or) + ")"
+		} else {
+			operands[i] = txQueryConditions[keys[i]]()
+		}
+	}
+
+	if or {
+		return strings.Join(operands, " OR ")
+	}
+	return strings.Join(operands, " AND ")
+}
+
+func TestTxSearchMatchesQuery(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	const txsCount = 50
+	owners := []string{"alice", "bob", "carol", "dave"}
+	txEvents := make(map[string]map[string][]string, txsCount)
+	for i := 0; i < txsCount; i++ {
+		attrs := map[string]string{
+			"account.number":  fmt.Sprint(tmrand.Intn(10)),
+			"account.owner":   owners[tmrand.Intn(len(owners))],
+			"transfer.owner":  owners[tmrand.Intn(len(owners))],
+			"transfer.amount": fmt.Sprint(tmrand.Intn(1000)),
+		}
+		txResult := txResultWithEvents(nil)
+		txResult.Tx = types.Tx(fmt.Sprintf("tx-%d", i))
+		txResult.Height = int64(1 + i/5)
+		txResult.Index = uint32(i % 5)
+		events := map[string][]string{types.TxHeightKey: {fmt.Sprint(txResult.Height)}}
+		for key, value := range attrs {
+			if tmrand.Bool() {
+				continue
+			}
+			parts := strings.Split(key, ".")
+			txResult.Result.Events = append(txResult.Result.Events, abci.Event{
+				Type:       parts[0],
+				Attributes: []abci.EventAttribute{{Key: []byte(parts[1]), Value: []byte(value), Index: true}},
+			})
+			events[key] = []string{value}
+		}
+		require.NoError(t, indexer.Index(txResult))
+		txEvents[string(txResult.Tx)] = events
+	}
+
+	ctx := context.Background()
+
+	// the indexer finds the txs whose events are matched by the query
+	for i := 0; i < 500; i++ {
+		q := query.MustParse(randomTxQuery(2, tmrand.Bool()))
+
+		var expected []string
+		for tx, events := range txEvents {
+			match, err := q.Matches(events)
+			require.NoError(t, err, q.String())
+			if match {
+				expected = append(expected, tx)
+			}
+		}
+
+		results, err := indexer.Search(ctx, q)
+		require.NoError(t, err, q.String())
+		var txs []string
+		for _, txr := range results {
+			txs = append(txs, string(txr.Tx))
+		}
+		require.ElementsMatch(t, expected, txs, q.String())
+	}
+}
+
+func TestTxIndexNumericIndexMarker(t *testing.T) {
+	// the txs are indexed by the numeric keys from the start
+	indexer := NewTxIndex(db.NewMemDB())
+	require.NoError(t, indexer.Index(txResultWithEvents(nil)))
+	ok, err := indexer.store.Has(numericIndexKey)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the store of the txs indexed before the numeric keys isn't marked
+	indexer = NewTxIndex(db.NewMemDB())
+	legacyTxResult := txResultWithEvents([]abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte("5"), Index: true}}},
+	})
+	b := indexer.store.NewBatch()
+	require.NoError(t, b.Set(keyForEvent("transfer.amount", []byte("5"), legacyTxResult), types.Tx(legacyTxResult.Tx).Hash()))
+	require.NoError(t, b.Set(keyForHeight(legacyTxResult), types.Tx(legacyTxResult.Tx).Hash()))
+	rawBytes, err := proto.Marshal(legacyTxResult)
+	require.NoError(t, err)
+	require.NoError(t, b.Set(types.Tx(legacyTxResult.Tx).Hash(), rawBytes))
+	require.NoError(t, b.Write())
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte("6"), Index: true}}},
+	})
+	txResult.Tx = types.Tx("HELLO WORLD 2")
+	txResult.Height = 2
+	require.NoError(t, indexer.Index(txResult))
+	ok, err = indexer.store.Has(numericIndexKey)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// so the range conditions find the txs without the numeric keys
+	results, err := indexer.Search(context.Background(), query.MustParse("transfer.amount >= 5"))
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestNumericKeysOrder(t *testing.T) {
+	values := []int64{math.MinInt64, -1000, -1, 0, 1, 255, 256, 1000, math.MaxInt64}
+	for i := 1; i < len(values); i++ {
+		prev := keyForNumericEvent("transfer.amount", values[i-1], &abci.TxResult{Height: 2, Index: 1})
+		next := keyForNumericEvent("transfer.amount", values[i], &abci.TxResult{Height: 1, Index: 0})
+		assert.Equal(t, -1, bytes.Compare(prev, next), "%d < %d", values[i-1], values[i])
+	}
+
+	prefix := numericKeyPrefixFor("transfer.amount")
+	v, height, index, err := parseNumericKey(
+		keyForNumericEvent("transfer.amount", -5, &abci.TxResult{Height: 10, Index: 3}), len(prefix))
+	require.NoError(t, err)
+	assert.EqualValues(t, -5, v)
+	assert.EqualValues(t, 10, height)
+	assert.EqualValues(t, 3, index)
+
+	// the keys of the composite key with another composite key as a prefix
+	// don't share its prefix
+	assert.False(t, bytes.HasPrefix(numericKeyPrefixFor("transfer.amount/x"), prefix))
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
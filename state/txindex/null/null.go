@@ -32,3 +32,12 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return []*abci.TxResult{}, nil
 }
+
+func (txi *TxIndex) SearchPage(
+	ctx context.Context,
+	q *query.Query,
+	orderDesc bool,
+	skip, limit int,
+) ([]*abci.TxResult, int, error) {
+	return []*abci.TxResult{}, 0, nil
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	tmtime "github.com/tendermint/tendermint/types/time"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/proxy"
@@ -63,10 +62,7 @@ func validateBlock(state State, block *types.Block) error {
 
 	// Validate app info
 	if !bytes.Equal(block.AppHash, state.AppHash) {
-		return fmt.Errorf("wrong Block.Header.AppHash.  Expected %X, got %v",
-			state.AppHash,
-			block.AppHash,
-		)
+		return ErrAppHashMismatch{Height: block.Height, Local: state.AppHash, Block: block.AppHash}
 	}
 	hashCP := types.HashConsensusParams(state.ConsensusParams)
 	if !bytes.Equal(block.ConsensusHash, hashCP) {
@@ -107,6 +103,15 @@ func validateBlock(state State, block *types.Block) error {
 			state.ChainID, state.LastBlockID, state.LastStateID, block.Height-1, block.LastCommit); err != nil {
 			return err
 		}
+		extensionsEnabled := types.VoteExtensionsEnabled(state.ConsensusParams.ABCI, block.Height-1)
+		if extensionsEnabled && !block.LastCommit.IsExtended() {
+			return fmt.Errorf("vote extensions are enabled at height %d, but the last commit has no vote extension",
+				block.Height-1)
+		}
+		if !extensionsEnabled && block.LastCommit.IsExtended() {
+			return fmt.Errorf("vote extensions are disabled at height %d, but the last commit has a vote extension",
+				block.Height-1)
+		}
 	}
 
 	// NOTE: We can't actually verify it's the right proposer because we don't
@@ -124,7 +129,7 @@ func validateBlock(state State, block *types.Block) error {
 		)
 	}
 
-	// Validate block Time is after previous block
+	// Validate block Time is at least the min step after previous block
 	switch {
 	case block.Height > state.InitialHeight:
 		if !block.Time.After(state.LastBlockTime) {
@@ -133,6 +138,14 @@ func validateBlock(state State, block *types.Block) error {
 				state.LastBlockTime,
 			)
 		}
+		minTime := state.LastBlockTime.Add(state.ConsensusParams.Synchrony.MinStep)
+		if block.Time.Before(minTime) {
+			return fmt.Errorf("block time %v is before last block time %v plus min step %v",
+				block.Time,
+				state.LastBlockTime,
+				state.ConsensusParams.Synchrony.MinStep,
+			)
+		}
 
 	case block.Height == state.InitialHeight:
 		genesisTime := state.LastBlockTime
@@ -180,22 +193,19 @@ func validateBlock(state State, block *types.Block) error {
 	return nil
 }
 
-func validateBlockTime(state State, block *types.Block) error {
+// validateBlockTime validates the block time, set by the proposer from its
+// clock, against the local clock: it can't be ahead of the local time by more
+// than the max drift. It isn't deterministic, so it's only checked for the
+// proposals, which are prevoted, not for the committed blocks.
+func validateBlockTime(state State, block *types.Block, now time.Time) error {
+	// the time of the first block is the genesis time
 	if block.Height == state.InitialHeight {
-		afterLast := state.LastBlockTime.Add(5 * time.Second)
-		beforeLast := state.LastBlockTime.Add(-5 * time.Second)
-		if block.Time.After(afterLast) || block.Time.Before(beforeLast) {
-			return fmt.Errorf("block time %v is out of window [%v, %v]",
-				block.Time, afterLast, beforeLast)
-		}
-	} else {
-		// Validate block Time is within a range of current time
-		after := tmtime.Now().Add(20 * time.Second)
-		before := tmtime.Now().Add(-20 * time.Second)
-		if block.Time.After(after) || block.Time.Before(before) {
-			return fmt.Errorf("block time %v is out of window [%v, %v]",
-				block.Time, before, after)
-		}
+		return nil
+	}
+	maxTime := now.Add(state.ConsensusParams.Synchrony.MaxDrift)
+	if block.Time.After(maxTime) {
+		return fmt.Errorf("block time %v is after local time %v plus max drift %v",
+			block.Time, now, state.ConsensusParams.Synchrony.MaxDrift)
 	}
 	return nil
 }
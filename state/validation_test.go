@@ -15,12 +15,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/log"
 	memmock "github.com/tendermint/tendermint/mempool/mock"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 const validationTestsStopHeight int64 = 10
@@ -200,6 +202,19 @@ func TestValidateBlockCommit(t *testing.T) {
 				height,
 				err,
 			)
+
+			/*
+				Test that the signers of the commit need 2/3 of the voting power
+			*/
+			fewSignersCommit, err := makeValidCommit(height-1, state.LastBlockID, state.LastStateID,
+				state.LastValidators, privVals)
+			require.NoError(t, err)
+			fewSignersCommit.Signers = bits.NewBitArray(state.LastValidators.Size())
+			block, _ = state.MakeBlock(height, nextChainLock, makeTxs(height), fewSignersCommit, nil, proTxHash)
+			err = blockExec.ValidateBlock(state, block)
+			_, isErrNotEnoughVotingPowerSigned := err.(types.ErrNotEnoughVotingPowerSigned)
+			require.True(t, isErrNotEnoughVotingPowerSigned,
+				"expected ErrNotEnoughVotingPowerSigned at height %d but got: %v", height, err)
 		}
 
 		/*
@@ -260,13 +275,174 @@ func TestValidateBlockCommit(t *testing.T) {
 		wrongSignorCommit = types.NewCommit(goodVote.Height, goodVote.Round,
 			blockID, stateID, state.Validators.QuorumHash,
 			goodVote.BlockSignature, goodVote.StateSignature)
+		wrongSignorCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
 
 		wrongVoteMessageSignedCommit = types.NewCommit(goodVote.Height, goodVote.Round,
 			blockID, stateID, state.Validators.QuorumHash,
 			badVote.BlockSignature, badVote.StateSignature)
+		wrongVoteMessageSignedCommit.Signers = types.MakeAllCommitSigners(state.Validators.Size())
+	}
+}
+
+func TestValidateBlockVoteExtensions(t *testing.T) {
+	const enableHeight = 3
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(3, 1)
+	state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = enableHeight
+	nodeProTxHash := &state.Validators.Validators[0].ProTxHash
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		proxyApp.Query(),
+		memmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		nil,
+	)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
+	extension := []byte("extension")
+
+	for height := int64(1); height < validationTestsStopHeight; height++ {
+		proTxHash := state.Validators.GetProposer().ProTxHash
+		if height > 1 {
+			// the last commit must be extended if, and only if, the extensions were enabled
+			var wrongCommit *types.Commit
+			var err error
+			if height-1 < enableHeight {
+				wrongCommit, err = makeValidExtendedCommit(height-1, state.LastBlockID, state.LastStateID,
+					state.LastValidators, privVals, extension)
+			} else {
+				wrongCommit, err = makeValidCommit(height-1, state.LastBlockID, state.LastStateID,
+					state.LastValidators, privVals)
+			}
+			require.NoError(t, err)
+			block, _ := state.MakeBlock(height, nil, makeTxs(height), wrongCommit, nil, proTxHash)
+			err = blockExec.ValidateBlock(state, block)
+			require.Error(t, err, "height %d", height)
+			assert.Contains(t, err.Error(), "vote extension", "height %d", height)
+		}
+
+		var err error
+		var blockID types.BlockID
+		var stateID types.StateID
+		state, blockID, stateID, err = makeAndApplyGoodBlock(state, nodeProTxHash, height, lastCommit, proTxHash,
+			blockExec, nil)
+		require.NoError(t, err, "height %d", height)
+		if types.VoteExtensionsEnabled(state.ConsensusParams.ABCI, height) {
+			lastCommit, err = makeValidExtendedCommit(height, blockID, stateID, state.LastValidators, privVals,
+				extension)
+		} else {
+			lastCommit, err = makeValidCommit(height, blockID, stateID, state.LastValidators, privVals)
+		}
+		require.NoError(t, err, "height %d", height)
 	}
 }
 
+func TestValidateBlockTime(t *testing.T) {
+	const (
+		minStep  = time.Second
+		maxDrift = 10 * time.Second
+	)
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(3, 1)
+	state.ConsensusParams.Synchrony = tmproto.SynchronyParams{MinStep: minStep, MaxDrift: maxDrift}
+	nodeProTxHash := &state.Validators.Validators[0].ProTxHash
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		proxyApp.Query(),
+		memmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		nil,
+	)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, types.StateID{}, nil, nil, nil)
+
+	state, _, _, lastCommit, err := makeAndCommitGoodBlock(state, nodeProTxHash, 1, lastCommit,
+		state.Validators.GetProposer().ProTxHash, blockExec, privVals, nil)
+	require.NoError(t, err)
+
+	// the proposer sets the time at least the min step after the last block
+	block, _ := state.MakeBlock(2, nil, makeTxs(2), lastCommit, nil, state.Validators.GetProposer().ProTxHash)
+	assert.False(t, block.Time.Before(state.LastBlockTime.Add(minStep)))
+	assert.NoError(t, blockExec.ValidateBlock(state, block))
+	assert.NoError(t, blockExec.ValidateBlockTime(state, block))
+
+	block.Time = state.LastBlockTime.Add(minStep - time.Millisecond)
+	err = blockExec.ValidateBlock(state, block)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "min step")
+
+	// the clock of the proposer is ahead of ours
+	testCases := []struct {
+		name  string
+		drift time.Duration
+		valid bool
+	}{
+		{"no drift", 0, true},
+		{"drift within max drift", maxDrift - time.Second, true},
+		{"drift beyond max drift", maxDrift + time.Second, false},
+	}
+	for _, tc := range testCases {
+		block.Time = tmtime.Now().Add(tc.drift)
+		err := blockExec.ValidateBlockTime(state, block)
+		if tc.valid {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.Error(t, err, tc.name)
+		}
+	}
+}
+
+// the states saved before the synchrony params were added are loaded with the
+// default synchrony params
+func TestValidateBlockTimeWithoutSynchronyParams(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(3, 2)
+	stateStore := sm.NewStore(stateDB)
+	state.ConsensusParams.Synchrony = tmproto.SynchronyParams{}
+	state.LastHeightConsensusParamsChanged = state.LastBlockHeight + 1
+	require.NoError(t, stateStore.Save(state))
+
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, types.DefaultSynchronyParams(), state.ConsensusParams.Synchrony)
+	params, err := stateStore.LoadConsensusParams(state.LastBlockHeight + 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.DefaultSynchronyParams(), params.Synchrony)
+
+	// the clock of the proposer is slightly ahead of ours
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		proxyApp.Query(),
+		memmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		nil,
+	)
+	block := makeBlock(state, state.LastBlockHeight+1)
+	block.Time = tmtime.Now().Add(time.Second)
+	assert.NoError(t, blockExec.ValidateBlockTime(state, block))
+
+	// the app updates the other params
+	update := &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 1024 * 1024, MaxGas: 10}}
+	assert.NoError(t, types.ValidateConsensusParams(types.UpdateConsensusParams(state.ConsensusParams, update)))
+	state.ConsensusParams.Synchrony = tmproto.SynchronyParams{}
+	assert.NoError(t, types.ValidateConsensusParams(types.UpdateConsensusParams(state.ConsensusParams, update)))
+}
+
 func TestValidateBlockEvidence(t *testing.T) {
 	proxyApp := newTestApp()
 	require.NoError(t, proxyApp.Start())
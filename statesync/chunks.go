@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	tmsync "github.com/tendermint/tendermint/libs/sync"
@@ -36,28 +37,63 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]p2p.ID          // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkFailed    map[uint32]bool            // chunks that couldn't be fetched, via Fail()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
-// newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
-// Callers must call Close() when done.
+// newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage. The
+// temp dir is named after the snapshot, and the chunks left in it by a previous, interrupted
+// restore of the same snapshot are reused. Callers must call Close() when done.
 func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
-	dir, err := ioutil.TempDir(tempDir, "tm-statesync")
-	if err != nil {
-		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
-	}
 	if snapshot.Chunks == 0 {
 		return nil, errors.New("snapshot has no chunks")
 	}
-	return &chunkQueue{
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+	key := snapshot.Key()
+	dir := filepath.Join(tempDir, fmt.Sprintf("tm-statesync-%x", key[:]))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
+	}
+	q := &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
 		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
 		chunkSenders:   make(map[uint32]p2p.ID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkFailed:    make(map[uint32]bool),
 		waiters:        make(map[uint32][]chan<- uint32),
-	}, nil
+	}
+	if err := q.resume(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// resume adds the chunks found in the temp dir to the queue, so they aren't fetched again. The
+// sender of these chunks is unknown. Partially written chunk files are removed.
+func (q *chunkQueue) resume() error {
+	files, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return fmt.Errorf("unable to read state sync temp dir %v: %w", q.dir, err)
+	}
+	for _, file := range files {
+		path := filepath.Join(q.dir, file.Name())
+		index, err := strconv.ParseUint(file.Name(), 10, 32)
+		if err != nil || uint32(index) >= q.snapshot.Chunks {
+			if strings.HasSuffix(file.Name(), ".tmp") {
+				if err := os.Remove(path); err != nil {
+					return fmt.Errorf("failed to remove partial chunk file %v: %w", path, err)
+				}
+			}
+			continue
+		}
+		q.chunkFiles[uint32(index)] = path
+		q.chunkAllocated[uint32(index)] = true
+	}
+	return nil
 }
 
 // Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
@@ -83,13 +119,19 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 		return false, nil
 	}
 
+	// The chunk is written to a temporary file first, so a partially written chunk is never
+	// picked up when resuming.
 	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
-	err := ioutil.WriteFile(path, chunk.Chunk, 0600)
+	err := ioutil.WriteFile(path+".tmp", chunk.Chunk, 0600)
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
 	q.chunkFiles[chunk.Index] = path
 	q.chunkSenders[chunk.Index] = chunk.Sender
+	delete(q.chunkFailed, chunk.Index)
 
 	// Signal any waiters that the chunk has arrived.
 	for _, waiter := range q.waiters[chunk.Index] {
@@ -167,6 +209,7 @@ func (q *chunkQueue) discard(index uint32) error {
 	delete(q.chunkFiles, index)
 	delete(q.chunkReturned, index)
 	delete(q.chunkAllocated, index)
+	delete(q.chunkFailed, index)
 	return nil
 }
 
@@ -188,6 +231,21 @@ func (q *chunkQueue) DiscardSender(peerID p2p.ID) error {
 	return nil
 }
 
+// Fail marks an allocated chunk as failed, once it couldn't be fetched from any peer. Next()
+// returns errTimeout instead of waiting for it, unless the chunk arrives after all.
+func (q *chunkQueue) Fail(index uint32) {
+	q.Lock()
+	defer q.Unlock()
+	if q.snapshot == nil || q.chunkFiles[index] != "" {
+		return
+	}
+	q.chunkFailed[index] = true
+	for _, waiter := range q.waiters[index] {
+		close(waiter)
+	}
+	delete(q.waiters, index)
+}
+
 // GetSender returns the sender of the chunk with the given index, or empty if not found.
 func (q *chunkQueue) GetSender(index uint32) p2p.ID {
 	q.Lock()
@@ -230,10 +288,13 @@ func (q *chunkQueue) Next() (*chunk, error) {
 	index, err := q.nextUp()
 	if err == nil {
 		chunk, err = q.load(index)
-		if err == nil {
+		if chunk != nil {
 			q.chunkReturned[index] = true
 		}
 	}
+	if chunk == nil && err == nil && q.chunkFailed[index] {
+		err = errTimeout
+	}
 	q.Unlock()
 	if chunk != nil || err != nil {
 		return chunk, err
@@ -242,6 +303,12 @@ func (q *chunkQueue) Next() (*chunk, error) {
 	select {
 	case _, ok := <-q.WaitFor(index):
 		if !ok {
+			q.Lock()
+			failed := q.snapshot != nil && q.chunkFailed[index]
+			q.Unlock()
+			if failed {
+				return nil, errTimeout
+			}
 			return nil, errDone // queue closed
 		}
 	case <-time.After(chunkTimeout):
@@ -298,7 +365,7 @@ func (q *chunkQueue) Size() uint32 {
 
 // WaitFor returns a channel that receives a chunk index when it arrives in the queue, or
 // immediately if it has already arrived. The channel is closed without a value if the queue is
-// closed or if the chunk index is not valid.
+// closed, if the chunk index is not valid or if the chunk failed to be fetched.
 func (q *chunkQueue) WaitFor(index uint32) <-chan uint32 {
 	q.Lock()
 	defer q.Unlock()
@@ -308,6 +375,8 @@ func (q *chunkQueue) WaitFor(index uint32) <-chan uint32 {
 		close(ch)
 	case index >= q.snapshot.Chunks:
 		close(ch)
+	case q.chunkFailed[index]:
+		close(ch)
 	case q.chunkFiles[index] != "":
 		ch <- index
 		close(ch)
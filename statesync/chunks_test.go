@@ -3,7 +3,9 @@ package statesync
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,11 +21,14 @@ func setupChunkQueue(t *testing.T) (*chunkQueue, func()) {
 		Hash:     []byte{7},
 		Metadata: nil,
 	}
-	queue, err := newChunkQueue(snapshot, "")
+	dir, err := ioutil.TempDir("", "chunkqueue")
+	require.NoError(t, err)
+	queue, err := newChunkQueue(snapshot, dir)
 	require.NoError(t, err)
 	teardown := func() {
 		err := queue.Close()
 		require.NoError(t, err)
+		os.RemoveAll(dir)
 	}
 	return queue, teardown
 }
@@ -54,6 +59,58 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Len(t, files, 0)
 }
 
+func TestNewChunkQueue_Resume(t *testing.T) {
+	snapshot := &snapshot{
+		Height:   3,
+		Format:   1,
+		Chunks:   5,
+		Hash:     []byte{7},
+		Metadata: nil,
+	}
+	dir, err := ioutil.TempDir("", "newchunkqueue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// An interrupted restore leaves its chunks behind, including a partially written one
+	queue, err := newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	for _, index := range []uint32{0, 2} {
+		_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: index, Chunk: []byte{3, 1, byte(index)}})
+		require.NoError(t, err)
+	}
+	err = ioutil.WriteFile(filepath.Join(queue.dir, "3.tmp"), []byte{3}, 0600)
+	require.NoError(t, err)
+
+	// The restarted restore of the same snapshot only fetches the missing chunks
+	queue, err = newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	defer queue.Close()
+	assert.True(t, queue.Has(0))
+	assert.False(t, queue.Has(1))
+	assert.True(t, queue.Has(2))
+	assert.NoFileExists(t, filepath.Join(queue.dir, "3.tmp"))
+
+	for _, expected := range []uint32{1, 3, 4} {
+		index, err := queue.Allocate()
+		require.NoError(t, err)
+		assert.Equal(t, expected, index)
+	}
+	_, err = queue.Allocate()
+	assert.Equal(t, errDone, err)
+
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}}, c)
+
+	// Another snapshot doesn't reuse the chunks
+	snapshot2 := *snapshot
+	snapshot2.Hash = []byte{8}
+	other, err := newChunkQueue(&snapshot2, dir)
+	require.NoError(t, err)
+	defer other.Close()
+	assert.False(t, other.Has(0))
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -414,6 +471,42 @@ func TestChunkQueue_Next_Closed(t *testing.T) {
 	assert.Equal(t, errDone, err)
 }
 
+func TestChunkQueue_Next_Failed(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	_, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}})
+	require.NoError(t, err)
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, c.Index)
+
+	// A waiting Next call should time out once the chunk fails to be fetched
+	chErr := make(chan error, 1)
+	go func() {
+		_, err := queue.Next()
+		chErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	queue.Fail(1)
+	assert.Equal(t, errTimeout, <-chErr)
+	_, err = queue.Next()
+	assert.Equal(t, errTimeout, err)
+	_, ok := <-queue.WaitFor(1)
+	assert.False(t, ok)
+
+	// The chunk may still arrive after all
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{3, 1, 1}})
+	require.NoError(t, err)
+	c, err = queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, c.Index)
+
+	// Failing an existing chunk does nothing
+	queue.Fail(1)
+	assert.True(t, queue.Has(1))
+}
+
 func TestChunkQueue_Retry(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
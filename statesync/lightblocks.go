@@ -0,0 +1,122 @@
+package statesync
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fetchLightBlock loads the light block at the height from the stores, 0
+// loads the latest one. It returns nil, if the light block isn't stored (yet).
+func (r *Reactor) fetchLightBlock(height uint64) (*tmproto.LightBlock, error) {
+	if r.stateStore == nil || r.blockStore == nil {
+		return nil, nil
+	}
+	h := int64(height)
+	latest := r.blockStore.Height()
+	if h == 0 {
+		h = latest
+	}
+	if h <= 0 || h < r.blockStore.Base() || h > latest {
+		return nil, nil
+	}
+
+	meta := r.blockStore.LoadBlockMeta(h)
+	if meta == nil {
+		return nil, nil
+	}
+	// the commit of the latest block is the one seen by the node, as the next
+	// block isn't committed yet
+	var commit *types.Commit
+	if h == latest {
+		commit = r.blockStore.LoadSeenCommit(h)
+	} else {
+		commit = r.blockStore.LoadBlockCommit(h)
+	}
+	if commit == nil {
+		return nil, nil
+	}
+	vals, err := r.stateStore.LoadValidators(h)
+	if errors.As(err, &sm.ErrNoValSetForHeight{}) || errors.As(err, &sm.ErrPruned{}) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lightBlock := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+		ValidatorSet: vals,
+	}
+	return lightBlock.ToProto()
+}
+
+// peerRateLimiter limits the rate of the requests of each peer with a token
+// bucket. A nil limiter does not limit anything.
+type peerRateLimiter struct {
+	rate  float64
+	burst int
+
+	mtx     tmsync.Mutex
+	buckets map[p2p.ID]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newPeerRateLimiter returns a limiter of rate requests per second per peer,
+// or nil if the rate is not positive.
+func newPeerRateLimiter(rate float64, burst int) *peerRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &peerRateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[p2p.ID]*tokenBucket),
+	}
+}
+
+// allow takes a token from the bucket of the peer. It returns false, if the
+// bucket is empty.
+func (l *peerRateLimiter) allow(id p2p.ID, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	b, ok := l.buckets[id]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.burst), last: now}
+		l.buckets[id] = b
+	}
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// remove forgets the bucket of the peer.
+func (l *peerRateLimiter) remove(id p2p.ID) {
+	if l == nil {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	delete(l.buckets, id)
+}
@@ -69,6 +69,13 @@ func TestValidateMsg(t *testing.T) {
 		"SnapshotsResponse no hash": {
 			&ssproto.SnapshotsResponse{Height: 1, Format: 1, Chunks: 2, Hash: []byte{}},
 			false},
+
+		"LightBlockRequest valid":      {&ssproto.LightBlockRequest{Height: 1}, true},
+		"LightBlockRequest latest":     {&ssproto.LightBlockRequest{}, true},
+		"LightBlockResponse not found": {&ssproto.LightBlockResponse{Height: 1}, true},
+		"LightBlockResponse no header": {
+			&ssproto.LightBlockResponse{Height: 1, LightBlock: &tmproto.LightBlock{}},
+			false},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -95,6 +102,8 @@ func TestStateSyncVectors(t *testing.T) {
 		{"SnapshotsResponse", &ssproto.SnapshotsResponse{Height: 1, Format: 2, Chunks: 3, Hash: []byte("chuck hash"), Metadata: []byte("snapshot metadata")}, "1225080110021803220a636875636b20686173682a11736e617073686f74206d65746164617461"},
 		{"ChunkRequest", &ssproto.ChunkRequest{Height: 1, Format: 2, Index: 3}, "1a06080110021803"},
 		{"ChunkResponse", &ssproto.ChunkResponse{Height: 1, Format: 2, Index: 3, Chunk: []byte("it's a chunk")}, "2214080110021803220c697427732061206368756e6b"},
+		{"LightBlockRequest", &ssproto.LightBlockRequest{Height: 1}, "2a020801"},
+		{"LightBlockResponse", &ssproto.LightBlockResponse{Height: 1}, "32021001"},
 	}
 
 	for _, tc := range testCases {
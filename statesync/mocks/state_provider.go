@@ -82,3 +82,35 @@ func (_m *StateProvider) State(ctx context.Context, height uint64) (state.State,
 
 	return r0, r1
 }
+
+// StateSignature provides a mock function with given fields: ctx, height
+func (_m *StateProvider) StateSignature(ctx context.Context, height uint64) (*types.Commit, *types.ValidatorSet, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *types.Commit
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.Commit); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Commit)
+		}
+	}
+
+	var r1 *types.ValidatorSet
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) *types.ValidatorSet); ok {
+		r1 = rf(ctx, height)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.ValidatorSet)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint64) error); ok {
+		r2 = rf(ctx, height)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
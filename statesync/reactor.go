@@ -6,6 +6,7 @@ import (
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
@@ -19,6 +20,8 @@ const (
 	SnapshotChannel = byte(0x60)
 	// ChunkChannel exchanges chunk contents
 	ChunkChannel = byte(0x61)
+	// LightBlockChannel exchanges light blocks, served to the light clients
+	LightBlockChannel = byte(0x62)
 	// recentSnapshots is the number of recent snapshots to send and receive per peer.
 	recentSnapshots = 10
 )
@@ -28,9 +31,14 @@ const (
 type Reactor struct {
 	p2p.BaseReactor
 
-	conn      proxy.AppConnSnapshot
-	connQuery proxy.AppConnQuery
-	tempDir   string
+	cfg        config.StateSyncConfig
+	conn       proxy.AppConnSnapshot
+	connQuery  proxy.AppConnQuery
+	stateStore sm.Store
+	blockStore sm.BlockStore
+
+	// limits the rate of the light block requests per peer
+	lightBlockLimiter *peerRateLimiter
 
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
@@ -38,11 +46,22 @@ type Reactor struct {
 	syncer *syncer
 }
 
-// NewReactor creates a new state sync reactor.
-func NewReactor(conn proxy.AppConnSnapshot, connQuery proxy.AppConnQuery, tempDir string) *Reactor {
+// NewReactor creates a new state sync reactor. The light blocks are served to
+// the light clients from the state and block stores.
+func NewReactor(
+	cfg config.StateSyncConfig,
+	conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+) *Reactor {
 	r := &Reactor{
-		conn:      conn,
-		connQuery: connQuery,
+		cfg:               cfg,
+		conn:              conn,
+		connQuery:         connQuery,
+		stateStore:        stateStore,
+		blockStore:        blockStore,
+		lightBlockLimiter: newPeerRateLimiter(cfg.LightBlockRequestsPerSecond, cfg.LightBlockRequestsBurst),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
 	return r
@@ -63,6 +82,12 @@ func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   4,
 			RecvMessageCapacity: chunkMsgSize,
 		},
+		{
+			ID:                  LightBlockChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: lightBlockMsgSize,
+		},
 	}
 }
 
@@ -82,6 +107,8 @@ func (r *Reactor) AddPeer(peer p2p.Peer) {
 
 // RemovePeer implements p2p.Reactor.
 func (r *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	r.lightBlockLimiter.remove(peer.ID())
+
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 	if r.syncer != nil {
@@ -205,12 +232,39 @@ func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			r.Logger.Error("Received unknown message %T", msg)
 		}
 
+	case LightBlockChannel:
+		switch msg := msg.(type) {
+		case *ssproto.LightBlockRequest:
+			if !r.lightBlockLimiter.allow(src.ID(), time.Now()) {
+				r.Logger.Debug("Dropping light block request, rate limit exceeded", "height", msg.Height,
+					"peer", src.ID())
+				return
+			}
+			lightBlock, err := r.fetchLightBlock(msg.Height)
+			if err != nil {
+				r.Logger.Error("Failed to fetch light block", "height", msg.Height, "err", err)
+				return
+			}
+			r.Logger.Debug("Sending light block", "height", msg.Height, "found", lightBlock != nil,
+				"peer", src.ID())
+			src.Send(LightBlockChannel, mustEncodeMsg(&ssproto.LightBlockResponse{
+				LightBlock: lightBlock,
+				Height:     msg.Height,
+			}))
+
+		case *ssproto.LightBlockResponse:
+			r.Logger.Debug("Received unexpected light block", "height", msg.Height, "peer", src.ID())
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
 	default:
 		r.Logger.Error("Received message on invalid channel %x", chID)
 	}
 }
 
-// recentSnapshots fetches the n most recent snapshots from the app
+// recentSnapshots fetches the n most recent snapshots from the app, matching the snapshot policy
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	resp, err := r.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
 	if err != nil {
@@ -228,11 +282,25 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 			return false
 		}
 	})
+	// With a snapshot policy, only the snapshots it schedules and keeps are advertised.
+	policy := sm.SnapshotPolicy{Interval: r.cfg.SnapshotInterval, KeepRecent: r.cfg.SnapshotKeepRecent}
+	var retainHeight uint64
 	snapshots := make([]*snapshot, 0, n)
-	for i, s := range resp.Snapshots {
-		if i >= recentSnapshots {
+	for _, s := range resp.Snapshots {
+		if len(snapshots) >= int(n) {
 			break
 		}
+		if policy.Interval > 0 {
+			if !policy.Matches(s.Height) {
+				continue
+			}
+			if len(snapshots) == 0 {
+				retainHeight = policy.RetainHeight(s.Height)
+			}
+			if s.Height < retainHeight {
+				break
+			}
+		}
 		snapshots = append(snapshots, &snapshot{
 			Height:   s.Height,
 			Format:   s.Format,
@@ -244,6 +312,17 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	return snapshots, nil
 }
 
+// SetDiscoveryTime changes the time a state sync in progress, if any, waits to discover further
+// snapshots, when none of the discovered ones could be restored.
+func (r *Reactor) SetDiscoveryTime(discoveryTime time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.cfg.DiscoveryTime = discoveryTime
+	if r.syncer != nil {
+		r.syncer.SetDiscoveryTime(discoveryTime)
+	}
+}
+
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
@@ -252,7 +331,7 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		r.mtx.Unlock()
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
-	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.cfg.TempDir)
 	r.mtx.Unlock()
 
 	// Request snapshots from all currently connected peers
@@ -9,10 +9,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/p2p"
 	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
+	smmocks "github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestReactor_Receive_ChunkRequest(t *testing.T) {
@@ -60,7 +64,7 @@ func TestReactor_Receive_ChunkRequest(t *testing.T) {
 			}
 
 			// Start a reactor and send a ssproto.ChunkRequest, then wait for and check response
-			r := NewReactor(conn, nil, "")
+			r := NewReactor(*config.DefaultStateSyncConfig(), conn, nil, nil, nil)
 			err := r.Start()
 			require.NoError(t, err)
 			t.Cleanup(func() {
@@ -83,8 +87,10 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 	testcases := map[string]struct {
 		snapshots       []*abci.Snapshot
 		expectResponses []*ssproto.SnapshotsResponse
+		interval        uint64
+		keepRecent      uint64
 	}{
-		"no snapshots": {nil, []*ssproto.SnapshotsResponse{}},
+		"no snapshots": {nil, []*ssproto.SnapshotsResponse{}, 0, 0},
 		">10 unordered snapshots": {
 			[]*abci.Snapshot{
 				{Height: 1, Format: 2, Chunks: 7, Hash: []byte{1, 2}, Metadata: []byte{1}},
@@ -112,6 +118,23 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 				{Height: 1, Format: 4, Chunks: 7, Hash: []byte{1, 4}, Metadata: []byte{7}},
 				{Height: 1, Format: 3, Chunks: 7, Hash: []byte{1, 3}, Metadata: []byte{10}},
 			},
+			0, 0,
+		},
+		"snapshot policy": {
+			[]*abci.Snapshot{
+				{Height: 5, Format: 1, Chunks: 7, Hash: []byte{5, 1}},
+				{Height: 6, Format: 1, Chunks: 7, Hash: []byte{6, 1}},
+				{Height: 10, Format: 1, Chunks: 7, Hash: []byte{10, 1}},
+				{Height: 15, Format: 1, Chunks: 7, Hash: []byte{15, 1}},
+				{Height: 15, Format: 2, Chunks: 7, Hash: []byte{15, 2}},
+				{Height: 17, Format: 1, Chunks: 7, Hash: []byte{17, 1}},
+			},
+			[]*ssproto.SnapshotsResponse{
+				{Height: 15, Format: 2, Chunks: 7, Hash: []byte{15, 2}},
+				{Height: 15, Format: 1, Chunks: 7, Hash: []byte{15, 1}},
+				{Height: 10, Format: 1, Chunks: 7, Hash: []byte{10, 1}},
+			},
+			5, 2,
 		},
 	}
 
@@ -137,7 +160,10 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 			}
 
 			// Start a reactor and send a SnapshotsRequestMessage, then wait for and check responses
-			r := NewReactor(conn, nil, "")
+			cfg := config.DefaultStateSyncConfig()
+			cfg.SnapshotInterval = tc.interval
+			cfg.SnapshotKeepRecent = tc.keepRecent
+			r := NewReactor(*cfg, conn, nil, nil, nil)
 			err := r.Start()
 			require.NoError(t, err)
 			t.Cleanup(func() {
@@ -155,3 +181,107 @@ func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
 		})
 	}
 }
+
+// lightBlockStore is a block store of the blocks between base and height, the
+// seen commit is told apart from the canonical one by its round.
+type lightBlockStore struct {
+	sm.BlockStore
+	base, height int64
+}
+
+func (bs lightBlockStore) Base() int64   { return bs.base }
+func (bs lightBlockStore) Height() int64 { return bs.height }
+
+func (bs lightBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return &types.BlockMeta{Header: types.Header{ChainID: "test", Height: height}}
+}
+
+func (bs lightBlockStore) LoadBlockCommit(height int64) *types.Commit {
+	return &types.Commit{Height: height, Round: 1}
+}
+
+func (bs lightBlockStore) LoadSeenCommit(height int64) *types.Commit {
+	return &types.Commit{Height: height, Round: 2}
+}
+
+func TestReactor_Receive_LightBlockRequest(t *testing.T) {
+	vals, _ := types.GenerateValidatorSet(4)
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", mock.Anything).Return(vals, nil)
+
+	testcases := map[string]struct {
+		height      uint64
+		expectRound int32
+		expectFound bool
+	}{
+		"canonical commit": {2, 1, true},
+		"latest":           {0, 2, true},
+		"seen commit":      {3, 2, true},
+		"pruned":           {1, 0, false},
+		"too high":         {4, 0, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			peer := &p2pmocks.Peer{}
+			peer.On("ID").Return(p2p.ID("id"))
+			var response *ssproto.LightBlockResponse
+			peer.On("Send", LightBlockChannel, mock.Anything).Run(func(args mock.Arguments) {
+				msg, err := decodeMsg(args[1].([]byte))
+				require.NoError(t, err)
+				response = msg.(*ssproto.LightBlockResponse)
+			}).Return(true)
+
+			r := NewReactor(*config.DefaultStateSyncConfig(), nil, nil, stateStore, lightBlockStore{base: 2, height: 3})
+			require.NoError(t, r.Start())
+			t.Cleanup(func() {
+				if err := r.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			r.Receive(LightBlockChannel, peer, mustEncodeMsg(&ssproto.LightBlockRequest{Height: tc.height}))
+			require.NotNil(t, response)
+			assert.Equal(t, tc.height, response.Height)
+			if !tc.expectFound {
+				assert.Nil(t, response.LightBlock)
+				return
+			}
+			lightBlock, err := types.LightBlockFromProto(response.LightBlock)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectRound, lightBlock.Commit.Round)
+			assert.Equal(t, lightBlock.Header.Height, lightBlock.Commit.Height)
+			assert.Equal(t, vals.Hash(), lightBlock.ValidatorSet.Hash())
+		})
+	}
+}
+
+func TestReactor_Receive_LightBlockRequest_RateLimit(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	cfg.LightBlockRequestsPerSecond = 0.001
+	cfg.LightBlockRequestsBurst = 2
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	responses := 0
+	peer.On("Send", LightBlockChannel, mock.Anything).Run(func(args mock.Arguments) {
+		responses++
+	}).Return(true)
+
+	r := NewReactor(*cfg, nil, nil, &smmocks.Store{}, lightBlockStore{base: 2, height: 3})
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the requests above the burst are dropped, until the peer reconnects
+	for i := 0; i < 3; i++ {
+		r.Receive(LightBlockChannel, peer, mustEncodeMsg(&ssproto.LightBlockRequest{Height: 10}))
+	}
+	assert.Equal(t, 2, responses)
+	r.RemovePeer(peer, nil)
+	r.Receive(LightBlockChannel, peer, mustEncodeMsg(&ssproto.LightBlockRequest{Height: 10}))
+	assert.Equal(t, 3, responses)
+}
@@ -32,6 +32,10 @@ type StateProvider interface {
 	Commit(ctx context.Context, height uint64) (*types.Commit, error)
 	// State returns a state object at the given height.
 	State(ctx context.Context, height uint64) (sm.State, error)
+	// StateSignature returns the commit, whose threshold state signature signs the app hash
+	// after the given height, i.e. the commit of the next height, and the validator set which
+	// signed it.
+	StateSignature(ctx context.Context, height uint64) (*types.Commit, *types.ValidatorSet, error)
 }
 
 // clientStateProvider is a state provider using the light client.
@@ -123,6 +127,23 @@ func (s *clientStateProvider) Commit(ctx context.Context, height uint64) (*types
 	return header.Commit, nil
 }
 
+// StateSignature implements StateProvider.
+func (s *clientStateProvider) StateSignature(
+	ctx context.Context,
+	height uint64,
+) (*types.Commit, *types.ValidatorSet, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	// The state ID signed by the commit of the next height contains the app hash after the
+	// given height.
+	lightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return lightBlock.Commit, lightBlock.ValidatorSet, nil
+}
+
 // State implements StateProvider.
 func (s *clientStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
 	s.Lock()
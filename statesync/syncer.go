@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
@@ -22,8 +24,6 @@ const (
 	chunkFetchers = 4
 	// chunkTimeout is the timeout while waiting for the next chunk from the chunk queue.
 	chunkTimeout = 2 * time.Minute
-	// requestTimeout is the timeout before rerequesting a chunk, possibly from a different peer.
-	chunkRequestTimeout = 10 * time.Second
 )
 
 var (
@@ -49,6 +49,7 @@ var (
 // sync all snapshots in the pool (pausing to discover new ones), or Sync() to sync a specific
 // snapshot. Snapshots and chunks are fed via AddSnapshot() and AddChunk() as appropriate.
 type syncer struct {
+	cfg           config.StateSyncConfig
 	logger        log.Logger
 	stateProvider StateProvider
 	conn          proxy.AppConnSnapshot
@@ -56,14 +57,16 @@ type syncer struct {
 	snapshots     *snapshotPool
 	tempDir       string
 
-	mtx    tmsync.RWMutex
-	chunks *chunkQueue
+	mtx           tmsync.RWMutex
+	chunks        *chunkQueue
+	discoveryTime time.Duration
 }
 
 // newSyncer creates a new syncer.
-func newSyncer(logger log.Logger, conn proxy.AppConnSnapshot, connQuery proxy.AppConnQuery,
-	stateProvider StateProvider, tempDir string) *syncer {
+func newSyncer(cfg config.StateSyncConfig, logger log.Logger, conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery, stateProvider StateProvider, tempDir string) *syncer {
 	return &syncer{
+		cfg:           cfg,
 		logger:        logger,
 		stateProvider: stateProvider,
 		conn:          conn,
@@ -116,6 +119,20 @@ func (s *syncer) AddPeer(peer p2p.Peer) {
 	peer.Send(SnapshotChannel, mustEncodeMsg(&ssproto.SnapshotsRequest{}))
 }
 
+// SetDiscoveryTime changes the time SyncAny waits to discover further snapshots, starting with the
+// next time it waits.
+func (s *syncer) SetDiscoveryTime(discoveryTime time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.discoveryTime = discoveryTime
+}
+
+func (s *syncer) getDiscoveryTime() time.Duration {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.discoveryTime
+}
+
 // RemovePeer removes a peer from the pool.
 func (s *syncer) RemovePeer(peer p2p.Peer) {
 	s.logger.Debug("Removing peer from sync", "peer", peer.ID())
@@ -124,8 +141,10 @@ func (s *syncer) RemovePeer(peer p2p.Peer) {
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
 // snapshots if none were found and discoveryTime > 0. It returns the latest state and block commit
-// which the caller must use to bootstrap the node.
+// which the caller must use to bootstrap the node. The discovery time can be changed with
+// SetDiscoveryTime while syncing.
 func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit, error) {
+	s.SetDiscoveryTime(discoveryTime)
 	if discoveryTime > 0 {
 		s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
 		time.Sleep(discoveryTime)
@@ -145,6 +164,7 @@ func (s *syncer) SyncAny(discoveryTime time.Duration) (sm.State, *types.Commit,
 			chunks = nil
 		}
 		if snapshot == nil {
+			discoveryTime := s.getDiscoveryTime()
 			if discoveryTime == 0 {
 				return sm.State{}, nil, errNoSnapshots
 			}
@@ -251,6 +271,10 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	if err != nil {
 		return sm.State{}, nil, fmt.Errorf("failed to fetch commit: %w", err)
 	}
+	stateCommit, stateVals, err := s.stateProvider.StateSignature(pctx, snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("failed to fetch state signature: %w", err)
+	}
 
 	// Restore snapshot
 	err = s.applyChunks(chunks)
@@ -265,6 +289,12 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 	state.Version.Consensus.App = appVersion
 
+	// Verify the restored app hash against the state ID signed by the quorum
+	err = s.verifyStateSignature(snapshot, state.ChainID, stateCommit, stateVals)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+
 	// Done! 🎉
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
 		"hash", snapshot.Hash)
@@ -389,28 +419,64 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
-		ticker := time.NewTicker(chunkRequestTimeout)
-		defer ticker.Stop()
-		s.requestChunk(snapshot, index)
+		if !s.fetchChunk(ctx, snapshot, chunks, index) {
+			return
+		}
+	}
+}
+
+// fetchChunk requests a chunk until it arrives, re-requesting it from another peer of the
+// snapshot on each timeout. Once the retries are exhausted, the chunk is marked as failed in the
+// queue. It returns false if the context is cancelled.
+func (s *syncer) fetchChunk(ctx context.Context, snapshot *snapshot, chunks *chunkQueue, index uint32) bool {
+	requested := make(map[p2p.ID]bool)
+	for retry := 0; ; retry++ {
+		if peer := s.requestChunk(snapshot, index, requested); peer != nil {
+			requested[peer.ID()] = true
+		}
+
+		timer := time.NewTimer(s.cfg.ChunkRequestTimeout)
 		select {
 		case <-chunks.WaitFor(index):
-		case <-ticker.C:
-			s.requestChunk(snapshot, index)
+			timer.Stop()
+			return true
 		case <-ctx.Done():
-			return
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+
+		if retry >= s.cfg.MaxChunkRetries {
+			s.logger.Error("Failed to fetch snapshot chunk, giving up", "height", snapshot.Height,
+				"format", snapshot.Format, "chunk", index, "retries", retry)
+			chunks.Fail(index)
+			return true
 		}
-		ticker.Stop()
+		s.logger.Info("Timed out fetching snapshot chunk, retrying", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "retry", retry+1)
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
-	if peer == nil {
+// requestChunk requests a chunk from a peer of the snapshot, preferring the peers which haven't
+// been requested the chunk yet. It returns the peer, or nil if the snapshot has no peers.
+func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32, requested map[p2p.ID]bool) p2p.Peer {
+	peers := s.snapshots.GetPeers(snapshot)
+	if len(peers) == 0 {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
-		return
+		return nil
+	}
+	candidates := make([]p2p.Peer, 0, len(peers))
+	for _, peer := range peers {
+		if !requested[peer.ID()] {
+			candidates = append(candidates, peer)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = peers
 	}
+	peer := candidates[rand.Intn(len(candidates))] // nolint:gosec // G404: Use of weak random number generator
+
 	s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height,
 		"format", snapshot.Format, "chunk", chunk, "peer", peer.ID())
 	peer.Send(ChunkChannel, mustEncodeMsg(&ssproto.ChunkRequest{
@@ -418,6 +484,7 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 		Format: snapshot.Format,
 		Index:  chunk,
 	}))
+	return peer
 }
 
 // verifyApp verifies the sync, checking the app hash and last block height. It returns the
@@ -448,3 +515,39 @@ func (s *syncer) verifyApp(snapshot *snapshot) (uint64, error) {
 	s.logger.Info("Verified ABCI app", "height", snapshot.Height, "appHash", snapshot.trustedAppHash)
 	return resp.AppVersion, nil
 }
+
+// verifyStateSignature verifies the app hash of the snapshot, which the app was verified against,
+// against the state ID signed by the commit of the next height with the threshold public key of
+// the quorum.
+func (s *syncer) verifyStateSignature(snapshot *snapshot, chainID string, commit *types.Commit,
+	vals *types.ValidatorSet) error {
+	if commit == nil || vals == nil {
+		s.logger.Error("State signature verification failed: no commit or validator set",
+			"height", snapshot.Height)
+		return errVerifyFailed
+	}
+	if commit.Height != int64(snapshot.Height)+1 {
+		s.logger.Error("State signature verification failed: unexpected commit height",
+			"expected", snapshot.Height+1, "actual", commit.Height)
+		return errVerifyFailed
+	}
+	if !bytes.Equal(commit.StateID.LastAppHash, snapshot.trustedAppHash) {
+		s.logger.Error("State signature verification failed: app hash doesn't match the signed state ID",
+			"expected", commit.StateID.LastAppHash, "actual", snapshot.trustedAppHash)
+		return errVerifyFailed
+	}
+	if !bytes.Equal(commit.QuorumHash, vals.QuorumHash) {
+		s.logger.Error("State signature verification failed: unexpected quorum",
+			"expected", vals.QuorumHash, "actual", commit.QuorumHash)
+		return errVerifyFailed
+	}
+	signID := commit.CanonicalVoteStateSignId(chainID, vals.QuorumType, vals.QuorumHash)
+	if !vals.ThresholdPublicKey.VerifySignatureDigest(signID, commit.ThresholdStateSignature) {
+		s.logger.Error("State signature verification failed: invalid threshold state signature",
+			"height", commit.Height, "signature", commit.ThresholdStateSignature)
+		return errVerifyFailed
+	}
+
+	s.logger.Info("Verified state signature", "height", snapshot.Height, "appHash", snapshot.trustedAppHash)
+	return nil
+}
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"context"
 	"errors"
 	"github.com/tendermint/tendermint/crypto"
 	"testing"
@@ -11,12 +12,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
 	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/proxy"
 	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
@@ -32,10 +37,25 @@ func setupOfferSyncer(t *testing.T) (*syncer, *proxymocks.AppConnSnapshot) {
 	connSnapshot := &proxymocks.AppConnSnapshot{}
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 	return syncer, connSnapshot
 }
 
+// Makes the commit of the next height, which signs the state ID with the app hash, and the
+// validator set which signed it
+func makeStateSignature(t *testing.T, chainID string, height int64,
+	appHash []byte) (*types.Commit, *types.ValidatorSet) {
+	vals, privVals := types.GenerateValidatorSet(4)
+	blockID := types.BlockID{
+		Hash:          tmrand.Bytes(tmhash.Size),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(tmhash.Size)},
+	}
+	voteSet := types.NewVoteSet(chainID, height+1, 0, tmproto.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, types.StateID{LastAppHash: appHash}, height+1, 0, voteSet, privVals)
+	require.NoError(t, err)
+	return commit, vals
+}
+
 // Sets up a simple peer mock with an ID
 func simplePeer(id string) *p2pmocks.Peer {
 	peer := &p2pmocks.Peer{}
@@ -60,7 +80,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 		LastStateID:     types.StateID{LastAppHash: []byte("statehash")},
 		LastBlockTime:   time.Now(),
 		LastResultsHash: []byte("last_results_hash"),
-		AppHash:         []byte("app_hash"),
+		AppHash:         tmrand.Bytes(tmhash.Size),
 
 		LastValidators: &types.ValidatorSet{Proposer: &types.Validator{ProTxHash: crypto.Sha256([]byte("val1"))}},
 		Validators:     &types.ValidatorSet{Proposer: &types.Validator{ProTxHash: crypto.Sha256([]byte("val2"))}},
@@ -70,6 +90,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 		LastHeightConsensusParamsChanged: 1,
 	}
 	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	stateCommit, stateVals := makeStateSignature(t, state.ChainID, 1, state.AppHash)
 
 	chunks := []*chunk{
 		{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 1, 0}},
@@ -83,10 +104,11 @@ func TestSyncer_SyncAny(t *testing.T) {
 	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return([]byte("app_hash_2"), nil)
 	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
 	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+	stateProvider.On("StateSignature", mock.Anything, uint64(1)).Return(stateCommit, stateVals, nil)
 	connSnapshot := &proxymocks.AppConnSnapshot{}
 	connQuery := &proxymocks.AppConnQuery{}
 
-	syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 
 	// Adding a chunk should error when no sync is in progress
 	_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}})
@@ -141,7 +163,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 			Metadata:              s.Metadata,
 			CoreChainLockedHeight: s.CoreChainLockedHeight,
 		},
-		AppHash: []byte("app_hash"),
+		AppHash: state.AppHash,
 	}).Times(2).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
 
 	chunkRequests := make(map[uint32]int)
@@ -189,7 +211,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 		AppVersion:                9,
 		LastBlockHeight:           1,
 		LastCoreChainLockedHeight: 1,
-		LastBlockAppHash:          []byte("app_hash"),
+		LastBlockAppHash:          state.AppHash,
 	}, nil)
 
 	newState, lastCommit, err := syncer.SyncAny(0)
@@ -414,7 +436,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 
 			body := []byte{1, 2, 3}
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, "")
@@ -465,7 +487,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 
 			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 3}, "")
 			require.NoError(t, err)
@@ -528,7 +550,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
 			stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 
 			// Set up three peers across two snapshots, and ask for one of them to be banned.
 			// It should be banned from all snapshots.
@@ -609,6 +631,68 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 	}
 }
 
+func TestSyncer_fetchChunks_fallback(t *testing.T) {
+	connQuery := &proxymocks.AppConnQuery{}
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+	cfg := *config.DefaultStateSyncConfig()
+	cfg.ChunkRequestTimeout = 100 * time.Millisecond
+	cfg.MaxChunkRetries = 2
+	syncer := newSyncer(cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}
+	chunks, err := newChunkQueue(s, "")
+	require.NoError(t, err)
+	defer chunks.Close()
+
+	// Peer a is dead, so every chunk must be served by peer b, and chunk 1 isn't served at all
+	requestsMtx := tmsync.Mutex{}
+	requests := make(map[uint32][]p2p.ID)
+	onChunkRequest := func(peer p2p.Peer) func(mock.Arguments) {
+		return func(args mock.Arguments) {
+			pb, err := decodeMsg(args[1].([]byte))
+			require.NoError(t, err)
+			msg := pb.(*ssproto.ChunkRequest)
+			requestsMtx.Lock()
+			requests[msg.Index] = append(requests[msg.Index], peer.ID())
+			requestsMtx.Unlock()
+			if peer.ID() == "b" && msg.Index == 0 {
+				_, err := chunks.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{0}, Sender: peer.ID()})
+				require.NoError(t, err)
+			}
+		}
+	}
+	peerA := simplePeer("a")
+	peerA.On("Send", ChunkChannel, mock.Anything).Run(onChunkRequest(peerA)).Return(true)
+	peerB := simplePeer("b")
+	peerB.On("Send", ChunkChannel, mock.Anything).Run(onChunkRequest(peerB)).Return(true)
+	_, err = syncer.AddSnapshot(peerA, s)
+	require.NoError(t, err)
+	_, err = syncer.AddSnapshot(peerB, s)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go syncer.fetchChunks(ctx, s, chunks)
+
+	c, err := chunks.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, c.Index)
+	assert.EqualValues(t, "b", c.Sender)
+
+	// The chunk is requested from both peers before giving up on it
+	_, err = chunks.Next()
+	assert.Equal(t, errTimeout, err)
+	requestsMtx.Lock()
+	defer requestsMtx.Unlock()
+	require.NotEmpty(t, requests[0])
+	assert.LessOrEqual(t, len(requests[0]), 2, "chunk 0 should be requested from b at most second")
+	assert.EqualValues(t, "b", requests[0][len(requests[0])-1])
+	require.Len(t, requests[1], 3)
+	assert.ElementsMatch(t, []p2p.ID{"a", "b"}, requests[1][:2])
+}
+
 func TestSyncer_verifyApp(t *testing.T) {
 	boom := errors.New("boom")
 	s := &snapshot{Height: 3, CoreChainLockedHeight: 10, Format: 1, Chunks: 5, Hash: []byte{1, 2, 3},
@@ -643,7 +727,7 @@ func TestSyncer_verifyApp(t *testing.T) {
 			connQuery := &proxymocks.AppConnQuery{}
 			connSnapshot := &proxymocks.AppConnSnapshot{}
 			stateProvider := &mocks.StateProvider{}
-			syncer := newSyncer(log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+			syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 
 			connQuery.On("InfoSync", proxy.RequestInfo).Return(tc.response, tc.err)
 			version, err := syncer.verifyApp(s)
@@ -659,6 +743,86 @@ func TestSyncer_verifyApp(t *testing.T) {
 	}
 }
 
+func TestSyncer_verifyStateSignature(t *testing.T) {
+	appHash := tmrand.Bytes(tmhash.Size)
+	s := &snapshot{Height: 3, CoreChainLockedHeight: 10, Format: 1, Chunks: 5, Hash: []byte{1, 2, 3},
+		trustedAppHash: appHash}
+	commit, vals := makeStateSignature(t, "chain", 3, appHash)
+	otherCommit, otherVals := makeStateSignature(t, "chain", 3, tmrand.Bytes(tmhash.Size))
+	nextCommit, nextVals := makeStateSignature(t, "chain", 4, appHash)
+	_, thresholdVals := makeStateSignature(t, "chain", 3, appHash)
+	thresholdVals.QuorumHash = vals.QuorumHash
+
+	testcases := map[string]struct {
+		commit    *types.Commit
+		vals      *types.ValidatorSet
+		expectErr error
+	}{
+		"verified":          {commit, vals, nil},
+		"other app hash":    {otherCommit, otherVals, errVerifyFailed},
+		"other height":      {nextCommit, nextVals, errVerifyFailed},
+		"other quorum":      {commit, otherVals, errVerifyFailed},
+		"invalid signature": {commit, thresholdVals, errVerifyFailed},
+		"no commit":         {nil, vals, errVerifyFailed},
+		"no validator set":  {commit, nil, errVerifyFailed},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			connQuery := &proxymocks.AppConnQuery{}
+			connSnapshot := &proxymocks.AppConnSnapshot{}
+			stateProvider := &mocks.StateProvider{}
+			syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+
+			err := syncer.verifyStateSignature(s, "chain", tc.commit, tc.vals)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+// The peer serves a tampered snapshot, whose app hash matches the trusted app hash, but not the
+// state ID signed by the quorum, and the sync is refused
+func TestSyncer_SyncAny_tamperedSnapshot(t *testing.T) {
+	state := sm.State{ChainID: "chain"}
+	tamperedAppHash := tmrand.Bytes(tmhash.Size)
+	stateCommit, stateVals := makeStateSignature(t, state.ChainID, 1, tmrand.Bytes(tmhash.Size))
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(tamperedAppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(&types.Commit{}, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+	stateProvider.On("StateSignature", mock.Anything, uint64(1)).Return(stateCommit, stateVals, nil)
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+	syncer := newSyncer(*config.DefaultStateSyncConfig(), log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+
+	s := &snapshot{Height: 1, CoreChainLockedHeight: 1, Format: 1, Chunks: 1, Hash: []byte{1, 2, 3}}
+	peer := simplePeer("a")
+	_, err := syncer.AddSnapshot(peer, s)
+	require.NoError(t, err)
+	peer.On("Send", ChunkChannel, mock.Anything).Maybe().Run(func(args mock.Arguments) {
+		_, err := syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}})
+		require.NoError(t, err)
+	}).Return(true)
+
+	connSnapshot.On("OfferSnapshotSync", abci.RequestOfferSnapshot{
+		Snapshot: toABCI(s), AppHash: tamperedAppHash,
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunkSync", abci.RequestApplySnapshotChunk{
+		Index: 0, Chunk: []byte{1},
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	connQuery.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		LastBlockHeight:           1,
+		LastCoreChainLockedHeight: 1,
+		LastBlockAppHash:          tamperedAppHash,
+	}, nil)
+
+	_, _, err = syncer.SyncAny(0)
+	assert.True(t, errors.Is(err, errVerifyFailed), err)
+	connSnapshot.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+}
+
 func toABCI(s *snapshot) *abci.Snapshot {
 	return &abci.Snapshot{
 		Height:                s.Height,
@@ -1,8 +1,10 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gogo/protobuf/proto"
 	dbm "github.com/tendermint/tm-db"
@@ -330,6 +332,114 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+// RollbackBlocks deletes the blocks above the given height, which becomes the
+// latest height of the store, and returns the number of blocks deleted. The
+// height can't be below the base, the store can't be emptied.
+func (bs *BlockStore) RollbackBlocks(height int64) (uint64, error) {
+	bs.mtx.RLock()
+	base, latest := bs.base, bs.height
+	bs.mtx.RUnlock()
+	if height < base || base == 0 {
+		return 0, fmt.Errorf("cannot roll back to height %v, it is lower than base height %v", height, base)
+	}
+	if height > latest {
+		return 0, fmt.Errorf("cannot roll back to height %v beyond the latest height %v", height, latest)
+	}
+
+	// Update the height first to make sure noone tries to access the deleted
+	// blocks. The keys left behind by an interrupted rollback are orphans (see
+	// PruneOrphans).
+	bs.mtx.Lock()
+	bs.height = height
+	bs.mtx.Unlock()
+	bs.saveState()
+
+	rolledBack := uint64(0)
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	for h := height + 1; h <= latest; h++ {
+		meta := bs.LoadBlockMeta(h)
+		if meta == nil { // assume already deleted
+			continue
+		}
+		if err := batch.Delete(calcBlockMetaKey(h)); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(calcBlockHashKey(meta.BlockID.Hash)); err != nil {
+			return 0, err
+		}
+		// the commit of the previous block is saved with the block
+		if err := batch.Delete(calcBlockCommitKey(h - 1)); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
+			return 0, err
+		}
+		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
+			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
+				return 0, err
+			}
+		}
+		rolledBack++
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, fmt.Errorf("failed to roll back to height %v: %w", height, err)
+	}
+	return rolledBack, nil
+}
+
+// PruneOrphans deletes the keys of the heights outside of the stored blocks,
+// which are left behind by interrupted writes (see SaveBlock) and prunes (see
+// PruneBlocks). It iterates over the whole database, so it's meant to be run
+// offline, e.g. by the experimental-compact command. It returns the number of
+// keys deleted. An empty block store is left as is, since state sync saves the
+// seen commit of the snapshot before any block.
+func (bs *BlockStore) PruneOrphans() (uint64, error) {
+	base, height := bs.Base(), bs.Height()
+	if height == 0 {
+		return 0, nil
+	}
+
+	// The keys are collected first, since not every backend allows to write
+	// while iterating.
+	iter, err := bs.db.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	var orphans [][]byte
+	for ; iter.Valid(); iter.Next() {
+		if h, ok := keyHeight(iter.Key(), iter.Value()); ok && (h < base || h > height) {
+			orphans = append(orphans, append([]byte(nil), iter.Key()...))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		iter.Close()
+		return 0, err
+	}
+	iter.Close()
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	for i, key := range orphans {
+		if err := batch.Delete(key); err != nil {
+			return 0, err
+		}
+		// flush every 1000 keys to avoid batches becoming too large
+		if (i+1)%1000 == 0 {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Close()
+			batch = bs.db.NewBatch()
+			defer batch.Close()
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	return uint64(len(orphans)), nil
+}
+
 // SaveBlock persists the given block, blockParts, and seenCommit to the underlying db.
 // blockParts: Must be parts of the block
 // seenCommit: The +2/3 precommits that were seen which committed at height.
@@ -454,6 +564,33 @@ func calcBlockHashKey(hash []byte) []byte {
 	return []byte(fmt.Sprintf("BH:%x", hash))
 }
 
+// keyHeight returns the height of the block the key belongs to, if any.
+func keyHeight(key, value []byte) (int64, bool) {
+	var s string
+	switch {
+	case bytes.HasPrefix(key, []byte("H:")):
+		s = string(key[2:])
+	case bytes.HasPrefix(key, []byte("P:")):
+		s = string(key[2:])
+		if i := strings.IndexByte(s, ':'); i >= 0 {
+			s = s[:i]
+		}
+	case bytes.HasPrefix(key, []byte("C:")):
+		s = string(key[2:])
+	case bytes.HasPrefix(key, []byte("SC:")):
+		s = string(key[3:])
+	case bytes.HasPrefix(key, []byte("BH:")):
+		s = string(value)
+	default:
+		return 0, false
+	}
+	height, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return height, true
+}
+
 //-----------------------------------------------------------------------------
 
 var blockStoreKey = []byte("blockStore")
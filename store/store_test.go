@@ -530,6 +530,130 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestPruneOrphans(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+
+	// the seen commit saved by state sync is kept in an empty store
+	require.NoError(t, bs.SaveSeenCommit(10, makeTestCommit(10, tmtime.Now())))
+	pruned, err := bs.PruneOrphans()
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+	require.NotNil(t, bs.LoadSeenCommit(10))
+
+	for h := int64(1); h <= 20; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+	_, err = bs.PruneBlocks(5)
+	require.NoError(t, err)
+
+	// left behind by an interrupted prune, and an interrupted write of the next block
+	block := makeBlock(21, state, new(types.Commit))
+	orphans := [][]byte{calcBlockMetaKey(3), calcBlockPartKey(3, 0), calcSeenCommitKey(3),
+		calcBlockPartKey(21, 0), calcBlockMetaKey(21), calcBlockHashKey(block.Hash())}
+	for _, key := range orphans[:len(orphans)-1] {
+		require.NoError(t, db.Set(key, []byte("orphan")))
+	}
+	require.NoError(t, db.Set(calcBlockHashKey(block.Hash()), []byte("21")))
+
+	// the commit of height 0 saved with the first block is never pruned by PruneBlocks
+	orphans = append(orphans, calcBlockCommitKey(0))
+
+	pruned, err = bs.PruneOrphans()
+	require.NoError(t, err)
+	assert.EqualValues(t, len(orphans), pruned)
+	for _, key := range orphans {
+		ok, err := db.Has(key)
+		require.NoError(t, err)
+		assert.False(t, ok, string(key))
+	}
+
+	for h := int64(5); h <= 20; h++ {
+		require.NotNil(t, bs.LoadBlock(h), "height %d", h)
+	}
+}
+
+func TestRollbackBlocks(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+
+	// rolling back an empty store should error
+	_, err = bs.RollbackBlocks(0)
+	require.Error(t, err)
+
+	blocks := make(map[int64]*types.Block)
+	for h := int64(1); h <= 20; h++ {
+		block := makeBlock(h, state, makeTestCommit(h-1, tmtime.Now()))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+		blocks[h] = block
+	}
+	_, err = bs.PruneBlocks(5)
+	require.NoError(t, err)
+	_, err = bs.PruneOrphans()
+	require.NoError(t, err)
+
+	// rolling back below the base or beyond the height should error
+	_, err = bs.RollbackBlocks(4)
+	require.Error(t, err)
+	_, err = bs.RollbackBlocks(21)
+	require.Error(t, err)
+
+	rolledBack, err := bs.RollbackBlocks(10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, rolledBack)
+	assert.EqualValues(t, 5, bs.Base())
+	assert.EqualValues(t, 10, bs.Height())
+	assert.EqualValues(t, tmstore.BlockStoreState{
+		Base:   5,
+		Height: 10,
+	}, LoadBlockStoreState(db))
+
+	for h := int64(5); h <= 10; h++ {
+		require.NotNil(t, bs.LoadBlock(h), "height %d", h)
+		require.NotNil(t, bs.LoadSeenCommit(h), "height %d", h)
+	}
+	// the commit of the latest block is saved with the next block
+	require.NotNil(t, bs.LoadBlockCommit(9))
+	require.Nil(t, bs.LoadBlockCommit(10))
+	for h := int64(11); h <= 20; h++ {
+		require.Nil(t, bs.LoadBlock(h), "height %d", h)
+		require.Nil(t, bs.LoadBlockMeta(h), "height %d", h)
+		require.Nil(t, bs.LoadBlockPart(h, 0), "height %d", h)
+		require.Nil(t, bs.LoadSeenCommit(h), "height %d", h)
+		require.Nil(t, bs.LoadBlockByHash(blocks[h].Hash()), "height %d", h)
+	}
+
+	// nothing is left behind
+	pruned, err := bs.PruneOrphans()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+
+	// the blocks above the height can be saved again
+	block := makeBlock(11, state, makeTestCommit(10, tmtime.Now()))
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(11, tmtime.Now()))
+	assert.EqualValues(t, 11, bs.Height())
+
+	// rolling back to the current height and to the base should work
+	rolledBack, err = bs.RollbackBlocks(11)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, rolledBack)
+	rolledBack, err = bs.RollbackBlocks(5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, rolledBack)
+	assert.EqualValues(t, 1, bs.Size())
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
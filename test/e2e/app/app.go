@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/tendermint/tendermint/crypto"
 
@@ -24,9 +26,16 @@ import (
 	"github.com/tendermint/tendermint/version"
 )
 
+// invalidTxKeyPrefix marks transactions which are rejected in CheckTx and
+// ProcessProposal unless the app is configured with invalid_proposals.
+const invalidTxKeyPrefix = "invalid-"
+
+// snapshotsQueryPath is the Query path listing the heights of the snapshots.
+const snapshotsQueryPath = "/snapshots"
+
 // Application is an ABCI application for use by end-to-end tests. It is a
 // simple key/value store for strings, storing data in memory and persisting
-// to disk as JSON, taking state sync snapshots if requested.
+// to disk as JSON, taking state sync snapshots when scheduled by Tenderdash.
 type Application struct {
 	abci.BaseApplication
 	logger          log.Logger
@@ -35,6 +44,10 @@ type Application struct {
 	cfg             *Config
 	restoreSnapshot *abci.Snapshot
 	restoreChunks   [][]byte
+
+	// the snapshot scheduled by Tenderdash in EndBlock, taken in Commit
+	takeSnapshot         bool
+	snapshotRetainHeight uint64
 }
 
 // NewApplication creates the application.
@@ -43,10 +56,13 @@ func NewApplication(cfg *Config) (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	snapshots, err := NewSnapshotStore(filepath.Join(cfg.Dir, "snapshots"))
+	snapshots, err := NewSnapshotStore(filepath.Join(cfg.Dir, "snapshots"), cfg.SnapshotChunkSize)
 	if err != nil {
 		return nil, err
 	}
+	if coreServer != nil {
+		coreServer.SetHeight(int64(state.Height))
+	}
 	return &Application{
 		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
 		state:     state,
@@ -63,6 +79,8 @@ func (app *Application) Info(req abci.RequestInfo) abci.ResponseInfo {
 		LastBlockHeight:           int64(app.state.Height),
 		LastBlockAppHash:          app.state.Hash,
 		LastCoreChainLockedHeight: app.state.CoreHeight,
+		SupportsProcessProposal:   true,
+		SupportsPrepareProposal:   true,
 	}
 }
 
@@ -94,16 +112,78 @@ func (app *Application) InitChain(req abci.RequestInitChain) abci.ResponseInitCh
 
 // CheckTx implements ABCI.
 func (app *Application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
-	_, _, err := parseTx(req.Tx)
+	app.logger.Debug("CheckTx", "type", req.Type, "height", req.Height, "block_time", req.BlockTime,
+		"next_proposer", fmt.Sprintf("%X", req.NextProposerProTxHash))
+	// a new tx is executed at the height after the last committed block, while
+	// a recheck may be sent after the next block was committed
+	app.state.RLock()
+	lastHeight := app.state.Height
+	app.state.RUnlock()
+	if req.Type == abci.CheckTxType_New && req.Height != 0 && lastHeight > 0 && uint64(req.Height) != lastHeight+1 {
+		app.logger.Error("Unexpected CheckTx height", "height", req.Height, "expected", lastHeight+1)
+	}
+
+	key, _, err := parseTx(req.Tx)
 	if err != nil {
 		return abci.ResponseCheckTx{
 			Code: code.CodeTypeEncodingError,
 			Log:  err.Error(),
 		}
 	}
+	if !app.cfg.InvalidProposals && strings.HasPrefix(key, invalidTxKeyPrefix) {
+		return abci.ResponseCheckTx{
+			Code: code.CodeTypeUnauthorized,
+			Log:  fmt.Sprintf("key %q is not allowed", key),
+		}
+	}
 	return abci.ResponseCheckTx{Code: code.CodeTypeOK, GasWanted: 1}
 }
 
+// PrepareProposal implements ABCI. The txs, which ProcessProposal rejects, are
+// dropped, unless the app is configured with invalid_proposals.
+func (app *Application) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	if app.cfg.InvalidProposals {
+		return abci.ResponsePrepareProposal{Txs: req.Txs}
+	}
+	txs := make([][]byte, 0, len(req.Txs))
+	for _, tx := range req.Txs {
+		if err := validateProposalTx(tx); err != nil {
+			app.logger.Info("Dropping proposal tx", "err", err)
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	return abci.ResponsePrepareProposal{Txs: txs}
+}
+
+// ProcessProposal implements ABCI.
+func (app *Application) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	if app.cfg.InvalidProposals {
+		return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
+	}
+	for _, tx := range req.Txs {
+		if err := validateProposalTx(tx); err != nil {
+			return abci.ResponseProcessProposal{
+				Status: abci.ResponseProcessProposal_REJECT,
+				Info:   err.Error(),
+			}
+		}
+	}
+	return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
+}
+
+// validateProposalTx returns an error if the tx isn't allowed in the blocks.
+func validateProposalTx(tx []byte) error {
+	key, _, err := parseTx(tx)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(key, invalidTxKeyPrefix) {
+		return fmt.Errorf("key %q is not allowed", key)
+	}
+	return nil
+}
+
 // DeliverTx implements ABCI.
 func (app *Application) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
 	key, value, err := parseTx(req.Tx)
@@ -116,6 +196,9 @@ func (app *Application) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDelive
 
 // EndBlock implements ABCI.
 func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+	app.takeSnapshot = req.TakeSnapshot
+	app.snapshotRetainHeight = req.SnapshotRetainHeight
+
 	var err error
 	resp := abci.ResponseEndBlock{}
 	resp.ValidatorSetUpdate, err = app.validatorSetUpdates(uint64(req.Height))
@@ -126,6 +209,20 @@ func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock
 	if err != nil {
 		panic(err)
 	}
+	if coreServer != nil && resp.NextCoreChainLockUpdate != nil {
+		// the proposed chain lock is the best one known by the mock Dash Core server
+		chainLock, err := types.CoreChainLockFromProto(resp.NextCoreChainLockUpdate)
+		if err != nil {
+			panic(err)
+		}
+		coreServer.SetChainLock(chainLock)
+	}
+	if req.Height == app.cfg.InvalidBlockSizeHeight {
+		// Tenderdash must drop this update instead of halting the chain
+		resp.ConsensusParamUpdates = &abci.ConsensusParams{
+			Block: &abci.BlockParams{MaxBytes: 0, MaxGas: -1},
+		}
+	}
 	resp.Events = []abci.Event{
 		{
 			Type: "val_updates",
@@ -150,13 +247,24 @@ func (app *Application) Commit() abci.ResponseCommit {
 	if err != nil {
 		panic(err)
 	}
-	if app.cfg.SnapshotInterval > 0 && height%app.cfg.SnapshotInterval == 0 {
+	if coreServer != nil {
+		// Dash Core rotates quorums with new blocks, the quorum returned at the height becomes active
+		coreServer.SetHeight(int64(height))
+	}
+	if app.takeSnapshot {
 		snapshot, err := app.snapshots.Create(app.state)
 		if err != nil {
 			panic(err)
 		}
 		app.logger.Info("Created state sync snapshot", "height", snapshot.Height)
 	}
+	if app.snapshotRetainHeight > 0 {
+		if err := app.snapshots.Prune(app.snapshotRetainHeight); err != nil {
+			panic(err)
+		}
+	}
+	app.takeSnapshot = false
+	app.snapshotRetainHeight = 0
 	retainHeight := int64(0)
 	if app.cfg.RetainBlocks > 0 {
 		retainHeight = int64(height - app.cfg.RetainBlocks + 1)
@@ -169,6 +277,9 @@ func (app *Application) Commit() abci.ResponseCommit {
 
 // Query implements ABCI.
 func (app *Application) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if req.Path == snapshotsQueryPath {
+		return app.querySnapshots()
+	}
 	return abci.ResponseQuery{
 		Height: int64(app.state.Height),
 		Key:    req.Data,
@@ -176,6 +287,26 @@ func (app *Application) Query(req abci.RequestQuery) abci.ResponseQuery {
 	}
 }
 
+// querySnapshots returns the heights of the snapshots, as a JSON array.
+func (app *Application) querySnapshots() abci.ResponseQuery {
+	snapshots, err := app.snapshots.List()
+	if err != nil {
+		panic(err)
+	}
+	heights := make([]uint64, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		heights = append(heights, snapshot.Height)
+	}
+	bz, err := json.Marshal(heights)
+	if err != nil {
+		panic(err)
+	}
+	return abci.ResponseQuery{
+		Height: int64(app.state.Height),
+		Value:  bz,
+	}
+}
+
 // ListSnapshots implements ABCI.
 func (app *Application) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
 	snapshots, err := app.snapshots.List()
@@ -187,6 +318,7 @@ func (app *Application) ListSnapshots(req abci.RequestListSnapshots) abci.Respon
 
 // LoadSnapshotChunk implements ABCI.
 func (app *Application) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	app.killAfterChunks(req)
 	chunk, err := app.snapshots.LoadChunk(req.Height, req.Format, req.Chunk)
 	if err != nil {
 		panic(err)
@@ -194,6 +326,27 @@ func (app *Application) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abc
 	return abci.ResponseLoadSnapshotChunk{Chunk: chunk}
 }
 
+// killAfterChunks kills the node, when a chunk past kill_after_chunks_percent
+// of the snapshot chunks is requested, so the snapshot must be restored from
+// the other peers.
+func (app *Application) killAfterChunks(req abci.RequestLoadSnapshotChunk) {
+	if app.cfg.KillAfterChunksPercent == 0 {
+		return
+	}
+	snapshots, err := app.snapshots.List()
+	if err != nil {
+		panic(err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Height == req.Height && snapshot.Format == req.Format &&
+			uint64(req.Chunk)*100 >= uint64(app.cfg.KillAfterChunksPercent)*uint64(snapshot.Chunks) {
+			app.logger.Info("Killing the node while serving the snapshot", "height", req.Height,
+				"chunk", req.Chunk, "chunks", snapshot.Chunks)
+			os.Exit(1)
+		}
+	}
+}
+
 // OfferSnapshot implements ABCI.
 func (app *Application) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
 	if app.restoreSnapshot != nil {
@@ -16,7 +16,8 @@ type Config struct {
 	Dir                     string
 	Mode                    string                       `toml:"mode"`
 	PersistInterval         uint64                       `toml:"persist_interval"`
-	SnapshotInterval        uint64                       `toml:"snapshot_interval"`
+	SnapshotChunkSize       uint64                       `toml:"snapshot_chunk_size"`
+	KillAfterChunksPercent  uint32                       `toml:"kill_after_chunks_percent"`
 	RetainBlocks            uint64                       `toml:"retain_blocks"`
 	ValidatorUpdates        map[string]map[string]string `toml:"validator_update"`
 	ThesholdPublicKeyUpdate map[string]string            `toml:"threshold_public_key_update"`
@@ -25,8 +26,18 @@ type Config struct {
 	PrivValServer           string                       `toml:"privval_server"`
 	PrivValKey              string                       `toml:"privval_key"`
 	PrivValState            string                       `toml:"privval_state"`
+	PrivValCoreRPCHost      string                       `toml:"privval_core_rpc_host"`
 	Misbehaviors            map[string]string            `toml:"misbehaviors"`
 	KeyType                 string                       `toml:"key_type"`
+	InvalidProposals        bool                         `toml:"invalid_proposals"`
+	InvalidBlockSizeHeight  int64                        `toml:"invalid_block_size_height"`
+	CoreRPCUsername         string                       `toml:"core_rpc_username"`
+	CoreRPCPassword         string                       `toml:"core_rpc_password"`
+	CoreServerScenario      string                       `toml:"core_server_scenario"`
+	LightProvider           string                       `toml:"light_provider"`
+	// CoreChainLockInterval advances the chain lock of the mock core server
+	// every given number of seconds, 0 disables it
+	CoreChainLockInterval int64 `toml:"core_chain_lock_interval"`
 }
 
 // LoadConfig loads the configuration from disk.
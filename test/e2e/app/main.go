@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
@@ -14,11 +15,14 @@ import (
 	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/abci/server"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	tmflags "github.com/tendermint/tendermint/libs/cli/flags"
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	lp2p "github.com/tendermint/tendermint/light/provider/p2p"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	dbs "github.com/tendermint/tendermint/light/store/db"
@@ -31,6 +35,7 @@ import (
 	"github.com/tendermint/tendermint/test/e2e/pkg/mockcoreserver"
 	mcs "github.com/tendermint/tendermint/test/maverick/consensus"
 	maverick "github.com/tendermint/tendermint/test/maverick/node"
+	"github.com/tendermint/tendermint/types"
 )
 
 var logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
@@ -40,6 +45,8 @@ var (
 	tmcfg      *config.Config
 	nodeLogger log.Logger
 	nodeKey    *p2p.NodeKey
+	// coreServer is the mock Dash Core server, the application notifies it about new heights
+	coreServer *mockcoreserver.MockCoreServer
 )
 
 func init() {
@@ -78,6 +85,15 @@ func run(configFile string) error {
 		return err
 	}
 
+	// Start mock core-server (must start before the remote signer signing with it).
+	coreSrv, err := setupCoreServer(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to setup mock core server: %w", err)
+	}
+	go func() {
+		coreSrv.Start()
+	}()
+
 	// Start remote signer (must start before node if running builtin).
 	if cfg.PrivValServer != "" {
 		if err = startSigner(cfg); err != nil {
@@ -88,15 +104,6 @@ func run(configFile string) error {
 		}
 	}
 
-	// Start mock core-server
-	coreSrv, err := setupCoreServer(cfg)
-	if err != nil {
-		return fmt.Errorf("unable to setup mock core server: %w", err)
-	}
-	go func() {
-		coreSrv.Start()
-	}()
-
 	// Start app server.
 	switch cfg.Protocol {
 	case "socket", "grpc":
@@ -139,6 +146,13 @@ func startApp(cfg *Config) error {
 		return err
 	}
 	logger.Info(fmt.Sprintf("Server listening on %v (%v protocol)", cfg.Listen, cfg.Protocol))
+
+	// Drain the requests in flight upon SIGTERM.
+	tmos.TrapSignal(logger, func() {
+		if err := srv.Stop(); err != nil {
+			logger.Error("Error while stopping server", "err", err)
+		}
+	})
 	return nil
 }
 
@@ -174,18 +188,49 @@ func startLightClient(cfg *Config) error {
 		return err
 	}
 
-	providers := rpcEndpoints(tmcfg.P2P.PersistentPeers)
+	endpoints := rpcEndpoints(tmcfg.P2P.PersistentPeers)
 
-	c, err := light.NewHTTPClient(
-		context.Background(),
-		cfg.ChainID,
-		providers[0],
-		providers[1:],
-		dbs.New(lightDB, "light"),
-		light.Logger(nodeLogger),
-	)
-	if err != nil {
-		return err
+	var c *light.Client
+	if cfg.LightProvider == "p2p" {
+		// the light blocks are requested from the persistent peers over p2p
+		p2pNode, err := lp2p.NewNode(cfg.ChainID, nodeKey, tmcfg.P2P, nodeLogger)
+		if err != nil {
+			return err
+		}
+		if err := p2pNode.Start(); err != nil {
+			return err
+		}
+		addrs := strings.Split(tmcfg.P2P.PersistentPeers, ",")
+		for i, addr := range addrs {
+			addrs[i] = lp2p.AddressPrefix + addr
+		}
+		providers, err := p2pNode.Providers(addrs)
+		if err != nil {
+			return err
+		}
+		c, err = light.NewClient(
+			context.Background(),
+			cfg.ChainID,
+			providers[0],
+			providers[1:],
+			dbs.New(lightDB, "light"),
+			light.Logger(nodeLogger),
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		c, err = light.NewHTTPClient(
+			context.Background(),
+			cfg.ChainID,
+			endpoints[0],
+			endpoints[1:],
+			dbs.New(lightDB, "light"),
+			light.Logger(nodeLogger),
+		)
+		if err != nil {
+			return err
+		}
 	}
 
 	rpccfg := rpcserver.DefaultConfig()
@@ -199,7 +244,7 @@ func startLightClient(cfg *Config) error {
 		rpccfg.WriteTimeout = tmcfg.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
-	p, err := lproxy.NewProxy(c, tmcfg.RPC.ListenAddress, providers[0], rpccfg, nodeLogger,
+	p, err := lproxy.NewProxy(c, tmcfg.RPC.ListenAddress, endpoints[0], rpccfg, nodeLogger,
 		lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
 	if err != nil {
 		return err
@@ -246,9 +291,23 @@ func startMaverick(cfg *Config) error {
 	return n.Start()
 }
 
-// startSigner starts a signer server connecting to the given endpoint.
+// startSigner starts a signer server connecting to the given endpoint. It signs
+// with the mock core server if its host is set, otherwise with the file
+// private validator.
 func startSigner(cfg *Config) error {
-	filePV := privval.LoadFilePV(cfg.PrivValKey, cfg.PrivValState)
+	var privVal types.PrivValidator
+	if cfg.PrivValCoreRPCHost != "" {
+		coreSigner, err := privval.NewDashCoreSignerClient(cfg.PrivValCoreRPCHost, cfg.CoreRPCUsername,
+			cfg.CoreRPCPassword, btcjson.LLMQType_5_60,
+			privval.DashCoreSignerClientLogger(logger.With("module", "privval")),
+		)
+		if err != nil {
+			return err
+		}
+		privVal = coreSigner
+	} else {
+		privVal = privval.LoadFilePV(cfg.PrivValKey, cfg.PrivValState)
+	}
 
 	protocol, address := tmnet.ProtocolAndAddress(cfg.PrivValServer)
 	var dialFn privval.SocketDialer
@@ -264,7 +323,7 @@ func startSigner(cfg *Config) error {
 	endpoint := privval.NewSignerDialerEndpoint(logger, dialFn,
 		privval.SignerDialerEndpointRetryWaitInterval(1*time.Second),
 		privval.SignerDialerEndpointConnRetries(100))
-	err := privval.NewSignerServer(endpoint, cfg.ChainID, filePV).Start()
+	err := privval.NewSignerServer(endpoint, cfg.ChainID, privVal).Start()
 	if err != nil {
 		return err
 	}
@@ -273,25 +332,89 @@ func startSigner(cfg *Config) error {
 }
 
 func setupCoreServer(cfg *Config) (*mockcoreserver.JRPCServer, error) {
-	srv := mockcoreserver.NewJRPCServer(tmcfg.PrivValidatorCoreRPCHost, "/")
+	host := tmcfg.PrivValidatorCoreRPCHost
+	if cfg.PrivValCoreRPCHost != "" {
+		// the node doesn't sign with Dash Core itself, the remote signer does
+		host = cfg.PrivValCoreRPCHost
+	}
+	srv := mockcoreserver.NewJRPCServer(host, "/")
+	if cfg.CoreRPCUsername != "" {
+		srv = srv.WithBasicAuth(cfg.CoreRPCUsername, cfg.CoreRPCPassword)
+	}
 	privValKeyPath := filepath.Clean(tmhome + "/" + tmcfg.PrivValidatorKey)
 	privValStatePath := filepath.Clean(tmhome + "/" + tmcfg.PrivValidatorState)
 	filePV := privval.LoadFilePV(privValKeyPath, privValStatePath)
-	coreServer := &mockcoreserver.MockCoreServer{
+	coreServer = &mockcoreserver.MockCoreServer{
 		ChainID:  cfg.ChainID,
 		LLMQType: btcjson.LLMQType_5_60,
 		FilePV:   filePV,
 	}
+	genDoc, err := types.GenesisDocFromFile(tmcfg.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	rotations, err := quorumRotations(cfg, genDoc)
+	if err != nil {
+		return nil, err
+	}
+	coreServer.ScheduleRotations(rotations)
+	masternodeLists, err := masternodeLists(cfg, genDoc)
+	if err != nil {
+		return nil, err
+	}
+	coreServer.ScheduleMasternodeLists(masternodeLists)
+	if genDoc.InitialCoreChainLockedHeight > 0 {
+		chainLock := types.NewMockChainLock(genDoc.InitialCoreChainLockedHeight)
+		coreServer.SetChainLock(&chainLock)
+	}
+	if cfg.CoreChainLockInterval > 0 {
+		// the chain lock moves like the one of Dash Core, one core block at a time
+		coreServer.AdvanceChainLock(context.Background(), time.Duration(cfg.CoreChainLockInterval)*time.Second, 1)
+	}
+	if cfg.CoreServerScenario != "" {
+		scenarioFile := cfg.CoreServerScenario
+		if !filepath.IsAbs(scenarioFile) {
+			scenarioFile = filepath.Join(tmhome, scenarioFile)
+		}
+		scenario, err := mockcoreserver.LoadScenario(scenarioFile)
+		if err != nil {
+			return nil, err
+		}
+		return mockcoreserver.WithMethods(srv, scenario.MethodFuncs(coreServer)...), nil
+	}
 	srv = mockcoreserver.WithMethods(
 		srv,
 		mockcoreserver.WithQuorumInfoMethod(coreServer, mockcoreserver.Endless),
 		mockcoreserver.WithQuorumSignMethod(coreServer, mockcoreserver.Endless),
 		mockcoreserver.WithMasternodeMethod(coreServer, mockcoreserver.Endless),
 		mockcoreserver.WithGetNetworkInfoMethod(coreServer, mockcoreserver.Endless),
+		mockcoreserver.WithGetBestChainLockMethod(coreServer, mockcoreserver.Endless),
+		mockcoreserver.WithGetBlockCountMethod(coreServer, mockcoreserver.Endless),
 	)
 	return srv, nil
 }
 
+// quorumRotations returns the heights at which the validator quorum changes: the genesis quorum
+// and the quorums returned in validator updates, the quorum returned in InitChain replaces the genesis one
+func quorumRotations(cfg *Config, genDoc *types.GenesisDoc) (map[int64]crypto.QuorumHash, error) {
+	rotations := make(map[int64]crypto.QuorumHash, len(cfg.QuorumHashUpdate)+1)
+	if genDoc.QuorumHash != nil {
+		rotations[0] = genDoc.QuorumHash
+	}
+	for heightStr, quorumHashStr := range cfg.QuorumHashUpdate {
+		height, err := strconv.ParseInt(heightStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quorum hash update height %q: %w", heightStr, err)
+		}
+		quorumHash, err := hex.DecodeString(quorumHashStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex quorum value %q: %w", quorumHashStr, err)
+		}
+		rotations[height] = quorumHash
+	}
+	return rotations, nil
+}
+
 func setupNode() (*config.Config, log.Logger, *p2p.NodeKey, error) {
 	var tmcfg *config.Config
 
@@ -348,3 +471,32 @@ func rpcEndpoints(peers string) []string {
 	}
 	return endpoints
 }
+
+// masternodeLists returns the masternode lists by height, the list changes in lockstep with
+// the validator updates, which always contain the whole quorum
+func masternodeLists(cfg *Config, genDoc *types.GenesisDoc) (map[int64][]crypto.ProTxHash, error) {
+	lists := make(map[int64][]crypto.ProTxHash, len(cfg.ValidatorUpdates)+1)
+	if len(genDoc.Validators) > 0 {
+		proTxHashes := make([]crypto.ProTxHash, 0, len(genDoc.Validators))
+		for _, val := range genDoc.Validators {
+			proTxHashes = append(proTxHashes, val.ProTxHash)
+		}
+		lists[0] = proTxHashes
+	}
+	for heightStr, updates := range cfg.ValidatorUpdates {
+		height, err := strconv.ParseInt(heightStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator update height %q: %w", heightStr, err)
+		}
+		proTxHashes := make([]crypto.ProTxHash, 0, len(updates))
+		for proTxHashStr := range updates {
+			proTxHash, err := hex.DecodeString(proTxHashStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex proTxHash value %q: %w", proTxHashStr, err)
+			}
+			proTxHashes = append(proTxHashes, proTxHash)
+		}
+		lists[height] = proTxHashes
+	}
+	return lists, nil
+}
@@ -16,6 +16,7 @@ import (
 )
 
 const (
+	// snapshotChunkSize is the default size of the snapshot chunks.
 	snapshotChunkSize = 1e6
 )
 
@@ -24,13 +25,18 @@ const (
 // into fixed-size chunks.
 type SnapshotStore struct {
 	sync.RWMutex
-	dir      string
-	metadata []abci.Snapshot
+	dir       string
+	chunkSize uint64
+	metadata  []abci.Snapshot
 }
 
-// NewSnapshotStore creates a new snapshot store.
-func NewSnapshotStore(dir string) (*SnapshotStore, error) {
-	store := &SnapshotStore{dir: dir}
+// NewSnapshotStore creates a new snapshot store, splitting the snapshots into
+// chunks of the given size. A chunk size of 0 uses the default size.
+func NewSnapshotStore(dir string, chunkSize uint64) (*SnapshotStore, error) {
+	if chunkSize == 0 {
+		chunkSize = snapshotChunkSize
+	}
+	store := &SnapshotStore{dir: dir, chunkSize: chunkSize}
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, err
 	}
@@ -93,7 +99,7 @@ func (s *SnapshotStore) Create(state *State) (abci.Snapshot, error) {
 		Height: state.Height,
 		Format: 1,
 		Hash:   hash[:],
-		Chunks: byteChunks(bz),
+		Chunks: byteChunks(bz, s.chunkSize),
 	}
 	err = ioutil.WriteFile(filepath.Join(s.dir, fmt.Sprintf("%v.json", state.Height)), bz, 0644)
 	if err != nil {
@@ -107,6 +113,28 @@ func (s *SnapshotStore) Create(state *State) (abci.Snapshot, error) {
 	return snapshot, nil
 }
 
+// Prune removes the snapshots below the given height.
+func (s *SnapshotStore) Prune(height uint64) error {
+	s.Lock()
+	defer s.Unlock()
+	metadata := make([]abci.Snapshot, 0, len(s.metadata))
+	for _, snapshot := range s.metadata {
+		if snapshot.Height >= height {
+			metadata = append(metadata, snapshot)
+			continue
+		}
+		err := os.Remove(filepath.Join(s.dir, fmt.Sprintf("%v.json", snapshot.Height)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if len(metadata) == len(s.metadata) {
+		return nil
+	}
+	s.metadata = metadata
+	return s.saveMetadata()
+}
+
 // List lists available snapshots.
 func (s *SnapshotStore) List() ([]*abci.Snapshot, error) {
 	s.RLock()
@@ -129,16 +157,16 @@ func (s *SnapshotStore) LoadChunk(height uint64, format uint32, chunk uint32) ([
 			if err != nil {
 				return nil, err
 			}
-			return byteChunk(bz, chunk), nil
+			return byteChunk(bz, chunk, s.chunkSize), nil
 		}
 	}
 	return nil, nil
 }
 
 // byteChunk returns the chunk at a given index from the full byte slice.
-func byteChunk(bz []byte, index uint32) []byte {
-	start := int(index * snapshotChunkSize)
-	end := int((index + 1) * snapshotChunkSize)
+func byteChunk(bz []byte, index uint32, chunkSize uint64) []byte {
+	start := int(uint64(index) * chunkSize)
+	end := int(uint64(index+1) * chunkSize)
 	switch {
 	case start >= len(bz):
 		return nil
@@ -150,6 +178,6 @@ func byteChunk(bz []byte, index uint32) []byte {
 }
 
 // byteChunks calculates the number of chunks in the byte slice.
-func byteChunks(bz []byte) uint32 {
-	return uint32(math.Ceil(float64(len(bz)) / snapshotChunkSize))
+func byteChunks(bz []byte, chunkSize uint64) uint32 {
+	return uint32(math.Ceil(float64(len(bz)) / float64(chunkSize)))
 }
@@ -25,10 +25,10 @@ var (
 	}
 
 	// The following specify randomly chosen values for testnet nodes.
-	nodeDatabases = uniformChoice{"goleveldb", "cleveldb", "rocksdb", "boltdb", "badgerdb"}
+	nodeDatabases = uniformChoice{"goleveldb", "cleveldb", "rocksdb", "boltdb", "badgerdb", "pebble"}
 	// FIXME: grpc disabled due to https://github.com/tendermint/tendermint/issues/5439
 	nodeABCIProtocols    = uniformChoice{"unix", "tcp", "builtin"} // "grpc"
-	nodePrivvalProtocols = uniformChoice{"file", "unix", "tcp", "dashcore"}
+	nodePrivvalProtocols = uniformChoice{"file", "unix", "tcp", "dashcore", "dashcore-tcp"}
 	// FIXME: v2 disabled due to flake
 	nodeFastSyncs         = uniformChoice{"", "v0"} // "v2"
 	nodeStateSyncs        = uniformChoice{false, true}
@@ -50,6 +50,9 @@ var (
 	}
 )
 
+// validatorChurnHeights is the number of heights a churned validator stays in the quorum.
+const validatorChurnHeights = 10
+
 // Generate generates random testnets using the given RNG.
 func Generate(r *rand.Rand) ([]e2e.Manifest, error) {
 	manifests := []e2e.Manifest{}
@@ -66,6 +69,7 @@ func Generate(r *rand.Rand) ([]e2e.Manifest, error) {
 // generateTestnet generates a single testnet with the given options.
 func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, error) {
 	manifest := e2e.Manifest{
+		Seed:             r.Int63(),
 		IPv6:             opt["ipv6"].(bool),
 		InitialHeight:    int64(opt["initialHeight"].(int)),
 		InitialState:     opt["initialState"].(map[string]string),
@@ -77,6 +81,7 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, er
 
 
 	var numSeeds, numValidators, numFulls, numChainLocks, numLightClients int
+	var churn bool
 	switch opt["topology"].(string) {
 	case "single":
 		numValidators = 1
@@ -84,6 +89,7 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, er
 	case "quad":
 		numValidators = 4
 		numChainLocks = r.Intn(10)
+		churn = true
 	case "large":
 		// FIXME Networks are kept small since large ones use too much CPU.
 		numSeeds = r.Intn(3)
@@ -91,6 +97,7 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, er
 		numValidators = 4 + r.Intn(7)
 		numFulls = r.Intn(5)
 		numChainLocks = r.Intn(10)
+		churn = r.Float64() >= 0.5
 	default:
 		return manifest, fmt.Errorf("unknown topology %q", opt["topology"])
 	}
@@ -104,6 +111,8 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, er
 	// Next, we generate validators. We make sure a BFT quorum of validators start
 	// at the initial height, and that we have two archive nodes. We also set up
 	// the initial validator set, and validator set updates for delayed nodes.
+	// With churn, the last delayed validator leaves the quorum some blocks after
+	// it was added.
 	nextStartAt := manifest.InitialHeight + 5
 	quorum := numValidators*2/3 + 1
 	for i := 1; i <= numValidators; i++ {
@@ -122,6 +131,13 @@ func generateTestnet(r *rand.Rand, opt map[string]interface{}) (e2e.Manifest, er
 			manifest.ValidatorUpdates[fmt.Sprint(startAt+5)] = map[string]int64{
 				name: 100,
 			}
+			if churn && i == numValidators {
+				removeAt := fmt.Sprint(startAt + 5 + validatorChurnHeights)
+				if _, ok := manifest.ValidatorUpdates[removeAt]; !ok {
+					manifest.ValidatorUpdates[removeAt] = map[string]int64{}
+				}
+				manifest.ValidatorUpdates[removeAt][name] = 0
+			}
 		}
 	}
 
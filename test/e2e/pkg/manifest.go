@@ -12,6 +12,11 @@ type Manifest struct {
 	// IPv6 uses IPv6 networking instead of IPv4. Defaults to IPv4.
 	IPv6 bool `toml:"ipv6"`
 
+	// Seed is the seed of the pseudorandom generators of the testnet, it
+	// determines every validator key, proTxHash and quorum hash, including the
+	// threshold public keys of the quorums. Defaults to a constant seed.
+	Seed int64 `toml:"seed"`
+
 	// QuorumType represents the initial quorum type
 	QuorumType uint32 `toml:"quorum_type"`
 
@@ -44,10 +49,16 @@ type Manifest struct {
 	// validator01 = 100
 	// validator02 = 100
 	//
-	// Specifying height 0 returns the validator update during InitChain. The
-	// application returns the validator updates as-is, i.e. removing a
-	// validator must be done by returning it with power 0, and any validators
-	// not specified are not changed.
+	// Specifying height 0 returns the validator update during InitChain. A
+	// validator is removed by giving it power 0, and any validators not
+	// specified are not changed. Each update regenerates the threshold keys of
+	// the resulting quorum, and the application returns the whole quorum with
+	// a new quorum hash, while the mock Dash Core server of every node updates
+	// its masternode list at the same height. A removed validator keeps
+	// running as a non-validator node and may be added back later:
+	//
+	// [validator_update.1010]
+	// validator04 = 0
 	ValidatorUpdates map[string]map[string]int64 `toml:"validator_update"`
 
 	// ChainLockUpdates is a map of heights at which a new chain lock should be proposed
@@ -62,6 +73,72 @@ type Manifest struct {
 
 	ChainLockUpdates map[string]int64 `toml:"chainlock_updates"`
 
+	// CoreChainLockInterval advances the best chain lock of the mock Dash Core
+	// server of every node by one core block every given number of seconds,
+	// independently of the chain lock updates. The nodes signing with Dash Core
+	// publish a ChainLockUpdate event each time it advances. Defaults to 0
+	// (disabled).
+	CoreChainLockInterval int64 `toml:"core_chain_lock_interval"`
+
+	// ProcessProposalHeight is the height starting from which validators ask
+	// the application to validate complete proposals before prevoting (see
+	// consensus.process_proposal_height). Defaults to 0 (disabled).
+	ProcessProposalHeight int64 `toml:"process_proposal_height"`
+
+	// QuorumRotate rotates the validator quorum every given number of heights,
+	// like Dash does it with LLMQ quorums: the application returns the same
+	// validators with new keys and a new quorum hash, and the mock Dash Core
+	// server retires old quorums. Rotations are scheduled for the first 100
+	// heights, explicit validator updates take precedence. Defaults to 0
+	// (disabled).
+	QuorumRotate int64 `toml:"quorum_rotate"`
+
+	// CompactBlocks enables the compact proposal blocks on all the nodes (see
+	// consensus.compact_blocks): the peers reconstruct the proposal blocks from
+	// their mempools instead of downloading the block parts.
+	CompactBlocks bool `toml:"compact_blocks"`
+
+	// AnnounceTxs enables the tx announcements on all the nodes (see
+	// mempool.announce-txs): the txs are announced to the peers by their
+	// hashes, and sent only to the peers, which request them.
+	AnnounceTxs bool `toml:"announce_txs"`
+
+	// VerifyMasternodeIdentity turns on the verification of the masternode
+	// ownership proofs in the p2p handshake on all the nodes (see
+	// p2p.verify_masternode_identity): the validators sign their node IDs,
+	// and the peers claiming a proTxHash without a valid proof are rejected.
+	VerifyMasternodeIdentity bool `toml:"verify_masternode_identity"`
+
+	// InvalidBlockSizeHeight is the height at which the applications try to set
+	// block.max_bytes to 0 by a consensus param update in EndBlock. The
+	// invalid update must be dropped with a RejectedUpdates event instead of
+	// halting the chain. Defaults to 0 (disabled).
+	InvalidBlockSizeHeight int64 `toml:"invalid_block_size_height"`
+
+	// LoadTxSizeBytes is the size of the random values of the transactions
+	// generated by the load, which are hex-encoded into the transactions.
+	// Large values give large blocks, e.g. for benchmarking the bandwidth.
+	// Defaults to 1024.
+	LoadTxSizeBytes int `toml:"load_tx_size_bytes"`
+
+	// Load shapes the transaction load generated by the runner, see
+	// ManifestLoad. Defaults to a constant load of 10 txs/s for the whole
+	// network, divided evenly among the nodes.
+	Load *ManifestLoad `toml:"load"`
+
+	// RollbackBlocks is the number of blocks the whole network is rolled back
+	// by with the rollback command, after the perturbations: all the nodes are
+	// stopped, rolled back with their signer states, their applications are
+	// reset to replay the blocks, and the network resumes consensus from
+	// there. The nodes must store all the blocks. Defaults to 0 (disabled).
+	RollbackBlocks int64 `toml:"rollback_blocks"`
+
+	// Tracing runs an OpenTelemetry collector container along with the nodes,
+	// which export the spans of the block lifecycle to it (see
+	// instrumentation.otel-endpoint). The collector writes the spans to
+	// otel/spans.json in the testnet directory. Defaults to false.
+	Tracing bool `toml:"tracing"`
+
 	// Nodes specifies the network nodes. At least one node must be given.
 	Nodes map[string]*ManifestNode `toml:"node"`
 
@@ -70,6 +147,56 @@ type Manifest struct {
 	KeyType string `toml:"key_type"`
 }
 
+// ManifestLoad represents the transaction load generated by the runner. The
+// transactions sent to every node and the times they're due are determined
+// by the seed, so a traffic shape can be reproduced:
+//
+// [load]
+// profile = "burst"
+// rate = 20
+// burst_interval = 2
+// node_rates = { validator04 = 0 }
+type ManifestLoad struct {
+	// Seed is the seed of the transactions and of their sizes. Defaults to
+	// the seed of the testnet.
+	Seed int64 `toml:"seed"`
+
+	// Profile is the shape of the load sent to every node:
+	//
+	// constant: the transactions are sent at a constant rate
+	// ramp:     the rate grows linearly from 0 during ramp_duration, then stays constant
+	// burst:    the transactions of every burst_interval are sent at once, at its start
+	//
+	// Defaults to constant.
+	Profile string `toml:"profile"`
+
+	// Rate is the number of transactions per second sent to every node, on
+	// average. Defaults to 10 txs/s divided by the number of nodes.
+	Rate float64 `toml:"rate"`
+
+	// NodeRates overrides the rate of the given nodes, by node name. Nodes
+	// with a rate of 0 get no transactions.
+	NodeRates map[string]float64 `toml:"node_rates"`
+
+	// RampDuration is the number of seconds the ramp profile takes to reach
+	// the rate. Defaults to 30.
+	RampDuration int64 `toml:"ramp_duration"`
+
+	// BurstInterval is the number of seconds between the bursts of the burst
+	// profile. Defaults to 5.
+	BurstInterval int64 `toml:"burst_interval"`
+
+	// TxSizeMin and TxSizeMax bound the sizes of the random values of the
+	// transactions, drawn uniformly from the seeded generator. Both default to
+	// load_tx_size_bytes.
+	TxSizeMin int `toml:"tx_size_min"`
+	TxSizeMax int `toml:"tx_size_max"`
+
+	// MaxFailedBroadcasts fails the load, if more transactions fail to be
+	// broadcast, e.g. 0 on the happy path. Defaults to unlimited.
+	MaxFailedBroadcasts *int `toml:"max_failed_broadcasts"`
+}
+
 // ManifestNode represents a node in a testnet manifest.
 type ManifestNode struct {
 	// Mode specifies the type of node: "validator", "full", "light" or "seed".
@@ -86,10 +213,22 @@ type ManifestNode struct {
 	// this relates to the providers the light client is connected to.
 	PersistentPeers []string `toml:"persistent_peers"`
 
+	// LightProvider specifies how a light client fetches the light blocks from
+	// its providers: "rpc" or "p2p". With p2p, the light client dials the
+	// persistent peers, which serve the light blocks from their stores, and
+	// forwards the unverified calls to the RPC of the first one. Defaults to
+	// rpc. Only light clients make use of this.
+	LightProvider string `toml:"light_provider"`
+
 	// Database specifies the database backend: "goleveldb", "cleveldb",
-	// "rocksdb", "boltdb", or "badgerdb". Defaults to goleveldb.
+	// "rocksdb", "boltdb", "badgerdb" or "pebble". Defaults to goleveldb.
 	Database string `toml:"database"`
 
+	// DatabaseBackends overrides the database backend of the stores, by the
+	// ID of their database: "blockstore", "state", "evidence", "tx_index" or
+	// "light". Defaults to none, all the stores use the database backend.
+	DatabaseBackends map[string]string `toml:"database_backends"`
+
 	// ABCIProtocol specifies the protocol used to communicate with the ABCI
 	// application: "unix", "tcp", "grpc", or "builtin". Defaults to unix.
 	// builtin will build a complete Tenderdash node into the application and
@@ -97,8 +236,10 @@ type ManifestNode struct {
 	ABCIProtocol string `toml:"abci_protocol"`
 
 	// PrivvalProtocol specifies the protocol used to sign consensus messages:
-	// "file", "unix", or "tcp". Defaults to "file". For unix and tcp, the ABCI
-	// application will launch a remote signer client in a separate goroutine.
+	// "file", "unix", "tcp", "dashcore", or "dashcore-tcp". Defaults to "file".
+	// For unix, tcp and dashcore-tcp, the ABCI application will launch a remote
+	// signer client in a separate goroutine, which signs with the mock Dash Core
+	// server for dashcore-tcp.
 	// Only nodes with mode=validator will actually make use of this.
 	PrivvalProtocol string `toml:"privval_protocol"`
 
@@ -121,10 +262,26 @@ type ManifestNode struct {
 	// 0 disables state persistence.
 	PersistInterval *uint64 `toml:"persist_interval"`
 
-	// SnapshotInterval specifies the height interval at which the application
-	// will take state sync snapshots. Defaults to 0 (disabled).
+	// SnapshotInterval specifies the height interval at which Tenderdash asks
+	// the application to take state sync snapshots. Defaults to 0 (disabled).
 	SnapshotInterval uint64 `toml:"snapshot_interval"`
 
+	// SnapshotKeepRecent specifies the number of the most recent snapshots the
+	// application keeps, pruning the older ones. Requires SnapshotInterval.
+	// Defaults to 0, which keeps all the snapshots.
+	SnapshotKeepRecent uint64 `toml:"snapshot_keep_recent"`
+
+	// SnapshotChunkSize specifies the size in bytes of the chunks of the
+	// application's snapshots. Nodes serving the same snapshots must use the
+	// same size. Defaults to 0, which uses 1MB chunks.
+	SnapshotChunkSize uint64 `toml:"snapshot_chunk_size"`
+
+	// KillAfterChunksPercent kills the node, once a state syncing peer requests
+	// a snapshot chunk past the given percent of the snapshot's chunks. The
+	// runner checks that the node is down after the state syncing nodes have
+	// started, and restarts it. Defaults to 0 (disabled).
+	KillAfterChunksPercent uint32 `toml:"kill_after_chunks_percent"`
+
 	// RetainBlocks specifies the number of recent blocks to retain. Defaults to
 	// 0, which retains all blocks. Must be greater that PersistInterval and
 	// SnapshotInterval.
@@ -148,6 +305,27 @@ type ManifestNode struct {
 	// For more information, look at the readme in the maverick folder.
 	// A list of all behaviors can be found in ../maverick/consensus/behavior.go
 	Misbehaviors map[string]string `toml:"misbehaviors"`
+
+	// InvalidProposals makes the node's application accept transactions that
+	// the applications of other nodes reject, both in CheckTx and in
+	// ProcessProposal. Blocks proposed by this node are therefore invalid for
+	// the rest of the network. The runner submits such transactions to this node.
+	// Only validators using ProcessProposal (process_proposal_height) can set this.
+	InvalidProposals bool `toml:"invalid_proposals"`
+
+	// CoreRPCUsername and CoreRPCPassword are the credentials the node uses to
+	// authenticate against the mock Dash Core RPC server, which rejects requests
+	// without them. Only nodes with privval_protocol=dashcore can set them.
+	// Defaults to empty, which disables the authentication.
+	CoreRPCUsername string `toml:"core_rpc_username"`
+	CoreRPCPassword string `toml:"core_rpc_password"`
+
+	// CoreServerScenario is a YAML scenario file, relative to the manifest,
+	// describing the methods of the node's mock Dash Core server: expected
+	// call counts, canned responses, injected errors and delays (see
+	// mockcoreserver.Scenario). Only nodes with privval_protocol=dashcore can
+	// set it. Defaults to empty, which serves all methods by the mock server.
+	CoreServerScenario string `toml:"core_server_scenario"`
 }
 
 // Save saves the testnet manifest to a file.
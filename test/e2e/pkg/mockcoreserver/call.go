@@ -2,9 +2,11 @@ package mockcoreserver
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"net/http"
 	"sync"
+	"time"
 )
 
 var (
@@ -30,6 +32,8 @@ type Call struct {
 	expectFunc  ExpectFunc
 	actualCnt   int
 	expectedCnt int
+	atLeast     bool
+	delay       time.Duration
 	guard       sync.Mutex
 }
 
@@ -47,6 +51,10 @@ func (c *Call) Respond(opts ...HandlerOptionFunc) *Call {
 				return err
 			}
 		}
+		if ro.handlerFunc != nil {
+			ro.handlerFunc(w, req)
+			return nil
+		}
 		if len(ro.header) > 0 {
 			for k, vals := range ro.header {
 				if len(vals) == 1 {
@@ -74,9 +82,30 @@ func (c *Call) Expect(fn ExpectFunc) *Call {
 	return c
 }
 
-// Times sets the expected number of calls
+// Times sets the expected number of calls, a call over the number is responded with an error.
+// Endless allows any number of calls
 func (c *Call) Times(cnt int) *Call {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.expectedCnt = cnt
+	c.atLeast = false
+	return c
+}
+
+// AtLeast sets the minimal expected number of calls, the number of calls is not limited
+func (c *Call) AtLeast(cnt int) *Call {
+	c.guard.Lock()
+	defer c.guard.Unlock()
 	c.expectedCnt = cnt
+	c.atLeast = true
+	return c
+}
+
+// Delay delays every response of the call, a request cancelled during the delay is not responded
+func (c *Call) Delay(d time.Duration) *Call {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.delay = d
 	return c
 }
 
@@ -88,23 +117,75 @@ func (c *Call) Once() *Call {
 
 // Forever do not use number expected calls for a mock
 func (c *Call) Forever() *Call {
-	c.Times(-1)
+	c.Times(Endless)
 	return c
 }
 
+// reserve counts a call if the expected number of calls is not exhausted yet
+func (c *Call) reserve() bool {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if c.isExhausted() {
+		return false
+	}
+	c.actualCnt++
+	return true
+}
+
+func (c *Call) exhausted() bool {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	return c.isExhausted()
+}
+
+func (c *Call) isExhausted() bool {
+	return !c.atLeast && c.expectedCnt != Endless && c.actualCnt >= c.expectedCnt
+}
+
+// unmet returns a description of the call expectation if it is not met
+func (c *Call) unmet(name string) (string, bool) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if c.expectedCnt == Endless || c.actualCnt >= c.expectedCnt {
+		return "", false
+	}
+	if c.atLeast {
+		return fmt.Sprintf("%q: expected at least %d call(s), actual %d", name, c.expectedCnt, c.actualCnt), true
+	}
+	return fmt.Sprintf("%q: expected %d call(s), actual %d", name, c.expectedCnt, c.actualCnt), true
+}
+
 func (c *Call) execute(w http.ResponseWriter, req *http.Request) error {
-	if c.expectFunc != nil {
-		err := c.expectFunc(req)
-		if err != nil {
-			return err
+	err := c.expect(req)
+	if err != nil {
+		return err
+	}
+	return c.respond(w, req)
+}
+
+// expect checks a request against the call expectation
+func (c *Call) expect(req *http.Request) error {
+	if c.expectFunc == nil {
+		return nil
+	}
+	return c.expectFunc(req)
+}
+
+func (c *Call) respond(w http.ResponseWriter, req *http.Request) error {
+	c.guard.Lock()
+	delay := c.delay
+	c.guard.Unlock()
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil
 		}
 	}
 	if c.handlerFunc != nil {
-		err := c.handlerFunc(w, req)
-		if err != nil {
-			return err
-		}
+		return c.handlerFunc(w, req)
 	}
-	c.actualCnt++
 	return nil
 }
@@ -1,51 +1,265 @@
 package mockcoreserver
 
 import (
+	"context"
 	"encoding/hex"
+	"sort"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/dashevo/dashd-go/btcjson"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
 )
 
 // CoreServer is an interface of a mock core-server
 type CoreServer interface {
-	QuorumInfo(cmd btcjson.QuorumCmd) btcjson.QuorumInfoResult
-	QuorumSign(cmd btcjson.QuorumCmd) btcjson.QuorumSignResult
+	QuorumInfo(cmd btcjson.QuorumCmd) (btcjson.QuorumInfoResult, error)
+	QuorumSign(cmd btcjson.QuorumCmd) (btcjson.QuorumSignResult, error)
 	MasternodeStatus(cmd btcjson.MasternodeCmd) btcjson.MasternodeStatusResult
 	GetNetworkInfo(cmd btcjson.GetNetworkInfoCmd) btcjson.GetNetworkInfoResult
+	GetBestChainLock(cmd GetBestChainLockCmd) (GetBestChainLockResult, error)
+	GetBlockCount(cmd btcjson.GetBlockCountCmd) int64
 }
 
+// ErrNoChainLock is returned by getbestchainlock, like in Dash Core, if no chain lock is known yet
+var ErrNoChainLock = btcjson.NewRPCError(btcjson.ErrRPCInternal.Code, "Unable to find any ChainLock")
+
+// GetBestChainLockCmd defines the getbestchainlock JSON-RPC command
+type GetBestChainLockCmd struct{}
+
+// GetBestChainLockResult models the data from the getbestchainlock command
+type GetBestChainLockResult struct {
+	BlockHash  string `json:"blockhash"`
+	Height     int32  `json:"height"`
+	Signature  string `json:"signature"`
+	KnownBlock bool   `json:"known_block"`
+}
+
+// defaultActiveQuorumCount is the number of the most recent quorums, which are able to sign by default
+const defaultActiveQuorumCount = 2
+
+// ErrQuorumNotFound is returned by quorum info and quorum sign, like in Dash Core, if the quorum is unknown
+// for the requested quorum type
+var ErrQuorumNotFound = btcjson.NewRPCError(btcjson.ErrRPCInvalidParameter, "quorum not found")
+
 // MockCoreServer is an implementation of a mock core-server
 type MockCoreServer struct {
-	ChainID  string
+	ChainID string
+	// LLMQType is the quorum type of the quorums, which are missing in SetQuorumType
 	LLMQType btcjson.LLMQType
 	FilePV   *privval.FilePV
+	// ActiveQuorumCount is the number of the most recent rotated quorums, which are able to sign,
+	// older quorums are retired. Defaults to 2, so the previous quorum is able to sign during a transition
+	ActiveQuorumCount int
+
+	guard   sync.Mutex
+	active  []crypto.QuorumHash
+	retired map[string]bool
+	members map[string][]btcjson.QuorumMember
+	// quorumTypes are the quorum types of the quorums by quorum hash
+	quorumTypes map[string]btcjson.LLMQType
+	rotations   []scheduledRotation
+	// nextRotation is an index of the next scheduled rotation to apply
+	nextRotation int
+	chainLock    *types.CoreChainLock
+	blockCount   int64
+	// masternodes is the set of registered masternodes by proTxHash, nil means the list is not tracked
+	masternodes        map[string]bool
+	masternodeLists    []scheduledMasternodeList
+	nextMasternodeList int
 }
 
-// QuorumInfo returns a quorum-info result
-func (c *MockCoreServer) QuorumInfo(cmd btcjson.QuorumCmd) btcjson.QuorumInfoResult {
-	var members []btcjson.QuorumMember
-	proTxHash, err := c.FilePV.GetProTxHash()
-	if err != nil {
-		panic(err)
+type scheduledRotation struct {
+	height     int64
+	quorumHash crypto.QuorumHash
+}
+
+type scheduledMasternodeList struct {
+	height      int64
+	proTxHashes []crypto.ProTxHash
+}
+
+// RotateQuorum makes the passed quorum the active one. The oldest quorum is retired, if the number
+// of active quorums exceeds ActiveQuorumCount, and quorum sign requests referencing it fail.
+// If members are nil, the members are built from the keys of FilePV.
+func (c *MockCoreServer) RotateQuorum(quorumHash crypto.QuorumHash, members []btcjson.QuorumMember) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.rotateQuorum(quorumHash, members)
+}
+
+func (c *MockCoreServer) rotateQuorum(quorumHash crypto.QuorumHash, members []btcjson.QuorumMember) {
+	if c.retired == nil {
+		c.retired = make(map[string]bool)
+		c.members = make(map[string][]btcjson.QuorumMember)
+	}
+	if n := len(c.active); n > 0 && c.active[n-1].String() == quorumHash.String() {
+		return
+	}
+	if members != nil {
+		c.members[quorumHash.String()] = members
+	}
+	delete(c.retired, quorumHash.String())
+	c.active = append(c.active, quorumHash)
+	count := c.ActiveQuorumCount
+	if count <= 0 {
+		count = defaultActiveQuorumCount
+	}
+	for len(c.active) > count {
+		c.retired[c.active[0].String()] = true
+		c.active = c.active[1:]
+	}
+}
+
+// SetQuorumType sets the quorum type of the quorum, quorum info and quorum sign requests of the quorum
+// with another quorum type fail with ErrQuorumNotFound. The quorums of several types are served at the same time.
+func (c *MockCoreServer) SetQuorumType(quorumHash crypto.QuorumHash, llmqType btcjson.LLMQType) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if c.quorumTypes == nil {
+		c.quorumTypes = make(map[string]btcjson.LLMQType)
 	}
+	c.quorumTypes[quorumHash.String()] = llmqType
+}
+
+func (c *MockCoreServer) quorumType(quorumHash crypto.QuorumHash) btcjson.LLMQType {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if llmqType, ok := c.quorumTypes[quorumHash.String()]; ok {
+		return llmqType
+	}
+	return c.LLMQType
+}
+
+// ScheduleRotations sets the heights at which quorums are rotated, see SetHeight
+func (c *MockCoreServer) ScheduleRotations(rotations map[int64]crypto.QuorumHash) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.rotations = make([]scheduledRotation, 0, len(rotations))
+	for height, quorumHash := range rotations {
+		c.rotations = append(c.rotations, scheduledRotation{height: height, quorumHash: quorumHash})
+	}
+	sort.Slice(c.rotations, func(i, j int) bool {
+		return c.rotations[i].height < c.rotations[j].height
+	})
+	c.nextRotation = 0
+}
+
+// SetMasternodeList replaces the list of registered masternodes, the masternode status of a node
+// missing in the list is REMOVED
+func (c *MockCoreServer) SetMasternodeList(proTxHashes []crypto.ProTxHash) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.setMasternodeList(proTxHashes)
+}
+
+func (c *MockCoreServer) setMasternodeList(proTxHashes []crypto.ProTxHash) {
+	c.masternodes = make(map[string]bool, len(proTxHashes))
+	for _, proTxHash := range proTxHashes {
+		c.masternodes[proTxHash.String()] = true
+	}
+}
+
+// ScheduleMasternodeLists sets the heights at which the list of registered masternodes is replaced, see SetHeight
+func (c *MockCoreServer) ScheduleMasternodeLists(lists map[int64][]crypto.ProTxHash) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.masternodeLists = make([]scheduledMasternodeList, 0, len(lists))
+	for height, proTxHashes := range lists {
+		c.masternodeLists = append(c.masternodeLists, scheduledMasternodeList{height: height, proTxHashes: proTxHashes})
+	}
+	sort.Slice(c.masternodeLists, func(i, j int) bool {
+		return c.masternodeLists[i].height < c.masternodeLists[j].height
+	})
+	c.nextMasternodeList = 0
+}
+
+// SetHeight notifies the mock core-server about a new block height,
+// all scheduled rotations and masternode lists up to the height are applied in order
+func (c *MockCoreServer) SetHeight(height int64) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	for ; c.nextRotation < len(c.rotations); c.nextRotation++ {
+		r := c.rotations[c.nextRotation]
+		if r.height > height {
+			break
+		}
+		c.rotateQuorum(r.quorumHash, nil)
+	}
+	for ; c.nextMasternodeList < len(c.masternodeLists); c.nextMasternodeList++ {
+		l := c.masternodeLists[c.nextMasternodeList]
+		if l.height > height {
+			break
+		}
+		c.setMasternodeList(l.proTxHashes)
+	}
+}
+
+// RotateEvery rotates the passed quorums one by one every interval,
+// until all of them are rotated or the context is done
+func (c *MockCoreServer) RotateEvery(ctx context.Context, interval time.Duration, quorumHashes ...crypto.QuorumHash) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for _, quorumHash := range quorumHashes {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.RotateQuorum(quorumHash, nil)
+			}
+		}
+	}()
+}
+
+func (c *MockCoreServer) isRetired(quorumHash crypto.QuorumHash) bool {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	return c.retired[quorumHash.String()]
+}
+
+func (c *MockCoreServer) quorumMembers(quorumHash crypto.QuorumHash) ([]btcjson.QuorumMember, bool) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	members, ok := c.members[quorumHash.String()]
+	return members, ok
+}
+
+// QuorumInfo returns a quorum-info result, the info of retired quorums is still available
+func (c *MockCoreServer) QuorumInfo(cmd btcjson.QuorumCmd) (btcjson.QuorumInfoResult, error) {
 	quorumHash := strVal(cmd.QuorumHash)
-	qq := bytes.HexBytes(quorumHash)
-	pk, err := c.FilePV.GetPubKey(qq)
+	quorumHashBytes, err := hex.DecodeString(quorumHash)
 	if err != nil {
 		panic(err)
 	}
-	if pk != nil {
-		members = append(members, btcjson.QuorumMember{
-			ProTxHash:      proTxHash.String(),
-			PubKeyOperator: crypto.CRandHex(96),
-			Valid:          true,
-			PubKeyShare:    pk.HexString(),
-		})
+	qq := bytes.HexBytes(quorumHashBytes)
+	llmqType := c.quorumType(qq)
+	if cmd.LLMQType != nil && *cmd.LLMQType != llmqType {
+		return btcjson.QuorumInfoResult{}, ErrQuorumNotFound
+	}
+	members, ok := c.quorumMembers(quorumHashBytes)
+	if !ok {
+		proTxHash, err := c.FilePV.GetProTxHash()
+		if err != nil {
+			panic(err)
+		}
+		pk, err := c.FilePV.GetPubKey(qq)
+		if err != nil {
+			panic(err)
+		}
+		if pk != nil {
+			members = append(members, btcjson.QuorumMember{
+				ProTxHash:      proTxHash.String(),
+				PubKeyOperator: crypto.CRandHex(96),
+				Valid:          true,
+				PubKeyShare:    pk.HexString(),
+			})
+		}
 	}
 	tpk, err := c.FilePV.GetThresholdPublicKey(qq)
 	if err != nil {
@@ -57,65 +271,49 @@ func (c *MockCoreServer) QuorumInfo(cmd btcjson.QuorumCmd) btcjson.QuorumInfoRes
 	}
 	return btcjson.QuorumInfoResult{
 		Height:          uint32(height),
-		Type:            strconv.Itoa(int(c.LLMQType)),
+		Type:            strconv.Itoa(int(llmqType)),
 		QuorumHash:      quorumHash,
 		Members:         members,
-		QuorumPublicKey: tpk.String(),
-	}
+		QuorumPublicKey: tpk.HexString(),
+	}, nil
 }
 
-// QuorumSign returns a quorum-sign result
-func (c *MockCoreServer) QuorumSign(cmd btcjson.QuorumCmd) btcjson.QuorumSignResult {
-	reqID, err := hex.DecodeString(strVal(cmd.RequestID))
-	if err != nil {
-		panic(err)
-	}
-	msgHash, err := hex.DecodeString(strVal(cmd.MessageHash))
-	if err != nil {
-		panic(err)
+// QuorumSign returns a quorum-sign result, the request fails like in Dash Core if the quorum is retired
+// or has another quorum type
+func (c *MockCoreServer) QuorumSign(cmd btcjson.QuorumCmd) (btcjson.QuorumSignResult, error) {
+	quorumHash := quorumHashFromCmd(cmd)
+	llmqType := c.quorumType(quorumHash)
+	if c.isRetired(quorumHash) || *cmd.LLMQType != llmqType {
+		return btcjson.QuorumSignResult{}, ErrQuorumNotFound
 	}
 
-	quorumHashBytes, err := hex.DecodeString(*cmd.QuorumHash)
-	if err != nil {
-		panic(err)
-	}
-	quorumHash := crypto.QuorumHash(quorumHashBytes)
-
-	signID := crypto.SignId(
-		*cmd.LLMQType,
-		bls12381.ReverseBytes(quorumHash),
-		bls12381.ReverseBytes(reqID),
-		bls12381.ReverseBytes(msgHash),
-	)
 	privateKey, err := c.FilePV.Key.PrivateKeyForQuorumHash(quorumHash)
 	if err != nil {
 		panic(err)
 	}
-
-	sign, err := privateKey.SignDigest(signID)
-	if err != nil {
-		panic(err)
-	}
-
-	res := btcjson.QuorumSignResult{
-		LLMQType:   int(c.LLMQType),
-		QuorumHash: quorumHash.String(),
-		ID:         hex.EncodeToString(reqID),
-		MsgHash:    hex.EncodeToString(msgHash),
-		SignHash:   hex.EncodeToString(signID),
-		Signature:  hex.EncodeToString(sign),
-	}
-	return res
+	return quorumSignResult(cmd, privateKey), nil
 }
 
-// MasternodeStatus returns a masternode-status result
+// MasternodeStatus returns a masternode-status result, the state is REMOVED if the masternode list
+// is tracked and the node is not in the list
 func (c *MockCoreServer) MasternodeStatus(_ btcjson.MasternodeCmd) btcjson.MasternodeStatusResult {
 	proTxHash, err := c.FilePV.GetProTxHash()
 	if err != nil {
 		panic(err)
 	}
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if c.masternodes != nil && !c.masternodes[proTxHash.String()] {
+		return btcjson.MasternodeStatusResult{
+			ProTxHash: proTxHash.String(),
+			State:     "REMOVED",
+			Status:    "Masternode removed from list",
+		}
+	}
 	return btcjson.MasternodeStatusResult{
 		ProTxHash: proTxHash.String(),
+		State:     "READY",
+		Status:    "Ready",
 	}
 }
 
@@ -124,22 +322,100 @@ func (c *MockCoreServer) GetNetworkInfo(_ btcjson.GetNetworkInfoCmd) btcjson.Get
 	return btcjson.GetNetworkInfoResult{}
 }
 
+// SetChainLock sets the best chain lock, nil makes getbestchainlock fail with ErrNoChainLock
+func (c *MockCoreServer) SetChainLock(chainLock *types.CoreChainLock) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if chainLock == nil {
+		c.chainLock = nil
+		return
+	}
+	cl := chainLock.Copy()
+	c.chainLock = &cl
+}
+
+// SetBlockCount sets the number of blocks in the core chain, the block count is never lower than the chain lock height
+func (c *MockCoreServer) SetBlockCount(blockCount int64) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	c.blockCount = blockCount
+}
+
+// AdvanceChainLock increases the height of the best chain lock by step every interval until the context is done,
+// the chain lock starts from the height 1 if it is not set
+func (c *MockCoreServer) AdvanceChainLock(ctx context.Context, interval time.Duration, step uint32) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.guard.Lock()
+				height := step
+				if c.chainLock != nil {
+					height += c.chainLock.CoreBlockHeight
+				}
+				chainLock := types.NewMockChainLock(height)
+				c.chainLock = &chainLock
+				c.guard.Unlock()
+			}
+		}
+	}()
+}
+
+// GetBestChainLock returns the best chain lock
+func (c *MockCoreServer) GetBestChainLock(_ GetBestChainLockCmd) (GetBestChainLockResult, error) {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if c.chainLock == nil {
+		return GetBestChainLockResult{}, ErrNoChainLock
+	}
+	return GetBestChainLockResult{
+		BlockHash:  hex.EncodeToString(c.chainLock.CoreBlockHash),
+		Height:     int32(c.chainLock.CoreBlockHeight),
+		Signature:  hex.EncodeToString(c.chainLock.Signature),
+		KnownBlock: true,
+	}, nil
+}
+
+// GetBlockCount returns the number of blocks in the core chain
+func (c *MockCoreServer) GetBlockCount(_ btcjson.GetBlockCountCmd) int64 {
+	c.guard.Lock()
+	defer c.guard.Unlock()
+	if c.chainLock != nil && int64(c.chainLock.CoreBlockHeight) > c.blockCount {
+		return int64(c.chainLock.CoreBlockHeight)
+	}
+	return c.blockCount
+}
+
 // StaticCoreServer is a mock of core-server with static result data
 type StaticCoreServer struct {
 	QuorumInfoResult       btcjson.QuorumInfoResult
 	QuorumSignResult       btcjson.QuorumSignResult
 	MasternodeStatusResult btcjson.MasternodeStatusResult
 	GetNetworkInfoResult   btcjson.GetNetworkInfoResult
+	// GetBestChainLockResult is returned by getbestchainlock, the zero value makes it fail with ErrNoChainLock
+	GetBestChainLockResult GetBestChainLockResult
+	GetBlockCountResult    int64
+	// KeyShare signs the quorum-sign requests if set, the signatures of the key shares of a quorum
+	// are recoverable into the signature of the quorum
+	KeyShare *bls12381.KeyShare
 }
 
 // Quorum returns constant quorum-info result
-func (c *StaticCoreServer) QuorumInfo(_ btcjson.QuorumCmd) btcjson.QuorumInfoResult {
-	return c.QuorumInfoResult
+func (c *StaticCoreServer) QuorumInfo(_ btcjson.QuorumCmd) (btcjson.QuorumInfoResult, error) {
+	return c.QuorumInfoResult, nil
 }
 
-// Quorum returns constant quorum-sign result
-func (c *StaticCoreServer) QuorumSign(_ btcjson.QuorumCmd) btcjson.QuorumSignResult {
-	return c.QuorumSignResult
+// QuorumSign returns the request signed with the key share, or constant quorum-sign result
+// if the key share isn't set
+func (c *StaticCoreServer) QuorumSign(cmd btcjson.QuorumCmd) (btcjson.QuorumSignResult, error) {
+	if c.KeyShare == nil {
+		return c.QuorumSignResult, nil
+	}
+	return quorumSignResult(cmd, c.KeyShare.PrivKey), nil
 }
 
 // MasternodeStatus returns constant masternode-status result
@@ -152,6 +428,55 @@ func (c *StaticCoreServer) GetNetworkInfo(_ btcjson.GetNetworkInfoCmd) btcjson.G
 	return c.GetNetworkInfoResult
 }
 
+// GetBestChainLock returns constant best-chain-lock result
+func (c *StaticCoreServer) GetBestChainLock(_ GetBestChainLockCmd) (GetBestChainLockResult, error) {
+	if c.GetBestChainLockResult == (GetBestChainLockResult{}) {
+		return GetBestChainLockResult{}, ErrNoChainLock
+	}
+	return c.GetBestChainLockResult, nil
+}
+
+// GetBlockCount returns constant block-count result
+func (c *StaticCoreServer) GetBlockCount(_ btcjson.GetBlockCountCmd) int64 {
+	return c.GetBlockCountResult
+}
+
+// quorumSignResult signs the sign id of the quorum-sign request with the private key
+func quorumSignResult(cmd btcjson.QuorumCmd, privateKey crypto.PrivKey) btcjson.QuorumSignResult {
+	// Dash Core displays the request id and the message hash in the reversed byte order,
+	// the sign id is calculated using their natural order
+	reqID, err := bytes.FromCoreHex(strVal(cmd.RequestID))
+	if err != nil {
+		panic(err)
+	}
+	msgHash, err := bytes.FromCoreHex(strVal(cmd.MessageHash))
+	if err != nil {
+		panic(err)
+	}
+	quorumHash := quorumHashFromCmd(cmd)
+	signID := crypto.SignId(*cmd.LLMQType, quorumHash.ReversedBytes(), reqID, msgHash)
+	sign, err := privateKey.SignDigest(signID)
+	if err != nil {
+		panic(err)
+	}
+	return btcjson.QuorumSignResult{
+		LLMQType:   int(*cmd.LLMQType),
+		QuorumHash: quorumHash.String(),
+		ID:         reqID.ToCoreHex(),
+		MsgHash:    msgHash.ToCoreHex(),
+		SignHash:   bytes.HexBytes(signID).ToCoreHex(),
+		Signature:  hex.EncodeToString(sign),
+	}
+}
+
+func quorumHashFromCmd(cmd btcjson.QuorumCmd) crypto.QuorumHash {
+	quorumHash, err := hex.DecodeString(strVal(cmd.QuorumHash))
+	if err != nil {
+		panic(err)
+	}
+	return quorumHash
+}
+
 func strVal(s *string) string {
 	if s == nil {
 		return ""
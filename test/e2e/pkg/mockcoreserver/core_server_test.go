@@ -0,0 +1,272 @@
+package mockcoreserver
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMockCoreServerQuorumRotation(t *testing.T) {
+	cs, quorumHashes := newRotationCoreServer(t, 3)
+	cs.ScheduleRotations(map[int64]crypto.QuorumHash{
+		1:  quorumHashes[0],
+		10: quorumHashes[1],
+		20: quorumHashes[2],
+	})
+	testCases := []struct {
+		name    string
+		height  int64
+		rotate  crypto.QuorumHash
+		retired []bool
+	}{
+		{
+			name:    "initial quorum",
+			height:  1,
+			retired: []bool{false, false, false},
+		},
+		{
+			name:    "previous quorum is able to sign during a transition",
+			height:  15,
+			retired: []bool{false, false, false},
+		},
+		{
+			name:    "the oldest quorum is retired",
+			height:  25,
+			retired: []bool{true, false, false},
+		},
+		{
+			name:    "height going back does not rotate quorums",
+			height:  5,
+			retired: []bool{true, false, false},
+		},
+		{
+			name:    "manual rotation",
+			rotate:  quorumHashes[0],
+			retired: []bool{false, true, false},
+		},
+	}
+	for _, tc := range testCases {
+		if tc.rotate != nil {
+			cs.RotateQuorum(tc.rotate, nil)
+		} else {
+			cs.SetHeight(tc.height)
+		}
+		for i, quorumHash := range quorumHashes {
+			_, err := cs.QuorumSign(newQuorumSignCmd(quorumHash))
+			if !tc.retired[i] {
+				assert.NoError(t, err, "%s: quorum #%d", tc.name, i)
+				continue
+			}
+			var rpcErr *btcjson.RPCError
+			if assert.True(t, errors.As(err, &rpcErr), "%s: quorum #%d", tc.name, i) {
+				assert.Equal(t, btcjson.ErrRPCInvalidParameter, rpcErr.Code)
+			}
+		}
+	}
+}
+
+func TestMockCoreServerRotateEvery(t *testing.T) {
+	cs, quorumHashes := newRotationCoreServer(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cs.RotateEvery(ctx, 10*time.Millisecond, quorumHashes...)
+	assert.Eventually(t, func() bool {
+		return cs.isRetired(quorumHashes[0])
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, cs.isRetired(quorumHashes[1]))
+	assert.False(t, cs.isRetired(quorumHashes[2]))
+}
+
+func TestQuorumSignMethodRetiredQuorum(t *testing.T) {
+	addr := "localhost:19994"
+	ctx := context.Background()
+	cs, quorumHashes := newRotationCoreServer(t, 3)
+	for _, quorumHash := range quorumHashes {
+		cs.RotateQuorum(quorumHash, nil)
+	}
+	srv := WithMethods(NewJRPCServer(addr, "/"), WithQuorumSignMethod(cs, Endless))
+	go func() {
+		srv.Start()
+	}()
+	defer srv.Stop(ctx)
+
+	cmd := newQuorumSignCmd(quorumHashes[0])
+	resp := postJRPC(t, "http://"+addr, "quorum", "sign", *cmd.LLMQType, *cmd.RequestID, *cmd.MessageHash,
+		*cmd.QuorumHash, false)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, btcjson.ErrRPCInvalidParameter, resp.Error.Code)
+	assert.Equal(t, "quorum not found", resp.Error.Message)
+}
+
+func TestMockCoreServerMasternodeList(t *testing.T) {
+	cs, _ := newRotationCoreServer(t, 1)
+	proTxHash, err := cs.FilePV.GetProTxHash()
+	require.NoError(t, err)
+	other := crypto.RandProTxHash()
+	cs.ScheduleMasternodeLists(map[int64][]crypto.ProTxHash{
+		10: {proTxHash, other},
+		20: {other},
+		30: {other, proTxHash},
+	})
+	testCases := []struct {
+		height int64
+		state  string
+	}{
+		{height: 1, state: "READY"},
+		{height: 10, state: "READY"},
+		{height: 25, state: "REMOVED"},
+		{height: 30, state: "READY"},
+	}
+	for _, tc := range testCases {
+		cs.SetHeight(tc.height)
+		res := cs.MasternodeStatus(btcjson.MasternodeCmd{})
+		assert.Equal(t, proTxHash.String(), res.ProTxHash)
+		assert.Equal(t, tc.state, res.State, "height %d", tc.height)
+	}
+	cs.SetMasternodeList(nil)
+	assert.Equal(t, "REMOVED", cs.MasternodeStatus(btcjson.MasternodeCmd{}).State)
+}
+
+func newRotationCoreServer(t *testing.T, n int) (*MockCoreServer, []crypto.QuorumHash) {
+	quorumHashes := make([]crypto.QuorumHash, n)
+	keys := make([]crypto.PrivKey, n)
+	for i := range quorumHashes {
+		quorumHashes[i] = crypto.RandQuorumHash()
+		keys[i] = ed25519.GenPrivKey()
+	}
+	filePV, err := privval.NewFilePVWithOptions(
+		privval.WithPrivateKeys(keys, quorumHashes, nil),
+		privval.WithProTxHash(crypto.RandProTxHash()),
+	)
+	require.NoError(t, err)
+	cs := &MockCoreServer{
+		ChainID:  "test-chain",
+		LLMQType: btcjson.LLMQType_5_60,
+		FilePV:   filePV,
+	}
+	return cs, quorumHashes
+}
+
+func newQuorumSignCmd(quorumHash crypto.QuorumHash) btcjson.QuorumCmd {
+	llmqType := btcjson.LLMQType_5_60
+	requestID := crypto.CRandHex(32)
+	messageHash := crypto.CRandHex(32)
+	quorumHashStr := quorumHash.String()
+	return btcjson.QuorumCmd{
+		SubCmd:      btcjson.QuorumSign,
+		LLMQType:    &llmqType,
+		RequestID:   &requestID,
+		MessageHash: &messageHash,
+		QuorumHash:  &quorumHashStr,
+	}
+}
+
+func TestMockCoreServerChainLock(t *testing.T) {
+	cs := &MockCoreServer{}
+	_, err := cs.GetBestChainLock(GetBestChainLockCmd{})
+	assert.Equal(t, ErrNoChainLock, err)
+	assert.EqualValues(t, 0, cs.GetBlockCount(btcjson.GetBlockCountCmd{}))
+
+	chainLock := types.NewMockChainLock(1000)
+	cs.SetChainLock(&chainLock)
+	res, err := cs.GetBestChainLock(GetBestChainLockCmd{})
+	require.NoError(t, err)
+	assert.Equal(t, GetBestChainLockResult{
+		BlockHash:  hex.EncodeToString(chainLock.CoreBlockHash),
+		Height:     1000,
+		Signature:  hex.EncodeToString(chainLock.Signature),
+		KnownBlock: true,
+	}, res)
+	// the block count is never lower than the chain lock height
+	assert.EqualValues(t, 1000, cs.GetBlockCount(btcjson.GetBlockCountCmd{}))
+	cs.SetBlockCount(1005)
+	assert.EqualValues(t, 1005, cs.GetBlockCount(btcjson.GetBlockCountCmd{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cs.AdvanceChainLock(ctx, 10*time.Millisecond, 2)
+	assert.Eventually(t, func() bool {
+		res, err := cs.GetBestChainLock(GetBestChainLockCmd{})
+		return err == nil && res.Height >= 1010
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	assert.GreaterOrEqual(t, cs.GetBlockCount(btcjson.GetBlockCountCmd{}), int64(1010))
+
+	cs.SetChainLock(nil)
+	_, err = cs.GetBestChainLock(GetBestChainLockCmd{})
+	assert.Equal(t, ErrNoChainLock, err)
+}
+
+func TestChainLockMethods(t *testing.T) {
+	addr := "localhost:19993"
+	ctx := context.Background()
+	cs := &StaticCoreServer{GetBlockCountResult: 1005}
+	srv := WithMethods(
+		NewJRPCServer(addr, "/"),
+		WithGetBestChainLockMethod(cs, Endless),
+		WithGetBlockCountMethod(cs, Endless),
+	)
+	go func() {
+		srv.Start()
+	}()
+	defer srv.Stop(ctx)
+
+	resp := postJRPC(t, "http://"+addr, "getbestchainlock")
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, ErrNoChainLock.Code, resp.Error.Code)
+	assert.Equal(t, "Unable to find any ChainLock", resp.Error.Message)
+
+	resp = postJRPC(t, "http://"+addr, "getblockcount")
+	assert.Nil(t, resp.Error)
+	assert.JSONEq(t, "1005", string(resp.Result))
+
+	cs.GetBestChainLockResult = GetBestChainLockResult{BlockHash: "00", Height: 1000, Signature: "01", KnownBlock: true}
+	resp = postJRPC(t, "http://"+addr, "getbestchainlock")
+	assert.Nil(t, resp.Error)
+	assert.JSONEq(t, `{"blockhash":"00","height":1000,"signature":"01","known_block":true}`, string(resp.Result))
+	assert.Equal(t, 2, srv.CallCount("getbestchainlock"))
+	assert.Equal(t, 1, srv.CallCount("getblockcount"))
+}
+
+func TestStaticCoreServerThresholdSignature(t *testing.T) {
+	const threshold = 3
+	quorumPrivKey := bls12381.GenPrivKey()
+	shares, err := bls12381.SplitKey(quorumPrivKey, 4, threshold)
+	require.NoError(t, err)
+
+	quorumHash := crypto.RandQuorumHash()
+	cmd := newQuorumSignCmd(quorumHash)
+	ids := make([]crypto.ProTxHash, len(shares))
+	sigShares := make([][]byte, len(shares))
+	var signHash string
+	for i := range shares {
+		cs := &StaticCoreServer{KeyShare: &shares[i]}
+		res, err := cs.QuorumSign(cmd)
+		require.NoError(t, err)
+		assert.Equal(t, quorumHash.String(), res.QuorumHash)
+		ids[i] = shares[i].ID
+		sigShares[i], err = hex.DecodeString(res.Signature)
+		require.NoError(t, err)
+		signHash = res.SignHash
+	}
+	signID, err := bytes.FromCoreHex(signHash)
+	require.NoError(t, err)
+
+	for _, n := range []int{threshold - 1, threshold, threshold + 1} {
+		sig, err := bls12381.RecoverThresholdSignature(sigShares[:n], ids[:n])
+		require.NoError(t, err)
+		assert.Equal(t, n >= threshold, quorumPrivKey.PubKey().VerifySignatureDigest(signID, sig), "%d shares", n)
+	}
+}
@@ -2,12 +2,16 @@ package mockcoreserver
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strings"
 
 	"github.com/dashevo/dashd-go/btcjson"
 )
@@ -64,7 +68,7 @@ func QueryShouldHave(expectedVales url.Values) ExpectFunc {
 	}
 }
 
-// And ...
+// And is a combinator of expectations, a request should match all of them
 func And(fns ...ExpectFunc) ExpectFunc {
 	return func(req *http.Request) error {
 		for _, fn := range fns {
@@ -77,6 +81,25 @@ func And(fns ...ExpectFunc) ExpectFunc {
 	}
 }
 
+// Or is a combinator of expectations, a request should match at least one of them,
+// an error of mismatch contains the descriptions of all failed expectations
+func Or(fns ...ExpectFunc) ExpectFunc {
+	return func(req *http.Request) error {
+		if len(fns) == 0 {
+			return nil
+		}
+		msgs := make([]string, 0, len(fns))
+		for _, fn := range fns {
+			err := fn(req)
+			if err == nil {
+				return nil
+			}
+			msgs = append(msgs, err.Error())
+		}
+		return fmt.Errorf("none of expectations matched: [%s]", strings.Join(msgs, "; "))
+	}
+}
+
 // JRPCRequest transforms http.Request into btcjson.Request and executes passed list of functions
 func JRPCRequest(fns ...func(req btcjson.Request) error) ExpectFunc {
 	return func(req *http.Request) error {
@@ -104,6 +127,78 @@ func JRPCParamsEmpty() ExpectFunc {
 	})
 }
 
+// MethodParamsCount expects that a JRPC request has exactly n params,
+// a sub-command like "sign" in "quorum sign" is counted as a param
+func MethodParamsCount(n int) ExpectFunc {
+	return JRPCRequest(func(req btcjson.Request) error {
+		if len(req.Params) != n {
+			return fmt.Errorf("jRPC request %q should have %d param(s), actual %d", req.Method, n, len(req.Params))
+		}
+		return nil
+	})
+}
+
+// ParamEquals expects that a JRPC request param at the index is equal to a passed value,
+// both values are compared in their JSON representation, so a value can be passed using any type
+// that is encoded to the same JSON as a param, for instance int64(100) and json.Number("100") are equal
+func ParamEquals(index int, want interface{}) ExpectFunc {
+	wantJSON := mustMarshal(want)
+	var wantVal interface{}
+	mustUnmarshal(wantJSON, &wantVal)
+	return JRPCRequest(func(req btcjson.Request) error {
+		param, err := jRPCParam(req, index)
+		if err != nil {
+			return err
+		}
+		var actualVal interface{}
+		err = json.Unmarshal(param, &actualVal)
+		if err != nil {
+			return fmt.Errorf("unable to decode param #%d of jRPC request %q: %w", index, req.Method, err)
+		}
+		if !reflect.DeepEqual(wantVal, actualVal) {
+			return fmt.Errorf("param #%d of jRPC request %q is not equal\nexpected: %s\nactual: %s",
+				index, req.Method, wantJSON, param)
+		}
+		return nil
+	})
+}
+
+// ParamHexEquals expects that a JRPC request param at the index is a hex string that
+// encodes the same bytes as a passed hex string, the letter case is ignored
+func ParamHexEquals(index int, hexStr string) ExpectFunc {
+	want, err := hex.DecodeString(hexStr)
+	if err != nil {
+		log.Panicf("invalid hex string %q: %v", hexStr, err)
+	}
+	return JRPCRequest(func(req btcjson.Request) error {
+		param, err := jRPCParam(req, index)
+		if err != nil {
+			return err
+		}
+		s := ""
+		err = json.Unmarshal(param, &s)
+		if err != nil {
+			return fmt.Errorf("param #%d of jRPC request %q should be a string, actual %s", index, req.Method, param)
+		}
+		actual, err := hex.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("param #%d of jRPC request %q should be a hex string, actual %q", index, req.Method, s)
+		}
+		if !bytes.Equal(want, actual) {
+			return fmt.Errorf("param #%d of jRPC request %q is not equal\nexpected: %s\nactual: %s",
+				index, req.Method, hexStr, s)
+		}
+		return nil
+	})
+}
+
+func jRPCParam(req btcjson.Request, index int) (json.RawMessage, error) {
+	if index < 0 || index >= len(req.Params) {
+		return nil, fmt.Errorf("jRPC request %q has %d param(s), param #%d not found", req.Method, len(req.Params), index)
+	}
+	return req.Params[index], nil
+}
+
 // Debug is a debug JRPC request handler
 func Debug() ExpectFunc {
 	return func(req *http.Request) error {
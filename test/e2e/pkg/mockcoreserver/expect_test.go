@@ -0,0 +1,195 @@
+package mockcoreserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJRPCMatchers(t *testing.T) {
+	const (
+		requestID  = "4e0fc4b8bb0c0a5ec1d6a2ee1cd1b55ef1b4a3ab3c1f77a1e5bc4c9ee4ad1a19"
+		quorumHash = "000004bfc56646880bfeb80a0b89ad955e557ead7b0f09bcc61e56c8473eaea9"
+	)
+	// quorum sign <llmqType> <requestID> <messageHash> <quorumHash> <submit>
+	params := []interface{}{"sign", 100, requestID, "00", quorumHash, false}
+	testCases := []struct {
+		name    string
+		expect  ExpectFunc
+		wantErr string
+	}{
+		{
+			name:   "param equals a string",
+			expect: ParamEquals(2, requestID),
+		},
+		{
+			name:   "param equals a number",
+			expect: ParamEquals(1, int64(100)),
+		},
+		{
+			name:   "param equals a bool",
+			expect: ParamEquals(5, false),
+		},
+		{
+			name:    "param is not equal",
+			expect:  ParamEquals(1, 101),
+			wantErr: "param #1 of jRPC request \"quorum\" is not equal",
+		},
+		{
+			name:    "param index is out of range",
+			expect:  ParamEquals(6, false),
+			wantErr: "jRPC request \"quorum\" has 6 param(s), param #6 not found",
+		},
+		{
+			name:   "param hex equals ignoring a letter case",
+			expect: ParamHexEquals(4, "000004BFC56646880BFEB80A0B89AD955E557EAD7B0F09BCC61E56C8473EAEA9"),
+		},
+		{
+			name:    "param hex is not equal",
+			expect:  ParamHexEquals(2, quorumHash),
+			wantErr: "param #2 of jRPC request \"quorum\" is not equal",
+		},
+		{
+			name:    "param is not a string",
+			expect:  ParamHexEquals(1, "64"),
+			wantErr: "param #1 of jRPC request \"quorum\" should be a string",
+		},
+		{
+			name:    "param is not a hex string",
+			expect:  ParamHexEquals(0, "00"),
+			wantErr: "param #0 of jRPC request \"quorum\" should be a hex string",
+		},
+		{
+			name:   "params count",
+			expect: MethodParamsCount(6),
+		},
+		{
+			name:    "wrong params count",
+			expect:  MethodParamsCount(5),
+			wantErr: "jRPC request \"quorum\" should have 5 param(s), actual 6",
+		},
+		{
+			name:   "and matches all",
+			expect: And(MethodParamsCount(6), ParamEquals(2, requestID), ParamHexEquals(4, quorumHash)),
+		},
+		{
+			name:    "and fails on the first mismatch",
+			expect:  And(ParamEquals(2, requestID), ParamEquals(1, 1), MethodParamsCount(1)),
+			wantErr: "param #1 of jRPC request \"quorum\" is not equal",
+		},
+		{
+			name:   "or matches any",
+			expect: Or(ParamEquals(1, 1), ParamEquals(1, 100)),
+		},
+		{
+			name:    "or fails if none matched",
+			expect:  Or(ParamEquals(1, 1), MethodParamsCount(1)),
+			wantErr: "none of expectations matched",
+		},
+		{
+			name:   "empty or matches",
+			expect: Or(),
+		},
+	}
+	req := newJRPCTestRequest(t, "quorum", params...)
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.expect(req)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParamHexEqualsPanicsOnInvalidHex(t *testing.T) {
+	assert.Panics(t, func() {
+		ParamHexEquals(0, "zz")
+	})
+}
+
+func TestJRPCServerMismatch(t *testing.T) {
+	addr := "localhost:19997"
+	srv := NewJRPCServer(addr, "/")
+	go func() {
+		srv.Start()
+	}()
+	defer srv.Stop(context.Background())
+	srv.
+		On("quorum sign").
+		Expect(And(MethodParamsCount(3), ParamEquals(2, "01"))).
+		Once().
+		Respond(JRPCResult("signature"), JsonContentType())
+
+	resp := postJRPC(t, "http://"+addr, "quorum", "sign", 100, "02")
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, btcjson.ErrRPCInvalidParams.Code, resp.Error.Code)
+	assert.Contains(t, resp.Error.Message, "the request \"quorum sign\" does not match expectations")
+
+	mismatches := srv.Mismatches()
+	require.Len(t, mismatches, 1)
+	assert.Equal(t, "quorum sign", mismatches[0].Name)
+	assert.Equal(t, resp.Error.Message, mismatches[0].Mismatch)
+
+	mt := &mockT{}
+	assert.False(t, srv.AssertExpectations(mt))
+	assert.Contains(t, mt.msg, "\"quorum sign\": expected 1 call(s), actual 0")
+	assert.Contains(t, mt.msg, mismatches[0].Mismatch)
+
+	resp = postJRPC(t, "http://"+addr, "quorum", "sign", 100, "01")
+	assert.Nil(t, resp.Error)
+	assert.JSONEq(t, `"signature"`, string(resp.Result))
+	assert.Equal(t, 2, srv.CallCount("quorum sign"))
+}
+
+type mockT struct {
+	msg string
+}
+
+func (t *mockT) Errorf(format string, args ...interface{}) {
+	t.msg += fmt.Sprintf(format, args...)
+}
+
+func newJRPCTestRequest(t *testing.T, method string, params ...interface{}) *http.Request {
+	jReq, err := btcjson.NewRequest(1, method, params)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+	return req.WithContext(context.WithValue(req.Context(), jRPCRequestKey, *jReq))
+}
+
+func postJRPC(t *testing.T, url, method string, params ...interface{}) btcjson.Response {
+	jReq, err := btcjson.NewRequest(1, method, params)
+	require.NoError(t, err)
+	body := mustMarshal(jReq)
+	var httpResp *http.Response
+	// the server is started in a separate goroutine, so give it a chance to listen
+	for i := 0; i < 50; i++ {
+		httpResp, err = http.Post(url, "application/json", bytes.NewBuffer(body))
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	buf, err := ioutil.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	require.NoError(t, err)
+	resp := btcjson.Response{}
+	require.NoError(t, json.Unmarshal(buf, &resp))
+	return resp
+}
@@ -1,6 +1,7 @@
 package mockcoreserver
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
@@ -14,22 +15,23 @@ type handler struct {
 
 // ServeHTTP is an entrypoint of a server request
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	h.guard.Lock()
-	defer h.guard.Unlock()
-	c := h.findCall()
+	c := h.reserveCall()
 	if c == nil {
-		log.Fatal("call not found")
+		http.Error(w, fmt.Sprintf("unexpected call %q", h.pattern), http.StatusInternalServerError)
+		return
 	}
 	err := c.execute(w, req)
 	if err != nil {
 		log.Fatalf("URL %s: %s", req.URL.String(), err.Error())
 	}
-	c.actualCnt++
 }
 
-func (h *handler) findCall() *Call {
+// reserveCall returns the first call whose expected number of calls is not exhausted and counts the request
+func (h *handler) reserveCall() *Call {
+	h.guard.Lock()
+	defer h.guard.Unlock()
 	for _, c := range h.calls {
-		if c.expectedCnt == -1 || c.expectedCnt >= c.actualCnt {
+		if c.reserve() {
 			return c
 		}
 	}
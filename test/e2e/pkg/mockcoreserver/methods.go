@@ -20,7 +20,7 @@ func WithQuorumInfoMethod(cs CoreServer, times int) MethodFunc {
 		if err != nil {
 			return nil, err
 		}
-		return cs.QuorumInfo(cmd), nil
+		return cs.QuorumInfo(cmd)
 	})
 	return func(srv *JRPCServer) {
 		srv.
@@ -39,7 +39,7 @@ func WithQuorumSignMethod(cs CoreServer, times int) MethodFunc {
 		if err != nil {
 			return nil, err
 		}
-		return cs.QuorumSign(cmd), nil
+		return cs.QuorumSign(cmd)
 	})
 	return func(srv *JRPCServer) {
 		srv.
@@ -84,6 +84,36 @@ func WithGetNetworkInfoMethod(cs CoreServer, times int) MethodFunc {
 	}
 }
 
+// WithGetBestChainLockMethod ...
+func WithGetBestChainLockMethod(cs CoreServer, times int) MethodFunc {
+	call := OnMethod(func(req btcjson.Request) (interface{}, error) {
+		cmd := GetBestChainLockCmd{}
+		return cs.GetBestChainLock(cmd)
+	})
+	return func(srv *JRPCServer) {
+		srv.
+			On("getbestchainlock").
+			Expect(And(Debug())).
+			Times(times).
+			Respond(call, JsonContentType())
+	}
+}
+
+// WithGetBlockCountMethod ...
+func WithGetBlockCountMethod(cs CoreServer, times int) MethodFunc {
+	call := OnMethod(func(req btcjson.Request) (interface{}, error) {
+		cmd := btcjson.GetBlockCountCmd{}
+		return cs.GetBlockCount(cmd), nil
+	})
+	return func(srv *JRPCServer) {
+		srv.
+			On("getblockcount").
+			Expect(And(Debug())).
+			Times(times).
+			Respond(call, JsonContentType())
+	}
+}
+
 // WithPingMethod ...
 func WithPingMethod(times int) MethodFunc {
 	return func(srv *JRPCServer) {
@@ -108,7 +138,7 @@ func WithGetPeerInfoMethod(times int) MethodFunc {
 }
 
 // WithMethods ...
-func WithMethods(srv *JRPCServer, methods ...func(srv *JRPCServer)) *JRPCServer {
+func WithMethods(srv *JRPCServer, methods ...MethodFunc) *JRPCServer {
 	for _, fn := range methods {
 		fn(srv)
 	}
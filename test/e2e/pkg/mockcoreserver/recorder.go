@@ -0,0 +1,141 @@
+package mockcoreserver
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+)
+
+// TestingT is the subset of testing.TB used by the assertion methods
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type tHelper interface {
+	Helper()
+}
+
+// RecordedCall is a JRPC request received by the mock server
+type RecordedCall struct {
+	// Name is a full name of the call, like "quorum sign" or "ping"
+	Name       string
+	Method     string
+	Params     []json.RawMessage
+	Request    btcjson.Request
+	Time       time.Time
+	RemoteAddr string
+	// Mismatch is a description why the request did not match registered expectations,
+	// it is empty if the request was matched
+	Mismatch string
+	// Overflow is true if the request arrived after the expected number of calls was exhausted
+	Overflow bool
+}
+
+// recorder keeps all received JRPC requests, it is safe for concurrent use
+type recorder struct {
+	mtx   sync.RWMutex
+	calls []RecordedCall
+}
+
+func newRecorder() *recorder {
+	return &recorder{}
+}
+
+func (r *recorder) record(call RecordedCall) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// filter returns the recorded calls matched by either a method or a full call name
+func (r *recorder) filter(method string) []RecordedCall {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var calls []RecordedCall
+	for _, call := range r.calls {
+		if call.Name == method || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (r *recorder) mismatches() []RecordedCall {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var calls []RecordedCall
+	for _, call := range r.calls {
+		if call.Mismatch != "" {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (r *recorder) overflows() []RecordedCall {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var calls []RecordedCall
+	for _, call := range r.calls {
+		if call.Overflow {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Calls returns the list of received requests for a method,
+// the method can be passed either as a name "quorum" or as a full name "quorum sign"
+func (s *JRPCServer) Calls(method string) []RecordedCall {
+	return s.recorder.filter(method)
+}
+
+// CallCount returns the number of received requests for a method
+func (s *JRPCServer) CallCount(method string) int {
+	return len(s.recorder.filter(method))
+}
+
+// Mismatches returns the list of received requests that did not match registered expectations
+func (s *JRPCServer) Mismatches() []RecordedCall {
+	return s.recorder.mismatches()
+}
+
+// Overflows returns the list of received requests that arrived after the expected number of calls was exhausted
+func (s *JRPCServer) Overflows() []RecordedCall {
+	return s.recorder.overflows()
+}
+
+// AssertExpectations fails a test if any expectation set up with Times (Once) or AtLeast was not met,
+// any request did not match registered expectations or arrived after the expected number of calls was exhausted,
+// the failure message lists all of them
+func (s *JRPCServer) AssertExpectations(t TestingT) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	var unmet []string
+	for name, calls := range s.calls {
+		for _, call := range calls {
+			if msg, ok := call.unmet(name); ok {
+				unmet = append(unmet, msg)
+			}
+		}
+	}
+	for _, call := range s.recorder.mismatches() {
+		unmet = append(unmet, call.Mismatch)
+	}
+	for _, call := range s.recorder.overflows() {
+		unmet = append(unmet, (&unexpectedCallError{name: call.Name}).Error())
+	}
+	if len(unmet) > 0 {
+		sort.Strings(unmet)
+		t.Errorf("mock core server has unmet expectations:\n\t%s", strings.Join(unmet, "\n\t"))
+		return false
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package mockcoreserver
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
@@ -65,14 +66,83 @@ func JsonContentType() HandlerOptionFunc {
 }
 
 // OnMethod ...
+// If fn returns *btcjson.RPCError, it is responded as a JRPC error, like Dash Core does it
 func OnMethod(fn func(req btcjson.Request) (interface{}, error)) HandlerOptionFunc {
 	return func(opt *respOption, req *http.Request) error {
 		return JRPCRequest(func(btcReq btcjson.Request) error {
 			val, err := fn(btcReq)
+			var rpcErr *btcjson.RPCError
+			if errors.As(err, &rpcErr) {
+				body, err := btcjson.MarshalResponse(btcReq.ID, nil, rpcErr)
+				if err != nil {
+					return err
+				}
+				opt.status = http.StatusInternalServerError
+				opt.body = bytes.NewBuffer(body)
+				return nil
+			}
 			if err != nil {
 				return err
 			}
+			opt.status = http.StatusOK
 			return JRPCResult(val)(opt, req)
 		})(req)
 	}
 }
+
+// writeJRPCError writes a JRPC error response on a request with the passed http status
+func writeJRPCError(
+	w http.ResponseWriter,
+	status int,
+	req btcjson.Request,
+	code btcjson.RPCErrorCode,
+	msg string,
+) error {
+	body, err := btcjson.MarshalResponse(req.ID, nil, btcjson.NewRPCError(code, msg))
+	if err != nil {
+		return err
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
+
+// HTTPError responds with an http status and a plain text body instead of a JRPC response,
+// like Dash Core rejects requests before they reach the JRPC layer
+func HTTPError(status int, body string) HandlerOptionFunc {
+	return func(opt *respOption, _ *http.Request) error {
+		opt.status = status
+		opt.header["content-type"] = []string{"text/plain"}
+		opt.body = bytes.NewBufferString(body)
+		return nil
+	}
+}
+
+// WorkQueueExceeded responds like Dash Core does when its RPC work queue is full
+func WorkQueueExceeded() HandlerOptionFunc {
+	return HTTPError(http.StatusServiceUnavailable, "Work queue depth exceeded")
+}
+
+// Unauthorized responds like Dash Core does on incorrect rpc credentials
+func Unauthorized() HandlerOptionFunc {
+	return HTTPError(http.StatusUnauthorized, "")
+}
+
+// DropConnection closes the connection without a response, like Dash Core crashing during a request
+func DropConnection() HandlerOptionFunc {
+	return func(opt *respOption, _ *http.Request) error {
+		opt.handlerFunc = func(w http.ResponseWriter, _ *http.Request) {
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				log.Panicf("unable to drop a connection: %T is not a http.Hijacker", w)
+			}
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				log.Panicf("unable to drop a connection: %v", err)
+			}
+			_ = conn.Close()
+		}
+		return nil
+	}
+}
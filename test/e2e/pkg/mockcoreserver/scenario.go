@@ -0,0 +1,209 @@
+package mockcoreserver
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"gopkg.in/yaml.v2"
+)
+
+// scenarioMethods are the methods, which are served by a core server if a scenario sets neither a response
+// nor an error
+var scenarioMethods = map[string]func(cs CoreServer, times int) MethodFunc{
+	"quorum info":       WithQuorumInfoMethod,
+	"quorum sign":       WithQuorumSignMethod,
+	"masternode status": WithMasternodeMethod,
+	"getnetworkinfo":    WithGetNetworkInfoMethod,
+	"getbestchainlock":  WithGetBestChainLockMethod,
+	"getblockcount":     WithGetBlockCountMethod,
+	"ping": func(_ CoreServer, times int) MethodFunc {
+		return WithPingMethod(times)
+	},
+	"getpeerinfo": func(_ CoreServer, times int) MethodFunc {
+		return WithGetPeerInfoMethod(times)
+	},
+}
+
+// Scenario describes the methods of a mock core server in YAML, for example:
+//
+//	methods:
+//	  - method: quorum sign
+//	    times: 10
+//	    delay: 100ms
+//	  - method: quorum sign
+//	    error: {code: -8, message: quorum not found}
+//	  - method: getnetworkinfo
+//	    response: {version: 170000, subversion: /Dash Core:0.17.0/}
+//
+// The registered calls of a method are matched in the order of the scenario.
+type Scenario struct {
+	Methods []ScenarioMethod `yaml:"methods"`
+}
+
+// ScenarioMethod describes a call of a method
+type ScenarioMethod struct {
+	// Method is a method name followed by a sub-command, if any, like "quorum sign"
+	Method string `yaml:"method"`
+	// Times is the expected number of calls, defaults to Endless
+	Times *int `yaml:"times"`
+	// AtLeast is the minimal expected number of calls, the number of calls is not limited then
+	AtLeast int `yaml:"at_least"`
+	// Delay delays every response, like "100ms"
+	Delay time.Duration `yaml:"delay"`
+	// Response is a canned result of the method
+	Response interface{} `yaml:"response"`
+	// Error is responded instead of a result
+	Error *ScenarioError `yaml:"error"`
+}
+
+// ScenarioError is a JRPC error injected by a scenario
+type ScenarioError struct {
+	Code    btcjson.RPCErrorCode `yaml:"code"`
+	Message string               `yaml:"message"`
+}
+
+// LoadScenario reads and validates a scenario file
+func LoadScenario(file string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read a scenario %q: %w", file, err)
+	}
+	scenario, err := ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scenario %q: %w", file, err)
+	}
+	return scenario, nil
+}
+
+// ParseScenario decodes and validates a scenario, unknown fields are rejected
+func ParseScenario(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	err := yaml.UnmarshalStrict(data, scenario)
+	if err != nil {
+		return nil, err
+	}
+	return scenario, scenario.Validate()
+}
+
+// Validate validates a scenario, the error refers to the first invalid method
+func (s *Scenario) Validate() error {
+	if len(s.Methods) == 0 {
+		return errors.New("scenario has no methods")
+	}
+	for i := range s.Methods {
+		m := &s.Methods[i]
+		if m.Method == "" {
+			return fmt.Errorf("method #%d has no name", i+1)
+		}
+		if err := m.validate(); err != nil {
+			return fmt.Errorf("method %q: %w", m.Method, err)
+		}
+	}
+	return nil
+}
+
+func (m *ScenarioMethod) validate() error {
+	_, builtin := scenarioMethods[m.Method]
+	switch {
+	case m.Response != nil && m.Error != nil:
+		return errors.New("response and error are mutually exclusive")
+	case m.Response == nil && m.Error == nil && !builtin:
+		return errors.New("unknown method, a response or an error is required")
+	case m.Times != nil && m.AtLeast != 0:
+		return errors.New("times and at_least are mutually exclusive")
+	case m.Times != nil && *m.Times < 0 && *m.Times != Endless:
+		return fmt.Errorf("times must be positive or %d (endless)", Endless)
+	case m.AtLeast < 0:
+		return errors.New("at_least can't be negative")
+	case m.Delay < 0:
+		return errors.New("delay can't be negative")
+	case m.Error != nil && m.Error.Message == "":
+		return errors.New("error has no message")
+	}
+	if m.Response != nil {
+		response, err := jsonValue(m.Response)
+		if err != nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+		m.Response = response
+	}
+	return nil
+}
+
+// MethodFuncs converts a scenario into methods of a JRPC server, the methods without a response
+// and an error are served by the core server
+func (s *Scenario) MethodFuncs(cs CoreServer) []MethodFunc {
+	methods := make([]MethodFunc, len(s.Methods))
+	for i, m := range s.Methods {
+		methods[i] = m.methodFunc(cs)
+	}
+	return methods
+}
+
+func (m ScenarioMethod) methodFunc(cs CoreServer) MethodFunc {
+	times := Endless
+	if m.Times != nil {
+		times = *m.Times
+	}
+	return func(srv *JRPCServer) {
+		switch {
+		case m.Error != nil:
+			rpcErr := btcjson.NewRPCError(m.Error.Code, m.Error.Message)
+			srv.
+				On(m.Method).
+				Expect(And(Debug())).
+				Respond(OnMethod(func(_ btcjson.Request) (interface{}, error) {
+					return nil, rpcErr
+				}), JsonContentType())
+		case m.Response != nil:
+			srv.
+				On(m.Method).
+				Expect(And(Debug())).
+				Respond(JRPCResult(m.Response), JsonContentType())
+		default:
+			scenarioMethods[m.Method](cs, times)(srv)
+		}
+		call := srv.lastCall(m.Method)
+		if m.AtLeast > 0 {
+			call.AtLeast(m.AtLeast)
+		} else {
+			call.Times(times)
+		}
+		call.Delay(m.Delay)
+	}
+}
+
+// jsonValue converts a decoded YAML value into a value, which can be encoded into JSON
+func jsonValue(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", k)
+			}
+			item, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = item
+		}
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, item := range val {
+			item, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = item
+		}
+		return res, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,132 @@
+package mockcoreserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadScenario(t *testing.T) {
+	// the scenario is used by the dashcore e2e network
+	scenario, err := LoadScenario("../../networks/scenarios/slow_core.yaml")
+	require.NoError(t, err)
+	require.Len(t, scenario.Methods, 7)
+	assert.Equal(t, "quorum sign", scenario.Methods[1].Method)
+	assert.Equal(t, 100*time.Millisecond, scenario.Methods[1].Delay)
+	assert.Equal(t, map[string]interface{}{
+		"version":    170000,
+		"subversion": "/Dash Core:0.17.0/",
+	}, scenario.Methods[3].Response)
+	assert.Equal(t, &ScenarioError{Code: -28, Message: "Loading block index..."}, scenario.Methods[5].Error)
+}
+
+func TestParseScenarioErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "no methods",
+			yaml:    "methods: []",
+			wantErr: "scenario has no methods",
+		},
+		{
+			name:    "unknown field",
+			yaml:    "methods:\n  - method: ping\n    timse: 1",
+			wantErr: "field timse not found",
+		},
+		{
+			name:    "no method name",
+			yaml:    "methods:\n  - method: ping\n  - times: 1",
+			wantErr: "method #2 has no name",
+		},
+		{
+			name:    "unknown method without a response",
+			yaml:    "methods:\n  - method: protx list",
+			wantErr: `method "protx list": unknown method`,
+		},
+		{
+			name:    "response and error",
+			yaml:    "methods:\n  - method: ping\n    response: ok\n    error: {code: -1, message: failed}",
+			wantErr: `method "ping": response and error are mutually exclusive`,
+		},
+		{
+			name:    "times and at_least",
+			yaml:    "methods:\n  - method: ping\n    times: 1\n    at_least: 1",
+			wantErr: `method "ping": times and at_least are mutually exclusive`,
+		},
+		{
+			name:    "negative times",
+			yaml:    "methods:\n  - method: ping\n    times: -2",
+			wantErr: `method "ping": times must be positive`,
+		},
+		{
+			name:    "invalid delay",
+			yaml:    "methods:\n  - method: ping\n    delay: soon",
+			wantErr: "soon",
+		},
+		{
+			name:    "error without a message",
+			yaml:    "methods:\n  - method: getblockcount\n    error: {code: -28}",
+			wantErr: `method "getblockcount": error has no message`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseScenario([]byte(tc.yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestScenarioMethods(t *testing.T) {
+	addr := "localhost:19988"
+	ctx := context.Background()
+	scenario, err := ParseScenario([]byte(`
+methods:
+  - method: getblockcount
+    times: 1
+    error: {code: -28, message: Loading block index...}
+  - method: getblockcount
+    delay: 50ms
+  - method: getnetworkinfo
+    at_least: 1
+    response: {version: 170000, networks: [{name: ipv4}]}
+`))
+	require.NoError(t, err)
+	cs := &StaticCoreServer{GetBlockCountResult: 1000}
+	srv := WithMethods(NewJRPCServer(addr, "/"), scenario.MethodFuncs(cs)...)
+	go func() {
+		srv.Start()
+	}()
+	defer srv.Stop(ctx)
+	waitForListen(t, addr)
+
+	resp := postJRPC(t, "http://"+addr, "getblockcount")
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, btcjson.RPCErrorCode(-28), resp.Error.Code)
+	assert.Equal(t, "Loading block index...", resp.Error.Message)
+
+	start := time.Now()
+	resp = postJRPC(t, "http://"+addr, "getblockcount")
+	require.Nil(t, resp.Error)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	assert.JSONEq(t, "1000", string(resp.Result))
+
+	resp = postJRPC(t, "http://"+addr, "getnetworkinfo")
+	require.Nil(t, resp.Error)
+	res := btcjson.GetNetworkInfoResult{}
+	require.NoError(t, json.Unmarshal(resp.Result, &res))
+	assert.Equal(t, int32(170000), res.Version)
+	require.Len(t, res.Networks, 1)
+	assert.Equal(t, "ipv4", res.Networks[0].Name)
+
+	assert.True(t, srv.AssertExpectations(t))
+}
@@ -3,12 +3,15 @@ package mockcoreserver
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/dashevo/dashd-go/btcjson"
 )
@@ -30,6 +33,8 @@ type HTTPServer struct {
 	guard    sync.Mutex
 	handlers map[string]*handler
 	httpSrv  *http.Server
+	certFile string
+	keyFile  string
 }
 
 // On returns a call structure to setup afterwards
@@ -46,10 +51,21 @@ func (s *HTTPServer) On(pattern string) *Call {
 		s.mux.Handle(pattern, h)
 	}
 	c := &Call{}
+	h.guard.Lock()
 	h.calls = append(h.calls, c)
+	h.guard.Unlock()
 	return c
 }
 
+// WithTLS makes the server serve HTTPS using the certificate and the private key from the passed files
+func (s *HTTPServer) WithTLS(certFile, keyFile string) *HTTPServer {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	s.certFile = certFile
+	s.keyFile = keyFile
+	return s
+}
+
 // Start listens and serves http requests
 func (s *HTTPServer) Start() {
 	s.guard.Lock()
@@ -57,8 +73,14 @@ func (s *HTTPServer) Start() {
 		Addr:    s.addr,
 		Handler: s.mux,
 	}
+	certFile, keyFile := s.certFile, s.keyFile
 	s.guard.Unlock()
-	err := s.httpSrv.ListenAndServe()
+	var err error
+	if certFile != "" {
+		err = s.httpSrv.ListenAndServeTLS(certFile, keyFile)
+	} else {
+		err = s.httpSrv.ListenAndServe()
+	}
 	if err != nil && err != http.ErrServerClosed {
 		log.Fatalf("unexpected stop a server: %v", err)
 	}
@@ -90,6 +112,9 @@ type JRPCServer struct {
 	guard       sync.Mutex
 	endpointURL string
 	calls       map[string][]*Call
+	recorder    *recorder
+	user        string
+	pass        string
 }
 
 // NewJRPCServer creates and returns a new mock of JRPC server
@@ -98,17 +123,32 @@ func NewJRPCServer(addr, endpointURL string) *JRPCServer {
 		httpSrv:     NewHTTPServer(addr),
 		endpointURL: endpointURL,
 		calls:       make(map[string][]*Call),
+		recorder:    newRecorder(),
 	}
 }
 
+// WithBasicAuth makes the server require HTTP basic authentication with the passed credentials like dashd does,
+// a request with missing or incorrect credentials is rejected with HTTP 401 and a JRPC error
+func (s *JRPCServer) WithBasicAuth(user, pass string) *JRPCServer {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	s.user = user
+	s.pass = pass
+	return s
+}
+
+// WithTLS makes the server serve HTTPS using the certificate and the private key from the passed files,
+// see GenerateSelfSignedCert to create them in tests
+func (s *JRPCServer) WithTLS(certFile, keyFile string) *JRPCServer {
+	s.httpSrv.WithTLS(certFile, keyFile)
+	return s
+}
+
 // Start starts listening and handling JRPC requests
 func (s *JRPCServer) Start() {
 	httpCall := s.httpSrv.On(s.endpointURL)
 	httpCall.Forever()
 	httpCall.handlerFunc = func(w http.ResponseWriter, req *http.Request) error {
-		ctx := context.Background()
-		s.guard.Lock()
-		defer s.guard.Unlock()
 		jReq := btcjson.Request{}
 		buf, err := ioutil.ReadAll(req.Body)
 		if err != nil {
@@ -120,36 +160,120 @@ func (s *JRPCServer) Start() {
 		}
 		req.Body = ioutil.NopCloser(bytes.NewBuffer(buf))
 		mustUnmarshal(buf, &jReq)
-		call, err := s.findCall(jReq)
-		if err != nil {
+		// put unmarshalled JRPC request into a context
+		req = req.WithContext(context.WithValue(req.Context(), jRPCRequestKey, jReq))
+		call, err := s.dispatch(jReq, req)
+		var (
+			mismatch   *mismatchError
+			unexpected *unexpectedCallError
+		)
+		switch {
+		case errors.Is(err, errUnauthorized):
+			return writeJRPCError(w, http.StatusUnauthorized, jReq, btcjson.ErrRPCInvalidRequest.Code, err.Error())
+		case errors.As(err, &mismatch):
+			// none of registered calls matched, respond a JRPC error with the mismatch description
+			s.record(req, jReq, err)
+			return writeJRPCError(w, http.StatusInternalServerError, jReq, btcjson.ErrRPCInvalidParams.Code, err.Error())
+		case errors.As(err, &unexpected):
+			s.record(req, jReq, err)
+			return writeJRPCError(w, http.StatusInternalServerError, jReq, btcjson.ErrRPCInvalidRequest.Code, err.Error())
+		case err != nil:
 			return err
 		}
-		// put unmarshalled JRPC request into a context
-		ctx = context.WithValue(req.Context(), jRPCRequestKey, jReq)
-		return call.execute(w, req.WithContext(ctx))
+		s.record(req, jReq, nil)
+		return call.respond(w, req)
 	}
 	s.httpSrv.Start()
 }
 
-func (s *JRPCServer) findCall(req btcjson.Request) (*Call, error) {
-	name, err := callName(req)
+// dispatch checks the credentials of a request and reserves a registered call to respond
+func (s *JRPCServer) dispatch(jReq btcjson.Request, req *http.Request) (*Call, error) {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	if !s.authorized(req) {
+		return nil, errUnauthorized
+	}
+	return s.findCall(jReq, req)
+}
+
+// authorized checks the credentials of a request if the basic authentication is required
+func (s *JRPCServer) authorized(req *http.Request) bool {
+	if s.user == "" && s.pass == "" {
+		return true
+	}
+	user, pass, ok := req.BasicAuth()
+	if !ok {
+		return false
+	}
+	userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(s.user)) == 1
+	passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(s.pass)) == 1
+	return userMatch && passMatch
+}
+
+// findCall returns the first registered call whose expectation matches a request and counts the request,
+// if there are calls for a method but neither matches, the returned error is *mismatchError,
+// if the matched calls are exhausted, the returned error is *unexpectedCallError
+func (s *JRPCServer) findCall(jReq btcjson.Request, req *http.Request) (*Call, error) {
+	name, err := callName(jReq)
 	if err != nil {
 		return nil, err
 	}
 	calls, ok := s.calls[name]
 	if !ok {
-		method := req.Method
-		if len(req.Params) > 0 {
-			method = method + " " + string(req.Params[0])
+		method := jReq.Method
+		if len(jReq.Params) > 0 {
+			method = method + " " + string(jReq.Params[0])
 		}
 		return nil, fmt.Errorf("the expectation for a method %q was not registered", method)
 	}
+	var (
+		mismatch error
+		overflow bool
+	)
 	for _, call := range calls {
-		if call.expectedCnt == -1 || call.actualCnt < call.expectedCnt {
+		err = call.expect(req)
+		if err != nil {
+			if mismatch == nil {
+				mismatch = err
+			}
+			continue
+		}
+		if call.reserve() {
 			return call, nil
 		}
+		overflow = true
+	}
+	if overflow {
+		return nil, &unexpectedCallError{name: name}
+	}
+	if mismatch != nil {
+		return nil, &mismatchError{name: name, err: mismatch}
 	}
-	return nil, fmt.Errorf("unable to find a call fro a method %s", req.Method)
+	return nil, fmt.Errorf("unable to find a call fro a method %s", jReq.Method)
+}
+
+// record saves a received request, err describes why the request was not matched to a registered call
+func (s *JRPCServer) record(httpReq *http.Request, req btcjson.Request, err error) {
+	name, nameErr := callName(req)
+	if nameErr != nil {
+		name = req.Method
+	}
+	call := RecordedCall{
+		Name:       name,
+		Method:     req.Method,
+		Params:     req.Params,
+		Request:    req,
+		Time:       time.Now(),
+		RemoteAddr: httpReq.RemoteAddr,
+	}
+	var unexpected *unexpectedCallError
+	switch {
+	case errors.As(err, &unexpected):
+		call.Overflow = true
+	case err != nil:
+		call.Mismatch = err.Error()
+	}
+	s.recorder.record(call)
 }
 
 // Stop ...
@@ -168,6 +292,56 @@ func (s *JRPCServer) On(pattern string) *Call {
 	return call
 }
 
+// InjectFailures makes the next n calls of a method fail with the passed response,
+// they are served before the calls registered earlier, e.g.
+//
+//	srv.InjectFailures("quorum sign", 2, WorkQueueExceeded())
+func (s *JRPCServer) InjectFailures(pattern string, n int, opts ...HandlerOptionFunc) *Call {
+	call := &Call{}
+	call.Times(n).Respond(opts...)
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	s.calls[pattern] = append([]*Call{call}, s.calls[pattern]...)
+	return call
+}
+
+// lastCall returns the most recently registered call for a pattern
+func (s *JRPCServer) lastCall(pattern string) *Call {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	calls := s.calls[pattern]
+	if len(calls) == 0 {
+		return nil
+	}
+	return calls[len(calls)-1]
+}
+
+// errUnauthorized is returned when a request has missing or incorrect credentials
+var errUnauthorized = errors.New("incorrect or missing rpc credentials")
+
+// unexpectedCallError is returned when a request arrives after the expected number of calls is exhausted
+type unexpectedCallError struct {
+	name string
+}
+
+func (e *unexpectedCallError) Error() string {
+	return fmt.Sprintf("unexpected call %q, the expected number of calls is exhausted", e.name)
+}
+
+// mismatchError is returned when a request does not match expectations of registered calls
+type mismatchError struct {
+	name string
+	err  error
+}
+
+func (e *mismatchError) Error() string {
+	return fmt.Sprintf("the request %q does not match expectations: %v", e.name, e.err)
+}
+
+func (e *mismatchError) Unwrap() error {
+	return e.err
+}
+
 func callName(req btcjson.Request) (string, error) {
 	name := req.Method
 	if len(req.Params) == 0 {
@@ -1,19 +1,36 @@
 package mockcoreserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/dashevo/dashd-go/rpcclient"
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/bls12381"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestServer(t *testing.T) {
@@ -22,6 +39,7 @@ func TestServer(t *testing.T) {
 	go func() {
 		srv.Start()
 	}()
+	waitForListen(t, "localhost:9981")
 	testCases := []struct {
 		url   string
 		e     string
@@ -66,29 +84,28 @@ func TestServer(t *testing.T) {
 func TestDashCoreSignerPingMethod(t *testing.T) {
 	addr := "localhost:19998"
 	ctx := context.Background()
-	srv := NewJRPCServer(addr, "/")
-	go func() {
-		srv.Start()
-	}()
-	srv = WithMethods(
-		srv,
+	srv := WithMethods(
+		NewJRPCServer(addr, "/"),
 		WithPingMethod(1),
 		WithGetPeerInfoMethod(1),
 	)
+	go func() {
+		srv.Start()
+	}()
+	waitForListen(t, addr)
 	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60)
 	assert.NoError(t, err)
 	err = client.Ping()
 	assert.NoError(t, err)
+	assert.Equal(t, 1, srv.CallCount("ping"))
+	assert.Equal(t, 1, srv.CallCount("getpeerinfo"))
+	srv.AssertExpectations(t)
 	srv.Stop(ctx)
 }
 
 func TestGetPubKey(t *testing.T) {
 	addr := "localhost:19998"
 	ctx := context.Background()
-	srv := NewJRPCServer(addr, "/")
-	go func() {
-		srv.Start()
-	}()
 	proTxHash := "6c91363d97b286e921afb5cf7672c88a2f1614d36d32058c34bef8b44e026007"
 	cs := &StaticCoreServer{
 		QuorumInfoResult: btcjson.QuorumInfoResult{
@@ -112,12 +129,16 @@ func TestGetPubKey(t *testing.T) {
 		},
 		GetNetworkInfoResult: btcjson.GetNetworkInfoResult{},
 	}
-	srv = WithMethods(
-		srv,
+	srv := WithMethods(
+		NewJRPCServer(addr, "/"),
 		WithQuorumInfoMethod(cs, Endless),
 		WithMasternodeMethod(cs, Endless),
 		WithGetNetworkInfoMethod(cs, Endless),
 	)
+	go func() {
+		srv.Start()
+	}()
+	waitForListen(t, addr)
 	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60)
 	assert.NoError(t, err)
 	quorumHash := crypto.RandQuorumHash()
@@ -125,5 +146,783 @@ func TestGetPubKey(t *testing.T) {
 	assert.NoError(t, err)
 	b, _ := hex.DecodeString("83349BA8363E5C03E9D6318B0491E38305CF59D9D57CEA2295A86ECFA696622571F266C28BACC78666E8B9B0FB2B3123")
 	assert.True(t, pubKey.Equals(bls12381.PubKey(b)))
+	calls := srv.Calls("quorum info")
+	if assert.Len(t, calls, 1) {
+		s := ""
+		mustUnmarshal(calls[0].Params[2], &s)
+		assert.Equal(t, quorumHash.String(), s)
+	}
+	srv.AssertExpectations(t)
 	srv.Stop(ctx)
 }
+
+func TestJRPCServerBasicAuth(t *testing.T) {
+	addr := "localhost:19996"
+	ctx := context.Background()
+	srv := WithMethods(NewJRPCServer(addr, "/").WithBasicAuth("dashrpc", "rpcpassword"), WithPingMethod(Endless))
+	go func() {
+		srv.Start()
+	}()
+	defer srv.Stop(ctx)
+	waitForListen(t, addr)
+
+	testCases := []struct {
+		name       string
+		user, pass string
+		wantStatus int
+	}{
+		{
+			name:       "correct credentials",
+			user:       "dashrpc",
+			pass:       "rpcpassword",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "wrong password",
+			user:       "dashrpc",
+			pass:       "wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing credentials",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			jReq, err := btcjson.NewRequest(1, "ping", nil)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, "http://"+addr, bytes.NewBuffer(mustMarshal(jReq)))
+			require.NoError(t, err)
+			if tc.user != "" {
+				req.SetBasicAuth(tc.user, tc.pass)
+			}
+			httpResp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			buf, err := ioutil.ReadAll(httpResp.Body)
+			_ = httpResp.Body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, httpResp.StatusCode)
+			resp := btcjson.Response{}
+			require.NoError(t, json.Unmarshal(buf, &resp))
+			if tc.wantStatus == http.StatusOK {
+				assert.Nil(t, resp.Error)
+				return
+			}
+			if assert.NotNil(t, resp.Error) {
+				assert.Equal(t, btcjson.ErrRPCInvalidRequest.Code, resp.Error.Code)
+			}
+		})
+	}
+	// rejected requests are not recorded
+	assert.Equal(t, 1, srv.CallCount("ping"))
+
+	client, err := privval.NewDashCoreSignerClient(addr, "dashrpc", "wrong", btcjson.LLMQType_5_60)
+	require.NoError(t, err)
+	assert.Error(t, client.Ping())
+}
+
+func TestJRPCServerTLS(t *testing.T) {
+	addr := "localhost:19995"
+	ctx := context.Background()
+	certFile, keyFile, err := GenerateSelfSignedCert(t.TempDir(), "localhost", "127.0.0.1")
+	require.NoError(t, err)
+	rootCA, err := ioutil.ReadFile(certFile)
+	require.NoError(t, err)
+	srv := WithMethods(NewJRPCServer(addr, "/").WithTLS(certFile, keyFile), WithPingMethod(Endless))
+	go func() {
+		srv.Start()
+	}()
+	defer srv.Stop(ctx)
+	waitForListen(t, addr)
+
+	// the client trusts the self-signed certificate
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         addr,
+		User:         "dashrpc",
+		Pass:         "rpcpassword",
+		HTTPPostMode: true,
+		Certificates: rootCA,
+	}, nil)
+	require.NoError(t, err)
+	defer client.Shutdown()
+	assert.NoError(t, client.Ping())
+	assert.Equal(t, 1, srv.CallCount("ping"))
+
+	// the handshake fails without the root CA
+	untrusted, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         addr,
+		User:         "dashrpc",
+		Pass:         "rpcpassword",
+		HTTPPostMode: true,
+	}, nil)
+	require.NoError(t, err)
+	defer untrusted.Shutdown()
+	assert.Error(t, untrusted.Ping())
+	assert.Equal(t, 1, srv.CallCount("ping"))
+}
+
+// waitForListen waits until the server, started in a separate goroutine, accepts connections
+func waitForListen(t testing.TB, addr string) {
+	var err error
+	for i := 0; i < 50; i++ {
+		var conn net.Conn
+		conn, err = net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+}
+
+func TestJRPCServerCallCount(t *testing.T) {
+	const requests = 5
+	testCases := []struct {
+		name          string
+		addr          string
+		setup         func(call *Call)
+		requests      int
+		wantOK        int
+		wantAssert    bool
+		wantAssertMsg string
+	}{
+		{
+			name:          "times is exhausted",
+			addr:          "localhost:19992",
+			setup:         func(call *Call) { call.Times(2) },
+			requests:      requests,
+			wantOK:        2,
+			wantAssertMsg: "unexpected call \"getblockcount\", the expected number of calls is exhausted",
+		},
+		{
+			name:       "endless never exhausts",
+			addr:       "localhost:19991",
+			setup:      func(call *Call) { call.Times(Endless) },
+			requests:   requests,
+			wantOK:     requests,
+			wantAssert: true,
+		},
+		{
+			name:       "at least allows unlimited calls",
+			addr:       "localhost:19990",
+			setup:      func(call *Call) { call.AtLeast(3) },
+			requests:   requests,
+			wantOK:     requests,
+			wantAssert: true,
+		},
+		{
+			name:          "at least is not met",
+			addr:          "localhost:19989",
+			setup:         func(call *Call) { call.AtLeast(3) },
+			requests:      2,
+			wantOK:        2,
+			wantAssertMsg: "\"getblockcount\": expected at least 3 call(s), actual 2",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv := NewJRPCServer(tc.addr, "/")
+			call := srv.On("getblockcount")
+			tc.setup(call)
+			call.Respond(JRPCResult(1000), JsonContentType())
+			go func() {
+				srv.Start()
+			}()
+			defer srv.Stop(context.Background())
+			waitForListen(t, tc.addr)
+
+			responses := make(chan btcjson.Response, tc.requests)
+			var wg sync.WaitGroup
+			for i := 0; i < tc.requests; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					responses <- postJRPC(t, "http://"+tc.addr, "getblockcount")
+				}()
+			}
+			wg.Wait()
+			close(responses)
+			ok := 0
+			for resp := range responses {
+				if resp.Error == nil {
+					ok++
+					continue
+				}
+				assert.Equal(t, btcjson.ErrRPCInvalidRequest.Code, resp.Error.Code)
+				assert.Contains(t, resp.Error.Message, "unexpected call")
+			}
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Len(t, srv.Overflows(), tc.requests-tc.wantOK)
+			assert.Equal(t, tc.requests, srv.CallCount("getblockcount"))
+
+			mt := &mockT{}
+			assert.Equal(t, tc.wantAssert, srv.AssertExpectations(mt))
+			if tc.wantAssertMsg != "" {
+				assert.Contains(t, mt.msg, tc.wantAssertMsg)
+			}
+		})
+	}
+}
+
+func newSignerCoreServer() *StaticCoreServer {
+	return &StaticCoreServer{
+		QuorumInfoResult: btcjson.QuorumInfoResult{
+			QuorumPublicKey: "0644ff153b9b92c6a59e2adf4ef0b9836f7f6af05fe432ffdcb69bc9e300a2a70af4a8d9fc61323f6b81074d740033d2",
+		},
+		MasternodeStatusResult: btcjson.MasternodeStatusResult{
+			ProTxHash: "6c91363d97b286e921afb5cf7672c88a2f1614d36d32058c34bef8b44e026007",
+		},
+	}
+}
+
+func TestDashCoreSignerRetry(t *testing.T) {
+	testCases := []struct {
+		name         string
+		addr         string
+		fail         HandlerOptionFunc
+		failures     int
+		wantErr      error
+		wantCalls    int
+		wantFailures int
+	}{
+		{
+			name:      "work queue exceeded is retried",
+			addr:      "localhost:19987",
+			fail:      WorkQueueExceeded(),
+			failures:  2,
+			wantCalls: 3,
+		},
+		{
+			name:      "dropped connection is retried",
+			addr:      "localhost:19986",
+			fail:      DropConnection(),
+			failures:  2,
+			wantCalls: 3,
+		},
+		{
+			name:         "auth failure is not retried",
+			addr:         "localhost:19985",
+			fail:         Unauthorized(),
+			failures:     1,
+			wantErr:      errors.New("status code: 401"),
+			wantCalls:    1,
+			wantFailures: 1,
+		},
+		{
+			name:     "retry timeout expires",
+			addr:     "localhost:19984",
+			fail:     WorkQueueExceeded(),
+			failures: Endless,
+			wantErr:  privval.ErrCoreUnavailable,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv := WithMethods(NewJRPCServer(tc.addr, "/"), WithQuorumInfoMethod(newSignerCoreServer(), Endless))
+			srv.InjectFailures("quorum info", tc.failures, tc.fail)
+			go func() {
+				srv.Start()
+			}()
+			defer srv.Stop(context.Background())
+			waitForListen(t, tc.addr)
+
+			client, err := privval.NewDashCoreSignerClient(tc.addr, "root", "root", btcjson.LLMQType_5_60,
+				privval.DashCoreSignerClientRetryTimeout(500*time.Millisecond),
+				privval.DashCoreSignerClientHealthCheckInterval(0),
+			)
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.GetThresholdPublicKey(crypto.RandQuorumHash())
+			switch {
+			case tc.wantErr == privval.ErrCoreUnavailable:
+				require.True(t, errors.Is(err, privval.ErrCoreUnavailable), err)
+				assert.Greater(t, srv.CallCount("quorum info"), 1)
+				assert.Greater(t, client.ConsecutiveFailures(), 1)
+				return
+			case tc.wantErr != nil:
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr.Error())
+			default:
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantCalls, srv.CallCount("quorum info"))
+			assert.Equal(t, tc.wantFailures, client.ConsecutiveFailures())
+		})
+	}
+}
+
+func TestDashCoreSignerReconnect(t *testing.T) {
+	addr := "localhost:19983"
+	cs := newSignerCoreServer()
+	srv := WithMethods(
+		NewJRPCServer(addr, "/"),
+		WithQuorumInfoMethod(cs, Endless),
+		WithGetNetworkInfoMethod(cs, Endless),
+	)
+	go func() {
+		srv.Start()
+	}()
+	waitForListen(t, addr)
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientRetryTimeout(5*time.Second),
+		privval.DashCoreSignerClientHealthCheckInterval(20*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.GetThresholdPublicKey(crypto.RandQuorumHash())
+	require.NoError(t, err)
+
+	// the probes notice Dash Core is down
+	srv.Stop(context.Background())
+	require.Eventually(t, func() bool {
+		return client.ConsecutiveFailures() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// the sign requests wait for Dash Core to restart
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		srv.Start()
+	}()
+	defer srv.Stop(context.Background())
+	_, err = client.GetThresholdPublicKey(crypto.RandQuorumHash())
+	require.NoError(t, err)
+	assert.Equal(t, 0, client.ConsecutiveFailures())
+}
+
+// newSigningCoreServer returns a mock core-server, which signs with the BLS keys of n quorums
+func newSigningCoreServer(t testing.TB, n int) (*MockCoreServer, []crypto.QuorumHash) {
+	quorumHashes := make([]crypto.QuorumHash, n)
+	keys := make([]crypto.PrivKey, n)
+	thresholdPublicKeys := make([]crypto.PubKey, n)
+	for i := range quorumHashes {
+		quorumHashes[i] = crypto.RandQuorumHash()
+		keys[i] = bls12381.GenPrivKey()
+		thresholdPublicKeys[i] = bls12381.GenPrivKey().PubKey()
+	}
+	filePV, err := privval.NewFilePVWithOptions(
+		privval.WithPrivateKeys(keys, quorumHashes, &thresholdPublicKeys),
+		privval.WithProTxHash(crypto.RandProTxHash()),
+	)
+	require.NoError(t, err)
+	filePV.Key.FirstHeightOfQuorums = make(map[string]string, n)
+	for _, quorumHash := range quorumHashes {
+		filePV.Key.FirstHeightOfQuorums[quorumHash.String()] = "1"
+	}
+	cs := &MockCoreServer{
+		ChainID:  "test-chain",
+		LLMQType: btcjson.LLMQType_5_60,
+		FilePV:   filePV,
+	}
+	return cs, quorumHashes
+}
+
+func startSigningCoreServer(t testing.TB, addr string, cs CoreServer) *JRPCServer {
+	srv := WithMethods(
+		NewJRPCServer(addr, "/"),
+		WithQuorumInfoMethod(cs, Endless),
+		WithQuorumSignMethod(cs, Endless),
+		WithMasternodeMethod(cs, Endless),
+	)
+	go func() {
+		srv.Start()
+	}()
+	waitForListen(t, addr)
+	return srv
+}
+
+func newSignVote(height int64) *tmproto.Vote {
+	return &tmproto.Vote{
+		Type:   tmproto.PrecommitType,
+		Height: height,
+		BlockID: tmproto.BlockID{
+			Hash:          crypto.CRandBytes(crypto.DefaultHashSize),
+			PartSetHeader: tmproto.PartSetHeader{Total: 1, Hash: crypto.CRandBytes(crypto.DefaultHashSize)},
+		},
+		StateID:            tmproto.StateID{LastAppHash: crypto.CRandBytes(crypto.DefaultHashSize)},
+		ValidatorProTxHash: crypto.RandProTxHash(),
+	}
+}
+
+func TestDashCoreSignerGetBestChainLock(t *testing.T) {
+	addr := "localhost:19973"
+	cs := &MockCoreServer{}
+	srv := WithMethods(
+		NewJRPCServer(addr, "/"),
+		WithGetBestChainLockMethod(cs, Endless),
+	)
+	go func() {
+		srv.Start()
+	}()
+	waitForListen(t, addr)
+	defer srv.Stop(context.Background())
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Dash Core doesn't know any chain lock yet, it's not retried
+	_, err = client.GetBestChainLock()
+	assert.Equal(t, privval.ErrNoChainLock, err)
+	assert.Equal(t, 1, srv.CallCount("getbestchainlock"))
+
+	chainLock := types.NewMockChainLock(1000)
+	cs.SetChainLock(&chainLock)
+	best, err := client.GetBestChainLock()
+	require.NoError(t, err)
+	assert.Equal(t, &chainLock, best)
+}
+
+func TestDashCoreSignerQuorumCache(t *testing.T) {
+	addr := "localhost:19982"
+	cs, quorumHashes := newSigningCoreServer(t, 2)
+	cs.ActiveQuorumCount = 1
+	cs.RotateQuorum(quorumHashes[0], nil)
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// the quorum info is requested once for all votes
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], newSignVote(height)))
+	}
+	assert.Equal(t, 1, srv.CallCount("quorum info"))
+
+	// the retired quorum is invalidated, the new one is requested
+	cs.RotateQuorum(quorumHashes[1], nil)
+	require.Error(t, client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], newSignVote(4)))
+	require.NoError(t, client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[1], newSignVote(4)))
+	assert.Equal(t, 2, srv.CallCount("quorum info"))
+	_, err = client.GetPubKey(quorumHashes[0])
+	require.NoError(t, err)
+	assert.Equal(t, 3, srv.CallCount("quorum info"))
+
+	// the refresh drops all quorums
+	client.Refresh()
+	_, err = client.GetThresholdPublicKey(quorumHashes[1])
+	require.NoError(t, err)
+	assert.Equal(t, 4, srv.CallCount("quorum info"))
+}
+
+func TestDashCoreSignerQuorumCacheTTL(t *testing.T) {
+	addr := "localhost:19981"
+	cs, quorumHashes := newSigningCoreServer(t, 1)
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+		privval.DashCoreSignerClientQuorumCacheTTL(time.Nanosecond),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetPubKey(quorumHashes[0])
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, srv.CallCount("quorum info"))
+}
+
+func TestDashCoreSignerInvalidSignatureShare(t *testing.T) {
+	addr := "localhost:19980"
+	cs, quorumHashes := newSigningCoreServer(t, 1)
+	proTxHash, err := cs.FilePV.GetProTxHash()
+	require.NoError(t, err)
+	// Dash Core reports a public key share, which doesn't match the signatures
+	cs.RotateQuorum(quorumHashes[0], []btcjson.QuorumMember{{
+		ProTxHash:   proTxHash.String(),
+		Valid:       true,
+		PubKeyShare: bls12381.GenPrivKey().PubKey().HexString(),
+	}})
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], newSignVote(1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid signature share")
+	// the quorum info is refreshed once before giving up
+	assert.Equal(t, 2, srv.CallCount("quorum info"))
+}
+
+func TestDashCoreSignerQuorumTypeRotation(t *testing.T) {
+	addr := "localhost:19975"
+	cs, quorumHashes := newSigningCoreServer(t, 2)
+	// the quorums of both types are served at the same time
+	cs.SetQuorumType(quorumHashes[0], btcjson.LLMQType_5_60)
+	cs.SetQuorumType(quorumHashes[1], btcjson.LLMQType_100_67)
+	cs.RotateQuorum(quorumHashes[0], nil)
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	for height := int64(1); height <= 2; height++ {
+		require.NoError(t, client.SignVote(cs.ChainID, btcjson.LLMQType_5_60, quorumHashes[0], newSignVote(height)))
+	}
+
+	// the quorum type changes at the rotation
+	cs.RotateQuorum(quorumHashes[1], nil)
+	_, err = client.GetPubKey(quorumHashes[1])
+	require.Error(t, err, "the default quorum type doesn't match the new quorum")
+	client.SetQuorumType(quorumHashes[1], btcjson.LLMQType_100_67)
+	pubKey, err := client.GetPubKey(quorumHashes[1])
+	require.NoError(t, err)
+	expectedPubKey, err := cs.FilePV.GetPubKey(quorumHashes[1])
+	require.NoError(t, err)
+	assert.Equal(t, expectedPubKey, pubKey)
+
+	require.NoError(t, client.SignVote(cs.ChainID, btcjson.LLMQType_100_67, quorumHashes[1], newSignVote(3)))
+	proposal := &tmproto.Proposal{
+		Type:     tmproto.ProposalType,
+		Height:   3,
+		PolRound: -1,
+		BlockID:  newSignVote(3).BlockID,
+	}
+	_, err = client.SignProposal(cs.ChainID, btcjson.LLMQType_100_67, quorumHashes[1], proposal)
+	require.NoError(t, err)
+	assert.Len(t, proposal.Signature, bls12381.SignatureSize)
+
+	// the previous quorum still signs with its quorum type
+	require.NoError(t, client.SignVote(cs.ChainID, btcjson.LLMQType_5_60, quorumHashes[0], newSignVote(4)))
+	require.Error(t, client.SignVote(cs.ChainID, btcjson.LLMQType_5_60, quorumHashes[1], newSignVote(4)))
+}
+
+func BenchmarkDashCoreSignerSignVote(b *testing.B) {
+	testCases := []struct {
+		name      string
+		addr      string
+		cacheSize int
+	}{
+		{name: "cached", addr: "localhost:19979", cacheSize: 32},
+		{name: "uncached", addr: "localhost:19978", cacheSize: 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		b.Run(tc.name, func(b *testing.B) {
+			cs, quorumHashes := newSigningCoreServer(b, 1)
+			srv := startSigningCoreServer(b, tc.addr, cs)
+			defer srv.Stop(context.Background())
+			client, err := privval.NewDashCoreSignerClient(tc.addr, "root", "root", btcjson.LLMQType_5_60,
+				privval.DashCoreSignerClientHealthCheckInterval(0),
+				privval.DashCoreSignerClientQuorumCacheSize(tc.cacheSize),
+			)
+			require.NoError(b, err)
+			defer client.Close()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				err := client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], newSignVote(int64(i+1)))
+				require.NoError(b, err)
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(srv.CallCount("quorum info"))/float64(b.N), "quorum-info/op")
+		})
+	}
+}
+
+func TestDashCoreFallbackSignerClient(t *testing.T) {
+	addr := "localhost:19977"
+	cs, quorumHashes := newSigningCoreServer(t, 1)
+	quorumHash := quorumHashes[0]
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	// the fallback uses the same key share as Dash Core
+	dir := t.TempDir()
+	filePV, err := privval.NewFilePVWithOptions(
+		privval.WithPrivateKeysMap(cs.FilePV.Key.PrivateKeys),
+		privval.WithProTxHash(cs.FilePV.Key.ProTxHash),
+		privval.WithKeyAndStateFilePaths(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")),
+	)
+	require.NoError(t, err)
+	core, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientRetryTimeout(200*time.Millisecond),
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+	client := privval.NewDashCoreFallbackSignerClient(core, filePV, tmlog.TestingLogger())
+	defer client.Close()
+
+	signVote := func(vote *tmproto.Vote) (*tmproto.Vote, error) {
+		vote = proto.Clone(vote).(*tmproto.Vote)
+		return vote, client.SignVote(cs.ChainID, cs.LLMQType, quorumHash, vote)
+	}
+	conflicting := func(vote *tmproto.Vote) *tmproto.Vote {
+		vote = proto.Clone(vote).(*tmproto.Vote)
+		vote.BlockID.Hash = crypto.CRandBytes(crypto.DefaultHashSize)
+		return vote
+	}
+
+	// Dash Core signs the prevote
+	prevote := newSignVote(1)
+	prevote.Type = tmproto.PrevoteType
+	signedPrevote, err := signVote(prevote)
+	require.NoError(t, err)
+	assert.Equal(t, 2, srv.CallCount("quorum sign"))
+
+	// Dash Core goes away mid-height, the precommit is signed with the file key
+	srv.Stop(context.Background())
+	_, err = signVote(conflicting(prevote))
+	assert.Error(t, err, "the fallback must not sign conflicting data for the step signed by Dash Core")
+	precommit := newSignVote(1)
+	signedPrecommit, err := signVote(precommit)
+	require.NoError(t, err)
+	resigned, err := signVote(precommit)
+	require.NoError(t, err)
+	assert.Equal(t, signedPrecommit.BlockSignature, resigned.BlockSignature)
+	assert.Equal(t, signedPrecommit.StateSignature, resigned.StateSignature)
+
+	// Dash Core returns
+	go func() {
+		srv.Start()
+	}()
+	waitForListen(t, addr)
+	calls := srv.CallCount("quorum sign")
+	_, err = signVote(conflicting(precommit))
+	assert.Error(t, err, "Dash Core must not sign conflicting data for the step signed by the fallback")
+	_, err = signVote(prevote)
+	assert.Error(t, err, "the steps must not regress")
+	assert.Equal(t, calls, srv.CallCount("quorum sign"))
+
+	// the next height is signed with Dash Core again, the signatures match the file key
+	nextPrevote := newSignVote(2)
+	nextPrevote.Type = tmproto.PrevoteType
+	signedNextPrevote, err := signVote(nextPrevote)
+	require.NoError(t, err)
+	assert.Equal(t, calls+2, srv.CallCount("quorum sign"))
+	blockSignID := types.VoteBlockSignId(cs.ChainID, signedNextPrevote, cs.LLMQType, quorumHash)
+	pubKey, err := filePV.GetPubKey(quorumHash)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifySignatureDigest(blockSignID, signedNextPrevote.BlockSignature))
+	assert.NotEqual(t, signedPrevote.BlockSignature, signedNextPrevote.BlockSignature)
+}
+
+func TestDashCoreSignerMetrics(t *testing.T) {
+	addr := "localhost:19976"
+	cs, quorumHashes := newSigningCoreServer(t, 1)
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	client, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientRetryTimeout(100*time.Millisecond),
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+		privval.DashCoreSignerClientMetrics(privval.PrometheusMetrics("test_dash_core_signer", "chain_id", cs.ChainID)),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	prevote := newSignVote(1)
+	prevote.Type = tmproto.PrevoteType
+	require.NoError(t, client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], prevote))
+	require.NoError(t, client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], newSignVote(1)))
+	proposal := &tmproto.Proposal{
+		Type:     tmproto.ProposalType,
+		Height:   2,
+		PolRound: -1,
+		BlockID:  newSignVote(2).BlockID,
+	}
+	_, err = client.SignProposal(cs.ChainID, cs.LLMQType, quorumHashes[0], proposal)
+	require.NoError(t, err)
+
+	// Dash Core goes away
+	srv.Stop(context.Background())
+	err = client.SignVote(cs.ChainID, cs.LLMQType, quorumHashes[0], newSignVote(3))
+	require.True(t, errors.Is(err, privval.ErrCoreUnavailable), err)
+
+	for name, count := range map[string]int{
+		// a prevote and a precommit
+		"test_dash_core_signer_privval_sign_vote_seconds":     2,
+		"test_dash_core_signer_privval_sign_proposal_seconds": 1,
+	} {
+		n, err := testutil.GatherAndCount(prometheus.DefaultGatherer, name)
+		require.NoError(t, err)
+		assert.Equal(t, count, n, name)
+	}
+	expected := `
+# HELP test_dash_core_signer_privval_last_signed_height Height of the last successful signature.
+# TYPE test_dash_core_signer_privval_last_signed_height gauge
+test_dash_core_signer_privval_last_signed_height{chain_id="test-chain"} 2
+# HELP test_dash_core_signer_privval_sign_failures Number of failed sign requests.
+# TYPE test_dash_core_signer_privval_sign_failures counter
+test_dash_core_signer_privval_sign_failures{chain_id="test-chain",class="core_unavailable",type="precommit"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected),
+		"test_dash_core_signer_privval_last_signed_height", "test_dash_core_signer_privval_sign_failures"))
+}
+
+func TestRemoteDashCoreSigner(t *testing.T) {
+	addr := "localhost:19974"
+	cs, quorumHashes := newSigningCoreServer(t, 1)
+	quorumHash := quorumHashes[0]
+	srv := startSigningCoreServer(t, addr, cs)
+	defer srv.Stop(context.Background())
+
+	// the remote signer hosts the Dash Core signer, the node only talks to the remote signer
+	core, err := privval.NewDashCoreSignerClient(addr, "root", "root", btcjson.LLMQType_5_60,
+		privval.DashCoreSignerClientHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+	defer core.Close()
+
+	laddr := "unix://" + filepath.Join(t.TempDir(), "privval.sock")
+	listener, err := privval.NewSignerListener(laddr, tmlog.TestingLogger())
+	require.NoError(t, err)
+	_, address := tmnet.ProtocolAndAddress(laddr)
+	dialer := privval.NewSignerDialerEndpoint(tmlog.TestingLogger(), privval.DialUnixFn(address))
+	signerServer := privval.NewSignerServer(dialer, cs.ChainID, core)
+	require.NoError(t, signerServer.Start())
+	defer func() { _ = signerServer.Stop() }()
+	client, err := privval.NewSignerClient(listener, cs.ChainID)
+	require.NoError(t, err)
+	defer client.Close()
+
+	pubKey, err := cs.FilePV.GetPubKey(quorumHash)
+	require.NoError(t, err)
+	proTxHash, err := cs.FilePV.GetProTxHash()
+	require.NoError(t, err)
+
+	vote := newSignVote(1)
+	require.NoError(t, client.SignVote(cs.ChainID, cs.LLMQType, quorumHash, vote))
+	blockSignID := types.VoteBlockSignId(cs.ChainID, vote, cs.LLMQType, quorumHash)
+	assert.True(t, pubKey.VerifySignatureDigest(blockSignID, vote.BlockSignature))
+
+	requestID := crypto.CRandBytes(crypto.DefaultHashSize)
+	messageHash := crypto.CRandBytes(crypto.DefaultHashSize)
+	signature, err := client.QuorumSign(cs.LLMQType, quorumHash, requestID, messageHash)
+	require.NoError(t, err)
+	signID := crypto.SignId(cs.LLMQType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(requestID),
+		bls12381.ReverseBytes(messageHash))
+	assert.True(t, pubKey.VerifySignatureDigest(signID, signature))
+
+	signature, err = client.SignNodeID(cs.ChainID, "node-id", cs.LLMQType, quorumHash)
+	require.NoError(t, err)
+	assert.NoError(t, types.VerifyNodeIDSignature(cs.ChainID, "node-id", proTxHash, cs.LLMQType, quorumHash,
+		pubKey, signature))
+
+	// a vote, a node ID and a message signed with Dash Core
+	assert.Equal(t, 4, srv.CallCount("quorum sign"))
+}
@@ -7,31 +7,40 @@ import (
 	"fmt"
 	"github.com/dashevo/dashd-go/btcjson"
 	"io"
+	"math"
 	"math/rand"
 	"net"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/test/e2e/pkg/mockcoreserver"
 	mcs "github.com/tendermint/tendermint/test/maverick/consensus"
 )
 
 const (
 	randomSeed     int64  = 2308084734268
+	// quorumRotationHeights is the number of heights, for which quorum rotations are scheduled
+	quorumRotationHeights int64 = 100
 	proxyPortFirst uint32 = 5701
 	networkIPv4           = "10.186.73.0/24"
 	networkIPv6           = "fd80:b10c::/48"
+
+	// loadTotalRate is the default rate of the load of the whole network, in txs/s
+	loadTotalRate = 10
 )
 
 type Mode string
 type Protocol string
 type Perturbation string
+type LoadProfile string
 
 const (
 	ModeValidator Mode = "validator"
@@ -45,11 +54,21 @@ const (
 	ProtocolTCP      Protocol = "tcp"
 	ProtocolUNIX     Protocol = "unix"
 	ProtocolDashCore Protocol = "dashcore"
+	// ProtocolDashCoreTCP is a remote signer connected over TCP, which signs
+	// with Dash Core.
+	ProtocolDashCoreTCP Protocol = "dashcore-tcp"
+
+	LightProviderRPC = "rpc"
+	LightProviderP2P = "p2p"
 
 	PerturbationDisconnect Perturbation = "disconnect"
 	PerturbationKill       Perturbation = "kill"
 	PerturbationPause      Perturbation = "pause"
 	PerturbationRestart    Perturbation = "restart"
+
+	LoadProfileConstant LoadProfile = "constant"
+	LoadProfileRamp     LoadProfile = "ramp"
+	LoadProfileBurst    LoadProfile = "burst"
 )
 
 // Testnet represents a single testnet.
@@ -64,6 +83,7 @@ type Testnet struct {
 	Validators                map[*Node]crypto.PubKey
 	ValidatorUpdates          map[int64]map[*Node]crypto.PubKey
 	ChainLockUpdates          map[int64]int64
+	CoreChainLockInterval     time.Duration
 	Nodes                     []*Node
 	KeyType                   string
 	ThresholdPublicKey        crypto.PubKey
@@ -71,39 +91,74 @@ type Testnet struct {
 	QuorumType                btcjson.LLMQType
 	QuorumHash                crypto.QuorumHash
 	QuorumHashUpdates         map[int64]crypto.QuorumHash
+	ProcessProposalHeight     int64
+	QuorumRotate              int64
+	CompactBlocks             bool
+	AnnounceTxs               bool
+	VerifyMasternodeIdentity  bool
+	InvalidBlockSizeHeight    int64
+	LoadTxSizeBytes           int
+	Load                      Load
+	RollbackBlocks            int64
+	Tracing                   bool
+	CollectorIP               net.IP
+	// ValidatorRemovals are validators leaving the quorum by validator updates, by update height
+	ValidatorRemovals map[int64][]*Node
 }
 
 // Node represents a Tenderdash node in a testnet.
 type Node struct {
-	Name                 string
-	Testnet              *Testnet
-	Mode                 Mode
-	PrivvalKeys          map[string]crypto.QuorumKeys
-	PrivvalUpdateHeights map[string]crypto.QuorumHash
-	NodeKey              crypto.PrivKey
-	ProTxHash            crypto.ProTxHash
-	IP                   net.IP
-	ProxyPort            uint32
-	StartAt              int64
-	FastSync             string
-	StateSync            bool
-	Database             string
-	ABCIProtocol         Protocol
-	PrivvalProtocol      Protocol
-	PersistInterval      uint64
-	SnapshotInterval     uint64
-	RetainBlocks         uint64
-	Seeds                []*Node
-	PersistentPeers      []*Node
-	Perturbations        []Perturbation
-	Misbehaviors         map[int64]string
+	Name                   string
+	Testnet                *Testnet
+	Mode                   Mode
+	PrivvalKeys            map[string]crypto.QuorumKeys
+	PrivvalUpdateHeights   map[string]crypto.QuorumHash
+	NodeKey                crypto.PrivKey
+	ProTxHash              crypto.ProTxHash
+	IP                     net.IP
+	ProxyPort              uint32
+	StartAt                int64
+	FastSync               string
+	StateSync              bool
+	Database               string
+	DatabaseBackends       map[string]string
+	ABCIProtocol           Protocol
+	PrivvalProtocol        Protocol
+	PersistInterval        uint64
+	SnapshotInterval       uint64
+	SnapshotKeepRecent     uint64
+	SnapshotChunkSize      uint64
+	KillAfterChunksPercent uint32
+	RetainBlocks           uint64
+	Seeds                  []*Node
+	PersistentPeers        []*Node
+	LightProvider          string
+	Perturbations          []Perturbation
+	Misbehaviors           map[int64]string
+	InvalidProposals       bool
+	CoreRPCUsername        string
+	CoreRPCPassword        string
+	CoreServerScenario     string
+	LoadRate               float64
+}
+
+// Load is the shape of the transaction load generated against a testnet, the
+// rates are set on the nodes.
+type Load struct {
+	Seed                int64
+	Profile             LoadProfile
+	RampDuration        time.Duration
+	BurstInterval       time.Duration
+	TxSizeMin           int
+	TxSizeMax           int
+	MaxFailedBroadcasts int // -1 is unlimited
 }
 
 // LoadTestnet loads a testnet from a manifest file, using the filename to
 // determine the testnet name and directory (from the basename of the file).
 // The testnet generation must be deterministic, since it is generated
 // separately by the runner and the test cases. For this reason, testnets use a
-// random seed to generate e.g. keys.
+// random seed to generate e.g. keys, which can be set in the manifest.
 func LoadTestnet(file string) (*Testnet, error) {
 	fmt.Printf("loading manifest")
 	manifest, err := LoadManifest(file)
@@ -124,9 +179,13 @@ func LoadTestnet(file string) (*Testnet, error) {
 	}
 
 	ipGen := newIPGenerator(ipNet)
-	keyGen := newKeyGenerator(randomSeed)
-	proTxHashGen := newProTxHashGenerator(randomSeed + 1)
-	quorumHashGen := newQuorumHashGenerator(randomSeed + 2)
+	randSeed := manifest.Seed
+	if randSeed == 0 {
+		randSeed = randomSeed
+	}
+	keyGen := newKeyGenerator(randSeed)
+	proTxHashGen := newProTxHashGenerator(randSeed + 1)
+	quorumHashGen := newQuorumHashGenerator(randSeed + 2)
 	proxyPortGen := newPortGenerator(proxyPortFirst)
 
 	// Set up nodes, in alphabetical order (IPs and ports get same order).
@@ -169,7 +228,7 @@ func LoadTestnet(file string) (*Testnet, error) {
 	}
 
 	proTxHashes, privateKeys, thresholdPublicKey :=
-		bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThresholdUsingSeedSource(proTxHashes, randomSeed)
+		bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThresholdUsingSeedSource(proTxHashes, randSeed)
 
 	quorumHash := quorumHashGen.Generate()
 
@@ -184,12 +243,23 @@ func LoadTestnet(file string) (*Testnet, error) {
 		Validators:                map[*Node]crypto.PubKey{},
 		ValidatorUpdates:          map[int64]map[*Node]crypto.PubKey{},
 		ChainLockUpdates:          map[int64]int64{},
+		CoreChainLockInterval:     time.Duration(manifest.CoreChainLockInterval) * time.Second,
 		Nodes:                     []*Node{},
 		ThresholdPublicKey:        thresholdPublicKey,
 		ThresholdPublicKeyUpdates: map[int64]crypto.PubKey{},
 		QuorumType:                btcjson.LLMQType(quorumType),
 		QuorumHash:                quorumHash,
 		QuorumHashUpdates:         map[int64]crypto.QuorumHash{},
+		ValidatorRemovals:         map[int64][]*Node{},
+		ProcessProposalHeight:     manifest.ProcessProposalHeight,
+		QuorumRotate:              manifest.QuorumRotate,
+		CompactBlocks:             manifest.CompactBlocks,
+		AnnounceTxs:               manifest.AnnounceTxs,
+		VerifyMasternodeIdentity:  manifest.VerifyMasternodeIdentity,
+		InvalidBlockSizeHeight:    manifest.InvalidBlockSizeHeight,
+		LoadTxSizeBytes:           1024,
+		RollbackBlocks:            manifest.RollbackBlocks,
+		Tracing:                   manifest.Tracing,
 	}
 	if manifest.InitialHeight > 0 {
 		testnet.InitialHeight = manifest.InitialHeight
@@ -197,6 +267,43 @@ func LoadTestnet(file string) (*Testnet, error) {
 	if manifest.InitialCoreChainLockedHeight > 0 {
 		testnet.InitialCoreHeight = manifest.InitialCoreChainLockedHeight
 	}
+	if manifest.LoadTxSizeBytes != 0 {
+		testnet.LoadTxSizeBytes = manifest.LoadTxSizeBytes
+	}
+	testnet.Load = Load{
+		Seed:                randSeed,
+		Profile:             LoadProfileConstant,
+		RampDuration:        30 * time.Second,
+		BurstInterval:       5 * time.Second,
+		TxSizeMin:           testnet.LoadTxSizeBytes,
+		TxSizeMax:           testnet.LoadTxSizeBytes,
+		MaxFailedBroadcasts: -1,
+	}
+	loadManifest := ManifestLoad{}
+	if manifest.Load != nil {
+		loadManifest = *manifest.Load
+	}
+	if loadManifest.Seed != 0 {
+		testnet.Load.Seed = loadManifest.Seed
+	}
+	if loadManifest.Profile != "" {
+		testnet.Load.Profile = LoadProfile(loadManifest.Profile)
+	}
+	if loadManifest.RampDuration != 0 {
+		testnet.Load.RampDuration = time.Duration(loadManifest.RampDuration) * time.Second
+	}
+	if loadManifest.BurstInterval != 0 {
+		testnet.Load.BurstInterval = time.Duration(loadManifest.BurstInterval) * time.Second
+	}
+	if loadManifest.TxSizeMin != 0 {
+		testnet.Load.TxSizeMin = loadManifest.TxSizeMin
+	}
+	if loadManifest.TxSizeMax != 0 {
+		testnet.Load.TxSizeMax = loadManifest.TxSizeMax
+	}
+	if loadManifest.MaxFailedBroadcasts != nil {
+		testnet.Load.MaxFailedBroadcasts = *loadManifest.MaxFailedBroadcasts
+	}
 
 	for _, name := range nodeNames {
 		fmt.Printf("Creating node: %s\n", name)
@@ -210,25 +317,32 @@ func LoadTestnet(file string) (*Testnet, error) {
 
 		nodeManifest := manifest.Nodes[name]
 		node := &Node{
-			Name:             name,
-			Testnet:          testnet,
-			PrivvalKeys:      privateKeysMap,
-			NodeKey:          keyGen.Generate("ed25519"),
-			ProTxHash:        nil,
-			IP:               ipGen.Next(),
-			ProxyPort:        proxyPortGen.Next(),
-			Mode:             ModeValidator,
-			Database:         "goleveldb",
-			ABCIProtocol:     ProtocolBuiltin,
-			PrivvalProtocol:  ProtocolFile,
-			StartAt:          nodeManifest.StartAt,
-			FastSync:         nodeManifest.FastSync,
-			StateSync:        nodeManifest.StateSync,
-			PersistInterval:  1,
-			SnapshotInterval: nodeManifest.SnapshotInterval,
-			RetainBlocks:     nodeManifest.RetainBlocks,
-			Perturbations:    []Perturbation{},
-			Misbehaviors:     make(map[int64]string),
+			Name:                   name,
+			Testnet:                testnet,
+			PrivvalKeys:            privateKeysMap,
+			NodeKey:                keyGen.Generate("ed25519"),
+			ProTxHash:              nil,
+			IP:                     ipGen.Next(),
+			ProxyPort:              proxyPortGen.Next(),
+			Mode:                   ModeValidator,
+			Database:               "goleveldb",
+			ABCIProtocol:           ProtocolBuiltin,
+			PrivvalProtocol:        ProtocolFile,
+			LightProvider:          LightProviderRPC,
+			StartAt:                nodeManifest.StartAt,
+			FastSync:               nodeManifest.FastSync,
+			StateSync:              nodeManifest.StateSync,
+			PersistInterval:        1,
+			SnapshotInterval:       nodeManifest.SnapshotInterval,
+			SnapshotKeepRecent:     nodeManifest.SnapshotKeepRecent,
+			SnapshotChunkSize:      nodeManifest.SnapshotChunkSize,
+			KillAfterChunksPercent: nodeManifest.KillAfterChunksPercent,
+			RetainBlocks:           nodeManifest.RetainBlocks,
+			Perturbations:          []Perturbation{},
+			Misbehaviors:           make(map[int64]string),
+			InvalidProposals:       nodeManifest.InvalidProposals,
+			CoreRPCUsername:        nodeManifest.CoreRPCUsername,
+			CoreRPCPassword:        nodeManifest.CoreRPCPassword,
 		}
 		if node.StartAt == testnet.InitialHeight {
 			node.StartAt = 0 // normalize to 0 for initial nodes, since code expects this
@@ -239,12 +353,24 @@ func LoadTestnet(file string) (*Testnet, error) {
 		if nodeManifest.Database != "" {
 			node.Database = nodeManifest.Database
 		}
+		if len(nodeManifest.DatabaseBackends) > 0 {
+			node.DatabaseBackends = nodeManifest.DatabaseBackends
+		}
 		if nodeManifest.ABCIProtocol != "" {
 			node.ABCIProtocol = Protocol(nodeManifest.ABCIProtocol)
 		}
 		if nodeManifest.PrivvalProtocol != "" {
 			node.PrivvalProtocol = Protocol(nodeManifest.PrivvalProtocol)
 		}
+		if nodeManifest.LightProvider != "" {
+			node.LightProvider = nodeManifest.LightProvider
+		}
+		if nodeManifest.CoreServerScenario != "" {
+			node.CoreServerScenario = nodeManifest.CoreServerScenario
+			if !filepath.IsAbs(node.CoreServerScenario) {
+				node.CoreServerScenario = filepath.Join(filepath.Dir(file), node.CoreServerScenario)
+			}
+		}
 		if nodeManifest.PersistInterval != nil {
 			node.PersistInterval = *nodeManifest.PersistInterval
 		}
@@ -260,6 +386,9 @@ func LoadTestnet(file string) (*Testnet, error) {
 		}
 		testnet.Nodes = append(testnet.Nodes, node)
 	}
+	if testnet.Tracing {
+		testnet.CollectorIP = ipGen.Next()
+	}
 
 	// We do a second pass to set up seeds and persistent peers, which allows graph cycles.
 	for _, node := range testnet.Nodes {
@@ -294,6 +423,28 @@ func LoadTestnet(file string) (*Testnet, error) {
 		}
 	}
 
+	// Set up the load rates of the nodes.
+	for name := range loadManifest.NodeRates {
+		if testnet.LookupNode(name) == nil {
+			return nil, fmt.Errorf("unknown node %q in load node rates", name)
+		}
+	}
+	var loadNodes []*Node
+	for _, node := range testnet.Nodes {
+		if !node.Stateless() {
+			loadNodes = append(loadNodes, node)
+		}
+	}
+	for _, node := range loadNodes {
+		node.LoadRate = loadManifest.Rate
+		if node.LoadRate == 0 {
+			node.LoadRate = loadTotalRate / float64(len(loadNodes))
+		}
+		if rate, ok := loadManifest.NodeRates[node.Name]; ok {
+			node.LoadRate = rate
+		}
+	}
+
 	// Set up genesis validators. If not specified explicitly, use all validator nodes.
 	if manifest.Validators != nil {
 		var i = 0
@@ -340,6 +491,21 @@ func LoadTestnet(file string) (*Testnet, error) {
 		}
 	}
 
+	// A quorum rotation is a validator update without changes in the validator set,
+	// it generates new validator keys and a new quorum hash.
+	if manifest.QuorumRotate > 0 {
+		if manifest.ValidatorUpdates == nil {
+			manifest.ValidatorUpdates = map[string]map[string]int64{}
+		}
+		lastHeight := testnet.InitialHeight + quorumRotationHeights
+		for height := testnet.InitialHeight + manifest.QuorumRotate; height <= lastHeight; height += manifest.QuorumRotate {
+			heightStr := strconv.FormatInt(height, 10)
+			if _, ok := manifest.ValidatorUpdates[heightStr]; !ok {
+				manifest.ValidatorUpdates[heightStr] = map[string]int64{}
+			}
+		}
+	}
+
 	heights := make([]int, len(manifest.ValidatorUpdates))
 	i := 0
 	// We need to do validator updates in order, as we use the previous validator set as the basis of current proTxHashes
@@ -359,26 +525,45 @@ func LoadTestnet(file string) (*Testnet, error) {
 		heightStr := strconv.FormatInt(int64(height), 10)
 		validators := manifest.ValidatorUpdates[heightStr]
 		valUpdate := map[*Node]crypto.PubKey{}
-		proTxHashesInUpdate := make([]crypto.ProTxHash, len(validators))
-		i := 0
+		// Names are sorted to generate proTxHashes deterministically.
+		names := make([]string, 0, len(validators))
 		for name := range validators {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
 			node := testnet.LookupNode(name)
 			if node == nil {
 				return nil, fmt.Errorf("unknown validator %q for update at height %v", name, height)
 			}
+			// A validator with power 0 leaves the quorum, the threshold keys are
+			// regenerated for the remaining validators below.
+			if validators[name] == 0 {
+				idx := proTxHashIndex(proTxHashes, node.ProTxHash)
+				if node.ProTxHash == nil || idx < 0 {
+					return nil, fmt.Errorf("validator %q removed at height %v is not in the validator set", name, height)
+				}
+				proTxHashes = append(proTxHashes[:idx:idx], proTxHashes[idx+1:]...)
+				testnet.ValidatorRemovals[int64(height)] = append(testnet.ValidatorRemovals[int64(height)], node)
+				fmt.Printf("Remove validator %s/%X at height %d\n", node.Name, node.ProTxHash, height)
+				continue
+			}
 			if node.ProTxHash == nil {
 				node.ProTxHash = proTxHashGen.Generate()
 				fmt.Printf("Set validator (at update) %s proTxHash to %X\n", node.Name, node.ProTxHash)
 			}
-			proTxHashesInUpdate[i] = node.ProTxHash
-			i++
+			if proTxHashIndex(proTxHashes, node.ProTxHash) < 0 {
+				proTxHashes = append(proTxHashes, node.ProTxHash)
+			}
+		}
+		if len(proTxHashes) == 0 {
+			return nil, fmt.Errorf("no validators left after the update at height %v", height)
 		}
-		proTxHashes = append(proTxHashes, proTxHashesInUpdate...)
 
 		sort.Sort(crypto.SortProTxHash(proTxHashes))
 
 		proTxHashes, privateKeys, thresholdPublicKey :=
-			bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThresholdUsingSeedSource(proTxHashes, randomSeed+int64(height))
+			bls12381.CreatePrivLLMQDataOnProTxHashesDefaultThresholdUsingSeedSource(proTxHashes, randSeed+int64(height))
 
 		quorumHash := quorumHashGen.Generate()
 
@@ -414,6 +599,9 @@ func LoadTestnet(file string) (*Testnet, error) {
 					ThresholdPublicKey: thresholdPublicKey,
 				}
 				node.PrivvalKeys[quorumHash.String()] = quorumKeys
+				if node.PrivvalUpdateHeights == nil {
+					node.PrivvalUpdateHeights = make(map[string]crypto.QuorumHash)
+				}
 				node.PrivvalUpdateHeights[strconv.Itoa(height+2)] = quorumHash
 			}
 		}
@@ -463,6 +651,24 @@ func (t Testnet) Validate() error {
 	if len(t.Nodes) == 0 {
 		return errors.New("network has no nodes")
 	}
+	if t.QuorumRotate < 0 {
+		return errors.New("quorum_rotate can't be negative")
+	}
+	if t.InvalidBlockSizeHeight < 0 {
+		return errors.New("invalid_block_size_height can't be negative")
+	}
+	if t.LoadTxSizeBytes <= 0 {
+		return errors.New("load_tx_size_bytes must be positive")
+	}
+	if err := t.Load.Validate(); err != nil {
+		return fmt.Errorf("invalid load: %w", err)
+	}
+	if t.RollbackBlocks < 0 {
+		return errors.New("rollback_blocks can't be negative")
+	}
+	if t.CoreChainLockInterval < 0 {
+		return errors.New("core_chain_lock_interval can't be negative")
+	}
 	for _, node := range t.Nodes {
 		if err := node.Validate(t); err != nil {
 			return fmt.Errorf("invalid node %q: %w", node.Name, err)
@@ -471,11 +677,61 @@ func (t Testnet) Validate() error {
 	return nil
 }
 
+// Validate validates a load.
+func (l Load) Validate() error {
+	switch l.Profile {
+	case LoadProfileConstant, LoadProfileRamp, LoadProfileBurst:
+	default:
+		return fmt.Errorf("invalid profile %q", l.Profile)
+	}
+	if l.RampDuration <= 0 {
+		return errors.New("ramp_duration must be positive")
+	}
+	if l.BurstInterval <= 0 {
+		return errors.New("burst_interval must be positive")
+	}
+	if l.TxSizeMin <= 0 || l.TxSizeMax < l.TxSizeMin {
+		return fmt.Errorf("invalid tx sizes [%d, %d]", l.TxSizeMin, l.TxSizeMax)
+	}
+	if l.MaxFailedBroadcasts < -1 {
+		return errors.New("max_failed_broadcasts can't be negative")
+	}
+	return nil
+}
+
+// TxsDue returns the number of transactions, which are due to be sent to a
+// node at the given rate by the given time since the start of the load.
+func (l Load) TxsDue(rate float64, elapsed time.Duration) int {
+	if rate <= 0 || elapsed < 0 {
+		return 0
+	}
+	t := elapsed.Seconds()
+	switch l.Profile {
+	case LoadProfileRamp:
+		ramp := l.RampDuration.Seconds()
+		if t < ramp {
+			return int(rate * t * t / (2 * ramp))
+		}
+		return int(rate * (t - ramp/2))
+	case LoadProfileBurst:
+		interval := l.BurstInterval.Seconds()
+		return int(rate * interval * (math.Floor(t/interval) + 1))
+	default:
+		return int(rate * t)
+	}
+}
+
 // Validate validates a node.
 func (n Node) Validate(testnet Testnet) error {
 	if n.Name == "" {
 		return errors.New("node has no name")
 	}
+	if n.LoadRate < 0 {
+		return errors.New("load rate can't be negative")
+	}
+	if n.LoadRate > 0 && n.Stateless() {
+		return fmt.Errorf("%s nodes can't get load", n.Mode)
+	}
 	if n.IP == nil {
 		return errors.New("node has no IP address")
 	}
@@ -505,11 +761,19 @@ func (n Node) Validate(testnet Testnet) error {
 	default:
 		return fmt.Errorf("invalid fast sync setting %q", n.FastSync)
 	}
-	switch n.Database {
-	case "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb":
-	default:
+	if !validDatabase(n.Database) {
 		return fmt.Errorf("invalid database setting %q", n.Database)
 	}
+	for store, database := range n.DatabaseBackends {
+		switch store {
+		case "blockstore", "state", "evidence", "tx_index", "light":
+		default:
+			return fmt.Errorf("invalid database backend store %q", store)
+		}
+		if !validDatabase(database) {
+			return fmt.Errorf("invalid database backend setting %q for %s", database, store)
+		}
+	}
 	switch n.ABCIProtocol {
 	case ProtocolBuiltin, ProtocolUNIX, ProtocolTCP, ProtocolGRPC:
 	default:
@@ -518,8 +782,13 @@ func (n Node) Validate(testnet Testnet) error {
 	if n.Mode == ModeLight && n.ABCIProtocol != ProtocolBuiltin {
 		return errors.New("light client must use builtin protocol")
 	}
+	switch n.LightProvider {
+	case LightProviderRPC, LightProviderP2P:
+	default:
+		return fmt.Errorf("invalid light provider setting %q", n.LightProvider)
+	}
 	switch n.PrivvalProtocol {
-	case ProtocolFile, ProtocolUNIX, ProtocolTCP, ProtocolDashCore:
+	case ProtocolFile, ProtocolUNIX, ProtocolTCP, ProtocolDashCore, ProtocolDashCoreTCP:
 	default:
 		return fmt.Errorf("invalid privval protocol setting %q", n.PrivvalProtocol)
 	}
@@ -540,6 +809,15 @@ func (n Node) Validate(testnet Testnet) error {
 	if n.SnapshotInterval > 0 && n.RetainBlocks > 0 && n.RetainBlocks < n.SnapshotInterval {
 		return errors.New("snapshot_interval must be less than er equal to retain_blocks")
 	}
+	if n.SnapshotKeepRecent > 0 && n.SnapshotInterval == 0 {
+		return errors.New("snapshot_keep_recent requires snapshot_interval to be set")
+	}
+	if n.KillAfterChunksPercent > 0 && n.SnapshotInterval == 0 {
+		return errors.New("kill_after_chunks_percent requires snapshot_interval to be set")
+	}
+	if n.KillAfterChunksPercent > 100 {
+		return fmt.Errorf("kill_after_chunks_percent %v is above 100", n.KillAfterChunksPercent)
+	}
 
 	for _, perturbation := range n.Perturbations {
 		switch perturbation {
@@ -549,10 +827,49 @@ func (n Node) Validate(testnet Testnet) error {
 		}
 	}
 
+	if testnet.RollbackBlocks > 0 {
+		if n.Mode != ModeValidator && n.Mode != ModeFull {
+			return errors.New("rollback_blocks requires validator and full nodes only")
+		}
+		if n.StartAt > 0 || n.StateSync || n.RetainBlocks > 0 {
+			return errors.New("rollback_blocks requires nodes storing all the blocks from the initial height")
+		}
+		if n.Mode == ModeValidator && n.PrivvalProtocol != ProtocolFile {
+			return errors.New("rollback_blocks requires the \"file\" privval protocol")
+		}
+	}
+
 	if (n.PrivvalProtocol != "file" || n.Mode != "validator") && len(n.Misbehaviors) != 0 {
 		return errors.New("must be using \"file\" privval protocol to implement misbehaviors")
 	}
 
+	if n.InvalidProposals {
+		if n.Mode != ModeValidator {
+			return errors.New("only validators can produce invalid proposals")
+		}
+		if testnet.ProcessProposalHeight == 0 {
+			return errors.New("invalid proposals require process_proposal_height to be set")
+		}
+	}
+
+	if n.CoreRPCUsername != "" || n.CoreRPCPassword != "" {
+		if !n.SignsWithDashCore() {
+			return errors.New("core rpc credentials require \"dashcore\" or \"dashcore-tcp\" privval protocol")
+		}
+		if n.CoreRPCUsername == "" || n.CoreRPCPassword == "" {
+			return errors.New("both core_rpc_username and core_rpc_password must be set")
+		}
+	}
+
+	if n.CoreServerScenario != "" {
+		if !n.SignsWithDashCore() {
+			return errors.New("core server scenario requires \"dashcore\" or \"dashcore-tcp\" privval protocol")
+		}
+		if _, err := mockcoreserver.LoadScenario(n.CoreServerScenario); err != nil {
+			return err
+		}
+	}
+
 	for height, misbehavior := range n.Misbehaviors {
 		if height < n.StartAt {
 			return fmt.Errorf("misbehavior height %d is below node start height %d",
@@ -576,6 +893,12 @@ func (n Node) Validate(testnet Testnet) error {
 	return nil
 }
 
+// CollectorEndpoint returns the OTLP gRPC endpoint of the OpenTelemetry
+// collector of the testnet, if tracing.
+func (t Testnet) CollectorEndpoint() string {
+	return net.JoinHostPort(t.CollectorIP.String(), "4317")
+}
+
 // LookupNode looks up a node by name. For now, simply do a linear search.
 func (t Testnet) LookupNode(name string) *Node {
 	for _, node := range t.Nodes {
@@ -596,6 +919,16 @@ func (t Testnet) LookupNodeByProTxHash(proTxHash crypto.ProTxHash) *Node {
 	return nil
 }
 
+// proTxHashIndex returns the index of a proTxHash in a list, or -1 if it is not there.
+func proTxHashIndex(proTxHashes []crypto.ProTxHash, proTxHash crypto.ProTxHash) int {
+	for i, h := range proTxHashes {
+		if bytes.Equal(h, proTxHash) {
+			return i
+		}
+	}
+	return -1
+}
+
 // ArchiveNodes returns a list of archive nodes that start at the initial height
 // and contain the entire blockchain history. They are used e.g. as light client
 // RPC servers.
@@ -634,6 +967,11 @@ func (t Testnet) HasPerturbations() bool {
 	return false
 }
 
+// HasValidatorRemovals returns whether any validator leaves the quorum by validator updates.
+func (t Testnet) HasValidatorRemovals() bool {
+	return len(t.ValidatorRemovals) > 0
+}
+
 // LastMisbehaviorHeight returns the height of the last misbehavior.
 func (t Testnet) LastMisbehaviorHeight() int64 {
 	lastHeight := int64(0)
@@ -681,6 +1019,33 @@ func (n Node) Stateless() bool {
 	return n.Mode == ModeLight || n.Mode == ModeSeed
 }
 
+// SignsWithDashCore returns true if the node signs with Dash Core, either
+// directly or through a remote signer.
+func (n Node) SignsWithDashCore() bool {
+	return n.PrivvalProtocol == ProtocolDashCore || n.PrivvalProtocol == ProtocolDashCoreTCP
+}
+
+// MixedDatabases returns true if some stores of the node use another database
+// backend than the node.
+func (n Node) MixedDatabases() bool {
+	for _, database := range n.DatabaseBackends {
+		if database != n.Database {
+			return true
+		}
+	}
+	return false
+}
+
+// validDatabase returns true if the database backend is supported.
+func validDatabase(database string) bool {
+	switch database {
+	case "goleveldb", "cleveldb", "boltdb", "rocksdb", "badgerdb", "pebble":
+		return true
+	default:
+		return false
+	}
+}
+
 // keyGenerator generates pseudorandom keys based on a seed.
 type keyGenerator struct {
 	random *rand.Rand
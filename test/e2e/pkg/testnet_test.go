@@ -0,0 +1,144 @@
+package e2e
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// to update the golden files, run:
+// go test ./test/e2e/pkg -run TestLoadTestnetGolden -update
+var update = flag.Bool("update", false, "update .golden files")
+
+const ciManifest = "../networks/ci.toml"
+
+func TestLoadTestnetDeterministic(t *testing.T) {
+	testnet1, err := LoadTestnet(ciManifest)
+	require.NoError(t, err)
+	testnet2, err := LoadTestnet(ciManifest)
+	require.NoError(t, err)
+
+	assert.Equal(t, testnet1.ThresholdPublicKey, testnet2.ThresholdPublicKey)
+	assert.Equal(t, testnet1.QuorumHash, testnet2.QuorumHash)
+	assert.Equal(t, testnet1.ThresholdPublicKeyUpdates, testnet2.ThresholdPublicKeyUpdates)
+	assert.Equal(t, testnet1.QuorumHashUpdates, testnet2.QuorumHashUpdates)
+	require.Len(t, testnet2.Nodes, len(testnet1.Nodes))
+	for i, node := range testnet1.Nodes {
+		assert.Equal(t, node.ProTxHash, testnet2.Nodes[i].ProTxHash, node.Name)
+		assert.Equal(t, node.NodeKey, testnet2.Nodes[i].NodeKey, node.Name)
+		assert.Equal(t, node.PrivvalKeys, testnet2.Nodes[i].PrivvalKeys, node.Name)
+	}
+}
+
+func TestLoadTestnetSeed(t *testing.T) {
+	manifest, err := LoadManifest(ciManifest)
+	require.NoError(t, err)
+	manifest.Seed = randomSeed + 1
+	file := filepath.Join(t.TempDir(), "ci.toml")
+	require.NoError(t, manifest.Save(file))
+
+	testnet1, err := LoadTestnet(ciManifest)
+	require.NoError(t, err)
+	testnet2, err := LoadTestnet(file)
+	require.NoError(t, err)
+	assert.NotEqual(t, testnet1.QuorumHash, testnet2.QuorumHash)
+	assert.NotEqual(t, testnet1.ThresholdPublicKey, testnet2.ThresholdPublicKey)
+	for i, node := range testnet1.Nodes {
+		assert.NotEqual(t, node.NodeKey, testnet2.Nodes[i].NodeKey, node.Name)
+	}
+}
+
+func TestLoadTestnetLoad(t *testing.T) {
+	testnet, err := LoadTestnet("../networks/load_burst.toml")
+	require.NoError(t, err)
+	assert.Equal(t, Load{
+		Seed:                4242,
+		Profile:             LoadProfileBurst,
+		RampDuration:        30 * time.Second,
+		BurstInterval:       2 * time.Second,
+		TxSizeMin:           256,
+		TxSizeMax:           4096,
+		MaxFailedBroadcasts: 0,
+	}, testnet.Load)
+	for _, node := range testnet.Nodes {
+		assert.EqualValues(t, 20, node.LoadRate, node.Name)
+	}
+
+	// by default, the network gets 10 txs/s at a constant rate
+	testnet, err = LoadTestnet(ciManifest)
+	require.NoError(t, err)
+	assert.Equal(t, LoadProfileConstant, testnet.Load.Profile)
+	assert.Equal(t, -1, testnet.Load.MaxFailedBroadcasts)
+	var total float64
+	for _, node := range testnet.Nodes {
+		if node.Stateless() {
+			assert.Zero(t, node.LoadRate, node.Name)
+		}
+		total += node.LoadRate
+	}
+	assert.InDelta(t, 10, total, 1e-9)
+}
+
+func TestLoadTxsDue(t *testing.T) {
+	load := Load{RampDuration: 10 * time.Second, BurstInterval: 2 * time.Second}
+	testCases := []struct {
+		profile LoadProfile
+		elapsed time.Duration
+		due     int
+	}{
+		{LoadProfileConstant, 0, 0},
+		{LoadProfileConstant, 1500 * time.Millisecond, 15},
+		{LoadProfileConstant, time.Minute, 600},
+		// the rate is 5 txs/s halfway through the ramp
+		{LoadProfileRamp, 5 * time.Second, 12},
+		{LoadProfileRamp, 10 * time.Second, 50},
+		{LoadProfileRamp, 11 * time.Second, 60},
+		// every burst sends the 20 txs of its interval
+		{LoadProfileBurst, 0, 20},
+		{LoadProfileBurst, 1999 * time.Millisecond, 20},
+		{LoadProfileBurst, 2 * time.Second, 40},
+		{LoadProfileBurst, time.Minute, 620},
+	}
+	for _, tc := range testCases {
+		load.Profile = tc.profile
+		assert.Equal(t, tc.due, load.TxsDue(10, tc.elapsed), "%v after %v", tc.profile, tc.elapsed)
+		assert.Zero(t, load.TxsDue(0, tc.elapsed), "%v after %v", tc.profile, tc.elapsed)
+	}
+}
+
+// TestLoadTestnetGolden makes sure, that the keys of a manifest don't change. The threshold keys
+// depend on the BLS library, the golden file covers everything else.
+func TestLoadTestnetGolden(t *testing.T) {
+	testnet, err := LoadTestnet(ciManifest)
+	require.NoError(t, err)
+
+	lines := []string{fmt.Sprintf("quorum_hash %s", testnet.QuorumHash)}
+	for height, quorumHash := range testnet.QuorumHashUpdates {
+		lines = append(lines, fmt.Sprintf("quorum_hash@%d %s", height, quorumHash))
+	}
+	for _, node := range testnet.Nodes {
+		lines = append(lines, fmt.Sprintf("%s node_key %X", node.Name, node.NodeKey.Bytes()))
+		if node.ProTxHash != nil {
+			lines = append(lines, fmt.Sprintf("%s pro_tx_hash %s", node.Name, node.ProTxHash))
+		}
+	}
+	sort.Strings(lines)
+	data := strings.Join(lines, "\n") + "\n"
+
+	goldenFilepath := filepath.Join("testdata", t.Name()+".golden")
+	if *update {
+		t.Logf("Updating golden file %s", goldenFilepath)
+		require.NoError(t, ioutil.WriteFile(goldenFilepath, []byte(data), 0644))
+	}
+	golden, err := ioutil.ReadFile(goldenFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), data)
+}
@@ -16,6 +16,7 @@ import (
 // 2. Block interval standard deviation
 // 3. Max block interval (slowest block)
 // 4. Min block interval (fastest block)
+// 5. Bytes sent by all the nodes to their peers
 //
 // Metrics are based of the `benchmarkLength`, the amount of consecutive blocks
 // sampled from in the testnet
@@ -26,6 +27,10 @@ func Benchmark(testnet *e2e.Testnet, benchmarkLength int64) error {
 	}
 
 	logger.Info("Beginning benchmark period...", "height", block.Height)
+	startBytesSent, err := fetchBytesSent(testnet)
+	if err != nil {
+		return err
+	}
 
 	// wait for the length of the benchmark period in blocks to pass. We allow 5 seconds for each block
 	// which should be sufficient.
@@ -36,6 +41,10 @@ func Benchmark(testnet *e2e.Testnet, benchmarkLength int64) error {
 	}
 
 	logger.Info("Ending benchmark period", "height", endHeight)
+	endBytesSent, err := fetchBytesSent(testnet)
+	if err != nil {
+		return err
+	}
 
 	// fetch a sample of blocks
 	blocks, err := fetchBlockChainSample(testnet, benchmarkLength)
@@ -48,6 +57,8 @@ func Benchmark(testnet *e2e.Testnet, benchmarkLength int64) error {
 	testnetStats := extractTestnetStats(timeIntervals)
 	testnetStats.startHeight = blocks[0].Header.Height
 	testnetStats.endHeight = blocks[len(blocks)-1].Header.Height
+	testnetStats.bytesSent = endBytesSent - startBytesSent
+	testnetStats.blocks = endHeight - block.Height
 
 	// print and return
 	logger.Info(testnetStats.String())
@@ -66,6 +77,10 @@ type testnetStats struct {
 	max time.Duration
 	// shortest time to produce a block
 	min time.Duration
+
+	// bytes sent by all the nodes during the benchmark period of blocks
+	bytesSent int64
+	blocks    int64
 }
 
 func (t *testnetStats) String() string {
@@ -74,6 +89,7 @@ func (t *testnetStats) String() string {
 	Standard Deviation: %f
 	Max Block Interval: %v
 	Min Block Interval: %v
+	Bytes Sent: %v (%v per block)
 	`,
 		t.startHeight,
 		t.endHeight,
@@ -81,6 +97,8 @@ func (t *testnetStats) String() string {
 		t.std,
 		t.max,
 		t.min,
+		t.bytesSent,
+		t.bytesSent/t.blocks,
 	)
 }
 
@@ -134,6 +152,29 @@ func fetchBlockChainSample(testnet *e2e.Testnet, benchmarkLength int64) ([]*type
 	return blocks, nil
 }
 
+// fetchBytesSent returns the bytes sent by all the nodes of the testnet to their
+// current peers.
+func fetchBytesSent(testnet *e2e.Testnet) (int64, error) {
+	var bytesSent int64
+	for _, node := range testnet.Nodes {
+		if node.Stateless() {
+			continue
+		}
+		c, err := node.Client()
+		if err != nil {
+			return 0, err
+		}
+		netInfo, err := c.NetInfo(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		for _, peer := range netInfo.Peers {
+			bytesSent += peer.ConnectionStatus.SendMonitor.Bytes
+		}
+	}
+	return bytesSent, nil
+}
+
 func splitIntoBlockIntervals(blocks []*types.BlockMeta) []time.Duration {
 	intervals := make([]time.Duration, len(blocks)-1)
 	lastTime := blocks[0].Header.Time
@@ -2,26 +2,47 @@ package main
 
 import (
 	"context"
-	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 	"github.com/tendermint/tendermint/types"
 )
 
+// invalidTxKeyPrefix marks transactions which are only accepted by the applications
+// of nodes with invalid_proposals set, it must match the prefix used by the app.
+const invalidTxKeyPrefix = "invalid-"
+
+// loadTick is how often the generators check for due transactions.
+const loadTick = 10 * time.Millisecond
+
+// loadSummaryFile is the file in the testnet directory, the summary of the
+// load is written to.
+const loadSummaryFile = "load.json"
+
 // Load generates transactions against the network until the given context is
-// canceled. A multiplier of greater than one can be supplied if load needs to
-// be generated beyond a minimum amount.
+// canceled. The transactions of every node are generated from the seed of
+// the load, and sent at the rate of the node in the shape of the load profile.
+// A multiplier of greater than one can be supplied if load needs to be
+// generated beyond a minimum amount, it multiplies the rates. Once canceled,
+// a summary of the load is written as JSON to load.json in the testnet
+// directory.
 func Load(ctx context.Context, testnet *e2e.Testnet, multiplier int) error {
 	// Since transactions are executed across all nodes in the network, we need
 	// to reduce transaction load for larger networks to avoid using too much
 	// CPU. This gives high-throughput small networks and low-throughput large ones.
 	// This also limits the number of TCP connections, since each worker has
-	// a connection to all nodes.
+	// a connection to its node.
 	concurrency := 64 / len(testnet.Nodes)
 	if concurrency == 0 {
 		concurrency = 1
@@ -29,19 +50,44 @@ func Load(ctx context.Context, testnet *e2e.Testnet, multiplier int) error {
 	initialTimeout := 1 * time.Minute
 	stallTimeout := 30 * time.Second
 
-	chTx := make(chan types.Tx)
 	chSuccess := make(chan types.Tx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// Spawn job generator and processors.
-	logger.Info(fmt.Sprintf("Starting transaction load (%v workers)...", concurrency))
+	// Spawn a job generator and processors for each node.
+	logger.Info(fmt.Sprintf("Starting %v transaction load (%v workers per node)...",
+		testnet.Load.Profile, concurrency))
 	started := time.Now()
 
-	go loadGenerate(ctx, chTx, multiplier)
+	stats := newLoadStats()
+	go loadWatchCommits(ctx, testnet, stats)
+
+	loaded := 0
+	for i, node := range testnet.Nodes {
+		rate := node.LoadRate * float64(multiplier)
+		if rate <= 0 {
+			continue
+		}
+		loaded++
+		stats.addNode(node.Name, rate)
 
-	for w := 0; w < concurrency; w++ {
-		go loadProcess(ctx, testnet, chTx, chSuccess)
+		chTx := make(chan types.Tx, concurrency)
+		r := rand.New(rand.NewSource(testnet.Load.Seed + int64(i))) // nolint: gosec
+		go func(node *e2e.Node, rate float64) {
+			// the load of a node starts once it's up, so its shape doesn't
+			// depend on how long the node takes to start
+			if !loadWaitForNode(ctx, node) {
+				close(chTx)
+				return
+			}
+			loadGenerate(ctx, testnet.Load, rate, r, chTx)
+		}(node, rate)
+		for w := 0; w < concurrency; w++ {
+			go loadProcess(ctx, node, chTx, chSuccess, stats)
+		}
+	}
+	if loaded == 0 {
+		return errors.New("no nodes to send transactions to")
 	}
 
 	// Monitor successful transactions, and abort on stalls.
@@ -58,66 +104,309 @@ func Load(ctx context.Context, testnet *e2e.Testnet, multiplier int) error {
 			if success == 0 {
 				return errors.New("failed to submit any transactions")
 			}
-			logger.Info(fmt.Sprintf("Ending transaction load after %v txs (%.1f tx/s)...",
-				success, float64(success)/time.Since(started).Seconds()))
+			summary := stats.summary(testnet.Load, time.Since(started))
+			logger.Info(fmt.Sprintf("Ending transaction load after %v txs (%.1f tx/s), %v committed, %v failed...",
+				success, float64(success)/time.Since(started).Seconds(), summary.Committed, summary.FailedBroadcasts))
+			if err := summary.save(filepath.Join(testnet.Dir, loadSummaryFile)); err != nil {
+				return err
+			}
+			max := testnet.Load.MaxFailedBroadcasts
+			if max >= 0 && summary.FailedBroadcasts > max {
+				return fmt.Errorf("%v transactions failed to be broadcast, at most %v are allowed",
+					summary.FailedBroadcasts, max)
+			}
 			return nil
 		}
 	}
 }
 
-// loadGenerate generates jobs until the context is canceled
-func loadGenerate(ctx context.Context, chTx chan<- types.Tx, multiplier int) {
-	for i := 0; i < math.MaxInt64; i++ {
-		// We keep generating the same 1000 keys over and over, with different values.
-		// This gives a reasonable load without putting too much data in the app.
-		id := i % 1000
+// loadWaitForNode waits until the node is up, it returns false if the context
+// is canceled first.
+func loadWaitForNode(ctx context.Context, node *e2e.Node) bool {
+	client, err := node.Client()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create a client for %v: %v", node.Name, err))
+		return false
+	}
+	for {
+		if _, err := client.Health(ctx); err == nil {
+			return true
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
 
-		bz := make([]byte, 1024) // 1kb hex-encoded
-		_, err := rand.Read(bz)
-		if err != nil {
-			panic(fmt.Sprintf("Failed to read random bytes: %v", err))
+// loadGenerate generates the jobs of a node, once they're due, until the
+// context is canceled. The values of the transactions are random bytes of
+// random sizes, both drawn from r.
+func loadGenerate(ctx context.Context, load e2e.Load, rate float64, r *rand.Rand, chTx chan<- types.Tx) {
+	defer close(chTx)
+
+	ticker := time.NewTicker(loadTick)
+	defer ticker.Stop()
+	started := time.Now()
+	for i := 0; ; {
+		for due := load.TxsDue(rate, time.Since(started)); i < due; i++ {
+			// We keep generating the same 1000 keys over and over, with different values.
+			// This gives a reasonable load without putting too much data in the app.
+			id := i % 1000
+
+			bz := make([]byte, load.TxSizeMin+r.Intn(load.TxSizeMax-load.TxSizeMin+1)) // hex-encoded
+			_, _ = r.Read(bz)
+			tx := types.Tx(fmt.Sprintf("load-%X=%x", id, bz))
+
+			select {
+			case chTx <- tx:
+			case <-ctx.Done():
+				return
+			}
 		}
-		tx := types.Tx(fmt.Sprintf("load-%X=%x", id, bz))
 
 		select {
-		case chTx <- tx:
-			time.Sleep(time.Duration(100/multiplier) * time.Millisecond)
-
+		case <-ticker.C:
 		case <-ctx.Done():
-			close(chTx)
 			return
 		}
 	}
 }
 
-// loadProcess processes transactions
-func loadProcess(ctx context.Context, testnet *e2e.Testnet, chTx <-chan types.Tx, chSuccess chan<- types.Tx) {
-	// Each worker gets its own client to each node, which allows for some
+// loadProcess broadcasts the transactions of a node.
+func loadProcess(
+	ctx context.Context,
+	node *e2e.Node,
+	chTx <-chan types.Tx,
+	chSuccess chan<- types.Tx,
+	stats *loadStats,
+) {
+	// Each worker gets its own client to the node, which allows for some
 	// concurrency while still bounding it.
-	clients := map[string]*rpchttp.HTTP{}
+	var client *rpchttp.HTTP
 
 	var err error
 	for tx := range chTx {
-		node := testnet.RandomNode()
-		client, ok := clients[node.Name]
-		if !ok {
+		if client == nil {
 			client, err = node.Client()
 			if err != nil {
+				stats.failed(node.Name)
 				continue
 			}
 
 			// check that the node is up
 			_, err = client.Health(ctx)
 			if err != nil {
+				client = nil
+				stats.failed(node.Name)
 				continue
 			}
-
-			clients[node.Name] = client
 		}
 
+		stats.sent(node.Name, tx)
 		if _, err = client.BroadcastTxSync(ctx, tx); err != nil {
+			stats.unsent(node.Name, tx)
+			if ctx.Err() == nil {
+				stats.failed(node.Name)
+			}
 			continue
 		}
-		chSuccess <- tx
+		if node.InvalidProposals {
+			// Feed the node's mempool with transactions that make its proposals invalid.
+			// These are rejected by the rest of the network and are never committed.
+			_, _ = client.BroadcastTxSync(ctx, append(types.Tx(invalidTxKeyPrefix), tx...))
+		}
+		select {
+		case chSuccess <- tx:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// loadWatchCommits records the commits of the transactions of the load, as
+// seen by a node, which runs from the start without perturbations, if any.
+// The load carries on without the commit latencies, if the node can't be
+// watched.
+func loadWatchCommits(ctx context.Context, testnet *e2e.Testnet, stats *loadStats) {
+	var node *e2e.Node
+	for _, n := range testnet.Nodes {
+		if n.Stateless() || n.StartAt > 0 {
+			continue
+		}
+		if node == nil || len(node.Perturbations) > 0 && len(n.Perturbations) == 0 {
+			node = n
+		}
+	}
+	if node == nil {
+		return
+	}
+
+	// the node may not be up yet
+	var (
+		client *rpchttp.HTTP
+		events <-chan rpctypes.ResultEvent
+	)
+	for events == nil {
+		var err error
+		client, err = node.Client()
+		if err == nil {
+			err = client.Start()
+		}
+		if err == nil {
+			events, err = client.Subscribe(ctx, "load", types.EventQueryNewBlock.String())
+			if err != nil {
+				_ = client.Stop()
+			}
+		}
+		if err != nil {
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	defer client.Stop() //nolint:errcheck
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				logger.Error(fmt.Sprintf("Stopped watching the commits of the load on %v", node.Name))
+				return
+			}
+			if data, ok := event.Data.(types.EventDataNewBlock); ok {
+				stats.committed(data.Block.Txs, time.Now())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// loadStats collects the statistics of the load.
+type loadStats struct {
+	mtx       sync.Mutex
+	nodes     map[string]*loadNodeSummary
+	pending   map[string]time.Time // the send times of the uncommitted txs by hash
+	latencies []time.Duration
+}
+
+func newLoadStats() *loadStats {
+	return &loadStats{
+		nodes:   map[string]*loadNodeSummary{},
+		pending: map[string]time.Time{},
+	}
+}
+
+func (s *loadStats) addNode(name string, rate float64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.nodes[name] = &loadNodeSummary{Rate: rate}
+}
+
+// sent records that the tx is being broadcast.
+func (s *loadStats) sent(node string, tx types.Tx) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.nodes[node].Broadcast++
+	s.pending[string(tx.Hash())] = time.Now()
+}
+
+// unsent records that the broadcast of the tx didn't go through.
+func (s *loadStats) unsent(node string, tx types.Tx) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.nodes[node].Broadcast--
+	delete(s.pending, string(tx.Hash()))
+}
+
+// failed records that a tx failed to be broadcast.
+func (s *loadStats) failed(node string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.nodes[node].FailedBroadcasts++
+}
+
+// committed records the commit latencies of the txs of the load, among the
+// given ones.
+func (s *loadStats) committed(txs types.Txs, at time.Time) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, tx := range txs {
+		hash := string(tx.Hash())
+		if sent, ok := s.pending[hash]; ok {
+			s.latencies = append(s.latencies, at.Sub(sent))
+			delete(s.pending, hash)
+		}
+	}
+}
+
+func (s *loadStats) summary(load e2e.Load, duration time.Duration) loadSummary {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	summary := loadSummary{
+		Profile:         load.Profile,
+		Seed:            load.Seed,
+		DurationSeconds: duration.Seconds(),
+		Committed:       len(s.latencies),
+		Nodes:           map[string]loadNodeSummary{},
+	}
+	for name, node := range s.nodes {
+		summary.Broadcast += node.Broadcast
+		summary.FailedBroadcasts += node.FailedBroadcasts
+		summary.Nodes[name] = *node
+	}
+	latencies := append([]time.Duration(nil), s.latencies...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	summary.CommitLatencyP50 = percentileMilliseconds(latencies, 0.5)
+	summary.CommitLatencyP95 = percentileMilliseconds(latencies, 0.95)
+	return summary
+}
+
+// percentileMilliseconds returns the given percentile of the sorted durations
+// in milliseconds, or 0 if there are none.
+func percentileMilliseconds(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return float64(sorted[i]) / float64(time.Millisecond)
+}
+
+// loadSummary is the summary of the load written to the testnet directory.
+type loadSummary struct {
+	Profile          e2e.LoadProfile            `json:"profile"`
+	Seed             int64                      `json:"seed"`
+	DurationSeconds  float64                    `json:"duration_seconds"`
+	Broadcast        int                        `json:"broadcast_txs"`
+	FailedBroadcasts int                        `json:"failed_broadcasts"`
+	Committed        int                        `json:"committed_txs"`
+	CommitLatencyP50 float64                    `json:"commit_latency_p50_ms"`
+	CommitLatencyP95 float64                    `json:"commit_latency_p95_ms"`
+	Nodes            map[string]loadNodeSummary `json:"nodes"`
+}
+
+// loadNodeSummary is the summary of the load of a node.
+type loadNodeSummary struct {
+	Rate             float64 `json:"rate"`
+	Broadcast        int     `json:"broadcast_txs"`
+	FailedBroadcasts int     `json:"failed_broadcasts"`
+}
+
+func (s loadSummary) save(file string) error {
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(file, bz, 0644); err != nil {
+		return fmt.Errorf("failed to write the load summary: %w", err)
 	}
+	return nil
 }
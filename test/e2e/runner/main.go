@@ -93,6 +93,18 @@ func NewCLI() *CLI {
 				}
 			}
 
+			if cli.testnet.HasValidatorRemovals() {
+				if err := PerturbValidatorRemovals(cli.testnet); err != nil {
+					return err
+				}
+			}
+
+			if cli.testnet.RollbackBlocks > 0 {
+				if err := Rollback(cli.testnet); err != nil {
+					return err
+				}
+			}
+
 			loadCancel()
 			if err := <-chLoadResult; err != nil {
 				return err
@@ -149,6 +161,14 @@ func NewCLI() *CLI {
 		},
 	})
 
+	cli.root.AddCommand(&cobra.Command{
+		Use:   "rollback",
+		Short: "Rolls back the Docker testnet by the configured number of blocks, and resumes it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Rollback(cli.testnet)
+		},
+	})
+
 	cli.root.AddCommand(&cobra.Command{
 		Use:   "wait",
 		Short: "Waits for a few blocks to be produced and all nodes to catch up",
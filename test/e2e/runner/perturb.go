@@ -1,11 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Perturbs a running testnet.
@@ -71,5 +78,175 @@ func PerturbNode(node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.Resul
 		return nil, err
 	}
 	logger.Info(fmt.Sprintf("Node %v recovered at height %v", node.Name, status.SyncInfo.LatestBlockHeight))
+
+	if perturbation == e2e.PerturbationRestart && node.Mode == e2e.ModeValidator {
+		if err := waitForRoundCatchUp(node, 20*time.Second); err != nil {
+			return nil, err
+		}
+	}
 	return status, nil
 }
+
+// waitForRoundCatchUp verifies that a restarted validator joins the round of
+// another validator before the network moves past one more round of the
+// height.
+func waitForRoundCatchUp(node *e2e.Node, timeout time.Duration) error {
+	var reference *e2e.Node
+	for _, n := range node.Testnet.Nodes {
+		if n.Mode == e2e.ModeValidator && n.Name != node.Name {
+			reference = n
+			break
+		}
+	}
+	if reference == nil {
+		return nil
+	}
+	refStatus, err := waitForNode(reference, 0, timeout)
+	if err != nil {
+		return err
+	}
+	if _, err := waitForNode(node, refStatus.SyncInfo.LatestBlockHeight, timeout); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	refHeight, startRound, err := consensusHeightRound(ctx, reference)
+	if err != nil {
+		return err
+	}
+	refRound := startRound
+	for {
+		height, round, err := consensusHeightRound(ctx, node)
+		if err != nil {
+			return err
+		}
+		if height > refHeight || (height == refHeight && round >= refRound) {
+			logger.Info(fmt.Sprintf("Node %v joined the round %v/%v", node.Name, refHeight, refRound))
+			return nil
+		}
+
+		h, r, err := consensusHeightRound(ctx, reference)
+		if err != nil {
+			return err
+		}
+		if h == refHeight && r > startRound+1 {
+			return fmt.Errorf("node %v is at %v/%v, the network moved from round %v to %v at height %v",
+				node.Name, height, round, startRound, r, refHeight)
+		}
+		if h == refHeight {
+			refRound = r
+		}
+
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// consensusHeightRound returns the height and the round the node is in.
+func consensusHeightRound(ctx context.Context, node *e2e.Node) (int64, int32, error) {
+	client, err := node.Client()
+	if err != nil {
+		return 0, 0, err
+	}
+	result, err := client.ConsensusState(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query consensus state of %v: %w", node.Name, err)
+	}
+	var rs struct {
+		HeightRoundStep string `json:"height/round/step"`
+	}
+	if err := json.Unmarshal(result.RoundState, &rs); err != nil {
+		return 0, 0, err
+	}
+	var (
+		height int64
+		round  int32
+		step   int
+	)
+	if _, err := fmt.Sscanf(rs.HeightRoundStep, "%d/%d/%d", &height, &round, &step); err != nil {
+		return 0, 0, fmt.Errorf("invalid height/round/step %q of %v: %w", rs.HeightRoundStep, node.Name, err)
+	}
+	return height, round, nil
+}
+
+// validatorRemovalBlocks is the number of blocks checked after a validator leaves the quorum.
+const validatorRemovalBlocks = 3
+
+// PerturbValidatorRemovals waits for the scheduled validator removals to take
+// effect, and verifies that removed validators stop participating in consensus
+// while the remaining quorum keeps producing blocks.
+func PerturbValidatorRemovals(testnet *e2e.Testnet) error {
+	archiveNodes := testnet.ArchiveNodes()
+	if len(archiveNodes) == 0 {
+		return errors.New("no archive nodes to verify validator removals")
+	}
+	client, err := archiveNodes[0].Client()
+	if err != nil {
+		return err
+	}
+	updateHeights := make([]int64, 0, len(testnet.ValidatorUpdates))
+	for height := range testnet.ValidatorUpdates {
+		updateHeights = append(updateHeights, height)
+	}
+	sort.Slice(updateHeights, func(i, j int) bool { return updateHeights[i] < updateHeights[j] })
+
+	for i, height := range updateHeights {
+		removed := testnet.ValidatorRemovals[height]
+		if len(removed) == 0 {
+			continue
+		}
+		// Validator updates take effect two blocks after they're returned, and
+		// a later update may bring the removed validators back.
+		from := height + 2
+		to := from + validatorRemovalBlocks
+		if i+1 < len(updateHeights) && updateHeights[i+1]+1 < to {
+			to = updateHeights[i+1] + 1
+		}
+		logger.Info(fmt.Sprintf("Verifying validators removed at height %v...", height))
+		if _, _, err := waitForHeight(testnet, to); err != nil {
+			return err
+		}
+		for h := from; h <= to; h++ {
+			if err := verifyValidatorsRemoved(client, h, removed); err != nil {
+				return err
+			}
+		}
+		time.Sleep(3 * time.Second) // give network some time to recover between each
+	}
+	return nil
+}
+
+// verifyValidatorsRemoved checks that none of the nodes is in the validator set
+// or proposes the block at the height.
+func verifyValidatorsRemoved(client *rpchttp.HTTP, height int64, nodes []*e2e.Node) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	block, err := client.Block(ctx, &height)
+	if err != nil {
+		return err
+	}
+	var validators []*types.Validator
+	perPage := 100
+	for page := 1; ; page++ {
+		resp, err := client.Validators(ctx, &height, &page, &perPage, nil)
+		if err != nil {
+			return err
+		}
+		validators = append(validators, resp.Validators...)
+		if len(validators) >= resp.Total {
+			break
+		}
+	}
+	for _, node := range nodes {
+		for _, val := range validators {
+			if bytes.Equal(val.ProTxHash, node.ProTxHash) {
+				return fmt.Errorf("removed validator %v is still in the validator set at height %v",
+					node.Name, height)
+			}
+		}
+		if bytes.Equal(block.Block.ProposerProTxHash, node.ProTxHash) {
+			return fmt.Errorf("removed validator %v proposed the block at height %v", node.Name, height)
+		}
+	}
+	return nil
+}
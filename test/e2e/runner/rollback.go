@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Rollback stops the network, rolls back all the nodes by the configured
+// number of blocks with the rollback command, resetting their signer states
+// and their applications, which replay the blocks on start, and verifies that
+// the network resumes consensus past the height it was stopped at.
+func Rollback(testnet *e2e.Testnet) error {
+	if testnet.RollbackBlocks <= 0 {
+		return errors.New("no rollback_blocks set for the testnet")
+	}
+	// every node must be above the height rolled back to
+	if _, err := waitForAllNodes(testnet, testnet.InitialHeight+testnet.RollbackBlocks+1,
+		waitingTime(len(testnet.Nodes))); err != nil {
+		return err
+	}
+	minHeight, maxHeight, err := nodeHeights(testnet)
+	if err != nil {
+		return err
+	}
+	height := minHeight - testnet.RollbackBlocks
+	discarded, err := blockAt(testnet.Nodes[0], height+1)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Stopping the network at height %v...", maxHeight))
+	if err := execCompose(testnet.Dir, "stop"); err != nil {
+		return err
+	}
+	for _, node := range testnet.Nodes {
+		logger.Info(fmt.Sprintf("Rolling back node %v to height %v...", node.Name, height))
+		if err := execCompose(testnet.Dir, "run", "--rm", "--entrypoint", "/usr/bin/tenderdash", node.Name,
+			"rollback", "--to-height", strconv.FormatInt(height, 10), "--reset-signer-state"); err != nil {
+			return err
+		}
+		// the application replays the blocks up to the height on start
+		if err := execCompose(testnet.Dir, "run", "--rm", "--entrypoint", "rm", node.Name,
+			"-f", "/tenderdash/data/app/state.json"); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Starting the rolled back network...")
+	if err := execCompose(testnet.Dir, "start"); err != nil {
+		return err
+	}
+	if err := WaitUntil(testnet, maxHeight+1); err != nil {
+		return err
+	}
+	for _, node := range testnet.Nodes {
+		block, err := blockAt(node, height+1)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(block.Hash(), discarded.Hash()) {
+			return fmt.Errorf("node %v kept the block %X discarded at height %v",
+				node.Name, discarded.Hash(), height+1)
+		}
+	}
+	logger.Info(fmt.Sprintf("Network resumed consensus after the rollback to height %v", height))
+	return nil
+}
+
+// nodeHeights returns the lowest and the highest latest heights of the nodes.
+func nodeHeights(testnet *e2e.Testnet) (int64, int64, error) {
+	var minHeight, maxHeight int64
+	for _, node := range testnet.Nodes {
+		client, err := node.Client()
+		if err != nil {
+			return 0, 0, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		status, err := client.Status(ctx)
+		cancel()
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to query status of %v: %w", node.Name, err)
+		}
+		latest := status.SyncInfo.LatestBlockHeight
+		if minHeight == 0 || latest < minHeight {
+			minHeight = latest
+		}
+		if latest > maxHeight {
+			maxHeight = latest
+		}
+	}
+	return minHeight, maxHeight, nil
+}
+
+// blockAt returns the block of the node at the height.
+func blockAt(node *e2e.Node, height int64) (*types.Block, error) {
+	client, err := node.Client()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := client.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query block %v of %v: %w", height, node.Name, err)
+	}
+	return result.Block, nil
+}
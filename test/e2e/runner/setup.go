@@ -38,6 +38,11 @@ const (
 	PrivvalStateFile      = "data/priv_validator_state.json"
 	PrivvalDummyKeyFile   = "config/dummy_validator_key.json"
 	PrivvalDummyStateFile = "data/dummy_validator_state.json"
+
+	CoreServerScenarioFile = "config/core_server_scenario.yaml"
+
+	CollectorConfigFile = "otel-collector.yaml"
+	CollectorSpansDir   = "otel"
 )
 
 // Setup sets up the testnet configuration.
@@ -58,6 +63,13 @@ func Setup(testnet *e2e.Testnet) error {
 		return err
 	}
 
+	if testnet.Tracing {
+		err = setupCollector(testnet)
+		if err != nil {
+			return err
+		}
+	}
+
 	genesis, err := MakeGenesis(testnet)
 	if err != nil {
 		return err
@@ -102,6 +114,17 @@ func Setup(testnet *e2e.Testnet) error {
 			continue
 		}
 
+		if node.CoreServerScenario != "" {
+			scenario, err := ioutil.ReadFile(node.CoreServerScenario)
+			if err != nil {
+				return err
+			}
+			err = ioutil.WriteFile(filepath.Join(nodeDir, CoreServerScenarioFile), scenario, 0644)
+			if err != nil {
+				return err
+			}
+		}
+
 		err = genesis.SaveAs(filepath.Join(nodeDir, "config", "genesis.json"))
 		if err != nil {
 			return err
@@ -147,6 +170,7 @@ func MakeDockerCompose(testnet *e2e.Testnet) ([]byte, error) {
 			}
 			return str
 		},
+		"appAddressTCP": func() string { return AppAddressTCP },
 	}).Parse(`version: '2.4'
 
 networks:
@@ -163,6 +187,21 @@ networks:
       - subnet: {{ .IP }}
 
 services:
+{{- if .Tracing }}
+  otel-collector:
+    labels:
+      e2e: true
+    container_name: otel-collector
+    image: otel/opentelemetry-collector:0.29.0
+    command: ["--config=/etc/otel-collector.yaml"]
+    volumes:
+    - ./otel-collector.yaml:/etc/otel-collector.yaml
+    - ./otel:/otel
+    networks:
+      {{ .Name }}:
+        ipv{{ if .IPv6 }}6{{ else }}4{{ end}}_address: {{ .CollectorIP }}
+
+{{- end }}
 {{- range .Nodes }}
   {{ .Name }}:
     labels:
@@ -176,6 +215,13 @@ services:
     command: ["node", "--misbehaviors", "{{ misbehaviorsToString .Misbehaviors }}"]
 {{- end }}
     init: true
+{{- if eq .ABCIProtocol "grpc" }}
+    healthcheck:
+      test: ["CMD", "abci-cli", "health", "--address", "{{ appAddressTCP }}"]
+      interval: 5s
+      timeout: 5s
+      retries: 3
+{{- end }}
     ports:
     - 26656
     - {{ if .ProxyPort }}{{ .ProxyPort }}:{{ end }}26657
@@ -199,6 +245,40 @@ services:
 	return buf.Bytes(), nil
 }
 
+// setupCollector writes the config of the OpenTelemetry collector, which
+// receives the spans of the nodes and writes them to the spans directory.
+func setupCollector(testnet *e2e.Testnet) error {
+	err := ioutil.WriteFile(filepath.Join(testnet.Dir, CollectorConfigFile), []byte(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+
+processors:
+  batch:
+
+exporters:
+  file:
+    path: /otel/spans.json
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [file]
+`), 0644)
+	if err != nil {
+		return err
+	}
+	// the collector doesn't run as root
+	spansDir := filepath.Join(testnet.Dir, CollectorSpansDir)
+	if err := os.MkdirAll(spansDir, 0755); err != nil {
+		return err
+	}
+	return os.Chmod(spansDir, 0777)
+}
+
 // MakeGenesis generates a genesis document.
 func MakeGenesis(testnet *e2e.Testnet) (types.GenesisDoc, error) {
 	genesis := types.GenesisDoc{
@@ -244,8 +324,29 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 	cfg.P2P.ExternalAddress = fmt.Sprintf("tcp://%v", node.AddressP2P(false))
 	cfg.P2P.AddrBookStrict = false
 	cfg.DBBackend = node.Database
+	for store, database := range node.DatabaseBackends {
+		switch store {
+		case "blockstore":
+			cfg.Storage.Backends.BlockStore = database
+		case "state":
+			cfg.Storage.Backends.State = database
+		case "evidence":
+			cfg.Storage.Backends.Evidence = database
+		case "tx_index":
+			cfg.Storage.Backends.TxIndex = database
+		case "light":
+			cfg.Storage.Backends.Light = database
+		}
+	}
 	cfg.StateSync.DiscoveryTime = 5 * time.Second
 	cfg.Consensus.AppHashSize = crypto.DefaultHashSize
+	cfg.Consensus.ProcessProposalHeight = node.Testnet.ProcessProposalHeight
+	cfg.Consensus.CompactBlocks = node.Testnet.CompactBlocks
+	cfg.Mempool.AnnounceTxs = node.Testnet.AnnounceTxs
+	cfg.P2P.VerifyMasternodeIdentity = node.Testnet.VerifyMasternodeIdentity
+	if node.Testnet.Tracing {
+		cfg.Instrumentation.OtelEndpoint = node.Testnet.CollectorEndpoint()
+	}
 	switch node.ABCIProtocol {
 	case e2e.ProtocolUNIX:
 		cfg.ProxyApp = AppAddressUNIX
@@ -282,6 +383,17 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 		case e2e.ProtocolDashCore:
 			cfg.PrivValidatorKey = PrivvalKeyFile
 			cfg.PrivValidatorState = PrivvalStateFile
+			if node.CoreRPCUsername != "" {
+				cfg.PrivValidatorCoreRPCUsername = node.CoreRPCUsername
+				cfg.PrivValidatorCoreRPCPassword = node.CoreRPCPassword
+			}
+		case e2e.ProtocolDashCoreTCP:
+			// the node only talks to the remote signer, the mock core server
+			// behind it signs with the keys of the node
+			cfg.PrivValidatorListenAddr = PrivvalAddressTCP
+			cfg.PrivValidatorCoreRPCHost = ""
+			cfg.PrivValidatorKey = PrivvalKeyFile
+			cfg.PrivValidatorState = PrivvalStateFile
 		default:
 			return nil, fmt.Errorf("invalid privval protocol setting %q", node.PrivvalProtocol)
 		}
@@ -300,6 +412,9 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 		cfg.FastSync.Version = node.FastSync
 	}
 
+	cfg.StateSync.SnapshotInterval = node.SnapshotInterval
+	cfg.StateSync.SnapshotKeepRecent = node.SnapshotKeepRecent
+
 	if node.StateSync {
 		cfg.StateSync.Enable = true
 		cfg.StateSync.RPCServers = []string{}
@@ -334,16 +449,19 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 // MakeAppConfig generates an ABCI application config for a node.
 func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 	cfg := map[string]interface{}{
-		"chain_id":          node.Testnet.Name,
-		"dir":               "data/app",
-		"listen":            AppAddressUNIX,
-		"mode":              node.Mode,
-		"proxy_port":        node.ProxyPort,
-		"protocol":          "socket",
-		"persist_interval":  node.PersistInterval,
-		"snapshot_interval": node.SnapshotInterval,
-		"retain_blocks":     node.RetainBlocks,
-		"key_type":          bls12381.KeyType,
+		"chain_id":                  node.Testnet.Name,
+		"dir":                       "data/app",
+		"listen":                    AppAddressUNIX,
+		"mode":                      node.Mode,
+		"proxy_port":                node.ProxyPort,
+		"protocol":                  "socket",
+		"persist_interval":          node.PersistInterval,
+		"retain_blocks":             node.RetainBlocks,
+		"snapshot_chunk_size":       node.SnapshotChunkSize,
+		"kill_after_chunks_percent": node.KillAfterChunksPercent,
+		"key_type":                  bls12381.KeyType,
+		"invalid_proposals":         node.InvalidProposals,
+		"invalid_block_size_height": node.Testnet.InvalidBlockSizeHeight,
 	}
 	switch node.ABCIProtocol {
 	case e2e.ProtocolUNIX:
@@ -359,10 +477,27 @@ func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 	default:
 		return nil, fmt.Errorf("unexpected ABCI protocol setting %q", node.ABCIProtocol)
 	}
+	if node.Mode == e2e.ModeLight {
+		cfg["light_provider"] = node.LightProvider
+	}
 	if node.Mode == e2e.ModeValidator {
 		switch node.PrivvalProtocol {
 		case e2e.ProtocolFile:
-		case e2e.ProtocolDashCore:
+		case e2e.ProtocolDashCore, e2e.ProtocolDashCoreTCP:
+			if node.PrivvalProtocol == e2e.ProtocolDashCoreTCP {
+				cfg["privval_server"] = PrivvalAddressTCP
+				cfg["privval_core_rpc_host"] = config.DefaultConfig().PrivValidatorCoreRPCHost
+			}
+			if node.CoreRPCUsername != "" {
+				cfg["core_rpc_username"] = node.CoreRPCUsername
+				cfg["core_rpc_password"] = node.CoreRPCPassword
+			}
+			if node.CoreServerScenario != "" {
+				cfg["core_server_scenario"] = CoreServerScenarioFile
+			}
+			if node.Testnet.CoreChainLockInterval > 0 {
+				cfg["core_chain_lock_interval"] = int64(node.Testnet.CoreChainLockInterval / time.Second)
+			}
 		case e2e.ProtocolTCP:
 			cfg["privval_server"] = PrivvalAddressTCP
 			cfg["privval_key"] = PrivvalKeyFile
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
@@ -81,7 +82,43 @@ func Start(testnet *e2e.Testnet) error {
 		}
 		logger.Info(fmt.Sprintf("Node %v up on http://127.0.0.1:%v at height %v",
 			node.Name, node.ProxyPort, status.SyncInfo.LatestBlockHeight))
+		if node.StateSync {
+			if err := restartKilledSnapshotNodes(testnet); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
+
+// restartKilledSnapshotNodes checks that the nodes with kill_after_chunks_percent
+// were killed while serving the snapshot chunks, so the state sync completed
+// with the chunks from the other peers, and restarts them.
+func restartKilledSnapshotNodes(testnet *e2e.Testnet) error {
+	for _, node := range testnet.Nodes {
+		if node.KillAfterChunksPercent == 0 {
+			continue
+		}
+		client, err := node.Client()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err = client.Status(ctx)
+		cancel()
+		if err == nil {
+			return fmt.Errorf("node %v should have been killed after serving %v%% of the snapshot chunks",
+				node.Name, node.KillAfterChunksPercent)
+		}
+
+		logger.Info(fmt.Sprintf("Restarting node %v killed while serving the snapshot...", node.Name))
+		if err := execCompose(testnet.Dir, "up", "-d", node.Name); err != nil {
+			return err
+		}
+		if _, err := waitForNode(node, 0, time.Minute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package e2e_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -61,6 +62,61 @@ func TestApp_Hash(t *testing.T) {
 	})
 }
 
+// Tests that the app hashes of the nodes using other database backends, or
+// mixing them across their stores, match the ones of a node using a single
+// database backend.
+func TestApp_HashAcrossDatabases(t *testing.T) {
+	testnet := loadTestnet(t)
+	var reference *e2e.Node
+	for _, node := range testnet.ArchiveNodes() {
+		if !node.MixedDatabases() {
+			reference = node
+			break
+		}
+	}
+	if reference == nil {
+		t.Skip("no archive node with a single database backend")
+	}
+	refClient, err := reference.Client()
+	require.NoError(t, err)
+
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		if node.Stateless() || node.Name == reference.Name {
+			return
+		}
+		if !node.MixedDatabases() && node.Database == reference.Database {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		refStatus, err := refClient.Status(ctx)
+		require.NoError(t, err)
+
+		first := status.SyncInfo.EarliestBlockHeight
+		if node.RetainBlocks > 0 {
+			first++ // avoid race conditions with block pruning
+		}
+		last := status.SyncInfo.LatestBlockHeight
+		if refStatus.SyncInfo.LatestBlockHeight < last {
+			last = refStatus.SyncInfo.LatestBlockHeight
+		}
+		require.LessOrEqual(t, first, last, "no common height with %v", reference.Name)
+
+		for h := first; h <= last; h++ {
+			h := h
+			refBlock, err := refClient.Block(ctx, &h)
+			require.NoError(t, err)
+			block, err := client.Block(ctx, &h)
+			require.NoError(t, err)
+			require.EqualValues(t, refBlock.Block.AppHash, block.Block.AppHash,
+				"app hash mismatch with %v for height %v", reference.Name, h)
+		}
+	})
+}
+
 // Tests that we can set a value and retrieve it.
 func TestApp_Tx(t *testing.T) {
 	testNode(t, func(t *testing.T, node e2e.Node) {
@@ -106,3 +162,39 @@ func TestApp_Tx(t *testing.T) {
 
 	})
 }
+
+// Tests that the app takes the snapshots at the scheduled heights, keeping the
+// configured number of the most recent ones.
+func TestApp_Snapshots(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		if node.SnapshotInterval == 0 {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+
+		// the query path must match the one of the app
+		resp, err := client.ABCIQuery(ctx, "/snapshots", nil)
+		require.NoError(t, err)
+		var heights []uint64
+		require.NoError(t, json.Unmarshal(resp.Response.Value, &heights))
+
+		for _, height := range heights {
+			assert.Zero(t, height%node.SnapshotInterval,
+				"snapshot at height %v doesn't match the interval %v", height, node.SnapshotInterval)
+		}
+		sync := status.SyncInfo
+		if uint64(sync.LatestBlockHeight-sync.EarliestBlockHeight) >= 2*node.SnapshotInterval {
+			require.NotEmpty(t, heights, "no snapshots taken")
+			latest := heights[len(heights)-1]
+			assert.GreaterOrEqual(t, latest+2*node.SnapshotInterval, uint64(sync.LatestBlockHeight),
+				"snapshot not taken for more than %v blocks", 2*node.SnapshotInterval)
+		}
+		if node.SnapshotKeepRecent > 0 {
+			assert.LessOrEqual(t, uint64(len(heights)), node.SnapshotKeepRecent, "snapshots not pruned")
+		}
+	})
+}
@@ -89,3 +89,32 @@ func TestBlock_Range(t *testing.T) {
 		}
 	})
 }
+
+// Tests that the invalid block size set by the application is dropped, and that
+// the network keeps making progress with the previous block size.
+func TestBlock_InvalidBlockSizeDropped(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		height := node.Testnet.InvalidBlockSizeHeight
+		if node.Mode == e2e.ModeSeed || height == 0 {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		if status.SyncInfo.EarliestBlockHeight > height {
+			return // the node doesn't have the params of the height
+		}
+		require.Greater(t, status.SyncInfo.LatestBlockHeight, height+1,
+			"the network halted at the invalid block size update")
+
+		params, err := client.ConsensusParams(ctx, &height)
+		require.NoError(t, err)
+		nextHeight := height + 1
+		nextParams, err := client.ConsensusParams(ctx, &nextHeight)
+		require.NoError(t, err)
+		assert.Greater(t, nextParams.ConsensusParams.Block.MaxBytes, int64(0))
+		assert.Equal(t, params.ConsensusParams, nextParams.ConsensusParams)
+	})
+}
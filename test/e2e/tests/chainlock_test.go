@@ -0,0 +1,55 @@
+package e2e_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Tests that the nodes signing with Dash Core follow the chain lock of their
+// mock Dash Core server, as it advances.
+func TestChainLock_Advances(t *testing.T) {
+	testnet := loadTestnet(t)
+	if testnet.CoreChainLockInterval == 0 {
+		t.Skip("the chain locks of the mock core servers don't advance")
+	}
+
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		// the nodes signing through a remote signer don't talk to Dash Core
+		if node.Mode != e2e.ModeValidator || node.PrivvalProtocol != e2e.ProtocolDashCore {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		require.NoError(t, client.Start())
+		defer client.Stop() //nolint:errcheck // ignore for tests
+
+		const subscriber = "TestChainLock_Advances"
+		events, err := client.Subscribe(ctx, subscriber, types.EventQueryChainLockUpdate.String())
+		require.NoError(t, err)
+		defer client.UnsubscribeAll(ctx, subscriber) //nolint:errcheck // ignore for tests
+
+		timeout := 3*testnet.CoreChainLockInterval + 10*time.Second
+		var last uint32
+		for i := 0; i < 3; i++ {
+			select {
+			case event := <-events:
+				update, ok := event.Data.(types.EventDataChainLockUpdate)
+				require.True(t, ok, "unexpected event data %T", event.Data)
+				require.Greater(t, update.CoreBlockHeight, last)
+				last = update.CoreBlockHeight
+			case <-time.After(timeout):
+				t.Fatalf("no chain lock update within %v, the last one was %d", timeout, last)
+			}
+		}
+
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, status.SyncInfo.ChainLockedHeight, last)
+	})
+}
@@ -0,0 +1,138 @@
+package e2e_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// otelAttribute is an attribute of the spans written by the collector, in
+// the JSON mapping of OTLP.
+type otelAttribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string          `json:"stringValue"`
+		IntValue    json.RawMessage `json:"intValue"`
+	} `json:"value"`
+}
+
+// otelExport is an export of spans written by the collector.
+type otelExport struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []otelAttribute `json:"attributes"`
+		} `json:"resource"`
+		InstrumentationLibrarySpans []struct {
+			Spans []struct {
+				Name       string          `json:"name"`
+				Attributes []otelAttribute `json:"attributes"`
+			} `json:"spans"`
+		} `json:"instrumentationLibrarySpans"`
+	} `json:"resourceSpans"`
+}
+
+// Tests that the trace of every height committed by the validators arrives
+// at the collector.
+func TestTracing_Heights(t *testing.T) {
+	testnet := loadTestnet(t)
+	if !testnet.Tracing {
+		t.Skip("tracing is disabled")
+	}
+
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		// the nodes, which were restarted or didn't commit all the heights in
+		// consensus, may have lost some spans
+		if node.Mode != e2e.ModeValidator || node.StartAt > 0 || node.StateSync || len(node.Perturbations) > 0 {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		last := status.SyncInfo.LatestBlockHeight
+
+		// the spans are exported in batches
+		deadline := time.Now().Add(time.Minute)
+		for {
+			heights := loadTracedHeights(t, testnet, node.Name)
+			missing := testnet.InitialHeight
+			for missing <= last && heights[missing] {
+				missing++
+			}
+			if missing > last {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("no trace of height %v", missing)
+			}
+			time.Sleep(time.Second)
+		}
+	})
+}
+
+// loadTracedHeights returns the heights traced by the node, according to the
+// spans written by the collector so far.
+func loadTracedHeights(t *testing.T, testnet e2e.Testnet, moniker string) map[int64]bool {
+	file, err := os.Open(filepath.Join(testnet.Dir, "otel", "spans.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	defer file.Close()
+
+	heights := make(map[int64]bool)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		var export otelExport
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &export))
+		for _, resourceSpans := range export.ResourceSpans {
+			if otelStringValue(resourceSpans.Resource.Attributes, "service.instance.id") != moniker {
+				continue
+			}
+			for _, librarySpans := range resourceSpans.InstrumentationLibrarySpans {
+				for _, span := range librarySpans.Spans {
+					if span.Name != "height" {
+						continue
+					}
+					height, err := otelIntValue(span.Attributes, "height")
+					require.NoError(t, err)
+					heights[height] = true
+				}
+			}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return heights
+}
+
+func otelStringValue(attributes []otelAttribute, key string) string {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return attribute.Value.StringValue
+		}
+	}
+	return ""
+}
+
+// otelIntValue returns the value of an int attribute, which is encoded as a
+// string or as a number.
+func otelIntValue(attributes []otelAttribute, key string) (int64, error) {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return strconv.ParseInt(strings.Trim(string(attribute.Value.IntValue), `"`), 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("no attribute %q", key)
+}
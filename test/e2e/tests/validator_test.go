@@ -74,6 +74,51 @@ func TestValidator_Sets(t *testing.T) {
 	})
 }
 
+// Tests that /status reports whether the node is a member of the latest
+// validator set, and returns empty values for nodes without a proTxHash.
+func TestValidator_Status(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		if node.Mode == e2e.ModeSeed {
+			return
+		}
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+		info := status.ValidatorInfo
+
+		if len(node.ProTxHash) == 0 {
+			require.Empty(t, info.ProTxHash)
+			require.False(t, info.IsValidator)
+			require.Nil(t, info.PubKey)
+			require.Empty(t, info.QuorumHash)
+			require.Zero(t, info.VotingPower)
+			return
+		}
+		require.Equal(t, node.ProTxHash, info.ProTxHash)
+		require.Len(t, info.QuorumHash, crypto.QuorumHashSize)
+		require.Equal(t, info.IsValidator, info.VotingPower > 0)
+		require.Equal(t, info.IsValidator, info.PubKey != nil)
+
+		// the validator set may change between the requests, so the membership is
+		// compared only if the quorum is the same
+		height := status.SyncInfo.LatestBlockHeight + 1
+		quorum, err := client.ValidatorQuorum(ctx, &height)
+		require.NoError(t, err)
+		if !bytes.Equal(quorum.QuorumHash, info.QuorumHash) {
+			return
+		}
+		isMember := false
+		for _, member := range quorum.Members {
+			if bytes.Equal(member.ProTxHash, node.ProTxHash) {
+				isMember = true
+				require.Equal(t, member.PubKey, info.PubKey)
+			}
+		}
+		require.Equal(t, isMember, info.IsValidator)
+	})
+}
+
 // Tests that a validator proposes blocks when it's supposed to. It tolerates some
 // missed blocks, e.g. due to testnet perturbations.
 func TestValidator_Propose(t *testing.T) {
@@ -82,6 +127,10 @@ func TestValidator_Propose(t *testing.T) {
 		if node.Mode != e2e.ModeValidator {
 			return
 		}
+		// Blocks proposed by this node are rejected by the other validators.
+		if node.InvalidProposals {
+			return
+		}
 		proTxHash := node.ProTxHash
 		valSchedule := newValidatorSchedule(*node.Testnet)
 
@@ -105,6 +154,74 @@ func TestValidator_Propose(t *testing.T) {
 	})
 }
 
+// Tests that consensus survives quorum rotations: blocks after each rotation
+// are committed by the rotated quorum.
+func TestValidator_QuorumRotation(t *testing.T) {
+	testnet := loadTestnet(t)
+	if testnet.QuorumRotate == 0 {
+		return
+	}
+	blocks := fetchBlockChain(t)
+	require.NotEmpty(t, blocks)
+	first := blocks[0].Height
+	last := blocks[len(blocks)-1].Height
+
+	rotations := 0
+	for height, quorumHash := range testnet.QuorumHashUpdates {
+		// the quorum returned at the height signs the commit for height+2,
+		// which is included into the block at height+3
+		commitHeight := height + 3
+		if height == 0 || commitHeight < first || commitHeight > last {
+			continue
+		}
+		block := blocks[commitHeight-first]
+		require.Equal(t, quorumHash.Bytes(), block.LastCommit.QuorumHash,
+			"the commit at height %d is not signed by the quorum rotated at height %d", commitHeight-1, height)
+		rotations++
+	}
+	require.NotZero(t, rotations, "the network did not pass any quorum rotation")
+}
+
+// Tests that validators removed by validator updates stop proposing blocks,
+// while the remaining quorum keeps producing them.
+func TestValidator_Removals(t *testing.T) {
+	testnet := loadTestnet(t)
+	if !testnet.HasValidatorRemovals() {
+		return
+	}
+	blocks := fetchBlockChain(t)
+	require.NotEmpty(t, blocks)
+	first := blocks[0].Height
+	last := blocks[len(blocks)-1].Height
+
+	valSchedule := newValidatorSchedule(testnet)
+	valSchedule.Increment(first - testnet.InitialHeight)
+	removals := 0
+	for _, block := range blocks {
+		for height, nodes := range testnet.ValidatorRemovals {
+			if block.Height != height+2 {
+				continue
+			}
+			for _, node := range nodes {
+				require.False(t, valSchedule.Set.HasProTxHash(node.ProTxHash),
+					"validator %v removed at height %v is in the validator set", node.Name, height)
+			}
+			removals++
+		}
+		for _, nodes := range testnet.ValidatorRemovals {
+			for _, node := range nodes {
+				if !valSchedule.Set.HasProTxHash(node.ProTxHash) {
+					require.NotEqual(t, node.ProTxHash, block.ProposerProTxHash,
+						"validator %v proposed block %v while removed", node.Name, block.Height)
+				}
+			}
+		}
+		valSchedule.Increment(1)
+	}
+	require.NotZero(t, removals, "the network did not pass any validator removal")
+	require.Greater(t, last, first, "the network did not produce blocks")
+}
+
 // validatorSchedule is a validator set iterator, which takes into account
 // validator set updates.
 type validatorSchedule struct {
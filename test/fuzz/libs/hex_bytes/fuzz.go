@@ -0,0 +1,65 @@
+package hexbytes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+const (
+	verbs = "svqxXpdb"
+	flags = "#-+ 0"
+)
+
+// Fuzz checks that formatting HexBytes with any verb, flags, width and
+// precision doesn't panic, and that the output without the width and the
+// precision matches the documented forms. The first byte of data selects the
+// verb, the second one the flags, the third one the width and the fourth one
+// the precision, the rest is the value.
+func Fuzz(data []byte) int {
+	if len(data) < 4 {
+		return -1
+	}
+	verb := verbs[int(data[0])%len(verbs)]
+	var format strings.Builder
+	format.WriteByte('%')
+	for i := range flags {
+		if data[1]&(1<<i) != 0 {
+			format.WriteByte(flags[i])
+		}
+	}
+	sized := false
+	if data[2]&0x80 != 0 {
+		format.WriteString(strconv.Itoa(int(data[2] & 0x3f)))
+		sized = true
+	}
+	if data[3]&0x80 != 0 {
+		format.WriteString("." + strconv.Itoa(int(data[3]&0x3f)))
+		sized = true
+	}
+	format.WriteByte(verb)
+
+	bz := tmbytes.HexBytes(data[4:])
+	out := fmt.Sprintf(format.String(), bz)
+	if sized || data[1] != 0 {
+		return 0
+	}
+
+	var want string
+	switch verb {
+	case 's', 'v', 'X':
+		want = bz.String()
+	case 'q':
+		want = strconv.Quote(bz.String())
+	case 'x':
+		want = strings.ToLower(bz.String())
+	default:
+		return 0
+	}
+	if out != want {
+		panic(fmt.Sprintf("%%%c of %X gives %s, want %s", verb, []byte(bz), out, want))
+	}
+	return 1
+}
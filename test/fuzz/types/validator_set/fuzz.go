@@ -0,0 +1,51 @@
+package validatorset
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Fuzz checks that the validator sets, which are decoded from proto, survive
+// a round trip through proto unchanged.
+func Fuzz(data []byte) int {
+	vp := new(tmproto.ValidatorSet)
+	if err := proto.Unmarshal(data, vp); err != nil {
+		return -1
+	}
+	vals, err := types.ValidatorSetFromProto(vp)
+	if err != nil {
+		return 0
+	}
+	// the validation of the threshold public key must not panic on any input
+	_ = vals.ThresholdPublicKeyValid()
+
+	vp2, err := vals.ToProto()
+	if err != nil {
+		panic(fmt.Sprintf("can't convert a decoded validator set to proto: %v", err))
+	}
+	bz, err := proto.Marshal(vp2)
+	if err != nil {
+		panic(fmt.Sprintf("can't marshal a decoded validator set: %v", err))
+	}
+	vp3 := new(tmproto.ValidatorSet)
+	if err := proto.Unmarshal(bz, vp3); err != nil {
+		panic(fmt.Sprintf("can't unmarshal a marshaled validator set: %v", err))
+	}
+	vals2, err := types.ValidatorSetFromProto(vp3)
+	if err != nil {
+		panic(fmt.Sprintf("can't decode a marshaled validator set: %v", err))
+	}
+	if !vals.Equals(vals2) || vals.HasPublicKeys != vals2.HasPublicKeys ||
+		vals.TotalVotingPower() != vals2.TotalVotingPower() {
+		panic(fmt.Sprintf("validator set changed in the round trip: %v != %v", vals, vals2))
+	}
+	if !proto.Equal(vp2, vp3) {
+		panic(fmt.Sprintf("proto of the validator set changed in the round trip: %v != %v", vp2, vp3))
+	}
+
+	return 1
+}
@@ -0,0 +1,52 @@
+// nolint: gosec
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func main() {
+	baseDir := flag.String("base", ".", `where the "corpus" directory will live`)
+	flag.Parse()
+
+	initCorpus(*baseDir)
+}
+
+func initCorpus(baseDir string) {
+	log.SetFlags(0)
+
+	// create "corpus" directory
+	corpusDir := filepath.Join(baseDir, "corpus")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		log.Fatalf("Creating %q err: %v", corpusDir, err)
+	}
+
+	// create corpus
+	for i, size := range []int{1, 2, 4, 10} {
+		vals, _ := types.GenerateValidatorSet(size)
+		vp, err := vals.ToProto()
+		if err != nil {
+			log.Fatalf("can't convert %v to proto: %v", vals, err)
+		}
+		bz, err := proto.Marshal(vp)
+		if err != nil {
+			log.Fatalf("can't marshal %v: %v", vals, err)
+		}
+
+		filename := filepath.Join(corpusDir, fmt.Sprintf("%d", i))
+		if err := ioutil.WriteFile(filename, bz, 0644); err != nil {
+			log.Fatalf("can't write %v to %q: %v", vals, filename, err)
+		}
+
+		log.Printf("wrote %q", filename)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/dashevo/dashd-go/btcjson"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmcon "github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto/merkle"
@@ -19,6 +21,10 @@ import (
 
 var crc32c = crc32.MakeTable(crc32.Castagnoli)
 
+// initChainAppStateChunkSize is the size of the chunks of the genesis app state
+// sent to the apps, which support InitChainAppStateChunk.
+const initChainAppStateChunkSize = 1 << 20 // 1MB
+
 // Functionality to replay blocks and messages on recovery from a crash.
 // There are two general failure scenarios:
 //
@@ -208,6 +214,9 @@ type Handshaker struct {
 
 	nBlocks int // number of blocks applied to the state
 
+	// the app wants the app state of the genesis doc in chunks
+	appStateChunks bool
+
 	appHashSize int
 }
 
@@ -263,6 +272,8 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 		"protocol-version", res.AppVersion,
 	)
 
+	h.appStateChunks = res.SupportsInitChainAppStateChunks
+
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
 		h.initialState.Version.Consensus.App = res.AppVersion
@@ -319,7 +330,15 @@ func (h *Handshaker) ReplayBlocks(
 			InitialHeight:   h.genDoc.InitialHeight,
 			ConsensusParams: csParams,
 			ValidatorSet:    nextVals,
-			AppStateBytes:   h.genDoc.AppState,
+		}
+		var err error
+		if h.appStateChunks {
+			req.AppStateChunks, err = h.sendAppStateChunks(proxyApp.Consensus())
+		} else {
+			req.AppStateBytes, err = h.genDoc.LoadAppState()
+		}
+		if err != nil {
+			return nil, err
 		}
 		res, err := proxyApp.Consensus().InitChainSync(req)
 		if err != nil {
@@ -341,8 +360,12 @@ func (h *Handshaker) ReplayBlocks(
 				if err != nil {
 					return nil, err
 				}
-				state.Validators = types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, h.genDoc.QuorumType, quorumHash, h.genDoc.NodeProTxHash)
-				state.NextValidators = types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, h.genDoc.QuorumType, quorumHash, h.genDoc.NodeProTxHash).CopyIncrementProposerPriority(1)
+				quorumType := h.genDoc.QuorumType
+				if res.ValidatorSetUpdate.QuorumType != 0 {
+					quorumType = btcjson.LLMQType(res.ValidatorSetUpdate.QuorumType)
+				}
+				state.Validators = types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, quorumType, quorumHash, h.genDoc.NodeProTxHash)
+				state.NextValidators = types.NewValidatorSetWithLocalNodeProTxHash(vals, thresholdPublicKey, quorumType, quorumHash, h.genDoc.NodeProTxHash).CopyIncrementProposerPriority(1)
 			} else if len(h.genDoc.Validators) == 0 {
 				// If validator set is not set in genesis and still empty after InitChain, exit.
 				return nil, fmt.Errorf("validator set is nil in genesis and still empty after InitChain")
@@ -440,6 +463,38 @@ func (h *Handshaker) ReplayBlocks(
 		appBlockHeight, storeBlockHeight, stateBlockHeight))
 }
 
+// sendAppStateChunks streams the app state of the genesis doc to the app in
+// chunks of initChainAppStateChunkSize bytes before InitChain, so that the app
+// state isn't held in memory at once. It returns the number of chunks sent.
+func (h *Handshaker) sendAppStateChunks(appConn proxy.AppConnConsensus) (uint32, error) {
+	r, err := h.genDoc.AppStateReader()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	var index uint32
+	for {
+		chunk := make([]byte, initChainAppStateChunkSize)
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			_, err := appConn.InitChainAppStateChunkSync(abci.RequestInitChainAppStateChunk{
+				Index: index,
+				Chunk: chunk[:n],
+			})
+			if err != nil {
+				return 0, fmt.Errorf("error sending the app state chunk %d: %w", index, err)
+			}
+			index++
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return index, nil
+		} else if err != nil {
+			return 0, fmt.Errorf("couldn't read the app state: %w", err)
+		}
+	}
+}
+
 func (h *Handshaker) replayBlocks(
 	state sm.State,
 	proxyApp proxy.AppConns,
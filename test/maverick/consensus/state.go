@@ -544,6 +544,27 @@ func (cs *State) GetValidators() (int64, []*types.Validator) {
 	return cs.state.LastBlockHeight, cs.state.Validators.Copy().Validators
 }
 
+// ForceRoundSkip makes the node give up its current round as if the precommit
+// wait timeout expired. It returns the height and the round it skipped.
+func (cs *State) ForceRoundSkip() (int64, int32, error) {
+	cs.mtx.RLock()
+	height, round, step := cs.Height, cs.Round, cs.Step
+	cs.mtx.RUnlock()
+
+	if step == cstypes.RoundStepNewHeight || step >= cstypes.RoundStepApplyCommit {
+		return height, round, fmt.Errorf("can't skip round %d at height %d in step %v", round, height, step)
+	}
+
+	cs.Logger.Info("forcing round skip", "height", height, "round", round, "step", step)
+	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{
+		Duration: 0,
+		Height:   height,
+		Round:    round,
+		Step:     cstypes.RoundStepPrecommitWait,
+	})
+	return height, round, nil
+}
+
 // SetPrivValidator sets the private validator account for signing votes. It
 // immediately requests pubkey and caches it.
 func (cs *State) SetPrivValidator(priv types.PrivValidator) {
@@ -1282,7 +1303,12 @@ func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.Pa
 	}
 	proposerProTxHash := cs.privValidatorProTxHash
 
-	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerProTxHash)
+	block, blockParts, err := cs.blockExec.CreateProposalBlock(cs.Height, cs.Round, cs.state, commit, proposerProTxHash)
+	if err != nil {
+		cs.Logger.Error("propose step; failed to create proposal block", "err", err)
+		return nil, nil
+	}
+	return block, blockParts
 }
 
 // Enter: any +2/3 prevotes at next round.
@@ -1902,6 +1928,9 @@ func (cs *State) updatePrivValidatorPubKey() error {
 		return nil
 	}
 
+	if setter, ok := cs.privValidator.(types.QuorumTypeSetter); ok {
+		setter.SetQuorumType(cs.Validators.QuorumHash, cs.Validators.QuorumType)
+	}
 	pubKey, err := cs.privValidator.GetPubKey(cs.Validators.QuorumHash)
 	if err != nil {
 		return err
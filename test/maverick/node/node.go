@@ -20,6 +20,7 @@ import (
 
 	dbm "github.com/tendermint/tm-db"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
 	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
 	bcv1 "github.com/tendermint/tendermint/blockchain/v1"
@@ -28,6 +29,7 @@ import (
 	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/evidence"
+	tmdb "github.com/tendermint/tendermint/libs/db"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
@@ -44,6 +46,7 @@ import (
 	"github.com/tendermint/tendermint/state/indexer"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
+	"github.com/tendermint/tendermint/state/indexer/sink/psql"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/state/txindex/null"
@@ -96,11 +99,11 @@ type DBContext struct {
 // DBProvider takes a DBContext and returns an instantiated DB.
 type DBProvider func(*DBContext) (dbm.DB, error)
 
-// DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the ctx.Config.
+// DefaultDBProvider returns a database using the DBBackend (or the backend
+// of the store in the storage config) and DBDir specified in the ctx.Config.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
-	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	dbType := dbm.BackendType(ctx.Config.DBBackendFor(ctx.ID))
+	return tmdb.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
 }
 
 // GenesisDocProvider returns a GenesisDoc.
@@ -141,20 +144,24 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger, misbehaviors map[int6
 
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*consensus.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state, privval and blockchain Metrics.
+type MetricsProvider func(chainID string) (*consensus.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics,
+	*privval.Metrics, *bcv0.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*consensus.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*consensus.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics,
+		*privval.Metrics, *bcv0.Metrics) {
 		if config.Prometheus {
 			return consensus.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				privval.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				bcv0.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return consensus.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return consensus.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), privval.NopMetrics(), bcv0.NopMetrics()
 	}
 }
 
@@ -274,7 +281,7 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 }
 
 func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyApp := proxy.NewAppConns(clientCreator, abcicli.NopMetrics())
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -295,16 +302,19 @@ func createAndStartIndexerService(
 	config *cfg.Config,
 	dbProvider DBProvider,
 	eventBus *types.EventBus,
+	chainID string,
 	logger log.Logger,
 ) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
 
 	var (
 		txIndexer    txindex.TxIndexer
 		blockIndexer indexer.BlockIndexer
+		eventSinks   []indexer.EventSink
 	)
 
-	switch config.TxIndex.Indexer {
-	case "kv":
+	// The search RPCs are served by the kv indexer, the other ones are
+	// write-only sinks.
+	if config.TxIndex.IsEnabled(string(indexer.KV)) {
 		store, err := dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
 			return nil, nil, nil, err
@@ -312,12 +322,20 @@ func createAndStartIndexerService(
 
 		txIndexer = kv.NewTxIndex(store)
 		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
-	default:
+	} else {
 		txIndexer = &null.TxIndex{}
 		blockIndexer = &blockidxnull.BlockerIndexer{}
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus)
+	if config.TxIndex.IsEnabled(string(indexer.PSQL)) {
+		sink, err := psql.NewEventSink(config.TxIndex.PsqlConn, chainID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("creating psql indexer: %w", err)
+		}
+		eventSinks = append(eventSinks, sink)
+	}
+
+	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, eventSinks...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	if err := indexerService.Start(); err != nil {
@@ -385,6 +403,7 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 		config.Mempool,
 		proxyApp.Mempool(),
 		state.LastBlockHeight,
+		mempl.WithLastBlock(state.LastBlockTime, state.NextProposerProTxHash()),
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
@@ -422,11 +441,13 @@ func createBlockchainReactor(config *cfg.Config,
 	blockStore *store.BlockStore,
 	nodeProTxHash *crypto.ProTxHash,
 	fastSync bool,
+	bcMetrics *bcv0.Metrics,
 	logger log.Logger) (bcReactor p2p.Reactor, err error) {
 
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, nodeProTxHash, fastSync)
+		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, nodeProTxHash, fastSync,
+			bcv0.ReactorMetrics(bcMetrics), bcv0.ReactorMaxInFlightBytes(config.FastSync.MaxInFlightBytes))
 	case "v1":
 		bcReactor = bcv1.NewBlockchainReactor(state.Copy(), blockExec, blockStore, nodeProTxHash, fastSync)
 	case "v2":
@@ -479,6 +500,8 @@ func createTransport(
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	identityVerifier p2p.MasternodeIdentityVerifier,
+	banList *p2p.BanList,
 ) (
 	*p2p.MultiplexTransport,
 	[]p2p.PeerFilterFunc,
@@ -536,6 +559,14 @@ func createTransport(
 
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 
+	// Verify the proof of the masternode ownership of the peers.
+	if identityVerifier != nil {
+		p2p.MultiplexTransportMasternodeIdentityVerifier(identityVerifier)(transport)
+	}
+
+	// Refuse the banned peers.
+	p2p.MultiplexTransportBanList(banList)(transport)
+
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
@@ -554,6 +585,7 @@ func createSwitch(config *cfg.Config,
 	evidenceReactor *evidence.Reactor,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
+	banList *p2p.BanList,
 	p2pLogger log.Logger) *p2p.Switch {
 
 	sw := p2p.NewSwitch(
@@ -561,6 +593,7 @@ func createSwitch(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchBanList(banList),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -604,7 +637,7 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 }
 
 func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
-	sw *p2p.Switch, logger log.Logger) *pex.Reactor {
+	sw *p2p.Switch, masternodes pex.MasternodeList, logger log.Logger) *pex.Reactor {
 
 	// TODO persistent peers ? so we can have their DNS addrs saved
 	pexReactor := pex.NewReactor(addrBook,
@@ -618,6 +651,8 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 			// https://github.com/tendermint/tendermint/issues/3523
 			SeedDisconnectWaitPeriod:     28 * time.Hour,
 			PersistentPeersMaxDialPeriod: config.P2P.PersistentPeersMaxDialPeriod,
+			OnlyMasternodePeers:          config.P2P.OnlyMasternodePeers,
+			Masternodes:                  masternodes,
 		})
 	pexReactor.SetLogger(logger.With("module", "pex"))
 	sw.AddReactor("PEX", pexReactor)
@@ -715,23 +750,38 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
+	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, genDoc.ChainID, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, pvMetrics, bcMetrics := metricsProvider(genDoc.ChainID)
+
 	var weAreOnlyValidator bool
 	var proTxHash crypto.ProTxHash
 	if config.PrivValidatorCoreRPCHost != "" {
 		logger.Info("Initializing Dash Core Signing", "quorum hash", state.Validators.QuorumHash.String())
 		username := config.BaseConfig.PrivValidatorCoreRPCUsername
 		password := config.BaseConfig.PrivValidatorCoreRPCPassword
-		llmqType := config.Consensus.QuorumType
+		// the quorum type of the validator set takes precedence over the config,
+		// the sign requests carry the quorum type of the validator set anyway
+		llmqType := state.Validators.QuorumType
+		if llmqType == 0 {
+			llmqType = config.Consensus.QuorumType
+		}
 		if llmqType == 0 {
 			llmqType = btcjson.LLMQType_100_67
 		}
 		// If a local port is provided for Dash Core rpc into the service to sign.
-		privValidator, err = createAndStartPrivValidatorRPCClient(config.PrivValidatorCoreRPCHost, config.Consensus.QuorumType, username, password, logger)
+		privValidator, err = createAndStartPrivValidatorRPCClient(
+			config.PrivValidatorCoreRPCHost,
+			llmqType,
+			username,
+			password,
+			config.Consensus.TimeoutPropose,
+			pvMetrics,
+			logger,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("error with private validator socket client: %w", err)
 		}
@@ -789,8 +839,6 @@ func NewNode(config *cfg.Config,
 
 	logNodeStartupInfo(state, proTxHash, logger, consensusLogger)
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
-
 	// Make Mempool Reactor
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
 
@@ -816,10 +864,15 @@ func NewNode(config *cfg.Config,
 		nextCoreChainLock,
 		sm.BlockExecutorWithMetrics(smMetrics),
 		sm.BlockExecutorWithAppHashSize(config.Consensus.AppHashSize),
+		sm.BlockExecutorWithSnapshotPolicy(sm.SnapshotPolicy{
+			Interval:   config.StateSync.SnapshotInterval,
+			KeepRecent: config.StateSync.SnapshotKeepRecent,
+		}),
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, &proTxHash, fastSync && !stateSync, logger)
+	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, &proTxHash, fastSync && !stateSync,
+		bcMetrics, logger)
 	if err != nil {
 		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
 	}
@@ -840,23 +893,36 @@ func NewNode(config *cfg.Config,
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
 	// https://github.com/tendermint/tendermint/issues/4644
-	stateSyncReactor := statesync.NewReactor(proxyApp.Snapshot(), proxyApp.Query(),
-		config.StateSync.TempDir)
+	stateSyncReactor := statesync.NewReactor(
+		*config.StateSync, proxyApp.Snapshot(), proxyApp.Query(), stateStore, blockStore,
+	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
 	if err != nil {
 		return nil, err
 	}
+	// Prove the ownership of the masternode to the peers.
+	if err := proveMasternodeIdentity(&nodeInfo, privValidator, state); err != nil {
+		logger.Error("Failed to prove the masternode identity, connecting as a full node", "err", err)
+	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	var identityVerifier p2p.MasternodeIdentityVerifier
+	if config.P2P.VerifyMasternodeIdentity {
+		identityVerifier = newMasternodeIdentityVerifier(genDoc.ChainID, stateStore, privValidator)
+	}
+	banList, err := p2p.LoadBanList(config.P2P.BanListFile())
+	if err != nil {
+		return nil, fmt.Errorf("could not load ban list: %w", err)
+	}
+	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp, identityVerifier, banList)
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, banList, p2pLogger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -888,7 +954,12 @@ func NewNode(config *cfg.Config,
 	// Note we currently use the addrBook regardless at least for AddOurAddress
 	var pexReactor *pex.Reactor
 	if config.P2P.PexReactor {
-		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, logger)
+		// the masternode list is provided by Dash Core
+		masternodes, _ := privValidator.(pex.MasternodeList)
+		if config.P2P.OnlyMasternodePeers && masternodes == nil {
+			return nil, errors.New("only_masternode_peers requires priv_validator_core_rpc_host")
+		}
+		pexReactor = createPEXReactorAndAddToSwitch(addrBook, config, sw, masternodes, logger)
 	}
 
 	if config.RPC.PprofListenAddress != "" {
@@ -1109,6 +1180,10 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.MaxConcurrentRequestsPerIP = n.config.RPC.MaxConcurrentRequestsPerIP
+	config.RateLimitedRequestsPerSecond = n.config.RPC.ExpensiveRequestsPerSecond
+	config.RateLimitedRequestsBurst = n.config.RPC.ExpensiveRequestsBurst
+	config.MaxSubscriptionsPerConnection = n.config.RPC.MaxSubscriptionsPerClient
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -1319,7 +1394,7 @@ func makeNodeInfo(
 	txIndexer txindex.TxIndexer,
 	genDoc *types.GenesisDoc,
 	state sm.State,
-) (p2p.NodeInfo, error) {
+) (p2p.DefaultNodeInfo, error) {
 	txIndexerStatus := "on"
 	if _, ok := txIndexer.(*null.TxIndex); ok {
 		txIndexerStatus = "off"
@@ -1334,7 +1409,7 @@ func makeNodeInfo(
 	case "v2":
 		bcChannel = bcv2.BlockchainChannel
 	default:
-		return nil, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
+		return p2p.DefaultNodeInfo{}, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
 	}
 
 	nodeInfo := p2p.DefaultNodeInfo{
@@ -1351,7 +1426,7 @@ func makeNodeInfo(
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
 			evidence.EvidenceChannel,
-			statesync.SnapshotChannel, statesync.ChunkChannel,
+			statesync.SnapshotChannel, statesync.ChunkChannel, statesync.LightBlockChannel,
 		},
 		Moniker: config.Moniker,
 		Other: p2p.DefaultNodeInfoOther{
@@ -1376,6 +1451,99 @@ func makeNodeInfo(
 	return nodeInfo, err
 }
 
+// proveMasternodeIdentity sets the proTxHash of the node and the signature of
+// the node ID by its key in the current quorum, so the peers can verify the
+// ownership of the masternode. The node info is left as is, if the node isn't
+// a member of the current validator set.
+func proveMasternodeIdentity(nodeInfo *p2p.DefaultNodeInfo, privValidator types.PrivValidator, state sm.State) error {
+	signer, ok := privValidator.(types.NodeIDSigner)
+	if !ok || state.Validators == nil {
+		return nil
+	}
+	proTxHash, err := privValidator.GetProTxHash()
+	if err != nil {
+		return fmt.Errorf("can't get proTxHash: %w", err)
+	}
+	vals := state.Validators
+	if !vals.HasProTxHash(proTxHash) {
+		return nil
+	}
+	signature, err := signer.SignNodeID(state.ChainID, string(nodeInfo.ID()), vals.QuorumType, vals.QuorumHash)
+	if err != nil {
+		return fmt.Errorf("can't sign the node ID: %w", err)
+	}
+	nodeInfo.ProTxHash = &proTxHash
+	nodeInfo.QuorumHash = vals.QuorumHash
+	nodeInfo.NodeIDSignature = signature
+	return nil
+}
+
+// quorumMemberKeys provides the public key shares of the quorum members, e.g.
+// the Dash Core signer client.
+type quorumMemberKeys interface {
+	GetMemberPubKey(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash,
+		proTxHash crypto.ProTxHash) (crypto.PubKey, error)
+}
+
+// masternodeIdentityVerifier verifies the proof of the masternode ownership of
+// the peers with the public keys of the validator sets of the latest state.
+// The keys of the other quorums are looked up in Dash Core, if available.
+type masternodeIdentityVerifier struct {
+	chainID    string
+	stateStore sm.Store
+	memberKeys quorumMemberKeys
+}
+
+var _ p2p.MasternodeIdentityVerifier = (*masternodeIdentityVerifier)(nil)
+
+func newMasternodeIdentityVerifier(
+	chainID string,
+	stateStore sm.Store,
+	privValidator types.PrivValidator,
+) *masternodeIdentityVerifier {
+	memberKeys, _ := privValidator.(quorumMemberKeys)
+	return &masternodeIdentityVerifier{
+		chainID:    chainID,
+		stateStore: stateStore,
+		memberKeys: memberKeys,
+	}
+}
+
+// VerifyMasternodeIdentity implements p2p.MasternodeIdentityVerifier.
+func (v *masternodeIdentityVerifier) VerifyMasternodeIdentity(nodeInfo p2p.DefaultNodeInfo) error {
+	proTxHash := *nodeInfo.ProTxHash
+	quorumHash := crypto.QuorumHash(nodeInfo.QuorumHash)
+	nodeID := string(nodeInfo.ID())
+
+	state, err := v.stateStore.Load()
+	if err != nil {
+		return err
+	}
+	for _, vals := range []*types.ValidatorSet{state.Validators, state.NextValidators, state.LastValidators} {
+		if vals == nil || !bytes.Equal(vals.QuorumHash, quorumHash) {
+			continue
+		}
+		_, val := vals.GetByProTxHash(proTxHash)
+		if val == nil {
+			return fmt.Errorf("masternode %v isn't a member of quorum %v", proTxHash, quorumHash)
+		}
+		return types.VerifyNodeIDSignature(v.chainID, nodeID, proTxHash, vals.QuorumType, quorumHash,
+			val.PubKey, nodeInfo.NodeIDSignature)
+	}
+
+	if v.memberKeys == nil || state.Validators == nil {
+		return fmt.Errorf("unknown quorum %v", quorumHash)
+	}
+	// the quorums of the same chain are of the same type
+	quorumType := state.Validators.QuorumType
+	pubKey, err := v.memberKeys.GetMemberPubKey(quorumType, quorumHash, proTxHash)
+	if err != nil {
+		return err
+	}
+	return types.VerifyNodeIDSignature(v.chainID, nodeID, proTxHash, quorumType, quorumHash,
+		pubKey, nodeInfo.NodeIDSignature)
+}
+
 //------------------------------------------------------------------------------
 
 var (
@@ -1473,10 +1641,17 @@ func createAndStartPrivValidatorRPCClient(
 	defaultQuorumType btcjson.LLMQType,
 	username string,
 	password string,
+	retryTimeout time.Duration,
+	metrics *privval.Metrics,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
 
-	pvsc, err := privval.NewDashCoreSignerClient(host, username, password, defaultQuorumType)
+	// the sign requests are retried within the proposal timeout, if Dash Core is restarted
+	pvsc, err := privval.NewDashCoreSignerClient(host, username, password, defaultQuorumType,
+		privval.DashCoreSignerClientRetryTimeout(retryTimeout),
+		privval.DashCoreSignerClientMetrics(metrics),
+		privval.DashCoreSignerClientLogger(logger.With("module", "privval")),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
@@ -291,6 +291,40 @@ func BlockFromProto(bp *tmproto.Block) (*Block, error) {
 	return b, b.ValidateBasic()
 }
 
+// ToCompactProto converts the block to the protobuf block without the
+// transactions, and returns the hashes of the transactions, so the block can be
+// reconstructed from the transactions with BlockFromCompactProto.
+func (b *Block) ToCompactProto() (*tmproto.Block, [][]byte, error) {
+	pb, err := b.ToProto()
+	if err != nil {
+		return nil, nil, err
+	}
+	pb.Data.Txs = nil
+
+	txHashes := make([][]byte, len(b.Txs))
+	for i, tx := range b.Txs {
+		txHashes[i] = tx.Hash()
+	}
+	return pb, txHashes, nil
+}
+
+// BlockFromCompactProto reconstructs the block from the protobuf block without
+// the transactions, see ToCompactProto, and the transactions.
+// It returns an error if the block is invalid.
+func BlockFromCompactProto(bp *tmproto.Block, txs Txs) (*Block, error) {
+	if bp == nil {
+		return nil, errors.New("nil block")
+	}
+	if len(bp.Data.Txs) > 0 {
+		return nil, errors.New("compact block contains transactions")
+	}
+
+	data := Data{Txs: txs}
+	full := *bp
+	full.Data = data.ToProto()
+	return BlockFromProto(&full)
+}
+
 //-----------------------------------------------------------------------------
 
 // MaxDataBytes returns the maximum size of block's data.
@@ -303,6 +337,7 @@ func MaxDataBytes(maxBytes int64, keyType crypto.KeyType, evidenceBytes int64, v
 		MaxHeaderBytes -
 		MaxCoreChainLockSize -
 		MaxCommitOverheadBytes -
+		MaxCommitSignersBytes(valsCount) -
 		evidenceBytes
 
 	if maxDataBytes < 0 {
@@ -327,7 +362,8 @@ func MaxDataBytesNoEvidence(maxBytes int64, keyType crypto.KeyType, valsCount in
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
 		MaxCoreChainLockSize -
-		MaxCommitOverheadBytes
+		MaxCommitOverheadBytes -
+		MaxCommitSignersBytes(valsCount)
 
 	if maxDataBytes < 0 {
 		panic(fmt.Sprintf(
@@ -618,8 +654,19 @@ const (
 	// MaxCommitOverheadBytes is the max size of commit -> 82 for BlockID, 34 for StateID, 8 for Height, 4 for Round.
 	// 96 for Block signature, 96 for State Signature
 	MaxCommitOverheadBytes int64 = 326
+	// MaxCommitVoteExtensionBytes is the max size, which a vote extension adds to a commit ->
+	// MaxVoteExtensionSize for the extension, 96 for its threshold signature and 6 for the field tags
+	// and lengths
+	MaxCommitVoteExtensionBytes int64 = int64(MaxVoteExtensionSize) + 96 + 6
 )
 
+// MaxCommitSignersBytes returns the max size, which the signers of a validator
+// set of the given size add to a commit -> 10 for every 64 validators, 11 for
+// their count and 8 for the field tags and lengths.
+func MaxCommitSignersBytes(valsCount int) int64 {
+	return 10*int64((valsCount+63)/64) + 11 + 8
+}
+
 //-------------------------------------
 
 // Commit contains the evidence that a block was committed by a set of validators.
@@ -636,6 +683,14 @@ type Commit struct {
 	QuorumHash              []byte      `json:"quorum_hash"`
 	ThresholdBlockSignature []byte      `json:"threshold_block_signature"`
 	ThresholdStateSignature []byte      `json:"threshold_state_signature"`
+	// VoteExtension and its threshold signature are set if vote extensions are enabled
+	VoteExtension                   []byte `json:"vote_extension,omitempty"`
+	ThresholdVoteExtensionSignature []byte `json:"threshold_vote_extension_signature,omitempty"`
+	// Signers are the validators, in the order of the validator set, whose
+	// precommits for the block were collected by the proposer of the next
+	// block. The threshold signatures don't show who signed, so the signers
+	// are covered by the hash of the commit instead.
+	Signers *bits.BitArray `json:"signers"`
 
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
@@ -662,14 +717,27 @@ func NewCommit(height int64, round int32, blockID BlockID, stateID StateID, quor
 //
 func (commit *Commit) GetCanonicalVote() *Vote {
 	return &Vote{
-		Type:    tmproto.PrecommitType,
-		Height:  commit.Height,
-		Round:   commit.Round,
-		BlockID: commit.BlockID,
-		StateID: commit.StateID,
+		Type:      tmproto.PrecommitType,
+		Height:    commit.Height,
+		Round:     commit.Round,
+		BlockID:   commit.BlockID,
+		StateID:   commit.StateID,
+		Extension: commit.VoteExtension,
 	}
 }
 
+// IsExtended returns true if the commit carries a threshold-signed vote extension.
+func (commit *Commit) IsExtended() bool {
+	return len(commit.ThresholdVoteExtensionSignature) > 0
+}
+
+// CanonicalVoteExtensionSignId returns the signId bytes of the vote extension that is threshold signed.
+func (commit *Commit) CanonicalVoteExtensionSignId(chainID string, quorumType btcjson.LLMQType,
+	quorumHash []byte) []byte {
+	v := commit.GetCanonicalVote()
+	return VoteExtensionSignId(chainID, v.ToProto(), quorumType, quorumHash)
+}
+
 // VoteBlockRequestId returns the requestId Hash of the Vote corresponding to valIdx for
 // signing.
 //
@@ -797,6 +865,16 @@ func (commit *Commit) ValidateBasic() error {
 			return fmt.Errorf("state threshold signature is wrong size (wanted: %d, received: %d)", SignatureSize, len(commit.ThresholdStateSignature))
 		}
 	}
+	if len(commit.VoteExtension) > MaxVoteExtensionSize {
+		return fmt.Errorf("vote extension is too big (max: %d)", MaxVoteExtensionSize)
+	}
+	if commit.IsExtended() && len(commit.ThresholdVoteExtensionSignature) != SignatureSize {
+		return fmt.Errorf("vote extension threshold signature is wrong size (wanted: %d, received: %d)",
+			SignatureSize, len(commit.ThresholdVoteExtensionSignature))
+	}
+	if !commit.IsExtended() && len(commit.VoteExtension) > 0 {
+		return errors.New("vote extension threshold signature is missing")
+	}
 	return nil
 }
 
@@ -809,6 +887,16 @@ func (commit *Commit) Hash() tmbytes.HexBytes {
 		bs := make([][]byte, 2)
 		bs[0] = commit.ThresholdBlockSignature
 		bs[1] = commit.ThresholdStateSignature
+		if commit.IsExtended() {
+			bs = append(bs, commit.VoteExtension, commit.ThresholdVoteExtensionSignature)
+		}
+		if commit.Signers != nil {
+			bz, err := commit.Signers.ToProto().Marshal()
+			if err != nil {
+				panic(err)
+			}
+			bs = append(bs, bz)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -872,6 +960,10 @@ func (commit *Commit) ToProto() *tmproto.Commit {
 
 	c.QuorumHash = commit.QuorumHash
 
+	c.VoteExtension = commit.VoteExtension
+	c.ThresholdVoteExtensionSignature = commit.ThresholdVoteExtensionSignature
+	c.Signers = commit.Signers.ToProto()
+
 	return c
 }
 
@@ -899,6 +991,17 @@ func CommitFromProto(cp *tmproto.Commit) (*Commit, error) {
 	commit.QuorumHash = cp.QuorumHash
 	commit.ThresholdBlockSignature = cp.ThresholdBlockSignature
 	commit.ThresholdStateSignature = cp.ThresholdStateSignature
+	commit.VoteExtension = cp.VoteExtension
+	commit.ThresholdVoteExtensionSignature = cp.ThresholdVoteExtensionSignature
+	if cp.Signers != nil {
+		if cp.Signers.Bits < 0 || cp.Signers.Bits > MaxVotesCount ||
+			int64(len(cp.Signers.Elems)) != (cp.Signers.Bits+63)/64 {
+			return nil, fmt.Errorf("invalid signers: %d bits in %d elements (max: %d bits)",
+				cp.Signers.Bits, len(cp.Signers.Elems), MaxVotesCount)
+		}
+		commit.Signers = new(bits.BitArray)
+		commit.Signers.FromProto(cp.Signers)
+	}
 
 	commit.Height = cp.Height
 	commit.Round = cp.Round
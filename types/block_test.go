@@ -321,6 +321,10 @@ func TestMaxCommitBytes(t *testing.T) {
 	pb = commit.ToProto()
 
 	assert.EqualValues(t, MaxCommitOverheadBytes, int64(pb.Size()))
+
+	// the signers of the largest validator set
+	commit.Signers = MakeAllCommitSigners(MaxVotesCount)
+	assert.LessOrEqual(t, int64(commit.ToProto().Size()), MaxCommitOverheadBytes+MaxCommitSignersBytes(MaxVotesCount))
 }
 
 func TestHeaderHash(t *testing.T) {
@@ -478,11 +482,12 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	}{
 		0: {-10, crypto.BLS12381, 1, 0, true, 0},
 		1: {10, crypto.BLS12381, 1, 0, true, 0},
-		2: {1114, crypto.BLS12381, 1, 0, true, 0},
-		3: {1115, crypto.BLS12381, 1, 0, false, 0},
-		4: {1116, crypto.BLS12381, 1, 0, false, 1},
-		5: {1116, crypto.BLS12381, 2, 0, false, 1},
-		6: {1215, crypto.BLS12381, 2, 100, false, 0},
+		2: {1143, crypto.BLS12381, 1, 0, true, 0},
+		3: {1144, crypto.BLS12381, 1, 0, false, 0},
+		4: {1145, crypto.BLS12381, 1, 0, false, 1},
+		5: {1145, crypto.BLS12381, 2, 0, false, 1},
+		6: {1244, crypto.BLS12381, 2, 100, false, 0},
+		7: {1155, crypto.BLS12381, 65, 0, false, 1},
 	}
 	// An extra 33 bytes (32 for sig, 1 for proto encoding are needed for BLS compared to edwards per validator
 
@@ -514,9 +519,9 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}{
 		0: {-10, 1, crypto.BLS12381, 1, true, 0},
 		1: {10, 1, crypto.BLS12381, 1, true, 0},
-		2: {1114, 1, crypto.BLS12381, 1, true, 0},
-		3: {1115, 1, crypto.BLS12381, 1, false, 0},
-		4: {1116, 1, crypto.BLS12381, 1, false, 1},
+		2: {1143, 1, crypto.BLS12381, 1, true, 0},
+		3: {1144, 1, crypto.BLS12381, 1, false, 0},
+		4: {1145, 1, crypto.BLS12381, 1, false, 1},
 	}
 
 	for i, tc := range testCases {
@@ -681,6 +686,38 @@ func TestBlockProtoBuf(t *testing.T) {
 	}
 }
 
+func TestBlockCompactProto(t *testing.T) {
+	txs := []Tx{Tx("tx1"), Tx("tx2"), Tx("tx3")}
+	block := MakeBlock(3, 0, nil, txs, randCommit(), []Evidence{})
+	block.ProposerProTxHash = tmrand.Bytes(crypto.DefaultHashSize)
+
+	pb, txHashes, err := block.ToCompactProto()
+	require.NoError(t, err)
+	assert.Empty(t, pb.Data.Txs)
+	require.Len(t, txHashes, len(txs))
+	for i, tx := range txs {
+		assert.EqualValues(t, tx.Hash(), txHashes[i])
+	}
+
+	// the reconstructed block has the same parts
+	reconstructed, err := BlockFromCompactProto(pb, txs)
+	require.NoError(t, err)
+	assert.Equal(t, block.MakePartSet(BlockPartSizeBytes).Header(),
+		reconstructed.MakePartSet(BlockPartSizeBytes).Header())
+	assert.Empty(t, pb.Data.Txs)
+
+	// the transactions must match the data hash
+	_, err = BlockFromCompactProto(pb, txs[:2])
+	assert.Error(t, err)
+
+	full, err := block.ToProto()
+	require.NoError(t, err)
+	_, err = BlockFromCompactProto(full, txs)
+	assert.Error(t, err)
+	_, err = BlockFromCompactProto(nil, txs)
+	assert.Error(t, err)
+}
+
 func TestDataProtoBuf(t *testing.T) {
 	data := &Data{Txs: Txs{Tx([]byte{1}), Tx([]byte{2}), Tx([]byte{3})}}
 	data2 := &Data{Txs: Txs{}}
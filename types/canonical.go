@@ -90,6 +90,17 @@ func CanonicalizeStateVote(vote *tmproto.Vote) tmproto.CanonicalStateVote {
 	}
 }
 
+// CanonicalizeVoteExtension transforms the extension of the given Vote to a
+// CanonicalVoteExtension, which is signed separately from the block and the state.
+func CanonicalizeVoteExtension(chainID string, vote *tmproto.Vote) tmproto.CanonicalVoteExtension {
+	return tmproto.CanonicalVoteExtension{
+		Extension: vote.Extension,
+		Height:    vote.Height,       // encoded as sfixed64
+		Round:     int64(vote.Round), // encoded as sfixed64
+		ChainID:   chainID,
+	}
+}
+
 // CanonicalTime can be used to stringify time in a canonical way.
 func CanonicalTime(t time.Time) string {
 	// Note that sending time over amino resets it to
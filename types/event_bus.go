@@ -81,6 +81,18 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeWithPolicy subscribes with the given policy applied to the slow
+// consumers (see tmpubsub.Server.SubscribeWithPolicy).
+func (b *EventBus) SubscribeWithPolicy(
+	ctx context.Context,
+	subscriber string,
+	query tmpubsub.Query,
+	capacity int,
+	policy tmpubsub.SlowConsumerPolicy,
+) (Subscription, error) {
+	return b.pubsub.SubscribeWithPolicy(ctx, subscriber, query, capacity, policy)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
@@ -140,6 +152,7 @@ func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
+	addValidatorSetUpdateEvents(events, data.ResultEndBlock.ValidatorSetUpdate)
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
@@ -154,6 +167,7 @@ func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) erro
 
 	// add predefined new block header event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockHeader)
+	addValidatorSetUpdateEvents(events, data.ResultEndBlock.ValidatorSetUpdate)
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
@@ -162,6 +176,10 @@ func (b *EventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return b.Publish(EventNewEvidence, evidence)
 }
 
+func (b *EventBus) PublishEventRejectedUpdates(data EventDataRejectedUpdates) error {
+	return b.Publish(EventRejectedUpdates, data)
+}
+
 func (b *EventBus) PublishEventVote(data EventDataVote) error {
 	return b.Publish(EventVote, data)
 }
@@ -227,8 +245,51 @@ func (b *EventBus) PublishEventLock(data EventDataRoundState) error {
 	return b.Publish(EventLock, data)
 }
 
+func (b *EventBus) PublishEventChainLockUpdate(data EventDataChainLockUpdate) error {
+	return b.Publish(EventChainLockUpdate, data)
+}
+
+// PublishEventValidatorSetUpdates publishes the validator set updates with the
+// predefined ValidatorSetUpdatesProTxHashKey, so subscribers can filter on the
+// proTxHash of a validator.
 func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
-	return b.Publish(EventValidatorSetUpdates, data)
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{EventTypeKey: {EventValidatorSetUpdates}}
+	for _, val := range data.ValidatorUpdates {
+		events[ValidatorSetUpdatesProTxHashKey] = append(events[ValidatorSetUpdatesProTxHashKey],
+			fmt.Sprintf("%X", val.ProTxHash.Bytes()))
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// PublishEventValidatorMissedBlock publishes the missed block with the
+// predefined ValidatorMissedBlockProTxHashKey, so subscribers can filter on the
+// proTxHash of a validator.
+func (b *EventBus) PublishEventValidatorMissedBlock(data EventDataValidatorMissedBlock) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{
+		EventTypeKey:                     {EventValidatorMissedBlock},
+		ValidatorMissedBlockProTxHashKey: {fmt.Sprintf("%X", data.ProTxHash.Bytes())},
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// addValidatorSetUpdateEvents adds the proTxHashes of the validators updated by
+// the block under the predefined ValidatorSetUpdatesProTxHashKey.
+func addValidatorSetUpdateEvents(events map[string][]string, update *types.ValidatorSetUpdate) {
+	if update == nil {
+		return
+	}
+	for _, val := range update.ValidatorUpdates {
+		events[ValidatorSetUpdatesProTxHashKey] = append(events[ValidatorSetUpdatesProTxHashKey],
+			fmt.Sprintf("%X", val.ProTxHash))
+	}
 }
 
 //-----------------------------------------------------------------------------
@@ -263,6 +324,10 @@ func (NopEventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return nil
 }
 
+func (NopEventBus) PublishEventRejectedUpdates(data EventDataRejectedUpdates) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventVote(data EventDataVote) error {
 	return nil
 }
@@ -310,3 +375,11 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventChainLockUpdate(data EventDataChainLockUpdate) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventValidatorMissedBlock(data EventDataValidatorMissedBlock) error {
+	return nil
+}
@@ -316,6 +316,92 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventValidatorSetUpdates(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	val1 := NewTestValidatorGeneratedFromProTxHash(crypto.RandProTxHash())
+	val2 := NewTestValidatorGeneratedFromProTxHash(crypto.RandProTxHash())
+
+	sub1, err := eventBus.Subscribe(context.Background(), "test", EventQueryValidatorSetUpdatesFor(val1.ProTxHash))
+	require.NoError(t, err)
+	allSub, err := eventBus.Subscribe(context.Background(), "test", EventQueryValidatorSetUpdates)
+	require.NoError(t, err)
+
+	// the first update changes both validators, the second one only the other validator
+	updates := []EventDataValidatorSetUpdates{
+		{ValidatorUpdates: []*Validator{val1, val2}},
+		{ValidatorUpdates: []*Validator{val2}},
+	}
+	for _, update := range updates {
+		require.NoError(t, eventBus.PublishEventValidatorSetUpdates(update))
+	}
+
+	for _, update := range updates {
+		select {
+		case msg := <-allSub.Out():
+			assert.Equal(t, update, msg.Data())
+		case <-time.After(1 * time.Second):
+			t.Fatal("did not receive validator set updates after 1 sec.")
+		}
+	}
+
+	select {
+	case msg := <-sub1.Out():
+		assert.Equal(t, updates[0], msg.Data())
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive validator set updates after 1 sec.")
+	}
+	select {
+	case msg := <-sub1.Out():
+		t.Fatalf("received validator set updates not changing the validator: %v", msg.Data())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEventBusPublishEventNewBlockValidatorSetUpdates(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	proTxHash := crypto.RandProTxHash()
+	query := fmt.Sprintf("tm.event='NewBlock' AND %s='%X'", ValidatorSetUpdatesProTxHashKey, proTxHash.Bytes())
+	blocksSub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query))
+	require.NoError(t, err)
+
+	otherBlock := MakeBlock(1, 0, nil, []Tx{}, nil, []Evidence{})
+	block := MakeBlock(2, 0, nil, []Tx{}, nil, []Evidence{})
+	for _, data := range []EventDataNewBlock{
+		{Block: otherBlock, ResultEndBlock: abci.ResponseEndBlock{ValidatorSetUpdate: &abci.ValidatorSetUpdate{
+			ValidatorUpdates: []abci.ValidatorUpdate{{ProTxHash: crypto.RandProTxHash()}},
+		}}},
+		{Block: block, ResultEndBlock: abci.ResponseEndBlock{ValidatorSetUpdate: &abci.ValidatorSetUpdate{
+			ValidatorUpdates: []abci.ValidatorUpdate{{ProTxHash: crypto.RandProTxHash()}, {ProTxHash: proTxHash}},
+		}}},
+	} {
+		require.NoError(t, eventBus.PublishEventNewBlock(data))
+	}
+
+	select {
+	case msg := <-blocksSub.Out():
+		edt := msg.Data().(EventDataNewBlock)
+		assert.Equal(t, block, edt.Block)
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a block after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -326,7 +412,7 @@ func TestEventBusPublish(t *testing.T) {
 		}
 	})
 
-	const numEventsExpected = 14
+	const numEventsExpected = 16
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.Empty{}, numEventsExpected)
 	require.NoError(t, err)
@@ -371,6 +457,10 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventChainLockUpdate(EventDataChainLockUpdate{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventValidatorMissedBlock(EventDataValidatorMissedBlock{})
+	require.NoError(t, err)
 
 	select {
 	case <-done:
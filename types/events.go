@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
@@ -19,9 +21,18 @@ const (
 	EventNewBlock            = "NewBlock"
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventNewEvidence         = "NewEvidence"
+	EventRejectedUpdates     = "RejectedUpdates"
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
+	// Fired for every validator, whose precommit for the last block wasn't
+	// collected by the node. Node-local, the nodes may see different misses.
+	EventValidatorMissedBlock = "ValidatorMissedBlock"
+
+	// Fired when the chain lock watcher of the node sees a chain lock of Dash
+	// Core above the previous one. Observational, it doesn't affect consensus.
+	EventChainLockUpdate = "ChainLockUpdate"
+
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
@@ -37,6 +48,9 @@ const (
 	EventValidBlock       = "ValidBlock"
 	EventVote             = "Vote"
 	EventCommit           = "Commit"
+	// Fired when the threshold signatures of the precommits failed to be
+	// recovered again in the round
+	EventThresholdRecoveryFailure = "ThresholdRecoveryFailure"
 )
 
 // ENCODING / DECODING
@@ -50,6 +64,7 @@ func init() {
 	tmjson.RegisterType(EventDataNewBlock{}, "tendermint/event/NewBlock")
 	tmjson.RegisterType(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader")
 	tmjson.RegisterType(EventDataNewEvidence{}, "tendermint/event/NewEvidence")
+	tmjson.RegisterType(EventDataRejectedUpdates{}, "tendermint/event/RejectedUpdates")
 	tmjson.RegisterType(EventDataTx{}, "tendermint/event/Tx")
 	tmjson.RegisterType(EventDataRoundState{}, "tendermint/event/RoundState")
 	tmjson.RegisterType(EventDataNewRound{}, "tendermint/event/NewRound")
@@ -57,6 +72,9 @@ func init() {
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	tmjson.RegisterType(EventDataEventsDropped{}, "tendermint/event/EventsDropped")
+	tmjson.RegisterType(EventDataChainLockUpdate{}, "tendermint/event/ChainLockUpdate")
+	tmjson.RegisterType(EventDataValidatorMissedBlock{}, "tendermint/event/ValidatorMissedBlock")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -126,10 +144,46 @@ type EventDataCommit struct {
 
 type EventDataString string
 
+// EventDataEventsDropped is delivered to a subscriber in place of the events
+// dropped by the slow consumer policy of the subscription.
+type EventDataEventsDropped struct {
+	Count int `json:"count"`
+}
+
 type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataRejectedUpdates is fired when the updates returned by the
+// application in EndBlock are invalid, and so they're dropped instead of
+// applied.
+type EventDataRejectedUpdates struct {
+	Height int64 `json:"height"`
+
+	// The consensus param updates, if they were rejected.
+	ConsensusParamUpdates *abci.ConsensusParams `json:"consensus_param_updates,omitempty"`
+	// The quorum type of the validator set update, if it was rejected.
+	QuorumType int32 `json:"quorum_type,omitempty"`
+	// Why the updates were rejected.
+	Reason string `json:"reason"`
+}
+
+// EventDataChainLockUpdate is fired when the best chain lock of Dash Core
+// advances.
+type EventDataChainLockUpdate struct {
+	CoreBlockHeight uint32           `json:"core_block_height"`
+	CoreBlockHash   tmbytes.HexBytes `json:"core_block_hash"`
+	// When the chain lock was seen by the node.
+	Time time.Time `json:"time"`
+}
+
+// EventDataValidatorMissedBlock is fired when the precommit of a validator for
+// the block of the height wasn't collected by the node.
+type EventDataValidatorMissedBlock struct {
+	Height    int64     `json:"height"`
+	ProTxHash ProTxHash `json:"pro_tx_hash"`
+}
+
 // PUBSUB
 
 const (
@@ -141,6 +195,14 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// ValidatorSetUpdatesProTxHashKey is a reserved key, used to specify the proTxHashes
+	// of the validators updated by a block.
+	// see EventBus#PublishEventValidatorSetUpdates and EventBus#PublishEventNewBlock
+	ValidatorSetUpdatesProTxHashKey = "validator_set.updates.pro_tx_hash"
+	// ValidatorMissedBlockProTxHashKey is a reserved key, used to specify the
+	// proTxHash of the validator, which missed a block.
+	// see EventBus#PublishEventValidatorMissedBlock
+	ValidatorMissedBlockProTxHashKey = "validator_missed_block.pro_tx_hash"
 
 	// BlockHeightKey is a reserved key used for indexing BeginBlock and Endblock
 	// events.
@@ -148,28 +210,45 @@ const (
 )
 
 var (
-	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
-	EventQueryLock                = QueryForEvent(EventLock)
-	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
-	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
-	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
-	EventQueryNewRound            = QueryForEvent(EventNewRound)
-	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
-	EventQueryPolka               = QueryForEvent(EventPolka)
-	EventQueryRelock              = QueryForEvent(EventRelock)
-	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
-	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
-	EventQueryTx                  = QueryForEvent(EventTx)
-	EventQueryUnlock              = QueryForEvent(EventUnlock)
-	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdates)
-	EventQueryValidBlock          = QueryForEvent(EventValidBlock)
-	EventQueryVote                = QueryForEvent(EventVote)
+	EventQueryChainLockUpdate      = QueryForEvent(EventChainLockUpdate)
+	EventQueryCompleteProposal     = QueryForEvent(EventCompleteProposal)
+	EventQueryLock                 = QueryForEvent(EventLock)
+	EventQueryNewBlock             = QueryForEvent(EventNewBlock)
+	EventQueryNewBlockHeader       = QueryForEvent(EventNewBlockHeader)
+	EventQueryNewEvidence          = QueryForEvent(EventNewEvidence)
+	EventQueryNewRound             = QueryForEvent(EventNewRound)
+	EventQueryNewRoundStep         = QueryForEvent(EventNewRoundStep)
+	EventQueryPolka                = QueryForEvent(EventPolka)
+	EventQueryRejectedUpdates      = QueryForEvent(EventRejectedUpdates)
+	EventQueryRelock               = QueryForEvent(EventRelock)
+	EventQueryTimeoutPropose       = QueryForEvent(EventTimeoutPropose)
+	EventQueryTimeoutWait          = QueryForEvent(EventTimeoutWait)
+	EventQueryTx                   = QueryForEvent(EventTx)
+	EventQueryUnlock               = QueryForEvent(EventUnlock)
+	EventQueryValidatorMissedBlock = QueryForEvent(EventValidatorMissedBlock)
+	EventQueryValidatorSetUpdates  = QueryForEvent(EventValidatorSetUpdates)
+	EventQueryValidBlock           = QueryForEvent(EventValidBlock)
+	EventQueryVote                 = QueryForEvent(EventVote)
 )
 
 func EventQueryTxFor(tx Tx) tmpubsub.Query {
 	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTx, TxHashKey, tx.Hash()))
 }
 
+// EventQueryValidatorSetUpdatesFor returns a query matching validator set updates
+// which update the validator with the given proTxHash.
+func EventQueryValidatorSetUpdatesFor(proTxHash ProTxHash) tmpubsub.Query {
+	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'",
+		EventTypeKey, EventValidatorSetUpdates, ValidatorSetUpdatesProTxHashKey, proTxHash.Bytes()))
+}
+
+// EventQueryValidatorMissedBlockFor returns a query matching the blocks missed
+// by the validator with the given proTxHash.
+func EventQueryValidatorMissedBlockFor(proTxHash ProTxHash) tmpubsub.Query {
+	return tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s='%X'",
+		EventTypeKey, EventValidatorMissedBlock, ValidatorMissedBlockProTxHashKey, proTxHash.Bytes()))
+}
+
 func QueryForEvent(eventType string) tmpubsub.Query {
 	return tmquery.MustParse(fmt.Sprintf("%s='%s'", EventTypeKey, eventType))
 }
@@ -179,8 +258,10 @@ type BlockEventPublisher interface {
 	PublishEventNewBlock(block EventDataNewBlock) error
 	PublishEventNewBlockHeader(header EventDataNewBlockHeader) error
 	PublishEventNewEvidence(evidence EventDataNewEvidence) error
+	PublishEventRejectedUpdates(EventDataRejectedUpdates) error
 	PublishEventTx(EventDataTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
+	PublishEventValidatorMissedBlock(EventDataValidatorMissedBlock) error
 }
 
 type TxEventPublisher interface {
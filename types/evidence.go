@@ -52,6 +52,9 @@ func NewDuplicateVoteEvidence(vote1, vote2 *Vote, blockTime time.Time, valSet *V
 	if vote1 == nil || vote2 == nil || valSet == nil {
 		return nil
 	}
+	if !bytes.Equal(vote1.ValidatorProTxHash, vote2.ValidatorProTxHash) {
+		return nil
+	}
 	idx, val := valSet.GetByProTxHash(vote1.ValidatorProTxHash)
 	if idx == -1 {
 		return nil
@@ -133,6 +136,18 @@ func (dve *DuplicateVoteEvidence) ValidateBasic() error {
 	if err := dve.VoteB.ValidateBasic(); err != nil {
 		return fmt.Errorf("invalid VoteB: %w", err)
 	}
+	// the validator is identified by its proTxHash, its key depends on the height
+	if !bytes.Equal(dve.VoteA.ValidatorProTxHash, dve.VoteB.ValidatorProTxHash) {
+		return fmt.Errorf("votes are from different validators: %X vs %X",
+			dve.VoteA.ValidatorProTxHash, dve.VoteB.ValidatorProTxHash)
+	}
+	if dve.VoteA.Height != dve.VoteB.Height ||
+		dve.VoteA.Round != dve.VoteB.Round ||
+		dve.VoteA.Type != dve.VoteB.Type {
+		return fmt.Errorf("h/r/s does not match: %d/%d/%v vs %d/%d/%v",
+			dve.VoteA.Height, dve.VoteA.Round, dve.VoteA.Type,
+			dve.VoteB.Height, dve.VoteB.Round, dve.VoteB.Type)
+	}
 	// Enforce Votes are lexicographically sorted on blockID
 	if strings.Compare(dve.VoteA.BlockID.Key(), dve.VoteB.BlockID.Key()) >= 0 {
 		return errors.New("duplicate votes in invalid order")
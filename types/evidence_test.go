@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -84,6 +85,10 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 			ev.VoteA = ev.VoteB.Copy()
 			ev.VoteB = swap
 		}, true},
+		{"Votes from different validators", func(ev *DuplicateVoteEvidence) {
+			ev.VoteB.ValidatorProTxHash = crypto.RandProTxHash()
+		}, true},
+		{"Votes of different rounds", func(ev *DuplicateVoteEvidence) { ev.VoteB.Round-- }, true},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -100,6 +105,21 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	}
 }
 
+func TestNewDuplicateVoteEvidenceDifferentValidators(t *testing.T) {
+	quorumHash := crypto.RandQuorumHash()
+	val, val2 := NewMockPVForQuorum(quorumHash), NewMockPVForQuorum(quorumHash)
+	quorumType := btcjson.LLMQType_5_60
+	const chainID = "mychain"
+	vote1 := makeVote(t, val, chainID, 0, 10, quorumType, quorumHash, 2, 1,
+		makeBlockID([]byte("blockhash"), 1000, []byte("partshash")), makeStateID([]byte("lastapphash")))
+	vote2 := makeVote(t, val2, chainID, 0, 10, quorumType, quorumHash, 2, 1,
+		makeBlockID([]byte("blockhash2"), 1000, []byte("partshash")), makeStateID([]byte("lastapphash")))
+	valSet := NewValidatorSet(
+		[]*Validator{val.ExtractIntoValidator(quorumHash), val2.ExtractIntoValidator(quorumHash)},
+		bls12381.GenPrivKey().PubKey(), quorumType, quorumHash, true)
+	assert.Nil(t, NewDuplicateVoteEvidence(vote1, vote2, defaultVoteTime, valSet))
+}
+
 func TestMockEvidenceValidateBasic(t *testing.T) {
 	goodEvidence := NewMockDuplicateVoteEvidence(int64(1), time.Now(), "mock-chain-id", btcjson.LLMQType_5_60,
 		crypto.RandQuorumHash())
@@ -54,11 +54,19 @@ type GenesisDoc struct {
 	NodeProTxHash                *crypto.ProTxHash        `json:"node_pro_tx_hash"`
 	AppHash                      tmbytes.HexBytes         `json:"app_hash"`
 	AppState                     json.RawMessage          `json:"app_state,omitempty"`
+
+	// the app state, which isn't held in memory, e.g. the app state in the
+	// genesis file if it's read with GenesisDocFromFileStreaming
+	appStateSource GenesisAppStateSource
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
 func (genDoc *GenesisDoc) SaveAs(file string) error {
-	genDocBytes, err := tmjson.MarshalIndent(genDoc, "", "  ")
+	doc, err := genDoc.WithAppState()
+	if err != nil {
+		return err
+	}
+	genDocBytes, err := tmjson.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -102,8 +110,11 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 
 	if genDoc.ConsensusParams == nil {
 		genDoc.ConsensusParams = DefaultConsensusParams()
-	} else if err := ValidateConsensusParams(*genDoc.ConsensusParams); err != nil {
-		return err
+	} else {
+		*genDoc.ConsensusParams = CompleteConsensusParams(*genDoc.ConsensusParams)
+		if err := ValidateConsensusParams(*genDoc.ConsensusParams); err != nil {
+			return err
+		}
 	}
 
 	for i, v := range genDoc.Validators {
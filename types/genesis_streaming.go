@@ -0,0 +1,452 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// genesisAppStateKey is the JSON key of the app state in the genesis doc.
+const genesisAppStateKey = "app_state"
+
+// GenesisAppStateSource provides the app state of a genesis doc, which isn't
+// held in memory.
+type GenesisAppStateSource interface {
+	// Size returns the size of the app state in bytes.
+	Size() int64
+	// Open returns a reader of the app state, which must be closed.
+	Open() (io.ReadCloser, error)
+}
+
+// genesisAppStateFile locates the app state in the genesis file of a genesis
+// doc read with GenesisDocFromFileStreaming.
+type genesisAppStateFile struct {
+	path   string
+	offset int64
+	size   int64
+}
+
+var _ GenesisAppStateSource = genesisAppStateFile{}
+
+func (f genesisAppStateFile) Size() int64 {
+	return f.size
+}
+
+func (f genesisAppStateFile) Open() (io.ReadCloser, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the app state: %w", err)
+	}
+	return &sectionReadCloser{
+		SectionReader: io.NewSectionReader(file, f.offset, f.size),
+		Closer:        file,
+	}, nil
+}
+
+type sectionReadCloser struct {
+	*io.SectionReader
+	io.Closer
+}
+
+// GenesisDocFromFileStreaming reads a GenesisDoc from a file like
+// GenesisDocFromFile, without reading the whole file into memory: the
+// top-level fields are decoded as they're read, and the app state is only
+// located in the file. The app state is read from the file when it's needed,
+// see GenesisDoc.LoadAppState and GenesisDoc.AppStateReader.
+//
+// The app state is only checked to be a well-formed JSON value structurally
+// (terminated strings, balanced objects and arrays), it's up to the
+// application to decode it in InitChain.
+func GenesisDocFromFileStreaming(genDocFile string) (*GenesisDoc, error) {
+	file, err := os.Open(genDocFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
+	}
+	defer file.Close()
+
+	genDoc, appState, err := decodeGenesisDocStreaming(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
+	}
+	if appState.size > 0 {
+		genDoc.appStateSource = genesisAppStateFile{
+			path:   genDocFile,
+			offset: appState.offset,
+			size:   appState.size,
+		}
+	}
+	return genDoc, nil
+}
+
+// GenesisDocFromJSONStreaming unmarshals JSON data into a GenesisDoc like
+// GenesisDocFromJSON, without decoding the app state: the app state of the
+// returned GenesisDoc is a slice of jsonBlob.
+func GenesisDocFromJSONStreaming(jsonBlob []byte) (*GenesisDoc, error) {
+	genDoc, appState, err := decodeGenesisDocStreaming(bytes.NewReader(jsonBlob))
+	if err != nil {
+		return nil, err
+	}
+	if appState.size > 0 {
+		genDoc.AppState = jsonBlob[appState.offset : appState.offset+appState.size]
+	}
+	return genDoc, nil
+}
+
+// LoadAppState returns the app state of the genesis doc. The app state of a
+// genesis doc read with GenesisDocFromFileStreaming is read from the genesis
+// file on every call, so that it's not held in memory by the genesis doc. The
+// same goes for the app state of a genesis doc with an app state source.
+func (genDoc *GenesisDoc) LoadAppState() (json.RawMessage, error) {
+	if genDoc.appStateSource == nil {
+		return genDoc.AppState, nil
+	}
+	r, err := genDoc.AppStateReader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	appState := make(json.RawMessage, genDoc.appStateSource.Size())
+	if _, err := io.ReadFull(r, appState); err != nil {
+		return nil, fmt.Errorf("couldn't read the app state: %w", err)
+	}
+	return appState, nil
+}
+
+// WithAppState returns a copy of the genesis doc with the app state in memory,
+// e.g. to marshal the genesis doc read with GenesisDocFromFileStreaming.
+func (genDoc *GenesisDoc) WithAppState() (*GenesisDoc, error) {
+	appState, err := genDoc.LoadAppState()
+	if err != nil {
+		return nil, err
+	}
+	doc := *genDoc
+	doc.AppState = appState
+	doc.appStateSource = nil
+	return &doc, nil
+}
+
+// WithAppStateSource returns a copy of the genesis doc, whose app state is read
+// from src when it's needed. A nil src returns the genesis doc without the app
+// state, e.g. to marshal the genesis doc apart from its app state.
+func (genDoc *GenesisDoc) WithAppStateSource(src GenesisAppStateSource) *GenesisDoc {
+	doc := *genDoc
+	doc.AppState = nil
+	doc.appStateSource = src
+	return &doc
+}
+
+// AppStateReader returns a reader of the app state of the genesis doc, which
+// allows to stream the app state without reading it into memory at once.
+// The reader must be closed.
+func (genDoc *GenesisDoc) AppStateReader() (io.ReadCloser, error) {
+	if genDoc.appStateSource == nil {
+		return ioutil.NopCloser(bytes.NewReader(genDoc.AppState)), nil
+	}
+	return genDoc.appStateSource.Open()
+}
+
+//------------------------------------------------------------
+
+// jsonSection locates a JSON value in the input.
+type jsonSection struct {
+	offset int64
+	size   int64
+}
+
+// decodeGenesisDocStreaming decodes the genesis doc read from r, except for
+// the app state which is only located in the input.
+func decodeGenesisDocStreaming(r io.Reader) (*GenesisDoc, jsonSection, error) {
+	s := &jsonScanner{r: bufio.NewReaderSize(r, 64<<10)}
+	var appState jsonSection
+
+	// the genesis doc without the app state, which is small enough to be
+	// decoded with tmjson like GenesisDocFromJSON does it
+	fields := bytes.NewBufferString("{")
+
+	b, err := s.nextToken()
+	if err != nil {
+		return nil, appState, err
+	}
+	if b != '{' {
+		return nil, appState, errors.New("genesis doc must be a JSON object")
+	}
+	if b, err = s.nextToken(); err != nil {
+		return nil, appState, err
+	}
+	for b != '}' {
+		if b != '"' {
+			return nil, appState, fmt.Errorf("invalid character %q at offset %d, expected a key", b, s.offset-1)
+		}
+		var key bytes.Buffer
+		if err := s.scanValue(b, &key); err != nil {
+			return nil, appState, err
+		}
+		var name string
+		if err := json.Unmarshal(key.Bytes(), &name); err != nil {
+			return nil, appState, err
+		}
+		if b, err = s.nextToken(); err != nil {
+			return nil, appState, err
+		}
+		if b != ':' {
+			return nil, appState, fmt.Errorf("invalid character %q at offset %d, expected ':'", b, s.offset-1)
+		}
+		if b, err = s.nextToken(); err != nil {
+			return nil, appState, err
+		}
+
+		if name == genesisAppStateKey {
+			if appState, err = s.scanAppState(b); err != nil {
+				return nil, appState, err
+			}
+		} else {
+			if fields.Len() > 1 {
+				fields.WriteByte(',')
+			}
+			fields.Write(key.Bytes())
+			fields.WriteByte(':')
+			if err := s.scanValue(b, fields); err != nil {
+				return nil, appState, err
+			}
+		}
+
+		if b, err = s.nextToken(); err != nil {
+			return nil, appState, err
+		}
+		switch b {
+		case ',':
+			if b, err = s.nextToken(); err != nil {
+				return nil, appState, err
+			}
+			if b == '}' {
+				return nil, appState, fmt.Errorf("invalid character '}' at offset %d, expected a key", s.offset-1)
+			}
+		case '}':
+		default:
+			return nil, appState, fmt.Errorf("invalid character %q at offset %d, expected ',' or '}'", b, s.offset-1)
+		}
+	}
+	if b, err := s.nextToken(); err != io.EOF {
+		if err != nil {
+			return nil, appState, err
+		}
+		return nil, appState, fmt.Errorf("invalid character %q at offset %d after the genesis doc", b, s.offset-1)
+	}
+	fields.WriteByte('}')
+
+	genDoc := GenesisDoc{}
+	if err := tmjson.Unmarshal(fields.Bytes(), &genDoc); err != nil {
+		return nil, appState, err
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, appState, err
+	}
+	return &genDoc, appState, nil
+}
+
+// jsonScanner scans JSON byte by byte, keeping track of the offset in the
+// input.
+type jsonScanner struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+func (s *jsonScanner) readByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	s.offset++
+	return b, nil
+}
+
+func (s *jsonScanner) unreadByte() {
+	if err := s.r.UnreadByte(); err == nil {
+		s.offset--
+	}
+}
+
+// nextToken returns the next byte, which isn't whitespace.
+func (s *jsonScanner) nextToken() (byte, error) {
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if !isJSONSpace(b) {
+			return b, nil
+		}
+	}
+}
+
+// scanAppState scans the app state starting with the byte b without keeping
+// it, and returns where it is in the input. A null app state is no app state.
+func (s *jsonScanner) scanAppState(b byte) (jsonSection, error) {
+	section := jsonSection{offset: s.offset - 1}
+	if b == '{' || b == '[' || b == '"' {
+		if err := s.scanValue(b, nil); err != nil {
+			return section, err
+		}
+		section.size = s.offset - section.offset
+		return section, nil
+	}
+
+	var scalar bytes.Buffer
+	if err := s.scanValue(b, &scalar); err != nil {
+		return section, err
+	}
+	if scalar.String() == "null" {
+		return jsonSection{}, nil
+	}
+	section.size = s.offset - section.offset
+	return section, nil
+}
+
+// scanValue scans the JSON value starting with the byte b, which is already
+// read. The value is written to buf, unless it's nil. Only the structure of
+// the value is checked: the strings must be terminated, and the objects and
+// the arrays must be balanced.
+func (s *jsonScanner) scanValue(b byte, buf *bytes.Buffer) error {
+	write := func(b byte) {
+		if buf != nil {
+			buf.WriteByte(b)
+		}
+	}
+
+	if b != '"' && b != '{' && b != '[' {
+		// a scalar ends with the input or at the next delimiter
+		for {
+			switch b {
+			case ',', ':', '}', ']', '{', '[', '"':
+				return fmt.Errorf("invalid character %q at offset %d in a JSON value", b, s.offset-1)
+			}
+			write(b)
+			var err error
+			if b, err = s.readByte(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if isJSONSpace(b) || b == ',' || b == '}' || b == ']' {
+				s.unreadByte()
+				return nil
+			}
+		}
+	}
+
+	v := valueScanner{}
+	if err := v.scan(b); err != nil {
+		return err
+	}
+	write(b)
+	for !v.done() {
+		// scan the buffered input at once, it's much faster than byte by byte
+		chunk, err := s.peek()
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+		n, err := v.scanChunk(chunk)
+		if err != nil {
+			return fmt.Errorf("%w at offset %d in a JSON value", err, s.offset+int64(n))
+		}
+		if buf != nil {
+			buf.Write(chunk[:n])
+		}
+		s.discard(n)
+	}
+	return nil
+}
+
+// peek returns the buffered input, reading more of it if there's none.
+func (s *jsonScanner) peek() ([]byte, error) {
+	if s.r.Buffered() == 0 {
+		if _, err := s.r.Peek(1); err != nil {
+			return nil, err
+		}
+	}
+	return s.r.Peek(s.r.Buffered())
+}
+
+func (s *jsonScanner) discard(n int) {
+	n, _ = s.r.Discard(n)
+	s.offset += int64(n)
+}
+
+// valueScanner tracks the structure of a JSON string, object or array.
+type valueScanner struct {
+	closing  []byte
+	inString bool
+	escaped  bool
+}
+
+func (v *valueScanner) done() bool {
+	return !v.inString && len(v.closing) == 0
+}
+
+// scanChunk scans the chunk until the end of the value, and returns the number
+// of bytes of the chunk which belong to the value.
+func (v *valueScanner) scanChunk(chunk []byte) (int, error) {
+	for i := 0; i < len(chunk); i++ {
+		// skip to the next byte, which matters
+		var j int
+		switch {
+		case v.escaped:
+		case v.inString:
+			j = bytes.IndexAny(chunk[i:], `"\`)
+		default:
+			j = bytes.IndexAny(chunk[i:], `"{}[]`)
+		}
+		if j < 0 {
+			return len(chunk), nil
+		}
+		i += j
+
+		if err := v.scan(chunk[i]); err != nil {
+			return i, err
+		}
+		if v.done() {
+			return i + 1, nil
+		}
+	}
+	return len(chunk), nil
+}
+
+func (v *valueScanner) scan(b byte) error {
+	switch {
+	case v.inString:
+		switch {
+		case v.escaped:
+			v.escaped = false
+		case b == '\\':
+			v.escaped = true
+		case b == '"':
+			v.inString = false
+		}
+	case b == '"':
+		v.inString = true
+	case b == '{':
+		v.closing = append(v.closing, '}')
+	case b == '[':
+		v.closing = append(v.closing, ']')
+	case b == '}' || b == ']':
+		if len(v.closing) == 0 || v.closing[len(v.closing)-1] != b {
+			return fmt.Errorf("invalid character %q", b)
+		}
+		v.closing = v.closing[:len(v.closing)-1]
+	}
+	return nil
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+func TestGenesisDocFromFileStreaming(t *testing.T) {
+	testCases := []struct {
+		name     string
+		appState string
+	}{
+		{"no app state", ""},
+		{"null app state", `null`},
+		{"object", `{"account_owner": "Bob"}`},
+		{"nested", `{"accounts": [{"id": 1, "keys": [[1, 2], {}]}, {"id": 2, "keys": []}], "empty": {}}`},
+		{"escaped strings", `{"a\"}": "x}\\\"]", "b": ["\\", "[{"]}`},
+		{"array", ` [1, "two", {"three": 3}, null, true] `},
+		{"string", `"some state"`},
+		{"number", `-12.5e3`},
+	}
+
+	dir, err := ioutil.TempDir("", "genesis")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			genDocBytes, err := tmjson.MarshalIndent(randomGenesisDoc(), "", "  ")
+			require.NoError(t, err)
+			if tc.appState != "" {
+				genDocBytes = append(genDocBytes[:len(genDocBytes)-1],
+					[]byte(fmt.Sprintf(",\n  \"app_state\": %s\n}\n", tc.appState))...)
+			}
+			genDocFile := filepath.Join(dir, fmt.Sprintf("genesis%d.json", i))
+			require.NoError(t, ioutil.WriteFile(genDocFile, genDocBytes, 0644))
+
+			genDoc, err := GenesisDocFromFile(genDocFile)
+			require.NoError(t, err)
+			streamedGenDoc, err := GenesisDocFromFileStreaming(genDocFile)
+			require.NoError(t, err)
+
+			// the app state is read from the file when needed
+			assert.Nil(t, streamedGenDoc.AppState)
+			appState, err := streamedGenDoc.LoadAppState()
+			require.NoError(t, err)
+			assert.Equal(t, genDoc.AppState, appState)
+			r, err := streamedGenDoc.AppStateReader()
+			require.NoError(t, err)
+			streamedAppState, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			assert.Equal(t, string(genDoc.AppState), string(streamedAppState))
+
+			// the genesis doc is the same, e.g. when it's saved by the node
+			withAppState, err := streamedGenDoc.WithAppState()
+			require.NoError(t, err)
+			assert.Equal(t, genDoc, withAppState)
+			streamedBytes, err := tmjson.Marshal(withAppState)
+			require.NoError(t, err)
+			expectedBytes, err := tmjson.Marshal(genDoc)
+			require.NoError(t, err)
+			assert.Equal(t, expectedBytes, streamedBytes)
+
+			fromJSON, err := GenesisDocFromJSONStreaming(genDocBytes)
+			require.NoError(t, err)
+			assert.Equal(t, genDoc, fromJSON)
+		})
+	}
+}
+
+func TestGenesisDocFromJSONStreamingBad(t *testing.T) {
+	genDocBytes, err := tmjson.Marshal(randomGenesisDoc())
+	require.NoError(t, err)
+	fields := string(genDocBytes[1 : len(genDocBytes)-1])
+
+	testCases := []string{
+		`[` + fields + `]`,
+		`{` + fields,
+		`{` + fields + `,}`,
+		`{` + fields + `}}`,
+		`{` + fields + `} garbage`,
+		`{` + fields + ` "app_state": {}}`,
+		`{` + fields + `, "app_state" {}}`,
+		`{` + fields + `, "app_state": {"a": [1, 2}]}`,
+		`{` + fields + `, "app_state": {"a": "unterminated}}`,
+		`{` + fields + `, "app_state": {"a": 1}`,
+		`{` + fields + `, "app_state": }`,
+		`{` + fields + `, app_state: {}}`,
+		string(genDocBytes[:len(genDocBytes)/2]),
+	}
+
+	for _, tc := range testCases {
+		_, err := GenesisDocFromJSONStreaming([]byte(tc))
+		assert.Error(t, err, "expected error for %s", tc)
+	}
+}
+
+// TestGenesisDocFromFileStreamingLarge checks that a large app state isn't read
+// into memory when the genesis doc is read.
+func TestGenesisDocFromFileStreamingLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the 200MB genesis in short mode")
+	}
+	const appStateSize = 200 << 20
+
+	dir, err := ioutil.TempDir("", "genesis")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	genDocFile := filepath.Join(dir, "genesis.json")
+	appStateHash := writeLargeGenesisDoc(t, genDocFile, appStateSize)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	genDoc, err := GenesisDocFromFileStreaming(genDocFile)
+	require.NoError(t, err)
+	r, err := genDoc.AppStateReader()
+	require.NoError(t, err)
+	hasher := sha256.New()
+	n, err := io.Copy(hasher, r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	runtime.ReadMemStats(&after)
+	assert.GreaterOrEqual(t, n, int64(appStateSize))
+	assert.Equal(t, appStateHash, hasher.Sum(nil))
+	// everything allocated while reading the genesis doc and streaming its app
+	// state must be a fraction of the app state
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(8<<20))
+}
+
+// writeLargeGenesisDoc writes a genesis doc with an app state of at least
+// appStateSize bytes, and returns the hash of the app state.
+func writeLargeGenesisDoc(t *testing.T, genDocFile string, appStateSize int) []byte {
+	genDocBytes, err := tmjson.Marshal(randomGenesisDoc())
+	require.NoError(t, err)
+
+	file, err := os.Create(genDocFile)
+	require.NoError(t, err)
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	hasher := sha256.New()
+	appState := io.MultiWriter(w, hasher)
+	_, err = w.Write(append(genDocBytes[:len(genDocBytes)-1], []byte(`,"app_state":`)...))
+	require.NoError(t, err)
+	_, err = appState.Write([]byte(`{"accounts":[`))
+	require.NoError(t, err)
+	var account bytes.Buffer
+	for i, size := 0, 0; size < appStateSize; i++ {
+		account.Reset()
+		if i > 0 {
+			account.WriteByte(',')
+		}
+		fmt.Fprintf(&account, `{"id":%d,"owner":"owner \"%x\"","balance":"%d"}`, i, sha256.Sum256([]byte{byte(i)}), i*1000)
+		_, err = appState.Write(account.Bytes())
+		require.NoError(t, err)
+		size += account.Len()
+	}
+	_, err = appState.Write([]byte(`]}`))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("}\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	return hasher.Sum(nil)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
@@ -92,6 +93,8 @@ func TestGenesisBad(t *testing.T) {
 	for _, testCase := range testCases {
 		_, err := GenesisDocFromJSON(testCase)
 		assert.Error(t, err, "expected error for empty genDoc json")
+		_, err = GenesisDocFromJSONStreaming(testCase)
+		assert.Error(t, err, "expected error for empty genDoc json when streaming")
 	}
 }
 
@@ -119,8 +122,12 @@ func TestGenesisGood(t *testing.T) {
 			"app_state":{"account_owner": "Bob"}
 		}`,
 	)
-	_, err := GenesisDocFromJSON(genDocBytes)
+	goodGenDoc, err := GenesisDocFromJSON(genDocBytes)
 	assert.NoError(t, err, "expected no error for good genDoc json")
+	streamedGenDoc, err := GenesisDocFromJSONStreaming(genDocBytes)
+	assert.NoError(t, err, "expected no error for good genDoc json when streaming")
+	streamedGenDoc.GenesisTime = goodGenDoc.GenesisTime // filled in with the current time
+	assert.Equal(t, goodGenDoc, streamedGenDoc)
 
 	pubkey := bls12381.GenPrivKey().PubKey()
 	// create a base gendoc from struct
@@ -147,6 +154,14 @@ func TestGenesisGood(t *testing.T) {
 	genDoc, err = GenesisDocFromJSON(genDocBytes)
 	assert.NoError(t, err, "expected no error for valid genDoc json")
 
+	// test consensus params without synchrony params, e.g. of an old genesis
+	genDoc.ConsensusParams.Synchrony = tmproto.SynchronyParams{}
+	genDocBytes, err = tmjson.Marshal(genDoc)
+	assert.NoError(t, err, "error marshalling genDoc")
+	genDoc, err = GenesisDocFromJSON(genDocBytes)
+	assert.NoError(t, err, "expected no error for genDoc json without synchrony params")
+	assert.Equal(t, DefaultSynchronyParams(), genDoc.ConsensusParams.Synchrony)
+
 	// test with invalid consensus params
 	genDoc.ConsensusParams.Block.MaxBytes = 0
 	genDocBytes, err = tmjson.Marshal(genDoc)
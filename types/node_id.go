@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/dashevo/dashd-go/btcjson"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+)
+
+// NodeIDSignBytes returns the bytes of the node ID, which are signed by the
+// masternode to prove its ownership to the peers.
+func NodeIDSignBytes(chainID string, nodeID string) []byte {
+	signBytes := []byte(chainID)
+	return append(signBytes, nodeID...)
+}
+
+// NodeIDRequestId returns the request id of the node ID of the masternode.
+func NodeIDRequestId(proTxHash crypto.ProTxHash, nodeID string) []byte {
+	requestIdMessage := []byte("dpnodeid")
+	requestIdMessage = append(requestIdMessage, proTxHash...)
+	requestIdMessage = append(requestIdMessage, nodeID...)
+
+	return crypto.Sha256(requestIdMessage)
+}
+
+// NodeIDSignId returns the signId bytes of the node ID, which are signed with
+// the key of the masternode in the quorum.
+func NodeIDSignId(chainID string, nodeID string, proTxHash crypto.ProTxHash, quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash) []byte {
+	messageHash := crypto.Sha256(NodeIDSignBytes(chainID, nodeID))
+
+	requestId := NodeIDRequestId(proTxHash, nodeID)
+
+	return crypto.SignId(quorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(requestId),
+		bls12381.ReverseBytes(messageHash))
+}
+
+// VerifyNodeIDSignature verifies the signature of the node ID by the
+// masternode with its public key in the quorum.
+func VerifyNodeIDSignature(chainID string, nodeID string, proTxHash crypto.ProTxHash, quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash, pubKey crypto.PubKey, signature []byte) error {
+	if pubKey == nil {
+		return fmt.Errorf("no public key of masternode %v in quorum %v", proTxHash, quorumHash)
+	}
+	signId := NodeIDSignId(chainID, nodeID, proTxHash, quorumType, quorumHash)
+	if !pubKey.VerifySignatureDigest(signId, signature) {
+		return fmt.Errorf("invalid node ID signature of masternode %v", proTxHash)
+	}
+	return nil
+}
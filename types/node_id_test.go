@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/dashevo/dashd-go/btcjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+func TestNodeIDSignature(t *testing.T) {
+	const (
+		chainID    = "test_chain_id"
+		nodeID     = "6f2d4e8b5d1a3c7e9f0b2a4c6e8d0f1a3b5c7d9e"
+		quorumType = btcjson.LLMQType_5_60
+	)
+	quorumHash := crypto.RandQuorumHash()
+	pv := NewMockPVForQuorum(quorumHash)
+	pubKey, err := pv.GetPubKey(quorumHash)
+	require.NoError(t, err)
+
+	signature, err := pv.SignNodeID(chainID, nodeID, quorumType, quorumHash)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyNodeIDSignature(chainID, nodeID, pv.ProTxHash, quorumType, quorumHash, pubKey, signature))
+
+	// the signature proves the ownership of the node ID on the chain only
+	assert.Error(t, VerifyNodeIDSignature("other_chain_id", nodeID, pv.ProTxHash, quorumType, quorumHash,
+		pubKey, signature))
+	assert.Error(t, VerifyNodeIDSignature(chainID, "other_node_id", pv.ProTxHash, quorumType, quorumHash,
+		pubKey, signature))
+	assert.Error(t, VerifyNodeIDSignature(chainID, nodeID, crypto.RandProTxHash(), quorumType, quorumHash,
+		pubKey, signature))
+	assert.Error(t, VerifyNodeIDSignature(chainID, nodeID, pv.ProTxHash, quorumType, quorumHash,
+		nil, signature))
+
+	// the quorum, which the node isn't a member of
+	_, err = pv.SignNodeID(chainID, nodeID, quorumType, crypto.RandQuorumHash())
+	assert.Error(t, err)
+}
@@ -28,6 +28,8 @@ func DefaultConsensusParams() *tmproto.ConsensusParams {
 		Evidence:  DefaultEvidenceParams(),
 		Validator: DefaultValidatorParams(),
 		Version:   DefaultVersionParams(),
+		ABCI:      DefaultABCIParams(),
+		Synchrony: DefaultSynchronyParams(),
 	}
 }
 
@@ -63,6 +65,38 @@ func DefaultVersionParams() tmproto.VersionParams {
 	}
 }
 
+// DefaultABCIParams returns a default ABCIParams, the vote extensions are
+// disabled.
+func DefaultABCIParams() tmproto.ABCIParams {
+	return tmproto.ABCIParams{
+		VoteExtensionsEnableHeight: 0,
+	}
+}
+
+// DefaultSynchronyParams returns a default SynchronyParams.
+func DefaultSynchronyParams() tmproto.SynchronyParams {
+	return tmproto.SynchronyParams{
+		MinStep:  time.Millisecond,
+		MaxDrift: 10 * time.Second,
+	}
+}
+
+// CompleteConsensusParams returns the params with the defaults of the params,
+// which are missing from the params created by the older versions, e.g. the
+// synchrony params of the genesis docs and the stored states.
+func CompleteConsensusParams(params tmproto.ConsensusParams) tmproto.ConsensusParams {
+	if params.Synchrony.Equal(tmproto.SynchronyParams{}) {
+		params.Synchrony = DefaultSynchronyParams()
+	}
+	return params
+}
+
+// VoteExtensionsEnabled returns true if the precommits at the height carry vote
+// extensions.
+func VoteExtensionsEnabled(params tmproto.ABCIParams, height int64) bool {
+	return params.VoteExtensionsEnableHeight > 0 && height >= params.VoteExtensionsEnableHeight
+}
+
 func IsValidPubkeyType(params tmproto.ValidatorParams, pubkeyType string) bool {
 	for i := 0; i < len(params.PubKeyTypes); i++ {
 		if params.PubKeyTypes[i] == pubkeyType {
@@ -118,6 +152,21 @@ func ValidateConsensusParams(params tmproto.ConsensusParams) error {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
 
+	if params.Synchrony.MinStep <= 0 {
+		return fmt.Errorf("synchrony.MinStep must be greater than 0. Got %v",
+			params.Synchrony.MinStep)
+	}
+
+	if params.Synchrony.MaxDrift <= 0 {
+		return fmt.Errorf("synchrony.MaxDrift must be greater than 0. Got %v",
+			params.Synchrony.MaxDrift)
+	}
+
+	if params.ABCI.VoteExtensionsEnableHeight < 0 {
+		return fmt.Errorf("abci.VoteExtensionsEnableHeight must be non negative. Got: %d",
+			params.ABCI.VoteExtensionsEnableHeight)
+	}
+
 	// Check if keyType is a known ABCIPubKeyType
 	for i := 0; i < len(params.Validator.PubKeyTypes); i++ {
 		keyType := params.Validator.PubKeyTypes[i]
@@ -130,6 +179,27 @@ func ValidateConsensusParams(params tmproto.ConsensusParams) error {
 	return nil
 }
 
+// ValidateConsensusParamsUpdate validates the update of the consensus params by
+// the block at the height, the updated params apply from the next height.
+// The vote extensions can't be disabled or rescheduled once they're enabled,
+// and they can only be enabled for a future height.
+func ValidateConsensusParamsUpdate(params, updated tmproto.ConsensusParams, height int64) error {
+	enableHeight := params.ABCI.VoteExtensionsEnableHeight
+	updatedEnableHeight := updated.ABCI.VoteExtensionsEnableHeight
+	if enableHeight == updatedEnableHeight {
+		return nil
+	}
+	if enableHeight > 0 && enableHeight <= height {
+		return fmt.Errorf("abci.VoteExtensionsEnableHeight can't be changed once the vote extensions "+
+			"are enabled at height %d", enableHeight)
+	}
+	if updatedEnableHeight > 0 && updatedEnableHeight <= height {
+		return fmt.Errorf("abci.VoteExtensionsEnableHeight must be greater than the current height %d. Got: %d",
+			height, updatedEnableHeight)
+	}
+	return nil
+}
+
 // Hash returns a hash of a subset of the parameters to store in the block header.
 // Only the Block.MaxBytes and Block.MaxGas are included in the hash.
 // This allows the ConsensusParams to evolve more without breaking the block
@@ -157,7 +227,7 @@ func HashConsensusParams(params tmproto.ConsensusParams) []byte {
 // Update returns a copy of the params with updates from the non-zero fields of p2.
 // NOTE: note: must not modify the original
 func UpdateConsensusParams(params tmproto.ConsensusParams, params2 *abci.ConsensusParams) tmproto.ConsensusParams {
-	res := params // explicit copy
+	res := CompleteConsensusParams(params) // explicit copy
 
 	if params2 == nil {
 		return res
@@ -181,5 +251,12 @@ func UpdateConsensusParams(params tmproto.ConsensusParams, params2 *abci.Consens
 	if params2.Version != nil {
 		res.Version.AppVersion = params2.Version.AppVersion
 	}
+	if params2.Abci != nil {
+		res.ABCI.VoteExtensionsEnableHeight = params2.Abci.VoteExtensionsEnableHeight
+	}
+	if params2.Synchrony != nil {
+		res.Synchrony.MinStep = params2.Synchrony.MinStep
+		res.Synchrony.MaxDrift = params2.Synchrony.MaxDrift
+	}
 	return res
 }
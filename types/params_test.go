@@ -40,6 +40,11 @@ func TestConsensusParamsValidation(t *testing.T) {
 		13: {makeParams(1, 0, 10, 2, 0, []string{}), false},
 		// test invalid pubkey type provided
 		14: {makeParams(1, 0, 10, 2, 0, []string{"potatoes make good pubkeys"}), false},
+		// test synchrony params
+		15: {makeSynchronyParams(0, time.Second), false},
+		16: {makeSynchronyParams(time.Millisecond, 0), false},
+		17: {makeSynchronyParams(time.Millisecond, -time.Second), false},
+		18: {makeSynchronyParams(time.Millisecond, time.Second), true},
 	}
 	for i, tc := range testCases {
 		if tc.valid {
@@ -71,9 +76,16 @@ func makeParams(
 		Validator: tmproto.ValidatorParams{
 			PubKeyTypes: pubkeyTypes,
 		},
+		Synchrony: DefaultSynchronyParams(),
 	}
 }
 
+func makeSynchronyParams(minStep, maxDrift time.Duration) tmproto.ConsensusParams {
+	params := makeParams(1, 0, 10, 2, 0, valBLS12381)
+	params.Synchrony = tmproto.SynchronyParams{MinStep: minStep, MaxDrift: maxDrift}
+	return params
+}
+
 func TestConsensusParamsHash(t *testing.T) {
 	params := []tmproto.ConsensusParams{
 		makeParams(4, 2, 10, 3, 1, valBLS12381),
@@ -148,3 +160,37 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 
 	assert.EqualValues(t, 1, updated.Version.AppVersion)
 }
+
+func TestConsensusParamsUpdate_Synchrony(t *testing.T) {
+	params := makeParams(1, 2, 10, 3, 0, valBLS12381)
+	synchrony := tmproto.SynchronyParams{MinStep: time.Second, MaxDrift: time.Minute}
+
+	updated := UpdateConsensusParams(params, &abci.ConsensusParams{Synchrony: &synchrony})
+	assert.Equal(t, synchrony, updated.Synchrony)
+}
+
+func TestConsensusParamsUpdate_VoteExtensions(t *testing.T) {
+	params := makeParams(1, 2, 10, 3, 0, valBLS12381)
+	height := int64(10)
+
+	updated := UpdateConsensusParams(params,
+		&abci.ConsensusParams{Abci: &tmproto.ABCIParams{VoteExtensionsEnableHeight: height + 1}})
+	assert.EqualValues(t, height+1, updated.ABCI.VoteExtensionsEnableHeight)
+	assert.NoError(t, ValidateConsensusParamsUpdate(params, updated, height))
+	assert.False(t, VoteExtensionsEnabled(updated.ABCI, height))
+	assert.True(t, VoteExtensionsEnabled(updated.ABCI, height+1))
+
+	// the vote extensions must be enabled for a future height
+	updated.ABCI.VoteExtensionsEnableHeight = height
+	assert.Error(t, ValidateConsensusParamsUpdate(params, updated, height))
+
+	// the vote extensions can't be disabled once they're enabled
+	params.ABCI.VoteExtensionsEnableHeight = height
+	updated.ABCI.VoteExtensionsEnableHeight = 0
+	assert.Error(t, ValidateConsensusParamsUpdate(params, updated, height))
+
+	// but they can be rescheduled before
+	params.ABCI.VoteExtensionsEnableHeight = height + 1
+	updated.ABCI.VoteExtensionsEnableHeight = height + 2
+	assert.NoError(t, ValidateConsensusParamsUpdate(params, updated, height))
+}
@@ -293,6 +293,23 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	return true, nil
 }
 
+// VerifyPart checks that the part belongs to the part set, without adding it.
+func (ps *PartSet) VerifyPart(part *Part) error {
+	if ps == nil {
+		return nil
+	}
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if part.Index >= ps.total {
+		return ErrPartSetUnexpectedIndex
+	}
+	if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
+		return ErrPartSetInvalidProof
+	}
+	return nil
+}
+
 func (ps *PartSet) GetPart(index int) *Part {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
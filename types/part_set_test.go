@@ -88,6 +88,24 @@ func TestWrongProof(t *testing.T) {
 	}
 }
 
+func TestVerifyPart(t *testing.T) {
+	data := tmrand.Bytes(testPartSize * 10)
+	partSet := NewPartSetFromData(data, testPartSize)
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+
+	assert.NoError(t, partSet2.VerifyPart(partSet.GetPart(0)))
+	// the verified part isn't added
+	assert.EqualValues(t, 0, partSet2.Count())
+
+	part := *partSet.GetPart(1)
+	part.Index = partSet.Total()
+	assert.Equal(t, ErrPartSetUnexpectedIndex, partSet2.VerifyPart(&part))
+
+	part = *partSet.GetPart(1)
+	part.Bytes = append([]byte{part.Bytes[0] + 0x01}, part.Bytes[1:]...)
+	assert.Equal(t, ErrPartSetInvalidProof, partSet2.VerifyPart(&part))
+}
+
 func TestPartSetHeaderValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName              string
@@ -32,6 +32,42 @@ type PrivValidator interface {
 	ExtractIntoValidator(quorumHash crypto.QuorumHash) *Validator
 }
 
+// QuorumTypeSetter is implemented by the private validators, which need the
+// LLMQ type of a quorum to look up its keys, e.g. the Dash Core signer client.
+type QuorumTypeSetter interface {
+	SetQuorumType(quorumHash crypto.QuorumHash, quorumType btcjson.LLMQType)
+}
+
+// ValidatorPubKeySetter is implemented by the private validators, which can
+// rotate their keys. They sign with the key, whose public key is the one of the
+// validator in the validator set of the signed height.
+type ValidatorPubKeySetter interface {
+	SetValidatorPubKey(height int64, quorumHash crypto.QuorumHash, pubKey crypto.PubKey)
+}
+
+// NodeIDSigner is implemented by the private validators, which can prove the
+// ownership of the masternode to the peers, by signing the node ID with the
+// key of the masternode in the quorum.
+type NodeIDSigner interface {
+	SignNodeID(chainID string, nodeID string, quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash) ([]byte, error)
+}
+
+// QuorumSigner is implemented by the private validators, which can sign any
+// message hash with their key in the quorum, under the given request ID. The
+// request ID and the message hash are in the byte order of Tenderdash, the
+// returned signature verifies against the sign ID built by crypto.SignId from
+// their reversed bytes.
+type QuorumSigner interface {
+	QuorumSign(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, requestID []byte,
+		messageHash []byte) ([]byte, error)
+}
+
+// ChainLockProvider is implemented by the private validators, which are backed
+// by Dash Core and so know the best chain lock of the core chain.
+type ChainLockProvider interface {
+	GetBestChainLock() (*CoreChainLock, error)
+}
+
 type PrivValidatorsByProTxHash []PrivValidator
 
 func (pvs PrivValidatorsByProTxHash) Len() int {
@@ -187,6 +223,15 @@ func (pv *MockPV) SignVote(chainID string, quorumType btcjson.LLMQType, quorumHa
 		vote.StateSignature = stateSignature
 	}
 
+	if VoteExtensionRequested(vote) {
+		extensionSignId := VoteExtensionSignId(useChainID, vote, quorumType, quorumHash)
+		extensionSignature, err := privKey.SignDigest(extensionSignId)
+		if err != nil {
+			return err
+		}
+		vote.ExtensionSignature = extensionSignature
+	}
+
 	return nil
 }
 
@@ -216,10 +261,44 @@ func (pv *MockPV) SignProposal(chainID string, quorumType btcjson.LLMQType, quor
 	return signId, nil
 }
 
+// SignNodeID implements NodeIDSigner.
+func (pv *MockPV) SignNodeID(chainID string, nodeID string, quorumType btcjson.LLMQType,
+	quorumHash crypto.QuorumHash) ([]byte, error) {
+	pv.mtx.RLock()
+	defer pv.mtx.RUnlock()
+
+	quorumKeys, ok := pv.PrivateKeys[quorumHash.String()]
+	if !ok {
+		return nil, fmt.Errorf("mock private validator could not sign node ID for quorum hash %v", quorumHash)
+	}
+	return quorumKeys.PrivKey.SignDigest(NodeIDSignId(chainID, nodeID, pv.ProTxHash, quorumType, quorumHash))
+}
+
+// QuorumSign implements QuorumSigner.
+func (pv *MockPV) QuorumSign(quorumType btcjson.LLMQType, quorumHash crypto.QuorumHash, requestID []byte,
+	messageHash []byte) ([]byte, error) {
+	pv.mtx.RLock()
+	defer pv.mtx.RUnlock()
+
+	quorumKeys, ok := pv.PrivateKeys[quorumHash.String()]
+	if !ok {
+		return nil, fmt.Errorf("mock private validator could not sign for quorum hash %v", quorumHash)
+	}
+	signID := crypto.SignId(quorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(requestID),
+		bls12381.ReverseBytes(messageHash))
+	return quorumKeys.PrivKey.SignDigest(signID)
+}
+
 func (pv *MockPV) UpdatePrivateKey(privateKey crypto.PrivKey, quorumHash crypto.QuorumHash, height int64) error {
 	// fmt.Printf("mockpv node %X setting a new key %X at height %d\n", pv.ProTxHash,
 	//  privateKey.PubKey().Bytes(), height)
-	pv.mtx.RLock()
+	pv.mtx.Lock()
+	if pv.UpdateHeights == nil {
+		pv.UpdateHeights = make(map[string]crypto.QuorumHash)
+	}
+	if pv.FirstHeightOfQuorums == nil {
+		pv.FirstHeightOfQuorums = make(map[string]string)
+	}
 	pv.PrivateKeys[quorumHash.String()] = crypto.QuorumKeys{
 		PrivKey: privateKey,
 		PubKey: privateKey.PubKey(),
@@ -228,7 +307,7 @@ func (pv *MockPV) UpdatePrivateKey(privateKey crypto.PrivKey, quorumHash crypto.
 	if _, ok := pv.FirstHeightOfQuorums[quorumHash.String()]; ok != true {
 		pv.FirstHeightOfQuorums[quorumHash.String()] = strconv.Itoa(int(height))
 	}
-	pv.mtx.RUnlock()
+	pv.mtx.Unlock()
 	return nil
 }
 
@@ -111,7 +111,12 @@ func (tm2pb) ValidatorUpdates(vals *ValidatorSet) abci.ValidatorSetUpdate {
 	if err != nil {
 		panic(err)
 	}
-	return abci.ValidatorSetUpdate{ValidatorUpdates: validators, ThresholdPublicKey: abciThresholdPublicKey, QuorumHash: vals.QuorumHash}
+	return abci.ValidatorSetUpdate{
+		ValidatorUpdates:   validators,
+		ThresholdPublicKey: abciThresholdPublicKey,
+		QuorumHash:         vals.QuorumHash,
+		QuorumType:         int32(vals.QuorumType),
+	}
 }
 
 func (tm2pb) ConsensusParams(params *tmproto.ConsensusParams) *abci.ConsensusParams {
@@ -122,6 +127,8 @@ func (tm2pb) ConsensusParams(params *tmproto.ConsensusParams) *abci.ConsensusPar
 		},
 		Evidence:  &params.Evidence,
 		Validator: &params.Validator,
+		Abci:      &params.ABCI,
+		Synchrony: &params.Synchrony,
 	}
 }
 
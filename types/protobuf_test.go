@@ -49,6 +49,7 @@ func TestABCIValidators(t *testing.T) {
 		ValidatorUpdates:   []abci.ValidatorUpdate{abciVal},
 		ThresholdPublicKey: abciVal.PubKey,
 		QuorumHash:         quorumHash,
+		QuorumType:         int32(btcjson.LLMQType_5_60),
 	}, abciVals)
 
 	abciVal = TM2PB.ValidatorUpdate(tmVal)
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 
+	"github.com/tendermint/tendermint/libs/bits"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/version"
@@ -36,6 +37,16 @@ func MakeCommit(blockID BlockID, stateID StateID, height int64, round int32,
 	return voteSet.MakeCommit(), nil
 }
 
+// MakeAllCommitSigners returns the signers of a commit, which all the
+// validators of a set of the given size signed.
+func MakeAllCommitSigners(size int) *bits.BitArray {
+	signers := bits.NewBitArray(size)
+	for i := 0; i < size; i++ {
+		signers.SetIndex(i, true)
+	}
+	return signers
+}
+
 func signAddVote(privVal PrivValidator, vote *Vote, voteSet *VoteSet) (signed bool, err error) {
 	v := vote.ToProto()
 	err = privVal.SignVote(voteSet.ChainID(), voteSet.valSet.QuorumType, voteSet.valSet.QuorumHash, v)
@@ -44,6 +55,7 @@ func signAddVote(privVal PrivValidator, vote *Vote, voteSet *VoteSet) (signed bo
 	}
 	vote.BlockSignature = v.BlockSignature
 	vote.StateSignature = v.StateSignature
+	vote.ExtensionSignature = v.ExtensionSignature
 	return voteSet.AddVote(vote)
 }
 
@@ -55,6 +55,35 @@ func ValidateAppHash(h []byte) error {
 	return nil
 }
 
+// VerifySignatureDigests verifies the signatures of the digests with the public
+// key and returns the validity of each signature. The signatures are verified
+// in one batch, if the key type supports batch verification.
+func VerifySignatureDigests(pubKey crypto.PubKey, digests, signatures [][]byte) []bool {
+	valid := make([]bool, len(signatures))
+	if _, ok := pubKey.(bls12381.PubKey); !ok {
+		for i := range signatures {
+			valid[i] = pubKey.VerifySignatureDigest(digests[i], signatures[i])
+		}
+		return valid
+	}
+	bv := bls12381.NewBatchVerifier()
+	batched := make([]int, 0, len(signatures))
+	for i := range signatures {
+		// the malformed signatures are invalid
+		if err := bv.Add(pubKey, digests[i], signatures[i]); err == nil {
+			batched = append(batched, i)
+		}
+	}
+	if len(batched) == 0 {
+		return valid
+	}
+	_, batchValid := bv.Verify()
+	for j, i := range batched {
+		valid[i] = batchValid[j]
+	}
+	return valid
+}
+
 // ValidateSignature returns an error if the signature is not empty, but its
 // size != tmhash.Size.
 func ValidateSignatureSize(keyType crypto.KeyType, h []byte) error {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	ce "github.com/tendermint/tendermint/crypto/encoding"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -70,7 +71,7 @@ func (v *Validator) ValidateBasic() error {
 		return errors.New("validator has negative voting power")
 	}
 
-	if len(v.ProTxHash) != crypto.DefaultHashSize {
+	if tmbytes.ValidateHash32(v.ProTxHash) != nil {
 		return fmt.Errorf("validator proTxHash is the wrong size: %v", len(v.ProTxHash))
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -41,6 +42,34 @@ const (
 var ErrTotalVotingPowerOverflow = fmt.Errorf("total voting power of resulting valset exceeds max %d",
 	MaxTotalVotingPower)
 
+// LLMQThreshold returns the number of members of a quorum of the type needed
+// to recover a threshold signature, or 0 if the type has no fixed threshold,
+// like the test quorums, whose parameters are set per devnet.
+func LLMQThreshold(quorumType btcjson.LLMQType) int {
+	switch quorumType {
+	case btcjson.LLMQType_50_60:
+		return 30
+	case btcjson.LLMQType_400_60:
+		return 240
+	case btcjson.LLMQType_400_85:
+		return 340
+	case btcjson.LLMQType_100_67:
+		return 67
+	default:
+		return 0
+	}
+}
+
+// ValidateThresholdPublicKey checks that the threshold public key of a quorum
+// is a BLS12-381 public key of the right size, which is a point of the G1
+// group.
+func ValidateThresholdPublicKey(thresholdPublicKey crypto.PubKey) error {
+	if thresholdPublicKey == nil {
+		return errors.New("threshold public key is not set")
+	}
+	return bls12381.PubKey(thresholdPublicKey.Bytes()).ValidateBasic()
+}
+
 // ValidatorSet represent a set of *Validator at a given height.
 //
 // The validators can be fetched by address or index.
@@ -204,7 +233,7 @@ func (vals *ValidatorSet) QuorumHashValid() error {
 	if vals.QuorumHash == nil {
 		return errors.New("quorum hash is not set")
 	}
-	if len(vals.QuorumHash.Bytes()) != crypto.DefaultHashSize {
+	if tmbytes.ValidateHash32(vals.QuorumHash) != nil {
 		return errors.New("quorum hash is wrong size")
 	}
 	return nil
@@ -833,10 +862,47 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 // - applies the updates against the validator set
 // - applies the removals against the validator set
 // - performs scaling and centering of priority values
+// - verifies the quorum of the resulting set (see verifyQuorum)
 // If an error is detected during verification steps, it is returned and the validator set
 // is not changed.
 func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator, newThresholdPublicKey crypto.PubKey, newQuorumHash crypto.QuorumHash) error {
-	return vals.updateWithChangeSet(changes, true, newThresholdPublicKey, newQuorumHash)
+	if len(changes) == 0 {
+		return nil
+	}
+	updated := vals.Copy()
+	if err := updated.updateWithChangeSet(changes, true, newThresholdPublicKey, newQuorumHash); err != nil {
+		return err
+	}
+	if err := updated.verifyQuorum(); err != nil {
+		return err
+	}
+	*vals = *updated
+	return nil
+}
+
+// verifyQuorum verifies that the threshold public key is valid and, if the
+// public keys of the members are known, that it's the one recovered from
+// them. The set must have at least the members needed to recover the
+// threshold signatures of its quorum type.
+func (vals *ValidatorSet) verifyQuorum() error {
+	if err := ValidateThresholdPublicKey(vals.ThresholdPublicKey); err != nil {
+		return fmt.Errorf("invalid threshold public key: %w", err)
+	}
+	if vals.HasPublicKeys {
+		for _, val := range vals.Validators {
+			if err := val.ValidatePubKey(); err != nil {
+				return fmt.Errorf("invalid validator %X: %w", val.ProTxHash, err)
+			}
+		}
+	}
+	if err := vals.ThresholdPublicKeyValid(); err != nil {
+		return fmt.Errorf("threshold public key doesn't match the members: %w", err)
+	}
+	if threshold := LLMQThreshold(vals.QuorumType); len(vals.Validators) < threshold {
+		return fmt.Errorf("the validator set would have %d members, fewer than the threshold %d of quorum type %d",
+			len(vals.Validators), threshold, vals.QuorumType)
+	}
+	return nil
 }
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
@@ -849,6 +915,58 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator, newThreshold
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, stateID StateID,
 	height int64, commit *Commit) error {
 
+	if err := verifyCommitIDs(blockID, stateID, height, commit); err != nil {
+		return err
+	}
+	if err := vals.verifyCommitSigners(commit); err != nil {
+		return err
+	}
+
+	signIds, signatures := vals.commitSignatures(chainID, commit)
+	// the block, the state and the vote extension signatures are verified in one batch
+	valid := VerifySignatureDigests(vals.ThresholdPublicKey, signIds, signatures)
+	return commitSignaturesError(chainID, commit, valid)
+}
+
+// VerifyCommits verifies the commits of the blocks with the given IDs at the
+// given heights, like VerifyCommit, but the threshold signatures of all the
+// commits are verified in one batch. It returns the error of each commit.
+func (vals *ValidatorSet) VerifyCommits(chainID string, blockIDs []BlockID, stateIDs []StateID,
+	heights []int64, commits []*Commit) []error {
+
+	errs := make([]error, len(commits))
+	var (
+		signIds, signatures [][]byte
+		batched             = make([][2]int, len(commits)) // the range of the signatures of each commit
+	)
+	for i, commit := range commits {
+		if errs[i] = verifyCommitIDs(blockIDs[i], stateIDs[i], heights[i], commit); errs[i] != nil {
+			continue
+		}
+		if errs[i] = vals.verifyCommitSigners(commit); errs[i] != nil {
+			continue
+		}
+		commitSignIds, commitSignatures := vals.commitSignatures(chainID, commit)
+		batched[i] = [2]int{len(signatures), len(signatures) + len(commitSignatures)}
+		signIds = append(signIds, commitSignIds...)
+		signatures = append(signatures, commitSignatures...)
+	}
+	if len(signatures) == 0 {
+		return errs
+	}
+
+	valid := VerifySignatureDigests(vals.ThresholdPublicKey, signIds, signatures)
+	for i, commit := range commits {
+		if errs[i] == nil {
+			errs[i] = commitSignaturesError(chainID, commit, valid[batched[i][0]:batched[i][1]])
+		}
+	}
+	return errs
+}
+
+// verifyCommitIDs checks the height, the block ID and the state ID of the
+// commit.
+func verifyCommitIDs(blockID BlockID, stateID StateID, height int64, commit *Commit) error {
 	// Validate Height and BlockID.
 	if height != commit.Height {
 		return NewErrInvalidCommitHeight(height, commit.Height)
@@ -862,23 +980,67 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, stateID
 		return fmt.Errorf("invalid commit -- wrong state ID: want %v, got %v",
 			stateID, commit.StateID)
 	}
+	return nil
+}
+
+// verifyCommitSigners checks that the signers of the commit are in the order
+// of the validator set and have a 2/3 majority of its voting power. The
+// threshold signatures can be recovered from the sign shares of any such
+// validators, so they can't tell who signed.
+func (vals *ValidatorSet) verifyCommitSigners(commit *Commit) error {
+	if commit.Signers == nil {
+		return errors.New("invalid commit -- no signers")
+	}
+	if commit.Signers.Size() != vals.Size() {
+		return fmt.Errorf("invalid commit -- wrong set size of signers: %v vs %v", vals.Size(),
+			commit.Signers.Size())
+	}
+	var signedPower int64
+	for idx, val := range vals.Validators {
+		if commit.Signers.GetIndex(idx) {
+			signedPower += val.VotingPower
+		}
+	}
+	if needed := vals.TotalVotingPower() * 2 / 3; signedPower <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: signedPower, Needed: needed}
+	}
+	return nil
+}
 
+// commitSignatures returns the sign IDs and the threshold signatures of the
+// block, the state and, if the commit is extended, the vote extension.
+func (vals *ValidatorSet) commitSignatures(chainID string, commit *Commit) (signIds, signatures [][]byte) {
 	blockSignId := commit.CanonicalVoteVerifySignId(chainID, vals.QuorumType, vals.QuorumHash)
+	stateSignId := commit.CanonicalVoteStateSignId(chainID, vals.QuorumType, vals.QuorumHash)
+
+	signIds = [][]byte{blockSignId, stateSignId}
+	signatures = [][]byte{commit.ThresholdBlockSignature, commit.ThresholdStateSignature}
+	if commit.IsExtended() {
+		signIds = append(signIds, commit.CanonicalVoteExtensionSignId(chainID, vals.QuorumType, vals.QuorumHash))
+		signatures = append(signatures, commit.ThresholdVoteExtensionSignature)
+	}
+	return signIds, signatures
+}
 
-	if !vals.ThresholdPublicKey.VerifySignatureDigest(blockSignId, commit.ThresholdBlockSignature) {
+// commitSignaturesError returns the error of the first invalid signature
+// returned by commitSignatures.
+func commitSignaturesError(chainID string, commit *Commit, valid []bool) error {
+	if !valid[0] {
 		canonicalVoteBlockSignBytes := commit.CanonicalVoteVerifySignBytes(chainID)
 		return fmt.Errorf("incorrect threshold block signature %X %X", canonicalVoteBlockSignBytes,
 			commit.ThresholdBlockSignature)
 	}
 
-	stateSignId := commit.CanonicalVoteStateSignId(chainID, vals.QuorumType, vals.QuorumHash)
-
-	if !vals.ThresholdPublicKey.VerifySignatureDigest(stateSignId, commit.ThresholdStateSignature) {
+	if !valid[1] {
 		canonicalVoteStateSignBytes := commit.CanonicalVoteStateSignBytes(chainID)
 		return fmt.Errorf("incorrect threshold state signature %X %X", canonicalVoteStateSignBytes,
 			commit.ThresholdStateSignature)
 	}
 
+	if commit.IsExtended() && !valid[2] {
+		return fmt.Errorf("incorrect threshold vote extension signature %X", commit.ThresholdVoteExtensionSignature)
+	}
+
 	return nil
 }
 
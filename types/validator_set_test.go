@@ -64,16 +64,43 @@ func TestValidatorSetBasic(t *testing.T) {
 
 	// update
 	val = randValidator(vset.TotalVotingPower())
-	assert.NoError(t, vset.UpdateWithChangeSet([]*Validator{val}, val.PubKey, crypto.RandQuorumHash()))
+	thresholdPublicKey := recoverThresholdPublicKey(t, vset, val)
+	assert.NoError(t, vset.UpdateWithChangeSet([]*Validator{val}, thresholdPublicKey, crypto.RandQuorumHash()))
 	_, val = vset.GetByProTxHash(val.ProTxHash)
 	val.PubKey = bls12381.GenPrivKey().PubKey()
 	proposerPriority := val.ProposerPriority
 
 	val.ProposerPriority = 0
-	assert.NoError(t, vset.UpdateWithChangeSet([]*Validator{val}, val.PubKey, crypto.RandQuorumHash()))
+	thresholdPublicKey = recoverThresholdPublicKey(t, vset, val)
+	assert.NoError(t, vset.UpdateWithChangeSet([]*Validator{val}, thresholdPublicKey, crypto.RandQuorumHash()))
 	_, val = vset.GetByProTxHash(val.ProTxHash)
 	assert.Equal(t, proposerPriority, val.ProposerPriority)
 
+	// the threshold public key must match the members
+	val = randValidator(vset.TotalVotingPower())
+	assert.Error(t, vset.UpdateWithChangeSet([]*Validator{val}, val.PubKey, crypto.RandQuorumHash()))
+	assert.False(t, vset.HasProTxHash(val.ProTxHash))
+
+}
+
+// recoverThresholdPublicKey returns the threshold public key of the set
+// updated with the validator.
+func recoverThresholdPublicKey(t *testing.T, vals *ValidatorSet, update *Validator) crypto.PubKey {
+	members := []*Validator{update}
+	for _, val := range vals.Validators {
+		if !bytes.Equal(val.ProTxHash, update.ProTxHash) {
+			members = append(members, val)
+		}
+	}
+	publicKeys := make([]crypto.PubKey, len(members))
+	proTxHashes := make([][]byte, len(members))
+	for i, val := range members {
+		publicKeys[i] = val.PubKey
+		proTxHashes[i] = val.ProTxHash
+	}
+	thresholdPublicKey, err := bls12381.RecoverThresholdPublicKeyFromPublicKeys(publicKeys, proTxHashes)
+	require.NoError(t, err)
+	return thresholdPublicKey
 }
 
 func TestValidatorSetValidateBasic(t *testing.T) {
@@ -294,7 +321,7 @@ func TestProposerSelection1(t *testing.T) {
 	var proposers []string
 	for i := 0; i < 99; i++ {
 		val := vset.GetProposer()
-		proposers = append(proposers, val.ProTxHash.ShortString())
+		proposers = append(proposers, val.ProTxHash[:3].String())
 		vset.IncrementProposerPriority(1)
 	}
 	expected := `2C26B4 BAA5A0 FCDE2B 2C26B4 BAA5A0 FCDE2B 2C26B4 BAA5A0 FCDE2B 2C26B4 BAA5A0 FCDE2B 2C26B4 BAA5A0 FCDE2B ` +
@@ -562,14 +589,18 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 	vote.BlockSignature = blockSig
 	vote.StateSignature = stateSig
 
-	commit := NewCommit(vote.Height,
-		vote.Round,
-		vote.BlockID,
-		vote.StateID,
-		quorumHash,
-		vote.BlockSignature,
-		vote.StateSignature,
-	)
+	newCommit := func(blockSig, stateSig []byte) *Commit {
+		commit := NewCommit(vote.Height, vote.Round, vote.BlockID, vote.StateID, quorumHash, blockSig, stateSig)
+		commit.Signers = MakeAllCommitSigners(vset.Size())
+		return commit
+	}
+	commit := newCommit(vote.BlockSignature, vote.StateSignature)
+	noSignersCommit := newCommit(vote.BlockSignature, vote.StateSignature)
+	noSignersCommit.Signers = nil
+	wrongSignersCommit := newCommit(vote.BlockSignature, vote.StateSignature)
+	wrongSignersCommit.Signers = MakeAllCommitSigners(vset.Size() + 1)
+	absentSignersCommit := newCommit(vote.BlockSignature, vote.StateSignature)
+	absentSignersCommit.Signers.SetIndex(0, false)
 
 	vote2 := *vote
 	blockSig2, err := privKey.SignDigest(VoteBlockSignBytes("EpsilonEridani", v))
@@ -595,14 +626,17 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 		{"wrong height", chainID, vote.BlockID, vote.StateID, vote.Height - 1, commit, true},
 
 		{"incorrect threshold block signature", chainID, vote.BlockID, vote.StateID, vote.Height,
-			NewCommit(vote.Height, vote.Round, vote.BlockID, vote.StateID, quorumHash, nil, nil), true},
+			newCommit(nil, nil), true},
 
 		{"incorrect threshold state signature", chainID, vote.BlockID, vote.StateID, vote.Height,
-			NewCommit(vote.Height, vote.Round, vote.BlockID, vote.StateID,
-				 quorumHash, vote.BlockSignature, nil), true},
+			newCommit(vote.BlockSignature, nil), true},
 
 		{"incorrect threshold block signature", chainID, vote.BlockID, vote.StateID, vote.Height,
-			NewCommit(vote.Height, vote.Round, vote.BlockID, vote.StateID, quorumHash, vote2.BlockSignature, vote2.StateSignature), true},
+			newCommit(vote2.BlockSignature, vote2.StateSignature), true},
+
+		{"no signers", chainID, vote.BlockID, vote.StateID, vote.Height, noSignersCommit, true},
+		{"wrong set size of signers", chainID, vote.BlockID, vote.StateID, vote.Height, wrongSignersCommit, true},
+		{"insufficient voting power", chainID, vote.BlockID, vote.StateID, vote.Height, absentSignersCommit, true},
 	}
 
 	for _, tc := range testCases {
@@ -646,6 +680,76 @@ func TestValidatorSet_VerifyCommit_CheckThresholdSignatures(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommit_BatchedThresholdSignatures(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+		stateID = makeStateIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4)
+	commit, err := MakeCommit(blockID, stateID, h, 0, voteSet, vals)
+	require.NoError(t, err)
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, stateID, h, commit))
+
+	// the invalid state signature is identified
+	blockSignature, stateSignature := commit.ThresholdBlockSignature, commit.ThresholdStateSignature
+	commit.ThresholdStateSignature = blockSignature
+	err = valSet.VerifyCommit(chainID, blockID, stateID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "incorrect threshold state signature")
+	}
+
+	// the swapped signatures are invalid
+	commit.ThresholdBlockSignature, commit.ThresholdStateSignature = stateSignature, blockSignature
+	err = valSet.VerifyCommit(chainID, blockID, stateID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "incorrect threshold block signature")
+	}
+}
+
+// VerifyCommits accepts and rejects the same commits with the same errors as
+// VerifyCommit.
+func TestValidatorSet_VerifyCommits(t *testing.T) {
+	const (
+		chainID = "test_chain_id"
+		n       = 8
+	)
+	valSet, vals := GenerateValidatorSet(4)
+
+	var (
+		blockIDs = make([]BlockID, n)
+		stateIDs = make([]StateID, n)
+		heights  = make([]int64, n)
+		commits  = make([]*Commit, n)
+	)
+	for i := range commits {
+		heights[i] = int64(i + 1)
+		blockIDs[i] = makeBlockIDRandom()
+		stateIDs[i] = makeStateIDRandom()
+		voteSet := NewVoteSet(chainID, heights[i], 0, tmproto.PrecommitType, valSet)
+		commit, err := MakeCommit(blockIDs[i], stateIDs[i], heights[i], 0, voteSet, vals)
+		require.NoError(t, err)
+		commits[i] = commit
+	}
+	require.Equal(t, make([]error, n), valSet.VerifyCommits(chainID, blockIDs, stateIDs, heights, commits))
+
+	commits[1].ThresholdStateSignature = commits[1].ThresholdBlockSignature
+	commits[2].ThresholdBlockSignature = nil
+	commits[3].ThresholdBlockSignature = commits[4].ThresholdBlockSignature
+	heights[5]++
+	blockIDs[6] = makeBlockIDRandom()
+
+	errs := valSet.VerifyCommits(chainID, blockIDs, stateIDs, heights, commits)
+	require.Len(t, errs, n)
+	for i, err := range errs {
+		expErr := valSet.VerifyCommit(chainID, blockIDs[i], stateIDs[i], heights[i], commits[i])
+		assert.Equal(t, expErr, err, "commit #%d", i)
+		assert.Equal(t, i != 0 && i != 4 && i != 7, err != nil, "commit #%d", i)
+	}
+}
+
 func TestEmptySet(t *testing.T) {
 
 	var valList []*Validator
@@ -707,6 +811,33 @@ func TestUpdatesForNewValidatorSet(t *testing.T) {
 
 }
 
+func TestUpdateWithChangeSetVerifiesQuorum(t *testing.T) {
+	valSet, _ := GenerateValidatorSet(LLMQThreshold(btcjson.LLMQType_50_60))
+	removal := NewTestRemoveValidatorGeneratedFromProTxHash(valSet.Validators[0].ProTxHash)
+
+	// a threshold public key, which is not a point of G1, is rejected: there's
+	// no point of the curve with x = 1
+	invalidPublicKey := make(bls12381.PubKey, bls12381.PubKeySize)
+	invalidPublicKey[bls12381.PubKeySize-1] = 1
+	valSetCopy := valSet.Copy()
+	assert.Error(t, valSetCopy.UpdateWithChangeSet([]*Validator{removal}, invalidPublicKey, valSet.QuorumHash))
+	assert.Equal(t, valSet, valSetCopy)
+
+	// the remaining members of the quorum still recover its threshold public key
+	assert.NoError(t, valSetCopy.UpdateWithChangeSet([]*Validator{removal}, valSet.ThresholdPublicKey,
+		valSet.QuorumHash))
+	assert.Equal(t, valSet.Size()-1, valSetCopy.Size())
+
+	// but not enough of them for a quorum of 50 members
+	valSet.QuorumType = btcjson.LLMQType_50_60
+	valSetCopy = valSet.Copy()
+	err := valSetCopy.UpdateWithChangeSet([]*Validator{removal}, valSet.ThresholdPublicKey, valSet.QuorumHash)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "fewer than the threshold")
+	}
+	assert.Equal(t, valSet, valSetCopy)
+}
+
 type testVal struct {
 	name  string
 	power int64
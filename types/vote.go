@@ -20,6 +20,9 @@ const (
 	// MaxVoteBytes is a maximum vote size (including amino overhead).
 	MaxVoteBytesBLS12381 int64 = 241
 	MaxVoteBytesEd25519  int64 = 209
+	// MaxVoteExtensionSize is the maximum size of a vote extension, which isn't
+	// included in the maximum vote size.
+	MaxVoteExtensionSize int = 1024
 )
 
 func MaxVoteBytesForKeyType(keyType crypto.KeyType) int64 {
@@ -39,6 +42,7 @@ var (
 	ErrVoteInvalidValidatorPubKeySize = errors.New("invalid validator public key size")
 	ErrVoteInvalidBlockSignature      = errors.New("invalid block signature")
 	ErrVoteInvalidStateSignature      = errors.New("invalid state signature")
+	ErrVoteInvalidExtensionSignature  = errors.New("invalid extension signature")
 	ErrVoteInvalidBlockHash           = errors.New("invalid block hash")
 	ErrVoteNonDeterministicSignature  = errors.New("non-deterministic signature")
 	ErrVoteNil                        = errors.New("nil vote")
@@ -63,8 +67,12 @@ func NewConflictingVoteError(vote1, vote2 *Vote) *ErrVoteConflictingVotes {
 // Address is hex bytes.
 type Address = crypto.Address
 
+// ProTxHash and QuorumHash are hex bytes, use tmbytes.Hash32FromHexBytes to
+// convert them into the fixed-size tmbytes.Hash32, which rejects truncated hashes.
 type ProTxHash = crypto.ProTxHash
 
+type QuorumHash = crypto.QuorumHash
+
 // Vote represents a prevote, precommit, or commit vote from validators for
 // consensus.
 type Vote struct {
@@ -77,6 +85,9 @@ type Vote struct {
 	ValidatorIndex     int32                 `json:"validator_index"`
 	BlockSignature     []byte                `json:"block_signature"`
 	StateSignature     []byte                `json:"state_signature"`
+	// Extension is signed by the precommits for a block, if vote extensions are enabled.
+	Extension          []byte `json:"extension,omitempty"`
+	ExtensionSignature []byte `json:"extension_signature,omitempty"`
 }
 
 // VoteBlockSignBytes returns the proto-encoding of the canonicalized Vote, for
@@ -145,6 +156,60 @@ func VoteStateSignId(chainID string, vote *tmproto.Vote, quorumType btcjson.LLMQ
 	return stateSignId
 }
 
+// VoteExtensionSignBytes returns the proto-encoding of the canonicalized vote
+// extension, for signing. Panics is the marshaling fails.
+//
+// See CanonicalizeVoteExtension
+func VoteExtensionSignBytes(chainID string, vote *tmproto.Vote) []byte {
+	pb := CanonicalizeVoteExtension(chainID, vote)
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// VoteExtensionSignId returns signId that should be signed for the vote extension
+func VoteExtensionSignId(chainID string, vote *tmproto.Vote, quorumType btcjson.LLMQType, quorumHash []byte) []byte {
+	extensionSignBytes := VoteExtensionSignBytes(chainID, vote)
+
+	extensionMessageHash := crypto.Sha256(extensionSignBytes)
+
+	extensionRequestId := VoteExtensionRequestIdProto(vote)
+
+	return crypto.SignId(quorumType, bls12381.ReverseBytes(quorumHash), bls12381.ReverseBytes(extensionRequestId),
+		bls12381.ReverseBytes(extensionMessageHash))
+}
+
+// VoteExtensionRequestIdProto returns the request id of the vote extension,
+// which is the same for all the validators at the height and round.
+func VoteExtensionRequestIdProto(vote *tmproto.Vote) []byte {
+	requestIdMessage := []byte("dpevote")
+	heightByteArray := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightByteArray, uint64(vote.Height))
+	roundByteArray := make([]byte, 4)
+	binary.LittleEndian.PutUint32(roundByteArray, uint32(vote.Round))
+
+	requestIdMessage = append(requestIdMessage, heightByteArray...)
+	requestIdMessage = append(requestIdMessage, roundByteArray...)
+
+	return crypto.Sha256(requestIdMessage)
+}
+
+// VoteExtensionRequested returns true if the private validator must sign the
+// extension of the vote. If vote extensions are enabled, consensus sets a
+// non-nil extension on the precommits for a block, even if the extension is
+// empty.
+func VoteExtensionRequested(vote *tmproto.Vote) bool {
+	return vote.Type == tmproto.PrecommitType && vote.BlockID.Hash != nil && vote.Extension != nil
+}
+
+// IsExtended returns true if the vote carries a signed vote extension.
+func (vote *Vote) IsExtended() bool {
+	return len(vote.ExtensionSignature) > 0
+}
+
 func (vote *Vote) Copy() *Vote {
 	voteCopy := *vote
 	return &voteCopy
@@ -261,10 +326,8 @@ func (vote *Vote) Verify(chainID string, quorumType btcjson.LLMQType, quorumHash
 	// fmt.Printf("block vote verify sign Id %s (%d - %s  - %s  - %s)\n", hex.EncodeToString(signId), quorumType,
 	//	hex.EncodeToString(quorumHash), hex.EncodeToString(blockRequestId), hex.EncodeToString(blockMessageHash))
 
-	if !pubKey.VerifySignatureDigest(signId, vote.BlockSignature) {
-		return fmt.Errorf("%s proTxHash %s pubKey %v vote %v sign bytes %s block signature %s", ErrVoteInvalidBlockSignature.Error(),
-			proTxHash, pubKey, vote, hex.EncodeToString(voteBlockSignBytes), hex.EncodeToString(vote.BlockSignature))
-	}
+	signIds := [][]byte{signId}
+	signatures := [][]byte{vote.BlockSignature}
 
 	// we must verify the stateID but only if the blockID isn't nil
 	if vote.BlockID.Hash != nil {
@@ -278,9 +341,28 @@ func (vote *Vote) Verify(chainID string, quorumType btcjson.LLMQType, quorumHash
 		// fmt.Printf("state vote verify sign Id %s (%d - %s  - %s  - %s)\n", hex.EncodeToString(stateSignId), quorumType,
 		//	hex.EncodeToString(quorumHash), hex.EncodeToString(stateRequestId), hex.EncodeToString(stateMessageHash))
 
-		if !pubKey.VerifySignatureDigest(stateSignId, vote.StateSignature) {
-			return ErrVoteInvalidStateSignature
-		}
+		signIds = append(signIds, stateSignId)
+		signatures = append(signatures, vote.StateSignature)
+	}
+
+	extensionIndex := -1
+	if vote.IsExtended() {
+		extensionIndex = len(signIds)
+		signIds = append(signIds, VoteExtensionSignId(chainID, v, quorumType, quorumHash))
+		signatures = append(signatures, vote.ExtensionSignature)
+	}
+
+	// the block, the state and the extension signatures are verified in one batch
+	valid := VerifySignatureDigests(pubKey, signIds, signatures)
+	if !valid[0] {
+		return fmt.Errorf("%s proTxHash %s pubKey %v vote %v sign bytes %s block signature %s", ErrVoteInvalidBlockSignature.Error(),
+			proTxHash, pubKey, vote, hex.EncodeToString(voteBlockSignBytes), hex.EncodeToString(vote.BlockSignature))
+	}
+	if vote.BlockID.Hash != nil && !valid[1] {
+		return ErrVoteInvalidStateSignature
+	}
+	if extensionIndex >= 0 && !valid[extensionIndex] {
+		return ErrVoteInvalidExtensionSignature
 	}
 
 	return nil
@@ -342,6 +424,24 @@ func (vote *Vote) ValidateBasic() error {
 		return fmt.Errorf("state signature is too big (max: %d)", SignatureSize)
 	}
 
+	if vote.Type != tmproto.PrecommitType || vote.BlockID.Hash == nil {
+		if len(vote.Extension) > 0 || len(vote.ExtensionSignature) > 0 {
+			return errors.New("only the precommits for a block can have an extension")
+		}
+	}
+
+	if len(vote.Extension) > MaxVoteExtensionSize {
+		return fmt.Errorf("extension is too big (max: %d)", MaxVoteExtensionSize)
+	}
+
+	if len(vote.Extension) > 0 && len(vote.ExtensionSignature) == 0 {
+		return errors.New("extension signature is missing")
+	}
+
+	if len(vote.ExtensionSignature) > SignatureSize {
+		return fmt.Errorf("extension signature is too big (max: %d)", SignatureSize)
+	}
+
 	return nil
 }
 
@@ -362,6 +462,8 @@ func (vote *Vote) ToProto() *tmproto.Vote {
 		ValidatorIndex:     vote.ValidatorIndex,
 		BlockSignature:     vote.BlockSignature,
 		StateSignature:     vote.StateSignature,
+		Extension:          vote.Extension,
+		ExtensionSignature: vote.ExtensionSignature,
 	}
 }
 
@@ -392,6 +494,8 @@ func VoteFromProto(pv *tmproto.Vote) (*Vote, error) {
 	vote.ValidatorIndex = pv.ValidatorIndex
 	vote.BlockSignature = pv.BlockSignature
 	vote.StateSignature = pv.StateSignature
+	vote.Extension = pv.Extension
+	vote.ExtensionSignature = pv.ExtensionSignature
 
 	return vote, vote.ValidateBasic()
 }
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"runtime/debug"
 	"strings"
+	"time"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/bls12381"
 
 	"github.com/tendermint/tendermint/libs/bits"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmsync "github.com/tendermint/tendermint/libs/sync"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 const (
@@ -78,6 +81,15 @@ type VoteSet struct {
 	thresholdStateSig []byte                 // If a 2/3 majority is seen, recover the state sig
 	votesByBlock      map[string]*blockVotes // string(blockHash|blockParts) -> blockVotes
 	peerMaj23s        map[P2PID]BlockID      // Maj23 for each peer
+
+	// If 2/3 of the precommits for the maj23 block sign the same vote extension,
+	// recover the vote extension sig
+	thresholdVoteExtension    []byte
+	thresholdVoteExtensionSig []byte
+
+	// The recovery of the threshold sigs of the first block with 2/3 of the
+	// voting power, which becomes maj23 once they're recovered
+	recovery *ThresholdRecovery
 }
 
 // NewVoteSet constructs a new VoteSet struct used to accumulate votes for given height/round.
@@ -204,7 +216,8 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	// If we already know of this vote, return false.
 	if existing, ok := voteSet.getVote(valIndex, blockKey); ok {
 		if bytes.Equal(existing.BlockSignature, vote.BlockSignature) &&
-			bytes.Equal(existing.StateSignature, vote.StateSignature) {
+			bytes.Equal(existing.StateSignature, vote.StateSignature) &&
+			bytes.Equal(existing.ExtensionSignature, vote.ExtensionSignature) {
 			return false, nil // duplicate
 		}
 		return false, fmt.Errorf("existing vote: %v; new vote: %v: %w", existing, vote, ErrVoteNonDeterministicSignature)
@@ -299,42 +312,68 @@ func (voteSet *VoteSet) addVerifiedVote(
 	votesByBlock.addVerifiedVote(vote, votingPower)
 
 	// If we just crossed the quorum threshold and have 2/3 majority...
-	if origSum < quorum && quorum <= votesByBlock.sum {
+	switch {
+	case origSum < quorum && quorum <= votesByBlock.sum:
 		// Only consider the first quorum reached
-		if voteSet.maj23 == nil {
-			maj23BlockID := vote.BlockID
-			stateMaj23StateID := vote.StateID
-			// fmt.Printf("vote majority reached at height %d (%d/%d) quorum size %d\n",
-			//  voteSet.height, voteSet.round, voteSet.signedMsgType, quorum)
-			voteSet.maj23 = &maj23BlockID
-			voteSet.stateMaj23 = &stateMaj23StateID
-			if len(votesByBlock.votes) > 1 {
-				err := voteSet.recoverThresholdSigs(votesByBlock)
-				if err != nil {
-					// fmt.Printf("error %v quorum %d\n", err, quorum)
-					// for i, vote := range votesByBlock.votes {
-					// 	fmt.Printf("vote %d %v\n", i, vote)
-					// }
-					panic(err)
-				}
-			} else {
-				// there is only 1 validator
-				voteSet.thresholdBlockSig = vote.BlockSignature
-				voteSet.thresholdStateSig = vote.StateSignature
-			}
-			// And also copy votes over to voteSet.votes
-			for i, vote := range votesByBlock.votes {
-				if vote != nil {
-					voteSet.votes[i] = vote
-				}
+		if voteSet.maj23 == nil && voteSet.recovery == nil {
+			voteSet.recovery = &ThresholdRecovery{
+				BlockID:    vote.BlockID,
+				QuorumTime: tmtime.Now(),
 			}
+			voteSet.recoverMaj23(vote, votesByBlock)
 		}
+	case voteSet.maj23 == nil && voteSet.recovery != nil && voteSet.recovery.BlockID.Key() == blockKey:
+		// the recovery failed, retry with the new sign share
+		voteSet.recoverMaj23(vote, votesByBlock)
+	case voteSet.maj23 != nil && voteSet.maj23.Key() == blockKey:
+		// the extensions of the first votes might have differed
+		voteSet.recoverThresholdVoteExtensionSig(votesByBlock)
 	}
 
 	return true, conflicting
 }
 
-func (voteSet *VoteSet) recoverThresholdSigs(blockVotes *blockVotes) error {
+// recoverMaj23 recovers the threshold signatures of the votes for the block,
+// which have 2/3 of the voting power. The block becomes the 2/3 majority once
+// they're recovered. The attempt is recorded in voteSet.recovery.
+func (voteSet *VoteSet) recoverMaj23(vote *Vote, votesByBlock *blockVotes) {
+	recovery := voteSet.recovery
+	recovery.Attempts++
+	recovery.AttemptTime = tmtime.Now()
+	start := time.Now()
+	var err error
+	if len(votesByBlock.votes) > 1 {
+		err = voteSet.recoverThresholdSigs(vote, votesByBlock)
+	} else {
+		// there is only 1 validator
+		voteSet.thresholdBlockSig = vote.BlockSignature
+		voteSet.thresholdStateSig = vote.StateSignature
+	}
+	recovery.AttemptDuration = time.Since(start)
+	if err != nil {
+		recovery.Error = err.Error()
+		return
+	}
+	recovery.Recovered = true
+	recovery.Error = ""
+
+	maj23BlockID := vote.BlockID
+	stateMaj23StateID := vote.StateID
+	voteSet.maj23 = &maj23BlockID
+	voteSet.stateMaj23 = &stateMaj23StateID
+	voteSet.recoverThresholdVoteExtensionSig(votesByBlock)
+	// And also copy votes over to voteSet.votes
+	for i, vote := range votesByBlock.votes {
+		if vote != nil {
+			voteSet.votes[i] = vote
+		}
+	}
+}
+
+// recoverThresholdSigs recovers the threshold block and state signatures from
+// the sign shares of the votes for the block, and verifies them with the
+// threshold public key of the quorum.
+func (voteSet *VoteSet) recoverThresholdSigs(vote *Vote, blockVotes *blockVotes) error {
 	if len(blockVotes.votes) < 2 {
 		return fmt.Errorf("attempting to recover a threshold signature with only 1 vote")
 	}
@@ -352,18 +391,124 @@ func (voteSet *VoteSet) recoverThresholdSigs(blockVotes *blockVotes) error {
 	if err != nil {
 		return fmt.Errorf("error recovering threshold block sig: %v", err)
 	}
-	voteSet.thresholdBlockSig = thresholdBlockSig
-	if voteSet.maj23 != nil && voteSet.maj23.Hash != nil {
+	var thresholdStateSig []byte
+	if vote.BlockID.Hash != nil {
 		// if the vote is voting for nil, then we do not care to recover the state signature
-		thresholdStateSig, err := bls12381.RecoverThresholdSignatureFromShares(stateSigs, blsIDs)
+		thresholdStateSig, err = bls12381.RecoverThresholdSignatureFromShares(stateSigs, blsIDs)
 		if err != nil {
 			return fmt.Errorf("error recovering threshold state sig: %v", err)
 		}
-		voteSet.thresholdStateSig = thresholdStateSig
 	}
+
+	// the sign shares were verified, but they might not belong to the quorum,
+	// e.g. if they're signed with the keys of a stale quorum
+	if voteSet.valSet.ThresholdPublicKey != nil {
+		thresholdVote := vote.Copy()
+		thresholdVote.BlockSignature = thresholdBlockSig
+		thresholdVote.StateSignature = thresholdStateSig
+		thresholdVote.Extension = nil
+		thresholdVote.ExtensionSignature = nil
+		err = thresholdVote.Verify(voteSet.chainID, voteSet.valSet.QuorumType, voteSet.valSet.QuorumHash,
+			voteSet.valSet.ThresholdPublicKey, vote.ValidatorProTxHash)
+		if err != nil {
+			return fmt.Errorf("error verifying threshold sigs with the threshold public key: %w", err)
+		}
+	}
+
+	voteSet.thresholdBlockSig = thresholdBlockSig
+	voteSet.thresholdStateSig = thresholdStateSig
 	return nil
 }
 
+// recoverThresholdVoteExtensionSig recovers the threshold signature of the vote
+// extension, once the precommits with 2/3 of the voting power for the maj23
+// block signed the same extension, and verifies it with the threshold public
+// key of the quorum. The precommits with another extension are ignored. If
+// the recovery fails, the error is recorded in voteSet.recovery, and it's
+// retried with the next sign share.
+func (voteSet *VoteSet) recoverThresholdVoteExtensionSig(blockVotes *blockVotes) {
+	if voteSet.signedMsgType != tmproto.PrecommitType || voteSet.maj23 == nil || voteSet.maj23.Hash == nil ||
+		voteSet.thresholdVoteExtensionSig != nil {
+		return
+	}
+	type extensionVotes struct {
+		sigs   [][]byte
+		blsIDs [][]byte
+		sum    int64
+	}
+	quorum := voteSet.valSet.TotalVotingPower()*2/3 + 1
+	votesByExtension := make(map[string]*extensionVotes)
+	var (
+		extension   []byte
+		quorumVotes *extensionVotes
+	)
+	for _, vote := range blockVotes.votes {
+		if vote == nil || !vote.IsExtended() {
+			continue
+		}
+		_, val := voteSet.valSet.GetByIndex(vote.ValidatorIndex)
+		votes, ok := votesByExtension[string(vote.Extension)]
+		if !ok {
+			votes = &extensionVotes{}
+			votesByExtension[string(vote.Extension)] = votes
+		}
+		votes.sigs = append(votes.sigs, vote.ExtensionSignature)
+		votes.blsIDs = append(votes.blsIDs, vote.ValidatorProTxHash)
+		votes.sum += val.VotingPower
+		// only one extension can have 2/3 of the voting power
+		if votes.sum >= quorum {
+			extension, quorumVotes = vote.Extension, votes
+		}
+	}
+	if quorumVotes == nil {
+		return
+	}
+
+	thresholdSig, err := voteSet.recoverThresholdVoteExtensionSigFromShares(extension, quorumVotes.sigs,
+		quorumVotes.blsIDs)
+	if err != nil {
+		voteSet.recovery.VoteExtensionError = err.Error()
+		return
+	}
+	voteSet.recovery.VoteExtensionError = ""
+	voteSet.thresholdVoteExtension = extension
+	voteSet.thresholdVoteExtensionSig = thresholdSig
+}
+
+// recoverThresholdVoteExtensionSigFromShares recovers the threshold signature
+// of the vote extension from the sign shares, and verifies it with the
+// threshold public key of the quorum.
+func (voteSet *VoteSet) recoverThresholdVoteExtensionSigFromShares(extension []byte, sigs, blsIDs [][]byte) (
+	[]byte, error) {
+	thresholdSig, err := bls12381.RecoverThresholdSignatureFromShares(sigs, blsIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering threshold vote extension sig: %w", err)
+	}
+
+	// the sign shares were verified, but they might not belong to the quorum,
+	// e.g. if they're signed with the keys of a stale quorum
+	if voteSet.valSet.ThresholdPublicKey != nil {
+		vote := &tmproto.Vote{Height: voteSet.height, Round: voteSet.round, Extension: extension}
+		signID := VoteExtensionSignId(voteSet.chainID, vote, voteSet.valSet.QuorumType, voteSet.valSet.QuorumHash)
+		if !voteSet.valSet.ThresholdPublicKey.VerifySignatureDigest(signID, thresholdSig) {
+			return nil, fmt.Errorf("error verifying threshold vote extension sig with the threshold public key: %w",
+				ErrVoteInvalidExtensionSignature)
+		}
+	}
+	return thresholdSig, nil
+}
+
+// HasThresholdVoteExtension returns true if the threshold signature of the vote
+// extension was recovered.
+func (voteSet *VoteSet) HasThresholdVoteExtension() bool {
+	if voteSet == nil {
+		return false
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	return voteSet.thresholdVoteExtensionSig != nil
+}
+
 // If a peer claims that it has 2/3 majority for given blockKey, call this.
 // NOTE: if there are too many peers, or too much peer churn,
 // this can cause memory issues.
@@ -559,6 +704,7 @@ func (voteSet *VoteSet) MarshalJSON() ([]byte, error) {
 		voteSet.voteStrings(),
 		voteSet.bitArrayString(),
 		voteSet.peerMaj23s,
+		voteSet.quorumSignProgress(),
 	})
 }
 
@@ -566,9 +712,93 @@ func (voteSet *VoteSet) MarshalJSON() ([]byte, error) {
 // NOTE: insufficient for unmarshalling from (compressed votes)
 // TODO: make the peerMaj23s nicer to read (eg just the block hash)
 type VoteSetJSON struct {
-	Votes         []string          `json:"votes"`
-	VotesBitArray string            `json:"votes_bit_array"`
-	PeerMaj23s    map[P2PID]BlockID `json:"peer_maj_23s"`
+	Votes              []string           `json:"votes"`
+	VotesBitArray      string             `json:"votes_bit_array"`
+	PeerMaj23s         map[P2PID]BlockID  `json:"peer_maj_23s"`
+	QuorumSignProgress QuorumSignProgress `json:"quorum_sign_progress"`
+}
+
+// ThresholdRecovery records the recovery of the threshold signatures from the
+// sign shares of the votes for the block, which have 2/3 of the voting power.
+// The recovery is retried with each new vote for the block, until it succeeds.
+type ThresholdRecovery struct {
+	// The block, whose votes have 2/3 of the voting power
+	BlockID BlockID `json:"block_id"`
+	// When the votes with 2/3 of the voting power were received
+	QuorumTime time.Time `json:"quorum_time"`
+	// When the recovery was last attempted, and how long it took
+	AttemptTime     time.Time     `json:"attempt_time"`
+	AttemptDuration time.Duration `json:"attempt_duration"`
+	// Number of the attempts
+	Attempts int `json:"attempts"`
+	// Whether the threshold signatures were recovered
+	Recovered bool `json:"recovered"`
+	// Error of the last failed attempt
+	Error string `json:"error,omitempty"`
+	// Error of the last failed recovery of the vote extension signature, once
+	// the threshold signatures of the block were recovered
+	VoteExtensionError string `json:"vote_extension_error,omitempty"`
+}
+
+// ThresholdRecovery returns the recovery of the threshold signatures, nil if
+// no block has 2/3 of the voting power yet.
+func (voteSet *VoteSet) ThresholdRecovery() *ThresholdRecovery {
+	if voteSet == nil {
+		return nil
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	if voteSet.recovery == nil {
+		return nil
+	}
+	recovery := *voteSet.recovery
+	return &recovery
+}
+
+// QuorumSignProgress shows how far the vote set is from recovering the
+// threshold signatures, which need the sign shares of a quorum of validators.
+type QuorumSignProgress struct {
+	// Number of the sign shares (votes) received
+	Shares int `json:"shares"`
+	// Validators, whose sign shares were not received
+	Missing []crypto.ProTxHash `json:"missing"`
+	// Whether the threshold signatures were recovered
+	Recovered bool `json:"recovered"`
+	// The recovery of the threshold signatures, once the votes have 2/3 of
+	// the voting power
+	Recovery *ThresholdRecovery `json:"recovery,omitempty"`
+}
+
+// String returns a short string representation of QuorumSignProgress like:
+// "QSP{shares:3 missing:1 recovered:true}"
+func (qsp QuorumSignProgress) String() string {
+	return fmt.Sprintf("QSP{shares:%d missing:%d recovered:%v}", qsp.Shares, len(qsp.Missing), qsp.Recovered)
+}
+
+// QuorumSignProgress returns the progress of collecting the sign shares.
+func (voteSet *VoteSet) QuorumSignProgress() QuorumSignProgress {
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+	return voteSet.quorumSignProgress()
+}
+
+func (voteSet *VoteSet) quorumSignProgress() QuorumSignProgress {
+	qsp := QuorumSignProgress{
+		Missing:   make([]crypto.ProTxHash, 0),
+		Recovered: voteSet.thresholdBlockSig != nil,
+	}
+	if voteSet.recovery != nil {
+		recovery := *voteSet.recovery
+		qsp.Recovery = &recovery
+	}
+	for i, vote := range voteSet.votes {
+		if vote != nil {
+			qsp.Shares++
+		} else {
+			qsp.Missing = append(qsp.Missing, voteSet.valSet.Validators[i].ProTxHash)
+		}
+	}
+	return qsp
 }
 
 // Return the bit-array of votes including
@@ -614,6 +844,7 @@ func (voteSet *VoteSet) voteStrings() []string {
 // 5. fraction of voted power
 // 6. votes bit array
 // 7. 2/3+ majority for each peer
+// 8. quorum sign progress
 func (voteSet *VoteSet) StringShort() string {
 	if voteSet == nil {
 		return nilVoteSetString
@@ -621,8 +852,9 @@ func (voteSet *VoteSet) StringShort() string {
 	voteSet.mtx.Lock()
 	defer voteSet.mtx.Unlock()
 	_, _, frac := voteSet.sumTotalFrac()
-	return fmt.Sprintf(`VoteSet{H:%v R:%v T:%v +2/3:%v(%v) %v %v}`,
-		voteSet.height, voteSet.round, voteSet.signedMsgType, voteSet.maj23, frac, voteSet.votesBitArray, voteSet.peerMaj23s)
+	return fmt.Sprintf(`VoteSet{H:%v R:%v T:%v +2/3:%v(%v) %v %v %v}`,
+		voteSet.height, voteSet.round, voteSet.signedMsgType, voteSet.maj23, frac, voteSet.votesBitArray, voteSet.peerMaj23s,
+		voteSet.quorumSignProgress())
 }
 
 // LogString produces a logging suitable string representation of the
@@ -680,7 +912,13 @@ func (voteSet *VoteSet) MakeCommit() *Commit {
 		panic("Cannot MakeCommit() unless a thresholdStateSig has been created")
 	}
 
-	return NewCommit(voteSet.GetHeight(), voteSet.GetRound(), *voteSet.maj23, *voteSet.stateMaj23, voteSet.valSet.QuorumHash, voteSet.thresholdBlockSig, voteSet.thresholdStateSig)
+	commit := NewCommit(voteSet.GetHeight(), voteSet.GetRound(), *voteSet.maj23, *voteSet.stateMaj23, voteSet.valSet.QuorumHash, voteSet.thresholdBlockSig, voteSet.thresholdStateSig)
+	if voteSet.thresholdVoteExtensionSig != nil {
+		commit.VoteExtension = voteSet.thresholdVoteExtension
+		commit.ThresholdVoteExtensionSignature = voteSet.thresholdVoteExtensionSig
+	}
+	commit.Signers = voteSet.votesByBlock[voteSet.maj23.Key()].bitArray.Copy()
+	return commit
 }
 
 //--------------------------------------------------------------------------------
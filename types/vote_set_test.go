@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -449,6 +451,205 @@ func TestVoteSet_MakeCommit(t *testing.T) {
 	}
 }
 
+func TestVoteSet_QuorumSignProgress(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, valSet, privValidators := randVoteSet(height, round, tmproto.PrecommitType, 4)
+
+	voteProto := &Vote{
+		ValidatorProTxHash: nil, // NOTE: must fill in
+		ValidatorIndex:     -1,  // NOTE: must fill in
+		Height:             height,
+		Round:              round,
+		Type:               tmproto.PrecommitType,
+		BlockID:            BlockID{tmrand.Bytes(32), PartSetHeader{1, tmrand.Bytes(32)}},
+		StateID:            StateID{LastAppHash: tmrand.Bytes(32)},
+	}
+	addVote := func(i int32) {
+		proTxHash, err := privValidators[i].GetProTxHash()
+		require.NoError(t, err)
+		_, err = signAddVote(privValidators[i], withValidator(voteProto, proTxHash, i), voteSet)
+		require.NoError(t, err)
+	}
+
+	// 2 out of 4 is not enough to recover the threshold signatures
+	addVote(0)
+	addVote(1)
+	assert.Equal(t, QuorumSignProgress{
+		Shares:  2,
+		Missing: []crypto.ProTxHash{valSet.Validators[2].ProTxHash, valSet.Validators[3].ProTxHash},
+	}, voteSet.QuorumSignProgress())
+
+	// the 4th validator stalls
+	addVote(2)
+	qsp := voteSet.QuorumSignProgress()
+	require.NotNil(t, qsp.Recovery)
+	assert.Equal(t, voteProto.BlockID, qsp.Recovery.BlockID)
+	assert.Equal(t, 1, qsp.Recovery.Attempts)
+	assert.True(t, qsp.Recovery.Recovered)
+	qsp.Recovery = nil
+	assert.Equal(t, QuorumSignProgress{
+		Shares:    3,
+		Missing:   []crypto.ProTxHash{valSet.Validators[3].ProTxHash},
+		Recovered: true,
+	}, qsp)
+	assert.Contains(t, voteSet.StringShort(), "QSP{shares:3 missing:1 recovered:true}")
+
+	bz, err := voteSet.MarshalJSON()
+	require.NoError(t, err)
+	var voteSetJSON VoteSetJSON
+	require.NoError(t, tmjson.Unmarshal(bz, &voteSetJSON))
+	assert.Equal(t, voteSet.QuorumSignProgress(), voteSetJSON.QuorumSignProgress)
+}
+
+// the 4th validator signs with the key of a stale quorum, so its vote is valid,
+// but the threshold signatures can't be recovered
+func TestVoteSet_ThresholdRecoveryFailure(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, valSet, privValidators := randVoteSet(height, round, tmproto.PrecommitType, 4)
+
+	staleKey := bls12381.GenPrivKey()
+	staleProTxHash, err := privValidators[3].GetProTxHash()
+	require.NoError(t, err)
+	privValidators[3] = NewMockPVWithParams(staleKey, staleProTxHash, valSet.QuorumHash,
+		valSet.ThresholdPublicKey, false, false)
+	valSet.Validators[3].PubKey = staleKey.PubKey()
+
+	voteProto := &Vote{
+		ValidatorProTxHash: nil, // NOTE: must fill in
+		ValidatorIndex:     -1,  // NOTE: must fill in
+		Height:             height,
+		Round:              round,
+		Type:               tmproto.PrecommitType,
+		BlockID:            BlockID{tmrand.Bytes(32), PartSetHeader{1, tmrand.Bytes(32)}},
+		StateID:            StateID{LastAppHash: tmrand.Bytes(32)},
+	}
+	addVote := func(i int32) {
+		proTxHash, err := privValidators[i].GetProTxHash()
+		require.NoError(t, err)
+		added, err := signAddVote(privValidators[i], withValidator(voteProto, proTxHash, i), voteSet)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	addVote(0)
+	addVote(3)
+	assert.Nil(t, voteSet.ThresholdRecovery())
+
+	// the votes have 2/3 of the voting power, but the recovery fails
+	addVote(1)
+	recovery := voteSet.ThresholdRecovery()
+	require.NotNil(t, recovery)
+	assert.Equal(t, voteProto.BlockID, recovery.BlockID)
+	assert.Equal(t, 1, recovery.Attempts)
+	assert.False(t, recovery.Recovered)
+	assert.Contains(t, recovery.Error, "threshold public key")
+	assert.False(t, recovery.QuorumTime.IsZero())
+	assert.True(t, voteSet.HasTwoThirdsAny())
+	assert.False(t, voteSet.HasTwoThirdsMajority())
+	assert.False(t, voteSet.QuorumSignProgress().Recovered)
+
+	// the recovery is retried with the next vote
+	addVote(2)
+	recovery = voteSet.ThresholdRecovery()
+	require.NotNil(t, recovery)
+	assert.Equal(t, 2, recovery.Attempts)
+	assert.False(t, recovery.Recovered)
+	assert.False(t, voteSet.HasTwoThirdsMajority())
+}
+
+func TestVoteSet_VoteExtension(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, valSet, privValidators := randVoteSet(height, round, tmproto.PrecommitType, 4)
+
+	voteProto := &Vote{
+		ValidatorProTxHash: nil, // NOTE: must fill in
+		ValidatorIndex:     -1,  // NOTE: must fill in
+		Height:             height,
+		Round:              round,
+		Type:               tmproto.PrecommitType,
+		BlockID:            BlockID{tmrand.Bytes(32), PartSetHeader{}},
+		StateID:            StateID{LastAppHash: tmrand.Bytes(32)},
+	}
+	addVote := func(i int32, extension []byte) {
+		proTxHash, err := privValidators[i].GetProTxHash()
+		require.NoError(t, err)
+		vote := withValidator(voteProto, proTxHash, i)
+		vote.Extension = extension
+		_, err = signAddVote(privValidators[i], vote, voteSet)
+		require.NoError(t, err)
+	}
+
+	// the block has 2/3 majority, but the extensions differ
+	addVote(0, []byte("extension"))
+	addVote(1, []byte("extension"))
+	addVote(2, []byte("other extension"))
+	_, ok := voteSet.TwoThirdsMajority()
+	require.True(t, ok)
+	assert.False(t, voteSet.HasThresholdVoteExtension())
+	assert.False(t, voteSet.MakeCommit().IsExtended())
+
+	// the 4th precommit completes the quorum of the extension
+	addVote(3, []byte("extension"))
+	assert.True(t, voteSet.HasThresholdVoteExtension())
+	commit := voteSet.MakeCommit()
+	require.NoError(t, commit.ValidateBasic())
+	assert.True(t, commit.IsExtended())
+	assert.Equal(t, []byte("extension"), commit.VoteExtension)
+	assert.NoError(t, valSet.VerifyCommit(voteSet.ChainID(), commit.BlockID, commit.StateID, height, commit))
+
+	// the extension is covered by the threshold signature
+	commit.VoteExtension = []byte("other extension")
+	assert.Error(t, valSet.VerifyCommit(voteSet.ChainID(), commit.BlockID, commit.StateID, height, commit))
+}
+
+// the 4th validator signs with the key of a stale quorum, and its extension
+// completes the quorum of the extension after the block sigs were recovered
+func TestVoteSet_VoteExtensionRecoveryFailure(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, valSet, privValidators := randVoteSet(height, round, tmproto.PrecommitType, 4)
+
+	staleKey := bls12381.GenPrivKey()
+	staleProTxHash, err := privValidators[3].GetProTxHash()
+	require.NoError(t, err)
+	privValidators[3] = NewMockPVWithParams(staleKey, staleProTxHash, valSet.QuorumHash,
+		valSet.ThresholdPublicKey, false, false)
+	valSet.Validators[3].PubKey = staleKey.PubKey()
+
+	voteProto := &Vote{
+		ValidatorProTxHash: nil, // NOTE: must fill in
+		ValidatorIndex:     -1,  // NOTE: must fill in
+		Height:             height,
+		Round:              round,
+		Type:               tmproto.PrecommitType,
+		BlockID:            BlockID{tmrand.Bytes(32), PartSetHeader{}},
+		StateID:            StateID{LastAppHash: tmrand.Bytes(32)},
+	}
+	addVote := func(i int32, extension []byte) {
+		proTxHash, err := privValidators[i].GetProTxHash()
+		require.NoError(t, err)
+		vote := withValidator(voteProto, proTxHash, i)
+		vote.Extension = extension
+		_, err = signAddVote(privValidators[i], vote, voteSet)
+		require.NoError(t, err)
+	}
+
+	addVote(0, []byte("extension"))
+	addVote(1, []byte("extension"))
+	addVote(2, []byte("other extension"))
+	require.True(t, voteSet.HasTwoThirdsMajority())
+
+	// the recovered signature isn't signed by the quorum
+	addVote(3, []byte("extension"))
+	recovery := voteSet.ThresholdRecovery()
+	require.NotNil(t, recovery)
+	assert.True(t, recovery.Recovered)
+	assert.Contains(t, recovery.VoteExtensionError, "threshold public key")
+	assert.False(t, voteSet.HasThresholdVoteExtension())
+	commit := voteSet.MakeCommit()
+	assert.False(t, commit.IsExtended())
+	assert.NoError(t, valSet.VerifyCommit(voteSet.ChainID(), commit.BlockID, commit.StateID, height, commit))
+}
+
 // NOTE: privValidators are in order
 func randVoteSet(
 	height int64,
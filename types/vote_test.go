@@ -224,6 +224,39 @@ func TestVoteVerify(t *testing.T) {
 	}
 }
 
+func TestVoteVerifyExtension(t *testing.T) {
+	quorumHash := crypto.RandQuorumHash()
+	privVal := NewMockPVForQuorum(quorumHash)
+	proTxHash, err := privVal.GetProTxHash()
+	require.NoError(t, err)
+	pubkey, err := privVal.GetPubKey(quorumHash)
+	require.NoError(t, err)
+	quorumType := btcjson.LLMQType_5_60
+
+	for _, extension := range [][]byte{[]byte("extension"), {}} {
+		vote := examplePrecommit()
+		vote.ValidatorProTxHash = proTxHash
+		vote.Extension = extension
+		v := vote.ToProto()
+		require.NoError(t, privVal.SignVote("test_chain_id", quorumType, quorumHash, v))
+		require.NotEmpty(t, v.ExtensionSignature)
+
+		// the empty extension is transmitted as nil
+		bs, err := proto.Marshal(v)
+		require.NoError(t, err)
+		pv := new(tmproto.Vote)
+		require.NoError(t, proto.Unmarshal(bs, pv))
+		vote, err = VoteFromProto(pv)
+		require.NoError(t, err)
+		assert.True(t, vote.IsExtended())
+		assert.NoError(t, vote.Verify("test_chain_id", quorumType, quorumHash, pubkey, proTxHash))
+
+		vote.Extension = []byte("other extension")
+		assert.Equal(t, ErrVoteInvalidExtensionSignature,
+			vote.Verify("test_chain_id", quorumType, quorumHash, pubkey, proTxHash))
+	}
+}
+
 func TestVoteString(t *testing.T) {
 	str := examplePrecommit().String()
 	expected := `Vote{56789:959A8F5EF2BE 12345/02/SIGNED_MSG_TYPE_PRECOMMIT(Precommit) 8B01023386C3 000000000000 46E3D8DC8536 000000000000}` //nolint:lll //ignore line length for tests
@@ -257,6 +290,20 @@ func TestVoteValidateBasic(t *testing.T) {
 		{"Invalid ValidatorIndex", func(v *Vote) { v.ValidatorIndex = -1 }, true},
 		{"Invalid Signature", func(v *Vote) { v.BlockSignature = nil }, true},
 		{"Too big Signature", func(v *Vote) { v.BlockSignature = make([]byte, SignatureSize+1) }, true},
+		{"Extension", func(v *Vote) {
+			v.Extension = []byte("extension")
+			v.ExtensionSignature = make([]byte, SignatureSize)
+		}, false},
+		{"Extension without signature", func(v *Vote) { v.Extension = []byte("extension") }, true},
+		{"Too big Extension", func(v *Vote) {
+			v.Extension = make([]byte, MaxVoteExtensionSize+1)
+			v.ExtensionSignature = make([]byte, SignatureSize)
+		}, true},
+		{"Extension of a Prevote", func(v *Vote) {
+			v.Type = tmproto.PrevoteType
+			v.Extension = []byte("extension")
+			v.ExtensionSignature = make([]byte, SignatureSize)
+		}, true},
 	}
 	for _, tc := range testCases {
 		tc := tc